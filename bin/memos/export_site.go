@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/server/service/staticsite"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+)
+
+var (
+	exportSiteUsername string
+
+	exportSiteCmd = &cobra.Command{
+		Use:   "export-site <format> <directory>",
+		Short: "Export the public memos as a static site",
+		Long:  fmt.Sprintf("Write the public memos and their resources into the directory as the content tree of a static site generator. The format is one of %s.", strings.Join(staticsite.Formats, ", ")),
+		Args:  cobra.ExactArgs(2),
+		Run: func(_cmd *cobra.Command, args []string) {
+			if err := runExportSite(context.Background(), args[0], args[1]); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to export site: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	exportSiteCmd.Flags().StringVarP(&exportSiteUsername, "user", "u", "", "username of the user to export the memos of, all users if it's empty")
+	rootCmd.AddCommand(exportSiteCmd)
+}
+
+func runExportSite(ctx context.Context, format, directory string) error {
+	dbDriver, err := db.NewDBDriver(profile)
+	if err != nil {
+		return errors.Wrap(err, "failed to create db driver")
+	}
+	defer dbDriver.Close()
+	if err := dbDriver.Migrate(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate database")
+	}
+	storeInstance := store.New(dbDriver, profile)
+	if err := storeInstance.MigrateManually(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate manually")
+	}
+
+	var creatorID *int32
+	if exportSiteUsername != "" {
+		user, err := storeInstance.GetUser(ctx, &store.FindUser{Username: &exportSiteUsername})
+		if err != nil {
+			return errors.Wrap(err, "failed to find user")
+		}
+		if user == nil {
+			return errors.Errorf("user %q not found", exportSiteUsername)
+		}
+		creatorID = &user.ID
+	}
+	result, err := apiv1.ExportStaticSite(ctx, storeInstance, format, creatorID, func(name string, data []byte) error {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(filePath, data, 0644)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d posts and %d assets\n", result.Posts, result.Assets)
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
+	"github.com/usememos/memos/server/service/importer"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+)
+
+// importProgressInterval is the number of the notes between the progress reports of the import.
+const importProgressInterval = 100
+
+var (
+	importUsername string
+
+	importCmd = &cobra.Command{
+		Use:   "import <source> <path>",
+		Short: "Import the memos from an export of another app",
+		Long:  fmt.Sprintf("Import the notes of the export file or directory as the private memos of the user. The source is one of %s.", strings.Join(apiv1.ImportSources, ", ")),
+		Args:  cobra.ExactArgs(2),
+		Run: func(_cmd *cobra.Command, args []string) {
+			if err := runImport(context.Background(), args[0], args[1]); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to import: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	importCmd.Flags().StringVarP(&importUsername, "user", "u", "", "username of the user to import the memos for")
+	if err := importCmd.MarkFlagRequired("user"); err != nil {
+		panic(err)
+	}
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(ctx context.Context, source, filePath string) error {
+	notes, err := parseImportPath(source, filePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse export")
+	}
+
+	dbDriver, err := db.NewDBDriver(profile)
+	if err != nil {
+		return errors.Wrap(err, "failed to create db driver")
+	}
+	defer dbDriver.Close()
+	if err := dbDriver.Migrate(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate database")
+	}
+	storeInstance := store.New(dbDriver, profile)
+	if err := storeInstance.MigrateManually(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate manually")
+	}
+	storeInstance.UseContentInterceptor(hookrunner.NewInterceptor())
+
+	user, err := storeInstance.GetUser(ctx, &store.FindUser{Username: &importUsername})
+	if err != nil {
+		return errors.Wrap(err, "failed to find user")
+	}
+	if user == nil {
+		return errors.Errorf("user %q not found", importUsername)
+	}
+	result, err := apiv1.ImportNotes(ctx, storeInstance, user, notes, func(processed int) {
+		if processed%importProgressInterval == 0 || processed == len(notes) {
+			fmt.Fprintf(os.Stderr, "Processed %d/%d notes\n", processed, len(notes))
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to import memos")
+	}
+	for _, message := range result.Errors {
+		fmt.Fprintln(os.Stderr, message)
+	}
+	fmt.Printf("Imported %d memos, %d resources and %d relations, %d errors\n", result.Memos, result.Resources, result.Relations, len(result.Errors))
+	return nil
+}
+
+// parseImportPath parses the export file or directory of the path.
+func parseImportPath(source, filePath string) ([]*importer.Note, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fileInfo.IsDir() {
+		return apiv1.ParseImportDirectory(source, os.DirFS(filePath))
+	}
+	return apiv1.ParseImportFile(source, filePath, file, fileInfo.Size())
+}
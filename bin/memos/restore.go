@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/server/service/replicator"
+)
+
+var (
+	restoreTimestamp string
+	restoreOutput    string
+
+	restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the SQLite database from the replica",
+		Long:  "Restore the SQLite database from the replica of --replica-url as of the timestamp, the latest state if it isn't set. The database is written to the DSN of the profile unless --output is set, which must not exist.",
+		Args:  cobra.NoArgs,
+		Run: func(_cmd *cobra.Command, _args []string) {
+			if err := runRestore(context.Background()); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to restore: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	restoreCmd.Flags().StringVarP(&restoreTimestamp, "to-timestamp", "", "", "RFC 3339 timestamp the database is restored to, e.g. 2024-01-02T15:04:05Z")
+	restoreCmd.Flags().StringVarP(&restoreOutput, "output", "o", "", "path of the restored database, the DSN of the profile if it isn't set")
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runRestore(ctx context.Context) error {
+	if profile.ReplicaURL == "" {
+		return errors.New("--replica-url is required")
+	}
+	var timestamp time.Time
+	if restoreTimestamp != "" {
+		var err error
+		if timestamp, err = time.Parse(time.RFC3339, restoreTimestamp); err != nil {
+			return errors.Wrap(err, "invalid timestamp")
+		}
+	}
+	output := restoreOutput
+	if output == "" {
+		output = profile.DSN
+	}
+	// The database isn't overwritten, it may be the only copy of the writes after the timestamp.
+	for _, path := range []string{output, output + "-wal"} {
+		if _, err := os.Stat(path); err == nil {
+			return errors.Errorf("%s already exists, move it away or set --output", path)
+		}
+	}
+
+	replica, err := replicator.NewReplica(ctx, profile.ReplicaURL)
+	if err != nil {
+		return err
+	}
+	result, err := replicator.Restore(ctx, replica, output, timestamp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored %s from generation %s at index %d, offset %d, written at %s\n",
+		output, result.Generation, result.Index, result.Offset, result.Time.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/server/service/seed"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+)
+
+var (
+	seedOptions = seed.Options{}
+	seedForce   bool
+
+	seedCmd = &cobra.Command{
+		Use:   "seed",
+		Short: "Generate demo users and memos",
+		Long:  "Generate the users and their memos, tags, resources, relations and reactions in the volumes of the flags, e.g. to measure the performance against a large workspace. The existing users of the usernames are reused, so the memos are added to them.",
+		Args:  cobra.NoArgs,
+		Run: func(_cmd *cobra.Command, _args []string) {
+			if err := runSeed(context.Background()); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to seed: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	seedCmd.Flags().IntVarP(&seedOptions.Users, "users", "", 10, "number of the users")
+	seedCmd.Flags().IntVarP(&seedOptions.Memos, "memos", "", 1000, "number of the memos of each user")
+	seedCmd.Flags().Float64VarP(&seedOptions.ResourceRatio, "resource-ratio", "", 0.1, "ratio of the memos with a resource, from 0 to 1")
+	seedCmd.Flags().IntVarP(&seedOptions.Tags, "tags", "", 20, "number of the tags of each user")
+	seedCmd.Flags().IntVarP(&seedOptions.Days, "days", "", 365, "number of the days before now over which the memos are created")
+	seedCmd.Flags().Int64VarP(&seedOptions.Seed, "seed", "", 1, "seed of the random data, the same seed generates the same data")
+	seedCmd.Flags().StringVarP(&seedOptions.UsernamePrefix, "username-prefix", "", "seed", "prefix of the usernames of the users, which are numbered from 1")
+	seedCmd.Flags().StringVarP(&seedOptions.Password, "password", "", "secret", "password of the users")
+	seedCmd.Flags().BoolVarP(&seedForce, "force", "", false, "seed the database of the prod mode")
+	rootCmd.AddCommand(seedCmd)
+}
+
+func runSeed(ctx context.Context) error {
+	if profile.Mode == "prod" && !seedForce {
+		return errors.New("the database of the prod mode isn't seeded without --force")
+	}
+
+	dbDriver, err := db.NewDBDriver(profile)
+	if err != nil {
+		return errors.Wrap(err, "failed to create db driver")
+	}
+	defer dbDriver.Close()
+	if err := dbDriver.Migrate(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate database")
+	}
+	storeInstance := store.New(dbDriver, profile)
+	if err := storeInstance.MigrateManually(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate manually")
+	}
+
+	start := time.Now()
+	total := seedOptions.Users * seedOptions.Memos
+	result, err := seed.Seed(ctx, storeInstance, &seedOptions, func(memos int) {
+		fmt.Fprintf(os.Stderr, "Generated %d/%d memos\n", memos, total)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Generated %d users, %d memos, %d resources, %d tags, %d relations and %d reactions in %s\n",
+		result.Users, result.Memos, result.Resources, result.Tags, result.Relations, result.Reactions, time.Since(start).Round(time.Millisecond))
+	return nil
+}
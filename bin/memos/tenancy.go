@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/usememos/memos/internal/handoff"
+	"github.com/usememos/memos/server/tenancy"
+)
+
+// runTenancy serves the workspaces of the tenants instead of a single workspace, until the instance is shut down.
+func runTenancy(ctx context.Context, cancel context.CancelFunc) {
+	manager := tenancy.NewManager(profile)
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	if handoff.UpgradeSignal != nil {
+		signal.Notify(c, handoff.UpgradeSignal)
+	}
+	go func() {
+		for sig := range c {
+			if sig == handoff.UpgradeSignal {
+				slog.Info("handing off listeners to new process")
+				if err := handoff.Upgrade(ctx); err != nil {
+					slog.Error("failed to hand off listeners", slog.Any("err", err))
+					continue
+				}
+			}
+			manager.Shutdown(ctx)
+			cancel()
+			return
+		}
+	}()
+
+	printGreetings()
+
+	if err := manager.Start(ctx); err != nil {
+		if err != http.ErrServerClosed {
+			slog.Error("failed to start tenancy", slog.Any("err", err))
+			manager.Shutdown(ctx)
+			cancel()
+		}
+	}
+
+	// Wait for CTRL-C.
+	<-ctx.Done()
+}
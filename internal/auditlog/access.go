@@ -0,0 +1,107 @@
+package auditlog
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AccessFormatJSON is the format of the JSON lines, like the ones of the audit log.
+	AccessFormatJSON = "json"
+	// AccessFormatCommon is the common log format, e.g. `127.0.0.1 - 1 [10/Oct/2024:13:55:36 +0000] "GET /api/v1/memo HTTP/1.1" 200 2326`.
+	AccessFormatCommon = "common"
+	// AccessFormatCombined is the common log format followed by the referer and the user agent.
+	AccessFormatCombined = "combined"
+)
+
+// AccessRecord is the record of a request.
+type AccessRecord struct {
+	Time     time.Time
+	Method   string
+	Path     string
+	Query    string
+	Protocol string
+	Status   int
+	Bytes    int64
+	Latency  time.Duration
+	// IP is the IP of the client, the one forwarded by the trusted proxies.
+	IP        string
+	UserAgent string
+	Referer   string
+	// UserID is the ID of the signed-in user, 0 if the request is anonymous.
+	UserID int32
+}
+
+func (r *AccessRecord) attrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.Path),
+		slog.Int("status", r.Status),
+		slog.Int64("bytes", r.Bytes),
+		slog.Int64("latency_ms", r.Latency.Milliseconds()),
+		slog.String("ip", r.IP),
+		slog.String("user_agent", r.UserAgent),
+	}
+	if r.Query != "" {
+		attrs = append(attrs, slog.String("query", r.Query))
+	}
+	if r.Referer != "" {
+		attrs = append(attrs, slog.String("referer", r.Referer))
+	}
+	if r.UserID != 0 {
+		attrs = append(attrs, slog.Int("user_id", int(r.UserID)))
+	}
+	return attrs
+}
+
+// format returns the line of the record in the common or the combined log format.
+// The remote user of the formats is the ID of the user, and a missing value is `-`.
+func (r *AccessRecord) format(format string) string {
+	user := "-"
+	if r.UserID != 0 {
+		user = strconv.Itoa(int(r.UserID))
+	}
+	target := r.Path
+	if r.Query != "" {
+		target += "?" + r.Query
+	}
+	bytes := "-"
+	if r.Bytes > 0 {
+		bytes = strconv.FormatInt(r.Bytes, 10)
+	}
+	line := fmt.Sprintf("%s - %s [%s] %s %d %s",
+		orDash(r.IP), user, r.Time.Format("02/Jan/2006:15:04:05 -0700"), quote(r.Method+" "+target+" "+r.Protocol), r.Status, bytes)
+	if format == AccessFormatCombined {
+		line += " " + quote(orDash(r.Referer)) + " " + quote(orDash(r.UserAgent))
+	}
+	return line + "\n"
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// quote quotes the field of a line, escaping the quotes and the control characters so a client can't forge lines.
+func quote(value string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, c := range value {
+		switch {
+		case c == '"' || c == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&builder, "\\x%02x", c)
+		default:
+			builder.WriteRune(c)
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
@@ -0,0 +1,126 @@
+// Package auditlog writes the audit log of the security events and the access log of the requests to their own sinks,
+// apart from the application logs, so they can be forwarded to a SIEM. The records are JSON lines, the access log can
+// also be written in the common or the combined log format of the web servers.
+package auditlog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OutcomeSuccess is the outcome of the events which succeeded.
+	OutcomeSuccess = "success"
+	// OutcomeFailure is the outcome of the events which failed, e.g. the sign-ins with a wrong password.
+	OutcomeFailure = "failure"
+)
+
+// sinkLog is a log written to its sink, it's nil while disabled.
+type sinkLog struct {
+	sink   io.WriteCloser
+	logger *slog.Logger
+	// format is the format of the access log records.
+	format string
+}
+
+var (
+	auditLog  atomic.Pointer[sinkLog]
+	accessLog atomic.Pointer[sinkLog]
+)
+
+// Setup opens the sinks of the audit and the access logs, see OpenSinks. An empty spec disables the log.
+// The access log records are written in the access format, JSON if it's empty.
+// The returned function closes the sinks, it must be called on shutdown.
+func Setup(auditSpec, accessSpec, accessFormat string) (func() error, error) {
+	if accessFormat == "" {
+		accessFormat = AccessFormatJSON
+	}
+	if accessFormat != AccessFormatJSON && accessFormat != AccessFormatCommon && accessFormat != AccessFormatCombined {
+		return nil, errors.Errorf("unknown access log format: %s", accessFormat)
+	}
+	audit, err := openLog(auditSpec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log")
+	}
+	access, err := openLog(accessSpec)
+	if err != nil {
+		if audit != nil {
+			audit.sink.Close()
+		}
+		return nil, errors.Wrap(err, "failed to open access log")
+	}
+	if access != nil {
+		access.format = accessFormat
+	}
+	auditLog.Store(audit)
+	accessLog.Store(access)
+	return func() error {
+		auditLog.Store(nil)
+		accessLog.Store(nil)
+		var err error
+		for _, l := range []*sinkLog{audit, access} {
+			if l == nil {
+				continue
+			}
+			if closeErr := l.sink.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+func openLog(spec string) (*sinkLog, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	sink, err := OpenSinks(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &sinkLog{
+		sink:   sink,
+		logger: slog.New(slog.NewJSONHandler(sink, nil)),
+	}, nil
+}
+
+// Enabled returns true if the audit log is written, so the attributes which need queries are only looked up then.
+func Enabled() bool {
+	return auditLog.Load() != nil
+}
+
+// Record writes the event of the action to the audit log, e.g. auth.sign_in, with its outcome and attributes like the user and the IP.
+func Record(ctx context.Context, action, outcome string, attrs ...slog.Attr) {
+	l := auditLog.Load()
+	if l == nil {
+		return
+	}
+	level := slog.LevelInfo
+	if outcome != OutcomeSuccess {
+		level = slog.LevelWarn
+	}
+	attrs = append([]slog.Attr{slog.String("action", action), slog.String("outcome", outcome)}, attrs...)
+	l.logger.LogAttrs(ctx, level, "audit", attrs...)
+}
+
+// AccessEnabled returns true if the access log is written.
+func AccessEnabled() bool {
+	return accessLog.Load() != nil
+}
+
+// RecordAccess writes the record of a request to the access log.
+func RecordAccess(ctx context.Context, record *AccessRecord) {
+	l := accessLog.Load()
+	if l == nil {
+		return
+	}
+	if l.format == AccessFormatJSON {
+		l.logger.LogAttrs(ctx, slog.LevelInfo, "access", record.attrs()...)
+		return
+	}
+	_, _ = io.WriteString(l.sink, record.format(l.format))
+}
@@ -0,0 +1,101 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	udpListener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer udpListener.Close()
+
+	auditFile := filepath.Join(dir, "audit.log")
+	closeLogs, err := Setup(auditFile+",syslog://"+udpListener.LocalAddr().String()+"?facility=auth", "file://"+filepath.Join(dir, "access.log")+"?max_size=1", "")
+	require.NoError(t, err)
+	require.True(t, Enabled())
+	Record(context.Background(), "auth.sign_in", OutcomeFailure, slog.String("target", "steven"))
+	RecordAccess(context.Background(), &AccessRecord{Method: "GET", Path: "/api/v1/memo", Status: 200, UserID: 1})
+
+	// The syslog message has the priority of the auth facility and the info severity.
+	buf := make([]byte, 1024)
+	require.NoError(t, udpListener.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := udpListener.ReadFrom(buf)
+	require.NoError(t, err)
+	message := string(buf[:n])
+	require.True(t, strings.HasPrefix(message, "<38>1 "), message)
+	require.Contains(t, message, ` memos `)
+	require.Contains(t, message, `"action":"auth.sign_in"`)
+
+	require.NoError(t, closeLogs())
+	require.False(t, Enabled())
+	// The records aren't written after the logs are closed.
+	Record(context.Background(), "auth.sign_out", OutcomeSuccess)
+
+	data, err := os.ReadFile(auditFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+	record := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Equal(t, "WARN", record["level"])
+	require.Equal(t, "audit", record["msg"])
+	require.Equal(t, "auth.sign_in", record["action"])
+	require.Equal(t, OutcomeFailure, record["outcome"])
+	require.Equal(t, "steven", record["target"])
+
+	data, err = os.ReadFile(filepath.Join(dir, "access.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"path":"/api/v1/memo"`)
+	require.Contains(t, string(data), `"user_id":1`)
+}
+
+func TestRecordAccessFormats(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Setup("", filepath.Join(dir, "access.log"), "yaml")
+	require.Error(t, err)
+
+	record := &AccessRecord{
+		Time:      time.Date(2024, 10, 10, 13, 55, 36, 0, time.UTC),
+		Method:    "GET",
+		Path:      "/api/v1/memo",
+		Query:     "limit=10",
+		Protocol:  "HTTP/1.1",
+		Status:    200,
+		Bytes:     2326,
+		IP:        "203.0.113.7",
+		UserAgent: "curl/8.0 \"\n127.0.0.1",
+		UserID:    1,
+	}
+	for format, expected := range map[string]string{
+		AccessFormatCommon:   `203.0.113.7 - 1 [10/Oct/2024:13:55:36 +0000] "GET /api/v1/memo?limit=10 HTTP/1.1" 200 2326` + "\n",
+		AccessFormatCombined: `203.0.113.7 - 1 [10/Oct/2024:13:55:36 +0000] "GET /api/v1/memo?limit=10 HTTP/1.1" 200 2326 "-" "curl/8.0 \"\x0a127.0.0.1"` + "\n",
+	} {
+		accessFile := filepath.Join(dir, format+".log")
+		closeLogs, err := Setup("", accessFile, format)
+		require.NoError(t, err)
+		RecordAccess(context.Background(), record)
+		require.NoError(t, closeLogs())
+
+		data, err := os.ReadFile(accessFile)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+}
+
+func TestOpenSinks(t *testing.T) {
+	for _, spec := range []string{"", " , ", "ftp://example.com", "syslog://host:514?facility=unknown", "syslog://", "file:///tmp/audit.log?max_size=-1"} {
+		_, err := OpenSinks(spec)
+		require.Error(t, err, spec)
+	}
+}
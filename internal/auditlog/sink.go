@@ -0,0 +1,131 @@
+package auditlog
+
+import (
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// stdoutSink writes to the standard output, which isn't closed with the sink.
+type stdoutSink struct{}
+
+func (stdoutSink) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
+func (stdoutSink) Close() error {
+	return nil
+}
+
+// multiSink writes the records to all of its sinks. A failing sink doesn't keep the records from the others.
+type multiSink struct {
+	mu    sync.Mutex
+	sinks []io.WriteCloser
+	specs []string
+}
+
+func (m *multiSink) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, sink := range m.sinks {
+		if _, err := sink.Write(p); err != nil {
+			slog.Warn("Failed to write log record", slog.String("sink", m.specs[i]), slog.Any("err", err))
+		}
+	}
+	return len(p), nil
+}
+
+func (m *multiSink) Close() error {
+	var err error
+	for _, sink := range m.sinks {
+		if closeErr := sink.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// OpenSinks opens the comma separated sinks of the spec, each one is either:
+//   - stdout, for the JSON lines on the standard output,
+//   - a file path or a file:// URL, for a file rotated by its size, e.g. file:///var/log/memos/audit.log?max_size=100&max_backups=10&max_age=30&compress=true,
+//     with the max size in megabytes and the max age in days,
+//   - syslog://host:514 over UDP, syslog+tcp://host:601 over TCP or syslog+unix:///dev/log, for the RFC 5424 messages to a syslog server,
+//     with the optional facility, e.g. syslog://host:514?facility=auth.
+func OpenSinks(spec string) (io.WriteCloser, error) {
+	sinks := &multiSink{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		sink, err := openSink(item)
+		if err != nil {
+			sinks.Close()
+			return nil, errors.Wrapf(err, "invalid log sink %q", item)
+		}
+		sinks.sinks = append(sinks.sinks, sink)
+		sinks.specs = append(sinks.specs, item)
+	}
+	if len(sinks.sinks) == 0 {
+		return nil, errors.New("no log sinks")
+	}
+	return sinks, nil
+}
+
+func openSink(spec string) (io.WriteCloser, error) {
+	if spec == "stdout" {
+		return stdoutSink{}, nil
+	}
+	if !strings.Contains(spec, "://") {
+		return &lumberjack.Logger{Filename: spec}, nil
+	}
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "file":
+		return openFileSink(u)
+	case "syslog", "syslog+udp":
+		return newSyslogSink("udp", u.Host, u.Query().Get("facility"))
+	case "syslog+tcp":
+		return newSyslogSink("tcp", u.Host, u.Query().Get("facility"))
+	case "syslog+unix":
+		return newSyslogSink("unixgram", u.Path, u.Query().Get("facility"))
+	default:
+		return nil, errors.Errorf("unsupported scheme %q", u.Scheme)
+	}
+}
+
+func openFileSink(u *url.URL) (io.WriteCloser, error) {
+	if u.Path == "" {
+		return nil, errors.New("file path required")
+	}
+	query := u.Query()
+	logger := &lumberjack.Logger{
+		Filename: u.Path,
+		Compress: query.Get("compress") == "true",
+	}
+	for name, value := range map[string]*int{
+		"max_size":    &logger.MaxSize,
+		"max_backups": &logger.MaxBackups,
+		"max_age":     &logger.MaxAge,
+	} {
+		if query.Get(name) == "" {
+			continue
+		}
+		number, err := strconv.Atoi(query.Get(name))
+		if err != nil || number < 0 {
+			return nil, errors.Errorf("invalid %s %q", name, query.Get(name))
+		}
+		*value = number
+	}
+	return logger, nil
+}
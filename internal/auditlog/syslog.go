@@ -0,0 +1,106 @@
+package auditlog
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// syslogAppName is the APP-NAME of the syslog messages.
+	syslogAppName = "memos"
+	// syslogSeverityInfo is the severity of the syslog messages, the level of the record is in the message.
+	syslogSeverityInfo = 6
+	// syslogDialTimeout is the timeout of connecting to the syslog server.
+	syslogDialTimeout = 5 * time.Second
+)
+
+// syslogFacilities are the codes of the facilities of the syslog messages by their names.
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogSink sends the records as RFC 5424 messages, one message per record. The connection is reopened after a failure.
+type syslogSink struct {
+	network  string
+	address  string
+	priority int
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogSink(network, address, facility string) (*syslogSink, error) {
+	if address == "" {
+		return nil, errors.New("syslog address required")
+	}
+	if facility == "" {
+		facility = "local0"
+	}
+	code, ok := syslogFacilities[facility]
+	if !ok {
+		return nil, errors.Errorf("unknown syslog facility %q", facility)
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{
+		network:  network,
+		address:  address,
+		priority: code*8 + syslogSeverityInfo,
+		hostname: hostname,
+	}, nil
+}
+
+func (s *syslogSink) Write(p []byte) (int, error) {
+	message := s.format(time.Now(), strings.TrimRight(string(p), "\n"))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			conn, err := net.DialTimeout(s.network, s.address, syslogDialTimeout)
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to connect to syslog server")
+			}
+			s.conn = conn
+		}
+		if _, err := s.conn.Write([]byte(message)); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			// The server may have closed the idle stream, the message is sent once more with a new connection.
+			if attempt == 0 {
+				continue
+			}
+			return 0, errors.Wrap(err, "failed to write to syslog server")
+		}
+		return len(p), nil
+	}
+}
+
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format returns the RFC 5424 message of the record. The streams are framed by the newlines, RFC 6587 non-transparent framing.
+func (s *syslogSink) format(t time.Time, record string) string {
+	message := fmt.Sprintf("<%d>1 %s %s %s %d - - %s", s.priority, t.UTC().Format(time.RFC3339Nano), s.hostname, syslogAppName, os.Getpid(), record)
+	if s.network == "tcp" {
+		message += "\n"
+	}
+	return message
+}
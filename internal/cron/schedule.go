@@ -62,6 +62,37 @@ func (s *Schedule) IsDue(m *Moment) bool {
 	return true
 }
 
+// maxNextSearch is how far ahead Schedule.Next looks for a due moment,
+// so the schedules which are never due (eg. "0 0 30 2 *") don't loop forever.
+const maxNextSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first minute after t which satisfies the current Schedule,
+// in the location of t. It returns the zero time if there is none within 5 years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	end := t.Add(maxNextSearch)
+	loc := t.Location()
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	for next.Before(end) {
+		moment := NewMoment(next)
+		_, dayOk := s.Days[moment.Day]
+		_, monthOk := s.Months[moment.Month]
+		_, dayOfWeekOk := s.DaysOfWeek[moment.DayOfWeek]
+		if !dayOk || !monthOk || !dayOfWeekOk {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if _, ok := s.Hours[moment.Hour]; !ok {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.IsDue(moment) {
+			return next
+		}
+		next = next.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
 // NewSchedule creates a new Schedule from a cron expression.
 //
 // A cron expression is consisted of 5 segments separated by space,
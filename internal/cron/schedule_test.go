@@ -359,3 +359,66 @@ func TestScheduleIsDue(t *testing.T) {
 		}
 	}
 }
+
+func TestScheduleNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	scenarios := []struct {
+		cronExpr string
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			"* * * * *",
+			time.Date(2024, 5, 9, 15, 20, 30, 0, time.UTC),
+			time.Date(2024, 5, 9, 15, 21, 0, 0, time.UTC),
+		},
+		{
+			"0 3 * * *",
+			time.Date(2024, 5, 9, 3, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 10, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			"*/10 * * * *",
+			time.Date(2024, 12, 31, 23, 55, 0, 0, time.UTC),
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"30 9 * * 1",
+			time.Date(2024, 5, 9, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 13, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			"0 0 29 2 *",
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// the clocks skip from 2:00 to 3:00.
+			"30 2 * * *",
+			time.Date(2024, 3, 30, 12, 0, 0, 0, berlin),
+			time.Date(2024, 4, 1, 2, 30, 0, 0, berlin),
+		},
+		{
+			"0 0 30 2 *",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Time{},
+		},
+	}
+
+	for i, s := range scenarios {
+		schedule, err := cron.NewSchedule(s.cronExpr)
+		if err != nil {
+			t.Fatalf("[%d-%s] Unexpected cron error: %v", i, s.cronExpr, err)
+		}
+
+		result := schedule.Next(s.after)
+
+		if !result.Equal(s.expected) {
+			t.Fatalf("[%d-%s] Expected %v, got %v", i, s.cronExpr, s.expected, result)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package debug serves the runtime profiles of the server with net/http/pprof, and writes the dumps of the goroutines
+// and the heap to the data directory, to debug the memory growth and the stuck requests of a running server.
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Dump is the files of a dump, relative to the dump directory.
+type Dump struct {
+	GoroutineFile string `json:"goroutineFile"`
+	HeapFile      string `json:"heapFile"`
+}
+
+// NewHandler returns the handler of the profiles under /debug/pprof/ and of the dumps written to the directory on POST /debug/dump.
+// It doesn't authenticate the requests.
+func NewHandler(dumpDir string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/dump", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		dump, err := WriteDump(dumpDir)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to write dump: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(dump)
+	})
+	return mux
+}
+
+// WriteDump writes the stacks of all the goroutines as text, and the heap profile after a garbage collection, to the directory.
+func WriteDump(dir string) (*Dump, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create dump directory")
+	}
+	timestamp := time.Now().UTC().Format("20060102T150405.000")
+	dump := &Dump{
+		GoroutineFile: fmt.Sprintf("goroutine-%s.txt", timestamp),
+		HeapFile:      fmt.Sprintf("heap-%s.pb.gz", timestamp),
+	}
+	// The verbose format has the states and the waiting durations of the goroutines.
+	if err := writeProfile(filepath.Join(dir, dump.GoroutineFile), "goroutine", 2); err != nil {
+		return nil, err
+	}
+	// The heap profile is of the last garbage collection, it's collected to be up to date.
+	runtime.GC()
+	if err := writeProfile(filepath.Join(dir, dump.HeapFile), "heap", 0); err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+// CheckLocalAddr returns an error if the host of the listening address isn't a loopback one,
+// the profiles aren't authenticated on such an address.
+func CheckLocalAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid address %q", addr)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return errors.Errorf("address %q isn't a loopback address", addr)
+	}
+	return nil
+}
+
+func writeProfile(path, name string, debug int) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s dump", name)
+	}
+	if err := runtimepprof.Lookup(name).WriteTo(file, debug); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to write %s dump", name)
+	}
+	return file.Close()
+}
@@ -0,0 +1,32 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDump(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "debug")
+	dump, err := WriteDump(dir)
+	require.NoError(t, err)
+
+	goroutines, err := os.ReadFile(filepath.Join(dir, dump.GoroutineFile))
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(goroutines), "TestWriteDump"))
+	info, err := os.Stat(filepath.Join(dir, dump.HeapFile))
+	require.NoError(t, err)
+	require.NotZero(t, info.Size())
+}
+
+func TestCheckLocalAddr(t *testing.T) {
+	for _, addr := range []string{"localhost:6060", "127.0.0.1:6060", "[::1]:6060"} {
+		require.NoError(t, CheckLocalAddr(addr), addr)
+	}
+	for _, addr := range []string{":6060", "0.0.0.0:6060", "192.168.1.2:6060", "example.com:6060", "localhost"} {
+		require.Error(t, CheckLocalAddr(addr), addr)
+	}
+}
@@ -0,0 +1,206 @@
+// Package event implements an in-process broker for memo related events,
+// which are pushed to connected clients so they stay in sync without polling,
+// and to the external event bus if it's configured.
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+type Type string
+
+const (
+	MemoCreated        Type = "memo.created"
+	MemoUpdated        Type = "memo.updated"
+	MemoDeleted        Type = "memo.deleted"
+	MemoCommentCreated Type = "memo.comment.created"
+	ReactionCreated    Type = "reaction.created"
+	ReactionDeleted    Type = "reaction.deleted"
+	InboxCreated       Type = "inbox.created"
+	ResourceCreated    Type = "resource.created"
+	ResourceDeleted    Type = "resource.deleted"
+	UserCreated        Type = "user.created"
+	UserUpdated        Type = "user.updated"
+	UserDeleted        Type = "user.deleted"
+)
+
+// subscriberBufferSize is the number of events buffered for a subscriber.
+// Events are dropped for subscribers which don't keep up.
+const subscriberBufferSize = 64
+
+type Event struct {
+	Type Type `json:"type"`
+	// MemoID is the id of the memo the event is about.
+	MemoID int32 `json:"memoId,omitempty"`
+	// RelatedMemoID is the id of the related memo, e.g. the commented memo of a comment.
+	RelatedMemoID int32 `json:"relatedMemoId,omitempty"`
+	ReactionID    int32 `json:"reactionId,omitempty"`
+	InboxID       int32 `json:"inboxId,omitempty"`
+	ResourceID    int32 `json:"resourceId,omitempty"`
+	UserID        int32 `json:"userId,omitempty"`
+	CreatedTs     int64 `json:"createdTs"`
+
+	// OwnerID is the id of the user who owns the memo or receives the inbox message.
+	OwnerID int32 `json:"-"`
+	// Visibility is the visibility of the event to users other than the owner.
+	Visibility store.Visibility `json:"-"`
+	// GroupID is the id of the group which the event is visible to for the GROUP visibility.
+	GroupID *int32 `json:"-"`
+}
+
+// NewMemoEvent returns the event about the memo, which is visible to the users who can see the memo.
+func NewMemoEvent(eventType Type, memo *store.Memo) *Event {
+	return &Event{
+		Type:       eventType,
+		MemoID:     memo.ID,
+		CreatedTs:  time.Now().Unix(),
+		OwnerID:    memo.CreatorID,
+		Visibility: memo.Visibility,
+		GroupID:    memo.GroupID,
+	}
+}
+
+// NewMemoCommentEvent returns the event about the comment on the related memo.
+// The owner of the related memo is notified of it even if the comment isn't visible to others.
+func NewMemoCommentEvent(comment *store.Memo, relatedMemo *store.Memo) *Event {
+	return &Event{
+		Type:          MemoCommentCreated,
+		MemoID:        comment.ID,
+		RelatedMemoID: relatedMemo.ID,
+		CreatedTs:     time.Now().Unix(),
+		OwnerID:       relatedMemo.CreatorID,
+		Visibility:    comment.Visibility,
+		GroupID:       comment.GroupID,
+	}
+}
+
+// NewReactionEvent returns the event about the reaction on the memo, which is visible to the users who can see the memo.
+func NewReactionEvent(eventType Type, reaction *storepb.Reaction, memo *store.Memo) *Event {
+	return &Event{
+		Type:       eventType,
+		MemoID:     memo.ID,
+		ReactionID: reaction.Id,
+		CreatedTs:  time.Now().Unix(),
+		OwnerID:    memo.CreatorID,
+		Visibility: memo.Visibility,
+		GroupID:    memo.GroupID,
+	}
+}
+
+// NewInboxEvent returns the event about the inbox message, which is only visible to the receiver.
+func NewInboxEvent(inbox *store.Inbox) *Event {
+	return &Event{
+		Type:       InboxCreated,
+		InboxID:    inbox.ID,
+		CreatedTs:  time.Now().Unix(),
+		OwnerID:    inbox.ReceiverID,
+		Visibility: store.Private,
+	}
+}
+
+// NewResourceEvent returns the event about the resource, which is only visible to its creator.
+func NewResourceEvent(eventType Type, resource *store.Resource) *Event {
+	e := &Event{
+		Type:       eventType,
+		ResourceID: resource.ID,
+		CreatedTs:  time.Now().Unix(),
+		OwnerID:    resource.CreatorID,
+		Visibility: store.Private,
+	}
+	if resource.MemoID != nil {
+		e.MemoID = *resource.MemoID
+	}
+	return e
+}
+
+// NewUserEvent returns the event about the user, which is only visible to the user.
+func NewUserEvent(eventType Type, userID int32) *Event {
+	return &Event{
+		Type:       eventType,
+		UserID:     userID,
+		CreatedTs:  time.Now().Unix(),
+		OwnerID:    userID,
+		Visibility: store.Private,
+	}
+}
+
+// IsVisibleTo returns true if the user is allowed to receive the event.
+// Anonymous users are identified by a zero user id, the events of the GROUP visibility are visible to the group members.
+func (e *Event) IsVisibleTo(ctx context.Context, s *store.Store, userID int32) (bool, error) {
+	if userID != 0 && e.OwnerID == userID {
+		return true, nil
+	}
+	switch e.Visibility {
+	case store.Public:
+		return true, nil
+	case store.Protected:
+		return userID != 0, nil
+	case store.Group:
+		if userID == 0 || e.GroupID == nil {
+			return false, nil
+		}
+		member, err := s.GetUserGroupMember(ctx, *e.GroupID, userID)
+		if err != nil {
+			return false, err
+		}
+		return member != nil, nil
+	default:
+		return false, nil
+	}
+}
+
+type Subscription struct {
+	C chan *Event
+}
+
+// Broker fans out published events to all subscriptions.
+type Broker struct {
+	mutex         sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscriptions: map[*Subscription]struct{}{},
+	}
+}
+
+func (b *Broker) Subscribe() *Subscription {
+	subscription := &Subscription{
+		C: make(chan *Event, subscriberBufferSize),
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+func (b *Broker) Unsubscribe(subscription *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.subscriptions[subscription]; ok {
+		delete(b.subscriptions, subscription)
+		close(subscription.C)
+	}
+}
+
+// Publish sends the event to all subscriptions without blocking.
+// It's a no-op on a nil broker, so callers don't need to check if the broker is configured.
+func (b *Broker) Publish(event *Event) {
+	if b == nil {
+		return
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	for subscription := range b.subscriptions {
+		select {
+		case subscription.C <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestBroker(t *testing.T) {
+	broker := NewBroker()
+	subscription := broker.Subscribe()
+	broker.Publish(NewMemoEvent(MemoCreated, &store.Memo{ID: 1, CreatorID: 1, Visibility: store.Public}))
+	e := <-subscription.C
+	require.Equal(t, MemoCreated, e.Type)
+	require.Equal(t, int32(1), e.MemoID)
+
+	broker.Unsubscribe(subscription)
+	_, ok := <-subscription.C
+	require.False(t, ok)
+	// Publishing without subscriptions and on a nil broker doesn't block.
+	broker.Publish(NewMemoEvent(MemoUpdated, &store.Memo{ID: 1}))
+	var nilBroker *Broker
+	nilBroker.Publish(NewMemoEvent(MemoUpdated, &store.Memo{ID: 1}))
+}
+
+func TestEventIsVisibleTo(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	owner, err := ts.CreateUser(ctx, &store.User{Username: "owner", Role: store.RoleHost})
+	require.NoError(t, err)
+	member, err := ts.CreateUser(ctx, &store.User{Username: "member", Role: store.RoleUser})
+	require.NoError(t, err)
+	outsider, err := ts.CreateUser(ctx, &store.User{Username: "outsider", Role: store.RoleUser})
+	require.NoError(t, err)
+	group, err := ts.CreateUserGroup(ctx, &store.UserGroup{CreatorID: owner.ID, Name: "design"})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserGroupMember(ctx, &store.UserGroupMember{GroupID: group.ID, UserID: member.ID, Role: store.UserGroupRoleMember})
+	require.NoError(t, err)
+	groupMemo := &store.Memo{CreatorID: owner.ID, Visibility: store.Group, GroupID: &group.ID}
+
+	tests := []struct {
+		event  *Event
+		userID int32
+		want   bool
+	}{
+		{
+			event:  NewMemoEvent(MemoCreated, &store.Memo{CreatorID: 1, Visibility: store.Private}),
+			userID: 1,
+			want:   true,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, &store.Memo{CreatorID: 1, Visibility: store.Private}),
+			userID: 2,
+			want:   false,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, &store.Memo{CreatorID: 1, Visibility: store.Protected}),
+			userID: 2,
+			want:   true,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, &store.Memo{CreatorID: 1, Visibility: store.Protected}),
+			userID: 0,
+			want:   false,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, groupMemo),
+			userID: owner.ID,
+			want:   true,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, groupMemo),
+			userID: member.ID,
+			want:   true,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, groupMemo),
+			userID: outsider.ID,
+			want:   false,
+		},
+		{
+			event:  NewMemoEvent(MemoCreated, groupMemo),
+			userID: 0,
+			want:   false,
+		},
+		{
+			event:  NewInboxEvent(&store.Inbox{ID: 1, ReceiverID: 2}),
+			userID: 2,
+			want:   true,
+		},
+		{
+			event:  NewInboxEvent(&store.Inbox{ID: 1, ReceiverID: 2}),
+			userID: 1,
+			want:   false,
+		},
+	}
+	for _, test := range tests {
+		visible, err := test.event.IsVisibleTo(ctx, ts, test.userID)
+		require.NoError(t, err)
+		require.Equal(t, test.want, visible)
+	}
+}
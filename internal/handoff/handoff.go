@@ -0,0 +1,222 @@
+// Package handoff passes the listeners of the server to a new process of it, so the server is upgraded or restarted
+// without refusing connections: the new process serves the inherited listeners, and the old one drains its
+// in-flight requests and exits once the new one is ready.
+package handoff
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// listenersEnv is the environment variable of the names of the listeners inherited by the new process,
+	// separated by commas. The listener of the i-th name is the file descriptor 3+i, the ready pipe follows them.
+	listenersEnv = "MEMOS_HANDOFF_LISTENERS"
+	// ReadyTimeout is the max duration of the new process starting up, e.g. migrating the database, before it's ready.
+	ReadyTimeout = 5 * time.Minute
+)
+
+var (
+	mutex sync.Mutex
+	// listeners are the listeners of the process by their names, in the order they're listened.
+	listeners     = map[string]*os.File{}
+	listenerNames = []string{}
+	// inherited are the listeners inherited from the parent process which aren't listened yet.
+	inherited = map[string]*os.File{}
+	// readyPipe is the pipe the parent process waits on, nil if the process isn't started by a handoff.
+	readyPipe *os.File
+)
+
+func init() {
+	value := os.Getenv(listenersEnv)
+	if value == "" {
+		return
+	}
+	// The variable isn't passed on to the processes started by the server.
+	os.Unsetenv(listenersEnv)
+	names := strings.Split(value, ",")
+	for i, name := range names {
+		inherited[name] = os.NewFile(uintptr(3+i), name)
+	}
+	readyPipe = os.NewFile(uintptr(3+len(names)), "ready")
+}
+
+// Inherited returns true if the process is started by a handoff.
+func Inherited() bool {
+	return readyPipe != nil
+}
+
+// Listen returns the TCP listener of the name inherited from the parent process, or listens on the address.
+// With reusePort, the address is listened with SO_REUSEPORT, so another process can listen on it at the same time.
+func Listen(name, address string, reusePort bool) (net.Listener, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var listener net.Listener
+	if file, ok := inherited[name]; ok {
+		delete(inherited, name)
+		var err error
+		if listener, err = net.FileListener(file); err != nil {
+			return nil, errors.Wrapf(err, "failed to inherit %s listener", name)
+		}
+		file.Close()
+	} else {
+		listenConfig := net.ListenConfig{}
+		if reusePort {
+			listenConfig.Control = reusePortControl
+		}
+		var err error
+		if listener, err = listenConfig.Listen(context.Background(), "tcp", address); err != nil {
+			return nil, err
+		}
+	}
+
+	tcpListener, ok := listener.(*net.TCPListener)
+	if !ok {
+		return listener, nil
+	}
+	// The file is a duplicate of the listener, which stays open for the handoff after the listener is closed on shutdown.
+	file, err := tcpListener.File()
+	if err != nil {
+		listener.Close()
+		return nil, errors.Wrapf(err, "failed to get file of %s listener", name)
+	}
+	keepListener(name, file)
+	return listener, nil
+}
+
+// ListenPacket returns the UDP connection of the name inherited from the parent process, or listens on the address,
+// e.g. of HTTP/3. With reusePort, the address is listened with SO_REUSEPORT like by Listen.
+func ListenPacket(name, address string, reusePort bool) (net.PacketConn, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var conn net.PacketConn
+	if file, ok := inherited[name]; ok {
+		delete(inherited, name)
+		var err error
+		if conn, err = net.FilePacketConn(file); err != nil {
+			return nil, errors.Wrapf(err, "failed to inherit %s connection", name)
+		}
+		file.Close()
+	} else {
+		listenConfig := net.ListenConfig{}
+		if reusePort {
+			listenConfig.Control = reusePortControl
+		}
+		var err error
+		if conn, err = listenConfig.ListenPacket(context.Background(), "udp", address); err != nil {
+			return nil, err
+		}
+	}
+
+	udpConn, ok := conn.(*net.UDPConn)
+	if !ok {
+		return conn, nil
+	}
+	file, err := udpConn.File()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "failed to get file of %s connection", name)
+	}
+	keepListener(name, file)
+	return conn, nil
+}
+
+// keepListener keeps the file of the listener for the handoff, the mutex must be held.
+func keepListener(name string, file *os.File) {
+	if previous, ok := listeners[name]; ok {
+		previous.Close()
+	} else {
+		listenerNames = append(listenerNames, name)
+	}
+	listeners[name] = file
+}
+
+// Ready tells the parent process the listeners are served, so it drains its requests and exits.
+// The inherited listeners which aren't listened again, e.g. of a disabled server, are closed.
+func Ready() error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for name, file := range inherited {
+		file.Close()
+		delete(inherited, name)
+	}
+	if readyPipe == nil {
+		return nil
+	}
+	defer func() {
+		readyPipe.Close()
+		readyPipe = nil
+	}()
+	if _, err := readyPipe.Write([]byte{1}); err != nil {
+		return errors.Wrap(err, "failed to notify parent process")
+	}
+	return nil
+}
+
+// Upgrade starts a new process of the executable with the arguments and the listeners of the process,
+// and waits until the new process is ready. The process should shut down afterwards.
+func Upgrade(ctx context.Context) error {
+	if !supported {
+		return errors.New("handoff is not supported on this platform")
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "failed to find executable")
+	}
+
+	mutex.Lock()
+	names := append([]string{}, listenerNames...)
+	files := []*os.File{}
+	for _, name := range names {
+		files = append(files, listeners[name])
+	}
+	mutex.Unlock()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return errors.Wrap(err, "failed to create ready pipe")
+	}
+	defer reader.Close()
+	process, err := startProcess(executable, os.Args, append(os.Environ(), listenersEnv+"="+strings.Join(names, ",")), append(files, writer))
+	// The pipe is closed by the new process only, so the reads end when it's ready or exits.
+	writer.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to start new process")
+	}
+
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			ready <- errors.New("new process exited before it was ready")
+			return
+		}
+		ready <- nil
+	}()
+	timer := time.NewTimer(ReadyTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-ready:
+	case <-timer.C:
+		err = errors.New("new process isn't ready in time")
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		_ = process.Kill()
+		_, _ = process.Wait()
+		return err
+	}
+	// The new process outlives this one.
+	return process.Release()
+}
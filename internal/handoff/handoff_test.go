@@ -0,0 +1,67 @@
+//go:build !windows
+
+package handoff
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenReusePort(t *testing.T) {
+	listener, err := Listen("first", "127.0.0.1:0", true)
+	require.NoError(t, err)
+	defer listener.Close()
+	address := listener.Addr().String()
+
+	// Another process of the new binary listens on the port before the old one stops.
+	another, err := Listen("second", address, true)
+	require.NoError(t, err)
+	another.Close()
+	_, err = Listen("third", address, false)
+	require.Error(t, err)
+
+	require.Equal(t, []string{"first", "second"}, listenerNames)
+	require.NoError(t, Ready())
+	require.False(t, Inherited())
+}
+
+func TestListenInherited(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	file, err := listener.(*net.TCPListener).File()
+	require.NoError(t, err)
+	listener.Close()
+	mutex.Lock()
+	inherited["http"] = file
+	mutex.Unlock()
+
+	// The listener is inherited instead of listening on the address.
+	listener, err = Listen("http", "", false)
+	require.NoError(t, err)
+	defer listener.Close()
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+	require.Empty(t, inherited)
+}
+
+func TestListenPacketInherited(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := conn.LocalAddr().String()
+	file, err := conn.(*net.UDPConn).File()
+	require.NoError(t, err)
+	conn.Close()
+	mutex.Lock()
+	inherited["http3"] = file
+	mutex.Unlock()
+
+	// The connection is inherited with its port.
+	conn, err = ListenPacket("http3", "", false)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Equal(t, address, conn.LocalAddr().String())
+	require.Empty(t, inherited)
+}
@@ -0,0 +1,48 @@
+//go:build !windows
+
+package handoff
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// supported is true if the listeners can be passed to a new process.
+const supported = true
+
+// UpgradeSignal is the signal upgrading the server, like the USR2 signal upgrading the binary of nginx.
+var UpgradeSignal os.Signal = syscall.SIGUSR2
+
+func reusePortControl(_, _ string, conn syscall.RawConn) error {
+	var err error
+	if controlErr := conn.Control(func(fd uintptr) {
+		err = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+	}); controlErr != nil {
+		return controlErr
+	}
+	return err
+}
+
+// startProcess starts the executable with the standard files of the process, and the files as the file descriptors from 3.
+// Unlike os/exec, it doesn't put the files in the blocking mode, which would block the accepts of the shared listeners.
+func startProcess(executable string, args, env []string, files []*os.File) (*os.Process, error) {
+	fds := []uintptr{0, 1, 2}
+	for _, file := range files {
+		conn, err := file.SyscallConn()
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.Control(func(fd uintptr) {
+			fds = append(fds, fd)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	pid, err := syscall.ForkExec(executable, args, &syscall.ProcAttr{Env: env, Files: fds})
+	if err != nil {
+		return nil, err
+	}
+	return os.FindProcess(pid)
+}
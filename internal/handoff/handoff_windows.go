@@ -0,0 +1,22 @@
+package handoff
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// supported is false as the processes on Windows don't inherit the listeners.
+const supported = false
+
+// UpgradeSignal is nil as there is no signal for the upgrades on Windows.
+var UpgradeSignal os.Signal
+
+func startProcess(string, []string, []string, []*os.File) (*os.Process, error) {
+	return nil, errors.New("handoff is not supported on Windows")
+}
+
+func reusePortControl(_, _ string, _ syscall.RawConn) error {
+	return errors.New("SO_REUSEPORT is not supported on Windows")
+}
@@ -0,0 +1,131 @@
+// Package i18n translates the text generated by the server, e.g. the emails, the digests and the feeds, into the locales of the recipients.
+// The messages are in the JSON files of the locales, which are named after the locales of the web app, and fall back to English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultLocale is the locale the missing messages fall back to.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs are the messages of the supported locales by their keys.
+var catalogs = loadCatalogs()
+
+// locales are the supported locales, sorted so the locales are matched in the same order.
+var locales = getLocales()
+
+func loadCatalogs() map[string]map[string]string {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := map[string]map[string]string{}
+	for _, entry := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("invalid locale file %s: %v", entry.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	return catalogs
+}
+
+func getLocales() []string {
+	list := []string{}
+	for locale := range catalogs {
+		list = append(list, locale)
+	}
+	slices.Sort(list)
+	return list
+}
+
+// MatchLocale returns the supported locale of the first preferred locale which is supported,
+// e.g. `de` for `de-AT` or `zh-Hans` for `zh`, and the default locale if none is supported.
+func MatchLocale(preferred ...string) string {
+	for _, locale := range preferred {
+		locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+		if locale == "" {
+			continue
+		}
+		for _, supported := range locales {
+			if strings.EqualFold(supported, locale) {
+				return supported
+			}
+		}
+		base, _, _ := strings.Cut(locale, "-")
+		// The locale of the language itself goes before its regional locales, e.g. `en` before `en-GB`.
+		if _, ok := catalogs[strings.ToLower(base)]; ok {
+			return strings.ToLower(base)
+		}
+		for _, supported := range locales {
+			supportedBase, _, _ := strings.Cut(supported, "-")
+			if strings.EqualFold(supportedBase, base) {
+				return supported
+			}
+		}
+	}
+	return DefaultLocale
+}
+
+// ParseAcceptLanguage returns the locales of the Accept-Language header, in the order of preference.
+// e.g. `fr-CH, fr;q=0.9, en;q=0.8` returns `fr-CH`, `fr` and `en`.
+func ParseAcceptLanguage(header string) []string {
+	list := []string{}
+	for _, part := range strings.Split(header, ",") {
+		locale, _, _ := strings.Cut(part, ";")
+		if locale = strings.TrimSpace(locale); locale != "" && locale != "*" {
+			list = append(list, locale)
+		}
+	}
+	return list
+}
+
+// Localizer translates the messages into a locale.
+type Localizer struct {
+	locale string
+}
+
+// NewLocalizer returns the localizer of the first supported locale of the preferred locales.
+func NewLocalizer(preferred ...string) *Localizer {
+	return &Localizer{
+		locale: MatchLocale(preferred...),
+	}
+}
+
+// Locale returns the supported locale the messages are translated into.
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+// T returns the message of the key, with the placeholders replaced by the args, which are pairs of the names and the values,
+// e.g. `T("digest.subject", "count", 3)` replaces `{count}` with 3.
+// The message falls back to the one of the default locale, and to the key if neither has it.
+func (l *Localizer) T(key string, args ...any) string {
+	message, ok := catalogs[l.locale][key]
+	if !ok {
+		if message, ok = catalogs[DefaultLocale][key]; !ok {
+			message = key
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	replacements := []string{}
+	for i := 0; i+1 < len(args); i += 2 {
+		replacements = append(replacements, fmt.Sprintf("{%v}", args[i]), fmt.Sprint(args[i+1]))
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
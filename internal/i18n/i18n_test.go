@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		preferred []string
+		want      string
+	}{
+		{preferred: nil, want: "en"},
+		{preferred: []string{"de"}, want: "de"},
+		{preferred: []string{"de-AT"}, want: "de"},
+		{preferred: []string{"zh"}, want: "zh-Hans"},
+		{preferred: []string{"ZH-hans"}, want: "zh-Hans"},
+		{preferred: []string{"ko", "fr"}, want: "fr"},
+		{preferred: []string{"", "ko"}, want: "en"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, MatchLocale(test.preferred...), test.preferred)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	require.Equal(t, []string{"fr-CH", "fr", "en"}, ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5"))
+	require.Equal(t, []string{}, ParseAcceptLanguage(""))
+}
+
+func TestLocalizer(t *testing.T) {
+	localizer := NewLocalizer("de")
+	require.Equal(t, "de", localizer.Locale())
+	require.NotEqual(t, catalogs[DefaultLocale]["notification.memo-reminder"], localizer.T("notification.memo-reminder"))
+	require.Equal(t, "Alice commented on your memo", NewLocalizer("en").T("notification.memo-comment", "name", "Alice"))
+	require.Equal(t, "unknown.key", localizer.T("unknown.key"))
+}
+
+func TestCatalogs(t *testing.T) {
+	for locale, messages := range catalogs {
+		require.Len(t, messages, len(catalogs[DefaultLocale]), locale)
+		for key := range catalogs[DefaultLocale] {
+			require.Contains(t, messages, key, locale)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/usememos/memos/internal/telemetry"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// DatabaseMaintenanceTask is the name of the scheduled task of the database maintenance.
+	DatabaseMaintenanceTask = "db_maintenance"
+	// databaseMaintenanceWindow is the duration from the scheduled time of the maintenance, after which its remaining
+	// statements aren't started, so the tables aren't locked beyond the quiet hours.
+	databaseMaintenanceWindow = time.Hour
+)
+
+// MaintainDatabase runs the housekeeping statements of the database, e.g. `VACUUM` and `ANALYZE` of SQLite,
+// and records their durations and the space reclaimed in the metrics.
+func MaintainDatabase(ctx context.Context, dataStore *store.Store) error {
+	start := time.Now()
+	result, err := dataStore.MaintainDatabase(ctx, start.Add(databaseMaintenanceWindow))
+	if histogram, histogramErr := telemetry.Meter().Float64Histogram("memos.db.maintenance.duration",
+		metric.WithDescription("Duration of the statements of the database maintenance."),
+		metric.WithUnit("s"),
+	); histogramErr == nil {
+		for _, statement := range result.Statements {
+			histogram.Record(ctx, statement.Duration.Seconds(), metric.WithAttributes(attribute.String("db.statement", statement.Statement)))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	reclaimed := int64(0)
+	if result.SizeBefore > 0 && result.SizeAfter > 0 && result.SizeAfter < result.SizeBefore {
+		reclaimed = result.SizeBefore - result.SizeAfter
+	}
+	if counter, counterErr := telemetry.Meter().Int64Counter("memos.db.maintenance.reclaimed",
+		metric.WithDescription("Size of the database reclaimed by the maintenance."),
+		metric.WithUnit("By"),
+	); counterErr == nil {
+		counter.Add(ctx, reclaimed)
+	}
+	slog.Info("Maintained database",
+		slog.Int("statements", len(result.Statements)),
+		slog.Int64("size_before", result.SizeBefore),
+		slog.Int64("size_after", result.SizeAfter),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
@@ -14,24 +14,9 @@ import (
 	"github.com/usememos/memos/store"
 )
 
-// RunPreSignLinks is a background job that pre-signs external links stored in the database.
+// PreSignLinks pre-signs the external links of the resources stored in the S3 compatible storage, before they expire.
 // It uses S3 client to generate presigned URLs and updates the corresponding resources in the store.
-func RunPreSignLinks(ctx context.Context, dataStore *store.Store) {
-	for {
-		if err := signExternalLinks(ctx, dataStore); err != nil {
-			slog.Error("failed to pre-sign links", err)
-		} else {
-			slog.Debug("pre-signed links")
-		}
-		select {
-		case <-time.After(s3.LinkLifetime / 2):
-		case <-ctx.Done():
-			return
-		}
-	}
-}
-
-func signExternalLinks(ctx context.Context, dataStore *store.Store) error {
+func PreSignLinks(ctx context.Context, dataStore *store.Store) error {
 	const pageSize = 32
 
 	objectStore, err := findObjectStorage(ctx, dataStore)
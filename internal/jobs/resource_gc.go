@@ -0,0 +1,19 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// CollectOrphanedResources deletes the orphaned resources and local files, which are older than the grace period.
+func CollectOrphanedResources(ctx context.Context, dataStore *store.Store) error {
+	result, err := apiv1.CollectOrphanedResources(ctx, dataStore, apiv1.ResourceGCGracePeriod)
+	if err != nil {
+		return err
+	}
+	slog.Debug("collected orphaned resources", slog.Int("resources", result.DeletedResourceCount), slog.Int("files", result.DeletedFileCount))
+	return nil
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/ocr"
+	"github.com/usememos/memos/plugin/pdftext"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// ExtractResourceTexts extracts the text from PDF documents, and recognizes the text in images if OCR is enabled,
+// so they can be found by the memo content search.
+func ExtractResourceTexts(ctx context.Context, dataStore *store.Store) error {
+	workspaceStorageSetting, err := dataStore.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get workspace storage setting")
+	}
+	ocrEngine := newOCREngine(workspaceStorageSetting.Ocr)
+
+	resources, err := dataStore.ListResources(ctx, &store.FindResource{})
+	if err != nil {
+		return errors.Wrap(err, "list resources")
+	}
+	for _, resource := range resources {
+		if resource.ExtractedText != nil {
+			continue
+		}
+		var extractText func(blob []byte) (string, error)
+		if resource.Type == "application/pdf" {
+			extractText = pdftext.ExtractText
+		} else if ocrEngine != nil && util.HasPrefixes(resource.Type, "image/png", "image/jpeg", "image/webp", "image/gif", "image/bmp", "image/tiff") {
+			extractText = func(blob []byte) (string, error) {
+				return ocrEngine.Recognize(ctx, blob)
+			}
+		} else {
+			continue
+		}
+
+		blob, err := apiv1.GetResourceBlob(ctx, dataStore, resource)
+		if err != nil {
+			slog.Warn("failed to get resource blob", slog.Int("resource", int(resource.ID)), slog.Any("err", err))
+			continue
+		}
+		if blob == nil {
+			// resource stored in external service
+			continue
+		}
+		text, err := extractText(blob)
+		if err != nil {
+			slog.Warn("failed to extract resource text", slog.Int("resource", int(resource.ID)), slog.Any("err", err))
+			continue
+		}
+		text = strings.TrimSpace(text)
+		if _, err := dataStore.UpdateResource(ctx, &store.UpdateResource{
+			ID:            resource.ID,
+			ExtractedText: &text,
+		}); err != nil {
+			return errors.Wrapf(err, "update resource %d extracted text", resource.ID)
+		}
+	}
+	return nil
+}
+
+// newOCREngine returns the OCR engine configured in the workspace storage setting, or nil if OCR is disabled.
+func newOCREngine(setting *storepb.OCRSetting) ocr.Engine {
+	if setting == nil {
+		return nil
+	}
+	switch setting.Engine {
+	case storepb.OCRSetting_TESSERACT:
+		return &ocr.TesseractEngine{
+			Command:  setting.Address,
+			Language: setting.Language,
+		}
+	case storepb.OCRSetting_HTTP:
+		return &ocr.HTTPEngine{
+			Endpoint: setting.Address,
+			Language: setting.Language,
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/usememos/memos/internal/event"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// PurgeDeletedUsers purges the users whose accounts are due to be deleted, and publishes their deletions.
+func PurgeDeletedUsers(ctx context.Context, dataStore *store.Store, eventBroker *event.Broker) error {
+	userIDs, err := apiv1.PurgeDeletedUsers(ctx, dataStore)
+	// The users purged before an error are deleted as well.
+	for _, userID := range userIDs {
+		eventBroker.Publish(event.NewUserEvent(event.UserDeleted, userID))
+	}
+	if err != nil {
+		return err
+	}
+	slog.Debug("purged deleted users", slog.Int("users", len(userIDs)))
+	return nil
+}
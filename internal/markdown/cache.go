@@ -0,0 +1,111 @@
+package markdown
+
+import (
+	"container/list"
+	"sync"
+)
+
+type cacheKey struct {
+	kind string
+	hash [32]byte
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	value any
+	size  int
+}
+
+// Stats is the stats of the cache since the server started.
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	// Entries is the number of the cached entries.
+	Entries int
+	// Size is the size of the cached entries in bytes, it's bounded by the max size.
+	Size    int
+	MaxSize int
+}
+
+// lruCache is the cache of the entries, whose least recently used entries are evicted once the max size is exceeded.
+type lruCache struct {
+	mutex   sync.Mutex
+	maxSize int
+	size    int
+	// order is the list of the entries, the most recently used first.
+	order   *list.List
+	entries map[cacheKey]*list.Element
+
+	hits, misses, evictions int64
+}
+
+func newLRUCache(maxSize int) *lruCache {
+	return &lruCache{
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: map[cacheKey]*list.Element{},
+	}
+}
+
+func (c *lruCache) get(key cacheKey) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).value, true
+}
+
+// add caches the entry, unless it's larger than the max size.
+func (c *lruCache) add(key cacheKey, value any, size int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if size > c.maxSize {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, size: size})
+	c.size += size
+	c.evict()
+}
+
+func (c *lruCache) resize(maxSize int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.maxSize = maxSize
+	c.evict()
+}
+
+func (c *lruCache) evict() {
+	for c.size > c.maxSize {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *lruCache) removeElement(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+func (c *lruCache) stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   len(c.entries),
+		Size:      c.size,
+		MaxSize:   c.maxSize,
+	}
+}
@@ -0,0 +1,90 @@
+// Package markdown parses and renders the contents of the memos with gomark, with the results cached in an LRU cache
+// bounded by the size of the contents, so the lists of the memos and the integrations don't parse the same contents
+// over and over. The entries are keyed by the hashes of the contents, so a new version of a memo is parsed again.
+package markdown
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/yourselfhosted/gomark/ast"
+	"github.com/yourselfhosted/gomark/parser"
+	"github.com/yourselfhosted/gomark/parser/tokenizer"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/usememos/memos/internal/telemetry"
+)
+
+// DefaultCacheSize is the max size of the cached contents in bytes, unless it's set by SetCacheSize.
+const DefaultCacheSize = 32 << 20
+
+// parseKind is the kind of the cached nodes, the other kinds are of the renders.
+const parseKind = "parse"
+
+var (
+	cache           = newLRUCache(DefaultCacheSize)
+	registerMetrics sync.Once
+)
+
+// SetCacheSize sets the max size of the cached contents in bytes, the least recently used entries are evicted
+// once the size is exceeded. Zero disables the cache.
+func SetCacheSize(size int) {
+	cache.resize(size)
+}
+
+// CacheStats returns the stats of the cache, e.g. for the metrics.
+func CacheStats() Stats {
+	return cache.stats()
+}
+
+// Parse returns the nodes of the content. The nodes are shared by the callers, they must not be changed:
+// the callers changing the nodes, e.g. to restore the content with a renamed tag, parse the content with gomark instead.
+func Parse(content string) ([]ast.Node, error) {
+	setupMetrics()
+	key := cacheKey{kind: parseKind, hash: sha256.Sum256([]byte(content))}
+	if value, ok := cache.get(key); ok {
+		return value.([]ast.Node), nil
+	}
+	nodes, err := parser.Parse(tokenizer.Tokenize(content))
+	if err != nil {
+		return nil, err
+	}
+	cache.add(key, nodes, len(content))
+	return nodes, nil
+}
+
+// Render returns the output rendered from the content by the render, e.g. the HTML of an RSS item.
+// The outputs are cached by the kind, which must identify the render and its parameters, e.g. the base URL of the links.
+func Render(kind, content string, render func(content string) (string, error)) (string, error) {
+	setupMetrics()
+	key := cacheKey{kind: kind, hash: sha256.Sum256([]byte(content))}
+	if value, ok := cache.get(key); ok {
+		return value.(string), nil
+	}
+	output, err := render(content)
+	if err != nil {
+		return "", err
+	}
+	cache.add(key, output, len(content)+len(output))
+	return output, nil
+}
+
+// setupMetrics registers the metrics of the cache, they're exported only when the telemetry is.
+func setupMetrics() {
+	registerMetrics.Do(func() {
+		meter := telemetry.Meter()
+		hits, _ := meter.Int64ObservableCounter("memos.markdown.cache.hits", metric.WithDescription("Number of the hits of the markdown cache."))
+		misses, _ := meter.Int64ObservableCounter("memos.markdown.cache.misses", metric.WithDescription("Number of the misses of the markdown cache."))
+		evictions, _ := meter.Int64ObservableCounter("memos.markdown.cache.evictions", metric.WithDescription("Number of the entries evicted from the markdown cache."))
+		size, _ := meter.Int64ObservableGauge("memos.markdown.cache.size", metric.WithDescription("Size of the contents cached in the markdown cache."), metric.WithUnit("By"))
+		_, _ = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+			stats := cache.stats()
+			observer.ObserveInt64(hits, stats.Hits)
+			observer.ObserveInt64(misses, stats.Misses)
+			observer.ObserveInt64(evictions, stats.Evictions)
+			observer.ObserveInt64(size, int64(stats.Size))
+			return nil
+		}, hits, misses, evictions, size)
+	})
+}
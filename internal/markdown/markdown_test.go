@@ -0,0 +1,77 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yourselfhosted/gomark/ast"
+)
+
+func TestParse(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+	SetCacheSize(DefaultCacheSize)
+	before := CacheStats()
+
+	content := "#memos Hello **world**"
+	nodes, err := Parse(content)
+	require.NoError(t, err)
+	require.NotEmpty(t, nodes)
+	cached, err := Parse(content)
+	require.NoError(t, err)
+	// The cached nodes are the same ones.
+	require.Same(t, nodes[0], cached[0])
+	_, ok := nodes[0].(*ast.Paragraph)
+	require.True(t, ok)
+
+	stats := CacheStats()
+	require.Equal(t, before.Hits+1, stats.Hits)
+	require.Equal(t, before.Misses+1, stats.Misses)
+}
+
+func TestRender(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+	SetCacheSize(DefaultCacheSize)
+
+	renders := 0
+	render := func(content string) (string, error) {
+		renders++
+		return strings.ToUpper(content), nil
+	}
+	for i := 0; i < 3; i++ {
+		output, err := Render("upper", "hello", render)
+		require.NoError(t, err)
+		require.Equal(t, "HELLO", output)
+	}
+	require.Equal(t, 1, renders)
+	// The outputs of the other kinds of the renders are cached apart.
+	output, err := Render("lower", "hello", func(content string) (string, error) {
+		return strings.ToLower(content), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "hello", output)
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := newLRUCache(10)
+	keys := []cacheKey{{kind: "a"}, {kind: "b"}, {kind: "c"}}
+	cache.add(keys[0], "a", 4)
+	cache.add(keys[1], "b", 4)
+	// The first entry is used, so the second one is the least recently used.
+	_, ok := cache.get(keys[0])
+	require.True(t, ok)
+	cache.add(keys[2], "c", 4)
+	_, ok = cache.get(keys[1])
+	require.False(t, ok)
+	_, ok = cache.get(keys[0])
+	require.True(t, ok)
+	// The entries larger than the max size aren't cached.
+	cache.add(cacheKey{kind: "d"}, "d", 11)
+	stats := cache.stats()
+	require.Equal(t, 2, stats.Entries)
+	require.Equal(t, 8, stats.Size)
+	require.Equal(t, int64(1), stats.Evictions)
+
+	cache.resize(0)
+	require.Zero(t, cache.stats().Entries)
+}
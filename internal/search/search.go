@@ -0,0 +1,227 @@
+// Package search ranks texts matching a query, and extracts the snippets around the matches.
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// The BM25 parameters, see https://en.wikipedia.org/wiki/Okapi_BM25.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// phraseBoost is the weight of a phrase relative to a single word.
+	phraseBoost = 1.5
+)
+
+// Query is a parsed search query.
+type Query struct {
+	// Terms are the lowercased words and phrases, which all must be contained in a matching text.
+	Terms []string
+}
+
+// Range is the range of a match, as the offsets of the characters (unicode code points) in the text.
+type Range struct {
+	Start int
+	End   int
+}
+
+// Snippet is a part of a text around matches.
+type Snippet struct {
+	Text string
+	// Start is the offset of the snippet in the text.
+	Start int
+	// Matches are the ranges of the matches in the snippet.
+	Matches []Range
+}
+
+// ParseQuery parses the query, where the quoted parts are phrases and the other parts are separated into words,
+// e.g. `"release notes" memos` has the terms `release notes` and `memos`.
+func ParseQuery(query string) *Query {
+	q := &Query{}
+	add := func(term string) {
+		term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+		if term != "" && !slices.Contains(q.Terms, term) {
+			q.Terms = append(q.Terms, term)
+		}
+	}
+	for i, part := range strings.Split(query, `"`) {
+		// The odd parts are between quotes.
+		if i%2 == 1 {
+			add(part)
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			add(word)
+		}
+	}
+	return q
+}
+
+// IsEmpty returns true if the query has no terms.
+func (q *Query) IsEmpty() bool {
+	return len(q.Terms) == 0
+}
+
+// Matches returns the sorted ranges of the terms in the text, overlapping ranges are merged.
+// It returns nil if a term isn't contained in the text.
+func (q *Query) Matches(text string) []Range {
+	runes := lowerRunes(text)
+	ranges := []Range{}
+	for _, term := range q.Terms {
+		termRanges := findAll(runes, []rune(term))
+		if len(termRanges) == 0 {
+			return nil
+		}
+		ranges = append(ranges, termRanges...)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+	merged := []Range{}
+	for _, r := range ranges {
+		if len(merged) > 0 && r.Start <= merged[len(merged)-1].End {
+			merged[len(merged)-1].End = max(merged[len(merged)-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// Rank returns the relevance scores of the texts with BM25, where the frequencies of the terms are counted
+// in the texts. Texts which don't contain all the terms score 0.
+func (q *Query) Rank(texts []string) []float64 {
+	scores := make([]float64, len(texts))
+	if len(texts) == 0 || q.IsEmpty() {
+		return scores
+	}
+
+	termFrequencies := make([]map[string]int, len(texts))
+	documentFrequencies := map[string]int{}
+	lengths := make([]int, len(texts))
+	totalLength := 0
+	for i, text := range texts {
+		runes := lowerRunes(text)
+		lengths[i] = len(runes)
+		totalLength += len(runes)
+		termFrequencies[i] = map[string]int{}
+		for _, term := range q.Terms {
+			if count := len(findAll(runes, []rune(term))); count > 0 {
+				termFrequencies[i][term] = count
+				documentFrequencies[term]++
+			}
+		}
+	}
+
+	averageLength := math.Max(float64(totalLength)/float64(len(texts)), 1)
+	total := float64(len(texts))
+	for i := range texts {
+		if len(termFrequencies[i]) != len(q.Terms) {
+			continue
+		}
+		score := 0.0
+		for _, term := range q.Terms {
+			tf := float64(termFrequencies[i][term])
+			df := float64(documentFrequencies[term])
+			idf := math.Log(1 + (total-df+0.5)/(df+0.5))
+			weight := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(lengths[i])/averageLength))
+			if strings.Contains(term, " ") {
+				weight *= phraseBoost
+			}
+			score += idf * weight
+		}
+		scores[i] = score
+	}
+	return scores
+}
+
+// Snippets returns at most limit snippets of the text around the matches, with about radius characters
+// of context on each side. The snippets are cut at spaces where possible.
+func Snippets(text string, matches []Range, limit, radius int) []*Snippet {
+	runes := []rune(text)
+	snippets := []*Snippet{}
+	for i := 0; i < len(matches) && len(snippets) < limit; {
+		start := cutStart(runes, matches[i].Start-radius, matches[i].Start)
+		end := cutEnd(runes, matches[i].End+radius, matches[i].End)
+		snippet := &Snippet{Start: start}
+		// Take the following matches within the snippet.
+		for ; i < len(matches) && matches[i].Start < end; i++ {
+			if matches[i].End > end {
+				end = matches[i].End
+			}
+			snippet.Matches = append(snippet.Matches, Range{
+				Start: matches[i].Start - start,
+				End:   matches[i].End - start,
+			})
+		}
+		snippet.Text = string(runes[start:end])
+		snippets = append(snippets, snippet)
+	}
+	return snippets
+}
+
+// cutStart returns the offset after the first space from offset to limit, or offset if there's no space.
+func cutStart(runes []rune, offset, limit int) int {
+	if offset <= 0 {
+		return 0
+	}
+	for i := offset; i < limit; i++ {
+		if unicode.IsSpace(runes[i]) {
+			return i + 1
+		}
+	}
+	return offset
+}
+
+// cutEnd returns the offset of the last space from limit to offset, or offset if there's no space.
+func cutEnd(runes []rune, offset, limit int) int {
+	if offset >= len(runes) {
+		return len(runes)
+	}
+	for i := offset; i > limit; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return offset
+}
+
+// lowerRunes returns the lowercased runes of the text, the offsets of the runes are kept.
+func lowerRunes(text string) []rune {
+	runes := []rune(text)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// findAll returns the ranges of the non-overlapping occurrences of the term.
+func findAll(runes, term []rune) []Range {
+	ranges := []Range{}
+	if len(term) == 0 {
+		return ranges
+	}
+	for i := 0; i+len(term) <= len(runes); {
+		if equalRunes(runes[i:i+len(term)], term) {
+			ranges = append(ranges, Range{Start: i, End: i + len(term)})
+			i += len(term)
+			continue
+		}
+		i++
+	}
+	return ranges
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
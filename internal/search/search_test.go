@@ -0,0 +1,58 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		terms []string
+	}{
+		{query: "", terms: nil},
+		{query: "Hello  world hello", terms: []string{"hello", "world"}},
+		{query: `"release  notes" memos`, terms: []string{"release notes", "memos"}},
+		{query: `go "unclosed phrase`, terms: []string{"go", "unclosed phrase"}},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.terms, ParseQuery(test.query).Terms, test.query)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	q := ParseQuery(`"hello world" wor`)
+	require.Equal(t, []Range{{Start: 3, End: 14}, {Start: 16, End: 19}}, q.Matches("Hi Hello World, world"))
+	require.Nil(t, q.Matches("hello"))
+	// The offsets are in characters.
+	require.Equal(t, []Range{{Start: 3, End: 5}}, ParseQuery("世界").Matches("你好，世界"))
+}
+
+func TestRank(t *testing.T) {
+	q := ParseQuery("memos")
+	scores := q.Rank([]string{
+		"memos memos memos",
+		"a long text about many other things, which mentions memos once",
+		"nothing",
+	})
+	require.Greater(t, scores[0], scores[1])
+	require.Greater(t, scores[1], 0.0)
+	require.Equal(t, 0.0, scores[2])
+}
+
+func TestSnippets(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog"
+	matches := ParseQuery("fox dog").Matches(text)
+	snippets := Snippets(text, matches, 3, 8)
+	require.Len(t, snippets, 2)
+	require.Equal(t, "brown fox jumps", snippets[0].Text)
+	require.Equal(t, []Range{{Start: 6, End: 9}}, snippets[0].Matches)
+	require.Equal(t, "lazy dog", snippets[1].Text)
+	require.Equal(t, 35, snippets[1].Start)
+	require.Len(t, Snippets(text, matches, 1, 8), 1)
+	// Close matches are in the same snippet.
+	snippets = Snippets(text, matches, 3, 40)
+	require.Len(t, snippets, 1)
+	require.Equal(t, text, snippets[0].Text)
+}
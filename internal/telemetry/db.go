@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/XSAM/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OpenDB opens the database like sql.Open, with the spans of the queries and the metrics of the connections.
+// The queries are also counted per request and logged if they're slow, see TrackQueries and SetQueryLimits.
+// The system is the name of the database in the semantic conventions, e.g. sqlite, mysql or postgresql.
+func OpenDB(driverName, dataSourceName, system string) (*sql.DB, error) {
+	// The driver is only looked up, the database isn't connected until it's used.
+	sqlDB, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	sqlDriver := sqlDB.Driver()
+	if err := sqlDB.Close(); err != nil {
+		return nil, err
+	}
+	var connector driver.Connector = dsnConnector{dsn: dataSourceName, driver: sqlDriver}
+	if driverContext, ok := sqlDriver.(driver.DriverContext); ok {
+		if connector, err = driverContext.OpenConnector(dataSourceName); err != nil {
+			return nil, err
+		}
+	}
+
+	attributes := otelsql.WithAttributes(attribute.String("db.system", system))
+	db := otelsql.OpenDB(&queryConnector{connector: connector}, attributes, otelsql.WithSpanOptions(otelsql.SpanOptions{
+		OmitConnResetSession: true,
+		OmitRows:             true,
+		// The queries outside of the requests and the jobs, e.g. of the migrations, aren't traced.
+		SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+			return trace.SpanContextFromContext(ctx).IsValid()
+		},
+	}))
+	if err := otelsql.RegisterDBStatsMetrics(db, attributes); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,315 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxLoggedQueryLength is the max length of the queries in the log of the slow queries.
+const maxLoggedQueryLength = 1024
+
+var (
+	// slowQueryThreshold is the min duration of the queries logged as slow, 0 means disabled.
+	slowQueryThreshold atomic.Int64
+	// maxRequestQueries is the max number of the queries of a request before it's logged, 0 means disabled.
+	maxRequestQueries atomic.Int64
+)
+
+// SetQueryLimits sets the min duration of the queries logged as slow, and the max number of the queries of a request
+// before it's logged as a possible N+1 query. Zero disables the log.
+func SetQueryLimits(slowQuery time.Duration, requestQueries int) {
+	slowQueryThreshold.Store(int64(slowQuery))
+	maxRequestQueries.Store(int64(requestQueries))
+}
+
+type queryStatsContextKey struct{}
+
+// queryStats is the number and the duration of the queries of a request.
+type queryStats struct {
+	route    string
+	count    atomic.Int64
+	duration atomic.Int64
+}
+
+// TrackQueries counts the queries run with the returned context, and records their number in the metrics of the route
+// when the returned function is called at the end of the request, e.g. the gRPC method or the path of the HTTP route.
+func TrackQueries(ctx context.Context, route string) (context.Context, func()) {
+	stats := &queryStats{route: route}
+	ctx = context.WithValue(ctx, queryStatsContextKey{}, stats)
+	start := time.Now()
+	return ctx, func() {
+		count := stats.count.Load()
+		if count == 0 {
+			return
+		}
+		if histogram, err := Meter().Int64Histogram("memos.db.request.queries",
+			metric.WithDescription("Number of the database queries of a request."),
+			metric.WithUnit("{query}"),
+		); err == nil {
+			histogram.Record(ctx, count, metric.WithAttributes(attribute.String("memos.route", route)))
+		}
+		if limit := maxRequestQueries.Load(); limit > 0 && count > limit {
+			slog.WarnContext(ctx, "Too many queries in request",
+				slog.String("route", route),
+				slog.Int64("queries", count),
+				slog.Duration("query_duration", time.Duration(stats.duration.Load())),
+				slog.Duration("duration", time.Since(start)),
+			)
+		}
+	}
+}
+
+// recordQuery counts the query in the stats of the request, and logs it if it's slow.
+// The time of a query is until its rows are returned, the rows are read afterwards.
+func recordQuery(ctx context.Context, query string, args []driver.NamedValue, start time.Time) {
+	duration := time.Since(start)
+	stats, _ := ctx.Value(queryStatsContextKey{}).(*queryStats)
+	if stats != nil {
+		stats.count.Add(1)
+		stats.duration.Add(int64(duration))
+	}
+	threshold := time.Duration(slowQueryThreshold.Load())
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+	if counter, err := Meter().Int64Counter("memos.db.slow_queries",
+		metric.WithDescription("Number of the database queries slower than the threshold."),
+		metric.WithUnit("{query}"),
+	); err == nil {
+		counter.Add(ctx, 1)
+	}
+	attrs := []slog.Attr{
+		slog.Duration("duration", duration),
+		slog.String("query", formatQuery(query)),
+		slog.Any("args", sanitizeArgs(args)),
+	}
+	if stats != nil {
+		attrs = append(attrs, slog.String("route", stats.route))
+	}
+	slog.LogAttrs(ctx, slog.LevelWarn, "Slow query", attrs...)
+}
+
+// formatQuery collapses the whitespaces of the query into a line, and truncates it.
+func formatQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) > maxLoggedQueryLength {
+		query = query[:maxLoggedQueryLength] + "..."
+	}
+	return query
+}
+
+// sanitizeArgs returns the arguments of a query to log, the strings and the bytes are replaced by their lengths,
+// so the contents of the memos and the secrets aren't logged.
+func sanitizeArgs(args []driver.NamedValue) []string {
+	sanitized := []string{}
+	for _, arg := range args {
+		switch value := arg.Value.(type) {
+		case nil:
+			sanitized = append(sanitized, "NULL")
+		case string:
+			sanitized = append(sanitized, fmt.Sprintf("<string len=%d>", len(value)))
+		case []byte:
+			sanitized = append(sanitized, fmt.Sprintf("<bytes len=%d>", len(value)))
+		case time.Time:
+			sanitized = append(sanitized, value.Format(time.RFC3339))
+		default:
+			sanitized = append(sanitized, fmt.Sprint(value))
+		}
+	}
+	return sanitized
+}
+
+// queryConnector opens the connections whose queries are recorded by recordQuery.
+type queryConnector struct {
+	connector driver.Connector
+}
+
+func (c *queryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &queryConn{Conn: conn}, nil
+}
+
+func (c *queryConnector) Driver() driver.Driver {
+	return &queryDriver{Driver: c.connector.Driver()}
+}
+
+type queryDriver struct {
+	driver.Driver
+}
+
+func (d *queryDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &queryConn{Conn: conn}, nil
+}
+
+// dsnConnector is the connector of the drivers which don't implement driver.DriverContext.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// queryConn records the queries of the connection. The optional interfaces which the connection doesn't implement
+// return driver.ErrSkip, so database/sql falls back like it does for the connection itself.
+type queryConn struct {
+	driver.Conn
+}
+
+func (c *queryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		recordQuery(ctx, query, args, start)
+	}
+	return rows, err
+}
+
+func (c *queryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		recordQuery(ctx, query, args, start)
+	}
+	return result, err
+}
+
+func (c *queryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &queryStmt{Stmt: stmt, query: query}, nil
+}
+
+func (c *queryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	// The fallback of the connections without BeginTx, like the one of database/sql.
+	// nolint
+	return c.Conn.Begin()
+}
+
+func (c *queryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *queryConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *queryConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *queryConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// queryStmt records the queries of the prepared statement.
+type queryStmt struct {
+	driver.Stmt
+	query string
+}
+
+func (s *queryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			// The fallback of the statements without QueryContext, like the one of database/sql.
+			// nolint
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+	recordQuery(ctx, s.query, args, start)
+	return rows, err
+}
+
+func (s *queryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var result driver.Result
+	var err error
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			// The fallback of the statements without ExecContext, like the one of database/sql.
+			// nolint
+			result, err = s.Stmt.Exec(values)
+		}
+	}
+	recordQuery(ctx, s.query, args, start)
+	return result, err
+}
+
+func (s *queryStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.Errorf("driver doesn't support the named argument %s", arg.Name)
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
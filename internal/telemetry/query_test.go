@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	// Register the sqlite driver.
+	_ "modernc.org/sqlite"
+)
+
+func TestQueryLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(buf, nil)))
+	defer slog.SetDefault(defaultLogger)
+	// Every query is slow, and a request of more than 2 queries is logged.
+	SetQueryLimits(time.Nanosecond, 2)
+	defer SetQueryLimits(0, 0)
+
+	db, err := OpenDB("sqlite", filepath.Join(t.TempDir(), "memos.db"), "sqlite")
+	require.NoError(t, err)
+	defer db.Close()
+	ctx, done := TrackQueries(context.Background(), "/memos.api.v2.MemoService/ListMemos")
+	_, err = db.ExecContext(ctx, "CREATE TABLE memo (id INTEGER PRIMARY KEY, content TEXT)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO memo (id, content) VALUES (?, ?)", 1, "my secret")
+	require.NoError(t, err)
+	stmt, err := db.PrepareContext(ctx, "SELECT content FROM memo WHERE id = ?")
+	require.NoError(t, err)
+	content := ""
+	require.NoError(t, stmt.QueryRowContext(ctx, 1).Scan(&content))
+	require.NoError(t, stmt.Close())
+	require.Equal(t, "my secret", content)
+	done()
+
+	log := buf.String()
+	require.Contains(t, log, `msg="Slow query"`)
+	require.Contains(t, log, `query="INSERT INTO memo (id, content) VALUES (?, ?)" args="[1 <string len=9>]" route=/memos.api.v2.MemoService/ListMemos`)
+	require.Contains(t, log, `query="SELECT content FROM memo WHERE id = ?"`)
+	require.NotContains(t, log, "my secret")
+	require.Contains(t, log, `msg="Too many queries in request" route=/memos.api.v2.MemoService/ListMemos queries=3`)
+}
+
+func TestSanitizeArgs(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: int64(7)},
+		{Ordinal: 2, Value: "password"},
+		{Ordinal: 3, Value: []byte{1, 2}},
+		{Ordinal: 4, Value: nil},
+		{Ordinal: 5, Value: true},
+	}
+	require.Equal(t, []string{"7", "<string len=8>", "<bytes len=2>", "NULL", "true"}, sanitizeArgs(args))
+}
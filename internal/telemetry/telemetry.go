@@ -0,0 +1,110 @@
+// Package telemetry exports the traces and metrics of the server with OpenTelemetry.
+// Nothing is exported unless the OTLP endpoint is configured, the instrumentation is a no-op then.
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/usememos/memos/server/profile"
+)
+
+const (
+	// ServiceName is the default name of the service of the exported telemetry, OTEL_SERVICE_NAME overrides it.
+	ServiceName = "memos"
+	// instrumentationName is the name of the tracer of the spans started by the server itself.
+	instrumentationName = "github.com/usememos/memos"
+	// metricInterval is the interval of exporting the metrics.
+	metricInterval = 30 * time.Second
+)
+
+// Setup registers the global providers exporting the telemetry to the OTLP endpoint of the profile,
+// and sets the limits of the queries logged, which are logged even if the telemetry isn't exported.
+// The returned function flushes and stops the exporters, it must be called on shutdown.
+// The exporters also read the standard OTEL_EXPORTER_OTLP_* environment variables, e.g. the headers of the endpoint.
+func Setup(ctx context.Context, profile *profile.Profile) (func(context.Context) error, error) {
+	SetQueryLimits(profile.SlowQueryThreshold, profile.MaxRequestQueries)
+	if profile.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(profile.Version),
+		attribute.String("memos.mode", profile.Mode),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create resource")
+	}
+	// The attributes of OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME take precedence.
+	if res, err = resource.Merge(res, resource.Environment()); err != nil {
+		return nil, errors.Wrap(err, "failed to merge resource of environment")
+	}
+	// The http scheme of the endpoint disables TLS.
+	traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(profile.OTLPEndpoint))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create trace exporter")
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(profile.OTLPEndpoint))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create metric exporter")
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(profile.OTLPSampleRatio))),
+	)
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(metricInterval))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if err := runtime.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start runtime metrics")
+	}
+
+	return func(ctx context.Context) error {
+		err := tracerProvider.Shutdown(ctx)
+		if meterErr := meterProvider.Shutdown(ctx); err == nil {
+			err = meterErr
+		}
+		return err
+	}, nil
+}
+
+// Meter returns the meter of the metrics recorded by the server itself, they're exported only when the telemetry is.
+func Meter() metric.Meter {
+	return otel.Meter(instrumentationName)
+}
+
+// TraceJob runs a run of a background job in a span named after the job, with the error of the run recorded.
+// The span is recorded only when the telemetry is exported.
+func TraceJob(ctx context.Context, name string, run func(ctx context.Context) error) error {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "job."+name, trace.WithAttributes(attribute.String("memos.job", name)))
+	defer span.End()
+	err := run(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
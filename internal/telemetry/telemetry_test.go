@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/usememos/memos/server/profile"
+)
+
+func TestTraceJob(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tracerProvider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	err := TraceJob(context.Background(), "resource_gc", func(ctx context.Context) error {
+		require.True(t, trace.SpanContextFromContext(ctx).IsValid())
+		return nil
+	})
+	require.NoError(t, err)
+	err = TraceJob(context.Background(), "highlight_sync", func(context.Context) error {
+		return errors.New("unavailable")
+	})
+	require.EqualError(t, err, "unavailable")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "job.resource_gc", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, "job.highlight_sync", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), &profile.Profile{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
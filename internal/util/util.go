@@ -29,6 +29,27 @@ func HasPrefixes(src string, prefixes ...string) bool {
 	return false
 }
 
+// NegotiateEncoding returns the first of the content encodings which is accepted by the Accept-Encoding header,
+// empty if none is. The encodings of q=0 are refused.
+func NegotiateEncoding(acceptEncoding string, encodings ...string) string {
+	accepted := map[string]bool{}
+	for _, value := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(value, ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err != nil || weight == 0 {
+				continue
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	for _, encoding := range encodings {
+		if accepted[encoding] {
+			return encoding
+		}
+	}
+	return ""
+}
+
 // ValidateEmail validates the email.
 func ValidateEmail(email string) bool {
 	if _, err := mail.ParseAddress(email); err != nil {
@@ -29,3 +29,33 @@ func TestValidateEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{
+			acceptEncoding: "gzip, deflate, br",
+			want:           "br",
+		},
+		{
+			acceptEncoding: "gzip;q=1.0, br;q=0",
+			want:           "gzip",
+		},
+		{
+			acceptEncoding: "identity",
+			want:           "",
+		},
+		{
+			acceptEncoding: "",
+			want:           "",
+		},
+	}
+	for _, test := range tests {
+		result := NegotiateEncoding(test.acceptEncoding, "br", "gzip")
+		if result != test.want {
+			t.Errorf("Negotiate encoding %s: got result %q, want %q.", test.acceptEncoding, result, test.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package workerpool runs the outgoing deliveries, e.g. of the webhooks and the notifications, on a fixed number of
+// workers with a limit of the deliveries to each destination at once, so a slow destination doesn't hold up the others
+// or pile up the goroutines blocked on it.
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// queueSizePerWorker is the number of the pending tasks of each worker, after which Submit blocks.
+const queueSizePerWorker = 64
+
+// ErrStopped is returned for the tasks which aren't run since the pool is stopped.
+var ErrStopped = errors.New("worker pool is stopped")
+
+type task struct {
+	ctx         context.Context
+	destination string
+	run         func(ctx context.Context) error
+	// done receives the error of the task, it's buffered so the caller doesn't have to wait for it.
+	done chan error
+}
+
+// Pool runs the submitted tasks on its workers, at most destinationLimit of the tasks of a destination at once.
+type Pool struct {
+	destinationLimit int
+	timeout          time.Duration
+	queueSize        int
+
+	mutex sync.Mutex
+	// cond is broadcast once a task is queued, started or finished, or the pool is stopped.
+	cond    *sync.Cond
+	pending []*task
+	running map[string]int
+	stopped bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New starts the pool of the workers. Each task is canceled after the timeout, 0 means no timeout.
+func New(workers, destinationLimit int, timeout time.Duration) *Pool {
+	workers, destinationLimit = max(workers, 1), max(destinationLimit, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		destinationLimit: destinationLimit,
+		timeout:          timeout,
+		queueSize:        workers * queueSizePerWorker,
+		running:          map[string]int{},
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	p.cond = sync.NewCond(&p.mutex)
+	for range workers {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues the task of the destination, e.g. the host of a webhook URL, and returns the channel receiving its error.
+// It blocks while the queue is full, until ctx is done. The task is run with the values of ctx, but it isn't canceled
+// with ctx, since the caller may not wait for it; it's canceled after the timeout of the pool or once the pool is stopped.
+func (p *Pool) Submit(ctx context.Context, destination string, run func(ctx context.Context) error) <-chan error {
+	done := make(chan error, 1)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	// The waiting submitters are woken up once ctx is done, as they wait on the cond.
+	stop := context.AfterFunc(ctx, func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		p.cond.Broadcast()
+	})
+	defer stop()
+	for len(p.pending) >= p.queueSize && !p.stopped && ctx.Err() == nil {
+		p.cond.Wait()
+	}
+	switch {
+	case p.stopped:
+		done <- ErrStopped
+	case ctx.Err() != nil:
+		done <- ctx.Err()
+	default:
+		p.pending = append(p.pending, &task{ctx: ctx, destination: destination, run: run, done: done})
+		p.cond.Broadcast()
+	}
+	return done
+}
+
+// Stop cancels the running tasks and fails the pending ones with ErrStopped, and waits for the workers to return.
+func (p *Pool) Stop() {
+	p.mutex.Lock()
+	p.stopped = true
+	for _, task := range p.pending {
+		task.done <- ErrStopped
+	}
+	p.pending = nil
+	p.cancel()
+	p.cond.Broadcast()
+	p.mutex.Unlock()
+	p.wg.Wait()
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for {
+		p.mutex.Lock()
+		task := p.next()
+		for task == nil && !p.stopped {
+			p.cond.Wait()
+			task = p.next()
+		}
+		if task == nil {
+			p.mutex.Unlock()
+			return
+		}
+		p.running[task.destination]++
+		p.cond.Broadcast()
+		p.mutex.Unlock()
+
+		task.done <- p.run(task)
+
+		p.mutex.Lock()
+		if p.running[task.destination]--; p.running[task.destination] == 0 {
+			delete(p.running, task.destination)
+		}
+		p.cond.Broadcast()
+		p.mutex.Unlock()
+	}
+}
+
+// next removes and returns the first pending task whose destination is under the limit, nil if there's none.
+func (p *Pool) next() *task {
+	for i, task := range p.pending {
+		if p.running[task.destination] < p.destinationLimit {
+			p.pending = append(p.pending[:i], p.pending[i+1:]...)
+			return task
+		}
+	}
+	return nil
+}
+
+func (p *Pool) run(task *task) error {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(task.ctx))
+	defer cancel()
+	if p.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+	return task.run(ctx)
+}
@@ -0,0 +1,73 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolDestinationLimit(t *testing.T) {
+	pool := New(4, 1, time.Second)
+	defer pool.Stop()
+
+	var running, maxRunning atomic.Int32
+	results := []<-chan error{}
+	for range 4 {
+		results = append(results, pool.Submit(context.Background(), "slow.example.com", func(context.Context) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				previous := maxRunning.Load()
+				if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}))
+	}
+	// The tasks of the other destinations aren't held up by the slow one.
+	start := time.Now()
+	require.NoError(t, <-pool.Submit(context.Background(), "fast.example.com", func(context.Context) error {
+		return nil
+	}))
+	require.Less(t, time.Since(start), 50*time.Millisecond)
+	for _, result := range results {
+		require.NoError(t, <-result)
+	}
+	require.Equal(t, int32(1), maxRunning.Load())
+}
+
+func TestPoolTimeout(t *testing.T) {
+	pool := New(1, 1, 10*time.Millisecond)
+	defer pool.Stop()
+
+	err := <-pool.Submit(context.Background(), "example.com", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPoolStop(t *testing.T) {
+	pool := New(1, 1, 0)
+	started := make(chan struct{})
+	running := pool.Submit(context.Background(), "example.com", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	pending := pool.Submit(context.Background(), "example.com", func(context.Context) error {
+		return nil
+	})
+	pool.Stop()
+	require.ErrorIs(t, <-running, context.Canceled)
+	require.ErrorIs(t, <-pending, ErrStopped)
+	require.ErrorIs(t, <-pool.Submit(context.Background(), "example.com", func(context.Context) error {
+		return nil
+	}), ErrStopped)
+}
@@ -0,0 +1,203 @@
+// Package bluesky is a client of the AT Protocol posting to the Bluesky account of an app password.
+package bluesky
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultServiceURL is the server of the accounts hosted by Bluesky.
+	DefaultServiceURL = "https://bsky.social"
+	// MaxPostLength is the number of the characters of a post.
+	MaxPostLength = 300
+	// MaxImages is the number of the images of a post.
+	MaxImages = 4
+	// MaxImageSize is the size of an image of a post.
+	MaxImageSize = 1000000
+)
+
+// timeout is the timeout of the requests to the server, the uploads of the images included.
+var timeout = time.Minute
+
+// Facet is the rich text of a range of a post, the range is of the bytes of the UTF-8 text.
+type Facet struct {
+	Index    FacetIndex      `json:"index"`
+	Features []*FacetFeature `json:"features"`
+}
+
+type FacetIndex struct {
+	ByteStart int `json:"byteStart"`
+	ByteEnd   int `json:"byteEnd"`
+}
+
+type FacetFeature struct {
+	Type string `json:"$type"`
+	URI  string `json:"uri"`
+}
+
+// NewLinkFacet returns the facet of the link in the range of the text.
+func NewLinkFacet(byteStart, byteEnd int, uri string) *Facet {
+	return &Facet{
+		Index:    FacetIndex{ByteStart: byteStart, ByteEnd: byteEnd},
+		Features: []*FacetFeature{{Type: "app.bsky.richtext.facet#link", URI: uri}},
+	}
+}
+
+// Image is an uploaded image embedded in a post.
+type Image struct {
+	Alt string `json:"alt"`
+	// Blob is the reference of the uploaded blob, which is posted as it's returned.
+	Blob json.RawMessage `json:"image"`
+}
+
+// Post is a created post.
+type Post struct {
+	URI string `json:"uri"`
+	CID string `json:"cid"`
+}
+
+// Client is the client of the server of an account, authorized by a session of its app password.
+type Client struct {
+	serviceURL  string
+	identifier  string
+	appPassword string
+
+	accessJwt string
+	did       string
+}
+
+// NewClient returns the client of the account of the identifier, which is its handle or email.
+// The service URL is the server hosting the account, DefaultServiceURL if it's empty.
+func NewClient(serviceURL, identifier, appPassword string) *Client {
+	if serviceURL == "" {
+		serviceURL = DefaultServiceURL
+	}
+	return &Client{
+		serviceURL:  strings.TrimSuffix(serviceURL, "/"),
+		identifier:  identifier,
+		appPassword: appPassword,
+	}
+}
+
+// ValidateServiceURL returns an error if the URL isn't the root of a server.
+func ValidateServiceURL(serviceURL string) error {
+	u, err := url.Parse(serviceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the service URL must be an http or https URL")
+	}
+	return nil
+}
+
+// CreateSession signs in the account, the other methods require a session.
+func (c *Client) CreateSession(ctx context.Context) error {
+	payload, err := json.Marshal(map[string]string{
+		"identifier": c.identifier,
+		"password":   c.appPassword,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal session")
+	}
+	session := struct {
+		AccessJwt string `json:"accessJwt"`
+		DID       string `json:"did"`
+	}{}
+	if err := c.call(ctx, "com.atproto.server.createSession", bytes.NewReader(payload), "application/json", &session); err != nil {
+		return err
+	}
+	c.accessJwt = session.AccessJwt
+	c.did = session.DID
+	return nil
+}
+
+// UploadImage uploads the image, which is embedded in a post with its alt text.
+func (c *Client) UploadImage(ctx context.Context, contentType string, data []byte, alt string) (*Image, error) {
+	if len(data) > MaxImageSize {
+		return nil, errors.Errorf("the image is larger than %d bytes", MaxImageSize)
+	}
+	result := struct {
+		Blob json.RawMessage `json:"blob"`
+	}{}
+	if err := c.call(ctx, "com.atproto.repo.uploadBlob", bytes.NewReader(data), contentType, &result); err != nil {
+		return nil, err
+	}
+	return &Image{Alt: alt, Blob: result.Blob}, nil
+}
+
+// CreatePost creates the post of the text with its facets and images.
+func (c *Client) CreatePost(ctx context.Context, text string, facets []*Facet, images []*Image) (*Post, error) {
+	if c.did == "" {
+		return nil, errors.New("the session isn't created")
+	}
+	record := map[string]any{
+		"$type":     "app.bsky.feed.post",
+		"text":      text,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	if len(facets) > 0 {
+		record["facets"] = facets
+	}
+	if len(images) > 0 {
+		record["embed"] = map[string]any{
+			"$type":  "app.bsky.embed.images",
+			"images": images,
+		}
+	}
+	payload, err := json.Marshal(map[string]any{
+		"repo":       c.did,
+		"collection": "app.bsky.feed.post",
+		"record":     record,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal post")
+	}
+	post := &Post{}
+	if err := c.call(ctx, "com.atproto.repo.createRecord", bytes.NewReader(payload), "application/json", post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// call calls the procedure of the XRPC API.
+func (c *Client) call(ctx context.Context, method string, body io.Reader, contentType string, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.serviceURL+"/xrpc/"+method, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Content-Type", contentType)
+	if c.accessJwt != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessJwt)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", method)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiError := struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}{}
+		_ = json.Unmarshal(respBody, &apiError)
+		return errors.Errorf("failed to call %s, status %d: %s %s", method, resp.StatusCode, apiError.Error, apiError.Message)
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response of %s", method)
+	}
+	return nil
+}
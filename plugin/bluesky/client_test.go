@@ -0,0 +1,77 @@
+package bluesky
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var record map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/xrpc/com.atproto.server.createSession", func(w http.ResponseWriter, r *http.Request) {
+		session := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&session))
+		require.Equal(t, map[string]string{"identifier": "bob.bsky.social", "password": "app-password"}, session)
+		_, _ = io.WriteString(w, `{"accessJwt":"jwt","did":"did:plc:bob"}`)
+	})
+	mux.HandleFunc("/xrpc/com.atproto.repo.uploadBlob", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer jwt", r.Header.Get("Authorization"))
+		require.Equal(t, "image/png", r.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(r.Body)
+		require.Equal(t, "png", string(data))
+		_, _ = io.WriteString(w, `{"blob":{"$type":"blob","ref":{"$link":"cid"},"mimeType":"image/png","size":3}}`)
+	})
+	mux.HandleFunc("/xrpc/com.atproto.repo.createRecord", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer jwt", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		_, _ = io.WriteString(w, `{"uri":"at://did:plc:bob/app.bsky.feed.post/1","cid":"cid"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL+"/", "bob.bsky.social", "app-password")
+	_, err := client.CreatePost(ctx, "Hello", nil, nil)
+	require.Error(t, err)
+	require.NoError(t, client.CreateSession(ctx))
+	image, err := client.UploadImage(ctx, "image/png", []byte("png"), "cat.png")
+	require.NoError(t, err)
+	post, err := client.CreatePost(ctx, "Hello https://example.com", []*Facet{NewLinkFacet(6, 25, "https://example.com")}, []*Image{image})
+	require.NoError(t, err)
+	require.Equal(t, "at://did:plc:bob/app.bsky.feed.post/1", post.URI)
+
+	require.Equal(t, "did:plc:bob", record["repo"])
+	require.Equal(t, "app.bsky.feed.post", record["collection"])
+	postRecord := record["record"].(map[string]any)
+	require.Equal(t, "Hello https://example.com", postRecord["text"])
+	require.Equal(t, []any{map[string]any{
+		"index":    map[string]any{"byteStart": float64(6), "byteEnd": float64(25)},
+		"features": []any{map[string]any{"$type": "app.bsky.richtext.facet#link", "uri": "https://example.com"}},
+	}}, postRecord["facets"])
+	require.Equal(t, map[string]any{
+		"$type": "app.bsky.embed.images",
+		"images": []any{map[string]any{
+			"alt":   "cat.png",
+			"image": map[string]any{"$type": "blob", "ref": map[string]any{"$link": "cid"}, "mimeType": "image/png", "size": float64(3)},
+		}},
+	}, postRecord["embed"])
+}
+
+func TestClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":"AuthenticationRequired","message":"Invalid identifier or password"}`)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL, "bob", "invalid").CreateSession(context.Background())
+	require.ErrorContains(t, err, "Invalid identifier or password")
+	_, err = NewClient(server.URL, "bob", "").UploadImage(context.Background(), "image/png", make([]byte, MaxImageSize+1), "")
+	require.ErrorContains(t, err, "larger than")
+}
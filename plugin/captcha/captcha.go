@@ -0,0 +1,80 @@
+// Package captcha verifies the responses of the captchas solved by the visitors, Cloudflare Turnstile and hCaptcha.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// timeout is the timeout of the requests to the captcha services.
+var timeout = 10 * time.Second
+
+const (
+	// ProviderTurnstile is Cloudflare Turnstile, see https://developers.cloudflare.com/turnstile/get-started/server-side-validation/.
+	ProviderTurnstile = "turnstile"
+	// ProviderHCaptcha is hCaptcha, see https://docs.hcaptcha.com/#verify-the-user-response-server-side.
+	ProviderHCaptcha = "hcaptcha"
+)
+
+// Providers are the supported captcha providers.
+var Providers = []string{ProviderTurnstile, ProviderHCaptcha}
+
+// verifyURLs are the endpoints verifying the responses of the providers.
+var verifyURLs = map[string]string{
+	ProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	ProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+}
+
+// ErrInvalidResponse is returned when the captcha service rejects the response.
+var ErrInvalidResponse = errors.New("invalid captcha response")
+
+// Verify verifies the response of the captcha solved by the visitor of the IP address with the secret key of the site.
+// The IP address is optional.
+func Verify(ctx context.Context, provider, secretKey, response, remoteIP string) error {
+	verifyURL, ok := verifyURLs[provider]
+	if !ok {
+		return errors.Errorf("unsupported captcha provider %q", provider)
+	}
+	if response == "" {
+		return ErrInvalidResponse
+	}
+	form := url.Values{}
+	form.Set("secret", secretKey)
+	form.Set("response", response)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post to %s", provider)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("%s responded with status %d", provider, resp.StatusCode)
+	}
+	result := struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+	if !result.Success {
+		return errors.Wrapf(ErrInvalidResponse, "%s", strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
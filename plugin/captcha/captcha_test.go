@@ -0,0 +1,35 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "203.0.113.1", r.PostForm.Get("remoteip"))
+		result := map[string]any{"success": r.PostForm.Get("response") == "solved"}
+		if r.PostForm.Get("response") != "solved" {
+			result["error-codes"] = []string{"invalid-input-response"}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(result))
+	}))
+	defer server.Close()
+	verifyURLs[ProviderTurnstile] = server.URL
+
+	ctx := context.Background()
+	require.NoError(t, Verify(ctx, ProviderTurnstile, "secret", "solved", "203.0.113.1"))
+	err := Verify(ctx, ProviderTurnstile, "secret", "guessed", "203.0.113.1")
+	require.True(t, errors.Is(err, ErrInvalidResponse))
+	require.Contains(t, err.Error(), "invalid-input-response")
+	require.True(t, errors.Is(Verify(ctx, ProviderTurnstile, "secret", "", ""), ErrInvalidResponse))
+	require.Error(t, Verify(ctx, "recaptcha", "secret", "solved", ""))
+}
@@ -0,0 +1,89 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// CreateMessage sends the message to the channel, as a reply to the message if replyToID isn't empty.
+func (b *Bot) CreateMessage(ctx context.Context, channelID, content, replyToID string) (*Message, error) {
+	payload := map[string]any{
+		"content": content,
+		// Don't ping the users mentioned in the memos.
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	}
+	if replyToID != "" {
+		payload["message_reference"] = map[string]string{"message_id": replyToID}
+	}
+	message := &Message{}
+	if err := b.request(ctx, http.MethodPost, "/channels/"+channelID+"/messages", payload, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// GetMessage returns the message in the channel.
+func (b *Bot) GetMessage(ctx context.Context, channelID, messageID string) (*Message, error) {
+	message := &Message{}
+	if err := b.request(ctx, http.MethodGet, "/channels/"+channelID+"/messages/"+messageID, nil, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// AddReaction reacts to the message with the unicode emoji.
+func (b *Bot) AddReaction(ctx context.Context, channelID, messageID, emoji string) error {
+	return b.request(ctx, http.MethodPut, "/channels/"+channelID+"/messages/"+messageID+"/reactions/"+url.PathEscape(emoji)+"/@me", nil, nil)
+}
+
+func (b *Bot) request(ctx context.Context, method, path string, payload, result any) error {
+	token := b.handler.BotToken(ctx)
+	if token == "" {
+		return ErrInvalidToken
+	}
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal payload")
+		}
+		body = bytes.NewReader(data)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, apiURL+path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Authorization", "Bot "+token)
+	req.Header.Set("User-Agent", "DiscordBot (https://github.com/usememos/memos, 1.0)")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to request %s", path)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("failed to request %s, status %d: %s", path, resp.StatusCode, respBody)
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response body")
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Package discord implements a Discord bot, which receives the messages and the reactions in the guilds
+// from the gateway, and sends the messages with the REST API.
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+type Handler interface {
+	BotToken(ctx context.Context) string
+	MessageHandle(ctx context.Context, bot *Bot, message *Message) error
+	ReactionHandle(ctx context.Context, bot *Bot, reaction *Reaction) error
+}
+
+type Bot struct {
+	handler Handler
+}
+
+// NewBotWithHandler create a discord bot with specified handler.
+func NewBotWithHandler(h Handler) *Bot {
+	return &Bot{handler: h}
+}
+
+var (
+	apiURL     = "https://discord.com/api/v10"
+	gatewayURL = "wss://gateway.discord.gg/?v=10&encoding=json"
+	// timeout is the timeout of the requests to the REST API.
+	timeout = 10 * time.Second
+)
+
+const (
+	noTokenWait  = 30 * time.Second
+	errRetryWait = 10 * time.Second
+	// tokenCheckInterval is the interval of checking if the token is changed, so the bot reconnects with the new token.
+	tokenCheckInterval = 30 * time.Second
+	// maxPayloadSize is the max size of the gateway payloads, the READY payload is large for the bots in many guilds.
+	maxPayloadSize = 8 << 20
+
+	// intents are the events received from the gateway: GUILDS, GUILD_MESSAGES, GUILD_MESSAGE_REACTIONS and MESSAGE_CONTENT.
+	intents = 1<<0 | 1<<9 | 1<<10 | 1<<15
+)
+
+// Gateway opcodes, see https://discord.com/developers/docs/topics/opcodes-and-status-codes.
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+)
+
+var ErrInvalidToken = errors.New("token is invalid")
+
+type gatewayPayload struct {
+	Op       int             `json:"op"`
+	Data     json.RawMessage `json:"d,omitempty"`
+	Sequence *int64          `json:"s,omitempty"`
+	Type     string          `json:"t,omitempty"`
+}
+
+// Start connects to the gateway and calls the handler with the events, until the context is done.
+// The bot reconnects on errors and when the token is changed.
+func (b *Bot) Start(ctx context.Context) {
+	for ctx.Err() == nil {
+		token := b.handler.BotToken(ctx)
+		if token == "" {
+			sleep(ctx, noTokenWait)
+			continue
+		}
+		if err := b.connect(ctx, token); err != nil && ctx.Err() == nil {
+			slog.Warn("Discord gateway disconnected", slog.Any("err", err))
+			sleep(ctx, errRetryWait)
+		}
+	}
+}
+
+// connect runs a gateway session, it returns nil if the session should be restarted right away.
+func (b *Bot) connect(ctx context.Context, token string) error {
+	conn, _, err := websocket.Dial(ctx, gatewayURL, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+	conn.SetReadLimit(maxPayloadSize)
+
+	hello := &gatewayPayload{}
+	if err := readPayload(ctx, conn, hello); err != nil {
+		return err
+	}
+	if hello.Op != opHello {
+		return errors.New("unexpected first gateway payload")
+	}
+	helloData := struct {
+		HeartbeatInterval int64 `json:"heartbeat_interval"`
+	}{}
+	if err := json.Unmarshal(hello.Data, &helloData); err != nil {
+		return err
+	}
+	if err := writePayload(ctx, conn, opIdentify, map[string]any{
+		"token":   token,
+		"intents": intents,
+		"properties": map[string]string{
+			"os":      "linux",
+			"browser": "memos",
+			"device":  "memos",
+		},
+	}); err != nil {
+		return err
+	}
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var sequence *int64
+	sequences := make(chan *int64, 1)
+	go b.keepAlive(sessionCtx, conn, token, time.Duration(helloData.HeartbeatInterval)*time.Millisecond, sequences)
+
+	for {
+		payload := &gatewayPayload{}
+		if err := readPayload(sessionCtx, conn, payload); err != nil {
+			return err
+		}
+		switch payload.Op {
+		case opDispatch:
+			if payload.Sequence != nil {
+				sequence = payload.Sequence
+				select {
+				case sequences <- sequence:
+				default:
+					<-sequences
+					sequences <- sequence
+				}
+			}
+			b.dispatch(sessionCtx, payload)
+		case opHeartbeat:
+			if err := writePayload(sessionCtx, conn, opHeartbeat, sequence); err != nil {
+				return err
+			}
+		case opReconnect, opInvalidSession:
+			return nil
+		}
+	}
+}
+
+// keepAlive sends the heartbeats with the last sequence,
+// and closes the connection if the token is changed.
+func (b *Bot) keepAlive(ctx context.Context, conn *websocket.Conn, token string, interval time.Duration, sequences <-chan *int64) {
+	if interval <= 0 {
+		interval = 45 * time.Second
+	}
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+	tokenCheck := time.NewTicker(tokenCheckInterval)
+	defer tokenCheck.Stop()
+	var sequence *int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sequence = <-sequences:
+		case <-heartbeat.C:
+			if err := writePayload(ctx, conn, opHeartbeat, sequence); err != nil {
+				conn.Close(websocket.StatusGoingAway, "heartbeat failed")
+				return
+			}
+		case <-tokenCheck.C:
+			if b.handler.BotToken(ctx) != token {
+				conn.Close(websocket.StatusNormalClosure, "token changed")
+				return
+			}
+		}
+	}
+}
+
+func (b *Bot) dispatch(ctx context.Context, payload *gatewayPayload) {
+	switch payload.Type {
+	case "MESSAGE_CREATE":
+		message := &Message{}
+		if err := json.Unmarshal(payload.Data, message); err != nil {
+			slog.Warn("Failed to unmarshal discord message", slog.Any("err", err))
+			return
+		}
+		if message.Author.Bot {
+			return
+		}
+		if err := b.handler.MessageHandle(ctx, b, message); err != nil {
+			slog.Error("Failed to handle discord message", slog.Any("err", err))
+		}
+	case "MESSAGE_REACTION_ADD":
+		reaction := &Reaction{}
+		if err := json.Unmarshal(payload.Data, reaction); err != nil {
+			slog.Warn("Failed to unmarshal discord reaction", slog.Any("err", err))
+			return
+		}
+		if err := b.handler.ReactionHandle(ctx, b, reaction); err != nil {
+			slog.Error("Failed to handle discord reaction", slog.Any("err", err))
+		}
+	}
+}
+
+func readPayload(ctx context.Context, conn *websocket.Conn, payload *gatewayPayload) error {
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, payload)
+}
+
+func writePayload(ctx context.Context, conn *websocket.Conn, op int, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(&gatewayPayload{Op: op, Data: raw})
+	if err != nil {
+		return err
+	}
+	return conn.Write(ctx, websocket.MessageText, payload)
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+type testHandler struct {
+	messages  chan *Message
+	reactions chan *Reaction
+}
+
+func (*testHandler) BotToken(_ context.Context) string {
+	return "token"
+}
+
+func (h *testHandler) MessageHandle(_ context.Context, _ *Bot, message *Message) error {
+	h.messages <- message
+	return nil
+}
+
+func (h *testHandler) ReactionHandle(_ context.Context, _ *Bot, reaction *Reaction) error {
+	h.reactions <- reaction
+	return nil
+}
+
+func TestGateway(t *testing.T) {
+	identified := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		ctx := r.Context()
+		write := func(payload string) {
+			_ = conn.Write(ctx, websocket.MessageText, []byte(payload))
+		}
+		write(`{"op":10,"d":{"heartbeat_interval":45000}}`)
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+		identify := &gatewayPayload{}
+		_ = json.Unmarshal(data, identify)
+		d := map[string]any{}
+		_ = json.Unmarshal(identify.Data, &d)
+		identified <- d
+		write(`{"op":0,"s":1,"t":"MESSAGE_CREATE","d":{"id":"1","channel_id":"2","author":{"id":"9","bot":true},"content":"from a bot"}}`)
+		write(`{"op":0,"s":2,"t":"MESSAGE_CREATE","d":{"id":"3","channel_id":"2","guild_id":"4","author":{"id":"5"},"content":"!memo hello"}}`)
+		write(`{"op":0,"s":3,"t":"MESSAGE_REACTION_ADD","d":{"user_id":"5","channel_id":"2","message_id":"3","guild_id":"4","emoji":{"name":"📝"}}}`)
+		_, _, _ = conn.Read(ctx)
+	}))
+	defer server.Close()
+	gatewayURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	handler := &testHandler{messages: make(chan *Message, 2), reactions: make(chan *Reaction, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go NewBotWithHandler(handler).Start(ctx)
+
+	select {
+	case d := <-identified:
+		require.Equal(t, "token", d["token"])
+		require.EqualValues(t, intents, d["intents"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("bot didn't identify")
+	}
+	select {
+	case message := <-handler.messages:
+		require.Equal(t, "!memo hello", message.Content)
+		require.Equal(t, "https://discord.com/channels/4/2/3", message.GetLink())
+	case <-time.After(5 * time.Second):
+		t.Fatal("message wasn't handled")
+	}
+	select {
+	case reaction := <-handler.reactions:
+		require.Equal(t, "📝", reaction.Emoji.Name)
+		require.Equal(t, "3", reaction.MessageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reaction wasn't handled")
+	}
+}
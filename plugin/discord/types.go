@@ -0,0 +1,43 @@
+package discord
+
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+}
+
+type Message struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	// GuildID is empty for the direct messages.
+	GuildID string `json:"guild_id"`
+	Author  User   `json:"author"`
+	Content string `json:"content"`
+	// ReferencedMessage is the message replied by the message.
+	ReferencedMessage *Message `json:"referenced_message"`
+}
+
+// GetLink returns the link to the message.
+func (m *Message) GetLink() string {
+	guildID := m.GuildID
+	if guildID == "" {
+		guildID = "@me"
+	}
+	return "https://discord.com/channels/" + guildID + "/" + m.ChannelID + "/" + m.ID
+}
+
+type Emoji struct {
+	// ID is empty for the unicode emojis.
+	ID string `json:"id"`
+	// Name is the unicode emoji or the name of the custom emoji.
+	Name string `json:"name"`
+}
+
+// Reaction is the payload of the MESSAGE_REACTION_ADD event.
+type Reaction struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+	GuildID   string `json:"guild_id"`
+	Emoji     Emoji  `json:"emoji"`
+}
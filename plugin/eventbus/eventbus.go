@@ -0,0 +1,75 @@
+// Package eventbus publishes the events to an external message system, NATS or Redis Streams.
+package eventbus
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// dialTimeout is the timeout for connecting to the message system.
+const dialTimeout = 10 * time.Second
+
+type Publisher interface {
+	// Publish publishes the data of the event of the type.
+	Publish(ctx context.Context, eventType string, data []byte) error
+	// Close closes the connection to the message system.
+	Close() error
+}
+
+// NewPublisher returns the publisher of the URL, which is in the format of `nats://[user:password@]host:port`
+// or `redis://[user:password@]host:port[/db][?maxlen=N]`. The schemes tls:// and rediss:// connect over TLS.
+// The subject is the prefix of the subjects of NATS, e.g. memos.memo.created, and the key of the stream of Redis.
+func NewPublisher(rawURL, subject string) (Publisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid event bus URL")
+	}
+	if subject == "" {
+		return nil, errors.New("event bus subject is required")
+	}
+	switch u.Scheme {
+	case "nats", "tls":
+		return newNATSPublisher(u, subject), nil
+	case "redis", "rediss":
+		return newRedisPublisher(u, subject)
+	default:
+		return nil, errors.Errorf("unsupported event bus scheme %q", u.Scheme)
+	}
+}
+
+// dial connects to the host of the URL, the default port is used if the URL doesn't have one.
+func dial(ctx context.Context, u *url.URL, defaultPort string, useTLS bool) (net.Conn, error) {
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if useTLS {
+		return upgradeTLS(ctx, conn, u.Hostname())
+	}
+	return conn, nil
+}
+
+func upgradeTLS(ctx context.Context, conn net.Conn, serverName string) (net.Conn, error) {
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to handshake TLS")
+	}
+	return tlsConn, nil
+}
+
+// setDeadline sets the deadline of the connection to the deadline of the context, or clears it.
+func setDeadline(ctx context.Context, conn net.Conn) error {
+	deadline, _ := ctx.Deadline()
+	return conn.SetDeadline(deadline)
+}
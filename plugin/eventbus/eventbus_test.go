@@ -0,0 +1,95 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serve accepts a connection on a local listener, and handles it with the handler in the background.
+func serve(t *testing.T, handle func(conn net.Conn, reader *bufio.Reader)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn, bufio.NewReader(conn))
+	}()
+	return listener.Addr().String()
+}
+
+func TestNATSPublisher(t *testing.T) {
+	received := make(chan string, 1)
+	address := serve(t, func(conn net.Conn, reader *bufio.Reader) {
+		_, _ = io.WriteString(conn, "INFO {\"server_id\":\"test\"}\r\n")
+		connect, _ := reader.ReadString('\n')
+		if !strings.Contains(connect, `"user":"bob","pass":"secret"`) {
+			_, _ = io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+			return
+		}
+		_, _ = reader.ReadString('\n')
+		_, _ = io.WriteString(conn, "PONG\r\n")
+		pub, _ := reader.ReadString('\n')
+		payload, _ := reader.ReadString('\n')
+		received <- pub + payload
+	})
+
+	publisher, err := NewPublisher("nats://bob:secret@"+address, "memos")
+	require.NoError(t, err)
+	defer publisher.Close()
+	require.NoError(t, publisher.Publish(context.Background(), "memo.created", []byte(`{"memoId":1}`)))
+	require.Equal(t, "PUB memos.memo.created 12\r\n{\"memoId\":1}\r\n", <-received)
+}
+
+func TestRedisPublisher(t *testing.T) {
+	received := make(chan []string, 1)
+	address := serve(t, func(conn net.Conn, reader *bufio.Reader) {
+		for {
+			args := []string{}
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			count := 0
+			for _, c := range strings.TrimSpace(line[1:]) {
+				count = count*10 + int(c-'0')
+			}
+			for i := 0; i < count; i++ {
+				_, _ = reader.ReadString('\n')
+				arg, _ := reader.ReadString('\n')
+				args = append(args, strings.TrimRight(arg, "\r\n"))
+			}
+			switch args[0] {
+			case "AUTH", "SELECT":
+				_, _ = io.WriteString(conn, "+OK\r\n")
+			case "XADD":
+				_, _ = io.WriteString(conn, "$15\r\n1700000000000-0\r\n")
+				received <- args
+			}
+		}
+	})
+
+	publisher, err := NewPublisher("redis://:secret@"+address+"/2?maxlen=100", "memos")
+	require.NoError(t, err)
+	defer publisher.Close()
+	require.NoError(t, publisher.Publish(context.Background(), "memo.created", []byte(`{"memoId":1}`)))
+	require.Equal(t, []string{"XADD", "memos", "MAXLEN", "~", "100", "*", "type", "memo.created", "data", `{"memoId":1}`}, <-received)
+}
+
+func TestNewPublisher(t *testing.T) {
+	_, err := NewPublisher("kafka://localhost:9092", "memos")
+	require.Error(t, err)
+	_, err = NewPublisher("redis://localhost/db", "memos")
+	require.Error(t, err)
+	_, err = NewPublisher("nats://localhost", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const natsDefaultPort = "4222"
+
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnectOptions struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// natsPublisher publishes the events to the subjects of NATS with the core protocol, there is no acknowledgement of the messages.
+// The connection is opened by the first event and reopened by the next event after it's lost.
+type natsPublisher struct {
+	url     *url.URL
+	subject string
+
+	// mutex guards the connection and the writes to it.
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+func newNATSPublisher(u *url.URL, subject string) *natsPublisher {
+	return &natsPublisher{
+		url:     u,
+		subject: subject,
+	}
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, eventType string, data []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	message := fmt.Sprintf("PUB %s.%s %d\r\n%s\r\n", p.subject, eventType, len(data), data)
+	// The message is retried once on a new connection, in case the connection was closed by the server.
+	for retry := 0; ; retry++ {
+		if p.conn == nil {
+			conn, err := p.connect(ctx)
+			if err != nil {
+				return errors.Wrap(err, "failed to connect to NATS")
+			}
+			p.conn = conn
+		}
+		if err := p.write(ctx, message); err == nil {
+			return nil
+		} else if retry > 0 {
+			return errors.Wrap(err, "failed to publish to NATS")
+		}
+	}
+}
+
+func (p *natsPublisher) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+// write writes to the connection, which is closed if the write fails. The caller must hold the mutex.
+func (p *natsPublisher) write(ctx context.Context, message string) error {
+	deadline, _ := ctx.Deadline()
+	if err := p.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	if _, err := p.conn.Write([]byte(message)); err != nil {
+		p.conn.Close()
+		p.conn = nil
+		return err
+	}
+	return nil
+}
+
+// connect opens the connection and waits for the server to accept it.
+// The server sends its info in plain text, then the connection is upgraded to TLS if it's required.
+func (p *natsPublisher) connect(ctx context.Context) (net.Conn, error) {
+	conn, err := dial(ctx, p.url, natsDefaultPort, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := setDeadline(ctx, conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to read server info")
+	}
+	infoJSON, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		conn.Close()
+		return nil, errors.Errorf("unexpected server info: %s", line)
+	}
+	info := &natsInfo{}
+	if err := json.Unmarshal([]byte(infoJSON), info); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to decode server info")
+	}
+	if p.url.Scheme == "tls" || info.TLSRequired {
+		if conn, err = upgradeTLS(ctx, conn, p.url.Hostname()); err != nil {
+			return nil, err
+		}
+		reader = bufio.NewReader(conn)
+	}
+
+	options := &natsConnectOptions{Name: "memos", Lang: "go"}
+	if p.url.User != nil {
+		if password, ok := p.url.User.Password(); ok {
+			options.User, options.Pass = p.url.User.Username(), password
+		} else {
+			options.AuthToken = p.url.User.Username()
+		}
+	}
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// The PONG of the PING confirms the connection is accepted, an error is sent otherwise.
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", optionsJSON); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to send connect")
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "failed to read reply")
+		}
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, errors.Errorf("connection refused: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go p.readLoop(conn, reader)
+	return conn, nil
+}
+
+// readLoop answers the pings of the server, which closes the connections not answering them.
+// The connection is closed when the server sends an error or the connection is lost.
+func (p *natsPublisher) readLoop(conn net.Conn, reader *bufio.Reader) {
+	defer func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		conn.Close()
+		if p.conn == conn {
+			p.conn = nil
+		}
+	}()
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "-ERR") {
+			return
+		}
+		if line == "PING" {
+			p.mutex.Lock()
+			if p.conn == conn {
+				err = p.write(context.Background(), "PONG\r\n")
+			}
+			p.mutex.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
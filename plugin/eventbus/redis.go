@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/redis"
+)
+
+// redisDefaultMaxLen is the approximate number of the events kept in the stream, the older events are trimmed.
+const redisDefaultMaxLen = 10000
+
+// redisPublisher adds the events to a stream of Redis, the fields of an entry are the type and the data of the event.
+type redisPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int
+}
+
+func newRedisPublisher(u *url.URL, stream string) (*redisPublisher, error) {
+	p := &redisPublisher{
+		stream: stream,
+		maxLen: redisDefaultMaxLen,
+	}
+	query := u.Query()
+	if maxLen := query.Get("maxlen"); maxLen != "" {
+		var err error
+		if p.maxLen, err = strconv.Atoi(maxLen); err != nil || p.maxLen < 0 {
+			return nil, errors.Errorf("invalid Redis stream max length %q", maxLen)
+		}
+	}
+	// The query of the stream isn't the one of the client.
+	clientURL := *u
+	query.Del("maxlen")
+	clientURL.RawQuery = query.Encode()
+	client, err := redis.NewClient(clientURL.String())
+	if err != nil {
+		return nil, err
+	}
+	p.client = client
+	return p, nil
+}
+
+func (p *redisPublisher) Publish(ctx context.Context, eventType string, data []byte) error {
+	args := []string{"XADD", p.stream}
+	// The length of 0 keeps all the events.
+	if p.maxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.Itoa(p.maxLen))
+	}
+	args = append(args, "*", "type", eventType, "data", string(data))
+	if _, err := p.client.Do(ctx, args...); err != nil {
+		return errors.Wrap(err, "failed to publish to Redis")
+	}
+	return nil
+}
+
+func (p *redisPublisher) Close() error {
+	return p.client.Close()
+}
@@ -0,0 +1,129 @@
+// Package gist is a client of the GitHub API managing the gists of the account of a personal access token.
+package gist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// baseURL is the URL of the GitHub API.
+	baseURL = "https://api.github.com"
+	// timeout is the timeout of the requests to the GitHub API.
+	timeout = 30 * time.Second
+)
+
+// ErrNotFound is returned when the gist doesn't exist, e.g. it's deleted on GitHub.
+var ErrNotFound = errors.New("gist not found")
+
+// File is a file of a gist.
+type File struct {
+	Content string `json:"content"`
+}
+
+// Gist is a gist of the account.
+type Gist struct {
+	ID          string           `json:"id"`
+	HTMLURL     string           `json:"html_url"`
+	Description string           `json:"description"`
+	Public      bool             `json:"public"`
+	Files       map[string]*File `json:"files"`
+}
+
+// Client is the client of the GitHub API authorized by a personal access token with the gist scope.
+type Client struct {
+	token string
+}
+
+func NewClient(token string) *Client {
+	return &Client{token: token}
+}
+
+// GetGist returns the gist of the id, or ErrNotFound if it doesn't exist.
+func (c *Client) GetGist(ctx context.Context, id string) (*Gist, error) {
+	gist := &Gist{}
+	if err := c.call(ctx, http.MethodGet, "/gists/"+url.PathEscape(id), nil, gist); err != nil {
+		return nil, err
+	}
+	return gist, nil
+}
+
+// CreateGist creates the gist of the files by their names. The public gists are listed on the profile of the account,
+// the secret gists are only found by their URLs.
+func (c *Client) CreateGist(ctx context.Context, description string, public bool, files map[string]*File) (*Gist, error) {
+	gist := &Gist{}
+	if err := c.call(ctx, http.MethodPost, "/gists", map[string]any{
+		"description": description,
+		"public":      public,
+		"files":       files,
+	}, gist); err != nil {
+		return nil, err
+	}
+	return gist, nil
+}
+
+// UpdateGist updates the description and the files of the gist. The files of nil are deleted, the others are created or replaced.
+func (c *Client) UpdateGist(ctx context.Context, id, description string, files map[string]*File) (*Gist, error) {
+	gist := &Gist{}
+	if err := c.call(ctx, http.MethodPatch, "/gists/"+url.PathEscape(id), map[string]any{
+		"description": description,
+		"files":       files,
+	}, gist); err != nil {
+		return nil, err
+	}
+	return gist, nil
+}
+
+func (c *Client) call(ctx context.Context, method, path string, payload any, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal payload")
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", path)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiError := struct {
+			Message string `json:"message"`
+		}{}
+		_ = json.Unmarshal(respBody, &apiError)
+		return errors.Errorf("failed to call %s, status %d: %s", path, resp.StatusCode, apiError.Message)
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response of %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package gist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var payloads []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.Method != http.MethodGet {
+			payload := map[string]any{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			payloads = append(payloads, payload)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /gists", "PATCH /gists/abc", "GET /gists/abc":
+			_, _ = io.WriteString(w, `{"id":"abc","html_url":"https://gist.github.com/bob/abc","public":true,"files":{"main.go":{"content":"package main"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message":"Not Found"}`)
+		}
+	}))
+	defer server.Close()
+	baseURL = server.URL
+
+	ctx := context.Background()
+	client := NewClient("token")
+	created, err := client.CreateGist(ctx, "Memo", true, map[string]*File{"main.go": {Content: "package main"}})
+	require.NoError(t, err)
+	require.Equal(t, "https://gist.github.com/bob/abc", created.HTMLURL)
+	gist, err := client.GetGist(ctx, "abc")
+	require.NoError(t, err)
+	require.Equal(t, "package main", gist.Files["main.go"].Content)
+	_, err = client.UpdateGist(ctx, "abc", "Memo", map[string]*File{"main.go": nil, "main.py": {Content: "print()"}})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{
+		{"description": "Memo", "public": true, "files": map[string]any{"main.go": map[string]any{"content": "package main"}}},
+		{"description": "Memo", "files": map[string]any{"main.go": nil, "main.py": map[string]any{"content": "print()"}}},
+	}, payloads)
+
+	_, err = client.GetGist(ctx, "deleted")
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,40 @@
+// Package highlight is a client of the read-it-later services listing the highlights of the articles, Readwise or Omnivore.
+package highlight
+
+import (
+	"context"
+	"time"
+)
+
+// timeout is the timeout of a request to the services.
+var timeout = time.Minute
+
+// Article is an article, a book or a page with its highlights.
+type Article struct {
+	// ID is the id of the article in the service.
+	ID     string
+	Title  string
+	Author string
+	URL    string
+	Tags   []string
+	// Highlights are the highlights of the article updated after the time of the list.
+	Highlights []*Highlight
+}
+
+// Highlight is a highlighted text of an article, or a note of the article.
+type Highlight struct {
+	// ID is the id of the highlight in the service.
+	ID string
+	// Text is the highlighted text, empty if the highlight is a note of the article.
+	Text string
+	// Note is the note of the user on the highlight.
+	Note          string
+	HighlightedTs int64
+	Tags          []string
+}
+
+// Source is a service of the highlights.
+type Source interface {
+	// ListArticles returns the articles which have highlights updated after the time, all the articles if the time is zero.
+	ListArticles(ctx context.Context, updatedAfter time.Time) ([]*Article, error)
+}
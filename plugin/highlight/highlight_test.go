@@ -0,0 +1,92 @@
+package highlight
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadwiseClient(t *testing.T) {
+	queries := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Token token", r.Header.Get("Authorization"))
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("pageCursor") == "" {
+			_, _ = io.WriteString(w, `{"count":2,"nextPageCursor":2,"results":[{"user_book_id":1,"title":"Book","author":"Alice","source_url":"","unique_url":"https://example.com/book","book_tags":[{"name":"reading"}],"highlights":[
+				{"id":10,"text":"First","note":"Nice","highlighted_at":"2024-01-02T03:04:05Z","tags":[{"name":"idea"}]},
+				{"id":11,"text":"Deleted","is_deleted":true}
+			]}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"count":2,"nextPageCursor":null,"results":[{"user_book_id":2,"title":"Empty","highlights":[]}]}`)
+	}))
+	defer server.Close()
+	readwiseExportURL = server.URL + "/"
+
+	articles, err := NewReadwiseClient("token").ListArticles(context.Background(), time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.Equal(t, []string{"updatedAfter=2023-11-14T22%3A13%3A20Z", "pageCursor=2&updatedAfter=2023-11-14T22%3A13%3A20Z"}, queries)
+	require.Equal(t, []*Article{{
+		ID:     "1",
+		Title:  "Book",
+		Author: "Alice",
+		URL:    "https://example.com/book",
+		Tags:   []string{"reading"},
+		Highlights: []*Highlight{
+			{ID: "10", Text: "First", Note: "Nice", HighlightedTs: 1704164645, Tags: []string{"idea"}},
+		},
+	}}, articles)
+}
+
+func TestOmnivoreClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "key", r.Header.Get("Authorization"))
+		request := struct {
+			Variables map[string]any `json:"variables"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, "in:all has:highlights sort:updated-asc", request.Variables["query"])
+		if request.Variables["after"] == nil {
+			_, _ = io.WriteString(w, `{"data":{"search":{"edges":[{"node":{"id":"a","title":"Article","author":"Bob","url":"https://omnivore.app/a","originalArticleUrl":"https://example.com/a","labels":[{"name":"web"}],"highlights":[
+				{"id":"h1","type":"HIGHLIGHT","quote":"Quote","annotation":"","createdAt":"2024-01-02T03:04:05.000Z","labels":[]},
+				{"id":"h2","type":"REDACTION","quote":"Hidden"},
+				{"id":"h3","type":"NOTE","quote":"","annotation":"Page note","createdAt":"2024-01-02T03:04:05.000Z"}
+			]}}],"pageInfo":{"hasNextPage":true,"endCursor":"1"}}}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"search":{"edges":[],"pageInfo":{"hasNextPage":false,"endCursor":"1"}}}}`)
+	}))
+	defer server.Close()
+	omnivoreAPIURL = server.URL
+
+	articles, err := NewOmnivoreClient("key").ListArticles(context.Background(), time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []*Article{{
+		ID:     "a",
+		Title:  "Article",
+		Author: "Bob",
+		URL:    "https://example.com/a",
+		Tags:   []string{"web"},
+		Highlights: []*Highlight{
+			{ID: "h1", Text: "Quote", HighlightedTs: 1704164645},
+			{ID: "h3", Note: "Page note", HighlightedTs: 1704164645},
+		},
+	}}, articles)
+}
+
+func TestOmnivoreClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":{"search":{"errorCodes":["UNAUTHORIZED"]}}}`)
+	}))
+	defer server.Close()
+	omnivoreAPIURL = server.URL
+
+	_, err := NewOmnivoreClient("invalid").ListArticles(context.Background(), time.Time{})
+	require.ErrorContains(t, err, "UNAUTHORIZED")
+}
@@ -0,0 +1,186 @@
+package highlight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// omnivoreAPIURL is the URL of the GraphQL API of Omnivore.
+var omnivoreAPIURL = "https://api-prod.omnivore.app/api/graphql"
+
+// omnivorePageSize is the number of the articles of a page of the search.
+const omnivorePageSize = 50
+
+const omnivoreSearchQuery = `query Search($after: String, $first: Int, $query: String) {
+  search(after: $after, first: $first, query: $query) {
+    ... on SearchSuccess {
+      edges {
+        node {
+          id
+          title
+          author
+          url
+          originalArticleUrl
+          labels { name }
+          highlights { id type quote annotation createdAt updatedAt labels { name } }
+        }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+    ... on SearchError { errorCodes }
+  }
+}`
+
+type omnivoreSearchResponse struct {
+	Data struct {
+		Search struct {
+			Edges []struct {
+				Node struct {
+					ID                 string `json:"id"`
+					Title              string `json:"title"`
+					Author             string `json:"author"`
+					URL                string `json:"url"`
+					OriginalArticleURL string `json:"originalArticleUrl"`
+					Labels             []struct {
+						Name string `json:"name"`
+					} `json:"labels"`
+					Highlights []struct {
+						ID         string `json:"id"`
+						Type       string `json:"type"`
+						Quote      string `json:"quote"`
+						Annotation string `json:"annotation"`
+						CreatedAt  string `json:"createdAt"`
+						UpdatedAt  string `json:"updatedAt"`
+						Labels     []struct {
+							Name string `json:"name"`
+						} `json:"labels"`
+					} `json:"highlights"`
+				} `json:"node"`
+			} `json:"edges"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			ErrorCodes []string `json:"errorCodes"`
+		} `json:"search"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// OmnivoreClient is the client of the Omnivore API authorized by an API key.
+type OmnivoreClient struct {
+	apiKey string
+}
+
+func NewOmnivoreClient(apiKey string) *OmnivoreClient {
+	return &OmnivoreClient{apiKey: apiKey}
+}
+
+// ListArticles returns the articles with their highlights. Omnivore lists all the highlights of the updated articles.
+func (c *OmnivoreClient) ListArticles(ctx context.Context, updatedAfter time.Time) ([]*Article, error) {
+	query := "in:all has:highlights sort:updated-asc"
+	if !updatedAfter.IsZero() {
+		query += " updated:" + updatedAfter.UTC().Format(time.RFC3339) + "..*"
+	}
+	articles := []*Article{}
+	cursor := ""
+	for {
+		variables := map[string]any{"first": omnivorePageSize, "query": query}
+		if cursor != "" {
+			variables["after"] = cursor
+		}
+		response := &omnivoreSearchResponse{}
+		if err := c.call(ctx, omnivoreSearchQuery, variables, response); err != nil {
+			return nil, err
+		}
+		search := response.Data.Search
+		if len(search.ErrorCodes) > 0 {
+			return nil, errors.Errorf("failed to search Omnivore articles: %s", strings.Join(search.ErrorCodes, ", "))
+		}
+		for _, edge := range search.Edges {
+			node := edge.Node
+			article := &Article{
+				ID:     node.ID,
+				Title:  node.Title,
+				Author: node.Author,
+				URL:    node.OriginalArticleURL,
+			}
+			if article.URL == "" {
+				article.URL = node.URL
+			}
+			for _, label := range node.Labels {
+				article.Tags = append(article.Tags, label.Name)
+			}
+			for _, h := range node.Highlights {
+				// The redactions aren't highlights, the notes are the notes of the article.
+				if h.Type != "HIGHLIGHT" && h.Type != "NOTE" {
+					continue
+				}
+				highlight := &Highlight{
+					ID:            h.ID,
+					Text:          h.Quote,
+					Note:          h.Annotation,
+					HighlightedTs: parseTime(h.CreatedAt),
+				}
+				if highlight.Text == "" && highlight.Note == "" {
+					continue
+				}
+				for _, label := range h.Labels {
+					highlight.Tags = append(highlight.Tags, label.Name)
+				}
+				article.Highlights = append(article.Highlights, highlight)
+			}
+			if len(article.Highlights) > 0 {
+				articles = append(articles, article)
+			}
+		}
+		if !search.PageInfo.HasNextPage || search.PageInfo.EndCursor == "" || search.PageInfo.EndCursor == cursor {
+			return articles, nil
+		}
+		cursor = search.PageInfo.EndCursor
+	}
+}
+
+func (c *OmnivoreClient) call(ctx context.Context, query string, variables map[string]any, result *omnivoreSearchResponse) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal query")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, omnivoreAPIURL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.apiKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call Omnivore API")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to call Omnivore API, status %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response of Omnivore API")
+	}
+	if len(result.Errors) > 0 {
+		return errors.Errorf("failed to call Omnivore API: %s", result.Errors[0].Message)
+	}
+	return nil
+}
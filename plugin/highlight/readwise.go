@@ -0,0 +1,144 @@
+package highlight
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// readwiseExportURL is the URL of the export API of Readwise, which lists the highlights by their books.
+var readwiseExportURL = "https://readwise.io/api/v2/export/"
+
+type readwiseExport struct {
+	NextPageCursor json.Number `json:"nextPageCursor"`
+	Results        []struct {
+		UserBookID int64  `json:"user_book_id"`
+		Title      string `json:"title"`
+		Author     string `json:"author"`
+		SourceURL  string `json:"source_url"`
+		UniqueURL  string `json:"unique_url"`
+		BookTags   []struct {
+			Name string `json:"name"`
+		} `json:"book_tags"`
+		Highlights []struct {
+			ID            int64  `json:"id"`
+			Text          string `json:"text"`
+			Note          string `json:"note"`
+			HighlightedAt string `json:"highlighted_at"`
+			CreatedAt     string `json:"created_at"`
+			IsDeleted     bool   `json:"is_deleted"`
+			Tags          []struct {
+				Name string `json:"name"`
+			} `json:"tags"`
+		} `json:"highlights"`
+	} `json:"results"`
+}
+
+// ReadwiseClient is the client of the Readwise API authorized by an access token.
+type ReadwiseClient struct {
+	token string
+}
+
+func NewReadwiseClient(token string) *ReadwiseClient {
+	return &ReadwiseClient{token: token}
+}
+
+func (c *ReadwiseClient) ListArticles(ctx context.Context, updatedAfter time.Time) ([]*Article, error) {
+	articles := []*Article{}
+	cursor := ""
+	for {
+		query := url.Values{}
+		if !updatedAfter.IsZero() {
+			query.Set("updatedAfter", updatedAfter.UTC().Format(time.RFC3339))
+		}
+		if cursor != "" {
+			query.Set("pageCursor", cursor)
+		}
+		export := &readwiseExport{}
+		if err := c.get(ctx, readwiseExportURL+"?"+query.Encode(), export); err != nil {
+			return nil, err
+		}
+		for _, result := range export.Results {
+			article := &Article{
+				ID:     strconv.FormatInt(result.UserBookID, 10),
+				Title:  result.Title,
+				Author: result.Author,
+				URL:    result.SourceURL,
+			}
+			if article.URL == "" {
+				article.URL = result.UniqueURL
+			}
+			for _, tag := range result.BookTags {
+				article.Tags = append(article.Tags, tag.Name)
+			}
+			for _, h := range result.Highlights {
+				if h.IsDeleted {
+					continue
+				}
+				highlight := &Highlight{
+					ID:            strconv.FormatInt(h.ID, 10),
+					Text:          h.Text,
+					Note:          h.Note,
+					HighlightedTs: parseTime(h.HighlightedAt),
+				}
+				if highlight.HighlightedTs == 0 {
+					highlight.HighlightedTs = parseTime(h.CreatedAt)
+				}
+				for _, tag := range h.Tags {
+					highlight.Tags = append(highlight.Tags, tag.Name)
+				}
+				article.Highlights = append(article.Highlights, highlight)
+			}
+			if len(article.Highlights) > 0 {
+				articles = append(articles, article)
+			}
+		}
+		if cursor = export.NextPageCursor.String(); cursor == "" {
+			return articles, nil
+		}
+	}
+}
+
+func (c *ReadwiseClient) get(ctx context.Context, rawURL string, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Authorization", "Token "+c.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call Readwise API")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiError := struct {
+			Detail string `json:"detail"`
+		}{}
+		_ = json.Unmarshal(body, &apiError)
+		return errors.Errorf("failed to call Readwise API, status %d: %s", resp.StatusCode, apiError.Detail)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response of Readwise API")
+	}
+	return nil
+}
+
+func parseTime(value string) int64 {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil && t.Unix() > 0 {
+		return t.Unix()
+	}
+	return 0
+}
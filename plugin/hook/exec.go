@@ -0,0 +1,41 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExecRunner runs an executable with the request on stdin and reads the response from stdout.
+// The executable is run without a shell and with only the PATH of the server in its environment.
+type ExecRunner struct {
+	Path string
+}
+
+func NewExecRunner(path string) *ExecRunner {
+	return &ExecRunner{
+		Path: path,
+	}
+}
+
+func (r *ExecRunner) Run(ctx context.Context, request *Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal hook request")
+	}
+	cmd := exec.CommandContext(ctx, r.Path)
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH")}
+	cmd.Stdin = bytes.NewReader(body)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to run hook %s: %s", r.Path, strings.TrimSpace(stderr.String()))
+	}
+	return parseResponse(bytes.TrimSpace(stdout.Bytes()))
+}
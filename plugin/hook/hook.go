@@ -0,0 +1,72 @@
+// Package hook runs the hooks of the administrators, which are the HTTP endpoints or the executables
+// sent the requests of the memo and upload events as JSON, and which respond whether to allow them and
+// how to transform the content, e.g. to auto-tag the memos or enforce a content policy.
+package hook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// maxResponseSize is the max size of the response of a hook.
+const maxResponseSize = 1 << 20
+
+const (
+	EventMemoCreate     = "memo.create"
+	EventMemoUpdate     = "memo.update"
+	EventResourceUpload = "resource.upload"
+	EventMemoRender     = "memo.render"
+)
+
+// Request is the request a hook is sent.
+type Request struct {
+	Event    string    `json:"event"`
+	Memo     *Memo     `json:"memo,omitempty"`
+	Resource *Resource `json:"resource,omitempty"`
+}
+
+// Memo is the memo of a request, the id is 0 before the memo is created.
+type Memo struct {
+	ID         int32  `json:"id"`
+	CreatorID  int32  `json:"creatorId"`
+	Content    string `json:"content"`
+	Visibility string `json:"visibility"`
+}
+
+// Resource is the uploaded file of a request, without its content.
+type Resource struct {
+	CreatorID int32  `json:"creatorId"`
+	Filename  string `json:"filename"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size"`
+}
+
+// Response is the response of a hook, an empty response allows the action as is.
+type Response struct {
+	// Reject is the flag to reject the action, with the message shown to the user.
+	Reject  bool   `json:"reject"`
+	Message string `json:"message"`
+	// Content is the transformed content of the memo, nil keeps the content.
+	Content *string `json:"content"`
+}
+
+type Runner interface {
+	// Run sends the request to the hook and returns its response.
+	Run(ctx context.Context, request *Request) (*Response, error)
+}
+
+func parseResponse(body []byte) (*Response, error) {
+	response := &Response{}
+	if len(body) == 0 {
+		return response, nil
+	}
+	if len(body) > maxResponseSize {
+		return nil, errors.Errorf("response is larger than %d bytes", maxResponseSize)
+	}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, errors.Wrap(err, "invalid response")
+	}
+	return response, nil
+}
@@ -0,0 +1,67 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/webhook"
+)
+
+func TestHTTPRunner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		require.True(t, strings.HasPrefix(r.Header.Get(webhook.SignatureHeader), "sha256="))
+		if strings.Contains(request.Memo.Content, "secret") {
+			_, _ = w.Write([]byte(`{"reject": true, "message": "no secrets"}`))
+			return
+		}
+		content := request.Memo.Content + " #auto"
+		_ = json.NewEncoder(w).Encode(&Response{Content: &content})
+	}))
+	defer server.Close()
+
+	runner := NewHTTPRunner(server.URL, "key")
+	response, err := runner.Run(context.Background(), &Request{Event: EventMemoCreate, Memo: &Memo{Content: "hello"}})
+	require.NoError(t, err)
+	require.False(t, response.Reject)
+	require.Equal(t, "hello #auto", *response.Content)
+
+	response, err = runner.Run(context.Background(), &Request{Event: EventMemoCreate, Memo: &Memo{Content: "a secret"}})
+	require.NoError(t, err)
+	require.True(t, response.Reject)
+	require.Equal(t, "no secrets", response.Message)
+}
+
+func TestExecRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook is a shell script")
+	}
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\ncat > /dev/null\necho '{\"content\": \"changed\"}'\n"), 0755))
+
+	response, err := NewExecRunner(path).Run(context.Background(), &Request{Event: EventMemoUpdate, Memo: &Memo{Content: "hello"}})
+	require.NoError(t, err)
+	require.Equal(t, "changed", *response.Content)
+
+	_, err = NewExecRunner(filepath.Join(t.TempDir(), "missing")).Run(context.Background(), &Request{Event: EventMemoUpdate})
+	require.Error(t, err)
+}
+
+func TestParseResponse(t *testing.T) {
+	response, err := parseResponse(nil)
+	require.NoError(t, err)
+	require.False(t, response.Reject)
+	require.Nil(t, response.Content)
+	_, err = parseResponse([]byte("not json"))
+	require.Error(t, err)
+}
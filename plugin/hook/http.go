@@ -0,0 +1,55 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/webhook"
+)
+
+// HTTPRunner posts the requests to an HTTP endpoint, signed like the webhooks if the secret is set.
+type HTTPRunner struct {
+	URL    string
+	Secret string
+}
+
+func NewHTTPRunner(url, secret string) *HTTPRunner {
+	return &HTTPRunner{
+		URL:    url,
+		Secret: secret,
+	}
+}
+
+func (r *HTTPRunner) Run(ctx context.Context, request *Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal hook request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to construct hook request to %s", r.URL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.Secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(r.Secret, body))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to post hook request to %s", r.URL)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read hook response from %s", r.URL)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Errorf("failed to post hook request to %s, status code: %d", r.URL, resp.StatusCode)
+	}
+	return parseResponse(b)
+}
@@ -0,0 +1,142 @@
+package getter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// fetchTimeout is the timeout for fetching a remote file.
+	fetchTimeout = 30 * time.Second
+	// maxRedirects is the max number of redirects followed when fetching a remote file.
+	maxRedirects = 5
+)
+
+type File struct {
+	Filename  string
+	Blob      []byte
+	Mediatype string
+}
+
+// safeClient is the HTTP client for fetching remote files on behalf of users.
+// It refuses to connect to loopback, private and other non-public addresses, so it can't be used to reach internal services.
+var safeClient = &http.Client{
+	Timeout: fetchTimeout,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: fetchTimeout,
+			Control: func(_, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				addr, err := netip.ParseAddr(host)
+				if err != nil || !isPublicIP(addr) {
+					return errors.Errorf("address %s is not allowed", host)
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: fetchTimeout,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New("too many redirects")
+		}
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return errors.Errorf("scheme %s is not allowed", req.URL.Scheme)
+		}
+		return nil
+	},
+}
+
+// deniedPrefixes are the special-purpose ranges which aren't covered by the net/netip checks, but can't be
+// reached on the public internet either.
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	// NAT64 translates the embedded IPv4 address, which may be a private one.
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	// IPv4-translated addresses embed an IPv4 address as well.
+	netip.MustParsePrefix("::ffff:0:0:0/96"),
+}
+
+func isPublicIP(addr netip.Addr) bool {
+	// The IPv4-mapped IPv6 addresses, e.g. ::ffff:127.0.0.1, are checked as the IPv4 addresses they map to.
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetFile downloads the remote file. The file is rejected if it's larger than maxSize bytes.
+func GetFile(ctx context.Context, urlStr string, maxSize int64) (*File, error) {
+	fileURL, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if fileURL.Scheme != "http" && fileURL.Scheme != "https" {
+		return nil, errors.Errorf("scheme %s is not allowed", fileURL.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := safeClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errors.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	if response.ContentLength > maxSize {
+		return nil, errors.Errorf("file size exceeds the limit of %d bytes", maxSize)
+	}
+
+	mediatype, err := getMediatype(response)
+	if err != nil {
+		return nil, err
+	}
+	bodyBytes, err := io.ReadAll(io.LimitReader(response.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(bodyBytes)) > maxSize {
+		return nil, errors.Errorf("file size exceeds the limit of %d bytes", maxSize)
+	}
+
+	filename := path.Base(response.Request.URL.Path)
+	if filename == "/" || filename == "." {
+		filename = fmt.Sprintf("%s_%d", fileURL.Hostname(), time.Now().Unix())
+	}
+	return &File{
+		Filename:  filename,
+		Blob:      bodyBytes,
+		Mediatype: mediatype,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package getter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFileRejectsLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	}))
+	defer server.Close()
+
+	_, err := GetFile(context.Background(), server.URL+"/image.png", 1024)
+	require.Error(t, err)
+}
+
+func TestGetFileRejectsScheme(t *testing.T) {
+	_, err := GetFile(context.Background(), "file:///etc/passwd", 1024)
+	require.Error(t, err)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "1.1.1.1", want: true},
+		{addr: "2606:4700:4700::1111", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "10.0.0.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "0.1.2.3", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "192.0.0.8", want: false},
+		{addr: "198.18.0.1", want: false},
+		{addr: "198.19.255.255", want: false},
+		{addr: "240.0.0.1", want: false},
+		{addr: "255.255.255.255", want: false},
+		{addr: "::1", want: false},
+		{addr: "fd00::1", want: false},
+		{addr: "64:ff9b::7f00:1", want: false},
+		{addr: "64:ff9b::a9fe:a9fe", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "::ffff:10.0.0.1", want: false},
+		{addr: "::ffff:100.64.0.1", want: false},
+		{addr: "::ffff:1.1.1.1", want: true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, isPublicIP(netip.MustParseAddr(test.addr)), test.addr)
+	}
+}
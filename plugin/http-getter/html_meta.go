@@ -1,6 +1,7 @@
 package getter
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -96,3 +97,39 @@ func extractMetaProperty(token html.Token, prop string) (content string, ok bool
 	}
 	return content, ok
 }
+
+// maxHTMLMetaSize is the max number of the bytes of a page read for its metadata, the metadata is in the head of the page.
+const maxHTMLMetaSize = 1 << 20
+
+// FetchHTMLMeta returns the metadata of the web page on behalf of a user, the page is fetched by the client which refuses the non-public addresses.
+func FetchHTMLMeta(ctx context.Context, urlStr string) (*HTMLMeta, error) {
+	pageURL, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if pageURL.Scheme != "http" && pageURL.Scheme != "https" {
+		return nil, errors.New("Wrong website scheme")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := safeClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errors.New("Wrong website status")
+	}
+
+	mediatype, err := getMediatype(response)
+	if err != nil {
+		return nil, err
+	}
+	if mediatype != "text/html" {
+		return nil, errors.New("Wrong website mediatype")
+	}
+	return extractHTMLMeta(io.LimitReader(response.Body, maxHTMLMetaSize)), nil
+}
@@ -0,0 +1,192 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		subject     string
+		from        string
+		text        string
+		attachments []string
+	}{
+		{
+			name:    "plain text",
+			data:    "From: Alice <alice@example.com>\r\nSubject: Hello\r\n\r\nHello world\r\n",
+			subject: "Hello",
+			from:    "alice@example.com",
+			text:    "Hello world",
+		},
+		{
+			name:    "encoded subject and quoted printable body",
+			data:    "From: alice@example.com\r\nSubject: =?utf-8?q?Caf=C3=A9?=\r\nContent-Type: text/plain; charset=iso-8859-1\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\nCaf=E9 au lait\r\n",
+			subject: "Café",
+			from:    "alice@example.com",
+			text:    "Café au lait",
+		},
+		{
+			name: "alternative with attachment",
+			data: strings.Join([]string{
+				"From: newsletter@example.com",
+				"Subject: Weekly",
+				"Content-Type: multipart/mixed; boundary=outer",
+				"",
+				"--outer",
+				"Content-Type: multipart/alternative; boundary=inner",
+				"",
+				"--inner",
+				"Content-Type: text/plain",
+				"",
+				"Plain body",
+				"--inner",
+				"Content-Type: text/html",
+				"",
+				"<p>HTML body</p>",
+				"--inner--",
+				"--outer",
+				"Content-Type: image/png; name=\"dot.png\"",
+				"Content-Disposition: attachment; filename=\"dot.png\"",
+				"Content-Transfer-Encoding: base64",
+				"",
+				"iVBORw0K",
+				"--outer--",
+				"",
+			}, "\r\n"),
+			subject:     "Weekly",
+			from:        "newsletter@example.com",
+			text:        "Plain body",
+			attachments: []string{"dot.png"},
+		},
+		{
+			name:    "html only",
+			data:    "From: receipts@example.com\r\nSubject: Receipt\r\nContent-Type: text/html\r\n\r\n<html><head><title>Receipt</title><style>p{}</style></head><body><p>Total: <b>$10</b></p><p>See <a href=\"https://example.com/r/1\">your order</a></p></body></html>",
+			subject: "Receipt",
+			from:    "receipts@example.com",
+			text:    "Total: $10\n\nSee [your order](https://example.com/r/1)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, err := ParseMessage([]byte(test.data))
+			require.NoError(t, err)
+			require.Equal(t, test.subject, message.Subject)
+			require.Equal(t, test.from, message.From)
+			require.Equal(t, test.text, message.Text)
+			filenames := []string{}
+			for _, attachment := range message.Attachments {
+				filenames = append(filenames, attachment.Filename)
+			}
+			require.ElementsMatch(t, test.attachments, filenames)
+		})
+	}
+}
+
+type testHandler struct {
+	mutex     sync.Mutex
+	envelopes []*Envelope
+}
+
+func (h *testHandler) Recipient(_ context.Context, address string) error {
+	if !strings.HasSuffix(address, "@memos.example.com") {
+		return ErrUnknownRecipient
+	}
+	return nil
+}
+
+func (h *testHandler) Deliver(_ context.Context, envelope *Envelope) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.envelopes = append(h.envelopes, envelope)
+	return nil
+}
+
+func (h *testHandler) getEnvelopes() []*Envelope {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.envelopes
+}
+
+func TestServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	handler := &testHandler{}
+	server := &Server{Handler: handler, Hostname: "memos.example.com", MaxMessageSize: 1024}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(ctx, listener)
+	}()
+
+	address := listener.Addr().String()
+	data := []byte("Subject: Hello\r\n\r\nHello world\r\n.leading dot\r\n")
+	require.NoError(t, smtp.SendMail(address, nil, "alice@example.com", []string{"token@memos.example.com"}, data))
+	envelopes := handler.getEnvelopes()
+	require.Len(t, envelopes, 1)
+	require.Equal(t, "alice@example.com", envelopes[0].From)
+	require.Equal(t, []string{"token@memos.example.com"}, envelopes[0].Recipients)
+	require.Equal(t, "Subject: Hello\n\nHello world\n.leading dot\n", string(envelopes[0].Data))
+
+	err = smtp.SendMail(address, nil, "alice@example.com", []string{"someone@example.com"}, data)
+	require.ErrorContains(t, err, "550")
+	err = smtp.SendMail(address, nil, "alice@example.com", []string{"token@memos.example.com"}, []byte(strings.Repeat("a", 2048)))
+	require.ErrorContains(t, err, "552")
+	require.Len(t, handler.getEnvelopes(), 1)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestSend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	handler := &testHandler{}
+	server := &Server{Handler: handler, Hostname: "memos.example.com"}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(ctx, listener)
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	config := &SenderConfig{
+		Host:      host,
+		Port:      portNumber,
+		FromEmail: "notify@example.com",
+		FromName:  "Memos",
+	}
+	err = Send(ctx, config, &OutgoingMessage{
+		To:      "alice@memos.example.com",
+		Subject: "New comment on\r\nBcc: injected@example.com",
+		Text:    "Café\nhttps://memos.example.com/m/abc",
+	})
+	require.NoError(t, err)
+	envelopes := handler.getEnvelopes()
+	require.Len(t, envelopes, 1)
+	require.Equal(t, "notify@example.com", envelopes[0].From)
+	require.Equal(t, []string{"alice@memos.example.com"}, envelopes[0].Recipients)
+	message, err := ParseMessage(envelopes[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, "New comment on Bcc: injected@example.com", message.Subject)
+	require.Equal(t, "notify@example.com", message.From)
+	require.Equal(t, "Café\nhttps://memos.example.com/m/abc", message.Text)
+
+	config.Security = SecuritySTARTTLS
+	require.Error(t, Send(ctx, config, &OutgoingMessage{To: "alice@memos.example.com", Subject: "Hello"}))
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,277 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+// maxPartDepth is the max depth of the nested multipart bodies.
+const maxPartDepth = 10
+
+// Message is a parsed email.
+type Message struct {
+	// From is the address of the From header.
+	From    string
+	Subject string
+	// Text is the plain text body, converted from the HTML body if the email has no plain text body.
+	Text        string
+	Attachments []*Attachment
+}
+
+type Attachment struct {
+	Filename string
+	MimeType string
+	Data     []byte
+}
+
+var wordDecoder = &mime.WordDecoder{
+	CharsetReader: charset.NewReaderLabel,
+}
+
+// ParseMessage parses the email of the data, with the headers and the body.
+func ParseMessage(data []byte) (*Message, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	message := &Message{
+		Subject: decodeHeader(msg.Header.Get("Subject")),
+	}
+	if from := msg.Header.Get("From"); from != "" {
+		if address, err := (&mail.AddressParser{WordDecoder: wordDecoder}).Parse(from); err == nil {
+			message.From = address.Address
+		}
+	}
+
+	p := &parser{message: message}
+	if err := p.parsePart(textproto.MIMEHeader(msg.Header), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	if len(p.texts) > 0 {
+		message.Text = strings.Join(p.texts, "\n\n")
+	} else {
+		message.Text = strings.Join(p.htmlTexts, "\n\n")
+	}
+	message.Text = strings.TrimSpace(message.Text)
+	return message, nil
+}
+
+type parser struct {
+	message   *Message
+	texts     []string
+	htmlTexts []string
+}
+
+func (p *parser) parsePart(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	body = decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxPartDepth {
+			return fmt.Errorf("multipart body nested more than %d levels", maxPartDepth)
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		// Only the preferred alternative is kept, which is the last one that can be rendered.
+		isAlternative := mediaType == "multipart/alternative"
+		var alternatives []*parser
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			target := p
+			if isAlternative {
+				target = &parser{message: p.message}
+				alternatives = append(alternatives, target)
+			}
+			if err := target.parsePart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+		if len(alternatives) > 0 {
+			p.mergeAlternatives(alternatives)
+		}
+		return nil
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = decodeHeader(filename)
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if disposition == "attachment" || !isText || (disposition == "inline" && filename != "") {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		if filename == "" {
+			filename = "attachment"
+			if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) > 0 {
+				filename += extensions[0]
+			}
+		}
+		p.message.Attachments = append(p.message.Attachments, &Attachment{
+			Filename: filepath.Base(filename),
+			MimeType: mediaType,
+			Data:     data,
+		})
+		return nil
+	}
+
+	if label := params["charset"]; label != "" && !strings.EqualFold(label, "utf-8") && !strings.EqualFold(label, "us-ascii") {
+		if reader, err := charset.NewReaderLabel(label, body); err == nil {
+			body = reader
+		}
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if mediaType == "text/html" {
+		p.htmlTexts = append(p.htmlTexts, convertHTMLToText(data))
+	} else {
+		p.texts = append(p.texts, strings.ReplaceAll(string(data), "\r\n", "\n"))
+	}
+	return nil
+}
+
+// mergeAlternatives keeps the text of the last alternative with a plain text body and the attachments of all alternatives.
+func (p *parser) mergeAlternatives(alternatives []*parser) {
+	var text, htmlText []string
+	for _, alternative := range alternatives {
+		if len(alternative.texts) > 0 {
+			text = alternative.texts
+		}
+		if len(alternative.htmlTexts) > 0 {
+			htmlText = alternative.htmlTexts
+		}
+	}
+	p.texts = append(p.texts, text...)
+	if len(text) == 0 {
+		p.htmlTexts = append(p.htmlTexts, htmlText...)
+	}
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// decodeHeader decodes the encoded words of the header, e.g. `=?utf-8?q?caf=C3=A9?=`.
+// The raw header is returned if it can't be decoded.
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+var blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+
+// convertHTMLToText returns the text of the HTML, with the links kept as markdown links.
+func convertHTMLToText(data []byte) string {
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	var builder strings.Builder
+	skipDepth := 0
+	var link string
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.DataAtom {
+			case atom.Script, atom.Style, atom.Head, atom.Title:
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+			case atom.Br:
+				builder.WriteString("\n")
+			case atom.P, atom.Div, atom.Tr, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote, atom.Table:
+				builder.WriteString("\n\n")
+			case atom.Li:
+				builder.WriteString("\n- ")
+			case atom.A:
+				link = ""
+				for _, attr := range token.Attr {
+					if attr.Key == "href" && (strings.HasPrefix(attr.Val, "http://") || strings.HasPrefix(attr.Val, "https://")) {
+						link = attr.Val
+					}
+				}
+				if link != "" {
+					builder.WriteString("[")
+				}
+			}
+		case html.EndTagToken:
+			switch token.DataAtom {
+			case atom.Script, atom.Style, atom.Head, atom.Title:
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote, atom.Table:
+				builder.WriteString("\n\n")
+			case atom.A:
+				if link != "" {
+					builder.WriteString("](" + link + ")")
+					link = ""
+				}
+			}
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			// The whitespaces are collapsed as the browsers render them.
+			words := strings.Fields(token.Data)
+			if len(words) == 0 {
+				if token.Data != "" {
+					builder.WriteString(" ")
+				}
+				continue
+			}
+			if strings.TrimLeft(token.Data, " \t\r\n") != token.Data {
+				builder.WriteString(" ")
+			}
+			builder.WriteString(strings.Join(words, " "))
+			if strings.TrimRight(token.Data, " \t\r\n") != token.Data {
+				builder.WriteString(" ")
+			}
+		}
+	}
+
+	lines := strings.Split(builder.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,168 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sendTimeout is the timeout of sending an email, including connecting to the server.
+const sendTimeout = 30 * time.Second
+
+type Security int
+
+const (
+	// SecurityAuto uses STARTTLS if the server supports it.
+	SecurityAuto Security = iota
+	SecurityNone
+	SecuritySTARTTLS
+	// SecurityTLS connects with TLS, which is usually on the port 465.
+	SecurityTLS
+)
+
+// SenderConfig is the config of the SMTP server sending the emails.
+type SenderConfig struct {
+	Host      string
+	Port      int
+	Username  string
+	Password  string
+	FromEmail string
+	FromName  string
+	Security  Security
+}
+
+// OutgoingMessage is a plain text email.
+type OutgoingMessage struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Send sends the message with the SMTP server of the config.
+func Send(ctx context.Context, config *SenderConfig, message *OutgoingMessage) error {
+	data, err := buildMessage(config, message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	address := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to smtp server")
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	tlsConfig := &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+	if config.Security == SecurityTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		return errors.Wrap(err, "failed to create smtp client")
+	}
+	defer client.Close()
+	if config.Security == SecuritySTARTTLS || config.Security == SecurityAuto {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return errors.Wrap(err, "failed to start tls")
+			}
+		} else if config.Security == SecuritySTARTTLS {
+			return errors.New("smtp server doesn't support STARTTLS")
+		}
+	}
+	if config.Username != "" {
+		// PlainAuth refuses to send the password without TLS, except to localhost.
+		if err := client.Auth(smtp.PlainAuth("", config.Username, config.Password, config.Host)); err != nil {
+			return errors.Wrap(err, "failed to authenticate")
+		}
+	}
+	if err := client.Mail(config.FromEmail); err != nil {
+		return errors.Wrap(err, "failed to set sender")
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return errors.Wrap(err, "failed to set recipient")
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to start data")
+	}
+	if _, err := writer.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write message")
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+	return client.Quit()
+}
+
+// buildMessage returns the message with the headers, the body is quoted-printable UTF-8 text.
+func buildMessage(config *SenderConfig, message *OutgoingMessage) ([]byte, error) {
+	if strings.ContainsAny(message.To, "\r\n") {
+		return nil, errors.New("invalid recipient")
+	}
+	messageID, err := generateMessageID(config.FromEmail)
+	if err != nil {
+		return nil, err
+	}
+	from := (&mail.Address{Name: config.FromName, Address: config.FromEmail}).String()
+	// Line breaks in the subject would inject headers.
+	subject := strings.Join(strings.Fields(message.Subject), " ")
+
+	var buffer bytes.Buffer
+	headers := [][2]string{
+		{"From", from},
+		{"To", message.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buffer, "%s: %s\r\n", header[0], header[1])
+	}
+	buffer.WriteString("\r\n")
+	// The writer converts the line endings to CRLF.
+	writer := quotedprintable.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(message.Text)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func generateMessageID(fromEmail string) (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	domain := "memos"
+	if _, addressDomain, ok := strings.Cut(fromEmail, "@"); ok && addressDomain != "" {
+		domain = addressDomain
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(randomBytes), domain), nil
+}
@@ -0,0 +1,333 @@
+// Package mail receives emails with a minimal SMTP server and parses them, so they can be saved as memos,
+// and sends the notification emails with an SMTP server.
+// Only the commands needed to receive emails are implemented, the server doesn't relay emails.
+package mail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// commandTimeout is the timeout of reading a command or the data of a message.
+	commandTimeout = 5 * time.Minute
+	// maxLineLength is the max length of the command lines, RFC 5321 limits them to 512 octets.
+	maxLineLength = 4096
+	// maxRecipients is the max number of the recipients of a message, RFC 5321 requires at least 100.
+	maxRecipients = 100
+	// maxInvalidCommands is the number of the invalid commands which closes the connection.
+	maxInvalidCommands = 10
+)
+
+// Envelope is a message received by the server.
+type Envelope struct {
+	// From is the address of the sender in the MAIL command, which may differ from the From header.
+	From string
+	// Recipients are the addresses accepted in the RCPT commands.
+	Recipients []string
+	// Data is the message, with the headers and the body. The line endings are converted to LF.
+	Data []byte
+}
+
+// Handler decides the recipients accepted by the server and receives the messages.
+type Handler interface {
+	// Recipient returns an error if the emails to the address are rejected.
+	Recipient(ctx context.Context, address string) error
+	// Deliver receives the message sent to the accepted recipients.
+	Deliver(ctx context.Context, envelope *Envelope) error
+}
+
+// Error is an error returned by the handler to reply with the code and the message.
+// Other errors are replied as temporary failures, so the sender retries later.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s", e.Code, e.Message)
+}
+
+var (
+	// ErrUnknownRecipient is returned by the handlers for the unknown recipients.
+	ErrUnknownRecipient = &Error{Code: 550, Message: "5.1.1 No such user"}
+	// ErrRejected is returned by the handlers for the messages which shouldn't be retried.
+	ErrRejected = &Error{Code: 554, Message: "5.7.1 Message rejected"}
+)
+
+type Server struct {
+	Handler Handler
+	// Hostname is the name of the server in the greeting.
+	Hostname string
+	// MaxMessageSize is the max size of the messages in bytes.
+	MaxMessageSize int64
+}
+
+// Serve accepts the connections of the listener until the context is done.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				time.Sleep(time.Second)
+				continue
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The connection is closed once the session ends or the context is done.
+			connCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				<-connCtx.Done()
+				conn.Close()
+			}()
+			session := &session{
+				server: s,
+				conn:   conn,
+				reader: bufio.NewReaderSize(conn, maxLineLength),
+				writer: bufio.NewWriter(conn),
+			}
+			if err := session.serve(ctx); err != nil && !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				slog.Debug("SMTP session closed", slog.String("remote", conn.RemoteAddr().String()), slog.Any("err", err))
+			}
+		}()
+	}
+}
+
+type session struct {
+	server *Server
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+
+	greeted    bool
+	from       *string
+	recipients []string
+}
+
+func (s *session) reply(code int, message string) error {
+	if _, err := fmt.Fprintf(s.writer, "%d %s\r\n", code, message); err != nil {
+		return err
+	}
+	return s.writer.Flush()
+}
+
+func (s *session) reset() {
+	s.from = nil
+	s.recipients = nil
+}
+
+func (s *session) readLine() (string, error) {
+	if err := s.conn.SetReadDeadline(time.Now().Add(commandTimeout)); err != nil {
+		return "", err
+	}
+	line, err := s.reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", errors.New("command line too long")
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+func (s *session) serve(ctx context.Context) error {
+	if err := s.reply(220, s.server.Hostname+" ESMTP memos"); err != nil {
+		return err
+	}
+	invalidCommands := 0
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		code, message, quit := s.handle(ctx, strings.ToUpper(verb), strings.TrimSpace(arg))
+		if code == 0 {
+			// The command replied itself.
+			continue
+		}
+		if err := s.reply(code, message); err != nil {
+			return err
+		}
+		if quit {
+			return nil
+		}
+		if code >= 500 {
+			invalidCommands++
+			if invalidCommands >= maxInvalidCommands {
+				return s.reply(421, "4.7.0 Too many errors")
+			}
+		}
+	}
+}
+
+// handle handles the command and returns the reply, or a zero code if the command replied itself.
+func (s *session) handle(ctx context.Context, verb, arg string) (int, string, bool) {
+	switch verb {
+	case "HELO", "EHLO":
+		if arg == "" {
+			return 501, "5.5.4 Domain required", false
+		}
+		s.greeted = true
+		s.reset()
+		if verb == "HELO" {
+			return 250, s.server.Hostname, false
+		}
+		extensions := []string{s.server.Hostname, "8BITMIME", "PIPELINING"}
+		if s.server.MaxMessageSize > 0 {
+			extensions = append(extensions, fmt.Sprintf("SIZE %d", s.server.MaxMessageSize))
+		}
+		for _, extension := range extensions[:len(extensions)-1] {
+			fmt.Fprintf(s.writer, "250-%s\r\n", extension)
+		}
+		return 250, extensions[len(extensions)-1], false
+	case "MAIL":
+		if !s.greeted {
+			return 503, "5.5.1 Send HELO or EHLO first", false
+		}
+		if s.from != nil {
+			return 503, "5.5.1 Sender already specified", false
+		}
+		address, params, ok := parsePath(arg, "FROM:")
+		if !ok {
+			return 501, "5.5.4 Syntax: MAIL FROM:<address>", false
+		}
+		for _, param := range params {
+			key, value, _ := strings.Cut(param, "=")
+			if strings.EqualFold(key, "SIZE") && s.server.MaxMessageSize > 0 {
+				if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > s.server.MaxMessageSize {
+					return 552, "5.3.4 Message too big", false
+				}
+			}
+		}
+		s.from = &address
+		return 250, "2.1.0 OK", false
+	case "RCPT":
+		if s.from == nil {
+			return 503, "5.5.1 Send MAIL first", false
+		}
+		address, _, ok := parsePath(arg, "TO:")
+		if !ok || address == "" {
+			return 501, "5.5.4 Syntax: RCPT TO:<address>", false
+		}
+		if len(s.recipients) >= maxRecipients {
+			return 452, "4.5.3 Too many recipients", false
+		}
+		if err := s.server.Handler.Recipient(ctx, address); err != nil {
+			code, message := replyOf(err)
+			return code, message, false
+		}
+		s.recipients = append(s.recipients, address)
+		return 250, "2.1.5 OK", false
+	case "DATA":
+		if len(s.recipients) == 0 {
+			return 503, "5.5.1 Send RCPT first", false
+		}
+		if err := s.reply(354, "End data with <CR><LF>.<CR><LF>"); err != nil {
+			return 421, "4.4.2 Connection error", true
+		}
+		code, message := s.readData(ctx)
+		s.reset()
+		return code, message, code == 421
+	case "RSET":
+		s.reset()
+		return 250, "2.0.0 OK", false
+	case "NOOP":
+		return 250, "2.0.0 OK", false
+	case "VRFY":
+		return 252, "2.5.0 Cannot verify users", false
+	case "QUIT":
+		return 221, "2.0.0 Bye", true
+	default:
+		return 502, "5.5.2 Command not implemented", false
+	}
+}
+
+func (s *session) readData(ctx context.Context) (int, string) {
+	if err := s.conn.SetReadDeadline(time.Now().Add(commandTimeout)); err != nil {
+		return 421, "4.4.2 Connection error"
+	}
+	reader := textproto.NewReader(s.reader).DotReader()
+	var limited io.Reader = reader
+	if s.server.MaxMessageSize > 0 {
+		limited = io.LimitReader(reader, s.server.MaxMessageSize+1)
+	}
+	data, err := io.ReadAll(limited)
+	if err != nil {
+		return 421, "4.4.2 Connection error"
+	}
+	if s.server.MaxMessageSize > 0 && int64(len(data)) > s.server.MaxMessageSize {
+		// The rest of the message is discarded, so the session can continue.
+		if _, err := io.Copy(io.Discard, reader); err != nil {
+			return 421, "4.4.2 Connection error"
+		}
+		return 552, "5.3.4 Message too big"
+	}
+	envelope := &Envelope{
+		From:       *s.from,
+		Recipients: s.recipients,
+		Data:       data,
+	}
+	if err := s.server.Handler.Deliver(ctx, envelope); err != nil {
+		return replyOf(err)
+	}
+	return 250, "2.0.0 OK"
+}
+
+// replyOf returns the reply of the error of the handler.
+func replyOf(err error) (int, string) {
+	var smtpErr *Error
+	if errors.As(err, &smtpErr) {
+		return smtpErr.Code, smtpErr.Message
+	}
+	slog.Warn("Failed to handle email", slog.Any("err", err))
+	return 451, "4.3.0 Temporary failure, try again later"
+}
+
+// parsePath parses the path and the parameters of the MAIL and RCPT commands, e.g. `FROM:<user@example.com> SIZE=1024`.
+func parsePath(arg, prefix string) (string, []string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	arg = strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(arg, "<") {
+		return "", nil, false
+	}
+	end := strings.Index(arg, ">")
+	if end < 0 {
+		return "", nil, false
+	}
+	address := arg[1:end]
+	// Drop the source route of the obsolete syntax, e.g. `<@relay.example.com:user@example.com>`.
+	if strings.HasPrefix(address, "@") {
+		if _, rest, ok := strings.Cut(address, ":"); ok {
+			address = rest
+		}
+	}
+	return address, strings.Fields(arg[end+1:]), true
+}
@@ -0,0 +1,192 @@
+// Package mastodon is a client of the Mastodon API posting the statuses of an account authorized by an access token.
+package mastodon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// timeout is the timeout of the requests to the Mastodon API, the uploads of the media included.
+	timeout = time.Minute
+	// mediaPollInterval is the interval of checking if an uploaded media is processed.
+	mediaPollInterval = time.Second
+)
+
+// maxMediaPolls is the number of the checks of an uploaded media before the status is posted regardless.
+const maxMediaPolls = 30
+
+// Instance is the configuration of the statuses of a Mastodon server.
+type Instance struct {
+	MaxCharacters            int
+	MaxMediaAttachments      int
+	CharactersReservedPerURL int
+}
+
+// DefaultInstance is the configuration of the servers which don't report theirs, it's the default of Mastodon.
+var DefaultInstance = &Instance{
+	MaxCharacters:            500,
+	MaxMediaAttachments:      4,
+	CharactersReservedPerURL: 23,
+}
+
+// Status is a posted status.
+type Status struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Client is the client of the Mastodon API of a server, authorized by the access token of an account.
+type Client struct {
+	serverURL string
+	token     string
+}
+
+func NewClient(serverURL, token string) *Client {
+	return &Client{
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		token:     token,
+	}
+}
+
+// ValidateServerURL returns an error if the URL isn't the root of a server.
+func ValidateServerURL(serverURL string) error {
+	u, err := url.Parse(serverURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the server URL must be an http or https URL")
+	}
+	return nil
+}
+
+// GetInstance returns the configuration of the statuses of the server, or DefaultInstance if it's unknown.
+func (c *Client) GetInstance(ctx context.Context) *Instance {
+	result := struct {
+		Configuration struct {
+			Statuses struct {
+				MaxCharacters            int `json:"max_characters"`
+				MaxMediaAttachments      int `json:"max_media_attachments"`
+				CharactersReservedPerURL int `json:"characters_reserved_per_url"`
+			} `json:"statuses"`
+		} `json:"configuration"`
+	}{}
+	if err := c.call(ctx, http.MethodGet, "/api/v2/instance", nil, "", nil, &result); err != nil {
+		return DefaultInstance
+	}
+	instance := &Instance{
+		MaxCharacters:            result.Configuration.Statuses.MaxCharacters,
+		MaxMediaAttachments:      result.Configuration.Statuses.MaxMediaAttachments,
+		CharactersReservedPerURL: result.Configuration.Statuses.CharactersReservedPerURL,
+	}
+	if instance.MaxCharacters <= 0 {
+		instance.MaxCharacters = DefaultInstance.MaxCharacters
+	}
+	if instance.MaxMediaAttachments <= 0 {
+		instance.MaxMediaAttachments = DefaultInstance.MaxMediaAttachments
+	}
+	if instance.CharactersReservedPerURL <= 0 {
+		instance.CharactersReservedPerURL = DefaultInstance.CharactersReservedPerURL
+	}
+	return instance
+}
+
+// UploadMedia uploads the file as a media, and waits for it to be processed. It returns the id of the media.
+func (c *Client) UploadMedia(ctx context.Context, filename string, data []byte) (string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create form file")
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", errors.Wrap(err, "failed to write form file")
+	}
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to close form")
+	}
+	media := struct {
+		ID  string  `json:"id"`
+		URL *string `json:"url"`
+	}{}
+	if err := c.call(ctx, http.MethodPost, "/api/v2/media", body, writer.FormDataContentType(), nil, &media); err != nil {
+		return "", err
+	}
+	// The large media are processed asynchronously, their URLs are null until they are processed.
+	for i := 0; media.URL == nil && i < maxMediaPolls; i++ {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(mediaPollInterval):
+		}
+		if err := c.call(ctx, http.MethodGet, "/api/v1/media/"+url.PathEscape(media.ID), nil, "", nil, &media); err != nil {
+			return "", err
+		}
+	}
+	return media.ID, nil
+}
+
+// PostStatus posts the public status with the media. The statuses of the same idempotency key are posted once.
+func (c *Client) PostStatus(ctx context.Context, text string, mediaIDs []string, idempotencyKey string) (*Status, error) {
+	payload, err := json.Marshal(map[string]any{
+		"status":     text,
+		"media_ids":  mediaIDs,
+		"visibility": "public",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal status")
+	}
+	header := http.Header{}
+	if idempotencyKey != "" {
+		header.Set("Idempotency-Key", idempotencyKey)
+	}
+	status := &Status{}
+	if err := c.call(ctx, http.MethodPost, "/api/v1/statuses", bytes.NewReader(payload), "application/json", header, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (c *Client) call(ctx context.Context, method, path string, body io.Reader, contentType string, header http.Header, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, c.serverURL+path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", path)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiError := struct {
+			Error string `json:"error"`
+		}{}
+		_ = json.Unmarshal(respBody, &apiError)
+		return errors.Errorf("failed to call %s, status %d: %s", path, resp.StatusCode, apiError.Error)
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response of %s", path)
+	}
+	return nil
+}
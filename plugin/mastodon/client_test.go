@@ -0,0 +1,74 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	mediaPollInterval = time.Millisecond
+	polls := 0
+	var status map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/instance", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"configuration":{"statuses":{"max_characters":1000,"max_media_attachments":4,"characters_reserved_per_url":23}}}`)
+	})
+	mux.HandleFunc("/api/v2/media", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		require.Equal(t, "cat.png", header.Filename)
+		require.Equal(t, "png", string(data))
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, `{"id":"1","url":null}`)
+	})
+	mux.HandleFunc("/api/v1/media/1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 2 {
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = io.WriteString(w, `{"id":"1","url":null}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"1","url":"https://files.example.com/1.png"}`)
+	})
+	mux.HandleFunc("/api/v1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "memo-uid", r.Header.Get("Idempotency-Key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		_, _ = io.WriteString(w, `{"id":"100","url":"https://mastodon.example.com/@bob/100"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL+"/", "token")
+	require.Equal(t, 1000, client.GetInstance(ctx).MaxCharacters)
+	mediaID, err := client.UploadMedia(ctx, "cat.png", []byte("png"))
+	require.NoError(t, err)
+	require.Equal(t, "1", mediaID)
+	require.Equal(t, 2, polls)
+	posted, err := client.PostStatus(ctx, "Hello", []string{mediaID}, "memo-uid")
+	require.NoError(t, err)
+	require.Equal(t, "100", posted.ID)
+	require.Equal(t, map[string]any{"status": "Hello", "media_ids": []any{"1"}, "visibility": "public"}, status)
+}
+
+func TestClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":"The access token is invalid"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "invalid")
+	require.Equal(t, DefaultInstance, client.GetInstance(context.Background()))
+	_, err := client.PostStatus(context.Background(), "Hello", nil, "")
+	require.ErrorContains(t, err, "The access token is invalid")
+}
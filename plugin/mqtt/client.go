@@ -0,0 +1,236 @@
+// Package mqtt is a client publishing the messages to an MQTT broker with MQTT 3.1.1.
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// dialTimeout is the timeout for connecting to the broker.
+const dialTimeout = 10 * time.Second
+
+// The types of the control packets.
+const (
+	packetConnect = 1
+	packetConnAck = 2
+	packetPublish = 3
+	packetPubAck  = 4
+)
+
+// Client publishes the messages with QoS 1, so a message is published once the broker acknowledges it.
+// The connection is opened by the first message and reopened by the next message after it's lost.
+// The keep alive is disabled, the broker doesn't close the idle connections.
+type Client struct {
+	url      *url.URL
+	clientID string
+
+	mutex    sync.Mutex
+	conn     net.Conn
+	reader   *bufio.Reader
+	packetID uint16
+}
+
+// NewClient returns the client of the broker of the URL, in the format of `mqtt://[user:password@]host:port`.
+// The scheme mqtts:// connects over TLS.
+func NewClient(rawURL, clientID string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid MQTT broker URL")
+	}
+	if u.Scheme != "mqtt" && u.Scheme != "mqtts" {
+		return nil, errors.Errorf("unsupported MQTT broker scheme %q", u.Scheme)
+	}
+	return &Client{
+		url:      u,
+		clientID: clientID,
+	}, nil
+}
+
+// Publish publishes the payload to the topic, and waits for the acknowledgement of the broker.
+func (c *Client) Publish(ctx context.Context, topic string, payload []byte, retain bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// The message is retried once on a new connection, in case the connection was closed by the broker.
+	for retry := 0; ; retry++ {
+		if c.conn == nil {
+			if err := c.connect(ctx); err != nil {
+				return errors.Wrap(err, "failed to connect to MQTT broker")
+			}
+		}
+		err := c.publish(ctx, topic, payload, retain)
+		if err == nil {
+			return nil
+		}
+		c.closeConn()
+		if retry > 0 {
+			return errors.Wrap(err, "failed to publish to MQTT broker")
+		}
+	}
+}
+
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.closeConn()
+	return nil
+}
+
+func (c *Client) closeConn() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// connect opens the connection with a clean session. The caller must hold the mutex.
+func (c *Client) connect(ctx context.Context) error {
+	address := c.url.Host
+	if c.url.Port() == "" {
+		port := "1883"
+		if c.url.Scheme == "mqtts" {
+			port = "8883"
+		}
+		address = net.JoinHostPort(c.url.Hostname(), port)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	if c.url.Scheme == "mqtts" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: c.url.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return errors.Wrap(err, "failed to handshake TLS")
+		}
+		conn = tlsConn
+	}
+	c.conn, c.reader = conn, bufio.NewReader(conn)
+
+	// The variable header is the protocol name, the protocol level 4, the connect flags and the keep alive of 0.
+	flags := byte(0x02)
+	body := appendString([]byte{}, "MQTT")
+	body = append(body, 4, 0, 0, 0)
+	body = appendString(body, c.clientID)
+	if c.url.User != nil {
+		flags |= 0x80
+		body = appendString(body, c.url.User.Username())
+		if password, ok := c.url.User.Password(); ok {
+			flags |= 0x40
+			body = appendString(body, password)
+		}
+	}
+	body[7] = flags
+	if err := c.writePacket(ctx, packetConnect<<4, body); err != nil {
+		c.closeConn()
+		return err
+	}
+	packetType, ack, err := c.readPacket(ctx)
+	if err != nil {
+		c.closeConn()
+		return err
+	}
+	if packetType != packetConnAck || len(ack) < 2 {
+		c.closeConn()
+		return errors.Errorf("unexpected packet type %d", packetType)
+	}
+	if ack[1] != 0 {
+		c.closeConn()
+		return errors.Errorf("connection refused with return code %d", ack[1])
+	}
+	return nil
+}
+
+func (c *Client) publish(ctx context.Context, topic string, payload []byte, retain bool) error {
+	c.packetID++
+	if c.packetID == 0 {
+		c.packetID = 1
+	}
+	header := byte(packetPublish<<4 | 0x02)
+	if retain {
+		header |= 0x01
+	}
+	body := appendString([]byte{}, topic)
+	body = binary.BigEndian.AppendUint16(body, c.packetID)
+	body = append(body, payload...)
+	if err := c.writePacket(ctx, header, body); err != nil {
+		return err
+	}
+	for {
+		packetType, ack, err := c.readPacket(ctx)
+		if err != nil {
+			return err
+		}
+		if packetType == packetPubAck && len(ack) >= 2 && binary.BigEndian.Uint16(ack) == c.packetID {
+			return nil
+		}
+	}
+}
+
+func (c *Client) writePacket(ctx context.Context, header byte, body []byte) error {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	packet := []byte{header}
+	// The remaining length is encoded in 7 bits per byte, the high bit means more bytes follow.
+	for length := len(body); ; {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		packet = append(packet, b)
+		if length == 0 {
+			break
+		}
+	}
+	_, err := c.conn.Write(append(packet, body...))
+	return err
+}
+
+func (c *Client) readPacket(ctx context.Context) (byte, []byte, error) {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return 0, nil, err
+	}
+	header, err := c.reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(b&0x7f) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header >> 4, body, nil
+}
+
+// appendString appends the string prefixed with its length.
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
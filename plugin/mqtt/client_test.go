@@ -0,0 +1,89 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type packet struct {
+	header byte
+	body   []byte
+}
+
+// serve accepts a connection on a local listener as the broker, which acknowledges the connection and the messages.
+func serve(t *testing.T, returnCode byte) (string, chan *packet) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	received := make(chan *packet, 4)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// The broker reads and writes the packets with a client of the connection.
+		broker := &Client{conn: conn, reader: bufio.NewReader(conn)}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for {
+			header, body, err := broker.readPacket(ctx)
+			if err != nil {
+				return
+			}
+			received <- &packet{header: header, body: body}
+			switch header {
+			case packetConnect:
+				_ = broker.writePacket(ctx, packetConnAck<<4, []byte{0, returnCode})
+			case packetPublish:
+				topicLength := binary.BigEndian.Uint16(body)
+				_ = broker.writePacket(ctx, packetPubAck<<4, body[2+topicLength:4+topicLength])
+			}
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestClientPublish(t *testing.T) {
+	address, received := serve(t, 0)
+	client, err := NewClient("mqtt://bob:secret@"+address, "memos-test")
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Publish(context.Background(), "memos/memo/created", []byte(`{"id":1}`), false))
+
+	connect := <-received
+	require.Equal(t, byte(packetConnect), connect.header)
+	body := appendString([]byte{}, "MQTT")
+	body = append(body, 4, 0xc2, 0, 0)
+	body = appendString(body, "memos-test")
+	body = appendString(body, "bob")
+	body = appendString(body, "secret")
+	require.Equal(t, body, connect.body)
+
+	publish := <-received
+	require.Equal(t, byte(packetPublish), publish.header)
+	body = appendString([]byte{}, "memos/memo/created")
+	body = append(body, 0, 1)
+	body = append(body, `{"id":1}`...)
+	require.Equal(t, body, publish.body)
+}
+
+func TestClientConnectionRefused(t *testing.T) {
+	address, _ := serve(t, 5)
+	client, err := NewClient("mqtt://"+address, "memos-test")
+	require.NoError(t, err)
+	defer client.Close()
+	err = client.Publish(context.Background(), "memos/memo/created", []byte(`{}`), false)
+	require.ErrorContains(t, err, "return code 5")
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("tcp://localhost:1883", "memos")
+	require.ErrorContains(t, err, "unsupported MQTT broker scheme")
+}
@@ -0,0 +1,105 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// requestTimeout is the timeout for recognizing a single image.
+const requestTimeout = 2 * time.Minute
+
+// Engine recognizes the text in images.
+type Engine interface {
+	Recognize(ctx context.Context, image []byte) (string, error)
+}
+
+// TesseractEngine recognizes the text with the tesseract command.
+type TesseractEngine struct {
+	// Command is the path of the tesseract command, default is `tesseract` in PATH.
+	Command string
+	// Language is the tesseract language, e.g. `eng` or `eng+deu`.
+	Language string
+}
+
+func (e *TesseractEngine) Recognize(ctx context.Context, image []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	command := e.Command
+	if command == "" {
+		command = "tesseract"
+	}
+	args := []string{"stdin", "stdout"}
+	if e.Language != "" {
+		args = append(args, "-l", e.Language)
+	}
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdin = bytes.NewReader(image)
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to run tesseract: %s", strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// HTTPEngine recognizes the text with an OCR service, e.g. a tesseract sidecar.
+// The image is posted as the request body, and the service responds with the text
+// either as plain text or as JSON in the format of `{"text": "..."}`.
+type HTTPEngine struct {
+	Endpoint string
+	Language string
+}
+
+func (e *HTTPEngine) Recognize(ctx context.Context, image []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	endpoint, err := url.Parse(e.Endpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse endpoint")
+	}
+	if e.Language != "" {
+		query := endpoint.Query()
+		query.Set("lang", e.Language)
+		endpoint.RawQuery = query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(image))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to post image")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", errors.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		result := struct {
+			Text string `json:"text"`
+		}{}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return "", errors.Wrap(err, "failed to unmarshal response body")
+		}
+		return strings.TrimSpace(result.Text), nil
+	}
+	return strings.TrimSpace(string(body)), nil
+}
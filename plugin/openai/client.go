@@ -0,0 +1,135 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// requestTimeout is the timeout of the requests to the API, the local models may be slow to answer.
+const requestTimeout = 2 * time.Minute
+
+// Client is the client of an OpenAI compatible API, e.g. OpenAI, or Ollama and the other local servers.
+type Client struct {
+	// endpoint is the base URL of the API, which the paths like `/chat/completions` are joined to.
+	endpoint   string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewClient returns the client of the API at the endpoint, e.g. `https://api.openai.com/v1` or `http://localhost:11434/v1`.
+// The API key is optional for the servers which don't need one.
+func NewClient(endpoint, apiKey string) *Client {
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// ChatCompletion returns the message of the model completing the conversation.
+func (c *Client) ChatCompletion(ctx context.Context, model string, messages []ChatCompletionMessage) (string, error) {
+	request := map[string]any{
+		"model":       model,
+		"messages":    messages,
+		"temperature": 0,
+	}
+	response := &struct {
+		Choices []ChatCompletionChoice `json:"choices"`
+	}{}
+	if err := c.post(ctx, "/chat/completions", request, response); err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 || response.Choices[0].Message == nil {
+		return "", errors.New("no choices in the chat completion")
+	}
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
+
+// CreateEmbeddings returns the embedding vectors of the inputs, in the order of the inputs.
+func (c *Client) CreateEmbeddings(ctx context.Context, model string, inputs []string) ([][]float32, error) {
+	request := map[string]any{
+		"model": model,
+		"input": inputs,
+	}
+	response := &struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}{}
+	if err := c.post(ctx, "/embeddings", request, response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) != len(inputs) {
+		return nil, errors.Errorf("got %d embeddings of %d inputs", len(response.Data), len(inputs))
+	}
+	embeddings := make([][]float32, len(inputs))
+	for _, data := range response.Data {
+		if data.Index < 0 || data.Index >= len(inputs) || len(data.Embedding) == 0 {
+			return nil, errors.Errorf("invalid embedding of index %d", data.Index)
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+	return embeddings, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, request, response any) error {
+	requestURL, err := url.JoinPath(c.endpoint, path)
+	if err != nil {
+		return errors.Wrap(err, "invalid endpoint")
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to request %s", path)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// The servers answer the errors as `{"error": {"message": ...}}`, or `{"error": "..."}` by some local servers.
+		errorResponse := &struct {
+			Error json.RawMessage `json:"error"`
+		}{}
+		message := strings.TrimSpace(string(data))
+		if err := json.Unmarshal(data, errorResponse); err == nil && len(errorResponse.Error) > 0 {
+			apiError := &apiError{}
+			if err := json.Unmarshal(errorResponse.Error, apiError); err == nil && apiError.Message != "" {
+				message = apiError.Message
+			} else if err := json.Unmarshal(errorResponse.Error, &message); err != nil {
+				message = string(errorResponse.Error)
+			}
+		}
+		return fmt.Errorf("%s returned status %d: %s", path, resp.StatusCode, message)
+	}
+	if err := json.Unmarshal(data, response); err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+	return nil
+}
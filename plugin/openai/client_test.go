@@ -0,0 +1,47 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := map[string]any{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			require.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+			require.Equal(t, "llama3", request["model"])
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":" A summary. "}}]}`))
+		case "/v1/embeddings":
+			if request["model"] == "missing" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"message":"model \"missing\" not found"}}`))
+				return
+			}
+			// The embeddings are answered out of order.
+			_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/v1/", "key")
+	content, err := client.ChatCompletion(context.Background(), "llama3", []ChatCompletionMessage{{Role: "user", Content: "Summarize"}})
+	require.NoError(t, err)
+	require.Equal(t, "A summary.", content)
+
+	embeddings, err := client.CreateEmbeddings(context.Background(), "nomic-embed-text", []string{"a", "b"})
+	require.NoError(t, err)
+	require.Equal(t, [][]float32{{1, 0}, {0, 1}}, embeddings)
+
+	_, err = client.CreateEmbeddings(context.Background(), "missing", []string{"a"})
+	require.ErrorContains(t, err, `model "missing" not found`)
+}
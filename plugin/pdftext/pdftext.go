@@ -0,0 +1,37 @@
+package pdftext
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/pkg/errors"
+)
+
+// MaxTextLength is the max length in bytes of the extracted text, the rest is dropped.
+const MaxTextLength = 1 << 20
+
+// ExtractText extracts the plain text from the PDF document.
+func ExtractText(blob []byte) (text string, err error) {
+	// The PDF reader panics on some malformed documents.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to parse pdf: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(blob), int64(len(blob)))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open pdf")
+	}
+	textReader, err := reader.GetPlainText()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to extract text")
+	}
+	buf := new(strings.Builder)
+	if _, err := io.Copy(buf, io.LimitReader(textReader, MaxTextLength)); err != nil {
+		return "", errors.Wrap(err, "failed to read text")
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
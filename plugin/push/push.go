@@ -0,0 +1,129 @@
+// Package push delivers the notifications to the self-hosted push services, ntfy and Gotify.
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// timeout is the timeout of the requests to the push services.
+var timeout = 10 * time.Second
+
+const (
+	// ProviderNtfy is ntfy, see https://docs.ntfy.sh/publish/.
+	ProviderNtfy = "ntfy"
+	// ProviderGotify is Gotify, see https://gotify.net/docs/pushmsg.
+	ProviderGotify = "gotify"
+)
+
+// Config is the service the notifications are pushed to.
+type Config struct {
+	Provider string
+	// ServerURL is the URL of the server, e.g. https://ntfy.sh.
+	ServerURL string
+	// Topic is the topic of ntfy, unused by Gotify.
+	Topic string
+	// Token is the access token of ntfy, or the application token of Gotify.
+	Token string
+}
+
+// Message is the notification, the URL is opened when the notification is clicked.
+type Message struct {
+	Title string
+	Text  string
+	URL   string
+}
+
+// Validate returns an error if the config is missing the fields its provider requires.
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.ServerURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the server URL must be an http or https URL")
+	}
+	switch c.Provider {
+	case ProviderNtfy:
+		if c.Topic == "" || strings.Contains(c.Topic, "/") {
+			return errors.New("the topic of ntfy is required, and can't contain slashes")
+		}
+	case ProviderGotify:
+		if c.Token == "" {
+			return errors.New("the application token of Gotify is required")
+		}
+	default:
+		return errors.Errorf("unsupported push provider %q", c.Provider)
+	}
+	return nil
+}
+
+// Send pushes the message to the service of the config.
+func Send(ctx context.Context, config *Config, message *Message) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	serverURL := strings.TrimSuffix(config.ServerURL, "/")
+	var endpoint string
+	var payload map[string]any
+	header := http.Header{}
+	switch config.Provider {
+	case ProviderNtfy:
+		// The message is published as JSON to the root of the server, so the title isn't limited to the characters of the headers.
+		endpoint = serverURL
+		payload = map[string]any{
+			"topic":   config.Topic,
+			"title":   message.Title,
+			"message": message.Text,
+		}
+		if message.URL != "" {
+			payload["click"] = message.URL
+		}
+		if config.Token != "" {
+			header.Set("Authorization", "Bearer "+config.Token)
+		}
+	case ProviderGotify:
+		endpoint = serverURL + "/message"
+		payload = map[string]any{
+			"title":    message.Title,
+			"message":  message.Text,
+			"priority": 5,
+		}
+		if message.URL != "" {
+			payload["extras"] = map[string]any{
+				"client::notification": map[string]any{
+					"click": map[string]any{"url": message.URL},
+				},
+			}
+		}
+		header.Set("X-Gotify-Key", config.Token)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post to %s", config.Provider)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("%s responded with status %d: %s", config.Provider, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendNtfy(t *testing.T) {
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/", r.URL.Path)
+		require.Equal(t, "Bearer tk_secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	err := Send(context.Background(), &Config{
+		Provider:  ProviderNtfy,
+		ServerURL: server.URL + "/",
+		Topic:     "memos",
+		Token:     "tk_secret",
+	}, &Message{Title: "Memo reminder", Text: "Buy milk", URL: "https://memos.example.com/m/1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"topic":   "memos",
+		"title":   "Memo reminder",
+		"message": "Buy milk",
+		"click":   "https://memos.example.com/m/1",
+	}, payload)
+}
+
+func TestSendGotify(t *testing.T) {
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/message", r.URL.Path)
+		require.Equal(t, "app_token", r.Header.Get("X-Gotify-Key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	err := Send(context.Background(), &Config{
+		Provider:  ProviderGotify,
+		ServerURL: server.URL,
+		Token:     "app_token",
+	}, &Message{Title: "Memo reminder", Text: "Buy milk"})
+	require.NoError(t, err)
+	require.Equal(t, "Buy milk", payload["message"])
+	require.Nil(t, payload["extras"])
+}
+
+func TestSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	err := Send(context.Background(), &Config{Provider: ProviderGotify, ServerURL: server.URL, Token: "invalid"}, &Message{Text: "Buy milk"})
+	require.ErrorContains(t, err, "status 401")
+}
+
+func TestValidate(t *testing.T) {
+	require.Error(t, (&Config{Provider: ProviderNtfy, ServerURL: "ftp://ntfy.sh", Topic: "memos"}).Validate())
+	require.Error(t, (&Config{Provider: ProviderNtfy, ServerURL: "https://ntfy.sh"}).Validate())
+	require.Error(t, (&Config{Provider: ProviderGotify, ServerURL: "https://gotify.example.com"}).Validate())
+	require.Error(t, (&Config{Provider: "pushover", ServerURL: "https://api.pushover.net"}).Validate())
+	require.NoError(t, (&Config{Provider: ProviderNtfy, ServerURL: "https://ntfy.sh", Topic: "memos"}).Validate())
+}
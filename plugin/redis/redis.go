@@ -0,0 +1,247 @@
+// Package redis is a minimal client of Redis, for the event streams and the state shared by the replicas of a deployment.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPort = "6379"
+	// dialTimeout is the timeout for connecting to Redis.
+	dialTimeout = 10 * time.Second
+	// maxIdleConns is the number of the idle connections kept for the next commands.
+	maxIdleConns = 16
+)
+
+// Error is an error replied by Redis, which isn't fixed by retrying the command.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Client sends the commands to Redis over a pool of connections, which are opened on demand.
+type Client struct {
+	url *url.URL
+	db  int
+	// idle is the pool of the idle connections.
+	idle chan *conn
+}
+
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// NewClient returns the client of the URL in the format of `redis://[user:password@]host:port[/db]`,
+// the scheme rediss:// connects over TLS. It doesn't connect until the first command.
+func NewClient(rawURL string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Redis URL")
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, errors.Errorf("unsupported Redis scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("Redis host is required")
+	}
+	c := &Client{
+		url:  u,
+		idle: make(chan *conn, maxIdleConns),
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil {
+			return nil, errors.Errorf("invalid Redis database %q", db)
+		}
+	}
+	return c, nil
+}
+
+// Do sends the command and returns the reply, which is a string, an int64, nil or a []any of them.
+// The command is retried once on a new connection if an idle connection was closed by the server.
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	for retry := 0; ; retry++ {
+		cn, reused, err := c.get(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to Redis")
+		}
+		reply, err := cn.do(ctx, args...)
+		if err == nil {
+			c.put(cn)
+			return reply, nil
+		}
+		if _, ok := err.(Error); ok {
+			c.put(cn)
+			return nil, err
+		}
+		cn.Close()
+		if !reused || retry > 0 {
+			return nil, err
+		}
+	}
+}
+
+// Close closes the idle connections.
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *Client) get(ctx context.Context) (*conn, bool, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, true, nil
+	default:
+	}
+	cn, err := c.connect(ctx)
+	return cn, false, err
+}
+
+func (c *Client) put(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+// connect opens a connection, authenticates and selects the database.
+func (c *Client) connect(ctx context.Context) (*conn, error) {
+	address := c.url.Host
+	if c.url.Port() == "" {
+		address = net.JoinHostPort(c.url.Hostname(), defaultPort)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if c.url.Scheme == "rediss" {
+		tlsConn := tls.Client(netConn, &tls.Config{ServerName: c.url.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			netConn.Close()
+			return nil, errors.Wrap(err, "failed to handshake TLS")
+		}
+		netConn = tlsConn
+	}
+	cn := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	if c.url.User != nil {
+		args := []string{"AUTH"}
+		if password, ok := c.url.User.Password(); ok {
+			// The user is optional, e.g. redis://:password@host.
+			if username := c.url.User.Username(); username != "" {
+				args = append(args, username)
+			}
+			args = append(args, password)
+		} else {
+			args = append(args, c.url.User.Username())
+		}
+		if _, err := cn.do(ctx, args...); err != nil {
+			cn.Close()
+			return nil, errors.Wrap(err, "failed to authenticate")
+		}
+	}
+	if c.db != 0 {
+		if _, err := cn.do(ctx, "SELECT", strconv.Itoa(c.db)); err != nil {
+			cn.Close()
+			return nil, errors.Wrap(err, "failed to select database")
+		}
+	}
+	return cn, nil
+}
+
+func (cn *conn) do(ctx context.Context, args ...string) (any, error) {
+	// The deadline of the context, or none.
+	deadline, _ := ctx.Deadline()
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	command := &strings.Builder{}
+	fmt.Fprintf(command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := cn.Write([]byte(command.String())); err != nil {
+		return nil, err
+	}
+	return readReply(cn.reader)
+}
+
+// readReply reads a reply of RESP2: the simple strings, the errors, the integers, the bulk strings and the arrays.
+// The error replies nested in the arrays are returned as Error values.
+func readReply(reader *bufio.Reader) (any, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid reply: %s", line)
+		}
+		return n, nil
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errors.Errorf("invalid reply: %s", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errors.Errorf("invalid reply: %s", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		items := make([]any, size)
+		for i := range items {
+			item, err := readReply(reader)
+			if err != nil {
+				if replyErr, ok := err.(Error); ok {
+					items[i] = replyErr
+					continue
+				}
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, errors.Errorf("unexpected reply: %s", line)
+	}
+}
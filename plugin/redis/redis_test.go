@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serve serves the connections with a fake Redis, which replies to the commands with handle.
+func serve(t *testing.T, handle func(args []string) string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					reply, err := readReply(reader)
+					if err != nil {
+						return
+					}
+					args := []string{}
+					for _, arg := range reply.([]any) {
+						args = append(args, arg.(string))
+					}
+					if _, err := io.WriteString(conn, handle(args)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func bulkString(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func TestSharedState(t *testing.T) {
+	values := map[string]string{}
+	commands := make(chan string, 16)
+	address := serve(t, func(args []string) string {
+		commands <- strings.Join(args, " ")
+		switch args[0] {
+		case "AUTH", "SELECT":
+			return "+OK\r\n"
+		case "GET":
+			value, ok := values[args[1]]
+			if !ok {
+				return "$-1\r\n"
+			}
+			return bulkString(value)
+		case "SET":
+			if _, ok := values[args[1]]; ok && args[3] == "NX" {
+				return "$-1\r\n"
+			}
+			values[args[1]] = args[2]
+			return "+OK\r\n"
+		case "EVAL":
+			return "*2\r\n:3\r\n:59000\r\n"
+		}
+		return "-ERR unknown command\r\n"
+	})
+
+	client, err := NewClient("redis://:secret@" + address + "/2")
+	require.NoError(t, err)
+	defer client.Close()
+	shared := NewSharedState(client, "memos:")
+	ctx := context.Background()
+
+	_, ok, err := shared.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, "AUTH secret", <-commands)
+	require.Equal(t, "SELECT 2", <-commands)
+	require.Equal(t, "GET memos:key", <-commands)
+	require.NoError(t, shared.Set(ctx, "key", []byte("value"), time.Minute))
+	require.Equal(t, "SET memos:key value PX 60000", <-commands)
+	require.NoError(t, shared.Add(ctx, "key", []byte("other"), time.Minute))
+	<-commands
+	value, ok, err := shared.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "value", string(value))
+	<-commands
+
+	count, ttl, err := shared.Increment(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+	require.Equal(t, 59*time.Second, ttl)
+	<-commands
+
+	unlock, ok, err := shared.TryLock(ctx, "lock", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotNil(t, unlock)
+	<-commands
+	_, ok, err = shared.TryLock(ctx, "lock", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+	<-commands
+
+	// The error replies aren't retried.
+	_, err = client.Do(ctx, "UNKNOWN")
+	var redisErr Error
+	require.ErrorAs(t, err, &redisErr)
+}
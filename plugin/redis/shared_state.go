@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// incrementScript increments the counter and starts its expiration with the first increment, it returns the count and the TTL in milliseconds.
+const incrementScript = `local count = redis.call('INCR', KEYS[1])
+if count == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return {count, redis.call('PTTL', KEYS[1])}`
+
+// unlockScript deletes the lock only if it's still held with the token, so an expired lock taken by another replica isn't released.
+const unlockScript = `if redis.call('GET', KEYS[1]) == ARGV[1] then return redis.call('DEL', KEYS[1]) end
+return 0`
+
+// SharedState is the state shared by the replicas on Redis, all of its keys have the prefix.
+type SharedState struct {
+	client *Client
+	prefix string
+}
+
+func NewSharedState(client *Client, prefix string) *SharedState {
+	return &SharedState{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get returns the value of the key, ok is false if the key doesn't exist.
+func (s *SharedState) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.client.Do(ctx, "GET", s.prefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return nil, false, errors.Errorf("unexpected reply of GET: %v", reply)
+	}
+	return []byte(value), true, nil
+}
+
+// Set sets the value of the key, which expires after the TTL.
+func (s *SharedState) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.client.Do(ctx, "SET", s.prefix+key, string(value), "PX", formatMillis(ttl))
+	return err
+}
+
+// Add sets the value of the key like Set, unless the key exists.
+func (s *SharedState) Add(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.client.Do(ctx, "SET", s.prefix+key, string(value), "NX", "PX", formatMillis(ttl))
+	return err
+}
+
+func (s *SharedState) Delete(ctx context.Context, key string) error {
+	_, err := s.client.Do(ctx, "DEL", s.prefix+key)
+	return err
+}
+
+// Increment increments the counter of the key, which expires after the window from the first increment.
+// It returns the count and the time left until the counter expires.
+func (s *SharedState) Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	reply, err := s.client.Do(ctx, "EVAL", incrementScript, "1", s.prefix+key, formatMillis(window))
+	if err != nil {
+		return 0, 0, err
+	}
+	items, ok := reply.([]any)
+	if !ok || len(items) != 2 {
+		return 0, 0, errors.Errorf("unexpected reply of increment: %v", reply)
+	}
+	count, countOK := items[0].(int64)
+	ttl, ttlOK := items[1].(int64)
+	if !countOK || !ttlOK {
+		return 0, 0, errors.Errorf("unexpected reply of increment: %v", reply)
+	}
+	// The TTL is negative if the counter has no expiration, e.g. if it was set by another client.
+	if ttl < 0 {
+		ttl = window.Milliseconds()
+	}
+	return count, time.Duration(ttl) * time.Millisecond, nil
+}
+
+// TryLock takes the lock of the key for the TTL, unless another client holds it.
+// The returned function releases the lock before it expires.
+func (s *SharedState) TryLock(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, bool, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, false, err
+	}
+	token := hex.EncodeToString(buf)
+	reply, err := s.client.Do(ctx, "SET", s.prefix+key, token, "NX", "PX", formatMillis(ttl))
+	if err != nil {
+		return nil, false, err
+	}
+	// The reply is nil if the key exists.
+	if reply == nil {
+		return nil, false, nil
+	}
+	return func(ctx context.Context) error {
+		_, err := s.client.Do(ctx, "EVAL", unlockScript, "1", s.prefix+key, token)
+		return err
+	}, true, nil
+}
+
+func formatMillis(d time.Duration) string {
+	// Redis rejects the expirations which aren't positive.
+	return strconv.FormatInt(max(d.Milliseconds(), 1), 10)
+}
@@ -0,0 +1,84 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ClamAVScanner scans files with a ClamAV daemon using the INSTREAM command.
+type ClamAVScanner struct {
+	// Address is the address of clamd, e.g. `tcp://127.0.0.1:3310` or `unix:///run/clamav/clamd.ctl`.
+	Address string
+}
+
+func NewClamAVScanner(address string) *ClamAVScanner {
+	return &ClamAVScanner{
+		Address: address,
+	}
+}
+
+func (s *ClamAVScanner) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	conn, err := dial(ctx, s.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to clamd")
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, errors.Wrap(err, "failed to send command")
+	}
+	// The content is sent as chunks prefixed with the length, and terminated by a zero length chunk.
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return nil, errors.Wrap(err, "failed to send chunk size")
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return nil, errors.Wrap(err, "failed to send chunk")
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read file")
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return nil, errors.Wrap(err, "failed to send end of stream")
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read reply")
+	}
+	return parseClamAVReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamAVReply parses the reply in the format of `stream: OK` or `stream: {signature} FOUND`.
+func parseClamAVReply(reply string) (*Result, error) {
+	_, status, found := strings.Cut(reply, ": ")
+	if !found {
+		return nil, errors.Errorf("unexpected reply: %s", reply)
+	}
+	if status == "OK" {
+		return &Result{}, nil
+	}
+	if signature, found := strings.CutSuffix(status, " FOUND"); found {
+		return &Result{
+			Infected:  true,
+			Signature: signature,
+		}, nil
+	}
+	return nil, errors.Errorf("scan failed: %s", status)
+}
@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ICAPScanner scans files with an ICAP server using the RESPMOD method.
+type ICAPScanner struct {
+	// Address is the ICAP service URL, e.g. `icap://127.0.0.1:1344/avscan`.
+	Address string
+}
+
+func NewICAPScanner(address string) *ICAPScanner {
+	return &ICAPScanner{
+		Address: address,
+	}
+}
+
+func (s *ICAPScanner) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	serviceURL, err := url.Parse(s.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse icap address")
+	}
+	if serviceURL.Scheme != "icap" {
+		return nil, errors.Errorf("unsupported scheme: %s", serviceURL.Scheme)
+	}
+	host := serviceURL.Host
+	if serviceURL.Port() == "" {
+		host = fmt.Sprintf("%s:1344", serviceURL.Hostname())
+	}
+	conn, err := dial(ctx, host)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to icap server")
+	}
+	defer conn.Close()
+
+	// The file is encapsulated as the body of an HTTP response.
+	httpHeader := "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\nTransfer-Encoding: chunked\r\n\r\n"
+	writer := bufio.NewWriter(conn)
+	fmt.Fprintf(writer, "RESPMOD %s ICAP/1.0\r\n", serviceURL.String())
+	fmt.Fprintf(writer, "Host: %s\r\n", serviceURL.Host)
+	fmt.Fprintf(writer, "Allow: 204\r\n")
+	fmt.Fprintf(writer, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(httpHeader))
+	writer.WriteString(httpHeader)
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(writer, "%x\r\n", n)
+			writer.Write(buf[:n])
+			writer.WriteString("\r\n")
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read file")
+		}
+	}
+	writer.WriteString("0\r\n\r\n")
+	if err := writer.Flush(); err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	statusLine, err := reader.ReadLine()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read response header")
+	}
+	return parseICAPResponse(statusLine, header)
+}
+
+// parseICAPResponse parses the ICAP response. 204 means the file is not modified, so it's clean.
+// Otherwise the server replaces the content and reports the threat in the headers.
+func parseICAPResponse(statusLine string, header textproto.MIMEHeader) (*Result, error) {
+	fields := strings.Fields(statusLine)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return nil, errors.Errorf("unexpected response: %s", statusLine)
+	}
+	switch fields[1] {
+	case "204":
+		return &Result{}, nil
+	case "200":
+		signature := header.Get("X-Virus-ID")
+		if infection := header.Get("X-Infection-Found"); infection != "" {
+			// The format is `Type=0; Resolution=2; Threat={signature};`.
+			for _, field := range strings.Split(infection, ";") {
+				if value, found := strings.CutPrefix(strings.TrimSpace(field), "Threat="); found {
+					signature = value
+				}
+			}
+		}
+		return &Result{
+			Infected:  true,
+			Signature: signature,
+		}, nil
+	default:
+		return nil, errors.Errorf("scan failed: %s", statusLine)
+	}
+}
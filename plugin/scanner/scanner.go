@@ -0,0 +1,49 @@
+package scanner
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// dialTimeout is the timeout for connecting to the scanner.
+const dialTimeout = 10 * time.Second
+
+// chunkSize is the size of chunks the file content is streamed to the scanner in.
+const chunkSize = 32 * 1024
+
+// Result is the result of scanning a file.
+type Result struct {
+	Infected bool
+	// Signature is the name of the threat found in the file, if the scanner reports it.
+	Signature string
+}
+
+type Scanner interface {
+	// Scan submits the content of r to the scanner and returns the result.
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// dial connects to the address in the format of `unix:///path/to/socket`, `tcp://host:port` or `host:port`.
+func dial(ctx context.Context, address string) (net.Conn, error) {
+	network := "tcp"
+	if strings.HasPrefix(address, "unix://") {
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+	} else {
+		address = strings.TrimPrefix(address, "tcp://")
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
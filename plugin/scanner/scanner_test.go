@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClamAVReply(t *testing.T) {
+	tests := []struct {
+		reply  string
+		result *Result
+	}{
+		{
+			reply:  "stream: OK",
+			result: &Result{},
+		},
+		{
+			reply: "stream: Eicar-Test-Signature FOUND",
+			result: &Result{
+				Infected:  true,
+				Signature: "Eicar-Test-Signature",
+			},
+		},
+	}
+	for _, test := range tests {
+		result, err := parseClamAVReply(test.reply)
+		require.NoError(t, err)
+		require.Equal(t, test.result, result)
+	}
+
+	_, err := parseClamAVReply("INSTREAM size limit exceeded. ERROR")
+	require.Error(t, err)
+}
+
+func TestParseICAPResponse(t *testing.T) {
+	result, err := parseICAPResponse("ICAP/1.0 204 No Content", textproto.MIMEHeader{})
+	require.NoError(t, err)
+	require.False(t, result.Infected)
+
+	result, err = parseICAPResponse("ICAP/1.0 200 OK", textproto.MIMEHeader{
+		"X-Infection-Found": []string{"Type=0; Resolution=2; Threat=Eicar-Test-Signature;"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Result{Infected: true, Signature: "Eicar-Test-Signature"}, result)
+
+	_, err = parseICAPResponse("ICAP/1.0 500 Server Error", textproto.MIMEHeader{})
+	require.Error(t, err)
+}
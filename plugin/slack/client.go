@@ -0,0 +1,91 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// baseURL is the base URL of the Slack Web API.
+	baseURL = "https://slack.com/api"
+	// timeout is the timeout of the requests to the Slack Web API.
+	timeout = 10 * time.Second
+)
+
+// Attachment is a legacy message attachment, used for the unfurls.
+type Attachment struct {
+	Title     string `json:"title,omitempty"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Footer    string `json:"footer,omitempty"`
+	Ts        int64  `json:"ts,omitempty"`
+}
+
+// Client is the client of the Slack Web API authorized by a bot token.
+type Client struct {
+	token string
+}
+
+func NewClient(token string) *Client {
+	return &Client{token: token}
+}
+
+// PostMessage posts the message to the channel, see https://api.slack.com/methods/chat.postMessage.
+func (c *Client) PostMessage(ctx context.Context, channel, text string) error {
+	return c.call(ctx, "chat.postMessage", map[string]any{
+		"channel": channel,
+		"text":    text,
+	})
+}
+
+// Unfurl unfurls the links in the message of the channel, the unfurls are keyed by the links,
+// see https://api.slack.com/methods/chat.unfurl.
+func (c *Client) Unfurl(ctx context.Context, channel, messageTs string, unfurls map[string]*Attachment) error {
+	return c.call(ctx, "chat.unfurl", map[string]any{
+		"channel": channel,
+		"ts":      messageTs,
+		"unfurls": unfurls,
+	})
+}
+
+func (c *Client) call(ctx context.Context, method string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", method)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	result := struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response of %s, status %d", method, resp.StatusCode)
+	}
+	if !result.OK {
+		return errors.Errorf("failed to call %s: %s", method, result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package slack
+
+import (
+	"net/url"
+)
+
+const (
+	// EventTypeURLVerification is the type of the request verifying the URL of the events.
+	EventTypeURLVerification = "url_verification"
+	// EventTypeEventCallback is the type of the request of a subscribed event.
+	EventTypeEventCallback = "event_callback"
+	// EventTypeLinkShared is the type of the event of the links shared in a message.
+	EventTypeLinkShared = "link_shared"
+)
+
+// EventRequest is the request of the Events API, see https://api.slack.com/apis/connections/events-api.
+type EventRequest struct {
+	Type string `json:"type"`
+	// Challenge is set for the url_verification requests, which is responded as is.
+	Challenge string `json:"challenge"`
+	TeamID    string `json:"team_id"`
+	Event     *Event `json:"event"`
+}
+
+// Event is the inner event of an event_callback request.
+type Event struct {
+	Type      string `json:"type"`
+	User      string `json:"user"`
+	Channel   string `json:"channel"`
+	MessageTs string `json:"message_ts"`
+	Links     []Link `json:"links"`
+}
+
+type Link struct {
+	Domain string `json:"domain"`
+	URL    string `json:"url"`
+}
+
+// SlashCommand is the form of the request of a slash command, see https://api.slack.com/interactivity/slash-commands.
+type SlashCommand struct {
+	TeamID      string
+	ChannelID   string
+	UserID      string
+	Command     string
+	Text        string
+	ResponseURL string
+}
+
+func ParseSlashCommand(form url.Values) *SlashCommand {
+	return &SlashCommand{
+		TeamID:      form.Get("team_id"),
+		ChannelID:   form.Get("channel_id"),
+		UserID:      form.Get("user_id"),
+		Command:     form.Get("command"),
+		Text:        form.Get("text"),
+		ResponseURL: form.Get("response_url"),
+	}
+}
+
+// CommandResponse is the response of a slash command.
+type CommandResponse struct {
+	// ResponseType is either ephemeral, only visible to the user, or in_channel.
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
@@ -0,0 +1,57 @@
+// Package slack implements the parts of the Slack API used by the Slack app integration:
+// verifying the requests signed by Slack, and posting and unfurling messages.
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader is the header of the signature of the requests sent by Slack.
+	SignatureHeader = "X-Slack-Signature"
+	// TimestampHeader is the header of the time the request was sent by Slack.
+	TimestampHeader = "X-Slack-Request-Timestamp"
+
+	// maxRequestAge is the max age of the requests accepted, so the replayed requests are rejected.
+	maxRequestAge = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidSignature is returned if the signature of the request doesn't match the signing secret.
+	ErrInvalidSignature = errors.New("invalid slack signature")
+	// ErrExpiredRequest is returned if the request was sent too long ago.
+	ErrExpiredRequest = errors.New("expired slack request")
+)
+
+// Sign returns the signature of the body sent at the timestamp, in the format of "v0=<hex digest>".
+func Sign(signingSecret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequest verifies the request is signed by Slack with the signing secret of the app,
+// see https://api.slack.com/authentication/verifying-requests-from-slack.
+func VerifyRequest(signingSecret, timestamp, signature string, body []byte, now time.Time) error {
+	if signingSecret == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSignature, "invalid timestamp")
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return ErrExpiredRequest
+	}
+	if !hmac.Equal([]byte(Sign(signingSecret, timestamp, body)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
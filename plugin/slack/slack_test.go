@@ -0,0 +1,23 @@
+package slack
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Unix(1531420618, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&command=%2Fmemo&text=hello")
+	signature := Sign("secret", timestamp, body)
+
+	require.NoError(t, VerifyRequest("secret", timestamp, signature, body, now))
+	require.ErrorIs(t, VerifyRequest("other", timestamp, signature, body, now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifyRequest("secret", timestamp, signature, []byte("text=changed"), now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifyRequest("", timestamp, signature, body, now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifyRequest("secret", "abc", signature, body, now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifyRequest("secret", timestamp, signature, body, now.Add(10*time.Minute)), ErrExpiredRequest)
+}
@@ -5,29 +5,41 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	s3config "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
 const LinkLifetime = 24 * time.Hour
 
+// ReferencePrefix is the prefix of the internal path of resources uploaded to a S3 storage by the server.
+// The full reference is in the format of `s3://{storageID}/{key}`.
+const ReferencePrefix = "s3://"
+
+// UploadLifetime is the lifetime of pre-signed upload requests.
+const UploadLifetime = 15 * time.Minute
+
+// Config is the configuration of a S3 storage. The json tags match the storage config stored in database.
 type Config struct {
-	AccessKey string
-	SecretKey string
-	Bucket    string
-	EndPoint  string
-	Region    string
-	URLPrefix string
-	URLSuffix string
-	PreSign   bool
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	Bucket    string `json:"bucket"`
+	EndPoint  string `json:"endPoint"`
+	Region    string `json:"region"`
+	URLPrefix string `json:"urlPrefix"`
+	URLSuffix string `json:"urlSuffix"`
+	PreSign   bool   `json:"presign"`
 }
 
 type Client struct {
@@ -60,6 +72,8 @@ func NewClient(ctx context.Context, config *Config) (*Client, error) {
 		return nil, err
 	}
 
+	// Trace the requests to the object storage, the spans are recorded only when the telemetry is exported.
+	otelaws.AppendMiddlewares(&awsConfig.APIOptions)
 	client := awss3.NewFromConfig(awsConfig)
 
 	return &Client{
@@ -85,7 +99,38 @@ func (client *Client) UploadFile(ctx context.Context, filename string, fileType
 		return "", err
 	}
 
-	link := uploadOutput.Location
+	return client.formatLink(ctx, filename, uploadOutput.Location)
+}
+
+// PreSignUploadFile generates a pre-signed PUT request, so clients can upload the file directly to the storage.
+// It returns the request and the link of the file after it's uploaded.
+func (client *Client) PreSignUploadFile(ctx context.Context, filename string, fileType string, size int64) (*v4.PresignedHTTPRequest, string, error) {
+	putInput := &awss3.PutObjectInput{
+		Bucket:        aws.String(client.Config.Bucket),
+		Key:           aws.String(filename),
+		ContentType:   aws.String(fileType),
+		ContentLength: aws.Int64(size),
+	}
+	// Set ACL according to if url prefix is set.
+	if client.Config.URLPrefix == "" && !client.Config.PreSign {
+		putInput.ACL = types.ObjectCannedACL(*aws.String("public-read"))
+	}
+	req, err := awss3.NewPresignClient(client.Client).PresignPutObject(ctx, putInput, awss3.WithPresignExpires(UploadLifetime))
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "pre-sign upload")
+	}
+
+	// The location of the object is the pre-signed URL without the signature.
+	location, _, _ := strings.Cut(req.URL, "?")
+	link, err := client.formatLink(ctx, filename, location)
+	if err != nil {
+		return nil, "", err
+	}
+	return req, link, nil
+}
+
+func (client *Client) formatLink(ctx context.Context, filename string, location string) (string, error) {
+	link := location
 	// If url prefix is set, use it as the file link.
 	if client.Config.URLPrefix != "" {
 		parts := strings.Split(filename, "/")
@@ -107,22 +152,45 @@ func (client *Client) UploadFile(ctx context.Context, filename string, fileType
 // If the link does not belong to the configured storage endpoint, it is returned as-is.
 // If the link belongs to the storage, the function generates a pre-signed URL using the AWS S3 client.
 func (client *Client) PreSignLink(ctx context.Context, sourceLink string) (string, error) {
-	u, err := url.Parse(sourceLink)
+	filename, ok, err := client.ObjectKey(sourceLink)
 	if err != nil {
-		return "", errors.Wrapf(err, "parse URL")
+		return "", err
+	}
+	if !ok {
+		return sourceLink, nil
+	}
+
+	req, err := awss3.NewPresignClient(client.Client).PresignGetObject(ctx, &awss3.GetObjectInput{
+		Bucket: aws.String(client.Config.Bucket),
+		Key:    aws.String(filename),
+	}, awss3.WithPresignExpires(LinkLifetime))
+	if err != nil {
+		return "", errors.Wrapf(err, "pre-sign link")
+	}
+	return req.URL, nil
+}
+
+// ObjectKey extracts the object key from the given link.
+// The second return value is false if the link does not belong to the configured storage endpoint.
+func (client *Client) ObjectKey(link string) (string, bool, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "parse URL")
 	}
 	// if link doesn't belong to storage, then return as-is.
 	// the empty hostname is corner-case for AWS native endpoint.
 	endpointURL, err := url.Parse(client.Config.EndPoint)
 	if err != nil {
-		return "", errors.Wrapf(err, "parse Endpoint URL")
+		return "", false, errors.Wrapf(err, "parse Endpoint URL")
 	}
+	// The link is either in the path style of the endpoint host, or in the virtual hosted style of the bucket subdomain.
 	endpointHost := endpointURL.Hostname()
-	if client.Config.Bucket != "" && !strings.Contains(endpointHost, client.Config.Bucket) {
-		endpointHost = fmt.Sprintf("%s.%s", client.Config.Bucket, endpointHost)
+	hosts := []string{endpointHost}
+	if client.Config.Bucket != "" && !strings.HasPrefix(endpointHost, client.Config.Bucket+".") {
+		hosts = append(hosts, fmt.Sprintf("%s.%s", client.Config.Bucket, endpointHost))
 	}
-	if client.Config.EndPoint != "" && !strings.Contains(endpointHost, u.Hostname()) {
-		return sourceLink, nil
+	if client.Config.EndPoint != "" && (u.Hostname() == "" || !slices.Contains(hosts, u.Hostname())) {
+		return "", false, nil
 	}
 
 	filename := u.Path
@@ -136,13 +204,53 @@ func (client *Client) PreSignLink(ctx context.Context, sourceLink string) (strin
 	if strings.HasPrefix(filename, client.Config.Bucket) {
 		filename = strings.Trim(filename[len(client.Config.Bucket):], "/")
 	}
+	return filename, true, nil
+}
 
-	req, err := awss3.NewPresignClient(client.Client).PresignGetObject(ctx, &awss3.GetObjectInput{
+// GetObject reads the object with the given key. The byteRange is the value of an HTTP Range header,
+// it's passed through to the object store so only the requested part of the object is read.
+func (client *Client) GetObject(ctx context.Context, key string, byteRange string) (*awss3.GetObjectOutput, error) {
+	input := &awss3.GetObjectInput{
 		Bucket: aws.String(client.Config.Bucket),
-		Key:    aws.String(filename),
-	}, awss3.WithPresignExpires(LinkLifetime))
+		Key:    aws.String(key),
+	}
+	if byteRange != "" {
+		input.Range = aws.String(byteRange)
+	}
+	output, err := client.Client.GetObject(ctx, input)
 	if err != nil {
-		return "", errors.Wrapf(err, "pre-sign link")
+		return nil, errors.Wrapf(err, "get object %s", key)
 	}
-	return req.URL, nil
+	return output, nil
+}
+
+// CheckBucket checks the bucket is reachable with the credentials of the config.
+func (client *Client) CheckBucket(ctx context.Context) error {
+	if _, err := client.Client.HeadBucket(ctx, &awss3.HeadBucketInput{
+		Bucket: aws.String(client.Config.Bucket),
+	}); err != nil {
+		return errors.Wrapf(err, "head bucket %s", client.Config.Bucket)
+	}
+	return nil
+}
+
+// FormatReference returns the internal path of an object uploaded to the given S3 storage.
+func FormatReference(storageID int32, key string) string {
+	return fmt.Sprintf("%s%d/%s", ReferencePrefix, storageID, key)
+}
+
+// ParseReference extracts the storage ID and the object key from an internal path.
+func ParseReference(reference string) (int32, string, bool) {
+	if !strings.HasPrefix(reference, ReferencePrefix) {
+		return 0, "", false
+	}
+	storageIDString, key, found := strings.Cut(strings.TrimPrefix(reference, ReferencePrefix), "/")
+	if !found || key == "" {
+		return 0, "", false
+	}
+	storageID, err := strconv.ParseInt(storageIDString, 10, 32)
+	if err != nil {
+		return 0, "", false
+	}
+	return int32(storageID), key, true
 }
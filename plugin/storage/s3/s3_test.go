@@ -0,0 +1,53 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectKey(t *testing.T) {
+	client := &Client{
+		Config: &Config{
+			Bucket:   "memos",
+			EndPoint: "https://s3.example.com",
+		},
+	}
+	tests := []struct {
+		link string
+		key  string
+		ok   bool
+	}{
+		{link: "https://s3.example.com/memos/assets/image.png", key: "assets/image.png", ok: true},
+		{link: "https://memos.s3.example.com/assets/image.png", key: "assets/image.png", ok: true},
+		// The hosts are compared as a whole.
+		{link: "https://example.com/memos/assets/image.png", ok: false},
+		{link: "https://s3.example.com.evil.com/memos/assets/image.png", ok: false},
+		{link: "https://3.example.com/memos/assets/image.png", ok: false},
+		// The links without a host don't belong to any storage.
+		{link: "/memos/assets/image.png", ok: false},
+		{link: "memos/assets/image.png", ok: false},
+	}
+	for _, test := range tests {
+		key, ok, err := client.ObjectKey(test.link)
+		require.NoError(t, err)
+		require.Equal(t, test.ok, ok, test.link)
+		if test.ok {
+			require.Equal(t, test.key, key, test.link)
+		}
+	}
+}
+
+func TestReference(t *testing.T) {
+	reference := FormatReference(3, "assets/image.png")
+	require.Equal(t, "s3://3/assets/image.png", reference)
+	storageID, key, ok := ParseReference(reference)
+	require.True(t, ok)
+	require.Equal(t, int32(3), storageID)
+	require.Equal(t, "assets/image.png", key)
+
+	for _, reference := range []string{"", "assets/image.png", "sftp://3/assets/image.png", "s3://3", "s3://3/", "s3://invalid/image.png"} {
+		_, _, ok := ParseReference(reference)
+		require.False(t, ok, reference)
+	}
+}
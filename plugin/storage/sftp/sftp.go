@@ -0,0 +1,174 @@
+package sftp
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// ReferencePrefix is the prefix of the internal path of resources stored in a SFTP storage.
+	// The full reference is in the format of `sftp://{storageID}/{filepath}`.
+	ReferencePrefix = "sftp://"
+
+	// dialTimeout is the timeout for connecting to the SFTP server.
+	dialTimeout = 30 * time.Second
+)
+
+// Config is the configuration of a SFTP storage. It's stored as JSON in the storage config.
+type Config struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	// PrivateKey is the PEM encoded private key used for public key authentication.
+	PrivateKey string `json:"privateKey"`
+	// Passphrase is the optional passphrase of the private key.
+	Passphrase string `json:"passphrase"`
+	// HostKey is the public key of the server in authorized_keys format, the server is verified against it.
+	HostKey string `json:"hostKey"`
+	// Path is the base path template of uploaded files on the remote server.
+	Path string `json:"path"`
+}
+
+type Client struct {
+	Config *Config
+
+	sshClient  *ssh.Client
+	sftpClient *sftp.Client
+}
+
+// Validate checks the required fields of the config, the host key is required so the server is always verified.
+func (config *Config) Validate() error {
+	if config.Host == "" || config.Username == "" {
+		return errors.New("host and username are required")
+	}
+	if config.PrivateKey == "" {
+		return errors.New("private key is required")
+	}
+	if config.HostKey == "" {
+		return errors.New("host key is required")
+	}
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(config.HostKey)); err != nil {
+		return errors.Wrap(err, "failed to parse host key")
+	}
+	return nil
+}
+
+func NewClient(config *Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var signer ssh.Signer
+	var err error
+	if config.Passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(config.PrivateKey), []byte(config.Passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(config.PrivateKey))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(config.HostKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse host key")
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = 22
+	}
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(config.Host, strconv.Itoa(port)), &ssh.ClientConfig{
+		User:            config.Username,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         dialTimeout,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", config.Host)
+	}
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, errors.Wrap(err, "failed to create sftp client")
+	}
+
+	return &Client{
+		Config:     config,
+		sshClient:  sshClient,
+		sftpClient: sftpClient,
+	}, nil
+}
+
+// UploadFile writes the content of src to the given path on the remote server.
+func (client *Client) UploadFile(filePath string, src io.Reader) error {
+	if err := client.sftpClient.MkdirAll(path.Dir(filePath)); err != nil {
+		return errors.Wrap(err, "failed to create remote directory")
+	}
+	dst, err := client.sftpClient.Create(filePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to create remote file")
+	}
+	defer dst.Close()
+	if _, err := dst.ReadFrom(src); err != nil {
+		return errors.Wrap(err, "failed to write remote file")
+	}
+	return nil
+}
+
+// OpenFile opens the given file on the remote server for reading. The returned file supports seeking,
+// so it can be served in ranges without reading the whole content.
+func (client *Client) OpenFile(filePath string) (*sftp.File, error) {
+	file, err := client.sftpClient.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open remote file")
+	}
+	return file, nil
+}
+
+// DeleteFile removes the given file from the remote server.
+func (client *Client) DeleteFile(filePath string) error {
+	return client.sftpClient.Remove(filePath)
+}
+
+// Wait blocks until the connection to the server is closed.
+func (client *Client) Wait() error {
+	return client.sshClient.Wait()
+}
+
+func (client *Client) Close() error {
+	if err := client.sftpClient.Close(); err != nil {
+		return err
+	}
+	return client.sshClient.Close()
+}
+
+// FormatReference returns the internal path of a file stored in the given SFTP storage.
+func FormatReference(storageID int32, filePath string) string {
+	return fmt.Sprintf("%s%d/%s", ReferencePrefix, storageID, filePath)
+}
+
+// ParseReference extracts the storage ID and the remote file path from an internal path.
+func ParseReference(reference string) (int32, string, bool) {
+	if !strings.HasPrefix(reference, ReferencePrefix) {
+		return 0, "", false
+	}
+	storageIDString, filePath, found := strings.Cut(strings.TrimPrefix(reference, ReferencePrefix), "/")
+	if !found {
+		return 0, "", false
+	}
+	storageID, err := strconv.ParseInt(storageIDString, 10, 32)
+	if err != nil {
+		return 0, "", false
+	}
+	return int32(storageID), filePath, true
+}
@@ -1,6 +1,9 @@
 package telegram
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Message struct {
 	MessageID            int64           `json:"message_id"`              // MessageID is a unique message identifier inside this chat
@@ -21,6 +24,7 @@ type Message struct {
 	Voice                *Voice          `json:"voice"`                   // Voice message is a voice message, information about the file;
 	Audio                *Audio          `json:"audio"`                   // Audio message is an audio file, information about the file;
 	Animation            *Animation      `json:"animation"`               // Animation message is an animation, information about the animation. For backward compatibility, when this field is set, the document field will also be set;
+	ReplyToMessage       *Message        `json:"reply_to_message"`        // ReplyToMessage for replies, the original message;
 }
 
 func (m Message) GetMaxPhotoFileID() string {
@@ -47,3 +51,20 @@ func (m Message) IsSupported() bool {
 	return m.Text != nil || m.Caption != nil || m.Document != nil || m.Photo != nil || m.Video != nil ||
 		m.Voice != nil || m.VideoNote != nil || m.Audio != nil || m.Animation != nil
 }
+
+// GetCommand returns the bot command the text of the message starts with and the rest of the text as its arguments,
+// e.g. search and "foo bar" for "/search@memos_bot foo bar".
+func (m Message) GetCommand() (string, string, bool) {
+	if m.Text == nil {
+		return "", "", false
+	}
+	for _, entity := range m.Entities {
+		if entity.Type != BotCommand || entity.Offset != 0 {
+			continue
+		}
+		command, args, _ := strings.Cut(*m.Text, " ")
+		command, _, _ = strings.Cut(strings.TrimPrefix(command, "/"), "@")
+		return strings.ToLower(command), strings.TrimSpace(args), true
+	}
+	return "", "", false
+}
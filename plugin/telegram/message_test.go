@@ -0,0 +1,45 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCommand(t *testing.T) {
+	tests := []struct {
+		text     string
+		entities []MessageEntity
+		command  string
+		args     string
+		ok       bool
+	}{
+		{
+			text:     "/search foo bar",
+			entities: []MessageEntity{{Type: BotCommand, Offset: 0, Length: 7}},
+			command:  "search",
+			args:     "foo bar",
+			ok:       true,
+		},
+		{
+			text:     "/Today@memos_bot",
+			entities: []MessageEntity{{Type: BotCommand, Offset: 0, Length: 16}},
+			command:  "today",
+			ok:       true,
+		},
+		{
+			text:     "see /search",
+			entities: []MessageEntity{{Type: BotCommand, Offset: 4, Length: 7}},
+		},
+		{
+			text: "/search without entity",
+		},
+	}
+	for _, test := range tests {
+		message := Message{Text: &test.text, Entities: test.entities}
+		command, args, ok := message.GetCommand()
+		require.Equal(t, test.ok, ok, test.text)
+		require.Equal(t, test.command, command, test.text)
+		require.Equal(t, test.args, args, test.text)
+	}
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// Presets are the payload templates of the chat tools which can be used by name,
+// so memos can be posted to them without a translation service.
+var Presets = map[string]string{
+	// Slack incoming webhooks, see https://api.slack.com/messaging/webhooks.
+	"slack": `{"text": {{ json (printf "*%s*\n%s" (or .Title .ActivityType) (truncate .Memo.Content 3000)) }}}`,
+	// Discord webhooks, where the content is limited to 2000 characters.
+	"discord": `{"content": {{ json (printf "**%s**\n%s" (or .Title .ActivityType) (truncate .Memo.Content 1900)) }}}`,
+	// ntfy topics, which take the request body as the message.
+	"ntfy": `{{ .Memo.Content }}`,
+}
+
+var templateFuncs = template.FuncMap{
+	// json returns the value encoded as JSON, e.g. a quoted and escaped string.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// truncate returns the first n characters of the string.
+	"truncate": func(s string, n int) string {
+		runes := []rune(s)
+		if len(runes) <= n {
+			return s
+		}
+		return string(runes[:n]) + "…"
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	if preset, ok := Presets[text]; ok {
+		text = preset
+	}
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid payload template")
+	}
+	return tmpl, nil
+}
+
+// ValidateTemplate returns an error if the payload template can't be parsed.
+func ValidateTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+	_, err := parseTemplate(text)
+	return err
+}
+
+// Render returns the request body of the payload.
+// The payload is rendered with the template, which is either the name of a preset or a text/template
+// executed with the WebhookPayload, or encoded as JSON if the template is empty.
+func Render(text string, payload *WebhookPayload) ([]byte, error) {
+	if text == "" {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal webhook payload")
+		}
+		return body, nil
+	}
+
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return nil, errors.Wrap(err, "failed to render payload template")
+	}
+	return buf.Bytes(), nil
+}
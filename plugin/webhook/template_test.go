@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	payload := &WebhookPayload{
+		ActivityType: "memos.memo.created",
+		Memo: &Memo{
+			Content: "Hello \"world\"",
+		},
+	}
+
+	body, err := Render("", payload)
+	require.NoError(t, err)
+	require.True(t, json.Valid(body))
+
+	body, err = Render("slack", payload)
+	require.NoError(t, err)
+	slack := map[string]string{}
+	require.NoError(t, json.Unmarshal(body, &slack))
+	require.Equal(t, "*memos.memo.created*\nHello \"world\"", slack["text"])
+
+	payload.Title = "Memo created"
+	body, err = Render("discord", payload)
+	require.NoError(t, err)
+	discord := map[string]string{}
+	require.NoError(t, json.Unmarshal(body, &discord))
+	require.Equal(t, "**Memo created**\nHello \"world\"", discord["content"])
+	payload.Title = ""
+
+	body, err = Render("ntfy", payload)
+	require.NoError(t, err)
+	require.Equal(t, "Hello \"world\"", string(body))
+
+	body, err = Render(`{{ upper .ActivityType }}: {{ truncate .Memo.Content 5 }}`, payload)
+	require.NoError(t, err)
+	require.Equal(t, "MEMOS.MEMO.CREATED: Hello…", string(body))
+}
+
+func TestValidateTemplate(t *testing.T) {
+	for name := range Presets {
+		require.NoError(t, ValidateTemplate(name))
+	}
+	require.NoError(t, ValidateTemplate(""))
+	require.Error(t, ValidateTemplate("{{ .Memo.Content"))
+}
@@ -2,6 +2,10 @@ package webhook
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -57,9 +61,11 @@ type MemoRelation struct {
 type WebhookPayload struct {
 	URL          string `json:"url"`
 	ActivityType string `json:"activityType"`
-	CreatorID    int32  `json:"creatorId"`
-	CreatedTs    int64  `json:"createdTs"`
-	Memo         *Memo  `json:"memo"`
+	// Title is the activity type in the locale of the creator of the webhook, used by the presets.
+	Title     string `json:"-"`
+	CreatorID int32  `json:"creatorId"`
+	CreatedTs int64  `json:"createdTs"`
+	Memo      *Memo  `json:"memo"`
 }
 
 // WebhookResponse is the response of webhook request.
@@ -69,45 +75,76 @@ type WebhookResponse struct {
 	Message string `json:"message"`
 }
 
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the request body,
+	// in the format of "sha256=<hex digest>".
+	SignatureHeader = "X-Memos-Signature-256"
+	// DeliveryHeader is the header of the delivery id, which is the same for the retries of a delivery.
+	DeliveryHeader = "X-Memos-Delivery"
+)
+
+// Sign returns the signature of the body signed with the secret, as sent in the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
 // Post posts the message to webhook endpoint.
 func Post(payload WebhookPayload) error {
 	body, err := json.Marshal(&payload)
 	if err != nil {
 		return errors.Wrapf(err, "failed to marshal webhook request to %s", payload.URL)
 	}
-	req, err := http.NewRequest("POST",
-		payload.URL, bytes.NewBuffer(body))
+	_, err = Deliver(context.Background(), payload.URL, body, "", "")
+	return err
+}
+
+// Deliver posts the body to the webhook endpoint, and returns the response status code.
+// The body is signed if the secret is set, and the delivery id is sent if it's set.
+// A zero status code is returned if no response is received.
+func Deliver(ctx context.Context, url string, body []byte, secret string, deliveryID string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
-		return errors.Wrapf(err, "failed to construct webhook request to %s", payload.URL)
+		return 0, errors.Wrapf(err, "failed to construct webhook request to %s", url)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	// Rendered templates may be plain text, e.g. messages of ntfy topics.
+	contentType := "text/plain; charset=utf-8"
+	if json.Valid(body) {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+	if deliveryID != "" {
+		req.Header.Set(DeliveryHeader, deliveryID)
+	}
 	client := &http.Client{
 		Timeout: timeout,
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return errors.Wrapf(err, "failed to post webhook to %s", payload.URL)
+		return 0, errors.Wrapf(err, "failed to post webhook to %s", url)
 	}
+	defer resp.Body.Close()
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read webhook response from %s", payload.URL)
+		return resp.StatusCode, errors.Wrapf(err, "failed to read webhook response from %s", url)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return errors.Errorf("failed to post webhook %s, status code: %d, response body: %s", payload.URL, resp.StatusCode, b)
+		return resp.StatusCode, errors.Errorf("failed to post webhook %s, status code: %d, response body: %s", url, resp.StatusCode, b)
 	}
 
+	// Receivers may respond with an error code in the body, other bodies are accepted as is,
+	// so deliveries are not retried because of the response format.
 	response := &WebhookResponse{}
-	if err := json.Unmarshal(b, response); err != nil {
-		return errors.Wrapf(err, "failed to unmarshal webhook response from %s", payload.URL)
-	}
-
-	if response.Code != 0 {
-		return errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
+	if err := json.Unmarshal(b, response); err == nil && response.Code != 0 {
+		return resp.StatusCode, errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
 	}
 
-	return nil
+	return resp.StatusCode, nil
 }
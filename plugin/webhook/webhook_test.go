@@ -1 +1,43 @@
 package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliverSignsBody(t *testing.T) {
+	secret, body := "secret", []byte(`{"activityType":"memos.memo.created"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, Sign(secret, received), r.Header.Get(SignatureHeader))
+		require.Equal(t, "1", r.Header.Get(DeliveryHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	statusCode, err := Deliver(context.Background(), server.URL, body, secret, "1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, statusCode)
+}
+
+func TestDeliverFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	statusCode, err := Deliver(context.Background(), server.URL, []byte("{}"), "", "")
+	require.Error(t, err)
+	require.Equal(t, http.StatusInternalServerError, statusCode)
+}
+
+func TestSign(t *testing.T) {
+	// Generated with `echo -n "{}" | openssl dgst -sha256 -hmac secret`.
+	require.Equal(t, "sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13", Sign("secret", []byte("{}")))
+}
@@ -0,0 +1,250 @@
+// Package webpush sends the notifications to the browsers with Web Push, the messages are encrypted
+// with RFC 8291 and the requests are authorized by VAPID of RFC 8292, so no third-party service is needed.
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	// client is the client of the push services.
+	client = http.DefaultClient
+	// timeout is the timeout of the requests to the push services.
+	timeout = 10 * time.Second
+	// ttl is the time the push services keep the messages for the offline browsers.
+	ttl = 24 * time.Hour
+)
+
+const (
+	// recordSize is the size of the record of the encrypted content, the messages fit in a single record.
+	recordSize = 4096
+	// MaxPayloadSize is the max size of a message. The push services accept the bodies up to the record size,
+	// which are the header of 86 bytes, the message, the delimiter and the tag of 16 bytes.
+	MaxPayloadSize = recordSize - 86 - 16 - 1
+)
+
+// ErrSubscriptionExpired is returned if the push service responds the subscription no longer exists, it should be removed.
+var ErrSubscriptionExpired = errors.New("the push subscription has expired or unsubscribed")
+
+// Subscription is the push subscription of a browser, the keys are encoded in URL-safe base64.
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// Validate returns an error if the endpoint isn't an https URL or the keys can't be decoded.
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("the endpoint must be an https URL")
+	}
+	if _, err := s.publicKey(); err != nil {
+		return err
+	}
+	if auth, err := decodeBase64(s.Keys.Auth); err != nil || len(auth) != 16 {
+		return errors.New("the auth secret must be 16 bytes")
+	}
+	return nil
+}
+
+func (s *Subscription) publicKey() (*ecdh.PublicKey, error) {
+	data, err := decodeBase64(s.Keys.P256dh)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid p256dh key")
+	}
+	key, err := ecdh.P256().NewPublicKey(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid p256dh key")
+	}
+	return key, nil
+}
+
+// VAPIDKey is the key pair identifying the server to the push services.
+type VAPIDKey struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// GenerateVAPIDKey returns a new key pair.
+func GenerateVAPIDKey() (*VAPIDKey, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	return &VAPIDKey{privateKey: privateKey}, nil
+}
+
+// ParseVAPIDKey returns the key pair of the private key encoded by VAPIDKey.PrivateKey.
+func ParseVAPIDKey(encoded string) (*VAPIDKey, error) {
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, errors.New("invalid VAPID private key")
+	}
+	key, err := ecdh.P256().NewPrivateKey(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid VAPID private key")
+	}
+	// The public key is the uncompressed point, 0x04 followed by the coordinates.
+	point := key.PublicKey().Bytes()
+	privateKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(point[1:33]),
+			Y:     new(big.Int).SetBytes(point[33:]),
+		},
+		D: new(big.Int).SetBytes(data),
+	}
+	return &VAPIDKey{privateKey: privateKey}, nil
+}
+
+// PrivateKey returns the private key in URL-safe base64, which is kept by the server.
+func (k *VAPIDKey) PrivateKey() string {
+	return base64.RawURLEncoding.EncodeToString(k.privateKey.D.FillBytes(make([]byte, 32)))
+}
+
+// PublicKey returns the uncompressed public key in URL-safe base64, which is the applicationServerKey of the subscriptions.
+func (k *VAPIDKey) PublicKey() string {
+	publicKey, err := k.privateKey.PublicKey.ECDH()
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(publicKey.Bytes())
+}
+
+// Send encrypts the payload for the subscription and posts it to the push service.
+// The subject is the contact of the sender for the push services, a mailto: or https: URL.
+func Send(ctx context.Context, key *VAPIDKey, subject string, subscription *Subscription, payload []byte) error {
+	if len(payload) > MaxPayloadSize {
+		return errors.Errorf("the payload is larger than %d bytes", MaxPayloadSize)
+	}
+	body, err := encrypt(subscription, payload)
+	if err != nil {
+		return err
+	}
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return errors.Wrap(err, "invalid endpoint")
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": subject,
+	}).SignedString(key.privateKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign VAPID token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+key.PublicKey())
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post to push service")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return ErrSubscriptionExpired
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("push service responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// encrypt returns the content of the payload encrypted with aes128gcm for the subscription, see RFC 8291.
+func encrypt(subscription *Subscription, payload []byte) ([]byte, error) {
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	userAgentKey, _ := subscription.publicKey()
+	authSecret, _ := decodeBase64(subscription.Keys.Auth)
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive shared secret")
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	serverPublicKey := serverKey.PublicKey().Bytes()
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentKey.Bytes()...)
+	keyInfo = append(keyInfo, serverPublicKey...)
+	ikm, err := readHKDF(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := readHKDF(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := readHKDF(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// The header is the salt, the record size and the public key of the server, followed by the single record.
+	content := append([]byte{}, salt...)
+	content = binary.BigEndian.AppendUint32(content, recordSize)
+	content = append(content, byte(len(serverPublicKey)))
+	content = append(content, serverPublicKey...)
+	// The delimiter 0x02 marks the last record.
+	return gcm.Seal(content, nonce, append(append([]byte{}, payload...), 0x02), nil), nil
+}
+
+func readHKDF(secret, salt, info []byte, length int) ([]byte, error) {
+	data := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), data); err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+	return data, nil
+}
+
+// decodeBase64 decodes the URL-safe base64 with or without the padding, as the browsers encode the keys either way.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.NewReplacer("+", "-", "/", "_").Replace(s), "=")
+	return base64.RawURLEncoding.DecodeString(s)
+}
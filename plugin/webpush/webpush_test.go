@@ -0,0 +1,135 @@
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// newSubscription returns the subscription of a browser and its private key.
+func newSubscription(t *testing.T, endpoint string) (*Subscription, *ecdh.PrivateKey, []byte) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+	subscription := &Subscription{Endpoint: endpoint}
+	subscription.Keys.P256dh = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	subscription.Keys.Auth = base64.URLEncoding.EncodeToString(auth)
+	return subscription, key, auth
+}
+
+// decrypt decrypts the content as the browser, see RFC 8291.
+func decrypt(t *testing.T, key *ecdh.PrivateKey, auth, content []byte) []byte {
+	salt, idLength := content[:16], int(content[20])
+	require.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(content[16:20]))
+	serverKey, err := ecdh.P256().NewPublicKey(content[21 : 21+idLength])
+	require.NoError(t, err)
+	sharedSecret, err := key.ECDH(serverKey)
+	require.NoError(t, err)
+	keyInfo := append([]byte("WebPush: info\x00"), key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, serverKey.Bytes()...)
+	ikm, err := readHKDF(sharedSecret, auth, keyInfo, 32)
+	require.NoError(t, err)
+	contentKey, err := readHKDF(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	require.NoError(t, err)
+	nonce, err := readHKDF(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(contentKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, content[21+idLength:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestSend(t *testing.T) {
+	vapidKey, err := GenerateVAPIDKey()
+	require.NoError(t, err)
+	var body []byte
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		authorization = r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client = server.Client()
+	defer func() { client = http.DefaultClient }()
+
+	subscription, key, auth := newSubscription(t, server.URL+"/push/1")
+	require.NoError(t, Send(context.Background(), vapidKey, "https://memos.example.com", subscription, []byte(`{"title":"Memo reminder"}`)))
+	require.Equal(t, `{"title":"Memo reminder"}`, string(decrypt(t, key, auth, body)))
+
+	// The token is signed by the VAPID key, whose public key is in the header.
+	token, publicKey, ok := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	require.True(t, ok)
+	require.Equal(t, vapidKey.PublicKey(), publicKey)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return &vapidKey.privateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	require.NoError(t, err)
+	require.Equal(t, server.URL, claims["aud"])
+}
+
+func TestSendExpiredSubscription(t *testing.T) {
+	vapidKey, err := GenerateVAPIDKey()
+	require.NoError(t, err)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+	client = server.Client()
+	defer func() { client = http.DefaultClient }()
+
+	subscription, _, _ := newSubscription(t, server.URL)
+	require.ErrorIs(t, Send(context.Background(), vapidKey, "https://memos.example.com", subscription, []byte(`{}`)), ErrSubscriptionExpired)
+}
+
+func TestParseVAPIDKey(t *testing.T) {
+	vapidKey, err := GenerateVAPIDKey()
+	require.NoError(t, err)
+	parsed, err := ParseVAPIDKey(vapidKey.PrivateKey())
+	require.NoError(t, err)
+	require.Equal(t, vapidKey.PublicKey(), parsed.PublicKey())
+	_, err = ParseVAPIDKey("invalid")
+	require.Error(t, err)
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	subscription, _, _ := newSubscription(t, "http://push.example.com/1")
+	require.ErrorContains(t, subscription.Validate(), "https")
+	subscription.Endpoint = "https://push.example.com/1"
+	require.NoError(t, subscription.Validate())
+	subscription.Keys.Auth = "c2hvcnQ"
+	require.ErrorContains(t, subscription.Validate(), "16 bytes")
+}
+
+// TestDecryptExample checks the decryption of the tests with the example of RFC 8291.
+func TestDecryptExample(t *testing.T) {
+	decode := func(s string) []byte {
+		data, err := base64.RawURLEncoding.DecodeString(s)
+		require.NoError(t, err)
+		return data
+	}
+	key, err := ecdh.P256().NewPrivateKey(decode("q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"))
+	require.NoError(t, err)
+	content := decode("DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+	require.Equal(t, "When I grow up, I want to be a watermelon", string(decrypt(t, key, decode("BTBZMqHH6r4Tts7J_aSIgg"), content)))
+}
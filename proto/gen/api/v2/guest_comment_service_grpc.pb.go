@@ -0,0 +1,271 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/v2/guest_comment_service.proto
+
+package apiv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	GuestCommentService_CreateGuestComment_FullMethodName    = "/memos.api.v2.GuestCommentService/CreateGuestComment"
+	GuestCommentService_ListMemoGuestComments_FullMethodName = "/memos.api.v2.GuestCommentService/ListMemoGuestComments"
+	GuestCommentService_ListGuestComments_FullMethodName     = "/memos.api.v2.GuestCommentService/ListGuestComments"
+	GuestCommentService_ModerateGuestComment_FullMethodName  = "/memos.api.v2.GuestCommentService/ModerateGuestComment"
+	GuestCommentService_DeleteGuestComment_FullMethodName    = "/memos.api.v2.GuestCommentService/DeleteGuestComment"
+)
+
+// GuestCommentServiceClient is the client API for GuestCommentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GuestCommentServiceClient interface {
+	// CreateGuestComment comments on a public memo without an account, when the workspace allows the guest comments.
+	// The comment is pending until the memo creator approves it.
+	CreateGuestComment(ctx context.Context, in *CreateGuestCommentRequest, opts ...grpc.CallOption) (*CreateGuestCommentResponse, error)
+	// ListMemoGuestComments returns the approved guest comments of a public memo, ordered by created time ascending.
+	// The memo creator and the admins can list the comments of the other statuses.
+	ListMemoGuestComments(ctx context.Context, in *ListMemoGuestCommentsRequest, opts ...grpc.CallOption) (*ListMemoGuestCommentsResponse, error)
+	// ListGuestComments returns the moderation queue of the guest comments on the memos of the current user.
+	ListGuestComments(ctx context.Context, in *ListGuestCommentsRequest, opts ...grpc.CallOption) (*ListGuestCommentsResponse, error)
+	// ModerateGuestComment approves or rejects a guest comment, by the memo creator or the admins.
+	ModerateGuestComment(ctx context.Context, in *ModerateGuestCommentRequest, opts ...grpc.CallOption) (*ModerateGuestCommentResponse, error)
+	// DeleteGuestComment deletes a guest comment, by the memo creator or the admins.
+	DeleteGuestComment(ctx context.Context, in *DeleteGuestCommentRequest, opts ...grpc.CallOption) (*DeleteGuestCommentResponse, error)
+}
+
+type guestCommentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGuestCommentServiceClient(cc grpc.ClientConnInterface) GuestCommentServiceClient {
+	return &guestCommentServiceClient{cc}
+}
+
+func (c *guestCommentServiceClient) CreateGuestComment(ctx context.Context, in *CreateGuestCommentRequest, opts ...grpc.CallOption) (*CreateGuestCommentResponse, error) {
+	out := new(CreateGuestCommentResponse)
+	err := c.cc.Invoke(ctx, GuestCommentService_CreateGuestComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestCommentServiceClient) ListMemoGuestComments(ctx context.Context, in *ListMemoGuestCommentsRequest, opts ...grpc.CallOption) (*ListMemoGuestCommentsResponse, error) {
+	out := new(ListMemoGuestCommentsResponse)
+	err := c.cc.Invoke(ctx, GuestCommentService_ListMemoGuestComments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestCommentServiceClient) ListGuestComments(ctx context.Context, in *ListGuestCommentsRequest, opts ...grpc.CallOption) (*ListGuestCommentsResponse, error) {
+	out := new(ListGuestCommentsResponse)
+	err := c.cc.Invoke(ctx, GuestCommentService_ListGuestComments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestCommentServiceClient) ModerateGuestComment(ctx context.Context, in *ModerateGuestCommentRequest, opts ...grpc.CallOption) (*ModerateGuestCommentResponse, error) {
+	out := new(ModerateGuestCommentResponse)
+	err := c.cc.Invoke(ctx, GuestCommentService_ModerateGuestComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestCommentServiceClient) DeleteGuestComment(ctx context.Context, in *DeleteGuestCommentRequest, opts ...grpc.CallOption) (*DeleteGuestCommentResponse, error) {
+	out := new(DeleteGuestCommentResponse)
+	err := c.cc.Invoke(ctx, GuestCommentService_DeleteGuestComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GuestCommentServiceServer is the server API for GuestCommentService service.
+// All implementations must embed UnimplementedGuestCommentServiceServer
+// for forward compatibility
+type GuestCommentServiceServer interface {
+	// CreateGuestComment comments on a public memo without an account, when the workspace allows the guest comments.
+	// The comment is pending until the memo creator approves it.
+	CreateGuestComment(context.Context, *CreateGuestCommentRequest) (*CreateGuestCommentResponse, error)
+	// ListMemoGuestComments returns the approved guest comments of a public memo, ordered by created time ascending.
+	// The memo creator and the admins can list the comments of the other statuses.
+	ListMemoGuestComments(context.Context, *ListMemoGuestCommentsRequest) (*ListMemoGuestCommentsResponse, error)
+	// ListGuestComments returns the moderation queue of the guest comments on the memos of the current user.
+	ListGuestComments(context.Context, *ListGuestCommentsRequest) (*ListGuestCommentsResponse, error)
+	// ModerateGuestComment approves or rejects a guest comment, by the memo creator or the admins.
+	ModerateGuestComment(context.Context, *ModerateGuestCommentRequest) (*ModerateGuestCommentResponse, error)
+	// DeleteGuestComment deletes a guest comment, by the memo creator or the admins.
+	DeleteGuestComment(context.Context, *DeleteGuestCommentRequest) (*DeleteGuestCommentResponse, error)
+	mustEmbedUnimplementedGuestCommentServiceServer()
+}
+
+// UnimplementedGuestCommentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGuestCommentServiceServer struct {
+}
+
+func (UnimplementedGuestCommentServiceServer) CreateGuestComment(context.Context, *CreateGuestCommentRequest) (*CreateGuestCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGuestComment not implemented")
+}
+func (UnimplementedGuestCommentServiceServer) ListMemoGuestComments(context.Context, *ListMemoGuestCommentsRequest) (*ListMemoGuestCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMemoGuestComments not implemented")
+}
+func (UnimplementedGuestCommentServiceServer) ListGuestComments(context.Context, *ListGuestCommentsRequest) (*ListGuestCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGuestComments not implemented")
+}
+func (UnimplementedGuestCommentServiceServer) ModerateGuestComment(context.Context, *ModerateGuestCommentRequest) (*ModerateGuestCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModerateGuestComment not implemented")
+}
+func (UnimplementedGuestCommentServiceServer) DeleteGuestComment(context.Context, *DeleteGuestCommentRequest) (*DeleteGuestCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGuestComment not implemented")
+}
+func (UnimplementedGuestCommentServiceServer) mustEmbedUnimplementedGuestCommentServiceServer() {}
+
+// UnsafeGuestCommentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GuestCommentServiceServer will
+// result in compilation errors.
+type UnsafeGuestCommentServiceServer interface {
+	mustEmbedUnimplementedGuestCommentServiceServer()
+}
+
+func RegisterGuestCommentServiceServer(s grpc.ServiceRegistrar, srv GuestCommentServiceServer) {
+	s.RegisterService(&GuestCommentService_ServiceDesc, srv)
+}
+
+func _GuestCommentService_CreateGuestComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGuestCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestCommentServiceServer).CreateGuestComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuestCommentService_CreateGuestComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestCommentServiceServer).CreateGuestComment(ctx, req.(*CreateGuestCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestCommentService_ListMemoGuestComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMemoGuestCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestCommentServiceServer).ListMemoGuestComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuestCommentService_ListMemoGuestComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestCommentServiceServer).ListMemoGuestComments(ctx, req.(*ListMemoGuestCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestCommentService_ListGuestComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestCommentServiceServer).ListGuestComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuestCommentService_ListGuestComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestCommentServiceServer).ListGuestComments(ctx, req.(*ListGuestCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestCommentService_ModerateGuestComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateGuestCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestCommentServiceServer).ModerateGuestComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuestCommentService_ModerateGuestComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestCommentServiceServer).ModerateGuestComment(ctx, req.(*ModerateGuestCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestCommentService_DeleteGuestComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGuestCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestCommentServiceServer).DeleteGuestComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuestCommentService_DeleteGuestComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestCommentServiceServer).DeleteGuestComment(ctx, req.(*DeleteGuestCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GuestCommentService_ServiceDesc is the grpc.ServiceDesc for GuestCommentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GuestCommentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "memos.api.v2.GuestCommentService",
+	HandlerType: (*GuestCommentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGuestComment",
+			Handler:    _GuestCommentService_CreateGuestComment_Handler,
+		},
+		{
+			MethodName: "ListMemoGuestComments",
+			Handler:    _GuestCommentService_ListMemoGuestComments_Handler,
+		},
+		{
+			MethodName: "ListGuestComments",
+			Handler:    _GuestCommentService_ListGuestComments_Handler,
+		},
+		{
+			MethodName: "ModerateGuestComment",
+			Handler:    _GuestCommentService_ModerateGuestComment_Handler,
+		},
+		{
+			MethodName: "DeleteGuestComment",
+			Handler:    _GuestCommentService_DeleteGuestComment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v2/guest_comment_service.proto",
+}
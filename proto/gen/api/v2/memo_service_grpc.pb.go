@@ -22,6 +22,7 @@ const (
 	MemoService_CreateMemo_FullMethodName         = "/memos.api.v2.MemoService/CreateMemo"
 	MemoService_ListMemos_FullMethodName          = "/memos.api.v2.MemoService/ListMemos"
 	MemoService_SearchMemos_FullMethodName        = "/memos.api.v2.MemoService/SearchMemos"
+	MemoService_ResurfaceMemos_FullMethodName     = "/memos.api.v2.MemoService/ResurfaceMemos"
 	MemoService_GetMemo_FullMethodName            = "/memos.api.v2.MemoService/GetMemo"
 	MemoService_UpdateMemo_FullMethodName         = "/memos.api.v2.MemoService/UpdateMemo"
 	MemoService_DeleteMemo_FullMethodName         = "/memos.api.v2.MemoService/DeleteMemo"
@@ -30,12 +31,16 @@ const (
 	MemoService_ListMemoResources_FullMethodName  = "/memos.api.v2.MemoService/ListMemoResources"
 	MemoService_SetMemoRelations_FullMethodName   = "/memos.api.v2.MemoService/SetMemoRelations"
 	MemoService_ListMemoRelations_FullMethodName  = "/memos.api.v2.MemoService/ListMemoRelations"
+	MemoService_DeleteMemoRelation_FullMethodName = "/memos.api.v2.MemoService/DeleteMemoRelation"
 	MemoService_CreateMemoComment_FullMethodName  = "/memos.api.v2.MemoService/CreateMemoComment"
 	MemoService_ListMemoComments_FullMethodName   = "/memos.api.v2.MemoService/ListMemoComments"
 	MemoService_GetUserMemosStats_FullMethodName  = "/memos.api.v2.MemoService/GetUserMemosStats"
 	MemoService_ListMemoReactions_FullMethodName  = "/memos.api.v2.MemoService/ListMemoReactions"
 	MemoService_UpsertMemoReaction_FullMethodName = "/memos.api.v2.MemoService/UpsertMemoReaction"
 	MemoService_DeleteMemoReaction_FullMethodName = "/memos.api.v2.MemoService/DeleteMemoReaction"
+	MemoService_SetMemoReminder_FullMethodName    = "/memos.api.v2.MemoService/SetMemoReminder"
+	MemoService_GetMemoReminder_FullMethodName    = "/memos.api.v2.MemoService/GetMemoReminder"
+	MemoService_DeleteMemoReminder_FullMethodName = "/memos.api.v2.MemoService/DeleteMemoReminder"
 )
 
 // MemoServiceClient is the client API for MemoService service.
@@ -48,6 +53,8 @@ type MemoServiceClient interface {
 	ListMemos(ctx context.Context, in *ListMemosRequest, opts ...grpc.CallOption) (*ListMemosResponse, error)
 	// SearchMemos searches memos.
 	SearchMemos(ctx context.Context, in *SearchMemosRequest, opts ...grpc.CallOption) (*SearchMemosResponse, error)
+	// ResurfaceMemos returns random old memos, or the memos created on this day in previous years, of the current user.
+	ResurfaceMemos(ctx context.Context, in *ResurfaceMemosRequest, opts ...grpc.CallOption) (*ResurfaceMemosResponse, error)
 	// GetMemo gets a memo.
 	GetMemo(ctx context.Context, in *GetMemoRequest, opts ...grpc.CallOption) (*GetMemoResponse, error)
 	// UpdateMemo updates a memo.
@@ -61,9 +68,12 @@ type MemoServiceClient interface {
 	// ListMemoResources lists resources for a memo.
 	ListMemoResources(ctx context.Context, in *ListMemoResourcesRequest, opts ...grpc.CallOption) (*ListMemoResourcesResponse, error)
 	// SetMemoRelations sets relations for a memo.
+	// The reference relations are replaced with the given ones, and the custom relations are added.
 	SetMemoRelations(ctx context.Context, in *SetMemoRelationsRequest, opts ...grpc.CallOption) (*SetMemoRelationsResponse, error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(ctx context.Context, in *ListMemoRelationsRequest, opts ...grpc.CallOption) (*ListMemoRelationsResponse, error)
+	// DeleteMemoRelation deletes a relation of a memo.
+	DeleteMemoRelation(ctx context.Context, in *DeleteMemoRelationRequest, opts ...grpc.CallOption) (*DeleteMemoRelationResponse, error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(ctx context.Context, in *CreateMemoCommentRequest, opts ...grpc.CallOption) (*CreateMemoCommentResponse, error)
 	// ListMemoComments lists comments for a memo.
@@ -76,6 +86,12 @@ type MemoServiceClient interface {
 	UpsertMemoReaction(ctx context.Context, in *UpsertMemoReactionRequest, opts ...grpc.CallOption) (*UpsertMemoReactionResponse, error)
 	// DeleteMemoReaction deletes a reaction for a memo.
 	DeleteMemoReaction(ctx context.Context, in *DeleteMemoReactionRequest, opts ...grpc.CallOption) (*DeleteMemoReactionResponse, error)
+	// SetMemoReminder sets the reminder of the current user for a memo.
+	SetMemoReminder(ctx context.Context, in *SetMemoReminderRequest, opts ...grpc.CallOption) (*SetMemoReminderResponse, error)
+	// GetMemoReminder gets the reminder of the current user for a memo.
+	GetMemoReminder(ctx context.Context, in *GetMemoReminderRequest, opts ...grpc.CallOption) (*GetMemoReminderResponse, error)
+	// DeleteMemoReminder deletes the reminder of the current user for a memo.
+	DeleteMemoReminder(ctx context.Context, in *DeleteMemoReminderRequest, opts ...grpc.CallOption) (*DeleteMemoReminderResponse, error)
 }
 
 type memoServiceClient struct {
@@ -113,6 +129,15 @@ func (c *memoServiceClient) SearchMemos(ctx context.Context, in *SearchMemosRequ
 	return out, nil
 }
 
+func (c *memoServiceClient) ResurfaceMemos(ctx context.Context, in *ResurfaceMemosRequest, opts ...grpc.CallOption) (*ResurfaceMemosResponse, error) {
+	out := new(ResurfaceMemosResponse)
+	err := c.cc.Invoke(ctx, MemoService_ResurfaceMemos_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) GetMemo(ctx context.Context, in *GetMemoRequest, opts ...grpc.CallOption) (*GetMemoResponse, error) {
 	out := new(GetMemoResponse)
 	err := c.cc.Invoke(ctx, MemoService_GetMemo_FullMethodName, in, out, opts...)
@@ -185,6 +210,15 @@ func (c *memoServiceClient) ListMemoRelations(ctx context.Context, in *ListMemoR
 	return out, nil
 }
 
+func (c *memoServiceClient) DeleteMemoRelation(ctx context.Context, in *DeleteMemoRelationRequest, opts ...grpc.CallOption) (*DeleteMemoRelationResponse, error) {
+	out := new(DeleteMemoRelationResponse)
+	err := c.cc.Invoke(ctx, MemoService_DeleteMemoRelation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) CreateMemoComment(ctx context.Context, in *CreateMemoCommentRequest, opts ...grpc.CallOption) (*CreateMemoCommentResponse, error) {
 	out := new(CreateMemoCommentResponse)
 	err := c.cc.Invoke(ctx, MemoService_CreateMemoComment_FullMethodName, in, out, opts...)
@@ -239,6 +273,33 @@ func (c *memoServiceClient) DeleteMemoReaction(ctx context.Context, in *DeleteMe
 	return out, nil
 }
 
+func (c *memoServiceClient) SetMemoReminder(ctx context.Context, in *SetMemoReminderRequest, opts ...grpc.CallOption) (*SetMemoReminderResponse, error) {
+	out := new(SetMemoReminderResponse)
+	err := c.cc.Invoke(ctx, MemoService_SetMemoReminder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) GetMemoReminder(ctx context.Context, in *GetMemoReminderRequest, opts ...grpc.CallOption) (*GetMemoReminderResponse, error) {
+	out := new(GetMemoReminderResponse)
+	err := c.cc.Invoke(ctx, MemoService_GetMemoReminder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) DeleteMemoReminder(ctx context.Context, in *DeleteMemoReminderRequest, opts ...grpc.CallOption) (*DeleteMemoReminderResponse, error) {
+	out := new(DeleteMemoReminderResponse)
+	err := c.cc.Invoke(ctx, MemoService_DeleteMemoReminder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MemoServiceServer is the server API for MemoService service.
 // All implementations must embed UnimplementedMemoServiceServer
 // for forward compatibility
@@ -249,6 +310,8 @@ type MemoServiceServer interface {
 	ListMemos(context.Context, *ListMemosRequest) (*ListMemosResponse, error)
 	// SearchMemos searches memos.
 	SearchMemos(context.Context, *SearchMemosRequest) (*SearchMemosResponse, error)
+	// ResurfaceMemos returns random old memos, or the memos created on this day in previous years, of the current user.
+	ResurfaceMemos(context.Context, *ResurfaceMemosRequest) (*ResurfaceMemosResponse, error)
 	// GetMemo gets a memo.
 	GetMemo(context.Context, *GetMemoRequest) (*GetMemoResponse, error)
 	// UpdateMemo updates a memo.
@@ -262,9 +325,12 @@ type MemoServiceServer interface {
 	// ListMemoResources lists resources for a memo.
 	ListMemoResources(context.Context, *ListMemoResourcesRequest) (*ListMemoResourcesResponse, error)
 	// SetMemoRelations sets relations for a memo.
+	// The reference relations are replaced with the given ones, and the custom relations are added.
 	SetMemoRelations(context.Context, *SetMemoRelationsRequest) (*SetMemoRelationsResponse, error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(context.Context, *ListMemoRelationsRequest) (*ListMemoRelationsResponse, error)
+	// DeleteMemoRelation deletes a relation of a memo.
+	DeleteMemoRelation(context.Context, *DeleteMemoRelationRequest) (*DeleteMemoRelationResponse, error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(context.Context, *CreateMemoCommentRequest) (*CreateMemoCommentResponse, error)
 	// ListMemoComments lists comments for a memo.
@@ -277,6 +343,12 @@ type MemoServiceServer interface {
 	UpsertMemoReaction(context.Context, *UpsertMemoReactionRequest) (*UpsertMemoReactionResponse, error)
 	// DeleteMemoReaction deletes a reaction for a memo.
 	DeleteMemoReaction(context.Context, *DeleteMemoReactionRequest) (*DeleteMemoReactionResponse, error)
+	// SetMemoReminder sets the reminder of the current user for a memo.
+	SetMemoReminder(context.Context, *SetMemoReminderRequest) (*SetMemoReminderResponse, error)
+	// GetMemoReminder gets the reminder of the current user for a memo.
+	GetMemoReminder(context.Context, *GetMemoReminderRequest) (*GetMemoReminderResponse, error)
+	// DeleteMemoReminder deletes the reminder of the current user for a memo.
+	DeleteMemoReminder(context.Context, *DeleteMemoReminderRequest) (*DeleteMemoReminderResponse, error)
 	mustEmbedUnimplementedMemoServiceServer()
 }
 
@@ -293,6 +365,9 @@ func (UnimplementedMemoServiceServer) ListMemos(context.Context, *ListMemosReque
 func (UnimplementedMemoServiceServer) SearchMemos(context.Context, *SearchMemosRequest) (*SearchMemosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchMemos not implemented")
 }
+func (UnimplementedMemoServiceServer) ResurfaceMemos(context.Context, *ResurfaceMemosRequest) (*ResurfaceMemosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResurfaceMemos not implemented")
+}
 func (UnimplementedMemoServiceServer) GetMemo(context.Context, *GetMemoRequest) (*GetMemoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMemo not implemented")
 }
@@ -317,6 +392,9 @@ func (UnimplementedMemoServiceServer) SetMemoRelations(context.Context, *SetMemo
 func (UnimplementedMemoServiceServer) ListMemoRelations(context.Context, *ListMemoRelationsRequest) (*ListMemoRelationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMemoRelations not implemented")
 }
+func (UnimplementedMemoServiceServer) DeleteMemoRelation(context.Context, *DeleteMemoRelationRequest) (*DeleteMemoRelationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMemoRelation not implemented")
+}
 func (UnimplementedMemoServiceServer) CreateMemoComment(context.Context, *CreateMemoCommentRequest) (*CreateMemoCommentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMemoComment not implemented")
 }
@@ -335,6 +413,15 @@ func (UnimplementedMemoServiceServer) UpsertMemoReaction(context.Context, *Upser
 func (UnimplementedMemoServiceServer) DeleteMemoReaction(context.Context, *DeleteMemoReactionRequest) (*DeleteMemoReactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMemoReaction not implemented")
 }
+func (UnimplementedMemoServiceServer) SetMemoReminder(context.Context, *SetMemoReminderRequest) (*SetMemoReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemoReminder not implemented")
+}
+func (UnimplementedMemoServiceServer) GetMemoReminder(context.Context, *GetMemoReminderRequest) (*GetMemoReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemoReminder not implemented")
+}
+func (UnimplementedMemoServiceServer) DeleteMemoReminder(context.Context, *DeleteMemoReminderRequest) (*DeleteMemoReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMemoReminder not implemented")
+}
 func (UnimplementedMemoServiceServer) mustEmbedUnimplementedMemoServiceServer() {}
 
 // UnsafeMemoServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -402,6 +489,24 @@ func _MemoService_SearchMemos_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_ResurfaceMemos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResurfaceMemosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ResurfaceMemos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ResurfaceMemos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ResurfaceMemos(ctx, req.(*ResurfaceMemosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_GetMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMemoRequest)
 	if err := dec(in); err != nil {
@@ -546,6 +651,24 @@ func _MemoService_ListMemoRelations_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_DeleteMemoRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMemoRelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).DeleteMemoRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_DeleteMemoRelation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).DeleteMemoRelation(ctx, req.(*DeleteMemoRelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_CreateMemoComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateMemoCommentRequest)
 	if err := dec(in); err != nil {
@@ -654,6 +777,60 @@ func _MemoService_DeleteMemoReaction_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_SetMemoReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemoReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).SetMemoReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_SetMemoReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).SetMemoReminder(ctx, req.(*SetMemoReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_GetMemoReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemoReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).GetMemoReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_GetMemoReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).GetMemoReminder(ctx, req.(*GetMemoReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_DeleteMemoReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMemoReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).DeleteMemoReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_DeleteMemoReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).DeleteMemoReminder(ctx, req.(*DeleteMemoReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MemoService_ServiceDesc is the grpc.ServiceDesc for MemoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -673,6 +850,10 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchMemos",
 			Handler:    _MemoService_SearchMemos_Handler,
 		},
+		{
+			MethodName: "ResurfaceMemos",
+			Handler:    _MemoService_ResurfaceMemos_Handler,
+		},
 		{
 			MethodName: "GetMemo",
 			Handler:    _MemoService_GetMemo_Handler,
@@ -705,6 +886,10 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMemoRelations",
 			Handler:    _MemoService_ListMemoRelations_Handler,
 		},
+		{
+			MethodName: "DeleteMemoRelation",
+			Handler:    _MemoService_DeleteMemoRelation_Handler,
+		},
 		{
 			MethodName: "CreateMemoComment",
 			Handler:    _MemoService_CreateMemoComment_Handler,
@@ -729,6 +914,18 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMemoReaction",
 			Handler:    _MemoService_DeleteMemoReaction_Handler,
 		},
+		{
+			MethodName: "SetMemoReminder",
+			Handler:    _MemoService_SetMemoReminder_Handler,
+		},
+		{
+			MethodName: "GetMemoReminder",
+			Handler:    _MemoService_GetMemoReminder_Handler,
+		},
+		{
+			MethodName: "DeleteMemoReminder",
+			Handler:    _MemoService_DeleteMemoReminder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v2/memo_service.proto",
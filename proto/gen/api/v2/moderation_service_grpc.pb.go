@@ -0,0 +1,306 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/v2/moderation_service.proto
+
+package apiv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ModerationService_CreateMemoReport_FullMethodName        = "/memos.api.v2.ModerationService/CreateMemoReport"
+	ModerationService_ListMemoReports_FullMethodName         = "/memos.api.v2.ModerationService/ListMemoReports"
+	ModerationService_ResolveMemoReport_FullMethodName       = "/memos.api.v2.ModerationService/ResolveMemoReport"
+	ModerationService_RestoreMemo_FullMethodName             = "/memos.api.v2.ModerationService/RestoreMemo"
+	ModerationService_ListPendingExploreMemos_FullMethodName = "/memos.api.v2.ModerationService/ListPendingExploreMemos"
+	ModerationService_SetMemoExploreApproval_FullMethodName  = "/memos.api.v2.ModerationService/SetMemoExploreApproval"
+)
+
+// ModerationServiceClient is the client API for ModerationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ModerationServiceClient interface {
+	// CreateMemoReport reports a memo or comment of another user to the admins.
+	CreateMemoReport(ctx context.Context, in *CreateMemoReportRequest, opts ...grpc.CallOption) (*CreateMemoReportResponse, error)
+	// ListMemoReports returns the memo reports for review, ordered by id descending.
+	ListMemoReports(ctx context.Context, in *ListMemoReportsRequest, opts ...grpc.CallOption) (*ListMemoReportsResponse, error)
+	// ResolveMemoReport resolves an open report, and notifies the reporters of the resolution.
+	ResolveMemoReport(ctx context.Context, in *ResolveMemoReportRequest, opts ...grpc.CallOption) (*ResolveMemoReportResponse, error)
+	// RestoreMemo shows a memo hidden after reports to other users again.
+	RestoreMemo(ctx context.Context, in *RestoreMemoRequest, opts ...grpc.CallOption) (*RestoreMemoResponse, error)
+	// ListPendingExploreMemos returns the memos waiting for the approval of the admins to be listed in explore, the oldest first.
+	ListPendingExploreMemos(ctx context.Context, in *ListPendingExploreMemosRequest, opts ...grpc.CallOption) (*ListPendingExploreMemosResponse, error)
+	// SetMemoExploreApproval approves a memo to be listed in explore, or revokes the approval.
+	SetMemoExploreApproval(ctx context.Context, in *SetMemoExploreApprovalRequest, opts ...grpc.CallOption) (*SetMemoExploreApprovalResponse, error)
+}
+
+type moderationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewModerationServiceClient(cc grpc.ClientConnInterface) ModerationServiceClient {
+	return &moderationServiceClient{cc}
+}
+
+func (c *moderationServiceClient) CreateMemoReport(ctx context.Context, in *CreateMemoReportRequest, opts ...grpc.CallOption) (*CreateMemoReportResponse, error) {
+	out := new(CreateMemoReportResponse)
+	err := c.cc.Invoke(ctx, ModerationService_CreateMemoReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moderationServiceClient) ListMemoReports(ctx context.Context, in *ListMemoReportsRequest, opts ...grpc.CallOption) (*ListMemoReportsResponse, error) {
+	out := new(ListMemoReportsResponse)
+	err := c.cc.Invoke(ctx, ModerationService_ListMemoReports_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moderationServiceClient) ResolveMemoReport(ctx context.Context, in *ResolveMemoReportRequest, opts ...grpc.CallOption) (*ResolveMemoReportResponse, error) {
+	out := new(ResolveMemoReportResponse)
+	err := c.cc.Invoke(ctx, ModerationService_ResolveMemoReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moderationServiceClient) RestoreMemo(ctx context.Context, in *RestoreMemoRequest, opts ...grpc.CallOption) (*RestoreMemoResponse, error) {
+	out := new(RestoreMemoResponse)
+	err := c.cc.Invoke(ctx, ModerationService_RestoreMemo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moderationServiceClient) ListPendingExploreMemos(ctx context.Context, in *ListPendingExploreMemosRequest, opts ...grpc.CallOption) (*ListPendingExploreMemosResponse, error) {
+	out := new(ListPendingExploreMemosResponse)
+	err := c.cc.Invoke(ctx, ModerationService_ListPendingExploreMemos_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moderationServiceClient) SetMemoExploreApproval(ctx context.Context, in *SetMemoExploreApprovalRequest, opts ...grpc.CallOption) (*SetMemoExploreApprovalResponse, error) {
+	out := new(SetMemoExploreApprovalResponse)
+	err := c.cc.Invoke(ctx, ModerationService_SetMemoExploreApproval_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ModerationServiceServer is the server API for ModerationService service.
+// All implementations must embed UnimplementedModerationServiceServer
+// for forward compatibility
+type ModerationServiceServer interface {
+	// CreateMemoReport reports a memo or comment of another user to the admins.
+	CreateMemoReport(context.Context, *CreateMemoReportRequest) (*CreateMemoReportResponse, error)
+	// ListMemoReports returns the memo reports for review, ordered by id descending.
+	ListMemoReports(context.Context, *ListMemoReportsRequest) (*ListMemoReportsResponse, error)
+	// ResolveMemoReport resolves an open report, and notifies the reporters of the resolution.
+	ResolveMemoReport(context.Context, *ResolveMemoReportRequest) (*ResolveMemoReportResponse, error)
+	// RestoreMemo shows a memo hidden after reports to other users again.
+	RestoreMemo(context.Context, *RestoreMemoRequest) (*RestoreMemoResponse, error)
+	// ListPendingExploreMemos returns the memos waiting for the approval of the admins to be listed in explore, the oldest first.
+	ListPendingExploreMemos(context.Context, *ListPendingExploreMemosRequest) (*ListPendingExploreMemosResponse, error)
+	// SetMemoExploreApproval approves a memo to be listed in explore, or revokes the approval.
+	SetMemoExploreApproval(context.Context, *SetMemoExploreApprovalRequest) (*SetMemoExploreApprovalResponse, error)
+	mustEmbedUnimplementedModerationServiceServer()
+}
+
+// UnimplementedModerationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedModerationServiceServer struct {
+}
+
+func (UnimplementedModerationServiceServer) CreateMemoReport(context.Context, *CreateMemoReportRequest) (*CreateMemoReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMemoReport not implemented")
+}
+func (UnimplementedModerationServiceServer) ListMemoReports(context.Context, *ListMemoReportsRequest) (*ListMemoReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMemoReports not implemented")
+}
+func (UnimplementedModerationServiceServer) ResolveMemoReport(context.Context, *ResolveMemoReportRequest) (*ResolveMemoReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveMemoReport not implemented")
+}
+func (UnimplementedModerationServiceServer) RestoreMemo(context.Context, *RestoreMemoRequest) (*RestoreMemoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMemo not implemented")
+}
+func (UnimplementedModerationServiceServer) ListPendingExploreMemos(context.Context, *ListPendingExploreMemosRequest) (*ListPendingExploreMemosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingExploreMemos not implemented")
+}
+func (UnimplementedModerationServiceServer) SetMemoExploreApproval(context.Context, *SetMemoExploreApprovalRequest) (*SetMemoExploreApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemoExploreApproval not implemented")
+}
+func (UnimplementedModerationServiceServer) mustEmbedUnimplementedModerationServiceServer() {}
+
+// UnsafeModerationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ModerationServiceServer will
+// result in compilation errors.
+type UnsafeModerationServiceServer interface {
+	mustEmbedUnimplementedModerationServiceServer()
+}
+
+func RegisterModerationServiceServer(s grpc.ServiceRegistrar, srv ModerationServiceServer) {
+	s.RegisterService(&ModerationService_ServiceDesc, srv)
+}
+
+func _ModerationService_CreateMemoReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMemoReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModerationServiceServer).CreateMemoReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModerationService_CreateMemoReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModerationServiceServer).CreateMemoReport(ctx, req.(*CreateMemoReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModerationService_ListMemoReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMemoReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModerationServiceServer).ListMemoReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModerationService_ListMemoReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModerationServiceServer).ListMemoReports(ctx, req.(*ListMemoReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModerationService_ResolveMemoReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveMemoReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModerationServiceServer).ResolveMemoReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModerationService_ResolveMemoReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModerationServiceServer).ResolveMemoReport(ctx, req.(*ResolveMemoReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModerationService_RestoreMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModerationServiceServer).RestoreMemo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModerationService_RestoreMemo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModerationServiceServer).RestoreMemo(ctx, req.(*RestoreMemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModerationService_ListPendingExploreMemos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingExploreMemosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModerationServiceServer).ListPendingExploreMemos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModerationService_ListPendingExploreMemos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModerationServiceServer).ListPendingExploreMemos(ctx, req.(*ListPendingExploreMemosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModerationService_SetMemoExploreApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemoExploreApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModerationServiceServer).SetMemoExploreApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModerationService_SetMemoExploreApproval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModerationServiceServer).SetMemoExploreApproval(ctx, req.(*SetMemoExploreApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ModerationService_ServiceDesc is the grpc.ServiceDesc for ModerationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ModerationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "memos.api.v2.ModerationService",
+	HandlerType: (*ModerationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMemoReport",
+			Handler:    _ModerationService_CreateMemoReport_Handler,
+		},
+		{
+			MethodName: "ListMemoReports",
+			Handler:    _ModerationService_ListMemoReports_Handler,
+		},
+		{
+			MethodName: "ResolveMemoReport",
+			Handler:    _ModerationService_ResolveMemoReport_Handler,
+		},
+		{
+			MethodName: "RestoreMemo",
+			Handler:    _ModerationService_RestoreMemo_Handler,
+		},
+		{
+			MethodName: "ListPendingExploreMemos",
+			Handler:    _ModerationService_ListPendingExploreMemos_Handler,
+		},
+		{
+			MethodName: "SetMemoExploreApproval",
+			Handler:    _ModerationService_SetMemoExploreApproval_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v2/moderation_service.proto",
+}
@@ -30,6 +30,19 @@ const (
 	UserService_ListUserAccessTokens_FullMethodName  = "/memos.api.v2.UserService/ListUserAccessTokens"
 	UserService_CreateUserAccessToken_FullMethodName = "/memos.api.v2.UserService/CreateUserAccessToken"
 	UserService_DeleteUserAccessToken_FullMethodName = "/memos.api.v2.UserService/DeleteUserAccessToken"
+	UserService_ListUserDomains_FullMethodName       = "/memos.api.v2.UserService/ListUserDomains"
+	UserService_CreateUserDomain_FullMethodName      = "/memos.api.v2.UserService/CreateUserDomain"
+	UserService_VerifyUserDomain_FullMethodName      = "/memos.api.v2.UserService/VerifyUserDomain"
+	UserService_DeleteUserDomain_FullMethodName      = "/memos.api.v2.UserService/DeleteUserDomain"
+	UserService_DisableUser_FullMethodName           = "/memos.api.v2.UserService/DisableUser"
+	UserService_EnableUser_FullMethodName            = "/memos.api.v2.UserService/EnableUser"
+	UserService_ResetUserPassword_FullMethodName     = "/memos.api.v2.UserService/ResetUserPassword"
+	UserService_GetUserQuota_FullMethodName          = "/memos.api.v2.UserService/GetUserQuota"
+	UserService_UpdateUserQuota_FullMethodName       = "/memos.api.v2.UserService/UpdateUserQuota"
+	UserService_GetUserProfile_FullMethodName        = "/memos.api.v2.UserService/GetUserProfile"
+	UserService_UpdateUserProfile_FullMethodName     = "/memos.api.v2.UserService/UpdateUserProfile"
+	UserService_TransferUserContent_FullMethodName   = "/memos.api.v2.UserService/TransferUserContent"
+	UserService_PurgeUserContent_FullMethodName      = "/memos.api.v2.UserService/PurgeUserContent"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -58,6 +71,33 @@ type UserServiceClient interface {
 	CreateUserAccessToken(ctx context.Context, in *CreateUserAccessTokenRequest, opts ...grpc.CallOption) (*CreateUserAccessTokenResponse, error)
 	// DeleteUserAccessToken deletes an access token for a user.
 	DeleteUserAccessToken(ctx context.Context, in *DeleteUserAccessTokenRequest, opts ...grpc.CallOption) (*DeleteUserAccessTokenResponse, error)
+	// ListUserDomains returns the custom domains of a user.
+	ListUserDomains(ctx context.Context, in *ListUserDomainsRequest, opts ...grpc.CallOption) (*ListUserDomainsResponse, error)
+	// CreateUserDomain adds a custom domain serving the public memos of a user, it's served once it's verified.
+	CreateUserDomain(ctx context.Context, in *CreateUserDomainRequest, opts ...grpc.CallOption) (*CreateUserDomainResponse, error)
+	// VerifyUserDomain verifies the ownership of a custom domain by the TXT record of its verification record name.
+	VerifyUserDomain(ctx context.Context, in *VerifyUserDomainRequest, opts ...grpc.CallOption) (*VerifyUserDomainResponse, error)
+	// DeleteUserDomain deletes a custom domain of a user.
+	DeleteUserDomain(ctx context.Context, in *DeleteUserDomainRequest, opts ...grpc.CallOption) (*DeleteUserDomainResponse, error)
+	// DisableUser disables a user, who can't sign in or use access tokens until being enabled.
+	DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error)
+	// EnableUser enables a disabled user.
+	EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error)
+	// ResetUserPassword resets the password of a user to a generated one, and removes the access tokens
+	// of the user so the user is signed out everywhere.
+	ResetUserPassword(ctx context.Context, in *ResetUserPasswordRequest, opts ...grpc.CallOption) (*ResetUserPasswordResponse, error)
+	// GetUserQuota gets the quota of a user.
+	GetUserQuota(ctx context.Context, in *GetUserQuotaRequest, opts ...grpc.CallOption) (*GetUserQuotaResponse, error)
+	// UpdateUserQuota updates the quota of a user.
+	UpdateUserQuota(ctx context.Context, in *UpdateUserQuotaRequest, opts ...grpc.CallOption) (*UpdateUserQuotaResponse, error)
+	// GetUserProfile gets the public profile of a user.
+	GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*GetUserProfileResponse, error)
+	// UpdateUserProfile updates the public profile of a user.
+	UpdateUserProfile(ctx context.Context, in *UpdateUserProfileRequest, opts ...grpc.CallOption) (*UpdateUserProfileResponse, error)
+	// TransferUserContent transfers the memos, resources and tags of a user to another user.
+	TransferUserContent(ctx context.Context, in *TransferUserContentRequest, opts ...grpc.CallOption) (*TransferUserContentResponse, error)
+	// PurgeUserContent deletes the memos, resources and tags of a user, and keeps the user.
+	PurgeUserContent(ctx context.Context, in *PurgeUserContentRequest, opts ...grpc.CallOption) (*PurgeUserContentResponse, error)
 }
 
 type userServiceClient struct {
@@ -167,6 +207,123 @@ func (c *userServiceClient) DeleteUserAccessToken(ctx context.Context, in *Delet
 	return out, nil
 }
 
+func (c *userServiceClient) ListUserDomains(ctx context.Context, in *ListUserDomainsRequest, opts ...grpc.CallOption) (*ListUserDomainsResponse, error) {
+	out := new(ListUserDomainsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserDomains_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUserDomain(ctx context.Context, in *CreateUserDomainRequest, opts ...grpc.CallOption) (*CreateUserDomainResponse, error) {
+	out := new(CreateUserDomainResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateUserDomain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyUserDomain(ctx context.Context, in *VerifyUserDomainRequest, opts ...grpc.CallOption) (*VerifyUserDomainResponse, error) {
+	out := new(VerifyUserDomainResponse)
+	err := c.cc.Invoke(ctx, UserService_VerifyUserDomain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUserDomain(ctx context.Context, in *DeleteUserDomainRequest, opts ...grpc.CallOption) (*DeleteUserDomainResponse, error) {
+	out := new(DeleteUserDomainResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteUserDomain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error) {
+	out := new(DisableUserResponse)
+	err := c.cc.Invoke(ctx, UserService_DisableUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error) {
+	out := new(EnableUserResponse)
+	err := c.cc.Invoke(ctx, UserService_EnableUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ResetUserPassword(ctx context.Context, in *ResetUserPasswordRequest, opts ...grpc.CallOption) (*ResetUserPasswordResponse, error) {
+	out := new(ResetUserPasswordResponse)
+	err := c.cc.Invoke(ctx, UserService_ResetUserPassword_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserQuota(ctx context.Context, in *GetUserQuotaRequest, opts ...grpc.CallOption) (*GetUserQuotaResponse, error) {
+	out := new(GetUserQuotaResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUserQuota(ctx context.Context, in *UpdateUserQuotaRequest, opts ...grpc.CallOption) (*UpdateUserQuotaResponse, error) {
+	out := new(UpdateUserQuotaResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateUserQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*GetUserProfileResponse, error) {
+	out := new(GetUserProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUserProfile(ctx context.Context, in *UpdateUserProfileRequest, opts ...grpc.CallOption) (*UpdateUserProfileResponse, error) {
+	out := new(UpdateUserProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateUserProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) TransferUserContent(ctx context.Context, in *TransferUserContentRequest, opts ...grpc.CallOption) (*TransferUserContentResponse, error) {
+	out := new(TransferUserContentResponse)
+	err := c.cc.Invoke(ctx, UserService_TransferUserContent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PurgeUserContent(ctx context.Context, in *PurgeUserContentRequest, opts ...grpc.CallOption) (*PurgeUserContentResponse, error) {
+	out := new(PurgeUserContentResponse)
+	err := c.cc.Invoke(ctx, UserService_PurgeUserContent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -193,6 +350,33 @@ type UserServiceServer interface {
 	CreateUserAccessToken(context.Context, *CreateUserAccessTokenRequest) (*CreateUserAccessTokenResponse, error)
 	// DeleteUserAccessToken deletes an access token for a user.
 	DeleteUserAccessToken(context.Context, *DeleteUserAccessTokenRequest) (*DeleteUserAccessTokenResponse, error)
+	// ListUserDomains returns the custom domains of a user.
+	ListUserDomains(context.Context, *ListUserDomainsRequest) (*ListUserDomainsResponse, error)
+	// CreateUserDomain adds a custom domain serving the public memos of a user, it's served once it's verified.
+	CreateUserDomain(context.Context, *CreateUserDomainRequest) (*CreateUserDomainResponse, error)
+	// VerifyUserDomain verifies the ownership of a custom domain by the TXT record of its verification record name.
+	VerifyUserDomain(context.Context, *VerifyUserDomainRequest) (*VerifyUserDomainResponse, error)
+	// DeleteUserDomain deletes a custom domain of a user.
+	DeleteUserDomain(context.Context, *DeleteUserDomainRequest) (*DeleteUserDomainResponse, error)
+	// DisableUser disables a user, who can't sign in or use access tokens until being enabled.
+	DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error)
+	// EnableUser enables a disabled user.
+	EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error)
+	// ResetUserPassword resets the password of a user to a generated one, and removes the access tokens
+	// of the user so the user is signed out everywhere.
+	ResetUserPassword(context.Context, *ResetUserPasswordRequest) (*ResetUserPasswordResponse, error)
+	// GetUserQuota gets the quota of a user.
+	GetUserQuota(context.Context, *GetUserQuotaRequest) (*GetUserQuotaResponse, error)
+	// UpdateUserQuota updates the quota of a user.
+	UpdateUserQuota(context.Context, *UpdateUserQuotaRequest) (*UpdateUserQuotaResponse, error)
+	// GetUserProfile gets the public profile of a user.
+	GetUserProfile(context.Context, *GetUserProfileRequest) (*GetUserProfileResponse, error)
+	// UpdateUserProfile updates the public profile of a user.
+	UpdateUserProfile(context.Context, *UpdateUserProfileRequest) (*UpdateUserProfileResponse, error)
+	// TransferUserContent transfers the memos, resources and tags of a user to another user.
+	TransferUserContent(context.Context, *TransferUserContentRequest) (*TransferUserContentResponse, error)
+	// PurgeUserContent deletes the memos, resources and tags of a user, and keeps the user.
+	PurgeUserContent(context.Context, *PurgeUserContentRequest) (*PurgeUserContentResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -233,6 +417,45 @@ func (UnimplementedUserServiceServer) CreateUserAccessToken(context.Context, *Cr
 func (UnimplementedUserServiceServer) DeleteUserAccessToken(context.Context, *DeleteUserAccessTokenRequest) (*DeleteUserAccessTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserAccessToken not implemented")
 }
+func (UnimplementedUserServiceServer) ListUserDomains(context.Context, *ListUserDomainsRequest) (*ListUserDomainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserDomains not implemented")
+}
+func (UnimplementedUserServiceServer) CreateUserDomain(context.Context, *CreateUserDomainRequest) (*CreateUserDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserDomain not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyUserDomain(context.Context, *VerifyUserDomainRequest) (*VerifyUserDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyUserDomain not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUserDomain(context.Context, *DeleteUserDomainRequest) (*DeleteUserDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserDomain not implemented")
+}
+func (UnimplementedUserServiceServer) DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedUserServiceServer) EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedUserServiceServer) ResetUserPassword(context.Context, *ResetUserPasswordRequest) (*ResetUserPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetUserPassword not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserQuota(context.Context, *GetUserQuotaRequest) (*GetUserQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserQuota not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUserQuota(context.Context, *UpdateUserQuotaRequest) (*UpdateUserQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserQuota not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserProfile(context.Context, *GetUserProfileRequest) (*GetUserProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserProfile not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUserProfile(context.Context, *UpdateUserProfileRequest) (*UpdateUserProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
+}
+func (UnimplementedUserServiceServer) TransferUserContent(context.Context, *TransferUserContentRequest) (*TransferUserContentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferUserContent not implemented")
+}
+func (UnimplementedUserServiceServer) PurgeUserContent(context.Context, *PurgeUserContentRequest) (*PurgeUserContentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUserContent not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -444,6 +667,240 @@ func _UserService_DeleteUserAccessToken_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUserDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserDomains(ctx, req.(*ListUserDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUserDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUserDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUserDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUserDomain(ctx, req.(*CreateUserDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyUserDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyUserDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyUserDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_VerifyUserDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyUserDomain(ctx, req.(*VerifyUserDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUserDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUserDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUserDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUserDomain(ctx, req.(*DeleteUserDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DisableUser(ctx, req.(*DisableUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EnableUser(ctx, req.(*EnableUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResetUserPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetUserPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResetUserPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ResetUserPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResetUserPassword(ctx, req.(*ResetUserPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserQuota(ctx, req.(*GetUserQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUserQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUserQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUserQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUserQuota(ctx, req.(*UpdateUserQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserProfile(ctx, req.(*GetUserProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUserProfile(ctx, req.(*UpdateUserProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_TransferUserContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferUserContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).TransferUserContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_TransferUserContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).TransferUserContent(ctx, req.(*TransferUserContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PurgeUserContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PurgeUserContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PurgeUserContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PurgeUserContent(ctx, req.(*PurgeUserContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -495,6 +952,58 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserAccessToken",
 			Handler:    _UserService_DeleteUserAccessToken_Handler,
 		},
+		{
+			MethodName: "ListUserDomains",
+			Handler:    _UserService_ListUserDomains_Handler,
+		},
+		{
+			MethodName: "CreateUserDomain",
+			Handler:    _UserService_CreateUserDomain_Handler,
+		},
+		{
+			MethodName: "VerifyUserDomain",
+			Handler:    _UserService_VerifyUserDomain_Handler,
+		},
+		{
+			MethodName: "DeleteUserDomain",
+			Handler:    _UserService_DeleteUserDomain_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _UserService_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _UserService_EnableUser_Handler,
+		},
+		{
+			MethodName: "ResetUserPassword",
+			Handler:    _UserService_ResetUserPassword_Handler,
+		},
+		{
+			MethodName: "GetUserQuota",
+			Handler:    _UserService_GetUserQuota_Handler,
+		},
+		{
+			MethodName: "UpdateUserQuota",
+			Handler:    _UserService_UpdateUserQuota_Handler,
+		},
+		{
+			MethodName: "GetUserProfile",
+			Handler:    _UserService_GetUserProfile_Handler,
+		},
+		{
+			MethodName: "UpdateUserProfile",
+			Handler:    _UserService_UpdateUserProfile_Handler,
+		},
+		{
+			MethodName: "TransferUserContent",
+			Handler:    _UserService_TransferUserContent_Handler,
+		},
+		{
+			MethodName: "PurgeUserContent",
+			Handler:    _UserService_PurgeUserContent_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v2/user_service.proto",
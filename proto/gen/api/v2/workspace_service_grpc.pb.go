@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	WorkspaceService_GetWorkspaceProfile_FullMethodName = "/memos.api.v2.WorkspaceService/GetWorkspaceProfile"
+	WorkspaceService_GetWorkspaceProfile_FullMethodName      = "/memos.api.v2.WorkspaceService/GetWorkspaceProfile"
+	WorkspaceService_GetWorkspaceAnnouncement_FullMethodName = "/memos.api.v2.WorkspaceService/GetWorkspaceAnnouncement"
 )
 
 // WorkspaceServiceClient is the client API for WorkspaceService service.
@@ -28,6 +29,8 @@ const (
 type WorkspaceServiceClient interface {
 	// GetWorkspaceProfile returns the workspace profile.
 	GetWorkspaceProfile(ctx context.Context, in *GetWorkspaceProfileRequest, opts ...grpc.CallOption) (*GetWorkspaceProfileResponse, error)
+	// GetWorkspaceAnnouncement returns the announcement shown to the users, if there is one which isn't expired.
+	GetWorkspaceAnnouncement(ctx context.Context, in *GetWorkspaceAnnouncementRequest, opts ...grpc.CallOption) (*GetWorkspaceAnnouncementResponse, error)
 }
 
 type workspaceServiceClient struct {
@@ -47,12 +50,23 @@ func (c *workspaceServiceClient) GetWorkspaceProfile(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *workspaceServiceClient) GetWorkspaceAnnouncement(ctx context.Context, in *GetWorkspaceAnnouncementRequest, opts ...grpc.CallOption) (*GetWorkspaceAnnouncementResponse, error) {
+	out := new(GetWorkspaceAnnouncementResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetWorkspaceAnnouncement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspaceServiceServer is the server API for WorkspaceService service.
 // All implementations must embed UnimplementedWorkspaceServiceServer
 // for forward compatibility
 type WorkspaceServiceServer interface {
 	// GetWorkspaceProfile returns the workspace profile.
 	GetWorkspaceProfile(context.Context, *GetWorkspaceProfileRequest) (*GetWorkspaceProfileResponse, error)
+	// GetWorkspaceAnnouncement returns the announcement shown to the users, if there is one which isn't expired.
+	GetWorkspaceAnnouncement(context.Context, *GetWorkspaceAnnouncementRequest) (*GetWorkspaceAnnouncementResponse, error)
 	mustEmbedUnimplementedWorkspaceServiceServer()
 }
 
@@ -63,6 +77,9 @@ type UnimplementedWorkspaceServiceServer struct {
 func (UnimplementedWorkspaceServiceServer) GetWorkspaceProfile(context.Context, *GetWorkspaceProfileRequest) (*GetWorkspaceProfileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaceProfile not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) GetWorkspaceAnnouncement(context.Context, *GetWorkspaceAnnouncementRequest) (*GetWorkspaceAnnouncementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaceAnnouncement not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) mustEmbedUnimplementedWorkspaceServiceServer() {}
 
 // UnsafeWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -94,6 +111,24 @@ func _WorkspaceService_GetWorkspaceProfile_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_GetWorkspaceAnnouncement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceAnnouncementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetWorkspaceAnnouncement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetWorkspaceAnnouncement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetWorkspaceAnnouncement(ctx, req.(*GetWorkspaceAnnouncementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -105,6 +140,10 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWorkspaceProfile",
 			Handler:    _WorkspaceService_GetWorkspaceProfile_Handler,
 		},
+		{
+			MethodName: "GetWorkspaceAnnouncement",
+			Handler:    _WorkspaceService_GetWorkspaceAnnouncement_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v2/workspace_service.proto",
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +22,321 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type UploadScannerSetting_Type int32
+
+const (
+	UploadScannerSetting_TYPE_UNSPECIFIED UploadScannerSetting_Type = 0
+	UploadScannerSetting_CLAMAV           UploadScannerSetting_Type = 1
+	UploadScannerSetting_ICAP             UploadScannerSetting_Type = 2
+)
+
+// Enum value maps for UploadScannerSetting_Type.
+var (
+	UploadScannerSetting_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "CLAMAV",
+		2: "ICAP",
+	}
+	UploadScannerSetting_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"CLAMAV":           1,
+		"ICAP":             2,
+	}
+)
+
+func (x UploadScannerSetting_Type) Enum() *UploadScannerSetting_Type {
+	p := new(UploadScannerSetting_Type)
+	*p = x
+	return p
+}
+
+func (x UploadScannerSetting_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UploadScannerSetting_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_workspace_setting_service_proto_enumTypes[0].Descriptor()
+}
+
+func (UploadScannerSetting_Type) Type() protoreflect.EnumType {
+	return &file_api_v2_workspace_setting_service_proto_enumTypes[0]
+}
+
+func (x UploadScannerSetting_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UploadScannerSetting_Type.Descriptor instead.
+func (UploadScannerSetting_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type OCRSetting_Engine int32
+
+const (
+	OCRSetting_ENGINE_UNSPECIFIED OCRSetting_Engine = 0
+	// TESSERACT runs the tesseract command.
+	OCRSetting_TESSERACT OCRSetting_Engine = 1
+	// HTTP posts the images to an OCR service.
+	OCRSetting_HTTP OCRSetting_Engine = 2
+)
+
+// Enum value maps for OCRSetting_Engine.
+var (
+	OCRSetting_Engine_name = map[int32]string{
+		0: "ENGINE_UNSPECIFIED",
+		1: "TESSERACT",
+		2: "HTTP",
+	}
+	OCRSetting_Engine_value = map[string]int32{
+		"ENGINE_UNSPECIFIED": 0,
+		"TESSERACT":          1,
+		"HTTP":               2,
+	}
+)
+
+func (x OCRSetting_Engine) Enum() *OCRSetting_Engine {
+	p := new(OCRSetting_Engine)
+	*p = x
+	return p
+}
+
+func (x OCRSetting_Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OCRSetting_Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_workspace_setting_service_proto_enumTypes[1].Descriptor()
+}
+
+func (OCRSetting_Engine) Type() protoreflect.EnumType {
+	return &file_api_v2_workspace_setting_service_proto_enumTypes[1]
+}
+
+func (x OCRSetting_Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OCRSetting_Engine.Descriptor instead.
+func (OCRSetting_Engine) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type SMTPSetting_Security int32
+
+const (
+	// STARTTLS is used if the server supports it.
+	SMTPSetting_SECURITY_UNSPECIFIED SMTPSetting_Security = 0
+	SMTPSetting_NONE                 SMTPSetting_Security = 1
+	SMTPSetting_STARTTLS             SMTPSetting_Security = 2
+	SMTPSetting_TLS                  SMTPSetting_Security = 3
+)
+
+// Enum value maps for SMTPSetting_Security.
+var (
+	SMTPSetting_Security_name = map[int32]string{
+		0: "SECURITY_UNSPECIFIED",
+		1: "NONE",
+		2: "STARTTLS",
+		3: "TLS",
+	}
+	SMTPSetting_Security_value = map[string]int32{
+		"SECURITY_UNSPECIFIED": 0,
+		"NONE":                 1,
+		"STARTTLS":             2,
+		"TLS":                  3,
+	}
+)
+
+func (x SMTPSetting_Security) Enum() *SMTPSetting_Security {
+	p := new(SMTPSetting_Security)
+	*p = x
+	return p
+}
+
+func (x SMTPSetting_Security) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SMTPSetting_Security) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_workspace_setting_service_proto_enumTypes[2].Descriptor()
+}
+
+func (SMTPSetting_Security) Type() protoreflect.EnumType {
+	return &file_api_v2_workspace_setting_service_proto_enumTypes[2]
+}
+
+func (x SMTPSetting_Security) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SMTPSetting_Security.Descriptor instead.
+func (SMTPSetting_Security) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{18, 0}
+}
+
+type WorkspaceAnnouncementSetting_Severity int32
+
+const (
+	WorkspaceAnnouncementSetting_SEVERITY_UNSPECIFIED WorkspaceAnnouncementSetting_Severity = 0
+	WorkspaceAnnouncementSetting_INFO                 WorkspaceAnnouncementSetting_Severity = 1
+	WorkspaceAnnouncementSetting_WARNING              WorkspaceAnnouncementSetting_Severity = 2
+	WorkspaceAnnouncementSetting_CRITICAL             WorkspaceAnnouncementSetting_Severity = 3
+)
+
+// Enum value maps for WorkspaceAnnouncementSetting_Severity.
+var (
+	WorkspaceAnnouncementSetting_Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "INFO",
+		2: "WARNING",
+		3: "CRITICAL",
+	}
+	WorkspaceAnnouncementSetting_Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"INFO":                 1,
+		"WARNING":              2,
+		"CRITICAL":             3,
+	}
+)
+
+func (x WorkspaceAnnouncementSetting_Severity) Enum() *WorkspaceAnnouncementSetting_Severity {
+	p := new(WorkspaceAnnouncementSetting_Severity)
+	*p = x
+	return p
+}
+
+func (x WorkspaceAnnouncementSetting_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceAnnouncementSetting_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_workspace_setting_service_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkspaceAnnouncementSetting_Severity) Type() protoreflect.EnumType {
+	return &file_api_v2_workspace_setting_service_proto_enumTypes[3]
+}
+
+func (x WorkspaceAnnouncementSetting_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceAnnouncementSetting_Severity.Descriptor instead.
+func (WorkspaceAnnouncementSetting_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{21, 0}
+}
+
+type WorkspaceHook_Type int32
+
+const (
+	WorkspaceHook_TYPE_UNSPECIFIED WorkspaceHook_Type = 0
+	// HTTP hooks are sent the requests as JSON in POST requests.
+	WorkspaceHook_HTTP WorkspaceHook_Type = 1
+	// EXEC hooks are executables in the hooks directory of the data directory, which read the requests from stdin and write the responses to stdout.
+	WorkspaceHook_EXEC WorkspaceHook_Type = 2
+)
+
+// Enum value maps for WorkspaceHook_Type.
+var (
+	WorkspaceHook_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "HTTP",
+		2: "EXEC",
+	}
+	WorkspaceHook_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"HTTP":             1,
+		"EXEC":             2,
+	}
+)
+
+func (x WorkspaceHook_Type) Enum() *WorkspaceHook_Type {
+	p := new(WorkspaceHook_Type)
+	*p = x
+	return p
+}
+
+func (x WorkspaceHook_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceHook_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_workspace_setting_service_proto_enumTypes[4].Descriptor()
+}
+
+func (WorkspaceHook_Type) Type() protoreflect.EnumType {
+	return &file_api_v2_workspace_setting_service_proto_enumTypes[4]
+}
+
+func (x WorkspaceHook_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceHook_Type.Descriptor instead.
+func (WorkspaceHook_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{25, 0}
+}
+
+type WorkspaceHook_Event int32
+
+const (
+	WorkspaceHook_EVENT_UNSPECIFIED WorkspaceHook_Event = 0
+	// MEMO_CREATE is run before a memo is created, the hook can transform or reject the content.
+	WorkspaceHook_MEMO_CREATE WorkspaceHook_Event = 1
+	// MEMO_UPDATE is run before the content of a memo is updated, the hook can transform or reject the content.
+	WorkspaceHook_MEMO_UPDATE WorkspaceHook_Event = 2
+	// RESOURCE_UPLOAD is run before an uploaded file is saved, the hook can reject the file.
+	WorkspaceHook_RESOURCE_UPLOAD WorkspaceHook_Event = 3
+	// MEMO_RENDER is run when the server renders a memo, e.g. in the feeds, the hook can transform the rendered content.
+	WorkspaceHook_MEMO_RENDER WorkspaceHook_Event = 4
+)
+
+// Enum value maps for WorkspaceHook_Event.
+var (
+	WorkspaceHook_Event_name = map[int32]string{
+		0: "EVENT_UNSPECIFIED",
+		1: "MEMO_CREATE",
+		2: "MEMO_UPDATE",
+		3: "RESOURCE_UPLOAD",
+		4: "MEMO_RENDER",
+	}
+	WorkspaceHook_Event_value = map[string]int32{
+		"EVENT_UNSPECIFIED": 0,
+		"MEMO_CREATE":       1,
+		"MEMO_UPDATE":       2,
+		"RESOURCE_UPLOAD":   3,
+		"MEMO_RENDER":       4,
+	}
+)
+
+func (x WorkspaceHook_Event) Enum() *WorkspaceHook_Event {
+	p := new(WorkspaceHook_Event)
+	*p = x
+	return p
+}
+
+func (x WorkspaceHook_Event) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceHook_Event) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_workspace_setting_service_proto_enumTypes[5].Descriptor()
+}
+
+func (WorkspaceHook_Event) Type() protoreflect.EnumType {
+	return &file_api_v2_workspace_setting_service_proto_enumTypes[5]
+}
+
+func (x WorkspaceHook_Event) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceHook_Event.Descriptor instead.
+func (WorkspaceHook_Event) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{25, 1}
+}
+
 type GetWorkspaceSettingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -223,6 +539,13 @@ type WorkspaceSetting struct {
 	// Types that are assignable to Value:
 	//
 	//	*WorkspaceSetting_GeneralSetting
+	//	*WorkspaceSetting_StorageSetting
+	//	*WorkspaceSetting_IntegrationSetting
+	//	*WorkspaceSetting_SchedulerSetting
+	//	*WorkspaceSetting_AnnouncementSetting
+	//	*WorkspaceSetting_ExploreSetting
+	//	*WorkspaceSetting_BrandingSetting
+	//	*WorkspaceSetting_HookSetting
 	Value isWorkspaceSetting_Value `protobuf_oneof:"value"`
 }
 
@@ -279,6 +602,55 @@ func (x *WorkspaceSetting) GetGeneralSetting() *WorkspaceGeneralSetting {
 	return nil
 }
 
+func (x *WorkspaceSetting) GetStorageSetting() *WorkspaceStorageSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_StorageSetting); ok {
+		return x.StorageSetting
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetIntegrationSetting() *WorkspaceIntegrationSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_IntegrationSetting); ok {
+		return x.IntegrationSetting
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetSchedulerSetting() *WorkspaceSchedulerSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_SchedulerSetting); ok {
+		return x.SchedulerSetting
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetAnnouncementSetting() *WorkspaceAnnouncementSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_AnnouncementSetting); ok {
+		return x.AnnouncementSetting
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetExploreSetting() *WorkspaceExploreSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_ExploreSetting); ok {
+		return x.ExploreSetting
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetBrandingSetting() *WorkspaceBrandingSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_BrandingSetting); ok {
+		return x.BrandingSetting
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetHookSetting() *WorkspaceHookSetting {
+	if x, ok := x.GetValue().(*WorkspaceSetting_HookSetting); ok {
+		return x.HookSetting
+	}
+	return nil
+}
+
 type isWorkspaceSetting_Value interface {
 	isWorkspaceSetting_Value()
 }
@@ -288,8 +660,57 @@ type WorkspaceSetting_GeneralSetting struct {
 	GeneralSetting *WorkspaceGeneralSetting `protobuf:"bytes,2,opt,name=general_setting,json=generalSetting,proto3,oneof"`
 }
 
+type WorkspaceSetting_StorageSetting struct {
+	// storage_setting is the storage setting of workspace.
+	StorageSetting *WorkspaceStorageSetting `protobuf:"bytes,3,opt,name=storage_setting,json=storageSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_IntegrationSetting struct {
+	// integration_setting is the integration setting of workspace, which is only visible to the host.
+	IntegrationSetting *WorkspaceIntegrationSetting `protobuf:"bytes,4,opt,name=integration_setting,json=integrationSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_SchedulerSetting struct {
+	// scheduler_setting is the scheduler setting of workspace, which is only visible to the host.
+	SchedulerSetting *WorkspaceSchedulerSetting `protobuf:"bytes,5,opt,name=scheduler_setting,json=schedulerSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_AnnouncementSetting struct {
+	// announcement_setting is the announcement setting of workspace.
+	AnnouncementSetting *WorkspaceAnnouncementSetting `protobuf:"bytes,6,opt,name=announcement_setting,json=announcementSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_ExploreSetting struct {
+	// explore_setting is the explore setting of workspace, which is only visible to the host.
+	ExploreSetting *WorkspaceExploreSetting `protobuf:"bytes,7,opt,name=explore_setting,json=exploreSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_BrandingSetting struct {
+	// branding_setting is the branding setting of workspace.
+	BrandingSetting *WorkspaceBrandingSetting `protobuf:"bytes,8,opt,name=branding_setting,json=brandingSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_HookSetting struct {
+	// hook_setting is the hook setting of workspace, which is only visible to the host.
+	HookSetting *WorkspaceHookSetting `protobuf:"bytes,9,opt,name=hook_setting,json=hookSetting,proto3,oneof"`
+}
+
 func (*WorkspaceSetting_GeneralSetting) isWorkspaceSetting_Value() {}
 
+func (*WorkspaceSetting_StorageSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_IntegrationSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_SchedulerSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_AnnouncementSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_ExploreSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_BrandingSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_HookSetting) isWorkspaceSetting_Value() {}
+
 type WorkspaceGeneralSetting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -305,6 +726,10 @@ type WorkspaceGeneralSetting struct {
 	AdditionalScript string `protobuf:"bytes,5,opt,name=additional_script,json=additionalScript,proto3" json:"additional_script,omitempty"`
 	// additional_style is the additional style.
 	AdditionalStyle string `protobuf:"bytes,6,opt,name=additional_style,json=additionalStyle,proto3" json:"additional_style,omitempty"`
+	// invite_only_signup is the flag to allow signup only with an invitation, disallow_signup disallows it too.
+	InviteOnlySignup bool `protobuf:"varint,7,opt,name=invite_only_signup,json=inviteOnlySignup,proto3" json:"invite_only_signup,omitempty"`
+	// allow_user_invitations is the flag to allow the users besides the admins to create the invitations of the user role.
+	AllowUserInvitations bool `protobuf:"varint,8,opt,name=allow_user_invitations,json=allowUserInvitations,proto3" json:"allow_user_invitations,omitempty"`
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -374,177 +799,2095 @@ func (x *WorkspaceGeneralSetting) GetAdditionalStyle() string {
 	return ""
 }
 
-var File_api_v2_workspace_setting_service_proto protoreflect.FileDescriptor
+func (x *WorkspaceGeneralSetting) GetInviteOnlySignup() bool {
+	if x != nil {
+		return x.InviteOnlySignup
+	}
+	return false
+}
 
-var file_api_v2_workspace_setting_service_proto_rawDesc = []byte{
-	0x0a, 0x26, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f,
-	0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35,
-	0x0a, 0x1a, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x57, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x56,
-	0x0a, 0x1a, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x07,
-	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
-	0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73,
-	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x57, 0x0a, 0x1b, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53,
-	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x22,
-	0x81, 0x01, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x67, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32,
-	0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
-	0x6c, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0e, 0x67, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x6c, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x17, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
-	0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x55,
-	0x72, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73,
-	0x69, 0x67, 0x6e, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x69, 0x73,
-	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x12, 0x36, 0x0a, 0x17, 0x64,
-	0x69, 0x73, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
-	0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x64, 0x69,
-	0x73, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4c, 0x6f,
-	0x67, 0x69, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61,
-	0x6c, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
-	0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x12, 0x29, 0x0a, 0x10, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73,
-	0x74, 0x79, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x64, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x32, 0xef, 0x02, 0x0a, 0x17,
-	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x9e, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x57,
-	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
-	0x28, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x47,
-	0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69,
-	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
-	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0xda, 0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x25, 0x12, 0x23, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x77, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x73, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x2a, 0x7d, 0x12, 0xb2, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74,
-	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x12, 0x28, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e,
-	0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74,
-	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x6d,
-	0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0xda, 0x41, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e,
-	0x67, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x36, 0x3a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x32, 0x2b, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x2f, 0x7b, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x6e, 0x61, 0x6d,
-	0x65, 0x3d, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x2a, 0x7d, 0x42, 0xb4, 0x01,
-	0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x76, 0x32, 0x42, 0x1c, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x50, 0x01, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75,
-	0x73, 0x65, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2f, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x3b, 0x61,
-	0x70, 0x69, 0x76, 0x32, 0xa2, 0x02, 0x03, 0x4d, 0x41, 0x58, 0xaa, 0x02, 0x0c, 0x4d, 0x65, 0x6d,
-	0x6f, 0x73, 0x2e, 0x41, 0x70, 0x69, 0x2e, 0x56, 0x32, 0xca, 0x02, 0x0c, 0x4d, 0x65, 0x6d, 0x6f,
-	0x73, 0x5c, 0x41, 0x70, 0x69, 0x5c, 0x56, 0x32, 0xe2, 0x02, 0x18, 0x4d, 0x65, 0x6d, 0x6f, 0x73,
-	0x5c, 0x41, 0x70, 0x69, 0x5c, 0x56, 0x32, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x3a, 0x3a, 0x41, 0x70, 0x69,
-	0x3a, 0x3a, 0x56, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *WorkspaceGeneralSetting) GetAllowUserInvitations() bool {
+	if x != nil {
+		return x.AllowUserInvitations
+	}
+	return false
 }
 
-var (
-	file_api_v2_workspace_setting_service_proto_rawDescOnce sync.Once
-	file_api_v2_workspace_setting_service_proto_rawDescData = file_api_v2_workspace_setting_service_proto_rawDesc
-)
+type WorkspaceStorageSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_api_v2_workspace_setting_service_proto_rawDescGZIP() []byte {
-	file_api_v2_workspace_setting_service_proto_rawDescOnce.Do(func() {
-		file_api_v2_workspace_setting_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v2_workspace_setting_service_proto_rawDescData)
-	})
-	return file_api_v2_workspace_setting_service_proto_rawDescData
+	// image_compression_threshold_mib is the size threshold of uploaded images to be recompressed.
+	// 0 means image compression is disabled.
+	ImageCompressionThresholdMib int32 `protobuf:"varint,1,opt,name=image_compression_threshold_mib,json=imageCompressionThresholdMib,proto3" json:"image_compression_threshold_mib,omitempty"`
+	// image_quality is the JPEG quality of recompressed images, from 1 to 100.
+	ImageQuality int32 `protobuf:"varint,2,opt,name=image_quality,json=imageQuality,proto3" json:"image_quality,omitempty"`
+	// image_max_dimension is the max width and height in pixels of recompressed images.
+	// 0 means images are not downscaled.
+	ImageMaxDimension int32 `protobuf:"varint,3,opt,name=image_max_dimension,json=imageMaxDimension,proto3" json:"image_max_dimension,omitempty"`
+	// upload_scanner is the scanner which uploaded files are submitted to.
+	UploadScanner *UploadScannerSetting `protobuf:"bytes,4,opt,name=upload_scanner,json=uploadScanner,proto3" json:"upload_scanner,omitempty"`
+	// ocr is the OCR setting for recognizing the text in uploaded images.
+	Ocr *OCRSetting `protobuf:"bytes,5,opt,name=ocr,proto3" json:"ocr,omitempty"`
+	// upload_restrictions are the upload restrictions for roles and users.
+	UploadRestrictions []*UploadRestriction `protobuf:"bytes,6,rep,name=upload_restrictions,json=uploadRestrictions,proto3" json:"upload_restrictions,omitempty"`
 }
 
-var file_api_v2_workspace_setting_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_api_v2_workspace_setting_service_proto_goTypes = []interface{}{
-	(*GetWorkspaceSettingRequest)(nil),  // 0: memos.api.v2.GetWorkspaceSettingRequest
-	(*GetWorkspaceSettingResponse)(nil), // 1: memos.api.v2.GetWorkspaceSettingResponse
-	(*SetWorkspaceSettingRequest)(nil),  // 2: memos.api.v2.SetWorkspaceSettingRequest
-	(*SetWorkspaceSettingResponse)(nil), // 3: memos.api.v2.SetWorkspaceSettingResponse
-	(*WorkspaceSetting)(nil),            // 4: memos.api.v2.WorkspaceSetting
-	(*WorkspaceGeneralSetting)(nil),     // 5: memos.api.v2.WorkspaceGeneralSetting
+func (x *WorkspaceStorageSetting) Reset() {
+	*x = WorkspaceStorageSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_api_v2_workspace_setting_service_proto_depIdxs = []int32{
-	4, // 0: memos.api.v2.GetWorkspaceSettingResponse.setting:type_name -> memos.api.v2.WorkspaceSetting
-	4, // 1: memos.api.v2.SetWorkspaceSettingRequest.setting:type_name -> memos.api.v2.WorkspaceSetting
-	4, // 2: memos.api.v2.SetWorkspaceSettingResponse.setting:type_name -> memos.api.v2.WorkspaceSetting
-	5, // 3: memos.api.v2.WorkspaceSetting.general_setting:type_name -> memos.api.v2.WorkspaceGeneralSetting
-	0, // 4: memos.api.v2.WorkspaceSettingService.GetWorkspaceSetting:input_type -> memos.api.v2.GetWorkspaceSettingRequest
-	2, // 5: memos.api.v2.WorkspaceSettingService.SetWorkspaceSetting:input_type -> memos.api.v2.SetWorkspaceSettingRequest
-	1, // 6: memos.api.v2.WorkspaceSettingService.GetWorkspaceSetting:output_type -> memos.api.v2.GetWorkspaceSettingResponse
-	3, // 7: memos.api.v2.WorkspaceSettingService.SetWorkspaceSetting:output_type -> memos.api.v2.SetWorkspaceSettingResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *WorkspaceStorageSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_api_v2_workspace_setting_service_proto_init() }
-func file_api_v2_workspace_setting_service_proto_init() {
-	if File_api_v2_workspace_setting_service_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_v2_workspace_setting_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetWorkspaceSettingRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_v2_workspace_setting_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetWorkspaceSettingResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_v2_workspace_setting_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetWorkspaceSettingRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*WorkspaceStorageSetting) ProtoMessage() {}
+
+func (x *WorkspaceStorageSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceStorageSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceStorageSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WorkspaceStorageSetting) GetImageCompressionThresholdMib() int32 {
+	if x != nil {
+		return x.ImageCompressionThresholdMib
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageSetting) GetImageQuality() int32 {
+	if x != nil {
+		return x.ImageQuality
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageSetting) GetImageMaxDimension() int32 {
+	if x != nil {
+		return x.ImageMaxDimension
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageSetting) GetUploadScanner() *UploadScannerSetting {
+	if x != nil {
+		return x.UploadScanner
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetOcr() *OCRSetting {
+	if x != nil {
+		return x.Ocr
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetUploadRestrictions() []*UploadRestriction {
+	if x != nil {
+		return x.UploadRestrictions
+	}
+	return nil
+}
+
+type UploadScannerSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type is the type of the scanner, unspecified means uploads are not scanned.
+	Type UploadScannerSetting_Type `protobuf:"varint,1,opt,name=type,proto3,enum=memos.api.v2.UploadScannerSetting_Type" json:"type,omitempty"`
+	// address is the address of the scanner.
+	// e.g. `tcp://127.0.0.1:3310` for ClamAV, `icap://127.0.0.1:1344/avscan` for ICAP.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// fail_open is the flag to accept uploads when the scanner is unavailable.
+	// Otherwise uploads are rejected.
+	FailOpen bool `protobuf:"varint,3,opt,name=fail_open,json=failOpen,proto3" json:"fail_open,omitempty"`
+}
+
+func (x *UploadScannerSetting) Reset() {
+	*x = UploadScannerSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadScannerSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadScannerSetting) ProtoMessage() {}
+
+func (x *UploadScannerSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadScannerSetting.ProtoReflect.Descriptor instead.
+func (*UploadScannerSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UploadScannerSetting) GetType() UploadScannerSetting_Type {
+	if x != nil {
+		return x.Type
+	}
+	return UploadScannerSetting_TYPE_UNSPECIFIED
+}
+
+func (x *UploadScannerSetting) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UploadScannerSetting) GetFailOpen() bool {
+	if x != nil {
+		return x.FailOpen
+	}
+	return false
+}
+
+type OCRSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// engine is the OCR engine, unspecified means OCR is disabled.
+	Engine OCRSetting_Engine `protobuf:"varint,1,opt,name=engine,proto3,enum=memos.api.v2.OCRSetting_Engine" json:"engine,omitempty"`
+	// address is the path of the tesseract command for TESSERACT, or the endpoint of the service for HTTP.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// language is the language of the text in images, e.g. `eng`.
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *OCRSetting) Reset() {
+	*x = OCRSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCRSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRSetting) ProtoMessage() {}
+
+func (x *OCRSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRSetting.ProtoReflect.Descriptor instead.
+func (*OCRSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OCRSetting) GetEngine() OCRSetting_Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return OCRSetting_ENGINE_UNSPECIFIED
+}
+
+func (x *OCRSetting) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *OCRSetting) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type UploadRestriction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// role is the role the restriction applies to.
+	Role User_Role `protobuf:"varint,1,opt,name=role,proto3,enum=memos.api.v2.User_Role" json:"role,omitempty"`
+	// user is the name of the user the restriction applies to.
+	// Format: users/{id}
+	// A restriction for a user takes precedence over the restriction for the user's role.
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// allowed_mime_types are the MIME types allowed to upload, e.g. `image/png` or `image/*`.
+	// Empty means all types are allowed.
+	AllowedMimeTypes []string `protobuf:"bytes,3,rep,name=allowed_mime_types,json=allowedMimeTypes,proto3" json:"allowed_mime_types,omitempty"`
+	// max_upload_size_mib is the max upload size.
+	// 0 means the max upload size system setting is used.
+	MaxUploadSizeMib int32 `protobuf:"varint,4,opt,name=max_upload_size_mib,json=maxUploadSizeMib,proto3" json:"max_upload_size_mib,omitempty"`
+}
+
+func (x *UploadRestriction) Reset() {
+	*x = UploadRestriction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadRestriction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRestriction) ProtoMessage() {}
+
+func (x *UploadRestriction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRestriction.ProtoReflect.Descriptor instead.
+func (*UploadRestriction) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UploadRestriction) GetRole() User_Role {
+	if x != nil {
+		return x.Role
+	}
+	return User_ROLE_UNSPECIFIED
+}
+
+func (x *UploadRestriction) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *UploadRestriction) GetAllowedMimeTypes() []string {
+	if x != nil {
+		return x.AllowedMimeTypes
+	}
+	return nil
+}
+
+func (x *UploadRestriction) GetMaxUploadSizeMib() int32 {
+	if x != nil {
+		return x.MaxUploadSizeMib
+	}
+	return 0
+}
+
+type WorkspaceIntegrationSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// slack is the setting of the Slack app.
+	Slack *SlackSetting `protobuf:"bytes,1,opt,name=slack,proto3" json:"slack,omitempty"`
+	// discord is the setting of the Discord bot.
+	Discord *DiscordSetting `protobuf:"bytes,2,opt,name=discord,proto3" json:"discord,omitempty"`
+	// email_ingestion is the setting of saving the received emails as memos.
+	EmailIngestion *EmailIngestionSetting `protobuf:"bytes,3,opt,name=email_ingestion,json=emailIngestion,proto3" json:"email_ingestion,omitempty"`
+	// smtp is the setting of the SMTP server sending the notification emails.
+	Smtp *SMTPSetting `protobuf:"bytes,4,opt,name=smtp,proto3" json:"smtp,omitempty"`
+	// webdav is the setting of the WebDAV view of the memos.
+	Webdav *WebDAVSetting `protobuf:"bytes,5,opt,name=webdav,proto3" json:"webdav,omitempty"`
+	// ai is the setting of the AI assistance, summarization, tag suggestions and semantic search.
+	Ai *AISetting `protobuf:"bytes,6,opt,name=ai,proto3" json:"ai,omitempty"`
+	// guest_comment is the setting of the comments of the visitors without accounts on the public memos.
+	GuestComment *GuestCommentSetting `protobuf:"bytes,7,opt,name=guest_comment,json=guestComment,proto3" json:"guest_comment,omitempty"`
+}
+
+func (x *WorkspaceIntegrationSetting) Reset() {
+	*x = WorkspaceIntegrationSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceIntegrationSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceIntegrationSetting) ProtoMessage() {}
+
+func (x *WorkspaceIntegrationSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceIntegrationSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceIntegrationSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WorkspaceIntegrationSetting) GetSlack() *SlackSetting {
+	if x != nil {
+		return x.Slack
+	}
+	return nil
+}
+
+func (x *WorkspaceIntegrationSetting) GetDiscord() *DiscordSetting {
+	if x != nil {
+		return x.Discord
+	}
+	return nil
+}
+
+func (x *WorkspaceIntegrationSetting) GetEmailIngestion() *EmailIngestionSetting {
+	if x != nil {
+		return x.EmailIngestion
+	}
+	return nil
+}
+
+func (x *WorkspaceIntegrationSetting) GetSmtp() *SMTPSetting {
+	if x != nil {
+		return x.Smtp
+	}
+	return nil
+}
+
+func (x *WorkspaceIntegrationSetting) GetWebdav() *WebDAVSetting {
+	if x != nil {
+		return x.Webdav
+	}
+	return nil
+}
+
+func (x *WorkspaceIntegrationSetting) GetAi() *AISetting {
+	if x != nil {
+		return x.Ai
+	}
+	return nil
+}
+
+func (x *WorkspaceIntegrationSetting) GetGuestComment() *GuestCommentSetting {
+	if x != nil {
+		return x.GuestComment
+	}
+	return nil
+}
+
+type GuestCommentSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// enabled is the flag to allow the visitors without accounts to comment on the public memos.
+	// The comments are shown after the memo creators approve them.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// captcha_provider is the captcha the visitors solve to comment, `turnstile` or `hcaptcha`.
+	CaptchaProvider string `protobuf:"bytes,2,opt,name=captcha_provider,json=captchaProvider,proto3" json:"captcha_provider,omitempty"`
+	// captcha_site_key is the public key of the site, the web client renders the captcha with it.
+	CaptchaSiteKey string `protobuf:"bytes,3,opt,name=captcha_site_key,json=captchaSiteKey,proto3" json:"captcha_site_key,omitempty"`
+	// captcha_secret_key is the secret key of the site, the server verifies the responses of the captcha with it.
+	CaptchaSecretKey string `protobuf:"bytes,4,opt,name=captcha_secret_key,json=captchaSecretKey,proto3" json:"captcha_secret_key,omitempty"`
+	// max_comments_per_hour is the max number of the comments from an IP address in an hour, default to 5.
+	MaxCommentsPerHour int32 `protobuf:"varint,5,opt,name=max_comments_per_hour,json=maxCommentsPerHour,proto3" json:"max_comments_per_hour,omitempty"`
+}
+
+func (x *GuestCommentSetting) Reset() {
+	*x = GuestCommentSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestCommentSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestCommentSetting) ProtoMessage() {}
+
+func (x *GuestCommentSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestCommentSetting.ProtoReflect.Descriptor instead.
+func (*GuestCommentSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GuestCommentSetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GuestCommentSetting) GetCaptchaProvider() string {
+	if x != nil {
+		return x.CaptchaProvider
+	}
+	return ""
+}
+
+func (x *GuestCommentSetting) GetCaptchaSiteKey() string {
+	if x != nil {
+		return x.CaptchaSiteKey
+	}
+	return ""
+}
+
+func (x *GuestCommentSetting) GetCaptchaSecretKey() string {
+	if x != nil {
+		return x.CaptchaSecretKey
+	}
+	return ""
+}
+
+func (x *GuestCommentSetting) GetMaxCommentsPerHour() int32 {
+	if x != nil {
+		return x.MaxCommentsPerHour
+	}
+	return 0
+}
+
+type AISetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// endpoint is the base URL of an OpenAI compatible API, e.g. `https://api.openai.com/v1`, or `http://localhost:11434/v1` for Ollama.
+	// Empty means the AI assistance is disabled.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// api_key is the key of the API, local servers like Ollama don't need one.
+	ApiKey string `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// chat_model is the model summarizing the memos and suggesting the tags, e.g. `gpt-4o-mini` or `llama3`.
+	// Empty means summarization and tag suggestions are disabled.
+	ChatModel string `protobuf:"bytes,3,opt,name=chat_model,json=chatModel,proto3" json:"chat_model,omitempty"`
+	// embedding_model is the model embedding the memos for the semantic search, e.g. `text-embedding-3-small` or `nomic-embed-text`.
+	// Empty means the semantic search is disabled.
+	EmbeddingModel string `protobuf:"bytes,4,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	// suggest_tags is the flag to suggest the tags of the memos when they are saved.
+	SuggestTags bool `protobuf:"varint,5,opt,name=suggest_tags,json=suggestTags,proto3" json:"suggest_tags,omitempty"`
+}
+
+func (x *AISetting) Reset() {
+	*x = AISetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AISetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AISetting) ProtoMessage() {}
+
+func (x *AISetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AISetting.ProtoReflect.Descriptor instead.
+func (*AISetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AISetting) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *AISetting) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *AISetting) GetChatModel() string {
+	if x != nil {
+		return x.ChatModel
+	}
+	return ""
+}
+
+func (x *AISetting) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+func (x *AISetting) GetSuggestTags() bool {
+	if x != nil {
+		return x.SuggestTags
+	}
+	return false
+}
+
+type WebDAVSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// enabled is the flag to serve the memos of the users as Markdown files at `/dav/`,
+	// authenticated by the username with the password or an access token of the user.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *WebDAVSetting) Reset() {
+	*x = WebDAVSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebDAVSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebDAVSetting) ProtoMessage() {}
+
+func (x *WebDAVSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebDAVSetting.ProtoReflect.Descriptor instead.
+func (*WebDAVSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WebDAVSetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SlackSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// signing_secret is the signing secret of the Slack app, used to verify the requests from Slack.
+	// Empty means the slash command and events are rejected.
+	SigningSecret string `protobuf:"bytes,1,opt,name=signing_secret,json=signingSecret,proto3" json:"signing_secret,omitempty"`
+	// bot_token is the bot user OAuth token of the Slack app, e.g. `xoxb-...`.
+	BotToken string `protobuf:"bytes,2,opt,name=bot_token,json=botToken,proto3" json:"bot_token,omitempty"`
+	// public_memo_channel is the id of the channel which the public memos are posted to.
+	// Empty means the public memos are not posted.
+	PublicMemoChannel string `protobuf:"bytes,3,opt,name=public_memo_channel,json=publicMemoChannel,proto3" json:"public_memo_channel,omitempty"`
+	// unfurl_links is the flag to unfurl the links to public memos in the channels.
+	UnfurlLinks bool `protobuf:"varint,4,opt,name=unfurl_links,json=unfurlLinks,proto3" json:"unfurl_links,omitempty"`
+}
+
+func (x *SlackSetting) Reset() {
+	*x = SlackSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlackSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlackSetting) ProtoMessage() {}
+
+func (x *SlackSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlackSetting.ProtoReflect.Descriptor instead.
+func (*SlackSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SlackSetting) GetSigningSecret() string {
+	if x != nil {
+		return x.SigningSecret
+	}
+	return ""
+}
+
+func (x *SlackSetting) GetBotToken() string {
+	if x != nil {
+		return x.BotToken
+	}
+	return ""
+}
+
+func (x *SlackSetting) GetPublicMemoChannel() string {
+	if x != nil {
+		return x.PublicMemoChannel
+	}
+	return ""
+}
+
+func (x *SlackSetting) GetUnfurlLinks() bool {
+	if x != nil {
+		return x.UnfurlLinks
+	}
+	return false
+}
+
+type DiscordSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// bot_token is the token of the Discord bot.
+	// Empty means the bot is disabled.
+	BotToken string `protobuf:"bytes,1,opt,name=bot_token,json=botToken,proto3" json:"bot_token,omitempty"`
+	// guilds are the settings of the guilds the bot serves, the messages of other guilds are ignored.
+	Guilds []*DiscordGuildSetting `protobuf:"bytes,2,rep,name=guilds,proto3" json:"guilds,omitempty"`
+}
+
+func (x *DiscordSetting) Reset() {
+	*x = DiscordSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscordSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscordSetting) ProtoMessage() {}
+
+func (x *DiscordSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscordSetting.ProtoReflect.Descriptor instead.
+func (*DiscordSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DiscordSetting) GetBotToken() string {
+	if x != nil {
+		return x.BotToken
+	}
+	return ""
+}
+
+func (x *DiscordSetting) GetGuilds() []*DiscordGuildSetting {
+	if x != nil {
+		return x.Guilds
+	}
+	return nil
+}
+
+type DiscordGuildSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// guild_id is the id of the guild.
+	GuildId string `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	// command_prefix is the prefix of the messages captured into memos, default to `!memo`.
+	CommandPrefix string `protobuf:"bytes,2,opt,name=command_prefix,json=commandPrefix,proto3" json:"command_prefix,omitempty"`
+	// capture_emoji is the emoji of the reactions capturing the messages into memos, default to `📝`.
+	CaptureEmoji string `protobuf:"bytes,3,opt,name=capture_emoji,json=captureEmoji,proto3" json:"capture_emoji,omitempty"`
+	// public_memo_channel_id is the id of the channel which the public memos are posted to.
+	// Empty means the public memos are not posted.
+	PublicMemoChannelId string `protobuf:"bytes,4,opt,name=public_memo_channel_id,json=publicMemoChannelId,proto3" json:"public_memo_channel_id,omitempty"`
+}
+
+func (x *DiscordGuildSetting) Reset() {
+	*x = DiscordGuildSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscordGuildSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscordGuildSetting) ProtoMessage() {}
+
+func (x *DiscordGuildSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscordGuildSetting.ProtoReflect.Descriptor instead.
+func (*DiscordGuildSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DiscordGuildSetting) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *DiscordGuildSetting) GetCommandPrefix() string {
+	if x != nil {
+		return x.CommandPrefix
+	}
+	return ""
+}
+
+func (x *DiscordGuildSetting) GetCaptureEmoji() string {
+	if x != nil {
+		return x.CaptureEmoji
+	}
+	return ""
+}
+
+func (x *DiscordGuildSetting) GetPublicMemoChannelId() string {
+	if x != nil {
+		return x.PublicMemoChannelId
+	}
+	return ""
+}
+
+type EmailIngestionSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// domain is the domain of the ingestion addresses, e.g. `memos.example.com` for `{token}@memos.example.com`.
+	// Empty means the emails are rejected. The emails are received by the SMTP server of the `--smtp-addr` flag.
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// sender_must_match is the flag to only accept the emails sent from the email address of the user.
+	SenderMustMatch bool `protobuf:"varint,2,opt,name=sender_must_match,json=senderMustMatch,proto3" json:"sender_must_match,omitempty"`
+}
+
+func (x *EmailIngestionSetting) Reset() {
+	*x = EmailIngestionSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmailIngestionSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailIngestionSetting) ProtoMessage() {}
+
+func (x *EmailIngestionSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailIngestionSetting.ProtoReflect.Descriptor instead.
+func (*EmailIngestionSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EmailIngestionSetting) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *EmailIngestionSetting) GetSenderMustMatch() bool {
+	if x != nil {
+		return x.SenderMustMatch
+	}
+	return false
+}
+
+type SMTPSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// host is the host of the SMTP server sending the notification emails.
+	// Empty means the notification emails are not sent.
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// port is the port of the SMTP server, e.g. 587 for STARTTLS or 465 for TLS.
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	// from_email is the address the emails are sent from.
+	FromEmail string `protobuf:"bytes,5,opt,name=from_email,json=fromEmail,proto3" json:"from_email,omitempty"`
+	// from_name is the display name the emails are sent from, default to `Memos`.
+	FromName string               `protobuf:"bytes,6,opt,name=from_name,json=fromName,proto3" json:"from_name,omitempty"`
+	Security SMTPSetting_Security `protobuf:"varint,7,opt,name=security,proto3,enum=memos.api.v2.SMTPSetting_Security" json:"security,omitempty"`
+}
+
+func (x *SMTPSetting) Reset() {
+	*x = SMTPSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SMTPSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMTPSetting) ProtoMessage() {}
+
+func (x *SMTPSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMTPSetting.ProtoReflect.Descriptor instead.
+func (*SMTPSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SMTPSetting) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SMTPSetting) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *SMTPSetting) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SMTPSetting) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *SMTPSetting) GetFromEmail() string {
+	if x != nil {
+		return x.FromEmail
+	}
+	return ""
+}
+
+func (x *SMTPSetting) GetFromName() string {
+	if x != nil {
+		return x.FromName
+	}
+	return ""
+}
+
+func (x *SMTPSetting) GetSecurity() SMTPSetting_Security {
+	if x != nil {
+		return x.Security
+	}
+	return SMTPSetting_SECURITY_UNSPECIFIED
+}
+
+type WorkspaceSchedulerSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// timezone is the IANA timezone the cron expressions are evaluated in, e.g. `Europe/Berlin`.
+	// Empty means UTC.
+	Timezone string `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// tasks are the schedules of the periodic tasks, the tasks not listed run on their default schedules.
+	Tasks []*ScheduledTask `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+}
+
+func (x *WorkspaceSchedulerSetting) Reset() {
+	*x = WorkspaceSchedulerSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceSchedulerSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceSchedulerSetting) ProtoMessage() {}
+
+func (x *WorkspaceSchedulerSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceSchedulerSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceSchedulerSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WorkspaceSchedulerSetting) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *WorkspaceSchedulerSetting) GetTasks() []*ScheduledTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type ScheduledTask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name is the name of the task, e.g. `resource_gc`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// cron is the cron expression of the task, e.g. `0 3 * * *`.
+	// Empty means the default schedule of the task.
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// disabled is the flag to not run the task on a schedule, it can still be run by the host.
+	// The tasks disabled by default, e.g. `resource_gc`, are enabled by a task without the flag.
+	Disabled bool `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+}
+
+func (x *ScheduledTask) Reset() {
+	*x = ScheduledTask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTask) ProtoMessage() {}
+
+func (x *ScheduledTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTask.ProtoReflect.Descriptor instead.
+func (*ScheduledTask) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScheduledTask) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type WorkspaceAnnouncementSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// content is the markdown content of the announcement, empty means there is no announcement.
+	Content  string                                `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Severity WorkspaceAnnouncementSetting_Severity `protobuf:"varint,2,opt,name=severity,proto3,enum=memos.api.v2.WorkspaceAnnouncementSetting_Severity" json:"severity,omitempty"`
+	// dismissible is the flag to allow the users to dismiss the announcement.
+	Dismissible bool `protobuf:"varint,3,opt,name=dismissible,proto3" json:"dismissible,omitempty"`
+	// expire_time is the time the announcement is no longer shown, unset means it never expires.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// update_time is the time the announcement is updated.
+	// The clients show the announcement again once it's updated, even if an earlier one was dismissed.
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+}
+
+func (x *WorkspaceAnnouncementSetting) Reset() {
+	*x = WorkspaceAnnouncementSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceAnnouncementSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceAnnouncementSetting) ProtoMessage() {}
+
+func (x *WorkspaceAnnouncementSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceAnnouncementSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceAnnouncementSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WorkspaceAnnouncementSetting) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *WorkspaceAnnouncementSetting) GetSeverity() WorkspaceAnnouncementSetting_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return WorkspaceAnnouncementSetting_SEVERITY_UNSPECIFIED
+}
+
+func (x *WorkspaceAnnouncementSetting) GetDismissible() bool {
+	if x != nil {
+		return x.Dismissible
+	}
+	return false
+}
+
+func (x *WorkspaceAnnouncementSetting) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *WorkspaceAnnouncementSetting) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type WorkspaceExploreSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// excluded_users are the users whose memos aren't listed in explore.
+	// Format: users/{id}
+	ExcludedUsers []string `protobuf:"bytes,1,rep,name=excluded_users,json=excludedUsers,proto3" json:"excluded_users,omitempty"`
+	// excluded_tags are the tags whose memos aren't listed in explore, with their child tags, e.g. `draft` for `#draft/idea`.
+	ExcludedTags []string `protobuf:"bytes,2,rep,name=excluded_tags,json=excludedTags,proto3" json:"excluded_tags,omitempty"`
+	// require_approval is the flag to only list the memos approved by the admins in explore.
+	RequireApproval bool `protobuf:"varint,3,opt,name=require_approval,json=requireApproval,proto3" json:"require_approval,omitempty"`
+}
+
+func (x *WorkspaceExploreSetting) Reset() {
+	*x = WorkspaceExploreSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceExploreSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceExploreSetting) ProtoMessage() {}
+
+func (x *WorkspaceExploreSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceExploreSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceExploreSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WorkspaceExploreSetting) GetExcludedUsers() []string {
+	if x != nil {
+		return x.ExcludedUsers
+	}
+	return nil
+}
+
+func (x *WorkspaceExploreSetting) GetExcludedTags() []string {
+	if x != nil {
+		return x.ExcludedTags
+	}
+	return nil
+}
+
+func (x *WorkspaceExploreSetting) GetRequireApproval() bool {
+	if x != nil {
+		return x.RequireApproval
+	}
+	return false
+}
+
+type WorkspaceBrandingSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// title is the title of the instance, in place of `Memos`.
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// description is the description of the instance.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// logo is the uploaded resource of the logo.
+	// Format: resources/{id}
+	Logo string `protobuf:"bytes,3,opt,name=logo,proto3" json:"logo,omitempty"`
+	// accent_color is the accent color of the web app, a hex color, e.g. `#0f766e`.
+	AccentColor string `protobuf:"bytes,4,opt,name=accent_color,json=accentColor,proto3" json:"accent_color,omitempty"`
+	// custom_footer is the markdown of the footer of the web app.
+	CustomFooter string `protobuf:"bytes,5,opt,name=custom_footer,json=customFooter,proto3" json:"custom_footer,omitempty"`
+	// custom_css is the style sheet applied to the web app.
+	CustomCss string `protobuf:"bytes,6,opt,name=custom_css,json=customCss,proto3" json:"custom_css,omitempty"`
+}
+
+func (x *WorkspaceBrandingSetting) Reset() {
+	*x = WorkspaceBrandingSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceBrandingSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceBrandingSetting) ProtoMessage() {}
+
+func (x *WorkspaceBrandingSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceBrandingSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceBrandingSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WorkspaceBrandingSetting) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *WorkspaceBrandingSetting) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkspaceBrandingSetting) GetLogo() string {
+	if x != nil {
+		return x.Logo
+	}
+	return ""
+}
+
+func (x *WorkspaceBrandingSetting) GetAccentColor() string {
+	if x != nil {
+		return x.AccentColor
+	}
+	return ""
+}
+
+func (x *WorkspaceBrandingSetting) GetCustomFooter() string {
+	if x != nil {
+		return x.CustomFooter
+	}
+	return ""
+}
+
+func (x *WorkspaceBrandingSetting) GetCustomCss() string {
+	if x != nil {
+		return x.CustomCss
+	}
+	return ""
+}
+
+type WorkspaceHookSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hooks are the hooks run in the order of the list.
+	Hooks []*WorkspaceHook `protobuf:"bytes,1,rep,name=hooks,proto3" json:"hooks,omitempty"`
+}
+
+func (x *WorkspaceHookSetting) Reset() {
+	*x = WorkspaceHookSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceHookSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceHookSetting) ProtoMessage() {}
+
+func (x *WorkspaceHookSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceHookSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceHookSetting) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WorkspaceHookSetting) GetHooks() []*WorkspaceHook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+type WorkspaceHook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name is the name of the hook shown in the rejections and the logs.
+	Name string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type WorkspaceHook_Type `protobuf:"varint,2,opt,name=type,proto3,enum=memos.api.v2.WorkspaceHook_Type" json:"type,omitempty"`
+	// events are the events the hook is run on.
+	Events []WorkspaceHook_Event `protobuf:"varint,3,rep,packed,name=events,proto3,enum=memos.api.v2.WorkspaceHook_Event" json:"events,omitempty"`
+	// url is the url of the HTTP hook.
+	Url string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// secret is the key of the HMAC-SHA256 signatures of the requests of the HTTP hook.
+	Secret string `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	// command is the filename of the executable of the EXEC hook in the hooks directory.
+	Command string `protobuf:"bytes,6,opt,name=command,proto3" json:"command,omitempty"`
+	// timeout_seconds is the timeout of a run of the hook, default to 5 seconds.
+	TimeoutSeconds int32 `protobuf:"varint,7,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// required is the flag to reject the action if the hook fails, otherwise the failed hook is skipped.
+	Required bool `protobuf:"varint,8,opt,name=required,proto3" json:"required,omitempty"`
+	// disabled is the flag to skip the hook.
+	Disabled bool `protobuf:"varint,9,opt,name=disabled,proto3" json:"disabled,omitempty"`
+}
+
+func (x *WorkspaceHook) Reset() {
+	*x = WorkspaceHook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_workspace_setting_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceHook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceHook) ProtoMessage() {}
+
+func (x *WorkspaceHook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_workspace_setting_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceHook.ProtoReflect.Descriptor instead.
+func (*WorkspaceHook) Descriptor() ([]byte, []int) {
+	return file_api_v2_workspace_setting_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WorkspaceHook) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkspaceHook) GetType() WorkspaceHook_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WorkspaceHook_TYPE_UNSPECIFIED
+}
+
+func (x *WorkspaceHook) GetEvents() []WorkspaceHook_Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WorkspaceHook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WorkspaceHook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WorkspaceHook) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *WorkspaceHook) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *WorkspaceHook) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *WorkspaceHook) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+var File_api_v2_workspace_setting_service_proto protoreflect.FileDescriptor
+
+var file_api_v2_workspace_setting_service_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x1a, 0x19, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76,
+	0x69, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x1a, 0x47, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x57, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x38, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32,
+	0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x56, 0x0a, 0x1a, 0x53, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x22, 0x57, 0x0a, 0x1b, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x38, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x22, 0xda, 0x05, 0x0a, 0x10,
+	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x5f,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x50, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x5c, 0x0a, 0x13, 0x69, 0x6e, 0x74, 0x65,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x56, 0x0a, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32,
+	0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x10, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x5f,
+	0x0a, 0x14, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6d,
+	0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x13, 0x61, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x50, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48,
+	0x00, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x53, 0x0a, 0x10, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6d, 0x65,
+	0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0f, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x47, 0x0a, 0x0c, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d,
+	0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x48, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x48, 0x00, 0x52, 0x0b, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42,
+	0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd9, 0x02, 0x0a, 0x17, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x64, 0x69, 0x73, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70,
+	0x12, 0x36, 0x0a, 0x17, 0x64, 0x69, 0x73, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x15, 0x64, 0x69, 0x73, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x79, 0x6c, 0x65,
+	0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f,
+	0x73, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x6e,
+	0x76, 0x69, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x12, 0x34,
+	0x0a, 0x16, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0xfe, 0x02, 0x0a, 0x17, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x45, 0x0a, 0x1f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f,
+	0x6d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1c, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x4d, 0x69, 0x62, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x13,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x4d, 0x61, 0x78, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x0e,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65,
+	0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x03, 0x6f, 0x63, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x32, 0x2e, 0x4f, 0x43, 0x52, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x03,
+	0x6f, 0x63, 0x72, 0x12, 0x50, 0x0a, 0x13, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x65,
+	0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x12, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x3b,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x6d,
+	0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x6f, 0x70,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x4f, 0x70,
+	0x65, 0x6e, 0x22, 0x32, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4c, 0x41, 0x4d, 0x41, 0x56, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04,
+	0x49, 0x43, 0x41, 0x50, 0x10, 0x02, 0x22, 0xb6, 0x01, 0x0a, 0x0a, 0x4f, 0x43, 0x52, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x37, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x32, 0x2e, 0x4f, 0x43, 0x52, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x06, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x16,
+	0x0a, 0x12, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x45, 0x53, 0x53, 0x45, 0x52,
+	0x41, 0x43, 0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x02, 0x22,
+	0xb1, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x32, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x5f, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x69, 0x6d, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x69, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x69, 0x7a, 0x65,
+	0x4d, 0x69, 0x62, 0x22, 0xaa, 0x03, 0x0a, 0x1b, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x32, 0x2e, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x05,
+	0x73, 0x6c, 0x61, 0x63, 0x6b, 0x12, 0x36, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x4c, 0x0a,
+	0x0f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x0e, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x04, 0x73,
+	0x6d, 0x74, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x4d, 0x54, 0x50, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x73, 0x6d, 0x74, 0x70, 0x12, 0x33, 0x0a, 0x06, 0x77, 0x65,
+	0x62, 0x64, 0x61, 0x76, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x6d,
+	0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x65, 0x62, 0x44, 0x41, 0x56,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x06, 0x77, 0x65, 0x62, 0x64, 0x61, 0x76, 0x12,
+	0x27, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65,
+	0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x41, 0x49, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x52, 0x02, 0x61, 0x69, 0x12, 0x46, 0x0a, 0x0d, 0x67, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x0c, 0x67, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x22, 0xe5, 0x01, 0x0a, 0x13, 0x47, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x61, 0x70, 0x74, 0x63, 0x68, 0x61, 0x5f, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x61,
+	0x70, 0x74, 0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x28, 0x0a,
+	0x10, 0x63, 0x61, 0x70, 0x74, 0x63, 0x68, 0x61, 0x5f, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x61, 0x70, 0x74, 0x63, 0x68, 0x61,
+	0x53, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x61, 0x70, 0x74, 0x63,
+	0x68, 0x61, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x61, 0x70, 0x74, 0x63, 0x68, 0x61, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x22, 0xab, 0x01, 0x0a, 0x09, 0x41, 0x49, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6d,
+	0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x6f,
+	0x64, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65,
+	0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x22, 0x29, 0x0a, 0x0d, 0x57, 0x65, 0x62, 0x44, 0x41, 0x56,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x22, 0xa5, 0x01, 0x0a, 0x0c, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e,
+	0x69, 0x6e, 0x67, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6f, 0x74,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6f,
+	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4d, 0x65, 0x6d, 0x6f, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x6e, 0x66, 0x75, 0x72, 0x6c,
+	0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x75, 0x6e,
+	0x66, 0x75, 0x72, 0x6c, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x22, 0x68, 0x0a, 0x0e, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x6f, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x62, 0x6f, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x39, 0x0a, 0x06, 0x67, 0x75, 0x69, 0x6c,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x47,
+	0x75, 0x69, 0x6c, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x06, 0x67, 0x75, 0x69,
+	0x6c, 0x64, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x47,
+	0x75, 0x69, 0x6c, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x67,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x45, 0x6d, 0x6f,
+	0x6a, 0x69, 0x12, 0x33, 0x0a, 0x16, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6d, 0x65, 0x6d,
+	0x6f, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4d, 0x65, 0x6d, 0x6f, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x15, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x5f, 0x6d, 0x75, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4d, 0x75, 0x73, 0x74, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x22, 0xb0, 0x02, 0x0a, 0x0b, 0x53, 0x4d, 0x54, 0x50, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x32, 0x2e, 0x53, 0x4d, 0x54, 0x50, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x22, 0x45, 0x0a, 0x08, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14,
+	0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01,
+	0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x52, 0x54, 0x54, 0x4c, 0x53, 0x10, 0x02, 0x12, 0x07,
+	0x0a, 0x03, 0x54, 0x4c, 0x53, 0x10, 0x03, 0x22, 0x6a, 0x0a, 0x19, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65,
+	0x12, 0x31, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x22, 0x53, 0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x54, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xf5, 0x02, 0x0a, 0x1c, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x4f, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x49, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49,
+	0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x03,
+	0x22, 0x90, 0x01, 0x0a, 0x17, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x55, 0x73,
+	0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f,
+	0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x61, 0x6c, 0x22, 0xcd, 0x01, 0x0a, 0x18, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x6f, 0x12, 0x21, 0x0a, 0x0c,
+	0x61, 0x63, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x46, 0x6f,
+	0x6f, 0x74, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x63,
+	0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x43, 0x73, 0x73, 0x22, 0x49, 0x0a, 0x14, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x48, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x05, 0x68,
+	0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x6d,
+	0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x48, 0x6f, 0x6f, 0x6b, 0x52, 0x05, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x22, 0xd3,
+	0x03, 0x0a, 0x0d, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x48, 0x6f, 0x6f, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x48, 0x6f, 0x6f, 0x6b, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x6d, 0x65, 0x6d,
+	0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x48, 0x6f, 0x6f, 0x6b, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x30, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50,
+	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x58, 0x45, 0x43, 0x10, 0x02, 0x22, 0x66, 0x0a, 0x05,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
+	0x4d, 0x45, 0x4d, 0x4f, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0f, 0x0a,
+	0x0b, 0x4d, 0x45, 0x4d, 0x4f, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x13,
+	0x0a, 0x0f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x50, 0x4c, 0x4f, 0x41,
+	0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x45, 0x4d, 0x4f, 0x5f, 0x52, 0x45, 0x4e, 0x44,
+	0x45, 0x52, 0x10, 0x04, 0x32, 0xef, 0x02, 0x0a, 0x17, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x9e, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x32, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0xda,
+	0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25, 0x12, 0x23, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2f,
+	0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x2a,
+	0x7d, 0x12, 0xb2, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x32, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46,
+	0xda, 0x41, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x36,
+	0x3a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2b, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x32, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2f, 0x7b, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x2f, 0x2a, 0x7d, 0x42, 0xb4, 0x01, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x6d,
+	0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x42, 0x1c, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x30, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x73, 0x65, 0x6d, 0x65, 0x6d, 0x6f, 0x73,
+	0x2f, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x3b, 0x61, 0x70, 0x69, 0x76, 0x32, 0xa2, 0x02, 0x03,
+	0x4d, 0x41, 0x58, 0xaa, 0x02, 0x0c, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x41, 0x70, 0x69, 0x2e,
+	0x56, 0x32, 0xca, 0x02, 0x0c, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x5c, 0x41, 0x70, 0x69, 0x5c, 0x56,
+	0x32, 0xe2, 0x02, 0x18, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x5c, 0x41, 0x70, 0x69, 0x5c, 0x56, 0x32,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x4d,
+	0x65, 0x6d, 0x6f, 0x73, 0x3a, 0x3a, 0x41, 0x70, 0x69, 0x3a, 0x3a, 0x56, 0x32, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v2_workspace_setting_service_proto_rawDescOnce sync.Once
+	file_api_v2_workspace_setting_service_proto_rawDescData = file_api_v2_workspace_setting_service_proto_rawDesc
+)
+
+func file_api_v2_workspace_setting_service_proto_rawDescGZIP() []byte {
+	file_api_v2_workspace_setting_service_proto_rawDescOnce.Do(func() {
+		file_api_v2_workspace_setting_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v2_workspace_setting_service_proto_rawDescData)
+	})
+	return file_api_v2_workspace_setting_service_proto_rawDescData
+}
+
+var file_api_v2_workspace_setting_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_v2_workspace_setting_service_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_api_v2_workspace_setting_service_proto_goTypes = []interface{}{
+	(UploadScannerSetting_Type)(0),             // 0: memos.api.v2.UploadScannerSetting.Type
+	(OCRSetting_Engine)(0),                     // 1: memos.api.v2.OCRSetting.Engine
+	(SMTPSetting_Security)(0),                  // 2: memos.api.v2.SMTPSetting.Security
+	(WorkspaceAnnouncementSetting_Severity)(0), // 3: memos.api.v2.WorkspaceAnnouncementSetting.Severity
+	(WorkspaceHook_Type)(0),                    // 4: memos.api.v2.WorkspaceHook.Type
+	(WorkspaceHook_Event)(0),                   // 5: memos.api.v2.WorkspaceHook.Event
+	(*GetWorkspaceSettingRequest)(nil),         // 6: memos.api.v2.GetWorkspaceSettingRequest
+	(*GetWorkspaceSettingResponse)(nil),        // 7: memos.api.v2.GetWorkspaceSettingResponse
+	(*SetWorkspaceSettingRequest)(nil),         // 8: memos.api.v2.SetWorkspaceSettingRequest
+	(*SetWorkspaceSettingResponse)(nil),        // 9: memos.api.v2.SetWorkspaceSettingResponse
+	(*WorkspaceSetting)(nil),                   // 10: memos.api.v2.WorkspaceSetting
+	(*WorkspaceGeneralSetting)(nil),            // 11: memos.api.v2.WorkspaceGeneralSetting
+	(*WorkspaceStorageSetting)(nil),            // 12: memos.api.v2.WorkspaceStorageSetting
+	(*UploadScannerSetting)(nil),               // 13: memos.api.v2.UploadScannerSetting
+	(*OCRSetting)(nil),                         // 14: memos.api.v2.OCRSetting
+	(*UploadRestriction)(nil),                  // 15: memos.api.v2.UploadRestriction
+	(*WorkspaceIntegrationSetting)(nil),        // 16: memos.api.v2.WorkspaceIntegrationSetting
+	(*GuestCommentSetting)(nil),                // 17: memos.api.v2.GuestCommentSetting
+	(*AISetting)(nil),                          // 18: memos.api.v2.AISetting
+	(*WebDAVSetting)(nil),                      // 19: memos.api.v2.WebDAVSetting
+	(*SlackSetting)(nil),                       // 20: memos.api.v2.SlackSetting
+	(*DiscordSetting)(nil),                     // 21: memos.api.v2.DiscordSetting
+	(*DiscordGuildSetting)(nil),                // 22: memos.api.v2.DiscordGuildSetting
+	(*EmailIngestionSetting)(nil),              // 23: memos.api.v2.EmailIngestionSetting
+	(*SMTPSetting)(nil),                        // 24: memos.api.v2.SMTPSetting
+	(*WorkspaceSchedulerSetting)(nil),          // 25: memos.api.v2.WorkspaceSchedulerSetting
+	(*ScheduledTask)(nil),                      // 26: memos.api.v2.ScheduledTask
+	(*WorkspaceAnnouncementSetting)(nil),       // 27: memos.api.v2.WorkspaceAnnouncementSetting
+	(*WorkspaceExploreSetting)(nil),            // 28: memos.api.v2.WorkspaceExploreSetting
+	(*WorkspaceBrandingSetting)(nil),           // 29: memos.api.v2.WorkspaceBrandingSetting
+	(*WorkspaceHookSetting)(nil),               // 30: memos.api.v2.WorkspaceHookSetting
+	(*WorkspaceHook)(nil),                      // 31: memos.api.v2.WorkspaceHook
+	(User_Role)(0),                             // 32: memos.api.v2.User.Role
+	(*timestamppb.Timestamp)(nil),              // 33: google.protobuf.Timestamp
+}
+var file_api_v2_workspace_setting_service_proto_depIdxs = []int32{
+	10, // 0: memos.api.v2.GetWorkspaceSettingResponse.setting:type_name -> memos.api.v2.WorkspaceSetting
+	10, // 1: memos.api.v2.SetWorkspaceSettingRequest.setting:type_name -> memos.api.v2.WorkspaceSetting
+	10, // 2: memos.api.v2.SetWorkspaceSettingResponse.setting:type_name -> memos.api.v2.WorkspaceSetting
+	11, // 3: memos.api.v2.WorkspaceSetting.general_setting:type_name -> memos.api.v2.WorkspaceGeneralSetting
+	12, // 4: memos.api.v2.WorkspaceSetting.storage_setting:type_name -> memos.api.v2.WorkspaceStorageSetting
+	16, // 5: memos.api.v2.WorkspaceSetting.integration_setting:type_name -> memos.api.v2.WorkspaceIntegrationSetting
+	25, // 6: memos.api.v2.WorkspaceSetting.scheduler_setting:type_name -> memos.api.v2.WorkspaceSchedulerSetting
+	27, // 7: memos.api.v2.WorkspaceSetting.announcement_setting:type_name -> memos.api.v2.WorkspaceAnnouncementSetting
+	28, // 8: memos.api.v2.WorkspaceSetting.explore_setting:type_name -> memos.api.v2.WorkspaceExploreSetting
+	29, // 9: memos.api.v2.WorkspaceSetting.branding_setting:type_name -> memos.api.v2.WorkspaceBrandingSetting
+	30, // 10: memos.api.v2.WorkspaceSetting.hook_setting:type_name -> memos.api.v2.WorkspaceHookSetting
+	13, // 11: memos.api.v2.WorkspaceStorageSetting.upload_scanner:type_name -> memos.api.v2.UploadScannerSetting
+	14, // 12: memos.api.v2.WorkspaceStorageSetting.ocr:type_name -> memos.api.v2.OCRSetting
+	15, // 13: memos.api.v2.WorkspaceStorageSetting.upload_restrictions:type_name -> memos.api.v2.UploadRestriction
+	0,  // 14: memos.api.v2.UploadScannerSetting.type:type_name -> memos.api.v2.UploadScannerSetting.Type
+	1,  // 15: memos.api.v2.OCRSetting.engine:type_name -> memos.api.v2.OCRSetting.Engine
+	32, // 16: memos.api.v2.UploadRestriction.role:type_name -> memos.api.v2.User.Role
+	20, // 17: memos.api.v2.WorkspaceIntegrationSetting.slack:type_name -> memos.api.v2.SlackSetting
+	21, // 18: memos.api.v2.WorkspaceIntegrationSetting.discord:type_name -> memos.api.v2.DiscordSetting
+	23, // 19: memos.api.v2.WorkspaceIntegrationSetting.email_ingestion:type_name -> memos.api.v2.EmailIngestionSetting
+	24, // 20: memos.api.v2.WorkspaceIntegrationSetting.smtp:type_name -> memos.api.v2.SMTPSetting
+	19, // 21: memos.api.v2.WorkspaceIntegrationSetting.webdav:type_name -> memos.api.v2.WebDAVSetting
+	18, // 22: memos.api.v2.WorkspaceIntegrationSetting.ai:type_name -> memos.api.v2.AISetting
+	17, // 23: memos.api.v2.WorkspaceIntegrationSetting.guest_comment:type_name -> memos.api.v2.GuestCommentSetting
+	22, // 24: memos.api.v2.DiscordSetting.guilds:type_name -> memos.api.v2.DiscordGuildSetting
+	2,  // 25: memos.api.v2.SMTPSetting.security:type_name -> memos.api.v2.SMTPSetting.Security
+	26, // 26: memos.api.v2.WorkspaceSchedulerSetting.tasks:type_name -> memos.api.v2.ScheduledTask
+	3,  // 27: memos.api.v2.WorkspaceAnnouncementSetting.severity:type_name -> memos.api.v2.WorkspaceAnnouncementSetting.Severity
+	33, // 28: memos.api.v2.WorkspaceAnnouncementSetting.expire_time:type_name -> google.protobuf.Timestamp
+	33, // 29: memos.api.v2.WorkspaceAnnouncementSetting.update_time:type_name -> google.protobuf.Timestamp
+	31, // 30: memos.api.v2.WorkspaceHookSetting.hooks:type_name -> memos.api.v2.WorkspaceHook
+	4,  // 31: memos.api.v2.WorkspaceHook.type:type_name -> memos.api.v2.WorkspaceHook.Type
+	5,  // 32: memos.api.v2.WorkspaceHook.events:type_name -> memos.api.v2.WorkspaceHook.Event
+	6,  // 33: memos.api.v2.WorkspaceSettingService.GetWorkspaceSetting:input_type -> memos.api.v2.GetWorkspaceSettingRequest
+	8,  // 34: memos.api.v2.WorkspaceSettingService.SetWorkspaceSetting:input_type -> memos.api.v2.SetWorkspaceSettingRequest
+	7,  // 35: memos.api.v2.WorkspaceSettingService.GetWorkspaceSetting:output_type -> memos.api.v2.GetWorkspaceSettingResponse
+	9,  // 36: memos.api.v2.WorkspaceSettingService.SetWorkspaceSetting:output_type -> memos.api.v2.SetWorkspaceSettingResponse
+	35, // [35:37] is the sub-list for method output_type
+	33, // [33:35] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
+}
+
+func init() { file_api_v2_workspace_setting_service_proto_init() }
+func file_api_v2_workspace_setting_service_proto_init() {
+	if File_api_v2_workspace_setting_service_proto != nil {
+		return
+	}
+	file_api_v2_user_service_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_api_v2_workspace_setting_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWorkspaceSettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWorkspaceSettingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetWorkspaceSettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
 		file_api_v2_workspace_setting_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetWorkspaceSettingResponse); i {
@@ -582,22 +2925,270 @@ func file_api_v2_workspace_setting_service_proto_init() {
 				return nil
 			}
 		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceStorageSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadScannerSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCRSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadRestriction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceIntegrationSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestCommentSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AISetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebDAVSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlackSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiscordSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiscordGuildSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmailIngestionSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SMTPSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceSchedulerSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceAnnouncementSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceExploreSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceBrandingSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceHookSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_workspace_setting_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceHook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_api_v2_workspace_setting_service_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*WorkspaceSetting_GeneralSetting)(nil),
+		(*WorkspaceSetting_StorageSetting)(nil),
+		(*WorkspaceSetting_IntegrationSetting)(nil),
+		(*WorkspaceSetting_SchedulerSetting)(nil),
+		(*WorkspaceSetting_AnnouncementSetting)(nil),
+		(*WorkspaceSetting_ExploreSetting)(nil),
+		(*WorkspaceSetting_BrandingSetting)(nil),
+		(*WorkspaceSetting_HookSetting)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v2_workspace_setting_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      6,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v2_workspace_setting_service_proto_goTypes,
 		DependencyIndexes: file_api_v2_workspace_setting_service_proto_depIdxs,
+		EnumInfos:         file_api_v2_workspace_setting_service_proto_enumTypes,
 		MessageInfos:      file_api_v2_workspace_setting_service_proto_msgTypes,
 	}.Build()
 	File_api_v2_workspace_setting_service_proto = out.File
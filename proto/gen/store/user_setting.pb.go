@@ -34,25 +34,89 @@ const (
 	UserSettingKey_USER_SETTING_MEMO_VISIBILITY UserSettingKey = 4
 	// The telegram user id of the user.
 	UserSettingKey_USER_SETTING_TELEGRAM_USER_ID UserSettingKey = 5
+	// The last time the user was active.
+	UserSettingKey_USER_SETTING_LAST_ACTIVE_TS UserSettingKey = 6
+	// The quota of the user set by admins.
+	UserSettingKey_USER_SETTING_QUOTA UserSettingKey = 7
+	// The slack user id of the user.
+	UserSettingKey_USER_SETTING_SLACK_USER_ID UserSettingKey = 8
+	// The discord user id of the user.
+	UserSettingKey_USER_SETTING_DISCORD_USER_ID UserSettingKey = 9
+	// The token of the email ingestion address of the user.
+	UserSettingKey_USER_SETTING_EMAIL_INGESTION_TOKEN UserSettingKey = 10
+	// The notification preferences of the user.
+	UserSettingKey_USER_SETTING_NOTIFICATION UserSettingKey = 11
+	// The token of the private feeds of the user.
+	UserSettingKey_USER_SETTING_FEED_TOKEN UserSettingKey = 12
+	// The Web Push subscriptions of the browsers of the user.
+	UserSettingKey_USER_SETTING_WEB_PUSH_SUBSCRIPTIONS UserSettingKey = 13
+	// The Mastodon account the public memos are cross-posted to.
+	UserSettingKey_USER_SETTING_MASTODON UserSettingKey = 14
+	// The Bluesky account the public memos are cross-posted to.
+	UserSettingKey_USER_SETTING_BLUESKY UserSettingKey = 15
+	// The GitHub account the code snippet memos are mirrored to as gists.
+	UserSettingKey_USER_SETTING_GITHUB_GIST UserSettingKey = 16
+	// The account of Readwise or Omnivore the highlights are imported from.
+	UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC UserSettingKey = 17
+	// The public profile of the user.
+	UserSettingKey_USER_SETTING_PROFILE UserSettingKey = 18
+	// The IANA timezone of the user, the days of the user start at its midnight.
+	UserSettingKey_USER_SETTING_TIMEZONE UserSettingKey = 19
+	// The time the account of the user is deleted at, the user requested it and can cancel it until then.
+	UserSettingKey_USER_SETTING_DELETION_TS UserSettingKey = 20
+	// The flag to keep the public memos of the user out of explore.
+	UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT UserSettingKey = 21
 )
 
 // Enum value maps for UserSettingKey.
 var (
 	UserSettingKey_name = map[int32]string{
-		0: "USER_SETTING_KEY_UNSPECIFIED",
-		1: "USER_SETTING_ACCESS_TOKENS",
-		2: "USER_SETTING_LOCALE",
-		3: "USER_SETTING_APPEARANCE",
-		4: "USER_SETTING_MEMO_VISIBILITY",
-		5: "USER_SETTING_TELEGRAM_USER_ID",
+		0:  "USER_SETTING_KEY_UNSPECIFIED",
+		1:  "USER_SETTING_ACCESS_TOKENS",
+		2:  "USER_SETTING_LOCALE",
+		3:  "USER_SETTING_APPEARANCE",
+		4:  "USER_SETTING_MEMO_VISIBILITY",
+		5:  "USER_SETTING_TELEGRAM_USER_ID",
+		6:  "USER_SETTING_LAST_ACTIVE_TS",
+		7:  "USER_SETTING_QUOTA",
+		8:  "USER_SETTING_SLACK_USER_ID",
+		9:  "USER_SETTING_DISCORD_USER_ID",
+		10: "USER_SETTING_EMAIL_INGESTION_TOKEN",
+		11: "USER_SETTING_NOTIFICATION",
+		12: "USER_SETTING_FEED_TOKEN",
+		13: "USER_SETTING_WEB_PUSH_SUBSCRIPTIONS",
+		14: "USER_SETTING_MASTODON",
+		15: "USER_SETTING_BLUESKY",
+		16: "USER_SETTING_GITHUB_GIST",
+		17: "USER_SETTING_HIGHLIGHT_SYNC",
+		18: "USER_SETTING_PROFILE",
+		19: "USER_SETTING_TIMEZONE",
+		20: "USER_SETTING_DELETION_TS",
+		21: "USER_SETTING_EXPLORE_OPT_OUT",
 	}
 	UserSettingKey_value = map[string]int32{
-		"USER_SETTING_KEY_UNSPECIFIED":  0,
-		"USER_SETTING_ACCESS_TOKENS":    1,
-		"USER_SETTING_LOCALE":           2,
-		"USER_SETTING_APPEARANCE":       3,
-		"USER_SETTING_MEMO_VISIBILITY":  4,
-		"USER_SETTING_TELEGRAM_USER_ID": 5,
+		"USER_SETTING_KEY_UNSPECIFIED":        0,
+		"USER_SETTING_ACCESS_TOKENS":          1,
+		"USER_SETTING_LOCALE":                 2,
+		"USER_SETTING_APPEARANCE":             3,
+		"USER_SETTING_MEMO_VISIBILITY":        4,
+		"USER_SETTING_TELEGRAM_USER_ID":       5,
+		"USER_SETTING_LAST_ACTIVE_TS":         6,
+		"USER_SETTING_QUOTA":                  7,
+		"USER_SETTING_SLACK_USER_ID":          8,
+		"USER_SETTING_DISCORD_USER_ID":        9,
+		"USER_SETTING_EMAIL_INGESTION_TOKEN":  10,
+		"USER_SETTING_NOTIFICATION":           11,
+		"USER_SETTING_FEED_TOKEN":             12,
+		"USER_SETTING_WEB_PUSH_SUBSCRIPTIONS": 13,
+		"USER_SETTING_MASTODON":               14,
+		"USER_SETTING_BLUESKY":                15,
+		"USER_SETTING_GITHUB_GIST":            16,
+		"USER_SETTING_HIGHLIGHT_SYNC":         17,
+		"USER_SETTING_PROFILE":                18,
+		"USER_SETTING_TIMEZONE":               19,
+		"USER_SETTING_DELETION_TS":            20,
+		"USER_SETTING_EXPLORE_OPT_OUT":        21,
 	}
 )
 
@@ -83,6 +147,203 @@ func (UserSettingKey) EnumDescriptor() ([]byte, []int) {
 	return file_store_user_setting_proto_rawDescGZIP(), []int{0}
 }
 
+type HighlightSyncUserSetting_Provider int32
+
+const (
+	HighlightSyncUserSetting_PROVIDER_UNSPECIFIED HighlightSyncUserSetting_Provider = 0
+	HighlightSyncUserSetting_READWISE             HighlightSyncUserSetting_Provider = 1
+	HighlightSyncUserSetting_OMNIVORE             HighlightSyncUserSetting_Provider = 2
+)
+
+// Enum value maps for HighlightSyncUserSetting_Provider.
+var (
+	HighlightSyncUserSetting_Provider_name = map[int32]string{
+		0: "PROVIDER_UNSPECIFIED",
+		1: "READWISE",
+		2: "OMNIVORE",
+	}
+	HighlightSyncUserSetting_Provider_value = map[string]int32{
+		"PROVIDER_UNSPECIFIED": 0,
+		"READWISE":             1,
+		"OMNIVORE":             2,
+	}
+)
+
+func (x HighlightSyncUserSetting_Provider) Enum() *HighlightSyncUserSetting_Provider {
+	p := new(HighlightSyncUserSetting_Provider)
+	*p = x
+	return p
+}
+
+func (x HighlightSyncUserSetting_Provider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HighlightSyncUserSetting_Provider) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_user_setting_proto_enumTypes[1].Descriptor()
+}
+
+func (HighlightSyncUserSetting_Provider) Type() protoreflect.EnumType {
+	return &file_store_user_setting_proto_enumTypes[1]
+}
+
+func (x HighlightSyncUserSetting_Provider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HighlightSyncUserSetting_Provider.Descriptor instead.
+func (HighlightSyncUserSetting_Provider) EnumDescriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type HighlightSyncUserSetting_Mode int32
+
+const (
+	// A memo of an article, the new highlights of the article are appended to it.
+	HighlightSyncUserSetting_MEMO_PER_ARTICLE HighlightSyncUserSetting_Mode = 0
+	// A memo of a highlight.
+	HighlightSyncUserSetting_MEMO_PER_HIGHLIGHT HighlightSyncUserSetting_Mode = 1
+)
+
+// Enum value maps for HighlightSyncUserSetting_Mode.
+var (
+	HighlightSyncUserSetting_Mode_name = map[int32]string{
+		0: "MEMO_PER_ARTICLE",
+		1: "MEMO_PER_HIGHLIGHT",
+	}
+	HighlightSyncUserSetting_Mode_value = map[string]int32{
+		"MEMO_PER_ARTICLE":   0,
+		"MEMO_PER_HIGHLIGHT": 1,
+	}
+)
+
+func (x HighlightSyncUserSetting_Mode) Enum() *HighlightSyncUserSetting_Mode {
+	p := new(HighlightSyncUserSetting_Mode)
+	*p = x
+	return p
+}
+
+func (x HighlightSyncUserSetting_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HighlightSyncUserSetting_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_user_setting_proto_enumTypes[2].Descriptor()
+}
+
+func (HighlightSyncUserSetting_Mode) Type() protoreflect.EnumType {
+	return &file_store_user_setting_proto_enumTypes[2]
+}
+
+func (x HighlightSyncUserSetting_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HighlightSyncUserSetting_Mode.Descriptor instead.
+func (HighlightSyncUserSetting_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{6, 1}
+}
+
+type NotificationUserSetting_Digest int32
+
+const (
+	// No digest is sent.
+	NotificationUserSetting_DIGEST_UNSPECIFIED NotificationUserSetting_Digest = 0
+	NotificationUserSetting_DAILY              NotificationUserSetting_Digest = 1
+	NotificationUserSetting_WEEKLY             NotificationUserSetting_Digest = 2
+)
+
+// Enum value maps for NotificationUserSetting_Digest.
+var (
+	NotificationUserSetting_Digest_name = map[int32]string{
+		0: "DIGEST_UNSPECIFIED",
+		1: "DAILY",
+		2: "WEEKLY",
+	}
+	NotificationUserSetting_Digest_value = map[string]int32{
+		"DIGEST_UNSPECIFIED": 0,
+		"DAILY":              1,
+		"WEEKLY":             2,
+	}
+)
+
+func (x NotificationUserSetting_Digest) Enum() *NotificationUserSetting_Digest {
+	p := new(NotificationUserSetting_Digest)
+	*p = x
+	return p
+}
+
+func (x NotificationUserSetting_Digest) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationUserSetting_Digest) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_user_setting_proto_enumTypes[3].Descriptor()
+}
+
+func (NotificationUserSetting_Digest) Type() protoreflect.EnumType {
+	return &file_store_user_setting_proto_enumTypes[3]
+}
+
+func (x NotificationUserSetting_Digest) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationUserSetting_Digest.Descriptor instead.
+func (NotificationUserSetting_Digest) EnumDescriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type PushNotificationSetting_Provider int32
+
+const (
+	// No notification is pushed.
+	PushNotificationSetting_PROVIDER_UNSPECIFIED PushNotificationSetting_Provider = 0
+	PushNotificationSetting_NTFY                 PushNotificationSetting_Provider = 1
+	PushNotificationSetting_GOTIFY               PushNotificationSetting_Provider = 2
+)
+
+// Enum value maps for PushNotificationSetting_Provider.
+var (
+	PushNotificationSetting_Provider_name = map[int32]string{
+		0: "PROVIDER_UNSPECIFIED",
+		1: "NTFY",
+		2: "GOTIFY",
+	}
+	PushNotificationSetting_Provider_value = map[string]int32{
+		"PROVIDER_UNSPECIFIED": 0,
+		"NTFY":                 1,
+		"GOTIFY":               2,
+	}
+)
+
+func (x PushNotificationSetting_Provider) Enum() *PushNotificationSetting_Provider {
+	p := new(PushNotificationSetting_Provider)
+	*p = x
+	return p
+}
+
+func (x PushNotificationSetting_Provider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushNotificationSetting_Provider) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_user_setting_proto_enumTypes[4].Descriptor()
+}
+
+func (PushNotificationSetting_Provider) Type() protoreflect.EnumType {
+	return &file_store_user_setting_proto_enumTypes[4]
+}
+
+func (x PushNotificationSetting_Provider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushNotificationSetting_Provider.Descriptor instead.
+func (PushNotificationSetting_Provider) EnumDescriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{10, 0}
+}
+
 type UserSetting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -97,6 +358,22 @@ type UserSetting struct {
 	//	*UserSetting_Appearance
 	//	*UserSetting_MemoVisibility
 	//	*UserSetting_TelegramUserId
+	//	*UserSetting_LastActiveTs
+	//	*UserSetting_Quota
+	//	*UserSetting_SlackUserId
+	//	*UserSetting_DiscordUserId
+	//	*UserSetting_EmailIngestionToken
+	//	*UserSetting_Notification
+	//	*UserSetting_FeedToken
+	//	*UserSetting_WebPushSubscriptions
+	//	*UserSetting_Mastodon
+	//	*UserSetting_Bluesky
+	//	*UserSetting_GithubGist
+	//	*UserSetting_HighlightSync
+	//	*UserSetting_Profile
+	//	*UserSetting_Timezone
+	//	*UserSetting_DeletionTs
+	//	*UserSetting_ExploreOptOut
 	Value isUserSetting_Value `protobuf_oneof:"value"`
 }
 
@@ -188,6 +465,118 @@ func (x *UserSetting) GetTelegramUserId() string {
 	return ""
 }
 
+func (x *UserSetting) GetLastActiveTs() int64 {
+	if x, ok := x.GetValue().(*UserSetting_LastActiveTs); ok {
+		return x.LastActiveTs
+	}
+	return 0
+}
+
+func (x *UserSetting) GetQuota() *QuotaUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_Quota); ok {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *UserSetting) GetSlackUserId() string {
+	if x, ok := x.GetValue().(*UserSetting_SlackUserId); ok {
+		return x.SlackUserId
+	}
+	return ""
+}
+
+func (x *UserSetting) GetDiscordUserId() string {
+	if x, ok := x.GetValue().(*UserSetting_DiscordUserId); ok {
+		return x.DiscordUserId
+	}
+	return ""
+}
+
+func (x *UserSetting) GetEmailIngestionToken() string {
+	if x, ok := x.GetValue().(*UserSetting_EmailIngestionToken); ok {
+		return x.EmailIngestionToken
+	}
+	return ""
+}
+
+func (x *UserSetting) GetNotification() *NotificationUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_Notification); ok {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *UserSetting) GetFeedToken() string {
+	if x, ok := x.GetValue().(*UserSetting_FeedToken); ok {
+		return x.FeedToken
+	}
+	return ""
+}
+
+func (x *UserSetting) GetWebPushSubscriptions() *WebPushSubscriptionsUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_WebPushSubscriptions); ok {
+		return x.WebPushSubscriptions
+	}
+	return nil
+}
+
+func (x *UserSetting) GetMastodon() *MastodonUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_Mastodon); ok {
+		return x.Mastodon
+	}
+	return nil
+}
+
+func (x *UserSetting) GetBluesky() *BlueskyUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_Bluesky); ok {
+		return x.Bluesky
+	}
+	return nil
+}
+
+func (x *UserSetting) GetGithubGist() *GithubGistUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_GithubGist); ok {
+		return x.GithubGist
+	}
+	return nil
+}
+
+func (x *UserSetting) GetHighlightSync() *HighlightSyncUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_HighlightSync); ok {
+		return x.HighlightSync
+	}
+	return nil
+}
+
+func (x *UserSetting) GetProfile() *ProfileUserSetting {
+	if x, ok := x.GetValue().(*UserSetting_Profile); ok {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *UserSetting) GetTimezone() string {
+	if x, ok := x.GetValue().(*UserSetting_Timezone); ok {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UserSetting) GetDeletionTs() int64 {
+	if x, ok := x.GetValue().(*UserSetting_DeletionTs); ok {
+		return x.DeletionTs
+	}
+	return 0
+}
+
+func (x *UserSetting) GetExploreOptOut() bool {
+	if x, ok := x.GetValue().(*UserSetting_ExploreOptOut); ok {
+		return x.ExploreOptOut
+	}
+	return false
+}
+
 type isUserSetting_Value interface {
 	isUserSetting_Value()
 }
@@ -212,6 +601,70 @@ type UserSetting_TelegramUserId struct {
 	TelegramUserId string `protobuf:"bytes,7,opt,name=telegram_user_id,json=telegramUserId,proto3,oneof"`
 }
 
+type UserSetting_LastActiveTs struct {
+	LastActiveTs int64 `protobuf:"varint,8,opt,name=last_active_ts,json=lastActiveTs,proto3,oneof"`
+}
+
+type UserSetting_Quota struct {
+	Quota *QuotaUserSetting `protobuf:"bytes,9,opt,name=quota,proto3,oneof"`
+}
+
+type UserSetting_SlackUserId struct {
+	SlackUserId string `protobuf:"bytes,10,opt,name=slack_user_id,json=slackUserId,proto3,oneof"`
+}
+
+type UserSetting_DiscordUserId struct {
+	DiscordUserId string `protobuf:"bytes,11,opt,name=discord_user_id,json=discordUserId,proto3,oneof"`
+}
+
+type UserSetting_EmailIngestionToken struct {
+	EmailIngestionToken string `protobuf:"bytes,12,opt,name=email_ingestion_token,json=emailIngestionToken,proto3,oneof"`
+}
+
+type UserSetting_Notification struct {
+	Notification *NotificationUserSetting `protobuf:"bytes,13,opt,name=notification,proto3,oneof"`
+}
+
+type UserSetting_FeedToken struct {
+	FeedToken string `protobuf:"bytes,14,opt,name=feed_token,json=feedToken,proto3,oneof"`
+}
+
+type UserSetting_WebPushSubscriptions struct {
+	WebPushSubscriptions *WebPushSubscriptionsUserSetting `protobuf:"bytes,15,opt,name=web_push_subscriptions,json=webPushSubscriptions,proto3,oneof"`
+}
+
+type UserSetting_Mastodon struct {
+	Mastodon *MastodonUserSetting `protobuf:"bytes,16,opt,name=mastodon,proto3,oneof"`
+}
+
+type UserSetting_Bluesky struct {
+	Bluesky *BlueskyUserSetting `protobuf:"bytes,17,opt,name=bluesky,proto3,oneof"`
+}
+
+type UserSetting_GithubGist struct {
+	GithubGist *GithubGistUserSetting `protobuf:"bytes,18,opt,name=github_gist,json=githubGist,proto3,oneof"`
+}
+
+type UserSetting_HighlightSync struct {
+	HighlightSync *HighlightSyncUserSetting `protobuf:"bytes,19,opt,name=highlight_sync,json=highlightSync,proto3,oneof"`
+}
+
+type UserSetting_Profile struct {
+	Profile *ProfileUserSetting `protobuf:"bytes,20,opt,name=profile,proto3,oneof"`
+}
+
+type UserSetting_Timezone struct {
+	Timezone string `protobuf:"bytes,21,opt,name=timezone,proto3,oneof"`
+}
+
+type UserSetting_DeletionTs struct {
+	DeletionTs int64 `protobuf:"varint,22,opt,name=deletion_ts,json=deletionTs,proto3,oneof"`
+}
+
+type UserSetting_ExploreOptOut struct {
+	ExploreOptOut bool `protobuf:"varint,23,opt,name=explore_opt_out,json=exploreOptOut,proto3,oneof"`
+}
+
 func (*UserSetting_AccessTokens) isUserSetting_Value() {}
 
 func (*UserSetting_Locale) isUserSetting_Value() {}
@@ -222,6 +675,38 @@ func (*UserSetting_MemoVisibility) isUserSetting_Value() {}
 
 func (*UserSetting_TelegramUserId) isUserSetting_Value() {}
 
+func (*UserSetting_LastActiveTs) isUserSetting_Value() {}
+
+func (*UserSetting_Quota) isUserSetting_Value() {}
+
+func (*UserSetting_SlackUserId) isUserSetting_Value() {}
+
+func (*UserSetting_DiscordUserId) isUserSetting_Value() {}
+
+func (*UserSetting_EmailIngestionToken) isUserSetting_Value() {}
+
+func (*UserSetting_Notification) isUserSetting_Value() {}
+
+func (*UserSetting_FeedToken) isUserSetting_Value() {}
+
+func (*UserSetting_WebPushSubscriptions) isUserSetting_Value() {}
+
+func (*UserSetting_Mastodon) isUserSetting_Value() {}
+
+func (*UserSetting_Bluesky) isUserSetting_Value() {}
+
+func (*UserSetting_GithubGist) isUserSetting_Value() {}
+
+func (*UserSetting_HighlightSync) isUserSetting_Value() {}
+
+func (*UserSetting_Profile) isUserSetting_Value() {}
+
+func (*UserSetting_Timezone) isUserSetting_Value() {}
+
+func (*UserSetting_DeletionTs) isUserSetting_Value() {}
+
+func (*UserSetting_ExploreOptOut) isUserSetting_Value() {}
+
 type AccessTokensUserSetting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -269,20 +754,16 @@ func (x *AccessTokensUserSetting) GetAccessTokens() []*AccessTokensUserSetting_A
 	return nil
 }
 
-type AccessTokensUserSetting_AccessToken struct {
+type WebPushSubscriptionsUserSetting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The access token is a JWT token.
-	// Including expiration time, issuer, etc.
-	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	// A description for the access token.
-	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Subscriptions []*WebPushSubscriptionsUserSetting_Subscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
 }
 
-func (x *AccessTokensUserSetting_AccessToken) Reset() {
-	*x = AccessTokensUserSetting_AccessToken{}
+func (x *WebPushSubscriptionsUserSetting) Reset() {
+	*x = WebPushSubscriptionsUserSetting{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_store_user_setting_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -290,13 +771,13 @@ func (x *AccessTokensUserSetting_AccessToken) Reset() {
 	}
 }
 
-func (x *AccessTokensUserSetting_AccessToken) String() string {
+func (x *WebPushSubscriptionsUserSetting) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AccessTokensUserSetting_AccessToken) ProtoMessage() {}
+func (*WebPushSubscriptionsUserSetting) ProtoMessage() {}
 
-func (x *AccessTokensUserSetting_AccessToken) ProtoReflect() protoreflect.Message {
+func (x *WebPushSubscriptionsUserSetting) ProtoReflect() protoreflect.Message {
 	mi := &file_store_user_setting_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -308,39 +789,804 @@ func (x *AccessTokensUserSetting_AccessToken) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AccessTokensUserSetting_AccessToken.ProtoReflect.Descriptor instead.
-func (*AccessTokensUserSetting_AccessToken) Descriptor() ([]byte, []int) {
-	return file_store_user_setting_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use WebPushSubscriptionsUserSetting.ProtoReflect.Descriptor instead.
+func (*WebPushSubscriptionsUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *AccessTokensUserSetting_AccessToken) GetAccessToken() string {
+func (x *WebPushSubscriptionsUserSetting) GetSubscriptions() []*WebPushSubscriptionsUserSetting_Subscription {
 	if x != nil {
-		return x.AccessToken
+		return x.Subscriptions
 	}
-	return ""
+	return nil
 }
 
-func (x *AccessTokensUserSetting_AccessToken) GetDescription() string {
-	if x != nil {
-		return x.Description
+type MastodonUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The URL of the Mastodon server, e.g. https://mastodon.social.
+	ServerUrl string `protobuf:"bytes,1,opt,name=server_url,json=serverUrl,proto3" json:"server_url,omitempty"`
+	// The access token of the account, with the write:statuses and write:media scopes.
+	AccessToken string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// The tag of the memos cross-posted, empty means all the public memos.
+	Tag string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *MastodonUserSetting) Reset() {
+	*x = MastodonUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-var File_store_user_setting_proto protoreflect.FileDescriptor
+func (x *MastodonUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-var file_store_user_setting_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
-	0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xbe, 0x02, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x2d, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e,
-	0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x4b, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+func (*MastodonUserSetting) ProtoMessage() {}
+
+func (x *MastodonUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MastodonUserSetting.ProtoReflect.Descriptor instead.
+func (*MastodonUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MastodonUserSetting) GetServerUrl() string {
+	if x != nil {
+		return x.ServerUrl
+	}
+	return ""
+}
+
+func (x *MastodonUserSetting) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *MastodonUserSetting) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type BlueskyUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The URL of the server hosting the account, empty means https://bsky.social.
+	ServiceUrl string `protobuf:"bytes,1,opt,name=service_url,json=serviceUrl,proto3" json:"service_url,omitempty"`
+	// The handle or the email of the account.
+	Identifier string `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// The app password of the account.
+	AppPassword string `protobuf:"bytes,3,opt,name=app_password,json=appPassword,proto3" json:"app_password,omitempty"`
+	// The tag of the memos cross-posted, empty means all the public memos.
+	Tag string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *BlueskyUserSetting) Reset() {
+	*x = BlueskyUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlueskyUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlueskyUserSetting) ProtoMessage() {}
+
+func (x *BlueskyUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlueskyUserSetting.ProtoReflect.Descriptor instead.
+func (*BlueskyUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlueskyUserSetting) GetServiceUrl() string {
+	if x != nil {
+		return x.ServiceUrl
+	}
+	return ""
+}
+
+func (x *BlueskyUserSetting) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *BlueskyUserSetting) GetAppPassword() string {
+	if x != nil {
+		return x.AppPassword
+	}
+	return ""
+}
+
+func (x *BlueskyUserSetting) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type GithubGistUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The personal access token of the account, with the gist scope.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *GithubGistUserSetting) Reset() {
+	*x = GithubGistUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GithubGistUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GithubGistUserSetting) ProtoMessage() {}
+
+func (x *GithubGistUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GithubGistUserSetting.ProtoReflect.Descriptor instead.
+func (*GithubGistUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GithubGistUserSetting) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type HighlightSyncUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider HighlightSyncUserSetting_Provider `protobuf:"varint,1,opt,name=provider,proto3,enum=memos.store.HighlightSyncUserSetting_Provider" json:"provider,omitempty"`
+	// The access token of Readwise, or the API key of Omnivore.
+	Token string                        `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Mode  HighlightSyncUserSetting_Mode `protobuf:"varint,3,opt,name=mode,proto3,enum=memos.store.HighlightSyncUserSetting_Mode" json:"mode,omitempty"`
+	// The tag of the imported memos.
+	Tag string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	// The time of the last sync, the highlights updated after it are imported by the next sync.
+	LastSyncTs int64 `protobuf:"varint,5,opt,name=last_sync_ts,json=lastSyncTs,proto3" json:"last_sync_ts,omitempty"`
+}
+
+func (x *HighlightSyncUserSetting) Reset() {
+	*x = HighlightSyncUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HighlightSyncUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightSyncUserSetting) ProtoMessage() {}
+
+func (x *HighlightSyncUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightSyncUserSetting.ProtoReflect.Descriptor instead.
+func (*HighlightSyncUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HighlightSyncUserSetting) GetProvider() HighlightSyncUserSetting_Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return HighlightSyncUserSetting_PROVIDER_UNSPECIFIED
+}
+
+func (x *HighlightSyncUserSetting) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *HighlightSyncUserSetting) GetMode() HighlightSyncUserSetting_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return HighlightSyncUserSetting_MEMO_PER_ARTICLE
+}
+
+func (x *HighlightSyncUserSetting) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *HighlightSyncUserSetting) GetLastSyncTs() int64 {
+	if x != nil {
+		return x.LastSyncTs
+	}
+	return 0
+}
+
+type ProfileUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bio in markdown, which is longer than the description of the user.
+	Bio string `protobuf:"bytes,1,opt,name=bio,proto3" json:"bio,omitempty"`
+	// The external links, e.g. the website or the accounts of the user.
+	Links []*ProfileUserSetting_Link `protobuf:"bytes,2,rep,name=links,proto3" json:"links,omitempty"`
+	// The ids of the memos pinned on the profile in order, only the public ones of the user are shown.
+	PinnedMemoIds []int32 `protobuf:"varint,3,rep,packed,name=pinned_memo_ids,json=pinnedMemoIds,proto3" json:"pinned_memo_ids,omitempty"`
+	// The theme of the public pages, e.g. "light" or "dark", empty means the one of the system.
+	Theme string `protobuf:"bytes,4,opt,name=theme,proto3" json:"theme,omitempty"`
+	// The accent color of the public pages in hex, e.g. "#0ea5e9".
+	AccentColor string `protobuf:"bytes,5,opt,name=accent_color,json=accentColor,proto3" json:"accent_color,omitempty"`
+}
+
+func (x *ProfileUserSetting) Reset() {
+	*x = ProfileUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileUserSetting) ProtoMessage() {}
+
+func (x *ProfileUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileUserSetting.ProtoReflect.Descriptor instead.
+func (*ProfileUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProfileUserSetting) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *ProfileUserSetting) GetLinks() []*ProfileUserSetting_Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ProfileUserSetting) GetPinnedMemoIds() []int32 {
+	if x != nil {
+		return x.PinnedMemoIds
+	}
+	return nil
+}
+
+func (x *ProfileUserSetting) GetTheme() string {
+	if x != nil {
+		return x.Theme
+	}
+	return ""
+}
+
+func (x *ProfileUserSetting) GetAccentColor() string {
+	if x != nil {
+		return x.AccentColor
+	}
+	return ""
+}
+
+type QuotaUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of memos, 0 means unlimited.
+	MaxMemoCount int32 `protobuf:"varint,1,opt,name=max_memo_count,json=maxMemoCount,proto3" json:"max_memo_count,omitempty"`
+	// The maximum total size of resources in bytes, 0 means unlimited.
+	MaxResourceSize int64 `protobuf:"varint,2,opt,name=max_resource_size,json=maxResourceSize,proto3" json:"max_resource_size,omitempty"`
+}
+
+func (x *QuotaUserSetting) Reset() {
+	*x = QuotaUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuotaUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUserSetting) ProtoMessage() {}
+
+func (x *QuotaUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaUserSetting.ProtoReflect.Descriptor instead.
+func (*QuotaUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuotaUserSetting) GetMaxMemoCount() int32 {
+	if x != nil {
+		return x.MaxMemoCount
+	}
+	return 0
+}
+
+func (x *QuotaUserSetting) GetMaxResourceSize() int64 {
+	if x != nil {
+		return x.MaxResourceSize
+	}
+	return 0
+}
+
+type NotificationUserSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The flags of the notifications sent by email.
+	EmailComment  bool `protobuf:"varint,1,opt,name=email_comment,json=emailComment,proto3" json:"email_comment,omitempty"`
+	EmailReaction bool `protobuf:"varint,2,opt,name=email_reaction,json=emailReaction,proto3" json:"email_reaction,omitempty"`
+	EmailMention  bool `protobuf:"varint,3,opt,name=email_mention,json=emailMention,proto3" json:"email_mention,omitempty"`
+	EmailReminder bool `protobuf:"varint,4,opt,name=email_reminder,json=emailReminder,proto3" json:"email_reminder,omitempty"`
+	// The frequency of the digests of the new memos of the workspace.
+	Digest NotificationUserSetting_Digest `protobuf:"varint,5,opt,name=digest,proto3,enum=memos.store.NotificationUserSetting_Digest" json:"digest,omitempty"`
+	// The time the last digest was sent, or the digest was enabled.
+	LastDigestTs int64 `protobuf:"varint,6,opt,name=last_digest_ts,json=lastDigestTs,proto3" json:"last_digest_ts,omitempty"`
+	// The push service the inbox messages are delivered to.
+	Push *PushNotificationSetting `protobuf:"bytes,7,opt,name=push,proto3" json:"push,omitempty"`
+}
+
+func (x *NotificationUserSetting) Reset() {
+	*x = NotificationUserSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotificationUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationUserSetting) ProtoMessage() {}
+
+func (x *NotificationUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationUserSetting.ProtoReflect.Descriptor instead.
+func (*NotificationUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NotificationUserSetting) GetEmailComment() bool {
+	if x != nil {
+		return x.EmailComment
+	}
+	return false
+}
+
+func (x *NotificationUserSetting) GetEmailReaction() bool {
+	if x != nil {
+		return x.EmailReaction
+	}
+	return false
+}
+
+func (x *NotificationUserSetting) GetEmailMention() bool {
+	if x != nil {
+		return x.EmailMention
+	}
+	return false
+}
+
+func (x *NotificationUserSetting) GetEmailReminder() bool {
+	if x != nil {
+		return x.EmailReminder
+	}
+	return false
+}
+
+func (x *NotificationUserSetting) GetDigest() NotificationUserSetting_Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return NotificationUserSetting_DIGEST_UNSPECIFIED
+}
+
+func (x *NotificationUserSetting) GetLastDigestTs() int64 {
+	if x != nil {
+		return x.LastDigestTs
+	}
+	return 0
+}
+
+func (x *NotificationUserSetting) GetPush() *PushNotificationSetting {
+	if x != nil {
+		return x.Push
+	}
+	return nil
+}
+
+type PushNotificationSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider PushNotificationSetting_Provider `protobuf:"varint,1,opt,name=provider,proto3,enum=memos.store.PushNotificationSetting_Provider" json:"provider,omitempty"`
+	// The URL of the server, e.g. https://ntfy.sh.
+	ServerUrl string `protobuf:"bytes,2,opt,name=server_url,json=serverUrl,proto3" json:"server_url,omitempty"`
+	// The topic of ntfy, unused by Gotify.
+	Topic string `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The access token of ntfy, or the application token of Gotify.
+	Token string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *PushNotificationSetting) Reset() {
+	*x = PushNotificationSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushNotificationSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushNotificationSetting) ProtoMessage() {}
+
+func (x *PushNotificationSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushNotificationSetting.ProtoReflect.Descriptor instead.
+func (*PushNotificationSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PushNotificationSetting) GetProvider() PushNotificationSetting_Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return PushNotificationSetting_PROVIDER_UNSPECIFIED
+}
+
+func (x *PushNotificationSetting) GetServerUrl() string {
+	if x != nil {
+		return x.ServerUrl
+	}
+	return ""
+}
+
+func (x *PushNotificationSetting) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *PushNotificationSetting) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type AccessTokensUserSetting_AccessToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The access token is a JWT token.
+	// Including expiration time, issuer, etc.
+	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// A description for the access token.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *AccessTokensUserSetting_AccessToken) Reset() {
+	*x = AccessTokensUserSetting_AccessToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccessTokensUserSetting_AccessToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessTokensUserSetting_AccessToken) ProtoMessage() {}
+
+func (x *AccessTokensUserSetting_AccessToken) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessTokensUserSetting_AccessToken.ProtoReflect.Descriptor instead.
+func (*AccessTokensUserSetting_AccessToken) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type WebPushSubscriptionsUserSetting_Subscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The URL of the push service the messages are posted to.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// The public key of the browser, in URL-safe base64.
+	P256Dh string `protobuf:"bytes,2,opt,name=p256dh,proto3" json:"p256dh,omitempty"`
+	// The authentication secret of the browser, in URL-safe base64.
+	Auth      string `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth,omitempty"`
+	CreatedTs int64  `protobuf:"varint,4,opt,name=created_ts,json=createdTs,proto3" json:"created_ts,omitempty"`
+}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) Reset() {
+	*x = WebPushSubscriptionsUserSetting_Subscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPushSubscriptionsUserSetting_Subscription) ProtoMessage() {}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPushSubscriptionsUserSetting_Subscription.ProtoReflect.Descriptor instead.
+func (*WebPushSubscriptionsUserSetting_Subscription) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) GetP256Dh() string {
+	if x != nil {
+		return x.P256Dh
+	}
+	return ""
+}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *WebPushSubscriptionsUserSetting_Subscription) GetCreatedTs() int64 {
+	if x != nil {
+		return x.CreatedTs
+	}
+	return 0
+}
+
+type ProfileUserSetting_Link struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Url   string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *ProfileUserSetting_Link) Reset() {
+	*x = ProfileUserSetting_Link{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_user_setting_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileUserSetting_Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileUserSetting_Link) ProtoMessage() {}
+
+func (x *ProfileUserSetting_Link) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileUserSetting_Link.ProtoReflect.Descriptor instead.
+func (*ProfileUserSetting_Link) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ProfileUserSetting_Link) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProfileUserSetting_Link) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+var File_store_user_setting_proto protoreflect.FileDescriptor
+
+var file_store_user_setting_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xb2, 0x09, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x2d, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e,
+	0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x4b, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
 	0x73, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0c,
 	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x06,
 	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
@@ -351,44 +1597,249 @@ var file_store_user_setting_proto_rawDesc = []byte{
 	0x09, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
 	0x69, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x65, 0x6c, 0x65, 0x67, 0x72, 0x61, 0x6d, 0x5f,
 	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x0e, 0x74, 0x65, 0x6c, 0x65, 0x67, 0x72, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x42,
-	0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x17, 0x41, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x12, 0x55, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6d, 0x65,
-	0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
-	0x67, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x0c, 0x61,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x1a, 0x52, 0x0a, 0x0b, 0x41,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2a,
-	0xcd, 0x01, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4b,
-	0x65, 0x79, 0x12, 0x20, 0x0a, 0x1c, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49,
-	0x4e, 0x47, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54,
-	0x54, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x4f, 0x4b, 0x45,
-	0x4e, 0x53, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54,
-	0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x1b, 0x0a,
-	0x17, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x50,
-	0x50, 0x45, 0x41, 0x52, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x55, 0x53,
-	0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x4d, 0x4f, 0x5f,
-	0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x10, 0x04, 0x12, 0x21, 0x0a, 0x1d,
-	0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x45, 0x4c,
-	0x45, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x10, 0x05, 0x42,
-	0x9b, 0x01, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x42, 0x10, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x73, 0x65, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2f, 0x6d, 0x65, 0x6d,
-	0x6f, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0xa2, 0x02, 0x03, 0x4d, 0x53, 0x58, 0xaa, 0x02, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x73,
-	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0xca, 0x02, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x5c, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0xe2, 0x02, 0x17, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x5c, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
-	0x0c, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x3a, 0x3a, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0e, 0x74, 0x65, 0x6c, 0x65, 0x67, 0x72, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x26, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x24,
+	0x0a, 0x0d, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x5f,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x0d, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x34,
+	0x0a, 0x15, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x13, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x4a, 0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x6d,
+	0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x48, 0x00, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1f, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x66, 0x65, 0x65, 0x64, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x64, 0x0a, 0x16, 0x77, 0x65, 0x62, 0x5f, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48,
+	0x00, 0x52, 0x14, 0x77, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3e, 0x0a, 0x08, 0x6d, 0x61, 0x73, 0x74, 0x6f,
+	0x64, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x6f, 0x64, 0x6f, 0x6e,
+	0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x08, 0x6d,
+	0x61, 0x73, 0x74, 0x6f, 0x64, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x07, 0x62, 0x6c, 0x75, 0x65, 0x73,
+	0x6b, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x75, 0x65, 0x73, 0x6b, 0x79, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6c, 0x75,
+	0x65, 0x73, 0x6b, 0x79, 0x12, 0x45, 0x0a, 0x0b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x5f, 0x67,
+	0x69, 0x73, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x47, 0x69,
+	0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52,
+	0x0a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x47, 0x69, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x0e, 0x68,
+	0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x55,
+	0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0d, 0x68, 0x69,
+	0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x3b, 0x0a, 0x07, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d,
+	0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65,
+	0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x4f, 0x70, 0x74,
+	0x4f, 0x75, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc4, 0x01, 0x0a,
+	0x17, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55, 0x73, 0x65,
+	0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x55, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x30, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x1a,
+	0x52, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21,
+	0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0xf9, 0x01, 0x0a, 0x1f, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x5f, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39,
+	0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x57, 0x65, 0x62,
+	0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x75, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x32, 0x35, 0x36, 0x64, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x32, 0x35, 0x36, 0x64, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x73, 0x22,
+	0x69, 0x0a, 0x13, 0x4d, 0x61, 0x73, 0x74, 0x6f, 0x64, 0x6f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x8a, 0x01, 0x0a, 0x12, 0x42,
+	0x6c, 0x75, 0x65, 0x73, 0x6b, 0x79, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55,
+	0x72, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x70, 0x70, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x2d, 0x0a, 0x15, 0x47, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x47, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xe8, 0x02, 0x0a, 0x18, 0x48, 0x69, 0x67, 0x68, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x12, 0x4a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x79, 0x6e,
+	0x63, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3e, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x55,
+	0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x73, 0x79, 0x6e, 0x63, 0x5f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x73, 0x22, 0x40, 0x0a, 0x08, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45,
+	0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0c, 0x0a, 0x08, 0x52, 0x45, 0x41, 0x44, 0x57, 0x49, 0x53, 0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a,
+	0x08, 0x4f, 0x4d, 0x4e, 0x49, 0x56, 0x4f, 0x52, 0x45, 0x10, 0x02, 0x22, 0x34, 0x0a, 0x04, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x45, 0x4d, 0x4f, 0x5f, 0x50, 0x45, 0x52, 0x5f,
+	0x41, 0x52, 0x54, 0x49, 0x43, 0x4c, 0x45, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x45, 0x4d,
+	0x4f, 0x5f, 0x50, 0x45, 0x52, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x10,
+	0x01, 0x22, 0xf3, 0x01, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x6f, 0x12, 0x3a, 0x0a, 0x05, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52,
+	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64,
+	0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x0d, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x6f, 0x49, 0x64, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x68, 0x65, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65,
+	0x6e, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x1a, 0x2e, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x64, 0x0a, 0x10, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0e, 0x6d,
+	0x61, 0x78, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61,
+	0x78, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x8f, 0x03,
+	0x0a, 0x17, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x25,
+	0x0a, 0x0e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x6d,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x4d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x5f, 0x72, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x12, 0x43, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2b, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x54, 0x73, 0x12, 0x38, 0x0a, 0x04,
+	0x70, 0x75, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x6d,
+	0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x04, 0x70, 0x75, 0x73, 0x68, 0x22, 0x37, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x12, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x41, 0x49, 0x4c,
+	0x59, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x57, 0x45, 0x45, 0x4b, 0x4c, 0x59, 0x10, 0x02, 0x22,
+	0xeb, 0x01, 0x0a, 0x17, 0x50, 0x75, 0x73, 0x68, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x49, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e,
+	0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x55, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x3a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x14, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x54, 0x46, 0x59, 0x10,
+	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4f, 0x54, 0x49, 0x46, 0x59, 0x10, 0x02, 0x2a, 0xbe, 0x05,
+	0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79,
+	0x12, 0x20, 0x0a, 0x1c, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47,
+	0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49,
+	0x4e, 0x47, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x53,
+	0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49,
+	0x4e, 0x47, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x55,
+	0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x50, 0x50, 0x45,
+	0x41, 0x52, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x55, 0x53, 0x45, 0x52,
+	0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x4d, 0x4f, 0x5f, 0x56, 0x49,
+	0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x10, 0x04, 0x12, 0x21, 0x0a, 0x1d, 0x55, 0x53,
+	0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x45, 0x4c, 0x45, 0x47,
+	0x52, 0x41, 0x4d, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x10, 0x05, 0x12, 0x1f, 0x0a,
+	0x1b, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x41,
+	0x53, 0x54, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x54, 0x53, 0x10, 0x06, 0x12, 0x16,
+	0x0a, 0x12, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x51,
+	0x55, 0x4f, 0x54, 0x41, 0x10, 0x07, 0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53,
+	0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x4c, 0x41, 0x43, 0x4b, 0x5f, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x49, 0x44, 0x10, 0x08, 0x12, 0x20, 0x0a, 0x1c, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53,
+	0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x55,
+	0x53, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x10, 0x09, 0x12, 0x26, 0x0a, 0x22, 0x55, 0x53, 0x45, 0x52,
+	0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x49,
+	0x4e, 0x47, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x10, 0x0a,
+	0x12, 0x1d, 0x0a, 0x19, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47,
+	0x5f, 0x4e, 0x4f, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0b, 0x12,
+	0x1b, 0x0a, 0x17, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f,
+	0x46, 0x45, 0x45, 0x44, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x10, 0x0c, 0x12, 0x27, 0x0a, 0x23,
+	0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x57, 0x45, 0x42,
+	0x5f, 0x50, 0x55, 0x53, 0x48, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x49,
+	0x4f, 0x4e, 0x53, 0x10, 0x0d, 0x12, 0x19, 0x0a, 0x15, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45,
+	0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x41, 0x53, 0x54, 0x4f, 0x44, 0x4f, 0x4e, 0x10, 0x0e,
+	0x12, 0x18, 0x0a, 0x14, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47,
+	0x5f, 0x42, 0x4c, 0x55, 0x45, 0x53, 0x4b, 0x59, 0x10, 0x0f, 0x12, 0x1c, 0x0a, 0x18, 0x55, 0x53,
+	0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x47, 0x49, 0x54, 0x48, 0x55,
+	0x42, 0x5f, 0x47, 0x49, 0x53, 0x54, 0x10, 0x10, 0x12, 0x1f, 0x0a, 0x1b, 0x55, 0x53, 0x45, 0x52,
+	0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x4c, 0x49, 0x47,
+	0x48, 0x54, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x11, 0x12, 0x18, 0x0a, 0x14, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c,
+	0x45, 0x10, 0x12, 0x12, 0x19, 0x0a, 0x15, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54,
+	0x49, 0x4e, 0x47, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5a, 0x4f, 0x4e, 0x45, 0x10, 0x13, 0x12, 0x1c,
+	0x0a, 0x18, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x44,
+	0x45, 0x4c, 0x45, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x53, 0x10, 0x14, 0x12, 0x20, 0x0a, 0x1c,
+	0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x58, 0x50,
+	0x4c, 0x4f, 0x52, 0x45, 0x5f, 0x4f, 0x50, 0x54, 0x5f, 0x4f, 0x55, 0x54, 0x10, 0x15, 0x42, 0x9b,
+	0x01, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x42, 0x10, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x75, 0x73, 0x65, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x2f, 0x6d, 0x65, 0x6d, 0x6f,
+	0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0xa2, 0x02, 0x03, 0x4d, 0x53, 0x58, 0xaa, 0x02, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0xca, 0x02, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x5c, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0xe2, 0x02, 0x17, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x5c, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0c,
+	0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x3a, 0x3a, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -403,23 +1854,53 @@ func file_store_user_setting_proto_rawDescGZIP() []byte {
 	return file_store_user_setting_proto_rawDescData
 }
 
-var file_store_user_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_store_user_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_store_user_setting_proto_goTypes = []interface{}{
-	(UserSettingKey)(0),                         // 0: memos.store.UserSettingKey
-	(*UserSetting)(nil),                         // 1: memos.store.UserSetting
-	(*AccessTokensUserSetting)(nil),             // 2: memos.store.AccessTokensUserSetting
-	(*AccessTokensUserSetting_AccessToken)(nil), // 3: memos.store.AccessTokensUserSetting.AccessToken
+	(UserSettingKey)(0),                                  // 0: memos.store.UserSettingKey
+	(HighlightSyncUserSetting_Provider)(0),               // 1: memos.store.HighlightSyncUserSetting.Provider
+	(HighlightSyncUserSetting_Mode)(0),                   // 2: memos.store.HighlightSyncUserSetting.Mode
+	(NotificationUserSetting_Digest)(0),                  // 3: memos.store.NotificationUserSetting.Digest
+	(PushNotificationSetting_Provider)(0),                // 4: memos.store.PushNotificationSetting.Provider
+	(*UserSetting)(nil),                                  // 5: memos.store.UserSetting
+	(*AccessTokensUserSetting)(nil),                      // 6: memos.store.AccessTokensUserSetting
+	(*WebPushSubscriptionsUserSetting)(nil),              // 7: memos.store.WebPushSubscriptionsUserSetting
+	(*MastodonUserSetting)(nil),                          // 8: memos.store.MastodonUserSetting
+	(*BlueskyUserSetting)(nil),                           // 9: memos.store.BlueskyUserSetting
+	(*GithubGistUserSetting)(nil),                        // 10: memos.store.GithubGistUserSetting
+	(*HighlightSyncUserSetting)(nil),                     // 11: memos.store.HighlightSyncUserSetting
+	(*ProfileUserSetting)(nil),                           // 12: memos.store.ProfileUserSetting
+	(*QuotaUserSetting)(nil),                             // 13: memos.store.QuotaUserSetting
+	(*NotificationUserSetting)(nil),                      // 14: memos.store.NotificationUserSetting
+	(*PushNotificationSetting)(nil),                      // 15: memos.store.PushNotificationSetting
+	(*AccessTokensUserSetting_AccessToken)(nil),          // 16: memos.store.AccessTokensUserSetting.AccessToken
+	(*WebPushSubscriptionsUserSetting_Subscription)(nil), // 17: memos.store.WebPushSubscriptionsUserSetting.Subscription
+	(*ProfileUserSetting_Link)(nil),                      // 18: memos.store.ProfileUserSetting.Link
 }
 var file_store_user_setting_proto_depIdxs = []int32{
-	0, // 0: memos.store.UserSetting.key:type_name -> memos.store.UserSettingKey
-	2, // 1: memos.store.UserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting
-	3, // 2: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: memos.store.UserSetting.key:type_name -> memos.store.UserSettingKey
+	6,  // 1: memos.store.UserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting
+	13, // 2: memos.store.UserSetting.quota:type_name -> memos.store.QuotaUserSetting
+	14, // 3: memos.store.UserSetting.notification:type_name -> memos.store.NotificationUserSetting
+	7,  // 4: memos.store.UserSetting.web_push_subscriptions:type_name -> memos.store.WebPushSubscriptionsUserSetting
+	8,  // 5: memos.store.UserSetting.mastodon:type_name -> memos.store.MastodonUserSetting
+	9,  // 6: memos.store.UserSetting.bluesky:type_name -> memos.store.BlueskyUserSetting
+	10, // 7: memos.store.UserSetting.github_gist:type_name -> memos.store.GithubGistUserSetting
+	11, // 8: memos.store.UserSetting.highlight_sync:type_name -> memos.store.HighlightSyncUserSetting
+	12, // 9: memos.store.UserSetting.profile:type_name -> memos.store.ProfileUserSetting
+	16, // 10: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
+	17, // 11: memos.store.WebPushSubscriptionsUserSetting.subscriptions:type_name -> memos.store.WebPushSubscriptionsUserSetting.Subscription
+	1,  // 12: memos.store.HighlightSyncUserSetting.provider:type_name -> memos.store.HighlightSyncUserSetting.Provider
+	2,  // 13: memos.store.HighlightSyncUserSetting.mode:type_name -> memos.store.HighlightSyncUserSetting.Mode
+	18, // 14: memos.store.ProfileUserSetting.links:type_name -> memos.store.ProfileUserSetting.Link
+	3,  // 15: memos.store.NotificationUserSetting.digest:type_name -> memos.store.NotificationUserSetting.Digest
+	15, // 16: memos.store.NotificationUserSetting.push:type_name -> memos.store.PushNotificationSetting
+	4,  // 17: memos.store.PushNotificationSetting.provider:type_name -> memos.store.PushNotificationSetting.Provider
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_store_user_setting_proto_init() }
@@ -453,6 +1934,114 @@ func file_store_user_setting_proto_init() {
 			}
 		}
 		file_store_user_setting_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebPushSubscriptionsUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MastodonUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlueskyUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GithubGistUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HighlightSyncUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuotaUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotificationUserSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushNotificationSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AccessTokensUserSetting_AccessToken); i {
 			case 0:
 				return &v.state
@@ -464,6 +2053,30 @@ func file_store_user_setting_proto_init() {
 				return nil
 			}
 		}
+		file_store_user_setting_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebPushSubscriptionsUserSetting_Subscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_user_setting_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileUserSetting_Link); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_store_user_setting_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*UserSetting_AccessTokens)(nil),
@@ -471,14 +2084,30 @@ func file_store_user_setting_proto_init() {
 		(*UserSetting_Appearance)(nil),
 		(*UserSetting_MemoVisibility)(nil),
 		(*UserSetting_TelegramUserId)(nil),
+		(*UserSetting_LastActiveTs)(nil),
+		(*UserSetting_Quota)(nil),
+		(*UserSetting_SlackUserId)(nil),
+		(*UserSetting_DiscordUserId)(nil),
+		(*UserSetting_EmailIngestionToken)(nil),
+		(*UserSetting_Notification)(nil),
+		(*UserSetting_FeedToken)(nil),
+		(*UserSetting_WebPushSubscriptions)(nil),
+		(*UserSetting_Mastodon)(nil),
+		(*UserSetting_Bluesky)(nil),
+		(*UserSetting_GithubGist)(nil),
+		(*UserSetting_HighlightSync)(nil),
+		(*UserSetting_Profile)(nil),
+		(*UserSetting_Timezone)(nil),
+		(*UserSetting_DeletionTs)(nil),
+		(*UserSetting_ExploreOptOut)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_store_user_setting_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      5,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
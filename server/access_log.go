@@ -0,0 +1,95 @@
+package server
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/auditlog"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+)
+
+// AccessLogMiddleware records the requests to the access log, when it's enabled.
+// The requests whose paths have any of the comma separated prefixes in skip aren't recorded, e.g. the health checks.
+func AccessLogMiddleware(skip string) echo.MiddlewareFunc {
+	skippedPrefixes := []string{}
+	for _, prefix := range strings.Split(skip, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			skippedPrefixes = append(skippedPrefixes, prefix)
+		}
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !auditlog.AccessEnabled() {
+				return next(c)
+			}
+			r := c.Request()
+			for _, prefix := range skippedPrefixes {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					return next(c)
+				}
+			}
+			start := time.Now()
+			// The error is handled here like the logger middleware of echo, so the status of the response is the one written.
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			record := &auditlog.AccessRecord{
+				Time:      start,
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Query:     r.URL.RawQuery,
+				Protocol:  r.Proto,
+				Status:    c.Response().Status,
+				Bytes:     c.Response().Size,
+				Latency:   time.Since(start),
+				IP:        c.RealIP(),
+				UserAgent: r.UserAgent(),
+				Referer:   r.Referer(),
+			}
+			if userID, ok := apiv1.GetUserID(c); ok {
+				record.UserID = userID
+			}
+			auditlog.RecordAccess(r.Context(), record)
+			return nil
+		}
+	}
+}
+
+// newIPExtractor returns the extractor of the client IPs from the X-Forwarded-For headers set by the trusted proxies,
+// the comma separated IPs or CIDRs. The loopback and the private proxies aren't trusted unless they're listed.
+// An empty list returns nil, so the headers are trusted as is.
+func newIPExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	ranges := []echo.TrustOption{}
+	for _, proxy := range strings.Split(trustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.Errorf("invalid trusted proxy: %s", proxy)
+			}
+			if ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted proxy: %s", proxy)
+		}
+		ranges = append(ranges, echo.TrustIPRange(ipNet))
+	}
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+	options := append([]echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}, ranges...)
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/server/route/api/apiversion"
+)
+
+const apiVersionsPath = "/api/versions"
+
+// APIVersion is the status of a version of the API.
+type APIVersion struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// DeprecatedTs and SunsetTs are zero if the version isn't deprecated or its removal isn't scheduled.
+	DeprecatedTs int64  `json:"deprecatedTs"`
+	SunsetTs     int64  `json:"sunsetTs"`
+	Successor    string `json:"successor,omitempty"`
+}
+
+// APIVersionMiddleware negotiates the version of the API requests and sets the version headers of the responses.
+func APIVersionMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			if grpcRequestSkipper(c) || !strings.HasPrefix(r.URL.Path, "/api/") || r.URL.Path == apiVersionsPath {
+				return next(c)
+			}
+
+			version, path, err := apiversion.Negotiate(r.URL.Path, r.Header.Get(apiversion.Header))
+			if errors.Is(err, apiversion.ErrConflict) {
+				return echo.NewHTTPError(http.StatusBadRequest, "API version header conflicts with the path").SetInternal(err)
+			} else if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Unsupported API version").SetInternal(err)
+			}
+			if version == nil {
+				return next(c)
+			}
+			if path != r.URL.Path {
+				r.URL.Path = path
+				r.URL.RawPath = ""
+			}
+			for key, value := range version.Headers(time.Now()) {
+				c.Response().Header().Set(key, value)
+			}
+			return next(c)
+		}
+	}
+}
+
+func listAPIVersions(c echo.Context) error {
+	now := time.Now()
+	list := []*APIVersion{}
+	for _, version := range apiversion.Versions {
+		apiVersion := &APIVersion{
+			Name:      version.Name,
+			Status:    version.Status(now),
+			Successor: version.Successor,
+		}
+		if !version.DeprecatedAt.IsZero() {
+			apiVersion.DeprecatedTs = version.DeprecatedAt.Unix()
+		}
+		if !version.SunsetAt.IsZero() {
+			apiVersion.SunsetTs = version.SunsetAt.Unix()
+		}
+		list = append(list, apiVersion)
+	}
+	return c.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/util"
+)
+
+const (
+	// minCompressedSize is the min size of the responses compressed, the smaller ones don't get any smaller.
+	minCompressedSize = 1024
+	// brotliLevel is the level of the brotli compression of the responses, the higher ones are too slow on the fly.
+	brotliLevel = 4
+	// gzipLevel is the level of the gzip compression of the responses.
+	gzipLevel = 5
+)
+
+// compressibleTypes are the media types of the responses which are compressed, besides the text ones.
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/manifest+json",
+	"image/svg+xml",
+}
+
+var (
+	gzipWriters   = sync.Pool{New: func() any { w, _ := gzip.NewWriterLevel(io.Discard, gzipLevel); return w }}
+	brotliWriters = sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, brotliLevel) }}
+)
+
+// CompressMiddleware compresses the responses with brotli or gzip, as the clients accept them, brotli over gzip.
+// Only the text, JSON and the other compressible responses of at least minCompressedSize are compressed,
+// the responses already encoded, e.g. the precompressed assets, and the partial ones aren't.
+func CompressMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			response := c.Response()
+			response.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+			encoding := util.NegotiateEncoding(r.Header.Get(echo.HeaderAcceptEncoding), "br", "gzip")
+			// The ranges are of the uncompressed content, and the upgraded connections aren't HTTP anymore.
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" || r.Header.Get("Upgrade") != "" {
+				return next(c)
+			}
+
+			writer := &compressWriter{ResponseWriter: response.Writer, encoding: encoding}
+			response.Writer = writer
+			defer func() {
+				if err := writer.Close(); err != nil {
+					c.Logger().Error(err)
+				}
+				response.Writer = writer.ResponseWriter
+			}()
+			return next(c)
+		}
+	}
+}
+
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	// The events are streamed, they're flushed one by one.
+	if mediaType == "text/event-stream" {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") {
+		return true
+	}
+	for _, compressibleType := range compressibleTypes {
+		if mediaType == compressibleType {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter buffers the beginning of the response, until it's known whether the response is compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	// The informational responses are sent as is, e.g. 103 Early Hints.
+	if w.decided || status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < minCompressedSize && !w.skipped() {
+		return len(p), nil
+	}
+	if err := w.decide(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// skipped returns true if the response isn't compressed regardless of its size.
+func (w *compressWriter) skipped() bool {
+	header := w.Header()
+	if header.Get(echo.HeaderContentEncoding) != "" || header.Get("Content-Range") != "" {
+		return true
+	}
+	if w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return true
+	}
+	if contentType := header.Get(echo.HeaderContentType); contentType != "" {
+		return !isCompressible(contentType)
+	}
+	return false
+}
+
+// decide writes the header of the response, compressed if it's compressible and large enough, and the buffered beginning.
+func (w *compressWriter) decide() error {
+	w.decided = true
+	header := w.Header()
+	if header.Get(echo.HeaderContentType) == "" && len(w.buf) > 0 {
+		header.Set(echo.HeaderContentType, http.DetectContentType(w.buf))
+	}
+	if len(w.buf) >= minCompressedSize && !w.skipped() {
+		header.Set(echo.HeaderContentEncoding, w.encoding)
+		header.Del(echo.HeaderContentLength)
+		// The strong ETags are of the uncompressed representation.
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = w.newEncoder()
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(w.buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(w.buf)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+	return err
+}
+
+func (w *compressWriter) newEncoder() io.WriteCloser {
+	switch w.encoding {
+	case "br":
+		encoder := brotliWriters.Get().(*brotli.Writer)
+		encoder.Reset(w.ResponseWriter)
+		return &pooledEncoder{WriteCloser: encoder, pool: &brotliWriters}
+	default:
+		encoder := gzipWriters.Get().(*gzip.Writer)
+		encoder.Reset(w.ResponseWriter)
+		return &pooledEncoder{WriteCloser: encoder, pool: &gzipWriters}
+	}
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close writes the rest of the response.
+func (w *compressWriter) Close() error {
+	if !w.decided && (w.status != 0 || len(w.buf) > 0) {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+	err := w.encoder.Close()
+	w.encoder = nil
+	return err
+}
+
+// pooledEncoder puts the encoder back to its pool once it's closed.
+type pooledEncoder struct {
+	io.WriteCloser
+	pool *sync.Pool
+}
+
+func (e *pooledEncoder) Flush() error {
+	if flusher, ok := e.WriteCloser.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (e *pooledEncoder) Close() error {
+	err := e.WriteCloser.Close()
+	e.pool.Put(e.WriteCloser)
+	return err
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(CompressMiddleware())
+	large := strings.Repeat(`{"content":"hello"}`, 100)
+	e.GET("/json", func(c echo.Context) error {
+		return c.JSONBlob(http.StatusOK, []byte(large))
+	})
+	e.GET("/small", func(c echo.Context) error {
+		return c.String(http.StatusOK, "hello")
+	})
+	e.GET("/image", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", []byte(large))
+	})
+	e.GET("/empty", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	serve := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		request.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve("/json", "gzip, deflate, br")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "br", recorder.Header().Get(echo.HeaderContentEncoding))
+	require.Equal(t, echo.HeaderAcceptEncoding, recorder.Header().Get(echo.HeaderVary))
+	body, err := io.ReadAll(brotli.NewReader(recorder.Body))
+	require.NoError(t, err)
+	require.Equal(t, large, string(body))
+
+	recorder = serve("/json", "gzip")
+	require.Equal(t, "gzip", recorder.Header().Get(echo.HeaderContentEncoding))
+	reader, err := gzip.NewReader(recorder.Body)
+	require.NoError(t, err)
+	body, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, large, string(body))
+
+	// The small, the incompressible and the empty responses aren't compressed, nor the ones to the clients without compression.
+	for path, acceptEncoding := range map[string]string{"/small": "br", "/image": "br", "/empty": "br", "/json": "identity"} {
+		recorder = serve(path, acceptEncoding)
+		require.Empty(t, recorder.Header().Get(echo.HeaderContentEncoding), path)
+	}
+	require.Equal(t, "hello", serve("/small", "br").Body.String())
+	require.Equal(t, http.StatusNoContent, serve("/empty", "br").Code)
+	require.Equal(t, large, serve("/image", "br").Body.String())
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+)
+
+// healthCheckTimeout is the max duration of the checks of a probe, below the default timeouts of the probes of the orchestrators.
+const healthCheckTimeout = 5 * time.Second
+
+// healthPaths are the paths of the probes, which aren't traced.
+var healthPaths = []string{"/healthz", "/livez", "/readyz"}
+
+// healthCheck is a check of a probe.
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// registerHealthRoutes registers the probes of the orchestrators, e.g. of Kubernetes. The liveness probe doesn't check
+// the dependencies, so the server isn't restarted once they're unavailable, while the readiness probe takes it out of
+// the load balancer until they're available again, and once it's shutting down.
+func (s *Server) registerHealthRoutes(e *echo.Echo) {
+	// The response of the endpoint older than the probes is kept for the existing health checks.
+	e.GET("/healthz", func(c echo.Context) error {
+		return c.String(http.StatusOK, "Service ready.")
+	})
+	e.GET("/livez", func(c echo.Context) error {
+		return serveHealthChecks(c, "livez", nil)
+	})
+	e.GET("/readyz", func(c echo.Context) error {
+		return serveHealthChecks(c, "readyz", []*healthCheck{
+			{name: "shutdown", check: func(context.Context) error {
+				if s.shuttingDown.Load() {
+					return errors.New("server is shutting down")
+				}
+				return nil
+			}},
+			{name: "database", check: s.Store.Ping},
+			{name: "migrations", check: func(ctx context.Context) error {
+				pending, err := s.Store.HasPendingMigration(ctx)
+				if err != nil {
+					return err
+				}
+				if pending {
+					return errors.New("the schema of the database is older than the one of the server")
+				}
+				return nil
+			}},
+			{name: "storage", check: func(ctx context.Context) error {
+				return apiv1.CheckStorage(ctx, s.Store)
+			}},
+		})
+	})
+}
+
+// serveHealthChecks runs the checks of the probe, and responds in the format of the health endpoints of Kubernetes:
+// `ok` once all of them pass, otherwise 503 with the failed checks. The `verbose` query lists all the checks,
+// and the `exclude` query skips the checks of the names, e.g. `/readyz?exclude=storage`.
+func serveHealthChecks(c echo.Context, probe string, checks []*healthCheck) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), healthCheckTimeout)
+	defer cancel()
+	excluded := map[string]bool{}
+	for _, name := range c.QueryParams()["exclude"] {
+		excluded[name] = true
+	}
+	_, verbose := c.QueryParams()["verbose"]
+
+	lines, failed := []string{}, false
+	for _, check := range checks {
+		if excluded[check.name] {
+			lines = append(lines, fmt.Sprintf("[+]%s excluded: ok", check.name))
+			continue
+		}
+		// The reasons are logged instead of responded, since the probes aren't authenticated.
+		if err := check.check(ctx); err != nil {
+			slog.Warn("Health check failed", slog.String("probe", probe), slog.String("check", check.name), slog.Any("err", err))
+			lines = append(lines, fmt.Sprintf("[-]%s failed: reason withheld", check.name))
+			failed = true
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("[+]%s ok", check.name))
+	}
+	if failed {
+		return c.String(http.StatusServiceUnavailable, strings.Join(lines, "\n")+"\n"+probe+" check failed\n")
+	}
+	if verbose {
+		return c.String(http.StatusOK, strings.Join(lines, "\n")+"\n"+probe+" check passed\n")
+	}
+	return c.String(http.StatusOK, "ok")
+}
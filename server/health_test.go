@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeHealthChecks(t *testing.T) {
+	e := echo.New()
+	e.GET("/readyz", func(c echo.Context) error {
+		return serveHealthChecks(c, "readyz", []*healthCheck{
+			{name: "database", check: func(context.Context) error { return nil }},
+			{name: "storage", check: func(context.Context) error { return errors.New("bucket unreachable at 10.0.0.1") }},
+		})
+	})
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	recorder := serve("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Equal(t, "[+]database ok\n[-]storage failed: reason withheld\nreadyz check failed\n", recorder.Body.String())
+
+	recorder = serve("/readyz?exclude=storage")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "ok", recorder.Body.String())
+
+	recorder = serve("/readyz?exclude=storage&verbose")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "[+]database ok\n[+]storage excluded: ok\nreadyz check passed\n", recorder.Body.String())
+}
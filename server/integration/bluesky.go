@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/bluesky"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	jobqueue "github.com/usememos/memos/server/service/job_queue"
+	"github.com/usememos/memos/store"
+)
+
+// BlueskyService cross-posts the created public memos to the Bluesky accounts linked in the user settings.
+type BlueskyService struct {
+	store       *store.Store
+	eventBroker *event.Broker
+}
+
+func NewBlueskyService(store *store.Store, eventBroker *event.Broker) *BlueskyService {
+	return &BlueskyService{store: store, eventBroker: eventBroker}
+}
+
+// RegisterJobs registers the handler of the jobs cross-posting the memos.
+func (s *BlueskyService) RegisterJobs(queue *jobqueue.Queue) {
+	registerCrossPostJob(queue, "bluesky", s.postMemo)
+}
+
+// Start queues the cross-posting of the created public memos, until the context is done.
+func (s *BlueskyService) Start(ctx context.Context) {
+	crossPostCreatedMemos(ctx, s.store, s.eventBroker, "bluesky")
+}
+
+func (s *BlueskyService) postMemo(ctx context.Context, memoID int32) error {
+	memo, err := s.store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.RowStatus != store.Normal || memo.Visibility != store.Public || memo.ParentID != nil {
+		return err
+	}
+	userSetting, err := s.store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &memo.CreatorID,
+		Key:    storepb.UserSettingKey_USER_SETTING_BLUESKY,
+	})
+	if err != nil {
+		return err
+	}
+	blueskySetting := userSetting.GetBluesky()
+	if blueskySetting.GetIdentifier() == "" || blueskySetting.GetAppPassword() == "" || !hasCrossPostTag(memo.Content, blueskySetting.GetTag()) {
+		return nil
+	}
+	workspaceGeneralSetting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	instanceURL := strings.TrimSuffix(workspaceGeneralSetting.GetInstanceUrl(), "/")
+
+	client := bluesky.NewClient(blueskySetting.ServiceUrl, blueskySetting.Identifier, blueskySetting.AppPassword)
+	if err := client.CreateSession(ctx); err != nil {
+		return err
+	}
+	resources, err := s.store.ListResources(ctx, &store.FindResource{MemoID: &memo.ID})
+	if err != nil {
+		return err
+	}
+	images := []*bluesky.Image{}
+	for _, resource := range resources {
+		if len(images) >= bluesky.MaxImages {
+			break
+		}
+		// The images larger than the limit of Bluesky are skipped, since they can't be uploaded.
+		if resource.ExternalLink != "" || !strings.HasPrefix(resource.Type, "image/") || resource.Size > bluesky.MaxImageSize {
+			continue
+		}
+		blob, err := apiv1.GetResourceBlob(ctx, s.store, resource)
+		if err != nil {
+			return err
+		}
+		image, err := client.UploadImage(ctx, resource.Type, blob, "")
+		if err != nil {
+			slog.Warn("Failed to upload resource to bluesky", slog.String("resource", resource.UID), slog.Any("err", err))
+			continue
+		}
+		images = append(images, image)
+	}
+
+	link := ""
+	if instanceURL != "" {
+		link = instanceURL + "/m/" + memo.UID
+	}
+	text, facets := formatBlueskyPost(getCrossPostContent(memo.Content, instanceURL), link)
+	_, err = client.CreatePost(ctx, text, facets, images)
+	return err
+}
+
+// formatBlueskyPost returns the post of the text followed by the link, which is truncated to the characters limit of Bluesky,
+// and the facets of the URLs in it, since Bluesky doesn't detect the links.
+// The limit is of the graphemes, the characters are counted as the code points so the posts are within it.
+func formatBlueskyPost(text, link string) (string, []*bluesky.Facet) {
+	limit := bluesky.MaxPostLength
+	if link != "" {
+		limit -= utf8.RuneCountInString(link) + len("\n\n")
+	}
+	if utf8.RuneCountInString(text) > limit {
+		text = truncateBlueskyText(text, limit-1) + "…"
+	}
+	if link != "" {
+		if text == "" {
+			text = link
+		} else {
+			text += "\n\n" + link
+		}
+	}
+
+	facets := []*bluesky.Facet{}
+	for _, loc := range urlRegexp.FindAllStringIndex(text, -1) {
+		uri := trimURLPunctuation(text[loc[0]:loc[1]])
+		facets = append(facets, bluesky.NewLinkFacet(loc[0], loc[0]+len(uri), uri))
+	}
+	return text, facets
+}
+
+// truncateBlueskyText returns the start of the text within the limit. The URLs aren't cut, so they're removed if they don't fit.
+func truncateBlueskyText(text string, limit int) string {
+	runes := []rune(text)
+	if limit < 0 {
+		limit = 0
+	}
+	if len(runes) <= limit {
+		return text
+	}
+	end := len(string(runes[:limit]))
+	for _, loc := range urlRegexp.FindAllStringIndex(text, -1) {
+		if loc[0] < end && end < loc[1] {
+			end = loc[0]
+		}
+	}
+	return strings.TrimSpace(text[:end])
+}
+
+// trimURLPunctuation returns the URL without the punctuation following it in the text, e.g. the period of a sentence.
+func trimURLPunctuation(uri string) string {
+	uri = strings.TrimRight(uri, ".,;:!?'\"")
+	if strings.HasSuffix(uri, ")") && !strings.Contains(uri, "(") {
+		uri = strings.TrimRight(strings.TrimSuffix(uri, ")"), ".,;:!?'\"")
+	}
+	return uri
+}
@@ -0,0 +1,37 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/bluesky"
+)
+
+func TestFormatBlueskyPost(t *testing.T) {
+	link := "https://memos.example.com/m/uid"
+
+	text, facets := formatBlueskyPost("Read https://example.com/a (https://example.com/b).", link)
+	require.Equal(t, "Read https://example.com/a (https://example.com/b).\n\n"+link, text)
+	require.Equal(t, []*bluesky.Facet{
+		bluesky.NewLinkFacet(5, 26, "https://example.com/a"),
+		bluesky.NewLinkFacet(28, 49, "https://example.com/b"),
+		bluesky.NewLinkFacet(53, 53+len(link), link),
+	}, facets)
+
+	// The offsets of the facets are of the bytes.
+	text, facets = formatBlueskyPost("Café https://example.com", "")
+	require.Equal(t, "Café https://example.com", text)
+	require.Equal(t, []*bluesky.Facet{bluesky.NewLinkFacet(6, 25, "https://example.com")}, facets)
+
+	text, _ = formatBlueskyPost(strings.Repeat("é", 400), link)
+	require.Equal(t, bluesky.MaxPostLength, utf8.RuneCountInString(text))
+	require.True(t, strings.HasSuffix(text, "…\n\n"+link))
+
+	// The URLs aren't cut.
+	text, facets = formatBlueskyPost(strings.Repeat("a", 250)+" https://example.com/a-long-path-of-a-page", link)
+	require.Equal(t, strings.Repeat("a", 250)+"…\n\n"+link, text)
+	require.Len(t, facets, 1)
+}
@@ -0,0 +1,241 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/discord"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	defaultDiscordCommandPrefix = "!memo"
+	defaultDiscordCaptureEmoji  = "📝"
+	// discordSavedEmoji is the reaction of the bot to the messages captured by reactions.
+	discordSavedEmoji = "✅"
+)
+
+// DiscordHandler captures the messages of the guilds of the workspace setting into memos,
+// with a command or a reaction, and posts the public memos to the channels of the guilds.
+type DiscordHandler struct {
+	store       *store.Store
+	eventBroker *event.Broker
+}
+
+func NewDiscordHandler(store *store.Store, eventBroker *event.Broker) *DiscordHandler {
+	return &DiscordHandler{store: store, eventBroker: eventBroker}
+}
+
+func (d *DiscordHandler) BotToken(ctx context.Context) string {
+	discordSetting, err := d.getDiscordSetting(ctx)
+	if err != nil {
+		return ""
+	}
+	return discordSetting.GetBotToken()
+}
+
+func (d *DiscordHandler) getDiscordSetting(ctx context.Context) (*storepb.DiscordSetting, error) {
+	integrationSetting, err := d.store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return integrationSetting.GetDiscord(), nil
+}
+
+// getGuildSetting returns the setting of the guild, nil if the bot doesn't serve the guild.
+func (d *DiscordHandler) getGuildSetting(ctx context.Context, guildID string) (*storepb.DiscordGuildSetting, error) {
+	if guildID == "" {
+		return nil, nil
+	}
+	discordSetting, err := d.getDiscordSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, guild := range discordSetting.GetGuilds() {
+		if guild.GuildId == guildID {
+			return guild, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *DiscordHandler) MessageHandle(ctx context.Context, bot *discord.Bot, message *discord.Message) error {
+	guild, err := d.getGuildSetting(ctx, message.GuildID)
+	if err != nil || guild == nil {
+		return err
+	}
+	prefix := guild.CommandPrefix
+	if prefix == "" {
+		prefix = defaultDiscordCommandPrefix
+	}
+	rest, ok := strings.CutPrefix(message.Content, prefix)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\n') {
+		return nil
+	}
+	reply := func(text string) error {
+		_, err := bot.CreateMessage(ctx, message.ChannelID, text, message.ID)
+		return err
+	}
+
+	content := strings.TrimSpace(rest)
+	// The command without content captures the replied message.
+	if content == "" && message.ReferencedMessage != nil {
+		content = formatDiscordMessageContent(message.ReferencedMessage)
+	}
+	if content == "" {
+		return reply(fmt.Sprintf("Usage: %s <content of the memo>, or reply to a message with %s", prefix, prefix))
+	}
+	creatorID, err := findLinkedUserID(ctx, d.store, storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID, message.Author.ID, (*storepb.UserSetting).GetDiscordUserId)
+	if err != nil {
+		return errors.Wrap(err, "failed to find user settings")
+	}
+	if creatorID == 0 {
+		return reply(fmt.Sprintf("Please set your discord user id %s in the settings of memos", message.Author.ID))
+	}
+	memo, err := d.createMemo(ctx, creatorID, content)
+	if err != nil {
+		return reply(fmt.Sprintf("Failed to create memo: %s", err))
+	}
+	return reply(fmt.Sprintf("Saved as %s Memo %d", memo.Visibility, memo.ID))
+}
+
+func (d *DiscordHandler) ReactionHandle(ctx context.Context, bot *discord.Bot, reaction *discord.Reaction) error {
+	guild, err := d.getGuildSetting(ctx, reaction.GuildID)
+	if err != nil || guild == nil {
+		return err
+	}
+	captureEmoji := guild.CaptureEmoji
+	if captureEmoji == "" {
+		captureEmoji = defaultDiscordCaptureEmoji
+	}
+	if reaction.Emoji.Name != captureEmoji {
+		return nil
+	}
+	creatorID, err := findLinkedUserID(ctx, d.store, storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID, reaction.UserID, (*storepb.UserSetting).GetDiscordUserId)
+	if err != nil || creatorID == 0 {
+		return err
+	}
+
+	message, err := bot.GetMessage(ctx, reaction.ChannelID, reaction.MessageID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get message")
+	}
+	message.GuildID = reaction.GuildID
+	// The message is captured once by each user, even if the reaction is removed and added again.
+	normalStatus := store.Normal
+	captured, err := d.store.ListMemos(ctx, &store.FindMemo{
+		CreatorID:     &creatorID,
+		RowStatus:     &normalStatus,
+		ContentSearch: []string{message.GetLink()},
+	})
+	if err != nil {
+		return err
+	}
+	if len(captured) > 0 {
+		return nil
+	}
+	if _, err := d.createMemo(ctx, creatorID, formatDiscordMessageContent(message)); err != nil {
+		return err
+	}
+	return bot.AddReaction(ctx, reaction.ChannelID, reaction.MessageID, discordSavedEmoji)
+}
+
+func (d *DiscordHandler) createMemo(ctx context.Context, creatorID int32, content string) (*store.Memo, error) {
+	visibility, err := getDefaultMemoVisibility(ctx, d.store, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	memo, err := d.store.CreateMemo(ctx, &store.Memo{
+		UID:        shortuuid.New(),
+		CreatorID:  creatorID,
+		Content:    content,
+		Visibility: visibility,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := upsertContentTags(ctx, d.store, creatorID, memo.Content); err != nil {
+		slog.Warn("Failed to upsert tags", slog.Any("err", err))
+	}
+	notifyMemoCreated(ctx, d.store, d.eventBroker, memo)
+	return memo, nil
+}
+
+// formatDiscordMessageContent returns the content of the memo captured from the message, with the link to the message.
+func formatDiscordMessageContent(message *discord.Message) string {
+	link := fmt.Sprintf("[Message link](%s)", message.GetLink())
+	if content := strings.TrimSpace(message.Content); content != "" {
+		return content + "\n\n" + link
+	}
+	return link
+}
+
+// PostPublicMemos posts the created public memos to the channels of the guilds, until the context is done.
+func (d *DiscordHandler) PostPublicMemos(ctx context.Context, bot *discord.Bot) {
+	subscription := d.eventBroker.Subscribe()
+	defer d.eventBroker.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type != event.MemoCreated || e.Visibility != store.Public {
+				continue
+			}
+			if err := d.postMemo(ctx, bot, e.MemoID); err != nil {
+				slog.Warn("Failed to post memo to discord", slog.Int("memo", int(e.MemoID)), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (d *DiscordHandler) postMemo(ctx context.Context, bot *discord.Bot, memoID int32) error {
+	discordSetting, err := d.getDiscordSetting(ctx)
+	if err != nil {
+		return err
+	}
+	channelIDs := []string{}
+	for _, guild := range discordSetting.GetGuilds() {
+		if guild.PublicMemoChannelId != "" {
+			channelIDs = append(channelIDs, guild.PublicMemoChannelId)
+		}
+	}
+	if discordSetting.GetBotToken() == "" || len(channelIDs) == 0 {
+		return nil
+	}
+	memo, err := d.store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.Visibility != store.Public || memo.ParentID != nil {
+		return err
+	}
+	creator, err := d.store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil || creator == nil {
+		return err
+	}
+
+	text := fmt.Sprintf("**%s** (@%s) posted a memo", creator.Nickname, creator.Username)
+	workspaceGeneralSetting, err := d.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if instanceURL := strings.TrimSuffix(workspaceGeneralSetting.GetInstanceUrl(), "/"); instanceURL != "" {
+		text += fmt.Sprintf("\n%s/m/%s", instanceURL, memo.UID)
+	}
+	text += "\n\n" + getMemoSnippet(memo.Content)
+	for _, channelID := range channelIDs {
+		if _, err := bot.CreateMessage(ctx, channelID, text, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
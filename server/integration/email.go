@@ -0,0 +1,173 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/mail"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// MaxEmailSize is the max size of the received emails, including the attachments.
+	MaxEmailSize = 32 << 20
+	// maxEmailContentLength is the max length of the memo content, the same as the memos created by the API.
+	maxEmailContentLength = 1 << 20
+)
+
+// EmailHandler saves the emails sent to the ingestion addresses of the users as memos,
+// with the subject as the first line and the attachments as resources.
+type EmailHandler struct {
+	store       *store.Store
+	eventBroker *event.Broker
+}
+
+func NewEmailHandler(store *store.Store, eventBroker *event.Broker) *EmailHandler {
+	return &EmailHandler{store: store, eventBroker: eventBroker}
+}
+
+// findRecipient returns the user of the ingestion address, nil if the address isn't an ingestion address.
+func (h *EmailHandler) findRecipient(ctx context.Context, address string) (*store.User, error) {
+	integrationSetting, err := h.store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain := integrationSetting.GetEmailIngestion().GetDomain()
+	localPart, addressDomain, ok := strings.Cut(address, "@")
+	if domain == "" || !ok || !strings.EqualFold(addressDomain, domain) {
+		return nil, nil
+	}
+	userID, err := findLinkedUserID(ctx, h.store, storepb.UserSettingKey_USER_SETTING_EMAIL_INGESTION_TOKEN, strings.ToLower(localPart), (*storepb.UserSetting).GetEmailIngestionToken)
+	if err != nil || userID == 0 {
+		return nil, err
+	}
+	return h.store.GetUser(ctx, &store.FindUser{ID: &userID})
+}
+
+func (h *EmailHandler) Recipient(ctx context.Context, address string) error {
+	user, err := h.findRecipient(ctx, address)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return mail.ErrUnknownRecipient
+	}
+	return nil
+}
+
+func (h *EmailHandler) Deliver(ctx context.Context, envelope *mail.Envelope) error {
+	message, err := mail.ParseMessage(envelope.Data)
+	if err != nil {
+		return &mail.Error{Code: 554, Message: "5.6.0 Malformed message"}
+	}
+	integrationSetting, err := h.store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return err
+	}
+	senderMustMatch := integrationSetting.GetEmailIngestion().GetSenderMustMatch()
+
+	saved := map[int32]bool{}
+	for _, recipient := range envelope.Recipients {
+		user, err := h.findRecipient(ctx, recipient)
+		if err != nil {
+			return err
+		}
+		if user == nil || saved[user.ID] {
+			continue
+		}
+		if senderMustMatch && !strings.EqualFold(message.From, user.Email) {
+			slog.Warn("Rejected email from other sender", slog.Int("user", int(user.ID)), slog.String("from", message.From))
+			continue
+		}
+		if err := h.saveMessage(ctx, user, message); err != nil {
+			return err
+		}
+		saved[user.ID] = true
+	}
+	if len(saved) == 0 {
+		return mail.ErrRejected
+	}
+	return nil
+}
+
+func (h *EmailHandler) saveMessage(ctx context.Context, user *store.User, message *mail.Message) error {
+	visibility, err := getDefaultMemoVisibility(ctx, h.store, user.ID)
+	if err != nil {
+		return err
+	}
+	memo, err := h.store.CreateMemo(ctx, &store.Memo{
+		UID:        shortuuid.New(),
+		CreatorID:  user.ID,
+		Content:    formatEmailContent(message),
+		Visibility: visibility,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create memo")
+	}
+	if err := upsertContentTags(ctx, h.store, user.ID, memo.Content); err != nil {
+		slog.Warn("Failed to upsert tags", slog.Any("err", err))
+	}
+
+	uploadLimit, err := apiv1.GetUploadLimit(ctx, h.store, user)
+	if err != nil {
+		return errors.Wrap(err, "failed to get upload limit")
+	}
+	// The memo is kept if an attachment can't be saved, the email shouldn't be retried once the memo is created.
+	for _, attachment := range message.Attachments {
+		if err := h.saveAttachment(ctx, user.ID, memo.ID, uploadLimit, attachment); err != nil {
+			slog.Warn("Failed to save email attachment", slog.String("filename", attachment.Filename), slog.Any("err", err))
+		}
+	}
+	notifyMemoCreated(ctx, h.store, h.eventBroker, memo)
+	return nil
+}
+
+func (h *EmailHandler) saveAttachment(ctx context.Context, creatorID, memoID int32, uploadLimit *apiv1.UploadLimit, attachment *mail.Attachment) error {
+	create := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: creatorID,
+		Filename:  attachment.Filename,
+		Type:      attachment.MimeType,
+		Size:      int64(len(attachment.Data)),
+		MemoID:    &memoID,
+	}
+	if create.Size > uploadLimit.MaxSizeBytes || !uploadLimit.IsTypeAllowed(create.Type) {
+		return errors.Errorf("file %s is not allowed to upload", create.Filename)
+	}
+	result, err := apiv1.ScanResourceBlob(ctx, h.store, bytes.NewReader(attachment.Data))
+	if err != nil {
+		return errors.Wrap(err, "failed to scan resource blob")
+	}
+	if result != nil && result.Infected {
+		return errors.Errorf("file %s is infected: %s", create.Filename, result.Signature)
+	}
+	if err := apiv1.SaveResourceBlob(ctx, h.store, create, bytes.NewReader(attachment.Data)); err != nil {
+		return errors.Wrap(err, "failed to save resource blob")
+	}
+	_, err = h.store.CreateResource(ctx, create)
+	return err
+}
+
+// formatEmailContent returns the content of the memo of the email, the subject is the first line.
+func formatEmailContent(message *mail.Message) string {
+	content := strings.TrimSpace(strings.Join([]string{message.Subject, message.Text}, "\n\n"))
+	if content == "" {
+		content = "(no subject)"
+	}
+	if len(content) > maxEmailContentLength {
+		content = content[:maxEmailContentLength]
+		for !utf8.ValidString(content) {
+			content = content[:len(content)-1]
+		}
+	}
+	return content
+}
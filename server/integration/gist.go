@@ -0,0 +1,218 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/yourselfhosted/gomark/ast"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/plugin/gist"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// gistTag is the tag of the memos mirrored to the gists, the memos only containing code blocks are mirrored regardless.
+	gistTag = "gist"
+	// gistURLProperty is the name of the memo property of the URL of the gist the memo is mirrored to.
+	gistURLProperty = "gist_url"
+)
+
+var (
+	gistExtensions = map[string]string{
+		"bash":       "sh",
+		"csharp":     "cs",
+		"golang":     "go",
+		"javascript": "js",
+		"kotlin":     "kt",
+		"markdown":   "md",
+		"python":     "py",
+		"ruby":       "rb",
+		"rust":       "rs",
+		"shell":      "sh",
+		"typescript": "ts",
+		"yml":        "yaml",
+		"zsh":        "sh",
+	}
+	gistExtensionRegexp = regexp.MustCompile(`^[a-z0-9]{1,16}$`)
+)
+
+// GistService mirrors the memos tagged #gist, or only containing code blocks, to the gists of the GitHub accounts
+// linked in the user settings, and keeps the gists updated when the memos are edited.
+type GistService struct {
+	store       *store.Store
+	eventBroker *event.Broker
+}
+
+func NewGistService(store *store.Store, eventBroker *event.Broker) *GistService {
+	return &GistService{store: store, eventBroker: eventBroker}
+}
+
+// Start mirrors the created and the updated memos, until the context is done.
+func (s *GistService) Start(ctx context.Context) {
+	subscription := s.eventBroker.Subscribe()
+	defer s.eventBroker.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type != event.MemoCreated && e.Type != event.MemoUpdated {
+				continue
+			}
+			if err := s.syncMemo(ctx, e.MemoID); err != nil {
+				slog.Warn("Failed to sync memo to gist", slog.Int("memo", int(e.MemoID)), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (s *GistService) syncMemo(ctx context.Context, memoID int32) error {
+	memo, err := s.store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.RowStatus != store.Normal || memo.ParentID != nil {
+		return err
+	}
+	files, err := getGistFiles(memo)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	userSetting, err := s.store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &memo.CreatorID,
+		Key:    storepb.UserSettingKey_USER_SETTING_GITHUB_GIST,
+	})
+	if err != nil {
+		return err
+	}
+	token := userSetting.GetGithubGist().GetToken()
+	if token == "" {
+		return nil
+	}
+	workspaceGeneralSetting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	description := "Memo " + memo.UID
+	if instanceURL := strings.TrimSuffix(workspaceGeneralSetting.GetInstanceUrl(), "/"); instanceURL != "" && memo.Visibility == store.Public {
+		description = instanceURL + "/m/" + memo.UID
+	}
+
+	client := gist.NewClient(token)
+	name := gistURLProperty
+	property, err := s.store.GetMemoProperty(ctx, &store.FindMemoProperty{MemoID: &memo.ID, Name: &name})
+	if err != nil {
+		return err
+	}
+	var mirrored *gist.Gist
+	if property != nil {
+		mirrored, err = s.updateGist(ctx, client, path.Base(property.Value), description, files)
+		if err != nil && !errors.Is(err, gist.ErrNotFound) {
+			return err
+		}
+		if mirrored != nil && mirrored.HTMLURL == property.Value {
+			return nil
+		}
+	}
+	// The gist is created again if it's deleted on GitHub. The gists can't be changed between public and secret,
+	// so the visibility of the memo when the gist is created is kept.
+	if mirrored == nil {
+		mirrored, err = client.CreateGist(ctx, description, memo.Visibility == store.Public, files)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = s.store.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      gistURLProperty,
+		Value:     mirrored.HTMLURL,
+		UpdatedTs: time.Now().Unix(),
+	})
+	return err
+}
+
+// updateGist replaces the files of the gist with the files, it doesn't update the gist if its files are the same.
+func (*GistService) updateGist(ctx context.Context, client *gist.Client, id, description string, files map[string]*gist.File) (*gist.Gist, error) {
+	current, err := client.GetGist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changed := current.Description != description || len(current.Files) != len(files)
+	updates := map[string]*gist.File{}
+	for name, file := range files {
+		if currentFile := current.Files[name]; currentFile == nil || currentFile.Content != file.Content {
+			changed = true
+		}
+		updates[name] = file
+	}
+	if !changed {
+		return current, nil
+	}
+	for name := range current.Files {
+		if files[name] == nil {
+			updates[name] = nil
+		}
+	}
+	return client.UpdateGist(ctx, id, description, updates)
+}
+
+// getGistFiles returns the files of the gist of the memo, none if the memo isn't mirrored.
+// The code blocks of a memo only containing them are the files, named after the memo and their languages.
+// The other memos tagged #gist are a Markdown file.
+func getGistFiles(memo *store.Memo) (map[string]*gist.File, error) {
+	nodes, err := markdown.Parse(memo.Content)
+	if err != nil {
+		return nil, err
+	}
+	codeBlocks := []*ast.CodeBlock{}
+	codeOnly := true
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case *ast.CodeBlock:
+			// GitHub doesn't accept the files of the blank contents.
+			if strings.TrimSpace(node.Content) != "" {
+				codeBlocks = append(codeBlocks, node)
+			}
+		case *ast.LineBreak:
+		default:
+			codeOnly = false
+		}
+	}
+	files := map[string]*gist.File{}
+	if codeOnly && len(codeBlocks) > 0 {
+		for i, codeBlock := range codeBlocks {
+			name := memo.UID
+			if len(codeBlocks) > 1 {
+				name = fmt.Sprintf("%s-%d", memo.UID, i+1)
+			}
+			files[name+"."+getGistExtension(codeBlock.Language)] = &gist.File{Content: codeBlock.Content}
+		}
+		return files, nil
+	}
+	if hasCrossPostTag(memo.Content, gistTag) {
+		files[memo.UID+".md"] = &gist.File{Content: memo.Content}
+	}
+	return files, nil
+}
+
+// getGistExtension returns the file extension of the language of a code block, which is txt if it's unknown.
+func getGistExtension(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if extension, ok := gistExtensions[language]; ok {
+		return extension
+	}
+	if gistExtensionRegexp.MatchString(language) {
+		return language
+	}
+	return "txt"
+}
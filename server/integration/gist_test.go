@@ -0,0 +1,50 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/gist"
+	"github.com/usememos/memos/store"
+)
+
+func TestGetGistFiles(t *testing.T) {
+	tests := []struct {
+		content string
+		files   map[string]*gist.File
+	}{
+		{
+			content: "```go\npackage main\n```",
+			files:   map[string]*gist.File{"uid.go": {Content: "package main"}},
+		},
+		{
+			content: "```Python\nprint()\n```\n\n```rust\nfn main() {}\n```\n\n```\nplain\n```",
+			files: map[string]*gist.File{
+				"uid-1.py":  {Content: "print()"},
+				"uid-2.rs":  {Content: "fn main() {}"},
+				"uid-3.txt": {Content: "plain"},
+			},
+		},
+		{
+			content: "A snippet #gist\n\n```sh\nls\n```",
+			files:   map[string]*gist.File{"uid.md": {Content: "A snippet #gist\n\n```sh\nls\n```"}},
+		},
+		{
+			content: "A snippet\n\n```sh\nls\n```",
+			files:   map[string]*gist.File{},
+		},
+	}
+	for _, test := range tests {
+		files, err := getGistFiles(&store.Memo{UID: "uid", Content: test.content})
+		require.NoError(t, err)
+		require.Equal(t, test.files, files, test.content)
+	}
+}
+
+func TestGetGistExtension(t *testing.T) {
+	require.Equal(t, "go", getGistExtension("go"))
+	require.Equal(t, "ts", getGistExtension("TypeScript"))
+	require.Equal(t, "txt", getGistExtension(""))
+	require.Equal(t, "txt", getGistExtension("objective-c"))
+}
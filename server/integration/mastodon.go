@@ -0,0 +1,155 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/mastodon"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	jobqueue "github.com/usememos/memos/server/service/job_queue"
+	"github.com/usememos/memos/store"
+)
+
+// MastodonService cross-posts the created public memos to the Mastodon accounts linked in the user settings.
+type MastodonService struct {
+	store       *store.Store
+	eventBroker *event.Broker
+}
+
+func NewMastodonService(store *store.Store, eventBroker *event.Broker) *MastodonService {
+	return &MastodonService{store: store, eventBroker: eventBroker}
+}
+
+// RegisterJobs registers the handler of the jobs cross-posting the memos.
+func (s *MastodonService) RegisterJobs(queue *jobqueue.Queue) {
+	registerCrossPostJob(queue, "mastodon", s.postMemo)
+}
+
+// Start queues the cross-posting of the created public memos, until the context is done.
+func (s *MastodonService) Start(ctx context.Context) {
+	crossPostCreatedMemos(ctx, s.store, s.eventBroker, "mastodon")
+}
+
+func (s *MastodonService) postMemo(ctx context.Context, memoID int32) error {
+	memo, err := s.store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.RowStatus != store.Normal || memo.Visibility != store.Public || memo.ParentID != nil {
+		return err
+	}
+	userSetting, err := s.store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &memo.CreatorID,
+		Key:    storepb.UserSettingKey_USER_SETTING_MASTODON,
+	})
+	if err != nil {
+		return err
+	}
+	mastodonSetting := userSetting.GetMastodon()
+	if mastodonSetting.GetServerUrl() == "" || mastodonSetting.GetAccessToken() == "" || !hasCrossPostTag(memo.Content, mastodonSetting.GetTag()) {
+		return nil
+	}
+	workspaceGeneralSetting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	instanceURL := strings.TrimSuffix(workspaceGeneralSetting.GetInstanceUrl(), "/")
+
+	client := mastodon.NewClient(mastodonSetting.ServerUrl, mastodonSetting.AccessToken)
+	instance := client.GetInstance(ctx)
+	resources, err := s.store.ListResources(ctx, &store.FindResource{MemoID: &memo.ID})
+	if err != nil {
+		return err
+	}
+	mediaIDs := []string{}
+	for _, resource := range resources {
+		if len(mediaIDs) >= instance.MaxMediaAttachments {
+			break
+		}
+		if resource.ExternalLink != "" || !isMastodonMedia(resource.Type) {
+			continue
+		}
+		blob, err := apiv1.GetResourceBlob(ctx, s.store, resource)
+		if err != nil {
+			return err
+		}
+		// The status is posted without the media which fail to upload, e.g. the files larger than the limits of the server.
+		mediaID, err := client.UploadMedia(ctx, resource.Filename, blob)
+		if err != nil {
+			slog.Warn("Failed to upload resource to mastodon", slog.String("resource", resource.UID), slog.Any("err", err))
+			continue
+		}
+		mediaIDs = append(mediaIDs, mediaID)
+	}
+
+	link := ""
+	if instanceURL != "" {
+		link = instanceURL + "/m/" + memo.UID
+	}
+	text := formatMastodonStatus(getCrossPostContent(memo.Content, instanceURL), link, instance)
+	// The memo uid is the idempotency key, so the retried requests don't post the memo twice.
+	_, err = client.PostStatus(ctx, text, mediaIDs, memo.UID)
+	return err
+}
+
+// formatMastodonStatus returns the status of the text followed by the link, which is truncated to the characters limit of the instance.
+func formatMastodonStatus(text, link string, instance *mastodon.Instance) string {
+	limit := instance.MaxCharacters
+	if link != "" {
+		limit -= instance.CharactersReservedPerURL + len("\n\n")
+	}
+	if countMastodonCharacters(text, instance) > limit {
+		text = truncateMastodonText(text, limit-1, instance) + "…"
+	}
+	if link == "" {
+		return text
+	}
+	if text == "" {
+		return link
+	}
+	return text + "\n\n" + link
+}
+
+// countMastodonCharacters returns the number of the characters of the text that count toward the limit of a status.
+// Mastodon counts the URLs as a fixed number of characters.
+func countMastodonCharacters(text string, instance *mastodon.Instance) int {
+	count := 0
+	last := 0
+	for _, loc := range urlRegexp.FindAllStringIndex(text, -1) {
+		count += utf8.RuneCountInString(text[last:loc[0]]) + instance.CharactersReservedPerURL
+		last = loc[1]
+	}
+	return count + utf8.RuneCountInString(text[last:])
+}
+
+// truncateMastodonText returns the start of the text within the limit. The URLs aren't cut, so they're removed if they don't fit.
+func truncateMastodonText(text string, limit int, instance *mastodon.Instance) string {
+	builder := strings.Builder{}
+	count := 0
+	last := 0
+	appendText := func(s string) bool {
+		for _, r := range s {
+			if count >= limit {
+				return false
+			}
+			builder.WriteRune(r)
+			count++
+		}
+		return true
+	}
+	for _, loc := range urlRegexp.FindAllStringIndex(text, -1) {
+		if !appendText(text[last:loc[0]]) || count+instance.CharactersReservedPerURL > limit {
+			return strings.TrimSpace(builder.String())
+		}
+		builder.WriteString(text[loc[0]:loc[1]])
+		count += instance.CharactersReservedPerURL
+		last = loc[1]
+	}
+	appendText(text[last:])
+	return strings.TrimSpace(builder.String())
+}
+
+func isMastodonMedia(resourceType string) bool {
+	return strings.HasPrefix(resourceType, "image/") || strings.HasPrefix(resourceType, "video/") || strings.HasPrefix(resourceType, "audio/")
+}
@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/mastodon"
+)
+
+func TestGetCrossPostContent(t *testing.T) {
+	tests := []struct {
+		content     string
+		instanceURL string
+		want        string
+	}{
+		{
+			content:     "Hello #memos\n\n![[resources/abc]]\n\n\nSee [[memos/xyz]] and [[memos/def?text=the+plan]].",
+			instanceURL: "https://memos.example.com",
+			want:        "Hello #memos\n\nSee https://memos.example.com/m/xyz and the plan (https://memos.example.com/m/def).",
+		},
+		{
+			content: "See [[memos/xyz]] and [[memos/def?text=the+plan]].",
+			want:    "See xyz and the plan.",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, getCrossPostContent(test.content, test.instanceURL))
+	}
+}
+
+func TestHasCrossPostTag(t *testing.T) {
+	require.True(t, hasCrossPostTag("Hello", ""))
+	require.True(t, hasCrossPostTag("Hello #public", "public"))
+	require.True(t, hasCrossPostTag("Hello #public/photos", "public"))
+	require.False(t, hasCrossPostTag("Hello #publication", "public"))
+	require.False(t, hasCrossPostTag("Hello", "public"))
+}
+
+func TestFormatMastodonStatus(t *testing.T) {
+	instance := &mastodon.Instance{MaxCharacters: 50, MaxMediaAttachments: 4, CharactersReservedPerURL: 23}
+	link := "https://memos.example.com/m/a-very-long-memo-uid"
+
+	require.Equal(t, "Hello\n\n"+link, formatMastodonStatus("Hello", link, instance))
+	require.Equal(t, link, formatMastodonStatus("", link, instance))
+	require.Equal(t, strings.Repeat("a", 50), formatMastodonStatus(strings.Repeat("a", 50), "", instance))
+
+	status := formatMastodonStatus(strings.Repeat("a", 40), link, instance)
+	require.Equal(t, strings.Repeat("a", 24)+"…\n\n"+link, status)
+	require.Equal(t, 50, countMastodonCharacters(status, instance))
+
+	// The URLs count as the reserved characters, and aren't cut.
+	require.Equal(t, "See https://example.com/a-long-path-of-a-page ok", formatMastodonStatus("See https://example.com/a-long-path-of-a-page ok", "", instance))
+	require.Equal(t, "See…\n\n"+link, formatMastodonStatus("See https://example.com/a-long-path-of-a-page ok", link, instance))
+}
@@ -0,0 +1,358 @@
+package integration
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/yourselfhosted/gomark/ast"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/plugin/webhook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	apiv2 "github.com/usememos/memos/server/route/api/v2"
+	jobqueue "github.com/usememos/memos/server/service/job_queue"
+	webhookdispatcher "github.com/usememos/memos/server/service/webhook_dispatcher"
+	"github.com/usememos/memos/store"
+)
+
+// maxMemoSnippetLength is the number of the characters of the memo content shown in the messages of the integrations.
+const maxMemoSnippetLength = 200
+
+// crossPostDelay is the delay of cross-posting a created memo, since the clients attach the resources after creating it.
+var crossPostDelay = 10 * time.Second
+
+var (
+	memoReferenceRegexp = regexp.MustCompile(`\[\[memos/([a-zA-Z0-9-]+)(?:\?([^\]]*))?\]\]`)
+	resourceEmbedRegexp = regexp.MustCompile(`!\[\[resources/[a-zA-Z0-9-]+(?:\?[^\]]*)?\]\]`)
+	blankLinesRegexp    = regexp.MustCompile(`\n{3,}`)
+	// urlRegexp matches the URLs in the text of a post.
+	urlRegexp = regexp.MustCompile(`https?://[^\s]+`)
+)
+
+func upsertContentTags(ctx context.Context, s *store.Store, creatorID int32, content string) error {
+	tags, err := getContentTags(content)
+	if err != nil {
+		return errors.Wrap(err, "Failed to parse content")
+	}
+	for _, tag := range tags {
+		_, err := s.UpsertTag(ctx, &store.Tag{
+			Name:      tag,
+			CreatorID: creatorID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "Failed to upsert tag")
+		}
+	}
+	return nil
+}
+
+func getContentTags(content string) ([]string, error) {
+	nodes, err := markdown.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	apiv2.TraverseASTNodes(nodes, func(node ast.Node) {
+		if tagNode, ok := node.(*ast.Tag); ok {
+			tag := tagNode.Content
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	})
+	return tags, nil
+}
+
+// notifyMemoCreated dispatches the webhooks, records the activity and publishes the event of the created memo.
+func notifyMemoCreated(ctx context.Context, s *store.Store, eventBroker *event.Broker, memo *store.Memo) {
+	_ = dispatchMemoRelatedWebhook(ctx, s, *memo, "memos.memo.created")
+	_, _ = s.CreateActivity(ctx, &store.Activity{
+		CreatorID: memo.CreatorID,
+		Type:      store.ActivityTypeMemoCreate,
+		Level:     store.ActivityLevelInfo,
+		Payload: &storepb.ActivityPayload{
+			MemoCreate: &storepb.ActivityMemoCreatePayload{MemoId: memo.ID},
+		},
+	})
+	eventBroker.Publish(event.NewMemoEvent(event.MemoCreated, memo))
+}
+
+// notifyMemoUpdated dispatches the webhooks and publishes the event of the updated memo.
+func notifyMemoUpdated(ctx context.Context, s *store.Store, eventBroker *event.Broker, memo *store.Memo) {
+	_ = dispatchMemoRelatedWebhook(ctx, s, *memo, "memos.memo.updated")
+	eventBroker.Publish(event.NewMemoEvent(event.MemoUpdated, memo))
+}
+
+func isPublicMemoDisabled(ctx context.Context, s *store.Store) (bool, error) {
+	setting, err := s.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
+		Name: apiv1.SystemSettingDisablePublicMemosName.String(),
+	})
+	if err != nil {
+		return false, err
+	}
+	disablePublicMemo := false
+	if setting != nil && setting.Value != "" {
+		if err := json.Unmarshal([]byte(setting.Value), &disablePublicMemo); err != nil {
+			return false, err
+		}
+	}
+	return disablePublicMemo, nil
+}
+
+// findLinkedUserID returns the id of the user who linked the account of an integration in the user setting of the key,
+// 0 if no one did or the user is archived.
+func findLinkedUserID(ctx context.Context, s *store.Store, key storepb.UserSettingKey, linkedID string, getLinkedID func(*storepb.UserSetting) string) (int32, error) {
+	if linkedID == "" {
+		return 0, nil
+	}
+	userSettings, err := s.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: key,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, userSetting := range userSettings {
+		if getLinkedID(userSetting) == linkedID {
+			return getActiveUserID(ctx, s, userSetting.UserId)
+		}
+	}
+	return 0, nil
+}
+
+// getActiveUserID returns the id of the user, 0 if the user doesn't exist or is archived,
+// so the disabled users don't create content by the integrations.
+func getActiveUserID(ctx context.Context, s *store.Store, userID int32) (int32, error) {
+	user, err := s.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return 0, err
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return 0, nil
+	}
+	return user.ID, nil
+}
+
+// getDefaultMemoVisibility returns the default memo visibility of the user,
+// which falls back to private if public memos are disabled.
+func getDefaultMemoVisibility(ctx context.Context, s *store.Store, userID int32) (store.Visibility, error) {
+	userSetting, err := s.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_MEMO_VISIBILITY,
+	})
+	if err != nil {
+		return "", err
+	}
+	visibility := store.Private
+	if userSetting != nil && userSetting.GetMemoVisibility() != "" {
+		visibility = store.Visibility(userSetting.GetMemoVisibility())
+	}
+	if visibility == store.Public {
+		disablePublicMemo, err := isPublicMemoDisabled(ctx, s)
+		if err != nil {
+			return "", err
+		}
+		if disablePublicMemo {
+			visibility = store.Private
+		}
+	}
+	return visibility, nil
+}
+
+func dispatchMemoRelatedWebhook(ctx context.Context, s *store.Store, memo store.Memo, activityType string) error {
+	webhooks, err := s.ListWebhooks(ctx, &store.FindWebhook{
+		CreatorID: &memo.CreatorID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range webhooks {
+		payload := convertMemoToWebhookPayload(ctx, s, memo)
+		payload.ActivityType = activityType
+		if err := webhookdispatcher.Enqueue(ctx, s, hook, payload); err != nil {
+			return errors.Wrap(err, "failed to enqueue webhook")
+		}
+	}
+	return nil
+}
+
+func convertMemoToWebhookPayload(ctx context.Context, s *store.Store, memo store.Memo) (payload *webhook.WebhookPayload) {
+	payload = &webhook.WebhookPayload{
+		CreatorID: memo.CreatorID,
+		CreatedTs: time.Now().Unix(),
+		Memo: &webhook.Memo{
+			ID:           memo.ID,
+			CreatorID:    memo.CreatorID,
+			CreatedTs:    memo.CreatedTs,
+			UpdatedTs:    memo.UpdatedTs,
+			Content:      memo.Content,
+			Visibility:   memo.Visibility.String(),
+			Pinned:       memo.Pinned,
+			ResourceList: make([]*webhook.Resource, 0),
+			RelationList: make([]*webhook.MemoRelation, 0),
+		},
+	}
+
+	resourceList, err := s.ListResources(ctx, &store.FindResource{
+		MemoID: &memo.ID,
+	})
+
+	if err != nil {
+		return payload
+	}
+	for _, resource := range resourceList {
+		payload.Memo.ResourceList = append(payload.Memo.ResourceList, &webhook.Resource{
+			ID:           resource.ID,
+			CreatorID:    resource.CreatorID,
+			CreatedTs:    resource.CreatedTs,
+			UpdatedTs:    resource.UpdatedTs,
+			Filename:     resource.Filename,
+			Type:         resource.Type,
+			Size:         resource.Size,
+			InternalPath: resource.InternalPath,
+			ExternalLink: resource.ExternalLink,
+		})
+	}
+
+	relationList, err := s.ListMemoRelations(ctx, &store.FindMemoRelation{
+		MemoID: &memo.ID,
+	})
+
+	if err != nil {
+		return payload
+	}
+
+	for _, relation := range relationList {
+		payload.Memo.RelationList = append(payload.Memo.RelationList, &webhook.MemoRelation{
+			MemoID:        relation.MemoID,
+			RelatedMemoID: relation.RelatedMemoID,
+			Type:          string(relation.Type),
+		})
+	}
+	return payload
+}
+
+// findAccessTokenUser returns the user of the access token, nil if the token is invalid or revoked.
+func findAccessTokenUser(ctx context.Context, s *store.Store, secret, token string) (*store.User, error) {
+	userID, err := apiv1.GetUserIDFromAccessToken(token, secret)
+	if err != nil {
+		return nil, nil
+	}
+	accessTokens, err := s.GetUserAccessTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, accessToken := range accessTokens {
+		if subtle.ConstantTimeCompare([]byte(accessToken.AccessToken), []byte(token)) == 1 {
+			user, err := s.GetUser(ctx, &store.FindUser{ID: &userID})
+			if err != nil || user == nil || user.RowStatus == store.Archived {
+				return nil, err
+			}
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func getMemoSnippet(content string) string {
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) <= maxMemoSnippetLength {
+		return string(runes)
+	}
+	return string(runes[:maxMemoSnippetLength]) + "..."
+}
+
+// crossPostJob is the payload of the job cross-posting a memo.
+type crossPostJob struct {
+	MemoID int32 `json:"memoId"`
+}
+
+// getCrossPostJobKind returns the kind of the jobs cross-posting the memos to the service.
+func getCrossPostJobKind(service string) string {
+	return "crosspost." + service
+}
+
+// registerCrossPostJob registers the handler of the jobs cross-posting the memos to the service with the function.
+func registerCrossPostJob(queue *jobqueue.Queue, service string, post func(ctx context.Context, memoID int32) error) {
+	queue.Register(getCrossPostJobKind(service), func(ctx context.Context, payload []byte) error {
+		job := &crossPostJob{}
+		if err := json.Unmarshal(payload, job); err != nil {
+			return jobqueue.Permanent(err)
+		}
+		return post(ctx, job.MemoID)
+	})
+}
+
+// crossPostCreatedMemos queues the jobs cross-posting the created public memos to the service after the delay, until the context is done.
+func crossPostCreatedMemos(ctx context.Context, s *store.Store, eventBroker *event.Broker, service string) {
+	subscription := eventBroker.Subscribe()
+	defer eventBroker.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type != event.MemoCreated || e.Visibility != store.Public {
+				continue
+			}
+			if _, err := jobqueue.Enqueue(ctx, s, getCrossPostJobKind(service), &crossPostJob{MemoID: e.MemoID}, jobqueue.WithDelay(crossPostDelay)); err != nil {
+				slog.Warn("Failed to queue memo post to "+service, slog.Int("memo", int(e.MemoID)), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// getCrossPostContent returns the content of the memo as the text of a post on another service.
+// The embedded resources are removed, since they are attached to the post, and the memo references become the links to the memos.
+func getCrossPostContent(content, instanceURL string) string {
+	content = resourceEmbedRegexp.ReplaceAllString(content, "")
+	content = memoReferenceRegexp.ReplaceAllStringFunc(content, func(reference string) string {
+		matches := memoReferenceRegexp.FindStringSubmatch(reference)
+		text := ""
+		if query, err := url.ParseQuery(matches[2]); err == nil {
+			text = query.Get("text")
+		}
+		if instanceURL == "" {
+			if text == "" {
+				return matches[1]
+			}
+			return text
+		}
+		if text == "" {
+			return instanceURL + "/m/" + matches[1]
+		}
+		return text + " (" + instanceURL + "/m/" + matches[1] + ")"
+	})
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(content, "\n\n"))
+}
+
+// hasCrossPostTag returns true if the memo content has the tag or one of its nested tags, or if the tag is empty.
+func hasCrossPostTag(content, tag string) bool {
+	if tag == "" {
+		return true
+	}
+	tags, err := getContentTags(content)
+	if err != nil {
+		return false
+	}
+	for _, contentTag := range tags {
+		if contentTag == tag || strings.HasPrefix(contentTag, tag+"/") {
+			return true
+		}
+	}
+	return false
+}
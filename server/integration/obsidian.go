@@ -0,0 +1,988 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/event"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxVaultNoteLength is the max size of the notes, which are often longer than the memos written in memos.
+	maxVaultNoteLength = 1 << 20
+	// maxVaultPathLength is the max length of the paths in bytes, the column of MySQL is VARCHAR(512).
+	maxVaultPathLength = 512
+	// maxVaultPushNotes is the max number of the notes pushed in a request, and maxVaultPushSize is the max size of the request.
+	maxVaultPushNotes = 100
+	maxVaultPushSize  = 32 << 20
+	// maxVaultNoteTitleLength is the max number of the characters of the filenames named after the first lines of the memos.
+	maxVaultNoteTitleLength = 60
+	vaultNoteExtension      = ".md"
+	// vaultAttachmentDirectory is the directory of the resources of the memos created in memos.
+	vaultAttachmentDirectory = "attachments"
+)
+
+const (
+	ObsidianFileNote       = "note"
+	ObsidianFileAttachment = "attachment"
+)
+
+const (
+	ObsidianPushOK = "ok"
+	// ObsidianPushConflict is the status of the notes whose memos changed since the client synced them,
+	// the memos are kept and the client resolves the conflict.
+	ObsidianPushConflict = "conflict"
+	// ObsidianPushSkipped is the status of the empty new notes, e.g. the untitled notes just created in Obsidian.
+	ObsidianPushSkipped = "skipped"
+	ObsidianPushError   = "error"
+)
+
+// wikilinkRegexp matches the code, which is kept as it is, or a wikilink of Obsidian, e.g. `[[Note|alias]]` or `![[image.png]]`.
+var wikilinkRegexp = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|(!?)\\[\\[([^\\[\\]|#\n]+)(#[^\\[\\]|\n]*)?(?:\\|([^\\[\\]\n]*))?\\]\\]")
+
+// memoLinkRegexp matches the code, which is kept as it is, or a reference of memos, e.g. `[[memos/uid?text=alias]]` or `![[resources/uid]]`.
+var memoLinkRegexp = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|(!?)\\[\\[(memos|resources)/([a-zA-Z0-9-]+)(?:\\?([^\\[\\]\n]*))?\\]\\]")
+
+// invalidFilenameCharactersRegexp matches the characters which are not allowed in the filenames of the notes by Obsidian.
+var invalidFilenameCharactersRegexp = regexp.MustCompile(`[\\/:*?"<>|#^\[\]]+`)
+
+// ObsidianFile is a file of the synced folder of the vault.
+type ObsidianFile struct {
+	// Path is the path of the file, relative to the synced folder.
+	Path string `json:"path"`
+	// Type is note or attachment.
+	Type       string `json:"type"`
+	MemoID     int32  `json:"memoId,omitempty"`
+	ResourceID int32  `json:"resourceId,omitempty"`
+	CreatedTs  int64  `json:"createdTs"`
+	UpdatedTs  int64  `json:"updatedTs"`
+	Deleted    bool   `json:"deleted"`
+	// Content is the Markdown of the note, with the references of memos converted to wikilinks.
+	Content string `json:"content,omitempty"`
+	// Size is the size of the attachment, which is downloaded separately.
+	Size int64 `json:"size,omitempty"`
+}
+
+type ObsidianChanges struct {
+	// SyncTs is the time of the changes, which is the since parameter of the next sync.
+	SyncTs int64           `json:"syncTs"`
+	Files  []*ObsidianFile `json:"files"`
+}
+
+// ObsidianNote is a note changed in the vault.
+type ObsidianNote struct {
+	Path string `json:"path"`
+	// OldPath is the path of the note before it was renamed.
+	OldPath string `json:"oldPath,omitempty"`
+	Content string `json:"content"`
+	// CreatedTs is the creation time of the new notes, which is kept as the creation time of the memos.
+	CreatedTs int64 `json:"createdTs,omitempty"`
+	// BaseUpdatedTs is the update time of the memo when the client last synced the note.
+	BaseUpdatedTs int64 `json:"baseUpdatedTs"`
+	Deleted       bool  `json:"deleted,omitempty"`
+}
+
+type ObsidianPushRequest struct {
+	Notes []*ObsidianNote `json:"notes"`
+}
+
+type ObsidianPushResult struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	MemoID    int32  `json:"memoId,omitempty"`
+	UpdatedTs int64  `json:"updatedTs,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ObsidianPushResponse struct {
+	Results []*ObsidianPushResult `json:"results"`
+}
+
+// ObsidianSyncService syncs a folder of an Obsidian vault with the memos of a user, so Obsidian is used as the editor of the memos.
+// The notes are the memos, the wikilinks are the references of the memos, and the attachments are the resources.
+// The requests are authenticated by the access tokens of the users.
+type ObsidianSyncService struct {
+	store       *store.Store
+	eventBroker *event.Broker
+	secret      string
+}
+
+func NewObsidianSyncService(store *store.Store, eventBroker *event.Broker, secret string) *ObsidianSyncService {
+	return &ObsidianSyncService{
+		store:       store,
+		eventBroker: eventBroker,
+		secret:      secret,
+	}
+}
+
+func (s *ObsidianSyncService) RegisterRoutes(g *echo.Group) {
+	g.GET("/o/obsidian/changes", s.ListChanges)
+	g.POST("/o/obsidian/notes", s.PushNotes)
+	g.GET("/o/obsidian/attachments", s.GetAttachment)
+	g.PUT("/o/obsidian/attachments", s.PutAttachment)
+	g.DELETE("/o/obsidian/attachments", s.DeleteAttachment)
+}
+
+func (s *ObsidianSyncService) authenticate(c echo.Context) (*store.User, error) {
+	token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || token == "" {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Missing access token")
+	}
+	user, err := findAccessTokenUser(c.Request().Context(), s.store, s.secret, token)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid access token")
+	}
+	return user, nil
+}
+
+// ListChanges lists the files changed since the time of the since parameter, all the files without it.
+// The memos created in memos are added to the vault here, the notes are named after their first lines.
+func (s *ObsidianSyncService) ListChanges(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticate(c)
+	if err != nil {
+		return err
+	}
+	var since int64
+	if value := c.QueryParam("since"); value != "" {
+		if since, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid since parameter").SetInternal(err)
+		}
+	}
+
+	now := time.Now().Unix()
+	v, err := s.loadVault(ctx, user)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load vault").SetInternal(err)
+	}
+	if err := s.syncVault(ctx, v, now); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to sync vault").SetInternal(err)
+	}
+
+	links := v.links()
+	changes := &ObsidianChanges{SyncTs: now, Files: []*ObsidianFile{}}
+	for _, file := range v.files {
+		obsidianFile := &ObsidianFile{
+			Path:       file.Path,
+			MemoID:     file.MemoID,
+			ResourceID: file.ResourceID,
+			UpdatedTs:  file.UpdatedTs,
+			Deleted:    file.Deleted,
+		}
+		// The updated time is the time the file was last changed in either memos or the vault.
+		if memo := v.memos[file.MemoID]; file.MemoID != 0 {
+			obsidianFile.Type = ObsidianFileNote
+			if memo != nil && !file.Deleted {
+				obsidianFile.CreatedTs = memo.CreatedTs
+				obsidianFile.UpdatedTs = max(memo.UpdatedTs, file.UpdatedTs)
+				obsidianFile.Content = convertMemoContentToVault(memo.Content, links)
+			}
+		} else {
+			obsidianFile.Type = ObsidianFileAttachment
+			if resource := v.resources[file.ResourceID]; resource != nil && !file.Deleted {
+				obsidianFile.CreatedTs = resource.CreatedTs
+				obsidianFile.UpdatedTs = max(resource.UpdatedTs, file.UpdatedTs)
+				obsidianFile.Size = resource.Size
+			}
+		}
+		if obsidianFile.UpdatedTs >= since {
+			changes.Files = append(changes.Files, obsidianFile)
+		}
+	}
+	return c.JSON(http.StatusOK, changes)
+}
+
+// PushNotes saves the notes changed in the vault as memos. A note is saved only if its memo didn't change since
+// the client synced it, otherwise its status is conflict. The attachments are pushed before the notes embedding them.
+func (s *ObsidianSyncService) PushNotes(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticate(c)
+	if err != nil {
+		return err
+	}
+	request := &ObsidianPushRequest{}
+	if err := json.NewDecoder(io.LimitReader(c.Request().Body, maxVaultPushSize)).Decode(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted push request").SetInternal(err)
+	}
+	if len(request.Notes) > maxVaultPushNotes {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Too many notes, the max is %d", maxVaultPushNotes))
+	}
+
+	v, err := s.loadVault(ctx, user)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load vault").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &ObsidianPushResponse{Results: s.pushNotes(ctx, v, request.Notes)})
+}
+
+// vaultNotePush is a pushed note, with the memo it's saved to.
+type vaultNotePush struct {
+	note *ObsidianNote
+	path string
+	// file and memo are nil for the new notes.
+	file   *store.VaultFile
+	memo   *store.Memo
+	uid    string
+	result *ObsidianPushResult
+	// saved is the memo created or updated by the push, and oldContent is its content before the push.
+	saved      *store.Memo
+	created    bool
+	oldContent string
+}
+
+func (s *ObsidianSyncService) pushNotes(ctx context.Context, v *vault, notes []*ObsidianNote) []*ObsidianPushResult {
+	// The uids of the new memos are generated before saving any note, so the notes link to the new notes in the same request.
+	links := v.links()
+	pushes := []*vaultNotePush{}
+	pushedPaths := map[string]bool{}
+	for _, note := range notes {
+		push := &vaultNotePush{note: note, result: &ObsidianPushResult{Path: note.Path}}
+		pushes = append(pushes, push)
+		notePath, ok := cleanVaultPath(note.Path)
+		if !ok || !strings.HasSuffix(notePath, vaultNoteExtension) {
+			push.result.Status, push.result.Error = ObsidianPushError, "invalid path"
+			continue
+		}
+		if pushedPaths[strings.ToLower(notePath)] {
+			push.result.Status, push.result.Error = ObsidianPushError, "duplicate path"
+			continue
+		}
+		pushedPaths[strings.ToLower(notePath)] = true
+		push.path, push.result.Path = notePath, notePath
+		push.file = v.findLiveFile(notePath)
+		if oldPath, ok := cleanVaultPath(note.OldPath); ok && push.file == nil {
+			push.file = v.findLiveFile(oldPath)
+		}
+		if push.file != nil && push.file.MemoID != 0 {
+			push.memo = v.memos[push.file.MemoID]
+		} else if push.file != nil {
+			push.result.Status, push.result.Error = ObsidianPushError, "path of an attachment"
+			continue
+		}
+		if push.memo != nil {
+			push.uid = push.memo.UID
+		} else {
+			push.uid = shortuuid.New()
+		}
+		if !note.Deleted {
+			links.notePaths[push.uid] = notePath
+		}
+	}
+
+	results := []*ObsidianPushResult{}
+	for _, push := range pushes {
+		if push.result.Status == "" {
+			if err := s.pushNote(ctx, v, push, links); err != nil {
+				slog.Warn("Failed to push obsidian note", slog.String("path", push.path), slog.Any("err", err))
+				push.result.Status, push.result.Error = ObsidianPushError, err.Error()
+			}
+		}
+		results = append(results, push.result)
+	}
+	// The links are saved after all the memos are saved, so the references of the new memos are found.
+	for _, push := range pushes {
+		if push.saved == nil {
+			continue
+		}
+		s.saveMemoLinks(ctx, v, push.saved, push.oldContent)
+		if push.created {
+			notifyMemoCreated(ctx, s.store, s.eventBroker, push.saved)
+		} else {
+			notifyMemoUpdated(ctx, s.store, s.eventBroker, push.saved)
+		}
+	}
+	return results
+}
+
+func (s *ObsidianSyncService) pushNote(ctx context.Context, v *vault, push *vaultNotePush, links *vaultLinks) error {
+	now := time.Now().Unix()
+	note, memo, result := push.note, push.memo, push.result
+	if memo != nil {
+		result.MemoID, result.UpdatedTs = memo.ID, memo.UpdatedTs
+	}
+	if note.Deleted {
+		if memo == nil {
+			result.Status = ObsidianPushOK
+			return nil
+		}
+		if memo.UpdatedTs > note.BaseUpdatedTs {
+			result.Status = ObsidianPushConflict
+			return nil
+		}
+		archived := store.Archived
+		if err := s.store.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, RowStatus: &archived, UpdatedTs: &now}); err != nil {
+			return errors.Wrap(err, "failed to archive memo")
+		}
+		deleted := true
+		if err := s.store.UpdateVaultFile(ctx, &store.UpdateVaultFile{ID: push.file.ID, Deleted: &deleted, UpdatedTs: &now}); err != nil {
+			return errors.Wrap(err, "failed to update vault file")
+		}
+		memo.RowStatus, memo.UpdatedTs = archived, now
+		notifyMemoUpdated(ctx, s.store, s.eventBroker, memo)
+		result.Status, result.UpdatedTs = ObsidianPushOK, now
+		return nil
+	}
+
+	content := convertVaultContentToMemo(note.Content, links)
+	if len(content) > maxVaultNoteLength {
+		return errors.New("note too large")
+	}
+	if v.isPathTaken(push.path, push.file) {
+		return errors.New("path is taken by another file")
+	}
+	if memo == nil {
+		if strings.TrimSpace(content) == "" {
+			result.Status = ObsidianPushSkipped
+			return nil
+		}
+		return s.createNoteMemo(ctx, v, push, content, now)
+	}
+
+	if content != memo.Content && memo.UpdatedTs > note.BaseUpdatedTs {
+		result.Status = ObsidianPushConflict
+		return nil
+	}
+	if push.file.Path != push.path {
+		if err := v.movePath(ctx, s.store, push.file, push.path, now); err != nil {
+			return err
+		}
+	}
+	if content != memo.Content {
+		if err := s.store.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, UpdatedTs: &now}); err != nil {
+			return errors.Wrap(err, "failed to update memo")
+		}
+		push.saved, push.oldContent = memo, memo.Content
+		memo.Content, memo.UpdatedTs = content, now
+	}
+	result.Status, result.UpdatedTs = ObsidianPushOK, memo.UpdatedTs
+	return nil
+}
+
+func (s *ObsidianSyncService) createNoteMemo(ctx context.Context, v *vault, push *vaultNotePush, content string, now int64) error {
+	visibility, err := getDefaultMemoVisibility(ctx, s.store, v.user.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get default memo visibility")
+	}
+	memo, err := s.store.CreateMemo(ctx, &store.Memo{
+		UID:        push.uid,
+		CreatorID:  v.user.ID,
+		Content:    content,
+		Visibility: visibility,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create memo")
+	}
+	if createdTs := push.note.CreatedTs; createdTs > 0 && createdTs < memo.CreatedTs {
+		if err := s.store.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs}); err != nil {
+			return errors.Wrap(err, "failed to update memo created time")
+		}
+		memo.CreatedTs = createdTs
+	}
+	v.memos[memo.ID] = memo
+	push.saved, push.created = memo, true
+
+	if push.file != nil {
+		// The file of an archived memo is linked to the new memo.
+		if err := s.store.UpdateVaultFile(ctx, &store.UpdateVaultFile{ID: push.file.ID, MemoID: &memo.ID, UpdatedTs: &now}); err != nil {
+			return errors.Wrap(err, "failed to update vault file")
+		}
+		push.file.MemoID = memo.ID
+		if push.file.Path != push.path {
+			if err := v.movePath(ctx, s.store, push.file, push.path, now); err != nil {
+				return err
+			}
+		}
+	} else if err := v.createFile(ctx, s.store, &store.VaultFile{Path: push.path, MemoID: memo.ID}, now); err != nil {
+		return err
+	}
+	push.result.Status, push.result.MemoID, push.result.UpdatedTs = ObsidianPushOK, memo.ID, memo.UpdatedTs
+	return nil
+}
+
+// saveMemoLinks saves the tags of the memo, the relations of its references of memos, and the resources it embeds.
+// The relations of the references removed from the old content are deleted, the other relations are kept.
+func (s *ObsidianSyncService) saveMemoLinks(ctx context.Context, v *vault, memo *store.Memo, oldContent string) {
+	if err := upsertContentTags(ctx, s.store, memo.CreatorID, memo.Content); err != nil {
+		slog.Warn("Failed to upsert tags", slog.Any("err", err))
+	}
+	memoUIDs, resourceUIDs := getMemoContentReferences(memo.Content)
+	oldMemoUIDs, _ := getMemoContentReferences(oldContent)
+	referenceType := store.MemoRelationReference
+	for _, uid := range oldMemoUIDs {
+		relatedMemo := v.findMemo(uid)
+		if slices.Contains(memoUIDs, uid) || relatedMemo == nil {
+			continue
+		}
+		if err := s.store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{MemoID: &memo.ID, RelatedMemoID: &relatedMemo.ID, Type: &referenceType}); err != nil {
+			slog.Warn("Failed to delete memo relation", slog.Any("err", err))
+		}
+	}
+	relations, err := s.store.ListMemoRelations(ctx, &store.FindMemoRelation{MemoID: &memo.ID, Type: &referenceType})
+	if err != nil {
+		slog.Warn("Failed to list memo relations", slog.Any("err", err))
+		return
+	}
+	for _, uid := range memoUIDs {
+		relatedMemo := v.findMemo(uid)
+		if relatedMemo == nil || relatedMemo.ID == memo.ID || slices.ContainsFunc(relations, func(relation *store.MemoRelation) bool {
+			return relation.RelatedMemoID == relatedMemo.ID
+		}) {
+			continue
+		}
+		relation, err := s.store.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: memo.ID, RelatedMemoID: relatedMemo.ID, Type: referenceType})
+		if err != nil {
+			slog.Warn("Failed to upsert memo relation", slog.Any("err", err))
+			continue
+		}
+		relations = append(relations, relation)
+	}
+	for _, uid := range resourceUIDs {
+		resource := v.findResource(uid)
+		// A resource belongs to one memo, the attachments embedded in several notes stay with the first one.
+		if resource == nil || resource.MemoID != nil {
+			continue
+		}
+		if _, err := s.store.UpdateResource(ctx, &store.UpdateResource{ID: resource.ID, MemoID: &memo.ID}); err != nil {
+			slog.Warn("Failed to update resource", slog.Any("err", err))
+			continue
+		}
+		resource.MemoID = &memo.ID
+	}
+}
+
+// GetAttachment serves the content of the attachment of the path parameter.
+func (s *ObsidianSyncService) GetAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+	v, file, err := s.findAttachment(c)
+	if err != nil {
+		return err
+	}
+	resource := v.resources[file.ResourceID]
+	if resource == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Attachment not found")
+	}
+	if resource.ExternalLink != "" {
+		return c.Redirect(http.StatusFound, resource.ExternalLink)
+	}
+	blob, err := apiv1.GetResourceBlob(ctx, s.store, resource)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get attachment").SetInternal(err)
+	}
+	return c.Blob(http.StatusOK, resource.Type, blob)
+}
+
+// PutAttachment saves the body as the attachment of the path parameter.
+// An existing attachment is replaced by a resource with the same uid, so the memos embedding it keep showing it.
+func (s *ObsidianSyncService) PutAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticate(c)
+	if err != nil {
+		return err
+	}
+	attachmentPath, ok := cleanVaultPath(c.QueryParam("path"))
+	if !ok || strings.HasSuffix(attachmentPath, vaultNoteExtension) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid path")
+	}
+	v, err := s.loadVault(ctx, user)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load vault").SetInternal(err)
+	}
+	file := v.findLiveFile(attachmentPath)
+	if file != nil && file.MemoID != 0 {
+		return echo.NewHTTPError(http.StatusConflict, "Path of a note")
+	}
+	if v.isPathTaken(attachmentPath, file) {
+		return echo.NewHTTPError(http.StatusConflict, "Path is taken by another file")
+	}
+
+	uploadLimit, err := apiv1.GetUploadLimit(ctx, s.store, user)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, uploadLimit.MaxSizeBytes+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read attachment").SetInternal(err)
+	}
+	mimeType := mime.TypeByExtension(path.Ext(attachmentPath))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	if int64(len(data)) > uploadLimit.MaxSizeBytes || !uploadLimit.IsTypeAllowed(mimeType) {
+		return echo.NewHTTPError(http.StatusBadRequest, "File is not allowed to upload")
+	}
+	result, err := apiv1.ScanResourceBlob(ctx, s.store, bytes.NewReader(data))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to scan attachment").SetInternal(err)
+	}
+	if result != nil && result.Infected {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File is infected: %s", result.Signature))
+	}
+
+	create := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+		Filename:  path.Base(attachmentPath),
+		Type:      mimeType,
+		Size:      int64(len(data)),
+	}
+	now := time.Now().Unix()
+	if file != nil {
+		if resource := v.resources[file.ResourceID]; resource != nil {
+			create.UID, create.MemoID = resource.UID, resource.MemoID
+			if err := s.deleteResource(ctx, resource); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to replace attachment").SetInternal(err)
+			}
+		}
+	}
+	if err := apiv1.SaveResourceBlob(ctx, s.store, create, bytes.NewReader(data)); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save attachment").SetInternal(err)
+	}
+	resource, err := s.store.CreateResource(ctx, create)
+	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Resource quota exceeded").SetInternal(err)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	if file != nil {
+		if err := s.store.UpdateVaultFile(ctx, &store.UpdateVaultFile{ID: file.ID, ResourceID: &resource.ID, UpdatedTs: &now}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update vault file").SetInternal(err)
+		}
+	} else if err := v.createFile(ctx, s.store, &store.VaultFile{Path: attachmentPath, ResourceID: resource.ID}, now); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create vault file").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &ObsidianFile{
+		Path:       attachmentPath,
+		Type:       ObsidianFileAttachment,
+		ResourceID: resource.ID,
+		CreatedTs:  resource.CreatedTs,
+		UpdatedTs:  max(resource.UpdatedTs, now),
+		Size:       resource.Size,
+	})
+}
+
+// DeleteAttachment deletes the attachment of the path parameter and its resource.
+func (s *ObsidianSyncService) DeleteAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+	v, file, err := s.findAttachment(c)
+	if err != nil {
+		return err
+	}
+	if resource := v.resources[file.ResourceID]; resource != nil {
+		if err := s.deleteResource(ctx, resource); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete attachment").SetInternal(err)
+		}
+	}
+	now, deleted := time.Now().Unix(), true
+	if err := s.store.UpdateVaultFile(ctx, &store.UpdateVaultFile{ID: file.ID, Deleted: &deleted, UpdatedTs: &now}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update vault file").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, true)
+}
+
+func (s *ObsidianSyncService) findAttachment(c echo.Context) (*vault, *store.VaultFile, error) {
+	user, err := s.authenticate(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	attachmentPath, ok := cleanVaultPath(c.QueryParam("path"))
+	if !ok {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid path")
+	}
+	v, err := s.loadVault(c.Request().Context(), user)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load vault").SetInternal(err)
+	}
+	file := v.findLiveFile(attachmentPath)
+	if file == nil || file.ResourceID == 0 {
+		return nil, nil, echo.NewHTTPError(http.StatusNotFound, "Attachment not found")
+	}
+	return v, file, nil
+}
+
+func (s *ObsidianSyncService) deleteResource(ctx context.Context, resource *store.Resource) error {
+	if err := apiv1.DeleteResourceBlob(ctx, s.store, resource); err != nil {
+		slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+	}
+	return s.store.DeleteResource(ctx, &store.DeleteResource{ID: resource.ID})
+}
+
+// vault is the synced folder of the vault of the user.
+type vault struct {
+	user  *store.User
+	files []*store.VaultFile
+	// memos are the normal memos of the user except the comments, which are the notes.
+	memos     map[int32]*store.Memo
+	resources map[int32]*store.Resource
+}
+
+func (s *ObsidianSyncService) loadVault(ctx context.Context, user *store.User) (*vault, error) {
+	files, err := s.store.ListVaultFiles(ctx, &store.FindVaultFile{CreatorID: &user.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list vault files")
+	}
+	normalStatus := store.Normal
+	memos, err := s.store.ListMemos(ctx, &store.FindMemo{
+		CreatorID:       &user.ID,
+		RowStatus:       &normalStatus,
+		ExcludeComments: true,
+		OrderBy:         store.MemoOrderByCreatedTs,
+		OrderAsc:        true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memos")
+	}
+	resources, err := s.store.ListResources(ctx, &store.FindResource{CreatorID: &user.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list resources")
+	}
+	v := &vault{
+		user:      user,
+		files:     files,
+		memos:     map[int32]*store.Memo{},
+		resources: map[int32]*store.Resource{},
+	}
+	for _, memo := range memos {
+		v.memos[memo.ID] = memo
+	}
+	for _, resource := range resources {
+		v.resources[resource.ID] = resource
+	}
+	return v, nil
+}
+
+// syncVault marks the files of the archived and deleted memos and resources as deleted, and the restored ones as not deleted.
+// The memos created in memos and their resources are added as new files.
+func (s *ObsidianSyncService) syncVault(ctx context.Context, v *vault, now int64) error {
+	linkedMemos, linkedResources := map[int32]bool{}, map[int32]bool{}
+	for _, file := range v.files {
+		exists := v.memos[file.MemoID] != nil
+		if file.MemoID == 0 {
+			exists = v.resources[file.ResourceID] != nil
+		}
+		if exists == file.Deleted {
+			deleted := !exists
+			if err := s.store.UpdateVaultFile(ctx, &store.UpdateVaultFile{ID: file.ID, Deleted: &deleted, UpdatedTs: &now}); err != nil {
+				return err
+			}
+			file.Deleted, file.UpdatedTs = deleted, now
+		}
+		if !file.Deleted {
+			linkedMemos[file.MemoID], linkedResources[file.ResourceID] = true, true
+		}
+	}
+
+	memos := []*store.Memo{}
+	for _, memo := range v.memos {
+		memos = append(memos, memo)
+	}
+	slices.SortFunc(memos, func(a, b *store.Memo) int { return int(a.ID - b.ID) })
+	for _, memo := range memos {
+		if linkedMemos[memo.ID] {
+			continue
+		}
+		if err := v.createFile(ctx, s.store, &store.VaultFile{Path: v.getNewNotePath(memo), MemoID: memo.ID}, now); err != nil {
+			return err
+		}
+	}
+	resources := []*store.Resource{}
+	for _, resource := range v.resources {
+		resources = append(resources, resource)
+	}
+	slices.SortFunc(resources, func(a, b *store.Resource) int { return int(a.ID - b.ID) })
+	for _, resource := range resources {
+		if linkedResources[resource.ID] || resource.MemoID == nil || v.memos[*resource.MemoID] == nil {
+			continue
+		}
+		if err := v.createFile(ctx, s.store, &store.VaultFile{Path: v.getNewAttachmentPath(resource), ResourceID: resource.ID}, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getNewNotePath returns the path of a memo created in memos, which is named after its first line.
+func (v *vault) getNewNotePath(memo *store.Memo) string {
+	title := strings.TrimSpace(getNoteTitle(memo.Content))
+	title = strings.Trim(invalidFilenameCharactersRegexp.ReplaceAllString(title, " "), " .")
+	title = strings.Join(strings.Fields(title), " ")
+	if utf8.RuneCountInString(title) > maxVaultNoteTitleLength {
+		title = strings.TrimSpace(string([]rune(title)[:maxVaultNoteTitleLength]))
+	}
+	if title == "" {
+		title = memo.UID
+	}
+	notePath := title + vaultNoteExtension
+	if v.isPathTaken(notePath, nil) {
+		notePath = title + " " + memo.UID + vaultNoteExtension
+	}
+	return notePath
+}
+
+func (v *vault) getNewAttachmentPath(resource *store.Resource) string {
+	filename := strings.Trim(invalidFilenameCharactersRegexp.ReplaceAllString(resource.Filename, " "), " ")
+	if filename == "" || filename == "." || filename == ".." {
+		filename = resource.UID
+	}
+	attachmentPath := vaultAttachmentDirectory + "/" + filename
+	if v.isPathTaken(attachmentPath, nil) || strings.HasSuffix(attachmentPath, vaultNoteExtension) {
+		attachmentPath = vaultAttachmentDirectory + "/" + resource.UID + "-" + filename
+	}
+	return attachmentPath
+}
+
+// getNoteTitle returns the first line of the content without the Markdown of a heading.
+func getNoteTitle(content string) string {
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	return strings.TrimLeft(firstLine, "# ")
+}
+
+func (v *vault) findLiveFile(filePath string) *store.VaultFile {
+	for _, file := range v.files {
+		if file.Path == filePath && !file.Deleted {
+			return file
+		}
+	}
+	return nil
+}
+
+// isPathTaken returns true if another file has the path, which is compared case-insensitively as on macOS and Windows.
+func (v *vault) isPathTaken(filePath string, except *store.VaultFile) bool {
+	for _, file := range v.files {
+		if file != except && !file.Deleted && strings.EqualFold(file.Path, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// createFile creates the file, a deleted file of the path is replaced.
+func (v *vault) createFile(ctx context.Context, s *store.Store, create *store.VaultFile, now int64) error {
+	if err := v.removeDeletedFile(ctx, s, create.Path); err != nil {
+		return err
+	}
+	create.CreatorID, create.UpdatedTs = v.user.ID, now
+	file, err := s.CreateVaultFile(ctx, create)
+	if err != nil {
+		return errors.Wrap(err, "failed to create vault file")
+	}
+	v.files = append(v.files, file)
+	return nil
+}
+
+// movePath renames the file, a deleted file of the path is replaced.
+func (v *vault) movePath(ctx context.Context, s *store.Store, file *store.VaultFile, filePath string, now int64) error {
+	if err := v.removeDeletedFile(ctx, s, filePath); err != nil {
+		return err
+	}
+	if err := s.UpdateVaultFile(ctx, &store.UpdateVaultFile{ID: file.ID, Path: &filePath, UpdatedTs: &now}); err != nil {
+		return errors.Wrap(err, "failed to rename vault file")
+	}
+	file.Path, file.UpdatedTs = filePath, now
+	return nil
+}
+
+func (v *vault) removeDeletedFile(ctx context.Context, s *store.Store, filePath string) error {
+	for i, file := range v.files {
+		if file.Path == filePath && file.Deleted {
+			if err := s.DeleteVaultFile(ctx, &store.DeleteVaultFile{ID: file.ID}); err != nil {
+				return errors.Wrap(err, "failed to delete vault file")
+			}
+			v.files = slices.Delete(v.files, i, i+1)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (v *vault) findMemo(uid string) *store.Memo {
+	for _, memo := range v.memos {
+		if memo.UID == uid {
+			return memo
+		}
+	}
+	return nil
+}
+
+func (v *vault) findResource(uid string) *store.Resource {
+	for _, resource := range v.resources {
+		if resource.UID == uid {
+			return resource
+		}
+	}
+	return nil
+}
+
+func (v *vault) links() *vaultLinks {
+	links := &vaultLinks{notePaths: map[string]string{}, attachmentPaths: map[string]string{}}
+	for _, file := range v.files {
+		if file.Deleted {
+			continue
+		}
+		if memo := v.memos[file.MemoID]; memo != nil {
+			links.notePaths[memo.UID] = file.Path
+		} else if resource := v.resources[file.ResourceID]; resource != nil {
+			links.attachmentPaths[resource.UID] = file.Path
+		}
+	}
+	return links
+}
+
+// vaultLinks converts the wikilinks of the notes to the references of the memos and the resources, and the other way around.
+type vaultLinks struct {
+	// notePaths and attachmentPaths map the uids of the memos and the resources to the paths of their files.
+	notePaths       map[string]string
+	attachmentPaths map[string]string
+}
+
+// resolveWikilink returns the uid of the file the target links to, as Obsidian resolves the links:
+// the target is the path of the file, or the end of the path, and the shortest path wins.
+func resolveWikilink(target string, paths map[string]string) string {
+	target = strings.TrimPrefix(path.Clean("/"+strings.TrimSpace(target)), "/")
+	uid, uidPath := "", ""
+	for candidateUID, candidatePath := range paths {
+		lowerPath, lowerTarget := strings.ToLower(candidatePath), strings.ToLower(target)
+		if lowerPath != lowerTarget && !strings.HasSuffix(lowerPath, "/"+lowerTarget) {
+			continue
+		}
+		if uid == "" || len(candidatePath) < len(uidPath) || (len(candidatePath) == len(uidPath) && candidatePath < uidPath) {
+			uid, uidPath = candidateUID, candidatePath
+		}
+	}
+	return uid
+}
+
+// getWikilinkText returns the shortest text linking to the path, which is the name of the file if no other file has it.
+func getWikilinkText(filePath string, paths map[string]string, trimExtension bool) string {
+	text := filePath
+	if trimExtension {
+		text = strings.TrimSuffix(filePath, vaultNoteExtension)
+	}
+	name := path.Base(text)
+	for _, otherPath := range paths {
+		if otherPath != filePath && strings.EqualFold(path.Base(otherPath), path.Base(filePath)) {
+			return text
+		}
+	}
+	return name
+}
+
+// convertVaultContentToMemo converts the wikilinks of the notes and the attachments to the references of the memos and the resources.
+// The links to the headings and the unknown files are kept as they are.
+func convertVaultContentToMemo(content string, links *vaultLinks) string {
+	return replaceLinks(wikilinkRegexp, content, func(groups []string) string {
+		embed, target, heading, alias := groups[1] == "!", groups[2], groups[3], groups[4]
+		if heading != "" {
+			return groups[0]
+		}
+		if embed {
+			if uid := resolveWikilink(target, links.attachmentPaths); uid != "" {
+				return "![[resources/" + uid + "]]"
+			}
+		}
+		noteTarget := target
+		if !strings.HasSuffix(strings.ToLower(noteTarget), vaultNoteExtension) {
+			noteTarget += vaultNoteExtension
+		}
+		uid := resolveWikilink(noteTarget, links.notePaths)
+		if uid == "" {
+			return groups[0]
+		}
+		if embed {
+			return "![[memos/" + uid + "]]"
+		}
+		if alias = strings.TrimSpace(alias); alias != "" {
+			return "[[memos/" + uid + "?" + url.Values{"text": {alias}}.Encode() + "]]"
+		}
+		return "[[memos/" + uid + "]]"
+	})
+}
+
+// convertMemoContentToVault converts the references of the memos and the resources of the vault to wikilinks.
+func convertMemoContentToVault(content string, links *vaultLinks) string {
+	return replaceLinks(memoLinkRegexp, content, func(groups []string) string {
+		embed, resourceType, uid, params := groups[1], groups[2], groups[3], groups[4]
+		if resourceType == "resources" {
+			attachmentPath, ok := links.attachmentPaths[uid]
+			if !ok || embed == "" {
+				return groups[0]
+			}
+			return "![[" + getWikilinkText(attachmentPath, links.attachmentPaths, false) + "]]"
+		}
+		notePath, ok := links.notePaths[uid]
+		if !ok {
+			return groups[0]
+		}
+		text := getWikilinkText(notePath, links.notePaths, true)
+		if values, err := url.ParseQuery(params); err == nil && values.Get("text") != "" && embed == "" {
+			text += "|" + values.Get("text")
+		}
+		return embed + "[[" + text + "]]"
+	})
+}
+
+// replaceLinks replaces the links matched by the regexp, the code matched without the link groups is kept.
+func replaceLinks(re *regexp.Regexp, content string, replace func(groups []string) string) string {
+	return re.ReplaceAllStringFunc(content, func(match string) string {
+		groups := re.FindStringSubmatch(match)
+		if groups == nil || groups[2] == "" {
+			return match
+		}
+		return replace(groups)
+	})
+}
+
+// getMemoContentReferences returns the uids of the memos and the resources referenced in the content.
+func getMemoContentReferences(content string) ([]string, []string) {
+	memoUIDs, resourceUIDs := []string{}, []string{}
+	replaceLinks(memoLinkRegexp, content, func(groups []string) string {
+		if groups[2] == "memos" {
+			memoUIDs = append(memoUIDs, groups[3])
+		} else {
+			resourceUIDs = append(resourceUIDs, groups[3])
+		}
+		return groups[0]
+	})
+	return memoUIDs, resourceUIDs
+}
+
+// cleanVaultPath returns the clean relative path of the file, false if it's not a valid path in the vault.
+func cleanVaultPath(filePath string) (string, bool) {
+	if filePath == "" || strings.Contains(filePath, "\\") || len(filePath) > maxVaultPathLength || !utf8.ValidString(filePath) {
+		return "", false
+	}
+	cleanPath := path.Clean(strings.TrimPrefix(filePath, "/"))
+	if cleanPath == "." || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") || strings.HasPrefix(cleanPath, "/") {
+		return "", false
+	}
+	for _, part := range strings.Split(cleanPath, "/") {
+		if strings.HasPrefix(part, ".") {
+			// The hidden files, e.g. the config of Obsidian in `.obsidian/`, aren't synced.
+			return "", false
+		}
+	}
+	return cleanPath, true
+}
@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertVaultContent(t *testing.T) {
+	links := &vaultLinks{
+		notePaths: map[string]string{
+			"note-a": "Projects/Plan.md",
+			"note-b": "Journal/Plan.md",
+			"note-c": "Ideas.md",
+		},
+		attachmentPaths: map[string]string{
+			"image": "attachments/photo.png",
+		},
+	}
+	tests := []struct {
+		vault string
+		memo  string
+	}{
+		{
+			vault: "See [[Ideas]] and [[Projects/Plan|the plan]].",
+			memo:  "See [[memos/note-c]] and [[memos/note-a?text=the+plan]].",
+		},
+		{
+			vault: "![[photo.png]]\n![[Ideas]]",
+			memo:  "![[resources/image]]\n![[memos/note-c]]",
+		},
+		{
+			// The links to the headings, the unknown notes and the links in the code are kept.
+			vault: "[[Ideas#Later]] [[Unknown]] `[[Ideas]]`\n```\n[[Ideas]]\n```",
+			memo:  "[[Ideas#Later]] [[Unknown]] `[[Ideas]]`\n```\n[[Ideas]]\n```",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.memo, convertVaultContentToMemo(test.vault, links))
+		require.Equal(t, test.vault, convertMemoContentToVault(test.memo, links))
+	}
+
+	// The shortest path wins when the names of the notes are the same.
+	require.Equal(t, "note-b", resolveWikilink("Plan.md", map[string]string{"note-a": "Projects/Plan.md", "note-b": "Journal/Plan.md"}))
+	memoUIDs, resourceUIDs := getMemoContentReferences("[[memos/note-a]] ![[resources/image]] `[[memos/note-b]]`")
+	require.Equal(t, []string{"note-a"}, memoUIDs)
+	require.Equal(t, []string{"image"}, resourceUIDs)
+}
+
+func TestCleanVaultPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		clean string
+		ok    bool
+	}{
+		{path: "Notes/Plan.md", clean: "Notes/Plan.md", ok: true},
+		{path: "/Notes/./Plan.md", clean: "Notes/Plan.md", ok: true},
+		{path: "../Plan.md", ok: false},
+		{path: ".obsidian/app.json", ok: false},
+		{path: "Notes\\Plan.md", ok: false},
+		{path: "", ok: false},
+	}
+	for _, test := range tests {
+		clean, ok := cleanVaultPath(test.path)
+		require.Equal(t, test.ok, ok, test.path)
+		require.Equal(t, test.clean, clean, test.path)
+	}
+}
@@ -0,0 +1,261 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/slack"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	jobqueue "github.com/usememos/memos/server/service/job_queue"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxSlackRequestSize is the max size of the requests sent by Slack.
+	maxSlackRequestSize = 1 << 20
+	// slackUnfurlJobKind is the kind of the jobs unfurling the links shared in Slack, whose payloads are the events.
+	slackUnfurlJobKind = "slack.unfurl"
+	// maxSlackUnfurlAttempts is the number of attempts of an unfurl, which is useless once the message is old.
+	maxSlackUnfurlAttempts = 3
+)
+
+// SlackService serves the slash command and the events of the Slack app,
+// and posts the public memos to the channel of the workspace setting.
+type SlackService struct {
+	store       *store.Store
+	eventBroker *event.Broker
+}
+
+func NewSlackService(store *store.Store, eventBroker *event.Broker) *SlackService {
+	return &SlackService{store: store, eventBroker: eventBroker}
+}
+
+func (s *SlackService) RegisterRoutes(g *echo.Group) {
+	g.POST("/o/slack/command", s.handleCommand)
+	g.POST("/o/slack/events", s.handleEvents)
+}
+
+// readVerifiedRequest reads the body of the request and verifies it's signed by the Slack app of the setting.
+func (s *SlackService) readVerifiedRequest(c echo.Context) ([]byte, *storepb.SlackSetting, error) {
+	ctx := c.Request().Context()
+	integrationSetting, err := s.store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find workspace setting").SetInternal(err)
+	}
+	slackSetting := integrationSetting.GetSlack()
+	if slackSetting.GetSigningSecret() == "" {
+		return nil, nil, echo.NewHTTPError(http.StatusNotFound, "Slack integration is not enabled")
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxSlackRequestSize))
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body").SetInternal(err)
+	}
+	header := c.Request().Header
+	if err := slack.VerifyRequest(slackSetting.SigningSecret, header.Get(slack.TimestampHeader), header.Get(slack.SignatureHeader), body, time.Now()); err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid Slack signature").SetInternal(err)
+	}
+	return body, slackSetting, nil
+}
+
+func (s *SlackService) handleCommand(c echo.Context) error {
+	ctx := c.Request().Context()
+	body, _, err := s.readVerifiedRequest(c)
+	if err != nil {
+		return err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted slash command").SetInternal(err)
+	}
+	command := slack.ParseSlashCommand(form)
+	reply := func(text string) error {
+		return c.JSON(http.StatusOK, &slack.CommandResponse{ResponseType: "ephemeral", Text: text})
+	}
+
+	content := strings.TrimSpace(command.Text)
+	if content == "" {
+		return reply(fmt.Sprintf("Usage: %s <content of the memo>", command.Command))
+	}
+	creatorID, err := findLinkedUserID(ctx, s.store, storepb.UserSettingKey_USER_SETTING_SLACK_USER_ID, command.UserID, (*storepb.UserSetting).GetSlackUserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user settings").SetInternal(err)
+	}
+	if creatorID == 0 {
+		return reply(fmt.Sprintf("Please set your slack user id %s in the settings of memos", command.UserID))
+	}
+	visibility, err := getDefaultMemoVisibility(ctx, s.store, creatorID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user setting").SetInternal(err)
+	}
+
+	memo, err := s.store.CreateMemo(ctx, &store.Memo{
+		UID:        shortuuid.New(),
+		CreatorID:  creatorID,
+		Content:    content,
+		Visibility: visibility,
+	})
+	if err != nil {
+		return reply(fmt.Sprintf("Failed to create memo: %s", err))
+	}
+	if err := upsertContentTags(ctx, s.store, creatorID, memo.Content); err != nil {
+		slog.Warn("Failed to upsert tags", slog.Any("err", err))
+	}
+	notifyMemoCreated(ctx, s.store, s.eventBroker, memo)
+	return reply(fmt.Sprintf("Saved as %s Memo %d", memo.Visibility, memo.ID))
+}
+
+func (s *SlackService) handleEvents(c echo.Context) error {
+	body, slackSetting, err := s.readVerifiedRequest(c)
+	if err != nil {
+		return err
+	}
+	request := &slack.EventRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted event request").SetInternal(err)
+	}
+
+	switch request.Type {
+	case slack.EventTypeURLVerification:
+		return c.JSON(http.StatusOK, map[string]string{"challenge": request.Challenge})
+	case slack.EventTypeEventCallback:
+		if request.Event != nil && request.Event.Type == slack.EventTypeLinkShared && slackSetting.UnfurlLinks {
+			// Slack expects the events to be acknowledged in 3 seconds, so the links are unfurled afterwards.
+			if _, err := jobqueue.Enqueue(c.Request().Context(), s.store, slackUnfurlJobKind, request.Event, jobqueue.WithMaxAttempts(maxSlackUnfurlAttempts)); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to queue link unfurls").SetInternal(err)
+			}
+		}
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// RegisterJobs registers the handler of the jobs unfurling the shared links.
+func (s *SlackService) RegisterJobs(queue *jobqueue.Queue) {
+	queue.Register(slackUnfurlJobKind, func(ctx context.Context, payload []byte) error {
+		slackEvent := &slack.Event{}
+		if err := json.Unmarshal(payload, slackEvent); err != nil {
+			return jobqueue.Permanent(err)
+		}
+		integrationSetting, err := s.store.GetWorkspaceIntegrationSetting(ctx)
+		if err != nil {
+			return err
+		}
+		// The links aren't unfurled if the app is disabled meanwhile.
+		slackSetting := integrationSetting.GetSlack()
+		if slackSetting.GetBotToken() == "" || !slackSetting.GetUnfurlLinks() {
+			return nil
+		}
+		return s.unfurlLinks(ctx, slackSetting, slackEvent)
+	})
+}
+
+// unfurlLinks unfurls the links to the public memos, the links to other memos are left as is.
+func (s *SlackService) unfurlLinks(ctx context.Context, slackSetting *storepb.SlackSetting, slackEvent *slack.Event) error {
+	unfurls := map[string]*slack.Attachment{}
+	for _, link := range slackEvent.Links {
+		attachment, err := s.getMemoAttachment(ctx, link.URL)
+		if err != nil {
+			return err
+		}
+		if attachment != nil {
+			unfurls[link.URL] = attachment
+		}
+	}
+	if len(unfurls) == 0 {
+		return nil
+	}
+	return slack.NewClient(slackSetting.BotToken).Unfurl(ctx, slackEvent.Channel, slackEvent.MessageTs, unfurls)
+}
+
+func (s *SlackService) getMemoAttachment(ctx context.Context, link string) (*slack.Attachment, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, nil
+	}
+	uid, ok := strings.CutPrefix(u.Path, "/m/")
+	if !ok || uid == "" || strings.Contains(uid, "/") {
+		return nil, nil
+	}
+	normalStatus := store.Normal
+	memo, err := s.store.GetMemo(ctx, &store.FindMemo{
+		UID:            &uid,
+		RowStatus:      &normalStatus,
+		VisibilityList: []store.Visibility{store.Public},
+		ExcludeHidden:  true,
+	})
+	if err != nil || memo == nil {
+		return nil, err
+	}
+	creator, err := s.store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil || creator == nil {
+		return nil, err
+	}
+	return &slack.Attachment{
+		Title:     fmt.Sprintf("%s (@%s) on Memos", creator.Nickname, creator.Username),
+		TitleLink: link,
+		Text:      getMemoSnippet(memo.Content),
+		Ts:        memo.CreatedTs,
+	}, nil
+}
+
+// Start posts the created public memos to the channel of the setting, until the context is done.
+func (s *SlackService) Start(ctx context.Context) {
+	subscription := s.eventBroker.Subscribe()
+	defer s.eventBroker.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type != event.MemoCreated || e.Visibility != store.Public {
+				continue
+			}
+			if err := s.postMemo(ctx, e.MemoID); err != nil {
+				slog.Warn("Failed to post memo to slack", slog.Int("memo", int(e.MemoID)), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (s *SlackService) postMemo(ctx context.Context, memoID int32) error {
+	integrationSetting, err := s.store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return err
+	}
+	slackSetting := integrationSetting.GetSlack()
+	if slackSetting.GetPublicMemoChannel() == "" || slackSetting.GetBotToken() == "" {
+		return nil
+	}
+	memo, err := s.store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.Visibility != store.Public || memo.ParentID != nil {
+		return err
+	}
+	creator, err := s.store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil || creator == nil {
+		return err
+	}
+
+	title := fmt.Sprintf("*%s* (@%s) posted a memo", creator.Nickname, creator.Username)
+	workspaceGeneralSetting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if instanceURL := strings.TrimSuffix(workspaceGeneralSetting.GetInstanceUrl(), "/"); instanceURL != "" {
+		title = fmt.Sprintf("<%s/m/%s|%s>", instanceURL, memo.UID, title)
+	}
+	return slack.NewClient(slackSetting.BotToken).PostMessage(ctx, slackSetting.PublicMemoChannel, title+"\n\n"+getMemoSnippet(memo.Content))
+}
@@ -3,34 +3,29 @@ package integration
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"log/slog"
 	"path/filepath"
-	"slices"
 	"strconv"
-	"time"
 	"unicode/utf16"
 
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
-	"github.com/yourselfhosted/gomark/ast"
-	"github.com/yourselfhosted/gomark/parser"
-	"github.com/yourselfhosted/gomark/parser/tokenizer"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/plugin/telegram"
-	"github.com/usememos/memos/plugin/webhook"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	apiv1 "github.com/usememos/memos/server/route/api/v1"
-	apiv2 "github.com/usememos/memos/server/route/api/v2"
 	"github.com/usememos/memos/store"
 )
 
 type TelegramHandler struct {
-	store *store.Store
+	store       *store.Store
+	eventBroker *event.Broker
 }
 
-func NewTelegramHandler(store *store.Store) *TelegramHandler {
-	return &TelegramHandler{store: store}
+func NewTelegramHandler(store *store.Store, eventBroker *event.Broker) *TelegramHandler {
+	return &TelegramHandler{store: store, eventBroker: eventBroker}
 }
 
 func (t *TelegramHandler) BotToken(ctx context.Context) string {
@@ -48,23 +43,18 @@ const (
 )
 
 func (t *TelegramHandler) MessageHandle(ctx context.Context, bot *telegram.Bot, message telegram.Message, attachments []telegram.Attachment) error {
+	if command, args, ok := message.GetCommand(); ok {
+		return t.commandHandle(ctx, bot, message, command, args)
+	}
+
 	reply, err := bot.SendReplyMessage(ctx, message.Chat.ID, message.MessageID, workingMessage)
 	if err != nil {
 		return errors.Wrap(err, "Failed to SendReplyMessage")
 	}
 
-	messageSenderID := strconv.FormatInt(message.From.ID, 10)
-	var creatorID int32
-	userSettingList, err := t.store.ListUserSettings(ctx, &store.FindUserSetting{
-		Key: storepb.UserSettingKey_USER_SETTING_TELEGRAM_USER_ID,
-	})
+	creatorID, err := t.getCreatorID(ctx, message.From.ID)
 	if err != nil {
-		return errors.Wrap(err, "Failed to find userSettingList")
-	}
-	for _, userSetting := range userSettingList {
-		if userSetting.GetTelegramUserId() == messageSenderID {
-			creatorID = userSetting.UserId
-		}
+		return err
 	}
 
 	// If creatorID is not found, ask the user to set the telegram userid in UserSetting of memos.
@@ -94,30 +84,16 @@ func (t *TelegramHandler) MessageHandle(ctx context.Context, bot *telegram.Bot,
 	}
 
 	// Dynamically upsert tags from memo content.
-	nodes, err := parser.Parse(tokenizer.Tokenize(create.Content))
-	if err != nil {
-		return errors.Wrap(err, "Failed to parse content")
-	}
-	tags := []string{}
-	apiv2.TraverseASTNodes(nodes, func(node ast.Node) {
-		if tagNode, ok := node.(*ast.Tag); ok {
-			tag := tagNode.Content
-			if !slices.Contains(tags, tag) {
-				tags = append(tags, tag)
-			}
-		}
-	})
-	for _, tag := range tags {
-		_, err := t.store.UpsertTag(ctx, &store.Tag{
-			Name:      tag,
-			CreatorID: creatorID,
-		})
-		if err != nil {
-			return errors.Wrap(err, "Failed to upsert tag")
-		}
+	if err := upsertContentTags(ctx, t.store, creatorID, create.Content); err != nil {
+		return err
 	}
 
 	// Create memo related resources.
+	uploadLimit, err := t.getUploadLimit(ctx, creatorID)
+	if err != nil {
+		_, err := bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, fmt.Sprintf("Failed to GetUploadLimit: %s", err), nil)
+		return err
+	}
 	for _, attachment := range attachments {
 		// Fill the common field of create
 		create := store.Resource{
@@ -129,7 +105,22 @@ func (t *TelegramHandler) MessageHandle(ctx context.Context, bot *telegram.Bot,
 			MemoID:    &memoMessage.ID,
 		}
 
-		err := apiv1.SaveResourceBlob(ctx, t.store, &create, bytes.NewReader(attachment.Data))
+		if create.Size > uploadLimit.MaxSizeBytes || !uploadLimit.IsTypeAllowed(create.Type) {
+			_, err := bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, fmt.Sprintf("File %s is not allowed to upload", create.Filename), nil)
+			return err
+		}
+
+		result, err := apiv1.ScanResourceBlob(ctx, t.store, bytes.NewReader(attachment.Data))
+		if err != nil {
+			_, err := bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, fmt.Sprintf("Failed to ScanResourceBlob: %s", err), nil)
+			return err
+		}
+		if result != nil && result.Infected {
+			_, err := bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, fmt.Sprintf("File %s is infected: %s", create.Filename, result.Signature), nil)
+			return err
+		}
+
+		err = apiv1.SaveResourceBlob(ctx, t.store, &create, bytes.NewReader(attachment.Data))
 		if err != nil {
 			_, err := bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, fmt.Sprintf("Failed to SaveResourceBlob: %s", err), nil)
 			return err
@@ -143,11 +134,52 @@ func (t *TelegramHandler) MessageHandle(ctx context.Context, bot *telegram.Bot,
 	}
 
 	keyboard := generateKeyboardForMemoID(memoMessage.ID)
-	_, err = bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, fmt.Sprintf("Saved as %s Memo %d", memoMessage.Visibility, memoMessage.ID), keyboard)
-	_ = t.dispatchMemoRelatedWebhook(ctx, *memoMessage, "memos.memo.created")
+	_, err = bot.EditMessage(ctx, message.Chat.ID, reply.MessageID, formatSavedMemoMessage(memoMessage), keyboard)
+	// Link the reply to the memo, so the changes of the memo are synced back to it.
+	if _, linkErr := t.store.CreateTelegramMessage(ctx, &store.TelegramMessage{
+		MemoID:          memoMessage.ID,
+		ChatID:          message.Chat.ID,
+		MessageID:       reply.MessageID,
+		SourceMessageID: message.MessageID,
+	}); linkErr != nil {
+		slog.Warn("Failed to create telegram message", slog.Any("err", linkErr))
+	}
+	notifyMemoCreated(ctx, t.store, t.eventBroker, memoMessage)
 	return err
 }
 
+// getCreatorID returns the id of the user who set the telegram user id in the user setting, 0 if no one did or the user is archived.
+func (t *TelegramHandler) getCreatorID(ctx context.Context, telegramUserID int64) (int32, error) {
+	messageSenderID := strconv.FormatInt(telegramUserID, 10)
+	var creatorID int32
+	userSettingList, err := t.store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_TELEGRAM_USER_ID,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to find userSettingList")
+	}
+	for _, userSetting := range userSettingList {
+		if userSetting.GetTelegramUserId() == messageSenderID {
+			creatorID = userSetting.UserId
+		}
+	}
+	if creatorID == 0 {
+		return 0, nil
+	}
+	return getActiveUserID(ctx, t.store, creatorID)
+}
+
+func (t *TelegramHandler) getUploadLimit(ctx context.Context, userID int32) (*apiv1.UploadLimit, error) {
+	user, err := t.store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.Errorf("user %d not found", userID)
+	}
+	return apiv1.GetUploadLimit(ctx, t.store, user)
+}
+
 func (t *TelegramHandler) CallbackQueryHandle(ctx context.Context, bot *telegram.Bot, callbackQuery telegram.CallbackQuery) error {
 	var memoID int32
 	var visibility store.Visibility
@@ -170,21 +202,12 @@ func (t *TelegramHandler) CallbackQueryHandle(ctx context.Context, bot *telegram
 		return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Memo %d not found, possibly deleted elsewhere", memoID))
 	}
 
-	setting, err := t.store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
-		Name: apiv1.SystemSettingDisablePublicMemosName.String(),
-	})
+	disablePublicMemo, err := isPublicMemoDisabled(ctx, t.store)
 	if err != nil {
 		return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Failed to get workspace setting %s", err))
 	}
-	if setting != nil && setting.Value != "" {
-		disablePublicMemo := false
-		err = json.Unmarshal([]byte(setting.Value), &disablePublicMemo)
-		if err != nil {
-			return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Failed to get workspace setting %s", err))
-		}
-		if disablePublicMemo && visibility == store.Public {
-			return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Failed to changing Memo %d to %s\n(workspace disallowed public memo)", memoID, visibility))
-		}
+	if disablePublicMemo && visibility == store.Public {
+		return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Failed to changing Memo %d to %s\n(workspace disallowed public memo)", memoID, visibility))
 	}
 
 	update := store.UpdateMemo{
@@ -195,22 +218,28 @@ func (t *TelegramHandler) CallbackQueryHandle(ctx context.Context, bot *telegram
 	if err != nil {
 		return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Failed to call UpdateMemo %s", err))
 	}
+	memo.Visibility = visibility
 
 	keyboard := generateKeyboardForMemoID(memoID)
-	_, err = bot.EditMessage(ctx, callbackQuery.Message.Chat.ID, callbackQuery.Message.MessageID, fmt.Sprintf("Saved as %s Memo %d", visibility, memoID), keyboard)
+	_, err = bot.EditMessage(ctx, callbackQuery.Message.Chat.ID, callbackQuery.Message.MessageID, formatSavedMemoMessage(memo), keyboard)
 	if err != nil {
 		return bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Failed to EditMessage %s", err))
 	}
 
 	err = bot.AnswerCallbackQuery(ctx, callbackQuery.ID, fmt.Sprintf("Success changing Memo %d to %s", memoID, visibility))
+	t.notifyMemoUpdated(ctx, memoID)
+	return err
+}
 
-	memo, webhookErr := t.store.GetMemo(ctx, &store.FindMemo{
+// notifyMemoUpdated dispatches the webhooks and publishes the event of the updated memo.
+func (t *TelegramHandler) notifyMemoUpdated(ctx context.Context, memoID int32) {
+	memo, err := t.store.GetMemo(ctx, &store.FindMemo{
 		ID: &memoID,
 	})
-	if webhookErr == nil {
-		_ = t.dispatchMemoRelatedWebhook(ctx, *memo, "memos.memo.updated")
+	if err != nil || memo == nil {
+		return
 	}
-	return err
+	notifyMemoUpdated(ctx, t.store, t.eventBroker, memo)
 }
 
 func generateKeyboardForMemoID(id int32) [][]telegram.InlineKeyboardButton {
@@ -282,78 +311,3 @@ func convertToMarkdown(text string, messageEntities []telegram.MessageEntity) st
 
 	return string(output)
 }
-
-func (t *TelegramHandler) dispatchMemoRelatedWebhook(ctx context.Context, memo store.Memo, activityType string) error {
-	webhooks, err := t.store.ListWebhooks(ctx, &store.FindWebhook{
-		CreatorID: &memo.CreatorID,
-	})
-	if err != nil {
-		return err
-	}
-	for _, hook := range webhooks {
-		payload := t.convertMemoToWebhookPayload(ctx, memo)
-		payload.ActivityType = activityType
-		payload.URL = hook.Url
-		err := webhook.Post(*payload)
-		if err != nil {
-			return errors.Wrap(err, "failed to post webhook")
-		}
-	}
-	return nil
-}
-
-func (t *TelegramHandler) convertMemoToWebhookPayload(ctx context.Context, memo store.Memo) (payload *webhook.WebhookPayload) {
-	payload = &webhook.WebhookPayload{
-		CreatorID: memo.CreatorID,
-		CreatedTs: time.Now().Unix(),
-		Memo: &webhook.Memo{
-			ID:           memo.ID,
-			CreatorID:    memo.CreatorID,
-			CreatedTs:    memo.CreatedTs,
-			UpdatedTs:    memo.UpdatedTs,
-			Content:      memo.Content,
-			Visibility:   memo.Visibility.String(),
-			Pinned:       memo.Pinned,
-			ResourceList: make([]*webhook.Resource, 0),
-			RelationList: make([]*webhook.MemoRelation, 0),
-		},
-	}
-
-	resourceList, err := t.store.ListResources(ctx, &store.FindResource{
-		MemoID: &memo.ID,
-	})
-
-	if err != nil {
-		return payload
-	}
-	for _, resource := range resourceList {
-		payload.Memo.ResourceList = append(payload.Memo.ResourceList, &webhook.Resource{
-			ID:           resource.ID,
-			CreatorID:    resource.CreatorID,
-			CreatedTs:    resource.CreatedTs,
-			UpdatedTs:    resource.UpdatedTs,
-			Filename:     resource.Filename,
-			Type:         resource.Type,
-			Size:         resource.Size,
-			InternalPath: resource.InternalPath,
-			ExternalLink: resource.ExternalLink,
-		})
-	}
-
-	relationList, err := t.store.ListMemoRelations(ctx, &store.FindMemoRelation{
-		MemoID: &memo.ID,
-	})
-
-	if err != nil {
-		return payload
-	}
-
-	for _, relation := range relationList {
-		payload.Memo.RelationList = append(payload.Memo.RelationList, &webhook.MemoRelation{
-			MemoID:        relation.MemoID,
-			RelatedMemoID: relation.RelatedMemoID,
-			Type:          string(relation.Type),
-		})
-	}
-	return payload
-}
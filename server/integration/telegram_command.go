@@ -0,0 +1,220 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/telegram"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxCommandMemos is the number of the memos listed by the search and today commands at most.
+	maxCommandMemos = 10
+
+	helpMessage = `Send or forward a message to save it as a memo.
+
+/search <keywords> - Search your memos
+/today - List the memos you created today
+/tag <tags> - Reply to a saved memo to add the tags
+/visibility <PUBLIC|PROTECTED|PRIVATE> - Reply to a saved memo to change its visibility`
+)
+
+// commandHandle handles the message of a bot command, the reply of the bot is sent to the chat of the message.
+func (t *TelegramHandler) commandHandle(ctx context.Context, bot *telegram.Bot, message telegram.Message, command, args string) error {
+	reply := func(text string) error {
+		_, err := bot.SendReplyMessage(ctx, message.Chat.ID, message.MessageID, text)
+		return err
+	}
+	if command == "start" || command == "help" {
+		return reply(helpMessage)
+	}
+
+	creatorID, err := t.getCreatorID(ctx, message.From.ID)
+	if err != nil {
+		return err
+	}
+	if creatorID == 0 {
+		return reply(fmt.Sprintf("Please set your telegram userid %d in UserSetting of memos", message.From.ID))
+	}
+
+	var text string
+	switch command {
+	case "search":
+		text, err = t.searchCommand(ctx, creatorID, args)
+	case "today":
+		text, err = t.todayCommand(ctx, creatorID)
+	case "tag":
+		text, err = t.tagCommand(ctx, creatorID, message, args)
+	case "visibility":
+		text, err = t.visibilityCommand(ctx, creatorID, message, args)
+	default:
+		text = fmt.Sprintf("Unknown command /%s\n\n%s", command, helpMessage)
+	}
+	if err != nil {
+		return reply(fmt.Sprintf("Failed to handle /%s: %s", command, err))
+	}
+	return reply(text)
+}
+
+func (t *TelegramHandler) searchCommand(ctx context.Context, creatorID int32, args string) (string, error) {
+	keywords := strings.Fields(args)
+	if len(keywords) == 0 {
+		return "Usage: /search <keywords>", nil
+	}
+	normalStatus := store.Normal
+	limit := maxCommandMemos
+	memos, err := t.store.ListMemos(ctx, &store.FindMemo{
+		CreatorID:     &creatorID,
+		RowStatus:     &normalStatus,
+		ContentSearch: keywords,
+		Limit:         &limit,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(memos) == 0 {
+		return fmt.Sprintf("No memos found for %q", strings.Join(keywords, " ")), nil
+	}
+	return formatMemoList(fmt.Sprintf("Memos matching %q:", strings.Join(keywords, " ")), memos), nil
+}
+
+func (t *TelegramHandler) todayCommand(ctx context.Context, creatorID int32) (string, error) {
+	now := time.Now()
+	// The memos are found with the created timestamps after createdTsAfter, which is exclusive.
+	createdTsAfter := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Unix() - 1
+	normalStatus := store.Normal
+	limit := maxCommandMemos
+	memos, err := t.store.ListMemos(ctx, &store.FindMemo{
+		CreatorID:      &creatorID,
+		RowStatus:      &normalStatus,
+		CreatedTsAfter: &createdTsAfter,
+		Limit:          &limit,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(memos) == 0 {
+		return "No memos created today", nil
+	}
+	return formatMemoList("Memos created today:", memos), nil
+}
+
+func (t *TelegramHandler) tagCommand(ctx context.Context, creatorID int32, message telegram.Message, args string) (string, error) {
+	newTags := []string{}
+	for _, tag := range strings.Fields(args) {
+		if tag = strings.TrimLeft(tag, "#"); tag != "" {
+			newTags = append(newTags, tag)
+		}
+	}
+	if len(newTags) == 0 {
+		return "Usage: reply to a saved memo with /tag <tags>", nil
+	}
+	memo, err := t.getRepliedMemo(ctx, creatorID, message)
+	if err != nil || memo == nil {
+		return "Please reply to a saved memo to add the tags", err
+	}
+
+	tags, err := getContentTags(memo.Content)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse content")
+	}
+	missingTags := []string{}
+	for _, tag := range newTags {
+		if !slices.Contains(tags, tag) && !slices.Contains(missingTags, tag) {
+			missingTags = append(missingTags, tag)
+		}
+	}
+	if len(missingTags) == 0 {
+		return fmt.Sprintf("Memo %d already has the tags", memo.ID), nil
+	}
+	content := strings.TrimRight(memo.Content, "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	content += "#" + strings.Join(missingTags, " #")
+	if err := t.store.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:      memo.ID,
+		Content: &content,
+	}); err != nil {
+		return "", err
+	}
+	if err := upsertContentTags(ctx, t.store, creatorID, content); err != nil {
+		return "", err
+	}
+	t.notifyMemoUpdated(ctx, memo.ID)
+	return fmt.Sprintf("Added #%s to Memo %d", strings.Join(missingTags, " #"), memo.ID), nil
+}
+
+func (t *TelegramHandler) visibilityCommand(ctx context.Context, creatorID int32, message telegram.Message, args string) (string, error) {
+	visibility := store.Visibility(strings.ToUpper(strings.TrimSpace(args)))
+	if visibility != store.Public && visibility != store.Protected && visibility != store.Private {
+		return "Usage: reply to a saved memo with /visibility <PUBLIC|PROTECTED|PRIVATE>", nil
+	}
+	memo, err := t.getRepliedMemo(ctx, creatorID, message)
+	if err != nil || memo == nil {
+		return "Please reply to a saved memo to change its visibility", err
+	}
+	if visibility == store.Public {
+		disablePublicMemo, err := isPublicMemoDisabled(ctx, t.store)
+		if err != nil {
+			return "", err
+		}
+		if disablePublicMemo {
+			return fmt.Sprintf("Failed to changing Memo %d to %s\n(workspace disallowed public memo)", memo.ID, visibility), nil
+		}
+	}
+	if err := t.store.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:         memo.ID,
+		Visibility: &visibility,
+	}); err != nil {
+		return "", err
+	}
+	t.notifyMemoUpdated(ctx, memo.ID)
+	return fmt.Sprintf("Success changing Memo %d to %s", memo.ID, visibility), nil
+}
+
+// getRepliedMemo returns the memo of the user linked to the message replied by the message,
+// which is either the message the memo was saved from or the reply of the bot to it.
+func (t *TelegramHandler) getRepliedMemo(ctx context.Context, creatorID int32, message telegram.Message) (*store.Memo, error) {
+	if message.ReplyToMessage == nil {
+		return nil, nil
+	}
+	telegramMessage, err := t.store.GetTelegramMessage(ctx, &store.FindTelegramMessage{
+		ChatID:    &message.Chat.ID,
+		MessageID: &message.ReplyToMessage.MessageID,
+	})
+	if err != nil || telegramMessage == nil {
+		return nil, err
+	}
+	memo, err := t.store.GetMemo(ctx, &store.FindMemo{
+		ID: &telegramMessage.MemoID,
+	})
+	if err != nil || memo == nil || memo.CreatorID != creatorID {
+		return nil, err
+	}
+	return memo, nil
+}
+
+func formatMemoList(title string, memos []*store.Memo) string {
+	lines := []string{title}
+	for _, memo := range memos {
+		snippet := strings.Join(strings.Fields(getMemoSnippet(memo.Content)), " ")
+		lines = append(lines, fmt.Sprintf("Memo %d (%s): %s", memo.ID, memo.Visibility, snippet))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatSavedMemoMessage returns the text of the reply of the bot to the message saved as the memo.
+func formatSavedMemoMessage(memo *store.Memo) string {
+	text := fmt.Sprintf("Saved as %s Memo %d", memo.Visibility, memo.ID)
+	if snippet := getMemoSnippet(memo.Content); snippet != "" {
+		text += "\n\n" + snippet
+	}
+	return text
+}
@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/telegram"
+	"github.com/usememos/memos/store"
+)
+
+// SyncMemoMessages edits the messages of the bot linked to the memos when the memos are updated or deleted elsewhere,
+// until the context is done.
+func (t *TelegramHandler) SyncMemoMessages(ctx context.Context, bot *telegram.Bot) {
+	subscription := t.eventBroker.Subscribe()
+	defer t.eventBroker.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type != event.MemoUpdated && e.Type != event.MemoDeleted {
+				continue
+			}
+			if err := t.syncMemoMessages(ctx, bot, e.MemoID); err != nil {
+				slog.Warn("Failed to sync telegram messages", slog.Int("memo", int(e.MemoID)), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (t *TelegramHandler) syncMemoMessages(ctx context.Context, bot *telegram.Bot, memoID int32) error {
+	telegramMessages, err := t.store.ListTelegramMessages(ctx, &store.FindTelegramMessage{
+		MemoID: &memoID,
+	})
+	if err != nil || len(telegramMessages) == 0 {
+		return err
+	}
+	memo, err := t.store.GetMemo(ctx, &store.FindMemo{
+		ID: &memoID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var text string
+	var keyboard [][]telegram.InlineKeyboardButton
+	switch {
+	case memo == nil:
+		text = fmt.Sprintf("Memo %d was deleted", memoID)
+	case memo.RowStatus == store.Archived:
+		text = fmt.Sprintf("Memo %d was archived", memoID)
+	default:
+		text = formatSavedMemoMessage(memo)
+		keyboard = generateKeyboardForMemoID(memoID)
+	}
+	for _, telegramMessage := range telegramMessages {
+		// The error of an unchanged text is expected, e.g. if the memo was updated from the chat.
+		if _, err := bot.EditMessage(ctx, telegramMessage.ChatID, telegramMessage.MessageID, text, keyboard); err != nil {
+			slog.Debug("Failed to edit telegram message", slog.Int64("message", telegramMessage.MessageID), slog.Any("err", err))
+		}
+	}
+	if memo == nil {
+		return t.store.DeleteTelegramMessage(ctx, &store.DeleteTelegramMessage{
+			MemoID: memoID,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,720 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/net/webdav"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/store"
+)
+
+// WebDAVPrefix is the path of the WebDAV tree of the memos of the authenticated user:
+//
+//	/by-date/{yyyy-mm}/{uid}.md
+//	/by-tag/{tag}/{uid}.md, where the nested tags are nested directories.
+//
+// The new files are saved as memos, with the tag of the directory, and the deleted files archive the memos.
+const WebDAVPrefix = "/dav"
+
+const (
+	webDAVDateDirectory = "by-date"
+	webDAVTagDirectory  = "by-tag"
+	webDAVFileExtension = ".md"
+	// webDAVMonthFormat is the format of the directories of the months in the date directory.
+	webDAVMonthFormat = "2006-01"
+	// maxWebDAVFileSize is the max size of the files, the same as the content of the memos saved from the emails.
+	maxWebDAVFileSize = maxEmailContentLength
+	// webDAVAuthCacheTTL is the duration the credentials are cached for, since the clients send them with every request.
+	webDAVAuthCacheTTL = time.Minute
+)
+
+// invalidUIDCharactersRegexp matches the characters which are not allowed in the memo uids.
+var invalidUIDCharactersRegexp = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+
+// WebDAVHandler serves the memos of the users as a WebDAV tree of Markdown files.
+type WebDAVHandler struct {
+	store       *store.Store
+	eventBroker *event.Broker
+	secret      string
+
+	mutex sync.Mutex
+	// lockSystems are the locks of the users, the paths of the users are separate trees.
+	lockSystems map[int32]webdav.LockSystem
+	// authCache maps the hashes of the credentials to the authenticated users.
+	authCache map[string]webDAVAuth
+}
+
+type webDAVAuth struct {
+	userID    int32
+	expiresAt time.Time
+}
+
+func NewWebDAVHandler(store *store.Store, eventBroker *event.Broker, secret string) *WebDAVHandler {
+	return &WebDAVHandler{
+		store:       store,
+		eventBroker: eventBroker,
+		secret:      secret,
+		lockSystems: map[int32]webdav.LockSystem{},
+		authCache:   map[string]webDAVAuth{},
+	}
+}
+
+func (h *WebDAVHandler) RegisterRoutes(e *echo.Echo) {
+	methods := []string{
+		http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete,
+		"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK",
+	}
+	handler := echo.WrapHandler(http.HandlerFunc(h.ServeHTTP))
+	e.Match(methods, WebDAVPrefix, handler)
+	e.Match(methods, WebDAVPrefix+"/*", handler)
+}
+
+func (h *WebDAVHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	integrationSetting, err := h.store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		http.Error(w, "Failed to get workspace setting", http.StatusInternalServerError)
+		return
+	}
+	if !integrationSetting.GetWebdav().GetEnabled() {
+		http.NotFound(w, r)
+		return
+	}
+	user, err := h.authenticate(ctx, r)
+	if err != nil {
+		slog.Warn("Failed to authenticate webdav request", slog.Any("err", err))
+		http.Error(w, "Failed to authenticate", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="memos", charset="UTF-8"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	handler := &webdav.Handler{
+		Prefix:     WebDAVPrefix,
+		FileSystem: &webDAVFileSystem{handler: h, user: user},
+		LockSystem: h.getLockSystem(user.ID),
+		Logger: func(r *http.Request, err error) {
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				slog.Debug("WebDAV request failed", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.Any("err", err))
+			}
+		},
+	}
+	handler.ServeHTTP(w, r)
+}
+
+func (h *WebDAVHandler) getLockSystem(userID int32) webdav.LockSystem {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	lockSystem, ok := h.lockSystems[userID]
+	if !ok {
+		lockSystem = webdav.NewMemLS()
+		h.lockSystems[userID] = lockSystem
+	}
+	return lockSystem
+}
+
+// authenticate returns the user of the basic auth credentials, nil if they are invalid.
+// The password is either the password or an access token of the user, since the users of SSO don't have passwords.
+func (h *WebDAVHandler) authenticate(ctx context.Context, r *http.Request) (*store.User, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		return nil, nil
+	}
+	hash := sha256.Sum256([]byte(username + "\x00" + password))
+	key := hex.EncodeToString(hash[:])
+	h.mutex.Lock()
+	cached, ok := h.authCache[key]
+	h.mutex.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		user, err := h.store.GetUser(ctx, &store.FindUser{ID: &cached.userID})
+		if err != nil || user == nil || user.RowStatus == store.Archived {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	user, err := h.store.GetUser(ctx, &store.FindUser{Username: &username})
+	if err != nil || user == nil || user.RowStatus == store.Archived {
+		return nil, err
+	}
+	valid := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+	if !valid {
+		valid, err = h.isAccessToken(ctx, user, password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !valid {
+		return nil, nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for cachedKey, auth := range h.authCache {
+		if time.Now().After(auth.expiresAt) {
+			delete(h.authCache, cachedKey)
+		}
+	}
+	h.authCache[key] = webDAVAuth{userID: user.ID, expiresAt: time.Now().Add(webDAVAuthCacheTTL)}
+	return user, nil
+}
+
+func (h *WebDAVHandler) isAccessToken(ctx context.Context, user *store.User, token string) (bool, error) {
+	tokenUser, err := findAccessTokenUser(ctx, h.store, h.secret, token)
+	if err != nil {
+		return false, err
+	}
+	return tokenUser != nil && tokenUser.ID == user.ID, nil
+}
+
+// webDAVFileSystem is the tree of the memos of the user.
+type webDAVFileSystem struct {
+	handler *WebDAVHandler
+	user    *store.User
+}
+
+// webDAVNode is a file or a directory of the tree.
+type webDAVNode struct {
+	name    string
+	modTime time.Time
+	// memo is the memo of a file, nil for the directories.
+	memo *store.Memo
+	// tag is the tag of a directory in the tag directory, and month is the month of a directory in the date directory.
+	tag   string
+	month string
+	// kind is the top level directory of the node, empty for the root.
+	kind string
+}
+
+func (n *webDAVNode) Name() string { return n.name }
+func (n *webDAVNode) Size() int64 {
+	if n.memo == nil {
+		return 0
+	}
+	return int64(len(n.memo.Content))
+}
+func (n *webDAVNode) Mode() fs.FileMode {
+	if n.memo == nil {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+func (n *webDAVNode) ModTime() time.Time { return n.modTime }
+func (n *webDAVNode) IsDir() bool        { return n.memo == nil }
+func (*webDAVNode) Sys() any             { return nil }
+
+func (f *webDAVFileSystem) listMemos(ctx context.Context) ([]*store.Memo, error) {
+	normalStatus := store.Normal
+	return f.handler.store.ListMemos(ctx, &store.FindMemo{
+		CreatorID:       &f.user.ID,
+		RowStatus:       &normalStatus,
+		ExcludeComments: true,
+	})
+}
+
+// listTags returns the tags of the user, including the tags of the memo contents.
+func (f *webDAVFileSystem) listTags(ctx context.Context, memos []*store.Memo) ([]string, error) {
+	tagList, err := f.handler.store.ListTags(ctx, &store.FindTag{CreatorID: f.user.ID})
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	for _, tag := range tagList {
+		tags = append(tags, tag.Name)
+	}
+	for _, memo := range memos {
+		memoTags, err := getContentTags(memo.Content)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, memoTags...)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags), nil
+}
+
+func getMemoMonth(memo *store.Memo) string {
+	return time.Unix(memo.CreatedTs, 0).Format(webDAVMonthFormat)
+}
+
+func memoHasTag(memo *store.Memo, tag string) bool {
+	tags, err := getContentTags(memo.Content)
+	return err == nil && slices.Contains(tags, tag)
+}
+
+func newMemoNode(memo *store.Memo) *webDAVNode {
+	return &webDAVNode{
+		name:    memo.UID + webDAVFileExtension,
+		modTime: time.Unix(memo.UpdatedTs, 0),
+		memo:    memo,
+	}
+}
+
+// resolve returns the node of the name, or os.ErrNotExist.
+func (f *webDAVFileSystem) resolve(ctx context.Context, name string) (*webDAVNode, error) {
+	parts := splitWebDAVPath(name)
+	if len(parts) == 0 {
+		return &webDAVNode{name: "/", modTime: time.Now()}, nil
+	}
+	switch parts[0] {
+	case webDAVDateDirectory:
+		if len(parts) == 1 {
+			return &webDAVNode{name: parts[0], kind: webDAVDateDirectory, modTime: time.Now()}, nil
+		}
+		month := parts[1]
+		if _, err := time.Parse(webDAVMonthFormat, month); err != nil || len(parts) > 3 {
+			return nil, os.ErrNotExist
+		}
+		memos, err := f.listMemos(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(parts) == 3 {
+			for _, memo := range memos {
+				if getMemoMonth(memo) == month && memo.UID+webDAVFileExtension == parts[2] {
+					return newMemoNode(memo), nil
+				}
+			}
+			return nil, os.ErrNotExist
+		}
+		// The directory of the current month always exists, so the new files can be saved there.
+		exists := month == time.Now().Format(webDAVMonthFormat)
+		for _, memo := range memos {
+			exists = exists || getMemoMonth(memo) == month
+		}
+		if !exists {
+			return nil, os.ErrNotExist
+		}
+		return &webDAVNode{name: month, kind: webDAVDateDirectory, month: month, modTime: time.Now()}, nil
+	case webDAVTagDirectory:
+		if len(parts) == 1 {
+			return &webDAVNode{name: parts[0], kind: webDAVTagDirectory, modTime: time.Now()}, nil
+		}
+		memos, err := f.listMemos(ctx)
+		if err != nil {
+			return nil, err
+		}
+		last := parts[len(parts)-1]
+		if strings.HasSuffix(last, webDAVFileExtension) && len(parts) > 2 {
+			tag := strings.Join(parts[1:len(parts)-1], "/")
+			for _, memo := range memos {
+				if memo.UID+webDAVFileExtension == last && memoHasTag(memo, tag) {
+					return newMemoNode(memo), nil
+				}
+			}
+		}
+		tag := strings.Join(parts[1:], "/")
+		tags, err := f.listTags(ctx, memos)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			if t == tag || strings.HasPrefix(t, tag+"/") {
+				return &webDAVNode{name: last, kind: webDAVTagDirectory, tag: tag, modTime: time.Now()}, nil
+			}
+		}
+		return nil, os.ErrNotExist
+	default:
+		return nil, os.ErrNotExist
+	}
+}
+
+// children returns the nodes in the directory.
+func (f *webDAVFileSystem) children(ctx context.Context, directory *webDAVNode) ([]fs.FileInfo, error) {
+	if directory.kind == "" {
+		return []fs.FileInfo{
+			&webDAVNode{name: webDAVDateDirectory, kind: webDAVDateDirectory, modTime: time.Now()},
+			&webDAVNode{name: webDAVTagDirectory, kind: webDAVTagDirectory, modTime: time.Now()},
+		}, nil
+	}
+	memos, err := f.listMemos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children := []fs.FileInfo{}
+	if directory.kind == webDAVDateDirectory {
+		if directory.month != "" {
+			for _, memo := range memos {
+				if getMemoMonth(memo) == directory.month {
+					children = append(children, newMemoNode(memo))
+				}
+			}
+			return children, nil
+		}
+		months := []string{time.Now().Format(webDAVMonthFormat)}
+		for _, memo := range memos {
+			months = append(months, getMemoMonth(memo))
+		}
+		slices.Sort(months)
+		for _, month := range slices.Compact(months) {
+			children = append(children, &webDAVNode{name: month, kind: webDAVDateDirectory, month: month, modTime: time.Now()})
+		}
+		return children, nil
+	}
+
+	tags, err := f.listTags(ctx, memos)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if directory.tag != "" {
+		prefix = directory.tag + "/"
+	}
+	subdirectories := []string{}
+	for _, tag := range tags {
+		if rest, ok := strings.CutPrefix(tag, prefix); ok && rest != "" {
+			subdirectories = append(subdirectories, strings.Split(rest, "/")[0])
+		}
+	}
+	for _, subdirectory := range slices.Compact(subdirectories) {
+		children = append(children, &webDAVNode{name: subdirectory, kind: webDAVTagDirectory, tag: prefix + subdirectory, modTime: time.Now()})
+	}
+	if directory.tag != "" {
+		for _, memo := range memos {
+			if memoHasTag(memo, directory.tag) {
+				children = append(children, newMemoNode(memo))
+			}
+		}
+	}
+	return children, nil
+}
+
+func splitWebDAVPath(name string) []string {
+	parts := []string{}
+	for _, part := range strings.Split(path.Clean("/"+name), "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func (f *webDAVFileSystem) Mkdir(ctx context.Context, name string, _ os.FileMode) error {
+	parts := splitWebDAVPath(name)
+	if len(parts) < 2 || parts[0] != webDAVTagDirectory {
+		return os.ErrPermission
+	}
+	if _, err := f.resolve(ctx, name); err == nil {
+		return os.ErrExist
+	}
+	// The directories in the tag directory are the tags, which are listed before any memo has them.
+	_, err := f.handler.store.UpsertTag(ctx, &store.Tag{
+		Name:      strings.Join(parts[1:], "/"),
+		CreatorID: f.user.ID,
+	})
+	return err
+}
+
+func (f *webDAVFileSystem) OpenFile(ctx context.Context, name string, flag int, _ os.FileMode) (webdav.File, error) {
+	node, err := f.resolve(ctx, name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	if node == nil {
+		if flag&os.O_CREATE == 0 {
+			return nil, os.ErrNotExist
+		}
+		parent, err := f.resolve(ctx, path.Dir(path.Clean("/"+name)))
+		if err != nil {
+			return nil, err
+		}
+		base := path.Base(name)
+		if parent.memo != nil || !strings.HasSuffix(base, webDAVFileExtension) || (parent.kind == webDAVTagDirectory && parent.tag == "") {
+			// Only the Markdown files are saved as memos, e.g. the temporary files of the editors are rejected.
+			return nil, os.ErrPermission
+		}
+		return &webDAVFile{fs: f, ctx: ctx, node: &webDAVNode{name: base, modTime: time.Now()}, parent: parent, writable: true}, nil
+	}
+	if node.memo == nil {
+		if writable {
+			return nil, os.ErrPermission
+		}
+		return &webDAVFile{fs: f, ctx: ctx, node: node}, nil
+	}
+	if flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+		return nil, os.ErrExist
+	}
+	file := &webDAVFile{fs: f, ctx: ctx, node: node, writable: writable}
+	if flag&os.O_TRUNC == 0 {
+		file.data = []byte(node.memo.Content)
+	}
+	return file, nil
+}
+
+// RemoveAll archives the memo of the file, the directories can't be removed.
+func (f *webDAVFileSystem) RemoveAll(ctx context.Context, name string) error {
+	node, err := f.resolve(ctx, name)
+	if err != nil {
+		return err
+	}
+	if node.memo == nil {
+		return os.ErrPermission
+	}
+	archived := store.Archived
+	if err := f.handler.store.UpdateMemo(ctx, &store.UpdateMemo{ID: node.memo.ID, RowStatus: &archived}); err != nil {
+		return err
+	}
+	f.notifyUpdated(ctx, node.memo.ID)
+	return nil
+}
+
+// Rename changes the uid of the memo of the file, the files can't be moved to other directories.
+func (f *webDAVFileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	node, err := f.resolve(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	if node.memo == nil || path.Dir(path.Clean("/"+oldName)) != path.Dir(path.Clean("/"+newName)) {
+		return os.ErrPermission
+	}
+	if _, err := f.resolve(ctx, newName); err == nil {
+		return os.ErrExist
+	}
+	uid, ok := strings.CutSuffix(path.Base(newName), webDAVFileExtension)
+	if !ok || !util.UIDMatcher.MatchString(uid) {
+		return os.ErrPermission
+	}
+	existing, err := f.handler.store.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return os.ErrExist
+	}
+	if err := f.handler.store.UpdateMemo(ctx, &store.UpdateMemo{ID: node.memo.ID, UID: &uid}); err != nil {
+		return err
+	}
+	f.notifyUpdated(ctx, node.memo.ID)
+	return nil
+}
+
+func (f *webDAVFileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	node, err := f.resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (f *webDAVFileSystem) notifyUpdated(ctx context.Context, memoID int32) {
+	memo, err := f.handler.store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil {
+		return
+	}
+	notifyMemoUpdated(ctx, f.handler.store, f.handler.eventBroker, memo)
+}
+
+// save saves the content written to the file, the new files are created as memos.
+func (f *webDAVFileSystem) save(ctx context.Context, file *webDAVFile) error {
+	if len(file.data) > maxWebDAVFileSize {
+		return errors.New("file too large")
+	}
+	content := string(file.data)
+	if file.node.memo != nil {
+		if content == file.node.memo.Content {
+			return nil
+		}
+		now := time.Now().Unix()
+		if err := f.handler.store.UpdateMemo(ctx, &store.UpdateMemo{ID: file.node.memo.ID, Content: &content, UpdatedTs: &now}); err != nil {
+			return err
+		}
+		if err := upsertContentTags(ctx, f.handler.store, f.user.ID, content); err != nil {
+			slog.Warn("Failed to upsert tags", slog.Any("err", err))
+		}
+		f.notifyUpdated(ctx, file.node.memo.ID)
+		return nil
+	}
+
+	// Some clients create an empty file before writing the content, which would leave an empty memo.
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	if tag := file.parent.tag; tag != "" {
+		memoTags, _ := getContentTags(content)
+		if !slices.Contains(memoTags, tag) {
+			content = strings.TrimRight(content, "\n") + "\n\n#" + tag
+		}
+	}
+	uid, err := f.getNewMemoUID(ctx, strings.TrimSuffix(file.node.name, webDAVFileExtension))
+	if err != nil {
+		return err
+	}
+	visibility, err := getDefaultMemoVisibility(ctx, f.handler.store, f.user.ID)
+	if err != nil {
+		return err
+	}
+	memo, err := f.handler.store.CreateMemo(ctx, &store.Memo{
+		UID:        uid,
+		CreatorID:  f.user.ID,
+		Content:    content,
+		Visibility: visibility,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create memo")
+	}
+	file.node = newMemoNode(memo)
+	if err := upsertContentTags(ctx, f.handler.store, f.user.ID, memo.Content); err != nil {
+		slog.Warn("Failed to upsert tags", slog.Any("err", err))
+	}
+	notifyMemoCreated(ctx, f.handler.store, f.handler.eventBroker, memo)
+	return nil
+}
+
+// getNewMemoUID returns the uid of the filename, so the file is found by the name it's saved with.
+// A random uid is used if the filename can't be a uid or it's taken.
+func (f *webDAVFileSystem) getNewMemoUID(ctx context.Context, filename string) (string, error) {
+	uid := strings.Trim(invalidUIDCharactersRegexp.ReplaceAllString(filename, "-"), "-")
+	if len(uid) > 32 {
+		uid = strings.TrimRight(uid[:32], "-")
+	}
+	if !util.UIDMatcher.MatchString(uid) {
+		return shortuuid.New(), nil
+	}
+	existing, err := f.handler.store.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return shortuuid.New(), nil
+	}
+	return uid, nil
+}
+
+// webDAVFile is an opened file or directory, the written content is saved when it's closed.
+type webDAVFile struct {
+	fs     *webDAVFileSystem
+	ctx    context.Context
+	node   *webDAVNode
+	parent *webDAVNode
+
+	writable bool
+	written  bool
+	data     []byte
+	offset   int64
+	// children are the remaining nodes of the directory to read.
+	children []fs.FileInfo
+	listed   bool
+}
+
+func (f *webDAVFile) Close() error {
+	if !f.writable || (!f.written && f.node.memo != nil) {
+		return nil
+	}
+	return f.fs.save(f.ctx, f)
+}
+
+func (f *webDAVFile) Read(p []byte) (int, error) {
+	if f.node.memo == nil {
+		return 0, os.ErrInvalid
+	}
+	if f.offset >= int64(len(f.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+func (f *webDAVFile) Write(p []byte) (int, error) {
+	if !f.writable {
+		return 0, os.ErrPermission
+	}
+	end := f.offset + int64(len(p))
+	if end > maxWebDAVFileSize {
+		return 0, errors.New("file too large")
+	}
+	if end > int64(len(f.data)) {
+		f.data = append(f.data, make([]byte, end-int64(len(f.data)))...)
+	}
+	copy(f.data[f.offset:], p)
+	f.offset = end
+	f.written = true
+	return len(p), nil
+}
+
+func (f *webDAVFile) Seek(offset int64, whence int) (int64, error) {
+	reader := bytes.NewReader(f.data)
+	if _, err := reader.Seek(f.offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	position, err := reader.Seek(offset, whence)
+	if err != nil {
+		return 0, err
+	}
+	f.offset = position
+	return position, nil
+}
+
+func (f *webDAVFile) Readdir(count int) ([]fs.FileInfo, error) {
+	if f.node.memo != nil || f.writable {
+		return nil, os.ErrInvalid
+	}
+	if !f.listed {
+		children, err := f.fs.children(f.ctx, f.node)
+		if err != nil {
+			return nil, err
+		}
+		f.children, f.listed = children, true
+	}
+	if count <= 0 {
+		children := f.children
+		f.children = nil
+		return children, nil
+	}
+	if len(f.children) == 0 {
+		return nil, io.EOF
+	}
+	count = min(count, len(f.children))
+	children := f.children[:count]
+	f.children = f.children[count:]
+	return children, nil
+}
+
+func (f *webDAVFile) Stat() (fs.FileInfo, error) {
+	if f.node.memo == nil && f.writable {
+		// The new file isn't saved yet.
+		return &webDAVFileInfo{name: f.node.name, size: int64(len(f.data)), modTime: f.node.modTime}, nil
+	}
+	if f.written {
+		return &webDAVFileInfo{name: f.node.name, size: int64(len(f.data)), modTime: time.Now()}, nil
+	}
+	return f.node, nil
+}
+
+// webDAVFileInfo is the info of a file being written.
+type webDAVFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (i *webDAVFileInfo) Name() string       { return i.name }
+func (i *webDAVFileInfo) Size() int64        { return i.size }
+func (*webDAVFileInfo) Mode() fs.FileMode    { return 0644 }
+func (i *webDAVFileInfo) ModTime() time.Time { return i.modTime }
+func (*webDAVFileInfo) IsDir() bool          { return false }
+func (*webDAVFileInfo) Sys() any             { return nil }
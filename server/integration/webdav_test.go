@@ -0,0 +1,212 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/usememos/memos/internal/event"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/route/api/auth"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestSplitWebDAVPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []string
+	}{
+		{name: "", parts: []string{}},
+		{name: "/", parts: []string{}},
+		{name: "/by-tag/travel/", parts: []string{"by-tag", "travel"}},
+		{name: "by-date//2024-05/memo.md", parts: []string{"by-date", "2024-05", "memo.md"}},
+		{name: "/../by-tag/./travel", parts: []string{"by-tag", "travel"}},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.parts, splitWebDAVPath(test.name), test.name)
+	}
+}
+
+func TestWebDAVAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	secret := "secret"
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	createUser := func(username string) (*store.User, string) {
+		user, err := ts.CreateUser(ctx, &store.User{Username: username, Role: store.RoleUser, PasswordHash: string(passwordHash)})
+		require.NoError(t, err)
+		accessToken, err := auth.GenerateAccessToken(user.Username, user.ID, time.Now().Add(time.Hour), []byte(secret))
+		require.NoError(t, err)
+		_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+			UserId: user.ID,
+			Key:    storepb.UserSettingKey_USER_SETTING_ACCESS_TOKENS,
+			Value: &storepb.UserSetting_AccessTokens{
+				AccessTokens: &storepb.AccessTokensUserSetting{
+					AccessTokens: []*storepb.AccessTokensUserSetting_AccessToken{{AccessToken: accessToken}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		return user, accessToken
+	}
+	user, accessToken := createUser("test")
+	_, otherAccessToken := createUser("other")
+	archived, archivedAccessToken := createUser("archived")
+	archivedStatus := store.Archived
+	_, err = ts.UpdateUser(ctx, &store.UpdateUser{ID: archived.ID, RowStatus: &archivedStatus})
+	require.NoError(t, err)
+	h := NewWebDAVHandler(ts, event.NewBroker(), secret)
+
+	tests := []struct {
+		username string
+		password string
+		want     *store.User
+	}{
+		{username: "test", password: "password", want: user},
+		{username: "test", password: accessToken, want: user},
+		{username: "test", password: "wrong"},
+		{username: "test", password: ""},
+		{username: "", password: "password"},
+		{username: "unknown", password: "password"},
+		// The access tokens of the other users don't authenticate the user.
+		{username: "test", password: otherAccessToken},
+		{username: "archived", password: "password"},
+		{username: "archived", password: archivedAccessToken},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, WebDAVPrefix+"/", nil)
+		request.SetBasicAuth(test.username, test.password)
+		authenticated, err := h.authenticate(ctx, request)
+		require.NoError(t, err, test.username, test.password)
+		if test.want == nil {
+			require.Nil(t, authenticated, test.username, test.password)
+			continue
+		}
+		require.NotNil(t, authenticated, test.username, test.password)
+		require.Equal(t, test.want.ID, authenticated.ID, test.username, test.password)
+	}
+
+	// The cached credentials don't authenticate the users archived later.
+	request := httptest.NewRequest(http.MethodGet, WebDAVPrefix+"/", nil)
+	request.SetBasicAuth("test", "password")
+	_, err = ts.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, RowStatus: &archivedStatus})
+	require.NoError(t, err)
+	authenticated, err := h.authenticate(ctx, request)
+	require.NoError(t, err)
+	require.Nil(t, authenticated)
+
+	// The tree is only served when WebDAV is enabled, and only to the authenticated users.
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest("PROPFIND", WebDAVPrefix+"/", nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	_, err = ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_INTEGRATION,
+		Value: &storepb.WorkspaceSetting_Integration{
+			Integration: &storepb.WorkspaceIntegrationSetting{Webdav: &storepb.WebDAVSetting{Enabled: true}},
+		},
+	})
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest("PROPFIND", WebDAVPrefix+"/", nil))
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.Contains(t, recorder.Header().Get("WWW-Authenticate"), "Basic")
+}
+
+func TestWebDAVResolve(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleUser})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser})
+	require.NoError(t, err)
+	createdTime := time.Date(2024, 5, 9, 12, 0, 0, 0, time.Local)
+	createMemo := func(uid string, creatorID int32, content string, rowStatus store.RowStatus) {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: content, Visibility: store.Private})
+		require.NoError(t, err)
+		createdTs := createdTime.Unix()
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs, RowStatus: &rowStatus}))
+	}
+	createMemo("trip", user.ID, "#travel/japan trip", store.Normal)
+	createMemo("archived", user.ID, "#travel archived", store.Archived)
+	createMemo("other", other.ID, "#travel other", store.Normal)
+	_, err = ts.UpsertTag(ctx, &store.Tag{Name: "empty", CreatorID: user.ID})
+	require.NoError(t, err)
+	f := &webDAVFileSystem{handler: NewWebDAVHandler(ts, event.NewBroker(), "secret"), user: user}
+	currentMonth := time.Now().Format(webDAVMonthFormat)
+
+	tests := []struct {
+		name string
+		// memo is the uid of the memo of the file, empty for the directories.
+		memo string
+		// tag is the tag of the directory in the tag directory.
+		tag    string
+		exists bool
+	}{
+		{name: "/", exists: true},
+		{name: "/by-date", exists: true},
+		{name: "/by-date/2024-05", exists: true},
+		{name: "/by-date/" + currentMonth, exists: true},
+		{name: "/by-date/2024-05/trip.md", memo: "trip", exists: true},
+		{name: "/by-date/2024-06/trip.md"},
+		{name: "/by-date/2000-01"},
+		{name: "/by-date/may"},
+		{name: "/by-date/2024-05/trip.md/more"},
+		{name: "/by-tag", exists: true},
+		{name: "/by-tag/travel", tag: "travel", exists: true},
+		{name: "/by-tag/travel/japan", tag: "travel/japan", exists: true},
+		{name: "/by-tag/empty", tag: "empty", exists: true},
+		{name: "/by-tag/travel/japan/trip.md", memo: "trip", exists: true},
+		// The memos are only in the directories of their own tags.
+		{name: "/by-tag/travel/trip.md"},
+		{name: "/by-tag/empty/trip.md"},
+		{name: "/by-tag/unknown"},
+		// The archived memos and the memos of the other users aren't in the tree.
+		{name: "/by-date/2024-05/archived.md"},
+		{name: "/by-date/2024-05/other.md"},
+		{name: "/other"},
+		{name: "/../by-tag/./travel", tag: "travel", exists: true},
+	}
+	for _, test := range tests {
+		node, err := f.resolve(ctx, test.name)
+		if !test.exists {
+			require.ErrorIs(t, err, os.ErrNotExist, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		if test.memo != "" {
+			require.False(t, node.IsDir(), test.name)
+			require.Equal(t, test.memo, node.memo.UID, test.name)
+			continue
+		}
+		require.True(t, node.IsDir(), test.name)
+		require.Equal(t, test.tag, node.tag, test.name)
+	}
+
+	// The new files in the tag directories are saved as memos with the tag.
+	file, err := f.OpenFile(ctx, "/by-tag/travel/new note.md", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("new note"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	node, err := f.resolve(ctx, "/by-tag/travel/new-note.md")
+	require.NoError(t, err)
+	require.Equal(t, "new note\n\n#travel", node.memo.Content)
+	node, err = f.resolve(ctx, fmt.Sprintf("/by-date/%s/new-note.md", currentMonth))
+	require.NoError(t, err)
+	require.Equal(t, "new-note", node.memo.UID)
+	for _, name := range []string{"/by-tag/travel/.new-note.md.swp", "/by-tag/new-note.md", "/by-tag/unknown/new-note.md"} {
+		_, err := f.OpenFile(ctx, name, os.O_CREATE|os.O_WRONLY, 0644)
+		require.Error(t, err, name)
+	}
+}
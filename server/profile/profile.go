@@ -6,10 +6,12 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
+	"github.com/usememos/memos/internal/debug"
 	"github.com/usememos/memos/server/version"
 )
 
@@ -32,12 +34,115 @@ type Profile struct {
 	Version string `json:"version"`
 	// Frontend indicate the frontend is enabled or not
 	Frontend bool `json:"-"`
+	// FrontendDir is the directory of the built frontend served instead of the default `dist`, e.g. a patched build
+	FrontendDir string `json:"-" mapstructure:"frontend_dir"`
+	// GRPCReflection indicate the gRPC server reflection is enabled in prod mode or not
+	GRPCReflection bool `json:"-" mapstructure:"grpc_reflection"`
+	// APIQuota is the max number of API requests per access token in a quota window, 0 means unlimited
+	APIQuota int `json:"-" mapstructure:"api_quota"`
+	// APIQuotaWindow is the duration of the quota windows
+	APIQuotaWindow time.Duration `json:"-" mapstructure:"api_quota_window"`
+	// APIExplorer indicate the API explorer is served in prod mode or not
+	APIExplorer bool `json:"-" mapstructure:"api_explorer"`
+	// SMTPAddr is the binding address of the SMTP server receiving the emails saved as memos, empty means disabled
+	SMTPAddr string `json:"-" mapstructure:"smtp_addr"`
+	// EventBus is the URL of NATS or Redis the events are published to, empty means disabled
+	EventBus string `json:"-" mapstructure:"event_bus"`
+	// EventBusSubject is the prefix of the subjects of NATS, or the key of the stream of Redis
+	EventBusSubject string `json:"-" mapstructure:"event_bus_subject"`
+	// MQTTBroker is the URL of the MQTT broker the memo events are published to, empty means disabled
+	MQTTBroker string `json:"-" mapstructure:"mqtt_broker"`
+	// MQTTTopic is the prefix of the topics of the memo events
+	MQTTTopic string `json:"-" mapstructure:"mqtt_topic"`
+	// OTLPEndpoint is the URL of the OTLP/HTTP endpoint the traces and metrics are exported to, empty means disabled
+	OTLPEndpoint string `json:"-" mapstructure:"otlp_endpoint"`
+	// OTLPSampleRatio is the ratio of the traces sampled, from 0 to 1
+	OTLPSampleRatio float64 `json:"-" mapstructure:"otlp_sample_ratio"`
+	// SlowQueryThreshold is the min duration of the database queries logged as slow, 0 means disabled
+	SlowQueryThreshold time.Duration `json:"-" mapstructure:"slow_query_threshold"`
+	// MaxRequestQueries is the max number of the database queries of a request before it's logged as a possible N+1 query, 0 means disabled
+	MaxRequestQueries int `json:"-" mapstructure:"max_request_queries"`
+	// MarkdownCacheSize is the max size in MiB of the memo contents whose parsed and rendered results are cached, 0 means disabled
+	MarkdownCacheSize int `json:"-" mapstructure:"markdown_cache_size"`
+	// MemoryLimit is the soft limit in MiB of the memory of the Go runtime, 0 means the one of GOMEMLIMIT
+	MemoryLimit int `json:"-" mapstructure:"memory_limit"`
+	// MaxProcs is the max number of the CPUs executing the goroutines at once, 0 means the one of GOMAXPROCS
+	MaxProcs int `json:"-" mapstructure:"max_procs"`
+	// DispatchWorkers is the number of the workers delivering the webhooks and the notifications
+	DispatchWorkers int `json:"-" mapstructure:"dispatch_workers"`
+	// DispatchDestinationLimit is the max number of the deliveries to a host at once
+	DispatchDestinationLimit int `json:"-" mapstructure:"dispatch_destination_limit"`
+	// DispatchTimeout is the max duration of a delivery of a webhook or a notification
+	DispatchTimeout time.Duration `json:"-" mapstructure:"dispatch_timeout"`
+	// Compression indicate the responses are compressed with brotli or gzip or not, it can be disabled behind a compressing proxy
+	Compression bool `json:"-" mapstructure:"compression"`
+	// AuditLog is the comma separated sinks of the audit log of the security events, empty means disabled
+	AuditLog string `json:"-" mapstructure:"audit_log"`
+	// AccessLog is the comma separated sinks of the access log of the requests, empty means disabled
+	AccessLog string `json:"-" mapstructure:"access_log"`
+	// AccessLogFormat is the format of the access log records, can be "json" or "common" or "combined"
+	AccessLogFormat string `json:"-" mapstructure:"access_log_format"`
+	// AccessLogSkip is the comma separated path prefixes of the requests which aren't recorded to the access log
+	AccessLogSkip string `json:"-" mapstructure:"access_log_skip"`
+	// TrustedProxies is the comma separated IPs or CIDRs of the proxies whose X-Forwarded-For headers are trusted, empty means the headers are trusted as is
+	TrustedProxies string `json:"-" mapstructure:"trusted_proxies"`
+	// Pprof indicate the pprof endpoints are served to the host under /api/v1/debug or not
+	Pprof bool `json:"-" mapstructure:"pprof"`
+	// PprofAddr is the loopback address of the listener of the pprof endpoints without authentication, empty means disabled
+	PprofAddr string `json:"-" mapstructure:"pprof_addr"`
+	// Redis is the URL of Redis shared by the replicas for the caches, the quotas and the job locks, empty means disabled
+	Redis string `json:"-" mapstructure:"redis"`
+	// RedisPrefix is the prefix of the keys in Redis, so the deployments can share a Redis
+	RedisPrefix string `json:"-" mapstructure:"redis_prefix"`
+	// TLSCert is the path of the PEM certificate chain of TLS, the server is served over HTTPS with it and TLSKey
+	TLSCert string `json:"-" mapstructure:"tls_cert"`
+	// TLSKey is the path of the PEM private key of TLS
+	TLSKey string `json:"-" mapstructure:"tls_key"`
+	// ACME indicate the TLS certificates of the instance and the custom domains of the users are issued by Let's Encrypt or not
+	ACME bool `json:"-" mapstructure:"acme"`
+	// ACMEEmail is the contact email of the ACME account, it's notified of the problems of the certificates
+	ACMEEmail string `json:"-" mapstructure:"acme_email"`
+	// HTTP3 indicate HTTP/3 is served over QUIC on the UDP port of the same number or not, it requires TLS
+	HTTP3 bool `json:"-" mapstructure:"http3"`
+	// ReusePort indicate the ports are listened with SO_REUSEPORT or not, so a new instance can listen on them before the old one stops
+	ReusePort bool `json:"-" mapstructure:"reuse_port"`
+	// ShutdownTimeout is the max duration of draining the in-flight requests on shutdown or on a handoff to a new process
+	ShutdownTimeout time.Duration `json:"-" mapstructure:"shutdown_timeout"`
+	// ReplicaURL is the file:// or s3:// URL the SQLite database is continuously replicated to, empty means disabled
+	ReplicaURL string `json:"-" mapstructure:"replica_url"`
+	// ReplicaSyncInterval is the interval of shipping the WAL of the database to the replica
+	ReplicaSyncInterval time.Duration `json:"-" mapstructure:"replica_sync_interval"`
+	// ReplicaSnapshotInterval is the interval of starting a new generation of the replica with a snapshot of the database
+	ReplicaSnapshotInterval time.Duration `json:"-" mapstructure:"replica_snapshot_interval"`
+	// ReplicaRetention is the duration the previous generations of the replica are kept, 0 means forever
+	ReplicaRetention time.Duration `json:"-" mapstructure:"replica_retention"`
+	// Tenancy indicate the workspaces of the tenants are served by hostname or path instead of a single workspace or not
+	Tenancy bool `json:"-" mapstructure:"tenancy"`
+	// TenancyAdminToken is the bearer token of the super-admin API managing the tenants, empty means the API is disabled
+	TenancyAdminToken string `json:"-" mapstructure:"tenancy_admin_token"`
 }
 
 func (p *Profile) IsDev() bool {
 	return p.Mode != "prod"
 }
 
+// IsAPIExplorerEnabled returns true if the API explorer should be served,
+// which is always the case in dev and demo mode.
+func (p *Profile) IsAPIExplorerEnabled() bool {
+	return p.APIExplorer || p.IsDev()
+}
+
+// IsGRPCReflectionEnabled returns true if the gRPC server reflection should be registered,
+// which is always the case in dev and demo mode.
+func (p *Profile) IsGRPCReflectionEnabled() bool {
+	return p.GRPCReflection || p.IsDev()
+}
+
+// IsTLSEnabled returns true if the server is served over HTTPS.
+func (p *Profile) IsTLSEnabled() bool {
+	return (p.TLSCert != "" && p.TLSKey != "") || p.ACME
+}
+
 func checkDataDir(dataDir string) (string, error) {
 	// Convert to absolute path if relative path is supplied.
 	if !filepath.IsAbs(dataDir) {
@@ -57,6 +162,26 @@ func checkDataDir(dataDir string) (string, error) {
 	return dataDir, nil
 }
 
+// GetFrontendDir returns the directory of the built frontend.
+func (p *Profile) GetFrontendDir() string {
+	if p.FrontendDir != "" {
+		return p.FrontendDir
+	}
+	return "dist"
+}
+
+// checkFrontendDir returns the absolute path of the directory of the built frontend, which must have its `index.html`.
+func checkFrontendDir(frontendDir string) (string, error) {
+	frontendDir, err := filepath.Abs(frontendDir)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid frontend directory")
+	}
+	if info, err := os.Stat(filepath.Join(frontendDir, "index.html")); err != nil || info.IsDir() {
+		return "", errors.Errorf("frontend directory %s has no index.html", frontendDir)
+	}
+	return frontendDir, nil
+}
+
 // GetProfile will return a profile for dev or prod.
 func GetProfile() (*Profile, error) {
 	profile := Profile{}
@@ -69,6 +194,40 @@ func GetProfile() (*Profile, error) {
 		profile.Mode = "demo"
 	}
 
+	if (profile.TLSCert == "") != (profile.TLSKey == "") {
+		return nil, errors.New("both the TLS certificate and key are required")
+	}
+	if profile.ACME && profile.TLSCert != "" {
+		return nil, errors.New("the TLS certificate can't be set with ACME")
+	}
+	if profile.HTTP3 && !profile.IsTLSEnabled() {
+		return nil, errors.New("HTTP/3 requires TLS")
+	}
+	if profile.MemoryLimit < 0 || profile.MaxProcs < 0 {
+		return nil, errors.New("the memory limit and the max procs can't be negative")
+	}
+	if profile.DispatchWorkers < 1 || profile.DispatchDestinationLimit < 1 || profile.DispatchTimeout <= 0 {
+		return nil, errors.New("the dispatch workers, destination limit and timeout must be positive")
+	}
+	if profile.ReplicaURL != "" {
+		if profile.Driver != "sqlite" {
+			return nil, errors.New("only the SQLite database can be replicated")
+		}
+		if profile.ReplicaSyncInterval <= 0 || profile.ReplicaSnapshotInterval <= 0 || profile.ReplicaRetention < 0 {
+			return nil, errors.New("the replica sync and snapshot intervals must be positive, and the retention can't be negative")
+		}
+	}
+
+	if profile.Tenancy {
+		// The tenants have their own secrets only in prod mode, otherwise the tokens of a tenant would be valid for the others.
+		if profile.Mode != "prod" || profile.Driver != "sqlite" {
+			return nil, errors.New("tenancy requires prod mode and the SQLite database")
+		}
+		if profile.IsTLSEnabled() || profile.Redis != "" || profile.ReplicaURL != "" {
+			return nil, errors.New("tenancy doesn't support TLS, Redis or the replica, it's served by a single instance behind a proxy")
+		}
+	}
+
 	if profile.Mode == "prod" && profile.Data == "" {
 		if runtime.GOOS == "windows" {
 			profile.Data = filepath.Join(os.Getenv("ProgramData"), "memos")
@@ -96,6 +255,17 @@ func GetProfile() (*Profile, error) {
 		profile.DSN = filepath.Join(dataDir, dbFile)
 	}
 	profile.Version = version.GetCurrentVersion(profile.Mode)
+	if profile.FrontendDir != "" {
+		if profile.FrontendDir, err = checkFrontendDir(profile.FrontendDir); err != nil {
+			return nil, err
+		}
+	}
+	// The profiles served on the address aren't authenticated, they are only reachable from the host.
+	if profile.PprofAddr != "" {
+		if err := debug.CheckLocalAddr(profile.PprofAddr); err != nil {
+			return nil, errors.Wrap(err, "invalid pprof address")
+		}
+	}
 
 	return &profile, nil
 }
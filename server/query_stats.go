@@ -0,0 +1,21 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/internal/telemetry"
+)
+
+// QueryStatsMiddleware counts the database queries of the requests by their routes, so the routes with N+1 queries are logged.
+// The queries of the gRPC gateway are counted by the interceptor of the gRPC server instead.
+func QueryStatsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			ctx, done := telemetry.TrackQueries(r.Context(), r.Method+" "+c.Path())
+			defer done()
+			c.SetRequest(r.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
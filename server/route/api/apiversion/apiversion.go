@@ -0,0 +1,142 @@
+// Package apiversion negotiates the version of the API requests and announces the deprecation of the older versions,
+// so third-party clients learn about the removal of a version before they break on an upgrade.
+package apiversion
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Header is the header of the API version requested by the client and served by the server.
+	Header = "Memos-API-Version"
+	// DeprecationHeader is the header of the time the version was deprecated, as defined by RFC 9745.
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader is the header of the time the version will be removed, as defined by RFC 8594.
+	SunsetHeader = "Sunset"
+	// LinkHeader is the header of the link to the successor of the deprecated version.
+	LinkHeader = "Link"
+)
+
+var (
+	// ErrUnsupported is returned if the requested version isn't served.
+	ErrUnsupported = errors.New("unsupported API version")
+	// ErrConflict is returned if the version in the header conflicts with the version in the path.
+	ErrConflict = errors.New("API version in the header conflicts with the path")
+)
+
+// Version is a version of the API.
+type Version struct {
+	// Name is the name of the version, which is also the prefix of its paths, e.g. v1 for /api/v1.
+	Name string
+	// Frozen versions only receive fixes, their behavior doesn't change anymore.
+	Frozen bool
+	// DeprecatedAt is the time the version was deprecated. Zero if the version isn't deprecated.
+	DeprecatedAt time.Time
+	// SunsetAt is the time the version will be removed. Zero if the removal isn't scheduled.
+	SunsetAt time.Time
+	// Successor is the link to the description of the version replacing this one.
+	Successor string
+}
+
+// Versions are the versions of the API served, from the oldest to the latest.
+var Versions = []*Version{
+	{
+		Name:         "v1",
+		Frozen:       true,
+		DeprecatedAt: time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC),
+		Successor:    "/api/v2/openapi.yaml",
+	},
+	{
+		Name: "v2",
+	},
+}
+
+// Find returns the version of the name, which may omit the v prefix, e.g. 2 for v2.
+// Nil is returned if the version isn't served.
+func Find(name string) *Version {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name != "" && !strings.HasPrefix(name, "v") {
+		name = "v" + name
+	}
+	for _, version := range Versions {
+		if version.Name == name {
+			return version
+		}
+	}
+	return nil
+}
+
+// FromPath returns the name of the version in the API path, e.g. v1 for /api/v1/memo.
+// An empty string is returned if the path isn't versioned.
+func FromPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, "/")
+	if len(name) < 2 || name[0] != 'v' || strings.Trim(name[1:], "0123456789") != "" {
+		return ""
+	}
+	return name
+}
+
+// Status returns the status of the version at the time, one of current, frozen, deprecated and sunset.
+func (v *Version) Status(now time.Time) string {
+	switch {
+	case !v.SunsetAt.IsZero() && !now.Before(v.SunsetAt):
+		return "sunset"
+	case !v.DeprecatedAt.IsZero() && !now.Before(v.DeprecatedAt):
+		return "deprecated"
+	case v.Frozen:
+		return "frozen"
+	default:
+		return "current"
+	}
+}
+
+// Headers returns the headers of the responses served by the version at the time.
+// The deprecation headers are only included once the version is deprecated.
+func (v *Version) Headers(now time.Time) map[string]string {
+	headers := map[string]string{
+		Header: v.Name,
+	}
+	if v.DeprecatedAt.IsZero() || now.Before(v.DeprecatedAt) {
+		return headers
+	}
+	headers[DeprecationHeader] = fmt.Sprintf("@%d", v.DeprecatedAt.Unix())
+	if !v.SunsetAt.IsZero() {
+		headers[SunsetHeader] = v.SunsetAt.UTC().Format(http.TimeFormat)
+	}
+	if v.Successor != "" {
+		headers[LinkHeader] = fmt.Sprintf(`<%s>; rel="successor-version"`, v.Successor)
+	}
+	return headers
+}
+
+// Negotiate returns the version of the API request and the path to serve it.
+// The version in the path takes precedence, the header only routes the unversioned paths, e.g. /api/memo to /api/v1/memo.
+// An error is returned if the version isn't served or the header conflicts with the path.
+func Negotiate(path, header string) (*Version, string, error) {
+	if name := FromPath(path); name != "" {
+		version := Find(name)
+		if version == nil {
+			return nil, "", fmt.Errorf("%w: %q", ErrUnsupported, name)
+		}
+		if header != "" && Find(header) != version {
+			return nil, "", fmt.Errorf("%w: %q and %q", ErrConflict, header, name)
+		}
+		return version, path, nil
+	}
+	if header == "" {
+		return nil, path, nil
+	}
+	version := Find(header)
+	if version == nil {
+		return nil, "", fmt.Errorf("%w: %q", ErrUnsupported, header)
+	}
+	return version, "/api/" + version.Name + strings.TrimPrefix(path, "/api"), nil
+}
@@ -0,0 +1,53 @@
+package apiversion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	version, path, err := Negotiate("/api/v1/memo", "")
+	require.NoError(t, err)
+	require.Equal(t, "v1", version.Name)
+	require.Equal(t, "/api/v1/memo", path)
+
+	// The header routes the unversioned paths.
+	version, path, err = Negotiate("/api/memo", "2")
+	require.NoError(t, err)
+	require.Equal(t, "v2", version.Name)
+	require.Equal(t, "/api/v2/memo", path)
+
+	version, path, err = Negotiate("/api/memo", "")
+	require.NoError(t, err)
+	require.Nil(t, version)
+	require.Equal(t, "/api/memo", path)
+
+	_, _, err = Negotiate("/api/v1/memo", "v2")
+	require.ErrorIs(t, err, ErrConflict)
+	_, _, err = Negotiate("/api/v9/memo", "")
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, _, err = Negotiate("/api/memo", "v9")
+	require.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestVersionHeaders(t *testing.T) {
+	version := &Version{
+		Name:         "v1",
+		Frozen:       true,
+		DeprecatedAt: time.Unix(1700000000, 0),
+		SunsetAt:     time.Unix(1800000000, 0),
+		Successor:    "/api/v2/openapi.yaml",
+	}
+	headers := version.Headers(time.Unix(1600000000, 0))
+	require.Equal(t, map[string]string{Header: "v1"}, headers)
+	require.Equal(t, "frozen", version.Status(time.Unix(1600000000, 0)))
+
+	headers = version.Headers(time.Unix(1700000000, 0))
+	require.Equal(t, "@1700000000", headers[DeprecationHeader])
+	require.Equal(t, "Fri, 15 Jan 2027 08:00:00 GMT", headers[SunsetHeader])
+	require.Equal(t, `</api/v2/openapi.yaml>; rel="successor-version"`, headers[LinkHeader])
+	require.Equal(t, "deprecated", version.Status(time.Unix(1700000000, 0)))
+	require.Equal(t, "sunset", version.Status(time.Unix(1800000000, 0)))
+}
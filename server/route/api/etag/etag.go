@@ -0,0 +1,54 @@
+// Package etag implements the conditional requests of the read endpoints,
+// so clients can skip downloading entities which haven't changed since they were fetched.
+package etag
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// IfNoneMatchHeader is the header of the ETags held by the client.
+	IfNoneMatchHeader = "If-None-Match"
+	// IfModifiedSinceHeader is the header of the time the client fetched the entity.
+	IfModifiedSinceHeader = "If-Modified-Since"
+)
+
+// New returns the weak ETag of the entity version, which is derived from its update time.
+// The variant distinguishes the representations of the same entity, e.g. with or without private fields.
+func New(kind string, id int32, updatedTs int64, variant string) string {
+	if variant != "" {
+		return fmt.Sprintf(`W/"%s-%d-%d-%s"`, kind, id, updatedTs, variant)
+	}
+	return fmt.Sprintf(`W/"%s-%d-%d"`, kind, id, updatedTs)
+}
+
+// IsNotModified returns true if the entity is not modified according to the request conditions.
+// If-Modified-Since is only checked if there is no If-None-Match, as required by RFC 9110.
+func IsNotModified(ifNoneMatch, ifModifiedSince, etag string, updatedTs int64) bool {
+	if ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// The weak comparison is used, so the W/ prefix is ignored.
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		return !time.Unix(updatedTs, 0).After(since)
+	}
+	return false
+}
+
+// LastModified returns the Last-Modified header value of the update time.
+func LastModified(updatedTs int64) string {
+	return time.Unix(updatedTs, 0).UTC().Format(http.TimeFormat)
+}
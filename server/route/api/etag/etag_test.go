@@ -0,0 +1,34 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNotModified(t *testing.T) {
+	tag := New("memo", 1, 1700000000, "")
+	require.Equal(t, `W/"memo-1-1700000000"`, tag)
+	require.Equal(t, `W/"user-1-1700000000-public"`, New("user", 1, 1700000000, "public"))
+
+	tests := []struct {
+		ifNoneMatch     string
+		ifModifiedSince string
+		want            bool
+	}{
+		{want: false},
+		{ifNoneMatch: tag, want: true},
+		{ifNoneMatch: `"memo-1-1700000000"`, want: true},
+		{ifNoneMatch: `W/"memo-1-1600000000", W/"memo-1-1700000000"`, want: true},
+		{ifNoneMatch: "*", want: true},
+		{ifNoneMatch: `W/"memo-1-1600000000"`, want: false},
+		{ifModifiedSince: LastModified(1700000000), want: true},
+		{ifModifiedSince: LastModified(1600000000), want: false},
+		{ifModifiedSince: "invalid", want: false},
+		// If-None-Match takes precedence over If-Modified-Since.
+		{ifNoneMatch: `W/"memo-1-1600000000"`, ifModifiedSince: LastModified(1700000000), want: false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, IsNotModified(test.ifNoneMatch, test.ifModifiedSince, tag, 1700000000), "%+v", test)
+	}
+}
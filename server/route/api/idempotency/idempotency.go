@@ -0,0 +1,115 @@
+// Package idempotency implements the idempotency keys of the create endpoints,
+// so clients can retry a create request without creating a duplicate.
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// Header is the request header of the idempotency key.
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on the responses which return a previously created entity.
+	ReplayedHeader = "Idempotent-Replayed"
+	// Window is how long a key is remembered, retries after it create a new entity.
+	Window = 24 * time.Hour
+	// MaxKeyLength is the maximum length of a key.
+	MaxKeyLength = 256
+)
+
+var (
+	// ErrInvalidKey is returned if the key is too long.
+	ErrInvalidKey = errors.New("invalid idempotency key")
+	// ErrInProgress is returned if a request with the same key hasn't finished yet.
+	ErrInProgress = errors.New("a request with the same idempotency key is in progress")
+)
+
+// Begin reserves the key for the entity about to be created by the user, it returns nil if there is no key.
+// If the key was used within the window, the returned key has the id of the entity created by then,
+// which should be returned instead of creating a new one. Otherwise the returned reservation has no entity id,
+// and must be finished with Complete once the entity is created, or with Release if it fails.
+func Begin(ctx context.Context, s *store.Store, creatorID int32, entityType, key string) (*store.IdempotencyKey, error) {
+	if key == "" {
+		return nil, nil
+	}
+	if len(key) > MaxKeyLength {
+		return nil, ErrInvalidKey
+	}
+
+	find := &store.FindIdempotencyKey{
+		CreatorID:  &creatorID,
+		EntityType: &entityType,
+		Key:        &key,
+	}
+	existing, err := s.GetIdempotencyKey(ctx, find)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find idempotency key")
+	}
+	if existing != nil {
+		if time.Unix(existing.CreatedTs, 0).Add(Window).After(time.Now()) {
+			if existing.EntityID == 0 {
+				return nil, ErrInProgress
+			}
+			return existing, nil
+		}
+		// The expired key is replaced, as it isn't deleted by the cleanup job yet.
+		if err := s.DeleteIdempotencyKey(ctx, &store.DeleteIdempotencyKey{ID: &existing.ID}); err != nil {
+			return nil, errors.Wrap(err, "failed to delete expired idempotency key")
+		}
+	}
+
+	reservation, err := s.CreateIdempotencyKey(ctx, &store.IdempotencyKey{
+		CreatorID:  creatorID,
+		EntityType: entityType,
+		Key:        key,
+	})
+	if err != nil {
+		// The key is reserved by a concurrent request.
+		if existing, findErr := s.GetIdempotencyKey(ctx, find); findErr == nil && existing != nil {
+			return nil, ErrInProgress
+		}
+		return nil, errors.Wrap(err, "failed to create idempotency key")
+	}
+	return reservation, nil
+}
+
+// Complete records the created entity of the reservation, so the retries return it.
+func Complete(ctx context.Context, s *store.Store, reservation *store.IdempotencyKey, entityID int32) error {
+	if reservation == nil {
+		return nil
+	}
+	if err := s.UpdateIdempotencyKey(ctx, &store.UpdateIdempotencyKey{
+		ID:       reservation.ID,
+		EntityID: entityID,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update idempotency key")
+	}
+	reservation.EntityID = entityID
+	return nil
+}
+
+// Release deletes the reservation if the entity wasn't created, so the request can be retried with the same key.
+// It's meant to be deferred after Begin.
+func Release(ctx context.Context, s *store.Store, reservation *store.IdempotencyKey) {
+	if reservation == nil || reservation.EntityID != 0 {
+		return
+	}
+	if err := s.DeleteIdempotencyKey(ctx, &store.DeleteIdempotencyKey{ID: &reservation.ID}); err != nil {
+		slog.Warn("Failed to release idempotency key", slog.Any("err", err))
+	}
+}
+
+// DeleteExpired deletes the keys older than the window.
+func DeleteExpired(ctx context.Context, s *store.Store) error {
+	createdTsBefore := time.Now().Add(-Window).Unix()
+	if err := s.DeleteIdempotencyKey(ctx, &store.DeleteIdempotencyKey{CreatedTsBefore: &createdTsBefore}); err != nil {
+		return errors.Wrap(err, "failed to delete expired idempotency keys")
+	}
+	return nil
+}
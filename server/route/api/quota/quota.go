@@ -0,0 +1,170 @@
+// Package quota limits the number of API requests made with an access token in a time window,
+// so runaway clients can't exhaust a shared instance.
+package quota
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// LimitHeader is the header of the number of requests allowed in a window.
+	LimitHeader = "X-RateLimit-Limit"
+	// RemainingHeader is the header of the number of requests left in the current window.
+	RemainingHeader = "X-RateLimit-Remaining"
+	// ResetHeader is the header of the unix time when the current window resets.
+	ResetHeader = "X-RateLimit-Reset"
+	// RetryAfterHeader is the header of the seconds to wait before retrying a rejected request.
+	RetryAfterHeader = "Retry-After"
+)
+
+// Result is the result of counting a request against the quota.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Headers returns the rate limit headers of the result.
+// The Retry-After header is only included if the request is rejected.
+func (r *Result) Headers(now time.Time) map[string]string {
+	headers := map[string]string{
+		LimitHeader:     strconv.Itoa(r.Limit),
+		RemainingHeader: strconv.Itoa(r.Remaining),
+		ResetHeader:     strconv.FormatInt(r.Reset.Unix(), 10),
+	}
+	if !r.Allowed {
+		retryAfter := int(r.Reset.Sub(now).Seconds() + 0.5)
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		headers[RetryAfterHeader] = strconv.Itoa(retryAfter)
+	}
+	return headers
+}
+
+// Counter counts the requests in the windows shared by the replicas, e.g. on Redis.
+type Counter interface {
+	// Increment increments the counter of the key, which expires after the window from the first increment.
+	// It returns the count and the time left until the counter expires.
+	Increment(ctx context.Context, key string, window time.Duration) (count int64, ttl time.Duration, err error)
+}
+
+type window struct {
+	count int
+	reset time.Time
+}
+
+// Limiter counts the requests of every key in fixed time windows.
+type Limiter struct {
+	limit  int
+	window time.Duration
+
+	mutex   sync.Mutex
+	windows map[string]*window
+	// lastSweep is the time when the expired windows were last removed.
+	lastSweep time.Time
+	now       func() time.Time
+	// counter is the counter shared by the replicas, nil if the windows are local.
+	counter Counter
+}
+
+// NewLimiter returns a limiter allowing limit requests per key in every window.
+// It returns nil if the limit or the window isn't positive, which disables the quota.
+func NewLimiter(limit int, windowDuration time.Duration) *Limiter {
+	if limit <= 0 || windowDuration <= 0 {
+		return nil
+	}
+	return &Limiter{
+		limit:   limit,
+		window:  windowDuration,
+		windows: map[string]*window{},
+		now:     time.Now,
+	}
+}
+
+// UseCounter makes the limiter count the requests with the counter shared by the replicas,
+// so a token has one quota however its requests are balanced. It must be called before the limiter is used.
+func (l *Limiter) UseCounter(counter Counter) {
+	l.counter = counter
+}
+
+// Allow counts a request of the key, and returns whether it's within the quota.
+// The request is counted locally if the shared counter fails, so the API stays available.
+func (l *Limiter) Allow(ctx context.Context, key string) *Result {
+	if l.counter != nil {
+		result, err := l.allowShared(ctx, key)
+		if err == nil {
+			return result
+		}
+		slog.Warn("Failed to count request in shared quota", slog.Any("err", err))
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.reset) {
+		w = &window{
+			reset: now.Add(l.window),
+		}
+		l.windows[key] = w
+	}
+
+	result := &Result{
+		Limit: l.limit,
+		Reset: w.reset,
+	}
+	if w.count >= l.limit {
+		return result
+	}
+	w.count++
+	result.Allowed = true
+	result.Remaining = l.limit - w.count
+	return result
+}
+
+func (l *Limiter) allowShared(ctx context.Context, key string) (*Result, error) {
+	// The keys are access tokens, only their hashes are shared.
+	hash := sha256.Sum256([]byte(key))
+	count, ttl, err := l.counter.Increment(ctx, "quota:"+hex.EncodeToString(hash[:]), l.window)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		Limit: l.limit,
+		Reset: l.now().Add(ttl),
+	}
+	if count > int64(l.limit) {
+		return result, nil
+	}
+	result.Allowed = true
+	result.Remaining = l.limit - int(count)
+	return result, nil
+}
+
+// Now returns the current time of the limiter.
+func (l *Limiter) Now() time.Time {
+	return l.now()
+}
+
+// sweep removes the expired windows once per window, so the keys of idle tokens don't pile up.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.reset) {
+			delete(l.windows, key)
+		}
+	}
+	l.lastSweep = now
+}
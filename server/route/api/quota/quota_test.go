@@ -0,0 +1,84 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	limiter := NewLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	result := limiter.Allow(ctx, "token")
+	require.True(t, result.Allowed)
+	require.Equal(t, 1, result.Remaining)
+	result = limiter.Allow(ctx, "token")
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	result = limiter.Allow(ctx, "token")
+	require.False(t, result.Allowed)
+	headers := result.Headers(now.Add(15 * time.Second))
+	require.Equal(t, "2", headers[LimitHeader])
+	require.Equal(t, "0", headers[RemainingHeader])
+	require.Equal(t, "1700000060", headers[ResetHeader])
+	require.Equal(t, "45", headers[RetryAfterHeader])
+
+	// Other tokens have their own quota.
+	require.True(t, limiter.Allow(ctx, "other").Allowed)
+
+	// The quota is reset in the next window.
+	now = now.Add(time.Minute)
+	result = limiter.Allow(ctx, "token")
+	require.True(t, result.Allowed)
+	require.Equal(t, 1, result.Remaining)
+	require.NotContains(t, result.Headers(now), RetryAfterHeader)
+}
+
+func TestNewLimiterDisabled(t *testing.T) {
+	require.Nil(t, NewLimiter(0, time.Minute))
+	require.Nil(t, NewLimiter(10, 0))
+}
+
+type fakeCounter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (c *fakeCounter) Increment(_ context.Context, key string, _ time.Duration) (int64, time.Duration, error) {
+	if c.err != nil {
+		return 0, 0, c.err
+	}
+	c.counts[key]++
+	return c.counts[key], 30 * time.Second, nil
+}
+
+func TestLimiterSharedCounter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	counter := &fakeCounter{counts: map[string]int64{}}
+	limiter := NewLimiter(1, time.Minute)
+	limiter.now = func() time.Time { return now }
+	limiter.UseCounter(counter)
+
+	result := limiter.Allow(ctx, "token")
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	require.Equal(t, now.Add(30*time.Second), result.Reset)
+	// The count is shared with the limiter of another replica.
+	other := NewLimiter(1, time.Minute)
+	other.UseCounter(counter)
+	require.False(t, other.Allow(ctx, "token").Allowed)
+	for key := range counter.counts {
+		require.NotContains(t, key, "token")
+	}
+
+	// The requests are counted locally if the counter fails.
+	counter.err = context.DeadlineExceeded
+	require.True(t, limiter.Allow(ctx, "token").Allowed)
+	require.False(t, limiter.Allow(ctx, "token").Allowed)
+}
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// createActivity records the activity of the user for the activity timeline.
+// The activity is an addition to the action, so a failure is only logged.
+func (s *APIV1Service) createActivity(ctx context.Context, creatorID int32, activityType store.ActivityType, payload *storepb.ActivityPayload) {
+	if _, err := s.Store.CreateActivity(ctx, &store.Activity{
+		CreatorID: creatorID,
+		Type:      activityType,
+		Level:     store.ActivityLevelInfo,
+		Payload:   payload,
+	}); err != nil {
+		slog.Warn("Failed to create activity", slog.String("type", activityType.String()), slog.Any("err", err))
+	}
+}
+
+func (s *APIV1Service) createMemoCreateActivity(ctx context.Context, memo *store.Memo) {
+	s.createActivity(ctx, memo.CreatorID, store.ActivityTypeMemoCreate, &storepb.ActivityPayload{
+		MemoCreate: &storepb.ActivityMemoCreatePayload{MemoId: memo.ID},
+	})
+}
+
+func (s *APIV1Service) createUserCreateActivity(ctx context.Context, user *store.User) {
+	s.createActivity(ctx, user.ID, store.ActivityTypeUserCreate, &storepb.ActivityPayload{
+		UserCreate: &storepb.ActivityUserCreatePayload{UserId: user.ID},
+	})
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/internal/auditlog"
+	"github.com/usememos/memos/store"
+)
+
+// auditTargetContextKey is the key of the username which a request acts on before the user is authenticated, e.g. of a sign-in.
+const auditTargetContextKey = "audit-target"
+
+// auditedRoutePrefixes are the prefixes of the routes whose writes are security events.
+var auditedRoutePrefixes = []string{
+	"/api/v1/auth/",
+	"/api/v1/user",
+	"/api/v1/idp",
+	"/api/v1/storage",
+	"/api/v1/system/",
+	"/api/v1/resource/gc",
+	"/api/v1/workspace/",
+	"/api/v1/debug/",
+	"/api/v1/job/",
+	"/api/v1/scheduler/",
+}
+
+// AuditMiddleware records the writes of the audited routes, and the workspace exports, to the audit log.
+func (s *APIV1Service) AuditMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !auditlog.Enabled() || !isAuditedRoute(c.Request().Method, c.Path()) {
+			return next(c)
+		}
+		err := next(c)
+
+		code := c.Response().Status
+		if err != nil {
+			code = http.StatusInternalServerError
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				code = httpErr.Code
+			}
+		}
+		outcome := auditlog.OutcomeSuccess
+		if code >= http.StatusBadRequest {
+			outcome = auditlog.OutcomeFailure
+		}
+		attrs := []slog.Attr{
+			slog.String("api", "v1"),
+			slog.String("route", c.Path()),
+			slog.Int("status", code),
+			slog.String("ip", c.RealIP()),
+		}
+		if userID, ok := c.Get(userIDContextKey).(int32); ok {
+			attrs = append(attrs, slog.Int("user_id", int(userID)))
+			if user, err := s.Store.GetUser(c.Request().Context(), &store.FindUser{ID: &userID}); err == nil && user != nil {
+				attrs = append(attrs, slog.String("username", user.Username))
+			}
+		}
+		if target, ok := c.Get(auditTargetContextKey).(string); ok && target != "" {
+			attrs = append(attrs, slog.String("target", target))
+		}
+		if params := c.ParamNames(); len(params) > 0 {
+			values := []string{}
+			for i, name := range params {
+				values = append(values, fmt.Sprintf("%s=%s", name, c.ParamValues()[i]))
+			}
+			attrs = append(attrs, slog.String("params", strings.Join(values, "&")))
+		}
+		auditlog.Record(c.Request().Context(), fmt.Sprintf("%s %s", c.Request().Method, c.Path()), outcome, attrs...)
+		return err
+	}
+}
+
+func isAuditedRoute(method, route string) bool {
+	if method == http.MethodGet {
+		// The exports are audited, they include the data of the whole workspace.
+		return route == "/api/v1/workspace/export"
+	}
+	if method == http.MethodHead || method == http.MethodOptions {
+		return false
+	}
+	for _, prefix := range auditedRoutePrefixes {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+	return false
+}
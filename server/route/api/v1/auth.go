@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/plugin/idp"
 	"github.com/usememos/memos/plugin/idp/oauth2"
@@ -36,6 +37,8 @@ type SSOSignIn struct {
 type SignUp struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// InviteCode is the code of an invitation, which is required if the signup is invite-only.
+	InviteCode string `json:"inviteCode"`
 }
 
 func (s *APIV1Service) registerAuthRoutes(g *echo.Group) {
@@ -72,6 +75,7 @@ func (s *APIV1Service) SignIn(c echo.Context) error {
 	if err := json.NewDecoder(c.Request().Body).Decode(signin); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted signin request").SetInternal(err)
 	}
+	c.Set(auditTargetContextKey, signin.Username)
 
 	user, err := s.Store.GetUser(ctx, &store.FindUser{
 		Username: &signin.Username,
@@ -204,7 +208,10 @@ func (s *APIV1Service) SignInSSO(c echo.Context) error {
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create user").SetInternal(err)
 		}
+		s.createUserCreateActivity(ctx, user)
+		s.eventBroker.Publish(event.NewUserEvent(event.UserCreated, user.ID))
 	}
+	c.Set(auditTargetContextKey, user.Username)
 	if user.RowStatus == store.Archived {
 		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("User has been archived with username %s", userInfo.Identifier))
 	}
@@ -231,7 +238,7 @@ func (s *APIV1Service) SignInSSO(c echo.Context) error {
 //	@Router		/api/v1/auth/signout [POST]
 func (s *APIV1Service) SignOut(c echo.Context) error {
 	accessToken := findAccessToken(c)
-	userID, _ := getUserIDFromAccessToken(accessToken, s.Secret)
+	userID, _ := GetUserIDFromAccessToken(accessToken, s.Secret)
 
 	err := removeAccessTokenAndCookies(c, s.Store, userID, accessToken)
 	if err != nil {
@@ -250,7 +257,7 @@ func (s *APIV1Service) SignOut(c echo.Context) error {
 //	@Param		body	body		SignUp		true	"Sign-up object"
 //	@Success	200		{object}	store.User	"User information"
 //	@Failure	400		{object}	nil			"Malformatted signup request | Failed to find users"
-//	@Failure	401		{object}	nil			"signup is disabled"
+//	@Failure	401		{object}	nil			"signup is disabled | an invitation is required to sign up | invalid invitation code"
 //	@Failure	403		{object}	nil			"Forbidden"
 //	@Failure	404		{object}	nil			"Not found"
 //	@Failure	500		{object}	nil			"Failed to find system setting | Failed to unmarshal system setting allow signup | Failed to generate password hash | Failed to create user | Failed to generate tokens | Failed to create activity"
@@ -261,6 +268,7 @@ func (s *APIV1Service) SignUp(c echo.Context) error {
 	if err := json.NewDecoder(c.Request().Body).Decode(signup); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted signup request").SetInternal(err)
 	}
+	c.Set(auditTargetContextKey, signup.Username)
 
 	hostUserType := store.RoleHost
 	existedHostUsers, err := s.Store.ListUsers(ctx, &store.FindUser{
@@ -293,6 +301,27 @@ func (s *APIV1Service) SignUp(c echo.Context) error {
 		if workspaceGeneralSetting.DisallowPasswordLogin {
 			return echo.NewHTTPError(http.StatusUnauthorized, "password login is deactivated").SetInternal(err)
 		}
+		if signup.InviteCode == "" && workspaceGeneralSetting.InviteOnlySignup {
+			return echo.NewHTTPError(http.StatusUnauthorized, "an invitation is required to sign up")
+		}
+		if signup.InviteCode != "" {
+			// The username is checked first, so the invitation isn't used by a failed signup.
+			existingUser, err := s.Store.GetUser(ctx, &store.FindUser{Username: &signup.Username})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+			}
+			if existingUser != nil {
+				return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Username %s is taken", signup.Username))
+			}
+			invitation, err := s.Store.RedeemInvitation(ctx, signup.InviteCode)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to redeem invitation").SetInternal(err)
+			}
+			if invitation == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid invitation code")
+			}
+			userCreate.Role = invitation.Role
+		}
 	}
 
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(signup.Password), bcrypt.DefaultCost)
@@ -305,6 +334,8 @@ func (s *APIV1Service) SignUp(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create user").SetInternal(err)
 	}
+	s.createUserCreateActivity(ctx, user)
+	s.eventBroker.Publish(event.NewUserEvent(event.UserCreated, user.ID))
 	accessToken, err := auth.GenerateAccessToken(user.Username, user.ID, time.Now().Add(auth.AccessTokenDuration), []byte(s.Secret))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to generate tokens, err: %s", err)).SetInternal(err)
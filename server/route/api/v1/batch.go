@@ -0,0 +1,567 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/event"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// maxBatchOperations is the max number of operations in a batch request.
+const maxBatchOperations = 1000
+
+// BatchMethod is the method of a batch operation.
+type BatchMethod string
+
+const (
+	BatchMethodCreate BatchMethod = "CREATE"
+	BatchMethodUpdate BatchMethod = "UPDATE"
+	BatchMethodDelete BatchMethod = "DELETE"
+)
+
+type BatchMemoOperation struct {
+	Method BatchMethod `json:"method"`
+	// ID is the id of the memo to update or delete.
+	ID int32 `json:"id"`
+	// Create is the memo to create.
+	Create *CreateMemoRequest `json:"create"`
+	// Patch is the patch of the memo to update.
+	Patch *PatchMemoRequest `json:"patch"`
+}
+
+type BatchMemoRequest struct {
+	Operations []*BatchMemoOperation `json:"operations"`
+}
+
+type BatchCreateResourceRequest struct {
+	Filename     string `json:"filename"`
+	Type         string `json:"type"`
+	ExternalLink string `json:"externalLink"`
+	// Blob is the base64 encoded content of the file, it's ignored if the external link is set.
+	Blob   []byte `json:"blob"`
+	MemoID *int32 `json:"memoId"`
+}
+
+type BatchResourceOperation struct {
+	Method BatchMethod `json:"method"`
+	// ID is the id of the resource to update or delete.
+	ID int32 `json:"id"`
+	// Create is the resource to create.
+	Create *BatchCreateResourceRequest `json:"create"`
+	// Patch is the patch of the resource to update.
+	Patch *UpdateResourceRequest `json:"patch"`
+}
+
+type BatchResourceRequest struct {
+	Operations []*BatchResourceOperation `json:"operations"`
+}
+
+type BatchResult struct {
+	// ID is the id of the created, updated or deleted item.
+	ID    int32  `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	// Committed is true if all operations succeeded and the changes are saved.
+	// Otherwise none of the changes are saved, and the result of the failed operation has the error.
+	Committed bool           `json:"committed"`
+	Results   []*BatchResult `json:"results"`
+}
+
+// BatchMemos godoc
+//
+//	@Summary		Create, update and delete memos in a batch
+//	@Description	The operations are executed in order in a transaction, so either all or none of them are saved.
+//	@Description	Webhooks and comment notifications are not sent for batch operations.
+//	@Tags			memo
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		BatchMemoRequest	true	"Request object."
+//	@Success		200		{object}	BatchResponse		"Committed batch results"
+//	@Failure		400		{object}	BatchResponse		"Malformatted batch memo request | Too many operations, up to %d | Failed batch results"
+//	@Failure		401		{object}	nil					"Missing user in session"
+//	@Failure		500		{object}	nil					"Failed to find user setting | Failed to find system setting | Failed to unmarshal system setting | Failed to find user"
+//	@Router			/api/v1/memo/batch [POST]
+func (s *APIV1Service) BatchMemos(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+
+	request := &BatchMemoRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted batch memo request").SetInternal(err)
+	}
+	if len(request.Operations) > maxBatchOperations {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Too many operations, up to %d", maxBatchOperations))
+	}
+
+	// Settings are resolved before the transaction, so they aren't read for every operation.
+	defaultVisibility := Private
+	userMemoVisibilitySetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_MEMO_VISIBILITY,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user setting").SetInternal(err)
+	}
+	if userMemoVisibilitySetting != nil {
+		defaultVisibility = Visibility(userMemoVisibilitySetting.GetMemoVisibility())
+	}
+	enforcePrivate := false
+	disablePublicMemosSystemSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
+		Name: SystemSettingDisablePublicMemosName.String(),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find system setting").SetInternal(err)
+	}
+	if disablePublicMemosSystemSetting != nil {
+		disablePublicMemos := false
+		if err := json.Unmarshal([]byte(disablePublicMemosSystemSetting.Value), &disablePublicMemos); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to unmarshal system setting").SetInternal(err)
+		}
+		if disablePublicMemos {
+			user, err := s.Store.GetUser(ctx, &store.FindUser{
+				ID: &userID,
+			})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+			}
+			// Enforce normal user to create private memo if public memos are disabled.
+			enforcePrivate = user != nil && user.Role == store.RoleUser
+		}
+	}
+
+	response := &BatchResponse{
+		Results: make([]*BatchResult, len(request.Operations)),
+	}
+	for i := range response.Results {
+		response.Results[i] = &BatchResult{}
+	}
+	events := []*event.Event{}
+	deletedResources := []*store.Resource{}
+	err = s.Store.RunInTx(ctx, func(txStore *store.Store) error {
+		for i, operation := range request.Operations {
+			result := response.Results[i]
+			memo, err := s.executeBatchMemoOperation(ctx, txStore, userID, operation, defaultVisibility, enforcePrivate, &deletedResources)
+			if err != nil {
+				result.Error = err.Error()
+				return err
+			}
+			result.ID = memo.ID
+			switch operation.Method {
+			case BatchMethodCreate:
+				events = append(events, event.NewMemoEvent(event.MemoCreated, memo))
+			case BatchMethodUpdate:
+				events = append(events, event.NewMemoEvent(event.MemoUpdated, memo))
+			case BatchMethodDelete:
+				events = append(events, event.NewMemoEvent(event.MemoDeleted, memo))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		markBatchRolledBack(response.Results)
+		return c.JSON(http.StatusBadRequest, response)
+	}
+
+	response.Committed = true
+	s.deleteBatchResourceFiles(ctx, deletedResources)
+	for _, e := range events {
+		if e.Type == event.MemoCreated {
+			s.createActivity(ctx, e.OwnerID, store.ActivityTypeMemoCreate, &storepb.ActivityPayload{
+				MemoCreate: &storepb.ActivityMemoCreatePayload{MemoId: e.MemoID},
+			})
+		}
+		s.eventBroker.Publish(e)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// executeBatchMemoOperation executes the operation with the store bound to the batch transaction,
+// and returns the created, updated or deleted memo.
+func (s *APIV1Service) executeBatchMemoOperation(ctx context.Context, txStore *store.Store, userID int32, operation *BatchMemoOperation, defaultVisibility Visibility, enforcePrivate bool, deletedResources *[]*store.Resource) (*store.Memo, error) {
+	switch operation.Method {
+	case BatchMethodCreate:
+		request := operation.Create
+		if request == nil {
+			return nil, errors.New("create is required")
+		}
+		if len(request.Content) > maxContentLength {
+			return nil, errors.New("content size overflow, up to 1MB")
+		}
+		if request.Visibility == "" {
+			request.Visibility = defaultVisibility
+		}
+		if enforcePrivate {
+			request.Visibility = Private
+		}
+		request.CreatorID = userID
+		memo, err := txStore.CreateMemo(ctx, convertCreateMemoRequestToMemoMessage(request))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create memo")
+		}
+		if err := attachBatchMemoResources(ctx, txStore, userID, memo.ID, request.ResourceIDList); err != nil {
+			return nil, err
+		}
+		for _, relation := range request.RelationList {
+			if _, err := txStore.UpsertMemoRelation(ctx, &store.MemoRelation{
+				MemoID:        memo.ID,
+				RelatedMemoID: relation.RelatedMemoID,
+				Type:          store.MemoRelationType(relation.Type),
+			}); err != nil {
+				return nil, errors.Wrap(err, "failed to upsert memo relation")
+			}
+		}
+		return memo, nil
+	case BatchMethodUpdate:
+		request := operation.Patch
+		if request == nil {
+			return nil, errors.New("patch is required")
+		}
+		memo, err := findBatchMemo(ctx, txStore, userID, operation.ID)
+		if err != nil {
+			return nil, err
+		}
+		if request.Content != nil && len(*request.Content) > maxContentLength {
+			return nil, errors.New("content size overflow, up to 1MB")
+		}
+		currentTs := time.Now().Unix()
+		update := &store.UpdateMemo{
+			ID:        memo.ID,
+			CreatedTs: request.CreatedTs,
+			UpdatedTs: &currentTs,
+			Content:   request.Content,
+		}
+		if request.RowStatus != nil {
+			rowStatus := store.RowStatus(request.RowStatus.String())
+			update.RowStatus = &rowStatus
+		}
+		if request.Visibility != nil {
+			visibility := store.Visibility(request.Visibility.String())
+			if enforcePrivate {
+				visibility = store.Private
+			}
+			update.Visibility = &visibility
+		}
+		if err := txStore.UpdateMemo(ctx, update); err != nil {
+			return nil, errors.Wrap(err, "failed to update memo")
+		}
+
+		if request.ResourceIDList != nil {
+			resources, err := txStore.ListResources(ctx, &store.FindResource{MemoID: &memo.ID})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list resources")
+			}
+			originResourceIDList := []int32{}
+			for _, resource := range resources {
+				originResourceIDList = append(originResourceIDList, resource.ID)
+			}
+			addedResourceIDList, removedResourceIDList := getIDListDiff(originResourceIDList, request.ResourceIDList)
+			if err := attachBatchMemoResources(ctx, txStore, userID, memo.ID, addedResourceIDList); err != nil {
+				return nil, err
+			}
+			for _, resourceID := range removedResourceIDList {
+				resource, err := txStore.GetResource(ctx, &store.FindResource{ID: &resourceID})
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to find resource")
+				}
+				if err := txStore.DeleteResource(ctx, &store.DeleteResource{ID: resourceID, KeepFiles: true}); err != nil {
+					return nil, errors.Wrap(err, "failed to delete resource")
+				}
+				*deletedResources = append(*deletedResources, resource)
+			}
+		}
+		if request.RelationList != nil {
+			relations, err := txStore.ListMemoRelations(ctx, &store.FindMemoRelation{MemoID: &memo.ID})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list memo relations")
+			}
+			originRelationList := []*MemoRelation{}
+			for _, relation := range relations {
+				originRelationList = append(originRelationList, convertMemoRelationFromStore(relation))
+			}
+			patchRelationList := []*MemoRelation{}
+			for _, relation := range request.RelationList {
+				patchRelationList = append(patchRelationList, &MemoRelation{
+					MemoID:        memo.ID,
+					RelatedMemoID: relation.RelatedMemoID,
+					Type:          relation.Type,
+				})
+			}
+			addedRelationList, removedRelationList := getMemoRelationListDiff(originRelationList, patchRelationList)
+			for _, relation := range addedRelationList {
+				if _, err := txStore.UpsertMemoRelation(ctx, relation); err != nil {
+					return nil, errors.Wrap(err, "failed to upsert memo relation")
+				}
+			}
+			for _, relation := range removedRelationList {
+				if err := txStore.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{
+					MemoID:        &relation.MemoID,
+					RelatedMemoID: &relation.RelatedMemoID,
+					Type:          &relation.Type,
+				}); err != nil {
+					return nil, errors.Wrap(err, "failed to delete memo relation")
+				}
+			}
+		}
+
+		memo, err = txStore.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find memo")
+		}
+		return memo, nil
+	case BatchMethodDelete:
+		memo, err := findBatchMemo(ctx, txStore, userID, operation.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := txStore.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}); err != nil {
+			return nil, errors.Wrap(err, "failed to delete memo")
+		}
+		return memo, nil
+	default:
+		return nil, errors.Errorf("invalid method %q", operation.Method)
+	}
+}
+
+func findBatchMemo(ctx context.Context, txStore *store.Store, userID int32, memoID int32) (*store.Memo, error) {
+	memo, err := txStore.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find memo")
+	}
+	if memo == nil {
+		return nil, errors.Errorf("memo not found: %d", memoID)
+	}
+	if memo.CreatorID != userID {
+		return nil, errors.Errorf("unauthorized to change memo %d", memoID)
+	}
+	return memo, nil
+}
+
+func attachBatchMemoResources(ctx context.Context, txStore *store.Store, userID int32, memoID int32, resourceIDList []int32) error {
+	for _, resourceID := range resourceIDList {
+		resource, err := txStore.GetResource(ctx, &store.FindResource{ID: &resourceID, CreatorID: &userID})
+		if err != nil {
+			return errors.Wrap(err, "failed to find resource")
+		}
+		if resource == nil {
+			return errors.Errorf("resource not found: %d", resourceID)
+		}
+		if _, err := txStore.UpdateResource(ctx, &store.UpdateResource{
+			ID:     resourceID,
+			MemoID: &memoID,
+		}); err != nil {
+			return errors.Wrap(err, "failed to attach resource")
+		}
+	}
+	return nil
+}
+
+// BatchResources godoc
+//
+//	@Summary		Create, update and delete resources in a batch
+//	@Description	The operations are executed in order in a transaction, so either all or none of them are saved.
+//	@Description	The files of created resources are saved before the transaction, they're garbage collected if the transaction fails.
+//	@Tags			resource
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		BatchResourceRequest	true	"Request object."
+//	@Success		200		{object}	BatchResponse			"Committed batch results"
+//	@Failure		400		{object}	BatchResponse			"Malformatted batch resource request | Too many operations, up to %d | Failed batch results"
+//	@Failure		401		{object}	nil						"Missing user in session"
+//	@Failure		500		{object}	nil						"Failed to get upload limit"
+//	@Router			/api/v1/resource/batch [POST]
+func (s *APIV1Service) BatchResources(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+
+	request := &BatchResourceRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted batch resource request").SetInternal(err)
+	}
+	if len(request.Operations) > maxBatchOperations {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Too many operations, up to %d", maxBatchOperations))
+	}
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+
+	response := &BatchResponse{
+		Results: make([]*BatchResult, len(request.Operations)),
+	}
+	for i := range response.Results {
+		response.Results[i] = &BatchResult{}
+	}
+	deletedResources := []*store.Resource{}
+	err = s.Store.RunInTx(ctx, func(txStore *store.Store) error {
+		for i, operation := range request.Operations {
+			result := response.Results[i]
+			resource, err := s.executeBatchResourceOperation(ctx, txStore, userID, operation, uploadLimit)
+			if err != nil {
+				result.Error = err.Error()
+				return err
+			}
+			result.ID = resource.ID
+			if operation.Method == BatchMethodDelete {
+				deletedResources = append(deletedResources, resource)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		markBatchRolledBack(response.Results)
+		return c.JSON(http.StatusBadRequest, response)
+	}
+
+	response.Committed = true
+	s.deleteBatchResourceFiles(ctx, deletedResources)
+	return c.JSON(http.StatusOK, response)
+}
+
+// deleteBatchResourceFiles deletes the files of the resources deleted in a batch,
+// which are only deleted after the transaction is committed.
+func (s *APIV1Service) deleteBatchResourceFiles(ctx context.Context, resources []*store.Resource) {
+	for _, resource := range resources {
+		if err := DeleteResourceBlob(ctx, s.Store, resource); err != nil {
+			slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+		}
+		s.Store.DeleteResourceFiles(resource)
+	}
+}
+
+// executeBatchResourceOperation executes the operation with the store bound to the batch transaction,
+// and returns the created, updated or deleted resource.
+func (s *APIV1Service) executeBatchResourceOperation(ctx context.Context, txStore *store.Store, userID int32, operation *BatchResourceOperation, uploadLimit *UploadLimit) (*store.Resource, error) {
+	switch operation.Method {
+	case BatchMethodCreate:
+		request := operation.Create
+		if request == nil {
+			return nil, errors.New("create is required")
+		}
+		if request.Filename == "" {
+			return nil, errors.New("filename is required")
+		}
+		if !uploadLimit.IsTypeAllowed(request.Type) {
+			return nil, errors.Errorf("file type %s is not allowed", request.Type)
+		}
+		create := &store.Resource{
+			UID:          shortuuid.New(),
+			CreatorID:    userID,
+			Filename:     request.Filename,
+			Type:         request.Type,
+			ExternalLink: request.ExternalLink,
+			MemoID:       request.MemoID,
+		}
+		if request.MemoID != nil {
+			if _, err := findBatchMemo(ctx, txStore, userID, *request.MemoID); err != nil {
+				return nil, err
+			}
+		}
+		if request.ExternalLink != "" {
+			linkURL, err := url.Parse(request.ExternalLink)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid external link")
+			}
+			if linkURL.Scheme != "http" && linkURL.Scheme != "https" {
+				return nil, errors.New("invalid external link scheme")
+			}
+		} else {
+			create.Size = int64(len(request.Blob))
+			if create.Size > uploadLimit.MaxSizeBytes {
+				return nil, errors.Errorf("file size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte)
+			}
+			result, err := ScanResourceBlob(ctx, s.Store, bytes.NewReader(request.Blob))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to scan file")
+			}
+			if result != nil && result.Infected {
+				return nil, errors.Errorf("file is infected: %s", result.Signature)
+			}
+			if err := SaveResourceBlob(ctx, s.Store, create, bytes.NewReader(request.Blob)); err != nil {
+				return nil, errors.Wrap(err, "failed to save resource")
+			}
+		}
+		resource, err := txStore.CreateResource(ctx, create)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create resource")
+		}
+		return resource, nil
+	case BatchMethodUpdate:
+		request := operation.Patch
+		if request == nil {
+			return nil, errors.New("patch is required")
+		}
+		if _, err := findBatchResource(ctx, txStore, userID, operation.ID); err != nil {
+			return nil, err
+		}
+		currentTs := time.Now().Unix()
+		resource, err := txStore.UpdateResource(ctx, &store.UpdateResource{
+			ID:        operation.ID,
+			UpdatedTs: &currentTs,
+			Filename:  request.Filename,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to update resource")
+		}
+		return resource, nil
+	case BatchMethodDelete:
+		resource, err := findBatchResource(ctx, txStore, userID, operation.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := txStore.DeleteResource(ctx, &store.DeleteResource{ID: resource.ID, KeepFiles: true}); err != nil {
+			return nil, errors.Wrap(err, "failed to delete resource")
+		}
+		return resource, nil
+	default:
+		return nil, errors.Errorf("invalid method %q", operation.Method)
+	}
+}
+
+func findBatchResource(ctx context.Context, txStore *store.Store, userID int32, resourceID int32) (*store.Resource, error) {
+	resource, err := txStore.GetResource(ctx, &store.FindResource{ID: &resourceID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find resource")
+	}
+	if resource == nil {
+		return nil, errors.Errorf("resource not found: %d", resourceID)
+	}
+	if resource.CreatorID != userID {
+		return nil, errors.Errorf("unauthorized to change resource %d", resourceID)
+	}
+	return resource, nil
+}
+
+// markBatchRolledBack clears the ids of the results of a rolled back batch,
+// and marks the operations after the failed one as skipped.
+func markBatchRolledBack(results []*BatchResult) {
+	failed := false
+	for _, result := range results {
+		result.ID = 0
+		if failed {
+			result.Error = "skipped"
+		}
+		if result.Error != "" {
+			failed = true
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/server/route/api/etag"
+)
+
+// checkNotModified sets the ETag and Last-Modified headers of the entity version,
+// and returns true if the client already has it, i.e. a 304 should be returned.
+func checkNotModified(c echo.Context, tag string, updatedTs int64) bool {
+	header := c.Response().Header()
+	header.Set(echo.HeaderLastModified, etag.LastModified(updatedTs))
+	header.Set("ETag", tag)
+	// The representation depends on the session, so shared caches must not mix them up.
+	header.Add(echo.HeaderVary, echo.HeaderAuthorization+", "+echo.HeaderCookie)
+	request := c.Request()
+	return etag.IsNotModified(request.Header.Get(etag.IfNoneMatchHeader), request.Header.Get(etag.IfModifiedSinceHeader), tag, updatedTs)
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/internal/debug"
+)
+
+func (s *APIV1Service) registerDebugRoutes(g *echo.Group) {
+	if !s.Profile.Pprof {
+		return
+	}
+	handler := http.StripPrefix("/api/v1", debug.NewHandler(filepath.Join(s.Profile.Data, "debug")))
+	g.Any("/debug/*", s.ServeDebug(handler))
+}
+
+// ServeDebug godoc
+//
+//	@Summary		Serve the runtime profiles of the server to the host
+//	@Description	The net/http/pprof endpoints are served under /api/v1/debug/pprof/ when the server is started with --pprof.
+//	@Description	POST /api/v1/debug/dump writes the stacks of the goroutines and the heap profile to the debug folder of the data directory.
+//	@Tags			system
+//	@Produce		json
+//	@Success		200	{object}	debug.Dump	"Written dump files"
+//	@Failure		401	{object}	nil			"Missing user in session | Unauthorized"
+//	@Failure		500	{object}	nil			"Failed to find user | Failed to write dump"
+//	@Router			/api/v1/debug/dump [POST]
+func (s *APIV1Service) ServeDebug(handler http.Handler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, err := s.getCurrentHostUser(c); err != nil {
+			return err
+		}
+		handler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// eventStreamHeartbeatInterval is the interval of heartbeats sent on idle event streams,
+// so proxies don't close the connections.
+const eventStreamHeartbeatInterval = 30 * time.Second
+
+func (s *APIV1Service) registerEventRoutes(g *echo.Group) {
+	g.GET("/event/stream", s.StreamEvents)
+}
+
+// StreamEvents godoc
+//
+//	@Summary		Stream events with server-sent events
+//	@Description	Events are memo.created, memo.updated, memo.deleted, memo.comment.created, reaction.created, reaction.deleted and inbox.created.
+//	@Description	Only the events of the memos and inbox messages visible to the current user are sent.
+//	@Tags			event
+//	@Produce		text/event-stream
+//	@Success		200	{object}	nil	"Event stream"
+//	@Failure		401	{object}	nil	"Missing user in session"
+//	@Failure		503	{object}	nil	"Event stream is not available"
+//	@Router			/api/v1/event/stream [GET]
+func (s *APIV1Service) StreamEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	if s.eventBroker == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Event stream is not available")
+	}
+
+	subscription := s.eventBroker.Subscribe()
+	defer s.eventBroker.Unsubscribe(subscription)
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Disable response buffering of nginx.
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	ticker := time.NewTicker(eventStreamHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := fmt.Fprint(response, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			response.Flush()
+		case e, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			visible, err := e.IsVisibleTo(ctx, s.Store, userID)
+			if err != nil {
+				slog.Warn("Failed to check event visibility", slog.Any("err", err))
+				continue
+			}
+			if !visible {
+				continue
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				slog.Warn("Failed to marshal event", slog.Any("err", err))
+				continue
+			}
+			if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return nil
+			}
+			response.Flush()
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxGraphQLQueryDepth is the max nesting depth of selections in a GraphQL query.
+	// It keeps deeply nested relations from fanning out into too many database queries.
+	maxGraphQLQueryDepth = 8
+	// maxGraphQLListLimit is the max number of memos returned by a GraphQL list query.
+	maxGraphQLListLimit = 100
+)
+
+type graphQLContextKey int
+
+const (
+	// graphQLUserIDContextKey is the context key of the current user id in GraphQL resolvers.
+	graphQLUserIDContextKey graphQLContextKey = iota
+)
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func (s *APIV1Service) registerGraphQLRoutes(g *echo.Group) {
+	schema, err := s.newGraphQLSchema()
+	if err != nil {
+		// The schema is static, so it only fails when it's defined incorrectly.
+		panic(errors.Wrap(err, "failed to create GraphQL schema"))
+	}
+	s.graphQLSchema = &schema
+	g.POST("/graphql", s.ExecuteGraphQL)
+}
+
+// ExecuteGraphQL godoc
+//
+//	@Summary		Execute a GraphQL query
+//	@Description	Query memos with their creator, resources, relations and reactions in one request.
+//	@Tags			graphql
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		GraphQLRequest	true	"GraphQL request"
+//	@Success		200		{object}	nil				"GraphQL result"
+//	@Failure		400		{object}	nil				"Malformatted GraphQL request | Query is required | Query depth exceeds the limit of %d"
+//	@Failure		401		{object}	nil				"Missing user in session"
+//	@Router			/api/v1/graphql [POST]
+func (s *APIV1Service) ExecuteGraphQL(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+
+	request := &GraphQLRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted GraphQL request").SetInternal(err)
+	}
+	if request.Query == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Query is required")
+	}
+	if document, err := parser.Parse(parser.ParseParams{Source: request.Query}); err == nil {
+		if getGraphQLQueryDepth(document) > maxGraphQLQueryDepth {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query depth exceeds the limit of %d", maxGraphQLQueryDepth))
+		}
+	}
+
+	// Syntax errors are reported in the result like any other GraphQL error.
+	result := graphql.Do(graphql.Params{
+		Schema:         *s.graphQLSchema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        context.WithValue(ctx, graphQLUserIDContextKey, userID),
+	})
+	return c.JSON(http.StatusOK, result)
+}
+
+func (s *APIV1Service) newGraphQLSchema() (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"username":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"nickname":  &graphql.Field{Type: graphql.String},
+			"avatarUrl": &graphql.Field{Type: graphql.String},
+			"role":      &graphql.Field{Type: graphql.String},
+			"rowStatus": &graphql.Field{Type: graphql.String},
+			"createdTs": &graphql.Field{Type: graphql.Int},
+			"updatedTs": &graphql.Field{Type: graphql.Int},
+			"email": &graphql.Field{
+				Type: graphql.String,
+				// The email is only visible to the user and the host.
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					user := p.Source.(*store.User)
+					currentUser, err := s.getGraphQLCurrentUser(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					if currentUser.ID != user.ID && currentUser.Role != store.RoleHost {
+						return nil, nil
+					}
+					return user.Email, nil
+				},
+			},
+		},
+	})
+	resolveCreator := func(ctx context.Context, creatorID int32) (any, error) {
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &creatorID})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find creator")
+		}
+		return user, nil
+	}
+
+	resourceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Resource",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"uid":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"filename":     &graphql.Field{Type: graphql.String},
+			"type":         &graphql.Field{Type: graphql.String},
+			"size":         &graphql.Field{Type: graphql.Int},
+			"externalLink": &graphql.Field{Type: graphql.String},
+			"createdTs":    &graphql.Field{Type: graphql.Int},
+			"updatedTs":    &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	reactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Reaction",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"reactionType": &graphql.Field{Type: graphql.String},
+			"createdTs":    &graphql.Field{Type: graphql.Int},
+			"creator": &graphql.Field{
+				Type: userType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolveCreator(p.Context, p.Source.(*storepb.Reaction).CreatorId)
+				},
+			},
+		},
+	})
+
+	var memoType *graphql.Object
+	memoRelationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MemoRelation",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"memoId":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"relatedMemoId": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"type":          &graphql.Field{Type: graphql.String},
+				"relatedMemo": &graphql.Field{
+					Type: memoType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return s.getGraphQLMemo(p.Context, p.Source.(*store.MemoRelation).RelatedMemoID)
+					},
+				},
+			}
+		}),
+	})
+
+	memoType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Memo",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"uid":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"content":    &graphql.Field{Type: graphql.String},
+			"visibility": &graphql.Field{Type: graphql.String},
+			"pinned":     &graphql.Field{Type: graphql.Boolean},
+			"rowStatus":  &graphql.Field{Type: graphql.String},
+			"createdTs":  &graphql.Field{Type: graphql.Int},
+			"updatedTs":  &graphql.Field{Type: graphql.Int},
+			"creator": &graphql.Field{
+				Type: userType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolveCreator(p.Context, p.Source.(*store.Memo).CreatorID)
+				},
+			},
+			"resources": &graphql.Field{
+				Type: graphql.NewList(resourceType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					memo := p.Source.(*store.Memo)
+					return s.Store.ListResources(p.Context, &store.FindResource{MemoID: &memo.ID})
+				},
+			},
+			"relations": &graphql.Field{
+				Type: graphql.NewList(memoRelationType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					memo := p.Source.(*store.Memo)
+					return s.Store.ListMemoRelations(p.Context, &store.FindMemoRelation{MemoID: &memo.ID})
+				},
+			},
+			"reactions": &graphql.Field{
+				Type: graphql.NewList(reactionType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					memo := p.Source.(*store.Memo)
+					// The content id of memo reactions is the memo name in API v2.
+					contentID := fmt.Sprintf("memos/%d", memo.ID)
+					return s.Store.ListReactions(p.Context, &store.FindReaction{ContentID: &contentID})
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type: userType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.getGraphQLCurrentUser(p.Context)
+				},
+			},
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id":       &graphql.ArgumentConfig{Type: graphql.Int},
+					"username": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					find := &store.FindUser{}
+					if id, ok := p.Args["id"].(int); ok {
+						userID := int32(id)
+						find.ID = &userID
+					}
+					if username, ok := p.Args["username"].(string); ok {
+						find.Username = &username
+					}
+					if find.ID == nil && find.Username == nil {
+						return nil, errors.New("id or username is required")
+					}
+					return s.Store.GetUser(p.Context, find)
+				},
+			},
+			"memo": &graphql.Field{
+				Type: memoType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.getGraphQLMemo(p.Context, int32(p.Args["id"].(int)))
+				},
+			},
+			"memos": &graphql.Field{
+				Type: graphql.NewList(memoType),
+				Args: graphql.FieldConfigArgument{
+					"creatorId": &graphql.ArgumentConfig{Type: graphql.Int},
+					"rowStatus": &graphql.ArgumentConfig{Type: graphql.String},
+					"tag":       &graphql.ArgumentConfig{Type: graphql.String},
+					"content":   &graphql.ArgumentConfig{Type: graphql.String},
+					"limit":     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: maxGraphQLListLimit},
+					"offset":    &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: s.resolveGraphQLMemos,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+func (s *APIV1Service) resolveGraphQLMemos(p graphql.ResolveParams) (any, error) {
+	currentUserID, _ := p.Context.Value(graphQLUserIDContextKey).(int32)
+	find := &store.FindMemo{
+		OrderByPinned: true,
+	}
+	// Follow the visibility rules of `GET /api/v1/memo`.
+	visibilityList := []store.Visibility{store.Public, store.Protected}
+	if creatorID, ok := p.Args["creatorId"].(int); ok && int32(creatorID) != currentUserID {
+		creatorID := int32(creatorID)
+		find.CreatorID = &creatorID
+	} else {
+		find.CreatorID = &currentUserID
+		visibilityList = append(visibilityList, store.Private, store.Group)
+	}
+	find.VisibilityList = visibilityList
+
+	if rowStatus, ok := p.Args["rowStatus"].(string); ok {
+		rowStatus := store.RowStatus(rowStatus)
+		find.RowStatus = &rowStatus
+	}
+	if tag, ok := p.Args["tag"].(string); ok && tag != "" {
+		find.ContentSearch = append(find.ContentSearch, "#"+tag)
+	}
+	if content, ok := p.Args["content"].(string); ok && content != "" {
+		find.ContentSearch = append(find.ContentSearch, content)
+		find.SearchResourceText = true
+	}
+	limit, _ := p.Args["limit"].(int)
+	if limit <= 0 || limit > maxGraphQLListLimit {
+		limit = maxGraphQLListLimit
+	}
+	find.Limit = &limit
+	if offset, ok := p.Args["offset"].(int); ok {
+		find.Offset = &offset
+	}
+
+	memoDisplayWithUpdatedTs, err := s.getMemoDisplayWithUpdatedTsSettingValue(p.Context)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get memo display with updated ts setting value")
+	}
+	find.OrderByUpdatedTs = memoDisplayWithUpdatedTs
+	return s.Store.ListMemos(p.Context, find)
+}
+
+// getGraphQLMemo returns the memo if the current user can access it, otherwise nil.
+func (s *APIV1Service) getGraphQLMemo(ctx context.Context, memoID int32) (*store.Memo, error) {
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find memo")
+	}
+	if memo == nil {
+		return nil, nil
+	}
+	currentUserID, _ := ctx.Value(graphQLUserIDContextKey).(int32)
+	if visible, err := s.Store.IsMemoVisibleTo(ctx, memo, currentUserID); err != nil {
+		return nil, errors.Wrap(err, "failed to check memo visibility")
+	} else if !visible {
+		return nil, nil
+	}
+	return memo, nil
+}
+
+func (s *APIV1Service) getGraphQLCurrentUser(ctx context.Context) (*store.User, error) {
+	userID, ok := ctx.Value(graphQLUserIDContextKey).(int32)
+	if !ok {
+		return nil, errors.New("missing user in session")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find user")
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// getGraphQLQueryDepth returns the max nesting depth of selections in the queries of the document.
+func getGraphQLQueryDepth(document *ast.Document) int {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	var selectionSetDepth func(selectionSet *ast.SelectionSet, visited map[string]bool) int
+	selectionSetDepth = func(selectionSet *ast.SelectionSet, visited map[string]bool) int {
+		if selectionSet == nil {
+			return 0
+		}
+		depth := 0
+		for _, selection := range selectionSet.Selections {
+			selectionDepth := 0
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.SelectionSet != nil {
+					selectionDepth = 1 + selectionSetDepth(selection.SelectionSet, visited)
+				}
+			case *ast.InlineFragment:
+				selectionDepth = selectionSetDepth(selection.SelectionSet, visited)
+			case *ast.FragmentSpread:
+				name := selection.Name.Value
+				if fragment, ok := fragments[name]; ok && !visited[name] {
+					visited[name] = true
+					selectionDepth = selectionSetDepth(fragment.SelectionSet, visited)
+					delete(visited, name)
+				}
+			}
+			depth = max(depth, selectionDepth)
+		}
+		return depth
+	}
+
+	depth := 0
+	for _, definition := range document.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			depth = max(depth, selectionSetDepth(operation.SelectionSet, map[string]bool{}))
+		}
+	}
+	return depth
+}
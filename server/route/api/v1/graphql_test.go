@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestGetGraphQLQueryDepth(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{
+			query: `{ me { username } }`,
+			want:  1,
+		},
+		{
+			query: `{ memo(id: 1) { id creator { username } relations { relatedMemo { id } } } }`,
+			want:  3,
+		},
+		{
+			query: `query { memos { ...memoFields } } fragment memoFields on Memo { resources { id } }`,
+			want:  2,
+		},
+		{
+			query: `{ memos { ...a } } fragment a on Memo { relations { relatedMemo { ...a } } }`,
+			want:  3,
+		},
+	}
+	for _, test := range tests {
+		document, err := parser.Parse(parser.ParseParams{Source: test.query})
+		if err != nil {
+			t.Fatalf("failed to parse query %q: %v", test.query, err)
+		}
+		if depth := getGraphQLQueryDepth(document); depth != test.want {
+			t.Errorf("getGraphQLQueryDepth(%q) = %d, want %d", test.query, depth, test.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
+)
+
+// getHookRejectedError returns the HTTP error of an action rejected by a hook, which is shown to the user,
+// nil if the error isn't a rejection. The hooks are run by the store on the memos and the resources it saves.
+func getHookRejectedError(err error) error {
+	rejectedErr := &hookrunner.RejectedError{}
+	if errors.As(err, &rejectedErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, rejectedErr.Error())
+	}
+	return nil
+}
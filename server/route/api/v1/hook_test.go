@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/hook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+// TestHookRejectedMemos tests the hooks reject the memos of the APIs which don't run them themselves,
+// since the store runs them on all the memos it saves.
+func TestHookRejectedMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	ts.UseContentInterceptor(hookrunner.NewInterceptor())
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &hook.Request{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		_ = json.NewEncoder(w).Encode(&hook.Response{
+			Reject:  strings.Contains(request.Memo.Content, "forbidden"),
+			Message: "forbidden word",
+		})
+	}))
+	defer hookServer.Close()
+	_, err := ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_HOOK,
+		Value: &storepb.WorkspaceSetting_Hook{
+			Hook: &storepb.WorkspaceHookSetting{
+				Hooks: []*storepb.WorkspaceHook{
+					{Name: "filter", Type: storepb.WorkspaceHook_HTTP, Url: hookServer.URL, Events: []storepb.WorkspaceHook_Event{storepb.WorkspaceHook_MEMO_CREATE, storepb.WorkspaceHook_MEMO_UPDATE}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "test",
+		Role:     store.RoleHost,
+		Email:    "test@test.com",
+		Nickname: "test_nickname",
+	})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts, eventBroker: event.NewBroker()}
+	e := echo.New()
+
+	// The batch is rolled back with the rejection as the error of the operation.
+	batch := func(content string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(&BatchMemoRequest{
+			Operations: []*BatchMemoOperation{
+				{Method: BatchMethodCreate, Create: &CreateMemoRequest{Content: "hello"}},
+				{Method: BatchMethodCreate, Create: &CreateMemoRequest{Content: content}},
+			},
+		})
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/memo/batch", strings.NewReader(string(body))), recorder)
+		c.Set(userIDContextKey, user.ID)
+		require.NoError(t, s.BatchMemos(c))
+		return recorder
+	}
+	recorder := batch("a forbidden memo")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	response := &BatchResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	require.False(t, response.Committed)
+	require.Contains(t, response.Results[1].Error, "Rejected by hook filter: forbidden word")
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 0)
+	require.Equal(t, http.StatusOK, batch("world").Code)
+
+	incomingWebhook, err := ts.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+		CreatorID: user.ID,
+		Name:      "inbox",
+		Token:     "incoming-webhook-token",
+	})
+	require.NoError(t, err)
+	receive := func(content string) error {
+		c := e.NewContext(httptest.NewRequest(http.MethodPost, "/o/webhook/"+incomingWebhook.Token, strings.NewReader(content)), httptest.NewRecorder())
+		c.SetParamNames("token")
+		c.SetParamValues(incomingWebhook.Token)
+		return s.ReceiveIncomingWebhook(c)
+	}
+	err = receive("a forbidden memo")
+	httpErr := &echo.HTTPError{}
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+	require.NoError(t, receive("hello"))
+
+	// The updates are run through the hooks too.
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 3)
+	content := "now forbidden"
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memos[0].ID, Content: &content})
+	rejectedErr := &hookrunner.RejectedError{}
+	require.ErrorAs(t, err, &rejectedErr)
+}
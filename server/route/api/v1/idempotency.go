@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/server/route/api/idempotency"
+	"github.com/usememos/memos/store"
+)
+
+// beginIdempotentCreate reserves the Idempotency-Key header, or the request id of the body, for the entity about to be created.
+// See idempotency.Begin for the returned key.
+func (s *APIV1Service) beginIdempotentCreate(c echo.Context, userID int32, entityType, requestID string) (*store.IdempotencyKey, error) {
+	key := c.Request().Header.Get(idempotency.Header)
+	if key == "" {
+		key = requestID
+	}
+	idempotencyKey, err := idempotency.Begin(c.Request().Context(), s.Store, userID, entityType, key)
+	if err != nil {
+		if errors.Is(err, idempotency.ErrInvalidKey) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid idempotency key")
+		}
+		if errors.Is(err, idempotency.ErrInProgress) {
+			return nil, echo.NewHTTPError(http.StatusConflict, "A request with the same idempotency key is in progress")
+		}
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check idempotency key").SetInternal(err)
+	}
+	return idempotencyKey, nil
+}
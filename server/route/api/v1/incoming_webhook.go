@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/internal/event"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// IncomingWebhookRequest is the JSON body accepted by incoming webhooks.
+// Other bodies are taken as the memo content as is.
+type IncomingWebhookRequest struct {
+	Content    string     `json:"content"`
+	Visibility Visibility `json:"visibility"`
+	// Tags are appended to the content, in addition to the default tags of the webhook.
+	Tags []string `json:"tags"`
+}
+
+func (s *APIV1Service) registerIncomingWebhookPublicRoutes(g *echo.Group) {
+	g.POST("/webhook/:token", s.ReceiveIncomingWebhook)
+}
+
+// ReceiveIncomingWebhook godoc
+//
+//	@Summary		Create a memo with an incoming webhook
+//	@Description	The body is either a JSON object with the content, visibility and tags, or the plain text content of the memo.
+//	@Description	The memo is created for the creator of the webhook, with the default visibility and tags of the webhook.
+//	@Tags			webhook
+//	@Accept			json,plain
+//	@Produce		json
+//	@Param			token	path		string					true	"Incoming webhook token"
+//	@Param			body	body		IncomingWebhookRequest	true	"Request object."
+//	@Success		200		{object}	Memo					"Created memo"
+//	@Failure		400		{object}	nil						"Malformatted incoming webhook request | Content is empty | Content size overflow, up to 1MB | Invalid visibility"
+//	@Failure		404		{object}	nil						"Incoming webhook not found"
+//	@Failure		500		{object}	nil						"Failed to find incoming webhook | Failed to create memo | Failed to compose memo response"
+//	@Router			/o/webhook/{token} [POST]
+func (s *APIV1Service) ReceiveIncomingWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	token := c.Param("token")
+	incomingWebhook, err := s.Store.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		Token: &token,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find incoming webhook").SetInternal(err)
+	}
+	if incomingWebhook == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Incoming webhook not found")
+	}
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{
+		ID: &incomingWebhook.CreatorID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	// The disabled users don't create memos by their incoming webhooks either.
+	if creator == nil || creator.RowStatus == store.Archived {
+		return echo.NewHTTPError(http.StatusForbidden, "Creator of the incoming webhook has been archived")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxContentLength+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted incoming webhook request").SetInternal(err)
+	}
+	request := &IncomingWebhookRequest{}
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		if err := json.Unmarshal(body, request); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformatted incoming webhook request").SetInternal(err)
+		}
+	} else {
+		request.Content = string(body)
+	}
+
+	content := buildIncomingWebhookContent(request.Content, append(incomingWebhook.Tags, request.Tags...))
+	if strings.TrimSpace(request.Content) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Content is empty")
+	}
+	if len(content) > maxContentLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "Content size overflow, up to 1MB")
+	}
+
+	visibility := request.Visibility
+	if visibility == "" {
+		visibility = Visibility(incomingWebhook.Visibility)
+	}
+	if visibility == "" {
+		userMemoVisibilitySetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+			UserID: &incomingWebhook.CreatorID,
+			Key:    storepb.UserSettingKey_USER_SETTING_MEMO_VISIBILITY,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user setting").SetInternal(err)
+		}
+		visibility = Private
+		if userMemoVisibilitySetting != nil {
+			visibility = Visibility(userMemoVisibilitySetting.GetMemoVisibility())
+		}
+	}
+	if visibility != Public && visibility != Protected && visibility != Private {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid visibility %q", visibility))
+	}
+
+	// Find disable public memos system setting.
+	disablePublicMemosSystemSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
+		Name: SystemSettingDisablePublicMemosName.String(),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find system setting").SetInternal(err)
+	}
+	if disablePublicMemosSystemSetting != nil {
+		disablePublicMemos := false
+		if err := json.Unmarshal([]byte(disablePublicMemosSystemSetting.Value), &disablePublicMemos); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to unmarshal system setting").SetInternal(err)
+		}
+		if disablePublicMemos {
+			// Enforce normal user to create private memo if public memos are disabled.
+			if creator.Role == store.RoleUser {
+				visibility = Private
+			}
+		}
+	}
+
+	memo, err := s.Store.CreateMemo(ctx, convertCreateMemoRequestToMemoMessage(&CreateMemoRequest{
+		CreatorID:  incomingWebhook.CreatorID,
+		Content:    content,
+		Visibility: visibility,
+	}))
+	if err != nil {
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create memo").SetInternal(err)
+	}
+	memoResponse, err := s.convertMemoFromStore(ctx, memo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compose memo response").SetInternal(err)
+	}
+	// Try to dispatch webhook when memo is created.
+	if err := s.DispatchMemoCreatedWebhook(ctx, memoResponse); err != nil {
+		slog.Warn("Failed to dispatch memo created webhook", slog.Any("err", err))
+	}
+	s.createMemoCreateActivity(ctx, memo)
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoCreated, memo))
+
+	return c.JSON(http.StatusOK, memoResponse)
+}
+
+// buildIncomingWebhookContent appends the tags which aren't in the content yet to the content.
+func buildIncomingWebhookContent(content string, tags []string) string {
+	content = strings.TrimSpace(content)
+	tagList, added := []string{}, map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" || added[tag] || strings.Contains(content, "#"+tag) {
+			continue
+		}
+		added[tag] = true
+		tagList = append(tagList, "#"+tag)
+	}
+	if len(tagList) == 0 {
+		return content
+	}
+	return content + "\n\n" + strings.Join(tagList, " ")
+}
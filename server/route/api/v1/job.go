@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/internal/util"
+	jobqueue "github.com/usememos/memos/server/service/job_queue"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// defaultJobListLimit is the number of the jobs listed if the limit isn't set.
+	defaultJobListLimit = 50
+	// maxJobListLimit is the max number of the jobs listed in a page.
+	maxJobListLimit = 200
+)
+
+type Job struct {
+	ID        int32 `json:"id"`
+	CreatedTs int64 `json:"createdTs"`
+	UpdatedTs int64 `json:"updatedTs"`
+
+	Kind          string          `json:"kind"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        store.JobStatus `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	MaxAttempts   int32           `json:"maxAttempts"`
+	NextAttemptTs int64           `json:"nextAttemptTs"`
+	LastError     string          `json:"lastError"`
+}
+
+func (s *APIV1Service) registerJobRoutes(g *echo.Group) {
+	g.GET("/job", s.ListJobs)
+	g.GET("/job/:jobId", s.GetJob)
+	g.POST("/job/:jobId/requeue", s.RequeueJob)
+}
+
+// ListJobs godoc
+//
+//	@Summary		List the queued background jobs
+//	@Description	The jobs are ordered from the newest. The succeeded jobs are kept for a week.
+//	@Tags			job
+//	@Produce		json
+//	@Param			status	query		string	false	"Status of the jobs: PENDING, RUNNING, SUCCEEDED or DEAD"
+//	@Param			kind	query		string	false	"Kind of the jobs"
+//	@Param			limit	query		int		false	"Max number of jobs, up to 200"	default(50)
+//	@Param			offset	query		int		false	"Offset of the page"
+//	@Success		200		{object}	[]Job	"Job list"
+//	@Failure		400		{object}	nil		"Invalid status | Invalid limit | Invalid offset"
+//	@Failure		401		{object}	nil		"Missing user in session | Unauthorized"
+//	@Failure		500		{object}	nil		"Failed to find user | Failed to list jobs"
+//	@Router			/api/v1/job [GET]
+func (s *APIV1Service) ListJobs(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+
+	limit, offset := defaultJobListLimit, 0
+	find := &store.FindJob{Limit: &limit, Offset: &offset}
+	if value := c.QueryParam("status"); value != "" {
+		status := store.JobStatus(value)
+		switch status {
+		case store.JobPending, store.JobRunning, store.JobSucceeded, store.JobDead:
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid status %s", value))
+		}
+		find.Status = &status
+	}
+	if value := c.QueryParam("kind"); value != "" {
+		find.Kind = &value
+	}
+	if value := c.QueryParam("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid limit").SetInternal(err)
+		}
+		limit = min(limit, maxJobListLimit)
+	}
+	if value := c.QueryParam("offset"); value != "" {
+		var err error
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid offset").SetInternal(err)
+		}
+	}
+
+	list, err := s.Store.ListJobs(ctx, find)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list jobs").SetInternal(err)
+	}
+	jobs := []*Job{}
+	for _, job := range list {
+		jobs = append(jobs, convertJobFromStore(job))
+	}
+	return c.JSON(http.StatusOK, jobs)
+}
+
+// GetJob godoc
+//
+//	@Summary	Get a queued background job
+//	@Tags		job
+//	@Produce	json
+//	@Param		jobId	path		int		true	"ID of the job"
+//	@Success	200		{object}	Job		"Job"
+//	@Failure	400		{object}	nil		"ID is not a number: %s"
+//	@Failure	401		{object}	nil		"Missing user in session | Unauthorized"
+//	@Failure	404		{object}	nil		"Job not found: %d"
+//	@Failure	500		{object}	nil		"Failed to find user | Failed to find job"
+//	@Router		/api/v1/job/{jobId} [GET]
+func (s *APIV1Service) GetJob(c echo.Context) error {
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+	job, err := s.findJob(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, convertJobFromStore(job))
+}
+
+// RequeueJob godoc
+//
+//	@Summary		Requeue a background job
+//	@Description	The job is attempted again as soon as possible, with all its attempts. It's meant for the dead jobs whose cause is fixed.
+//	@Tags			job
+//	@Produce		json
+//	@Param			jobId	path		int		true	"ID of the job"
+//	@Success		200		{object}	Job		"Requeued job"
+//	@Failure		400		{object}	nil		"ID is not a number: %s"
+//	@Failure		401		{object}	nil		"Missing user in session | Unauthorized"
+//	@Failure		404		{object}	nil		"Job not found: %d"
+//	@Failure		409		{object}	nil		"Job is running"
+//	@Failure		500		{object}	nil		"Failed to find user | Failed to find job | Failed to requeue job"
+//	@Router			/api/v1/job/{jobId}/requeue [POST]
+func (s *APIV1Service) RequeueJob(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+	job, err := s.findJob(c)
+	if err != nil {
+		return err
+	}
+	// The running jobs are requeued by the queue if their attempts are interrupted.
+	if job.Status == store.JobRunning {
+		return echo.NewHTTPError(http.StatusConflict, "Job is running")
+	}
+
+	if err := jobqueue.Requeue(ctx, s.Store, job.ID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to requeue job").SetInternal(err)
+	}
+	job, err = s.findJob(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, convertJobFromStore(job))
+}
+
+func (s *APIV1Service) findJob(c echo.Context) (*store.Job, error) {
+	jobID, err := util.ConvertStringToInt32(c.Param("jobId"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("jobId"))).SetInternal(err)
+	}
+	job, err := s.Store.GetJob(c.Request().Context(), &store.FindJob{ID: &jobID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find job").SetInternal(err)
+	}
+	if job == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Job not found: %d", jobID))
+	}
+	return job, nil
+}
+
+func convertJobFromStore(job *store.Job) *Job {
+	return &Job{
+		ID:            job.ID,
+		CreatedTs:     job.CreatedTs,
+		UpdatedTs:     job.UpdatedTs,
+		Kind:          job.Kind,
+		Payload:       json.RawMessage(job.Payload),
+		Status:        job.Status,
+		Attempts:      job.Attempts,
+		MaxAttempts:   job.MaxAttempts,
+		NextAttemptTs: job.NextAttemptTs,
+		LastError:     job.LastError,
+	}
+}
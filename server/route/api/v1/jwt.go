@@ -22,6 +22,12 @@ const (
 	userIDContextKey = "user-id"
 )
 
+// GetUserID returns the ID of the user authenticated by the JWT middleware, if any.
+func GetUserID(c echo.Context) (int32, bool) {
+	userID, ok := c.Get(userIDContextKey).(int32)
+	return userID, ok
+}
+
 func extractTokenFromHeader(c echo.Context) (string, error) {
 	authHeader := c.Request().Header.Get("Authorization")
 	if authHeader == "" {
@@ -78,7 +84,7 @@ func JWTMiddleware(server *APIV1Service, next echo.HandlerFunc, secret string) e
 			return echo.NewHTTPError(http.StatusUnauthorized, "Missing access token")
 		}
 
-		userID, err := getUserIDFromAccessToken(accessToken, secret)
+		userID, err := GetUserIDFromAccessToken(accessToken, secret)
 		if err != nil {
 			err = removeAccessTokenAndCookies(c, server.Store, userID, accessToken)
 			if err != nil {
@@ -109,6 +115,15 @@ func JWTMiddleware(server *APIV1Service, next echo.HandlerFunc, secret string) e
 		if user == nil {
 			return echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("Failed to find user ID: %d", userID))
 		}
+		if user.RowStatus == store.Archived {
+			return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("User has been archived with username %s", user.Username))
+		}
+		if err := server.checkQuota(c, accessToken); err != nil {
+			return err
+		}
+		if err := server.Store.UpdateUserLastActiveTs(ctx, user.ID); err != nil {
+			slog.Warn("Failed to update user last active time", slog.Any("err", err))
+		}
 
 		// Stores userID into context.
 		c.Set(userIDContextKey, userID)
@@ -116,7 +131,8 @@ func JWTMiddleware(server *APIV1Service, next echo.HandlerFunc, secret string) e
 	}
 }
 
-func getUserIDFromAccessToken(accessToken, secret string) (int32, error) {
+// GetUserIDFromAccessToken returns the id of the user of the access token, after validating its signature and expiration.
+func GetUserIDFromAccessToken(accessToken, secret string) (int32, error) {
 	claims := &auth.ClaimsMessage{}
 	_, err := jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (any, error) {
 		if t.Method.Alg() != jwt.SigningMethodHS256.Name {
@@ -13,9 +13,13 @@ import (
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/plugin/webhook"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/route/api/etag"
+	"github.com/usememos/memos/server/route/api/idempotency"
+	webhookdispatcher "github.com/usememos/memos/server/service/webhook_dispatcher"
 	"github.com/usememos/memos/store"
 )
 
@@ -78,6 +82,9 @@ type CreateMemoRequest struct {
 	// Related fields
 	ResourceIDList []int32                      `json:"resourceIdList"`
 	RelationList   []*UpsertMemoRelationRequest `json:"relationList"`
+
+	// RequestID is the idempotency key of the request, if there is no Idempotency-Key header.
+	RequestID string `json:"requestId"`
 }
 
 type PatchMemoRequest struct {
@@ -120,6 +127,7 @@ const maxContentLength = 1 << 30
 func (s *APIV1Service) registerMemoRoutes(g *echo.Group) {
 	g.GET("/memo", s.GetMemoList)
 	g.POST("/memo", s.CreateMemo)
+	g.POST("/memo/batch", s.BatchMemos)
 	g.GET("/memo/all", s.GetAllMemos)
 	g.GET("/memo/stats", s.GetMemoStats)
 	g.GET("/memo/:memoId", s.GetMemo)
@@ -140,8 +148,10 @@ func (s *APIV1Service) registerMemoRoutes(g *echo.Group) {
 //	@Param		content			query		string			false	"Search for content"
 //	@Param		limit			query		int				false	"Limit"
 //	@Param		offset			query		int				false	"Offset"
+//	@Param		orderBy			query		string			false	"Order by displayTs (default), createdTs, updatedTs, contentLength or reactionCount"
+//	@Param		order			query		string			false	"Order direction, asc or desc (default)"
 //	@Success	200				{object}	[]store.Memo	"Memo list"
-//	@Failure	400				{object}	nil				"Missing user to find memo"
+//	@Failure	400				{object}	nil				"Missing user to find memo | Invalid order by | Invalid order"
 //	@Failure	500				{object}	nil				"Failed to get memo display with updated ts setting value | Failed to fetch memo list | Failed to compose memo response"
 //	@Router		/api/v1/memo [GET]
 func (s *APIV1Service) GetMemoList(c echo.Context) error {
@@ -160,6 +170,8 @@ func (s *APIV1Service) GetMemoList(c echo.Context) error {
 		}
 	}
 
+	// The memos hidden after reports are only listed to their creators.
+	find.ExcludeHidden = true
 	currentUserID, ok := c.Get(userIDContextKey).(int32)
 	if !ok {
 		// Anonymous use should only fetch PUBLIC memos with specified user
@@ -168,13 +180,14 @@ func (s *APIV1Service) GetMemoList(c echo.Context) error {
 		}
 		find.VisibilityList = []store.Visibility{store.Public}
 	} else {
+		find.HiddenVisibleToID = &currentUserID
 		// Authorized user can fetch all PUBLIC/PROTECTED memo
 		visibilityList := []store.Visibility{store.Public, store.Protected}
 
 		// If Creator is authorized user (as default), PRIVATE memo is OK
 		if find.CreatorID == nil || *find.CreatorID == currentUserID {
 			find.CreatorID = &currentUserID
-			visibilityList = append(visibilityList, store.Private)
+			visibilityList = append(visibilityList, store.Private, store.Group)
 		}
 		find.VisibilityList = visibilityList
 	}
@@ -194,6 +207,7 @@ func (s *APIV1Service) GetMemoList(c echo.Context) error {
 		contentSearch = append(contentSearch, content)
 	}
 	find.ContentSearch = contentSearch
+	find.SearchResourceText = content != ""
 
 	if limit, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
 		find.Limit = &limit
@@ -209,6 +223,26 @@ func (s *APIV1Service) GetMemoList(c echo.Context) error {
 	if memoDisplayWithUpdatedTs {
 		find.OrderByUpdatedTs = true
 	}
+	switch c.QueryParam("orderBy") {
+	case "", "displayTs":
+	case "createdTs":
+		find.OrderBy = store.MemoOrderByCreatedTs
+	case "updatedTs":
+		find.OrderBy = store.MemoOrderByUpdatedTs
+	case "contentLength":
+		find.OrderBy = store.MemoOrderByContentLength
+	case "reactionCount":
+		find.OrderBy = store.MemoOrderByReactionCount
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order by")
+	}
+	switch c.QueryParam("order") {
+	case "", "desc":
+	case "asc":
+		find.OrderAsc = true
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order")
+	}
 
 	list, err := s.Store.ListMemos(ctx, find)
 	if err != nil {
@@ -230,15 +264,19 @@ func (s *APIV1Service) GetMemoList(c echo.Context) error {
 //	@Summary		Create a memo
 //	@Description	Visibility can be PUBLIC, PROTECTED or PRIVATE
 //	@Description	*You should omit fields to use their default values
+//	@Description	The memo created by a previous request with the same Idempotency-Key header or requestId within 24 hours is returned instead of creating a new one.
 //	@Tags			memo
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		CreateMemoRequest	true	"Request object."
-//	@Success		200		{object}	store.Memo			"Stored memo"
-//	@Failure		400		{object}	nil					"Malformatted post memo request | Content size overflow, up to 1MB"
-//	@Failure		401		{object}	nil					"Missing user in session"
-//	@Failure		404		{object}	nil					"User not found | Memo not found: %d"
-//	@Failure		500		{object}	nil					"Failed to find user setting | Failed to unmarshal user setting value | Failed to find system setting | Failed to unmarshal system setting | Failed to find user | Failed to create memo | Failed to create activity | Failed to upsert memo resource | Failed to upsert memo relation | Failed to compose memo | Failed to compose memo response"
+//	@Param			Idempotency-Key	header		string				false	"Idempotency key"
+//	@Param			body			body		CreateMemoRequest	true	"Request object."
+//	@Success		200				{object}	store.Memo			"Stored memo"
+//	@Failure		400				{object}	nil					"Malformatted post memo request | Content size overflow, up to 1MB | Invalid idempotency key"
+//	@Failure		401				{object}	nil					"Missing user in session"
+//	@Failure		403				{object}	nil					"Memo quota exceeded"
+//	@Failure		404				{object}	nil					"User not found | Memo not found: %d"
+//	@Failure		409				{object}	nil					"A request with the same idempotency key is in progress"
+//	@Failure		500				{object}	nil					"Failed to find user setting | Failed to unmarshal user setting value | Failed to find system setting | Failed to unmarshal system setting | Failed to find user | Failed to check idempotency key | Failed to create memo | Failed to create activity | Failed to upsert memo resource | Failed to upsert memo relation | Failed to compose memo | Failed to compose memo response"
 //	@Router			/api/v1/memo [POST]
 //
 // NOTES:
@@ -258,6 +296,15 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Content size overflow, up to 1MB")
 	}
 
+	idempotencyKey, err := s.beginIdempotentCreate(c, userID, "memo", createMemoRequest.RequestID)
+	if err != nil {
+		return err
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		return s.replayCreateMemo(c, idempotencyKey.EntityID)
+	}
+	defer idempotency.Release(ctx, s.Store, idempotencyKey)
+
 	if createMemoRequest.Visibility == "" {
 		userMemoVisibilitySetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
 			UserID: &userID,
@@ -274,6 +321,10 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 		}
 	}
 
+	if store.Visibility(createMemoRequest.Visibility) == store.Group {
+		return echo.NewHTTPError(http.StatusBadRequest, "Group memos are only supported by api v2")
+	}
+
 	// Find disable public memos system setting.
 	disablePublicMemosSystemSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
 		Name: SystemSettingDisablePublicMemosName.String(),
@@ -307,8 +358,18 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 	createMemoRequest.CreatorID = userID
 	memo, err := s.Store.CreateMemo(ctx, convertCreateMemoRequestToMemoMessage(createMemoRequest))
 	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Memo quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create memo").SetInternal(err)
 	}
+	if err := idempotency.Complete(ctx, s.Store, idempotencyKey, memo.ID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create memo").SetInternal(err)
+	}
+	s.createMemoCreateActivity(ctx, memo)
 
 	for _, resourceID := range createMemoRequest.ResourceIDList {
 		if _, err := s.Store.UpdateResource(ctx, &store.UpdateResource{
@@ -349,7 +410,7 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 				if err != nil {
 					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create activity").SetInternal(err)
 				}
-				if _, err := s.Store.CreateInbox(ctx, &store.Inbox{
+				inbox, err := s.Store.CreateInbox(ctx, &store.Inbox{
 					SenderID:   memo.CreatorID,
 					ReceiverID: relatedMemo.CreatorID,
 					Status:     store.UNREAD,
@@ -357,10 +418,13 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 						Type:       storepb.InboxMessage_TYPE_MEMO_COMMENT,
 						ActivityId: &activity.ID,
 					},
-				}); err != nil {
+				})
+				if err != nil {
 					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create inbox").SetInternal(err)
 				}
+				s.eventBroker.Publish(event.NewInboxEvent(inbox))
 			}
+			s.eventBroker.Publish(event.NewMemoCommentEvent(memo, relatedMemo))
 		}
 	}
 
@@ -404,10 +468,31 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 	if err := s.DispatchMemoCreatedWebhook(ctx, memoResponse); err != nil {
 		slog.Warn("Failed to dispatch memo created webhook", err)
 	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoCreated, composedMemo))
 
 	return c.JSON(http.StatusOK, memoResponse)
 }
 
+// replayCreateMemo responds with the memo created by a previous request with the same idempotency key.
+func (s *APIV1Service) replayCreateMemo(c echo.Context, memoID int32) error {
+	ctx := c.Request().Context()
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		ID: &memoID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compose memo").SetInternal(err)
+	}
+	if memo == nil {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Memo not found: %d", memoID))
+	}
+	memoResponse, err := s.convertMemoFromStore(ctx, memo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compose memo response").SetInternal(err)
+	}
+	c.Response().Header().Set(idempotency.ReplayedHeader, "true")
+	return c.JSON(http.StatusOK, memoResponse)
+}
+
 // GetAllMemos godoc
 //
 //	@Summary		Get a list of public memos matching optional filters
@@ -418,14 +503,17 @@ func (s *APIV1Service) CreateMemo(c echo.Context) error {
 //	@Param			limit	query		int				false	"Limit"
 //	@Param			offset	query		int				false	"Offset"
 //	@Success		200		{object}	[]store.Memo	"Memo list"
-//	@Failure		500		{object}	nil				"Failed to get memo display with updated ts setting value | Failed to fetch all memo list | Failed to compose memo response"
+//	@Failure		500		{object}	nil				"Failed to apply explore setting | Failed to get memo display with updated ts setting value | Failed to fetch all memo list | Failed to compose memo response"
 //	@Router			/api/v1/memo/all [GET]
 //
 //	NOTES:
 //	- creatorUsername is listed at ./web/src/helpers/api.ts:82, but it's not present here
 func (s *APIV1Service) GetAllMemos(c echo.Context) error {
 	ctx := c.Request().Context()
-	memoFind := &store.FindMemo{}
+	memoFind := &store.FindMemo{
+		// The memos hidden after reports are excluded from explore.
+		ExcludeHidden: true,
+	}
 	_, ok := c.Get(userIDContextKey).(int32)
 	if !ok {
 		memoFind.VisibilityList = []store.Visibility{store.Public}
@@ -443,6 +531,9 @@ func (s *APIV1Service) GetAllMemos(c echo.Context) error {
 	// Only fetch normal status memos.
 	normalStatus := store.Normal
 	memoFind.RowStatus = &normalStatus
+	if err := s.Store.ApplyExploreSetting(ctx, memoFind); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply explore setting").SetInternal(err)
+	}
 
 	memoDisplayWithUpdatedTs, err := s.getMemoDisplayWithUpdatedTsSettingValue(ctx)
 	if err != nil {
@@ -508,7 +599,7 @@ func (s *APIV1Service) GetMemoStats(c echo.Context) error {
 		if *findMemoMessage.CreatorID != currentUserID {
 			findMemoMessage.VisibilityList = []store.Visibility{store.Public, store.Protected}
 		} else {
-			findMemoMessage.VisibilityList = []store.Visibility{store.Public, store.Protected, store.Private}
+			findMemoMessage.VisibilityList = []store.Visibility{store.Public, store.Protected, store.Private, store.Group}
 		}
 	}
 
@@ -545,6 +636,7 @@ func (s *APIV1Service) GetMemoStats(c echo.Context) error {
 //	@Produce	json
 //	@Param		memoId	path		int				true	"Memo ID"
 //	@Success	200		{object}	[]store.Memo	"Memo list"
+//	@Success	304		{object}	nil				"Memo not modified"
 //	@Failure	400		{object}	nil				"ID is not a number: %s"
 //	@Failure	401		{object}	nil				"Missing user in session"
 //	@Failure	403		{object}	nil				"this memo is private only | this memo is protected, missing user in session
@@ -577,6 +669,17 @@ func (s *APIV1Service) GetMemo(c echo.Context) error {
 		if !ok {
 			return echo.NewHTTPError(http.StatusForbidden, "this memo is protected, missing user in session")
 		}
+	} else if memo.Visibility == store.Group {
+		visible, err := s.Store.IsMemoVisibleTo(ctx, memo, userID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check memo visibility").SetInternal(err)
+		}
+		if !visible {
+			return echo.NewHTTPError(http.StatusForbidden, "this memo is shared with a group only")
+		}
+	}
+	if checkNotModified(c, etag.New("memo", memo.ID, memo.UpdatedTs, ""), memo.UpdatedTs) {
+		return c.NoContent(http.StatusNotModified)
 	}
 	memoResponse, err := s.convertMemoFromStore(ctx, memo)
 	if err != nil {
@@ -633,6 +736,7 @@ func (s *APIV1Service) DeleteMemo(c echo.Context) error {
 	}); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete memo ID: %v", memoID)).SetInternal(err)
 	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoDeleted, memo))
 	return c.JSON(http.StatusOK, true)
 }
 
@@ -693,7 +797,6 @@ func (s *APIV1Service) UpdateMemo(c echo.Context) error {
 	if patchMemoRequest.Content != nil && len(*patchMemoRequest.Content) > maxContentLength {
 		return echo.NewHTTPError(http.StatusBadRequest, "Content size overflow, up to 1MB").SetInternal(err)
 	}
-
 	updateMemoMessage := &store.UpdateMemo{
 		ID:        memoID,
 		CreatedTs: patchMemoRequest.CreatedTs,
@@ -705,8 +808,14 @@ func (s *APIV1Service) UpdateMemo(c echo.Context) error {
 		updateMemoMessage.RowStatus = &rowStatus
 	}
 	if patchMemoRequest.Visibility != nil {
+		if store.Visibility(*patchMemoRequest.Visibility) == store.Group {
+			return echo.NewHTTPError(http.StatusBadRequest, "Group memos are only supported by api v2")
+		}
 		visibility := store.Visibility(patchMemoRequest.Visibility.String())
 		updateMemoMessage.Visibility = &visibility
+		// The memo isn't shared with its group any more.
+		clearGroupID := int32(0)
+		updateMemoMessage.GroupID = &clearGroupID
 		// Find disable public memos system setting.
 		disablePublicMemosSystemSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
 			Name: SystemSettingDisablePublicMemosName.String(),
@@ -739,8 +848,12 @@ func (s *APIV1Service) UpdateMemo(c echo.Context) error {
 		}
 	}
 
+	previousVisibility := memo.Visibility
 	err = s.Store.UpdateMemo(ctx, updateMemoMessage)
 	if err != nil {
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to patch memo").SetInternal(err)
 	}
 	memo, err = s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
@@ -811,6 +924,11 @@ func (s *APIV1Service) UpdateMemo(c echo.Context) error {
 	if memo == nil {
 		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Memo not found: %d", memoID))
 	}
+	if previousVisibility == store.Private && memo.Visibility != store.Private {
+		s.createActivity(ctx, userID, store.ActivityTypeMemoShare, &storepb.ActivityPayload{
+			MemoShare: &storepb.ActivityMemoSharePayload{MemoId: memo.ID, Visibility: memo.Visibility.String()},
+		})
+	}
 
 	memoResponse, err := s.convertMemoFromStore(ctx, memo)
 	if err != nil {
@@ -820,6 +938,7 @@ func (s *APIV1Service) UpdateMemo(c echo.Context) error {
 	if err := s.DispatchMemoUpdatedWebhook(ctx, memoResponse); err != nil {
 		slog.Error("Failed to dispatch memo updated webhook", err)
 	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoUpdated, memo))
 
 	return c.JSON(http.StatusOK, memoResponse)
 }
@@ -1008,10 +1127,8 @@ func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *Mem
 	for _, hook := range webhooks {
 		payload := convertMemoToWebhookPayload(memo)
 		payload.ActivityType = activityType
-		payload.URL = hook.Url
-		err := webhook.Post(*payload)
-		if err != nil {
-			return errors.Wrap(err, "failed to post webhook")
+		if err := webhookdispatcher.Enqueue(ctx, s.Store, hook, payload); err != nil {
+			return errors.Wrap(err, "failed to enqueue webhook")
 		}
 	}
 	return nil
@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/server/service/ai"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// defaultSemanticSearchLimit is the number of the memos found by the semantic search if the limit isn't set.
+	defaultSemanticSearchLimit = 10
+	// maxSemanticSearchLimit is the max number of the memos found by the semantic search.
+	maxSemanticSearchLimit = 50
+)
+
+type MemoSummary struct {
+	Summary string `json:"summary"`
+}
+
+type SemanticSearchResult struct {
+	Memo *Memo `json:"memo"`
+	// Score is the cosine similarity of the memo to the query, from -1 to 1.
+	Score float32 `json:"score"`
+}
+
+func (s *APIV1Service) registerMemoAIRoutes(g *echo.Group) {
+	g.POST("/memo/:memoId/summary", s.SummarizeMemo)
+	g.GET("/memo/search/semantic", s.SearchMemosSemantically)
+}
+
+// SummarizeMemo godoc
+//
+//	@Summary		Summarize a memo with the AI assistance
+//	@Description	The summary is kept until the memo is updated. The AI assistance is configured by the host in the integration setting of the workspace.
+//	@Tags			memo
+//	@Produce		json
+//	@Param			memoId	path		int			true	"ID of memo to summarize"
+//	@Success		200		{object}	MemoSummary	"Summary of the memo"
+//	@Failure		400		{object}	nil			"ID is not a number: %s | AI summarization is disabled"
+//	@Failure		401		{object}	nil			"Missing user in session"
+//	@Failure		403		{object}	nil			"this memo is private only"
+//	@Failure		404		{object}	nil			"Memo not found: %d"
+//	@Failure		500		{object}	nil			"Failed to find memo | Failed to get AI setting"
+//	@Failure		502		{object}	nil			"Failed to summarize memo"
+//	@Router			/api/v1/memo/{memoId}/summary [POST]
+func (s *APIV1Service) SummarizeMemo(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	memoID, err := util.ConvertStringToInt32(c.Param("memoId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("memoId"))).SetInternal(err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+	}
+	if memo == nil {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Memo not found: %d", memoID))
+	}
+	if visible, err := s.Store.IsMemoVisibleTo(ctx, memo, userID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check memo visibility").SetInternal(err)
+	} else if !visible {
+		return echo.NewHTTPError(http.StatusForbidden, "this memo is private only")
+	}
+
+	assistant, err := ai.NewAssistant(ctx, s.Store)
+	if err != nil && !errors.Is(err, ai.ErrDisabled) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get AI setting").SetInternal(err)
+	}
+	if assistant == nil || !assistant.CanChat() {
+		return echo.NewHTTPError(http.StatusBadRequest, "AI summarization is disabled")
+	}
+	summary, err := assistant.SummarizeMemo(ctx, s.Store, memo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, "Failed to summarize memo").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &MemoSummary{Summary: summary})
+}
+
+// SearchMemosSemantically godoc
+//
+//	@Summary		Search the memos of the current user by meaning with the AI assistance
+//	@Description	The memos are ordered by the similarity of their embeddings to the embedding of the query. The memos are embedded in the background after they are saved.
+//	@Tags			memo
+//	@Produce		json
+//	@Param			query	query		string					true	"Search query"
+//	@Param			limit	query		int						false	"Max number of memos, up to 50"	default(10)
+//	@Success		200		{object}	[]SemanticSearchResult	"Found memos"
+//	@Failure		400		{object}	nil						"Query is required | Invalid limit | AI semantic search is disabled"
+//	@Failure		401		{object}	nil						"Missing user in session"
+//	@Failure		500		{object}	nil						"Failed to get AI setting | Failed to list memo embeddings | Failed to find memo | Failed to compose memo response"
+//	@Failure		502		{object}	nil						"Failed to embed query"
+//	@Router			/api/v1/memo/search/semantic [GET]
+func (s *APIV1Service) SearchMemosSemantically(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	query := strings.TrimSpace(c.QueryParam("query"))
+	if query == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Query is required")
+	}
+	limit := defaultSemanticSearchLimit
+	if value := c.QueryParam("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid limit").SetInternal(err)
+		}
+		limit = min(limit, maxSemanticSearchLimit)
+	}
+
+	assistant, err := ai.NewAssistant(ctx, s.Store)
+	if err != nil && !errors.Is(err, ai.ErrDisabled) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get AI setting").SetInternal(err)
+	}
+	if assistant == nil || !assistant.CanEmbed() {
+		return echo.NewHTTPError(http.StatusBadRequest, "AI semantic search is disabled")
+	}
+	model := assistant.EmbeddingModel()
+	embeddings, err := s.Store.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{CreatorID: &userID, Model: &model})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memo embeddings").SetInternal(err)
+	}
+	results := []*SemanticSearchResult{}
+	if len(embeddings) == 0 {
+		return c.JSON(http.StatusOK, results)
+	}
+	vectors, err := assistant.Embed(ctx, []string{query})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, "Failed to embed query").SetInternal(err)
+	}
+
+	scores := map[int32]float32{}
+	for _, embedding := range embeddings {
+		scores[embedding.MemoID] = ai.CosineSimilarity(vectors[0], embedding.Embedding)
+	}
+	sort.SliceStable(embeddings, func(i, j int) bool {
+		return scores[embeddings[i].MemoID] > scores[embeddings[j].MemoID]
+	})
+	for _, embedding := range embeddings {
+		if len(results) == limit {
+			break
+		}
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &embedding.MemoID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+		}
+		// The archived memos keep their embeddings until they are deleted.
+		if memo == nil || memo.RowStatus != store.Normal {
+			continue
+		}
+		memoResponse, err := s.convertMemoFromStore(ctx, memo)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compose memo response").SetInternal(err)
+		}
+		results = append(results, &SemanticSearchResult{
+			Memo:  memoResponse,
+			Score: scores[embedding.MemoID],
+		})
+	}
+	return c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,308 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/event"
+	getter "github.com/usememos/memos/plugin/http-getter"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/route/api/idempotency"
+	"github.com/usememos/memos/store"
+)
+
+// captureMetaTimeout is the timeout for fetching the metadata of the captured page, the memo is created without it after the timeout.
+const captureMetaTimeout = 10 * time.Second
+
+// CaptureMemoRequest is the captured page of a web clipper.
+type CaptureMemoRequest struct {
+	URL string `json:"url"`
+	// Title is the title of the page in the browser, the title in the metadata of the page is used if it's empty.
+	Title string `json:"title"`
+	// Text is the text selected on the page.
+	Text string `json:"text"`
+	// Note is the comment of the user on the page.
+	Note       string     `json:"note"`
+	Visibility Visibility `json:"visibility"`
+	Tags       []string   `json:"tags"`
+}
+
+func (s *APIV1Service) registerMemoCaptureRoutes(g *echo.Group) {
+	g.POST("/memo/capture", s.CaptureMemo)
+}
+
+// CaptureMemo godoc
+//
+//	@Summary		Create a memo of a web page captured by a web clipper
+//	@Description	The memo is the link to the page with its title, followed by the selected text as a quote, or the description of the page if no text is selected, and the note.
+//	@Description	The metadata of the page is fetched by the server, the page is linked with the given title if it can't be fetched.
+//	@Description	The screenshot is attached to the memo. Tags are comma separated or repeated.
+//	@Description	The memo created by a previous request with the same Idempotency-Key header or requestId within 24 hours is returned instead of creating a new one.
+//	@Tags			memo
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			Idempotency-Key	header		string	false	"Idempotency key"
+//	@Param			url				formData	string	true	"URL of the page"
+//	@Param			title			formData	string	false	"Title of the page"
+//	@Param			text			formData	string	false	"Selected text"
+//	@Param			note			formData	string	false	"Note of the user"
+//	@Param			visibility		formData	string	false	"Visibility of the memo"
+//	@Param			tags			formData	string	false	"Tags of the memo"
+//	@Param			screenshot		formData	file	false	"Screenshot of the page"
+//	@Param			requestId		formData	string	false	"Idempotency key, if there is no Idempotency-Key header"
+//	@Success		200				{object}	Memo	"Created memo"
+//	@Failure		400				{object}	nil		"Failed to parse capture data | Invalid URL | Content size overflow, up to 1MB | Invalid visibility | Screenshot must be an image | File size exceeds allowed limit of %d MiB | File type %s is not allowed | File is infected: %s | Invalid idempotency key"
+//	@Failure		401				{object}	nil		"Missing user in session"
+//	@Failure		403				{object}	nil		"Memo quota exceeded | Resource quota exceeded"
+//	@Failure		409				{object}	nil		"A request with the same idempotency key is in progress"
+//	@Failure		500				{object}	nil		"Failed to find user setting | Failed to find system setting | Failed to unmarshal system setting | Failed to find user | Failed to check idempotency key | Failed to get upload limit | Failed to open file | Failed to scan file | Failed to save resource | Failed to create resource | Failed to create memo | Failed to upsert memo resource | Failed to compose memo response"
+//	@Router			/api/v1/memo/capture [POST]
+func (s *APIV1Service) CaptureMemo(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	if err := c.Request().ParseMultipartForm(maxUploadBufferSizeBytes); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse capture data").SetInternal(err)
+	}
+
+	request := &CaptureMemoRequest{
+		URL:        strings.TrimSpace(c.FormValue("url")),
+		Title:      c.FormValue("title"),
+		Text:       c.FormValue("text"),
+		Note:       c.FormValue("note"),
+		Visibility: Visibility(c.FormValue("visibility")),
+	}
+	for _, value := range c.Request().MultipartForm.Value["tags"] {
+		request.Tags = append(request.Tags, strings.Split(value, ",")...)
+	}
+	if pageURL, err := url.Parse(request.URL); err != nil || pageURL.Scheme != "http" && pageURL.Scheme != "https" || pageURL.Host == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid URL").SetInternal(err)
+	}
+	if request.Visibility != "" && request.Visibility != Public && request.Visibility != Protected && request.Visibility != Private {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid visibility %q", request.Visibility))
+	}
+
+	metaCtx, cancel := context.WithTimeout(ctx, captureMetaTimeout)
+	htmlMeta, err := getter.FetchHTMLMeta(metaCtx, request.URL)
+	cancel()
+	if err != nil {
+		// The page may be behind a login or unreachable from the server, the memo is created with what the clipper sent.
+		slog.Debug("Failed to fetch metadata of the captured page", slog.String("url", request.URL), slog.Any("err", err))
+		htmlMeta = &getter.HTMLMeta{}
+	}
+	content := buildCaptureContent(request, htmlMeta)
+	if len(content) > maxContentLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "Content size overflow, up to 1MB")
+	}
+
+	idempotencyKey, err := s.beginIdempotentCreate(c, userID, "memo", c.FormValue("requestId"))
+	if err != nil {
+		return err
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		return s.replayCreateMemo(c, idempotencyKey.EntityID)
+	}
+	defer idempotency.Release(ctx, s.Store, idempotencyKey)
+
+	visibility, err := s.getCaptureMemoVisibility(ctx, userID, request.Visibility)
+	if err != nil {
+		return err
+	}
+	var screenshot *store.Resource
+	if files := c.Request().MultipartForm.File["screenshot"]; len(files) > 0 {
+		if screenshot, err = s.createCaptureScreenshot(c, userID); err != nil {
+			return err
+		}
+	}
+
+	memo, err := s.Store.CreateMemo(ctx, convertCreateMemoRequestToMemoMessage(&CreateMemoRequest{
+		CreatorID:  userID,
+		Content:    content,
+		Visibility: visibility,
+	}))
+	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Memo quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create memo").SetInternal(err)
+	}
+	if err := idempotency.Complete(ctx, s.Store, idempotencyKey, memo.ID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create memo").SetInternal(err)
+	}
+	s.createMemoCreateActivity(ctx, memo)
+	if screenshot != nil {
+		if _, err := s.Store.UpdateResource(ctx, &store.UpdateResource{
+			ID:     screenshot.ID,
+			MemoID: &memo.ID,
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to upsert memo resource").SetInternal(err)
+		}
+	}
+
+	memoResponse, err := s.convertMemoFromStore(ctx, memo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compose memo response").SetInternal(err)
+	}
+	// Try to dispatch webhook when memo is created.
+	if err := s.DispatchMemoCreatedWebhook(ctx, memoResponse); err != nil {
+		slog.Warn("Failed to dispatch memo created webhook", slog.Any("err", err))
+	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoCreated, memo))
+	return c.JSON(http.StatusOK, memoResponse)
+}
+
+// getCaptureMemoVisibility returns the visibility of the captured memo, the default visibility of the user if it's empty.
+// A normal user can only create private memos if public memos are disabled.
+func (s *APIV1Service) getCaptureMemoVisibility(ctx context.Context, userID int32, visibility Visibility) (Visibility, error) {
+	if visibility == "" {
+		userMemoVisibilitySetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+			UserID: &userID,
+			Key:    storepb.UserSettingKey_USER_SETTING_MEMO_VISIBILITY,
+		})
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user setting").SetInternal(err)
+		}
+		visibility = Private
+		if userMemoVisibilitySetting != nil {
+			visibility = Visibility(userMemoVisibilitySetting.GetMemoVisibility())
+		}
+	}
+	if visibility == Private {
+		return visibility, nil
+	}
+
+	disablePublicMemosSystemSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
+		Name: SystemSettingDisablePublicMemosName.String(),
+	})
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to find system setting").SetInternal(err)
+	}
+	if disablePublicMemosSystemSetting == nil {
+		return visibility, nil
+	}
+	disablePublicMemos := false
+	if err := json.Unmarshal([]byte(disablePublicMemosSystemSetting.Value), &disablePublicMemos); err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to unmarshal system setting").SetInternal(err)
+	}
+	if disablePublicMemos {
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+		}
+		if user != nil && user.Role == store.RoleUser {
+			visibility = Private
+		}
+	}
+	return visibility, nil
+}
+
+// createCaptureScreenshot creates the resource of the screenshot of the capture, which is checked like the uploaded files.
+func (s *APIV1Service) createCaptureScreenshot(c echo.Context, userID int32) (*store.Resource, error) {
+	ctx := c.Request().Context()
+	file, err := c.FormFile("screenshot")
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to parse capture data").SetInternal(err)
+	}
+	fileType := file.Header.Get("Content-Type")
+	if !strings.HasPrefix(fileType, "image/") {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Screenshot must be an image")
+	}
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+	if file.Size > uploadLimit.MaxSizeBytes {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte))
+	}
+	if !uploadLimit.IsTypeAllowed(fileType) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File type %s is not allowed", fileType))
+	}
+
+	sourceFile, err := file.Open()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file").SetInternal(err)
+	}
+	defer sourceFile.Close()
+	result, err := ScanResourceBlob(ctx, s.Store, sourceFile)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to scan file").SetInternal(err)
+	}
+	if result != nil && result.Infected {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File is infected: %s", result.Signature))
+	}
+	if _, err := sourceFile.Seek(0, io.SeekStart); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file").SetInternal(err)
+	}
+
+	create := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: userID,
+		Filename:  file.Filename,
+		Type:      fileType,
+		Size:      file.Size,
+	}
+	if create.Filename == "" || create.Filename == "blob" {
+		create.Filename = "screenshot"
+		if _, subtype, ok := strings.Cut(fileType, "/"); ok {
+			create.Filename += "." + subtype
+		}
+	}
+	if err := SaveResourceBlob(ctx, s.Store, create, sourceFile); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to save resource").SetInternal(err)
+	}
+	resource, err := s.Store.CreateResource(ctx, create)
+	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return nil, echo.NewHTTPError(http.StatusForbidden, "Resource quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return nil, rejectedErr
+		}
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceCreated, resource))
+	return resource, nil
+}
+
+// buildCaptureContent returns the content of the memo of the captured page.
+func buildCaptureContent(request *CaptureMemoRequest, htmlMeta *getter.HTMLMeta) string {
+	title := strings.Join(strings.Fields(request.Title), " ")
+	if title == "" {
+		title = strings.Join(strings.Fields(htmlMeta.Title), " ")
+	}
+	parts := []string{request.URL}
+	if title != "" && title != request.URL {
+		parts[0] = "[" + strings.NewReplacer("[", "\\[", "]", "\\]").Replace(title) + "](" + request.URL + ")"
+	}
+	quote := strings.TrimSpace(strings.ReplaceAll(request.Text, "\r\n", "\n"))
+	if quote == "" {
+		quote = strings.TrimSpace(htmlMeta.Description)
+	}
+	if quote != "" {
+		lines := strings.Split(quote, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace("> " + strings.TrimSpace(line))
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	if note := strings.TrimSpace(strings.ReplaceAll(request.Note, "\r\n", "\n")); note != "" {
+		parts = append(parts, note)
+	}
+	return buildIncomingWebhookContent(strings.Join(parts, "\n\n"), request.Tags)
+}
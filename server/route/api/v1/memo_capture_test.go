@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	getter "github.com/usememos/memos/plugin/http-getter"
+)
+
+func TestBuildCaptureContent(t *testing.T) {
+	tests := []struct {
+		request  *CaptureMemoRequest
+		htmlMeta *getter.HTMLMeta
+		want     string
+	}{
+		{
+			request:  &CaptureMemoRequest{URL: "https://example.com"},
+			htmlMeta: &getter.HTMLMeta{},
+			want:     "https://example.com",
+		},
+		{
+			request:  &CaptureMemoRequest{URL: "https://example.com", Tags: []string{"reading", " clip "}},
+			htmlMeta: &getter.HTMLMeta{Title: "Example\n Domain", Description: "An example page."},
+			want:     "[Example Domain](https://example.com)\n\n> An example page.\n\n#reading #clip",
+		},
+		{
+			request: &CaptureMemoRequest{
+				URL:   "https://example.com/post",
+				Title: "A [draft] post",
+				Text:  "First line.\r\n\r\nSecond line.",
+				Note:  "Worth a read #reading",
+				Tags:  []string{"reading"},
+			},
+			htmlMeta: &getter.HTMLMeta{Title: "Ignored", Description: "Ignored"},
+			want:     "[A \\[draft\\] post](https://example.com/post)\n\n> First line.\n>\n> Second line.\n\nWorth a read #reading",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, buildCaptureContent(test.request, test.htmlMeta))
+	}
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/store"
+)
+
+// memoExportBatchSize is the number of the memos or resources loaded at a time while streaming an export.
+const memoExportBatchSize = 100
+
+// MemoExportLine is a line of the NDJSON export, which has either a memo or a resource.
+type MemoExportLine struct {
+	// Type is memo, resource or error. An error line ends the stream early.
+	Type     string    `json:"type"`
+	Memo     *Memo     `json:"memo,omitempty"`
+	Resource *Resource `json:"resource,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+func (s *APIV1Service) registerMemoExportRoutes(g *echo.Group) {
+	g.GET("/memo/export", s.ExportMemos)
+}
+
+// ExportMemos godoc
+//
+//	@Summary		Stream all memos of the current user as NDJSON
+//	@Description	Each line is a JSON object of the type memo or resource. The memos, including the archived ones and comments, are ordered by created time ascending, followed by the resources.
+//	@Description	The blobs of the resources aren't included. If an error occurs after streaming starts, a line of the type error is sent and the stream ends.
+//	@Tags			memo
+//	@Produce		application/x-ndjson
+//	@Param			resources	query		bool			false	"Include the metadata of the resources"
+//	@Success		200			{object}	MemoExportLine	"Memo export lines"
+//	@Failure		400			{object}	nil				"Invalid resources parameter"
+//	@Failure		401			{object}	nil				"Missing user in session"
+//	@Router			/api/v1/memo/export [GET]
+func (s *APIV1Service) ExportMemos(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	includeResources := false
+	if value := c.QueryParam("resources"); value != "" {
+		var err error
+		if includeResources, err = strconv.ParseBool(value); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid resources parameter").SetInternal(err)
+		}
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="memos-%s.ndjson"`, time.Now().Format("20060102")))
+	// Disable response buffering of nginx.
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(response)
+	// The lines are written batch by batch, so a slow reader holds back the loading of the next batch.
+	write := func(lines []*MemoExportLine) bool {
+		for _, line := range lines {
+			if err := encoder.Encode(line); err != nil {
+				return false
+			}
+		}
+		response.Flush()
+		return ctx.Err() == nil
+	}
+	writeError := func(message string) error {
+		write([]*MemoExportLine{{Type: "error", Error: message}})
+		return nil
+	}
+
+	limit := memoExportBatchSize
+	for offset := 0; ; offset += limit {
+		memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+			CreatorID: &userID,
+			OrderBy:   store.MemoOrderByCreatedTs,
+			OrderAsc:  true,
+			Limit:     &limit,
+			Offset:    &offset,
+		})
+		if err != nil {
+			return writeError("Failed to fetch memo list")
+		}
+		lines := []*MemoExportLine{}
+		for _, memo := range memos {
+			memoMessage, err := s.convertMemoFromStore(ctx, memo)
+			if err != nil {
+				return writeError("Failed to compose memo response")
+			}
+			lines = append(lines, &MemoExportLine{Type: "memo", Memo: memoMessage})
+		}
+		if !write(lines) {
+			return nil
+		}
+		if len(memos) < limit {
+			break
+		}
+	}
+	if !includeResources {
+		return nil
+	}
+	for offset := 0; ; offset += limit {
+		resources, err := s.Store.ListResources(ctx, &store.FindResource{
+			CreatorID: &userID,
+			Limit:     &limit,
+			Offset:    &offset,
+		})
+		if err != nil {
+			return writeError("Failed to fetch resource list")
+		}
+		lines := []*MemoExportLine{}
+		for _, resource := range resources {
+			lines = append(lines, &MemoExportLine{Type: "resource", Resource: convertResourceFromStore(resource)})
+		}
+		if !write(lines) {
+			return nil
+		}
+		if len(resources) < limit {
+			break
+		}
+	}
+	return nil
+}
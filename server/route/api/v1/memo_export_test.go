@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+// TestExportMemos tests all memos of the user are streamed in order across the batches, followed by the resources.
+func TestExportMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser})
+	require.NoError(t, err)
+	memoCount := memoExportBatchSize + 1
+	for i := 0; i < memoCount; i++ {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: fmt.Sprintf("memo %d", i), Visibility: store.Private})
+		require.NoError(t, err)
+		createdTs := int64(1715227200 + i)
+		update := &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs}
+		// The archived memos are exported too.
+		if i == 0 {
+			rowStatus := store.Archived
+			update.RowStatus = &rowStatus
+		}
+		require.NoError(t, ts.UpdateMemo(ctx, update))
+	}
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "other", CreatorID: other.ID, Content: "other", Visibility: store.Public})
+	require.NoError(t, err)
+	_, err = ts.CreateResource(ctx, &store.Resource{UID: "resource", CreatorID: user.ID, Filename: "image.png"})
+	require.NoError(t, err)
+	_, err = ts.CreateResource(ctx, &store.Resource{UID: "other-resource", CreatorID: other.ID, Filename: "other.png"})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts, eventBroker: event.NewBroker()}
+	e := echo.New()
+	export := func(query string) (*httptest.ResponseRecorder, error) {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/memo/export"+query, nil)
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(request, recorder)
+		c.Set(userIDContextKey, user.ID)
+		return recorder, s.ExportMemos(c)
+	}
+
+	tests := []struct {
+		query         string
+		resourceCount int
+	}{
+		{query: ""},
+		{query: "?resources=false"},
+		{query: "?resources=true", resourceCount: 1},
+	}
+	for _, test := range tests {
+		recorder, err := export(test.query)
+		require.NoError(t, err, test.query)
+		require.Equal(t, http.StatusOK, recorder.Code, test.query)
+		require.Equal(t, "application/x-ndjson", recorder.Header().Get(echo.HeaderContentType), test.query)
+		lines := []*MemoExportLine{}
+		scanner := bufio.NewScanner(recorder.Body)
+		for scanner.Scan() {
+			line := &MemoExportLine{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), line), test.query)
+			lines = append(lines, line)
+		}
+		require.NoError(t, scanner.Err())
+		require.Len(t, lines, memoCount+test.resourceCount, test.query)
+		for i, line := range lines[:memoCount] {
+			require.Equal(t, "memo", line.Type, test.query)
+			require.Equal(t, fmt.Sprintf("memo %d", i), line.Memo.Content, test.query)
+		}
+		require.Equal(t, Archived, lines[0].Memo.RowStatus, test.query)
+		for _, line := range lines[memoCount:] {
+			require.Equal(t, "resource", line.Type, test.query)
+			require.Equal(t, "image.png", line.Resource.Filename, test.query)
+		}
+	}
+
+	_, err = export("?resources=yes")
+	httpError := &echo.HTTPError{}
+	require.ErrorAs(t, err, &httpError)
+	require.Equal(t, http.StatusBadRequest, httpError.Code)
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/memo/export", nil), httptest.NewRecorder())
+	err = s.ExportMemos(c)
+	require.ErrorAs(t, err, &httpError)
+	require.Equal(t, http.StatusUnauthorized, httpError.Code)
+}
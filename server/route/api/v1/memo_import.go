@@ -0,0 +1,440 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/server/service/importer"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// ImportSourceNotion is the source of the imports of the Notion exports.
+	ImportSourceNotion = "notion"
+	// ImportSourceENEX is the source of the imports of the ENEX exports of Evernote.
+	ImportSourceENEX = "enex"
+	// ImportSourceJoplin is the source of the imports of the JEX and RAW exports of Joplin.
+	ImportSourceJoplin = "joplin"
+	// ImportSourceDayOne is the source of the imports of the JSON exports of Day One.
+	ImportSourceDayOne = "dayone"
+	// ImportSourceKeep is the source of the imports of the Google Takeout archives of Google Keep.
+	ImportSourceKeep = "keep"
+	// ImportSourceAppleNotes is the source of the imports of the HTML exports of Apple Notes.
+	ImportSourceAppleNotes = "applenotes"
+	// ImportSourceStandardNotes is the source of the imports of the decrypted backups of Standard Notes.
+	ImportSourceStandardNotes = "standardnotes"
+	// ImportSourcePocket is the source of the imports of the HTML and csv exports of Pocket, and of the responses of its retrieve API.
+	ImportSourcePocket = "pocket"
+	// ImportSourceInstapaper is the source of the imports of the csv and HTML exports of Instapaper.
+	ImportSourceInstapaper = "instapaper"
+)
+
+// ImportSources are the supported sources of the imports.
+var ImportSources = []string{ImportSourceNotion, ImportSourceENEX, ImportSourceJoplin, ImportSourceDayOne, ImportSourceKeep, ImportSourceAppleNotes, ImportSourceStandardNotes, ImportSourcePocket, ImportSourceInstapaper}
+
+// ParseImportFile parses the export file of the source to the notes to import.
+func ParseImportFile(source, filename string, r io.ReaderAt, size int64) ([]*importer.Note, error) {
+	switch source {
+	case ImportSourceNotion:
+		return importer.ParseNotionExport(r, size)
+	case ImportSourceENEX:
+		return importer.ParseENEXExport(filename, r, size)
+	case ImportSourceJoplin:
+		return importer.ParseJoplinExport(r, size)
+	case ImportSourceDayOne:
+		return importer.ParseDayOneExport(filename, r, size)
+	case ImportSourceKeep:
+		return importer.ParseKeepExport(r, size)
+	case ImportSourceAppleNotes:
+		return importer.ParseAppleNotesExport(r, size)
+	case ImportSourceStandardNotes:
+		return importer.ParseStandardNotesBackup(r, size)
+	case ImportSourcePocket:
+		return importer.ParsePocketExport(r, size)
+	case ImportSourceInstapaper:
+		return importer.ParseInstapaperExport(r, size)
+	default:
+		return nil, errors.Errorf("unsupported import source %q", source)
+	}
+}
+
+// ParseImportDirectory parses the export directory of the source to the notes to import.
+func ParseImportDirectory(source string, fsys fs.FS) ([]*importer.Note, error) {
+	switch source {
+	case ImportSourceJoplin:
+		return importer.ParseJoplinDirectory(fsys)
+	case ImportSourceAppleNotes:
+		return importer.ParseAppleNotesDirectory(fsys)
+	default:
+		return nil, errors.Errorf("import source %q doesn't support directories", source)
+	}
+}
+
+const (
+	ImportJobRunning = "RUNNING"
+	ImportJobDone    = "DONE"
+	ImportJobFailed  = "FAILED"
+)
+
+// importJobRetention is how long the finished import jobs are kept for their reports.
+const importJobRetention = 24 * time.Hour
+
+// ImportJob is an import running in the background, which reports the progress of a large export.
+type ImportJob struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	// Status is RUNNING, DONE or FAILED.
+	Status string `json:"status"`
+	// Total is the number of the notes in the export, Processed is the number of the notes imported or failed so far.
+	Total     int              `json:"total"`
+	Processed int              `json:"processed"`
+	Result    *importer.Result `json:"result,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	CreatedTs int64            `json:"createdTs"`
+	UpdatedTs int64            `json:"updatedTs"`
+
+	creatorID int32
+}
+
+// importJobList is the list of the import jobs in the memory, they're lost when the server restarts.
+type importJobList struct {
+	mu   sync.Mutex
+	jobs map[string]*ImportJob
+}
+
+// add adds the job to the list, and removes the jobs finished before the retention.
+func (l *importJobList) add(job *ImportJob) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.jobs == nil {
+		l.jobs = map[string]*ImportJob{}
+	}
+	for id, existing := range l.jobs {
+		if existing.Status != ImportJobRunning && time.Since(time.Unix(existing.UpdatedTs, 0)) > importJobRetention {
+			delete(l.jobs, id)
+		}
+	}
+	l.jobs[job.ID] = job
+}
+
+// get returns a copy of the job of the id, nil if it's not found.
+func (l *importJobList) get(id string) *ImportJob {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	job, ok := l.jobs[id]
+	if !ok {
+		return nil
+	}
+	copied := *job
+	return &copied
+}
+
+func (l *importJobList) update(job *ImportJob, update func(job *ImportJob)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	update(job)
+	job.UpdatedTs = time.Now().Unix()
+}
+
+func (s *APIV1Service) registerMemoImportRoutes(g *echo.Group) {
+	g.POST("/memo/import", s.ImportMemos)
+	g.GET("/memo/import/:jobId", s.GetImportJob)
+}
+
+// ImportMemos godoc
+//
+//	@Summary		Import the memos from an export of another app
+//	@Description	Import the notes of the export file as the private memos of the current user. The sources are:
+//	@Description	- notion: the zip file of a Markdown & CSV export of Notion.
+//	@Description	- enex: an ENEX export of Evernote, or a zip file of them.
+//	@Description	- joplin: a JEX export of Joplin, or a zip file of its RAW export directory.
+//	@Description	- dayone: a JSON export of Day One, or the zip file of it with the media.
+//	@Description	- keep: the Google Takeout archive of Google Keep.
+//	@Description	- applenotes: the zip file of the HTML export of Apple Notes.
+//	@Description	- standardnotes: a decrypted backup file of Standard Notes.
+//	@Description	- pocket: the HTML or csv export of Pocket, the zip file of the csv export, or the JSON response of its retrieve API with the complete details.
+//	@Description	- instapaper: the csv or HTML export of Instapaper.
+//	@Description	The saved links of Pocket and Instapaper become link memos with their titles, excerpts and tags.
+//	@Description	The files of the notes are imported as resources and the links between the notes become memo references.
+//	@Description	A note which fails to import is reported in the errors and skipped. With async, the export is imported in the background and the import job is returned to follow the progress.
+//	@Tags			memo
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			source	query		string			true	"Source of the export"
+//	@Param			async	query		bool			false	"Import in the background"
+//	@Param			file	formData	file			true	"Export file"
+//	@Success		200		{object}	importer.Result	"Imported items"
+//	@Success		202		{object}	ImportJob		"Import job"
+//	@Failure		400		{object}	nil				"Unsupported import source | Invalid async parameter | Upload file not found | Failed to parse export file"
+//	@Failure		401		{object}	nil				"Missing user in session"
+//	@Failure		500		{object}	nil				"Failed to find user | Failed to read export file | Failed to import memos"
+//	@Router			/api/v1/memo/import [POST]
+func (s *APIV1Service) ImportMemos(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	source := c.QueryParam("source")
+	if !slices.Contains(ImportSources, source) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported import source %q", source))
+	}
+	async := false
+	if value := c.QueryParam("async"); value != "" {
+		if async, err = strconv.ParseBool(value); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid async parameter").SetInternal(err)
+		}
+	}
+
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+	// The export is limited like the uploaded files, with some room for the other parts of the multipart form.
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, uploadLimit.MaxSizeBytes+MebiByte)
+	file, err := c.FormFile("file")
+	if err != nil {
+		maxBytesErr := &http.MaxBytesError{}
+		if errors.As(err, &maxBytesErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("File size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte)).SetInternal(err)
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, "Upload file not found").SetInternal(err)
+	}
+	if file == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Upload file not found")
+	}
+	if file.Size > uploadLimit.MaxSizeBytes {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("File size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte))
+	}
+	sourceFile, err := file.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to read export file").SetInternal(err)
+	}
+	defer sourceFile.Close()
+
+	// The export is parsed before responding, as the uploaded file is removed after the request.
+	notes, err := ParseImportFile(source, file.Filename, sourceFile, file.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse export file").SetInternal(err)
+	}
+	if !async {
+		result, err := ImportNotes(ctx, s.Store, user, notes, nil)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import memos").SetInternal(err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+
+	now := time.Now().Unix()
+	job := &ImportJob{
+		ID:        shortuuid.New(),
+		Source:    source,
+		Status:    ImportJobRunning,
+		Total:     len(notes),
+		CreatedTs: now,
+		UpdatedTs: now,
+		creatorID: user.ID,
+	}
+	s.importJobs.add(job)
+	response := s.importJobs.get(job.ID)
+	go func() {
+		result, err := ImportNotes(context.Background(), s.Store, user, notes, func(processed int) {
+			s.importJobs.update(job, func(job *ImportJob) {
+				job.Processed = processed
+			})
+		})
+		s.importJobs.update(job, func(job *ImportJob) {
+			if err != nil {
+				slog.Error("Failed to import memos", slog.String("job", job.ID), slog.Any("err", err))
+				job.Status, job.Error = ImportJobFailed, err.Error()
+				return
+			}
+			job.Status, job.Result = ImportJobDone, result
+		})
+	}()
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// GetImportJob godoc
+//
+//	@Summary		Get the import job of the current user
+//	@Description	The finished import jobs are kept for a day, the jobs are lost when the server restarts.
+//	@Tags			memo
+//	@Produce		json
+//	@Param			jobId	path		string		true	"ID of the import job"
+//	@Success		200		{object}	ImportJob	"Import job"
+//	@Failure		401		{object}	nil			"Missing user in session"
+//	@Failure		404		{object}	nil			"Import job not found"
+//	@Router			/api/v1/memo/import/{jobId} [GET]
+func (s *APIV1Service) GetImportJob(c echo.Context) error {
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	job := s.importJobs.get(c.Param("jobId"))
+	if job == nil || job.creatorID != userID {
+		return echo.NewHTTPError(http.StatusNotFound, "Import job not found")
+	}
+	return c.JSON(http.StatusOK, job)
+}
+
+// ImportNotes imports the notes as the private memos of the user, with their resources, tags and references.
+// A note which fails to import is reported in the errors of the result, the import stops if the quota of the user is exceeded.
+// The progress is called with the number of the processed notes after each note if it isn't nil.
+// The webhooks aren't dispatched for the imported memos.
+func ImportNotes(ctx context.Context, s *store.Store, user *store.User, notes []*importer.Note, progress func(processed int)) (*importer.Result, error) {
+	uploadLimit, err := GetUploadLimit(ctx, s, user)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &importer.Result{Errors: []string{}}
+	memos := map[string]*store.Memo{}
+	importedNotes := []*importer.Note{}
+	for i, note := range notes {
+		if progress != nil && i > 0 {
+			progress(i)
+		}
+		memo, err := importNote(ctx, s, user, note)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", note.Source, err))
+			if errors.Is(err, store.ErrQuotaExceeded) {
+				break
+			}
+			continue
+		}
+		memos[memo.UID] = memo
+		importedNotes = append(importedNotes, note)
+		result.Memos++
+
+		for _, attachment := range note.Attachments {
+			if err := importAttachment(ctx, s, user, uploadLimit, memo, attachment); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %s: %v", note.Source, attachment.Filename, err))
+				continue
+			}
+			result.Resources++
+		}
+	}
+
+	// The references are saved after all the memos are created, as a note may link to a note after it.
+	for _, note := range importedNotes {
+		memo := memos[note.UID]
+		for _, uid := range importer.GetMemoReferences(memo.Content) {
+			relatedMemo := memos[uid]
+			if relatedMemo == nil || relatedMemo.ID == memo.ID {
+				continue
+			}
+			if _, err := s.UpsertMemoRelation(ctx, &store.MemoRelation{
+				MemoID:        memo.ID,
+				RelatedMemoID: relatedMemo.ID,
+				Type:          store.MemoRelationReference,
+			}); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to create memo relation: %v", note.Source, err))
+				continue
+			}
+			result.Relations++
+		}
+	}
+	if progress != nil {
+		progress(len(notes))
+	}
+	return result, nil
+}
+
+func importNote(ctx context.Context, s *store.Store, user *store.User, note *importer.Note) (*store.Memo, error) {
+	memo, err := s.CreateMemo(ctx, &store.Memo{
+		UID:        note.UID,
+		CreatorID:  user.ID,
+		Content:    note.MemoContent(),
+		Visibility: store.Private,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create memo")
+	}
+
+	update := &store.UpdateMemo{ID: memo.ID}
+	if note.CreatedTs > 0 {
+		update.CreatedTs = &note.CreatedTs
+		memo.CreatedTs = note.CreatedTs
+		// The memo is shown as updated when it's created if the export doesn't have the updated time.
+		updatedTs := max(note.CreatedTs, note.UpdatedTs)
+		update.UpdatedTs = &updatedTs
+		memo.UpdatedTs = updatedTs
+	} else if note.UpdatedTs > 0 {
+		update.UpdatedTs = &note.UpdatedTs
+		memo.UpdatedTs = note.UpdatedTs
+	}
+	if note.Archived {
+		rowStatus := store.Archived
+		update.RowStatus = &rowStatus
+		memo.RowStatus = rowStatus
+	}
+	if update.CreatedTs != nil || update.UpdatedTs != nil || update.RowStatus != nil {
+		if err := s.UpdateMemo(ctx, update); err != nil {
+			return nil, errors.Wrap(err, "failed to update memo")
+		}
+	}
+	if note.Pinned {
+		if _, err := s.UpsertMemoOrganizer(ctx, &store.MemoOrganizer{MemoID: memo.ID, UserID: user.ID, Pinned: true}); err != nil {
+			return nil, errors.Wrap(err, "failed to pin memo")
+		}
+	}
+	for _, tag := range findTagListFromMemoContent(memo.Content) {
+		if _, err := s.UpsertTag(ctx, &store.Tag{Name: tag, CreatorID: user.ID}); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert tag")
+		}
+	}
+	return memo, nil
+}
+
+func importAttachment(ctx context.Context, s *store.Store, user *store.User, uploadLimit *UploadLimit, memo *store.Memo, attachment *importer.Attachment) error {
+	if int64(len(attachment.Blob)) > uploadLimit.MaxSizeBytes {
+		return errors.Errorf("file size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte)
+	}
+	if !uploadLimit.IsTypeAllowed(attachment.Type) {
+		return errors.Errorf("file type %s is not allowed", attachment.Type)
+	}
+	result, err := ScanResourceBlob(ctx, s, bytes.NewReader(attachment.Blob))
+	if err != nil {
+		return err
+	}
+	if result != nil && result.Infected {
+		return errors.Errorf("file is infected: %s", result.Signature)
+	}
+
+	create := &store.Resource{
+		UID:       attachment.UID,
+		CreatorID: user.ID,
+		Filename:  attachment.Filename,
+		Type:      attachment.Type,
+		Size:      int64(len(attachment.Blob)),
+		MemoID:    &memo.ID,
+	}
+	if err := SaveResourceBlob(ctx, s, create, bytes.NewReader(attachment.Blob)); err != nil {
+		return err
+	}
+	if _, err := s.CreateResource(ctx, create); err != nil {
+		return errors.Wrap(err, "failed to create resource")
+	}
+	return nil
+}
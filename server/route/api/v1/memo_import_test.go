@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+// TestImportMemosUploadLimit tests the exports larger than the upload limit are rejected before they're parsed.
+func TestImportMemosUploadLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+		Name:  SystemSettingMaxUploadSizeMiBName.String(),
+		Value: "1",
+	})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts, eventBroker: event.NewBroker()}
+	e := echo.New()
+
+	for _, size := range []int{MebiByte + 1, 3 * MebiByte} {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "export.zip")
+		require.NoError(t, err)
+		_, err = part.Write(make([]byte, size))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/memo/import?source="+ImportSourceNotion, body)
+		request.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		c := e.NewContext(request, httptest.NewRecorder())
+		c.Set(userIDContextKey, user.ID)
+		err = s.ImportMemos(c)
+		httpError := &echo.HTTPError{}
+		require.ErrorAs(t, err, &httpError, size)
+		require.Equal(t, http.StatusRequestEntityTooLarge, httpError.Code, size)
+	}
+}
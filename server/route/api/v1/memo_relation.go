@@ -16,6 +16,8 @@ type MemoRelationType string
 const (
 	MemoRelationReference MemoRelationType = "REFERENCE"
 	MemoRelationComment   MemoRelationType = "COMMENT"
+	// Other relation types are user-defined, e.g. "blocks", "follows-up" or "contradicts",
+	// which are made of lowercase letters, digits and hyphens.
 )
 
 func (t MemoRelationType) String() string {
@@ -46,8 +48,9 @@ func (s *APIV1Service) registerMemoRelationRoutes(g *echo.Group) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		memoId	path		int						true	"ID of memo to find relations"
+//	@Param		type	query		string					false	"Type of relations to find"
 //	@Success	200		{object}	[]store.MemoRelation	"Memo relation information list"
-//	@Failure	400		{object}	nil						"ID is not a number: %s"
+//	@Failure	400		{object}	nil						"ID is not a number: %s | Invalid memo relation type"
 //	@Failure	500		{object}	nil						"Failed to list memo relations"
 //	@Router		/api/v1/memo/{memoId}/relation [GET]
 func (s *APIV1Service) GetMemoRelationList(c echo.Context) error {
@@ -57,9 +60,16 @@ func (s *APIV1Service) GetMemoRelationList(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("memoId"))).SetInternal(err)
 	}
 
-	memoRelationList, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
+	find := &store.FindMemoRelation{
 		MemoID: &memoID,
-	})
+	}
+	if relationType := store.MemoRelationType(c.QueryParam("type")); relationType != "" {
+		if !relationType.IsValid() {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid memo relation type")
+		}
+		find.Type = &relationType
+	}
+	memoRelationList, err := s.Store.ListMemoRelations(ctx, find)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memo relations").SetInternal(err)
 	}
@@ -76,7 +86,7 @@ func (s *APIV1Service) GetMemoRelationList(c echo.Context) error {
 //	@Param			memoId	path		int							true	"ID of memo to relate"
 //	@Param			body	body		UpsertMemoRelationRequest	true	"Memo relation object"
 //	@Success		200		{object}	store.MemoRelation			"Memo relation information"
-//	@Failure		400		{object}	nil							"ID is not a number: %s | Malformatted post memo relation request"
+//	@Failure		400		{object}	nil							"ID is not a number: %s | Malformatted post memo relation request | Invalid memo relation type"
 //	@Failure		500		{object}	nil							"Failed to upsert memo relation"
 //	@Router			/api/v1/memo/{memoId}/relation [POST]
 //
@@ -95,6 +105,9 @@ func (s *APIV1Service) CreateMemoRelation(c echo.Context) error {
 	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted post memo relation request").SetInternal(err)
 	}
+	if !store.MemoRelationType(request.Type).IsValid() {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid memo relation type")
+	}
 
 	memoRelation, err := s.Store.UpsertMemoRelation(ctx, &store.MemoRelation{
 		MemoID:        memoID,
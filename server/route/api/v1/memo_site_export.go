@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
+	"github.com/usememos/memos/server/service/staticsite"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) registerMemoSiteExportRoutes(g *echo.Group) {
+	g.GET("/memo/export/site", s.ExportMemoSite)
+}
+
+// ExportMemoSite godoc
+//
+//	@Summary		Export the public memos of the current user as a static site
+//	@Description	The zip file is the content tree of Hugo or Jekyll, the memos are the posts with their front matter and the resources are the assets.
+//	@Description	The permalinks of the posts are /memos/{uid}/, the references between the public memos are linked to them.
+//	@Tags			memo
+//	@Produce		application/zip
+//	@Param			format	query		string	false	"Format of the site, hugo or jekyll"	default(hugo)
+//	@Success		200		{file}		file	"Zip file of the site"
+//	@Failure		400		{object}	nil		"Unsupported site format"
+//	@Failure		401		{object}	nil		"Missing user in session"
+//	@Router			/api/v1/memo/export/site [GET]
+func (s *APIV1Service) ExportMemoSite(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	format := c.QueryParam("format")
+	if format == "" {
+		format = staticsite.FormatHugo
+	}
+	if !slices.Contains(staticsite.Formats, format) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported site format %q", format))
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "application/zip")
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="memos-%s-%s.zip"`, format, time.Now().Format("20060102")))
+	response.WriteHeader(http.StatusOK)
+	// The status is sent before the files are written, so an error after it ends the zip file early.
+	writer := zip.NewWriter(response)
+	_, err := ExportStaticSite(ctx, s.Store, format, &userID, func(name string, data []byte) error {
+		w, err := writer.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		slog.Warn("Failed to export static site", slog.Any("err", err))
+		return nil
+	}
+	_ = writer.Close()
+	return nil
+}
+
+// ExportStaticSite writes the public memos of the creator, or of all users if the creator is nil, as the content tree of the format.
+// The resources of the memos and the resources embedded in them are written as the assets.
+func ExportStaticSite(ctx context.Context, s *store.Store, format string, creatorID *int32, writeFile func(name string, data []byte) error) (*staticsite.Result, error) {
+	normalStatus := store.Normal
+	memos, err := s.ListMemos(ctx, &store.FindMemo{
+		CreatorID:       creatorID,
+		RowStatus:       &normalStatus,
+		VisibilityList:  []store.Visibility{store.Public},
+		ExcludeComments: true,
+		ExcludeHidden:   true,
+		OrderBy:         store.MemoOrderByCreatedTs,
+		OrderAsc:        true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memos")
+	}
+
+	memoResources := map[int32][]*store.Resource{}
+	resources := []*store.Resource{}
+	resourceUIDs := map[string]bool{}
+	for _, memo := range memos {
+		list, err := s.ListResources(ctx, &store.FindResource{MemoID: &memo.ID})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list resources")
+		}
+		memoResources[memo.ID] = list
+		for _, resource := range list {
+			if !resourceUIDs[resource.UID] {
+				resourceUIDs[resource.UID] = true
+				resources = append(resources, resource)
+			}
+		}
+	}
+	// The resources of other memos embedded in a memo are published with it if they belong to its creator.
+	for _, memo := range memos {
+		for _, uid := range getEmbeddedResourceUIDs(memo.Content) {
+			if resourceUIDs[uid] {
+				continue
+			}
+			resource, err := s.GetResource(ctx, &store.FindResource{UID: &uid})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to find resource")
+			}
+			if resource != nil && resource.CreatorID == memo.CreatorID {
+				resourceUIDs[uid] = true
+				resources = append(resources, resource)
+			}
+		}
+	}
+
+	// The memos are published with the contents rendered by the hooks.
+	for i, memo := range memos {
+		renderedMemo := *memo
+		renderedMemo.Content = hookrunner.RenderMemoContent(ctx, s, memo)
+		memos[i] = &renderedMemo
+	}
+	site, err := staticsite.New(format, memos, resources)
+	if err != nil {
+		return nil, err
+	}
+	result := &staticsite.Result{}
+	for _, memo := range memos {
+		data, err := site.RenderPost(memo, findTagListFromMemoContent(memo.Content), memoResources[memo.ID])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render memo %q", memo.UID)
+		}
+		if err := writeFile(site.PostPath(memo), data); err != nil {
+			return nil, errors.Wrapf(err, "failed to write memo %q", memo.UID)
+		}
+		result.Posts++
+	}
+	for _, resource := range resources {
+		assetPath := site.AssetPath(resource)
+		if assetPath == "" {
+			continue
+		}
+		blob, err := GetResourceBlob(ctx, s, resource)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read resource %q", resource.UID)
+		}
+		if err := writeFile(assetPath, blob); err != nil {
+			return nil, errors.Wrapf(err, "failed to write resource %q", resource.UID)
+		}
+		result.Assets++
+	}
+	return result, nil
+}
+
+// getEmbeddedResourceUIDs returns the uids of the resources embedded in the content.
+func getEmbeddedResourceUIDs(content string) []string {
+	uids := []string{}
+	for _, part := range strings.Split(content, "![[resources/")[1:] {
+		uid, _, ok := strings.Cut(part, "]]")
+		if !ok {
+			continue
+		}
+		uid, _, _ = strings.Cut(uid, "?")
+		if uid != "" && !slices.Contains(uids, uid) {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
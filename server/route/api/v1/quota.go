@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// checkQuota counts the request against the quota of the access token, and sets the rate limit headers.
+func (s *APIV1Service) checkQuota(c echo.Context, accessToken string) error {
+	if s.quotaLimiter == nil {
+		return nil
+	}
+	result := s.quotaLimiter.Allow(c.Request().Context(), accessToken)
+	for key, value := range result.Headers(s.quotaLimiter.Now()) {
+		c.Response().Header().Set(key, value)
+	}
+	if !result.Allowed {
+		return echo.NewHTTPError(http.StatusTooManyRequests, fmt.Sprintf("API quota of %d requests exceeded", result.Limit))
+	}
+	return nil
+}
@@ -1,25 +1,35 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"image/png"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/disintegration/imaging"
 	"github.com/labstack/echo/v4"
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
+	getter "github.com/usememos/memos/plugin/http-getter"
 	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/storage/sftp"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/route/api/idempotency"
 	"github.com/usememos/memos/store"
 )
 
@@ -46,6 +56,36 @@ type CreateResourceRequest struct {
 	Filename     string `json:"filename"`
 	ExternalLink string `json:"externalLink"`
 	Type         string `json:"type"`
+	Size         int64  `json:"size"`
+
+	// RequestID is the idempotency key of the request, if there is no Idempotency-Key header.
+	RequestID string `json:"requestId"`
+}
+
+type FetchResourceRequest struct {
+	URL string `json:"url"`
+	// Filename is optional, it defaults to the last segment of the URL path.
+	Filename string `json:"filename"`
+}
+
+type PreSignResourceUploadRequest struct {
+	Filename string `json:"filename"`
+	Type     string `json:"type"`
+	Size     int64  `json:"size"`
+}
+
+type PreSignResourceUploadResponse struct {
+	// UploadURL is the pre-signed URL to upload the file to.
+	UploadURL string `json:"uploadUrl"`
+	Method    string `json:"method"`
+	// Header is the headers that must be sent with the upload request.
+	Header map[string]string `json:"header"`
+	// ExternalLink is the link of the file after it's uploaded.
+	ExternalLink string `json:"externalLink"`
+	ExpiresTs    int64  `json:"expiresTs"`
+	// Resource is the resource of the upload, which is created along with the pre-signed request,
+	// so the key of the object is tied to it.
+	Resource *Resource `json:"resource"`
 }
 
 type FindResourceRequest struct {
@@ -68,10 +108,17 @@ const (
 
 var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
 
+// fetchableResourceTypes are the media type prefixes of remote files which can be fetched as resources.
+var fetchableResourceTypes = []string{"image/", "video/", "audio/", "application/pdf", "text/plain"}
+
 func (s *APIV1Service) registerResourceRoutes(g *echo.Group) {
 	g.GET("/resource", s.GetResourceList)
 	g.POST("/resource", s.CreateResource)
+	g.POST("/resource/batch", s.BatchResources)
 	g.POST("/resource/blob", s.UploadResource)
+	g.POST("/resource/fetch", s.FetchResource)
+	g.POST("/resource/presign", s.PreSignResourceUpload)
+	g.POST("/resource/gc", s.GarbageCollectResources)
 	g.PATCH("/resource/:resourceId", s.UpdateResource)
 	g.DELETE("/resource/:resourceId", s.DeleteResource)
 }
@@ -116,16 +163,20 @@ func (s *APIV1Service) GetResourceList(c echo.Context) error {
 
 // CreateResource godoc
 //
-//	@Summary	Create resource
-//	@Tags		resource
-//	@Accept		json
-//	@Produce	json
-//	@Param		body	body		CreateResourceRequest	true	"Request object."
-//	@Success	200		{object}	store.Resource			"Created resource"
-//	@Failure	400		{object}	nil						"Malformatted post resource request | Invalid external link | Invalid external link scheme | Failed to request %s | Failed to read %s | Failed to read mime from %s"
-//	@Failure	401		{object}	nil						"Missing user in session"
-//	@Failure	500		{object}	nil						"Failed to save resource | Failed to create resource | Failed to create activity"
-//	@Router		/api/v1/resource [POST]
+//	@Summary		Create resource
+//	@Description	The resource created by a previous request with the same Idempotency-Key header or requestId within 24 hours is returned instead of creating a new one.
+//	@Tags			resource
+//	@Accept			json
+//	@Produce		json
+//	@Param			Idempotency-Key	header		string					false	"Idempotency key"
+//	@Param			body			body		CreateResourceRequest	true	"Request object."
+//	@Success		200				{object}	store.Resource			"Created resource"
+//	@Failure		400				{object}	nil						"Malformatted post resource request | Invalid external link | Invalid external link scheme | File size exceeds allowed limit of %d MiB | File type %s is not allowed | Failed to request %s | Failed to read %s | Failed to read mime from %s | Invalid idempotency key"
+//	@Failure		401				{object}	nil						"Missing user in session"
+//	@Failure		403				{object}	nil						"Resource quota exceeded"
+//	@Failure		409				{object}	nil						"A request with the same idempotency key is in progress"
+//	@Failure		500				{object}	nil						"Failed to get upload limit | Failed to check idempotency key | Failed to save resource | Failed to create resource | Failed to create activity"
+//	@Router			/api/v1/resource [POST]
 func (s *APIV1Service) CreateResource(c echo.Context) error {
 	ctx := c.Request().Context()
 	userID, ok := c.Get(userIDContextKey).(int32)
@@ -138,12 +189,22 @@ func (s *APIV1Service) CreateResource(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted post resource request").SetInternal(err)
 	}
 
+	idempotencyKey, err := s.beginIdempotentCreate(c, userID, "resource", request.RequestID)
+	if err != nil {
+		return err
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		return s.replayCreateResource(c, idempotencyKey.EntityID)
+	}
+	defer idempotency.Release(ctx, s.Store, idempotencyKey)
+
 	create := &store.Resource{
 		UID:          shortuuid.New(),
 		CreatorID:    userID,
 		Filename:     request.Filename,
 		ExternalLink: request.ExternalLink,
 		Type:         request.Type,
+		Size:         request.Size,
 	}
 	if request.ExternalLink != "" {
 		// Only allow those external links scheme with http/https
@@ -155,26 +216,67 @@ func (s *APIV1Service) CreateResource(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, "Invalid external link scheme")
 		}
 	}
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+	if create.Size > uploadLimit.MaxSizeBytes {
+		message := fmt.Sprintf("File size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte)
+		return echo.NewHTTPError(http.StatusBadRequest, message)
+	}
+	if !uploadLimit.IsTypeAllowed(create.Type) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File type %s is not allowed", create.Type))
+	}
 
 	resource, err := s.Store.CreateResource(ctx, create)
 	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Resource quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	if err := idempotency.Complete(ctx, s.Store, idempotencyKey, resource.ID); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
 	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceCreated, resource))
+	return c.JSON(http.StatusOK, convertResourceFromStore(resource))
+}
+
+// replayCreateResource responds with the resource created by a previous request with the same idempotency key.
+func (s *APIV1Service) replayCreateResource(c echo.Context, resourceID int32) error {
+	resource, err := s.Store.GetResource(c.Request().Context(), &store.FindResource{
+		ID: &resourceID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find resource").SetInternal(err)
+	}
+	if resource == nil {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Resource not found: %d", resourceID))
+	}
+	c.Response().Header().Set(idempotency.ReplayedHeader, "true")
 	return c.JSON(http.StatusOK, convertResourceFromStore(resource))
 }
 
 // UploadResource godoc
 //
-//	@Summary	Upload resource
-//	@Tags		resource
-//	@Accept		multipart/form-data
-//	@Produce	json
-//	@Param		file	formData	file			true	"File to upload"
-//	@Success	200		{object}	store.Resource	"Created resource"
-//	@Failure	400		{object}	nil				"Upload file not found | File size exceeds allowed limit of %d MiB | Failed to parse upload data"
-//	@Failure	401		{object}	nil				"Missing user in session"
-//	@Failure	500		{object}	nil				"Failed to get uploading file | Failed to open file | Failed to save resource | Failed to create resource | Failed to create activity"
-//	@Router		/api/v1/resource/blob [POST]
+//	@Summary		Upload resource
+//	@Description	The resource created by a previous request with the same Idempotency-Key header or requestId within 24 hours is returned instead of creating a new one.
+//	@Tags			resource
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			Idempotency-Key	header		string			false	"Idempotency key"
+//	@Param			file			formData	file			true	"File to upload"
+//	@Param			requestId		formData	string			false	"Idempotency key, if there is no Idempotency-Key header"
+//	@Success		200				{object}	store.Resource	"Created resource"
+//	@Failure		400				{object}	nil				"Upload file not found | File size exceeds allowed limit of %d MiB | File type %s is not allowed | Failed to parse upload data | File is infected: %s | Invalid idempotency key"
+//	@Failure		401				{object}	nil				"Missing user in session"
+//	@Failure		403				{object}	nil				"Resource quota exceeded"
+//	@Failure		409				{object}	nil				"A request with the same idempotency key is in progress"
+//	@Failure		500				{object}	nil				"Failed to get upload limit | Failed to get uploading file | Failed to open file | Failed to check idempotency key | Failed to scan file | Failed to get workspace storage setting | Failed to save resource | Failed to create resource | Failed to create activity"
+//	@Router			/api/v1/resource/blob [POST]
 func (s *APIV1Service) UploadResource(c echo.Context) error {
 	ctx := c.Request().Context()
 	userID, ok := c.Get(userIDContextKey).(int32)
@@ -182,20 +284,9 @@ func (s *APIV1Service) UploadResource(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
 	}
 
-	maxUploadSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{Name: SystemSettingMaxUploadSizeMiBName.String()})
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get max upload size").SetInternal(err)
-	}
-	var settingMaxUploadSizeBytes int
-	if maxUploadSetting != nil {
-		if settingMaxUploadSizeMiB, err := strconv.Atoi(maxUploadSetting.Value); err == nil {
-			settingMaxUploadSizeBytes = settingMaxUploadSizeMiB * MebiByte
-		} else {
-			settingMaxUploadSizeBytes = 0
-		}
-	} else {
-		// Default to 32 MiB.
-		settingMaxUploadSizeBytes = 32 * MebiByte
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
 	}
 
 	file, err := c.FormFile("file")
@@ -206,14 +297,26 @@ func (s *APIV1Service) UploadResource(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Upload file not found").SetInternal(err)
 	}
 
-	if file.Size > int64(settingMaxUploadSizeBytes) {
-		message := fmt.Sprintf("File size exceeds allowed limit of %d MiB", settingMaxUploadSizeBytes/MebiByte)
+	if file.Size > uploadLimit.MaxSizeBytes {
+		message := fmt.Sprintf("File size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte)
 		return echo.NewHTTPError(http.StatusBadRequest, message).SetInternal(err)
 	}
+	if fileType := file.Header.Get("Content-Type"); !uploadLimit.IsTypeAllowed(fileType) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File type %s is not allowed", fileType))
+	}
 	if err := c.Request().ParseMultipartForm(maxUploadBufferSizeBytes); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse upload data").SetInternal(err)
 	}
 
+	idempotencyKey, err := s.beginIdempotentCreate(c, userID, "resource", c.FormValue("requestId"))
+	if err != nil {
+		return err
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		return s.replayCreateResource(c, idempotencyKey.EntityID)
+	}
+	defer idempotency.Release(ctx, s.Store, idempotencyKey)
+
 	sourceFile, err := file.Open()
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file").SetInternal(err)
@@ -227,18 +330,257 @@ func (s *APIV1Service) UploadResource(c echo.Context) error {
 		Type:      file.Header.Get("Content-Type"),
 		Size:      file.Size,
 	}
-	err = SaveResourceBlob(ctx, s.Store, create, sourceFile)
+	result, err := ScanResourceBlob(ctx, s.Store, sourceFile)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to scan file").SetInternal(err)
+	}
+	if result != nil && result.Infected {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File is infected: %s", result.Signature))
+	}
+
+	var reader io.Reader = sourceFile
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace storage setting").SetInternal(err)
+	}
+	if threshold := int64(workspaceStorageSetting.ImageCompressionThresholdMib) * MebiByte; threshold > 0 && file.Size > threshold && util.HasPrefixes(create.Type, "image/png", "image/jpeg") {
+		blob, err := compressImage(sourceFile, create.Type, workspaceStorageSetting)
+		if err != nil {
+			slog.Warn("Failed to compress image", slog.Any("err", err))
+		}
+		// Keep the original image if it can't be compressed smaller.
+		if err == nil && int64(len(blob)) < file.Size {
+			reader = bytes.NewReader(blob)
+			create.Size = int64(len(blob))
+		} else if _, err := sourceFile.Seek(0, io.SeekStart); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file").SetInternal(err)
+		}
+	}
+	err = SaveResourceBlob(ctx, s.Store, create, reader)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save resource").SetInternal(err)
 	}
 
 	resource, err := s.Store.CreateResource(ctx, create)
 	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Resource quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
 	}
+	if err := idempotency.Complete(ctx, s.Store, idempotencyKey, resource.ID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceCreated, resource))
 	return c.JSON(http.StatusOK, convertResourceFromStore(resource))
 }
 
+// FetchResource godoc
+//
+//	@Summary	Download a remote file and save it as a resource
+//	@Tags		resource
+//	@Accept		json
+//	@Produce	json
+//	@Param		body	body		FetchResourceRequest	true	"Request object."
+//	@Success	200		{object}	store.Resource			"Created resource"
+//	@Failure	400		{object}	nil						"Malformatted fetch resource request | Invalid URL | Failed to fetch remote file | File type %s is not allowed | Invalid filename | File is infected: %s"
+//	@Failure	401		{object}	nil						"Missing user in session"
+//	@Failure	403		{object}	nil						"Resource quota exceeded"
+//	@Failure	500		{object}	nil						"Failed to get upload limit | Failed to scan file | Failed to save resource | Failed to create resource"
+//	@Router		/api/v1/resource/fetch [POST]
+func (s *APIV1Service) FetchResource(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+
+	request := &FetchResourceRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted fetch resource request").SetInternal(err)
+	}
+	if _, err := url.ParseRequestURI(request.URL); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid URL").SetInternal(err)
+	}
+
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+	file, err := getter.GetFile(ctx, request.URL, uploadLimit.MaxSizeBytes)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to fetch remote file").SetInternal(err)
+	}
+	if !util.HasPrefixes(file.Mediatype, fetchableResourceTypes...) || !uploadLimit.IsTypeAllowed(file.Mediatype) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File type %s is not allowed", file.Mediatype))
+	}
+
+	create := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: userID,
+		Filename:  file.Filename,
+		Type:      file.Mediatype,
+		Size:      int64(len(file.Blob)),
+	}
+	if request.Filename != "" {
+		if !isValidFilename(request.Filename) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid filename")
+		}
+		create.Filename = request.Filename
+	}
+	result, err := ScanResourceBlob(ctx, s.Store, bytes.NewReader(file.Blob))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to scan file").SetInternal(err)
+	}
+	if result != nil && result.Infected {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File is infected: %s", result.Signature))
+	}
+	if err := SaveResourceBlob(ctx, s.Store, create, bytes.NewReader(file.Blob)); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save resource").SetInternal(err)
+	}
+
+	resource, err := s.Store.CreateResource(ctx, create)
+	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Resource quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceCreated, resource))
+	return c.JSON(http.StatusOK, convertResourceFromStore(resource))
+}
+
+// PreSignResourceUpload godoc
+//
+//	@Summary	Pre-sign a direct upload to the object storage
+//	@Tags		resource
+//	@Accept		json
+//	@Produce	json
+//	@Param		body	body		PreSignResourceUploadRequest	true	"Request object."
+//	@Success	200		{object}	PreSignResourceUploadResponse	"Pre-signed upload request"
+//	@Failure	400		{object}	nil								"Malformatted pre-sign upload request | Filename is required | Invalid filename | Size must be positive | File size exceeds allowed limit of %d MiB | File type %s is not allowed | Direct upload is not allowed while upload scanning is enabled | Current storage doesn't support direct upload"
+//	@Failure	401		{object}	nil								"Missing user in session"
+//	@Failure	403		{object}	nil								"Resource quota exceeded"
+//	@Failure	500		{object}	nil								"Failed to get upload limit | Failed to get workspace storage setting | Failed to find storage | Failed to create s3 client | Failed to pre-sign upload | Failed to create resource"
+//	@Router		/api/v1/resource/presign [POST]
+func (s *APIV1Service) PreSignResourceUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+
+	request := &PreSignResourceUploadRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted pre-sign upload request").SetInternal(err)
+	}
+	if request.Filename == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Filename is required")
+	}
+	if !isValidFilename(request.Filename) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid filename")
+	}
+	// The size is signed into the upload URL, so the storage rejects the uploads of any other size.
+	if request.Size <= 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Size must be positive")
+	}
+	uploadLimit, err := s.getUploadLimit(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload limit").SetInternal(err)
+	}
+	if request.Size > uploadLimit.MaxSizeBytes {
+		message := fmt.Sprintf("File size exceeds allowed limit of %d MiB", uploadLimit.MaxSizeBytes/MebiByte)
+		return echo.NewHTTPError(http.StatusBadRequest, message)
+	}
+	if !uploadLimit.IsTypeAllowed(request.Type) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File type %s is not allowed", request.Type))
+	}
+
+	// Files uploaded directly to the object storage can't be scanned.
+	uploadScannerEnabled, err := isUploadScannerEnabled(ctx, s.Store)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace storage setting").SetInternal(err)
+	}
+	if uploadScannerEnabled {
+		return echo.NewHTTPError(http.StatusBadRequest, "Direct upload is not allowed while upload scanning is enabled")
+	}
+
+	storageServiceID, err := getStorageServiceID(ctx, s.Store)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find storage").SetInternal(err)
+	}
+	storage, err := s.Store.GetStorage(ctx, &store.FindStorage{ID: &storageServiceID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find storage").SetInternal(err)
+	}
+	if storage == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Current storage doesn't support direct upload")
+	}
+	storageMessage, err := ConvertStorageFromStore(storage)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find storage").SetInternal(err)
+	}
+	if storageMessage.Type != StorageS3 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Current storage doesn't support direct upload")
+	}
+
+	s3Config := storageMessage.Config.S3Config
+	s3Client, err := s3.NewClient(ctx, convertS3Config(s3Config))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create s3 client").SetInternal(err)
+	}
+	create := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: userID,
+		Filename:  request.Filename,
+		Type:      request.Type,
+		Size:      request.Size,
+	}
+	// The key is generated by the server and prefixed with the uid of the resource,
+	// so the upload can't overwrite the other objects of the bucket.
+	key := formatS3FilePath(s3Config, fmt.Sprintf("%s_%s", create.UID, request.Filename))
+	req, link, err := s3Client.PreSignUploadFile(ctx, key, request.Type, request.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to pre-sign upload").SetInternal(err)
+	}
+	create.ExternalLink = link
+	create.InternalPath = s3.FormatReference(storage.ID, key)
+	resource, err := s.Store.CreateResource(ctx, create)
+	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusForbidden, "Resource quota exceeded").SetInternal(err)
+		}
+		if rejectedErr := getHookRejectedError(err); rejectedErr != nil {
+			return rejectedErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceCreated, resource))
+
+	header := map[string]string{}
+	for key := range req.SignedHeader {
+		// Host and Content-Length are set by the client itself.
+		if key == "Host" || key == "Content-Length" {
+			continue
+		}
+		header[key] = req.SignedHeader.Get(key)
+	}
+	return c.JSON(http.StatusOK, &PreSignResourceUploadResponse{
+		UploadURL:    req.URL,
+		Method:       req.Method,
+		Header:       header,
+		ExternalLink: link,
+		ExpiresTs:    time.Now().Add(s3.UploadLifetime).Unix(),
+		Resource:     convertResourceFromStore(resource),
+	})
+}
+
 // DeleteResource godoc
 //
 //	@Summary	Delete a resource
@@ -274,11 +616,15 @@ func (s *APIV1Service) DeleteResource(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Resource not found: %d", resourceID))
 	}
 
+	if err := DeleteResourceBlob(ctx, s.Store, resource); err != nil {
+		slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+	}
 	if err := s.Store.DeleteResource(ctx, &store.DeleteResource{
 		ID: resourceID,
 	}); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete resource").SetInternal(err)
 	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceDeleted, resource))
 	return c.JSON(http.StatusOK, true)
 }
 
@@ -341,8 +687,24 @@ func (s *APIV1Service) UpdateResource(c echo.Context) error {
 	return c.JSON(http.StatusOK, convertResourceFromStore(resource))
 }
 
+// isValidFilename returns true if the filename is a single path element, so it can't point out of a directory.
+func isValidFilename(filename string) bool {
+	return filename != "" && filename != "." && filename != ".." && !strings.ContainsAny(filename, `/\`)
+}
+
+// getTemplateFilename returns the last element of the filename for the storage path templates,
+// so the files of any filename are saved in the directory of the template.
+func getTemplateFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	if !isValidFilename(filename) {
+		return "file"
+	}
+	return filename
+}
+
 func replacePathTemplate(path, filename string) string {
 	t := time.Now()
+	filename = getTemplateFilename(filename)
 	path = fileKeyPattern.ReplaceAllStringFunc(path, func(s string) string {
 		switch s {
 		case "{filename}":
@@ -391,19 +753,11 @@ func convertResourceFromStore(resource *store.Resource) *Resource {
 // Depend on the storage config, some fields of *store.ResourceCreate will be changed:
 // 1. *DatabaseStorage*: `create.Blob`.
 // 2. *LocalStorage*: `create.InternalPath`.
-// 3. Others( external service): `create.ExternalLink`.
+// 3. Others( external service): `create.ExternalLink`, and `create.InternalPath` as the reference of the object.
 func SaveResourceBlob(ctx context.Context, s *store.Store, create *store.Resource, r io.Reader) error {
-	systemSettingStorageServiceID, err := s.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{Name: SystemSettingStorageServiceIDName.String()})
+	storageServiceID, err := getStorageServiceID(ctx, s)
 	if err != nil {
-		return errors.Wrap(err, "Failed to find SystemSettingStorageServiceIDName")
-	}
-
-	storageServiceID := DefaultStorage
-	if systemSettingStorageServiceID != nil {
-		err = json.Unmarshal([]byte(systemSettingStorageServiceID.Value), &storageServiceID)
-		if err != nil {
-			return errors.Wrap(err, "Failed to unmarshal storage service id")
-		}
+		return err
 	}
 
 	// `DatabaseStorage` means store blob into database
@@ -470,30 +824,38 @@ func SaveResourceBlob(ctx context.Context, s *store.Store, create *store.Resourc
 		return errors.Wrap(err, "Failed to ConvertStorageFromStore")
 	}
 
+	if storageMessage.Type == StorageSFTP {
+		sftpConfig := storageMessage.Config.SFTPConfig
+		sftpClient, err := sftp.NewClient(convertSFTPConfig(sftpConfig))
+		if err != nil {
+			return errors.Wrap(err, "Failed to create sftp client")
+		}
+		defer sftpClient.Close()
+
+		filePath := sftpConfig.Path
+		if !strings.Contains(filePath, "{filename}") {
+			filePath = path.Join(filePath, "{filename}")
+		}
+		filePath = replacePathTemplate(filePath, create.Filename)
+		if err := sftpClient.UploadFile(filePath, r); err != nil {
+			return errors.Wrap(err, "Failed to upload via sftp client")
+		}
+
+		create.InternalPath = sftp.FormatReference(storage.ID, filePath)
+		return nil
+	}
+
 	if storageMessage.Type != StorageS3 {
 		return errors.Errorf("Unsupported storage type: %s", storageMessage.Type)
 	}
 
 	s3Config := storageMessage.Config.S3Config
-	s3Client, err := s3.NewClient(ctx, &s3.Config{
-		AccessKey: s3Config.AccessKey,
-		SecretKey: s3Config.SecretKey,
-		EndPoint:  s3Config.EndPoint,
-		Region:    s3Config.Region,
-		Bucket:    s3Config.Bucket,
-		URLPrefix: s3Config.URLPrefix,
-		URLSuffix: s3Config.URLSuffix,
-		PreSign:   s3Config.PreSign,
-	})
+	s3Client, err := s3.NewClient(ctx, convertS3Config(s3Config))
 	if err != nil {
 		return errors.Wrap(err, "Failed to create s3 client")
 	}
 
-	filePath := s3Config.Path
-	if !strings.Contains(filePath, "{filename}") {
-		filePath = filepath.Join(filePath, "{filename}")
-	}
-	filePath = replacePathTemplate(filePath, create.Filename)
+	filePath := formatS3FilePath(s3Config, create.Filename)
 
 	link, err := s3Client.UploadFile(ctx, filePath, create.Type, r)
 	if err != nil {
@@ -501,5 +863,196 @@ func SaveResourceBlob(ctx context.Context, s *store.Store, create *store.Resourc
 	}
 
 	create.ExternalLink = link
+	// The object is recorded, so it's served by the server only if the server itself uploaded it.
+	create.InternalPath = s3.FormatReference(storage.ID, filePath)
 	return nil
 }
+
+// DeleteResourceBlob deletes the blob of resource from the remote storage if it's stored in one
+// that is only reachable by the server, such as SFTP.
+func DeleteResourceBlob(ctx context.Context, s *store.Store, resource *store.Resource) error {
+	storageID, filePath, ok := sftp.ParseReference(resource.InternalPath)
+	if !ok {
+		return nil
+	}
+	storage, err := s.GetStorage(ctx, &store.FindStorage{ID: &storageID})
+	if err != nil {
+		return errors.Wrap(err, "Failed to find storage")
+	}
+	if storage == nil {
+		return errors.Errorf("Storage %d not found", storageID)
+	}
+	storageMessage, err := ConvertStorageFromStore(storage)
+	if err != nil {
+		return errors.Wrap(err, "Failed to ConvertStorageFromStore")
+	}
+	if storageMessage.Type != StorageSFTP {
+		return errors.Errorf("Unsupported storage type: %s", storageMessage.Type)
+	}
+
+	sftpClient, err := sftp.NewClient(convertSFTPConfig(storageMessage.Config.SFTPConfig))
+	if err != nil {
+		return errors.Wrap(err, "Failed to create sftp client")
+	}
+	defer sftpClient.Close()
+	return sftpClient.DeleteFile(filePath)
+}
+
+// GetResourceBlob reads the blob of resource from where it's stored.
+// A nil blob is returned for resources stored in external services.
+func GetResourceBlob(ctx context.Context, s *store.Store, resource *store.Resource) ([]byte, error) {
+	if storageID, filePath, ok := sftp.ParseReference(resource.InternalPath); ok {
+		storage, err := s.GetStorage(ctx, &store.FindStorage{ID: &storageID})
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to find storage")
+		}
+		if storage == nil {
+			return nil, errors.Errorf("Storage %d not found", storageID)
+		}
+		storageMessage, err := ConvertStorageFromStore(storage)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to ConvertStorageFromStore")
+		}
+		if storageMessage.Type != StorageSFTP {
+			return nil, errors.Errorf("Unsupported storage type: %s", storageMessage.Type)
+		}
+		sftpClient, err := sftp.NewClient(convertSFTPConfig(storageMessage.Config.SFTPConfig))
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to create sftp client")
+		}
+		defer sftpClient.Close()
+		file, err := sftpClient.OpenFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+	// The resources uploaded to S3 have the references of their objects as well.
+	if resource.ExternalLink != "" {
+		return nil, nil
+	}
+	if resource.InternalPath != "" {
+		return os.ReadFile(resolveLocalPath(s.Profile.Data, resource.InternalPath))
+	}
+	if resource.Blob != nil {
+		return resource.Blob, nil
+	}
+	// The blob is not loaded by default, so find the resource again with it.
+	resourceWithBlob, err := s.GetResource(ctx, &store.FindResource{ID: &resource.ID, GetBlob: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to find resource")
+	}
+	if resourceWithBlob == nil {
+		return nil, errors.Errorf("Resource %d not found", resource.ID)
+	}
+	return resourceWithBlob.Blob, nil
+}
+
+func convertSFTPConfig(config *StorageSFTPConfig) *sftp.Config {
+	return &sftp.Config{
+		Host:       config.Host,
+		Port:       config.Port,
+		Username:   config.Username,
+		PrivateKey: config.PrivateKey,
+		Passphrase: config.Passphrase,
+		HostKey:    config.HostKey,
+		Path:       config.Path,
+	}
+}
+
+func (s *APIV1Service) getUploadLimit(ctx context.Context, userID int32) (*UploadLimit, error) {
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.Errorf("user %d not found", userID)
+	}
+	return GetUploadLimit(ctx, s.Store, user)
+}
+
+func getMaxUploadSizeBytes(ctx context.Context, s *store.Store) (int, error) {
+	maxUploadSetting, err := s.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{Name: SystemSettingMaxUploadSizeMiBName.String()})
+	if err != nil {
+		return 0, err
+	}
+	if maxUploadSetting == nil {
+		// Default to 32 MiB.
+		return 32 * MebiByte, nil
+	}
+	settingMaxUploadSizeMiB, err := strconv.Atoi(maxUploadSetting.Value)
+	if err != nil {
+		// An invalid setting disallows uploading.
+		return 0, nil
+	}
+	return settingMaxUploadSizeMiB * MebiByte, nil
+}
+
+func getStorageServiceID(ctx context.Context, s *store.Store) (int32, error) {
+	systemSettingStorageServiceID, err := s.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{Name: SystemSettingStorageServiceIDName.String()})
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to find SystemSettingStorageServiceIDName")
+	}
+
+	storageServiceID := DefaultStorage
+	if systemSettingStorageServiceID != nil {
+		err = json.Unmarshal([]byte(systemSettingStorageServiceID.Value), &storageServiceID)
+		if err != nil {
+			return 0, errors.Wrap(err, "Failed to unmarshal storage service id")
+		}
+	}
+	return storageServiceID, nil
+}
+
+func formatS3FilePath(config *StorageS3Config, filename string) string {
+	filePath := config.Path
+	if !strings.Contains(filePath, "{filename}") {
+		filePath = filepath.Join(filePath, "{filename}")
+	}
+	return replacePathTemplate(filePath, filename)
+}
+
+func convertS3Config(config *StorageS3Config) *s3.Config {
+	return &s3.Config{
+		AccessKey: config.AccessKey,
+		SecretKey: config.SecretKey,
+		EndPoint:  config.EndPoint,
+		Region:    config.Region,
+		Bucket:    config.Bucket,
+		URLPrefix: config.URLPrefix,
+		URLSuffix: config.URLSuffix,
+		PreSign:   config.PreSign,
+	}
+}
+
+// defaultImageQuality is the JPEG quality used if the workspace storage setting doesn't specify one.
+const defaultImageQuality = 80
+
+// compressImage downscales the image to the max dimension and re-encodes it according to the workspace storage setting.
+func compressImage(r io.Reader, imageType string, setting *storepb.WorkspaceStorageSetting) ([]byte, error) {
+	src, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode image")
+	}
+	if maxDimension := int(setting.ImageMaxDimension); maxDimension > 0 {
+		if bounds := src.Bounds(); bounds.Dx() > maxDimension || bounds.Dy() > maxDimension {
+			src = imaging.Fit(src, maxDimension, maxDimension, imaging.Lanczos)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if imageType == "image/png" {
+		err = imaging.Encode(buf, src, imaging.PNG, imaging.PNGCompressionLevel(png.BestCompression))
+	} else {
+		quality := int(setting.ImageQuality)
+		if quality <= 0 {
+			quality = defaultImageQuality
+		}
+		err = imaging.Encode(buf, src, imaging.JPEG, imaging.JPEGQuality(quality))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to encode image")
+	}
+	return buf.Bytes(), nil
+}
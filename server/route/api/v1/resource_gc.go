@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+// ResourceGCGracePeriod is the minimum age of an orphaned resource or file before it's collected.
+// It keeps freshly uploaded resources, which are not yet attached to a memo, from being deleted.
+const ResourceGCGracePeriod = 24 * time.Hour
+
+type ResourceGCResult struct {
+	DeletedResourceCount int `json:"deletedResourceCount"`
+	DeletedFileCount     int `json:"deletedFileCount"`
+}
+
+// GarbageCollectResources godoc
+//
+//	@Summary	Delete orphaned resources and local files
+//	@Tags		resource
+//	@Produce	json
+//	@Success	200	{object}	ResourceGCResult	"Garbage collection result"
+//	@Failure	401	{object}	nil					"Missing user in session | Unauthorized"
+//	@Failure	500	{object}	nil					"Failed to find user | Failed to collect orphaned resources"
+//	@Router		/api/v1/resource/gc [POST]
+func (s *APIV1Service) GarbageCollectResources(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{
+		ID: &userID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil || user.Role != store.RoleHost {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	result, err := CollectOrphanedResources(ctx, s.Store, ResourceGCGracePeriod)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to collect orphaned resources").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// CollectOrphanedResources deletes the resources which are not related to any memo,
+// and the files in the local storage directory which are not referenced by any resource.
+// Only resources and files older than the grace period are deleted.
+func CollectOrphanedResources(ctx context.Context, s *store.Store, gracePeriod time.Duration) (*ResourceGCResult, error) {
+	result := &ResourceGCResult{}
+	deadline := time.Now().Add(-gracePeriod)
+
+	resources, err := s.ListResources(ctx, &store.FindResource{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list resources")
+	}
+	referencedResourceIDs, err := listReferencedResourceIDs(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	internalPaths := map[string]bool{}
+	for _, resource := range resources {
+		if resource.MemoID != nil || referencedResourceIDs[resource.ID] || time.Unix(resource.UpdatedTs, 0).After(deadline) {
+			if resource.InternalPath != "" {
+				internalPaths[resolveLocalPath(s.Profile.Data, resource.InternalPath)] = true
+			}
+			continue
+		}
+
+		if err := DeleteResourceBlob(ctx, s, resource); err != nil {
+			slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+		}
+		if err := s.DeleteResource(ctx, &store.DeleteResource{ID: resource.ID}); err != nil {
+			return nil, errors.Wrapf(err, "Failed to delete resource %d", resource.ID)
+		}
+		result.DeletedResourceCount++
+	}
+
+	localStorageDir, localStoragePattern, err := getLocalStorageFiles(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if localStorageDir == "" {
+		return result, nil
+	}
+	err = filepath.WalkDir(localStorageDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		// Only the files saved with the path template are collected, the other files in the directory are kept.
+		if entry.IsDir() || internalPaths[filePath] || !localStoragePattern.MatchString(filepath.ToSlash(filePath)) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(deadline) {
+			return nil
+		}
+		if err := os.Remove(filePath); err != nil {
+			return err
+		}
+		result.DeletedFileCount++
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to collect orphaned local files")
+	}
+	return result, nil
+}
+
+// listReferencedResourceIDs returns the ids of the resources which are in use without being related to a memo,
+// e.g. the logo of the workspace branding and the attachments of the synced Obsidian vaults.
+func listReferencedResourceIDs(ctx context.Context, s *store.Store) (map[int32]bool, error) {
+	referencedResourceIDs := map[int32]bool{}
+	vaultFiles, err := s.ListVaultFiles(ctx, &store.FindVaultFile{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list vault files")
+	}
+	for _, vaultFile := range vaultFiles {
+		if vaultFile.ResourceID != 0 && !vaultFile.Deleted {
+			referencedResourceIDs[vaultFile.ResourceID] = true
+		}
+	}
+	brandingSetting, err := s.GetWorkspaceBrandingSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get workspace branding setting")
+	}
+	if brandingSetting.LogoResourceId != 0 {
+		referencedResourceIDs[brandingSetting.LogoResourceId] = true
+	}
+	return referencedResourceIDs, nil
+}
+
+// localStoragePlaceholderPatterns are the patterns of the values of the local storage path template placeholders.
+var localStoragePlaceholderPatterns = map[string]string{
+	"{filename}":  `[^/]+`,
+	"{timestamp}": `\d+`,
+	"{year}":      `\d{4}`,
+	"{month}":     `\d{2}`,
+	"{day}":       `\d{2}`,
+	"{hour}":      `\d{2}`,
+	"{minute}":    `\d{2}`,
+	"{second}":    `\d{2}`,
+	"{uuid}":      `[0-9a-f-]{36}`,
+}
+
+// getLocalStorageFiles returns the static directory part of the local storage path template, and the pattern of
+// the paths of the files saved with the template. An empty directory is returned if the files can't be told apart
+// safely, e.g. the template starts with a placeholder, so it would be the data directory or the file system root,
+// or the directory is out of the data directory, so it may be shared with other files of the host.
+func getLocalStorageFiles(ctx context.Context, s *store.Store) (string, *regexp.Regexp, error) {
+	systemSettingLocalStoragePath, err := s.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{Name: SystemSettingLocalStoragePathName.String()})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Failed to find SystemSettingLocalStoragePathName")
+	}
+	localStoragePath := "assets/{timestamp}_{filename}"
+	if systemSettingLocalStoragePath != nil && systemSettingLocalStoragePath.Value != "" {
+		if err := json.Unmarshal([]byte(systemSettingLocalStoragePath.Value), &localStoragePath); err != nil {
+			return "", nil, errors.Wrap(err, "Failed to unmarshal SystemSettingLocalStoragePathName")
+		}
+	}
+
+	if !strings.Contains(localStoragePath, "{filename}") {
+		localStoragePath = path.Join(localStoragePath, "{filename}")
+	}
+	staticPath, _, _ := strings.Cut(filepath.ToSlash(localStoragePath), "{")
+	// The part after the last slash is a part of the file name, e.g. `assets/` in `assets/{timestamp}_{filename}`.
+	staticPath = path.Dir(staticPath + "_")
+	if staticPath == "" || staticPath == "." {
+		return "", nil, nil
+	}
+
+	dataDir := filepath.Clean(s.Profile.Data)
+	dir := resolveLocalPath(dataDir, staticPath)
+	relativeDir, err := filepath.Rel(dataDir, dir)
+	if err != nil || relativeDir == "." || relativeDir == ".." || strings.HasPrefix(relativeDir, ".."+string(filepath.Separator)) {
+		return "", nil, nil
+	}
+
+	template := filepath.ToSlash(resolveLocalPath(dataDir, localStoragePath))
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	last := 0
+	for _, match := range fileKeyPattern.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:match[0]]))
+		placeholder := template[match[0]:match[1]]
+		if placeholderPattern, ok := localStoragePlaceholderPatterns[placeholder]; ok {
+			pattern.WriteString(placeholderPattern)
+		} else {
+			pattern.WriteString(regexp.QuoteMeta(placeholder))
+		}
+		last = match[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	localStoragePattern, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Failed to compile the local storage path pattern")
+	}
+	return dir, localStoragePattern, nil
+}
+
+func resolveLocalPath(dataDir, internalPath string) string {
+	osPath := filepath.FromSlash(internalPath)
+	if !filepath.IsAbs(osPath) {
+		osPath = filepath.Join(dataDir, osPath)
+	}
+	return filepath.Clean(osPath)
+}
@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestGetLocalStorageFiles(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		template string
+		dir      string
+		matches  []string
+		others   []string
+	}{
+		{
+			template: "assets/{timestamp}_{filename}",
+			dir:      "assets",
+			matches:  []string{"assets/1715227200_image.png"},
+			others:   []string{"assets/image.png", "assets/backup/1715227200_image.png", ".thumbnail_cache/1.png"},
+		},
+		{
+			template: "assets/{year}/{month}/{filename}",
+			dir:      "assets",
+			matches:  []string{"assets/2024/05/image.png"},
+			others:   []string{"assets/2024/image.png", "assets/notes/05/image.png"},
+		},
+		// The directories which may hold other files aren't collected.
+		{template: "{filename}"},
+		{template: "{timestamp}/{filename}"},
+		{template: "../shared/{filename}"},
+		{template: "/var/shared/{filename}"},
+	}
+	for _, test := range tests {
+		// The workspace settings are cached by the store, so each template has its own store.
+		ts := teststore.NewTestingStore(ctx, t)
+		dataDir := filepath.Clean(ts.Profile.Data)
+		value, err := json.Marshal(test.template)
+		require.NoError(t, err)
+		_, err = ts.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+			Name:  SystemSettingLocalStoragePathName.String(),
+			Value: string(value),
+		})
+		require.NoError(t, err)
+		dir, pattern, err := getLocalStorageFiles(ctx, ts)
+		require.NoError(t, err)
+		if test.dir == "" {
+			require.Empty(t, dir, test.template)
+			ts.Close()
+			continue
+		}
+		require.Equal(t, filepath.Join(dataDir, test.dir), dir, test.template)
+		for _, match := range test.matches {
+			require.True(t, pattern.MatchString(filepath.ToSlash(filepath.Join(dataDir, match))), match)
+		}
+		for _, other := range test.others {
+			require.False(t, pattern.MatchString(filepath.ToSlash(filepath.Join(dataDir, other))), other)
+		}
+		ts.Close()
+	}
+}
+
+func TestCollectOrphanedResources(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "memo", Visibility: store.Private})
+	require.NoError(t, err)
+	past := time.Now().Add(-2 * ResourceGCGracePeriod)
+
+	createResource := func(uid string, memoID *int32, updatedTs int64) *store.Resource {
+		resource, err := ts.CreateResource(ctx, &store.Resource{UID: uid, CreatorID: user.ID, Filename: uid, MemoID: memoID})
+		require.NoError(t, err)
+		resource, err = ts.UpdateResource(ctx, &store.UpdateResource{ID: resource.ID, UpdatedTs: &updatedTs})
+		require.NoError(t, err)
+		return resource
+	}
+	createFile := func(name string, modTime time.Time) string {
+		filePath := filepath.Join(ts.Profile.Data, "assets", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), os.ModePerm))
+		require.NoError(t, os.WriteFile(filePath, []byte(name), 0644))
+		require.NoError(t, os.Chtimes(filePath, modTime, modTime))
+		return filePath
+	}
+
+	attached := createResource("attached", &memo.ID, past.Unix())
+	orphaned := createResource("orphaned", nil, past.Unix())
+	fresh := createResource("fresh", nil, time.Now().Unix())
+	// The logo of the workspace branding isn't related to a memo.
+	logo := createResource("logo", nil, past.Unix())
+	_, err = ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_BRANDING,
+		Value: &storepb.WorkspaceSetting_Branding{
+			Branding: &storepb.WorkspaceBrandingSetting{LogoResourceId: logo.ID},
+		},
+	})
+	require.NoError(t, err)
+	// The attachments of the synced vault aren't related to memos either, unless they've been deleted from the vault.
+	attachment := createResource("attachment", nil, past.Unix())
+	_, err = ts.CreateVaultFile(ctx, &store.VaultFile{CreatorID: user.ID, Path: "attachments/image.png", ResourceID: attachment.ID})
+	require.NoError(t, err)
+	deletedAttachment := createResource("deleted-attachment", nil, past.Unix())
+	_, err = ts.CreateVaultFile(ctx, &store.VaultFile{CreatorID: user.ID, Path: "attachments/deleted.png", ResourceID: deletedAttachment.ID, Deleted: true})
+	require.NoError(t, err)
+	referencedFile := createFile("1715227200_referenced.txt", past)
+	internalPath := "assets/1715227200_referenced.txt"
+	_, err = ts.UpdateResource(ctx, &store.UpdateResource{ID: attached.ID, InternalPath: &internalPath})
+	require.NoError(t, err)
+	orphanedFile := createFile("1715227200_orphaned.txt", past)
+	freshFile := createFile("1715227200_fresh.txt", time.Now())
+	otherFile := createFile("notes.txt", past)
+
+	result, err := CollectOrphanedResources(ctx, ts, ResourceGCGracePeriod)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.DeletedResourceCount)
+	require.Equal(t, 1, result.DeletedFileCount)
+	for _, test := range []struct {
+		resource *store.Resource
+		exists   bool
+	}{
+		{resource: attached, exists: true},
+		{resource: orphaned, exists: false},
+		{resource: fresh, exists: true},
+		{resource: logo, exists: true},
+		{resource: attachment, exists: true},
+		{resource: deletedAttachment, exists: false},
+	} {
+		resource, err := ts.GetResource(ctx, &store.FindResource{ID: &test.resource.ID})
+		require.NoError(t, err)
+		require.Equal(t, test.exists, resource != nil, test.resource.UID)
+	}
+	for _, test := range []struct {
+		filePath string
+		exists   bool
+	}{
+		{filePath: referencedFile, exists: true},
+		{filePath: orphanedFile, exists: false},
+		{filePath: freshFile, exists: true},
+		// The files which aren't saved with the path template are kept.
+		{filePath: otherFile, exists: true},
+	} {
+		_, err := os.Stat(test.filePath)
+		require.Equal(t, test.exists, err == nil, test.filePath)
+	}
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// UploadLimit is the upload limit applied to a user.
+type UploadLimit struct {
+	MaxSizeBytes int64
+	// AllowedTypes are the allowed MIME types, e.g. `image/png` or `image/*`.
+	// Empty means all types are allowed.
+	AllowedTypes []string
+}
+
+// IsTypeAllowed returns true if files of the MIME type can be uploaded.
+func (l *UploadLimit) IsTypeAllowed(mimeType string) bool {
+	if len(l.AllowedTypes) == 0 {
+		return true
+	}
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	for _, allowedType := range l.AllowedTypes {
+		allowedType = strings.ToLower(strings.TrimSpace(allowedType))
+		if allowedType == "*" || allowedType == "*/*" || allowedType == mimeType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowedType, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUploadLimit returns the upload limit of the user.
+// The upload restriction for the user takes precedence over the restriction for the user's role,
+// and the max upload size system setting is used if the restriction doesn't set one.
+func GetUploadLimit(ctx context.Context, s *store.Store, user *store.User) (*UploadLimit, error) {
+	maxUploadSizeBytes, err := getMaxUploadSizeBytes(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get max upload size")
+	}
+	uploadLimit := &UploadLimit{
+		MaxSizeBytes: int64(maxUploadSizeBytes),
+	}
+
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get workspace storage setting")
+	}
+	restriction := findUploadRestriction(workspaceStorageSetting.UploadRestrictions, user)
+	if restriction == nil {
+		return uploadLimit, nil
+	}
+	if restriction.MaxUploadSizeMib > 0 {
+		uploadLimit.MaxSizeBytes = int64(restriction.MaxUploadSizeMib) * MebiByte
+	}
+	uploadLimit.AllowedTypes = restriction.AllowedMimeTypes
+	return uploadLimit, nil
+}
+
+func findUploadRestriction(restrictions []*storepb.UploadRestriction, user *store.User) *storepb.UploadRestriction {
+	var roleRestriction *storepb.UploadRestriction
+	for _, restriction := range restrictions {
+		if restriction.UserId != 0 {
+			if restriction.UserId == user.ID {
+				return restriction
+			}
+			continue
+		}
+		if roleRestriction == nil && restriction.Role == user.Role.String() {
+			roleRestriction = restriction
+		}
+	}
+	return roleRestriction
+}
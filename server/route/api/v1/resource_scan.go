@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/scanner"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// ScanResourceBlob submits the blob to the upload scanner configured in the workspace storage setting,
+// and rewinds the reader after scanning. A nil result means the blob is not scanned.
+// If the scanner is unavailable, an error is returned unless the fail-open policy is configured.
+func ScanResourceBlob(ctx context.Context, s *store.Store, r io.ReadSeeker) (*scanner.Result, error) {
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get workspace storage setting")
+	}
+	uploadScannerSetting := workspaceStorageSetting.UploadScanner
+	if uploadScannerSetting == nil {
+		return nil, nil
+	}
+
+	var uploadScanner scanner.Scanner
+	switch uploadScannerSetting.Type {
+	case storepb.UploadScannerSetting_CLAMAV:
+		uploadScanner = scanner.NewClamAVScanner(uploadScannerSetting.Address)
+	case storepb.UploadScannerSetting_ICAP:
+		uploadScanner = scanner.NewICAPScanner(uploadScannerSetting.Address)
+	default:
+		return nil, nil
+	}
+
+	result, err := uploadScanner.Scan(ctx, r)
+	if _, seekErr := r.Seek(0, io.SeekStart); seekErr != nil {
+		return nil, errors.Wrap(seekErr, "Failed to rewind blob")
+	}
+	if err != nil {
+		if uploadScannerSetting.FailOpen {
+			slog.Warn("Failed to scan resource blob, accepting it as the fail-open policy is configured", slog.Any("err", err))
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Failed to scan resource blob")
+	}
+	return result, nil
+}
+
+// isUploadScannerEnabled returns true if uploaded files must be submitted to a scanner.
+func isUploadScannerEnabled(ctx context.Context, s *store.Store) (bool, error) {
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to get workspace storage setting")
+	}
+	uploadScannerSetting := workspaceStorageSetting.UploadScanner
+	return uploadScannerSetting != nil && uploadScannerSetting.Type != storepb.UploadScannerSetting_TYPE_UNSPECIFIED, nil
+}
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestIsValidFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     bool
+	}{
+		{filename: "image.png", want: true},
+		{filename: "..hidden", want: true},
+		{filename: "", want: false},
+		{filename: ".", want: false},
+		{filename: "..", want: false},
+		{filename: "../../x", want: false},
+		{filename: "dir/image.png", want: false},
+		{filename: `..\..\x`, want: false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, isValidFilename(test.filename), test.filename)
+	}
+}
+
+func TestGetTemplateFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{filename: "image.png", want: "image.png"},
+		{filename: "../../x", want: "x"},
+		{filename: "/etc/passwd", want: "passwd"},
+		{filename: `..\..\x`, want: "x"},
+		{filename: "..", want: "file"},
+		{filename: "", want: "file"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, getTemplateFilename(test.filename), test.filename)
+	}
+}
+
+// TestSaveResourceBlobLocalPath tests the files are saved in the directory of the local storage path template
+// whatever their filenames are.
+func TestSaveResourceBlobLocalPath(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	_, err := ts.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+		Name:  SystemSettingStorageServiceIDName.String(),
+		Value: "-1",
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+		Name:  SystemSettingLocalStoragePathName.String(),
+		Value: `"assets/{filename}"`,
+	})
+	require.NoError(t, err)
+
+	for _, filename := range []string{"../../escape.txt", "/tmp/escape.txt", ".."} {
+		create := &store.Resource{Filename: filename}
+		require.NoError(t, SaveResourceBlob(ctx, ts, create, strings.NewReader("content")))
+		require.Equal(t, "assets", filepath.Dir(filepath.FromSlash(create.InternalPath)), filename)
+		_, err := os.Stat(filepath.Join(ts.Profile.Data, filepath.FromSlash(create.InternalPath)))
+		require.NoError(t, err)
+	}
+}
+
+// TestPreSignResourceUpload tests the keys of the pre-signed uploads are generated by the server and tied to their resources.
+func TestPreSignResourceUpload(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	storage, err := ts.CreateStorage(ctx, &store.Storage{
+		Name:   "s3",
+		Type:   StorageS3.String(),
+		Config: `{"endPoint":"https://s3.example.com","path":"assets/{filename}","region":"us-east-1","accessKey":"key","secretKey":"secret","bucket":"memos"}`,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+		Name:  SystemSettingStorageServiceIDName.String(),
+		Value: fmt.Sprintf("%d", storage.ID),
+	})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts, eventBroker: event.NewBroker()}
+	e := echo.New()
+	preSign := func(body string) (*httptest.ResponseRecorder, error) {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/resource/presign", strings.NewReader(body))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(request, recorder)
+		c.Set(userIDContextKey, user.ID)
+		return recorder, s.PreSignResourceUpload(c)
+	}
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{body: `{"filename":"../other/image.png","type":"image/png","size":10}`, status: http.StatusBadRequest},
+		{body: `{"filename":"other/image.png","type":"image/png","size":10}`, status: http.StatusBadRequest},
+		{body: `{"filename":"..","type":"image/png","size":10}`, status: http.StatusBadRequest},
+		{body: `{"filename":"image.png","type":"image/png","size":0}`, status: http.StatusBadRequest},
+		{body: `{"filename":"image.png","type":"image/png","size":-1}`, status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		_, err := preSign(test.body)
+		httpError := &echo.HTTPError{}
+		require.ErrorAs(t, err, &httpError, test.body)
+		require.Equal(t, test.status, httpError.Code, test.body)
+	}
+
+	recorder, err := preSign(`{"filename":"image.png","type":"image/png","size":10}`)
+	require.NoError(t, err)
+	response := &PreSignResourceUploadResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	require.NotNil(t, response.Resource)
+	resource, err := ts.GetResource(ctx, &store.FindResource{ID: &response.Resource.ID})
+	require.NoError(t, err)
+	key := fmt.Sprintf("assets/%s_image.png", resource.UID)
+	require.Equal(t, s3.FormatReference(storage.ID, key), resource.InternalPath)
+	require.Equal(t, response.ExternalLink, resource.ExternalLink)
+	require.Contains(t, response.UploadURL, "/"+key+"?")
+	require.Equal(t, int64(10), resource.Size)
+}
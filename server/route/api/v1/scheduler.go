@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/server/service/scheduler"
+	"github.com/usememos/memos/store"
+)
+
+type ScheduledTask struct {
+	Name string `json:"name"`
+	// Cron is the schedule of the task, the scheduler setting of the workspace overrides the default one.
+	Cron        string `json:"cron"`
+	DefaultCron string `json:"defaultCron"`
+	Disabled    bool   `json:"disabled"`
+	// NextRunTs is the time the task runs next, 0 if it's disabled.
+	NextRunTs int64 `json:"nextRunTs"`
+	Running   bool  `json:"running"`
+	// LastRun is the last run of the task, null if it has never run.
+	LastRun *TaskRun `json:"lastRun"`
+}
+
+type TaskRun struct {
+	Status     store.TaskRunStatus `json:"status"`
+	StartedTs  int64               `json:"startedTs"`
+	FinishedTs int64               `json:"finishedTs"`
+	Error      string              `json:"error"`
+}
+
+func (s *APIV1Service) registerSchedulerRoutes(g *echo.Group) {
+	g.GET("/scheduler/task", s.ListScheduledTasks)
+	g.POST("/scheduler/task/:name/run", s.RunScheduledTask)
+}
+
+// ListScheduledTasks godoc
+//
+//	@Summary		List the periodic tasks of the workspace
+//	@Description	The schedules of the tasks are set in the scheduler setting of the workspace, they're evaluated in its timezone.
+//	@Tags			scheduler
+//	@Produce		json
+//	@Success		200	{object}	[]ScheduledTask	"Scheduled task list"
+//	@Failure		401	{object}	nil				"Missing user in session | Unauthorized"
+//	@Failure		500	{object}	nil				"Failed to find user | Failed to list scheduled tasks"
+//	@Router			/api/v1/scheduler/task [GET]
+func (s *APIV1Service) ListScheduledTasks(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+
+	statuses, err := s.taskScheduler.ListTasks(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list scheduled tasks").SetInternal(err)
+	}
+	tasks := []*ScheduledTask{}
+	for _, status := range statuses {
+		tasks = append(tasks, convertScheduledTaskFromStatus(status))
+	}
+	return c.JSON(http.StatusOK, tasks)
+}
+
+// RunScheduledTask godoc
+//
+//	@Summary		Run a periodic task now
+//	@Description	The task runs in the background regardless of its schedule, even if it's disabled. Its run is listed with the tasks.
+//	@Tags			scheduler
+//	@Produce		json
+//	@Param			name	path		string	true	"Name of the task"
+//	@Success		202		{object}	nil		"Task is started"
+//	@Failure		401		{object}	nil		"Missing user in session | Unauthorized"
+//	@Failure		404		{object}	nil		"Scheduled task not found: %s"
+//	@Failure		409		{object}	nil		"Scheduled task is running"
+//	@Failure		500		{object}	nil		"Failed to find user | Failed to run scheduled task"
+//	@Router			/api/v1/scheduler/task/{name}/run [POST]
+func (s *APIV1Service) RunScheduledTask(c echo.Context) error {
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+
+	name := c.Param("name")
+	if err := s.taskScheduler.RunTask(name); err != nil {
+		if errors.Is(err, scheduler.ErrTaskNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Scheduled task not found: %s", name))
+		}
+		if errors.Is(err, scheduler.ErrTaskRunning) {
+			return echo.NewHTTPError(http.StatusConflict, "Scheduled task is running")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to run scheduled task").SetInternal(err)
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+func convertScheduledTaskFromStatus(status *scheduler.TaskStatus) *ScheduledTask {
+	task := &ScheduledTask{
+		Name:        status.Task.Name,
+		Cron:        status.Cron,
+		DefaultCron: status.Task.DefaultCron,
+		Disabled:    status.Disabled,
+		NextRunTs:   status.NextRunTs,
+		Running:     status.Running,
+	}
+	if status.LastRun != nil {
+		task.LastRun = &TaskRun{
+			Status:     status.LastRun.Status,
+			StartedTs:  status.LastRun.StartedTs,
+			FinishedTs: status.LastRun.FinishedTs,
+			Error:      status.LastRun.Error,
+		}
+	}
+	return task
+}
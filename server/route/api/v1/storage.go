@@ -1,13 +1,17 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/storage/sftp"
 	"github.com/usememos/memos/store"
 )
 
@@ -23,7 +27,8 @@ const (
 type StorageType string
 
 const (
-	StorageS3 StorageType = "S3"
+	StorageS3   StorageType = "S3"
+	StorageSFTP StorageType = "SFTP"
 )
 
 func (t StorageType) String() string {
@@ -31,7 +36,8 @@ func (t StorageType) String() string {
 }
 
 type StorageConfig struct {
-	S3Config *StorageS3Config `json:"s3Config"`
+	S3Config   *StorageS3Config   `json:"s3Config"`
+	SFTPConfig *StorageSFTPConfig `json:"sftpConfig"`
 }
 
 type StorageS3Config struct {
@@ -46,6 +52,16 @@ type StorageS3Config struct {
 	PreSign   bool   `json:"presign"`
 }
 
+type StorageSFTPConfig struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Username   string `json:"username"`
+	PrivateKey string `json:"privateKey"`
+	Passphrase string `json:"passphrase"`
+	HostKey    string `json:"hostKey"`
+	Path       string `json:"path"`
+}
+
 type Storage struct {
 	ID     int32          `json:"id"`
 	Name   string         `json:"name"`
@@ -156,6 +172,15 @@ func (s *APIV1Service) CreateStorage(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, "Malformatted post storage request").SetInternal(err)
 		}
 		configString = string(configBytes)
+	} else if create.Type == StorageSFTP && create.Config.SFTPConfig != nil {
+		if err := convertSFTPConfig(create.Config.SFTPConfig).Validate(); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid sftp config: %s", err.Error())).SetInternal(err)
+		}
+		configBytes, err := json.Marshal(create.Config.SFTPConfig)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformatted post storage request").SetInternal(err)
+		}
+		configString = string(configBytes)
 	}
 
 	storage, err := s.Store.CreateStorage(ctx, &store.Storage{
@@ -282,6 +307,19 @@ func (s *APIV1Service) UpdateStorage(c echo.Context) error {
 			}
 			configString := string(configBytes)
 			storageUpdate.Config = &configString
+		} else if update.Type == StorageSFTP {
+			if update.Config.SFTPConfig == nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid sftp config: missing config")
+			}
+			if err := convertSFTPConfig(update.Config.SFTPConfig).Validate(); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid sftp config: %s", err.Error())).SetInternal(err)
+			}
+			configBytes, err := json.Marshal(update.Config.SFTPConfig)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformatted post storage request").SetInternal(err)
+			}
+			configString := string(configBytes)
+			storageUpdate.Config = &configString
 		}
 	}
 
@@ -311,6 +349,54 @@ func ConvertStorageFromStore(storage *store.Storage) (*Storage, error) {
 		storageMessage.Config = &StorageConfig{
 			S3Config: s3Config,
 		}
+	} else if storageMessage.Type == StorageSFTP {
+		sftpConfig := &StorageSFTPConfig{}
+		if err := json.Unmarshal([]byte(storage.Config), sftpConfig); err != nil {
+			return nil, err
+		}
+		storageMessage.Config = &StorageConfig{
+			SFTPConfig: sftpConfig,
+		}
 	}
 	return storageMessage, nil
 }
+
+// CheckStorage checks the storage service the resources are uploaded to is reachable, e.g. for the readiness probe.
+// The database and the local storage are always reachable once the database is.
+func CheckStorage(ctx context.Context, s *store.Store) error {
+	storageServiceID, err := getStorageServiceID(ctx, s)
+	if err != nil {
+		return err
+	}
+	if storageServiceID == DatabaseStorage || storageServiceID == LocalStorage {
+		return nil
+	}
+	storage, err := s.GetStorage(ctx, &store.FindStorage{ID: &storageServiceID})
+	if err != nil {
+		return errors.Wrap(err, "failed to find storage")
+	}
+	if storage == nil {
+		return errors.Errorf("storage %d not found", storageServiceID)
+	}
+	storageMessage, err := ConvertStorageFromStore(storage)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert storage")
+	}
+	switch storageMessage.Type {
+	case StorageS3:
+		s3Client, err := s3.NewClient(ctx, convertS3Config(storageMessage.Config.S3Config))
+		if err != nil {
+			return errors.Wrap(err, "failed to create s3 client")
+		}
+		return s3Client.CheckBucket(ctx)
+	case StorageSFTP:
+		// The client connects to the server once it's created.
+		sftpClient, err := sftp.NewClient(convertSFTPConfig(storageMessage.Config.SFTPConfig))
+		if err != nil {
+			return err
+		}
+		return sftpClient.Close()
+	default:
+		return errors.Errorf("unsupported storage type: %s", storageMessage.Type)
+	}
+}
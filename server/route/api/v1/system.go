@@ -2,10 +2,16 @@ package v1
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/usememos/memos/internal/markdown"
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
 )
@@ -32,10 +38,75 @@ type SystemStatus struct {
 	MemoDisplayWithUpdatedTs bool `json:"memoDisplayWithUpdatedTs"`
 }
 
+// serverStartTime is the time the server process started, approximately.
+var serverStartTime = time.Now()
+
+// RuntimeStatus is the resource usage of the server process.
+type RuntimeStatus struct {
+	StartTs       int64  `json:"startTs"`
+	UptimeSeconds int64  `json:"uptimeSeconds"`
+	GoVersion     string `json:"goVersion"`
+	Goroutines    int    `json:"goroutines"`
+	// MaxProcs is the max number of the CPUs executing the goroutines at once.
+	MaxProcs int                 `json:"maxProcs"`
+	NumCPU   int                 `json:"numCpu"`
+	Memory   RuntimeMemoryStatus `json:"memory"`
+	DB       DBPoolStatus        `json:"db"`
+	Caches   []*CacheStatus      `json:"caches"`
+}
+
+// RuntimeMemoryStatus is the memory usage of the Go runtime in bytes.
+type RuntimeMemoryStatus struct {
+	HeapAlloc int64 `json:"heapAlloc"`
+	HeapInuse int64 `json:"heapInuse"`
+	// Sys is the memory obtained from the OS.
+	Sys int64 `json:"sys"`
+	// Limit is the soft limit of the memory, 0 if it's unlimited.
+	Limit        int64  `json:"limit"`
+	NumGC        uint32 `json:"numGc"`
+	GCPauseTotal int64  `json:"gcPauseTotalNs"`
+}
+
+// DBPoolStatus is the stats of the connection pool of the database.
+type DBPoolStatus struct {
+	// MaxOpenConnections is the max number of the open connections, 0 if it's unlimited.
+	MaxOpenConnections int   `json:"maxOpenConnections"`
+	OpenConnections    int   `json:"openConnections"`
+	InUse              int   `json:"inUse"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"waitCount"`
+	WaitDurationMs     int64 `json:"waitDurationMs"`
+}
+
+// CacheStatus is the hits and the misses of a cache of the server.
+type CacheStatus struct {
+	Name   string `json:"name"`
+	Hits   int64  `json:"hits"`
+	Misses int64  `json:"misses"`
+	// HitRate is the ratio of the hits to the lookups, from 0 to 1.
+	HitRate float64 `json:"hitRate"`
+	// Entries and Size are the number and the size in bytes of the cached entries, if the cache is bounded by its size.
+	Entries int `json:"entries,omitempty"`
+	Size    int `json:"size,omitempty"`
+}
+
+func newCacheStatus(name string, hits, misses int64) *CacheStatus {
+	cacheStatus := &CacheStatus{
+		Name:   name,
+		Hits:   hits,
+		Misses: misses,
+	}
+	if lookups := hits + misses; lookups > 0 {
+		cacheStatus.HitRate = float64(hits) / float64(lookups)
+	}
+	return cacheStatus
+}
+
 func (s *APIV1Service) registerSystemRoutes(g *echo.Group) {
 	g.GET("/ping", s.PingSystem)
 	g.GET("/status", s.GetSystemStatus)
 	g.POST("/system/vacuum", s.ExecVacuum)
+	g.GET("/system/runtime", s.GetRuntimeStatus)
 }
 
 // PingSystem godoc
@@ -98,7 +169,7 @@ func (s *APIV1Service) GetSystemStatus(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find system setting list").SetInternal(err)
 	}
 	for _, systemSetting := range systemSettingList {
-		if systemSetting.Name == SystemSettingServerIDName.String() || systemSetting.Name == SystemSettingSecretSessionName.String() || systemSetting.Name == SystemSettingTelegramBotTokenName.String() {
+		if systemSetting.Name == SystemSettingServerIDName.String() || systemSetting.Name == SystemSettingSecretSessionName.String() || systemSetting.Name == SystemSettingTelegramBotTokenName.String() || systemSetting.Name == SystemSettingWebPushVAPIDKeyName.String() {
 			continue
 		}
 
@@ -165,3 +236,68 @@ func (s *APIV1Service) ExecVacuum(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, true)
 }
+
+// GetRuntimeStatus godoc
+//
+//	@Summary		Get the resource usage of the server
+//	@Description	The memory usage, the goroutines, the database connection pool, the cache hit rates and the uptime of the server process,
+//	@Description	which are bounded by the --memory-limit and --max-procs flags.
+//	@Tags			system
+//	@Produce		json
+//	@Success		200	{object}	RuntimeStatus	"Runtime status"
+//	@Failure		401	{object}	nil				"Missing user in session | Unauthorized"
+//	@Failure		500	{object}	nil				"Failed to find user"
+//	@Router			/api/v1/system/runtime [GET]
+func (s *APIV1Service) GetRuntimeStatus(c echo.Context) error {
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+
+	memStats := runtime.MemStats{}
+	runtime.ReadMemStats(&memStats)
+	memoryLimit := debug.SetMemoryLimit(-1)
+	if memoryLimit == math.MaxInt64 {
+		memoryLimit = 0
+	}
+	dbStats := s.Store.GetDBStats()
+	runtimeStatus := RuntimeStatus{
+		StartTs:       serverStartTime.Unix(),
+		UptimeSeconds: int64(time.Since(serverStartTime).Seconds()),
+		GoVersion:     runtime.Version(),
+		Goroutines:    runtime.NumGoroutine(),
+		MaxProcs:      runtime.GOMAXPROCS(0),
+		NumCPU:        runtime.NumCPU(),
+		Memory: RuntimeMemoryStatus{
+			HeapAlloc:    int64(memStats.HeapAlloc),
+			HeapInuse:    int64(memStats.HeapInuse),
+			Sys:          int64(memStats.Sys),
+			Limit:        memoryLimit,
+			NumGC:        memStats.NumGC,
+			GCPauseTotal: int64(memStats.PauseTotalNs),
+		},
+		DB: DBPoolStatus{
+			MaxOpenConnections: dbStats.MaxOpenConnections,
+			OpenConnections:    dbStats.OpenConnections,
+			InUse:              dbStats.InUse,
+			Idle:               dbStats.Idle,
+			WaitCount:          dbStats.WaitCount,
+			WaitDurationMs:     dbStats.WaitDuration.Milliseconds(),
+		},
+		Caches: []*CacheStatus{},
+	}
+
+	markdownStats := markdown.CacheStats()
+	markdownStatus := newCacheStatus("markdown", markdownStats.Hits, markdownStats.Misses)
+	markdownStatus.Entries, markdownStatus.Size = markdownStats.Entries, markdownStats.Size
+	runtimeStatus.Caches = append(runtimeStatus.Caches, markdownStatus)
+	storeCacheStats := s.Store.GetCacheStats()
+	names := []string{}
+	for name := range storeCacheStats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		runtimeStatus.Caches = append(runtimeStatus.Caches, newCacheStatus(name, storeCacheStats[name].Hits, storeCacheStats[name].Misses))
+	}
+	return c.JSON(http.StatusOK, runtimeStatus)
+}
@@ -33,6 +33,8 @@ const (
 	SystemSettingTelegramBotTokenName SystemSettingName = "telegram-bot-token"
 	// SystemSettingMemoDisplayWithUpdatedTsName is the name of memo display with updated ts.
 	SystemSettingMemoDisplayWithUpdatedTsName SystemSettingName = "memo-display-with-updated-ts"
+	// SystemSettingWebPushVAPIDKeyName is the name of the private key of Web Push, which is generated by the server.
+	SystemSettingWebPushVAPIDKeyName SystemSettingName = "web-push-vapid-key"
 )
 const systemSettingUnmarshalError = `failed to unmarshal value from system setting "%v"`
 
@@ -160,7 +162,7 @@ func (s *APIV1Service) CreateSystemSetting(c echo.Context) error {
 
 func (upsert UpsertSystemSettingRequest) Validate() error {
 	switch settingName := upsert.Name; settingName {
-	case SystemSettingServerIDName:
+	case SystemSettingServerIDName, SystemSettingWebPushVAPIDKeyName:
 		return errors.Errorf("updating %v is not allowed", settingName)
 	case SystemSettingDisablePublicMemosName:
 		var value bool
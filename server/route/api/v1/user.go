@@ -11,7 +11,9 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/server/route/api/etag"
 	"github.com/usememos/memos/store"
 )
 
@@ -180,6 +182,8 @@ func (s *APIV1Service) CreateUser(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create user").SetInternal(err)
 	}
+	s.createUserCreateActivity(ctx, user)
+	s.eventBroker.Publish(event.NewUserEvent(event.UserCreated, user.ID))
 
 	userMessage := convertUserFromStore(user)
 	return c.JSON(http.StatusOK, userMessage)
@@ -191,6 +195,7 @@ func (s *APIV1Service) CreateUser(c echo.Context) error {
 //	@Tags		user
 //	@Produce	json
 //	@Success	200	{object}	store.User	"Current user"
+//	@Success	304	{object}	nil			"User not modified"
 //	@Failure	401	{object}	nil			"Missing auth session"
 //	@Failure	500	{object}	nil			"Failed to find user | Failed to find userSettingList"
 //	@Router		/api/v1/user/me [GET]
@@ -208,6 +213,9 @@ func (s *APIV1Service) GetCurrentUser(c echo.Context) error {
 	if user == nil {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Missing auth session")
 	}
+	if checkNotModified(c, etag.New("user", user.ID, user.UpdatedTs, ""), user.UpdatedTs) {
+		return c.NoContent(http.StatusNotModified)
+	}
 
 	userMessage := convertUserFromStore(user)
 	return c.JSON(http.StatusOK, userMessage)
@@ -220,6 +228,7 @@ func (s *APIV1Service) GetCurrentUser(c echo.Context) error {
 //	@Produce	json
 //	@Param		username	path		string		true	"Username"
 //	@Success	200			{object}	store.User	"Requested user"
+//	@Success	304			{object}	nil			"User not modified"
 //	@Failure	404			{object}	nil			"User not found"
 //	@Failure	500			{object}	nil			"Failed to find user"
 //	@Router		/api/v1/user/name/{username} [GET]
@@ -233,6 +242,9 @@ func (s *APIV1Service) GetUserByUsername(c echo.Context) error {
 	if user == nil {
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
+	if checkNotModified(c, etag.New("user", user.ID, user.UpdatedTs, "public"), user.UpdatedTs) {
+		return c.NoContent(http.StatusNotModified)
+	}
 
 	userMessage := convertUserFromStore(user)
 	// data desensitize
@@ -247,6 +259,7 @@ func (s *APIV1Service) GetUserByUsername(c echo.Context) error {
 //	@Produce	json
 //	@Param		id	path		int			true	"User ID"
 //	@Success	200	{object}	store.User	"Requested user"
+//	@Success	304	{object}	nil			"User not modified"
 //	@Failure	400	{object}	nil			"Malformatted user id"
 //	@Failure	404	{object}	nil			"User not found"
 //	@Failure	500	{object}	nil			"Failed to find user"
@@ -266,9 +279,18 @@ func (s *APIV1Service) GetUserByID(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
 
-	userMessage := convertUserFromStore(user)
 	userID, ok := c.Get(userIDContextKey).(int32)
-	if !ok || userID != user.ID {
+	isSelf := ok && userID == user.ID
+	variant := "public"
+	if isSelf {
+		variant = ""
+	}
+	if checkNotModified(c, etag.New("user", user.ID, user.UpdatedTs, variant), user.UpdatedTs) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	userMessage := convertUserFromStore(user)
+	if !isSelf {
 		// Data desensitize.
 		userMessage.Email = ""
 	}
@@ -319,6 +341,7 @@ func (s *APIV1Service) DeleteUser(c echo.Context) error {
 	}); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete user").SetInternal(err)
 	}
+	s.eventBroker.Publish(event.NewUserEvent(event.UserDeleted, userID))
 	return c.JSON(http.StatusOK, true)
 }
 
@@ -405,6 +428,7 @@ func (s *APIV1Service) UpdateUser(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to patch user").SetInternal(err)
 	}
+	s.eventBroker.Publish(event.NewUserEvent(event.UserUpdated, user.ID))
 
 	userMessage := convertUserFromStore(user)
 	return c.JSON(http.StatusOK, userMessage)
@@ -0,0 +1,446 @@
+package v1
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// UserDeletionGracePeriod is the time between a user requesting the deletion of their account and it being purged,
+// the user can cancel the deletion until then.
+const UserDeletionGracePeriod = 14 * 24 * time.Hour
+
+// userDataExportSettingKeys are the keys of the user settings in the export of the data of a user.
+// The tokens and the credentials of the integrations are left out.
+var userDataExportSettingKeys = []storepb.UserSettingKey{
+	storepb.UserSettingKey_USER_SETTING_LOCALE,
+	storepb.UserSettingKey_USER_SETTING_APPEARANCE,
+	storepb.UserSettingKey_USER_SETTING_MEMO_VISIBILITY,
+	storepb.UserSettingKey_USER_SETTING_TELEGRAM_USER_ID,
+	storepb.UserSettingKey_USER_SETTING_LAST_ACTIVE_TS,
+	storepb.UserSettingKey_USER_SETTING_QUOTA,
+	storepb.UserSettingKey_USER_SETTING_SLACK_USER_ID,
+	storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID,
+	storepb.UserSettingKey_USER_SETTING_NOTIFICATION,
+	storepb.UserSettingKey_USER_SETTING_PROFILE,
+	storepb.UserSettingKey_USER_SETTING_TIMEZONE,
+	storepb.UserSettingKey_USER_SETTING_DELETION_TS,
+}
+
+// UserDataExport is the user.json file of the export of the data of a user.
+type UserDataExport struct {
+	ExportedTs int64 `json:"exportedTs"`
+	User       *User `json:"user"`
+	// Settings are the user settings in the JSON of their protobuf messages.
+	Settings []json.RawMessage `json:"settings"`
+}
+
+type UserDataReaction struct {
+	ID        int32 `json:"id"`
+	CreatedTs int64 `json:"createdTs"`
+	// ContentID is the name of the content reacted to, e.g. memos/101.
+	ContentID    string `json:"contentId"`
+	ReactionType string `json:"reactionType"`
+}
+
+type UserDataActivity struct {
+	ID        int32  `json:"id"`
+	CreatedTs int64  `json:"createdTs"`
+	Type      string `json:"type"`
+	Level     string `json:"level"`
+	// Payload is the JSON of the protobuf message of the payload.
+	Payload json.RawMessage `json:"payload"`
+}
+
+type UserDeletion struct {
+	// DeletionTs is the time the account is purged at, 0 if its deletion isn't requested.
+	DeletionTs int64 `json:"deletionTs"`
+}
+
+func (s *APIV1Service) registerUserDataRoutes(g *echo.Group) {
+	g.GET("/user/me/export", s.ExportUserData)
+	g.GET("/user/me/deletion", s.GetUserDeletion)
+	g.POST("/user/me/deletion", s.RequestUserDeletion)
+	g.DELETE("/user/me/deletion", s.CancelUserDeletion)
+}
+
+// ExportUserData godoc
+//
+//	@Summary		Export all data of the current user as a zip file
+//	@Description	The zip file has user.json with the user and their settings, and the NDJSON files memos.ndjson, resources.ndjson, reactions.ndjson and activities.ndjson.
+//	@Description	The memos include the archived ones and the comments. The blobs of the resources are the files resources/{id}/{filename}, the ones linked to external services aren't included.
+//	@Description	The status is sent before the files are written, so an error after it ends the zip file early.
+//	@Tags			user
+//	@Produce		application/zip
+//	@Success		200	{file}		file	"Zip file of the data"
+//	@Failure		401	{object}	nil		"Missing user in session"
+//	@Failure		404	{object}	nil		"User not found"
+//	@Failure		500	{object}	nil		"Failed to find user"
+//	@Router			/api/v1/user/me/export [GET]
+func (s *APIV1Service) ExportUserData(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getCurrentUser(c)
+	if err != nil {
+		return err
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "application/zip")
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="memos-%s-%s.zip"`, user.Username, time.Now().Format("20060102")))
+	// Disable response buffering of nginx.
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+	writer := zip.NewWriter(response)
+	if err := s.writeUserData(ctx, writer, user); err != nil {
+		slog.Warn("Failed to export user data", slog.Int("user", int(user.ID)), slog.Any("err", err))
+		return nil
+	}
+	_ = writer.Close()
+	return nil
+}
+
+// writeUserData writes the files of the data of the user to the zip file.
+func (s *APIV1Service) writeUserData(ctx context.Context, writer *zip.Writer, user *store.User) error {
+	userDataExport := &UserDataExport{
+		ExportedTs: time.Now().Unix(),
+		User:       convertUserFromStore(user),
+		Settings:   []json.RawMessage{},
+	}
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{UserID: &user.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list user settings")
+	}
+	for _, userSetting := range userSettings {
+		if !slices.Contains(userDataExportSettingKeys, userSetting.Key) {
+			continue
+		}
+		data, err := protojson.Marshal(userSetting)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal user setting")
+		}
+		userDataExport.Settings = append(userDataExport.Settings, data)
+	}
+	w, err := writer.Create("user.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(userDataExport); err != nil {
+		return err
+	}
+
+	limit := memoExportBatchSize
+	w, err = writer.Create("memos.ndjson")
+	if err != nil {
+		return err
+	}
+	encoder = json.NewEncoder(w)
+	for offset := 0; ; offset += limit {
+		memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+			CreatorID: &user.ID,
+			OrderBy:   store.MemoOrderByCreatedTs,
+			OrderAsc:  true,
+			Limit:     &limit,
+			Offset:    &offset,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list memos")
+		}
+		for _, memo := range memos {
+			memoMessage, err := s.convertMemoFromStore(ctx, memo)
+			if err != nil {
+				return errors.Wrap(err, "failed to convert memo")
+			}
+			if err := encoder.Encode(memoMessage); err != nil {
+				return err
+			}
+		}
+		if len(memos) < limit {
+			break
+		}
+	}
+
+	// The blobs are written after the metadata of all resources, since only one file of a zip file is written at a time.
+	resources := []*store.Resource{}
+	w, err = writer.Create("resources.ndjson")
+	if err != nil {
+		return err
+	}
+	encoder = json.NewEncoder(w)
+	for offset := 0; ; offset += limit {
+		list, err := s.Store.ListResources(ctx, &store.FindResource{
+			CreatorID: &user.ID,
+			Limit:     &limit,
+			Offset:    &offset,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list resources")
+		}
+		for _, resource := range list {
+			if err := encoder.Encode(convertResourceFromStore(resource)); err != nil {
+				return err
+			}
+		}
+		resources = append(resources, list...)
+		if len(list) < limit {
+			break
+		}
+	}
+
+	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{CreatorID: &user.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list reactions")
+	}
+	w, err = writer.Create("reactions.ndjson")
+	if err != nil {
+		return err
+	}
+	encoder = json.NewEncoder(w)
+	for _, reaction := range reactions {
+		if err := encoder.Encode(&UserDataReaction{
+			ID:           reaction.Id,
+			CreatedTs:    reaction.CreatedTs,
+			ContentID:    reaction.ContentId,
+			ReactionType: reaction.ReactionType.String(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	activities, err := s.Store.ListActivities(ctx, &store.FindActivity{CreatorID: &user.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list activities")
+	}
+	w, err = writer.Create("activities.ndjson")
+	if err != nil {
+		return err
+	}
+	encoder = json.NewEncoder(w)
+	for _, activity := range activities {
+		payload, err := protojson.Marshal(activity.Payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal activity payload")
+		}
+		if err := encoder.Encode(&UserDataActivity{
+			ID:        activity.ID,
+			CreatedTs: activity.CreatedTs,
+			Type:      string(activity.Type),
+			Level:     string(activity.Level),
+			Payload:   payload,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, resource := range resources {
+		blob, err := GetResourceBlob(ctx, s.Store, resource)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get blob of resource %d", resource.ID)
+		}
+		if blob == nil {
+			continue
+		}
+		w, err := writer.Create(fmt.Sprintf("resources/%d/%s", resource.ID, getUserDataFilename(resource.Filename)))
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(blob); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetUserDeletion godoc
+//
+//	@Summary	Get the deletion of the account of the current user
+//	@Tags		user
+//	@Produce	json
+//	@Success	200	{object}	UserDeletion	"User deletion"
+//	@Failure	401	{object}	nil				"Missing user in session"
+//	@Failure	404	{object}	nil				"User not found"
+//	@Failure	500	{object}	nil				"Failed to find user | Failed to find user setting"
+//	@Router		/api/v1/user/me/deletion [GET]
+func (s *APIV1Service) GetUserDeletion(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getCurrentUser(c)
+	if err != nil {
+		return err
+	}
+
+	deletionTs, err := getUserDeletionTs(ctx, s.Store, user.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user setting").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &UserDeletion{DeletionTs: deletionTs})
+}
+
+// RequestUserDeletion godoc
+//
+//	@Summary		Request the deletion of the account of the current user
+//	@Description	The account is purged after the grace period of 14 days with the memos, resources, reactions, activities, webhooks and settings of the user.
+//	@Description	The user keeps using the account and can cancel the deletion until then. Requesting it again keeps the time of the first request.
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{object}	UserDeletion	"User deletion"
+//	@Failure		400	{object}	nil				"The host can't delete their account"
+//	@Failure		401	{object}	nil				"Missing user in session"
+//	@Failure		404	{object}	nil				"User not found"
+//	@Failure		500	{object}	nil				"Failed to find user | Failed to find user setting | Failed to upsert user setting"
+//	@Router			/api/v1/user/me/deletion [POST]
+func (s *APIV1Service) RequestUserDeletion(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getCurrentUser(c)
+	if err != nil {
+		return err
+	}
+	// The workspace can't be left without its host.
+	if user.Role == store.RoleHost {
+		return echo.NewHTTPError(http.StatusBadRequest, "The host can't delete their account")
+	}
+
+	deletionTs, err := getUserDeletionTs(ctx, s.Store, user.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user setting").SetInternal(err)
+	}
+	if deletionTs == 0 {
+		deletionTs = time.Now().Add(UserDeletionGracePeriod).Unix()
+		if err := upsertUserDeletionTs(ctx, s.Store, user.ID, deletionTs); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to upsert user setting").SetInternal(err)
+		}
+	}
+	return c.JSON(http.StatusOK, &UserDeletion{DeletionTs: deletionTs})
+}
+
+// CancelUserDeletion godoc
+//
+//	@Summary	Cancel the deletion of the account of the current user
+//	@Tags		user
+//	@Produce	json
+//	@Success	200	{object}	UserDeletion	"User deletion"
+//	@Failure	401	{object}	nil				"Missing user in session"
+//	@Failure	404	{object}	nil				"User not found"
+//	@Failure	500	{object}	nil				"Failed to find user | Failed to upsert user setting"
+//	@Router		/api/v1/user/me/deletion [DELETE]
+func (s *APIV1Service) CancelUserDeletion(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getCurrentUser(c)
+	if err != nil {
+		return err
+	}
+
+	if err := upsertUserDeletionTs(ctx, s.Store, user.ID, 0); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to upsert user setting").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &UserDeletion{})
+}
+
+// PurgeDeletedUsers deletes the users whose deletion time has passed, and returns the ids of the deleted users.
+// The resources of the users are deleted with their blobs, the rest of their data is vacuumed with the users.
+// The user groups the users created are kept for their other members.
+func PurgeDeletedUsers(ctx context.Context, s *store.Store) ([]int32, error) {
+	userSettings, err := s.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_DELETION_TS,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user settings")
+	}
+	now := time.Now().Unix()
+	userIDs := []int32{}
+	for _, userSetting := range userSettings {
+		if userSetting.GetDeletionTs() == 0 || userSetting.GetDeletionTs() > now {
+			continue
+		}
+		user, err := s.GetUser(ctx, &store.FindUser{ID: &userSetting.UserId})
+		if err != nil {
+			return userIDs, errors.Wrap(err, "failed to get user")
+		}
+		if user == nil || user.Role == store.RoleHost {
+			continue
+		}
+		if err := purgeUser(ctx, s, user); err != nil {
+			return userIDs, errors.Wrapf(err, "failed to purge user %d", user.ID)
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+	return userIDs, nil
+}
+
+func purgeUser(ctx context.Context, s *store.Store, user *store.User) error {
+	resources, err := s.ListResources(ctx, &store.FindResource{CreatorID: &user.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list resources")
+	}
+	for _, resource := range resources {
+		if err := DeleteResourceBlob(ctx, s, resource); err != nil {
+			slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+		}
+		if err := s.DeleteResource(ctx, &store.DeleteResource{ID: resource.ID}); err != nil {
+			return errors.Wrapf(err, "failed to delete resource %d", resource.ID)
+		}
+	}
+	return s.DeleteUser(ctx, &store.DeleteUser{ID: user.ID})
+}
+
+func getUserDeletionTs(ctx context.Context, s *store.Store, userID int32) (int64, error) {
+	userSetting, err := s.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_DELETION_TS,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return userSetting.GetDeletionTs(), nil
+}
+
+func upsertUserDeletionTs(ctx context.Context, s *store.Store, userID int32, deletionTs int64) error {
+	_, err := s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_DELETION_TS,
+		Value: &storepb.UserSetting_DeletionTs{
+			DeletionTs: deletionTs,
+		},
+	})
+	return err
+}
+
+// getUserDataFilename returns the filename without the directories, so the file can't be extracted out of the resources directory.
+func getUserDataFilename(filename string) string {
+	filename = path.Base(path.Clean("/" + filename))
+	if filename == "/" || filename == "." {
+		return "file"
+	}
+	return filename
+}
+
+// getCurrentUser returns the user of the session.
+func (s *APIV1Service) getCurrentUser(c echo.Context) (*store.User, error) {
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	user, err := s.Store.GetUser(c.Request().Context(), &store.FindUser{
+		ID: &userID,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+	return user, nil
+}
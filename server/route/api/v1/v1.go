@@ -4,13 +4,17 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/graphql-go/graphql"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/plugin/telegram"
 	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/server/route/api/quota"
 	"github.com/usememos/memos/server/route/resource"
 	"github.com/usememos/memos/server/route/rss"
+	"github.com/usememos/memos/server/service/scheduler"
 	"github.com/usememos/memos/store"
 )
 
@@ -19,6 +23,14 @@ type APIV1Service struct {
 	Profile     *profile.Profile
 	Store       *store.Store
 	telegramBot *telegram.Bot
+	eventBroker *event.Broker
+	// quotaLimiter limits the requests per access token, it's nil if the quota is disabled.
+	quotaLimiter *quota.Limiter
+	// taskScheduler runs the periodic tasks of the workspace.
+	taskScheduler *scheduler.Scheduler
+
+	graphQLSchema *graphql.Schema
+	importJobs    importJobList
 }
 
 // @title						memos API
@@ -35,12 +47,15 @@ type APIV1Service struct {
 //
 // @externalDocs.url			https://usememos.com/
 // @externalDocs.description	Find out more about Memos.
-func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, telegramBot *telegram.Bot) *APIV1Service {
+func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, telegramBot *telegram.Bot, eventBroker *event.Broker, quotaLimiter *quota.Limiter, taskScheduler *scheduler.Scheduler) *APIV1Service {
 	return &APIV1Service{
-		Secret:      secret,
-		Profile:     profile,
-		Store:       store,
-		telegramBot: telegramBot,
+		Secret:        secret,
+		Profile:       profile,
+		Store:         store,
+		telegramBot:   telegramBot,
+		eventBroker:   eventBroker,
+		quotaLimiter:  quotaLimiter,
+		taskScheduler: taskScheduler,
 	}
 }
 
@@ -65,17 +80,32 @@ func (s *APIV1Service) Register(rootGroup *echo.Group) {
 	apiV1Group.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return JWTMiddleware(s, next, s.Secret)
 	})
+	// The audit middleware is after the JWT middleware, so the records have the authenticated users.
+	apiV1Group.Use(s.AuditMiddleware)
 	s.registerSystemRoutes(apiV1Group)
 	s.registerSystemSettingRoutes(apiV1Group)
 	s.registerAuthRoutes(apiV1Group)
 	s.registerIdentityProviderRoutes(apiV1Group)
 	s.registerUserRoutes(apiV1Group)
+	s.registerUserDataRoutes(apiV1Group)
 	s.registerTagRoutes(apiV1Group)
 	s.registerStorageRoutes(apiV1Group)
 	s.registerResourceRoutes(apiV1Group)
 	s.registerMemoRoutes(apiV1Group)
 	s.registerMemoOrganizerRoutes(apiV1Group)
 	s.registerMemoRelationRoutes(apiV1Group)
+	s.registerMemoExportRoutes(apiV1Group)
+	s.registerMemoSiteExportRoutes(apiV1Group)
+	s.registerMemoImportRoutes(apiV1Group)
+	s.registerMemoCaptureRoutes(apiV1Group)
+	s.registerMemoAIRoutes(apiV1Group)
+	s.registerGraphQLRoutes(apiV1Group)
+	s.registerEventRoutes(apiV1Group)
+	s.registerWorkspaceArchiveRoutes(apiV1Group)
+	s.registerWebPushRoutes(apiV1Group)
+	s.registerDebugRoutes(apiV1Group)
+	s.registerJobRoutes(apiV1Group)
+	s.registerSchedulerRoutes(apiV1Group)
 
 	// Register public routes.
 	publicGroup := rootGroup.Group("/o")
@@ -83,6 +113,7 @@ func (s *APIV1Service) Register(rootGroup *echo.Group) {
 		return JWTMiddleware(s, next, s.Secret)
 	})
 	s.registerGetterPublicRoutes(publicGroup)
+	s.registerIncomingWebhookPublicRoutes(publicGroup)
 
 	// Create and register resource public routes.
 	resource.NewResourceService(s.Profile, s.Store).RegisterRoutes(publicGroup)
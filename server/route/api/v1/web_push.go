@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/webpush"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// WebPushVAPIDKey is the public key of the server, which is the applicationServerKey of the subscriptions of the browsers.
+type WebPushVAPIDKey struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// WebPushSubscription is a subscription of a browser of the user, its keys aren't returned.
+type WebPushSubscription struct {
+	Endpoint  string `json:"endpoint"`
+	CreatedTs int64  `json:"createdTs"`
+}
+
+type DeleteWebPushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+}
+
+func (s *APIV1Service) registerWebPushRoutes(g *echo.Group) {
+	g.GET("/web-push/vapid-key", s.GetWebPushVAPIDKey)
+	g.GET("/web-push/subscription", s.ListWebPushSubscriptions)
+	g.POST("/web-push/subscription", s.CreateWebPushSubscription)
+	g.DELETE("/web-push/subscription", s.DeleteWebPushSubscription)
+}
+
+// GetWebPushVAPIDKey godoc
+//
+//	@Summary	Get the VAPID public key of the server
+//	@Tags		web-push
+//	@Produce	json
+//	@Success	200	{object}	WebPushVAPIDKey	"VAPID public key"
+//	@Failure	401	{object}	nil				"Missing user in session"
+//	@Failure	500	{object}	nil				"Failed to get VAPID key"
+//	@Router		/api/v1/web-push/vapid-key [GET]
+func (s *APIV1Service) GetWebPushVAPIDKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, ok := c.Get(userIDContextKey).(int32); !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	key, err := GetWebPushVAPIDKey(ctx, s.Store)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get VAPID key").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &WebPushVAPIDKey{PublicKey: key.PublicKey()})
+}
+
+// ListWebPushSubscriptions godoc
+//
+//	@Summary	List the Web Push subscriptions of the current user
+//	@Tags		web-push
+//	@Produce	json
+//	@Success	200	{object}	[]WebPushSubscription	"Subscription list"
+//	@Failure	401	{object}	nil						"Missing user in session"
+//	@Failure	500	{object}	nil						"Failed to list subscriptions"
+//	@Router		/api/v1/web-push/subscription [GET]
+func (s *APIV1Service) ListWebPushSubscriptions(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	subscriptions, err := s.Store.GetUserWebPushSubscriptions(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list subscriptions").SetInternal(err)
+	}
+	list := []*WebPushSubscription{}
+	for _, subscription := range subscriptions {
+		list = append(list, &WebPushSubscription{
+			Endpoint:  subscription.Endpoint,
+			CreatedTs: subscription.CreatedTs,
+		})
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// CreateWebPushSubscription godoc
+//
+//	@Summary		Subscribe a browser of the current user to the notifications
+//	@Description	The body is the JSON of the PushSubscription of the browser, the inbox messages are pushed to it.
+//	@Description	Subscribing the endpoint again replaces its keys.
+//	@Tags			web-push
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		webpush.Subscription	true	"Push subscription of the browser"
+//	@Success		200		{object}	WebPushSubscription		"Created subscription"
+//	@Failure		400		{object}	nil						"Malformatted subscription | Invalid subscription"
+//	@Failure		401		{object}	nil						"Missing user in session"
+//	@Failure		500		{object}	nil						"Failed to create subscription"
+//	@Router			/api/v1/web-push/subscription [POST]
+func (s *APIV1Service) CreateWebPushSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	subscription := &webpush.Subscription{}
+	if err := json.NewDecoder(c.Request().Body).Decode(subscription); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted subscription").SetInternal(err)
+	}
+	if err := subscription.Validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid subscription: "+err.Error())
+	}
+	createdTs := time.Now().Unix()
+	if err := s.Store.UpsertUserWebPushSubscription(ctx, userID, &storepb.WebPushSubscriptionsUserSetting_Subscription{
+		Endpoint:  subscription.Endpoint,
+		P256Dh:    subscription.Keys.P256dh,
+		Auth:      subscription.Keys.Auth,
+		CreatedTs: createdTs,
+	}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create subscription").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &WebPushSubscription{
+		Endpoint:  subscription.Endpoint,
+		CreatedTs: createdTs,
+	})
+}
+
+// DeleteWebPushSubscription godoc
+//
+//	@Summary	Unsubscribe a browser of the current user from the notifications
+//	@Tags		web-push
+//	@Accept		json
+//	@Param		body	body		DeleteWebPushSubscriptionRequest	true	"Endpoint of the subscription"
+//	@Success	200		{boolean}	true								"Subscription deleted"
+//	@Failure	400		{object}	nil									"Malformatted delete subscription request"
+//	@Failure	401		{object}	nil									"Missing user in session"
+//	@Failure	500		{object}	nil									"Failed to delete subscription"
+//	@Router		/api/v1/web-push/subscription [DELETE]
+func (s *APIV1Service) DeleteWebPushSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	request := &DeleteWebPushSubscriptionRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(request); err != nil || request.Endpoint == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted delete subscription request")
+	}
+	if err := s.Store.RemoveUserWebPushSubscription(ctx, userID, request.Endpoint); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete subscription").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, true)
+}
+
+// GetWebPushVAPIDKey returns the VAPID key of the workspace, which is generated once and kept in the system settings.
+func GetWebPushVAPIDKey(ctx context.Context, s *store.Store) (*webpush.VAPIDKey, error) {
+	setting, err := s.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
+		Name: SystemSettingWebPushVAPIDKeyName.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if setting != nil && setting.Value != "" {
+		return webpush.ParseVAPIDKey(setting.Value)
+	}
+	key, err := webpush.GenerateVAPIDKey()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+		Name:  SystemSettingWebPushVAPIDKeyName.String(),
+		Value: key.PrivateKey(),
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to upsert VAPID key")
+	}
+	return key, nil
+}
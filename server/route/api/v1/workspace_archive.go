@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	workspacearchive "github.com/usememos/memos/server/service/workspace_archive"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) registerWorkspaceArchiveRoutes(g *echo.Group) {
+	g.GET("/workspace/export", s.ExportWorkspace)
+	g.POST("/workspace/import", s.ImportWorkspace)
+}
+
+// ExportWorkspace godoc
+//
+//	@Summary		Export the workspace
+//	@Description	Export the users, memos, resources, settings and relations of the workspace as a versioned JSON archive.
+//	@Description	The blobs of the resources stored in the database are included, the local files must be copied separately.
+//	@Tags			workspace
+//	@Produce		json
+//	@Success		200	{object}	workspacearchive.Archive	"Workspace archive"
+//	@Failure		401	{object}	nil							"Missing user in session | Unauthorized"
+//	@Failure		500	{object}	nil							"Failed to find user | Failed to export workspace"
+//	@Router			/api/v1/workspace/export [GET]
+func (s *APIV1Service) ExportWorkspace(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, err := s.getCurrentHostUser(c); err != nil {
+		return err
+	}
+
+	archive, err := workspacearchive.Export(ctx, s.Store, s.Profile.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to export workspace").SetInternal(err)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="memos-archive-%s.json"`, time.Now().Format("20060102")))
+	return c.JSON(http.StatusOK, archive)
+}
+
+// ImportWorkspace godoc
+//
+//	@Summary		Import the workspace
+//	@Description	Import a workspace archive into a fresh instance, which has no memos and no users except the current host.
+//	@Description	The host of the archive replaces the current host. Items get new ids, the references between them are remapped.
+//	@Tags			workspace
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		workspacearchive.Archive	true	"Workspace archive"
+//	@Success		200		{object}	workspacearchive.Result		"Imported items"
+//	@Failure		400		{object}	nil							"Malformatted workspace archive | Unsupported archive version"
+//	@Failure		401		{object}	nil							"Missing user in session | Unauthorized"
+//	@Failure		409		{object}	nil							"Workspace is not empty"
+//	@Failure		500		{object}	nil							"Failed to find user | Failed to import workspace"
+//	@Router			/api/v1/workspace/import [POST]
+func (s *APIV1Service) ImportWorkspace(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getCurrentHostUser(c)
+	if err != nil {
+		return err
+	}
+
+	archive := &workspacearchive.Archive{}
+	if err := json.NewDecoder(c.Request().Body).Decode(archive); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted workspace archive").SetInternal(err)
+	}
+	if archive.Version != workspacearchive.Version {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported archive version %d", archive.Version))
+	}
+
+	result, err := workspacearchive.Import(ctx, s.Store, archive, user.ID)
+	if err != nil {
+		if errors.Is(err, workspacearchive.ErrNotEmpty) {
+			return echo.NewHTTPError(http.StatusConflict, "Workspace is not empty")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import workspace").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// getCurrentHostUser returns the current user, or an echo error if the user isn't the host.
+func (s *APIV1Service) getCurrentHostUser(c echo.Context) (*store.User, error) {
+	userID, ok := c.Get(userIDContextKey).(int32)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Missing user in session")
+	}
+	user, err := s.Store.GetUser(c.Request().Context(), &store.FindUser{
+		ID: &userID,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil || user.Role != store.RoleHost {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+	return user, nil
+}
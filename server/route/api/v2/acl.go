@@ -2,6 +2,7 @@ package v2
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/usememos/memos/internal/util"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/route/api/auth"
+	"github.com/usememos/memos/server/route/api/quota"
 	"github.com/usememos/memos/store"
 )
 
@@ -31,13 +33,16 @@ const (
 type GRPCAuthInterceptor struct {
 	Store  *store.Store
 	secret string
+	// quotaLimiter limits the requests per access token, it's nil if the quota is disabled.
+	quotaLimiter *quota.Limiter
 }
 
 // NewGRPCAuthInterceptor returns a new API auth interceptor.
-func NewGRPCAuthInterceptor(store *store.Store, secret string) *GRPCAuthInterceptor {
+func NewGRPCAuthInterceptor(store *store.Store, secret string, quotaLimiter *quota.Limiter) *GRPCAuthInterceptor {
 	return &GRPCAuthInterceptor{
-		Store:  store,
-		secret: secret,
+		Store:        store,
+		secret:       secret,
+		quotaLimiter: quotaLimiter,
 	}
 }
 
@@ -74,6 +79,12 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 	if isOnlyForAdminAllowedMethod(serverInfo.FullMethod) && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
 		return nil, errors.Errorf("user %q is not admin", username)
 	}
+	if err := in.checkQuota(ctx, accessToken); err != nil {
+		return nil, err
+	}
+	if err := in.Store.UpdateUserLastActiveTs(ctx, user.ID); err != nil {
+		slog.Warn("Failed to update user last active time", slog.Any("err", err))
+	}
 
 	// Stores userID into context.
 	childCtx := context.WithValue(ctx, usernameContextKey, username)
@@ -129,6 +140,21 @@ func (in *GRPCAuthInterceptor) authenticate(ctx context.Context, accessToken str
 	return user.Username, nil
 }
 
+// checkQuota counts the request against the quota of the access token, and sends the rate limit headers.
+func (in *GRPCAuthInterceptor) checkQuota(ctx context.Context, accessToken string) error {
+	if in.quotaLimiter == nil {
+		return nil
+	}
+	result := in.quotaLimiter.Allow(ctx, accessToken)
+	if err := grpc.SetHeader(ctx, metadata.New(result.Headers(in.quotaLimiter.Now()))); err != nil {
+		return errors.Wrap(err, "failed to set rate limit headers")
+	}
+	if !result.Allowed {
+		return status.Errorf(codes.ResourceExhausted, "API quota of %d requests exceeded", result.Limit)
+	}
+	return nil
+}
+
 func getTokenFromMetadata(md metadata.MD) (string, error) {
 	// Check the HTTP request header first.
 	authorizationHeaders := md.Get("Authorization")
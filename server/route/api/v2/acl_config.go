@@ -2,6 +2,7 @@ package v2
 
 var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v2.WorkspaceService/GetWorkspaceProfile":        true,
+	"/memos.api.v2.WorkspaceService/GetWorkspaceAnnouncement":   true,
 	"/memos.api.v2.WorkspaceSettingService/GetWorkspaceSetting": true,
 	"/memos.api.v2.AuthService/GetAuthStatus":                   true,
 	"/memos.api.v2.AuthService/SignIn":                          true,
@@ -10,6 +11,7 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v2.AuthService/SignUp":                          true,
 	"/memos.api.v2.UserService/GetUser":                         true,
 	"/memos.api.v2.UserService/SearchUsers":                     true,
+	"/memos.api.v2.UserService/GetUserProfile":                  true,
 	"/memos.api.v2.MemoService/ListMemos":                       true,
 	"/memos.api.v2.MemoService/GetMemo":                         true,
 	"/memos.api.v2.MemoService/SearchMemos":                     true,
@@ -17,6 +19,10 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v2.MemoService/ListMemoRelations":               true,
 	"/memos.api.v2.MemoService/ListMemoComments":                true,
 	"/memos.api.v2.LinkService/GetLinkMetadata":                 true,
+	"/memos.api.v2.SearchService/SearchMemoContents":            true,
+	"/memos.api.v2.GuestCommentService/CreateGuestComment":      true,
+	"/memos.api.v2.GuestCommentService/ListMemoGuestComments":   true,
+	"/grpc.health.v1.Health/Check":                              true,
 }
 
 // isUnauthorizeAllowedMethod returns whether the method is exempted from authentication.
@@ -25,7 +31,19 @@ func isUnauthorizeAllowedMethod(fullMethodName string) bool {
 }
 
 var allowedMethodsOnlyForAdmin = map[string]bool{
-	"/memos.api.v2.UserService/CreateUser": true,
+	"/memos.api.v2.UserService/CreateUser":                    true,
+	"/memos.api.v2.UserService/DisableUser":                   true,
+	"/memos.api.v2.UserService/EnableUser":                    true,
+	"/memos.api.v2.UserService/ResetUserPassword":             true,
+	"/memos.api.v2.UserService/UpdateUserQuota":               true,
+	"/memos.api.v2.UserService/TransferUserContent":           true,
+	"/memos.api.v2.UserService/PurgeUserContent":              true,
+	"/memos.api.v2.AnalyticsService/GetWorkspaceAnalytics":    true,
+	"/memos.api.v2.ModerationService/ListMemoReports":         true,
+	"/memos.api.v2.ModerationService/ResolveMemoReport":       true,
+	"/memos.api.v2.ModerationService/RestoreMemo":             true,
+	"/memos.api.v2.ModerationService/ListPendingExploreMemos": true,
+	"/memos.api.v2.ModerationService/SetMemoExploreApproval":  true,
 }
 
 // isOnlyForAdminAllowedMethod returns true if the method is allowed to be called only by admin.
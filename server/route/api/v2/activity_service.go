@@ -2,6 +2,7 @@ package v2
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -13,13 +14,121 @@ import (
 	"github.com/usememos/memos/store"
 )
 
+// activityBatchSize is the number of the activities loaded at a time while filtering a page by visibility.
+const activityBatchSize = 100
+
+func (s *APIV2Service) ListActivities(ctx context.Context, request *apiv2pb.ListActivitiesRequest) (*apiv2pb.ListActivitiesResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+
+	activityFind := &store.FindActivity{}
+	switch request.Creator {
+	case "":
+		activityFind.CreatorID = &user.ID
+	case UserNamePrefix + "-":
+	default:
+		creatorID, err := ExtractUserIDFromName(request.Creator)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid creator: %v", err)
+		}
+		activityFind.CreatorID = &creatorID
+	}
+	for _, activityType := range request.Types {
+		activityFind.TypeList = append(activityFind.TypeList, store.ActivityType(activityType))
+	}
+	if request.StartTime != nil {
+		startTs := request.StartTime.AsTime().Unix()
+		activityFind.CreatedTsAfter = &startTs
+	}
+	if request.EndTime != nil {
+		endTs := request.EndTime.AsTime().Unix()
+		activityFind.CreatedTsBefore = &endTs
+	}
+
+	// The activities are filtered by visibility after they are loaded, so the page is filled batch by batch.
+	// The page token holds the offset of the activities loaded, not the ones returned.
+	activities := []*store.Activity{}
+	nextOffset := offset
+	batchSize := activityBatchSize
+	activityFind.Limit = &batchSize
+	hasMore := false
+	for !hasMore {
+		activityFind.Offset = &nextOffset
+		batch, err := s.Store.ListActivities(ctx, activityFind)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list activities: %v", err)
+		}
+		for _, activity := range batch {
+			if limit > 0 && len(activities) == limit {
+				hasMore = true
+				break
+			}
+			nextOffset++
+			visible, err := s.isActivityVisible(ctx, activity, user)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to check activity visibility: %v", err)
+			}
+			if visible {
+				activities = append(activities, activity)
+			}
+		}
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	response := &apiv2pb.ListActivitiesResponse{
+		Activities: []*apiv2pb.Activity{},
+	}
+	if hasMore {
+		response.NextPageToken, err = getPageToken(limit, nextOffset)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, activity := range activities {
+		activityMessage, err := s.convertActivityFromStore(ctx, activity)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert activity from store: %v", err)
+		}
+		response.Activities = append(response.Activities, activityMessage)
+	}
+	return response, nil
+}
+
 func (s *APIV2Service) GetActivity(ctx context.Context, request *apiv2pb.GetActivityRequest) (*apiv2pb.GetActivityResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
 	activity, err := s.Store.GetActivity(ctx, &store.FindActivity{
 		ID: &request.Id,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get activity: %v", err)
 	}
+	if activity == nil {
+		return nil, status.Errorf(codes.NotFound, "activity not found")
+	}
+	visible, err := s.isActivityVisible(ctx, activity, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check activity visibility: %v", err)
+	}
+	if !visible {
+		return nil, status.Errorf(codes.NotFound, "activity not found")
+	}
 
 	activityMessage, err := s.convertActivityFromStore(ctx, activity)
 	if err != nil {
@@ -30,6 +139,73 @@ func (s *APIV2Service) GetActivity(ctx context.Context, request *apiv2pb.GetActi
 	}, nil
 }
 
+// isActivityVisible returns true if the user can see the activity.
+// The activities of other users are visible if the memos they are about are visible, and the version updates are only visible to the admins.
+func (s *APIV2Service) isActivityVisible(ctx context.Context, activity *store.Activity, user *store.User) (bool, error) {
+	if activity.CreatorID == user.ID {
+		return true, nil
+	}
+	payload := activity.Payload
+	memoIDs := []int32{}
+	switch activity.Type {
+	case store.ActivityTypeUserCreate:
+		return true, nil
+	case store.ActivityTypeVersionUpdate:
+		return user.Role == store.RoleHost || user.Role == store.RoleAdmin, nil
+	case store.ActivityTypeMemoReportResolve:
+		if user.Role == store.RoleHost || user.Role == store.RoleAdmin {
+			return true, nil
+		}
+		reportID := payload.GetMemoReportResolve().GetReportId()
+		report, err := s.Store.GetMemoReport(ctx, &store.FindMemoReport{ID: &reportID})
+		if err != nil {
+			return false, err
+		}
+		return report != nil && report.ReporterID == user.ID, nil
+	case store.ActivityTypeMemoCreate:
+		memoIDs = append(memoIDs, payload.GetMemoCreate().GetMemoId())
+	case store.ActivityTypeMemoComment:
+		memoIDs = append(memoIDs, payload.GetMemoComment().GetMemoId(), payload.GetMemoComment().GetRelatedMemoId())
+	case store.ActivityTypeMemoReaction:
+		memoIDs = append(memoIDs, payload.GetMemoReaction().GetMemoId())
+	case store.ActivityTypeMemoShare:
+		memoIDs = append(memoIDs, payload.GetMemoShare().GetMemoId())
+	case store.ActivityTypeMemoMention:
+		memoIDs = append(memoIDs, payload.GetMemoMention().GetMemoId())
+	case store.ActivityTypeMemoReminder:
+		// The reminders are private to the users who set them.
+		return false, nil
+	default:
+		return false, nil
+	}
+	for _, memoID := range memoIDs {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+		if err != nil {
+			return false, err
+		}
+		if memo == nil || memo.RowStatus != store.Normal {
+			return false, nil
+		}
+		if visible, err := s.Store.IsMemoVisibleTo(ctx, memo, user.ID); err != nil || !visible {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// createActivity records the activity of the user for the activity timeline.
+// The activity is an addition to the action, so a failure is only logged.
+func (s *APIV2Service) createActivity(ctx context.Context, creatorID int32, activityType store.ActivityType, payload *storepb.ActivityPayload) {
+	if _, err := s.Store.CreateActivity(ctx, &store.Activity{
+		CreatorID: creatorID,
+		Type:      activityType,
+		Level:     store.ActivityLevelInfo,
+		Payload:   payload,
+	}); err != nil {
+		slog.Warn("Failed to create activity", slog.String("type", activityType.String()), slog.Any("err", err))
+	}
+}
+
 func (*APIV2Service) convertActivityFromStore(_ context.Context, activity *store.Activity) (*apiv2pb.Activity, error) {
 	return &apiv2pb.Activity{
 		Id:         activity.ID,
@@ -49,6 +225,46 @@ func convertActivityPayloadFromStore(payload *storepb.ActivityPayload) *apiv2pb.
 			RelatedMemoId: payload.MemoComment.RelatedMemoId,
 		}
 	}
+	if payload.MemoCreate != nil {
+		v2Payload.MemoCreate = &apiv2pb.ActivityMemoCreatePayload{
+			MemoId: payload.MemoCreate.MemoId,
+		}
+	}
+	if payload.MemoReaction != nil {
+		v2Payload.MemoReaction = &apiv2pb.ActivityMemoReactionPayload{
+			MemoId:       payload.MemoReaction.MemoId,
+			ReactionType: payload.MemoReaction.ReactionType,
+		}
+	}
+	if payload.MemoShare != nil {
+		v2Payload.MemoShare = &apiv2pb.ActivityMemoSharePayload{
+			MemoId:     payload.MemoShare.MemoId,
+			Visibility: payload.MemoShare.Visibility,
+		}
+	}
+	if payload.UserCreate != nil {
+		v2Payload.UserCreate = &apiv2pb.ActivityUserCreatePayload{
+			UserId: payload.UserCreate.UserId,
+		}
+	}
+	if payload.MemoReportResolve != nil {
+		v2Payload.MemoReportResolve = &apiv2pb.ActivityMemoReportResolvePayload{
+			ReportId: payload.MemoReportResolve.ReportId,
+			MemoId:   payload.MemoReportResolve.MemoId,
+			Status:   payload.MemoReportResolve.Status,
+		}
+	}
+	if payload.MemoMention != nil {
+		v2Payload.MemoMention = &apiv2pb.ActivityMemoMentionPayload{
+			MemoId: payload.MemoMention.MemoId,
+		}
+	}
+	if payload.MemoReminder != nil {
+		v2Payload.MemoReminder = &apiv2pb.ActivityMemoReminderPayload{
+			MemoId:     payload.MemoReminder.MemoId,
+			ReminderId: payload.MemoReminder.ReminderId,
+		}
+	}
 	if payload.VersionUpdate != nil {
 		v2Payload.VersionUpdate = &apiv2pb.ActivityVersionUpdatePayload{
 			Version: payload.VersionUpdate.Version,
@@ -0,0 +1,309 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/jobs"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 365
+	maxAnalyticsTopTags  = 20
+	// analyticsCacheTTL is how long the computed metrics are served from the cache.
+	analyticsCacheTTL = 5 * time.Minute
+)
+
+// cachedAnalytics is the analytics cached by the number of days.
+type cachedAnalytics struct {
+	analytics  *apiv2pb.WorkspaceAnalytics
+	expireTime time.Time
+}
+
+func (s *APIV2Service) GetWorkspaceAnalytics(ctx context.Context, request *apiv2pb.GetWorkspaceAnalyticsRequest) (*apiv2pb.GetWorkspaceAnalyticsResponse, error) {
+	days := int(request.Days)
+	if days == 0 {
+		days = defaultAnalyticsDays
+	}
+	if days < 1 || days > maxAnalyticsDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be from 1 to %d", maxAnalyticsDays)
+	}
+
+	if cache, ok := s.analyticsCache.Load(days); ok && time.Now().Before(cache.(*cachedAnalytics).expireTime) {
+		return &apiv2pb.GetWorkspaceAnalyticsResponse{
+			Analytics: cache.(*cachedAnalytics).analytics,
+		}, nil
+	}
+	analytics, err := s.computeWorkspaceAnalytics(ctx, days)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute workspace analytics: %v", err)
+	}
+	s.analyticsCache.Store(days, &cachedAnalytics{
+		analytics:  analytics,
+		expireTime: time.Now().Add(analyticsCacheTTL),
+	})
+	return &apiv2pb.GetWorkspaceAnalyticsResponse{
+		Analytics: analytics,
+	}, nil
+}
+
+func (s *APIV2Service) computeWorkspaceAnalytics(ctx context.Context, days int) (*apiv2pb.WorkspaceAnalytics, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -(days - 1))
+	// The memos are found with the timestamps after startTs, which is exclusive.
+	startTs := start.Unix() - 1
+	dates := []string{}
+	for date := start; !date.After(today); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date.Format(time.DateOnly))
+	}
+	getDate := func(ts int64) string {
+		return time.Unix(ts, 0).UTC().Format(time.DateOnly)
+	}
+
+	activeUsers := map[string]map[int32]bool{}
+	addActiveUser := func(ts int64, userID int32) {
+		if ts <= startTs {
+			return
+		}
+		date := getDate(ts)
+		if activeUsers[date] == nil {
+			activeUsers[date] = map[int32]bool{}
+		}
+		activeUsers[date][userID] = true
+	}
+	createdMemos := map[string]int32{}
+	tagCounts := map[string]int32{}
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		UpdatedTsAfter: &startTs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, memo := range memos {
+		addActiveUser(memo.UpdatedTs, memo.CreatorID)
+		addActiveUser(memo.CreatedTs, memo.CreatorID)
+		if memo.CreatedTs <= startTs {
+			continue
+		}
+		createdMemos[getDate(memo.CreatedTs)]++
+		tags, err := getMemoContentTags(memo.Content)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagCounts[tag]++
+		}
+	}
+	signUps := map[string]int32{}
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{})
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.CreatedTs > startTs {
+			signUps[getDate(user.CreatedTs)]++
+		}
+	}
+	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{})
+	if err != nil {
+		return nil, err
+	}
+	for _, reaction := range reactions {
+		addActiveUser(reaction.CreatedTs, reaction.CreatorId)
+	}
+
+	analytics := &apiv2pb.WorkspaceAnalytics{
+		ComputeTime:          timestamppb.New(now),
+		ActiveUsers:          []*apiv2pb.DailyCount{},
+		CreatedMemos:         []*apiv2pb.DailyCount{},
+		StorageUsages:        []*apiv2pb.UserStorageUsage{},
+		TopTags:              []*apiv2pb.TagUsage{},
+		WebhookDeliveryStats: []*apiv2pb.WebhookDeliveryStats{},
+		SignUps:              []*apiv2pb.DailyCount{},
+		InstanceStats: &apiv2pb.InstanceStats{
+			Version:   s.Profile.Version,
+			Mode:      s.Profile.Mode,
+			StartTime: timestamppb.New(s.startTime),
+		},
+	}
+	for _, date := range dates {
+		analytics.ActiveUsers = append(analytics.ActiveUsers, &apiv2pb.DailyCount{
+			Date:  date,
+			Count: int32(len(activeUsers[date])),
+		})
+		analytics.CreatedMemos = append(analytics.CreatedMemos, &apiv2pb.DailyCount{
+			Date:  date,
+			Count: createdMemos[date],
+		})
+		analytics.SignUps = append(analytics.SignUps, &apiv2pb.DailyCount{
+			Date:  date,
+			Count: signUps[date],
+		})
+	}
+
+	for tag, count := range tagCounts {
+		analytics.TopTags = append(analytics.TopTags, &apiv2pb.TagUsage{Tag: tag, Count: count})
+	}
+	sort.Slice(analytics.TopTags, func(i, j int) bool {
+		if analytics.TopTags[i].Count != analytics.TopTags[j].Count {
+			return analytics.TopTags[i].Count > analytics.TopTags[j].Count
+		}
+		return analytics.TopTags[i].Tag < analytics.TopTags[j].Tag
+	})
+	if len(analytics.TopTags) > maxAnalyticsTopTags {
+		analytics.TopTags = analytics.TopTags[:maxAnalyticsTopTags]
+	}
+
+	resources, err := s.Store.ListResources(ctx, &store.FindResource{})
+	if err != nil {
+		return nil, err
+	}
+	storageUsages := map[int32]*apiv2pb.UserStorageUsage{}
+	getStorageUsage := func(userID int32) *apiv2pb.UserStorageUsage {
+		storageUsage, ok := storageUsages[userID]
+		if !ok {
+			storageUsage = &apiv2pb.UserStorageUsage{
+				User: fmt.Sprintf("%s%d", UserNamePrefix, userID),
+			}
+			storageUsages[userID] = storageUsage
+			analytics.StorageUsages = append(analytics.StorageUsages, storageUsage)
+		}
+		return storageUsage
+	}
+	for _, resource := range resources {
+		storageUsage := getStorageUsage(resource.CreatorID)
+		storageUsage.ResourceCount++
+		storageUsage.Size += resource.Size
+	}
+	allMemos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		ExcludeContent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, memo := range allMemos {
+		getStorageUsage(memo.CreatorID).MemoCount++
+	}
+	sort.Slice(analytics.StorageUsages, func(i, j int) bool {
+		if analytics.StorageUsages[i].Size != analytics.StorageUsages[j].Size {
+			return analytics.StorageUsages[i].Size > analytics.StorageUsages[j].Size
+		}
+		return analytics.StorageUsages[i].MemoCount > analytics.StorageUsages[j].MemoCount
+	})
+
+	webhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{})
+	if err != nil {
+		return nil, err
+	}
+	deliveryStartTs := start.Unix()
+	for _, webhook := range webhooks {
+		deliveries, err := s.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+			WebhookID:      &webhook.Id,
+			CreatedTsAfter: &deliveryStartTs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		stats := &apiv2pb.WebhookDeliveryStats{
+			WebhookId:   webhook.Id,
+			WebhookName: webhook.Name,
+			Total:       int32(len(deliveries)),
+		}
+		for _, delivery := range deliveries {
+			switch delivery.Status {
+			case store.WebhookDeliverySucceeded:
+				stats.Succeeded++
+			case store.WebhookDeliveryFailed:
+				stats.Failed++
+				if stats.LastFailureTime == nil || delivery.UpdatedTs > stats.LastFailureTime.Seconds {
+					stats.LastError = delivery.LastError
+					stats.LastFailureTime = timestamppb.New(time.Unix(delivery.UpdatedTs, 0))
+				}
+			default:
+				stats.Pending++
+			}
+		}
+		if finished := stats.Succeeded + stats.Failed; finished > 0 {
+			stats.FailureRate = float64(stats.Failed) / float64(finished)
+		}
+		analytics.WebhookDeliveryStats = append(analytics.WebhookDeliveryStats, stats)
+	}
+
+	if analytics.JobBacklogs, err = s.getJobBacklogs(ctx); err != nil {
+		return nil, err
+	}
+	if analytics.DatabaseStats, err = s.getDatabaseStats(ctx); err != nil {
+		return nil, err
+	}
+	return analytics, nil
+}
+
+// getJobBacklogs returns the jobs of the queue which aren't done by kind, ordered by kind.
+func (s *APIV2Service) getJobBacklogs(ctx context.Context) ([]*apiv2pb.JobBacklog, error) {
+	jobBacklogs := map[string]*apiv2pb.JobBacklog{}
+	for _, jobStatus := range []store.JobStatus{store.JobPending, store.JobRunning, store.JobDead} {
+		jobs, err := s.Store.ListJobs(ctx, &store.FindJob{Status: &jobStatus})
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			jobBacklog, ok := jobBacklogs[job.Kind]
+			if !ok {
+				jobBacklog = &apiv2pb.JobBacklog{Kind: job.Kind}
+				jobBacklogs[job.Kind] = jobBacklog
+			}
+			switch job.Status {
+			case store.JobPending:
+				jobBacklog.Pending++
+				if jobBacklog.OldestPendingTime == nil || job.CreatedTs < jobBacklog.OldestPendingTime.Seconds {
+					jobBacklog.OldestPendingTime = timestamppb.New(time.Unix(job.CreatedTs, 0))
+				}
+			case store.JobRunning:
+				jobBacklog.Running++
+			default:
+				jobBacklog.Dead++
+			}
+		}
+	}
+	list := []*apiv2pb.JobBacklog{}
+	for _, jobBacklog := range jobBacklogs {
+		list = append(list, jobBacklog)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Kind < list[j].Kind
+	})
+	return list, nil
+}
+
+// getDatabaseStats returns the size of the database and the last run of its scheduled maintenance.
+func (s *APIV2Service) getDatabaseStats(ctx context.Context) (*apiv2pb.DatabaseStats, error) {
+	databaseStats := &apiv2pb.DatabaseStats{
+		Driver: s.Profile.Driver,
+	}
+	// The size isn't available to the users without the privilege, e.g. of MySQL.
+	databaseStats.Size, _ = s.Store.GetCurrentDBSize(ctx)
+	taskName := jobs.DatabaseMaintenanceTask
+	taskRun, err := s.Store.GetTaskRun(ctx, &store.FindTaskRun{Name: &taskName})
+	if err != nil {
+		return nil, err
+	}
+	if taskRun != nil {
+		databaseStats.LastMaintenanceStatus = taskRun.Status.String()
+		databaseStats.LastMaintenanceStartTime = timestamppb.New(time.Unix(taskRun.StartedTs, 0))
+		databaseStats.LastMaintenanceError = taskRun.Error
+		if taskRun.FinishedTs != 0 {
+			databaseStats.LastMaintenanceFinishTime = timestamppb.New(time.Unix(taskRun.FinishedTs, 0))
+		}
+	}
+	return databaseStats, nil
+}
@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestGetWorkspaceAnalytics(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser})
+	require.NoError(t, err)
+	today := time.Now().UTC()
+	createMemo := func(uid string, creatorID int32, createdTime time.Time) {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: "#test memo", Visibility: store.Private})
+		require.NoError(t, err)
+		createdTs := createdTime.Unix()
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs}))
+	}
+	createMemo("today", user.ID, today)
+	createMemo("two-days-ago", user.ID, today.AddDate(0, 0, -2))
+	// The memos created before the days are only in the storage usages.
+	createMemo("ten-days-ago", user.ID, today.AddDate(0, 0, -10))
+	createMemo("other", other.ID, today)
+	createJob := func(kind string, status store.JobStatus) *store.Job {
+		job, err := ts.CreateJob(ctx, &store.Job{Kind: kind, Payload: "{}", Status: store.JobPending, MaxAttempts: 3})
+		require.NoError(t, err)
+		if status != store.JobPending {
+			require.NoError(t, ts.UpdateJob(ctx, &store.UpdateJob{ID: job.ID, Status: &status}))
+		}
+		return job
+	}
+	oldestJob := createJob("email", store.JobPending)
+	createJob("email", store.JobPending)
+	createJob("email", store.JobDead)
+	createJob("webhook", store.JobRunning)
+	// The succeeded jobs aren't in the backlog.
+	createJob("webhook", store.JobSucceeded)
+	createJob("telegram", store.JobSucceeded)
+	startTime := time.Now().Add(-time.Hour)
+	s := &APIV2Service{Store: ts, Profile: ts.Profile, startTime: startTime}
+
+	for _, days := range []int32{-1, maxAnalyticsDays + 1} {
+		_, err := s.GetWorkspaceAnalytics(ctx, &apiv2pb.GetWorkspaceAnalyticsRequest{Days: days})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), days)
+	}
+	response, err := s.GetWorkspaceAnalytics(ctx, &apiv2pb.GetWorkspaceAnalyticsRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Analytics.SignUps, defaultAnalyticsDays)
+	response, err = s.GetWorkspaceAnalytics(ctx, &apiv2pb.GetWorkspaceAnalyticsRequest{Days: 3})
+	require.NoError(t, err)
+	analytics := response.Analytics
+
+	getDate := func(days int) string {
+		return today.AddDate(0, 0, -days).Format(time.DateOnly)
+	}
+	tests := []struct {
+		name   string
+		counts []*apiv2pb.DailyCount
+		want   []int32
+	}{
+		{name: "sign ups", counts: analytics.SignUps, want: []int32{0, 0, 2}},
+		{name: "created memos", counts: analytics.CreatedMemos, want: []int32{1, 0, 2}},
+	}
+	for _, test := range tests {
+		require.Len(t, test.counts, len(test.want), test.name)
+		for i, count := range test.counts {
+			require.Equal(t, getDate(len(test.want)-1-i), count.Date, test.name)
+			require.Equal(t, test.want[i], count.Count, test.name)
+		}
+	}
+	require.Len(t, analytics.TopTags, 1)
+	require.Equal(t, "test", analytics.TopTags[0].Tag)
+	require.Equal(t, int32(3), analytics.TopTags[0].Count)
+
+	memoCounts := map[string]int32{}
+	for _, storageUsage := range analytics.StorageUsages {
+		memoCounts[storageUsage.User] = storageUsage.MemoCount
+	}
+	require.Equal(t, map[string]int32{
+		fmt.Sprintf("%s%d", UserNamePrefix, user.ID):  3,
+		fmt.Sprintf("%s%d", UserNamePrefix, other.ID): 1,
+	}, memoCounts)
+
+	require.Len(t, analytics.JobBacklogs, 2)
+	require.Equal(t, "email", analytics.JobBacklogs[0].Kind)
+	require.Equal(t, int32(2), analytics.JobBacklogs[0].Pending)
+	require.Equal(t, int32(0), analytics.JobBacklogs[0].Running)
+	require.Equal(t, int32(1), analytics.JobBacklogs[0].Dead)
+	require.Equal(t, oldestJob.CreatedTs, analytics.JobBacklogs[0].OldestPendingTime.Seconds)
+	require.Equal(t, "webhook", analytics.JobBacklogs[1].Kind)
+	require.Equal(t, int32(0), analytics.JobBacklogs[1].Pending)
+	require.Equal(t, int32(1), analytics.JobBacklogs[1].Running)
+	require.Nil(t, analytics.JobBacklogs[1].OldestPendingTime)
+
+	require.Equal(t, ts.Profile.Version, analytics.InstanceStats.Version)
+	require.Equal(t, ts.Profile.Mode, analytics.InstanceStats.Mode)
+	require.Equal(t, startTime.Unix(), analytics.InstanceStats.StartTime.Seconds)
+	require.Equal(t, ts.Profile.Driver, analytics.DatabaseStats.Driver)
+
+	// The analytics are cached, so the new memos are counted after the cache expires.
+	createMemo("new", other.ID, today)
+	response, err = s.GetWorkspaceAnalytics(ctx, &apiv2pb.GetWorkspaceAnalyticsRequest{Days: 3})
+	require.NoError(t, err)
+	require.Same(t, analytics, response.Analytics)
+}
@@ -0,0 +1,65 @@
+package v2
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+//go:generate go run ./openapi/gen -title "memos API" apidocs.swagger.yaml openapi.yaml
+
+// openAPIDocument is the OpenAPI 3 document of api v2, converted from the generated swagger document.
+//
+//go:embed openapi.yaml
+var openAPIDocument []byte
+
+// swaggerUIVersion is the version of Swagger UI loaded by the API explorer.
+const swaggerUIVersion = "5.11.8"
+
+const apiExplorerHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <meta name="viewport" content="width=device-width, initial-scale=1" />
+  <title>memos API explorer</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/api/v2/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// registerAPIDocsRoutes serves the OpenAPI document in YAML and JSON, and the API explorer if it's enabled.
+func (s *APIV2Service) registerAPIDocsRoutes(e *echo.Echo) error {
+	document := map[string]any{}
+	if err := yaml.Unmarshal(openAPIDocument, &document); err != nil {
+		return errors.Wrap(err, "failed to parse openapi document")
+	}
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal openapi document")
+	}
+
+	e.GET("/api/v2/openapi.yaml", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "application/yaml", openAPIDocument)
+	})
+	e.GET("/api/v2/openapi.json", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, documentJSON)
+	})
+	if s.Profile.IsAPIExplorerEnabled() {
+		e.GET("/api/v2/explorer", func(c echo.Context) error {
+			return c.HTML(http.StatusOK, fmt.Sprintf(apiExplorerHTML, swaggerUIVersion))
+		})
+	}
+	return nil
+}
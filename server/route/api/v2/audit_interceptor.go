@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/usememos/memos/internal/auditlog"
+)
+
+// auditedServices are the services whose writes are security events.
+var auditedServices = []string{
+	"/memos.api.v2.AuthService/",
+	"/memos.api.v2.UserService/",
+	"/memos.api.v2.IdentityProviderService/",
+	"/memos.api.v2.WorkspaceSettingService/",
+	"/memos.api.v2.WebhookService/",
+	"/memos.api.v2.ModerationService/",
+	"/memos.api.v2.GroupService/",
+	"/memos.api.v2.InvitationService/",
+}
+
+type AuditInterceptor struct {
+}
+
+func NewAuditInterceptor() *AuditInterceptor {
+	return &AuditInterceptor{}
+}
+
+// AuditInterceptor records the writes of the audited services to the audit log. It must be chained after the authentication.
+func (*AuditInterceptor) AuditInterceptor(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !auditlog.Enabled() || !isAuditedMethod(serverInfo.FullMethod) {
+		return handler(ctx, request)
+	}
+	resp, err := handler(ctx, request)
+
+	code := status.Code(err)
+	outcome := auditlog.OutcomeSuccess
+	if err != nil {
+		outcome = auditlog.OutcomeFailure
+	}
+	attrs := []slog.Attr{
+		slog.String("api", "v2"),
+		slog.String("status", code.String()),
+	}
+	if ip := getClientIP(ctx); ip != "" {
+		attrs = append(attrs, slog.String("ip", ip))
+	}
+	if username, ok := ctx.Value(usernameContextKey).(string); ok {
+		attrs = append(attrs, slog.String("username", username))
+	}
+	if message, ok := request.(proto.Message); ok {
+		if target := getAuditTarget(message.ProtoReflect()); target != "" {
+			attrs = append(attrs, slog.String("target", target))
+		}
+	}
+	auditlog.Record(ctx, serverInfo.FullMethod, outcome, attrs...)
+	return resp, err
+}
+
+func isAuditedMethod(fullMethod string) bool {
+	for _, service := range auditedServices {
+		if name, ok := strings.CutPrefix(fullMethod, service); ok {
+			return !strings.HasPrefix(name, "Get") && !strings.HasPrefix(name, "List") && !strings.HasPrefix(name, "Search")
+		}
+	}
+	return false
+}
+
+// getAuditTarget returns the name of the resource or the username the request acts on, e.g. `users/steven` of an UpdateUserRequest.
+// The fields of the nested messages are looked up too, like the name of the setting of a SetWorkspaceSettingRequest.
+func getAuditTarget(message protoreflect.Message) string {
+	fields := message.Descriptor().Fields()
+	for _, name := range []protoreflect.Name{"name", "username"} {
+		if field := fields.ByName(name); field != nil && field.Kind() == protoreflect.StringKind && !field.IsList() {
+			if value := message.Get(field).String(); value != "" {
+				return value
+			}
+		}
+	}
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() || !message.Has(field) {
+			continue
+		}
+		if target := getAuditTarget(message.Get(field).Message()); target != "" {
+			return target
+		}
+	}
+	return ""
+}
+
+// getClientIP returns the IP of the client, which is forwarded by the gateway, or the peer of the direct gRPC requests.
+func getClientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			return strings.TrimSpace(strings.Split(values[0], ",")[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -14,10 +14,12 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/plugin/idp"
 	"github.com/usememos/memos/plugin/idp/oauth2"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/route/api/auth"
 	"github.com/usememos/memos/store"
 )
@@ -135,6 +137,10 @@ func (s *APIV2Service) SignInWithSSO(ctx context.Context, request *apiv2pb.SignI
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to create user, err: %s", err))
 		}
+		s.createActivity(ctx, user.ID, store.ActivityTypeUserCreate, &storepb.ActivityPayload{
+			UserCreate: &storepb.ActivityUserCreatePayload{UserId: user.ID},
+		})
+		s.eventBroker.Publish(event.NewUserEvent(event.UserCreated, user.ID))
 	}
 	if user.RowStatus == store.Archived {
 		return nil, status.Errorf(codes.PermissionDenied, fmt.Sprintf("user has been archived with username %s", userInfo.Identifier))
@@ -205,12 +211,37 @@ func (s *APIV2Service) SignUp(ctx context.Context, request *apiv2pb.SignUpReques
 		create.Role = store.RoleHost
 	} else {
 		create.Role = store.RoleUser
+		if request.InviteCode == "" && workspaceGeneralSetting.InviteOnlySignup {
+			return nil, status.Errorf(codes.PermissionDenied, "an invitation is required to sign up")
+		}
+		if request.InviteCode != "" {
+			// The username is checked first, so the invitation isn't used by a failed signup.
+			existingUser, err := s.Store.GetUser(ctx, &store.FindUser{Username: &create.Username})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+			}
+			if existingUser != nil {
+				return nil, status.Errorf(codes.AlreadyExists, "username %s is taken", create.Username)
+			}
+			invitation, err := s.Store.RedeemInvitation(ctx, request.InviteCode)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to redeem invitation: %v", err)
+			}
+			if invitation == nil {
+				return nil, status.Errorf(codes.PermissionDenied, "invalid invitation code")
+			}
+			create.Role = invitation.Role
+		}
 	}
 
 	user, err := s.Store.CreateUser(ctx, create)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to create user, err: %s", err))
 	}
+	s.createActivity(ctx, user.ID, store.ActivityTypeUserCreate, &storepb.ActivityPayload{
+		UserCreate: &storepb.ActivityUserCreatePayload{UserId: user.ID},
+	})
+	s.eventBroker.Publish(event.NewUserEvent(event.UserCreated, user.ID))
 
 	if err := s.doSignIn(ctx, user, time.Now().Add(auth.AccessTokenDuration)); err != nil {
 		return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to sign in, err: %s", err))
@@ -54,6 +54,25 @@ func getPageToken(limit int, offset int) (string, error) {
 	})
 }
 
+// getPageLimitOffset returns the limit and offset of the page requested by the page size and token.
+// The page token takes precedence over the page size, and a zero limit means all items are requested.
+func getPageLimitOffset(pageSize int32, pageToken string) (int, int, error) {
+	if pageToken == "" {
+		if pageSize < 0 {
+			return 0, 0, errors.New("page size must not be negative")
+		}
+		return int(pageSize), 0, nil
+	}
+	var token apiv2pb.PageToken
+	if err := unmarshalPageToken(pageToken, &token); err != nil {
+		return 0, 0, err
+	}
+	if token.Limit <= 0 || token.Offset < 0 {
+		return 0, 0, errors.New("invalid page token")
+	}
+	return int(token.Limit), int(token.Offset), nil
+}
+
 func marshalPageToken(pageToken *apiv2pb.PageToken) (string, error) {
 	b, err := proto.Marshal(pageToken)
 	if err != nil {
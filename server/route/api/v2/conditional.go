@@ -0,0 +1,72 @@
+package v2
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/server/route/api/etag"
+)
+
+// httpCodeHeader is the header metadata of the HTTP status code the gateway responds with.
+const httpCodeHeader = "x-http-code"
+
+// checkNotModified sets the ETag and Last-Modified header metadata of the entity version,
+// and returns true if the client already has it. The caller should then return an empty response,
+// which the gateway turns into a 304 Not Modified, while gRPC clients get the x-http-code header.
+// The representation depends on the session, so it varies by the auth headers.
+func checkNotModified(ctx context.Context, tag string, updatedTs int64) bool {
+	header := metadata.Pairs("etag", tag, "last-modified", etag.LastModified(updatedTs), "vary", "Authorization, Cookie")
+	notModified := etag.IsNotModified(getIncomingHeader(ctx, etag.IfNoneMatchHeader), getIncomingHeader(ctx, etag.IfModifiedSinceHeader), tag, updatedTs)
+	if notModified {
+		header.Set(httpCodeHeader, strconv.Itoa(http.StatusNotModified))
+	}
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		slog.Warn("failed to set conditional request headers", slog.Any("err", err))
+		return false
+	}
+	return notModified
+}
+
+// getIncomingHeader returns the request header sent by gRPC clients or forwarded by the gateway.
+func getIncomingHeader(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	key = strings.ToLower(key)
+	for _, k := range []string{key, runtime.MetadataPrefix + key} {
+		if values := md.Get(k); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// forwardResponseStatus sets the status code of the gateway response from the x-http-code header metadata.
+func forwardResponseStatus(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.HeaderMD.Get(httpCodeHeader)
+	if len(values) == 0 {
+		return nil
+	}
+	code, err := strconv.Atoi(values[0])
+	if err != nil {
+		return err
+	}
+	if code == http.StatusNotModified {
+		w.Header().Del("Content-Type")
+	}
+	w.WriteHeader(code)
+	return nil
+}
@@ -0,0 +1,478 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+const maxGroupDescriptionLength = 1000
+
+func (s *APIV2Service) CreateGroup(ctx context.Context, request *apiv2pb.CreateGroupRequest) (*apiv2pb.CreateGroupResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.Group == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "group is required")
+	}
+	if err := s.validateGroupHandle(ctx, request.Group.Handle, 0); err != nil {
+		return nil, err
+	}
+	if len(request.Group.Description) > maxGroupDescriptionLength {
+		return nil, status.Errorf(codes.InvalidArgument, "description is too long")
+	}
+
+	var group *store.UserGroup
+	if err := s.Store.RunInTx(ctx, func(txStore *store.Store) error {
+		group, err = txStore.CreateUserGroup(ctx, &store.UserGroup{
+			CreatorID:   user.ID,
+			Name:        request.Group.Handle,
+			Description: request.Group.Description,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = txStore.UpsertUserGroupMember(ctx, &store.UserGroupMember{
+			GroupID: group.ID,
+			UserID:  user.ID,
+			Role:    store.UserGroupRoleOwner,
+		})
+		return err
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create group: %v", err)
+	}
+	return &apiv2pb.CreateGroupResponse{
+		Group: convertGroupFromStore(group),
+	}, nil
+}
+
+func (s *APIV2Service) ListGroups(ctx context.Context, _ *apiv2pb.ListGroupsRequest) (*apiv2pb.ListGroupsResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	groupFind := &store.FindUserGroup{}
+	if user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		groupFind.MemberID = &user.ID
+	}
+	groups, err := s.Store.ListUserGroups(ctx, groupFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list groups: %v", err)
+	}
+	response := &apiv2pb.ListGroupsResponse{
+		Groups: []*apiv2pb.Group{},
+	}
+	for _, group := range groups {
+		response.Groups = append(response.Groups, convertGroupFromStore(group))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) GetGroup(ctx context.Context, request *apiv2pb.GetGroupRequest) (*apiv2pb.GetGroupResponse, error) {
+	_, group, _, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv2pb.GetGroupResponse{
+		Group: convertGroupFromStore(group),
+	}, nil
+}
+
+func (s *APIV2Service) UpdateGroup(ctx context.Context, request *apiv2pb.UpdateGroupRequest) (*apiv2pb.UpdateGroupResponse, error) {
+	if request.Group == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "group is required")
+	}
+	paths, err := getUpdateMaskPaths(request.UpdateMask, "handle", "description")
+	if err != nil {
+		return nil, err
+	}
+	user, group, member, err := s.getGroupWithMembership(ctx, request.Group.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageGroup(user, member) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	currentTs := time.Now().Unix()
+	update := &store.UpdateUserGroup{
+		ID:        group.ID,
+		UpdatedTs: &currentTs,
+	}
+	for _, path := range paths {
+		if path == "handle" {
+			if err := s.validateGroupHandle(ctx, request.Group.Handle, group.ID); err != nil {
+				return nil, err
+			}
+			update.Name = &request.Group.Handle
+		} else if path == "description" {
+			if len(request.Group.Description) > maxGroupDescriptionLength {
+				return nil, status.Errorf(codes.InvalidArgument, "description is too long")
+			}
+			update.Description = &request.Group.Description
+		}
+	}
+	group, err = s.Store.UpdateUserGroup(ctx, update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update group: %v", err)
+	}
+	return &apiv2pb.UpdateGroupResponse{
+		Group: convertGroupFromStore(group),
+	}, nil
+}
+
+func (s *APIV2Service) DeleteGroup(ctx context.Context, request *apiv2pb.DeleteGroupRequest) (*apiv2pb.DeleteGroupResponse, error) {
+	user, group, member, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageGroup(user, member) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if err := s.Store.DeleteUserGroup(ctx, &store.DeleteUserGroup{ID: group.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete group: %v", err)
+	}
+	return &apiv2pb.DeleteGroupResponse{}, nil
+}
+
+func (s *APIV2Service) ListGroupMembers(ctx context.Context, request *apiv2pb.ListGroupMembersRequest) (*apiv2pb.ListGroupMembersResponse, error) {
+	_, group, _, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.Store.ListUserGroupMembers(ctx, &store.FindUserGroupMember{GroupID: &group.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list group members: %v", err)
+	}
+	response := &apiv2pb.ListGroupMembersResponse{
+		Members: []*apiv2pb.GroupMember{},
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, convertGroupMemberFromStore(member))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) SetGroupMember(ctx context.Context, request *apiv2pb.SetGroupMemberRequest) (*apiv2pb.SetGroupMemberResponse, error) {
+	if request.Member == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "member is required")
+	}
+	user, group, member, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageGroup(user, member) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	userID, err := ExtractUserIDFromName(request.Member.User)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	memberUser, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if memberUser == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	role := convertGroupMemberRoleToStore(request.Member.Role)
+	if role != store.UserGroupRoleOwner {
+		if err := s.checkGroupKeepsOwner(ctx, group.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+	upserted, err := s.Store.UpsertUserGroupMember(ctx, &store.UserGroupMember{
+		GroupID: group.ID,
+		UserID:  userID,
+		Role:    role,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set group member: %v", err)
+	}
+	return &apiv2pb.SetGroupMemberResponse{
+		Member: convertGroupMemberFromStore(upserted),
+	}, nil
+}
+
+func (s *APIV2Service) RemoveGroupMember(ctx context.Context, request *apiv2pb.RemoveGroupMemberRequest) (*apiv2pb.RemoveGroupMemberResponse, error) {
+	user, group, member, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := ExtractUserIDFromName(request.User)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	// The members can leave the group by themselves.
+	if userID != user.ID && !canManageGroup(user, member) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if err := s.checkGroupKeepsOwner(ctx, group.ID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteUserGroupMember(ctx, &store.DeleteUserGroupMember{
+		GroupID: group.ID,
+		UserID:  userID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove group member: %v", err)
+	}
+	return &apiv2pb.RemoveGroupMemberResponse{}, nil
+}
+
+func (s *APIV2Service) ListGroupMemos(ctx context.Context, request *apiv2pb.ListGroupMemosRequest) (*apiv2pb.ListGroupMemosResponse, error) {
+	user, group, member, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	// The memos of a group are shared with its members only, not with the admins managing it.
+	if member == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	rowStatus := store.Normal
+	memoFind := &store.FindMemo{
+		GroupID:           &group.ID,
+		RowStatus:         &rowStatus,
+		ExcludeComments:   true,
+		ExcludeHidden:     true,
+		HiddenVisibleToID: &user.ID,
+	}
+	if request.Tag != "" {
+		// Narrow the memos down with the content, the tags are checked after parsing.
+		memoFind.ContentSearch = []string{"#" + request.Tag}
+	} else {
+		limitPlusOne := limit + 1
+		memoFind.Limit = &limitPlusOne
+		memoFind.Offset = &offset
+	}
+	memos, err := s.Store.ListMemos(ctx, memoFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	if request.Tag != "" {
+		tagged := []*store.Memo{}
+		for _, memo := range memos {
+			tags, err := getMemoContentTags(memo.Content)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to parse memo content: %v", err)
+			}
+			if slices.Contains(tags, request.Tag) {
+				tagged = append(tagged, memo)
+			}
+		}
+		memos = tagged[min(offset, len(tagged)):]
+	}
+
+	response := &apiv2pb.ListGroupMemosResponse{
+		Memos: []*apiv2pb.Memo{},
+	}
+	if len(memos) > limit {
+		memos = memos[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, memo := range memos {
+		memoMessage, err := s.convertMemoFromStore(ctx, memo)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert memo: %v", err)
+		}
+		response.Memos = append(response.Memos, memoMessage)
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) ListGroupTags(ctx context.Context, request *apiv2pb.ListGroupTagsRequest) (*apiv2pb.ListGroupTagsResponse, error) {
+	_, group, member, err := s.getGroupWithMembership(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	rowStatus := store.Normal
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		GroupID:         &group.ID,
+		RowStatus:       &rowStatus,
+		ExcludeComments: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	response := &apiv2pb.ListGroupTagsResponse{
+		TagAmounts: map[string]int32{},
+	}
+	for _, memo := range memos {
+		tags, err := getMemoContentTags(memo.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse memo content: %v", err)
+		}
+		for _, tag := range tags {
+			response.TagAmounts[tag]++
+		}
+	}
+	return response, nil
+}
+
+// getGroupWithMembership returns the current user, the group of the name and the membership of the user in it.
+// The groups are only found by their members and the admins, the membership is nil for the admins who aren't members.
+func (s *APIV2Service) getGroupWithMembership(ctx context.Context, name string) (*store.User, *store.UserGroup, *store.UserGroupMember, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, nil, nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	groupID, err := ExtractGroupIDFromName(name)
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.InvalidArgument, "invalid group name: %v", err)
+	}
+	group, err := s.Store.GetUserGroup(ctx, &store.FindUserGroup{ID: &groupID})
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "failed to get group: %v", err)
+	}
+	if group == nil {
+		return nil, nil, nil, status.Errorf(codes.NotFound, "group not found")
+	}
+	member, err := s.Store.GetUserGroupMember(ctx, group.ID, user.ID)
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "failed to get group member: %v", err)
+	}
+	if member == nil && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		return nil, nil, nil, status.Errorf(codes.NotFound, "group not found")
+	}
+	return user, group, member, nil
+}
+
+// getMemoGroupID returns the id of the group of the name to share a memo of the user with, the user must be a member of it.
+func (s *APIV2Service) getMemoGroupID(ctx context.Context, user *store.User, name string) (int32, error) {
+	if name == "" {
+		return 0, status.Errorf(codes.InvalidArgument, "group is required by the group visibility")
+	}
+	groupID, err := ExtractGroupIDFromName(name)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid group name: %v", err)
+	}
+	member, err := s.Store.GetUserGroupMember(ctx, groupID, user.ID)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get group member: %v", err)
+	}
+	if member == nil {
+		return 0, status.Errorf(codes.PermissionDenied, "not a member of the group")
+	}
+	return groupID, nil
+}
+
+// validateGroupHandle checks the handle of the group of the id, 0 for a new group.
+// The handles are mentioned like the usernames, so they must not be taken by a user either.
+func (s *APIV2Service) validateGroupHandle(ctx context.Context, handle string, groupID int32) error {
+	if !util.UIDMatcher.MatchString(handle) {
+		return status.Errorf(codes.InvalidArgument, "invalid group handle: %s", handle)
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{Username: &handle})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user != nil {
+		return status.Errorf(codes.AlreadyExists, "handle is taken by a user")
+	}
+	group, err := s.Store.GetUserGroup(ctx, &store.FindUserGroup{Name: &handle})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get group: %v", err)
+	}
+	if group != nil && group.ID != groupID {
+		return status.Errorf(codes.AlreadyExists, "handle is taken by another group")
+	}
+	return nil
+}
+
+// checkGroupKeepsOwner returns an error if the user is the last owner of the group, so the group can't lose all its owners.
+func (s *APIV2Service) checkGroupKeepsOwner(ctx context.Context, groupID, userID int32) error {
+	members, err := s.Store.ListUserGroupMembers(ctx, &store.FindUserGroupMember{GroupID: &groupID})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list group members: %v", err)
+	}
+	owners, isOwner := 0, false
+	for _, member := range members {
+		if member.Role == store.UserGroupRoleOwner {
+			owners++
+			isOwner = isOwner || member.UserID == userID
+		}
+	}
+	if isOwner && owners == 1 {
+		return status.Errorf(codes.FailedPrecondition, "the last owner of the group can't be removed")
+	}
+	return nil
+}
+
+func canManageGroup(user *store.User, member *store.UserGroupMember) bool {
+	if user.Role == store.RoleHost || user.Role == store.RoleAdmin {
+		return true
+	}
+	return member != nil && member.Role == store.UserGroupRoleOwner
+}
+
+func convertGroupFromStore(group *store.UserGroup) *apiv2pb.Group {
+	return &apiv2pb.Group{
+		Name:        fmt.Sprintf("%s%d", GroupNamePrefix, group.ID),
+		Handle:      group.Name,
+		Description: group.Description,
+		Creator:     fmt.Sprintf("%s%d", UserNamePrefix, group.CreatorID),
+		CreateTime:  timestamppb.New(time.Unix(group.CreatedTs, 0)),
+		UpdateTime:  timestamppb.New(time.Unix(group.UpdatedTs, 0)),
+	}
+}
+
+func convertGroupMemberFromStore(member *store.UserGroupMember) *apiv2pb.GroupMember {
+	return &apiv2pb.GroupMember{
+		User:       fmt.Sprintf("%s%d", UserNamePrefix, member.UserID),
+		Role:       convertGroupMemberRoleFromStore(member.Role),
+		CreateTime: timestamppb.New(time.Unix(member.CreatedTs, 0)),
+	}
+}
+
+func convertGroupMemberRoleFromStore(role store.UserGroupRole) apiv2pb.GroupMember_Role {
+	switch role {
+	case store.UserGroupRoleOwner:
+		return apiv2pb.GroupMember_OWNER
+	case store.UserGroupRoleMember:
+		return apiv2pb.GroupMember_MEMBER
+	default:
+		return apiv2pb.GroupMember_ROLE_UNSPECIFIED
+	}
+}
+
+func convertGroupMemberRoleToStore(role apiv2pb.GroupMember_Role) store.UserGroupRole {
+	switch role {
+	case apiv2pb.GroupMember_OWNER:
+		return store.UserGroupRoleOwner
+	default:
+		return store.UserGroupRoleMember
+	}
+}
@@ -0,0 +1,338 @@
+package v2
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/captcha"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxGuestCommentNameLength is the max number of the characters of the names of the guests.
+	maxGuestCommentNameLength = 64
+	// maxGuestCommentLength is the max number of the characters of a guest comment.
+	maxGuestCommentLength = 4096
+	// defaultMaxGuestCommentsPerHour is the max number of the comments from an IP address in an hour, if the setting doesn't set it.
+	defaultMaxGuestCommentsPerHour = 5
+)
+
+func (s *APIV2Service) CreateGuestComment(ctx context.Context, request *apiv2pb.CreateGuestCommentRequest) (*apiv2pb.CreateGuestCommentResponse, error) {
+	guestCommentSetting, err := s.getGuestCommentSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !guestCommentSetting.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "guest comments are disabled")
+	}
+	if request.Comment == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "comment is required")
+	}
+	authorName := strings.TrimSpace(request.Comment.AuthorName)
+	if authorName == "" || utf8.RuneCountInString(authorName) > maxGuestCommentNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "author name must be from 1 to %d characters", maxGuestCommentNameLength)
+	}
+	authorEmail := strings.TrimSpace(request.Comment.AuthorEmail)
+	if authorEmail != "" && !util.ValidateEmail(authorEmail) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid author email: %s", authorEmail)
+	}
+	content := strings.TrimSpace(request.Comment.Content)
+	if content == "" || utf8.RuneCountInString(content) > maxGuestCommentLength {
+		return nil, status.Errorf(codes.InvalidArgument, "content must be from 1 to %d characters", maxGuestCommentLength)
+	}
+	memo, err := s.getGuestCommentMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	clientIP := getClientIP(ctx)
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		clientIP = host
+	}
+	ipHash := s.hashGuestCommentIP(clientIP)
+	maxCommentsPerHour := int(guestCommentSetting.MaxCommentsPerHour)
+	if maxCommentsPerHour == 0 {
+		maxCommentsPerHour = defaultMaxGuestCommentsPerHour
+	}
+	createdTsAfter := time.Now().Add(-time.Hour).Unix()
+	recentComments, err := s.Store.ListGuestComments(ctx, &store.FindGuestComment{
+		IPHash:         &ipHash,
+		CreatedTsAfter: &createdTsAfter,
+		Limit:          &maxCommentsPerHour,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list guest comments: %v", err)
+	}
+	if len(recentComments) >= maxCommentsPerHour {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many comments, try again later")
+	}
+	if err := captcha.Verify(ctx, guestCommentSetting.CaptchaProvider, guestCommentSetting.CaptchaSecretKey, request.CaptchaResponse, clientIP); err != nil {
+		if errors.Is(err, captcha.ErrInvalidResponse) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid captcha response")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify captcha: %v", err)
+	}
+
+	guestComment, err := s.Store.CreateGuestComment(ctx, &store.GuestComment{
+		MemoID:  memo.ID,
+		Name:    authorName,
+		Email:   authorEmail,
+		Content: content,
+		Status:  store.GuestCommentPending,
+		IPHash:  ipHash,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create guest comment: %v", err)
+	}
+	return &apiv2pb.CreateGuestCommentResponse{
+		Comment: convertGuestCommentFromStore(guestComment, true),
+	}, nil
+}
+
+func (s *APIV2Service) ListMemoGuestComments(ctx context.Context, request *apiv2pb.ListMemoGuestCommentsRequest) (*apiv2pb.ListMemoGuestCommentsResponse, error) {
+	memo, err := s.getGuestCommentMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	isModerator := canModerateGuestComments(user, memo)
+	commentStatus := store.GuestCommentApproved
+	if request.Status != apiv2pb.GuestComment_STATUS_UNSPECIFIED {
+		commentStatus = convertGuestCommentStatusToStore(request.Status)
+	}
+	if commentStatus != store.GuestCommentApproved && !isModerator {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	guestComments, err := s.Store.ListGuestComments(ctx, &store.FindGuestComment{
+		MemoID: &memo.ID,
+		Status: &commentStatus,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list guest comments: %v", err)
+	}
+	response := &apiv2pb.ListMemoGuestCommentsResponse{
+		Comments: []*apiv2pb.GuestComment{},
+	}
+	for _, guestComment := range guestComments {
+		response.Comments = append(response.Comments, convertGuestCommentFromStore(guestComment, isModerator))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) ListGuestComments(ctx context.Context, request *apiv2pb.ListGuestCommentsRequest) (*apiv2pb.ListGuestCommentsResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	commentStatus := store.GuestCommentPending
+	if request.Status != apiv2pb.GuestComment_STATUS_UNSPECIFIED {
+		commentStatus = convertGuestCommentStatusToStore(request.Status)
+	}
+	guestCommentFind := &store.FindGuestComment{
+		MemoCreatorID: &user.ID,
+		Status:        &commentStatus,
+	}
+	if limit > 0 {
+		limitPlusOne := limit + 1
+		guestCommentFind.Limit = &limitPlusOne
+		guestCommentFind.Offset = &offset
+	}
+	guestComments, err := s.Store.ListGuestComments(ctx, guestCommentFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list guest comments: %v", err)
+	}
+
+	response := &apiv2pb.ListGuestCommentsResponse{
+		Comments: []*apiv2pb.GuestComment{},
+	}
+	if limit > 0 && len(guestComments) > limit {
+		guestComments = guestComments[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, guestComment := range guestComments {
+		response.Comments = append(response.Comments, convertGuestCommentFromStore(guestComment, true))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) ModerateGuestComment(ctx context.Context, request *apiv2pb.ModerateGuestCommentRequest) (*apiv2pb.ModerateGuestCommentResponse, error) {
+	guestComment, err := s.getModeratedGuestComment(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != apiv2pb.GuestComment_APPROVED && request.Status != apiv2pb.GuestComment_REJECTED {
+		return nil, status.Errorf(codes.InvalidArgument, "status must be APPROVED or REJECTED")
+	}
+	commentStatus := convertGuestCommentStatusToStore(request.Status)
+	updatedTs := time.Now().Unix()
+	guestComment, err = s.Store.UpdateGuestComment(ctx, &store.UpdateGuestComment{
+		ID:        guestComment.ID,
+		UpdatedTs: &updatedTs,
+		Status:    &commentStatus,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update guest comment: %v", err)
+	}
+	return &apiv2pb.ModerateGuestCommentResponse{
+		Comment: convertGuestCommentFromStore(guestComment, true),
+	}, nil
+}
+
+func (s *APIV2Service) DeleteGuestComment(ctx context.Context, request *apiv2pb.DeleteGuestCommentRequest) (*apiv2pb.DeleteGuestCommentResponse, error) {
+	guestComment, err := s.getModeratedGuestComment(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteGuestComment(ctx, &store.DeleteGuestComment{ID: guestComment.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete guest comment: %v", err)
+	}
+	return &apiv2pb.DeleteGuestCommentResponse{}, nil
+}
+
+func (s *APIV2Service) getGuestCommentSetting(ctx context.Context) (*storepb.GuestCommentSetting, error) {
+	workspaceIntegrationSetting, err := s.Store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace integration setting: %v", err)
+	}
+	if workspaceIntegrationSetting.GuestComment == nil {
+		return &storepb.GuestCommentSetting{}, nil
+	}
+	return workspaceIntegrationSetting.GuestComment, nil
+}
+
+// getGuestCommentMemo returns the memo of the name, which must be public so the guests can see it.
+func (s *APIV2Service) getGuestCommentMemo(ctx context.Context, name string) (*store.Memo, error) {
+	memoID, err := ExtractMemoIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	normalStatus := store.Normal
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		ID:             &memoID,
+		RowStatus:      &normalStatus,
+		VisibilityList: []store.Visibility{store.Public},
+		ExcludeHidden:  true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	return memo, nil
+}
+
+// getModeratedGuestComment returns the guest comment of the name, which the current user must be able to moderate.
+func (s *APIV2Service) getModeratedGuestComment(ctx context.Context, name string) (*store.GuestComment, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	guestCommentID, err := ExtractGuestCommentIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid guest comment name: %v", err)
+	}
+	guestComment, err := s.Store.GetGuestComment(ctx, &store.FindGuestComment{ID: &guestCommentID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get guest comment: %v", err)
+	}
+	if guestComment == nil {
+		return nil, status.Errorf(codes.NotFound, "guest comment not found")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &guestComment.MemoID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil || !canModerateGuestComments(user, memo) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return guestComment, nil
+}
+
+// hashGuestCommentIP returns the hash of the IP address, keyed by the secret of the instance so the addresses can't be
+// recovered by hashing all of them.
+func (s *APIV2Service) hashGuestCommentIP(ip string) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// canModerateGuestComments returns whether the user moderates the guest comments of the memo, as its creator or an admin.
+func canModerateGuestComments(user *store.User, memo *store.Memo) bool {
+	if user == nil {
+		return false
+	}
+	return user.ID == memo.CreatorID || user.Role == store.RoleHost || user.Role == store.RoleAdmin
+}
+
+// convertGuestCommentFromStore converts the guest comment, the email of the guest is only kept for the moderators.
+func convertGuestCommentFromStore(guestComment *store.GuestComment, includeEmail bool) *apiv2pb.GuestComment {
+	guestCommentMessage := &apiv2pb.GuestComment{
+		Name:       fmt.Sprintf("%s%d", GuestCommentNamePrefix, guestComment.ID),
+		Memo:       fmt.Sprintf("%s%d", MemoNamePrefix, guestComment.MemoID),
+		AuthorName: guestComment.Name,
+		Content:    guestComment.Content,
+		Status:     convertGuestCommentStatusFromStore(guestComment.Status),
+		CreateTime: timestamppb.New(time.Unix(guestComment.CreatedTs, 0)),
+	}
+	if includeEmail {
+		guestCommentMessage.AuthorEmail = guestComment.Email
+	}
+	return guestCommentMessage
+}
+
+func convertGuestCommentStatusFromStore(guestCommentStatus store.GuestCommentStatus) apiv2pb.GuestComment_Status {
+	switch guestCommentStatus {
+	case store.GuestCommentPending:
+		return apiv2pb.GuestComment_PENDING
+	case store.GuestCommentApproved:
+		return apiv2pb.GuestComment_APPROVED
+	case store.GuestCommentRejected:
+		return apiv2pb.GuestComment_REJECTED
+	default:
+		return apiv2pb.GuestComment_STATUS_UNSPECIFIED
+	}
+}
+
+func convertGuestCommentStatusToStore(guestCommentStatus apiv2pb.GuestComment_Status) store.GuestCommentStatus {
+	switch guestCommentStatus {
+	case apiv2pb.GuestComment_APPROVED:
+		return store.GuestCommentApproved
+	case apiv2pb.GuestComment_REJECTED:
+		return store.GuestCommentRejected
+	default:
+		return store.GuestCommentPending
+	}
+}
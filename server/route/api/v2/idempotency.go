@@ -0,0 +1,39 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/server/route/api/idempotency"
+	"github.com/usememos/memos/store"
+)
+
+// beginIdempotentCreate reserves the Idempotency-Key header, or the request id of the request, for the entity about to be created.
+// See idempotency.Begin for the returned key.
+func (s *APIV2Service) beginIdempotentCreate(ctx context.Context, userID int32, entityType, requestID string) (*store.IdempotencyKey, error) {
+	key := getIncomingHeader(ctx, idempotency.Header)
+	if key == "" {
+		key = requestID
+	}
+	idempotencyKey, err := idempotency.Begin(ctx, s.Store, userID, entityType, key)
+	if err != nil {
+		if errors.Is(err, idempotency.ErrInvalidKey) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid idempotency key")
+		}
+		if errors.Is(err, idempotency.ErrInProgress) {
+			return nil, status.Errorf(codes.Aborted, "a request with the same idempotency key is in progress")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to check idempotency key: %v", err)
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		if err := grpc.SetHeader(ctx, metadata.Pairs(idempotency.ReplayedHeader, "true")); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set idempotency header: %v", err)
+		}
+	}
+	return idempotencyKey, nil
+}
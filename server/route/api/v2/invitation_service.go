@@ -0,0 +1,156 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// invitationCodeLength is the length of the generated invitation codes.
+	invitationCodeLength = 16
+	// maxInvitationUses is the max number of the users signing up with an invitation.
+	maxInvitationUses = 1000
+)
+
+func (s *APIV2Service) CreateInvitation(ctx context.Context, request *apiv2pb.CreateInvitationRequest) (*apiv2pb.CreateInvitationResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.Invitation == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invitation is required")
+	}
+	isAdmin := user.Role == store.RoleHost || user.Role == store.RoleAdmin
+	if !isAdmin {
+		workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get workspace general setting: %v", err)
+		}
+		if !workspaceGeneralSetting.AllowUserInvitations {
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		}
+	}
+
+	role := store.RoleUser
+	switch request.Invitation.Role {
+	case apiv2pb.User_ROLE_UNSPECIFIED, apiv2pb.User_USER:
+	case apiv2pb.User_ADMIN:
+		// Only the host creates the admins, like CreateUser.
+		if user.Role != store.RoleHost {
+			return nil, status.Errorf(codes.PermissionDenied, "only the host can invite the admins")
+		}
+		role = store.RoleAdmin
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid invitation role: %s", request.Invitation.Role)
+	}
+	maxUses := request.Invitation.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if maxUses < 0 || maxUses > maxInvitationUses {
+		return nil, status.Errorf(codes.InvalidArgument, "max uses must be from 1 to %d", maxInvitationUses)
+	}
+	var expiresTs int64
+	if request.Invitation.ExpireTime != nil {
+		if !request.Invitation.ExpireTime.AsTime().After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "expire time must be in the future")
+		}
+		expiresTs = request.Invitation.ExpireTime.AsTime().Unix()
+	}
+
+	code, err := util.RandomString(invitationCodeLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate invitation code: %v", err)
+	}
+	invitation, err := s.Store.CreateInvitation(ctx, &store.Invitation{
+		CreatorID: user.ID,
+		Code:      code,
+		Role:      role,
+		MaxUses:   maxUses,
+		ExpiresTs: expiresTs,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create invitation: %v", err)
+	}
+	return &apiv2pb.CreateInvitationResponse{
+		Invitation: convertInvitationFromStore(invitation),
+	}, nil
+}
+
+func (s *APIV2Service) ListInvitations(ctx context.Context, _ *apiv2pb.ListInvitationsRequest) (*apiv2pb.ListInvitationsResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	invitationFind := &store.FindInvitation{}
+	if user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+		invitationFind.CreatorID = &user.ID
+	}
+	invitations, err := s.Store.ListInvitations(ctx, invitationFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list invitations: %v", err)
+	}
+	response := &apiv2pb.ListInvitationsResponse{
+		Invitations: []*apiv2pb.Invitation{},
+	}
+	for _, invitation := range invitations {
+		response.Invitations = append(response.Invitations, convertInvitationFromStore(invitation))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) DeleteInvitation(ctx context.Context, request *apiv2pb.DeleteInvitationRequest) (*apiv2pb.DeleteInvitationResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	id, err := ExtractInvitationIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid invitation name: %v", err)
+	}
+	invitation, err := s.Store.GetInvitation(ctx, &store.FindInvitation{ID: &id})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get invitation: %v", err)
+	}
+	if invitation == nil || (invitation.CreatorID != user.ID && user.Role != store.RoleHost && user.Role != store.RoleAdmin) {
+		return nil, status.Errorf(codes.NotFound, "invitation not found")
+	}
+	if err := s.Store.DeleteInvitation(ctx, &store.DeleteInvitation{ID: invitation.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete invitation: %v", err)
+	}
+	return &apiv2pb.DeleteInvitationResponse{}, nil
+}
+
+func convertInvitationFromStore(invitation *store.Invitation) *apiv2pb.Invitation {
+	invitationMessage := &apiv2pb.Invitation{
+		Name:       fmt.Sprintf("%s%d", InvitationNamePrefix, invitation.ID),
+		Code:       invitation.Code,
+		Role:       convertUserRoleFromStore(invitation.Role),
+		MaxUses:    invitation.MaxUses,
+		UseCount:   invitation.UseCount,
+		Creator:    fmt.Sprintf("%s%d", UserNamePrefix, invitation.CreatorID),
+		CreateTime: timestamppb.New(time.Unix(invitation.CreatedTs, 0)),
+	}
+	if invitation.ExpiresTs != 0 {
+		invitationMessage.ExpireTime = timestamppb.New(time.Unix(invitation.ExpiresTs, 0))
+	}
+	return invitationMessage
+}
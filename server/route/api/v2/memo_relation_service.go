@@ -16,6 +16,12 @@ func (s *APIV2Service) SetMemoRelations(ctx context.Context, request *apiv2pb.Se
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
+	// Validate the relation types before changing any relations.
+	for _, relation := range request.Relations {
+		if !convertMemoRelationTypeToStore(relation.Type, relation.CustomType).IsValid() && relation.Type != apiv2pb.MemoRelation_COMMENT {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid custom relation type: %s", relation.CustomType)
+		}
+	}
 	referenceType := store.MemoRelationReference
 	// Delete all reference relations first.
 	if err := s.Store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{
@@ -42,7 +48,7 @@ func (s *APIV2Service) SetMemoRelations(ctx context.Context, request *apiv2pb.Se
 		if _, err := s.Store.UpsertMemoRelation(ctx, &store.MemoRelation{
 			MemoID:        id,
 			RelatedMemoID: relatedMemoID,
-			Type:          convertMemoRelationTypeToStore(relation.Type),
+			Type:          convertMemoRelationTypeToStore(relation.Type, relation.CustomType),
 		}); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to upsert memo relation")
 		}
@@ -56,24 +62,43 @@ func (s *APIV2Service) ListMemoRelations(ctx context.Context, request *apiv2pb.L
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
+	// All custom relations are listed if the custom type is empty.
+	listCustom := request.Type == apiv2pb.MemoRelation_CUSTOM && request.CustomType == ""
+	var relationType *store.MemoRelationType
+	if request.Type != apiv2pb.MemoRelation_TYPE_UNSPECIFIED && !listCustom {
+		t := convertMemoRelationTypeToStore(request.Type, request.CustomType)
+		if !t.IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid custom relation type: %s", request.CustomType)
+		}
+		relationType = &t
+	}
+	match := func(relation *store.MemoRelation) bool {
+		return !listCustom || relation.Type.IsCustom()
+	}
 	relationList := []*apiv2pb.MemoRelation{}
 	tempList, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
 		MemoID: &id,
+		Type:   relationType,
 	})
 	if err != nil {
 		return nil, err
 	}
 	for _, relation := range tempList {
-		relationList = append(relationList, convertMemoRelationFromStore(relation))
+		if match(relation) {
+			relationList = append(relationList, convertMemoRelationFromStore(relation))
+		}
 	}
 	tempList, err = s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
 		RelatedMemoID: &id,
+		Type:          relationType,
 	})
 	if err != nil {
 		return nil, err
 	}
 	for _, relation := range tempList {
-		relationList = append(relationList, convertMemoRelationFromStore(relation))
+		if match(relation) {
+			relationList = append(relationList, convertMemoRelationFromStore(relation))
+		}
 	}
 
 	response := &apiv2pb.ListMemoRelationsResponse{
@@ -82,12 +107,60 @@ func (s *APIV2Service) ListMemoRelations(ctx context.Context, request *apiv2pb.L
 	return response, nil
 }
 
+func (s *APIV2Service) DeleteMemoRelation(ctx context.Context, request *apiv2pb.DeleteMemoRelationRequest) (*apiv2pb.DeleteMemoRelationResponse, error) {
+	id, err := ExtractMemoIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	relatedMemoID, err := ExtractMemoIDFromName(request.RelatedMemo)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid related memo name: %v", err)
+	}
+	if request.Type == apiv2pb.MemoRelation_TYPE_UNSPECIFIED || request.Type == apiv2pb.MemoRelation_COMMENT {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid relation type: %s", request.Type)
+	}
+	relationType := convertMemoRelationTypeToStore(request.Type, request.CustomType)
+	if !relationType.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid custom relation type: %s", request.CustomType)
+	}
+
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if memo.CreatorID != user.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	if err := s.Store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{
+		MemoID:        &id,
+		RelatedMemoID: &relatedMemoID,
+		Type:          &relationType,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete memo relation")
+	}
+	return &apiv2pb.DeleteMemoRelationResponse{}, nil
+}
+
 func convertMemoRelationFromStore(memoRelation *store.MemoRelation) *apiv2pb.MemoRelation {
-	return &apiv2pb.MemoRelation{
+	relation := &apiv2pb.MemoRelation{
 		Memo:        fmt.Sprintf("%s%d", MemoNamePrefix, memoRelation.MemoID),
 		RelatedMemo: fmt.Sprintf("%s%d", MemoNamePrefix, memoRelation.RelatedMemoID),
 		Type:        convertMemoRelationTypeFromStore(memoRelation.Type),
 	}
+	if relation.Type == apiv2pb.MemoRelation_CUSTOM {
+		relation.CustomType = string(memoRelation.Type)
+	}
+	return relation
 }
 
 func convertMemoRelationTypeFromStore(relationType store.MemoRelationType) apiv2pb.MemoRelation_Type {
@@ -97,16 +170,22 @@ func convertMemoRelationTypeFromStore(relationType store.MemoRelationType) apiv2
 	case store.MemoRelationComment:
 		return apiv2pb.MemoRelation_COMMENT
 	default:
+		if relationType.IsCustom() {
+			return apiv2pb.MemoRelation_CUSTOM
+		}
 		return apiv2pb.MemoRelation_TYPE_UNSPECIFIED
 	}
 }
 
-func convertMemoRelationTypeToStore(relationType apiv2pb.MemoRelation_Type) store.MemoRelationType {
+// convertMemoRelationTypeToStore returns the store type of the relation, which is the custom type for CUSTOM relations.
+func convertMemoRelationTypeToStore(relationType apiv2pb.MemoRelation_Type, customType string) store.MemoRelationType {
 	switch relationType {
 	case apiv2pb.MemoRelation_REFERENCE:
 		return store.MemoRelationReference
 	case apiv2pb.MemoRelation_COMMENT:
 		return store.MemoRelationComment
+	case apiv2pb.MemoRelation_CUSTOM:
+		return store.MemoRelationType(customType)
 	default:
 		return store.MemoRelationReference
 	}
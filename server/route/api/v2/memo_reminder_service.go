@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+// reminderDateHour is the hour of the day the reminders of a date are delivered at, in the timezone of the user.
+const reminderDateHour = 9
+
+func (s *APIV2Service) SetMemoReminder(ctx context.Context, request *apiv2pb.SetMemoReminderRequest) (*apiv2pb.SetMemoReminderResponse, error) {
+	user, memo, err := s.getReminderMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	var remindTime time.Time
+	if request.RemindDate != "" {
+		location, err := s.Store.GetUserLocation(ctx, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+		}
+		date, err := time.ParseInLocation(time.DateOnly, request.RemindDate, location)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid remind date %q", request.RemindDate)
+		}
+		remindTime = time.Date(date.Year(), date.Month(), date.Day(), reminderDateHour, 0, 0, 0, location)
+	} else if request.RemindTime != nil {
+		remindTime = request.RemindTime.AsTime()
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "remind time is required")
+	}
+	if !remindTime.After(time.Now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "remind time must be in the future")
+	}
+
+	reminder, err := s.Store.UpsertMemoReminder(ctx, &store.MemoReminder{
+		CreatorID: user.ID,
+		MemoID:    memo.ID,
+		RemindTs:  remindTime.Unix(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert memo reminder: %v", err)
+	}
+	return &apiv2pb.SetMemoReminderResponse{
+		Reminder: convertMemoReminderFromStore(reminder),
+	}, nil
+}
+
+func (s *APIV2Service) GetMemoReminder(ctx context.Context, request *apiv2pb.GetMemoReminderRequest) (*apiv2pb.GetMemoReminderResponse, error) {
+	user, memo, err := s.getReminderMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	reminder, err := s.Store.GetMemoReminder(ctx, &store.FindMemoReminder{
+		CreatorID: &user.ID,
+		MemoID:    &memo.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo reminder: %v", err)
+	}
+	if reminder == nil {
+		return nil, status.Errorf(codes.NotFound, "memo reminder not found")
+	}
+	return &apiv2pb.GetMemoReminderResponse{
+		Reminder: convertMemoReminderFromStore(reminder),
+	}, nil
+}
+
+func (s *APIV2Service) DeleteMemoReminder(ctx context.Context, request *apiv2pb.DeleteMemoReminderRequest) (*apiv2pb.DeleteMemoReminderResponse, error) {
+	user, memo, err := s.getReminderMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteMemoReminder(ctx, &store.DeleteMemoReminder{
+		CreatorID: user.ID,
+		MemoID:    memo.ID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete memo reminder: %v", err)
+	}
+	return &apiv2pb.DeleteMemoReminderResponse{}, nil
+}
+
+// getReminderMemo returns the current user and the memo of the name, the users can only be reminded of the memos they can see.
+func (s *APIV2Service) getReminderMemo(ctx context.Context, name string) (*store.User, *store.Memo, error) {
+	id, err := ExtractMemoIDFromName(name)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &id})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if visible, err := s.Store.IsMemoVisibleTo(ctx, memo, user.ID); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to check memo visibility: %v", err)
+	} else if !visible {
+		return nil, nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	return user, memo, nil
+}
+
+func convertMemoReminderFromStore(reminder *store.MemoReminder) *apiv2pb.MemoReminder {
+	reminderMessage := &apiv2pb.MemoReminder{
+		Memo:       fmt.Sprintf("%s%d", MemoNamePrefix, reminder.MemoID),
+		RemindTime: timestamppb.New(time.Unix(reminder.RemindTs, 0)),
+	}
+	if reminder.DeliveredTs != 0 {
+		reminderMessage.DeliverTime = timestamppb.New(time.Unix(reminder.DeliveredTs, 0))
+	}
+	return reminderMessage
+}
@@ -3,10 +3,14 @@ package v2
 import (
 	"archive/zip"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/cel-go/cel"
@@ -17,11 +21,16 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/plugin/webhook"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/route/api/etag"
+	"github.com/usememos/memos/server/route/api/idempotency"
 	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
+	webhookdispatcher "github.com/usememos/memos/server/service/webhook_dispatcher"
 	"github.com/usememos/memos/store"
 )
 
@@ -29,8 +38,13 @@ const (
 	DefaultPageSize  = 10
 	MaxContentLength = 8 * 1024
 	ChunkSize        = 64 * 1024 // 64 KiB
+	// maxMemoSlugLength is the maximum length of the slugs of the memos.
+	maxMemoSlugLength = 64
 )
 
+// memoSlugPattern matches the slugs of the memos, the lowercase words separated by hyphens, e.g. `my-first-memo`.
+var memoSlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
 func (s *APIV2Service) CreateMemo(ctx context.Context, request *apiv2pb.CreateMemoRequest) (*apiv2pb.CreateMemoResponse, error) {
 	user, err := getCurrentUser(ctx, s.Store)
 	if err != nil {
@@ -43,12 +57,43 @@ func (s *APIV2Service) CreateMemo(ctx context.Context, request *apiv2pb.CreateMe
 		return nil, status.Errorf(codes.InvalidArgument, "content too long")
 	}
 
+	idempotencyKey, err := s.beginIdempotentCreate(ctx, user.ID, "memo", request.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &idempotencyKey.EntityID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+		}
+		if memo == nil {
+			return nil, status.Errorf(codes.NotFound, "memo not found")
+		}
+		memoMessage, err := s.convertMemoFromStore(ctx, memo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert memo")
+		}
+		return &apiv2pb.CreateMemoResponse{
+			Memo: memoMessage,
+		}, nil
+	}
+	defer idempotency.Release(ctx, s.Store, idempotencyKey)
+
 	create := &store.Memo{
 		UID:        shortuuid.New(),
 		CreatorID:  user.ID,
 		Content:    request.Content,
 		Visibility: convertVisibilityToStore(request.Visibility),
 	}
+	if create.Visibility == store.Group {
+		groupID, err := s.getMemoGroupID(ctx, user, request.Group)
+		if err != nil {
+			return nil, err
+		}
+		create.GroupID = &groupID
+	} else if request.Group != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group is only allowed with the group visibility")
+	}
 	// Find disable public memos system setting.
 	disablePublicMemosSystem, err := s.getDisablePublicMemosSystemSettingValue(ctx)
 	if err != nil {
@@ -60,8 +105,20 @@ func (s *APIV2Service) CreateMemo(ctx context.Context, request *apiv2pb.CreateMe
 
 	memo, err := s.Store.CreateMemo(ctx, create)
 	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
+		}
+		if rejectedErr := getHookRejectedStatus(err); rejectedErr != nil {
+			return nil, rejectedErr
+		}
 		return nil, err
 	}
+	if err := idempotency.Complete(ctx, s.Store, idempotencyKey, memo.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to complete idempotency key: %v", err)
+	}
+	s.createActivity(ctx, memo.CreatorID, store.ActivityTypeMemoCreate, &storepb.ActivityPayload{
+		MemoCreate: &storepb.ActivityMemoCreatePayload{MemoId: memo.ID},
+	})
 
 	memoMessage, err := s.convertMemoFromStore(ctx, memo)
 	if err != nil {
@@ -71,6 +128,7 @@ func (s *APIV2Service) CreateMemo(ctx context.Context, request *apiv2pb.CreateMe
 	if err := s.DispatchMemoCreatedWebhook(ctx, memoMessage); err != nil {
 		slog.Warn("Failed to dispatch memo created webhook", err)
 	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoCreated, memo))
 
 	response := &apiv2pb.CreateMemoResponse{
 		Memo: memoMessage,
@@ -86,6 +144,9 @@ func (s *APIV2Service) ListMemos(ctx context.Context, request *apiv2pb.ListMemos
 	if err := s.buildMemoFindWithFilter(ctx, memoFind, request.Filter); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "failed to build find memos with filter")
 	}
+	if err := setMemoFindOrderBy(memoFind, request.OrderBy); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order by: %v", err)
+	}
 
 	var limit, offset int
 	if request.PageToken != "" {
@@ -165,6 +226,104 @@ func (s *APIV2Service) SearchMemos(ctx context.Context, request *apiv2pb.SearchM
 	return response, nil
 }
 
+func (s *APIV2Service) ResurfaceMemos(ctx context.Context, request *apiv2pb.ResurfaceMemosRequest) (*apiv2pb.ResurfaceMemosResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	location, err := s.getLocation(ctx, request.Timezone, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	onThisDay := request.Mode == apiv2pb.ResurfaceMemosRequest_ON_THIS_DAY
+	limit := int(request.Limit)
+	if limit <= 0 {
+		limit = 1
+		if onThisDay {
+			limit = 10
+		}
+	}
+	if limit > DefaultPageSize*10 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be at most %d", DefaultPageSize*10)
+	}
+
+	rowStatus := store.Normal
+	memoFind := &store.FindMemo{
+		CreatorID:       &user.ID,
+		RowStatus:       &rowStatus,
+		ExcludeComments: true,
+	}
+	for _, visibility := range request.Visibilities {
+		memoFind.VisibilityList = append(memoFind.VisibilityList, convertVisibilityToStore(visibility))
+	}
+	for _, tag := range request.Tags {
+		// Narrow the memos down with the content, the tags are checked after parsing.
+		memoFind.ContentSearch = append(memoFind.ContentSearch, "#"+tag)
+	}
+
+	now := time.Now().In(location)
+	memos := []*store.Memo{}
+	if onThisDay {
+		startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location).Unix()
+		memoFind.CreatedTsBefore = &startOfToday
+		list, err := s.Store.ListMemos(ctx, memoFind)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list memos")
+		}
+		for _, memo := range list {
+			createdTime := time.Unix(memo.CreatedTs, 0).In(location)
+			if createdTime.Month() == now.Month() && createdTime.Day() == now.Day() {
+				memos = append(memos, memo)
+			}
+		}
+	} else {
+		minAgeDays := int(request.MinAgeDays)
+		if minAgeDays <= 0 {
+			minAgeDays = 30
+		}
+		createdTsBefore := now.AddDate(0, 0, -minAgeDays).Unix()
+		memoFind.CreatedTsBefore = &createdTsBefore
+		memoFind.Random = true
+		// Take more random memos in case some of them don't have the tags.
+		candidateLimit := limit
+		if len(request.Tags) > 0 {
+			candidateLimit = limit * 5
+		}
+		memoFind.Limit = &candidateLimit
+		memos, err = s.Store.ListMemos(ctx, memoFind)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list memos")
+		}
+	}
+
+	memoMessages := []*apiv2pb.Memo{}
+	for _, memo := range memos {
+		if len(memoMessages) == limit {
+			break
+		}
+		tags, err := getMemoContentTags(memo.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse memo content: %v", err)
+		}
+		if !containsAllTags(tags, request.Tags) {
+			continue
+		}
+		memoMessage, err := s.convertMemoFromStore(ctx, memo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert memo")
+		}
+		memoMessages = append(memoMessages, memoMessage)
+	}
+
+	response := &apiv2pb.ResurfaceMemosResponse{
+		Memos: memoMessages,
+	}
+	return response, nil
+}
+
 func (s *APIV2Service) GetMemo(ctx context.Context, request *apiv2pb.GetMemoRequest) (*apiv2pb.GetMemoResponse, error) {
 	id, err := ExtractMemoIDFromName(request.Name)
 	if err != nil {
@@ -187,10 +346,17 @@ func (s *APIV2Service) GetMemo(ctx context.Context, request *apiv2pb.GetMemoRequ
 		if user == nil {
 			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		}
-		if memo.Visibility == store.Private && memo.CreatorID != user.ID {
+		visible, err := s.Store.IsMemoVisibleTo(ctx, memo, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check memo visibility: %v", err)
+		}
+		if !visible {
 			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		}
 	}
+	if checkNotModified(ctx, etag.New("memo", memo.ID, memo.UpdatedTs, ""), memo.UpdatedTs) {
+		return &apiv2pb.GetMemoResponse{}, nil
+	}
 
 	memoMessage, err := s.convertMemoFromStore(ctx, memo)
 	if err != nil {
@@ -207,8 +373,10 @@ func (s *APIV2Service) UpdateMemo(ctx context.Context, request *apiv2pb.UpdateMe
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
-	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
+	// created_ts is the former name of create_time.
+	paths, err := getUpdateMaskPaths(request.UpdateMask, "content", "uid", "visibility", "row_status", "create_time", "created_ts", "pinned", "group", "slug")
+	if err != nil {
+		return nil, err
 	}
 
 	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &id})
@@ -224,19 +392,21 @@ func (s *APIV2Service) UpdateMemo(ctx context.Context, request *apiv2pb.UpdateMe
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	currentTs := time.Now().Unix()
 	update := &store.UpdateMemo{
-		ID:        id,
-		UpdatedTs: &currentTs,
+		ID: id,
 	}
-	for _, path := range request.UpdateMask.Paths {
+	updatePinned := false
+	for _, path := range paths {
 		if path == "content" {
+			if len(request.Memo.Content) > MaxContentLength {
+				return nil, status.Errorf(codes.InvalidArgument, "content too long")
+			}
 			update.Content = &request.Memo.Content
 		} else if path == "uid" {
-			update.UID = &request.Memo.Name
-			if !util.UIDMatcher.MatchString(*update.UID) {
-				return nil, status.Errorf(codes.InvalidArgument, "invalid resource name")
+			if !util.UIDMatcher.MatchString(request.Memo.Uid) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid memo uid")
 			}
+			update.UID = &request.Memo.Uid
 		} else if path == "visibility" {
 			visibility := convertVisibilityToStore(request.Memo.Visibility)
 			// Find disable public memos system setting.
@@ -248,36 +418,94 @@ func (s *APIV2Service) UpdateMemo(ctx context.Context, request *apiv2pb.UpdateMe
 				return nil, status.Errorf(codes.PermissionDenied, "disable public memos system setting is enabled")
 			}
 			update.Visibility = &visibility
+			if visibility != store.Group {
+				// The memo isn't shared with its group any more.
+				clearGroupID := int32(0)
+				update.GroupID = &clearGroupID
+			}
 		} else if path == "row_status" {
 			rowStatus := convertRowStatusToStore(request.Memo.RowStatus)
 			update.RowStatus = &rowStatus
-		} else if path == "created_ts" {
+		} else if path == "create_time" || path == "created_ts" {
+			if request.Memo.CreateTime == nil {
+				return nil, status.Errorf(codes.InvalidArgument, "create time is required")
+			}
 			createdTs := request.Memo.CreateTime.AsTime().Unix()
 			update.CreatedTs = &createdTs
 		} else if path == "pinned" {
-			if _, err := s.Store.UpsertMemoOrganizer(ctx, &store.MemoOrganizer{
-				MemoID: id,
-				UserID: user.ID,
-				Pinned: request.Memo.Pinned,
-			}); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to upsert memo organizer")
+			updatePinned = true
+		} else if path == "slug" {
+			if request.Memo.Slug != "" && (len(request.Memo.Slug) > maxMemoSlugLength || !memoSlugPattern.MatchString(request.Memo.Slug)) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid memo slug")
+			}
+			if request.Memo.Slug != "" {
+				memoSlug, err := s.Store.GetMemoSlug(ctx, &store.FindMemoSlug{Slug: &request.Memo.Slug, CreatorID: &memo.CreatorID})
+				if err != nil {
+					return nil, status.Errorf(codes.Internal, "failed to get memo slug: %v", err)
+				}
+				if memoSlug != nil && memoSlug.MemoID != memo.ID {
+					return nil, status.Errorf(codes.AlreadyExists, "memo slug already exists")
+				}
 			}
 		}
 	}
-	if update.Content != nil && len(*update.Content) > MaxContentLength {
-		return nil, status.Errorf(codes.InvalidArgument, "content too long")
+	// The memos of the group visibility are shared with a group the creator is a member of.
+	visibility := memo.Visibility
+	if update.Visibility != nil {
+		visibility = *update.Visibility
+	}
+	if slices.Contains(paths, "group") {
+		if visibility != store.Group {
+			return nil, status.Errorf(codes.InvalidArgument, "group is only allowed with the group visibility")
+		}
+		groupID, err := s.getMemoGroupID(ctx, user, request.Memo.Group)
+		if err != nil {
+			return nil, err
+		}
+		update.GroupID = &groupID
+	} else if visibility == store.Group && memo.Visibility != store.Group {
+		return nil, status.Errorf(codes.InvalidArgument, "group is required by the group visibility")
+	}
+
+	// The pinned state belongs to the user's organizer and the slug is kept apart, so neither updates the memo itself.
+	if slices.ContainsFunc(paths, func(path string) bool { return path != "pinned" && path != "slug" }) {
+		currentTs := time.Now().Unix()
+		update.UpdatedTs = &currentTs
+		if err = s.Store.UpdateMemo(ctx, update); err != nil {
+			if rejectedErr := getHookRejectedStatus(err); rejectedErr != nil {
+				return nil, rejectedErr
+			}
+			return nil, status.Errorf(codes.Internal, "failed to update memo")
+		}
+	}
+	if updatePinned {
+		if _, err := s.Store.UpsertMemoOrganizer(ctx, &store.MemoOrganizer{
+			MemoID: id,
+			UserID: user.ID,
+			Pinned: request.Memo.Pinned,
+		}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to upsert memo organizer")
+		}
 	}
 
-	if err = s.Store.UpdateMemo(ctx, update); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update memo")
+	if slices.Contains(paths, "slug") {
+		if err := s.updateMemoSlug(ctx, memo, request.Memo.Slug); err != nil {
+			return nil, err
+		}
 	}
 
+	previousVisibility := memo.Visibility
 	memo, err = s.Store.GetMemo(ctx, &store.FindMemo{
 		ID: &id,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get memo")
 	}
+	if previousVisibility == store.Private && memo.Visibility != store.Private {
+		s.createActivity(ctx, user.ID, store.ActivityTypeMemoShare, &storepb.ActivityPayload{
+			MemoShare: &storepb.ActivityMemoSharePayload{MemoId: memo.ID, Visibility: memo.Visibility.String()},
+		})
+	}
 	memoMessage, err := s.convertMemoFromStore(ctx, memo)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to convert memo")
@@ -286,6 +514,7 @@ func (s *APIV2Service) UpdateMemo(ctx context.Context, request *apiv2pb.UpdateMe
 	if err := s.DispatchMemoUpdatedWebhook(ctx, memoMessage); err != nil {
 		slog.Warn("Failed to dispatch memo updated webhook", err)
 	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoUpdated, memo))
 
 	return &apiv2pb.UpdateMemoResponse{
 		Memo: memoMessage,
@@ -322,6 +551,7 @@ func (s *APIV2Service) DeleteMemo(ctx context.Context, request *apiv2pb.DeleteMe
 	if err = s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: id}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete memo")
 	}
+	s.eventBroker.Publish(event.NewMemoEvent(event.MemoDeleted, memo))
 
 	return &apiv2pb.DeleteMemoResponse{}, nil
 }
@@ -375,7 +605,7 @@ func (s *APIV2Service) CreateMemoComment(ctx context.Context, request *apiv2pb.C
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to create activity")
 		}
-		if _, err := s.Store.CreateInbox(ctx, &store.Inbox{
+		inbox, err := s.Store.CreateInbox(ctx, &store.Inbox{
 			SenderID:   creatorID,
 			ReceiverID: relatedMemo.CreatorID,
 			Status:     store.UNREAD,
@@ -383,9 +613,19 @@ func (s *APIV2Service) CreateMemoComment(ctx context.Context, request *apiv2pb.C
 				Type:       storepb.InboxMessage_TYPE_MEMO_COMMENT,
 				ActivityId: &activity.ID,
 			},
-		}); err != nil {
+		})
+		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to create inbox")
 		}
+		s.eventBroker.Publish(event.NewInboxEvent(inbox))
+	}
+	if memo.Visibility != apiv2pb.Visibility_PRIVATE {
+		comment := &store.Memo{
+			ID:         memoID,
+			CreatorID:  creatorID,
+			Visibility: convertVisibilityToStore(memo.Visibility),
+		}
+		s.eventBroker.Publish(event.NewMemoCommentEvent(comment, relatedMemo))
 	}
 
 	response := &apiv2pb.CreateMemoCommentResponse{
@@ -408,6 +648,27 @@ func (s *APIV2Service) ListMemoComments(ctx context.Context, request *apiv2pb.Li
 		return nil, status.Errorf(codes.Internal, "failed to list memo relations")
 	}
 
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	// Comments are few per memo, so they're paginated in memory.
+	slices.SortFunc(memoRelations, func(a, b *store.MemoRelation) int {
+		return cmp.Compare(a.MemoID, b.MemoID)
+	})
+	nextPageToken := ""
+	if offset > len(memoRelations) {
+		offset = len(memoRelations)
+	}
+	memoRelations = memoRelations[offset:]
+	if limit > 0 && len(memoRelations) > limit {
+		memoRelations = memoRelations[:limit]
+		nextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+
 	var memos []*apiv2pb.Memo
 	for _, memoRelation := range memoRelations {
 		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
@@ -426,7 +687,8 @@ func (s *APIV2Service) ListMemoComments(ctx context.Context, request *apiv2pb.Li
 	}
 
 	response := &apiv2pb.ListMemoCommentsResponse{
-		Memos: memos,
+		Memos:         memos,
+		NextPageToken: nextPageToken,
 	}
 	return response, nil
 }
@@ -462,9 +724,10 @@ func (s *APIV2Service) GetUserMemosStats(ctx context.Context, request *apiv2pb.G
 		return nil, status.Errorf(codes.Internal, "failed to list memos")
 	}
 
-	location, err := time.LoadLocation(request.Timezone)
+	// The days of the stats are the days of the user.
+	location, err := s.getLocation(ctx, request.Timezone, user.ID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid timezone location")
+		return nil, err
 	}
 
 	displayWithUpdatedTs, err := s.getMemoDisplayWithUpdatedTsSettingValue(ctx)
@@ -554,24 +817,80 @@ func (s *APIV2Service) convertMemoFromStore(ctx context.Context, memo *store.Mem
 		return nil, errors.Wrap(err, "failed to list memo reactions")
 	}
 
+	memoProperties, err := s.Store.ListMemoProperties(ctx, &store.FindMemoProperty{MemoID: &memo.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo properties")
+	}
+	properties := map[string]string{}
+	for _, memoProperty := range memoProperties {
+		properties[memoProperty.Name] = memoProperty.Value
+	}
+	group := ""
+	if memo.GroupID != nil {
+		group = fmt.Sprintf("%s%d", GroupNamePrefix, *memo.GroupID)
+	}
+	memoSlug, err := s.Store.GetMemoSlug(ctx, &store.FindMemoSlug{MemoID: &memo.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get memo slug")
+	}
+	if memoSlug == nil {
+		memoSlug = &store.MemoSlug{}
+	}
+
 	return &apiv2pb.Memo{
-		Name:        name,
-		Uid:         memo.UID,
-		RowStatus:   convertRowStatusFromStore(memo.RowStatus),
-		Creator:     fmt.Sprintf("%s%d", UserNamePrefix, creator.ID),
-		CreateTime:  timestamppb.New(time.Unix(memo.CreatedTs, 0)),
-		UpdateTime:  timestamppb.New(time.Unix(memo.UpdatedTs, 0)),
-		DisplayTime: timestamppb.New(time.Unix(displayTs, 0)),
-		Content:     memo.Content,
-		Visibility:  convertVisibilityFromStore(memo.Visibility),
-		Pinned:      memo.Pinned,
-		ParentId:    memo.ParentID,
-		Relations:   listMemoRelationsResponse.Relations,
-		Resources:   listMemoResourcesResponse.Resources,
-		Reactions:   listMemoReactionsResponse.Reactions,
+		Name:           name,
+		Uid:            memo.UID,
+		RowStatus:      convertRowStatusFromStore(memo.RowStatus),
+		Creator:        fmt.Sprintf("%s%d", UserNamePrefix, creator.ID),
+		CreateTime:     timestamppb.New(time.Unix(memo.CreatedTs, 0)),
+		UpdateTime:     timestamppb.New(time.Unix(memo.UpdatedTs, 0)),
+		DisplayTime:    timestamppb.New(time.Unix(displayTs, 0)),
+		Content:        memo.Content,
+		Visibility:     convertVisibilityFromStore(memo.Visibility),
+		Pinned:         memo.Pinned,
+		ParentId:       memo.ParentID,
+		Relations:      listMemoRelationsResponse.Relations,
+		Resources:      listMemoResourcesResponse.Resources,
+		Reactions:      listMemoReactionsResponse.Reactions,
+		Properties:     properties,
+		Group:          group,
+		Slug:           memoSlug.Slug,
+		SlugClickCount: memoSlug.ClickCount,
 	}, nil
 }
 
+// updateMemoSlug sets the slug of the memo, or clears it if it's empty.
+func (s *APIV2Service) updateMemoSlug(ctx context.Context, memo *store.Memo, slug string) error {
+	if slug == "" {
+		if err := s.Store.DeleteMemoSlug(ctx, &store.DeleteMemoSlug{MemoID: memo.ID}); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete memo slug: %v", err)
+		}
+		return nil
+	}
+	if _, err := s.Store.UpsertMemoSlug(ctx, &store.MemoSlug{
+		MemoID:    memo.ID,
+		CreatorID: memo.CreatorID,
+		Slug:      slug,
+		UpdatedTs: time.Now().Unix(),
+	}); err != nil {
+		if errors.Is(err, store.ErrMemoSlugExists) {
+			return status.Errorf(codes.AlreadyExists, "memo slug already exists")
+		}
+		return status.Errorf(codes.Internal, "failed to upsert memo slug: %v", err)
+	}
+	return nil
+}
+
+// getHookRejectedStatus returns the status of an action rejected by a hook, which is shown to the user,
+// nil if the error isn't a rejection. The hooks are run by the store on the memos and the resources it saves.
+func getHookRejectedStatus(err error) error {
+	rejectedErr := &hookrunner.RejectedError{}
+	if errors.As(err, &rejectedErr) {
+		return status.Errorf(codes.InvalidArgument, "%s", rejectedErr.Error())
+	}
+	return nil
+}
+
 func (s *APIV2Service) getMemoDisplayWithUpdatedTsSettingValue(ctx context.Context) (bool, error) {
 	memoDisplayWithUpdatedTsSetting, err := s.Store.GetWorkspaceSetting(ctx, &store.FindWorkspaceSetting{
 		Name: apiv1.SystemSettingMemoDisplayWithUpdatedTsName.String(),
@@ -616,6 +935,8 @@ func convertVisibilityFromStore(visibility store.Visibility) apiv2pb.Visibility
 		return apiv2pb.Visibility_PROTECTED
 	case store.Public:
 		return apiv2pb.Visibility_PUBLIC
+	case store.Group:
+		return apiv2pb.Visibility_GROUP
 	default:
 		return apiv2pb.Visibility_VISIBILITY_UNSPECIFIED
 	}
@@ -629,6 +950,8 @@ func convertVisibilityToStore(visibility apiv2pb.Visibility) store.Visibility {
 		return store.Protected
 	case apiv2pb.Visibility_PUBLIC:
 		return store.Public
+	case apiv2pb.Visibility_GROUP:
+		return store.Group
 	default:
 		return store.Private
 	}
@@ -646,6 +969,7 @@ func (s *APIV2Service) buildMemoFindWithFilter(ctx context.Context, find *store.
 		}
 		if len(filter.ContentSearch) > 0 {
 			find.ContentSearch = filter.ContentSearch
+			find.SearchResourceText = true
 		}
 		if len(filter.Visibilities) > 0 {
 			find.VisibilityList = filter.Visibilities
@@ -703,6 +1027,25 @@ func (s *APIV2Service) buildMemoFindWithFilter(ctx context.Context, find *store.
 		if filter.Limit != nil {
 			find.Limit = filter.Limit
 		}
+		if filter.ContentRegex != nil {
+			if _, err := regexp.Compile(*filter.ContentRegex); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid content regex: %v", err)
+			}
+			find.ContentRegex = filter.ContentRegex
+		}
+		find.Pinned = filter.Pinned
+		find.HasResource = filter.HasResource
+		find.ResourceType = filter.ResourceType
+		find.HasRelation = filter.HasRelation
+		find.HasLink = filter.HasLink
+		find.HasCode = filter.HasCode
+		find.HasTaskList = filter.HasTaskList
+		find.HasIncompleteTasks = filter.HasIncompleteTasks
+		if filter.Explore {
+			if err := s.Store.ApplyExploreSetting(ctx, find); err != nil {
+				return status.Errorf(codes.Internal, "failed to apply explore setting: %v", err)
+			}
+		}
 	}
 
 	// If the user is not authenticated, only public memos are visible.
@@ -714,7 +1057,16 @@ func (s *APIV2Service) buildMemoFindWithFilter(ctx context.Context, find *store.
 
 		find.VisibilityList = []store.Visibility{store.Public}
 	} else if find.CreatorID != nil && *find.CreatorID != user.ID {
-		find.VisibilityList = []store.Visibility{store.Public, store.Protected}
+		find.VisibilityList = []store.Visibility{store.Public, store.Protected, store.Group}
+	}
+	// The group memos are only listed to the members of their groups.
+	if user != nil {
+		find.GroupMemberID = &user.ID
+	}
+	// The memos hidden after reports are only listed to their creators.
+	find.ExcludeHidden = true
+	if user != nil {
+		find.HiddenVisibleToID = &user.ID
 	}
 
 	displayWithUpdatedTs, err := s.getMemoDisplayWithUpdatedTsSettingValue(ctx)
@@ -727,6 +1079,41 @@ func (s *APIV2Service) buildMemoFindWithFilter(ctx context.Context, find *store.
 	return nil
 }
 
+// setMemoFindOrderBy sets the order of the memos with the order by of a list request, e.g. "update_time asc".
+// The memos are ordered by the display time if it's empty.
+func setMemoFindOrderBy(find *store.FindMemo, orderBy string) error {
+	fields := strings.Fields(orderBy)
+	if len(fields) == 0 {
+		return nil
+	}
+	if len(fields) > 2 {
+		return errors.Errorf("invalid order by %q", orderBy)
+	}
+	switch fields[0] {
+	case "display_time":
+	case "create_time":
+		find.OrderBy = store.MemoOrderByCreatedTs
+	case "update_time":
+		find.OrderBy = store.MemoOrderByUpdatedTs
+	case "content_length":
+		find.OrderBy = store.MemoOrderByContentLength
+	case "reaction_count":
+		find.OrderBy = store.MemoOrderByReactionCount
+	default:
+		return errors.Errorf("unsupported order by field %q", fields[0])
+	}
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+			find.OrderAsc = true
+		case "desc":
+		default:
+			return errors.Errorf("invalid order direction %q", fields[1])
+		}
+	}
+	return nil
+}
+
 // SearchMemosFilterCELAttributes are the CEL attributes.
 var SearchMemosFilterCELAttributes = []cel.EnvOption{
 	cel.Variable("content_search", cel.ListType(cel.StringType)),
@@ -739,19 +1126,44 @@ var SearchMemosFilterCELAttributes = []cel.EnvOption{
 	cel.Variable("row_status", cel.StringType),
 	cel.Variable("random", cel.BoolType),
 	cel.Variable("limit", cel.IntType),
+	// content_regex is a regular expression matched against the content,
+	// it's evaluated by the database so only the common syntax is portable.
+	cel.Variable("content_regex", cel.StringType),
+	cel.Variable("pinned", cel.BoolType),
+	cel.Variable("has_resource", cel.BoolType),
+	// resource_type is the prefix of the type of a related resource, e.g. "image/".
+	cel.Variable("resource_type", cel.StringType),
+	// has_relation matches memos referencing or referenced by other memos.
+	cel.Variable("has_relation", cel.BoolType),
+	cel.Variable("has_link", cel.BoolType),
+	cel.Variable("has_code", cel.BoolType),
+	cel.Variable("has_task_list", cel.BoolType),
+	cel.Variable("has_incomplete_tasks", cel.BoolType),
+	// explore narrows the memos down to the ones listed in explore, with the explore setting and the opt-outs of the users.
+	cel.Variable("explore", cel.BoolType),
 }
 
 type SearchMemosFilter struct {
-	ContentSearch     []string
-	Visibilities      []store.Visibility
-	OrderByPinned     bool
-	DisplayTimeBefore *int64
-	DisplayTimeAfter  *int64
-	Creator           *string
-	UID               *string
-	RowStatus         *store.RowStatus
-	Random            bool
-	Limit             *int
+	ContentSearch      []string
+	Visibilities       []store.Visibility
+	OrderByPinned      bool
+	DisplayTimeBefore  *int64
+	DisplayTimeAfter   *int64
+	Creator            *string
+	UID                *string
+	RowStatus          *store.RowStatus
+	Random             bool
+	Limit              *int
+	ContentRegex       *string
+	Pinned             *bool
+	HasResource        *bool
+	ResourceType       *string
+	HasRelation        *bool
+	HasLink            *bool
+	HasCode            *bool
+	HasTaskList        *bool
+	HasIncompleteTasks *bool
+	Explore            bool
 }
 
 func parseSearchMemosFilter(expression string) (*SearchMemosFilter, error) {
@@ -815,6 +1227,36 @@ func findSearchMemosField(callExpr *expr.Expr_Call, filter *SearchMemosFilter) {
 			} else if idExpr.Name == "limit" {
 				limit := int(callExpr.Args[1].GetConstExpr().GetInt64Value())
 				filter.Limit = &limit
+			} else if idExpr.Name == "content_regex" {
+				contentRegex := callExpr.Args[1].GetConstExpr().GetStringValue()
+				filter.ContentRegex = &contentRegex
+			} else if idExpr.Name == "pinned" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.Pinned = &value
+			} else if idExpr.Name == "has_resource" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.HasResource = &value
+			} else if idExpr.Name == "resource_type" {
+				resourceType := callExpr.Args[1].GetConstExpr().GetStringValue()
+				filter.ResourceType = &resourceType
+			} else if idExpr.Name == "has_relation" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.HasRelation = &value
+			} else if idExpr.Name == "has_link" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.HasLink = &value
+			} else if idExpr.Name == "has_code" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.HasCode = &value
+			} else if idExpr.Name == "has_task_list" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.HasTaskList = &value
+			} else if idExpr.Name == "has_incomplete_tasks" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.HasIncompleteTasks = &value
+			} else if idExpr.Name == "explore" {
+				value := callExpr.Args[1].GetConstExpr().GetBoolValue()
+				filter.Explore = value
 			}
 			return
 		}
@@ -859,9 +1301,8 @@ func (s *APIV2Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *api
 			return errors.Wrap(err, "failed to convert memo to webhook payload")
 		}
 		payload.ActivityType = activityType
-		payload.URL = hook.Url
-		if err := webhook.Post(*payload); err != nil {
-			return errors.Wrap(err, "failed to post webhook")
+		if err := webhookdispatcher.Enqueue(ctx, s.Store, hook, payload); err != nil {
+			return errors.Wrap(err, "failed to enqueue webhook")
 		}
 	}
 	return nil
@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/event"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestResurfaceMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser})
+	require.NoError(t, err)
+	s := &APIV2Service{Store: ts, Profile: ts.Profile, eventBroker: event.NewBroker()}
+	ctx = context.WithValue(ctx, usernameContextKey, user.Username)
+
+	now := time.Now().UTC()
+	createMemo := func(uid string, creatorID int32, content string, visibility store.Visibility, createdTime time.Time, rowStatus store.RowStatus) {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: content, Visibility: visibility})
+		require.NoError(t, err)
+		createdTs := createdTime.Unix()
+		err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs, RowStatus: &rowStatus})
+		require.NoError(t, err)
+	}
+	createMemo("last-year", user.ID, "#travel last year", store.Private, now.AddDate(-1, 0, 0), store.Normal)
+	createMemo("two-years", user.ID, "#travel #food two years", store.Public, now.AddDate(-2, 0, 0), store.Normal)
+	createMemo("months", user.ID, "#food months", store.Private, now.AddDate(0, 0, -90), store.Normal)
+	createMemo("today", user.ID, "#travel today", store.Private, now, store.Normal)
+	createMemo("archived", user.ID, "#travel archived", store.Private, now.AddDate(-1, 0, 0), store.Archived)
+	createMemo("other", other.ID, "#travel other", store.Public, now.AddDate(-1, 0, 0), store.Normal)
+
+	tests := []struct {
+		name    string
+		request *apiv2pb.ResurfaceMemosRequest
+		want    []string
+		// ordered is whether the memos must be returned in the order of want.
+		ordered bool
+	}{
+		{
+			name:    "on this day",
+			request: &apiv2pb.ResurfaceMemosRequest{Mode: apiv2pb.ResurfaceMemosRequest_ON_THIS_DAY},
+			want:    []string{"last-year", "two-years"},
+			ordered: true,
+		},
+		{
+			name:    "on this day with a limit",
+			request: &apiv2pb.ResurfaceMemosRequest{Mode: apiv2pb.ResurfaceMemosRequest_ON_THIS_DAY, Limit: 1},
+			want:    []string{"last-year"},
+			ordered: true,
+		},
+		{
+			name:    "on this day with tags",
+			request: &apiv2pb.ResurfaceMemosRequest{Mode: apiv2pb.ResurfaceMemosRequest_ON_THIS_DAY, Tags: []string{"travel", "food"}},
+			want:    []string{"two-years"},
+		},
+		{
+			name:    "on this day with visibilities",
+			request: &apiv2pb.ResurfaceMemosRequest{Mode: apiv2pb.ResurfaceMemosRequest_ON_THIS_DAY, Visibilities: []apiv2pb.Visibility{apiv2pb.Visibility_PRIVATE}},
+			want:    []string{"last-year"},
+		},
+		{
+			name:    "random",
+			request: &apiv2pb.ResurfaceMemosRequest{Limit: 10},
+			want:    []string{"last-year", "two-years", "months"},
+		},
+		{
+			name:    "random with tags",
+			request: &apiv2pb.ResurfaceMemosRequest{Mode: apiv2pb.ResurfaceMemosRequest_RANDOM, Tags: []string{"food"}, Limit: 10},
+			want:    []string{"two-years", "months"},
+		},
+		{
+			name:    "random with a minimum age",
+			request: &apiv2pb.ResurfaceMemosRequest{MinAgeDays: 400, Limit: 10},
+			want:    []string{"two-years"},
+		},
+	}
+	for _, test := range tests {
+		test.request.Timezone = "UTC"
+		response, err := s.ResurfaceMemos(ctx, test.request)
+		require.NoError(t, err, test.name)
+		uids := []string{}
+		for _, memo := range response.Memos {
+			uids = append(uids, memo.Uid)
+		}
+		if test.ordered {
+			require.Equal(t, test.want, uids, test.name)
+		} else {
+			require.ElementsMatch(t, test.want, uids, test.name)
+		}
+	}
+
+	// A random memo is returned by default.
+	response, err := s.ResurfaceMemos(ctx, &apiv2pb.ResurfaceMemosRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Memos, 1)
+	require.Contains(t, []string{"last-year", "two-years", "months"}, response.Memos[0].Uid)
+
+	for _, request := range []*apiv2pb.ResurfaceMemosRequest{
+		{Limit: DefaultPageSize*10 + 1},
+		{Timezone: "Invalid/Zone"},
+	} {
+		_, err := s.ResurfaceMemos(ctx, request)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+	_, err = s.ResurfaceMemos(context.Background(), &apiv2pb.ResurfaceMemosRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
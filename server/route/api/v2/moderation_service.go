@@ -0,0 +1,424 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/event"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const maxMemoReportReasonLength = 1000
+
+func (s *APIV2Service) CreateMemoReport(ctx context.Context, request *apiv2pb.CreateMemoReportRequest) (*apiv2pb.CreateMemoReportResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if len(request.Reason) > maxMemoReportReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is too long")
+	}
+	memoID, err := ExtractMemoIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if visible, err := s.Store.IsMemoVisibleTo(ctx, memo, user.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check memo visibility: %v", err)
+	} else if !visible {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if memo.CreatorID == user.ID {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot report your own memo")
+	}
+
+	existing, err := s.Store.GetMemoReport(ctx, &store.FindMemoReport{
+		ReporterID: &user.ID,
+		MemoID:     &memo.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo report: %v", err)
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "memo is already reported")
+	}
+	report, err := s.Store.CreateMemoReport(ctx, &store.MemoReport{
+		ReporterID: user.ID,
+		MemoID:     memo.ID,
+		Reason:     request.Reason,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create memo report: %v", err)
+	}
+	reportMessage, err := s.convertMemoReportFromStore(ctx, report)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert memo report: %v", err)
+	}
+	return &apiv2pb.CreateMemoReportResponse{
+		Report: reportMessage,
+	}, nil
+}
+
+func (s *APIV2Service) ListMemoReports(ctx context.Context, request *apiv2pb.ListMemoReportsRequest) (*apiv2pb.ListMemoReportsResponse, error) {
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	reportFind := &store.FindMemoReport{}
+	if request.Status != apiv2pb.MemoReport_STATUS_UNSPECIFIED {
+		reportStatus := convertMemoReportStatusToStore(request.Status)
+		reportFind.Status = &reportStatus
+	}
+	if request.Memo != "" {
+		memoID, err := ExtractMemoIDFromName(request.Memo)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+		}
+		reportFind.MemoID = &memoID
+	}
+	if limit > 0 {
+		limitPlusOne := limit + 1
+		reportFind.Limit = &limitPlusOne
+		reportFind.Offset = &offset
+	}
+	reports, err := s.Store.ListMemoReports(ctx, reportFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memo reports: %v", err)
+	}
+
+	response := &apiv2pb.ListMemoReportsResponse{
+		Reports: []*apiv2pb.MemoReport{},
+	}
+	if limit > 0 && len(reports) > limit {
+		reports = reports[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, report := range reports {
+		reportMessage, err := s.convertMemoReportFromStore(ctx, report)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert memo report: %v", err)
+		}
+		response.Reports = append(response.Reports, reportMessage)
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) ResolveMemoReport(ctx context.Context, request *apiv2pb.ResolveMemoReportRequest) (*apiv2pb.ResolveMemoReportResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	reportID, err := ExtractMemoReportIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo report name: %v", err)
+	}
+	report, err := s.Store.GetMemoReport(ctx, &store.FindMemoReport{ID: &reportID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo report: %v", err)
+	}
+	if report == nil {
+		return nil, status.Errorf(codes.NotFound, "memo report not found")
+	}
+	if report.Status != store.MemoReportOpen {
+		return nil, status.Errorf(codes.FailedPrecondition, "memo report is already resolved")
+	}
+
+	reports := []*store.MemoReport{report}
+	reportStatus := store.MemoReportDismissed
+	switch request.Action {
+	case apiv2pb.ResolveMemoReportRequest_DISMISS:
+	case apiv2pb.ResolveMemoReportRequest_HIDE, apiv2pb.ResolveMemoReportRequest_HIDE_AND_BAN:
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &report.MemoID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+		}
+		if memo == nil {
+			return nil, status.Errorf(codes.NotFound, "memo not found")
+		}
+		if request.Action == apiv2pb.ResolveMemoReportRequest_HIDE_AND_BAN {
+			creator, err := s.getManagedUser(ctx, fmt.Sprintf("%s%d", UserNamePrefix, memo.CreatorID))
+			if err != nil {
+				return nil, err
+			}
+			if _, err := s.updateUserRowStatus(ctx, creator, store.Archived); err != nil {
+				return nil, err
+			}
+		}
+		// All open reports of the memo are resolved together, so every reporter is notified.
+		openStatus := store.MemoReportOpen
+		reports, err = s.Store.ListMemoReports(ctx, &store.FindMemoReport{
+			MemoID: &memo.ID,
+			Status: &openStatus,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list memo reports: %v", err)
+		}
+		reportStatus = store.MemoReportHidden
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "action is required")
+	}
+
+	var resolvedReport *store.MemoReport
+	for _, report := range reports {
+		updatedReport, err := s.updateMemoReportStatus(ctx, report, reportStatus, user.ID, request.Resolution)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.notifyMemoReportResolved(ctx, updatedReport); err != nil {
+			return nil, err
+		}
+		if updatedReport.ID == reportID {
+			resolvedReport = updatedReport
+		}
+	}
+	reportMessage, err := s.convertMemoReportFromStore(ctx, resolvedReport)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert memo report: %v", err)
+	}
+	return &apiv2pb.ResolveMemoReportResponse{
+		Report: reportMessage,
+	}, nil
+}
+
+func (s *APIV2Service) RestoreMemo(ctx context.Context, request *apiv2pb.RestoreMemoRequest) (*apiv2pb.RestoreMemoResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	memoID, err := ExtractMemoIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	hiddenStatus := store.MemoReportHidden
+	reports, err := s.Store.ListMemoReports(ctx, &store.FindMemoReport{
+		MemoID: &memoID,
+		Status: &hiddenStatus,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memo reports: %v", err)
+	}
+	if len(reports) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "memo isn't hidden")
+	}
+	for _, report := range reports {
+		if _, err := s.updateMemoReportStatus(ctx, report, store.MemoReportDismissed, user.ID, "Restored"); err != nil {
+			return nil, err
+		}
+	}
+	return &apiv2pb.RestoreMemoResponse{}, nil
+}
+
+func (s *APIV2Service) ListPendingExploreMemos(ctx context.Context, request *apiv2pb.ListPendingExploreMemosRequest) (*apiv2pb.ListPendingExploreMemosResponse, error) {
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	normalStatus := store.Normal
+	memoFind := &store.FindMemo{
+		RowStatus:       &normalStatus,
+		VisibilityList:  []store.Visibility{store.Public, store.Protected},
+		ExcludeComments: true,
+		ExcludeHidden:   true,
+		OrderBy:         store.MemoOrderByCreatedTs,
+		OrderAsc:        true,
+	}
+	// The memos kept out of explore by the setting aren't waiting for the approval.
+	if err := s.Store.ApplyExploreSetting(ctx, memoFind); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to apply explore setting: %v", err)
+	}
+	approved := false
+	memoFind.ExploreApproved = &approved
+	if limit > 0 {
+		limitPlusOne := limit + 1
+		memoFind.Limit = &limitPlusOne
+		memoFind.Offset = &offset
+	}
+	memos, err := s.Store.ListMemos(ctx, memoFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+
+	response := &apiv2pb.ListPendingExploreMemosResponse{
+		Memos: []*apiv2pb.Memo{},
+	}
+	if limit > 0 && len(memos) > limit {
+		memos = memos[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, memo := range memos {
+		memoMessage, err := s.convertMemoFromStore(ctx, memo)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert memo: %v", err)
+		}
+		response.Memos = append(response.Memos, memoMessage)
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) SetMemoExploreApproval(ctx context.Context, request *apiv2pb.SetMemoExploreApprovalRequest) (*apiv2pb.SetMemoExploreApprovalResponse, error) {
+	user, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	memoID, err := ExtractMemoIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+
+	if !request.Approved {
+		if err := s.Store.DeleteMemoProperty(ctx, &store.DeleteMemoProperty{
+			MemoID: memo.ID,
+			Name:   store.MemoExploreApprovedProperty,
+		}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to delete memo property: %v", err)
+		}
+		return &apiv2pb.SetMemoExploreApprovalResponse{}, nil
+	}
+	if _, err := s.Store.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      store.MemoExploreApprovedProperty,
+		Value:     fmt.Sprint(user.ID),
+		UpdatedTs: time.Now().Unix(),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert memo property: %v", err)
+	}
+	return &apiv2pb.SetMemoExploreApprovalResponse{}, nil
+}
+
+func (s *APIV2Service) updateMemoReportStatus(ctx context.Context, report *store.MemoReport, reportStatus store.MemoReportStatus, resolverID int32, resolution string) (*store.MemoReport, error) {
+	currentTs := time.Now().Unix()
+	updatedReport, err := s.Store.UpdateMemoReport(ctx, &store.UpdateMemoReport{
+		ID:         report.ID,
+		UpdatedTs:  &currentTs,
+		Status:     &reportStatus,
+		ResolverID: &resolverID,
+		Resolution: &resolution,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update memo report: %v", err)
+	}
+	return updatedReport, nil
+}
+
+// notifyMemoReportResolved sends the resolution of the report to the inbox of the reporter.
+func (s *APIV2Service) notifyMemoReportResolved(ctx context.Context, report *store.MemoReport) error {
+	activity, err := s.Store.CreateActivity(ctx, &store.Activity{
+		CreatorID: report.ResolverID,
+		Type:      store.ActivityTypeMemoReportResolve,
+		Level:     store.ActivityLevelInfo,
+		Payload: &storepb.ActivityPayload{
+			MemoReportResolve: &storepb.ActivityMemoReportResolvePayload{
+				ReportId: report.ID,
+				MemoId:   report.MemoID,
+				Status:   report.Status.String(),
+			},
+		},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create activity: %v", err)
+	}
+	inbox, err := s.Store.CreateInbox(ctx, &store.Inbox{
+		SenderID:   report.ResolverID,
+		ReceiverID: report.ReporterID,
+		Status:     store.UNREAD,
+		Message: &storepb.InboxMessage{
+			Type:       storepb.InboxMessage_TYPE_MEMO_REPORT_RESOLVED,
+			ActivityId: &activity.ID,
+		},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create inbox: %v", err)
+	}
+	s.eventBroker.Publish(event.NewInboxEvent(inbox))
+	return nil
+}
+
+func (s *APIV2Service) convertMemoReportFromStore(ctx context.Context, report *store.MemoReport) (*apiv2pb.MemoReport, error) {
+	reportMessage := &apiv2pb.MemoReport{
+		Name:       fmt.Sprintf("%s%d", MemoReportNamePrefix, report.ID),
+		Memo:       fmt.Sprintf("%s%d", MemoNamePrefix, report.MemoID),
+		Reporter:   fmt.Sprintf("%s%d", UserNamePrefix, report.ReporterID),
+		Reason:     report.Reason,
+		Status:     convertMemoReportStatusFromStore(report.Status),
+		Resolution: report.Resolution,
+		CreateTime: timestamppb.New(time.Unix(report.CreatedTs, 0)),
+		UpdateTime: timestamppb.New(time.Unix(report.UpdatedTs, 0)),
+	}
+	if report.ResolverID != 0 {
+		reportMessage.Resolver = fmt.Sprintf("%s%d", UserNamePrefix, report.ResolverID)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &report.MemoID, ExcludeContent: true})
+	if err != nil {
+		return nil, err
+	}
+	if memo == nil {
+		return reportMessage, nil
+	}
+	hiddenStatus := store.MemoReportHidden
+	hiddenReports, err := s.Store.ListMemoReports(ctx, &store.FindMemoReport{
+		MemoCreatorID: &memo.CreatorID,
+		Status:        &hiddenStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hiddenMemoIDs := map[int32]bool{}
+	for _, hiddenReport := range hiddenReports {
+		hiddenMemoIDs[hiddenReport.MemoID] = true
+	}
+	reportMessage.CreatorHiddenMemoCount = int32(len(hiddenMemoIDs))
+	return reportMessage, nil
+}
+
+func convertMemoReportStatusFromStore(reportStatus store.MemoReportStatus) apiv2pb.MemoReport_Status {
+	switch reportStatus {
+	case store.MemoReportOpen:
+		return apiv2pb.MemoReport_OPEN
+	case store.MemoReportDismissed:
+		return apiv2pb.MemoReport_DISMISSED
+	case store.MemoReportHidden:
+		return apiv2pb.MemoReport_HIDDEN
+	default:
+		return apiv2pb.MemoReport_STATUS_UNSPECIFIED
+	}
+}
+
+func convertMemoReportStatusToStore(reportStatus apiv2pb.MemoReport_Status) store.MemoReportStatus {
+	switch reportStatus {
+	case apiv2pb.MemoReport_DISMISSED:
+		return store.MemoReportDismissed
+	case apiv2pb.MemoReport_HIDDEN:
+		return store.MemoReportHidden
+	default:
+		return store.MemoReportOpen
+	}
+}
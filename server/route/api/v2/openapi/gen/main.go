@@ -0,0 +1,37 @@
+// Command gen converts the Swagger 2.0 document of api v2 to the OpenAPI 3 document served by the server.
+//
+// Usage: go run ./openapi/gen -title <title> <swagger file> <openapi file>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/usememos/memos/server/route/api/v2/openapi"
+)
+
+func main() {
+	title := flag.String("title", "", "title of the document")
+	flag.Parse()
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gen -title <title> <swagger file> <openapi file>")
+		os.Exit(2)
+	}
+
+	swagger, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read swagger document: %v\n", err)
+		os.Exit(1)
+	}
+	document, err := openapi.Convert(swagger, *title)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to convert swagger document: %v\n", err)
+		os.Exit(1)
+	}
+	header := []byte("# Code generated by openapi/gen from " + flag.Arg(0) + ". DO NOT EDIT.\n")
+	if err := os.WriteFile(flag.Arg(1), append(header, document...), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write openapi document: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,222 @@
+// Package openapi converts the Swagger 2.0 document generated from the gRPC-gateway annotations
+// to an OpenAPI 3 document.
+package openapi
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the OpenAPI version of the converted documents.
+const Version = "3.0.3"
+
+// schemaKeys are the keys of Swagger 2.0 non-body parameters which belong to the schema in OpenAPI 3.
+var schemaKeys = []string{"type", "format", "items", "enum", "default", "maximum", "minimum", "exclusiveMaximum", "exclusiveMinimum", "pattern", "maxLength", "minLength", "maxItems", "minItems", "uniqueItems"}
+
+// Convert returns the OpenAPI 3 document in YAML converted from the Swagger 2.0 document in YAML or JSON.
+// The title of the document is replaced if it's set.
+func Convert(swagger []byte, title string) ([]byte, error) {
+	doc := map[string]any{}
+	if err := yaml.Unmarshal(swagger, &doc); err != nil {
+		return nil, errors.Wrap(err, "failed to parse swagger document")
+	}
+	if version, _ := doc["swagger"].(string); version != "2.0" {
+		return nil, errors.Errorf("unsupported swagger version %q", version)
+	}
+
+	info, _ := doc["info"].(map[string]any)
+	if info == nil {
+		info = map[string]any{}
+	}
+	if title != "" {
+		info["title"] = title
+	}
+	result := map[string]any{
+		"openapi": Version,
+		"info":    info,
+		"servers": []any{map[string]any{"url": "/"}},
+	}
+	for _, key := range []string{"tags", "externalDocs", "security"} {
+		if value, ok := doc[key]; ok {
+			result[key] = value
+		}
+	}
+
+	consumes := getStrings(doc["consumes"], []string{"application/json"})
+	produces := getStrings(doc["produces"], []string{"application/json"})
+	paths := map[string]any{}
+	for path, item := range getMap(doc["paths"]) {
+		pathItem := map[string]any{}
+		for method, operation := range getMap(item) {
+			if operation, ok := operation.(map[string]any); ok && method != "parameters" {
+				pathItem[method] = convertOperation(operation, consumes, produces)
+			} else {
+				pathItem[method] = operation
+			}
+		}
+		paths[path] = pathItem
+	}
+	result["paths"] = paths
+
+	components := map[string]any{}
+	if definitions := getMap(doc["definitions"]); len(definitions) > 0 {
+		components["schemas"] = definitions
+	}
+	if securityDefinitions := getMap(doc["securityDefinitions"]); len(securityDefinitions) > 0 {
+		components["securitySchemes"] = securityDefinitions
+	}
+	if len(components) > 0 {
+		result["components"] = components
+	}
+
+	rewriteRefs(result)
+	// The top level keys are written in the conventional order, nested keys are sorted.
+	keys := []string{"openapi", "info", "servers", "tags", "externalDocs", "security", "paths", "components"}
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range keys {
+		value, ok := result[key]
+		if !ok {
+			continue
+		}
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(value); err != nil {
+			return nil, errors.Wrapf(err, "failed to encode %s", key)
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, errors.Wrap(err, "failed to encode openapi document")
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to encode openapi document")
+	}
+	return buf.Bytes(), nil
+}
+
+func convertOperation(operation map[string]any, consumes, produces []string) map[string]any {
+	consumes = getStrings(operation["consumes"], consumes)
+	produces = getStrings(operation["produces"], produces)
+	result := map[string]any{}
+	for key, value := range operation {
+		switch key {
+		case "consumes", "produces", "schemes":
+		case "parameters":
+			parameters := []any{}
+			for _, parameter := range getSlice(value) {
+				parameter := getMap(parameter)
+				if parameter["in"] == "body" {
+					requestBody := map[string]any{
+						"content": mediaTypes(consumes, parameter["schema"]),
+					}
+					for _, key := range []string{"description", "required"} {
+						if value, ok := parameter[key]; ok {
+							requestBody[key] = value
+						}
+					}
+					result["requestBody"] = requestBody
+					continue
+				}
+				parameters = append(parameters, convertParameter(parameter))
+			}
+			if len(parameters) > 0 {
+				result["parameters"] = parameters
+			}
+		case "responses":
+			responses := map[string]any{}
+			for code, response := range getMap(value) {
+				response := getMap(response)
+				converted := map[string]any{
+					"description": response["description"],
+				}
+				if schema, ok := response["schema"]; ok {
+					converted["content"] = mediaTypes(produces, schema)
+				}
+				responses[code] = converted
+			}
+			result["responses"] = responses
+		default:
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func convertParameter(parameter map[string]any) map[string]any {
+	result, schema := map[string]any{}, map[string]any{}
+	for key, value := range parameter {
+		result[key] = value
+	}
+	for _, key := range schemaKeys {
+		if value, ok := result[key]; ok {
+			schema[key] = value
+			delete(result, key)
+		}
+	}
+	if items := getMap(schema["items"]); items != nil {
+		delete(items, "collectionFormat")
+	}
+	// Query arrays are repeated for every item with "multi", and comma separated otherwise.
+	if collectionFormat, ok := result["collectionFormat"]; ok {
+		result["explode"] = collectionFormat == "multi"
+		delete(result, "collectionFormat")
+	}
+	result["schema"] = schema
+	return result
+}
+
+func mediaTypes(types []string, schema any) map[string]any {
+	content := map[string]any{}
+	for _, mediaType := range types {
+		content[mediaType] = map[string]any{
+			"schema": schema,
+		}
+	}
+	return content
+}
+
+// rewriteRefs points the references of definitions to the component schemas.
+func rewriteRefs(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, v := range value {
+			if ref, ok := v.(string); ok && key == "$ref" {
+				value[key] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+				continue
+			}
+			rewriteRefs(v)
+		}
+	case []any:
+		for _, v := range value {
+			rewriteRefs(v)
+		}
+	}
+}
+
+func getMap(value any) map[string]any {
+	m, _ := value.(map[string]any)
+	return m
+}
+
+func getSlice(value any) []any {
+	s, _ := value.([]any)
+	return s
+}
+
+func getStrings(value any, defaultValue []string) []string {
+	list := []string{}
+	for _, v := range getSlice(value) {
+		if s, ok := v.(string); ok {
+			list = append(list, s)
+		}
+	}
+	if len(list) == 0 {
+		return defaultValue
+	}
+	return list
+}
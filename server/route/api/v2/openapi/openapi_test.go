@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const swagger = `swagger: "2.0"
+info:
+  title: api/v2/memo_service.proto
+  version: version not set
+consumes:
+  - application/json
+produces:
+  - application/json
+paths:
+  /api/v2/memos/{id}:
+    patch:
+      operationId: MemoService_UpdateMemo
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: integer
+          format: int32
+        - name: tags
+          in: query
+          type: array
+          items:
+            type: string
+          collectionFormat: multi
+        - name: memo
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/v2Memo'
+      responses:
+        "200":
+          description: A successful response.
+          schema:
+            $ref: '#/definitions/v2Memo'
+definitions:
+  v2Memo:
+    type: object
+    properties:
+      content:
+        type: string
+`
+
+func TestConvert(t *testing.T) {
+	document, err := Convert([]byte(swagger), "memos API")
+	require.NoError(t, err)
+
+	result := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(document, &result))
+	require.Equal(t, Version, result["openapi"])
+	require.Equal(t, "memos API", getMap(result["info"])["title"])
+
+	operation := getMap(getMap(getMap(result["paths"])["/api/v2/memos/{id}"])["patch"])
+	parameters := getSlice(operation["parameters"])
+	require.Len(t, parameters, 2)
+	require.Equal(t, map[string]any{"type": "integer", "format": "int32"}, getMap(parameters[0])["schema"])
+	require.Equal(t, true, getMap(parameters[1])["explode"])
+
+	requestBody := getMap(operation["requestBody"])
+	require.Equal(t, true, requestBody["required"])
+	schema := getMap(getMap(getMap(requestBody["content"])["application/json"])["schema"])
+	require.Equal(t, "#/components/schemas/v2Memo", schema["$ref"])
+
+	response := getMap(getMap(operation["responses"])["200"])
+	require.Equal(t, "A successful response.", response["description"])
+	require.Contains(t, getMap(getMap(result["components"])["schemas"]), "v2Memo")
+}
+
+func TestConvertUnsupportedVersion(t *testing.T) {
+	_, err := Convert([]byte(`openapi: 3.0.0`), "")
+	require.Error(t, err)
+}
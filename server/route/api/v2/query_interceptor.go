@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/usememos/memos/internal/telemetry"
+)
+
+type QueryInterceptor struct {
+}
+
+func NewQueryInterceptor() *QueryInterceptor {
+	return &QueryInterceptor{}
+}
+
+// QueryInterceptor counts the database queries of the method, so the methods with N+1 queries are logged.
+// It's chained first, so the queries of the authentication are counted too.
+func (*QueryInterceptor) QueryInterceptor(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, done := telemetry.TrackQueries(ctx, serverInfo.FullMethod)
+	defer done()
+	return handler(ctx, request)
+}
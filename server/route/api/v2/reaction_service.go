@@ -7,15 +7,26 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/usememos/memos/internal/event"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
 func (s *APIV2Service) ListMemoReactions(ctx context.Context, request *apiv2pb.ListMemoReactionsRequest) (*apiv2pb.ListMemoReactionsResponse, error) {
-	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	reactionFind := &store.FindReaction{
 		ContentID: &request.Name,
-	})
+	}
+	if limit > 0 {
+		limitPlusOne := limit + 1
+		reactionFind.Limit = &limitPlusOne
+		reactionFind.Offset = &offset
+	}
+	reactions, err := s.Store.ListReactions(ctx, reactionFind)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list reactions")
 	}
@@ -23,6 +34,13 @@ func (s *APIV2Service) ListMemoReactions(ctx context.Context, request *apiv2pb.L
 	response := &apiv2pb.ListMemoReactionsResponse{
 		Reactions: []*apiv2pb.Reaction{},
 	}
+	if limit > 0 && len(reactions) > limit {
+		reactions = reactions[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
 	for _, reaction := range reactions {
 		reactionMessage, err := s.convertReactionFromStore(ctx, reaction)
 		if err != nil {
@@ -46,6 +64,12 @@ func (s *APIV2Service) UpsertMemoReaction(ctx context.Context, request *apiv2pb.
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upsert reaction")
 	}
+	if memoID, err := ExtractMemoIDFromName(reaction.ContentId); err == nil {
+		s.createActivity(ctx, user.ID, store.ActivityTypeMemoReaction, &storepb.ActivityPayload{
+			MemoReaction: &storepb.ActivityMemoReactionPayload{MemoId: memoID, ReactionType: reaction.ReactionType.String()},
+		})
+	}
+	s.publishReactionEvent(ctx, event.ReactionCreated, reaction)
 
 	reactionMessage, err := s.convertReactionFromStore(ctx, reaction)
 	if err != nil {
@@ -61,15 +85,37 @@ func (s *APIV2Service) DeleteMemoReaction(ctx context.Context, request *apiv2pb.
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
+	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list reactions")
+	}
 	if err := s.Store.DeleteReaction(ctx, &store.DeleteReaction{
 		ID: id,
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete reaction")
 	}
+	for _, reaction := range reactions {
+		s.publishReactionEvent(ctx, event.ReactionDeleted, reaction)
+	}
 
 	return &apiv2pb.DeleteMemoReactionResponse{}, nil
 }
 
+// publishReactionEvent publishes the event of the reaction if it's on a memo.
+func (s *APIV2Service) publishReactionEvent(ctx context.Context, eventType event.Type, reaction *storepb.Reaction) {
+	memoID, err := ExtractMemoIDFromName(reaction.ContentId)
+	if err != nil {
+		return
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil {
+		return
+	}
+	s.eventBroker.Publish(event.NewReactionEvent(eventType, reaction, memo))
+}
+
 func (s *APIV2Service) convertReactionFromStore(ctx context.Context, reaction *storepb.Reaction) (*apiv2pb.Reaction, error) {
 	creator, err := s.Store.GetUser(ctx, &store.FindUser{
 		ID: &reaction.CreatorId,
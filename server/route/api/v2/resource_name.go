@@ -15,6 +15,10 @@ const (
 	MemoNamePrefix             = "memos/"
 	ResourceNamePrefix         = "resources/"
 	InboxNamePrefix            = "inboxes/"
+	MemoReportNamePrefix       = "reports/"
+	GroupNamePrefix            = "groups/"
+	InvitationNamePrefix       = "invitations/"
+	GuestCommentNamePrefix     = "guest_comments/"
 )
 
 // GetNameParentTokens returns the tokens from a resource name.
@@ -96,3 +100,55 @@ func ExtractInboxIDFromName(name string) (int32, error) {
 	}
 	return id, nil
 }
+
+// ExtractMemoReportIDFromName returns the memo report ID from a resource name.
+func ExtractMemoReportIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, MemoReportNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid memo report ID %q", tokens[0])
+	}
+	return id, nil
+}
+
+// ExtractInvitationIDFromName returns the invitation ID from a resource name.
+func ExtractInvitationIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, InvitationNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid invitation ID %q", tokens[0])
+	}
+	return id, nil
+}
+
+// ExtractGuestCommentIDFromName returns the guest comment ID from a resource name.
+func ExtractGuestCommentIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, GuestCommentNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid guest comment ID %q", tokens[0])
+	}
+	return id, nil
+}
+
+// ExtractGroupIDFromName returns the group ID from a resource name.
+func ExtractGroupIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, GroupNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid group ID %q", tokens[0])
+	}
+	return id, nil
+}
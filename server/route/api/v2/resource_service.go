@@ -3,6 +3,7 @@ package v2
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"time"
 
@@ -14,7 +15,11 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/event"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/server/route/api/etag"
+	"github.com/usememos/memos/server/route/api/idempotency"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
 	"github.com/usememos/memos/store"
 )
 
@@ -33,6 +38,31 @@ func (s *APIV2Service) CreateResource(ctx context.Context, request *apiv2pb.Crea
 			return nil, status.Errorf(codes.InvalidArgument, "invalid external link scheme: %v", linkURL.Scheme)
 		}
 	}
+	uploadLimit, err := apiv1.GetUploadLimit(ctx, s.Store, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get upload limit: %v", err)
+	}
+	if !uploadLimit.IsTypeAllowed(request.Type) {
+		return nil, status.Errorf(codes.InvalidArgument, "file type %s is not allowed", request.Type)
+	}
+
+	idempotencyKey, err := s.beginIdempotentCreate(ctx, user.ID, "resource", request.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	if idempotencyKey != nil && idempotencyKey.EntityID != 0 {
+		resource, err := s.Store.GetResource(ctx, &store.FindResource{ID: &idempotencyKey.EntityID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get resource: %v", err)
+		}
+		if resource == nil {
+			return nil, status.Errorf(codes.NotFound, "resource not found")
+		}
+		return &apiv2pb.CreateResourceResponse{
+			Resource: s.convertResourceFromStore(ctx, resource),
+		}, nil
+	}
+	defer idempotency.Release(ctx, s.Store, idempotencyKey)
 
 	create := &store.Resource{
 		UID:          shortuuid.New(),
@@ -50,27 +80,54 @@ func (s *APIV2Service) CreateResource(ctx context.Context, request *apiv2pb.Crea
 	}
 	resource, err := s.Store.CreateResource(ctx, create)
 	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
+		}
+		if rejectedErr := getHookRejectedStatus(err); rejectedErr != nil {
+			return nil, rejectedErr
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create resource: %v", err)
 	}
+	if err := idempotency.Complete(ctx, s.Store, idempotencyKey, resource.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to complete idempotency key: %v", err)
+	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceCreated, resource))
 
 	return &apiv2pb.CreateResourceResponse{
 		Resource: s.convertResourceFromStore(ctx, resource),
 	}, nil
 }
 
-func (s *APIV2Service) ListResources(ctx context.Context, _ *apiv2pb.ListResourcesRequest) (*apiv2pb.ListResourcesResponse, error) {
+func (s *APIV2Service) ListResources(ctx context.Context, request *apiv2pb.ListResourcesRequest) (*apiv2pb.ListResourcesResponse, error) {
 	user, err := getCurrentUser(ctx, s.Store)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
 	}
-	resources, err := s.Store.ListResources(ctx, &store.FindResource{
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	resourceFind := &store.FindResource{
 		CreatorID: &user.ID,
-	})
+	}
+	if limit > 0 {
+		limitPlusOne := limit + 1
+		resourceFind.Limit = &limitPlusOne
+		resourceFind.Offset = &offset
+	}
+	resources, err := s.Store.ListResources(ctx, resourceFind)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list resources: %v", err)
 	}
 
 	response := &apiv2pb.ListResourcesResponse{}
+	if limit > 0 && len(resources) > limit {
+		resources = resources[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
 	for _, resource := range resources {
 		response.Resources = append(response.Resources, s.convertResourceFromStore(ctx, resource))
 	}
@@ -120,6 +177,9 @@ func (s *APIV2Service) GetResource(ctx context.Context, request *apiv2pb.GetReso
 	if resource == nil {
 		return nil, status.Errorf(codes.NotFound, "resource not found")
 	}
+	if checkNotModified(ctx, etag.New("resource", resource.ID, resource.UpdatedTs, ""), resource.UpdatedTs) {
+		return &apiv2pb.GetResourceResponse{}, nil
+	}
 
 	return &apiv2pb.GetResourceResponse{
 		Resource: s.convertResourceFromStore(ctx, resource),
@@ -131,8 +191,9 @@ func (s *APIV2Service) UpdateResource(ctx context.Context, request *apiv2pb.Upda
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid resource id: %v", err)
 	}
-	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
+	paths, err := getUpdateMaskPaths(request.UpdateMask, "filename", "memo")
+	if err != nil {
+		return nil, err
 	}
 
 	currentTs := time.Now().Unix()
@@ -140,7 +201,7 @@ func (s *APIV2Service) UpdateResource(ctx context.Context, request *apiv2pb.Upda
 		ID:        id,
 		UpdatedTs: &currentTs,
 	}
-	for _, field := range request.UpdateMask.Paths {
+	for _, field := range paths {
 		if field == "filename" {
 			update.Filename = &request.Resource.Filename
 		} else if field == "memo" {
@@ -183,12 +244,16 @@ func (s *APIV2Service) DeleteResource(ctx context.Context, request *apiv2pb.Dele
 	if resource == nil {
 		return nil, status.Errorf(codes.NotFound, "resource not found")
 	}
+	if err := apiv1.DeleteResourceBlob(ctx, s.Store, resource); err != nil {
+		slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+	}
 	// Delete the resource from the database.
 	if err := s.Store.DeleteResource(ctx, &store.DeleteResource{
 		ID: resource.ID,
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete resource: %v", err)
 	}
+	s.eventBroker.Publish(event.NewResourceEvent(event.ResourceDeleted, resource))
 	return &apiv2pb.DeleteResourceResponse{}, nil
 }
 
@@ -0,0 +1,275 @@
+package v2
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/yourselfhosted/gomark/ast"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/internal/search"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxSearchCandidates is the maximum number of the most recent memos ranked by a search.
+	maxSearchCandidates = 1000
+	maxSearchSnippets   = 3
+	searchSnippetRadius = 60
+)
+
+// searchCandidate is a memo matching a search.
+type searchCandidate struct {
+	memo      *store.Memo
+	matches   []search.Range
+	tags      []string
+	displayTs int64
+	score     float64
+}
+
+func (s *APIV2Service) SearchMemoContents(ctx context.Context, request *apiv2pb.SearchMemoContentsRequest) (*apiv2pb.SearchMemoContentsResponse, error) {
+	query := search.ParseQuery(request.Query)
+	if query.IsEmpty() {
+		return nil, status.Errorf(codes.InvalidArgument, "query is required")
+	}
+	user, _ := getCurrentUser(ctx, s.Store)
+	scope := request.Scope
+	if scope == apiv2pb.SearchMemoContentsRequest_SCOPE_UNSPECIFIED {
+		scope = apiv2pb.SearchMemoContentsRequest_PUBLIC
+		if user != nil {
+			scope = apiv2pb.SearchMemoContentsRequest_WORKSPACE
+		}
+	}
+	if user == nil && scope != apiv2pb.SearchMemoContentsRequest_PUBLIC {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken apiv2pb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		limit = int(pageToken.Limit)
+		offset = int(pageToken.Offset)
+	} else {
+		limit = int(request.PageSize)
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	displayWithUpdatedTs, err := s.getMemoDisplayWithUpdatedTsSettingValue(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo display with updated ts setting value")
+	}
+	rowStatus := store.Normal
+	candidateLimit := maxSearchCandidates
+	memoFind := &store.FindMemo{
+		RowStatus:        &rowStatus,
+		ContentSearch:    query.Terms,
+		ExcludeComments:  true,
+		ExcludeHidden:    true,
+		Limit:            &candidateLimit,
+		OrderByUpdatedTs: displayWithUpdatedTs,
+	}
+	if request.StartTime != nil {
+		startTs := request.StartTime.AsTime().Unix()
+		if displayWithUpdatedTs {
+			memoFind.UpdatedTsAfter = &startTs
+		} else {
+			memoFind.CreatedTsAfter = &startTs
+		}
+	}
+	if request.EndTime != nil {
+		endTs := request.EndTime.AsTime().Unix()
+		if displayWithUpdatedTs {
+			memoFind.UpdatedTsBefore = &endTs
+		} else {
+			memoFind.CreatedTsBefore = &endTs
+		}
+	}
+	memos, err := s.listSearchMemos(ctx, memoFind, scope, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+
+	candidates := []*searchCandidate{}
+	for _, memo := range memos {
+		// The memos are listed with LIKE patterns, the matches are checked case-insensitively here.
+		matches := query.Matches(memo.Content)
+		if matches == nil {
+			continue
+		}
+		tags, err := getMemoContentTags(memo.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse memo content: %v", err)
+		}
+		if !containsAllTags(tags, request.Tags) {
+			continue
+		}
+		displayTs := memo.CreatedTs
+		if displayWithUpdatedTs {
+			displayTs = memo.UpdatedTs
+		}
+		candidates = append(candidates, &searchCandidate{
+			memo:      memo,
+			matches:   matches,
+			tags:      tags,
+			displayTs: displayTs,
+		})
+	}
+	contents := []string{}
+	for _, candidate := range candidates {
+		contents = append(contents, candidate.memo.Content)
+	}
+	for i, score := range query.Rank(contents) {
+		candidates[i].score = score
+	}
+	// The more recent memo comes first on ties.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].displayTs > candidates[j].displayTs
+	})
+
+	response := &apiv2pb.SearchMemoContentsResponse{
+		TotalSize:   int32(len(candidates)),
+		TagFacets:   getSearchTagFacets(candidates),
+		MonthFacets: getSearchMonthFacets(candidates),
+	}
+	if offset+limit < len(candidates) {
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, candidate := range candidates[min(offset, len(candidates)):min(offset+limit, len(candidates))] {
+		memoMessage, err := s.convertMemoFromStore(ctx, candidate.memo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert memo")
+		}
+		result := &apiv2pb.SearchResult{
+			Memo:     memoMessage,
+			Score:    candidate.score,
+			Matches:  convertTextRangesToProto(candidate.matches),
+			Snippets: []*apiv2pb.SearchSnippet{},
+		}
+		for _, snippet := range search.Snippets(candidate.memo.Content, candidate.matches, maxSearchSnippets, searchSnippetRadius) {
+			result.Snippets = append(result.Snippets, &apiv2pb.SearchSnippet{
+				Text:    snippet.Text,
+				Start:   int32(snippet.Start),
+				Matches: convertTextRangesToProto(snippet.Matches),
+			})
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
+// listSearchMemos lists the memos in the scope of the search.
+func (s *APIV2Service) listSearchMemos(ctx context.Context, find *store.FindMemo, scope apiv2pb.SearchMemoContentsRequest_Scope, user *store.User) ([]*store.Memo, error) {
+	switch scope {
+	case apiv2pb.SearchMemoContentsRequest_OWN:
+		find.CreatorID = &user.ID
+		return s.Store.ListMemos(ctx, find)
+	case apiv2pb.SearchMemoContentsRequest_WORKSPACE:
+		ownFind := *find
+		ownFind.CreatorID = &user.ID
+		memos, err := s.Store.ListMemos(ctx, &ownFind)
+		if err != nil {
+			return nil, err
+		}
+		find.VisibilityList = []store.Visibility{store.Public, store.Protected, store.Group}
+		find.GroupMemberID = &user.ID
+		others, err := s.Store.ListMemos(ctx, find)
+		if err != nil {
+			return nil, err
+		}
+		for _, memo := range others {
+			if memo.CreatorID != user.ID {
+				memos = append(memos, memo)
+			}
+		}
+		return memos, nil
+	default:
+		find.VisibilityList = []store.Visibility{store.Public}
+		return s.Store.ListMemos(ctx, find)
+	}
+}
+
+func getMemoContentTags(content string) ([]string, error) {
+	nodes, err := markdown.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	TraverseASTNodes(nodes, func(node ast.Node) {
+		if tagNode, ok := node.(*ast.Tag); ok && !slices.Contains(tags, tagNode.Content) {
+			tags = append(tags, tagNode.Content)
+		}
+	})
+	return tags, nil
+}
+
+func containsAllTags(tags []string, required []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func getSearchTagFacets(candidates []*searchCandidate) []*apiv2pb.SearchFacet {
+	counts := map[string]int32{}
+	for _, candidate := range candidates {
+		for _, tag := range candidate.tags {
+			counts[tag]++
+		}
+	}
+	facets := []*apiv2pb.SearchFacet{}
+	for tag, count := range counts {
+		facets = append(facets, &apiv2pb.SearchFacet{Value: tag, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	return facets
+}
+
+func getSearchMonthFacets(candidates []*searchCandidate) []*apiv2pb.SearchFacet {
+	counts := map[string]int32{}
+	for _, candidate := range candidates {
+		counts[time.Unix(candidate.displayTs, 0).UTC().Format("2006-01")]++
+	}
+	facets := []*apiv2pb.SearchFacet{}
+	for month, count := range counts {
+		facets = append(facets, &apiv2pb.SearchFacet{Value: month, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		return facets[i].Value > facets[j].Value
+	})
+	return facets
+}
+
+func convertTextRangesToProto(ranges []search.Range) []*apiv2pb.TextRange {
+	list := []*apiv2pb.TextRange{}
+	for _, r := range ranges {
+		list = append(list, &apiv2pb.TextRange{
+			Start: int32(r.Start),
+			End:   int32(r.End),
+		})
+	}
+	return list
+}
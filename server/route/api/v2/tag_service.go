@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/usememos/memos/internal/markdown"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	"github.com/usememos/memos/store"
 )
@@ -96,7 +97,7 @@ func (s *APIV2Service) RenameTag(ctx context.Context, request *apiv2pb.RenameTag
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
 	}
-	// Replace tag name in memo content.
+	// Replace tag name in memo content, the nodes are changed so they aren't the cached ones.
 	for _, memo := range memos {
 		nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
 		if err != nil {
@@ -200,7 +201,7 @@ func (s *APIV2Service) GetTagSuggestions(ctx context.Context, request *apiv2pb.G
 	}
 	tagMapSet := make(map[string]bool)
 	for _, memo := range memos {
-		nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
+		nodes, err := markdown.Parse(memo.Content)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to parse memo content")
 		}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// getUpdateMaskPaths returns the normalized paths of the update mask, which must be in the allowed paths.
+// Only the fields in the mask are updated, so the omitted fields are kept as they are.
+// The paths are the proto field names, while the JSON names ("avatarUrl") are accepted as well.
+func getUpdateMaskPaths(updateMask *fieldmaskpb.FieldMask, allowedPaths ...string) ([]string, error) {
+	if updateMask == nil || len(updateMask.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
+	}
+	paths := []string{}
+	for _, path := range updateMask.Paths {
+		path = toSnakeCase(strings.TrimSpace(path))
+		if !slices.Contains(allowedPaths, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", path)
+		}
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func toSnakeCase(s string) string {
+	var builder strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				builder.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,116 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/usememos/memos/internal/event"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestGetUpdateMaskPaths(t *testing.T) {
+	allowedPaths := []string{"content", "visibility", "avatar_url"}
+	tests := []struct {
+		paths []string
+		want  []string
+		code  codes.Code
+	}{
+		{paths: []string{"content"}, want: []string{"content"}},
+		{paths: []string{"content", "visibility"}, want: []string{"content", "visibility"}},
+		// The JSON names are accepted as well.
+		{paths: []string{"avatarUrl"}, want: []string{"avatar_url"}},
+		{paths: []string{" content ", "content"}, want: []string{"content"}},
+		{paths: []string{"avatar_url", "avatarUrl"}, want: []string{"avatar_url"}},
+		{paths: []string{"creator"}, code: codes.InvalidArgument},
+		{paths: []string{"content", "row_status"}, code: codes.InvalidArgument},
+		{paths: []string{}, code: codes.InvalidArgument},
+		{paths: nil, code: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		paths, err := getUpdateMaskPaths(&fieldmaskpb.FieldMask{Paths: test.paths}, allowedPaths...)
+		if test.code != codes.OK {
+			require.Equal(t, test.code, status.Code(err), test.paths)
+			continue
+		}
+		require.NoError(t, err, test.paths)
+		require.Equal(t, test.want, paths, test.paths)
+	}
+
+	_, err := getUpdateMaskPaths(nil, allowedPaths...)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "content", want: "content"},
+		{s: "avatarUrl", want: "avatar_url"},
+		{s: "rowStatus", want: "row_status"},
+		{s: "display_time", want: "display_time"},
+		{s: "Content", want: "content"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, toSnakeCase(test.s), test.s)
+	}
+}
+
+// TestUpdateMemoUpdateMask tests only the fields in the update mask are updated.
+func TestUpdateMemoUpdateMask(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "content", Visibility: store.Private})
+	require.NoError(t, err)
+	s := &APIV2Service{Store: ts, Profile: ts.Profile, eventBroker: event.NewBroker()}
+	ctx = context.WithValue(ctx, usernameContextKey, user.Username)
+
+	tests := []struct {
+		paths      []string
+		memo       *apiv2pb.Memo
+		content    string
+		visibility store.Visibility
+	}{
+		{
+			paths:      []string{"content"},
+			memo:       &apiv2pb.Memo{Content: "updated content", Visibility: apiv2pb.Visibility_PUBLIC},
+			content:    "updated content",
+			visibility: store.Private,
+		},
+		{
+			paths:      []string{"visibility"},
+			memo:       &apiv2pb.Memo{Visibility: apiv2pb.Visibility_PROTECTED},
+			content:    "updated content",
+			visibility: store.Protected,
+		},
+		{
+			paths:      []string{"content", "visibility"},
+			memo:       &apiv2pb.Memo{Content: "content", Visibility: apiv2pb.Visibility_PRIVATE},
+			content:    "content",
+			visibility: store.Private,
+		},
+	}
+	for _, test := range tests {
+		test.memo.Name = fmt.Sprintf("%s%d", MemoNamePrefix, memo.ID)
+		_, err := s.UpdateMemo(ctx, &apiv2pb.UpdateMemoRequest{
+			Memo:       test.memo,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: test.paths},
+		})
+		require.NoError(t, err, test.paths)
+		updated, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+		require.NoError(t, err)
+		require.Equal(t, test.content, updated.Content, test.paths)
+		require.Equal(t, test.visibility, updated.Visibility, test.paths)
+	}
+}
@@ -0,0 +1,378 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/util"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	userQuotaNameSuffix = "/quota"
+	// resetPasswordLength is the length of the generated passwords.
+	resetPasswordLength = 16
+)
+
+func (s *APIV2Service) DisableUser(ctx context.Context, request *apiv2pb.DisableUserRequest) (*apiv2pb.DisableUserResponse, error) {
+	user, err := s.getManagedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	updatedUser, err := s.updateUserRowStatus(ctx, user, store.Archived)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.removeUserAccessTokens(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return &apiv2pb.DisableUserResponse{
+		User: convertUserFromStore(updatedUser),
+	}, nil
+}
+
+func (s *APIV2Service) EnableUser(ctx context.Context, request *apiv2pb.EnableUserRequest) (*apiv2pb.EnableUserResponse, error) {
+	user, err := s.getManagedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	updatedUser, err := s.updateUserRowStatus(ctx, user, store.Normal)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv2pb.EnableUserResponse{
+		User: convertUserFromStore(updatedUser),
+	}, nil
+}
+
+func (s *APIV2Service) ResetUserPassword(ctx context.Context, request *apiv2pb.ResetUserPasswordRequest) (*apiv2pb.ResetUserPasswordResponse, error) {
+	user, err := s.getManagedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	password, err := util.RandomString(resetPasswordLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate password: %v", err)
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate password hash: %v", err)
+	}
+	passwordHashStr := string(passwordHash)
+	currentTs := time.Now().Unix()
+	if _, err := s.Store.UpdateUser(ctx, &store.UpdateUser{
+		ID:           user.ID,
+		UpdatedTs:    &currentTs,
+		PasswordHash: &passwordHashStr,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	}
+	if err := s.removeUserAccessTokens(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return &apiv2pb.ResetUserPasswordResponse{
+		Password: password,
+	}, nil
+}
+
+func (s *APIV2Service) GetUserQuota(ctx context.Context, request *apiv2pb.GetUserQuotaRequest) (*apiv2pb.GetUserQuotaResponse, error) {
+	userID, err := ExtractUserIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser.ID != userID && currentUser.Role != store.RoleHost && currentUser.Role != store.RoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	quota, err := s.getUserQuota(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv2pb.GetUserQuotaResponse{
+		Quota: quota,
+	}, nil
+}
+
+func (s *APIV2Service) UpdateUserQuota(ctx context.Context, request *apiv2pb.UpdateUserQuotaRequest) (*apiv2pb.UpdateUserQuotaResponse, error) {
+	if request.Quota == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "quota is required")
+	}
+	userName, ok := strings.CutSuffix(request.Quota.Name, userQuotaNameSuffix)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid quota name: %s", request.Quota.Name)
+	}
+	user, err := s.getManagedUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	if request.Quota.MaxMemoCount < 0 || request.Quota.MaxResourceSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "quota must not be negative")
+	}
+	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_QUOTA,
+		Value: &storepb.UserSetting_Quota{
+			Quota: &storepb.QuotaUserSetting{
+				MaxMemoCount:    request.Quota.MaxMemoCount,
+				MaxResourceSize: request.Quota.MaxResourceSize,
+			},
+		},
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+	}
+	quota, err := s.getUserQuota(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv2pb.UpdateUserQuotaResponse{
+		Quota: quota,
+	}, nil
+}
+
+func (s *APIV2Service) TransferUserContent(ctx context.Context, request *apiv2pb.TransferUserContentRequest) (*apiv2pb.TransferUserContentResponse, error) {
+	user, err := s.getManagedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID, err := ExtractUserIDFromName(request.TargetUser)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid target user name: %v", err)
+	}
+	if targetUserID == user.ID {
+		return nil, status.Errorf(codes.InvalidArgument, "target user must be another user")
+	}
+	targetUser, err := s.Store.GetUser(ctx, &store.FindUser{ID: &targetUserID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get target user: %v", err)
+	}
+	if targetUser == nil {
+		return nil, status.Errorf(codes.NotFound, "target user not found")
+	}
+
+	// The content is transferred at once, so the users don't end up sharing it.
+	var memos []*store.Memo
+	var resources []*store.Resource
+	if err := s.Store.RunInTx(ctx, func(txStore *store.Store) error {
+		var err error
+		memos, err = txStore.ListMemos(ctx, &store.FindMemo{
+			CreatorID:      &user.ID,
+			ExcludeContent: true,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list memos: %v", err)
+		}
+		for _, memo := range memos {
+			if err := txStore.UpdateMemo(ctx, &store.UpdateMemo{
+				ID:        memo.ID,
+				CreatorID: &targetUser.ID,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to update memo: %v", err)
+			}
+		}
+		resources, err = txStore.ListResources(ctx, &store.FindResource{
+			CreatorID: &user.ID,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list resources: %v", err)
+		}
+		for _, resource := range resources {
+			if _, err := txStore.UpdateResource(ctx, &store.UpdateResource{
+				ID:        resource.ID,
+				CreatorID: &targetUser.ID,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to update resource: %v", err)
+			}
+		}
+		tags, err := txStore.ListTags(ctx, &store.FindTag{
+			CreatorID: user.ID,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list tags: %v", err)
+		}
+		for _, tag := range tags {
+			if _, err := txStore.UpsertTag(ctx, &store.Tag{
+				Name:      tag.Name,
+				CreatorID: targetUser.ID,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to upsert tag: %v", err)
+			}
+			if err := txStore.DeleteTag(ctx, &store.DeleteTag{
+				Name:      tag.Name,
+				CreatorID: user.ID,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to delete tag: %v", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &apiv2pb.TransferUserContentResponse{
+		MemoCount:     int32(len(memos)),
+		ResourceCount: int32(len(resources)),
+	}, nil
+}
+
+func (s *APIV2Service) PurgeUserContent(ctx context.Context, request *apiv2pb.PurgeUserContentRequest) (*apiv2pb.PurgeUserContentResponse, error) {
+	user, err := s.getManagedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The files of the resources are only deleted once the content is deleted from the database.
+	var memos []*store.Memo
+	var resources []*store.Resource
+	if err := s.Store.RunInTx(ctx, func(txStore *store.Store) error {
+		var err error
+		resources, err = txStore.ListResources(ctx, &store.FindResource{
+			CreatorID: &user.ID,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list resources: %v", err)
+		}
+		for _, resource := range resources {
+			if err := txStore.DeleteResource(ctx, &store.DeleteResource{
+				ID:        resource.ID,
+				KeepFiles: true,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to delete resource: %v", err)
+			}
+		}
+		memos, err = txStore.ListMemos(ctx, &store.FindMemo{
+			CreatorID:      &user.ID,
+			ExcludeContent: true,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list memos: %v", err)
+		}
+		for _, memo := range memos {
+			if err := txStore.DeleteMemo(ctx, &store.DeleteMemo{
+				ID: memo.ID,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to delete memo: %v", err)
+			}
+		}
+		tags, err := txStore.ListTags(ctx, &store.FindTag{
+			CreatorID: user.ID,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list tags: %v", err)
+		}
+		for _, tag := range tags {
+			if err := txStore.DeleteTag(ctx, &store.DeleteTag{
+				Name:      tag.Name,
+				CreatorID: user.ID,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to delete tag: %v", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		if err := apiv1.DeleteResourceBlob(ctx, s.Store, resource); err != nil {
+			slog.Warn("Failed to delete resource blob", slog.Any("err", err))
+		}
+		s.Store.DeleteResourceFiles(resource)
+	}
+	return &apiv2pb.PurgeUserContentResponse{
+		MemoCount:     int32(len(memos)),
+		ResourceCount: int32(len(resources)),
+	}, nil
+}
+
+// getManagedUser returns the user of the name if the current user can manage the user.
+// The host can't be managed, and only the host can manage admins.
+func (s *APIV2Service) getManagedUser(ctx context.Context, name string) (*store.User, error) {
+	userID, err := ExtractUserIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	if user.ID == currentUser.ID || user.Role == store.RoleHost {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if user.Role == store.RoleAdmin && currentUser.Role != store.RoleHost {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return user, nil
+}
+
+func (s *APIV2Service) updateUserRowStatus(ctx context.Context, user *store.User, rowStatus store.RowStatus) (*store.User, error) {
+	currentTs := time.Now().Unix()
+	updatedUser, err := s.Store.UpdateUser(ctx, &store.UpdateUser{
+		ID:        user.ID,
+		UpdatedTs: &currentTs,
+		RowStatus: &rowStatus,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	}
+	return updatedUser, nil
+}
+
+// removeUserAccessTokens removes the access tokens of the user to sign out the user everywhere.
+func (s *APIV2Service) removeUserAccessTokens(ctx context.Context, userID int32) error {
+	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_ACCESS_TOKENS,
+		Value: &storepb.UserSetting_AccessTokens{
+			AccessTokens: &storepb.AccessTokensUserSetting{},
+		},
+	}); err != nil {
+		return status.Errorf(codes.Internal, "failed to remove access tokens: %v", err)
+	}
+	return nil
+}
+
+func (s *APIV2Service) getUserQuota(ctx context.Context, userID int32) (*apiv2pb.UserQuota, error) {
+	quota, err := s.Store.GetUserQuota(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user quota: %v", err)
+	}
+	memoCount, resourceSize, err := s.Store.GetUserUsage(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user usage: %v", err)
+	}
+	return &apiv2pb.UserQuota{
+		Name:            fmt.Sprintf("%s%d%s", UserNamePrefix, userID, userQuotaNameSuffix),
+		MaxMemoCount:    quota.MaxMemoCount,
+		MaxResourceSize: quota.MaxResourceSize,
+		MemoCount:       memoCount,
+		ResourceSize:    resourceSize,
+	}, nil
+}
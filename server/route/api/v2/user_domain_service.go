@@ -0,0 +1,171 @@
+package v2
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// domainVerificationRecordPrefix is the prefix of the name of the TXT record verifying a domain.
+	domainVerificationRecordPrefix = "_memos-challenge."
+	// domainVerificationValuePrefix is the prefix of the value of the TXT record verifying a domain.
+	domainVerificationValuePrefix = "memos-verification="
+	// maxUserDomains is the max number of the custom domains of a user.
+	maxUserDomains = 10
+)
+
+// domainHostnamePattern matches the hostnames with at least two labels, e.g. `notes.example.com`.
+var domainHostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9-]{0,61}[a-z0-9]$`)
+
+func (s *APIV2Service) ListUserDomains(ctx context.Context, request *apiv2pb.ListUserDomainsRequest) (*apiv2pb.ListUserDomainsResponse, error) {
+	user, err := s.getOwnedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	domains, err := s.Store.ListUserDomains(ctx, &store.FindUserDomain{UserID: &user.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list domains: %v", err)
+	}
+	response := &apiv2pb.ListUserDomainsResponse{
+		Domains: []*apiv2pb.UserDomain{},
+	}
+	for _, domain := range domains {
+		response.Domains = append(response.Domains, convertUserDomainFromStore(domain))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) CreateUserDomain(ctx context.Context, request *apiv2pb.CreateUserDomainRequest) (*apiv2pb.CreateUserDomainResponse, error) {
+	user, err := s.getOwnedUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	hostname := normalizeDomainHostname(request.Hostname)
+	if !domainHostnamePattern.MatchString(hostname) || len(hostname) > 253 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid hostname: %s", request.Hostname)
+	}
+	workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace general setting: %v", err)
+	}
+	if instanceURL, err := url.Parse(workspaceGeneralSetting.GetInstanceUrl()); err == nil && strings.EqualFold(instanceURL.Hostname(), hostname) {
+		return nil, status.Errorf(codes.InvalidArgument, "the hostname of the instance can't be a custom domain")
+	}
+	existing, err := s.Store.GetUserDomain(ctx, &store.FindUserDomain{Hostname: &hostname})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get domain: %v", err)
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "domain %s already exists", hostname)
+	}
+	domains, err := s.Store.ListUserDomains(ctx, &store.FindUserDomain{UserID: &user.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list domains: %v", err)
+	}
+	if len(domains) >= maxUserDomains {
+		return nil, status.Errorf(codes.ResourceExhausted, "a user can have at most %d domains", maxUserDomains)
+	}
+
+	token, err := util.RandomString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate verification token: %v", err)
+	}
+	domain, err := s.Store.CreateUserDomain(ctx, &store.UserDomain{
+		UserID:            user.ID,
+		Hostname:          hostname,
+		VerificationToken: token,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create domain: %v", err)
+	}
+	return &apiv2pb.CreateUserDomainResponse{
+		Domain: convertUserDomainFromStore(domain),
+	}, nil
+}
+
+func (s *APIV2Service) VerifyUserDomain(ctx context.Context, request *apiv2pb.VerifyUserDomainRequest) (*apiv2pb.VerifyUserDomainResponse, error) {
+	domain, err := s.getUserDomain(ctx, request.Name, request.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	if domain.VerifiedTs == 0 {
+		records, err := net.DefaultResolver.LookupTXT(ctx, domainVerificationRecordPrefix+domain.Hostname)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to look up the TXT record of %s: %v", domainVerificationRecordPrefix+domain.Hostname, err)
+		}
+		if !slices.Contains(records, domainVerificationValuePrefix+domain.VerificationToken) {
+			return nil, status.Errorf(codes.FailedPrecondition, "the TXT record of %s doesn't contain the verification value", domainVerificationRecordPrefix+domain.Hostname)
+		}
+		verifiedTs := time.Now().Unix()
+		domain, err = s.Store.UpdateUserDomain(ctx, &store.UpdateUserDomain{
+			ID:         domain.ID,
+			VerifiedTs: &verifiedTs,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update domain: %v", err)
+		}
+	}
+	return &apiv2pb.VerifyUserDomainResponse{
+		Domain: convertUserDomainFromStore(domain),
+	}, nil
+}
+
+func (s *APIV2Service) DeleteUserDomain(ctx context.Context, request *apiv2pb.DeleteUserDomainRequest) (*apiv2pb.DeleteUserDomainResponse, error) {
+	domain, err := s.getUserDomain(ctx, request.Name, request.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteUserDomain(ctx, &store.DeleteUserDomain{ID: domain.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete domain: %v", err)
+	}
+	return &apiv2pb.DeleteUserDomainResponse{}, nil
+}
+
+// getUserDomain returns the domain of the hostname of the user of the name.
+func (s *APIV2Service) getUserDomain(ctx context.Context, name, hostname string) (*store.UserDomain, error) {
+	user, err := s.getOwnedUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	hostname = normalizeDomainHostname(hostname)
+	domain, err := s.Store.GetUserDomain(ctx, &store.FindUserDomain{UserID: &user.ID, Hostname: &hostname})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get domain: %v", err)
+	}
+	if domain == nil {
+		return nil, status.Errorf(codes.NotFound, "domain not found")
+	}
+	return domain, nil
+}
+
+// normalizeDomainHostname returns the hostname in lower case without the trailing dot.
+func normalizeDomainHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+}
+
+func convertUserDomainFromStore(domain *store.UserDomain) *apiv2pb.UserDomain {
+	userDomain := &apiv2pb.UserDomain{
+		Hostname:                domain.Hostname,
+		VerificationRecordName:  domainVerificationRecordPrefix + domain.Hostname,
+		VerificationRecordValue: domainVerificationValuePrefix + domain.VerificationToken,
+		Verified:                domain.VerifiedTs != 0,
+		CreateTime:              timestamppb.New(time.Unix(domain.CreatedTs, 0)),
+	}
+	if domain.VerifiedTs != 0 {
+		userDomain.VerifyTime = timestamppb.New(time.Unix(domain.VerifiedTs, 0))
+	}
+	return userDomain
+}
@@ -0,0 +1,217 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	userProfileNameSuffix = "/profile"
+	// maxProfileBioLength is the max number of the characters of the bio.
+	maxProfileBioLength = 4096
+	// maxProfileLinks is the max number of the links of a profile.
+	maxProfileLinks = 10
+	// maxProfilePinnedMemos is the max number of the memos pinned on a profile.
+	maxProfilePinnedMemos = 6
+)
+
+var (
+	profileThemes = []string{"", "light", "dark"}
+	// accentColorPattern matches the colors in hex, e.g. `#0ea5e9`.
+	accentColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+func (s *APIV2Service) GetUserProfile(ctx context.Context, request *apiv2pb.GetUserProfileRequest) (*apiv2pb.GetUserProfileResponse, error) {
+	userID, err := ExtractUserIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv2pb.GetUserProfileResponse{
+		Profile: profile,
+	}, nil
+}
+
+func (s *APIV2Service) UpdateUserProfile(ctx context.Context, request *apiv2pb.UpdateUserProfileRequest) (*apiv2pb.UpdateUserProfileResponse, error) {
+	if request.Profile == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "profile is required")
+	}
+	userName, ok := strings.CutSuffix(request.Profile.Name, userProfileNameSuffix)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid profile name: %s", request.Profile.Name)
+	}
+	user, err := s.getOwnedUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := getUpdateMaskPaths(request.UpdateMask, "bio", "links", "pinned_memos", "theme", "accent_color")
+	if err != nil {
+		return nil, err
+	}
+
+	profileSetting, err := s.Store.GetUserProfile(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user profile: %v", err)
+	}
+	for _, path := range paths {
+		switch path {
+		case "bio":
+			if utf8.RuneCountInString(request.Profile.Bio) > maxProfileBioLength {
+				return nil, status.Errorf(codes.InvalidArgument, "bio is longer than %d characters", maxProfileBioLength)
+			}
+			profileSetting.Bio = request.Profile.Bio
+		case "links":
+			links, err := convertProfileLinksToStore(request.Profile.Links)
+			if err != nil {
+				return nil, err
+			}
+			profileSetting.Links = links
+		case "pinned_memos":
+			memoIDs, err := s.getProfilePinnedMemoIDs(ctx, user, request.Profile.PinnedMemos)
+			if err != nil {
+				return nil, err
+			}
+			profileSetting.PinnedMemoIds = memoIDs
+		case "theme":
+			if !slices.Contains(profileThemes, request.Profile.Theme) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid theme: %s", request.Profile.Theme)
+			}
+			profileSetting.Theme = request.Profile.Theme
+		case "accent_color":
+			if request.Profile.AccentColor != "" && !accentColorPattern.MatchString(request.Profile.AccentColor) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid accent color: %s", request.Profile.AccentColor)
+			}
+			profileSetting.AccentColor = strings.ToLower(request.Profile.AccentColor)
+		}
+	}
+	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_PROFILE,
+		Value: &storepb.UserSetting_Profile{
+			Profile: profileSetting,
+		},
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+	}
+
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv2pb.UpdateUserProfileResponse{
+		Profile: profile,
+	}, nil
+}
+
+// getUserProfile returns the profile of the user, with the pinned memos which are still public.
+func (s *APIV2Service) getUserProfile(ctx context.Context, user *store.User) (*apiv2pb.UserProfile, error) {
+	profileSetting, err := s.Store.GetUserProfile(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user profile: %v", err)
+	}
+	profile := &apiv2pb.UserProfile{
+		Name:           fmt.Sprintf("%s%d%s", UserNamePrefix, user.ID, userProfileNameSuffix),
+		User:           convertUserFromStore(user),
+		Bio:            profileSetting.Bio,
+		Links:          []*apiv2pb.UserProfile_Link{},
+		PinnedMemos:    []string{},
+		PinnedMemoList: []*apiv2pb.Memo{},
+		Theme:          profileSetting.Theme,
+		AccentColor:    profileSetting.AccentColor,
+	}
+	// The profile is public, so the email of the user isn't a part of it.
+	profile.User.Email = ""
+	for _, link := range profileSetting.Links {
+		profile.Links = append(profile.Links, &apiv2pb.UserProfile_Link{
+			Title: link.Title,
+			Url:   link.Url,
+		})
+	}
+	memos, err := s.Store.ListProfilePinnedMemos(ctx, user.ID, profileSetting.PinnedMemoIds)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list pinned memos: %v", err)
+	}
+	for _, memo := range memos {
+		memoMessage, err := s.convertMemoFromStore(ctx, memo)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert memo: %v", err)
+		}
+		profile.PinnedMemos = append(profile.PinnedMemos, memoMessage.Name)
+		profile.PinnedMemoList = append(profile.PinnedMemoList, memoMessage)
+	}
+	return profile, nil
+}
+
+// getProfilePinnedMemoIDs returns the ids of the memos of the names, which must be the public memos of the user.
+func (s *APIV2Service) getProfilePinnedMemoIDs(ctx context.Context, user *store.User, names []string) ([]int32, error) {
+	if len(names) > maxProfilePinnedMemos {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d memos can be pinned", maxProfilePinnedMemos)
+	}
+	memoIDs := []int32{}
+	for _, name := range names {
+		memoID, err := ExtractMemoIDFromName(name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+		}
+		if slices.Contains(memoIDs, memoID) {
+			return nil, status.Errorf(codes.InvalidArgument, "memo %s is pinned twice", name)
+		}
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+		}
+		if memo == nil || memo.CreatorID != user.ID {
+			return nil, status.Errorf(codes.NotFound, "memo %s not found", name)
+		}
+		if memo.Visibility != store.Public {
+			return nil, status.Errorf(codes.InvalidArgument, "only the public memos can be pinned on the profile")
+		}
+		memoIDs = append(memoIDs, memoID)
+	}
+	return memoIDs, nil
+}
+
+func convertProfileLinksToStore(links []*apiv2pb.UserProfile_Link) ([]*storepb.ProfileUserSetting_Link, error) {
+	if len(links) > maxProfileLinks {
+		return nil, status.Errorf(codes.InvalidArgument, "a profile can have at most %d links", maxProfileLinks)
+	}
+	list := []*storepb.ProfileUserSetting_Link{}
+	for _, link := range links {
+		// The links are shown on the public pages, so the other schemes, e.g. `javascript:`, aren't allowed.
+		u, err := url.Parse(strings.TrimSpace(link.Url))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid link url: %s", link.Url)
+		}
+		title := strings.TrimSpace(link.Title)
+		if title == "" {
+			title = u.Host
+		}
+		list = append(list, &storepb.ProfileUserSetting_Link{
+			Title: title,
+			Url:   u.String(),
+		})
+	}
+	return list, nil
+}
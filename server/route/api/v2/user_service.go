@@ -18,14 +18,19 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/event"
 	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/bluesky"
+	"github.com/usememos/memos/plugin/mastodon"
+	"github.com/usememos/memos/plugin/push"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/route/api/auth"
+	"github.com/usememos/memos/server/route/api/etag"
 	"github.com/usememos/memos/store"
 )
 
-func (s *APIV2Service) ListUsers(ctx context.Context, _ *apiv2pb.ListUsersRequest) (*apiv2pb.ListUsersResponse, error) {
+func (s *APIV2Service) ListUsers(ctx context.Context, request *apiv2pb.ListUsersRequest) (*apiv2pb.ListUsersResponse, error) {
 	currentUser, err := getCurrentUser(ctx, s.Store)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
@@ -34,16 +39,74 @@ func (s *APIV2Service) ListUsers(ctx context.Context, _ *apiv2pb.ListUsersReques
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	users, err := s.Store.ListUsers(ctx, &store.FindUser{})
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	userFind := &store.FindUser{}
+	if request.Role != apiv2pb.User_ROLE_UNSPECIFIED {
+		role := convertUserRoleToStore(request.Role)
+		userFind.Role = &role
+	}
+	if request.RowStatus != apiv2pb.RowStatus_ROW_STATUS_UNSPECIFIED {
+		rowStatus := convertRowStatusToStore(request.RowStatus)
+		userFind.RowStatus = &rowStatus
+	}
+	// The users are paged after being filtered by the last active time, which isn't stored with the users.
+	filterLastActive := request.LastActiveAfter != nil || request.LastActiveBefore != nil
+	if limit > 0 && !filterLastActive {
+		limitPlusOne := limit + 1
+		userFind.Limit = &limitPlusOne
+		userFind.Offset = &offset
+	}
+	users, err := s.Store.ListUsers(ctx, userFind)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
 	}
+	lastActiveSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_LAST_ACTIVE_TS,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user settings: %v", err)
+	}
+	lastActiveTsMap := map[int32]int64{}
+	for _, setting := range lastActiveSettings {
+		lastActiveTsMap[setting.UserId] = setting.GetLastActiveTs()
+	}
+	if filterLastActive {
+		filtered := []*store.User{}
+		for _, user := range users {
+			lastActiveTs := lastActiveTsMap[user.ID]
+			if request.LastActiveAfter != nil && lastActiveTs <= request.LastActiveAfter.AsTime().Unix() {
+				continue
+			}
+			if request.LastActiveBefore != nil && lastActiveTs > request.LastActiveBefore.AsTime().Unix() {
+				continue
+			}
+			filtered = append(filtered, user)
+		}
+		users = filtered
+		if limit > 0 {
+			users = users[min(offset, len(users)):min(offset+limit+1, len(users))]
+		}
+	}
 
 	response := &apiv2pb.ListUsersResponse{
 		Users: []*apiv2pb.User{},
 	}
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
 	for _, user := range users {
-		response.Users = append(response.Users, convertUserFromStore(user))
+		userMessage := convertUserFromStore(user)
+		if lastActiveTs, ok := lastActiveTsMap[user.ID]; ok {
+			userMessage.LastActiveTime = timestamppb.New(time.Unix(lastActiveTs, 0))
+		}
+		response.Users = append(response.Users, userMessage)
 	}
 	return response, nil
 }
@@ -95,6 +158,9 @@ func (s *APIV2Service) GetUser(ctx context.Context, request *apiv2pb.GetUserRequ
 	if user == nil {
 		return nil, status.Errorf(codes.NotFound, "user not found")
 	}
+	if checkNotModified(ctx, etag.New("user", user.ID, user.UpdatedTs, ""), user.UpdatedTs) {
+		return &apiv2pb.GetUserResponse{}, nil
+	}
 
 	userMessage := convertUserFromStore(user)
 	response := &apiv2pb.GetUserResponse{
@@ -129,6 +195,10 @@ func (s *APIV2Service) CreateUser(ctx context.Context, request *apiv2pb.CreateUs
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
+	s.createActivity(ctx, user.ID, store.ActivityTypeUserCreate, &storepb.ActivityPayload{
+		UserCreate: &storepb.ActivityUserCreatePayload{UserId: user.ID},
+	})
+	s.eventBroker.Publish(event.NewUserEvent(event.UserCreated, user.ID))
 
 	response := &apiv2pb.CreateUserResponse{
 		User: convertUserFromStore(user),
@@ -148,8 +218,9 @@ func (s *APIV2Service) UpdateUser(ctx context.Context, request *apiv2pb.UpdateUs
 	if currentUser.ID != userID && currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
-	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "update mask is empty")
+	paths, err := getUpdateMaskPaths(request.UpdateMask, "username", "nickname", "email", "avatar_url", "description", "role", "password", "row_status")
+	if err != nil {
+		return nil, err
 	}
 
 	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
@@ -165,7 +236,7 @@ func (s *APIV2Service) UpdateUser(ctx context.Context, request *apiv2pb.UpdateUs
 		ID:        user.ID,
 		UpdatedTs: &currentTs,
 	}
-	for _, field := range request.UpdateMask.Paths {
+	for _, field := range paths {
 		if field == "username" {
 			if !util.UIDMatcher.MatchString(strings.ToLower(request.User.Username)) {
 				return nil, status.Errorf(codes.InvalidArgument, "invalid username: %s", request.User.Username)
@@ -192,8 +263,6 @@ func (s *APIV2Service) UpdateUser(ctx context.Context, request *apiv2pb.UpdateUs
 		} else if field == "row_status" {
 			rowStatus := convertRowStatusToStore(request.User.RowStatus)
 			update.RowStatus = &rowStatus
-		} else {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", field)
 		}
 	}
 
@@ -201,6 +270,7 @@ func (s *APIV2Service) UpdateUser(ctx context.Context, request *apiv2pb.UpdateUs
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 	}
+	s.eventBroker.Publish(event.NewUserEvent(event.UserUpdated, updatedUser.ID))
 
 	response := &apiv2pb.UpdateUserResponse{
 		User: convertUserFromStore(updatedUser),
@@ -234,15 +304,28 @@ func (s *APIV2Service) DeleteUser(ctx context.Context, request *apiv2pb.DeleteUs
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
 	}
+	s.eventBroker.Publish(event.NewUserEvent(event.UserDeleted, user.ID))
 
 	return &apiv2pb.DeleteUserResponse{}, nil
 }
 
+const (
+	// emailIngestionTokenLength is the length of the tokens of the email ingestion addresses.
+	emailIngestionTokenLength = 24
+	// feedTokenLength is the length of the tokens of the private feeds.
+	feedTokenLength = 32
+)
+
 func getDefaultUserSetting() *apiv2pb.UserSetting {
 	return &apiv2pb.UserSetting{
 		Locale:         "en",
 		Appearance:     "system",
 		MemoVisibility: "PRIVATE",
+		Notification:   &apiv2pb.UserNotificationSetting{},
+		Mastodon:       &apiv2pb.UserMastodonSetting{},
+		Bluesky:        &apiv2pb.UserBlueskySetting{},
+		GithubGist:     &apiv2pb.UserGithubGistSetting{},
+		HighlightSync:  &apiv2pb.UserHighlightSyncSetting{},
 	}
 }
 
@@ -268,6 +351,36 @@ func (s *APIV2Service) GetUserSetting(ctx context.Context, _ *apiv2pb.GetUserSet
 			userSettingMessage.MemoVisibility = setting.GetMemoVisibility()
 		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_TELEGRAM_USER_ID {
 			userSettingMessage.TelegramUserId = setting.GetTelegramUserId()
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_SLACK_USER_ID {
+			userSettingMessage.SlackUserId = setting.GetSlackUserId()
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID {
+			userSettingMessage.DiscordUserId = setting.GetDiscordUserId()
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_EMAIL_INGESTION_TOKEN {
+			integrationSetting, err := s.Store.GetWorkspaceIntegrationSetting(ctx)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get workspace integration setting: %v", err)
+			}
+			if domain := integrationSetting.GetEmailIngestion().GetDomain(); domain != "" && setting.GetEmailIngestionToken() != "" {
+				userSettingMessage.EmailIngestionAddress = setting.GetEmailIngestionToken() + "@" + domain
+			}
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_NOTIFICATION {
+			userSettingMessage.Notification = convertNotificationUserSettingFromStore(setting.GetNotification())
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_FEED_TOKEN {
+			userSettingMessage.FeedToken = setting.GetFeedToken()
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_MASTODON {
+			userSettingMessage.Mastodon = convertMastodonUserSettingFromStore(setting.GetMastodon())
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_BLUESKY {
+			userSettingMessage.Bluesky = convertBlueskyUserSettingFromStore(setting.GetBluesky())
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_GITHUB_GIST {
+			userSettingMessage.GithubGist = &apiv2pb.UserGithubGistSetting{
+				Token: setting.GetGithubGist().GetToken(),
+			}
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC {
+			userSettingMessage.HighlightSync = convertHighlightSyncUserSettingFromStore(setting.GetHighlightSync())
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_TIMEZONE {
+			userSettingMessage.Timezone = setting.GetTimezone()
+		} else if setting.Key == storepb.UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT {
+			userSettingMessage.ExploreOptOut = setting.GetExploreOptOut()
 		}
 	}
 	return &apiv2pb.GetUserSettingResponse{
@@ -307,6 +420,10 @@ func (s *APIV2Service) UpdateUserSetting(ctx context.Context, request *apiv2pb.U
 				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
 			}
 		} else if field == "memo_visibility" {
+			// The group of a GROUP memo is chosen for each memo.
+			if store.Visibility(request.Setting.MemoVisibility) == store.Group {
+				return nil, status.Errorf(codes.InvalidArgument, "group visibility can't be the default memo visibility")
+			}
 			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
 				UserId: user.ID,
 				Key:    storepb.UserSettingKey_USER_SETTING_MEMO_VISIBILITY,
@@ -326,6 +443,182 @@ func (s *APIV2Service) UpdateUserSetting(ctx context.Context, request *apiv2pb.U
 			}); err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
 			}
+		} else if field == "slack_user_id" {
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_SLACK_USER_ID,
+				Value: &storepb.UserSetting_SlackUserId{
+					SlackUserId: request.Setting.SlackUserId,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "discord_user_id" {
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID,
+				Value: &storepb.UserSetting_DiscordUserId{
+					DiscordUserId: request.Setting.DiscordUserId,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "email_ingestion_address" {
+			// The address can't be chosen, so the token is regenerated regardless of the requested value.
+			token, err := util.RandomString(emailIngestionTokenLength)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to generate email ingestion token: %v", err)
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_EMAIL_INGESTION_TOKEN,
+				Value: &storepb.UserSetting_EmailIngestionToken{
+					// The local parts of the addresses are lowercase, since some mail servers don't preserve the case.
+					EmailIngestionToken: strings.ToLower(token),
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "feed_token" {
+			// The token can't be chosen, so it's regenerated regardless of the requested value.
+			token, err := util.RandomString(feedTokenLength)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to generate feed token: %v", err)
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_FEED_TOKEN,
+				Value: &storepb.UserSetting_FeedToken{
+					FeedToken: token,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "notification" {
+			notificationSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+				UserID: &user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_NOTIFICATION,
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get user setting: %v", err)
+			}
+			notification := convertNotificationUserSettingToStore(request.Setting.Notification)
+			if err := validatePushNotificationSetting(notification.Push); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid push notification setting: %v", err)
+			}
+			// The digests only include the memos created after they are enabled.
+			notification.LastDigestTs = notificationSetting.GetNotification().GetLastDigestTs()
+			if notification.Digest != storepb.NotificationUserSetting_DIGEST_UNSPECIFIED && notification.LastDigestTs == 0 {
+				notification.LastDigestTs = time.Now().Unix()
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_NOTIFICATION,
+				Value: &storepb.UserSetting_Notification{
+					Notification: notification,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "mastodon" {
+			mastodonSetting := convertMastodonUserSettingToStore(request.Setting.Mastodon)
+			if mastodonSetting.ServerUrl != "" {
+				if err := mastodon.ValidateServerURL(mastodonSetting.ServerUrl); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid mastodon server url: %v", err)
+				}
+				if mastodonSetting.AccessToken == "" {
+					return nil, status.Errorf(codes.InvalidArgument, "mastodon access token is required")
+				}
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_MASTODON,
+				Value: &storepb.UserSetting_Mastodon{
+					Mastodon: mastodonSetting,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "bluesky" {
+			blueskySetting := convertBlueskyUserSettingToStore(request.Setting.Bluesky)
+			if blueskySetting.ServiceUrl != "" {
+				if err := bluesky.ValidateServiceURL(blueskySetting.ServiceUrl); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid bluesky service url: %v", err)
+				}
+			}
+			if blueskySetting.Identifier != "" && blueskySetting.AppPassword == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "bluesky app password is required")
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_BLUESKY,
+				Value: &storepb.UserSetting_Bluesky{
+					Bluesky: blueskySetting,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "github_gist" {
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_GITHUB_GIST,
+				Value: &storepb.UserSetting_GithubGist{
+					GithubGist: &storepb.GithubGistUserSetting{
+						Token: strings.TrimSpace(request.Setting.GetGithubGist().GetToken()),
+					},
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "highlight_sync" {
+			highlightSyncSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+				UserID: &user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC,
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get user setting: %v", err)
+			}
+			highlightSync := convertHighlightSyncUserSettingToStore(request.Setting.HighlightSync)
+			if highlightSync.Provider != storepb.HighlightSyncUserSetting_PROVIDER_UNSPECIFIED && highlightSync.Token == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "highlight sync token is required")
+			}
+			// The highlights are imported again from the start if the account is changed.
+			if current := highlightSyncSetting.GetHighlightSync(); current.GetProvider() == highlightSync.Provider && current.GetToken() == highlightSync.Token {
+				highlightSync.LastSyncTs = current.GetLastSyncTs()
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC,
+				Value: &storepb.UserSetting_HighlightSync{
+					HighlightSync: highlightSync,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "timezone" {
+			// The timezone of the server isn't the timezone of the user.
+			if _, err := time.LoadLocation(request.Setting.Timezone); err != nil || request.Setting.Timezone == "Local" {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid timezone %q", request.Setting.Timezone)
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_TIMEZONE,
+				Value: &storepb.UserSetting_Timezone{
+					Timezone: request.Setting.Timezone,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "explore_opt_out" {
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT,
+				Value: &storepb.UserSetting_ExploreOptOut{
+					ExploreOptOut: request.Setting.ExploreOptOut,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
 		} else {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", field)
 		}
@@ -501,6 +794,45 @@ func (s *APIV2Service) UpsertAccessTokenToStore(ctx context.Context, user *store
 	return nil
 }
 
+// getLocation returns the location of the timezone, or the timezone of the user if it's empty.
+func (s *APIV2Service) getLocation(ctx context.Context, timezone string, userID int32) (*time.Location, error) {
+	if timezone == "" {
+		location, err := s.Store.GetUserLocation(ctx, userID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+		}
+		return location, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid time zone: %v", err)
+	}
+	return location, nil
+}
+
+// getOwnedUser returns the user of the name, whose profile and domains are managed by the user or the admins.
+func (s *APIV2Service) getOwnedUser(ctx context.Context, name string) (*store.User, error) {
+	userID, err := ExtractUserIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil || (currentUser.ID != userID && currentUser.Role != store.RoleHost && currentUser.Role != store.RoleAdmin) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return user, nil
+}
+
 func convertUserFromStore(user *store.User) *apiv2pb.User {
 	return &apiv2pb.User{
 		Name:        fmt.Sprintf("%s%d", UserNamePrefix, user.ID),
@@ -599,3 +931,111 @@ func findSearchUsersField(callExpr *expr.Expr_Call, filter *SearchUsersFilter) {
 		}
 	}
 }
+
+func convertNotificationUserSettingFromStore(setting *storepb.NotificationUserSetting) *apiv2pb.UserNotificationSetting {
+	return &apiv2pb.UserNotificationSetting{
+		EmailComment:  setting.GetEmailComment(),
+		EmailReaction: setting.GetEmailReaction(),
+		EmailMention:  setting.GetEmailMention(),
+		EmailReminder: setting.GetEmailReminder(),
+		Digest:        apiv2pb.UserNotificationSetting_Digest(setting.GetDigest()),
+		Push: &apiv2pb.UserPushNotificationSetting{
+			Provider:  apiv2pb.UserPushNotificationSetting_Provider(setting.GetPush().GetProvider()),
+			ServerUrl: setting.GetPush().GetServerUrl(),
+			Topic:     setting.GetPush().GetTopic(),
+			Token:     setting.GetPush().GetToken(),
+		},
+	}
+}
+
+func convertNotificationUserSettingToStore(setting *apiv2pb.UserNotificationSetting) *storepb.NotificationUserSetting {
+	return &storepb.NotificationUserSetting{
+		EmailComment:  setting.GetEmailComment(),
+		EmailReaction: setting.GetEmailReaction(),
+		EmailMention:  setting.GetEmailMention(),
+		EmailReminder: setting.GetEmailReminder(),
+		Digest:        storepb.NotificationUserSetting_Digest(setting.GetDigest()),
+		Push: &storepb.PushNotificationSetting{
+			Provider:  storepb.PushNotificationSetting_Provider(setting.GetPush().GetProvider()),
+			ServerUrl: strings.TrimSpace(setting.GetPush().GetServerUrl()),
+			Topic:     strings.TrimSpace(setting.GetPush().GetTopic()),
+			Token:     strings.TrimSpace(setting.GetPush().GetToken()),
+		},
+	}
+}
+
+// validatePushNotificationSetting returns an error if the push service is missing the fields its provider requires.
+func validatePushNotificationSetting(setting *storepb.PushNotificationSetting) error {
+	config := &push.Config{
+		ServerURL: setting.GetServerUrl(),
+		Topic:     setting.GetTopic(),
+		Token:     setting.GetToken(),
+	}
+	switch setting.GetProvider() {
+	case storepb.PushNotificationSetting_PROVIDER_UNSPECIFIED:
+		return nil
+	case storepb.PushNotificationSetting_NTFY:
+		config.Provider = push.ProviderNtfy
+	case storepb.PushNotificationSetting_GOTIFY:
+		config.Provider = push.ProviderGotify
+	default:
+		return errors.Errorf("unsupported push provider %d", setting.GetProvider())
+	}
+	return config.Validate()
+}
+
+func convertMastodonUserSettingFromStore(setting *storepb.MastodonUserSetting) *apiv2pb.UserMastodonSetting {
+	return &apiv2pb.UserMastodonSetting{
+		ServerUrl:   setting.GetServerUrl(),
+		AccessToken: setting.GetAccessToken(),
+		Tag:         setting.GetTag(),
+	}
+}
+
+func convertMastodonUserSettingToStore(setting *apiv2pb.UserMastodonSetting) *storepb.MastodonUserSetting {
+	return &storepb.MastodonUserSetting{
+		ServerUrl:   strings.TrimSuffix(strings.TrimSpace(setting.GetServerUrl()), "/"),
+		AccessToken: strings.TrimSpace(setting.GetAccessToken()),
+		Tag:         strings.TrimPrefix(strings.TrimSpace(setting.GetTag()), "#"),
+	}
+}
+
+func convertBlueskyUserSettingFromStore(setting *storepb.BlueskyUserSetting) *apiv2pb.UserBlueskySetting {
+	return &apiv2pb.UserBlueskySetting{
+		ServiceUrl:  setting.GetServiceUrl(),
+		Identifier:  setting.GetIdentifier(),
+		AppPassword: setting.GetAppPassword(),
+		Tag:         setting.GetTag(),
+	}
+}
+
+func convertBlueskyUserSettingToStore(setting *apiv2pb.UserBlueskySetting) *storepb.BlueskyUserSetting {
+	return &storepb.BlueskyUserSetting{
+		ServiceUrl:  strings.TrimSuffix(strings.TrimSpace(setting.GetServiceUrl()), "/"),
+		Identifier:  strings.TrimPrefix(strings.TrimSpace(setting.GetIdentifier()), "@"),
+		AppPassword: strings.TrimSpace(setting.GetAppPassword()),
+		Tag:         strings.TrimPrefix(strings.TrimSpace(setting.GetTag()), "#"),
+	}
+}
+
+func convertHighlightSyncUserSettingFromStore(setting *storepb.HighlightSyncUserSetting) *apiv2pb.UserHighlightSyncSetting {
+	highlightSync := &apiv2pb.UserHighlightSyncSetting{
+		Provider: apiv2pb.UserHighlightSyncSetting_Provider(setting.GetProvider()),
+		Token:    setting.GetToken(),
+		Mode:     apiv2pb.UserHighlightSyncSetting_Mode(setting.GetMode()),
+		Tag:      setting.GetTag(),
+	}
+	if setting.GetLastSyncTs() > 0 {
+		highlightSync.LastSyncTime = timestamppb.New(time.Unix(setting.GetLastSyncTs(), 0))
+	}
+	return highlightSync
+}
+
+func convertHighlightSyncUserSettingToStore(setting *apiv2pb.UserHighlightSyncSetting) *storepb.HighlightSyncUserSetting {
+	return &storepb.HighlightSyncUserSetting{
+		Provider: storepb.HighlightSyncUserSetting_Provider(setting.GetProvider()),
+		Token:    strings.TrimSpace(setting.GetToken()),
+		Mode:     storepb.HighlightSyncUserSetting_Mode(setting.GetMode()),
+		Tag:      strings.TrimPrefix(strings.TrimSpace(setting.GetTag()), "#"),
+	}
+}
@@ -5,17 +5,27 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/handoff"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/server/route/api/idempotency"
+	"github.com/usememos/memos/server/route/api/quota"
 	"github.com/usememos/memos/store"
 )
 
@@ -31,30 +41,49 @@ type APIV2Service struct {
 	apiv2pb.UnimplementedActivityServiceServer
 	apiv2pb.UnimplementedWebhookServiceServer
 	apiv2pb.UnimplementedLinkServiceServer
+	apiv2pb.UnimplementedSearchServiceServer
+	apiv2pb.UnimplementedAnalyticsServiceServer
+	apiv2pb.UnimplementedModerationServiceServer
+	apiv2pb.UnimplementedGroupServiceServer
+	apiv2pb.UnimplementedInvitationServiceServer
+	apiv2pb.UnimplementedGuestCommentServiceServer
 
 	Secret  string
 	Profile *profile.Profile
 	Store   *store.Store
 
+	eventBroker    *event.Broker
 	grpcServer     *grpc.Server
 	grpcServerPort int
+	healthServer   *health.Server
+
+	// analyticsCache caches the workspace analytics by the number of days.
+	analyticsCache sync.Map
+	// startTime is the time the service was created, the uptime of the instance is from it.
+	startTime time.Time
 }
 
-func NewAPIV2Service(secret string, profile *profile.Profile, store *store.Store, grpcServerPort int) *APIV2Service {
+func NewAPIV2Service(secret string, profile *profile.Profile, store *store.Store, eventBroker *event.Broker, quotaLimiter *quota.Limiter, grpcServerPort int) *APIV2Service {
 	grpc.EnableTracing = true
-	authProvider := NewGRPCAuthInterceptor(store, secret)
+	authProvider := NewGRPCAuthInterceptor(store, secret, quotaLimiter)
 	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
+			NewQueryInterceptor().QueryInterceptor,
 			NewLoggerInterceptor().LoggerInterceptor,
 			authProvider.AuthenticationInterceptor,
+			NewAuditInterceptor().AuditInterceptor,
 		),
 	)
 	apiv2Service := &APIV2Service{
 		Secret:         secret,
 		Profile:        profile,
 		Store:          store,
+		eventBroker:    eventBroker,
 		grpcServer:     grpcServer,
 		grpcServerPort: grpcServerPort,
+		healthServer:   health.NewServer(),
+		startTime:      time.Now(),
 	}
 
 	apiv2pb.RegisterWorkspaceServiceServer(grpcServer, apiv2Service)
@@ -68,7 +97,17 @@ func NewAPIV2Service(secret string, profile *profile.Profile, store *store.Store
 	apiv2pb.RegisterActivityServiceServer(grpcServer, apiv2Service)
 	apiv2pb.RegisterWebhookServiceServer(grpcServer, apiv2Service)
 	apiv2pb.RegisterLinkServiceServer(grpcServer, apiv2Service)
-	reflection.Register(grpcServer)
+	apiv2pb.RegisterSearchServiceServer(grpcServer, apiv2Service)
+	apiv2pb.RegisterAnalyticsServiceServer(grpcServer, apiv2Service)
+	apiv2pb.RegisterModerationServiceServer(grpcServer, apiv2Service)
+	apiv2pb.RegisterGroupServiceServer(grpcServer, apiv2Service)
+	apiv2pb.RegisterInvitationServiceServer(grpcServer, apiv2Service)
+	apiv2pb.RegisterGuestCommentServiceServer(grpcServer, apiv2Service)
+	healthpb.RegisterHealthServer(grpcServer, apiv2Service.healthServer)
+	// Reflection exposes the whole API schema, so it's only enabled in prod mode on demand.
+	if profile.IsGRPCReflectionEnabled() {
+		reflection.Register(grpcServer)
+	}
 
 	return apiv2Service
 }
@@ -79,18 +118,29 @@ func (s *APIV2Service) GetGRPCServer() *grpc.Server {
 
 // RegisterGateway registers the gRPC-Gateway with the given Echo instance.
 func (s *APIV2Service) RegisterGateway(ctx context.Context, e *echo.Echo) error {
+	// Listen before the gateway dials, so the port chosen by the system is known when it isn't set.
+	listen, err := s.listenGRPC()
+	if err != nil {
+		return errors.Wrap(err, "failed to start gRPC server")
+	}
 	// Create a client connection to the gRPC Server we just started.
 	// This is where the gRPC-Gateway proxies the requests.
 	conn, err := grpc.DialContext(
 		ctx,
-		fmt.Sprintf(":%d", s.grpcServerPort),
+		fmt.Sprintf(":%d", listen.Addr().(*net.TCPAddr).Port),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// The trace of the gateway request is propagated to the gRPC server.
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return err
 	}
 
-	gwMux := runtime.NewServeMux()
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithForwardResponseOption(forwardResponseStatus),
+	)
 	if err := apiv2pb.RegisterWorkspaceServiceHandler(context.Background(), gwMux, conn); err != nil {
 		return err
 	}
@@ -124,6 +174,27 @@ func (s *APIV2Service) RegisterGateway(ctx context.Context, e *echo.Echo) error
 	if err := apiv2pb.RegisterLinkServiceHandler(context.Background(), gwMux, conn); err != nil {
 		return err
 	}
+	if err := apiv2pb.RegisterSearchServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return err
+	}
+	if err := apiv2pb.RegisterAnalyticsServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return err
+	}
+	if err := apiv2pb.RegisterModerationServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return err
+	}
+	if err := apiv2pb.RegisterGroupServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return err
+	}
+	if err := apiv2pb.RegisterInvitationServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return err
+	}
+	if err := apiv2pb.RegisterGuestCommentServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return err
+	}
+	if err := s.registerAPIDocsRoutes(e); err != nil {
+		return err
+	}
 	e.Any("/api/v2/*", echo.WrapHandler(gwMux))
 
 	// GRPC web proxy.
@@ -137,15 +208,55 @@ func (s *APIV2Service) RegisterGateway(ctx context.Context, e *echo.Echo) error
 	e.Any("/memos.api.v2.*", echo.WrapHandler(wrappedGrpc))
 
 	// Start gRPC server.
-	listen, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.Profile.Addr, s.grpcServerPort))
-	if err != nil {
-		return errors.Wrap(err, "failed to start gRPC server")
-	}
 	go func() {
 		if err := s.grpcServer.Serve(listen); err != nil {
 			slog.Error("failed to start gRPC server", err)
 		}
 	}()
+	// The overall health and the health of every service are reported, so probes can check either.
+	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	for serviceName := range s.grpcServer.GetServiceInfo() {
+		s.healthServer.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+	}
 
 	return nil
 }
+
+// listenGRPC listens on the gRPC port, or on a loopback port chosen by the system if it's 0, e.g. for the servers of the tenants,
+// whose listeners aren't handed off since they're chosen again by the new process.
+func (s *APIV2Service) listenGRPC() (net.Listener, error) {
+	if s.grpcServerPort == 0 {
+		return net.Listen("tcp", "127.0.0.1:0")
+	}
+	return handoff.Listen("grpc", fmt.Sprintf("%s:%d", s.Profile.Addr, s.grpcServerPort), s.Profile.ReusePort)
+}
+
+// incomingHeaderMatcher passes the Idempotency-Key header to the services, in addition to the headers passed by the default matcher.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, idempotency.Header) {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher passes the rate limit, conditional request and idempotency headers through the gateway as they are,
+// other headers are prefixed as by the default matcher.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch strings.ToLower(key) {
+	case strings.ToLower(quota.LimitHeader), strings.ToLower(quota.RemainingHeader), strings.ToLower(quota.ResetHeader), strings.ToLower(quota.RetryAfterHeader):
+		return key, true
+	case "etag", "last-modified", "vary", strings.ToLower(idempotency.ReplayedHeader):
+		return key, true
+	case httpCodeHeader:
+		// The status code is set by forwardResponseStatus.
+		return "", false
+	default:
+		return runtime.MetadataHeaderPrefix + key, true
+	}
+}
+
+// Shutdown reports all services as not serving, and stops the gRPC server.
+func (s *APIV2Service) Shutdown() {
+	s.healthServer.Shutdown()
+	s.grpcServer.Stop()
+}
@@ -0,0 +1,62 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/server/profile"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestNewAPIV2ServiceReflection(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	tests := []struct {
+		mode           string
+		grpcReflection bool
+		want           bool
+	}{
+		{mode: "dev", want: true},
+		{mode: "demo", want: true},
+		{mode: "prod", want: false},
+		{mode: "prod", grpcReflection: true, want: true},
+	}
+	for _, test := range tests {
+		s := NewAPIV2Service("secret", &profile.Profile{Mode: test.mode, GRPCReflection: test.grpcReflection}, ts, event.NewBroker(), nil, 0)
+		serviceInfo := s.GetGRPCServer().GetServiceInfo()
+		_, ok := serviceInfo["grpc.reflection.v1alpha.ServerReflection"]
+		require.Equal(t, test.want, ok, test)
+		_, ok = serviceInfo[healthpb.Health_ServiceDesc.ServiceName]
+		require.True(t, ok, test)
+	}
+}
+
+// TestHealth tests the services are reported as serving once the gRPC server is started, until it's shut down.
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	s := NewAPIV2Service("secret", ts.Profile, ts, event.NewBroker(), nil, 0)
+	require.True(t, isUnauthorizeAllowedMethod("/grpc.health.v1.Health/Check"))
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		response, err := s.healthServer.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		return response.Status
+	}
+	services := []string{"", "memos.api.v2.MemoService", healthpb.Health_ServiceDesc.ServiceName}
+	require.NoError(t, s.RegisterGateway(ctx, echo.New()))
+	for _, service := range services {
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, check(service), service)
+	}
+	s.Shutdown()
+	for _, service := range services {
+		require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(service), service)
+	}
+}
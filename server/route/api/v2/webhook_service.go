@@ -2,12 +2,15 @@ package v2
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/webhook"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -19,10 +22,22 @@ func (s *APIV2Service) CreateWebhook(ctx context.Context, request *apiv2pb.Creat
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
 
+	if err := webhook.ValidateTemplate(request.PayloadTemplate); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid payload template: %v", err)
+	}
+	secret := request.Secret
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate webhook secret: %v", err)
+		}
+	}
+
 	webhook, err := s.Store.CreateWebhook(ctx, &storepb.Webhook{
-		CreatorId: currentUser.ID,
-		Name:      request.Name,
-		Url:       request.Url,
+		CreatorId:       currentUser.ID,
+		Name:            request.Name,
+		Url:             request.Url,
+		Secret:          secret,
+		PayloadTemplate: request.PayloadTemplate,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create webhook, error: %+v", err)
@@ -75,7 +90,9 @@ func (s *APIV2Service) UpdateWebhook(ctx context.Context, request *apiv2pb.Updat
 		return nil, status.Errorf(codes.InvalidArgument, "update_mask is required")
 	}
 
-	update := &store.UpdateWebhook{}
+	update := &store.UpdateWebhook{
+		ID: request.Webhook.Id,
+	}
 	for _, field := range request.UpdateMask.Paths {
 		switch field {
 		case "row_status":
@@ -85,6 +102,21 @@ func (s *APIV2Service) UpdateWebhook(ctx context.Context, request *apiv2pb.Updat
 			update.Name = &request.Webhook.Name
 		case "url":
 			update.URL = &request.Webhook.Url
+		case "secret":
+			// An empty secret rotates the secret to a random one.
+			secret := request.Webhook.Secret
+			if secret == "" {
+				var err error
+				if secret, err = generateWebhookSecret(); err != nil {
+					return nil, status.Errorf(codes.Internal, "failed to generate webhook secret: %v", err)
+				}
+			}
+			update.Secret = &secret
+		case "payload_template":
+			if err := webhook.ValidateTemplate(request.Webhook.PayloadTemplate); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid payload template: %v", err)
+			}
+			update.PayloadTemplate = &request.Webhook.PayloadTemplate
 		}
 	}
 
@@ -107,14 +139,202 @@ func (s *APIV2Service) DeleteWebhook(ctx context.Context, request *apiv2pb.Delet
 	return &apiv2pb.DeleteWebhookResponse{}, nil
 }
 
+func (s *APIV2Service) ListWebhookDeliveries(ctx context.Context, request *apiv2pb.ListWebhookDeliveriesRequest) (*apiv2pb.ListWebhookDeliveriesResponse, error) {
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	webhook, err := s.Store.GetWebhooks(ctx, &store.FindWebhook{
+		ID:        &request.Id,
+		CreatorID: &currentUser.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get webhook, error: %+v", err)
+	}
+	if webhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+	limit, offset, err := getPageLimitOffset(request.PageSize, request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+
+	deliveryFind := &store.FindWebhookDelivery{
+		WebhookID: &webhook.Id,
+	}
+	if limit > 0 {
+		limitPlusOne := limit + 1
+		deliveryFind.Limit = &limitPlusOne
+		deliveryFind.Offset = &offset
+	}
+	deliveries, err := s.Store.ListWebhookDeliveries(ctx, deliveryFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries, error: %+v", err)
+	}
+
+	response := &apiv2pb.ListWebhookDeliveriesResponse{
+		Deliveries: []*apiv2pb.WebhookDelivery{},
+	}
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		response.NextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertWebhookDeliveryFromStore(delivery))
+	}
+	return response, nil
+}
+
 func convertWebhookFromStore(webhook *storepb.Webhook) *apiv2pb.Webhook {
 	return &apiv2pb.Webhook{
-		Id:          webhook.Id,
-		CreatedTime: timestamppb.New(time.Unix(webhook.CreatedTs, 0)),
-		UpdatedTime: timestamppb.New(time.Unix(webhook.UpdatedTs, 0)),
-		RowStatus:   apiv2pb.RowStatus(webhook.RowStatus),
-		CreatorId:   webhook.CreatorId,
-		Name:        webhook.Name,
-		Url:         webhook.Url,
+		Id:              webhook.Id,
+		CreatedTime:     timestamppb.New(time.Unix(webhook.CreatedTs, 0)),
+		UpdatedTime:     timestamppb.New(time.Unix(webhook.UpdatedTs, 0)),
+		RowStatus:       apiv2pb.RowStatus(webhook.RowStatus),
+		CreatorId:       webhook.CreatorId,
+		Name:            webhook.Name,
+		Url:             webhook.Url,
+		Secret:          webhook.Secret,
+		PayloadTemplate: webhook.PayloadTemplate,
+	}
+}
+
+func convertWebhookDeliveryFromStore(delivery *store.WebhookDelivery) *apiv2pb.WebhookDelivery {
+	webhookDelivery := &apiv2pb.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.WebhookID,
+		ActivityType:   delivery.ActivityType,
+		Payload:        delivery.Payload,
+		Status:         convertWebhookDeliveryStatusFromStore(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreateTime:     timestamppb.New(time.Unix(delivery.CreatedTs, 0)),
+		UpdateTime:     timestamppb.New(time.Unix(delivery.UpdatedTs, 0)),
+	}
+	if delivery.Status == store.WebhookDeliveryPending {
+		webhookDelivery.NextAttemptTime = timestamppb.New(time.Unix(delivery.NextAttemptTs, 0))
+	}
+	return webhookDelivery
+}
+
+func convertWebhookDeliveryStatusFromStore(status store.WebhookDeliveryStatus) apiv2pb.WebhookDelivery_Status {
+	switch status {
+	case store.WebhookDeliveryPending:
+		return apiv2pb.WebhookDelivery_PENDING
+	case store.WebhookDeliverySucceeded:
+		return apiv2pb.WebhookDelivery_SUCCEEDED
+	case store.WebhookDeliveryFailed:
+		return apiv2pb.WebhookDelivery_FAILED
+	default:
+		return apiv2pb.WebhookDelivery_STATUS_UNSPECIFIED
+	}
+}
+
+// generateWebhookSecret returns a random secret for signing webhook payloads.
+func generateWebhookSecret() (string, error) {
+	return util.RandomString(32)
+}
+
+func (s *APIV2Service) CreateIncomingWebhook(ctx context.Context, request *apiv2pb.CreateIncomingWebhookRequest) (*apiv2pb.CreateIncomingWebhookResponse, error) {
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	for _, tag := range request.Tags {
+		if tag == "" || strings.ContainsAny(tag, ", \t\n") {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid tag %q", tag)
+		}
+	}
+
+	// The token is the only credential of the webhook, so it's as long as an access token.
+	token, err := util.RandomString(48)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate incoming webhook token: %v", err)
+	}
+	visibility := store.Visibility("")
+	if request.Visibility != apiv2pb.Visibility_VISIBILITY_UNSPECIFIED {
+		visibility = convertVisibilityToStore(request.Visibility)
+	}
+	incomingWebhook, err := s.Store.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+		CreatorID:  currentUser.ID,
+		Name:       request.Name,
+		Token:      token,
+		Visibility: visibility,
+		Tags:       request.Tags,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create incoming webhook, error: %+v", err)
+	}
+	return &apiv2pb.CreateIncomingWebhookResponse{
+		IncomingWebhook: convertIncomingWebhookFromStore(incomingWebhook),
+	}, nil
+}
+
+func (s *APIV2Service) ListIncomingWebhooks(ctx context.Context, _ *apiv2pb.ListIncomingWebhooksRequest) (*apiv2pb.ListIncomingWebhooksResponse, error) {
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	incomingWebhooks, err := s.Store.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{
+		CreatorID: &currentUser.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list incoming webhooks, error: %+v", err)
+	}
+
+	response := &apiv2pb.ListIncomingWebhooksResponse{
+		IncomingWebhooks: []*apiv2pb.IncomingWebhook{},
+	}
+	for _, incomingWebhook := range incomingWebhooks {
+		response.IncomingWebhooks = append(response.IncomingWebhooks, convertIncomingWebhookFromStore(incomingWebhook))
+	}
+	return response, nil
+}
+
+func (s *APIV2Service) DeleteIncomingWebhook(ctx context.Context, request *apiv2pb.DeleteIncomingWebhookRequest) (*apiv2pb.DeleteIncomingWebhookResponse, error) {
+	currentUser, err := getCurrentUser(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	incomingWebhook, err := s.Store.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		ID:        &request.Id,
+		CreatorID: &currentUser.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get incoming webhook, error: %+v", err)
+	}
+	if incomingWebhook == nil {
+		return nil, status.Errorf(codes.NotFound, "incoming webhook not found")
+	}
+	if err := s.Store.DeleteIncomingWebhook(ctx, &store.DeleteIncomingWebhook{
+		ID: incomingWebhook.ID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete incoming webhook, error: %+v", err)
+	}
+	return &apiv2pb.DeleteIncomingWebhookResponse{}, nil
+}
+
+func convertIncomingWebhookFromStore(incomingWebhook *store.IncomingWebhook) *apiv2pb.IncomingWebhook {
+	return &apiv2pb.IncomingWebhook{
+		Id:         incomingWebhook.ID,
+		CreatorId:  incomingWebhook.CreatorID,
+		CreateTime: timestamppb.New(time.Unix(incomingWebhook.CreatedTs, 0)),
+		Name:       incomingWebhook.Name,
+		Token:      incomingWebhook.Token,
+		Visibility: convertVisibilityFromStore(incomingWebhook.Visibility),
+		Tags:       incomingWebhook.Tags,
 	}
 }
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -33,11 +34,53 @@ func (s *APIV2Service) GetWorkspaceProfile(ctx context.Context, _ *apiv2pb.GetWo
 	workspaceProfile.DisablePasswordLogin = generalSetting.DisallowPasswordLogin
 	workspaceProfile.AdditionalStyle = generalSetting.AdditionalStyle
 	workspaceProfile.AdditionalScript = generalSetting.AdditionalScript
+	workspaceProfile.InviteOnlySignup = generalSetting.InviteOnlySignup
+	integrationSetting, err := s.Store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace integration setting: %v", err)
+	}
+	if guestCommentSetting := integrationSetting.GetGuestComment(); guestCommentSetting.GetEnabled() {
+		workspaceProfile.GuestCommentsEnabled = true
+		workspaceProfile.CaptchaProvider = guestCommentSetting.CaptchaProvider
+		workspaceProfile.CaptchaSiteKey = guestCommentSetting.CaptchaSiteKey
+	}
+	brandingSetting, err := s.Store.GetWorkspaceBrandingSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace branding setting: %v", err)
+	}
+	workspaceProfile.Title = brandingSetting.Title
+	workspaceProfile.Description = brandingSetting.Description
+	workspaceProfile.AccentColor = brandingSetting.AccentColor
+	workspaceProfile.CustomFooter = brandingSetting.CustomFooter
+	workspaceProfile.CustomCss = brandingSetting.CustomCss
+	if brandingSetting.LogoResourceId != 0 {
+		logo, err := s.Store.GetResource(ctx, &store.FindResource{ID: &brandingSetting.LogoResourceId})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get logo resource: %v", err)
+		}
+		// The logo is left out if its resource is deleted.
+		if logo != nil {
+			workspaceProfile.LogoUrl = "/o/r/" + logo.UID
+		}
+	}
 	return &apiv2pb.GetWorkspaceProfileResponse{
 		WorkspaceProfile: workspaceProfile,
 	}, nil
 }
 
+func (s *APIV2Service) GetWorkspaceAnnouncement(ctx context.Context, _ *apiv2pb.GetWorkspaceAnnouncementRequest) (*apiv2pb.GetWorkspaceAnnouncementResponse, error) {
+	announcementSetting, err := s.Store.GetWorkspaceAnnouncementSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace announcement setting: %v", err)
+	}
+	response := &apiv2pb.GetWorkspaceAnnouncementResponse{}
+	if announcementSetting.Content == "" || (announcementSetting.ExpireTs != 0 && announcementSetting.ExpireTs <= time.Now().Unix()) {
+		return response, nil
+	}
+	response.Announcement = convertWorkspaceAnnouncementSettingFromStore(announcementSetting)
+	return response, nil
+}
+
 func (s *APIV2Service) GetInstanceOwner(ctx context.Context) (*apiv2pb.User, error) {
 	if ownerCache != nil {
 		return ownerCache, nil
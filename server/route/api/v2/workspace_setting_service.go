@@ -3,21 +3,56 @@ package v2
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/cron"
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/captcha"
 	apiv2pb "github.com/usememos/memos/proto/gen/api/v2"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
 	"github.com/usememos/memos/store"
 )
 
+const (
+	// maxAnnouncementLength is the max number of the characters of the announcement.
+	maxAnnouncementLength = 4096
+	// maxBrandingTitleLength is the max number of the characters of the branded title.
+	maxBrandingTitleLength = 64
+	// maxBrandingDescriptionLength is the max number of the characters of the branded description.
+	maxBrandingDescriptionLength = 512
+	// maxBrandingFooterLength is the max number of the characters of the branded footer.
+	maxBrandingFooterLength = 4096
+	// maxBrandingCSSLength is the max number of the characters of the branded style sheet.
+	maxBrandingCSSLength = 65536
+	// maxHookTimeoutSeconds is the max timeout of a run of a hook.
+	maxHookTimeoutSeconds = 60
+)
+
 func (s *APIV2Service) GetWorkspaceSetting(ctx context.Context, request *apiv2pb.GetWorkspaceSettingRequest) (*apiv2pb.GetWorkspaceSettingResponse, error) {
 	settingKeyString, err := ExtractWorkspaceSettingKeyFromName(request.Name)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid workspace setting name: %v", err)
 	}
 	settingKey := storepb.WorkspaceSettingKey(storepb.WorkspaceSettingKey_value[settingKeyString])
+	// The integration setting has the secrets of the integrations, so it's only visible to the host, like the scheduler, explore and hook settings.
+	if settingKey == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_INTEGRATION || settingKey == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_SCHEDULER || settingKey == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_EXPLORE || settingKey == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_HOOK {
+		user, err := getCurrentUser(ctx, s.Store)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+		}
+		if user == nil || user.Role != store.RoleHost {
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		}
+	}
 	workspaceSetting, err := s.Store.GetWorkspaceSettingV1(ctx, &store.FindWorkspaceSettingV1{
 		Key: settingKey,
 	})
@@ -46,30 +81,319 @@ func (s *APIV2Service) SetWorkspaceSetting(ctx context.Context, request *apiv2pb
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	if _, err := s.Store.UpsertWorkspaceSettingV1(ctx, convertWorkspaceSettingToStore(request.Setting)); err != nil {
+	if storageSetting := request.Setting.GetStorageSetting(); storageSetting != nil {
+		if storageSetting.ImageQuality < 0 || storageSetting.ImageQuality > 100 {
+			return nil, status.Errorf(codes.InvalidArgument, "image quality must be between 1 and 100")
+		}
+		if storageSetting.ImageCompressionThresholdMib < 0 || storageSetting.ImageMaxDimension < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "image compression threshold and max dimension must not be negative")
+		}
+		if uploadScanner := storageSetting.UploadScanner; uploadScanner != nil && uploadScanner.Type != apiv2pb.UploadScannerSetting_TYPE_UNSPECIFIED && uploadScanner.Address == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "upload scanner address is required")
+		}
+		if ocr := storageSetting.Ocr; ocr != nil && ocr.Engine == apiv2pb.OCRSetting_HTTP && ocr.Address == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "OCR service endpoint is required")
+		}
+		for _, restriction := range storageSetting.UploadRestrictions {
+			if restriction.Role == apiv2pb.User_ROLE_UNSPECIFIED && restriction.User == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "upload restriction role or user is required")
+			}
+			if restriction.User != "" {
+				if _, err := ExtractUserIDFromName(restriction.User); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid upload restriction user: %v", err)
+				}
+			}
+			if restriction.MaxUploadSizeMib < 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "max upload size must not be negative")
+			}
+		}
+	}
+
+	if slackSetting := request.Setting.GetIntegrationSetting().GetSlack(); slackSetting != nil {
+		if (slackSetting.PublicMemoChannel != "" || slackSetting.UnfurlLinks) && slackSetting.BotToken == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "slack bot token is required to post or unfurl memos")
+		}
+	}
+	if discordSetting := request.Setting.GetIntegrationSetting().GetDiscord(); discordSetting != nil {
+		guildIDs := map[string]bool{}
+		for _, guild := range discordSetting.Guilds {
+			if guild.GuildId == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "discord guild id is required")
+			}
+			if guildIDs[guild.GuildId] {
+				return nil, status.Errorf(codes.InvalidArgument, "duplicate discord guild %s", guild.GuildId)
+			}
+			guildIDs[guild.GuildId] = true
+		}
+	}
+	if emailIngestionSetting := request.Setting.GetIntegrationSetting().GetEmailIngestion(); emailIngestionSetting != nil {
+		if strings.ContainsAny(emailIngestionSetting.Domain, "@ ") {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid email ingestion domain %q", emailIngestionSetting.Domain)
+		}
+	}
+	if smtpSetting := request.Setting.GetIntegrationSetting().GetSmtp(); smtpSetting != nil && smtpSetting.Host != "" {
+		if smtpSetting.Port <= 0 || smtpSetting.Port > 65535 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid smtp port %d", smtpSetting.Port)
+		}
+		if !util.ValidateEmail(smtpSetting.FromEmail) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid smtp from email %q", smtpSetting.FromEmail)
+		}
+	}
+
+	if aiSetting := request.Setting.GetIntegrationSetting().GetAi(); aiSetting != nil && aiSetting.Endpoint != "" {
+		if endpoint, err := url.Parse(aiSetting.Endpoint); err != nil || endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid AI endpoint %q", aiSetting.Endpoint)
+		}
+		if aiSetting.SuggestTags && aiSetting.ChatModel == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "AI chat model is required to suggest tags")
+		}
+	}
+
+	if guestCommentSetting := request.Setting.GetIntegrationSetting().GetGuestComment(); guestCommentSetting != nil && guestCommentSetting.Enabled {
+		// The guests are anonymous, so they're kept from spamming the memos by the captcha.
+		if !slices.Contains(captcha.Providers, guestCommentSetting.CaptchaProvider) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid captcha provider %q", guestCommentSetting.CaptchaProvider)
+		}
+		if guestCommentSetting.CaptchaSiteKey == "" || guestCommentSetting.CaptchaSecretKey == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "captcha site key and secret key are required to enable guest comments")
+		}
+		if guestCommentSetting.MaxCommentsPerHour < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "max comments per hour must not be negative")
+		}
+	}
+
+	if schedulerSetting := request.Setting.GetSchedulerSetting(); schedulerSetting != nil {
+		if _, err := time.LoadLocation(schedulerSetting.Timezone); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid scheduler timezone %q", schedulerSetting.Timezone)
+		}
+		taskNames := map[string]bool{}
+		for _, task := range schedulerSetting.Tasks {
+			if task.Name == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "scheduled task name is required")
+			}
+			if taskNames[task.Name] {
+				return nil, status.Errorf(codes.InvalidArgument, "duplicate scheduled task %s", task.Name)
+			}
+			taskNames[task.Name] = true
+			if task.Cron != "" {
+				if _, err := cron.NewSchedule(task.Cron); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid cron expression %q of task %s: %v", task.Cron, task.Name, err)
+				}
+			}
+		}
+	}
+
+	if announcementSetting := request.Setting.GetAnnouncementSetting(); announcementSetting != nil {
+		if utf8.RuneCountInString(announcementSetting.Content) > maxAnnouncementLength {
+			return nil, status.Errorf(codes.InvalidArgument, "announcement is longer than %d characters", maxAnnouncementLength)
+		}
+		if _, ok := apiv2pb.WorkspaceAnnouncementSetting_Severity_name[int32(announcementSetting.Severity)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid announcement severity %d", announcementSetting.Severity)
+		}
+		if announcementSetting.ExpireTime != nil && !announcementSetting.ExpireTime.AsTime().After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "announcement expire time must be in the future")
+		}
+	}
+
+	if exploreSetting := request.Setting.GetExploreSetting(); exploreSetting != nil {
+		for _, excludedUser := range exploreSetting.ExcludedUsers {
+			if _, err := ExtractUserIDFromName(excludedUser); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid excluded user: %v", err)
+			}
+		}
+		for _, excludedTag := range exploreSetting.ExcludedTags {
+			tag := strings.TrimPrefix(excludedTag, "#")
+			if tag == "" || strings.ContainsAny(tag, " \t\n#") {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid excluded tag %q", excludedTag)
+			}
+		}
+	}
+
+	if brandingSetting := request.Setting.GetBrandingSetting(); brandingSetting != nil {
+		if err := s.validateWorkspaceBrandingSetting(ctx, brandingSetting); err != nil {
+			return nil, err
+		}
+	}
+
+	if hookSetting := request.Setting.GetHookSetting(); hookSetting != nil {
+		if err := s.validateWorkspaceHookSetting(hookSetting); err != nil {
+			return nil, err
+		}
+	}
+
+	workspaceSetting := convertWorkspaceSettingToStore(request.Setting)
+	if announcementSetting := workspaceSetting.GetAnnouncement(); announcementSetting != nil {
+		announcementSetting.UpdatedTs = time.Now().Unix()
+	}
+	if _, err := s.Store.UpsertWorkspaceSettingV1(ctx, workspaceSetting); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upsert workspace setting: %v", err)
 	}
 
 	return &apiv2pb.SetWorkspaceSettingResponse{}, nil
 }
 
+// validateWorkspaceBrandingSetting returns an error if the branding setting is invalid.
+// The logo is served to the visitors, so it must be an image which isn't attached to a memo.
+func (s *APIV2Service) validateWorkspaceBrandingSetting(ctx context.Context, setting *apiv2pb.WorkspaceBrandingSetting) error {
+	if utf8.RuneCountInString(setting.Title) > maxBrandingTitleLength {
+		return status.Errorf(codes.InvalidArgument, "title is longer than %d characters", maxBrandingTitleLength)
+	}
+	if utf8.RuneCountInString(setting.Description) > maxBrandingDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description is longer than %d characters", maxBrandingDescriptionLength)
+	}
+	if utf8.RuneCountInString(setting.CustomFooter) > maxBrandingFooterLength {
+		return status.Errorf(codes.InvalidArgument, "custom footer is longer than %d characters", maxBrandingFooterLength)
+	}
+	if len(setting.CustomCss) > maxBrandingCSSLength {
+		return status.Errorf(codes.InvalidArgument, "custom css is longer than %d bytes", maxBrandingCSSLength)
+	}
+	if setting.AccentColor != "" && !accentColorPattern.MatchString(setting.AccentColor) {
+		return status.Errorf(codes.InvalidArgument, "invalid accent color: %s", setting.AccentColor)
+	}
+	if setting.Logo == "" {
+		return nil
+	}
+	resourceID, err := ExtractResourceIDFromName(setting.Logo)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid logo: %v", err)
+	}
+	resource, err := s.Store.GetResource(ctx, &store.FindResource{ID: &resourceID})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get resource: %v", err)
+	}
+	if resource == nil {
+		return status.Errorf(codes.NotFound, "logo resource not found")
+	}
+	if !strings.HasPrefix(resource.Type, "image/") {
+		return status.Errorf(codes.InvalidArgument, "logo must be an image")
+	}
+	if resource.MemoID != nil {
+		return status.Errorf(codes.InvalidArgument, "logo must not be attached to a memo")
+	}
+	return nil
+}
+
+// validateWorkspaceHookSetting returns an error if a hook is invalid.
+// The EXEC hooks can only run the executables in the hooks directory of the data directory.
+func (s *APIV2Service) validateWorkspaceHookSetting(setting *apiv2pb.WorkspaceHookSetting) error {
+	names := map[string]bool{}
+	for _, hook := range setting.Hooks {
+		if hook.Name == "" {
+			return status.Errorf(codes.InvalidArgument, "hook name is required")
+		}
+		if names[hook.Name] {
+			return status.Errorf(codes.InvalidArgument, "duplicate hook %s", hook.Name)
+		}
+		names[hook.Name] = true
+		if len(hook.Events) == 0 {
+			return status.Errorf(codes.InvalidArgument, "events of hook %s are required", hook.Name)
+		}
+		for _, event := range hook.Events {
+			if _, ok := apiv2pb.WorkspaceHook_Event_name[int32(event)]; !ok || event == apiv2pb.WorkspaceHook_EVENT_UNSPECIFIED {
+				return status.Errorf(codes.InvalidArgument, "invalid event %d of hook %s", event, hook.Name)
+			}
+		}
+		if hook.TimeoutSeconds < 0 || hook.TimeoutSeconds > maxHookTimeoutSeconds {
+			return status.Errorf(codes.InvalidArgument, "timeout of hook %s must be at most %d seconds", hook.Name, maxHookTimeoutSeconds)
+		}
+		switch hook.Type {
+		case apiv2pb.WorkspaceHook_HTTP:
+			hookURL, err := url.Parse(hook.Url)
+			if err != nil || (hookURL.Scheme != "http" && hookURL.Scheme != "https") || hookURL.Host == "" {
+				return status.Errorf(codes.InvalidArgument, "invalid url of hook %s", hook.Name)
+			}
+		case apiv2pb.WorkspaceHook_EXEC:
+			if _, err := hookrunner.GetCommandPath(s.Profile, hook.Command); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid command of hook %s: %v", hook.Name, err)
+			}
+		default:
+			return status.Errorf(codes.InvalidArgument, "invalid type of hook %s", hook.Name)
+		}
+	}
+	return nil
+}
+
 func convertWorkspaceSettingFromStore(setting *storepb.WorkspaceSetting) *apiv2pb.WorkspaceSetting {
-	return &apiv2pb.WorkspaceSetting{
+	workspaceSetting := &apiv2pb.WorkspaceSetting{
 		Name: fmt.Sprintf("%s%s", WorkspaceSettingNamePrefix, setting.Key.String()),
-		Value: &apiv2pb.WorkspaceSetting_GeneralSetting{
+	}
+	switch setting.Value.(type) {
+	case *storepb.WorkspaceSetting_General:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_GeneralSetting{
 			GeneralSetting: convertWorkspaceGeneralSettingFromStore(setting.GetGeneral()),
-		},
+		}
+	case *storepb.WorkspaceSetting_Storage:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_StorageSetting{
+			StorageSetting: convertWorkspaceStorageSettingFromStore(setting.GetStorage()),
+		}
+	case *storepb.WorkspaceSetting_Integration:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_IntegrationSetting{
+			IntegrationSetting: convertWorkspaceIntegrationSettingFromStore(setting.GetIntegration()),
+		}
+	case *storepb.WorkspaceSetting_Scheduler:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_SchedulerSetting{
+			SchedulerSetting: convertWorkspaceSchedulerSettingFromStore(setting.GetScheduler()),
+		}
+	case *storepb.WorkspaceSetting_Announcement:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_AnnouncementSetting{
+			AnnouncementSetting: convertWorkspaceAnnouncementSettingFromStore(setting.GetAnnouncement()),
+		}
+	case *storepb.WorkspaceSetting_Explore:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_ExploreSetting{
+			ExploreSetting: convertWorkspaceExploreSettingFromStore(setting.GetExplore()),
+		}
+	case *storepb.WorkspaceSetting_Branding:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_BrandingSetting{
+			BrandingSetting: convertWorkspaceBrandingSettingFromStore(setting.GetBranding()),
+		}
+	case *storepb.WorkspaceSetting_Hook:
+		workspaceSetting.Value = &apiv2pb.WorkspaceSetting_HookSetting{
+			HookSetting: convertWorkspaceHookSettingFromStore(setting.GetHook()),
+		}
 	}
+	return workspaceSetting
 }
 
 func convertWorkspaceSettingToStore(setting *apiv2pb.WorkspaceSetting) *storepb.WorkspaceSetting {
 	settingKeyString, _ := ExtractWorkspaceSettingKeyFromName(setting.Name)
-	return &storepb.WorkspaceSetting{
+	workspaceSetting := &storepb.WorkspaceSetting{
 		Key: storepb.WorkspaceSettingKey(storepb.WorkspaceSettingKey_value[settingKeyString]),
-		Value: &storepb.WorkspaceSetting_General{
+	}
+	switch setting.Value.(type) {
+	case *apiv2pb.WorkspaceSetting_GeneralSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_General{
 			General: convertWorkspaceGeneralSettingToStore(setting.GetGeneralSetting()),
-		},
+		}
+	case *apiv2pb.WorkspaceSetting_StorageSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Storage{
+			Storage: convertWorkspaceStorageSettingToStore(setting.GetStorageSetting()),
+		}
+	case *apiv2pb.WorkspaceSetting_IntegrationSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Integration{
+			Integration: convertWorkspaceIntegrationSettingToStore(setting.GetIntegrationSetting()),
+		}
+	case *apiv2pb.WorkspaceSetting_SchedulerSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Scheduler{
+			Scheduler: convertWorkspaceSchedulerSettingToStore(setting.GetSchedulerSetting()),
+		}
+	case *apiv2pb.WorkspaceSetting_AnnouncementSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Announcement{
+			Announcement: convertWorkspaceAnnouncementSettingToStore(setting.GetAnnouncementSetting()),
+		}
+	case *apiv2pb.WorkspaceSetting_ExploreSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Explore{
+			Explore: convertWorkspaceExploreSettingToStore(setting.GetExploreSetting()),
+		}
+	case *apiv2pb.WorkspaceSetting_BrandingSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Branding{
+			Branding: convertWorkspaceBrandingSettingToStore(setting.GetBrandingSetting()),
+		}
+	case *apiv2pb.WorkspaceSetting_HookSetting:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_Hook{
+			Hook: convertWorkspaceHookSettingToStore(setting.GetHookSetting()),
+		}
 	}
+	return workspaceSetting
 }
 
 func convertWorkspaceGeneralSettingFromStore(setting *storepb.WorkspaceGeneralSetting) *apiv2pb.WorkspaceGeneralSetting {
@@ -82,6 +406,8 @@ func convertWorkspaceGeneralSettingFromStore(setting *storepb.WorkspaceGeneralSe
 		DisallowPasswordLogin: setting.DisallowPasswordLogin,
 		AdditionalScript:      setting.AdditionalScript,
 		AdditionalStyle:       setting.AdditionalStyle,
+		InviteOnlySignup:      setting.InviteOnlySignup,
+		AllowUserInvitations:  setting.AllowUserInvitations,
 	}
 }
 
@@ -95,5 +421,415 @@ func convertWorkspaceGeneralSettingToStore(setting *apiv2pb.WorkspaceGeneralSett
 		DisallowPasswordLogin: setting.DisallowPasswordLogin,
 		AdditionalScript:      setting.AdditionalScript,
 		AdditionalStyle:       setting.AdditionalStyle,
+		InviteOnlySignup:      setting.InviteOnlySignup,
+		AllowUserInvitations:  setting.AllowUserInvitations,
+	}
+}
+
+func convertWorkspaceStorageSettingFromStore(setting *storepb.WorkspaceStorageSetting) *apiv2pb.WorkspaceStorageSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceStorageSetting := &apiv2pb.WorkspaceStorageSetting{
+		ImageCompressionThresholdMib: setting.ImageCompressionThresholdMib,
+		ImageQuality:                 setting.ImageQuality,
+		ImageMaxDimension:            setting.ImageMaxDimension,
+	}
+	if setting.UploadScanner != nil {
+		workspaceStorageSetting.UploadScanner = &apiv2pb.UploadScannerSetting{
+			Type:     apiv2pb.UploadScannerSetting_Type(setting.UploadScanner.Type),
+			Address:  setting.UploadScanner.Address,
+			FailOpen: setting.UploadScanner.FailOpen,
+		}
+	}
+	if setting.Ocr != nil {
+		workspaceStorageSetting.Ocr = &apiv2pb.OCRSetting{
+			Engine:   apiv2pb.OCRSetting_Engine(setting.Ocr.Engine),
+			Address:  setting.Ocr.Address,
+			Language: setting.Ocr.Language,
+		}
+	}
+	for _, restriction := range setting.UploadRestrictions {
+		uploadRestriction := &apiv2pb.UploadRestriction{
+			AllowedMimeTypes: restriction.AllowedMimeTypes,
+			MaxUploadSizeMib: restriction.MaxUploadSizeMib,
+		}
+		if restriction.Role != "" {
+			uploadRestriction.Role = convertUserRoleFromStore(store.Role(restriction.Role))
+		}
+		if restriction.UserId != 0 {
+			uploadRestriction.User = fmt.Sprintf("%s%d", UserNamePrefix, restriction.UserId)
+		}
+		workspaceStorageSetting.UploadRestrictions = append(workspaceStorageSetting.UploadRestrictions, uploadRestriction)
+	}
+	return workspaceStorageSetting
+}
+
+func convertWorkspaceStorageSettingToStore(setting *apiv2pb.WorkspaceStorageSetting) *storepb.WorkspaceStorageSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceStorageSetting := &storepb.WorkspaceStorageSetting{
+		ImageCompressionThresholdMib: setting.ImageCompressionThresholdMib,
+		ImageQuality:                 setting.ImageQuality,
+		ImageMaxDimension:            setting.ImageMaxDimension,
+	}
+	if setting.UploadScanner != nil {
+		workspaceStorageSetting.UploadScanner = &storepb.UploadScannerSetting{
+			Type:     storepb.UploadScannerSetting_Type(setting.UploadScanner.Type),
+			Address:  setting.UploadScanner.Address,
+			FailOpen: setting.UploadScanner.FailOpen,
+		}
+	}
+	if setting.Ocr != nil {
+		workspaceStorageSetting.Ocr = &storepb.OCRSetting{
+			Engine:   storepb.OCRSetting_Engine(setting.Ocr.Engine),
+			Address:  setting.Ocr.Address,
+			Language: setting.Ocr.Language,
+		}
+	}
+	for _, restriction := range setting.UploadRestrictions {
+		uploadRestriction := &storepb.UploadRestriction{
+			AllowedMimeTypes: restriction.AllowedMimeTypes,
+			MaxUploadSizeMib: restriction.MaxUploadSizeMib,
+		}
+		if restriction.Role != apiv2pb.User_ROLE_UNSPECIFIED {
+			uploadRestriction.Role = convertUserRoleToStore(restriction.Role).String()
+		}
+		if restriction.User != "" {
+			// The user name is validated before the setting is saved.
+			uploadRestriction.UserId, _ = ExtractUserIDFromName(restriction.User)
+		}
+		workspaceStorageSetting.UploadRestrictions = append(workspaceStorageSetting.UploadRestrictions, uploadRestriction)
+	}
+	return workspaceStorageSetting
+}
+
+func convertWorkspaceIntegrationSettingFromStore(setting *storepb.WorkspaceIntegrationSetting) *apiv2pb.WorkspaceIntegrationSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceIntegrationSetting := &apiv2pb.WorkspaceIntegrationSetting{}
+	if setting.Slack != nil {
+		workspaceIntegrationSetting.Slack = &apiv2pb.SlackSetting{
+			SigningSecret:     setting.Slack.SigningSecret,
+			BotToken:          setting.Slack.BotToken,
+			PublicMemoChannel: setting.Slack.PublicMemoChannel,
+			UnfurlLinks:       setting.Slack.UnfurlLinks,
+		}
+	}
+	if setting.Discord != nil {
+		workspaceIntegrationSetting.Discord = &apiv2pb.DiscordSetting{
+			BotToken: setting.Discord.BotToken,
+		}
+		for _, guild := range setting.Discord.Guilds {
+			workspaceIntegrationSetting.Discord.Guilds = append(workspaceIntegrationSetting.Discord.Guilds, &apiv2pb.DiscordGuildSetting{
+				GuildId:             guild.GuildId,
+				CommandPrefix:       guild.CommandPrefix,
+				CaptureEmoji:        guild.CaptureEmoji,
+				PublicMemoChannelId: guild.PublicMemoChannelId,
+			})
+		}
+	}
+	if setting.EmailIngestion != nil {
+		workspaceIntegrationSetting.EmailIngestion = &apiv2pb.EmailIngestionSetting{
+			Domain:          setting.EmailIngestion.Domain,
+			SenderMustMatch: setting.EmailIngestion.SenderMustMatch,
+		}
+	}
+	if setting.Smtp != nil {
+		workspaceIntegrationSetting.Smtp = &apiv2pb.SMTPSetting{
+			Host:      setting.Smtp.Host,
+			Port:      setting.Smtp.Port,
+			Username:  setting.Smtp.Username,
+			Password:  setting.Smtp.Password,
+			FromEmail: setting.Smtp.FromEmail,
+			FromName:  setting.Smtp.FromName,
+			Security:  apiv2pb.SMTPSetting_Security(setting.Smtp.Security),
+		}
+	}
+	if setting.Webdav != nil {
+		workspaceIntegrationSetting.Webdav = &apiv2pb.WebDAVSetting{
+			Enabled: setting.Webdav.Enabled,
+		}
+	}
+	if setting.Ai != nil {
+		workspaceIntegrationSetting.Ai = &apiv2pb.AISetting{
+			Endpoint:       setting.Ai.Endpoint,
+			ApiKey:         setting.Ai.ApiKey,
+			ChatModel:      setting.Ai.ChatModel,
+			EmbeddingModel: setting.Ai.EmbeddingModel,
+			SuggestTags:    setting.Ai.SuggestTags,
+		}
+	}
+	if setting.GuestComment != nil {
+		workspaceIntegrationSetting.GuestComment = &apiv2pb.GuestCommentSetting{
+			Enabled:            setting.GuestComment.Enabled,
+			CaptchaProvider:    setting.GuestComment.CaptchaProvider,
+			CaptchaSiteKey:     setting.GuestComment.CaptchaSiteKey,
+			CaptchaSecretKey:   setting.GuestComment.CaptchaSecretKey,
+			MaxCommentsPerHour: setting.GuestComment.MaxCommentsPerHour,
+		}
+	}
+	return workspaceIntegrationSetting
+}
+
+func convertWorkspaceIntegrationSettingToStore(setting *apiv2pb.WorkspaceIntegrationSetting) *storepb.WorkspaceIntegrationSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceIntegrationSetting := &storepb.WorkspaceIntegrationSetting{}
+	if setting.Slack != nil {
+		workspaceIntegrationSetting.Slack = &storepb.SlackSetting{
+			SigningSecret:     setting.Slack.SigningSecret,
+			BotToken:          setting.Slack.BotToken,
+			PublicMemoChannel: setting.Slack.PublicMemoChannel,
+			UnfurlLinks:       setting.Slack.UnfurlLinks,
+		}
+	}
+	if setting.Discord != nil {
+		workspaceIntegrationSetting.Discord = &storepb.DiscordSetting{
+			BotToken: setting.Discord.BotToken,
+		}
+		for _, guild := range setting.Discord.Guilds {
+			workspaceIntegrationSetting.Discord.Guilds = append(workspaceIntegrationSetting.Discord.Guilds, &storepb.DiscordGuildSetting{
+				GuildId:             guild.GuildId,
+				CommandPrefix:       guild.CommandPrefix,
+				CaptureEmoji:        guild.CaptureEmoji,
+				PublicMemoChannelId: guild.PublicMemoChannelId,
+			})
+		}
+	}
+	if setting.EmailIngestion != nil {
+		workspaceIntegrationSetting.EmailIngestion = &storepb.EmailIngestionSetting{
+			Domain:          setting.EmailIngestion.Domain,
+			SenderMustMatch: setting.EmailIngestion.SenderMustMatch,
+		}
+	}
+	if setting.Smtp != nil {
+		workspaceIntegrationSetting.Smtp = &storepb.SMTPSetting{
+			Host:      setting.Smtp.Host,
+			Port:      setting.Smtp.Port,
+			Username:  setting.Smtp.Username,
+			Password:  setting.Smtp.Password,
+			FromEmail: setting.Smtp.FromEmail,
+			FromName:  setting.Smtp.FromName,
+			Security:  storepb.SMTPSetting_Security(setting.Smtp.Security),
+		}
+	}
+	if setting.Webdav != nil {
+		workspaceIntegrationSetting.Webdav = &storepb.WebDAVSetting{
+			Enabled: setting.Webdav.Enabled,
+		}
+	}
+	if setting.Ai != nil {
+		workspaceIntegrationSetting.Ai = &storepb.AISetting{
+			Endpoint:       setting.Ai.Endpoint,
+			ApiKey:         setting.Ai.ApiKey,
+			ChatModel:      setting.Ai.ChatModel,
+			EmbeddingModel: setting.Ai.EmbeddingModel,
+			SuggestTags:    setting.Ai.SuggestTags,
+		}
+	}
+	if setting.GuestComment != nil {
+		workspaceIntegrationSetting.GuestComment = &storepb.GuestCommentSetting{
+			Enabled:            setting.GuestComment.Enabled,
+			CaptchaProvider:    setting.GuestComment.CaptchaProvider,
+			CaptchaSiteKey:     setting.GuestComment.CaptchaSiteKey,
+			CaptchaSecretKey:   setting.GuestComment.CaptchaSecretKey,
+			MaxCommentsPerHour: setting.GuestComment.MaxCommentsPerHour,
+		}
+	}
+	return workspaceIntegrationSetting
+}
+
+func convertWorkspaceSchedulerSettingFromStore(setting *storepb.WorkspaceSchedulerSetting) *apiv2pb.WorkspaceSchedulerSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceSchedulerSetting := &apiv2pb.WorkspaceSchedulerSetting{
+		Timezone: setting.Timezone,
+	}
+	for _, task := range setting.Tasks {
+		workspaceSchedulerSetting.Tasks = append(workspaceSchedulerSetting.Tasks, &apiv2pb.ScheduledTask{
+			Name:     task.Name,
+			Cron:     task.Cron,
+			Disabled: task.Disabled,
+		})
+	}
+	return workspaceSchedulerSetting
+}
+
+func convertWorkspaceSchedulerSettingToStore(setting *apiv2pb.WorkspaceSchedulerSetting) *storepb.WorkspaceSchedulerSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceSchedulerSetting := &storepb.WorkspaceSchedulerSetting{
+		Timezone: setting.Timezone,
+	}
+	for _, task := range setting.Tasks {
+		workspaceSchedulerSetting.Tasks = append(workspaceSchedulerSetting.Tasks, &storepb.ScheduledTask{
+			Name:     task.Name,
+			Cron:     task.Cron,
+			Disabled: task.Disabled,
+		})
+	}
+	return workspaceSchedulerSetting
+}
+
+func convertWorkspaceAnnouncementSettingFromStore(setting *storepb.WorkspaceAnnouncementSetting) *apiv2pb.WorkspaceAnnouncementSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceAnnouncementSetting := &apiv2pb.WorkspaceAnnouncementSetting{
+		Content:     setting.Content,
+		Severity:    apiv2pb.WorkspaceAnnouncementSetting_Severity(setting.Severity),
+		Dismissible: setting.Dismissible,
+	}
+	if setting.ExpireTs != 0 {
+		workspaceAnnouncementSetting.ExpireTime = timestamppb.New(time.Unix(setting.ExpireTs, 0))
+	}
+	if setting.UpdatedTs != 0 {
+		workspaceAnnouncementSetting.UpdateTime = timestamppb.New(time.Unix(setting.UpdatedTs, 0))
+	}
+	return workspaceAnnouncementSetting
+}
+
+func convertWorkspaceAnnouncementSettingToStore(setting *apiv2pb.WorkspaceAnnouncementSetting) *storepb.WorkspaceAnnouncementSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceAnnouncementSetting := &storepb.WorkspaceAnnouncementSetting{
+		Content:     setting.Content,
+		Severity:    storepb.WorkspaceAnnouncementSetting_Severity(setting.Severity),
+		Dismissible: setting.Dismissible,
+	}
+	if setting.ExpireTime != nil {
+		workspaceAnnouncementSetting.ExpireTs = setting.ExpireTime.AsTime().Unix()
+	}
+	return workspaceAnnouncementSetting
+}
+
+func convertWorkspaceExploreSettingFromStore(setting *storepb.WorkspaceExploreSetting) *apiv2pb.WorkspaceExploreSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceExploreSetting := &apiv2pb.WorkspaceExploreSetting{
+		ExcludedUsers:   []string{},
+		ExcludedTags:    setting.ExcludedTags,
+		RequireApproval: setting.RequireApproval,
+	}
+	for _, userID := range setting.ExcludedUserIds {
+		workspaceExploreSetting.ExcludedUsers = append(workspaceExploreSetting.ExcludedUsers, fmt.Sprintf("%s%d", UserNamePrefix, userID))
+	}
+	return workspaceExploreSetting
+}
+
+func convertWorkspaceExploreSettingToStore(setting *apiv2pb.WorkspaceExploreSetting) *storepb.WorkspaceExploreSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceExploreSetting := &storepb.WorkspaceExploreSetting{
+		ExcludedUserIds: []int32{},
+		ExcludedTags:    []string{},
+		RequireApproval: setting.RequireApproval,
+	}
+	for _, excludedUser := range setting.ExcludedUsers {
+		// The names are validated before the setting is converted.
+		userID, _ := ExtractUserIDFromName(excludedUser)
+		workspaceExploreSetting.ExcludedUserIds = append(workspaceExploreSetting.ExcludedUserIds, userID)
+	}
+	for _, excludedTag := range setting.ExcludedTags {
+		workspaceExploreSetting.ExcludedTags = append(workspaceExploreSetting.ExcludedTags, strings.TrimPrefix(excludedTag, "#"))
+	}
+	return workspaceExploreSetting
+}
+
+func convertWorkspaceBrandingSettingFromStore(setting *storepb.WorkspaceBrandingSetting) *apiv2pb.WorkspaceBrandingSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceBrandingSetting := &apiv2pb.WorkspaceBrandingSetting{
+		Title:        setting.Title,
+		Description:  setting.Description,
+		AccentColor:  setting.AccentColor,
+		CustomFooter: setting.CustomFooter,
+		CustomCss:    setting.CustomCss,
+	}
+	if setting.LogoResourceId != 0 {
+		workspaceBrandingSetting.Logo = fmt.Sprintf("%s%d", ResourceNamePrefix, setting.LogoResourceId)
+	}
+	return workspaceBrandingSetting
+}
+
+func convertWorkspaceBrandingSettingToStore(setting *apiv2pb.WorkspaceBrandingSetting) *storepb.WorkspaceBrandingSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceBrandingSetting := &storepb.WorkspaceBrandingSetting{
+		Title:        strings.TrimSpace(setting.Title),
+		Description:  strings.TrimSpace(setting.Description),
+		AccentColor:  strings.ToLower(setting.AccentColor),
+		CustomFooter: setting.CustomFooter,
+		CustomCss:    setting.CustomCss,
+	}
+	if setting.Logo != "" {
+		// The logo is validated before the setting is converted.
+		workspaceBrandingSetting.LogoResourceId, _ = ExtractResourceIDFromName(setting.Logo)
+	}
+	return workspaceBrandingSetting
+}
+
+func convertWorkspaceHookSettingFromStore(setting *storepb.WorkspaceHookSetting) *apiv2pb.WorkspaceHookSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceHookSetting := &apiv2pb.WorkspaceHookSetting{
+		Hooks: []*apiv2pb.WorkspaceHook{},
+	}
+	for _, hook := range setting.Hooks {
+		workspaceHook := &apiv2pb.WorkspaceHook{
+			Name:           hook.Name,
+			Type:           apiv2pb.WorkspaceHook_Type(hook.Type),
+			Events:         []apiv2pb.WorkspaceHook_Event{},
+			Url:            hook.Url,
+			Secret:         hook.Secret,
+			Command:        hook.Command,
+			TimeoutSeconds: hook.TimeoutSeconds,
+			Required:       hook.Required,
+			Disabled:       hook.Disabled,
+		}
+		for _, event := range hook.Events {
+			workspaceHook.Events = append(workspaceHook.Events, apiv2pb.WorkspaceHook_Event(event))
+		}
+		workspaceHookSetting.Hooks = append(workspaceHookSetting.Hooks, workspaceHook)
+	}
+	return workspaceHookSetting
+}
+
+func convertWorkspaceHookSettingToStore(setting *apiv2pb.WorkspaceHookSetting) *storepb.WorkspaceHookSetting {
+	if setting == nil {
+		return nil
+	}
+	workspaceHookSetting := &storepb.WorkspaceHookSetting{
+		Hooks: []*storepb.WorkspaceHook{},
+	}
+	for _, hook := range setting.Hooks {
+		workspaceHook := &storepb.WorkspaceHook{
+			Name:           hook.Name,
+			Type:           storepb.WorkspaceHook_Type(hook.Type),
+			Events:         []storepb.WorkspaceHook_Event{},
+			Url:            hook.Url,
+			Secret:         hook.Secret,
+			Command:        hook.Command,
+			TimeoutSeconds: hook.TimeoutSeconds,
+			Required:       hook.Required,
+			Disabled:       hook.Disabled,
+		}
+		for _, event := range hook.Events {
+			workspaceHook.Events = append(workspaceHook.Events, storepb.WorkspaceHook_Event(event))
+		}
+		workspaceHookSetting.Hooks = append(workspaceHookSetting.Hooks, workspaceHook)
 	}
+	return workspaceHookSetting
 }
@@ -0,0 +1,385 @@
+// Package domain serves the public memos of the users on their verified custom domains, e.g. `notes.example.com`,
+// while the instance serves the app on its own hostname.
+package domain
+
+import (
+	"context"
+	"html/template"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourselfhosted/gomark/renderer"
+
+	"github.com/usememos/memos/internal/markdown"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// pathPrefix is the prefix of the routes the requests of the custom domains are rewritten to.
+	pathPrefix = "/_domain"
+	// contextUserKey is the key of the user of the custom domain of a request in the echo context.
+	contextUserKey = "domain-user"
+	// pageSize is the number of the memos on a page of the index.
+	pageSize = 20
+	// hostCacheTTL is the duration the user of a hostname is cached, including the hostnames which aren't custom domains.
+	hostCacheTTL = time.Minute
+	// maxHostCacheSize is the max number of the cached hostnames, so the hosts of the requests can't grow the cache forever.
+	maxHostCacheSize = 4096
+	// contentSecurityPolicy prevents the scripts on the pages, the content is rendered from the markdown of the memos as is.
+	contentSecurityPolicy = "default-src 'none'; img-src * data:; media-src *; style-src 'unsafe-inline'"
+)
+
+type hostCacheEntry struct {
+	// userID is the user of the custom domain, 0 if the hostname isn't a verified custom domain.
+	userID    int32
+	expiresAt time.Time
+}
+
+type DomainService struct {
+	Store *store.Store
+
+	mutex     sync.Mutex
+	hostCache map[string]*hostCacheEntry
+}
+
+func NewDomainService(store *store.Store) *DomainService {
+	return &DomainService{
+		Store:     store,
+		hostCache: map[string]*hostCacheEntry{},
+	}
+}
+
+// Register registers the routes of the pages and the middleware routing the requests of the custom domains to them.
+// The custom domains serve:
+//   - `/`: the public memos of the user, paginated by `?page=`.
+//   - `/m/:uid`: a public memo of the user, or a redirect to it if `uid` is the slug of the memo.
+//   - `/rss.xml` and `/atom.xml`: the feeds of the user.
+//   - `/o/r/:uid`: the resources of the memos.
+//
+// The other paths aren't found.
+func (s *DomainService) Register(e *echo.Echo) {
+	e.Pre(s.rewriteMiddleware)
+	g := e.Group(pathPrefix, s.requireDomainUser)
+	g.GET("", s.getIndexPage)
+	g.GET("/m/:uid", s.getMemoPage)
+	g.Any("/*", func(echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "Not found")
+	})
+}
+
+// rewriteMiddleware rewrites the paths of the requests of the custom domains, the other requests are passed as is.
+func (s *DomainService) rewriteMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := c.Request()
+		// The instance isn't reached on the routes of the pages, they're only of the custom domains.
+		if strings.HasPrefix(request.URL.Path, pathPrefix) {
+			request.URL.Path = pathPrefix + "/"
+		}
+		user, err := s.findHostUser(request.Context(), request.Host)
+		if err != nil {
+			slog.Warn("Failed to find the user of the custom domain", slog.String("host", request.Host), slog.Any("err", err))
+			return next(c)
+		}
+		if user == nil {
+			return next(c)
+		}
+
+		c.Set(contextUserKey, user)
+		switch path := request.URL.Path; {
+		case path == "/rss.xml" || path == "/atom.xml":
+			request.URL.Path = "/u/" + user.Username + path
+		case strings.HasPrefix(path, "/o/r/"):
+		default:
+			request.URL.Path = pathPrefix + strings.TrimSuffix(path, "/")
+		}
+		request.URL.RawPath = ""
+		return next(c)
+	}
+}
+
+// requireDomainUser lets only the requests of the custom domains reach the pages.
+func (*DomainService) requireDomainUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, ok := c.Get(contextUserKey).(*store.User); !ok {
+			return echo.NewHTTPError(http.StatusNotFound, "Not found")
+		}
+		c.Response().Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		return next(c)
+	}
+}
+
+// findHostUser returns the user of the verified custom domain of the host, nil if the host isn't one.
+func (s *DomainService) findHostUser(ctx context.Context, host string) (*store.User, error) {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	hostname := strings.TrimSuffix(strings.ToLower(host), ".")
+	// The instance is usually reached by an IP address or a name without a dot on the local networks.
+	if !strings.Contains(hostname, ".") || net.ParseIP(hostname) != nil {
+		return nil, nil
+	}
+
+	s.mutex.Lock()
+	entry, ok := s.hostCache[hostname]
+	s.mutex.Unlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		verified := true
+		domain, err := s.Store.GetUserDomain(ctx, &store.FindUserDomain{Hostname: &hostname, Verified: &verified})
+		if err != nil {
+			return nil, err
+		}
+		entry = &hostCacheEntry{expiresAt: time.Now().Add(hostCacheTTL)}
+		if domain != nil {
+			entry.userID = domain.UserID
+		}
+		s.mutex.Lock()
+		if len(s.hostCache) >= maxHostCacheSize {
+			s.hostCache = map[string]*hostCacheEntry{}
+		}
+		s.hostCache[hostname] = entry
+		s.mutex.Unlock()
+	}
+	if entry.userID == 0 {
+		return nil, nil
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &entry.userID})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return nil, nil
+	}
+	return user, nil
+}
+
+func (s *DomainService) getIndexPage(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get(contextUserKey).(*store.User)
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	normalStatus := store.Normal
+	// One more memo is found to know if there is a next page.
+	limit, offset := pageSize+1, (page-1)*pageSize
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		RowStatus:       &normalStatus,
+		CreatorID:       &user.ID,
+		VisibilityList:  []store.Visibility{store.Public},
+		ExcludeComments: true,
+		ExcludeHidden:   true,
+		OrderByPinned:   true,
+		Limit:           &limit,
+		Offset:          &offset,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memos").SetInternal(err)
+	}
+
+	data, err := s.newPageData(ctx, user)
+	if err != nil {
+		return err
+	}
+	if page == 1 {
+		pinnedMemos, err := s.Store.ListProfilePinnedMemos(ctx, user.ID, data.Profile.PinnedMemoIds)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list pinned memos").SetInternal(err)
+		}
+		for _, memo := range pinnedMemos {
+			data.PinnedMemos = append(data.PinnedMemos, newMemoData(memo))
+		}
+	}
+	if len(memos) > pageSize {
+		memos = memos[:pageSize]
+		data.NextPage = page + 1
+	}
+	if page > 1 {
+		data.PrevPage = page - 1
+	}
+	for _, memo := range memos {
+		data.Memos = append(data.Memos, newMemoData(memo))
+	}
+	return render(c, data)
+}
+
+func (s *DomainService) getMemoPage(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get(contextUserKey).(*store.User)
+	uid, normalStatus := c.Param("uid"), store.Normal
+	// The other memos of the instance aren't served on the domain of the user.
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		UID:            &uid,
+		RowStatus:      &normalStatus,
+		CreatorID:      &user.ID,
+		VisibilityList: []store.Visibility{store.Public},
+		ExcludeHidden:  true,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get memo").SetInternal(err)
+	}
+	if memo == nil {
+		return s.redirectSlugMemo(c, user, uid)
+	}
+	data, err := s.newPageData(ctx, user)
+	if err != nil {
+		return err
+	}
+	data.Memo = true
+	data.Memos = []*memoData{newMemoData(memo)}
+	return render(c, data)
+}
+
+// redirectSlugMemo redirects to the public memo of the slug of the user, and counts the click.
+func (s *DomainService) redirectSlugMemo(c echo.Context, user *store.User, slug string) error {
+	ctx := c.Request().Context()
+	memoSlug, err := s.Store.GetMemoSlug(ctx, &store.FindMemoSlug{Slug: &slug, CreatorID: &user.ID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get memo slug").SetInternal(err)
+	}
+	if memoSlug == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Memo not found")
+	}
+	normalStatus := store.Normal
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		ID:             &memoSlug.MemoID,
+		RowStatus:      &normalStatus,
+		VisibilityList: []store.Visibility{store.Public},
+		ExcludeHidden:  true,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get memo").SetInternal(err)
+	}
+	if memo == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Memo not found")
+	}
+	if err := s.Store.IncreaseMemoSlugClickCount(ctx, memo.ID); err != nil {
+		slog.Warn("Failed to increase memo slug click count", slog.Any("err", err))
+	}
+	return c.Redirect(http.StatusFound, "/m/"+memo.UID)
+}
+
+// newPageData returns the data of a page of the user, with the profile of the user.
+func (s *DomainService) newPageData(ctx context.Context, user *store.User) (*pageData, error) {
+	profile, err := s.Store.GetUserProfile(ctx, user.ID)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user profile").SetInternal(err)
+	}
+	return &pageData{
+		User:    user,
+		Profile: profile,
+		Bio:     renderMarkdown(profile.Bio),
+	}, nil
+}
+
+type pageData struct {
+	User    *store.User
+	Profile *storepb.ProfileUserSetting
+	Bio     template.HTML
+	// PinnedMemos are shown on the first page of the index.
+	PinnedMemos []*memoData
+	// Memo indicate the page is of a single memo or not.
+	Memo     bool
+	Memos    []*memoData
+	PrevPage int
+	NextPage int
+}
+
+type memoData struct {
+	UID       string
+	Content   template.HTML
+	CreatedAt time.Time
+}
+
+func newMemoData(memo *store.Memo) *memoData {
+	return &memoData{
+		UID:       memo.UID,
+		Content:   renderMarkdown(memo.Content),
+		CreatedAt: time.Unix(memo.CreatedTs, 0).UTC(),
+	}
+}
+
+// renderMarkdown returns the HTML of the markdown, or the escaped markdown if it fails to render.
+func renderMarkdown(content string) template.HTML {
+	html, err := markdown.Render("domain.html", content, func(content string) (string, error) {
+		nodes, err := markdown.Parse(content)
+		if err != nil {
+			return "", err
+		}
+		return renderer.NewHTMLRenderer().Render(nodes), nil
+	})
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(content))
+	}
+	return template.HTML(html)
+}
+
+func render(c echo.Context, data *pageData) error {
+	var builder strings.Builder
+	if err := pageTemplate.Execute(&builder, data); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render page").SetInternal(err)
+	}
+	return c.HTML(http.StatusOK, builder.String())
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html{{with .Profile.Theme}} data-theme="{{.}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.User.Nickname}}</title>
+<link rel="alternate" type="application/rss+xml" title="{{.User.Nickname}}" href="/rss.xml">
+<style>
+:root { color-scheme: light dark; --accent: {{or .Profile.AccentColor "#0ea5e9"}}; }
+html[data-theme="light"] { color-scheme: light; }
+html[data-theme="dark"] { color-scheme: dark; }
+body { max-width: 42rem; margin: 0 auto; padding: 1.5rem 1rem; font-family: system-ui, sans-serif; line-height: 1.6; }
+a { color: var(--accent); }
+header { margin-bottom: 1.5rem; }
+header h1 { margin: 0; font-size: 1.5rem; }
+header h1 a { color: inherit; text-decoration: none; }
+article { padding: 1rem 0; border-bottom: 1px solid #8884; overflow-wrap: break-word; }
+.links { display: flex; flex-wrap: wrap; gap: 0.75rem; padding: 0; list-style: none; }
+.pinned { border-left: 3px solid var(--accent); padding-left: 0.75rem; }
+article img { max-width: 100%; }
+article pre { overflow-x: auto; }
+time, nav, .description { opacity: 0.7; font-size: 0.875rem; }
+nav { display: flex; justify-content: space-between; padding-top: 1rem; }
+</style>
+</head>
+<body>
+<header>
+<h1><a href="/">{{.User.Nickname}}</a></h1>
+{{with .User.Description}}<p class="description">{{.}}</p>{{end}}
+{{if not .Memo}}{{.Bio}}
+{{with .Profile.Links}}<ul class="links">
+{{range .}}<li><a href="{{.Url}}" rel="me noopener">{{.Title}}</a></li>
+{{end}}</ul>{{end}}{{end}}
+</header>
+<main>
+{{range .PinnedMemos}}<article class="pinned">
+<a href="/m/{{.UID}}"><time datetime="{{.CreatedAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.CreatedAt.Format "2006-01-02 15:04"}}</time></a>
+{{.Content}}
+</article>
+{{end}}{{range .Memos}}<article>
+<a href="/m/{{.UID}}"><time datetime="{{.CreatedAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.CreatedAt.Format "2006-01-02 15:04"}}</time></a>
+{{.Content}}
+</article>
+{{else}}<p>No memos yet.</p>
+{{end}}
+</main>
+{{if not .Memo}}<nav>
+<span>{{if .PrevPage}}<a href="/?page={{.PrevPage}}">Newer</a>{{end}}</span>
+<span>{{if .NextPage}}<a href="/?page={{.NextPage}}">Older</a>{{end}}</span>
+</nav>{{end}}
+</body>
+</html>
+`))
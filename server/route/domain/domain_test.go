@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestDomainService(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "alice", Nickname: "Alice", Role: store.RoleUser})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "bob", Nickname: "Bob", Role: store.RoleUser})
+	require.NoError(t, err)
+	verifiedTs := int64(1700000000)
+	_, err = ts.CreateUserDomain(ctx, &store.UserDomain{UserID: user.ID, Hostname: "notes.example.com", VerificationToken: "token", VerifiedTs: verifiedTs})
+	require.NoError(t, err)
+	_, err = ts.CreateUserDomain(ctx, &store.UserDomain{UserID: other.ID, Hostname: "unverified.example.com", VerificationToken: "token"})
+	require.NoError(t, err)
+	memoIDs := []int32{}
+	for _, memo := range []*store.Memo{
+		{UID: "public", CreatorID: user.ID, Content: "**public** memo", Visibility: store.Public},
+		{UID: "private", CreatorID: user.ID, Content: "private memo", Visibility: store.Private},
+		{UID: "other", CreatorID: other.ID, Content: "memo of bob", Visibility: store.Public},
+	} {
+		memo, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_PROFILE,
+		Value: &storepb.UserSetting_Profile{Profile: &storepb.ProfileUserSetting{
+			Bio:   "I *write* things",
+			Links: []*storepb.ProfileUserSetting_Link{{Title: "Website", Url: "https://alice.example.com"}},
+			// The private memo and the memo of another user aren't shown.
+			PinnedMemoIds: memoIDs,
+			Theme:         "dark",
+			AccentColor:   "#ff0066",
+		}},
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	NewDomainService(ts).Register(e)
+	e.GET("/u/:username/rss.xml", func(c echo.Context) error {
+		return c.String(http.StatusOK, "feed of "+c.Param("username"))
+	})
+	e.GET("/*", func(c echo.Context) error {
+		return c.String(http.StatusOK, "app")
+	})
+	serve := func(host, path string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		request.Host = host
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve("notes.example.com", "/")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "<strong>public</strong> memo")
+	require.NotContains(t, recorder.Body.String(), "private memo")
+	require.NotContains(t, recorder.Body.String(), "memo of bob")
+	require.Contains(t, recorder.Body.String(), "I <em>write</em> things")
+	require.Contains(t, recorder.Body.String(), `<a href="https://alice.example.com" rel="me noopener">Website</a>`)
+	require.Contains(t, recorder.Body.String(), `<article class="pinned">`)
+	require.Contains(t, recorder.Body.String(), `data-theme="dark"`)
+	require.Contains(t, recorder.Body.String(), "--accent: #ff0066;")
+	require.Equal(t, contentSecurityPolicy, recorder.Header().Get("Content-Security-Policy"))
+
+	recorder = serve("Notes.Example.com:443", "/m/public")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "<strong>public</strong> memo")
+	require.Equal(t, http.StatusNotFound, serve("notes.example.com", "/m/private").Code)
+	require.Equal(t, http.StatusNotFound, serve("notes.example.com", "/m/other").Code)
+	require.Equal(t, http.StatusNotFound, serve("notes.example.com", "/explore").Code)
+	require.Equal(t, "feed of alice", serve("notes.example.com", "/rss.xml").Body.String())
+
+	// The other hosts are served the app, and can't reach the pages of the domains.
+	require.Equal(t, "app", serve("memos.example.com", "/").Body.String())
+	require.Equal(t, "app", serve("unverified.example.com", "/").Body.String())
+	require.Equal(t, "app", serve("localhost:8081", "/m/public").Body.String())
+	require.Equal(t, http.StatusNotFound, serve("memos.example.com", "/_domain/m/public").Code)
+}
@@ -3,16 +3,20 @@ package frontend
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"mime"
 	"net/http"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"github.com/yourselfhosted/gomark/parser"
-	"github.com/yourselfhosted/gomark/parser/tokenizer"
 	"github.com/yourselfhosted/gomark/renderer"
 
+	"github.com/usememos/memos/internal/markdown"
 	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
@@ -23,6 +27,12 @@ const (
 	maxMetadataDescriptionLength = 256
 )
 
+// precompressedExtensions are the extensions of the precompressed files of the assets by their encodings.
+var precompressedExtensions = map[string]string{
+	"br":   ".br",
+	"gzip": ".gz",
+}
+
 type FrontendService struct {
 	Profile *profile.Profile
 	Store   *store.Store
@@ -36,22 +46,89 @@ func NewFrontendService(profile *profile.Profile, store *store.Store) *FrontendS
 }
 
 func (s *FrontendService) Serve(ctx context.Context, e *echo.Echo) {
+	skipper := func(c echo.Context) bool {
+		return util.HasPrefixes(c.Path(), "/api", "/memos.api.v2", "/dav", "/robots.txt", "/sitemap.xml", "/m/:name", "/m/:username", "/_domain")
+	}
+	root := s.Profile.GetFrontendDir()
+	e.Use(cacheControlMiddleware(root, skipper))
+	e.Use(precompressedMiddleware(root, skipper))
 	// Use echo static middleware to serve the built dist folder.
+	// The unknown paths are routes of the app, which are served `index.html` with HTML5.
 	// refer: https://github.com/labstack/echo/blob/master/middleware/static.go
 	e.Use(middleware.StaticWithConfig(middleware.StaticConfig{
-		Root:  "dist",
-		HTML5: true,
-		Skipper: func(c echo.Context) bool {
-			return util.HasPrefixes(c.Path(), "/api", "/memos.api.v2", "/robots.txt", "/sitemap.xml", "/m/:name")
-		},
+		Root:    root,
+		HTML5:   true,
+		Skipper: skipper,
 	}))
 
 	s.registerRoutes(e)
 	s.registerFileRoutes(ctx, e)
 }
 
+// cacheControlMiddleware caches the built assets, whose names have the hashes of their contents, e.g. `assets/index-4a2c1f.js`,
+// for a year. The other files, `index.html` of the routes of the app included, are revalidated, so a new build is served at once.
+func cacheControlMiddleware(root string, skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			if skipper(c) || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+				return next(c)
+			}
+			cacheControl := "no-cache"
+			// The missing assets are served `index.html` too, which isn't cached.
+			if strings.HasPrefix(r.URL.Path, "/assets/") {
+				name := filepath.Join(root, filepath.FromSlash(path.Clean("/"+r.URL.Path)))
+				if info, err := os.Stat(name); err == nil && !info.IsDir() {
+					cacheControl = "public, max-age=31536000, immutable"
+				}
+			}
+			c.Response().Header().Set("Cache-Control", cacheControl)
+			return next(c)
+		}
+	}
+}
+
+// precompressedMiddleware serves the precompressed files of the built assets, e.g. `assets/index.js.br` for `assets/index.js`,
+// if the client accepts their encodings. The other files are served by the static middleware, and compressed on the fly.
+func precompressedMiddleware(root string, skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			if skipper(c) || (r.Method != http.MethodGet && r.Method != http.MethodHead) || r.Header.Get("Range") != "" {
+				return next(c)
+			}
+			name := filepath.Join(root, filepath.FromSlash(path.Clean("/"+r.URL.Path)))
+			if info, err := os.Stat(name); err != nil || info.IsDir() {
+				return next(c)
+			}
+			acceptEncoding := r.Header.Get(echo.HeaderAcceptEncoding)
+			for _, encoding := range []string{"br", "gzip"} {
+				precompressedName := name + precompressedExtensions[encoding]
+				if util.NegotiateEncoding(acceptEncoding, encoding) == "" {
+					continue
+				}
+				if info, err := os.Stat(precompressedName); err != nil || info.IsDir() {
+					continue
+				}
+				header := c.Response().Header()
+				header.Set(echo.HeaderContentEncoding, encoding)
+				// The compression middleware varies the responses by the encoding already, unless it's disabled.
+				if !slices.Contains(header.Values(echo.HeaderVary), echo.HeaderAcceptEncoding) {
+					header.Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+				}
+				// The type is of the original file, ServeContent would detect the one of the compressed file.
+				if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+					header.Set(echo.HeaderContentType, contentType)
+				}
+				return c.File(precompressedName)
+			}
+			return next(c)
+		}
+	}
+}
+
 func (s *FrontendService) registerRoutes(e *echo.Echo) {
-	rawIndexHTML := getRawIndexHTML()
+	rawIndexHTML := getRawIndexHTML(s.Profile.GetFrontendDir())
 
 	e.GET("/m/:uid", func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -77,6 +154,42 @@ func (s *FrontendService) registerRoutes(e *echo.Echo) {
 		indexHTML = strings.ReplaceAll(indexHTML, "<!-- memos.metadata.body -->", fmt.Sprintf("<!-- memos.memo.%d -->", memo.ID))
 		return c.HTML(http.StatusOK, indexHTML)
 	})
+
+	// The short links of the memos by the slugs of their creators, which redirect to the memos and count the clicks.
+	e.GET("/m/:username/:slug", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		memo, err := s.findSlugMemo(ctx, c.Param("username"), c.Param("slug"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+		}
+		if memo == nil {
+			return c.HTML(http.StatusNotFound, rawIndexHTML)
+		}
+		if err := s.Store.IncreaseMemoSlugClickCount(ctx, memo.ID); err != nil {
+			slog.Warn("Failed to increase memo slug click count", slog.Any("err", err))
+		}
+		return c.Redirect(http.StatusFound, fmt.Sprintf("/m/%s", memo.UID))
+	})
+}
+
+// findSlugMemo returns the memo of the slug of the user, nil if there isn't one or it isn't shared.
+func (s *FrontendService) findSlugMemo(ctx context.Context, username, slug string) (*store.Memo, error) {
+	user, err := s.Store.GetUser(ctx, &store.FindUser{Username: &username})
+	if err != nil || user == nil {
+		return nil, err
+	}
+	memoSlug, err := s.Store.GetMemoSlug(ctx, &store.FindMemoSlug{Slug: &slug, CreatorID: &user.ID})
+	if err != nil || memoSlug == nil {
+		return nil, err
+	}
+	normalStatus := store.Normal
+	// The private memos aren't redirected to, so the slugs don't tell of them.
+	return s.Store.GetMemo(ctx, &store.FindMemo{
+		ID:             &memoSlug.MemoID,
+		RowStatus:      &normalStatus,
+		VisibilityList: []store.Visibility{store.Public, store.Protected},
+		ExcludeHidden:  true,
+	})
 }
 
 func (s *FrontendService) registerFileRoutes(ctx context.Context, e *echo.Echo) {
@@ -119,9 +232,13 @@ func generateMemoMetadata(memo *store.Memo, creator *store.User) *Metadata {
 	metadata := getDefaultMetadata()
 	metadata.Title = fmt.Sprintf("%s(@%s) on Memos", creator.Nickname, creator.Username)
 	if memo.Visibility == store.Public {
-		tokens := tokenizer.Tokenize(memo.Content)
-		nodes, _ := parser.Parse(tokens)
-		description := renderer.NewStringRenderer().Render(nodes)
+		description, _ := markdown.Render("string", memo.Content, func(content string) (string, error) {
+			nodes, err := markdown.Parse(content)
+			if err != nil {
+				return "", err
+			}
+			return renderer.NewStringRenderer().Render(nodes), nil
+		})
 		if len(description) == 0 {
 			description = memo.Content
 		}
@@ -134,8 +251,8 @@ func generateMemoMetadata(memo *store.Memo, creator *store.User) *Metadata {
 	return metadata
 }
 
-func getRawIndexHTML() string {
-	bytes, _ := os.ReadFile("dist/index.html")
+func getRawIndexHTML(root string) string {
+	bytes, _ := os.ReadFile(filepath.Join(root, "index.html"))
 	return string(bytes)
 }
 
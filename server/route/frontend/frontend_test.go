@@ -0,0 +1,54 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeFrontendDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "assets"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "index.html"), []byte("<html>custom</html>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "assets", "index-4a2c1f.js"), []byte("console.log(1)"), 0644))
+
+	e := echo.New()
+	skipper := func(c echo.Context) bool {
+		return c.Path() == "/api/ping"
+	}
+	e.Use(cacheControlMiddleware(root, skipper))
+	e.Use(middleware.StaticWithConfig(middleware.StaticConfig{
+		Root:    root,
+		HTML5:   true,
+		Skipper: skipper,
+	}))
+	e.GET("/api/ping", func(c echo.Context) error {
+		return c.String(http.StatusOK, "pong")
+	})
+
+	tests := []struct {
+		path         string
+		body         string
+		cacheControl string
+	}{
+		{path: "/assets/index-4a2c1f.js", body: "console.log(1)", cacheControl: "public, max-age=31536000, immutable"},
+		// The routes of the app and the missing assets are served index.html.
+		{path: "/", body: "<html>custom</html>", cacheControl: "no-cache"},
+		{path: "/explore", body: "<html>custom</html>", cacheControl: "no-cache"},
+		{path: "/assets/index-0000.js", body: "<html>custom</html>", cacheControl: "no-cache"},
+		{path: "/api/ping", body: "pong", cacheControl: ""},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+		require.Equal(t, http.StatusOK, recorder.Code, test.path)
+		require.Equal(t, test.body, recorder.Body.String(), test.path)
+		require.Equal(t, test.cacheControl, recorder.Header().Get("Cache-Control"), test.path)
+	}
+}
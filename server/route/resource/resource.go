@@ -2,22 +2,30 @@ package resource
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/disintegration/imaging"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/storage/sftp"
 	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/server/route/api/etag"
 	"github.com/usememos/memos/store"
 )
 
@@ -32,12 +40,23 @@ const (
 type ResourceService struct {
 	Profile *profile.Profile
 	Store   *store.Store
+
+	// sftpClients caches the connected client of each SFTP storage, so the resources are served without a new SSH handshake.
+	sftpMutex   sync.Mutex
+	sftpClients map[int32]*sftpClient
+}
+
+// sftpClient is a cached SFTP client along with the storage config it's connected with.
+type sftpClient struct {
+	config string
+	client *sftp.Client
 }
 
 func NewResourceService(profile *profile.Profile, store *store.Store) *ResourceService {
 	return &ResourceService{
-		Profile: profile,
-		Store:   store,
+		Profile:     profile,
+		Store:       store,
+		sftpClients: map[int32]*sftpClient{},
 	}
 }
 
@@ -72,11 +91,35 @@ func (s *ResourceService) streamResource(c echo.Context) error {
 			if !ok || (memo.Visibility == store.Private && userID != resource.CreatorID) {
 				return echo.NewHTTPError(http.StatusUnauthorized, "Resource visibility not match")
 			}
+			if memo.Visibility == store.Group && userID != resource.CreatorID {
+				visible, err := s.Store.IsMemoVisibleTo(ctx, memo, userID)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check memo visibility").SetInternal(err)
+				}
+				if !visible {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Resource visibility not match")
+				}
+			}
 		}
 	}
 
-	blob := resource.Blob
-	if resource.InternalPath != "" {
+	if resource.ExternalLink != "" {
+		return s.streamExternalResource(c, resource)
+	}
+
+	var content io.ReadSeeker = bytes.NewReader(resource.Blob)
+	if storageID, filePath, ok := sftp.ParseReference(resource.InternalPath); ok {
+		client, err := s.getSFTPClient(ctx, storageID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to connect to the sftp storage: %d", storageID)).SetInternal(err)
+		}
+		src, err := client.OpenFile(filePath)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to open the sftp resource: %s", filePath)).SetInternal(err)
+		}
+		defer src.Close()
+		content = src
+	} else if resource.InternalPath != "" {
 		resourcePath := filepath.FromSlash(resource.InternalPath)
 		if !filepath.IsAbs(resourcePath) {
 			resourcePath = filepath.Join(s.Profile.Data, resourcePath)
@@ -87,34 +130,169 @@ func (s *ResourceService) streamResource(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to open the local resource: %s", resourcePath)).SetInternal(err)
 		}
 		defer src.Close()
-		blob, err = io.ReadAll(src)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to read the local resource: %s", resourcePath)).SetInternal(err)
-		}
+		content = src
 	}
 
 	if c.QueryParam("thumbnail") == "1" && util.HasPrefixes(resource.Type, "image/png", "image/jpeg") {
+		blob, err := io.ReadAll(content)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to read the resource: %s", uid)).SetInternal(err)
+		}
+		content = bytes.NewReader(blob)
+
 		ext := filepath.Ext(resource.Filename)
 		thumbnailPath := filepath.Join(s.Profile.Data, thumbnailImagePath, fmt.Sprintf("%d%s", resource.ID, ext))
 		thumbnailBlob, err := getOrGenerateThumbnailImage(blob, thumbnailPath)
 		if err != nil {
 			slog.Warn("failed to get or generate thumbnail image", err)
 		} else {
-			blob = thumbnailBlob
+			content = bytes.NewReader(thumbnailBlob)
+		}
+	}
+
+	setResourceHeaders(c, resource)
+	// ServeContent handles the range requests, so audio and video resources can be seeked and streamed.
+	http.ServeContent(c.Response(), c.Request(), resource.Filename, time.Unix(resource.UpdatedTs, 0), content)
+	return nil
+}
+
+// streamExternalResource proxies the resource uploaded to a S3 storage by the server, passing the Range header through to the object store.
+// Other external links are not proxied to avoid reading arbitrary objects or addresses with the credentials of the server,
+// the client is redirected instead.
+func (s *ResourceService) streamExternalResource(c echo.Context, resource *store.Resource) error {
+	ctx := c.Request().Context()
+	storageID, key, ok := s3.ParseReference(resource.InternalPath)
+	if !ok {
+		return c.Redirect(http.StatusFound, resource.ExternalLink)
+	}
+	client, err := s.newS3Client(ctx, storageID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find the s3 storage: %d", storageID)).SetInternal(err)
+	}
+	request := c.Request()
+	if etag.IsNotModified(request.Header.Get(etag.IfNoneMatchHeader), request.Header.Get(etag.IfModifiedSinceHeader), getResourceETag(c, resource), resource.UpdatedTs) {
+		setResourceHeaders(c, resource)
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	output, err := client.GetObject(ctx, key, c.Request().Header.Get("Range"))
+	if err != nil {
+		var responseError *awshttp.ResponseError
+		if errors.As(err, &responseError) && responseError.HTTPStatusCode() == http.StatusRequestedRangeNotSatisfiable {
+			return echo.NewHTTPError(http.StatusRequestedRangeNotSatisfiable, "Requested range not satisfiable").SetInternal(err)
 		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get the s3 object: %s", key)).SetInternal(err)
+	}
+	defer output.Body.Close()
+
+	setResourceHeaders(c, resource)
+	header := c.Response().Header()
+	header.Set("Accept-Ranges", "bytes")
+	if output.ContentLength != nil {
+		header.Set(echo.HeaderContentLength, strconv.FormatInt(*output.ContentLength, 10))
+	}
+	status := http.StatusOK
+	if output.ContentRange != nil {
+		header.Set("Content-Range", *output.ContentRange)
+		status = http.StatusPartialContent
 	}
+	return c.Stream(status, header.Get(echo.HeaderContentType), output.Body)
+}
 
-	c.Response().Writer.Header().Set(echo.HeaderCacheControl, "max-age=3600")
-	c.Response().Writer.Header().Set(echo.HeaderContentSecurityPolicy, "default-src 'none'; script-src 'none'; img-src 'self'; media-src 'self'; sandbox;")
-	c.Response().Writer.Header().Set("Content-Disposition", fmt.Sprintf(`filename="%s"`, resource.Filename))
+func setResourceHeaders(c echo.Context, resource *store.Resource) {
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, "max-age=3600")
+	header.Set("ETag", getResourceETag(c, resource))
+	header.Set(echo.HeaderContentSecurityPolicy, "default-src 'none'; script-src 'none'; img-src 'self'; media-src 'self'; sandbox;")
+	header.Set("Content-Disposition", fmt.Sprintf(`filename="%s"`, resource.Filename))
 	resourceType := strings.ToLower(resource.Type)
 	if strings.HasPrefix(resourceType, "text") {
 		resourceType = echo.MIMETextPlainCharsetUTF8
-	} else if strings.HasPrefix(resourceType, "video") || strings.HasPrefix(resourceType, "audio") {
-		http.ServeContent(c.Response(), c.Request(), resource.Filename, time.Unix(resource.UpdatedTs, 0), bytes.NewReader(blob))
-		return nil
 	}
-	return c.Stream(http.StatusOK, resourceType, bytes.NewReader(blob))
+	if resourceType != "" {
+		header.Set(echo.HeaderContentType, resourceType)
+	}
+}
+
+// getResourceETag returns the ETag of the resource blob, the thumbnail has its own one.
+// ServeContent checks it against If-None-Match, as it does If-Modified-Since with the update time.
+func getResourceETag(c echo.Context, resource *store.Resource) string {
+	variant := ""
+	if c.QueryParam("thumbnail") == "1" {
+		variant = "thumbnail"
+	}
+	return etag.New("resource", resource.ID, resource.UpdatedTs, variant)
+}
+
+// getSFTPClient returns the cached client of the SFTP storage, it connects again if the storage config has been
+// changed or the connection has been closed.
+func (s *ResourceService) getSFTPClient(ctx context.Context, storageID int32) (*sftp.Client, error) {
+	storage, err := s.Store.GetStorage(ctx, &store.FindStorage{ID: &storageID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find storage")
+	}
+	if storage == nil {
+		return nil, errors.Errorf("storage %d not found", storageID)
+	}
+
+	s.sftpMutex.Lock()
+	cached, ok := s.sftpClients[storageID]
+	s.sftpMutex.Unlock()
+	if ok && cached.config == storage.Config {
+		return cached.client, nil
+	}
+
+	// The storage config of SFTP storage is stored as the JSON of sftp.Config.
+	config := &sftp.Config{}
+	if err := json.Unmarshal([]byte(storage.Config), config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal sftp config")
+	}
+	// Connect without holding the lock, so a slow server doesn't block the resources of the other storages.
+	client, err := sftp.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sftpMutex.Lock()
+	defer s.sftpMutex.Unlock()
+	if cached, ok := s.sftpClients[storageID]; ok {
+		if cached.config == storage.Config {
+			// Another request connected in the meantime, keep its client.
+			client.Close()
+			return cached.client, nil
+		}
+		cached.client.Close()
+	}
+	s.sftpClients[storageID] = &sftpClient{
+		config: storage.Config,
+		client: client,
+	}
+	go func() {
+		// Drop the client once the connection is lost, the next request connects again.
+		_ = client.Wait()
+		s.sftpMutex.Lock()
+		defer s.sftpMutex.Unlock()
+		if cached, ok := s.sftpClients[storageID]; ok && cached.client == client {
+			delete(s.sftpClients, storageID)
+		}
+	}()
+	return client, nil
+}
+
+func (s *ResourceService) newS3Client(ctx context.Context, storageID int32) (*s3.Client, error) {
+	storage, err := s.Store.GetStorage(ctx, &store.FindStorage{ID: &storageID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find storage")
+	}
+	if storage == nil || storage.Type != "S3" {
+		return nil, errors.Errorf("s3 storage %d not found", storageID)
+	}
+	// The storage config of S3 storage is stored as the JSON of s3.Config.
+	config := &s3.Config{}
+	if err := json.Unmarshal([]byte(storage.Config), config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal s3 config")
+	}
+	return s3.NewClient(ctx, config)
 }
 
 var availableGeneratorAmount int32 = 32
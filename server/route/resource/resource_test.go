@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+// TestStreamExternalResource tests the links into a S3 storage are only proxied if the server uploaded the objects itself.
+func TestStreamExternalResource(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost})
+	require.NoError(t, err)
+	_, err = ts.CreateStorage(ctx, &store.Storage{
+		Name:   "s3",
+		Type:   "S3",
+		Config: `{"bucket":"memos","endPoint":"https://s3.example.com","region":"us-east-1"}`,
+	})
+	require.NoError(t, err)
+	s := NewResourceService(ts.Profile, ts)
+	e := echo.New()
+
+	tests := []struct {
+		uid          string
+		externalLink string
+		internalPath string
+		status       int
+	}{
+		{uid: "foreign-link", externalLink: "https://example.com/image.png", status: http.StatusFound},
+		// A link into the bucket which the server didn't upload is redirected rather than read with the credentials of the server.
+		{uid: "bucket-link", externalLink: "https://s3.example.com/memos/secret.png", status: http.StatusFound},
+		{uid: "missing-storage", externalLink: "https://s3.example.com/memos/image.png", internalPath: "s3://99/image.png", status: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		_, err := ts.CreateResource(ctx, &store.Resource{
+			UID:          test.uid,
+			CreatorID:    user.ID,
+			Filename:     "image.png",
+			ExternalLink: test.externalLink,
+			InternalPath: test.internalPath,
+			Type:         "image/png",
+		})
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/o/r/"+test.uid, nil), recorder)
+		c.SetParamNames("uid")
+		c.SetParamValues(test.uid)
+		err = s.streamResource(c)
+		if test.status == http.StatusFound {
+			require.NoError(t, err, test.uid)
+			require.Equal(t, test.status, recorder.Code, test.uid)
+			require.Equal(t, test.externalLink, recorder.Header().Get(echo.HeaderLocation), test.uid)
+			continue
+		}
+		httpError := &echo.HTTPError{}
+		require.ErrorAs(t, err, &httpError, test.uid)
+		require.Equal(t, test.status, httpError.Code, test.uid)
+	}
+}
@@ -0,0 +1,224 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxCalendarDatesPerMemo is the max number of the date references of a memo shown as events.
+	maxCalendarDatesPerMemo = 10
+	// maxCalendarEventCount is the max number of the events in a calendar.
+	maxCalendarEventCount = 500
+	// maxICSLineLength is the max length of the content lines in octets, the longer lines are folded.
+	maxICSLineLength = 75
+)
+
+// dateReferenceRegexp matches the dates in the content, with an optional time, e.g. `2024-05-01` or `2024-05-01 14:30`.
+var dateReferenceRegexp = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})(?:[ T](\d{2}:\d{2}))?\b`)
+
+type calendarEvent struct {
+	uid   string
+	start time.Time
+	// allDay is the flag of the events of a date, and floating is the flag of the times without a timezone.
+	allDay   bool
+	floating bool
+	summary  string
+	memo     *store.Memo
+}
+
+// GetUserCalendar serves the events of the date references in the memos of the user and, with the user's own feed token, of their reminders.
+func (s *RSSService) GetUserCalendar(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.findFeedUser(c)
+	if err != nil {
+		return err
+	}
+	visibilityList, tokenUser, err := s.authenticateFeed(c, user)
+	if err != nil {
+		return err
+	}
+
+	normalStatus := store.Normal
+	memoList, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		CreatorID:      &user.ID,
+		RowStatus:      &normalStatus,
+		VisibilityList: visibilityList,
+		ExcludeHidden:  true,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+	}
+	events := []*calendarEvent{}
+	for _, memo := range memoList {
+		events = append(events, getMemoDateEvents(memo)...)
+	}
+	if tokenUser != nil && tokenUser.ID == user.ID {
+		reminderEvents, err := s.getReminderEvents(ctx, user)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo reminders").SetInternal(err)
+		}
+		events = append(events, reminderEvents...)
+	}
+	if len(events) > maxCalendarEventCount {
+		events = events[:maxCalendarEventCount]
+	}
+
+	baseURL := c.Scheme() + "://" + c.Request().Host
+	calendar := generateCalendar(user.Nickname+" - Memos", events, baseURL)
+	c.Response().Header().Set(echo.HeaderContentType, "text/calendar; charset=UTF-8")
+	return c.String(http.StatusOK, calendar)
+}
+
+func (s *RSSService) getReminderEvents(ctx context.Context, user *store.User) ([]*calendarEvent, error) {
+	reminders, err := s.Store.ListMemoReminders(ctx, &store.FindMemoReminder{
+		CreatorID: &user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := []*calendarEvent{}
+	for _, reminder := range reminders {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &reminder.MemoID})
+		if err != nil {
+			return nil, err
+		}
+		if memo == nil || memo.RowStatus != store.Normal {
+			continue
+		}
+		if visible, err := s.Store.IsMemoVisibleTo(ctx, memo, user.ID); err != nil {
+			return nil, err
+		} else if !visible {
+			continue
+		}
+		events = append(events, &calendarEvent{
+			uid:     fmt.Sprintf("reminder-%d", reminder.ID),
+			start:   time.Unix(reminder.RemindTs, 0),
+			summary: "Reminder: " + getCalendarEventSummary(memo.Content),
+			memo:    memo,
+		})
+	}
+	return events, nil
+}
+
+// getMemoDateEvents returns the events of the distinct date references in the memo content.
+// The dates without a time are all-day events, and the times are floating times in the timezone of the calendar app.
+func getMemoDateEvents(memo *store.Memo) []*calendarEvent {
+	events := []*calendarEvent{}
+	seen := map[string]bool{}
+	for _, match := range dateReferenceRegexp.FindAllStringSubmatch(memo.Content, -1) {
+		if len(events) >= maxCalendarDatesPerMemo {
+			break
+		}
+		if seen[match[0]] {
+			continue
+		}
+		seen[match[0]] = true
+		event := &calendarEvent{
+			uid:     fmt.Sprintf("memo-%s-%d", memo.UID, len(events)),
+			summary: getCalendarEventSummary(memo.Content),
+			memo:    memo,
+		}
+		var err error
+		if match[2] == "" {
+			event.allDay = true
+			event.start, err = time.Parse(time.DateOnly, match[1])
+		} else {
+			event.floating = true
+			event.start, err = time.Parse("2006-01-02 15:04", match[1]+" "+match[2])
+		}
+		if err != nil {
+			// Not a valid date, e.g. `2024-13-45`.
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func getCalendarEventSummary(content string) string {
+	summary := strings.TrimSpace(getRSSItemTitle(content))
+	if utf8.RuneCountInString(summary) > maxRSSItemTitleLength {
+		summary = string([]rune(summary)[:maxRSSItemTitleLength]) + "..."
+	}
+	return summary
+}
+
+func generateCalendar(name string, events []*calendarEvent, baseURL string) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//usememos//memos//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:" + escapeICSText(name),
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(baseURL, "https://"), "http://")
+	for _, event := range events {
+		link := baseURL + "/m/" + event.memo.UID
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+event.uid+"@"+host,
+			"DTSTAMP:"+time.Unix(event.memo.UpdatedTs, 0).UTC().Format("20060102T150405Z"),
+		)
+		if event.allDay {
+			lines = append(lines,
+				"DTSTART;VALUE=DATE:"+event.start.Format("20060102"),
+				"DTEND;VALUE=DATE:"+event.start.AddDate(0, 0, 1).Format("20060102"),
+			)
+		} else if event.floating {
+			lines = append(lines, "DTSTART:"+event.start.Format("20060102T150405"))
+		} else {
+			lines = append(lines, "DTSTART:"+event.start.UTC().Format("20060102T150405Z"))
+		}
+		lines = append(lines,
+			"SUMMARY:"+escapeICSText(event.summary),
+			"DESCRIPTION:"+escapeICSText(strings.TrimSpace(event.memo.Content)),
+			"URL:"+link,
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldICSLine(line))
+		builder.WriteString("\r\n")
+	}
+	return builder.String()
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeICSText escapes the text values of RFC 5545.
+func escapeICSText(text string) string {
+	return icsTextEscaper.Replace(text)
+}
+
+// foldICSLine folds the line into the lines of at most 75 octets, without splitting the UTF-8 characters.
+func foldICSLine(line string) string {
+	if len(line) <= maxICSLineLength {
+		return line
+	}
+	var builder strings.Builder
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > maxICSLineLength {
+			// The continuation lines start with a space, which counts towards their length.
+			builder.WriteString("\r\n ")
+			length = 1
+		}
+		builder.WriteRune(r)
+		length += size
+	}
+	return builder.String()
+}
@@ -2,6 +2,8 @@ package rss
 
 import (
 	"context"
+	"crypto/subtle"
+	"html"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,8 +15,12 @@ import (
 	"github.com/yourselfhosted/gomark/ast"
 	"github.com/yourselfhosted/gomark/renderer"
 
+	"github.com/usememos/memos/internal/i18n"
+	"github.com/usememos/memos/internal/markdown"
 	"github.com/usememos/memos/internal/util"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/profile"
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
 	"github.com/usememos/memos/store"
 )
 
@@ -23,6 +29,13 @@ const (
 	maxRSSItemTitleLength = 128
 )
 
+type feedFormat string
+
+const (
+	feedFormatRSS  feedFormat = "rss"
+	feedFormatAtom feedFormat = "atom"
+)
+
 type RSSService struct {
 	Profile *profile.Profile
 	Store   *store.Store
@@ -35,58 +48,128 @@ func NewRSSService(profile *profile.Profile, store *store.Store) *RSSService {
 	}
 }
 
+// RegisterRoutes registers the feeds of the explore page and the users.
+// The feeds accept the query parameters:
+//   - tag: only the memos with the tag or its child tags, e.g. `tag=blog`.
+//   - content=full: the items include the rendered memo content with the resources.
+//   - token: the feed token of a user, which includes the protected memos, and the private memos of the user's own feed.
+//
+// The calendar of a user accepts the token as well, which includes the reminders of the user's own calendar.
 func (s *RSSService) RegisterRoutes(g *echo.Group) {
 	g.GET("/explore/rss.xml", s.GetExploreRSS)
+	g.GET("/explore/atom.xml", s.GetExploreAtom)
 	g.GET("/u/:username/rss.xml", s.GetUserRSS)
+	g.GET("/u/:username/atom.xml", s.GetUserAtom)
+	g.GET("/u/:username/calendar.ics", s.GetUserCalendar)
 }
 
 func (s *RSSService) GetExploreRSS(c echo.Context) error {
-	ctx := c.Request().Context()
-	normalStatus := store.Normal
-	memoFind := store.FindMemo{
-		RowStatus:      &normalStatus,
-		VisibilityList: []store.Visibility{store.Public},
-	}
-	memoList, err := s.Store.ListMemos(ctx, &memoFind)
+	return s.serveFeed(c, nil, feedFormatRSS)
+}
+
+func (s *RSSService) GetExploreAtom(c echo.Context) error {
+	return s.serveFeed(c, nil, feedFormatAtom)
+}
+
+func (s *RSSService) GetUserRSS(c echo.Context) error {
+	user, err := s.findFeedUser(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+		return err
 	}
+	return s.serveFeed(c, user, feedFormatRSS)
+}
 
-	baseURL := c.Scheme() + "://" + c.Request().Host
-	rss, err := s.generateRSSFromMemoList(ctx, memoList, baseURL)
+func (s *RSSService) GetUserAtom(c echo.Context) error {
+	user, err := s.findFeedUser(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
+		return err
 	}
-	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
-	return c.String(http.StatusOK, rss)
+	return s.serveFeed(c, user, feedFormatAtom)
 }
 
-func (s *RSSService) GetUserRSS(c echo.Context) error {
-	ctx := c.Request().Context()
+func (s *RSSService) findFeedUser(c echo.Context) (*store.User, error) {
 	username := c.Param("username")
-	user, err := s.Store.GetUser(ctx, &store.FindUser{
+	user, err := s.Store.GetUser(c.Request().Context(), &store.FindUser{
 		Username: &username,
 	})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
 	}
 	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+	return user, nil
+}
+
+// serveFeed serves the feed of the memos of the creator, or of all users if the creator is nil.
+func (s *RSSService) serveFeed(c echo.Context, creator *store.User, format feedFormat) error {
+	ctx := c.Request().Context()
+	visibilityList, tokenUser, err := s.authenticateFeed(c, creator)
+	if err != nil {
+		return err
 	}
 
 	normalStatus := store.Normal
 	memoFind := store.FindMemo{
-		CreatorID:      &user.ID,
 		RowStatus:      &normalStatus,
-		VisibilityList: []store.Visibility{store.Public},
+		VisibilityList: visibilityList,
+		ExcludeHidden:  true,
+	}
+	if creator != nil {
+		memoFind.CreatorID = &creator.ID
+	} else if err := s.Store.ApplyExploreSetting(ctx, &memoFind); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply explore setting").SetInternal(err)
+	}
+	tag := strings.TrimPrefix(c.QueryParam("tag"), "#")
+	if tag != "" {
+		// Narrow the memos down with the content, the tags are checked after parsing.
+		memoFind.ContentSearch = []string{"#" + tag}
 	}
 	memoList, err := s.Store.ListMemos(ctx, &memoFind)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
 	}
+	if tag != "" {
+		memoList = filterMemosByTag(memoList, tag)
+	}
 
 	baseURL := c.Scheme() + "://" + c.Request().Host
-	rss, err := s.generateRSSFromMemoList(ctx, memoList, baseURL)
+	feed, err := s.generateFeedFromMemoList(ctx, memoList, baseURL, c.QueryParam("content") == "full")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
+	}
+	localizer, err := s.getFeedLocalizer(c, tokenUser)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get locale").SetInternal(err)
+	}
+	feed.Description = localizer.T("rss.description")
+	brandingSetting, err := s.Store.GetWorkspaceBrandingSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace branding setting").SetInternal(err)
+	}
+	if brandingSetting.Title != "" {
+		feed.Title = brandingSetting.Title
+	}
+	if brandingSetting.Description != "" {
+		feed.Description = brandingSetting.Description
+	}
+	if creator != nil {
+		feed.Title = creator.Nickname + " - " + feed.Title
+		feed.Link = &feeds.Link{Href: baseURL + "/u/" + creator.Username}
+	}
+	if tag != "" {
+		feed.Title += " #" + tag
+	}
+
+	if format == feedFormatAtom {
+		atom, err := feed.ToAtom()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate atom").SetInternal(err)
+		}
+		c.Response().Header().Set(echo.HeaderContentType, "application/atom+xml; charset=UTF-8")
+		return c.String(http.StatusOK, atom)
+	}
+	rss, err := feed.ToRss()
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
 	}
@@ -94,7 +177,70 @@ func (s *RSSService) GetUserRSS(c echo.Context) error {
 	return c.String(http.StatusOK, rss)
 }
 
-func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*store.Memo, baseURL string) (string, error) {
+// authenticateFeed returns the visibilities of the memos in the feed of the creator and the user of the feed token, if any.
+func (s *RSSService) authenticateFeed(c echo.Context, creator *store.User) ([]store.Visibility, *store.User, error) {
+	visibilityList := []store.Visibility{store.Public}
+	token := c.QueryParam("token")
+	if token == "" {
+		return visibilityList, nil, nil
+	}
+	tokenUser, err := s.findFeedTokenUser(c.Request().Context(), token)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find feed token").SetInternal(err)
+	}
+	if tokenUser == nil {
+		return nil, nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid feed token")
+	}
+	visibilityList = append(visibilityList, store.Protected)
+	if creator != nil && creator.ID == tokenUser.ID {
+		visibilityList = append(visibilityList, store.Private, store.Group)
+	}
+	// The feed with the private memos must not be stored by shared caches.
+	c.Response().Header().Set(echo.HeaderCacheControl, "private")
+	return visibilityList, tokenUser, nil
+}
+
+// getFeedLocalizer returns the localizer of the locale of the user of the feed token,
+// or of the Accept-Language header of the request and the default locale of the workspace without a token.
+func (s *RSSService) getFeedLocalizer(c echo.Context, tokenUser *store.User) (*i18n.Localizer, error) {
+	ctx := c.Request().Context()
+	if tokenUser != nil {
+		locale, err := s.Store.GetUserLocale(ctx, tokenUser.ID)
+		if err != nil {
+			return nil, err
+		}
+		return i18n.NewLocalizer(locale), nil
+	}
+	c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+	defaultLocale, err := s.Store.GetWorkspaceDefaultLocale(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i18n.NewLocalizer(append(i18n.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language")), defaultLocale)...), nil
+}
+
+// findFeedTokenUser returns the user of the feed token, nil if the token is unknown.
+func (s *RSSService) findFeedTokenUser(ctx context.Context, token string) (*store.User, error) {
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_FEED_TOKEN,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, userSetting := range userSettings {
+		feedToken := userSetting.GetFeedToken()
+		if feedToken != "" && subtle.ConstantTimeCompare([]byte(feedToken), []byte(token)) == 1 {
+			user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userSetting.UserId})
+			if err != nil || user == nil || user.RowStatus == store.Archived {
+				return nil, err
+			}
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *RSSService) generateFeedFromMemoList(ctx context.Context, memoList []*store.Memo, baseURL string, fullContent bool) (*feeds.Feed, error) {
 	feed := &feeds.Feed{
 		Title:       "Memos",
 		Link:        &feeds.Link{Href: baseURL},
@@ -106,48 +252,68 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 	feed.Items = make([]*feeds.Item, itemCountLimit)
 	for i := 0; i < itemCountLimit; i++ {
 		memo := memoList[i]
-		description, err := getRSSItemDescription(memo.Content)
+		content := hookrunner.RenderMemoContent(ctx, s.Store, memo)
+		description, err := getRSSItemDescription(content, baseURL)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+		link := baseURL + "/m/" + memo.UID
 		feed.Items[i] = &feeds.Item{
-			Title:       getRSSItemTitle(memo.Content),
-			Link:        &feeds.Link{Href: baseURL + "/m/" + memo.UID},
+			Title:       getRSSItemTitle(content),
+			Link:        &feeds.Link{Href: link},
+			Id:          link,
 			Description: description,
 			Created:     time.Unix(memo.CreatedTs, 0),
+			Updated:     time.Unix(memo.UpdatedTs, 0),
 		}
 		resources, err := s.Store.ListResources(ctx, &store.FindResource{
 			MemoID: &memo.ID,
 		})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if len(resources) > 0 {
 			resource := resources[0]
 			enclosure := feeds.Enclosure{}
-			if resource.ExternalLink != "" {
-				enclosure.Url = resource.ExternalLink
-			} else {
-				enclosure.Url = baseURL + "/o/r/" + resource.UID
-			}
+			enclosure.Url = getResourceURL(resource, baseURL)
 			enclosure.Length = strconv.Itoa(int(resource.Size))
 			enclosure.Type = resource.Type
 			feed.Items[i].Enclosure = &enclosure
 		}
+		if fullContent {
+			feed.Items[i].Content = description + renderResources(resources, baseURL)
+		}
 	}
+	return feed, nil
+}
 
-	rss, err := feed.ToRss()
-	if err != nil {
-		return "", err
+func getResourceURL(resource *store.Resource, baseURL string) string {
+	if resource.ExternalLink != "" {
+		return resource.ExternalLink
 	}
-	return rss, nil
+	return baseURL + "/o/r/" + resource.UID
+}
+
+// renderResources returns the HTML of the resources, the images are shown inline and the other files are linked.
+func renderResources(resources []*store.Resource, baseURL string) string {
+	var builder strings.Builder
+	for _, resource := range resources {
+		url := html.EscapeString(getResourceURL(resource, baseURL))
+		filename := html.EscapeString(resource.Filename)
+		if strings.HasPrefix(resource.Type, "image/") {
+			builder.WriteString(`<p><img src="` + url + `" alt="` + filename + `"></p>`)
+		} else {
+			builder.WriteString(`<p><a href="` + url + `">` + filename + `</a></p>`)
+		}
+	}
+	return builder.String()
 }
 
 func getRSSItemTitle(content string) string {
-	nodes, _ := gomark.Parse(content)
-	if len(nodes) > 0 {
-		firstNode := nodes[0]
-		title := renderer.NewStringRenderer().Render([]ast.Node{firstNode})
+	if nodes, _ := markdown.Parse(content); len(nodes) > 0 {
+		title, _ := markdown.Render("rss.title", content, func(string) (string, error) {
+			return renderer.NewStringRenderer().Render(nodes[:1]), nil
+		})
 		return title
 	}
 
@@ -159,11 +325,75 @@ func getRSSItemTitle(content string) string {
 	return title
 }
 
-func getRSSItemDescription(content string) (string, error) {
-	nodes, err := gomark.Parse(content)
-	if err != nil {
-		return "", err
+// getRSSItemDescription returns the HTML of the content, with the relative image and link urls resolved against the base url,
+// since the feed readers don't know the instance of the feed items.
+// The nodes are changed, so they're parsed with gomark instead of the cache of the nodes.
+func getRSSItemDescription(content, baseURL string) (string, error) {
+	return markdown.Render("rss.description "+baseURL, content, func(content string) (string, error) {
+		nodes, err := gomark.Parse(content)
+		if err != nil {
+			return "", err
+		}
+		traverseNodes(nodes, func(node ast.Node) {
+			switch n := node.(type) {
+			case *ast.Image:
+				n.URL = resolveURL(n.URL, baseURL)
+			case *ast.Link:
+				n.URL = resolveURL(n.URL, baseURL)
+			}
+		})
+		return renderer.NewHTMLRenderer().Render(nodes), nil
+	})
+}
+
+func resolveURL(url, baseURL string) string {
+	if strings.HasPrefix(url, "/") && !strings.HasPrefix(url, "//") {
+		return baseURL + url
+	}
+	return url
+}
+
+// filterMemosByTag returns the memos with the tag or its child tags, e.g. `#blog/travel` for `blog`.
+func filterMemosByTag(memoList []*store.Memo, tag string) []*store.Memo {
+	filtered := []*store.Memo{}
+	for _, memo := range memoList {
+		nodes, err := markdown.Parse(memo.Content)
+		if err != nil {
+			continue
+		}
+		matched := false
+		traverseNodes(nodes, func(node ast.Node) {
+			if tagNode, ok := node.(*ast.Tag); ok && (tagNode.Content == tag || strings.HasPrefix(tagNode.Content, tag+"/")) {
+				matched = true
+			}
+		})
+		if matched {
+			filtered = append(filtered, memo)
+		}
+	}
+	return filtered
+}
+
+// traverseNodes calls the function for the nodes and their descendants.
+// It's the same as the traversal of api v2, which can't be imported by the routes of api v1.
+func traverseNodes(nodes []ast.Node, fn func(ast.Node)) {
+	for _, node := range nodes {
+		fn(node)
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			traverseNodes(n.Children, fn)
+		case *ast.Heading:
+			traverseNodes(n.Children, fn)
+		case *ast.Blockquote:
+			traverseNodes(n.Children, fn)
+		case *ast.OrderedList:
+			traverseNodes(n.Children, fn)
+		case *ast.UnorderedList:
+			traverseNodes(n.Children, fn)
+		case *ast.TaskList:
+			traverseNodes(n.Children, fn)
+		case *ast.Bold:
+			traverseNodes(n.Children, fn)
+		}
 	}
-	result := renderer.NewHTMLRenderer().Render(nodes)
-	return result, nil
 }
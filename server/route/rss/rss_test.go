@@ -0,0 +1,63 @@
+package rss
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestGetRSSItemDescription(t *testing.T) {
+	description, err := getRSSItemDescription("![cat](/o/r/abc) [home](https://example.com)", "https://memos.example.com")
+	require.NoError(t, err)
+	require.Contains(t, description, `src="https://memos.example.com/o/r/abc"`)
+	require.Contains(t, description, `href="https://example.com"`)
+}
+
+func TestFilterMemosByTag(t *testing.T) {
+	memoList := []*store.Memo{
+		{ID: 1, Content: "#blog hello"},
+		{ID: 2, Content: "#blog/travel trip"},
+		{ID: 3, Content: "#blogging other"},
+		{ID: 4, Content: "`#blog` in code"},
+	}
+	ids := []int32{}
+	for _, memo := range filterMemosByTag(memoList, "blog") {
+		ids = append(ids, memo.ID)
+	}
+	require.Equal(t, []int32{1, 2}, ids)
+}
+
+func TestRenderResources(t *testing.T) {
+	resources := []*store.Resource{
+		{UID: "img", Filename: "cat.png", Type: "image/png"},
+		{Filename: "a<b>.pdf", Type: "application/pdf", ExternalLink: "https://example.com/a.pdf"},
+	}
+	require.Equal(t,
+		`<p><img src="https://memos.example.com/o/r/img" alt="cat.png"></p><p><a href="https://example.com/a.pdf">a&lt;b&gt;.pdf</a></p>`,
+		renderResources(resources, "https://memos.example.com"))
+}
+
+func TestGetMemoDateEvents(t *testing.T) {
+	memo := &store.Memo{UID: "abc", Content: "Dentist 2024-05-01 14:30, tax due 2024-04-15 and 2024-04-15, not 2024-13-45"}
+	events := getMemoDateEvents(memo)
+	require.Len(t, events, 2)
+	require.True(t, events[0].floating)
+	require.Equal(t, "2024-05-01 14:30", events[0].start.Format("2006-01-02 15:04"))
+	require.True(t, events[1].allDay)
+	require.Equal(t, "2024-04-15", events[1].start.Format(time.DateOnly))
+}
+
+func TestGenerateCalendar(t *testing.T) {
+	memo := &store.Memo{UID: "abc", Content: "Trip; pack, go\n" + strings.Repeat("é", 60), UpdatedTs: 0}
+	calendar := generateCalendar("alice - Memos", getMemoDateEvents(&store.Memo{UID: "abc", Content: "2024-04-15"}), "https://memos.example.com")
+	require.Contains(t, calendar, "DTSTART;VALUE=DATE:20240415\r\nDTEND;VALUE=DATE:20240416\r\n")
+	require.Contains(t, calendar, "UID:memo-abc-0@memos.example.com\r\n")
+	require.Equal(t, `Trip\; pack\, go\n`, escapeICSText("Trip; pack, go\n"))
+	for _, line := range strings.Split(foldICSLine("DESCRIPTION:"+escapeICSText(memo.Content)), "\r\n") {
+		require.LessOrEqual(t, len(line), maxICSLineLength)
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/jobs"
+	"github.com/usememos/memos/server/route/api/idempotency"
+	"github.com/usememos/memos/server/service/ai"
+	highlightsync "github.com/usememos/memos/server/service/highlight_sync"
+	"github.com/usememos/memos/server/service/notifier"
+	"github.com/usememos/memos/server/service/scheduler"
+)
+
+// newScheduler returns the scheduler of the periodic tasks of the workspace, the host can override their default schedules
+// in the scheduler setting. The event driven and the polling runners, e.g. the reminders and the job queue, aren't tasks.
+func (s *Server) newScheduler() *scheduler.Scheduler {
+	taskScheduler := scheduler.NewScheduler(s.Store)
+	taskScheduler.Register(&scheduler.Task{
+		Name: "presign_links",
+		// The links are signed for a day, they're signed again after half of it.
+		DefaultCron: "0 */12 * * *",
+		// The links may have expired while the server was stopped.
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			return jobs.PreSignLinks(ctx, s.Store)
+		},
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name:        "resource_gc",
+		DefaultCron: "0 3 * * *",
+		// The orphaned resources and files are deleted for good, the host opts in to collect them.
+		DisabledByDefault: true,
+		Run: func(ctx context.Context) error {
+			return jobs.CollectOrphanedResources(ctx, s.Store)
+		},
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name: jobs.DatabaseMaintenanceTask,
+		// The maintenance may lock the tables, it's run in the quiet hours of the timezone of the scheduler setting.
+		DefaultCron: "30 4 * * 0",
+		Run: func(ctx context.Context) error {
+			return jobs.MaintainDatabase(ctx, s.Store)
+		},
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name:        "resource_text_extraction",
+		DefaultCron: "*/10 * * * *",
+		Run: func(ctx context.Context) error {
+			return jobs.ExtractResourceTexts(ctx, s.Store)
+		},
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name:        "idempotency_key_gc",
+		DefaultCron: "0 * * * *",
+		Run: func(ctx context.Context) error {
+			return idempotency.DeleteExpired(ctx, s.Store)
+		},
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name: "user_deletion",
+		// The users are purged once the grace periods of their deletions have passed.
+		DefaultCron: "0 * * * *",
+		Run: func(ctx context.Context) error {
+			return jobs.PurgeDeletedUsers(ctx, s.Store, s.eventBroker)
+		},
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name: "digests",
+		// The digests of the users are sent once their days or weeks have begun in their timezones.
+		DefaultCron: "0 * * * *",
+		Run:         notifier.NewNotifier(s.Store, s.eventBroker, s.dispatchPool).SendDigests,
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name:        "highlight_sync",
+		DefaultCron: "0 * * * *",
+		Run:         highlightsync.NewSyncer(s.Store).SyncAll,
+	})
+	taskScheduler.Register(&scheduler.Task{
+		Name:        "ai_index",
+		DefaultCron: "*/10 * * * *",
+		Run: func(ctx context.Context) error {
+			if err := ai.NewIndexer(s.Store, s.eventBroker).IndexOutdatedMemos(ctx); err != nil && !errors.Is(err, ai.ErrDisabled) {
+				return err
+			}
+			return nil
+		},
+	})
+	return taskScheduler
+}
@@ -2,27 +2,59 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
+	"github.com/quic-go/quic-go/http3"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 
+	"github.com/usememos/memos/internal/debug"
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/handoff"
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/internal/telemetry"
+	"github.com/usememos/memos/internal/workerpool"
+	"github.com/usememos/memos/plugin/discord"
+	"github.com/usememos/memos/plugin/eventbus"
+	"github.com/usememos/memos/plugin/mail"
+	"github.com/usememos/memos/plugin/mqtt"
 	"github.com/usememos/memos/plugin/telegram"
 	"github.com/usememos/memos/server/integration"
 	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/server/route/api/apiversion"
+	"github.com/usememos/memos/server/route/api/quota"
 	apiv1 "github.com/usememos/memos/server/route/api/v1"
 	apiv2 "github.com/usememos/memos/server/route/api/v2"
+	"github.com/usememos/memos/server/route/domain"
 	"github.com/usememos/memos/server/route/frontend"
+	"github.com/usememos/memos/server/service/ai"
+	eventpublisher "github.com/usememos/memos/server/service/event_publisher"
+	hookrunner "github.com/usememos/memos/server/service/hook_runner"
+	jobqueue "github.com/usememos/memos/server/service/job_queue"
+	mqttpublisher "github.com/usememos/memos/server/service/mqtt_publisher"
+	"github.com/usememos/memos/server/service/notifier"
+	"github.com/usememos/memos/server/service/replicator"
+	"github.com/usememos/memos/server/service/scheduler"
 	versionchecker "github.com/usememos/memos/server/service/version_checker"
+	webhookdispatcher "github.com/usememos/memos/server/service/webhook_dispatcher"
 	"github.com/usememos/memos/store"
 )
 
 type Server struct {
 	e *echo.Echo
+	// http3Server serves HTTP/3 alongside echo when it's enabled, nil otherwise.
+	http3Server *http3.Server
 
 	ID      string
 	Secret  string
@@ -30,7 +62,30 @@ type Server struct {
 	Store   *store.Store
 
 	// Asynchronous runners.
-	telegramBot *telegram.Bot
+	telegramBot     *telegram.Bot
+	telegramHandler *integration.TelegramHandler
+	slackService    *integration.SlackService
+	mastodonService *integration.MastodonService
+	blueskyService  *integration.BlueskyService
+	gistService     *integration.GistService
+	discordBot      *discord.Bot
+	discordHandler  *integration.DiscordHandler
+	emailHandler    *integration.EmailHandler
+
+	eventBroker *event.Broker
+	// eventPublisher publishes the events to the event bus, nil if it isn't configured.
+	eventPublisher *eventpublisher.Publisher
+	// mqttPublisher publishes the memo events to the MQTT broker, nil if it isn't configured.
+	mqttPublisher *mqttpublisher.Publisher
+	// taskScheduler runs the periodic tasks of the workspace.
+	taskScheduler *scheduler.Scheduler
+	apiV2Service  *apiv2.APIV2Service
+	// dispatchPool delivers the webhooks and the notifications.
+	dispatchPool *workerpool.Pool
+	// replicator replicates the SQLite database to the replica, nil if it isn't configured.
+	replicator *replicator.Replicator
+	// shuttingDown is set once the server is shutting down, so it isn't ready for the new requests.
+	shuttingDown atomic.Bool
 }
 
 func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store) (*Server, error) {
@@ -39,17 +94,79 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	e.HideBanner = true
 	e.HidePort = true
 
+	// The hooks are run on all the memos and the resources saved, whichever API or integration saves them.
+	store.UseContentInterceptor(hookrunner.NewInterceptor())
+	eventBroker := event.NewBroker()
+	telegramHandler := integration.NewTelegramHandler(store, eventBroker)
+	discordHandler := integration.NewDiscordHandler(store, eventBroker)
 	s := &Server{
 		e:       e,
 		Store:   store,
 		Profile: profile,
 
 		// Asynchronous runners.
-		telegramBot: telegram.NewBotWithHandler(integration.NewTelegramHandler(store)),
+		telegramBot:     telegram.NewBotWithHandler(telegramHandler),
+		telegramHandler: telegramHandler,
+		slackService:    integration.NewSlackService(store, eventBroker),
+		mastodonService: integration.NewMastodonService(store, eventBroker),
+		blueskyService:  integration.NewBlueskyService(store, eventBroker),
+		gistService:     integration.NewGistService(store, eventBroker),
+		discordBot:      discord.NewBotWithHandler(discordHandler),
+		discordHandler:  discordHandler,
+		emailHandler:    integration.NewEmailHandler(store, eventBroker),
+
+		eventBroker:  eventBroker,
+		dispatchPool: workerpool.New(profile.DispatchWorkers, profile.DispatchDestinationLimit, profile.DispatchTimeout),
+	}
+	s.taskScheduler = s.newScheduler()
+
+	if profile.ReplicaURL != "" {
+		databaseReplicator, err := replicator.NewReplicator(ctx, profile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create database replicator")
+		}
+		s.replicator = databaseReplicator
+	}
+
+	if profile.EventBus != "" {
+		client, err := eventbus.NewPublisher(profile.EventBus, profile.EventBusSubject)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create event bus publisher")
+		}
+		s.eventPublisher = eventpublisher.NewPublisher(eventBroker, client)
 	}
 
+	markdown.SetCacheSize(profile.MarkdownCacheSize << 20)
+
+	ipExtractor, err := newIPExtractor(profile.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	if ipExtractor != nil {
+		e.IPExtractor = ipExtractor
+	}
+
+	// Register the tracing middleware first, so the spans cover the other middlewares.
+	e.Use(otelecho.Middleware(telemetry.ServiceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		return slices.Contains(healthPaths, c.Path())
+	})))
+	// Register the access log middleware after the tracing one, so the records are written within the spans.
+	e.Use(AccessLogMiddleware(profile.AccessLogSkip))
+	// Register the query stats middleware, the requests are routed before it so the queries are counted by the routes.
+	e.Use(QueryStatsMiddleware())
+	// Register API version middleware before routing, so the unversioned paths can be routed by the header.
+	e.Pre(APIVersionMiddleware())
 	// Register CORS middleware.
 	e.Use(CORSMiddleware())
+	if profile.HTTP3 {
+		s.http3Server = &http3.Server{Handler: e, Port: profile.Port}
+		// Register the Alt-Svc middleware, so the clients of HTTP/2 and HTTP/1.1 switch to HTTP/3.
+		e.Use(AltSvcMiddleware(s.http3Server))
+	}
+	if profile.Compression {
+		// Register the compression middleware after the access log one, so the sizes recorded are of the uncompressed responses.
+		e.Use(CompressMiddleware())
+	}
 
 	serverID, err := s.getSystemServerID(ctx)
 	if err != nil {
@@ -57,6 +174,15 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	}
 	s.ID = serverID
 
+	if profile.MQTTBroker != "" {
+		// The client id is derived from the server id, so the sessions of the instances sharing a broker don't take over each other.
+		client, err := mqtt.NewClient(profile.MQTTBroker, "memos-"+s.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create MQTT client")
+		}
+		s.mqttPublisher = mqttpublisher.NewPublisher(store, eventBroker, client, profile.MQTTTopic)
+	}
+
 	secret := "usememos"
 	if profile.Mode == "prod" {
 		secret, err = s.getSystemSecretSessionName(ctx)
@@ -66,10 +192,18 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	}
 	s.Secret = secret
 
-	// Register healthz endpoint.
-	e.GET("/healthz", func(c echo.Context) error {
-		return c.String(http.StatusOK, "Service ready.")
-	})
+	// The VAPID key is generated on the first start, so the concurrent requests don't generate different keys.
+	if _, err := apiv1.GetWebPushVAPIDKey(ctx, store); err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve Web Push VAPID key")
+	}
+
+	// Register the liveness and readiness probes.
+	s.registerHealthRoutes(e)
+	// Register the endpoint listing the API versions and their status.
+	e.GET(apiVersionsPath, listAPIVersions)
+
+	// Register the pages of the custom domains of the users, the requests of the domains are rewritten to them before routing.
+	domain.NewDomainService(store).Register(e)
 
 	// Only serve frontend when it's enabled.
 	if profile.Frontend {
@@ -79,12 +213,29 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 
 	// Register API v1 endpoints.
 	rootGroup := e.Group("")
-	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store, s.telegramBot)
+	// The quota is shared by api v1 and v2, so the requests of a token are counted together.
+	quotaLimiter := quota.NewLimiter(profile.APIQuota, profile.APIQuotaWindow)
+	// The replicas sharing the store count the requests of a token together.
+	if sharedState := store.GetSharedState(); quotaLimiter != nil && sharedState != nil {
+		quotaLimiter.UseCounter(sharedState)
+	}
+	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store, s.telegramBot, s.eventBroker, quotaLimiter, s.taskScheduler)
 	apiV1Service.Register(rootGroup)
+	// Register the endpoints of the Slack app, which are authenticated by the signature of Slack.
+	s.slackService.RegisterRoutes(rootGroup)
+	// Register the WebDAV tree of the memos, which is authenticated by basic auth.
+	integration.NewWebDAVHandler(store, s.eventBroker, s.Secret).RegisterRoutes(e)
+	// Register the sync endpoints of the Obsidian plugin, which are authenticated by access tokens.
+	integration.NewObsidianSyncService(store, s.eventBroker, s.Secret).RegisterRoutes(rootGroup)
 
-	apiV2Service := apiv2.NewAPIV2Service(s.Secret, profile, store, s.Profile.Port+1)
+	// The servers of the tenants aren't listening on a port, their gRPC servers listen on loopback ports chosen by the system.
+	grpcServerPort := 0
+	if profile.Port != 0 {
+		grpcServerPort = profile.Port + 1
+	}
+	s.apiV2Service = apiv2.NewAPIV2Service(s.Secret, profile, store, s.eventBroker, quotaLimiter, grpcServerPort)
 	// Register gRPC gateway as api v2.
-	if err := apiV2Service.RegisterGateway(ctx, e); err != nil {
+	if err := s.apiV2Service.RegisterGateway(ctx, e); err != nil {
 		return nil, errors.Wrap(err, "failed to register gRPC gateway")
 	}
 
@@ -92,13 +243,135 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 }
 
 func (s *Server) Start(ctx context.Context) error {
+	if err := s.StartRunners(ctx); err != nil {
+		return err
+	}
+	listener, err := handoff.Listen("http", fmt.Sprintf("%s:%d", s.Profile.Addr, s.Profile.Port), s.Profile.ReusePort)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen")
+	}
+	if !s.Profile.IsTLSEnabled() {
+		s.e.Listener = listener
+		s.ready()
+		return s.e.Start("")
+	}
+
+	tlsConfig, err := s.newTLSConfig()
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	if s.http3Server != nil {
+		if err := s.startHTTP3Server(tlsConfig); err != nil {
+			listener.Close()
+			return err
+		}
+	}
+	s.e.TLSListener = tls.NewListener(listener, tlsConfig)
+	s.e.TLSServer.TLSConfig = tlsConfig
+	s.ready()
+	return s.e.StartServer(s.e.TLSServer)
+}
+
+// StartRunners starts the asynchronous runners of the server without listening, e.g. for the servers of the tenants
+// mounted by the tenancy.
+func (s *Server) StartRunners(ctx context.Context) error {
 	go versionchecker.NewVersionChecker(s.Store, s.Profile).Start(ctx)
+	go webhookdispatcher.NewDispatcher(s.Store, s.dispatchPool).Start(ctx)
+	jobQueue := jobqueue.NewQueue(s.Store)
+	s.slackService.RegisterJobs(jobQueue)
+	s.mastodonService.RegisterJobs(jobQueue)
+	s.blueskyService.RegisterJobs(jobQueue)
+	go jobQueue.Start(ctx)
+	go notifier.NewNotifier(s.Store, s.eventBroker, s.dispatchPool).Start(ctx)
+	go s.taskScheduler.Start(ctx)
+	go ai.NewIndexer(s.Store, s.eventBroker).Start(ctx)
+	if s.eventPublisher != nil {
+		go s.eventPublisher.Start(ctx)
+	}
+	if s.mqttPublisher != nil {
+		go s.mqttPublisher.Start(ctx)
+	}
+	if s.replicator != nil {
+		go s.replicator.Start(ctx)
+	}
 	go s.telegramBot.Start(ctx)
-	return s.e.Start(fmt.Sprintf("%s:%d", s.Profile.Addr, s.Profile.Port))
+	go s.telegramHandler.SyncMemoMessages(ctx, s.telegramBot)
+	go s.slackService.Start(ctx)
+	go s.mastodonService.Start(ctx)
+	go s.blueskyService.Start(ctx)
+	go s.gistService.Start(ctx)
+	go s.discordBot.Start(ctx)
+	go s.discordHandler.PostPublicMemos(ctx, s.discordBot)
+	if s.Profile.SMTPAddr != "" {
+		if err := s.startMailServer(ctx); err != nil {
+			return errors.Wrap(err, "failed to start SMTP server")
+		}
+	}
+	if s.Profile.PprofAddr != "" {
+		if err := s.startDebugServer(ctx); err != nil {
+			return errors.Wrap(err, "failed to start pprof server")
+		}
+	}
+	return nil
+}
+
+// ready finishes the handoff, the process of the previous binary drains its requests and exits once all the listeners are served.
+func (s *Server) ready() {
+	if err := handoff.Ready(); err != nil {
+		slog.Warn("failed to finish handoff", slog.Any("err", err))
+	}
+}
+
+// startDebugServer serves the profiles and the dumps on the loopback address of the profile, until the context is done.
+func (s *Server) startDebugServer(ctx context.Context) error {
+	listener, err := handoff.Listen("pprof", s.Profile.PprofAddr, s.Profile.ReusePort)
+	if err != nil {
+		return err
+	}
+	debugServer := &http.Server{
+		Handler:           debug.NewHandler(filepath.Join(s.Profile.Data, "debug")),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		debugServer.Close()
+	}()
+	go func() {
+		if err := debugServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			slog.Error("pprof server stopped", slog.Any("err", err))
+		}
+	}()
+	return nil
+}
+
+// startMailServer listens on the SMTP address and receives the emails saved as memos, until the context is done.
+func (s *Server) startMailServer(ctx context.Context) error {
+	listener, err := handoff.Listen("smtp", s.Profile.SMTPAddr, s.Profile.ReusePort)
+	if err != nil {
+		return err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "memos"
+	}
+	mailServer := &mail.Server{
+		Handler:        s.emailHandler,
+		Hostname:       hostname,
+		MaxMessageSize: integration.MaxEmailSize,
+	}
+	go func() {
+		if err := mailServer.Serve(ctx, listener); err != nil {
+			slog.Error("SMTP server stopped", slog.Any("err", err))
+		}
+	}()
+	return nil
 }
 
 func (s *Server) Shutdown(ctx context.Context) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	s.shuttingDown.Store(true)
+	// The context isn't canceled yet, so the in-flight requests are drained until the timeout.
+	ctx, cancel := context.WithTimeout(ctx, s.Profile.ShutdownTimeout)
 	defer cancel()
 
 	// Shutdown echo server
@@ -106,6 +379,24 @@ func (s *Server) Shutdown(ctx context.Context) {
 		fmt.Printf("failed to shutdown server, error: %v\n", err)
 	}
 
+	// Shutdown HTTP/3 server
+	if s.http3Server != nil {
+		if err := s.http3Server.Shutdown(ctx); err != nil {
+			fmt.Printf("failed to shutdown HTTP/3 server, error: %v\n", err)
+		}
+	}
+
+	// Shutdown gRPC server
+	s.apiV2Service.Shutdown()
+
+	// Stop the deliveries of the webhooks and the notifications, the pending webhooks are delivered on the next start.
+	s.dispatchPool.Stop()
+
+	// Ship the remaining WAL to the replica before the database is closed.
+	if s.replicator != nil {
+		s.replicator.Stop()
+	}
+
 	// Close database connection
 	if err := s.Store.Close(); err != nil {
 		fmt.Printf("failed to close database, error: %v\n", err)
@@ -163,7 +454,8 @@ func grpcRequestSkipper(c echo.Context) bool {
 func CORSMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if grpcRequestSkipper(c) {
+			// The WebDAV clients aren't browsers, and they need the OPTIONS responses of the WebDAV handler.
+			if grpcRequestSkipper(c) || strings.HasPrefix(c.Request().URL.Path, integration.WebDAVPrefix+"/") || c.Request().URL.Path == integration.WebDAVPrefix {
 				return next(c)
 			}
 
@@ -172,7 +464,8 @@ func CORSMiddleware() echo.MiddlewareFunc {
 
 			w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key, "+apiversion.Header)
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join([]string{apiversion.Header, apiversion.DeprecationHeader, apiversion.SunsetHeader, apiversion.LinkHeader}, ", "))
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 			// If it's preflight request, return immediately.
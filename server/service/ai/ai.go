@@ -0,0 +1,228 @@
+// Package ai provides the opt-in AI assistance of the memos, with the models of an OpenAI compatible API configured by the host:
+// the summaries of the memos, the tags suggested for the saved memos and the semantic search of the memos by their embeddings.
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/openai"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// SummaryProperty is the name of the memo property of the summary of the memo, which is reused until the memo is updated.
+	SummaryProperty = "summary"
+	// SuggestedTagsProperty is the name of the memo property of the tags suggested for the memo, separated by commas.
+	SuggestedTagsProperty = "suggested_tags"
+
+	// maxInputLength is the number of the characters of the content sent to the models, the rest is cut off to fit their contexts.
+	maxInputLength = 8000
+	// maxSuggestedTags is the max number of the tags suggested for a memo.
+	maxSuggestedTags = 5
+)
+
+var (
+	// ErrDisabled is returned if the host hasn't configured the models of the assistance.
+	ErrDisabled = errors.New("AI assistance is disabled")
+	// listMarkerRegexp matches the markers of the items of the lists the models may answer with.
+	listMarkerRegexp = regexp.MustCompile(`^\s*(?:[-*]|\d+[.)])\s+`)
+)
+
+// Assistant is the assistance of the models configured in the AI setting of the workspace.
+type Assistant struct {
+	setting *storepb.AISetting
+	client  *openai.Client
+}
+
+// NewAssistant returns the assistant of the AI setting of the workspace, or ErrDisabled if there is no endpoint.
+func NewAssistant(ctx context.Context, s *store.Store) (*Assistant, error) {
+	integrationSetting, err := s.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace integration setting")
+	}
+	setting := integrationSetting.GetAi()
+	if setting.GetEndpoint() == "" {
+		return nil, ErrDisabled
+	}
+	return &Assistant{
+		setting: setting,
+		client:  openai.NewClient(setting.Endpoint, setting.ApiKey),
+	}, nil
+}
+
+// CanChat returns true if the chat model is configured, for the summaries and the tag suggestions.
+func (a *Assistant) CanChat() bool {
+	return a.setting.ChatModel != ""
+}
+
+// CanEmbed returns true if the embedding model is configured, for the semantic search.
+func (a *Assistant) CanEmbed() bool {
+	return a.setting.EmbeddingModel != ""
+}
+
+// SuggestsTags returns true if the tags of the saved memos are suggested.
+func (a *Assistant) SuggestsTags() bool {
+	return a.setting.SuggestTags && a.CanChat()
+}
+
+// EmbeddingModel returns the model of the embeddings, the stored embeddings of other models are outdated.
+func (a *Assistant) EmbeddingModel() string {
+	return a.setting.EmbeddingModel
+}
+
+// Summarize returns the summary of the content.
+func (a *Assistant) Summarize(ctx context.Context, content string) (string, error) {
+	if !a.CanChat() {
+		return "", ErrDisabled
+	}
+	return a.client.ChatCompletion(ctx, a.setting.ChatModel, []openai.ChatCompletionMessage{
+		{
+			Role:    "system",
+			Content: "You summarize the notes of the user. Answer with the summary of the note in one to three sentences, in the language of the note, without any introduction.",
+		},
+		{Role: "user", Content: truncateInput(content)},
+	})
+}
+
+// SummarizeMemo returns the summary of the memo, the summary is kept in the properties of the memo until it's updated.
+func (a *Assistant) SummarizeMemo(ctx context.Context, s *store.Store, memo *store.Memo) (string, error) {
+	name := SummaryProperty
+	property, err := s.GetMemoProperty(ctx, &store.FindMemoProperty{MemoID: &memo.ID, Name: &name})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get memo property")
+	}
+	if property != nil && property.Value != "" && property.UpdatedTs >= memo.UpdatedTs {
+		return property.Value, nil
+	}
+	summary, err := a.Summarize(ctx, memo.Content)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      SummaryProperty,
+		Value:     summary,
+		UpdatedTs: time.Now().Unix(),
+	}); err != nil {
+		return "", errors.Wrap(err, "failed to upsert memo property")
+	}
+	return summary, nil
+}
+
+// SuggestTags returns the tags suggested for the content, which doesn't have them yet.
+// The existing tags of the user are preferred, and the suggestions matching them are spelled like them.
+func (a *Assistant) SuggestTags(ctx context.Context, content string, existingTags []string) ([]string, error) {
+	if !a.CanChat() {
+		return nil, ErrDisabled
+	}
+	prompt := "You suggest the tags of the notes of the user. Answer with a JSON array of at most 5 short tags for the note, without the # signs and without any explanation."
+	if len(existingTags) > 0 {
+		prompt += " Prefer the existing tags of the user when they fit: " + strings.Join(existingTags, ", ") + "."
+	}
+	answer, err := a.client.ChatCompletion(ctx, a.setting.ChatModel, []openai.ChatCompletionMessage{
+		{Role: "system", Content: prompt},
+		{Role: "user", Content: truncateInput(content)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseSuggestedTags(answer, content, existingTags), nil
+}
+
+// Embed returns the embeddings of the contents, in the order of the contents.
+func (a *Assistant) Embed(ctx context.Context, contents []string) ([][]float32, error) {
+	if !a.CanEmbed() {
+		return nil, ErrDisabled
+	}
+	inputs := make([]string, len(contents))
+	for i, content := range contents {
+		inputs[i] = truncateInput(content)
+	}
+	return a.client.CreateEmbeddings(ctx, a.setting.EmbeddingModel, inputs)
+}
+
+// CosineSimilarity returns the cosine of the angle between the vectors, 0 if their dimensions differ.
+func CosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
+
+// parseSuggestedTags returns the tags of the answer of the model, which is a JSON array, or the tags separated by commas or lines
+// if the model doesn't follow the prompt. The tags already in the content are left out.
+func parseSuggestedTags(answer, content string, existingTags []string) []string {
+	names := []string{}
+	if start, end := strings.Index(answer, "["), strings.LastIndex(answer, "]"); start >= 0 && end > start {
+		if err := json.Unmarshal([]byte(answer[start:end+1]), &names); err != nil {
+			names = nil
+		}
+	}
+	if len(names) == 0 {
+		for _, name := range strings.FieldsFunc(answer, func(r rune) bool {
+			return r == ',' || r == '\n'
+		}) {
+			names = append(names, listMarkerRegexp.ReplaceAllString(name, ""))
+		}
+	}
+
+	contentTags := map[string]bool{}
+	for _, word := range strings.Fields(content) {
+		if tag, ok := strings.CutPrefix(word, "#"); ok {
+			contentTags[strings.ToLower(strings.TrimRightFunc(tag, unicode.IsPunct))] = true
+		}
+	}
+	existing := map[string]string{}
+	for _, tag := range existingTags {
+		existing[strings.ToLower(tag)] = tag
+	}
+	tags := []string{}
+	added := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(strings.Trim(strings.TrimSpace(name), `"'`+"`"))
+		name = strings.TrimLeft(name, "#")
+		tag := strings.Join(strings.Fields(name), "-")
+		if tag == "" || strings.ContainsAny(tag, ",#[]") {
+			continue
+		}
+		if existingTag, ok := existing[strings.ToLower(tag)]; ok {
+			tag = existingTag
+		}
+		key := strings.ToLower(tag)
+		if added[key] || contentTags[key] {
+			continue
+		}
+		added[key] = true
+		tags = append(tags, tag)
+		if len(tags) == maxSuggestedTags {
+			break
+		}
+	}
+	return tags
+}
+
+func truncateInput(content string) string {
+	content = strings.TrimSpace(content)
+	if runes := []rune(content); len(runes) > maxInputLength {
+		return string(runes[:maxInputLength])
+	}
+	return content
+}
@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSuggestedTags(t *testing.T) {
+	tests := []struct {
+		answer       string
+		content      string
+		existingTags []string
+		want         []string
+	}{
+		{
+			answer: `["gardening", "#Spring", "tomato plants", "gardening"]`,
+			want:   []string{"gardening", "Spring", "tomato-plants"},
+		},
+		{
+			// The suggestions are spelled like the existing tags, and the tags of the content are left out.
+			answer:       "Here are the tags: [\"Book\", \"reading\", \"2024\"]",
+			content:      "Finished the novel #reading.",
+			existingTags: []string{"book"},
+			want:         []string{"book", "2024"},
+		},
+		{
+			answer: "1. travel\n2. japan\n- food",
+			want:   []string{"travel", "japan", "food"},
+		},
+		{
+			answer: "work, meetings, notes, ideas, todo, extra",
+			want:   []string{"work", "meetings", "notes", "ideas", "todo"},
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, parseSuggestedTags(test.answer, test.content, test.existingTags))
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	require.InDelta(t, 1, CosineSimilarity([]float32{1, 2}, []float32{2, 4}), 1e-6)
+	require.InDelta(t, 0, CosineSimilarity([]float32{1, 0}, []float32{0, 1}), 1e-6)
+	require.InDelta(t, -1, CosineSimilarity([]float32{1, 0}, []float32{-1, 0}), 1e-6)
+	require.Zero(t, CosineSimilarity([]float32{1, 0}, []float32{1, 0, 0}))
+	require.Zero(t, CosineSimilarity([]float32{0, 0}, []float32{1, 0}))
+}
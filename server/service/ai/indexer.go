@@ -0,0 +1,196 @@
+package ai
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxIndexedMemos is the max number of the memos embedded in an interval.
+	maxIndexedMemos = 256
+	// embeddingBatchSize is the number of the memos embedded in a request.
+	embeddingBatchSize = 16
+)
+
+// Indexer embeds the saved memos for the semantic search and suggests their tags, when the assistance is enabled.
+type Indexer struct {
+	Store       *store.Store
+	EventBroker *event.Broker
+}
+
+func NewIndexer(store *store.Store, eventBroker *event.Broker) *Indexer {
+	return &Indexer{
+		Store:       store,
+		EventBroker: eventBroker,
+	}
+}
+
+// Start handles the saved memos until the context is done, the outdated memos are embedded by the scheduler.
+func (i *Indexer) Start(ctx context.Context) {
+	subscription := i.EventBroker.Subscribe()
+	defer i.EventBroker.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type != event.MemoCreated && e.Type != event.MemoUpdated {
+				continue
+			}
+			if err := i.handleMemo(ctx, e.MemoID); err != nil && !errors.Is(err, ErrDisabled) {
+				slog.Warn("Failed to index memo", slog.Int("memo", int(e.MemoID)), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// handleMemo suggests the tags of the saved memo and updates its embedding.
+func (i *Indexer) handleMemo(ctx context.Context, memoID int32) error {
+	assistant, err := NewAssistant(ctx, i.Store)
+	if err != nil {
+		return err
+	}
+	memo, err := i.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil {
+		return errors.Wrap(err, "failed to get memo")
+	}
+	if memo == nil || memo.RowStatus != store.Normal || strings.TrimSpace(memo.Content) == "" {
+		return nil
+	}
+	if assistant.SuggestsTags() {
+		if err := i.suggestTags(ctx, assistant, memo); err != nil {
+			return err
+		}
+	}
+	if assistant.CanEmbed() {
+		embedding, err := i.getEmbedding(ctx, assistant, memo.ID)
+		if err != nil {
+			return err
+		}
+		if embedding == nil || embedding.UpdatedTs < memo.UpdatedTs {
+			return i.embedMemos(ctx, assistant, []*store.Memo{memo})
+		}
+	}
+	return nil
+}
+
+// suggestTags keeps the tags suggested for the memo in its properties, the suggestions are updated when the memo is edited.
+func (i *Indexer) suggestTags(ctx context.Context, assistant *Assistant, memo *store.Memo) error {
+	name := SuggestedTagsProperty
+	property, err := i.Store.GetMemoProperty(ctx, &store.FindMemoProperty{MemoID: &memo.ID, Name: &name})
+	if err != nil {
+		return errors.Wrap(err, "failed to get memo property")
+	}
+	if property != nil && property.UpdatedTs >= memo.UpdatedTs {
+		return nil
+	}
+	userTags, err := i.Store.ListTags(ctx, &store.FindTag{CreatorID: memo.CreatorID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list tags")
+	}
+	existingTags := []string{}
+	for _, tag := range userTags {
+		existingTags = append(existingTags, tag.Name)
+	}
+	tags, err := assistant.SuggestTags(ctx, memo.Content, existingTags)
+	if err != nil {
+		return errors.Wrap(err, "failed to suggest tags")
+	}
+	if _, err := i.Store.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      SuggestedTagsProperty,
+		Value:     strings.Join(tags, ","),
+		UpdatedTs: time.Now().Unix(),
+	}); err != nil {
+		return errors.Wrap(err, "failed to upsert memo property")
+	}
+	return nil
+}
+
+func (i *Indexer) getEmbedding(ctx context.Context, assistant *Assistant, memoID int32) (*store.MemoEmbedding, error) {
+	model := assistant.EmbeddingModel()
+	embeddings, err := i.Store.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{MemoID: &memoID, Model: &model})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo embeddings")
+	}
+	if len(embeddings) == 0 {
+		return nil, nil
+	}
+	return embeddings[0], nil
+}
+
+// IndexOutdatedMemos embeds the memos whose embeddings are missing, older than the memos or of another model,
+// e.g. the memos saved before the semantic search was enabled, or while the endpoint was unavailable.
+func (i *Indexer) IndexOutdatedMemos(ctx context.Context) error {
+	assistant, err := NewAssistant(ctx, i.Store)
+	if err != nil {
+		return err
+	}
+	if !assistant.CanEmbed() {
+		return ErrDisabled
+	}
+	model := assistant.EmbeddingModel()
+	embeddings, err := i.Store.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{Model: &model})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memo embeddings")
+	}
+	embeddedTs := map[int32]int64{}
+	for _, embedding := range embeddings {
+		embeddedTs[embedding.MemoID] = embedding.UpdatedTs
+	}
+	normalStatus := store.Normal
+	memos, err := i.Store.ListMemos(ctx, &store.FindMemo{RowStatus: &normalStatus})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memos")
+	}
+	outdated := []*store.Memo{}
+	for _, memo := range memos {
+		if ts, ok := embeddedTs[memo.ID]; (!ok || ts < memo.UpdatedTs) && strings.TrimSpace(memo.Content) != "" {
+			outdated = append(outdated, memo)
+		}
+		if len(outdated) == maxIndexedMemos {
+			break
+		}
+	}
+	for start := 0; start < len(outdated); start += embeddingBatchSize {
+		end := min(start+embeddingBatchSize, len(outdated))
+		if err := i.embedMemos(ctx, assistant, outdated[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Indexer) embedMemos(ctx context.Context, assistant *Assistant, memos []*store.Memo) error {
+	contents := []string{}
+	for _, memo := range memos {
+		contents = append(contents, memo.Content)
+	}
+	vectors, err := assistant.Embed(ctx, contents)
+	if err != nil {
+		return errors.Wrap(err, "failed to embed memos")
+	}
+	now := time.Now().Unix()
+	for index, memo := range memos {
+		if _, err := i.Store.UpsertMemoEmbedding(ctx, &store.MemoEmbedding{
+			MemoID:    memo.ID,
+			Model:     assistant.EmbeddingModel(),
+			Embedding: vectors[index],
+			UpdatedTs: now,
+		}); err != nil {
+			return errors.Wrap(err, "failed to upsert memo embedding")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package eventpublisher publishes the events of the broker to the external event bus, so the automations react to the changes
+// of the memos, the resources and the users without the webhooks of every user.
+package eventpublisher
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/plugin/eventbus"
+	"github.com/usememos/memos/store"
+)
+
+// publishTimeout is the timeout of publishing an event, the events published while the bus is unreachable are dropped.
+const publishTimeout = 5 * time.Second
+
+// Message is the message of an event published to the bus.
+type Message struct {
+	*event.Event
+	// OwnerID is the id of the user who owns the memo or the resource, or the user of the user events.
+	OwnerID    int32            `json:"ownerId"`
+	Visibility store.Visibility `json:"visibility"`
+}
+
+type Publisher struct {
+	EventBroker *event.Broker
+	Client      eventbus.Publisher
+}
+
+func NewPublisher(eventBroker *event.Broker, client eventbus.Publisher) *Publisher {
+	return &Publisher{
+		EventBroker: eventBroker,
+		Client:      client,
+	}
+}
+
+// Start publishes the events until the context is done. The events are delivered at most once,
+// the broker drops the events if the bus is slower than the changes.
+func (p *Publisher) Start(ctx context.Context) {
+	subscription := p.EventBroker.Subscribe()
+	defer p.EventBroker.Unsubscribe(subscription)
+	defer p.Client.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			p.publish(ctx, e)
+		}
+	}
+}
+
+func (p *Publisher) publish(ctx context.Context, e *event.Event) {
+	data, err := json.Marshal(&Message{
+		Event:      e,
+		OwnerID:    e.OwnerID,
+		Visibility: e.Visibility,
+	})
+	if err != nil {
+		slog.Warn("Failed to marshal event", slog.Any("err", err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if err := p.Client.Publish(ctx, string(e.Type), data); err != nil {
+		slog.Warn("Failed to publish event", slog.String("type", string(e.Type)), slog.Any("err", err))
+	}
+}
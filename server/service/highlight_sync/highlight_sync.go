@@ -0,0 +1,361 @@
+package highlightsync
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/highlight"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	"github.com/usememos/memos/server/service/importer"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// articleProperty is the name of the memo property of the article of a memo, e.g. readwise:123.
+	articleProperty = "highlight_article"
+	// highlightIDsProperty is the name of the memo property of the ids of the highlights in the memo of an article.
+	highlightIDsProperty = "highlight_ids"
+	// highlightProperty is the name of the memo property of the highlight of a memo, e.g. omnivore:abc.
+	highlightProperty = "highlight"
+)
+
+// Syncer imports the highlights of the Readwise and the Omnivore accounts in the user settings as the private memos.
+// The imported articles and highlights are kept as the memo properties, so they're imported once.
+type Syncer struct {
+	Store *store.Store
+}
+
+func NewSyncer(store *store.Store) *Syncer {
+	return &Syncer{
+		Store: store,
+	}
+}
+
+// SyncAll imports the new highlights of all the users, it is run by the scheduler.
+func (s *Syncer) SyncAll(ctx context.Context) error {
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list user settings")
+	}
+	for _, userSetting := range userSettings {
+		setting := userSetting.GetHighlightSync()
+		if setting.GetProvider() == storepb.HighlightSyncUserSetting_PROVIDER_UNSPECIFIED || setting.GetToken() == "" {
+			continue
+		}
+		if err := s.Sync(ctx, userSetting.UserId, setting); err != nil {
+			slog.Warn("Failed to sync highlights of user", slog.Int("user", int(userSetting.UserId)), slog.Any("err", err))
+		}
+	}
+	return nil
+}
+
+// Sync imports the highlights of the account updated after the last sync.
+func (s *Syncer) Sync(ctx context.Context, userID int32, setting *storepb.HighlightSyncUserSetting) error {
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil || user == nil || user.RowStatus == store.Archived {
+		return err
+	}
+	var source highlight.Source
+	var prefix string
+	switch setting.Provider {
+	case storepb.HighlightSyncUserSetting_READWISE:
+		source, prefix = highlight.NewReadwiseClient(setting.Token), "readwise:"
+	case storepb.HighlightSyncUserSetting_OMNIVORE:
+		source, prefix = highlight.NewOmnivoreClient(setting.Token), "omnivore:"
+	default:
+		return errors.Errorf("unsupported highlight provider %d", setting.Provider)
+	}
+
+	syncTs := time.Now().Unix()
+	updatedAfter := time.Time{}
+	if setting.LastSyncTs > 0 {
+		updatedAfter = time.Unix(setting.LastSyncTs, 0)
+	}
+	articles, err := source.ListArticles(ctx, updatedAfter)
+	if err != nil {
+		return err
+	}
+	result, err := s.importArticles(ctx, user, setting, prefix, articles)
+	if err != nil {
+		return err
+	}
+	// The time of the sync is kept if some highlights failed to import, so they are imported by the next sync.
+	if len(result.Errors) > 0 {
+		return errors.Errorf("failed to import highlights: %s", strings.Join(result.Errors, "; "))
+	}
+
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC,
+	})
+	if err != nil {
+		return err
+	}
+	// The setting may be changed during the sync, the time of the sync is only kept for the same account.
+	current := userSetting.GetHighlightSync()
+	if current.GetProvider() != setting.Provider || current.GetToken() != setting.Token {
+		return nil
+	}
+	current.LastSyncTs = syncTs
+	_, err = s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC,
+		Value: &storepb.UserSetting_HighlightSync{
+			HighlightSync: current,
+		},
+	})
+	return err
+}
+
+// importArticles imports the highlights of the articles which aren't imported yet.
+// The new highlights of an article imported as a memo are appended to the memo.
+func (s *Syncer) importArticles(ctx context.Context, user *store.User, setting *storepb.HighlightSyncUserSetting, prefix string, articles []*highlight.Article) (*importer.Result, error) {
+	notes := []*importer.Note{}
+	noteProperties := map[string][]*store.MemoProperty{}
+	for _, article := range articles {
+		if setting.Mode == storepb.HighlightSyncUserSetting_MEMO_PER_HIGHLIGHT {
+			for _, h := range article.Highlights {
+				memo, err := s.findMemo(ctx, user.ID, highlightProperty, prefix+h.ID)
+				if err != nil {
+					return nil, err
+				}
+				if memo != nil {
+					continue
+				}
+				note := newHighlightNote(article, h, setting.Tag)
+				notes = append(notes, note)
+				noteProperties[note.UID] = []*store.MemoProperty{{Name: highlightProperty, Value: prefix + h.ID}}
+			}
+			continue
+		}
+
+		memo, err := s.findMemo(ctx, user.ID, articleProperty, prefix+article.ID)
+		if err != nil {
+			return nil, err
+		}
+		if memo == nil {
+			note := newArticleNote(article, setting.Tag)
+			notes = append(notes, note)
+			noteProperties[note.UID] = []*store.MemoProperty{
+				{Name: articleProperty, Value: prefix + article.ID},
+				{Name: highlightIDsProperty, Value: strings.Join(getHighlightIDs(article.Highlights), ",")},
+			}
+			continue
+		}
+		if err := s.appendHighlights(ctx, user, memo, article); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := apiv1.ImportNotes(ctx, s.Store, user, notes, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &note.UID})
+		if err != nil {
+			return nil, err
+		}
+		if memo == nil {
+			continue
+		}
+		for _, property := range noteProperties[note.UID] {
+			property.MemoID = memo.ID
+			property.UpdatedTs = time.Now().Unix()
+			if _, err := s.Store.UpsertMemoProperty(ctx, property); err != nil {
+				return nil, errors.Wrap(err, "failed to upsert memo property")
+			}
+		}
+	}
+	return result, nil
+}
+
+// appendHighlights appends the highlights of the article which aren't in the memo of the article yet.
+func (s *Syncer) appendHighlights(ctx context.Context, user *store.User, memo *store.Memo, article *highlight.Article) error {
+	name := highlightIDsProperty
+	property, err := s.Store.GetMemoProperty(ctx, &store.FindMemoProperty{MemoID: &memo.ID, Name: &name})
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	if property != nil && property.Value != "" {
+		ids = strings.Split(property.Value, ",")
+	}
+	highlights := []*highlight.Highlight{}
+	for _, h := range article.Highlights {
+		if !slices.Contains(ids, h.ID) {
+			highlights = append(highlights, h)
+		}
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	formatted := []string{}
+	note := &importer.Note{}
+	for _, h := range highlights {
+		formatted = append(formatted, formatHighlight(h))
+		note.Tags = append(note.Tags, h.Tags...)
+	}
+	note.Content = insertBeforeTags(memo.Content, strings.Join(formatted, "\n\n"))
+	content, updatedTs := note.MemoContent(), time.Now().Unix()
+	if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, UpdatedTs: &updatedTs}); err != nil {
+		return errors.Wrap(err, "failed to update memo")
+	}
+	for _, tag := range note.Tags {
+		if _, err := s.Store.UpsertTag(ctx, &store.Tag{Name: importer.FormatTag(tag), CreatorID: user.ID}); err != nil {
+			return errors.Wrap(err, "failed to upsert tag")
+		}
+	}
+	_, err = s.Store.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      highlightIDsProperty,
+		Value:     strings.Join(append(ids, getHighlightIDs(highlights)...), ","),
+		UpdatedTs: updatedTs,
+	})
+	return err
+}
+
+// findMemo returns the memo of the user which has the property, nil if it's not found.
+func (s *Syncer) findMemo(ctx context.Context, userID int32, name, value string) (*store.Memo, error) {
+	properties, err := s.Store.ListMemoProperties(ctx, &store.FindMemoProperty{Name: &name, Value: &value})
+	if err != nil {
+		return nil, err
+	}
+	for _, property := range properties {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &property.MemoID})
+		if err != nil {
+			return nil, err
+		}
+		if memo != nil && memo.CreatorID == userID {
+			return memo, nil
+		}
+	}
+	return nil, nil
+}
+
+// newArticleNote returns the note of the article, which is its title and source followed by its highlights.
+func newArticleNote(article *highlight.Article, tag string) *importer.Note {
+	note := &importer.Note{UID: importer.NewUID(), Source: article.Title}
+	parts := []string{}
+	if title := strings.TrimSpace(article.Title); title != "" {
+		parts = append(parts, "# "+title)
+	}
+	if source := formatSource(article.Author, article.URL); source != "" {
+		parts = append(parts, source)
+	}
+	for _, h := range article.Highlights {
+		parts = append(parts, formatHighlight(h))
+		note.Tags = append(note.Tags, h.Tags...)
+		if h.HighlightedTs > 0 && (note.CreatedTs == 0 || h.HighlightedTs < note.CreatedTs) {
+			note.CreatedTs = h.HighlightedTs
+		}
+	}
+	note.Content = strings.Join(parts, "\n\n")
+	note.Tags = append(getArticleTags(article, tag), note.Tags...)
+	return note
+}
+
+// newHighlightNote returns the note of the highlight, which is followed by the title and the source of its article.
+func newHighlightNote(article *highlight.Article, h *highlight.Highlight, tag string) *importer.Note {
+	note := &importer.Note{
+		UID:       importer.NewUID(),
+		Source:    article.Title,
+		CreatedTs: h.HighlightedTs,
+		Tags:      append(getArticleTags(article, tag), h.Tags...),
+	}
+	title := strings.TrimSpace(article.Title)
+	if title != "" && article.URL != "" {
+		title = "[" + title + "](" + article.URL + ")"
+	} else if title == "" {
+		title = article.URL
+	}
+	if author := strings.TrimSpace(article.Author); author != "" {
+		title = strings.TrimPrefix(title+", "+author, ", ")
+	}
+	note.Content = formatHighlight(h)
+	if title != "" {
+		note.Content += "\n\n— " + title
+	}
+	return note
+}
+
+// formatHighlight returns the highlighted text as a quote followed by the note.
+func formatHighlight(h *highlight.Highlight) string {
+	parts := []string{}
+	if text := strings.TrimSpace(strings.ReplaceAll(h.Text, "\r\n", "\n")); text != "" {
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace("> " + line)
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	if note := strings.TrimSpace(h.Note); note != "" {
+		parts = append(parts, note)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// insertBeforeTags returns the content with the text inserted before its last paragraph if it's the tags of the memo.
+func insertBeforeTags(content, text string) string {
+	content = strings.TrimSpace(content)
+	rest, last, ok := cutLastParagraph(content)
+	if !ok || !isTagLine(last) {
+		return content + "\n\n" + text
+	}
+	return rest + "\n\n" + text + "\n\n" + last
+}
+
+func cutLastParagraph(content string) (string, string, bool) {
+	i := strings.LastIndex(content, "\n\n")
+	if i < 0 {
+		return "", "", false
+	}
+	return content[:i], content[i+2:], true
+}
+
+func isTagLine(line string) bool {
+	fields := strings.Fields(line)
+	for _, field := range fields {
+		if len(field) < 2 || field[0] != '#' {
+			return false
+		}
+	}
+	return len(fields) > 0
+}
+
+// formatSource returns the line of the author and the URL of an article.
+func formatSource(author, url string) string {
+	parts := []string{}
+	if author = strings.TrimSpace(author); author != "" {
+		parts = append(parts, "by "+author)
+	}
+	if url != "" {
+		parts = append(parts, url)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func getArticleTags(article *highlight.Article, tag string) []string {
+	tags := []string{}
+	if tag != "" {
+		tags = append(tags, tag)
+	}
+	return append(tags, article.Tags...)
+}
+
+func getHighlightIDs(highlights []*highlight.Highlight) []string {
+	ids := []string{}
+	for _, h := range highlights {
+		ids = append(ids, h.ID)
+	}
+	return ids
+}
@@ -0,0 +1,43 @@
+package highlightsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/highlight"
+)
+
+func TestNewArticleNote(t *testing.T) {
+	article := &highlight.Article{
+		ID:     "1",
+		Title:  "Book",
+		Author: "Alice",
+		URL:    "https://example.com/book",
+		Tags:   []string{"reading"},
+		Highlights: []*highlight.Highlight{
+			{ID: "10", Text: "First line\nSecond line", Note: "Nice", HighlightedTs: 200, Tags: []string{"idea"}},
+			{ID: "11", Text: "Another", HighlightedTs: 100},
+		},
+	}
+	note := newArticleNote(article, "highlights")
+	require.Equal(t, "# Book\n\nby Alice · https://example.com/book\n\n> First line\n> Second line\n\nNice\n\n> Another", note.Content)
+	require.Equal(t, "# Book\n\nby Alice · https://example.com/book\n\n> First line\n> Second line\n\nNice\n\n> Another\n\n#highlights #reading #idea", note.MemoContent())
+	require.Equal(t, int64(100), note.CreatedTs)
+}
+
+func TestNewHighlightNote(t *testing.T) {
+	article := &highlight.Article{Title: "Book", Author: "Alice", URL: "https://example.com/book"}
+	note := newHighlightNote(article, &highlight.Highlight{ID: "10", Text: "Quote", HighlightedTs: 100}, "")
+	require.Equal(t, "> Quote\n\n— [Book](https://example.com/book), Alice", note.MemoContent())
+	require.Equal(t, int64(100), note.CreatedTs)
+
+	note = newHighlightNote(&highlight.Article{URL: "https://example.com/page"}, &highlight.Highlight{Note: "A page note"}, "highlights")
+	require.Equal(t, "A page note\n\n— https://example.com/page\n\n#highlights", note.MemoContent())
+}
+
+func TestInsertBeforeTags(t *testing.T) {
+	require.Equal(t, "# Book\n\n> First\n\n> Second\n\n#highlights #reading", insertBeforeTags("# Book\n\n> First\n\n#highlights #reading", "> Second"))
+	require.Equal(t, "# Book\n\n> First\n\n> Second", insertBeforeTags("# Book\n\n> First", "> Second"))
+	require.Equal(t, "# Book\n\n> Second", insertBeforeTags("# Book", "> Second"))
+}
@@ -0,0 +1,285 @@
+// Package hookrunner runs the hooks of the workspace hook setting on the memo and upload events.
+// The memo and upload hooks are run by the store through the Interceptor, and the render hooks by the feeds and the exports.
+package hookrunner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/plugin/hook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// HooksDirName is the directory of the executables of the EXEC hooks in the data directory.
+	HooksDirName = "hooks"
+	// defaultHookTimeout is the timeout of a run of a hook without a timeout.
+	defaultHookTimeout = 5 * time.Second
+	// maxRenderCacheSize is the max number of the contents rendered by the hooks which are cached.
+	maxRenderCacheSize = 1024
+)
+
+var (
+	// renderCache caches the contents rendered by the hooks, by the hash of the hooks and the request,
+	// since the feeds render the same memos on every request.
+	renderCache      = map[string]string{}
+	renderCacheMutex sync.Mutex
+)
+
+// RejectedError is the error of an action rejected by a hook, which is shown to the user.
+type RejectedError struct {
+	Hook    string
+	Message string
+}
+
+func (e *RejectedError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Rejected by hook %s", e.Hook)
+	}
+	return fmt.Sprintf("Rejected by hook %s: %s", e.Hook, e.Message)
+}
+
+// Interceptor runs the hooks on the memos and the resources saved to the store,
+// so the policies of the hooks are enforced on all of them, whichever API, integration or import saves them.
+type Interceptor struct{}
+
+// NewInterceptor returns the interceptor of the store which runs the hooks.
+func NewInterceptor() *Interceptor {
+	return &Interceptor{}
+}
+
+func (*Interceptor) InterceptMemoCreate(ctx context.Context, s *store.Store, create *store.Memo) error {
+	content, err := RunMemoHooks(ctx, s, storepb.WorkspaceHook_MEMO_CREATE, convertMemoToHookMemo(create))
+	if err != nil {
+		return err
+	}
+	create.Content = content
+	return nil
+}
+
+func (*Interceptor) InterceptMemoUpdate(ctx context.Context, s *store.Store, update *store.UpdateMemo) error {
+	hooks, err := listEventHooks(ctx, s, storepb.WorkspaceHook_MEMO_UPDATE)
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+	memo, err := s.GetMemo(ctx, &store.FindMemo{ID: &update.ID, ExcludeContent: true})
+	if err != nil {
+		return errors.Wrap(err, "Failed to get memo")
+	}
+	if memo == nil {
+		return errors.Errorf("memo %d not found", update.ID)
+	}
+	request := &hook.Request{
+		Event: hook.EventMemoUpdate,
+		Memo:  convertMemoToHookMemo(memo),
+	}
+	request.Memo.Content = *update.Content
+	if update.Visibility != nil {
+		request.Memo.Visibility = update.Visibility.String()
+	}
+	if err := runHooks(ctx, s.Profile, hooks, request); err != nil {
+		return err
+	}
+	update.Content = &request.Memo.Content
+	return nil
+}
+
+func (*Interceptor) InterceptResourceCreate(ctx context.Context, s *store.Store, create *store.Resource) error {
+	return RunResourceHooks(ctx, s, create)
+}
+
+// RunMemoHooks runs the hooks of the event on the memo, and returns the content transformed by the hooks.
+// A RejectedError is returned if a hook rejects the memo.
+func RunMemoHooks(ctx context.Context, s *store.Store, event storepb.WorkspaceHook_Event, memo *hook.Memo) (string, error) {
+	hooks, err := listEventHooks(ctx, s, event)
+	if err != nil {
+		return "", err
+	}
+	request := &hook.Request{
+		Event: getHookEventName(event),
+		Memo:  memo,
+	}
+	if err := runHooks(ctx, s.Profile, hooks, request); err != nil {
+		return "", err
+	}
+	return request.Memo.Content, nil
+}
+
+// RunResourceHooks runs the upload hooks on the resource, a RejectedError is returned if a hook rejects it.
+func RunResourceHooks(ctx context.Context, s *store.Store, resource *store.Resource) error {
+	hooks, err := listEventHooks(ctx, s, storepb.WorkspaceHook_RESOURCE_UPLOAD)
+	if err != nil {
+		return err
+	}
+	return runHooks(ctx, s.Profile, hooks, &hook.Request{
+		Event: hook.EventResourceUpload,
+		Resource: &hook.Resource{
+			CreatorID: resource.CreatorID,
+			Filename:  resource.Filename,
+			Type:      resource.Type,
+			Size:      resource.Size,
+		},
+	})
+}
+
+// RenderMemoContent returns the content of the memo transformed by the render hooks, for the contents rendered by the server.
+// The content is rendered as is if a hook fails, so a failed hook doesn't break the feeds.
+func RenderMemoContent(ctx context.Context, s *store.Store, memo *store.Memo) string {
+	hooks, err := listEventHooks(ctx, s, storepb.WorkspaceHook_MEMO_RENDER)
+	if err != nil || len(hooks) == 0 {
+		return memo.Content
+	}
+	request := &hook.Request{
+		Event: hook.EventMemoRender,
+		Memo:  convertMemoToHookMemo(memo),
+	}
+	cacheKey, err := getRenderCacheKey(hooks, request)
+	if err != nil {
+		return memo.Content
+	}
+	renderCacheMutex.Lock()
+	content, ok := renderCache[cacheKey]
+	renderCacheMutex.Unlock()
+	if ok {
+		return content
+	}
+	if err := runHooks(ctx, s.Profile, hooks, request); err != nil {
+		slog.Warn("Failed to render memo with hooks", slog.Int("memo", int(memo.ID)), slog.Any("err", err))
+		return memo.Content
+	}
+	renderCacheMutex.Lock()
+	if len(renderCache) >= maxRenderCacheSize {
+		clear(renderCache)
+	}
+	renderCache[cacheKey] = request.Memo.Content
+	renderCacheMutex.Unlock()
+	return request.Memo.Content
+}
+
+// GetCommandPath returns the path of the executable of the command in the hooks directory,
+// the command must be a filename so the hooks can't run the other executables of the server.
+func GetCommandPath(profile *profile.Profile, command string) (string, error) {
+	if command == "" || command != filepath.Base(command) || strings.HasPrefix(command, ".") {
+		return "", errors.Errorf("invalid hook command %q", command)
+	}
+	return filepath.Join(profile.Data, HooksDirName, command), nil
+}
+
+func convertMemoToHookMemo(memo *store.Memo) *hook.Memo {
+	return &hook.Memo{
+		ID:         memo.ID,
+		CreatorID:  memo.CreatorID,
+		Content:    memo.Content,
+		Visibility: memo.Visibility.String(),
+	}
+}
+
+func listEventHooks(ctx context.Context, s *store.Store, event storepb.WorkspaceHook_Event) ([]*storepb.WorkspaceHook, error) {
+	workspaceHookSetting, err := s.GetWorkspaceHookSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get workspace hook setting")
+	}
+	hooks := []*storepb.WorkspaceHook{}
+	for _, workspaceHook := range workspaceHookSetting.Hooks {
+		if !workspaceHook.Disabled && slices.Contains(workspaceHook.Events, event) {
+			hooks = append(hooks, workspaceHook)
+		}
+	}
+	return hooks, nil
+}
+
+// runHooks runs the hooks in order, each is sent the content transformed by the ones before.
+// A hook which fails is skipped unless it's required.
+func runHooks(ctx context.Context, profile *profile.Profile, hooks []*storepb.WorkspaceHook, request *hook.Request) error {
+	for _, workspaceHook := range hooks {
+		response, err := runHook(ctx, profile, workspaceHook, request)
+		if err != nil {
+			if workspaceHook.Required {
+				return errors.Wrapf(err, "Failed to run hook %s", workspaceHook.Name)
+			}
+			slog.Warn("Failed to run hook, skipping it as it isn't required", slog.String("hook", workspaceHook.Name), slog.Any("err", err))
+			continue
+		}
+		if response.Reject {
+			return &RejectedError{
+				Hook:    workspaceHook.Name,
+				Message: response.Message,
+			}
+		}
+		if response.Content != nil && request.Memo != nil {
+			request.Memo.Content = *response.Content
+		}
+	}
+	return nil
+}
+
+func runHook(ctx context.Context, profile *profile.Profile, workspaceHook *storepb.WorkspaceHook, request *hook.Request) (*hook.Response, error) {
+	var runner hook.Runner
+	switch workspaceHook.Type {
+	case storepb.WorkspaceHook_HTTP:
+		runner = hook.NewHTTPRunner(workspaceHook.Url, workspaceHook.Secret)
+	case storepb.WorkspaceHook_EXEC:
+		path, err := GetCommandPath(profile, workspaceHook.Command)
+		if err != nil {
+			return nil, err
+		}
+		runner = hook.NewExecRunner(path)
+	default:
+		return nil, errors.Errorf("unsupported hook type %s", workspaceHook.Type)
+	}
+
+	timeout := defaultHookTimeout
+	if workspaceHook.TimeoutSeconds > 0 {
+		timeout = time.Duration(workspaceHook.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return runner.Run(ctx, request)
+}
+
+func getHookEventName(event storepb.WorkspaceHook_Event) string {
+	switch event {
+	case storepb.WorkspaceHook_MEMO_CREATE:
+		return hook.EventMemoCreate
+	case storepb.WorkspaceHook_MEMO_UPDATE:
+		return hook.EventMemoUpdate
+	case storepb.WorkspaceHook_RESOURCE_UPLOAD:
+		return hook.EventResourceUpload
+	case storepb.WorkspaceHook_MEMO_RENDER:
+		return hook.EventMemoRender
+	default:
+		return ""
+	}
+}
+
+// getRenderCacheKey returns the hash of the hooks and the request, so the cached contents are rendered again once either changes.
+func getRenderCacheKey(hooks []*storepb.WorkspaceHook, request *hook.Request) (string, error) {
+	hash := sha256.New()
+	for _, workspaceHook := range hooks {
+		b, err := proto.Marshal(workspaceHook)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(b)
+	}
+	b, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(b)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
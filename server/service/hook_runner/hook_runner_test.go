@@ -0,0 +1,80 @@
+package hookrunner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/hook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestRunMemoHooks(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	runs := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		request := &hook.Request{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		if strings.Contains(request.Memo.Content, "forbidden") {
+			_ = json.NewEncoder(w).Encode(&hook.Response{Reject: true, Message: "forbidden word"})
+			return
+		}
+		content := request.Memo.Content + " #" + strings.ReplaceAll(request.Event, ".", "-")
+		_ = json.NewEncoder(w).Encode(&hook.Response{Content: &content})
+	}))
+	defer server.Close()
+	_, err := ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_HOOK,
+		Value: &storepb.WorkspaceSetting_Hook{
+			Hook: &storepb.WorkspaceHookSetting{
+				Hooks: []*storepb.WorkspaceHook{
+					{Name: "tagger", Type: storepb.WorkspaceHook_HTTP, Url: server.URL, Events: []storepb.WorkspaceHook_Event{storepb.WorkspaceHook_MEMO_CREATE, storepb.WorkspaceHook_MEMO_RENDER}},
+					{Name: "broken", Type: storepb.WorkspaceHook_HTTP, Url: "http://127.0.0.1:1", Events: []storepb.WorkspaceHook_Event{storepb.WorkspaceHook_MEMO_CREATE}},
+					{Name: "disabled", Type: storepb.WorkspaceHook_HTTP, Url: server.URL, Events: []storepb.WorkspaceHook_Event{storepb.WorkspaceHook_MEMO_CREATE}, Disabled: true},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	content, err := RunMemoHooks(ctx, ts, storepb.WorkspaceHook_MEMO_CREATE, &hook.Memo{Content: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, "hello #memo-create", content)
+	content, err = RunMemoHooks(ctx, ts, storepb.WorkspaceHook_MEMO_UPDATE, &hook.Memo{Content: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, "hello", content)
+	_, err = RunMemoHooks(ctx, ts, storepb.WorkspaceHook_MEMO_CREATE, &hook.Memo{Content: "forbidden"})
+	rejectedErr := &RejectedError{}
+	require.True(t, errors.As(err, &rejectedErr))
+	require.Equal(t, "tagger", rejectedErr.Hook)
+
+	memo := &store.Memo{ID: 1, Content: "hello", Visibility: store.Public}
+	require.Equal(t, "hello #memo-render", RenderMemoContent(ctx, ts, memo))
+	runsBefore := runs.Load()
+	require.Equal(t, "hello #memo-render", RenderMemoContent(ctx, ts, memo))
+	require.Equal(t, runsBefore, runs.Load())
+}
+
+func TestGetCommandPath(t *testing.T) {
+	profile := &profile.Profile{Data: "/var/opt/memos"}
+	path, err := GetCommandPath(profile, "autotag.sh")
+	require.NoError(t, err)
+	require.Equal(t, "/var/opt/memos/hooks/autotag.sh", path)
+	for _, command := range []string{"", "../memos", "/bin/sh", "sub/autotag.sh", ".hidden"} {
+		_, err := GetCommandPath(profile, command)
+		require.Error(t, err, command)
+	}
+}
@@ -0,0 +1,259 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type appleNotesExport struct {
+	fsys fs.FS
+	// notes are the notes by the paths of their files, the links to them are the references of the memos.
+	notes map[string]*Note
+	// attachments are the attachments by the paths of their files, a file linked by several notes is imported once.
+	attachments map[string]*Attachment
+}
+
+// ParseAppleNotesExport parses the zip file of the HTML export of Apple Notes.
+// The zip file may have the export directory at its root, which isn't a folder of the notes.
+func ParseAppleNotesExport(r io.ReaderAt, size int64) ([]*Note, error) {
+	zipReader, err := openZipFile(r, size, newZipBudget())
+	if err != nil {
+		return nil, err
+	}
+	roots := map[string]bool{}
+	for _, file := range zipReader.File {
+		if !strings.HasPrefix(file.Name, "__MACOSX/") {
+			root, _, _ := strings.Cut(path.Clean(file.Name), "/")
+			roots[root] = true
+		}
+	}
+	var fsys fs.FS = zipReader
+	if len(roots) == 1 {
+		for root := range roots {
+			if info, err := fs.Stat(zipReader, root); err == nil && info.IsDir() {
+				if fsys, err = fs.Sub(zipReader, root); err != nil {
+					return nil, errors.Wrap(err, "failed to read export directory")
+				}
+			}
+		}
+	}
+	return ParseAppleNotesDirectory(fsys)
+}
+
+// ParseAppleNotesDirectory parses the HTML export directory of Apple Notes, which has an HTML file of each note.
+// The folders become the nested tags of the notes, and the embedded media become the resources.
+func ParseAppleNotesDirectory(fsys fs.FS) ([]*Note, error) {
+	export := &appleNotesExport{
+		fsys:        fsys,
+		notes:       map[string]*Note{},
+		attachments: map[string]*Attachment{},
+	}
+	notePaths := []string{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// The attachments of the notes are in the directories of the attachments, which aren't folders.
+			if filePath == "__MACOSX" || strings.EqualFold(entry.Name(), "attachments") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if extension := strings.ToLower(path.Ext(filePath)); extension == ".html" || extension == ".htm" {
+			notePaths = append(notePaths, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read export directory")
+	}
+	if len(notePaths) == 0 {
+		return nil, errors.New("no notes found in the Apple Notes export")
+	}
+	sort.Strings(notePaths)
+	for _, notePath := range notePaths {
+		export.notes[notePath] = &Note{UID: NewUID(), Source: notePath}
+	}
+
+	notes := []*Note{}
+	for _, notePath := range notePaths {
+		note, err := export.convertNote(notePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert note %q", notePath)
+		}
+		notes = append(notes, note)
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedTs < notes[j].CreatedTs
+	})
+	return notes, nil
+}
+
+func (e *appleNotesExport) convertNote(notePath string) (*Note, error) {
+	data, err := fs.ReadFile(e.fsys, notePath)
+	if err != nil {
+		return nil, err
+	}
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse HTML")
+	}
+	note := e.notes[notePath]
+	if folder := path.Dir(notePath); folder != "." {
+		note.Tags = append(note.Tags, folder)
+	}
+	// The exports keep the dates of the notes as the modification times of the files, or in the meta elements.
+	if info, err := fs.Stat(e.fsys, notePath); err == nil && info.ModTime().Unix() > 0 {
+		note.CreatedTs = info.ModTime().Unix()
+		note.UpdatedTs = info.ModTime().Unix()
+	}
+	var title string
+	var body *html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.DataAtom {
+		case atom.Title:
+			title = strings.Join(strings.Fields(getHTMLText(n)), " ")
+		case atom.Meta:
+			switch strings.ToLower(getHTMLAttr(n, "name")) {
+			case "created", "creation-date":
+				if ts, ok := parseAppleNotesDate(getHTMLAttr(n, "content")); ok {
+					note.CreatedTs = ts
+				}
+			case "modified", "last-modified":
+				if ts, ok := parseAppleNotesDate(getHTMLAttr(n, "content")); ok {
+					note.UpdatedTs = ts
+				}
+			}
+		case atom.Body:
+			body = n
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(root)
+	if body == nil {
+		body = root
+	}
+
+	dir := path.Dir(notePath)
+	converter := &htmlConverter{}
+	converter.convertElement = func(n *html.Node) (string, bool) {
+		switch n.DataAtom {
+		case atom.Img:
+			if attachment := e.getAttachment(note, dir, getHTMLAttr(n, "src")); attachment != nil {
+				return FormatResourceEmbed(attachment.UID), true
+			}
+		case atom.A:
+			if linkedNote := e.notes[resolveAppleNotesPath(dir, getHTMLAttr(n, "href"))]; linkedNote != nil {
+				return FormatMemoReference(linkedNote.UID, converter.writeInline(n)), true
+			}
+			if attachment := e.getAttachment(note, dir, getHTMLAttr(n, "href")); attachment != nil {
+				if text := converter.writeInline(n); text != "" && text != attachment.Filename {
+					return text + " " + FormatResourceEmbed(attachment.UID), true
+				}
+				return FormatResourceEmbed(attachment.UID), true
+			}
+		}
+		return "", false
+	}
+	content := converter.convertHTMLToMarkdown(body)
+
+	// The title of a note is its first line, which is added if the body doesn't start with it.
+	if title == "" {
+		title = strings.TrimSuffix(path.Base(notePath), path.Ext(notePath))
+	}
+	firstLine, _, _ := strings.Cut(content, "\n")
+	if strings.TrimSpace(strings.Trim(firstLine, "#*")) != title {
+		content = strings.TrimSpace("# " + title + "\n\n" + content)
+	}
+	note.Content = content
+	return note, nil
+}
+
+// getAttachment returns the attachment of the source of a media, which is a data URL or the path of a file relative to the note.
+// It returns nil if the source isn't a file of the export.
+func (e *appleNotesExport) getAttachment(note *Note, dir, src string) *Attachment {
+	if data, ok := strings.CutPrefix(src, "data:"); ok {
+		mediaType, encoded, ok := strings.Cut(data, ",")
+		if !ok || !strings.HasSuffix(mediaType, ";base64") {
+			return nil
+		}
+		blob, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil
+		}
+		attachment := &Attachment{
+			UID:      NewUID(),
+			Filename: "attachment",
+			Type:     strings.TrimSuffix(mediaType, ";base64"),
+			Blob:     blob,
+		}
+		if _, subtype, ok := strings.Cut(attachment.Type, "/"); ok {
+			subtype, _, _ = strings.Cut(subtype, "+")
+			attachment.Filename += "." + subtype
+		}
+		note.Attachments = append(note.Attachments, attachment)
+		return attachment
+	}
+
+	filePath := resolveAppleNotesPath(dir, src)
+	if filePath == "" {
+		return nil
+	}
+	if attachment := e.attachments[filePath]; attachment != nil {
+		return attachment
+	}
+	blob, err := fs.ReadFile(e.fsys, filePath)
+	if err != nil {
+		return nil
+	}
+	attachment := &Attachment{
+		UID:      NewUID(),
+		Filename: path.Base(filePath),
+		Type:     mime.TypeByExtension(path.Ext(filePath)),
+		Blob:     blob,
+	}
+	e.attachments[filePath] = attachment
+	note.Attachments = append(note.Attachments, attachment)
+	return attachment
+}
+
+// parseAppleNotesDate parses the date of a meta element, which is in RFC 3339 or a layout of the dates of Notion.
+func parseAppleNotesDate(value string) (int64, bool) {
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+		return t.Unix(), true
+	}
+	return parseNotionDate(value)
+}
+
+// resolveAppleNotesPath returns the path of the file of a link relative to the directory, empty if the link isn't to a file of the export.
+func resolveAppleNotesPath(dir, link string) string {
+	if link == "" || strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:") || strings.HasPrefix(link, "#") {
+		return ""
+	}
+	link, _, _ = strings.Cut(link, "#")
+	if unescaped, err := url.PathUnescape(link); err == nil {
+		link = unescaped
+	}
+	filePath := path.Join(dir, link)
+	if !fs.ValidPath(filePath) {
+		return ""
+	}
+	return filePath
+}
@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAppleNotesDirectory(t *testing.T) {
+	modified := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"Work/Projects/Plan.html": &fstest.MapFile{
+			Data:    []byte(`<html><head><title>Plan</title></head><body><div><h1>Plan</h1></div><div>See <a href="../../Ideas.html">ideas</a>.</div><div><img src="Attachments/chart.png"></div><div><img src="data:image/png;base64,cG5n"></div></body></html>`),
+			ModTime: modified,
+		},
+		"Work/Projects/Attachments/chart.png": &fstest.MapFile{Data: []byte("chart")},
+		"Ideas.html": &fstest.MapFile{
+			Data:    []byte(`<html><head><meta name="created" content="2024-01-01T10:00:00Z"></head><body><div>Read more</div><div><a href="Work/Projects/Attachments/chart.png">chart.png</a></div></body></html>`),
+			ModTime: modified,
+		},
+	}
+	notes, err := ParseAppleNotesDirectory(fsys)
+	require.NoError(t, err)
+	// The notes are ordered by their created times.
+	require.Len(t, notes, 2)
+	ideas, plan := notes[0], notes[1]
+
+	require.Empty(t, ideas.Tags)
+	require.Equal(t, int64(1704103200), ideas.CreatedTs)
+	require.Equal(t, modified.Unix(), ideas.UpdatedTs)
+	// The attachment linked by several notes is imported once, by the first note.
+	require.Len(t, ideas.Attachments, 1)
+	chart := ideas.Attachments[0]
+	require.Equal(t, "chart.png", chart.Filename)
+	require.Equal(t, "image/png", chart.Type)
+	require.Equal(t, []byte("chart"), chart.Blob)
+
+	require.Equal(t, []string{"Work/Projects"}, plan.Tags)
+	require.Equal(t, modified.Unix(), plan.CreatedTs)
+	require.Len(t, plan.Attachments, 1)
+	image := plan.Attachments[0]
+	require.Equal(t, "attachment.png", image.Filename)
+	require.Equal(t, []byte("png"), image.Blob)
+	require.Equal(t, "# Plan\n\nSee "+FormatMemoReference(ideas.UID, "ideas")+".\n"+FormatResourceEmbed(chart.UID)+"\n"+FormatResourceEmbed(image.UID), plan.Content)
+
+	// The title is added to the note without it.
+	require.Equal(t, "# Ideas\n\nRead more\n"+FormatResourceEmbed(chart.UID), ideas.Content)
+}
+
+func TestParseAppleNotesExport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"Notes/Work/Plan.html": "<div>Plan</div>",
+		"Notes/Home.html":      "<div>Home</div>",
+	} {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	// The export directory at the root of the zip file isn't a folder.
+	notes, err := ParseAppleNotesExport(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	require.Empty(t, notes[0].Tags)
+	require.Equal(t, "Plan", notes[1].Content)
+	require.Equal(t, []string{"Work"}, notes[1].Tags)
+}
@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// The media are embedded by their identifiers, the photos by dayone-moment://ID and the others by dayone-moment:/type/ID.
+	dayOneMomentRegexp = regexp.MustCompile(`!\[[^\]]*\]\(dayone-moment:/(?:/|[a-zA-Z]+/)([0-9A-Fa-f]+)\)`)
+	dayOneEntryRegexp  = regexp.MustCompile(`\[([^\]]*)\]\(dayone2?://view\?entryId=([0-9A-Fa-f]+)[^)]*\)`)
+	// Day One escapes the punctuations of Markdown, the escapes of the punctuations which are harmless in memos are removed.
+	dayOneEscapeRegexp = regexp.MustCompile(`\\([.!()])`)
+)
+
+type dayOneExport struct {
+	Entries []*dayOneEntry `json:"entries"`
+}
+
+type dayOneEntry struct {
+	UUID           string         `json:"uuid"`
+	CreationDate   string         `json:"creationDate"`
+	ModifiedDate   string         `json:"modifiedDate"`
+	Text           string         `json:"text"`
+	Tags           []string       `json:"tags"`
+	Starred        bool           `json:"starred"`
+	IsPinned       bool           `json:"isPinned"`
+	Photos         []*dayOneMedia `json:"photos"`
+	Videos         []*dayOneMedia `json:"videos"`
+	Audios         []*dayOneMedia `json:"audios"`
+	PDFAttachments []*dayOneMedia `json:"pdfAttachments"`
+}
+
+type dayOneMedia struct {
+	Identifier string `json:"identifier"`
+	MD5        string `json:"md5"`
+	Type       string `json:"type"`
+	Filename   string `json:"filename"`
+}
+
+// ParseDayOneExport parses the JSON export of Day One, or the zip file of the JSON files of the journals and their media.
+// The entries are imported with their dates, the journals and the tags of Day One become the tags.
+// The starred and pinned entries are pinned.
+func ParseDayOneExport(filename string, r io.ReaderAt, size int64) ([]*Note, error) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read export file")
+	}
+	journals := map[string]*dayOneExport{}
+	files := map[string]*zip.File{}
+	if !bytes.Equal(header, []byte("PK\x03\x04")) {
+		export := &dayOneExport{}
+		if err := json.NewDecoder(io.NewSectionReader(r, 0, size)).Decode(export); err != nil {
+			return nil, errors.Wrap(err, "failed to decode Day One export")
+		}
+		journals[getNotebookName(filename)] = export
+	} else {
+		zipReader, err := openZipFile(r, size, newZipBudget())
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range zipReader.File {
+			if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+				continue
+			}
+			files[path.Clean(file.Name)] = file
+			if path.Ext(file.Name) != ".json" {
+				continue
+			}
+			data, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+			export := &dayOneExport{}
+			if err := json.Unmarshal(data, export); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode %q", file.Name)
+			}
+			journals[path.Clean(file.Name)] = export
+		}
+	}
+
+	journalNames := []string{}
+	for name := range journals {
+		journalNames = append(journalNames, name)
+	}
+	sort.Strings(journalNames)
+	entryUIDs := map[string]string{}
+	for _, name := range journalNames {
+		for _, entry := range journals[name].Entries {
+			entryUIDs[strings.ToUpper(entry.UUID)] = NewUID()
+		}
+	}
+	notes := []*Note{}
+	for _, name := range journalNames {
+		// The media are in the directories next to the JSON file of the journal.
+		dir := path.Dir(name)
+		for _, entry := range journals[name].Entries {
+			note := convertDayOneEntry(entry, getNotebookName(name), entryUIDs, func(dirName, filename string) ([]byte, bool) {
+				file := files[path.Join(dir, dirName, filename)]
+				if file == nil {
+					return nil, false
+				}
+				data, err := readZipFile(file)
+				return data, err == nil
+			})
+			notes = append(notes, note)
+		}
+	}
+	if len(notes) == 0 {
+		return nil, errors.New("no entries found in the Day One export")
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedTs < notes[j].CreatedTs
+	})
+	return notes, nil
+}
+
+// convertDayOneEntry converts the entry, the media are read from the export by the names of their directories and files.
+func convertDayOneEntry(entry *dayOneEntry, journal string, entryUIDs map[string]string, readMedia func(dirName, filename string) ([]byte, bool)) *Note {
+	note := &Note{
+		UID:    entryUIDs[strings.ToUpper(entry.UUID)],
+		Source: entry.UUID,
+		Pinned: entry.Starred || entry.IsPinned,
+	}
+	if journal != "" {
+		note.Tags = append(note.Tags, journal)
+	}
+	note.Tags = append(note.Tags, entry.Tags...)
+	if t, err := time.Parse(time.RFC3339, entry.CreationDate); err == nil {
+		note.CreatedTs = t.Unix()
+	}
+	if t, err := time.Parse(time.RFC3339, entry.ModifiedDate); err == nil {
+		note.UpdatedTs = t.Unix()
+	}
+
+	attachments := map[string]*Attachment{}
+	// The media are in the directories of their kinds, named after their md5 hashes.
+	mediaLists := []struct {
+		dirName string
+		media   []*dayOneMedia
+	}{
+		{dirName: "photos", media: entry.Photos},
+		{dirName: "videos", media: entry.Videos},
+		{dirName: "audios", media: entry.Audios},
+		{dirName: "pdfs", media: entry.PDFAttachments},
+	}
+	for _, mediaList := range mediaLists {
+		for _, media := range mediaList.media {
+			extension := strings.ToLower(media.Type)
+			if extension == "" {
+				continue
+			}
+			data, ok := readMedia(mediaList.dirName, media.MD5+"."+extension)
+			if !ok {
+				continue
+			}
+			attachment := &Attachment{
+				UID:      NewUID(),
+				Filename: media.Filename,
+				Type:     mime.TypeByExtension("." + extension),
+				Blob:     data,
+			}
+			if attachment.Filename == "" {
+				attachment.Filename = media.MD5 + "." + extension
+			}
+			attachments[strings.ToUpper(media.Identifier)] = attachment
+			note.Attachments = append(note.Attachments, attachment)
+		}
+	}
+
+	note.Content = replaceOutsideCodeBlocks(strings.ReplaceAll(entry.Text, "\r\n", "\n"), func(line string) string {
+		line = dayOneEscapeRegexp.ReplaceAllString(line, "$1")
+		line = dayOneMomentRegexp.ReplaceAllStringFunc(line, func(moment string) string {
+			if attachment := attachments[strings.ToUpper(dayOneMomentRegexp.FindStringSubmatch(moment)[1])]; attachment != nil {
+				return FormatResourceEmbed(attachment.UID)
+			}
+			return ""
+		})
+		return dayOneEntryRegexp.ReplaceAllStringFunc(line, func(link string) string {
+			matches := dayOneEntryRegexp.FindStringSubmatch(link)
+			if uid := entryUIDs[strings.ToUpper(matches[2])]; uid != "" {
+				return FormatMemoReference(uid, matches[1])
+			}
+			return matches[1]
+		})
+	})
+	note.Content = strings.TrimSpace(note.Content)
+	return note
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDayOneJournal = `{
+  "metadata": {"version": "1.0"},
+  "entries": [
+    {
+      "uuid": "B0000000000000000000000000000002",
+      "creationDate": "2024-02-01T08:00:00Z",
+      "modifiedDate": "2024-02-02T08:00:00Z",
+      "text": "Back home\\! See [the trip](dayone2://view?entryId=B0000000000000000000000000000001).",
+      "tags": ["home"],
+      "starred": true
+    },
+    {
+      "uuid": "B0000000000000000000000000000001",
+      "creationDate": "2024-01-01T08:00:00Z",
+      "text": "# Trip\n\n![](dayone-moment://A0000000000000000000000000000001)\n![](dayone-moment:/audio/A0000000000000000000000000000002)\n![](dayone-moment://A0000000000000000000000000000009)",
+      "photos": [{"identifier": "A0000000000000000000000000000001", "md5": "c0ffee", "type": "jpeg"}],
+      "audios": [{"identifier": "A0000000000000000000000000000002", "md5": "beef", "type": "m4a", "filename": "voice.m4a"}]
+    }
+  ]
+}`
+
+func TestParseDayOneExport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"Travel.json":        testDayOneJournal,
+		"photos/c0ffee.jpeg": "jpeg",
+		"audios/beef.m4a":    "m4a",
+	} {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	notes, err := ParseDayOneExport("export.zip", bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	// The entries are ordered by their dates.
+	trip, home := notes[0], notes[1]
+
+	require.Equal(t, []string{"Travel"}, trip.Tags)
+	require.Equal(t, int64(1704096000), trip.CreatedTs)
+	require.Zero(t, trip.UpdatedTs)
+	require.False(t, trip.Pinned)
+	require.Len(t, trip.Attachments, 2)
+	photo, audio := trip.Attachments[0], trip.Attachments[1]
+	require.Equal(t, "c0ffee.jpeg", photo.Filename)
+	require.Equal(t, "image/jpeg", photo.Type)
+	require.Equal(t, []byte("jpeg"), photo.Blob)
+	require.Equal(t, "voice.m4a", audio.Filename)
+	// The missing photo is removed.
+	require.Equal(t, "# Trip\n\n"+FormatResourceEmbed(photo.UID)+"\n"+FormatResourceEmbed(audio.UID), trip.Content)
+
+	require.Equal(t, []string{"Travel", "home"}, home.Tags)
+	require.Equal(t, int64(1706774400), home.CreatedTs)
+	require.Equal(t, int64(1706860800), home.UpdatedTs)
+	require.True(t, home.Pinned)
+	require.Equal(t, "Back home! See "+FormatMemoReference(trip.UID, "the trip")+".", home.Content)
+
+	// A JSON file is the export of the journal of its name.
+	notes, err = ParseDayOneExport("Travel.json", bytes.NewReader([]byte(testDayOneJournal)), int64(len(testDayOneJournal)))
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	require.Equal(t, []string{"Travel"}, notes[0].Tags)
+	require.Empty(t, notes[0].Attachments)
+}
@@ -0,0 +1,215 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+const enexTimeLayout = "20060102T150405Z"
+
+// The elements of ENML are void, but the HTML parser only knows the void elements of HTML.
+var enmlVoidElementRegexp = regexp.MustCompile(`<(en-media|en-todo|en-crypt)(\s[^>]*?)?/>`)
+
+type enexNote struct {
+	Title      string   `xml:"title"`
+	Content    string   `xml:"content"`
+	Created    string   `xml:"created"`
+	Updated    string   `xml:"updated"`
+	Tags       []string `xml:"tag"`
+	Attributes struct {
+		SourceURL string `xml:"source-url"`
+	} `xml:"note-attributes"`
+	Resources []*enexResource `xml:"resource"`
+}
+
+type enexResource struct {
+	Data       string `xml:"data"`
+	Mime       string `xml:"mime"`
+	Attributes struct {
+		FileName string `xml:"file-name"`
+	} `xml:"resource-attributes"`
+}
+
+// ParseENEXExport parses the ENEX export of Evernote, or a zip file of the ENEX exports of several notebooks.
+// The notebooks are named after the files, they become the tags of the notes with the tags of Evernote.
+func ParseENEXExport(filename string, r io.ReaderAt, size int64) ([]*Note, error) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read export file")
+	}
+	if !bytes.Equal(header, []byte("PK\x03\x04")) {
+		return ParseENEX(io.NewSectionReader(r, 0, size), getNotebookName(filename))
+	}
+
+	zipReader, err := openZipFile(r, size, newZipBudget())
+	if err != nil {
+		return nil, err
+	}
+	notes := []*Note{}
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || path.Ext(file.Name) != ".enex" || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open %q", file.Name)
+		}
+		fileNotes, err := ParseENEX(reader, getNotebookName(file.Name))
+		reader.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", file.Name)
+		}
+		notes = append(notes, fileNotes...)
+	}
+	if len(notes) == 0 {
+		return nil, errors.New("no notes found in the ENEX export")
+	}
+	return notes, nil
+}
+
+// ParseENEX parses the notes of an ENEX file, the notebook is added to the tags of the notes if it isn't empty.
+func ParseENEX(r io.Reader, notebook string) ([]*Note, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	notes := []*Note{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode ENEX")
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "note" {
+			continue
+		}
+		enexNote := &enexNote{}
+		if err := decoder.DecodeElement(enexNote, &element); err != nil {
+			return nil, errors.Wrap(err, "failed to decode note")
+		}
+		note, err := convertENEXNote(enexNote, notebook)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert note %q", enexNote.Title)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+func convertENEXNote(enexNote *enexNote, notebook string) (*Note, error) {
+	note := &Note{
+		UID:    NewUID(),
+		Source: strings.TrimSpace(enexNote.Title),
+	}
+	if note.Source == "" {
+		note.Source = "Untitled"
+	}
+	if notebook != "" {
+		note.Tags = append(note.Tags, notebook)
+	}
+	note.Tags = append(note.Tags, enexNote.Tags...)
+	if t, err := time.Parse(enexTimeLayout, strings.TrimSpace(enexNote.Created)); err == nil {
+		note.CreatedTs = t.Unix()
+	}
+	if t, err := time.Parse(enexTimeLayout, strings.TrimSpace(enexNote.Updated)); err == nil {
+		note.UpdatedTs = t.Unix()
+	}
+
+	// The resources are embedded in the content by the md5 hashes of their data.
+	attachments := map[string]*Attachment{}
+	for _, resource := range enexNote.Resources {
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(resource.Data), ""))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode resource")
+		}
+		attachment := &Attachment{
+			UID:      NewUID(),
+			Filename: strings.TrimSpace(resource.Attributes.FileName),
+			Type:     strings.TrimSpace(resource.Mime),
+			Blob:     data,
+		}
+		if attachment.Filename == "" {
+			attachment.Filename = "attachment"
+			if extensions, _ := mime.ExtensionsByType(attachment.Type); len(extensions) > 0 {
+				attachment.Filename += extensions[0]
+			}
+		}
+		hash := md5.Sum(data)
+		attachments[hex.EncodeToString(hash[:])] = attachment
+		note.Attachments = append(note.Attachments, attachment)
+	}
+
+	content, err := convertENML(enexNote.Content, attachments)
+	if err != nil {
+		return nil, err
+	}
+	parts := []string{}
+	if title := strings.TrimSpace(enexNote.Title); title != "" {
+		parts = append(parts, "# "+title)
+	}
+	if content != "" {
+		parts = append(parts, content)
+	}
+	if sourceURL := strings.TrimSpace(enexNote.Attributes.SourceURL); sourceURL != "" {
+		parts = append(parts, "Source: "+sourceURL)
+	}
+	note.Content = strings.Join(parts, "\n\n")
+	return note, nil
+}
+
+// convertENML converts the ENML content of a note to Markdown, the media are converted to the embeds of the attachments of their hashes.
+func convertENML(content string, attachments map[string]*Attachment) (string, error) {
+	content = enmlVoidElementRegexp.ReplaceAllString(content, "<$1$2></$1>")
+	root, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse ENML")
+	}
+	converter := &htmlConverter{
+		convertElement: func(n *html.Node) (string, bool) {
+			switch n.Data {
+			case "en-media":
+				if attachment := attachments[getHTMLAttr(n, "hash")]; attachment != nil {
+					return FormatResourceEmbed(attachment.UID), true
+				}
+				return "", true
+			case "en-todo":
+				if getHTMLAttr(n, "checked") == "true" {
+					return "- [x] ", true
+				}
+				return "- [ ] ", true
+			case "en-crypt":
+				return "", true
+			case "div":
+				// The code blocks of Evernote are the divs of the en-codeblock style.
+				if strings.Contains(getHTMLAttr(n, "style"), "-en-codeblock:true") {
+					return "\n\n```\n" + strings.Trim(getHTMLText(n), "\n") + "\n```\n\n", true
+				}
+			}
+			return "", false
+		},
+	}
+	return converter.convertHTMLToMarkdown(root), nil
+}
+
+// getNotebookName returns the name of the notebook of the export file.
+func getNotebookName(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	return strings.TrimSuffix(name, path.Ext(name))
+}
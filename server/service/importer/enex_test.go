@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testENEX = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE en-export SYSTEM "http://xml.evernote.com/pub/evernote-export4.dtd">
+<en-export export-date="20240301T101500Z" application="Evernote" version="10.68.2">
+  <note>
+    <title>Trip &amp; plans</title>
+    <created>20240101T120000Z</created>
+    <updated>20240102T080000Z</updated>
+    <tag>travel</tag>
+    <tag>summer plans</tag>
+    <note-attributes><source-url>https://usememos.com</source-url></note-attributes>
+    <content><![CDATA[<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<!DOCTYPE en-note SYSTEM "http://xml.evernote.com/pub/enml2.dtd">
+<en-note><div>Pack <b>light</b>, see <a href="https://example.com">the list</a>.</div><div><br/></div><div><en-todo checked="true"/>Book hotel</div><div><en-todo/>Rent car</div><ul><li><div>One</div></li><li><div>Two</div><ol><li>Nested</li></ol></li></ul><en-media hash="e09a574ca3760a3e28a3e5920fe4627e" type="image/png"/><div style="-en-codeblock:true;"><div>let a = 1</div><div>let b = 2</div></div><table><tr><th>Day</th><th>City</th></tr><tr><td>1</td><td>Rome</td></tr></table></en-note>]]></content>
+    <resource>
+      <data encoding="base64">aW1hZ2UgZGF0YQ==</data>
+      <mime>image/png</mime>
+      <resource-attributes><file-name>map.png</file-name></resource-attributes>
+    </resource>
+  </note>
+  <note>
+    <title>Empty</title>
+    <content><![CDATA[<en-note><ul style="--en-todo:true;"><li style="--en-checked:true;"><div>Done</div></li><li style="--en-checked:false;"><div>Todo</div></li></ul></en-note>]]></content>
+  </note>
+</en-export>`
+
+func TestParseENEX(t *testing.T) {
+	notes, err := ParseENEX(strings.NewReader(testENEX), "Travel notebook")
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+
+	note := notes[0]
+	require.Equal(t, "Trip & plans", note.Source)
+	require.Equal(t, []string{"Travel notebook", "travel", "summer plans"}, note.Tags)
+	require.Equal(t, int64(1704110400), note.CreatedTs)
+	require.Equal(t, int64(1704182400), note.UpdatedTs)
+	require.Len(t, note.Attachments, 1)
+	attachment := note.Attachments[0]
+	require.Equal(t, "map.png", attachment.Filename)
+	require.Equal(t, "image/png", attachment.Type)
+	require.Equal(t, []byte("image data"), attachment.Blob)
+	require.Equal(t, strings.Join([]string{
+		"# Trip & plans",
+		"",
+		"Pack **light**, see [the list](https://example.com).",
+		"",
+		"- [x] Book hotel",
+		"- [ ] Rent car",
+		"",
+		"- One",
+		"- Two",
+		"  1. Nested",
+		"",
+		FormatResourceEmbed(attachment.UID),
+		"",
+		"```",
+		"let a = 1",
+		"let b = 2",
+		"```",
+		"",
+		"| Day | City |",
+		"| --- | --- |",
+		"| 1 | Rome |",
+		"",
+		"Source: https://usememos.com",
+	}, "\n"), note.Content)
+
+	require.Equal(t, "# Empty\n\n- [x] Done\n- [ ] Todo", notes[1].Content)
+	require.Zero(t, notes[1].CreatedTs)
+}
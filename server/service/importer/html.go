@@ -0,0 +1,297 @@
+package importer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+
+// htmlConverter converts the HTML of the notes to Markdown.
+type htmlConverter struct {
+	// convertElement returns the Markdown of the elements specific to an app, false to convert the element as HTML.
+	convertElement func(n *html.Node) (string, bool)
+	listDepth      int
+}
+
+// convertHTMLToMarkdown returns the Markdown of the HTML of the node and its children.
+func (c *htmlConverter) convertHTMLToMarkdown(n *html.Node) string {
+	w := &markdownWriter{}
+	c.writeNode(w, n)
+	lines := strings.Split(w.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+func (c *htmlConverter) writeChildren(w *markdownWriter, n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.writeNode(w, child)
+	}
+}
+
+// writeInline returns the Markdown of the children of the node in a line.
+func (c *htmlConverter) writeInline(n *html.Node) string {
+	w := &markdownWriter{}
+	c.writeChildren(w, n)
+	return strings.Join(strings.Fields(w.String()), " ")
+}
+
+func (c *htmlConverter) writeNode(w *markdownWriter, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.writeText(n.Data)
+		return
+	case html.DocumentNode:
+		c.writeChildren(w, n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if c.convertElement != nil {
+		if markdown, ok := c.convertElement(n); ok {
+			w.WriteString(markdown)
+			return
+		}
+	}
+
+	switch n.DataAtom {
+	case atom.Head, atom.Title, atom.Script, atom.Style:
+	case atom.Br:
+		w.WriteString("\n")
+	case atom.Div:
+		w.ensureNewlines(1)
+		c.writeChildren(w, n)
+		w.ensureNewlines(1)
+	case atom.P:
+		w.ensureNewlines(2)
+		c.writeChildren(w, n)
+		w.ensureNewlines(2)
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		w.ensureNewlines(2)
+		if text := c.writeInline(n); text != "" {
+			level, _ := strconv.Atoi(n.Data[1:])
+			w.WriteString(strings.Repeat("#", level) + " " + text)
+		}
+		w.ensureNewlines(2)
+	case atom.B, atom.Strong:
+		c.writeEmphasis(w, n, "**")
+	case atom.I, atom.Em:
+		c.writeEmphasis(w, n, "*")
+	case atom.S, atom.Strike, atom.Del:
+		c.writeEmphasis(w, n, "~~")
+	case atom.Code:
+		c.writeEmphasis(w, n, "`")
+	case atom.A:
+		text, href := c.writeInline(n), getHTMLAttr(n, "href")
+		if href == "" || !strings.Contains(href, "://") && !strings.HasPrefix(href, "mailto:") {
+			w.writeText(text)
+		} else if text == "" || text == href {
+			w.writeText(href)
+		} else {
+			w.writeText("[" + text + "](" + href + ")")
+		}
+	case atom.Img:
+		if src := getHTMLAttr(n, "src"); strings.Contains(src, "://") {
+			w.writeText("![" + getHTMLAttr(n, "alt") + "](" + src + ")")
+		}
+	case atom.Ul, atom.Ol:
+		c.writeList(w, n)
+	case atom.Blockquote:
+		w.ensureNewlines(2)
+		quote := &markdownWriter{}
+		c.writeChildren(quote, n)
+		lines := strings.Split(strings.TrimSpace(quote.String()), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		w.WriteString(strings.Join(lines, "\n"))
+		w.ensureNewlines(2)
+	case atom.Pre:
+		w.writeCodeBlock(getHTMLText(n))
+	case atom.Hr:
+		w.ensureNewlines(2)
+		w.WriteString("---")
+		w.ensureNewlines(2)
+	case atom.Table:
+		c.writeTable(w, n)
+	default:
+		c.writeChildren(w, n)
+	}
+}
+
+func (c *htmlConverter) writeEmphasis(w *markdownWriter, n *html.Node, marker string) {
+	if text := c.writeInline(n); text != "" {
+		w.writeText(marker + text + marker)
+	}
+}
+
+func (c *htmlConverter) writeList(w *markdownWriter, n *html.Node) {
+	if c.listDepth > 0 {
+		w.ensureNewlines(1)
+	} else {
+		w.ensureNewlines(2)
+	}
+	c.listDepth++
+	// The checklists of Evernote are the lists of the en-todo style.
+	isChecklist := strings.Contains(getHTMLAttr(n, "style"), "--en-todo:true")
+	index := 0
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+		index++
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(index) + ". "
+		}
+		itemWriter := &markdownWriter{}
+		c.writeChildren(itemWriter, item)
+		lines := strings.Split(strings.TrimSpace(itemWriter.String()), "\n")
+		if isChecklist {
+			if strings.Contains(getHTMLAttr(item, "style"), "--en-checked:true") {
+				lines[0] = "[x] " + lines[0]
+			} else {
+				lines[0] = "[ ] " + lines[0]
+			}
+		}
+		for i, line := range lines {
+			if i == 0 {
+				lines[i] = marker + line
+			} else if line != "" {
+				lines[i] = strings.Repeat(" ", len(marker)) + line
+			}
+		}
+		w.ensureNewlines(1)
+		w.WriteString(strings.Join(lines, "\n"))
+	}
+	c.listDepth--
+	if c.listDepth > 0 {
+		w.ensureNewlines(1)
+	} else {
+		w.ensureNewlines(2)
+	}
+}
+
+func (c *htmlConverter) writeTable(w *markdownWriter, n *html.Node) {
+	rows := [][]string{}
+	var findRows func(n *html.Node)
+	findRows = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				findRows(child)
+				continue
+			}
+			row := []string{}
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					row = append(row, strings.ReplaceAll(c.writeInline(cell), "|", "\\|"))
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	findRows(n)
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return
+	}
+
+	w.ensureNewlines(2)
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		w.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			w.WriteString(strings.Repeat("| --- ", columns) + "|\n")
+		}
+	}
+	w.ensureNewlines(2)
+}
+
+// markdownWriter writes the Markdown, the whitespaces of the text are collapsed as the browsers render them.
+type markdownWriter struct {
+	strings.Builder
+}
+
+// ensureNewlines ends the Markdown with at least the number of the newlines, unless it's empty.
+func (w *markdownWriter) ensureNewlines(count int) {
+	s := w.String()
+	if s == "" {
+		return
+	}
+	newlines := len(s) - len(strings.TrimRight(s, "\n"))
+	for ; newlines < count; newlines++ {
+		w.WriteString("\n")
+	}
+}
+
+func (w *markdownWriter) writeText(text string) {
+	s := w.String()
+	atLineStart := s == "" || strings.HasSuffix(s, "\n")
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		if text != "" && !atLineStart && !strings.HasSuffix(s, " ") {
+			w.WriteString(" ")
+		}
+		return
+	}
+	if strings.TrimLeft(text, " \t\r\n") != text && !atLineStart && !strings.HasSuffix(s, " ") {
+		w.WriteString(" ")
+	}
+	w.WriteString(strings.Join(words, " "))
+	if strings.TrimRight(text, " \t\r\n") != text {
+		w.WriteString(" ")
+	}
+}
+
+func (w *markdownWriter) writeCodeBlock(code string) {
+	w.ensureNewlines(2)
+	w.WriteString("```\n" + strings.Trim(code, "\n") + "\n```")
+	w.ensureNewlines(2)
+}
+
+// getHTMLText returns the text of the node and its children, the lines are ended by the line breaks and the divs.
+func getHTMLText(n *html.Node) string {
+	var builder strings.Builder
+	var write func(n *html.Node)
+	write = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			builder.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.DataAtom == atom.Br:
+			builder.WriteString("\n")
+		default:
+			for child := n.FirstChild; child != nil; child = child.NextSibling {
+				write(child)
+			}
+			if n.Type == html.ElementNode && (n.DataAtom == atom.Div || n.DataAtom == atom.P) && !strings.HasSuffix(builder.String(), "\n") {
+				builder.WriteString("\n")
+			}
+		}
+	}
+	write(n)
+	return builder.String()
+}
+
+func getHTMLAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
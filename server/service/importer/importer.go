@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lithammer/shortuuid/v4"
+)
+
+// Note is a note of an export of another app, which is imported as a memo.
+// The parsers assign the uids of the memos and the resources beforehand,
+// so the links between the notes are written as the references of memos in the content.
+type Note struct {
+	// UID is the uid of the memo.
+	UID string
+	// Source is the path or the id of the note in the export, which identifies it in the errors.
+	Source  string
+	Content string
+	// Tags are appended to the content if the content doesn't have them.
+	Tags []string
+	// CreatedTs and UpdatedTs are 0 if the export doesn't have them.
+	CreatedTs   int64
+	UpdatedTs   int64
+	Pinned      bool
+	Archived    bool
+	Attachments []*Attachment
+}
+
+// Attachment is a file of a note, which is imported as a resource of the memo.
+type Attachment struct {
+	// UID is the uid of the resource.
+	UID      string
+	Filename string
+	Type     string
+	Blob     []byte
+}
+
+// Result is the number of the imported items, and the errors of the notes which failed to import.
+type Result struct {
+	Memos     int      `json:"memos"`
+	Resources int      `json:"resources"`
+	Relations int      `json:"relations"`
+	Errors    []string `json:"errors"`
+}
+
+var (
+	memoReferenceRegexp = regexp.MustCompile(`\[\[memos/([a-zA-Z0-9-]+)(?:\?[^\]]*)?\]\]`)
+	tagReplacer         = strings.NewReplacer("#", "", " ", "_", "\t", "_")
+)
+
+// NewUID returns a new uid of a memo or a resource.
+func NewUID() string {
+	return shortuuid.New()
+}
+
+// FormatMemoReference returns the reference to the memo of the uid, which is shown as the text.
+func FormatMemoReference(uid, text string) string {
+	if text == "" {
+		return "[[memos/" + uid + "]]"
+	}
+	return "[[memos/" + uid + "?text=" + url.QueryEscape(text) + "]]"
+}
+
+// FormatResourceEmbed returns the embed of the resource of the uid.
+func FormatResourceEmbed(uid string) string {
+	return "![[resources/" + uid + "]]"
+}
+
+// FormatTag returns the tag of the name, the characters ending a tag are replaced or removed.
+func FormatTag(name string) string {
+	return tagReplacer.Replace(strings.TrimSpace(name))
+}
+
+// MemoContent returns the content of the memo of the note, which ends with the tags missing in the content.
+func (n *Note) MemoContent() string {
+	content := strings.TrimSpace(n.Content)
+	missingTags := []string{}
+	for _, tag := range n.Tags {
+		tag = "#" + FormatTag(tag)
+		if tag == "#" || slices.Contains(missingTags, tag) || containsTag(content, tag) {
+			continue
+		}
+		missingTags = append(missingTags, tag)
+	}
+	if len(missingTags) == 0 {
+		return content
+	}
+	if content == "" {
+		return strings.Join(missingTags, " ")
+	}
+	return content + "\n\n" + strings.Join(missingTags, " ")
+}
+
+// GetMemoReferences returns the uids of the memos referenced in the content.
+func GetMemoReferences(content string) []string {
+	uids := []string{}
+	for _, match := range memoReferenceRegexp.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(uids, match[1]) {
+			uids = append(uids, match[1])
+		}
+	}
+	return uids
+}
+
+// replaceOutsideCodeBlocks replaces the lines of the content which aren't in the fenced code blocks.
+func replaceOutsideCodeBlocks(content string, replace func(line string) string) string {
+	lines := strings.Split(content, "\n")
+	inCodeBlock := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if !inCodeBlock {
+			lines[i] = replace(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func containsTag(content, tag string) bool {
+	for index := 0; ; {
+		i := strings.Index(content[index:], tag)
+		if i < 0 {
+			return false
+		}
+		end := index + i + len(tag)
+		if end == len(content) || strings.ContainsRune(" \t\n#", rune(content[end])) {
+			return true
+		}
+		index = end
+	}
+}
@@ -0,0 +1,117 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// The folders of Instapaper which aren't the folders of the user.
+const (
+	instapaperFolderUnread  = "Unread"
+	instapaperFolderArchive = "Archive"
+	instapaperFolderStarred = "Starred"
+)
+
+// ParseInstapaperExport parses the csv or the HTML export of Instapaper.
+// The selections of the bookmarks are the excerpts, the tags and the folders of the user become the tags.
+// The starred bookmarks are pinned.
+func ParseInstapaperExport(r io.ReaderAt, size int64) ([]*Note, error) {
+	first, err := readFirstByte(r, size)
+	if err != nil {
+		return nil, err
+	}
+	var items []*readLaterItem
+	if first == '<' {
+		items, err = parseInstapaperHTML(io.NewSectionReader(r, 0, size))
+	} else {
+		items, err = parseInstapaperCSV(r, size)
+	}
+	if err != nil {
+		return nil, err
+	}
+	notes := readLaterNotes(items)
+	if len(notes) == 0 {
+		return nil, errors.New("no bookmarks found in the Instapaper export")
+	}
+	return notes, nil
+}
+
+// parseInstapaperCSV parses the csv export, which has the columns URL, Title, Selection, Folder, Timestamp and Tags.
+// The tags are a JSON array of the names.
+func parseInstapaperCSV(r io.ReaderAt, size int64) ([]*readLaterItem, error) {
+	records, err := readCSVFiles(r, size)
+	if err != nil {
+		return nil, err
+	}
+	items := []*readLaterItem{}
+	for _, record := range records {
+		item := newInstapaperItem(record["folder"])
+		item.URL = record["url"]
+		item.Title = record["title"]
+		item.Excerpt = record["selection"]
+		if ts, err := strconv.ParseInt(strings.TrimSpace(record["timestamp"]), 10, 64); err == nil && ts > 0 {
+			item.CreatedTs = ts
+		}
+		if value := strings.TrimSpace(record["tags"]); value != "" {
+			tags := []string{}
+			if err := json.Unmarshal([]byte(value), &tags); err != nil {
+				tags = strings.Split(value, ",")
+			}
+			for _, tag := range tags {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					item.Tags = append(item.Tags, tag)
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseInstapaperHTML parses the HTML export, which has a heading of each folder followed by a list of the links of its bookmarks.
+func parseInstapaperHTML(r io.Reader) ([]*readLaterItem, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse HTML")
+	}
+	items := []*readLaterItem{}
+	folder := ""
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.DataAtom {
+		case atom.H1, atom.H2:
+			folder = strings.TrimSpace(getHTMLText(n))
+			return
+		case atom.A:
+			item := newInstapaperItem(folder)
+			item.URL = getHTMLAttr(n, "href")
+			item.Title = getHTMLText(n)
+			items = append(items, item)
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(root)
+	return items, nil
+}
+
+// newInstapaperItem returns the item of a bookmark in the folder, a folder of the user is its tag.
+func newInstapaperItem(folder string) *readLaterItem {
+	item := &readLaterItem{}
+	switch folder = strings.TrimSpace(folder); folder {
+	case "", instapaperFolderUnread, instapaperFolderArchive:
+	case instapaperFolderStarred:
+		item.Pinned = true
+	default:
+		item.Tags = append(item.Tags, folder)
+	}
+	return item
+}
@@ -0,0 +1,48 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstapaperExport(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		data := "URL,Title,Selection,Folder,Timestamp,Tags\n" +
+			"https://example.com/a,Article,\"Worth reading.\",Starred,1704103200,\"[\"\"news\"\"]\"\n" +
+			"https://example.com/b,Recipe,,Cooking,1704016800,[]\n" +
+			"https://example.com/c,,,Archive,1704189600,\n"
+		notes, err := ParseInstapaperExport(strings.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Len(t, notes, 3)
+		recipe, article, link := notes[0], notes[1], notes[2]
+
+		require.Equal(t, "[Recipe](https://example.com/b)", recipe.Content)
+		require.Equal(t, []string{"Cooking"}, recipe.Tags)
+		require.False(t, recipe.Pinned)
+
+		require.Equal(t, "[Article](https://example.com/a)\n\n> Worth reading.", article.Content)
+		require.Equal(t, []string{"news"}, article.Tags)
+		require.True(t, article.Pinned)
+		require.Equal(t, int64(1704103200), article.CreatedTs)
+
+		require.Equal(t, "https://example.com/c", link.Content)
+		require.Empty(t, link.Tags)
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		data := `<!DOCTYPE html><html><head><title>Instapaper: Export</title></head><body>
+<h1>Unread</h1>
+<ol><li><a href="https://example.com/a">Article</a></li></ol>
+<h1>Travel</h1>
+<ol><li><a href="https://example.com/b">Guide</a></li></ol>
+</body></html>`
+		notes, err := ParseInstapaperExport(strings.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Len(t, notes, 2)
+		require.Equal(t, "[Article](https://example.com/a)", notes[0].Content)
+		require.Empty(t, notes[0].Tags)
+		require.Equal(t, []string{"Travel"}, notes[1].Tags)
+	})
+}
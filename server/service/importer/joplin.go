@@ -0,0 +1,339 @@
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/fs"
+	"mime"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// The types of the items of Joplin.
+const (
+	joplinTypeNote     = "1"
+	joplinTypeFolder   = "2"
+	joplinTypeResource = "4"
+	joplinTypeTag      = "5"
+	joplinTypeNoteTag  = "6"
+)
+
+// joplinMarkupHTML is the markup language of the notes written in HTML.
+const joplinMarkupHTML = "2"
+
+var (
+	joplinPropertyRegexp = regexp.MustCompile(`^([a-z_]+): ?(.*)$`)
+	// The links to the items of Joplin are the ids of the items after :/, the resized images are the img elements of HTML.
+	joplinLinkRegexp  = regexp.MustCompile(`(!?)\[([^\]]*)\]\(:/([0-9a-f]{32})(?:#[^)\s]*)?(?:\s+"[^"]*")?\)`)
+	joplinImageRegexp = regexp.MustCompile(`<img\s[^>]*src=["']:/([0-9a-f]{32})["'][^>]*>`)
+)
+
+type joplinItem struct {
+	title      string
+	body       string
+	properties map[string]string
+}
+
+type joplinExport struct {
+	items map[string]*joplinItem
+	// resourceFiles are the files of the resources by the ids of the resources.
+	resourceFiles map[string][]byte
+	notes         map[string]*Note
+	attachments   map[string]*Attachment
+}
+
+// ParseJoplinExport parses the JEX export of Joplin, or a zip file of the RAW export directory of Joplin.
+// The notebooks become the nested tags of the notes, and the links to the notes and the resources are converted.
+// The encrypted notes can't be imported, they're skipped.
+func ParseJoplinExport(r io.ReaderAt, size int64) ([]*Note, error) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read export file")
+	}
+	if bytes.Equal(header, []byte("PK\x03\x04")) {
+		zipReader, err := openZipFile(r, size, newZipBudget())
+		if err != nil {
+			return nil, err
+		}
+		return ParseJoplinDirectory(zipReader)
+	}
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(io.NewSectionReader(r, 0, size))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read JEX file")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %q", header.Name)
+		}
+		files[path.Clean(header.Name)] = data
+	}
+	return parseJoplinFiles(files)
+}
+
+// ParseJoplinDirectory parses the RAW export directory of Joplin.
+func ParseJoplinDirectory(fsys fs.FS) ([]*Note, error) {
+	files := map[string][]byte{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(filePath, "__MACOSX/") {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+		files[filePath] = data
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read export directory")
+	}
+	return parseJoplinFiles(files)
+}
+
+func parseJoplinFiles(files map[string][]byte) ([]*Note, error) {
+	export := &joplinExport{
+		items:         map[string]*joplinItem{},
+		resourceFiles: map[string][]byte{},
+		notes:         map[string]*Note{},
+		attachments:   map[string]*Attachment{},
+	}
+	// The items may be in a directory of the archive, the resources are in the resources directory next to them.
+	for filePath, data := range files {
+		if path.Base(path.Dir(filePath)) == "resources" {
+			name := path.Base(filePath)
+			export.resourceFiles[strings.TrimSuffix(name, path.Ext(name))] = data
+			continue
+		}
+		if path.Ext(filePath) != ".md" {
+			continue
+		}
+		if item := parseJoplinItem(string(data)); item != nil && item.properties["id"] != "" {
+			export.items[item.properties["id"]] = item
+		}
+	}
+
+	ids := []string{}
+	for id, item := range export.items {
+		if item.properties["type_"] == joplinTypeNote && item.properties["encryption_applied"] != "1" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no notes found in the Joplin export")
+	}
+	// The notes are imported in the order of their creation.
+	sort.Slice(ids, func(i, j int) bool {
+		createdI, createdJ := export.items[ids[i]].getTime("created_time"), export.items[ids[j]].getTime("created_time")
+		if createdI != createdJ {
+			return createdI < createdJ
+		}
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		item := export.items[id]
+		source := item.title
+		if source == "" {
+			source = id
+		}
+		export.notes[id] = &Note{UID: NewUID(), Source: source}
+	}
+
+	tags := map[string][]string{}
+	for _, item := range export.items {
+		if item.properties["type_"] != joplinTypeNoteTag {
+			continue
+		}
+		noteID := item.properties["note_id"]
+		if tag := export.items[item.properties["tag_id"]]; tag != nil && tag.title != "" {
+			tags[noteID] = append(tags[noteID], tag.title)
+		}
+	}
+
+	notes := []*Note{}
+	for _, id := range ids {
+		item, note := export.items[id], export.notes[id]
+		if notebook := export.getNotebookTag(item.properties["parent_id"]); notebook != "" {
+			note.Tags = append(note.Tags, notebook)
+		}
+		noteTags := tags[id]
+		sort.Strings(noteTags)
+		note.Tags = append(note.Tags, noteTags...)
+		note.CreatedTs = item.getTime("user_created_time", "created_time")
+		note.UpdatedTs = item.getTime("user_updated_time", "updated_time")
+
+		var body string
+		if item.properties["markup_language"] == joplinMarkupHTML {
+			converted, err := export.convertHTML(note, item.body)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to convert note %q", note.Source)
+			}
+			body = converted
+		} else {
+			body = export.convertMarkdown(note, item.body)
+		}
+
+		parts := []string{}
+		if item.properties["is_todo"] == "1" {
+			if completed := item.properties["todo_completed"]; completed != "" && completed != "0" {
+				parts = append(parts, "- [x] "+item.title)
+			} else {
+				parts = append(parts, "- [ ] "+item.title)
+			}
+		} else if item.title != "" {
+			parts = append(parts, "# "+item.title)
+		}
+		if body = strings.TrimSpace(body); body != "" {
+			parts = append(parts, body)
+		}
+		note.Content = strings.Join(parts, "\n\n")
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// parseJoplinItem parses the file of an item, which is the title and the body followed by the properties after the last blank line.
+func parseJoplinItem(data string) *joplinItem {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(data, "\r\n", "\n"), "\n"), "\n")
+	item := &joplinItem{properties: map[string]string{}}
+	i := len(lines) - 1
+	for ; i >= 0 && strings.TrimSpace(lines[i]) != ""; i-- {
+		matches := joplinPropertyRegexp.FindStringSubmatch(lines[i])
+		if matches == nil {
+			return nil
+		}
+		item.properties[matches[1]] = strings.ReplaceAll(matches[2], "\\n", "\n")
+	}
+	if item.properties["type_"] == "" {
+		return nil
+	}
+	if i > 0 {
+		item.title = strings.TrimSpace(lines[0])
+		item.body = strings.Trim(strings.Join(lines[1:i], "\n"), "\n")
+	}
+	return item
+}
+
+// getTime returns the time of the first property which is set, 0 if none is.
+func (i *joplinItem) getTime(keys ...string) int64 {
+	for _, key := range keys {
+		if t, err := time.Parse(time.RFC3339Nano, i.properties[key]); err == nil && t.Unix() > 0 {
+			return t.Unix()
+		}
+	}
+	return 0
+}
+
+// getNotebookTag returns the path of the folder as a nested tag, e.g. Work/Projects.
+func (e *joplinExport) getNotebookTag(folderID string) string {
+	names := []string{}
+	// The depth is limited in case the parents of the folders are a loop.
+	for depth := 0; folderID != "" && depth < 32; depth++ {
+		folder := e.items[folderID]
+		if folder == nil || folder.properties["type_"] != joplinTypeFolder {
+			break
+		}
+		if name := strings.ReplaceAll(folder.title, "/", "-"); name != "" {
+			names = append([]string{name}, names...)
+		}
+		folderID = folder.properties["parent_id"]
+	}
+	return strings.Join(names, "/")
+}
+
+func (e *joplinExport) convertMarkdown(note *Note, body string) string {
+	return replaceOutsideCodeBlocks(body, func(line string) string {
+		line = joplinImageRegexp.ReplaceAllStringFunc(line, func(image string) string {
+			markdown, _ := e.convertLink(note, true, "", joplinImageRegexp.FindStringSubmatch(image)[1])
+			return markdown
+		})
+		return joplinLinkRegexp.ReplaceAllStringFunc(line, func(link string) string {
+			matches := joplinLinkRegexp.FindStringSubmatch(link)
+			markdown, _ := e.convertLink(note, matches[1] == "!", matches[2], matches[3])
+			return markdown
+		})
+	})
+}
+
+func (e *joplinExport) convertHTML(note *Note, body string) (string, error) {
+	root, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse HTML")
+	}
+	converter := &htmlConverter{}
+	converter.convertElement = func(n *html.Node) (string, bool) {
+		switch n.DataAtom {
+		case atom.Img:
+			if id, ok := strings.CutPrefix(getHTMLAttr(n, "src"), ":/"); ok {
+				return e.convertLink(note, true, getHTMLAttr(n, "alt"), id)
+			}
+		case atom.A:
+			if id, ok := strings.CutPrefix(getHTMLAttr(n, "href"), ":/"); ok {
+				return e.convertLink(note, false, converter.writeInline(n), id)
+			}
+		}
+		return "", false
+	}
+	return converter.convertHTMLToMarkdown(root), nil
+}
+
+// convertLink returns the reference to the memo of the note, or the embed of the resource of the id.
+// Only the text is kept if the item of the id isn't exported.
+func (e *joplinExport) convertLink(note *Note, isImage bool, text, id string) (string, bool) {
+	if linkedNote := e.notes[id]; linkedNote != nil {
+		if item := e.items[id]; item != nil && text == item.title {
+			text = ""
+		}
+		return FormatMemoReference(linkedNote.UID, text), true
+	}
+	item := e.items[id]
+	data, ok := e.resourceFiles[id]
+	if item == nil || item.properties["type_"] != joplinTypeResource || !ok {
+		return text, true
+	}
+
+	attachment := e.attachments[id]
+	if attachment == nil {
+		attachment = &Attachment{
+			UID:      NewUID(),
+			Filename: item.title,
+			Type:     item.properties["mime"],
+			Blob:     data,
+		}
+		if attachment.Filename == "" {
+			attachment.Filename = id
+			if extension := item.properties["file_extension"]; extension != "" {
+				attachment.Filename += "." + extension
+			}
+		}
+		if attachment.Type == "" {
+			attachment.Type = mime.TypeByExtension(path.Ext(attachment.Filename))
+		}
+		e.attachments[id] = attachment
+		note.Attachments = append(note.Attachments, attachment)
+	}
+	if !isImage && text != "" && text != attachment.Filename {
+		return text + " " + FormatResourceEmbed(attachment.UID), true
+	}
+	return FormatResourceEmbed(attachment.UID), true
+}
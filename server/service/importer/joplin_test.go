@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testJoplinFolder    = "f0000000000000000000000000000001"
+	testJoplinSubfolder = "f0000000000000000000000000000002"
+	testJoplinNote      = "a0000000000000000000000000000001"
+	testJoplinTodo      = "a0000000000000000000000000000002"
+	testJoplinResource  = "e0000000000000000000000000000001"
+)
+
+var testJoplinFiles = map[string]string{
+	testJoplinFolder + ".md":    "Work\n\nid: " + testJoplinFolder + "\nparent_id: \ntype_: 2",
+	testJoplinSubfolder + ".md": "Projects\n\nid: " + testJoplinSubfolder + "\nparent_id: " + testJoplinFolder + "\ntype_: 2",
+	testJoplinNote + ".md": "Plan\n\nSee [Call](:/" + testJoplinTodo + ") and [gone](:/b0000000000000000000000000000009).\n\n![chart.png](:/" + testJoplinResource + ")\n<img src=\":/" + testJoplinResource + "\" width=\"100\"/>\n\n```\n[Call](:/" + testJoplinTodo + ")\n```\n\n" +
+		"id: " + testJoplinNote + "\nparent_id: " + testJoplinSubfolder + "\ncreated_time: 2024-01-02T10:00:00.000Z\nupdated_time: 2024-01-03T10:00:00.000Z\nuser_created_time: 2024-01-01T10:00:00.000Z\nuser_updated_time: 2024-01-03T10:00:00.000Z\nis_todo: 0\nmarkup_language: 1\ntype_: 1",
+	testJoplinTodo + ".md": "Call\n\n<p>Call <a href=\":/" + testJoplinNote + "\">the plan</a> owner</p>\n\n" +
+		"id: " + testJoplinTodo + "\nparent_id: " + testJoplinFolder + "\ncreated_time: 2024-01-05T10:00:00.000Z\nis_todo: 1\ntodo_completed: 1704448800000\nmarkup_language: 2\ntype_: 1",
+	"c0000000000000000000000000000001.md":      "\n\nid: c0000000000000000000000000000001\nencryption_applied: 1\ntype_: 1",
+	"d0000000000000000000000000000001.md":      "reading list\n\nid: d0000000000000000000000000000001\ntype_: 5",
+	"b0000000000000000000000000000001.md":      "id: b0000000000000000000000000000001\nnote_id: " + testJoplinNote + "\ntag_id: d0000000000000000000000000000001\ntype_: 6",
+	testJoplinResource + ".md":                 "chart.png\n\nid: " + testJoplinResource + "\nmime: image/png\nfile_extension: png\ntype_: 4",
+	"resources/" + testJoplinResource + ".png": "png",
+}
+
+func TestParseJoplinExport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := tar.NewWriter(buf)
+	for name, content := range testJoplinFiles {
+		require.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+		_, err := writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	notes, err := ParseJoplinExport(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	testJoplinNotes(t, notes)
+}
+
+func TestParseJoplinDirectory(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for name, content := range testJoplinFiles {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	notes, err := ParseJoplinDirectory(fsys)
+	require.NoError(t, err)
+	testJoplinNotes(t, notes)
+}
+
+func testJoplinNotes(t *testing.T, notes []*Note) {
+	// The encrypted note is skipped, the notes are ordered by the created time.
+	require.Len(t, notes, 2)
+	plan, call := notes[0], notes[1]
+
+	require.Equal(t, "Plan", plan.Source)
+	require.Equal(t, []string{"Work/Projects", "reading list"}, plan.Tags)
+	require.Equal(t, int64(1704103200), plan.CreatedTs)
+	require.Equal(t, int64(1704276000), plan.UpdatedTs)
+	require.Len(t, plan.Attachments, 1)
+	chart := plan.Attachments[0]
+	require.Equal(t, "chart.png", chart.Filename)
+	require.Equal(t, "image/png", chart.Type)
+	require.Equal(t, []byte("png"), chart.Blob)
+	embed := FormatResourceEmbed(chart.UID)
+	require.Equal(t, "# Plan\n\nSee "+FormatMemoReference(call.UID, "")+" and gone.\n\n"+embed+"\n"+embed+"\n\n```\n[Call](:/"+testJoplinTodo+")\n```", plan.Content)
+
+	require.Equal(t, []string{"Work"}, call.Tags)
+	require.Equal(t, "- [x] Call\n\nCall "+FormatMemoReference(plan.UID, "the plan")+" owner", call.Content)
+}
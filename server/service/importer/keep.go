@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type keepNote struct {
+	Title       string `json:"title"`
+	TextContent string `json:"textContent"`
+	ListContent []struct {
+		Text      string `json:"text"`
+		IsChecked bool   `json:"isChecked"`
+	} `json:"listContent"`
+	Color                   string `json:"color"`
+	IsTrashed               bool   `json:"isTrashed"`
+	IsPinned                bool   `json:"isPinned"`
+	IsArchived              bool   `json:"isArchived"`
+	CreatedTimestampUsec    int64  `json:"createdTimestampUsec"`
+	UserEditedTimestampUsec int64  `json:"userEditedTimestampUsec"`
+	Labels                  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Attachments []struct {
+		FilePath string `json:"filePath"`
+		Mimetype string `json:"mimetype"`
+	} `json:"attachments"`
+	Annotations []struct {
+		URL   string `json:"url"`
+		Title string `json:"title"`
+	} `json:"annotations"`
+}
+
+// ParseKeepExport parses the Google Takeout archive of Google Keep.
+// The lists become the checkboxes, the labels become the tags and the colors are kept in the content.
+// The trashed notes are skipped.
+func ParseKeepExport(r io.ReaderAt, size int64) ([]*Note, error) {
+	zipReader, err := openZipFile(r, size, newZipBudget())
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]*zip.File{}
+	// The attachments are found by their names without the extensions too, as the extensions in the notes may differ from the files.
+	filesWithoutExtension := map[string]*zip.File{}
+	jsonPaths := []string{}
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		filePath := path.Clean(file.Name)
+		files[filePath] = file
+		switch path.Ext(filePath) {
+		case ".json":
+			jsonPaths = append(jsonPaths, filePath)
+		case ".html":
+		default:
+			filesWithoutExtension[strings.TrimSuffix(filePath, path.Ext(filePath))] = file
+		}
+	}
+	sort.Strings(jsonPaths)
+
+	notes := []*Note{}
+	for _, jsonPath := range jsonPaths {
+		data, err := readZipFile(files[jsonPath])
+		if err != nil {
+			return nil, err
+		}
+		keepNote := &keepNote{}
+		// The other JSON files of the archive, e.g. the labels, aren't notes.
+		if err := json.Unmarshal(data, keepNote); err != nil || keepNote.CreatedTimestampUsec == 0 && keepNote.UserEditedTimestampUsec == 0 {
+			continue
+		}
+		if keepNote.IsTrashed {
+			continue
+		}
+
+		note := &Note{
+			UID:       NewUID(),
+			Source:    jsonPath,
+			CreatedTs: keepNote.CreatedTimestampUsec / 1e6,
+			UpdatedTs: keepNote.UserEditedTimestampUsec / 1e6,
+			Pinned:    keepNote.IsPinned,
+			Archived:  keepNote.IsArchived,
+		}
+		for _, label := range keepNote.Labels {
+			note.Tags = append(note.Tags, label.Name)
+		}
+		dir := path.Dir(jsonPath)
+		for _, keepAttachment := range keepNote.Attachments {
+			attachmentPath := path.Join(dir, keepAttachment.FilePath)
+			file := files[attachmentPath]
+			if file == nil {
+				file = filesWithoutExtension[strings.TrimSuffix(attachmentPath, path.Ext(attachmentPath))]
+			}
+			if file == nil {
+				continue
+			}
+			data, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+			attachment := &Attachment{
+				UID:      NewUID(),
+				Filename: path.Base(file.Name),
+				Type:     mime.TypeByExtension(path.Ext(file.Name)),
+				Blob:     data,
+			}
+			if attachment.Type == "" {
+				attachment.Type = keepAttachment.Mimetype
+			}
+			note.Attachments = append(note.Attachments, attachment)
+		}
+
+		parts := []string{}
+		if title := strings.TrimSpace(keepNote.Title); title != "" {
+			parts = append(parts, "# "+title)
+		}
+		if text := strings.TrimSpace(strings.ReplaceAll(keepNote.TextContent, "\r\n", "\n")); text != "" {
+			parts = append(parts, text)
+		}
+		if len(keepNote.ListContent) > 0 {
+			items := []string{}
+			for _, item := range keepNote.ListContent {
+				checkbox := "- [ ] "
+				if item.IsChecked {
+					checkbox = "- [x] "
+				}
+				items = append(items, checkbox+strings.Join(strings.Fields(item.Text), " "))
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		}
+		if len(keepNote.Annotations) > 0 {
+			links := []string{}
+			for _, annotation := range keepNote.Annotations {
+				if annotation.URL == "" {
+					continue
+				}
+				if title := strings.TrimSpace(annotation.Title); title != "" {
+					links = append(links, "- ["+title+"]("+annotation.URL+")")
+				} else {
+					links = append(links, "- "+annotation.URL)
+				}
+			}
+			if len(links) > 0 {
+				parts = append(parts, strings.Join(links, "\n"))
+			}
+		}
+		for _, attachment := range note.Attachments {
+			parts = append(parts, FormatResourceEmbed(attachment.UID))
+		}
+		if color := keepNote.Color; color != "" && color != "DEFAULT" {
+			parts = append(parts, "Color: "+strings.ToUpper(color[:1])+strings.ToLower(color[1:]))
+		}
+		note.Content = strings.Join(parts, "\n\n")
+		notes = append(notes, note)
+	}
+	if len(notes) == 0 {
+		return nil, errors.New("no notes found in the Google Keep export")
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedTs < notes[j].CreatedTs
+	})
+	return notes, nil
+}
@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeepExport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"Takeout/Keep/Groceries.json": `{"color":"YELLOW","isTrashed":false,"isPinned":true,"isArchived":false,"title":"Groceries",` +
+			`"listContent":[{"text":"Milk","isChecked":true},{"text":"Eggs","isChecked":false}],` +
+			`"labels":[{"name":"Home"},{"name":"to buy"}],"createdTimestampUsec":1704103200000000,"userEditedTimestampUsec":1704189600000000}`,
+		"Takeout/Keep/Trip.json": `{"color":"DEFAULT","isTrashed":false,"isPinned":false,"isArchived":true,"title":"","textContent":"Pack the camera",` +
+			`"annotations":[{"url":"https://example.com","title":"Example"}],` +
+			`"attachments":[{"filePath":"photo.jpeg","mimetype":"image/jpeg"}],"createdTimestampUsec":1704016800000000,"userEditedTimestampUsec":1704016800000000}`,
+		"Takeout/Keep/Old.json":       `{"isTrashed":true,"textContent":"Gone","createdTimestampUsec":1704016800000000}`,
+		"Takeout/Keep/Labels.json":    `{"labels":[{"name":"Home"}]}`,
+		"Takeout/Keep/photo.jpg":      "jpg",
+		"Takeout/Keep/Groceries.html": "<html></html>",
+	} {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	notes, err := ParseKeepExport(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	// The trashed note is skipped, the notes are ordered by their created times.
+	require.Len(t, notes, 2)
+	trip, groceries := notes[0], notes[1]
+
+	require.True(t, trip.Archived)
+	require.False(t, trip.Pinned)
+	require.Equal(t, int64(1704016800), trip.CreatedTs)
+	// The extension of the attachment differs from the file.
+	require.Len(t, trip.Attachments, 1)
+	photo := trip.Attachments[0]
+	require.Equal(t, "photo.jpg", photo.Filename)
+	require.Equal(t, "image/jpeg", photo.Type)
+	require.Equal(t, []byte("jpg"), photo.Blob)
+	require.Equal(t, "Pack the camera\n\n- [Example](https://example.com)\n\n"+FormatResourceEmbed(photo.UID), trip.Content)
+
+	require.True(t, groceries.Pinned)
+	require.Equal(t, int64(1704103200), groceries.CreatedTs)
+	require.Equal(t, int64(1704189600), groceries.UpdatedTs)
+	require.Equal(t, []string{"Home", "to buy"}, groceries.Tags)
+	require.Equal(t, "# Groceries\n\n- [x] Milk\n- [ ] Eggs\n\nColor: Yellow", groceries.Content)
+}
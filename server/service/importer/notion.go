@@ -0,0 +1,323 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// The files of the pages and the databases are named after their titles followed by their ids.
+	notionIDRegexp = regexp.MustCompile(`^(.*?)\s+[0-9a-f]{32}$`)
+	// The paths of the links are escaped, one level of parentheses is allowed in them.
+	notionLinkRegexp     = regexp.MustCompile(`(!?)\[([^\]]*)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`)
+	notionPropertyRegexp = regexp.MustCompile(`^([^:]+): (.*)$`)
+	// notionTagProperties are the names of the properties of the databases which are imported as tags.
+	notionTagProperties = []string{"tags", "tag", "labels", "label", "category", "categories", "topics", "topic"}
+	notionDateLayouts   = []string{
+		"January 2, 2006 3:04 PM",
+		"January 2, 2006 15:04",
+		"January 2, 2006",
+		"2006/01/02 15:04",
+		"2006/01/02",
+		"2006-01-02",
+	}
+)
+
+type notionPage struct {
+	note  *Note
+	path  string
+	title string
+	// database is the database of the page if the page is a row of it.
+	database *notionDatabase
+}
+
+type notionDatabase struct {
+	title      string
+	properties []string
+}
+
+type notionExport struct {
+	files     map[string]*zip.File
+	pages     map[string]*notionPage
+	databases map[string]*notionDatabase
+	// attachments are the attachments of the file paths, a file linked in several pages is imported once.
+	attachments map[string]*Attachment
+}
+
+// ParseNotionExport parses the Markdown & CSV export of a Notion workspace, the parts of the export may be nested zip files.
+// The pages become the memos and the files linked in them become the resources.
+// The properties of the pages of the databases are kept in the content, except the tags and the created and edited times.
+func ParseNotionExport(r io.ReaderAt, size int64) ([]*Note, error) {
+	export := &notionExport{
+		files:       map[string]*zip.File{},
+		pages:       map[string]*notionPage{},
+		databases:   map[string]*notionDatabase{},
+		attachments: map[string]*Attachment{},
+	}
+	if err := export.addZipFiles(r, size, 1, newZipBudget()); err != nil {
+		return nil, err
+	}
+
+	filePaths := []string{}
+	for filePath := range export.files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	for _, filePath := range filePaths {
+		if path.Ext(filePath) != ".csv" {
+			continue
+		}
+		// The databases are exported as the csv files of the current view, and of all the rows with the _all suffix.
+		databasePath := strings.TrimSuffix(strings.TrimSuffix(filePath, ".csv"), "_all")
+		if export.databases[databasePath] != nil {
+			continue
+		}
+		properties, err := export.parseDatabaseProperties(filePath)
+		if err != nil {
+			return nil, err
+		}
+		export.databases[databasePath] = &notionDatabase{
+			title:      getNotionTitle(databasePath + ".csv"),
+			properties: properties,
+		}
+	}
+	pages := []*notionPage{}
+	for _, filePath := range filePaths {
+		if path.Ext(filePath) != ".md" {
+			continue
+		}
+		page := &notionPage{
+			note:     &Note{UID: NewUID(), Source: filePath},
+			path:     filePath,
+			title:    getNotionTitle(filePath),
+			database: export.databases[path.Dir(filePath)],
+		}
+		export.pages[filePath] = page
+		pages = append(pages, page)
+	}
+	if len(pages) == 0 {
+		return nil, errors.New("no pages found in the Notion export")
+	}
+
+	notes := []*Note{}
+	for _, page := range pages {
+		if err := export.parsePage(page); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse page %q", page.path)
+		}
+		notes = append(notes, page.note)
+	}
+	return notes, nil
+}
+
+func (e *notionExport) addZipFiles(r io.ReaderAt, size int64, depth int, budget *uint64) error {
+	if depth > maxZipDepth {
+		return errors.Errorf("zip files are nested more than %d levels deep", maxZipDepth)
+	}
+	zipReader, err := openZipFile(r, size, budget)
+	if err != nil {
+		return err
+	}
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		if path.Ext(file.Name) == ".zip" {
+			data, err := readZipFile(file)
+			if err != nil {
+				return err
+			}
+			if err := e.addZipFiles(bytes.NewReader(data), int64(len(data)), depth+1, budget); err != nil {
+				return errors.Wrapf(err, "failed to read %q", file.Name)
+			}
+			continue
+		}
+		e.files[path.Clean(file.Name)] = file
+	}
+	return nil
+}
+
+// parseDatabaseProperties returns the names of the properties in the header of the csv file of the database.
+func (e *notionExport) parseDatabaseProperties(filePath string) ([]string, error) {
+	data, err := readZipFile(e.files[filePath])
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "failed to read database %q", filePath)
+	}
+	return header, nil
+}
+
+func (e *notionExport) parsePage(page *notionPage) error {
+	data, err := readZipFile(e.files[page.path])
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) > 0 {
+		if title, ok := strings.CutPrefix(lines[0], "# "); ok {
+			page.title = strings.TrimSpace(title)
+			lines = lines[1:]
+		}
+	}
+
+	note := page.note
+	propertyLines := []string{}
+	if page.database != nil {
+		if page.database.title != "" {
+			note.Tags = append(note.Tags, page.database.title)
+		}
+		for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+			lines = lines[1:]
+		}
+		for ; len(lines) > 0; lines = lines[1:] {
+			matches := notionPropertyRegexp.FindStringSubmatch(lines[0])
+			if matches == nil || !slices.Contains(page.database.properties, matches[1]) {
+				break
+			}
+			if !setNotionProperty(note, matches[1], strings.TrimSpace(matches[2])) {
+				propertyLines = append(propertyLines, lines[0])
+			}
+		}
+	}
+
+	parts := []string{}
+	if page.title != "" {
+		parts = append(parts, "# "+page.title)
+	}
+	if len(propertyLines) > 0 {
+		parts = append(parts, strings.Join(propertyLines, "\n"))
+	}
+	if body := strings.TrimSpace(e.convertLinks(page, lines)); body != "" {
+		parts = append(parts, body)
+	}
+	note.Content = strings.Join(parts, "\n\n")
+	return nil
+}
+
+// setNotionProperty sets the tags or the times of the note from the property, returns false if the property isn't one of them.
+func setNotionProperty(n *Note, name, value string) bool {
+	lowerName := strings.ToLower(name)
+	switch {
+	case slices.Contains(notionTagProperties, lowerName):
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				n.Tags = append(n.Tags, tag)
+			}
+		}
+		return true
+	case strings.Contains(lowerName, "created"):
+		if ts, ok := parseNotionDate(value); ok {
+			n.CreatedTs = ts
+			return true
+		}
+	case strings.Contains(lowerName, "edited") || strings.Contains(lowerName, "updated"):
+		if ts, ok := parseNotionDate(value); ok {
+			n.UpdatedTs = ts
+			return true
+		}
+	}
+	return false
+}
+
+// convertLinks converts the links to the pages to the references of the memos, and the links to the files to the embeds of the resources.
+// The links in the code blocks are kept.
+func (e *notionExport) convertLinks(page *notionPage, lines []string) string {
+	return replaceOutsideCodeBlocks(strings.Join(lines, "\n"), func(line string) string {
+		return notionLinkRegexp.ReplaceAllStringFunc(line, func(link string) string {
+			matches := notionLinkRegexp.FindStringSubmatch(link)
+			if converted, ok := e.convertLink(page, matches[2], matches[3]); ok {
+				return converted
+			}
+			return link
+		})
+	})
+}
+
+func (e *notionExport) convertLink(page *notionPage, text, target string) (string, bool) {
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") || strings.HasPrefix(target, "#") {
+		return "", false
+	}
+	target, err := url.PathUnescape(target)
+	if err != nil {
+		return "", false
+	}
+	filePath := path.Join(path.Dir(page.path), target)
+	switch path.Ext(filePath) {
+	case ".md":
+		linkedPage := e.pages[filePath]
+		if linkedPage == nil {
+			// The page isn't exported, only the text is kept.
+			return text, true
+		}
+		if text == linkedPage.title || text == getNotionTitle(filePath) {
+			text = ""
+		}
+		return FormatMemoReference(linkedPage.note.UID, text), true
+	case ".csv":
+		return text, true
+	}
+
+	attachment := e.attachments[filePath]
+	if attachment == nil {
+		file := e.files[filePath]
+		if file == nil {
+			return "", false
+		}
+		data, err := readZipFile(file)
+		if err != nil {
+			return "", false
+		}
+		attachment = &Attachment{
+			UID:      NewUID(),
+			Filename: path.Base(filePath),
+			Type:     mime.TypeByExtension(path.Ext(filePath)),
+			Blob:     data,
+		}
+		if attachment.Type == "" {
+			attachment.Type = http.DetectContentType(data)
+		}
+		e.attachments[filePath] = attachment
+		page.note.Attachments = append(page.note.Attachments, attachment)
+	}
+	return FormatResourceEmbed(attachment.UID), true
+}
+
+// getNotionTitle returns the title in the name of the file, without the id.
+func getNotionTitle(filePath string) string {
+	name := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	if matches := notionIDRegexp.FindStringSubmatch(name); matches != nil {
+		return matches[1]
+	}
+	return name
+}
+
+// parseNotionDate parses the date of a property, the start of the range if it's a range.
+func parseNotionDate(value string) (int64, bool) {
+	value, _, _ = strings.Cut(value, " → ")
+	if i := strings.Index(value, " (GMT"); i >= 0 {
+		value = value[:i]
+	}
+	for _, layout := range notionDateLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(value), time.Local); err == nil {
+			return t.Unix(), true
+		}
+	}
+	return 0, false
+}
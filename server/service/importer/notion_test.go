@@ -0,0 +1,65 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotionExport(t *testing.T) {
+	part := newTestZip(t, map[string]string{
+		"Projects 0123456789abcdef0123456789abcdef.md":                                               "# Projects\n\nSee [Plan](Projects%200123456789abcdef0123456789abcdef/Plan%20(draft)%20abcdefabcdefabcdefabcdefabcdefab.md) and [Tasks](Tasks%2011111111111111111111111111111111.csv).\n\n![photo.png](Projects%200123456789abcdef0123456789abcdef/photo.png)\n\n```\n[Plan](Plan.md)\n```",
+		"Projects 0123456789abcdef0123456789abcdef/Plan (draft) abcdefabcdefabcdefabcdefabcdefab.md": "# Plan (draft)\n\nUses ![](photo.png) and [Missing](Missing%2022222222222222222222222222222222.md), [site](https://usememos.com).",
+		"Projects 0123456789abcdef0123456789abcdef/photo.png":                                        "png",
+		"Tasks 11111111111111111111111111111111.csv":                                                 "\ufeffName,Tags,Status,Created\nWrite docs,\"Work, Docs\",Done,\"March 3, 2024 10:15 AM\"\n",
+		"Tasks 11111111111111111111111111111111_all.csv":                                             "\ufeffName,Tags,Status,Created\n",
+		"Tasks 11111111111111111111111111111111/Write docs 33333333333333333333333333333333.md":      "# Write docs\n\nTags: Work, Docs\nStatus: Done\nCreated: March 3, 2024 10:15 AM\n\nNote: a line of the content",
+	})
+	export := newTestZip(t, map[string]string{"Export-Part-1.zip": part.String()})
+
+	notes, err := ParseNotionExport(bytes.NewReader(export.Bytes()), int64(export.Len()))
+	require.NoError(t, err)
+	require.Len(t, notes, 3)
+	projects, plan, task := notes[0], notes[1], notes[2]
+
+	require.Len(t, projects.Attachments, 1)
+	photo := projects.Attachments[0]
+	require.Equal(t, "photo.png", photo.Filename)
+	require.Equal(t, "image/png", photo.Type)
+	require.Equal(t, []byte("png"), photo.Blob)
+	require.Equal(t, "# Projects\n\nSee "+FormatMemoReference(plan.UID, "Plan")+" and Tasks.\n\n"+FormatResourceEmbed(photo.UID)+"\n\n```\n[Plan](Plan.md)\n```", projects.Content)
+	// The file linked in several pages is imported once.
+	require.Empty(t, plan.Attachments)
+	require.Equal(t, "# Plan (draft)\n\nUses "+FormatResourceEmbed(photo.UID)+" and Missing, [site](https://usememos.com).", plan.Content)
+
+	require.Equal(t, []string{"Tasks", "Work", "Docs"}, task.Tags)
+	createdTs, err := time.ParseInLocation("2006-01-02 15:04", "2024-03-03 10:15", time.Local)
+	require.NoError(t, err)
+	require.Equal(t, createdTs.Unix(), task.CreatedTs)
+	require.Equal(t, "# Write docs\n\nStatus: Done\n\nNote: a line of the content", task.Content)
+	require.Equal(t, "# Write docs\n\nStatus: Done\n\nNote: a line of the content\n\n#Tasks #Work #Docs", task.MemoContent())
+	require.Equal(t, []string{plan.UID}, GetMemoReferences(projects.Content))
+}
+
+func TestNoteMemoContent(t *testing.T) {
+	note := &Note{Content: "Reading #books now\n", Tags: []string{"books", "to read", "book"}}
+	require.Equal(t, "Reading #books now\n\n#to_read #book", note.MemoContent())
+	note = &Note{Tags: []string{"#inbox"}}
+	require.Equal(t, "#inbox", note.MemoContent())
+}
+
+func newTestZip(t *testing.T, files map[string]string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf
+}
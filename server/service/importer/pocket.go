@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// pocketRetrieveResponse is the response of the retrieve API of Pocket, with the complete details of the items.
+type pocketRetrieveResponse struct {
+	// List is an object of the items by their ids, or an empty array if there are no items.
+	List json.RawMessage `json:"list"`
+}
+
+type pocketItem struct {
+	ItemID        string `json:"item_id"`
+	GivenURL      string `json:"given_url"`
+	ResolvedURL   string `json:"resolved_url"`
+	GivenTitle    string `json:"given_title"`
+	ResolvedTitle string `json:"resolved_title"`
+	Excerpt       string `json:"excerpt"`
+	Favorite      string `json:"favorite"`
+	// Status is 0 for the unread items, 1 for the archived items and 2 for the deleted items.
+	Status    string `json:"status"`
+	TimeAdded string `json:"time_added"`
+	Tags      map[string]struct {
+		Tag string `json:"tag"`
+	} `json:"tags"`
+}
+
+// ParsePocketExport parses an export of Pocket, which is the HTML export, the csv export or the zip file of it,
+// or the JSON response of the retrieve API with the complete details.
+// The saved items of the list and of the archive become the link memos, only the responses of the API have the excerpts.
+// The favorite items are pinned.
+func ParsePocketExport(r io.ReaderAt, size int64) ([]*Note, error) {
+	first, err := readFirstByte(r, size)
+	if err != nil {
+		return nil, err
+	}
+	var items []*readLaterItem
+	switch first {
+	case '{':
+		items, err = parsePocketRetrieveResponse(io.NewSectionReader(r, 0, size))
+	case '<':
+		items, err = parsePocketHTML(io.NewSectionReader(r, 0, size))
+	default:
+		items, err = parsePocketCSV(r, size)
+	}
+	if err != nil {
+		return nil, err
+	}
+	notes := readLaterNotes(items)
+	if len(notes) == 0 {
+		return nil, errors.New("no items found in the Pocket export")
+	}
+	return notes, nil
+}
+
+func parsePocketRetrieveResponse(r io.Reader) ([]*readLaterItem, error) {
+	response := &pocketRetrieveResponse{}
+	if err := json.NewDecoder(r).Decode(response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode Pocket response")
+	}
+	list := map[string]*pocketItem{}
+	if len(response.List) > 0 && response.List[0] == '{' {
+		if err := json.Unmarshal(response.List, &list); err != nil {
+			return nil, errors.Wrap(err, "failed to decode Pocket items")
+		}
+	}
+	ids := []string{}
+	for id := range list {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	items := []*readLaterItem{}
+	for _, id := range ids {
+		pocketItem := list[id]
+		if pocketItem.Status == "2" {
+			continue
+		}
+		item := &readLaterItem{
+			URL:       pocketItem.ResolvedURL,
+			Title:     pocketItem.ResolvedTitle,
+			Excerpt:   pocketItem.Excerpt,
+			CreatedTs: parsePocketTime(pocketItem.TimeAdded),
+			Pinned:    pocketItem.Favorite == "1",
+		}
+		if item.URL == "" {
+			item.URL = pocketItem.GivenURL
+		}
+		if item.Title == "" {
+			item.Title = pocketItem.GivenTitle
+		}
+		for name, tag := range pocketItem.Tags {
+			if tag.Tag != "" {
+				name = tag.Tag
+			}
+			item.Tags = append(item.Tags, name)
+		}
+		sort.Strings(item.Tags)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parsePocketHTML parses the HTML export, which has a list of the links of the items for the list and for the archive.
+func parsePocketHTML(r io.Reader) ([]*readLaterItem, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse HTML")
+	}
+	items := []*readLaterItem{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.DataAtom == atom.A {
+			item := &readLaterItem{
+				URL:       getHTMLAttr(n, "href"),
+				Title:     getHTMLText(n),
+				CreatedTs: parsePocketTime(getHTMLAttr(n, "time_added")),
+			}
+			for _, tag := range strings.Split(getHTMLAttr(n, "tags"), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					item.Tags = append(item.Tags, tag)
+				}
+			}
+			items = append(items, item)
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(root)
+	return items, nil
+}
+
+// parsePocketCSV parses the csv export, which has the columns title, url, time_added, tags and status, and the tags are separated by |.
+func parsePocketCSV(r io.ReaderAt, size int64) ([]*readLaterItem, error) {
+	records, err := readCSVFiles(r, size)
+	if err != nil {
+		return nil, err
+	}
+	items := []*readLaterItem{}
+	for _, record := range records {
+		item := &readLaterItem{
+			URL:       record["url"],
+			Title:     record["title"],
+			CreatedTs: parsePocketTime(record["time_added"]),
+		}
+		for _, tag := range strings.Split(record["tags"], "|") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				item.Tags = append(item.Tags, tag)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func parsePocketTime(value string) int64 {
+	if ts, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil && ts > 0 {
+		return ts
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePocketExport(t *testing.T) {
+	t.Run("HTML", func(t *testing.T) {
+		data := `<!DOCTYPE html><html><head><title>Pocket Export</title></head><body>
+<h1>Unread</h1>
+<ul>
+<li><a href="https://example.com/go" time_added="1704103200" tags="go,reading list">Learning [Go]</a></li>
+<li><a href="javascript:alert(1)" time_added="1704103200" tags="">Script</a></li>
+</ul>
+<h1>Read Archive</h1>
+<ul>
+<li><a href="https://example.com/old" time_added="1704016800" tags="">https://example.com/old</a></li>
+</ul>
+</body></html>`
+		notes, err := ParsePocketExport(strings.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		// The link which isn't a web URL is skipped, the items are ordered by their added times.
+		require.Len(t, notes, 2)
+		require.Equal(t, "https://example.com/old", notes[0].Content)
+		require.Equal(t, int64(1704016800), notes[0].CreatedTs)
+		require.Equal(t, "[Learning \\[Go\\]](https://example.com/go)", notes[1].Content)
+		require.Equal(t, []string{"go", "reading list"}, notes[1].Tags)
+		require.Equal(t, "https://example.com/go", notes[1].Source)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		writer := zip.NewWriter(buf)
+		w, err := writer.Create("part_000000.csv")
+		require.NoError(t, err)
+		_, err = w.Write([]byte("title,url,time_added,cursor,tags,status\n\"Hello, world\",https://example.com/hello,1704103200,,go|news,archive\n"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		notes, err := ParsePocketExport(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		require.Equal(t, "[Hello, world](https://example.com/hello)", notes[0].Content)
+		require.Equal(t, []string{"go", "news"}, notes[0].Tags)
+		require.Equal(t, int64(1704103200), notes[0].CreatedTs)
+	})
+
+	t.Run("API", func(t *testing.T) {
+		data := `{"status":1,"list":{` +
+			`"1":{"item_id":"1","given_url":"https://example.com/a?utm=1","resolved_url":"https://example.com/a","given_title":"","resolved_title":"Article",` +
+			`"excerpt":"The first line.\nThe second line.","favorite":"1","status":"0","time_added":"1704103200","tags":{"news":{"item_id":"1","tag":"news"}}},` +
+			`"2":{"item_id":"2","given_url":"https://example.com/b","resolved_url":"","given_title":"Deleted","status":"2","time_added":"1704103200"}}}`
+		notes, err := ParsePocketExport(strings.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		// The deleted item is skipped.
+		require.Len(t, notes, 1)
+		require.True(t, notes[0].Pinned)
+		require.Equal(t, []string{"news"}, notes[0].Tags)
+		require.Equal(t, "[Article](https://example.com/a)\n\n> The first line.\n> The second line.", notes[0].Content)
+
+		data = `{"status":2,"list":[]}`
+		_, err = ParsePocketExport(strings.NewReader(data), int64(len(data)))
+		require.Error(t, err)
+	})
+}
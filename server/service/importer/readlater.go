@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var linkTextReplacer = strings.NewReplacer("[", "\\[", "]", "\\]")
+
+// readLaterItem is a link saved in a read-later app, which is imported as a link memo.
+type readLaterItem struct {
+	URL       string
+	Title     string
+	Excerpt   string
+	Tags      []string
+	CreatedTs int64
+	Pinned    bool
+}
+
+// readLaterNotes returns the notes of the items ordered by their created times.
+// The content of a note is the link to the saved URL with the title as its text, followed by the excerpt as a quote.
+func readLaterNotes(items []*readLaterItem) []*Note {
+	notes := []*Note{}
+	for _, item := range items {
+		link := strings.TrimSpace(item.URL)
+		if !isWebURL(link) {
+			continue
+		}
+		title := strings.Join(strings.Fields(item.Title), " ")
+		parts := []string{link}
+		if title != "" && title != link {
+			parts[0] = "[" + linkTextReplacer.Replace(title) + "](" + link + ")"
+		}
+		if excerpt := strings.TrimSpace(strings.ReplaceAll(item.Excerpt, "\r\n", "\n")); excerpt != "" {
+			lines := strings.Split(excerpt, "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimSpace("> " + strings.TrimSpace(line))
+			}
+			parts = append(parts, strings.Join(lines, "\n"))
+		}
+		notes = append(notes, &Note{
+			UID:       NewUID(),
+			Source:    link,
+			Content:   strings.Join(parts, "\n\n"),
+			Tags:      item.Tags,
+			CreatedTs: item.CreatedTs,
+			UpdatedTs: item.CreatedTs,
+			Pinned:    item.Pinned,
+		})
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedTs < notes[j].CreatedTs
+	})
+	return notes
+}
+
+// readCSVFiles returns the records of the csv file, or of the csv files in the zip file, as the maps of the lowercase names of the columns to the values.
+func readCSVFiles(r io.ReaderAt, size int64) ([]map[string]string, error) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read export file")
+	}
+	if !bytes.Equal(header, []byte("PK\x03\x04")) {
+		data, err := io.ReadAll(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read export file")
+		}
+		return readCSV(data)
+	}
+	zipReader, err := openZipFile(r, size, newZipBudget())
+	if err != nil {
+		return nil, err
+	}
+	files := []*zip.File{}
+	for _, file := range zipReader.File {
+		if !file.FileInfo().IsDir() && !strings.HasPrefix(file.Name, "__MACOSX/") && strings.ToLower(path.Ext(file.Name)) == ".csv" {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	records := []map[string]string{}
+	for _, file := range files {
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileRecords, err := readCSV(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %q", file.Name)
+		}
+		records = append(records, fileRecords...)
+	}
+	return records, nil
+}
+
+func readCSV(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse csv file")
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	columns := rows[0]
+	for i, column := range columns {
+		columns[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+	records := []map[string]string{}
+	for _, row := range rows[1:] {
+		record := map[string]string{}
+		for i, value := range row {
+			if i < len(columns) {
+				record[columns[i]] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// readFirstByte returns the first byte of the file which isn't a space or the byte order mark.
+func readFirstByte(r io.ReaderAt, size int64) (byte, error) {
+	header := make([]byte, 512)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return 0, errors.Wrap(err, "failed to read export file")
+	}
+	header = bytes.TrimLeft(bytes.TrimPrefix(header[:n], []byte("\ufeff")), " \t\r\n")
+	if len(header) == 0 || size == 0 {
+		return 0, nil
+	}
+	return header[0], nil
+}
+
+func isWebURL(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
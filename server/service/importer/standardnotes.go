@@ -0,0 +1,290 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The content types of the items of Standard Notes.
+const (
+	standardNotesTypeNote = "Note"
+	standardNotesTypeTag  = "Tag"
+)
+
+// standardNotesParentTagReference is the type of the reference of a tag to its parent tag.
+const standardNotesParentTagReference = "TagToParentTag"
+
+type standardNotesBackup struct {
+	Items []*standardNotesItem `json:"items"`
+}
+
+type standardNotesItem struct {
+	UUID        string `json:"uuid"`
+	ContentType string `json:"content_type"`
+	// Content is an object in the decrypted backups, and a string in the encrypted backups.
+	Content   json.RawMessage `json:"content"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+	Deleted   bool            `json:"deleted"`
+}
+
+type standardNotesContent struct {
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+	NoteType   string `json:"noteType"`
+	Trashed    bool   `json:"trashed"`
+	Pinned     bool   `json:"pinned"`
+	Archived   bool   `json:"archived"`
+	References []struct {
+		UUID          string `json:"uuid"`
+		ContentType   string `json:"content_type"`
+		ReferenceType string `json:"reference_type"`
+	} `json:"references"`
+	AppData struct {
+		StandardNotes struct {
+			Pinned          bool   `json:"pinned"`
+			Archived        bool   `json:"archived"`
+			ClientUpdatedAt string `json:"client_updated_at"`
+		} `json:"org.standardnotes.sn"`
+	} `json:"appData"`
+}
+
+// ParseStandardNotesBackup parses the decrypted backup file of Standard Notes, or the zip file of it.
+// The tags of Standard Notes become the nested tags of the notes, the links between the notes become the references of the memos.
+// The trashed notes are skipped.
+func ParseStandardNotesBackup(r io.ReaderAt, size int64) ([]*Note, error) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read backup file")
+	}
+	backup := &standardNotesBackup{}
+	if !bytes.Equal(header, []byte("PK\x03\x04")) {
+		if err := json.NewDecoder(io.NewSectionReader(r, 0, size)).Decode(backup); err != nil {
+			return nil, errors.Wrap(err, "failed to decode Standard Notes backup")
+		}
+	} else {
+		zipReader, err := openZipFile(r, size, newZipBudget())
+		if err != nil {
+			return nil, err
+		}
+		// The backup file is the text or JSON file of the items in the zip file.
+		for _, file := range zipReader.File {
+			if extension := path.Ext(file.Name); file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") || extension != ".txt" && extension != ".json" {
+				continue
+			}
+			data, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+			fileBackup := &standardNotesBackup{}
+			if err := json.Unmarshal(data, fileBackup); err != nil {
+				continue
+			}
+			backup.Items = append(backup.Items, fileBackup.Items...)
+		}
+	}
+
+	items := map[string]*standardNotesItem{}
+	contents := map[string]*standardNotesContent{}
+	for _, item := range backup.Items {
+		if item.Deleted || item.ContentType != standardNotesTypeNote && item.ContentType != standardNotesTypeTag {
+			continue
+		}
+		if len(item.Content) > 0 && item.Content[0] == '"' {
+			return nil, errors.New("the Standard Notes backup is encrypted, export a decrypted backup instead")
+		}
+		content := &standardNotesContent{}
+		if err := json.Unmarshal(item.Content, content); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode item %q", item.UUID)
+		}
+		items[item.UUID] = item
+		contents[item.UUID] = content
+	}
+
+	notes := map[string]*Note{}
+	uuids := []string{}
+	for uuid, item := range items {
+		if item.ContentType == standardNotesTypeNote && !contents[uuid].Trashed {
+			notes[uuid] = &Note{UID: NewUID(), Source: uuid}
+			uuids = append(uuids, uuid)
+		}
+	}
+	if len(uuids) == 0 {
+		return nil, errors.New("no notes found in the Standard Notes backup")
+	}
+
+	// The tags reference their notes, and their parent tags.
+	noteTags := map[string][]string{}
+	for uuid, item := range items {
+		if item.ContentType != standardNotesTypeTag {
+			continue
+		}
+		tag := getStandardNotesTag(uuid, items, contents)
+		if tag == "" {
+			continue
+		}
+		for _, reference := range contents[uuid].References {
+			if notes[reference.UUID] != nil {
+				noteTags[reference.UUID] = append(noteTags[reference.UUID], tag)
+			}
+		}
+	}
+
+	for _, uuid := range uuids {
+		item, content, note := items[uuid], contents[uuid], notes[uuid]
+		note.CreatedTs = parseStandardNotesTime(item.CreatedAt)
+		note.UpdatedTs = parseStandardNotesTime(content.AppData.StandardNotes.ClientUpdatedAt)
+		if note.UpdatedTs == 0 {
+			note.UpdatedTs = parseStandardNotesTime(item.UpdatedAt)
+		}
+		note.Pinned = content.Pinned || content.AppData.StandardNotes.Pinned
+		note.Archived = content.Archived || content.AppData.StandardNotes.Archived
+		tags := noteTags[uuid]
+		sort.Strings(tags)
+		note.Tags = tags
+
+		parts := []string{}
+		if title := strings.TrimSpace(content.Title); title != "" {
+			parts = append(parts, "# "+title)
+		}
+		text := strings.ReplaceAll(content.Text, "\r\n", "\n")
+		// The super notes are the JSON of the editor, their texts are extracted.
+		if content.NoteType == "super" {
+			text = convertStandardNotesSuperNote(text)
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+		references := []string{}
+		for _, reference := range content.References {
+			if linkedNote := notes[reference.UUID]; linkedNote != nil && reference.UUID != uuid {
+				references = append(references, FormatMemoReference(linkedNote.UID, ""))
+			}
+		}
+		if len(references) > 0 {
+			parts = append(parts, strings.Join(references, "\n"))
+		}
+		note.Content = strings.Join(parts, "\n\n")
+	}
+
+	// The notes are imported in the order of their creation.
+	sort.Slice(uuids, func(i, j int) bool {
+		if notes[uuids[i]].CreatedTs != notes[uuids[j]].CreatedTs {
+			return notes[uuids[i]].CreatedTs < notes[uuids[j]].CreatedTs
+		}
+		return uuids[i] < uuids[j]
+	})
+	result := []*Note{}
+	for _, uuid := range uuids {
+		result = append(result, notes[uuid])
+	}
+	return result, nil
+}
+
+// getStandardNotesTag returns the path of the tag as a nested tag, e.g. work/projects.
+func getStandardNotesTag(uuid string, items map[string]*standardNotesItem, contents map[string]*standardNotesContent) string {
+	names := []string{}
+	// The depth is limited in case the parents of the tags are a loop.
+	for depth := 0; uuid != "" && depth < 32; depth++ {
+		item := items[uuid]
+		if item == nil || item.ContentType != standardNotesTypeTag {
+			break
+		}
+		content := contents[uuid]
+		if name := strings.ReplaceAll(strings.TrimSpace(content.Title), "/", "-"); name != "" {
+			names = append([]string{name}, names...)
+		}
+		uuid = ""
+		for _, reference := range content.References {
+			if reference.ReferenceType == standardNotesParentTagReference {
+				uuid = reference.UUID
+			}
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+func parseStandardNotesTime(value string) int64 {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil && t.Unix() > 0 {
+		return t.Unix()
+	}
+	return 0
+}
+
+type standardNotesSuperNode struct {
+	Type     string                    `json:"type"`
+	Text     string                    `json:"text"`
+	Tag      string                    `json:"tag"`
+	ListType string                    `json:"listType"`
+	Checked  bool                      `json:"checked"`
+	Children []*standardNotesSuperNode `json:"children"`
+}
+
+// convertStandardNotesSuperNote returns the Markdown of the blocks of a super note, or the text itself if it isn't the JSON of the editor.
+func convertStandardNotesSuperNote(text string) string {
+	document := struct {
+		Root *standardNotesSuperNode `json:"root"`
+	}{}
+	if err := json.Unmarshal([]byte(text), &document); err != nil || document.Root == nil {
+		return text
+	}
+	blocks := []string{}
+	for _, block := range document.Root.Children {
+		switch block.Type {
+		case "heading":
+			level, err := strconv.Atoi(strings.TrimPrefix(block.Tag, "h"))
+			if err != nil || level < 1 || level > 6 {
+				level = 1
+			}
+			blocks = append(blocks, strings.Repeat("#", level)+" "+getStandardNotesSuperText(block))
+		case "list":
+			items := []string{}
+			for i, item := range block.Children {
+				switch block.ListType {
+				case "check":
+					if item.Checked {
+						items = append(items, "- [x] "+getStandardNotesSuperText(item))
+					} else {
+						items = append(items, "- [ ] "+getStandardNotesSuperText(item))
+					}
+				case "number":
+					items = append(items, strconv.Itoa(i+1)+". "+getStandardNotesSuperText(item))
+				default:
+					items = append(items, "- "+getStandardNotesSuperText(item))
+				}
+			}
+			blocks = append(blocks, strings.Join(items, "\n"))
+		case "quote":
+			blocks = append(blocks, "> "+getStandardNotesSuperText(block))
+		case "code":
+			blocks = append(blocks, "```\n"+getStandardNotesSuperText(block)+"\n```")
+		case "horizontalrule":
+			blocks = append(blocks, "---")
+		default:
+			if text := getStandardNotesSuperText(block); text != "" {
+				blocks = append(blocks, text)
+			}
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// getStandardNotesSuperText returns the text of the node and its children, the line breaks are kept.
+func getStandardNotesSuperText(n *standardNotesSuperNode) string {
+	if n.Type == "linebreak" {
+		return "\n"
+	}
+	text := n.Text
+	for _, child := range n.Children {
+		text += getStandardNotesSuperText(child)
+	}
+	return text
+}
@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testStandardNotesBackup = `{
+  "version": "004",
+  "items": [
+    {
+      "uuid": "note-1",
+      "content_type": "Note",
+      "created_at": "2024-01-01T10:00:00.000Z",
+      "updated_at": "2024-01-05T10:00:00.000Z",
+      "content": {
+        "title": "Plan",
+        "text": "Write the plan",
+        "references": [{"uuid": "note-2", "content_type": "Note"}],
+        "appData": {"org.standardnotes.sn": {"pinned": true, "client_updated_at": "2024-01-03T10:00:00.000Z"}}
+      }
+    },
+    {
+      "uuid": "note-2",
+      "content_type": "Note",
+      "created_at": "2024-01-02T10:00:00.000Z",
+      "updated_at": "2024-01-02T10:00:00.000Z",
+      "content": {
+        "title": "",
+        "noteType": "super",
+        "archived": true,
+        "text": "{\"root\":{\"children\":[{\"type\":\"heading\",\"tag\":\"h2\",\"children\":[{\"type\":\"text\",\"text\":\"Tasks\"}]},{\"type\":\"list\",\"listType\":\"check\",\"children\":[{\"type\":\"listitem\",\"checked\":true,\"children\":[{\"type\":\"text\",\"text\":\"Call\"}]}]}]}}",
+        "references": []
+      }
+    },
+    {
+      "uuid": "note-3",
+      "content_type": "Note",
+      "created_at": "2024-01-02T10:00:00.000Z",
+      "content": {"title": "Old", "text": "Gone", "trashed": true, "references": []}
+    },
+    {
+      "uuid": "tag-1",
+      "content_type": "Tag",
+      "content": {"title": "work", "references": [{"uuid": "note-1", "content_type": "Note"}]}
+    },
+    {
+      "uuid": "tag-2",
+      "content_type": "Tag",
+      "content": {"title": "projects", "references": [{"uuid": "tag-1", "content_type": "Tag", "reference_type": "TagToParentTag"}, {"uuid": "note-1", "content_type": "Note"}, {"uuid": "note-2", "content_type": "Note"}]}
+    },
+    {
+      "uuid": "tag-3",
+      "content_type": "Tag",
+      "deleted": true,
+      "content": {"title": "deleted", "references": [{"uuid": "note-1", "content_type": "Note"}]}
+    }
+  ]
+}`
+
+func TestParseStandardNotesBackup(t *testing.T) {
+	notes, err := ParseStandardNotesBackup(bytes.NewReader([]byte(testStandardNotesBackup)), int64(len(testStandardNotesBackup)))
+	require.NoError(t, err)
+	// The trashed note is skipped, the notes are ordered by their created times.
+	require.Len(t, notes, 2)
+	plan, tasks := notes[0], notes[1]
+
+	require.Equal(t, int64(1704103200), plan.CreatedTs)
+	require.Equal(t, int64(1704276000), plan.UpdatedTs)
+	require.True(t, plan.Pinned)
+	require.Equal(t, []string{"work", "work/projects"}, plan.Tags)
+	require.Equal(t, "# Plan\n\nWrite the plan\n\n"+FormatMemoReference(tasks.UID, ""), plan.Content)
+
+	require.True(t, tasks.Archived)
+	require.Equal(t, int64(1704189600), tasks.UpdatedTs)
+	require.Equal(t, []string{"work/projects"}, tasks.Tags)
+	require.Equal(t, "## Tasks\n\n- [x] Call", tasks.Content)
+
+	// The encrypted backups can't be imported.
+	encrypted := `{"items": [{"uuid": "note-1", "content_type": "Note", "content": "004:abc"}]}`
+	_, err = ParseStandardNotesBackup(bytes.NewReader([]byte(encrypted)), int64(len(encrypted)))
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"archive/zip"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxDecompressedSize is the maximum total size of the files of a zip export once decompressed,
+	// the nested zip files count with their files.
+	MaxDecompressedSize = 1 << 30
+	// maxZipDepth is the maximum depth of the nested zip files, the parts of a Notion export are one level deep.
+	maxZipDepth = 3
+)
+
+// openZipFile returns the reader of the zip file, whose files mustn't be larger than the budget once decompressed.
+// The declared sizes of the files are taken from the budget, and readZipFile doesn't read more than them.
+func openZipFile(r io.ReaderAt, size int64, budget *uint64) (*zip.Reader, error) {
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read zip file")
+	}
+	for _, file := range zipReader.File {
+		if file.UncompressedSize64 > *budget {
+			return nil, errors.Errorf("zip file is larger than %d bytes once decompressed", MaxDecompressedSize)
+		}
+		*budget -= file.UncompressedSize64
+	}
+	return zipReader, nil
+}
+
+// newZipBudget returns the budget of the decompressed files of an export.
+func newZipBudget() *uint64 {
+	budget := uint64(MaxDecompressedSize)
+	return &budget
+}
+
+// readZipFile reads the file of the zip file, up to its declared size.
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %q", file.Name)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, int64(file.UncompressedSize64)+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", file.Name)
+	}
+	if uint64(len(data)) > file.UncompressedSize64 {
+		return nil, errors.Errorf("%q is larger than its declared size", file.Name)
+	}
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenZipFile(t *testing.T) {
+	export := newTestZip(t, map[string]string{"a.md": "12345", "b.md": "67890"})
+	tests := []struct {
+		budget uint64
+		left   uint64
+		ok     bool
+	}{
+		{budget: 11, left: 1, ok: true},
+		{budget: 10, left: 0, ok: true},
+		{budget: 9, ok: false},
+	}
+	for _, test := range tests {
+		budget := test.budget
+		_, err := openZipFile(bytes.NewReader(export.Bytes()), int64(export.Len()), &budget)
+		if !test.ok {
+			require.Error(t, err, test.budget)
+			continue
+		}
+		require.NoError(t, err, test.budget)
+		require.Equal(t, test.left, budget, test.budget)
+	}
+}
+
+// TestReadZipFile tests the files aren't read beyond their declared sizes.
+func TestReadZipFile(t *testing.T) {
+	content := []byte("larger than declared")
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	w, err := writer.CreateRaw(&zip.FileHeader{
+		Name:               "note.md",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(content),
+		CompressedSize64:   uint64(len(content)),
+		UncompressedSize64: 4,
+	})
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	zipReader, err := openZipFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), newZipBudget())
+	require.NoError(t, err)
+	_, err = readZipFile(zipReader.File[0])
+	require.Error(t, err)
+}
+
+func TestParseNotionExportNestedZipFiles(t *testing.T) {
+	export := newTestZip(t, map[string]string{"Page 0123456789abcdef0123456789abcdef.md": "# Page"})
+	for depth := 1; depth <= maxZipDepth+1; depth++ {
+		_, err := ParseNotionExport(bytes.NewReader(export.Bytes()), int64(export.Len()))
+		if depth <= maxZipDepth {
+			require.NoError(t, err, depth)
+		} else {
+			require.Error(t, err, depth)
+		}
+		export = newTestZip(t, map[string]string{"Export-Part-1.zip": export.String()})
+	}
+}
@@ -0,0 +1,266 @@
+// Package jobqueue runs the background jobs queued in the store, so they survive the restarts of the server.
+// The failed jobs are retried with exponential backoff, and are dead after their attempts, until they are requeued.
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/telemetry"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// runInterval is the interval of checking for due jobs.
+	runInterval = 5 * time.Second
+	// runBatchSize is the max number of jobs attempted in a run.
+	runBatchSize = 20
+	// DefaultMaxAttempts is the number of attempts after which a job is dead, unless the job is queued with another.
+	DefaultMaxAttempts = 8
+	// initialRetryDelay is the delay before the first retry, which is doubled for every following retry.
+	initialRetryDelay = 30 * time.Second
+	// attemptTimeout is the max duration of an attempt. The jobs running for longer, e.g. when the server stopped
+	// during the attempt, are retried.
+	attemptTimeout = 10 * time.Minute
+	// succeededJobRetention is the duration the succeeded jobs are kept for the inspection.
+	succeededJobRetention = 7 * 24 * time.Hour
+)
+
+// Handler runs a job with its JSON encoded payload. The job is retried if the handler returns an error,
+// unless the error is permanent.
+type Handler func(ctx context.Context, payload []byte) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error of a handler as permanent, e.g. for a malformed payload, so the job is dead without retrying.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// EnqueueOption is an option of a queued job.
+type EnqueueOption func(job *store.Job)
+
+// WithDelay delays the first attempt of the job.
+func WithDelay(delay time.Duration) EnqueueOption {
+	return func(job *store.Job) {
+		job.NextAttemptTs = time.Now().Add(delay).Unix()
+	}
+}
+
+// WithMaxAttempts sets the number of attempts after which the job is dead.
+func WithMaxAttempts(maxAttempts int32) EnqueueOption {
+	return func(job *store.Job) {
+		job.MaxAttempts = maxAttempts
+	}
+}
+
+// Enqueue queues the job of the kind with the payload encoded in JSON.
+// The job is attempted by the queue, so it doesn't block the caller.
+func Enqueue(ctx context.Context, s *store.Store, kind string, payload any, options ...EnqueueOption) (*store.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode job payload")
+	}
+	job := &store.Job{
+		Kind:          kind,
+		Payload:       string(data),
+		Status:        store.JobPending,
+		MaxAttempts:   DefaultMaxAttempts,
+		NextAttemptTs: time.Now().Unix(),
+	}
+	for _, option := range options {
+		option(job)
+	}
+	job, err = s.CreateJob(ctx, job)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create job")
+	}
+	return job, nil
+}
+
+// Requeue queues the job again with all its attempts, e.g. the dead jobs after their cause is fixed.
+func Requeue(ctx context.Context, s *store.Store, jobID int32) error {
+	now := time.Now().Unix()
+	status, attempts, lastError := store.JobPending, int32(0), ""
+	return s.UpdateJob(ctx, &store.UpdateJob{
+		ID:            jobID,
+		UpdatedTs:     &now,
+		Status:        &status,
+		Attempts:      &attempts,
+		NextAttemptTs: &now,
+		LastError:     &lastError,
+	})
+}
+
+// Queue attempts the due jobs with the handlers of their kinds.
+type Queue struct {
+	Store *store.Store
+
+	mutex    sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewQueue(store *store.Store) *Queue {
+	return &Queue{
+		Store:    store,
+		handlers: map[string]Handler{},
+	}
+}
+
+// Register registers the handler of the jobs of the kind. The handlers must be registered before the queue is started.
+func (q *Queue) Register(kind string, handler Handler) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.handlers[kind] = handler
+}
+
+func (q *Queue) getHandler(kind string) Handler {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return q.handlers[kind]
+}
+
+func (q *Queue) Start(ctx context.Context) {
+	ticker := time.NewTicker(runInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// The replicas sharing the store take turns, so a job isn't attempted by two of them at once.
+		if !q.Store.ClaimJobRun(ctx, "job_queue", runInterval) {
+			continue
+		}
+		if err := telemetry.TraceJob(ctx, "job_queue", q.Run); err != nil {
+			slog.Warn("Failed to run queued jobs", slog.Any("err", err))
+		}
+	}
+}
+
+// Run attempts the jobs which are due, after recovering the interrupted ones.
+func (q *Queue) Run(ctx context.Context) error {
+	if err := q.recoverInterrupted(ctx); err != nil {
+		return err
+	}
+	pendingStatus, now, limit := store.JobPending, time.Now().Unix(), runBatchSize
+	jobs, err := q.Store.ListJobs(ctx, &store.FindJob{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &now,
+		Limit:               &limit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list jobs")
+	}
+	for _, job := range jobs {
+		if err := q.attempt(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	if err := q.Store.DeleteJobs(ctx, &store.DeleteJob{
+		Status:          store.JobSucceeded,
+		UpdatedTsBefore: time.Now().Add(-succeededJobRetention).Unix(),
+	}); err != nil {
+		return errors.Wrap(err, "failed to delete succeeded jobs")
+	}
+	return nil
+}
+
+// recoverInterrupted fails the attempts of the jobs which have been running for longer than an attempt,
+// e.g. when the server stopped during them, so they are retried.
+func (q *Queue) recoverInterrupted(ctx context.Context) error {
+	runningStatus, before := store.JobRunning, time.Now().Add(-attemptTimeout).Unix()
+	jobs, err := q.Store.ListJobs(ctx, &store.FindJob{
+		Status:          &runningStatus,
+		UpdatedTsBefore: &before,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list running jobs")
+	}
+	for _, job := range jobs {
+		if err := q.finish(ctx, job, errors.New("attempt interrupted")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *Queue) attempt(ctx context.Context, job *store.Job) error {
+	now := time.Now().Unix()
+	runningStatus, attempts := store.JobRunning, job.Attempts+1
+	if err := q.Store.UpdateJob(ctx, &store.UpdateJob{
+		ID:        job.ID,
+		UpdatedTs: &now,
+		Status:    &runningStatus,
+		Attempts:  &attempts,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update job")
+	}
+	job.Attempts = attempts
+
+	var err error
+	if handler := q.getHandler(job.Kind); handler == nil {
+		err = Permanent(errors.Errorf("no handler of job kind %q", job.Kind))
+	} else {
+		attemptCtx, cancel := context.WithTimeout(ctx, attemptTimeout)
+		err = telemetry.TraceJob(attemptCtx, "job_queue."+job.Kind, func(ctx context.Context) error {
+			return handler(ctx, []byte(job.Payload))
+		})
+		cancel()
+	}
+	if err != nil {
+		slog.Warn("Failed to run job", slog.Int("job", int(job.ID)), slog.String("kind", job.Kind), slog.Any("err", err))
+	}
+	return q.finish(ctx, job, err)
+}
+
+// finish updates the status of the attempted job with the error of the attempt.
+func (q *Queue) finish(ctx context.Context, job *store.Job, attemptErr error) error {
+	now := time.Now().Unix()
+	status, lastError := store.JobSucceeded, ""
+	update := &store.UpdateJob{
+		ID:        job.ID,
+		UpdatedTs: &now,
+		Status:    &status,
+		LastError: &lastError,
+	}
+	if attemptErr != nil {
+		lastError = attemptErr.Error()
+		var permanent *permanentError
+		if job.Attempts >= job.MaxAttempts || errors.As(attemptErr, &permanent) {
+			status = store.JobDead
+		} else {
+			status = store.JobPending
+			nextAttemptTs := time.Now().Add(getRetryDelay(job.Attempts)).Unix()
+			update.NextAttemptTs = &nextAttemptTs
+		}
+	}
+	if err := q.Store.UpdateJob(ctx, update); err != nil {
+		return errors.Wrap(err, "failed to update job")
+	}
+	return nil
+}
+
+// getRetryDelay returns the delay before the next attempt after the given number of attempts.
+func getRetryDelay(attempts int32) time.Duration {
+	return initialRetryDelay << (attempts - 1)
+}
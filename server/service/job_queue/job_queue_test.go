@@ -0,0 +1,97 @@
+package jobqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	queue := NewQueue(ts)
+	payloads := []string{}
+	queue.Register("succeed", func(_ context.Context, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	})
+	queue.Register("fail", func(context.Context, []byte) error {
+		return errors.New("unavailable")
+	})
+	queue.Register("malformed", func(context.Context, []byte) error {
+		return Permanent(errors.New("malformed payload"))
+	})
+
+	succeeded, err := Enqueue(ctx, ts, "succeed", map[string]int{"memoId": 1})
+	require.NoError(t, err)
+	failed, err := Enqueue(ctx, ts, "fail", nil)
+	require.NoError(t, err)
+	lastAttempt, err := Enqueue(ctx, ts, "fail", nil, WithMaxAttempts(1))
+	require.NoError(t, err)
+	malformed, err := Enqueue(ctx, ts, "malformed", nil)
+	require.NoError(t, err)
+	unknown, err := Enqueue(ctx, ts, "unknown", nil)
+	require.NoError(t, err)
+	delayed, err := Enqueue(ctx, ts, "succeed", nil, WithDelay(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, queue.Run(ctx))
+
+	require.Equal(t, []string{`{"memoId":1}`}, payloads)
+	job := getJob(ctx, t, ts, succeeded.ID)
+	require.Equal(t, store.JobSucceeded, job.Status)
+	require.Equal(t, int32(1), job.Attempts)
+	// The failed job is retried later.
+	job = getJob(ctx, t, ts, failed.ID)
+	require.Equal(t, store.JobPending, job.Status)
+	require.Equal(t, "unavailable", job.LastError)
+	require.Greater(t, job.NextAttemptTs, time.Now().Unix())
+	for _, id := range []int32{lastAttempt.ID, malformed.ID, unknown.ID} {
+		require.Equal(t, store.JobDead, getJob(ctx, t, ts, id).Status)
+	}
+	require.Equal(t, store.JobPending, getJob(ctx, t, ts, delayed.ID).Status)
+	require.Equal(t, int32(0), getJob(ctx, t, ts, delayed.ID).Attempts)
+
+	require.NoError(t, Requeue(ctx, ts, malformed.ID))
+	job = getJob(ctx, t, ts, malformed.ID)
+	require.Equal(t, store.JobPending, job.Status)
+	require.Equal(t, int32(0), job.Attempts)
+	require.Empty(t, job.LastError)
+}
+
+func TestQueueRecoverInterrupted(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	queue := NewQueue(ts)
+
+	job, err := Enqueue(ctx, ts, "succeed", nil, WithDelay(time.Hour))
+	require.NoError(t, err)
+	// The server stopped during the attempt.
+	runningStatus, attempts, updatedTs := store.JobRunning, int32(1), time.Now().Add(-time.Hour).Unix()
+	require.NoError(t, ts.UpdateJob(ctx, &store.UpdateJob{ID: job.ID, Status: &runningStatus, Attempts: &attempts, UpdatedTs: &updatedTs}))
+	require.NoError(t, queue.Run(ctx))
+
+	job = getJob(ctx, t, ts, job.ID)
+	require.Equal(t, store.JobPending, job.Status)
+	require.Equal(t, "attempt interrupted", job.LastError)
+}
+
+func TestGetRetryDelay(t *testing.T) {
+	require.Equal(t, 30*time.Second, getRetryDelay(1))
+	require.Equal(t, time.Minute, getRetryDelay(2))
+	require.Equal(t, 32*time.Minute, getRetryDelay(DefaultMaxAttempts-1))
+}
+
+func getJob(ctx context.Context, t *testing.T, ts *store.Store, id int32) *store.Job {
+	job, err := ts.GetJob(ctx, &store.FindJob{ID: &id})
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	return job
+}
@@ -0,0 +1,170 @@
+// Package mqttpublisher publishes the memo lifecycle events to an MQTT broker, in flat JSON payloads
+// which the automations of Home Assistant read with templates, e.g. to add the tasks of the memos tagged #shopping to a list.
+package mqttpublisher
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yourselfhosted/gomark/ast"
+	"github.com/yourselfhosted/gomark/restore"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/plugin/mqtt"
+	apiv2 "github.com/usememos/memos/server/route/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+// publishTimeout is the timeout of publishing the messages of an event, the events published while the broker is unreachable are dropped.
+const publishTimeout = 5 * time.Second
+
+// topicReplacer replaces the wildcards of MQTT, which aren't allowed in the topics of the messages.
+var topicReplacer = strings.NewReplacer("+", "_", "#", "_")
+
+// Task is a task of the task lists of a memo.
+type Task struct {
+	Text      string `json:"text"`
+	Completed bool   `json:"completed"`
+}
+
+// Message is the payload of a memo event. The content, tags and tasks are empty for the deleted memos.
+type Message struct {
+	// Event is the type of the event, e.g. memo.created.
+	Event string `json:"event"`
+	// Action is the last part of the type of the event, e.g. created.
+	Action     string           `json:"action"`
+	ID         int32            `json:"id"`
+	UID        string           `json:"uid,omitempty"`
+	Creator    string           `json:"creator,omitempty"`
+	Visibility store.Visibility `json:"visibility,omitempty"`
+	Pinned     bool             `json:"pinned"`
+	Content    string           `json:"content"`
+	Tags       []string         `json:"tags"`
+	Tasks      []*Task          `json:"tasks"`
+	Timestamp  int64            `json:"timestamp"`
+}
+
+type Publisher struct {
+	Store       *store.Store
+	EventBroker *event.Broker
+	Client      *mqtt.Client
+	// Topic is the prefix of the topics of the messages.
+	Topic string
+}
+
+func NewPublisher(store *store.Store, eventBroker *event.Broker, client *mqtt.Client, topic string) *Publisher {
+	return &Publisher{
+		Store:       store,
+		EventBroker: eventBroker,
+		Client:      client,
+		Topic:       strings.TrimSuffix(topic, "/"),
+	}
+}
+
+// Start publishes the memo events until the context is done. The message of an event is published to {topic}/memo/{action},
+// and to {topic}/tag/{tag}/{action} for each tag of the memo, so the automations subscribe to the tags they react to.
+func (p *Publisher) Start(ctx context.Context) {
+	subscription := p.EventBroker.Subscribe()
+	defer p.EventBroker.Unsubscribe(subscription)
+	defer p.Client.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if e.Type == event.MemoCreated || e.Type == event.MemoUpdated || e.Type == event.MemoDeleted {
+				p.publish(ctx, e)
+			}
+		}
+	}
+}
+
+func (p *Publisher) publish(ctx context.Context, e *event.Event) {
+	message := &Message{
+		Event:     string(e.Type),
+		Action:    strings.TrimPrefix(string(e.Type), "memo."),
+		ID:        e.MemoID,
+		Tags:      []string{},
+		Tasks:     []*Task{},
+		Timestamp: e.CreatedTs,
+	}
+	if e.Type != event.MemoDeleted {
+		memo, err := p.Store.GetMemo(ctx, &store.FindMemo{ID: &e.MemoID})
+		if err != nil {
+			slog.Warn("Failed to find memo of event", slog.Any("err", err))
+			return
+		}
+		if memo == nil {
+			return
+		}
+		creator, err := p.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+		if err != nil {
+			slog.Warn("Failed to find creator of memo", slog.Any("err", err))
+			return
+		}
+		message = NewMessage(e, memo, creator)
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		slog.Warn("Failed to marshal memo event", slog.Any("err", err))
+		return
+	}
+
+	topics := []string{p.Topic + "/memo/" + message.Action}
+	for _, tag := range message.Tags {
+		topics = append(topics, p.Topic+"/tag/"+topicReplacer.Replace(tag)+"/"+message.Action)
+	}
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	for _, topic := range topics {
+		if err := p.Client.Publish(ctx, topic, data, false); err != nil {
+			slog.Warn("Failed to publish memo event to MQTT", slog.String("topic", topic), slog.Any("err", err))
+			return
+		}
+	}
+}
+
+// NewMessage returns the message of the event about the memo, the tags and the tasks are parsed from its content.
+func NewMessage(e *event.Event, memo *store.Memo, creator *store.User) *Message {
+	message := &Message{
+		Event:      string(e.Type),
+		Action:     strings.TrimPrefix(string(e.Type), "memo."),
+		ID:         memo.ID,
+		UID:        memo.UID,
+		Visibility: memo.Visibility,
+		Pinned:     memo.Pinned,
+		Content:    memo.Content,
+		Tags:       []string{},
+		Tasks:      []*Task{},
+		Timestamp:  e.CreatedTs,
+	}
+	if creator != nil {
+		message.Creator = creator.Username
+	}
+	nodes, err := markdown.Parse(memo.Content)
+	if err != nil {
+		return message
+	}
+	apiv2.TraverseASTNodes(nodes, func(node ast.Node) {
+		switch n := node.(type) {
+		case *ast.Tag:
+			if !slices.Contains(message.Tags, n.Content) {
+				message.Tags = append(message.Tags, n.Content)
+			}
+		case *ast.TaskList:
+			message.Tasks = append(message.Tasks, &Task{
+				Text:      strings.TrimSpace(restore.Restore(n.Children)),
+				Completed: n.Complete,
+			})
+		}
+	})
+	return message
+}
@@ -0,0 +1,27 @@
+package mqttpublisher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/store"
+)
+
+func TestNewMessage(t *testing.T) {
+	memo := &store.Memo{
+		ID:         1,
+		UID:        "groceries",
+		CreatorID:  2,
+		Content:    "Groceries #shopping #home/kitchen\n\n- [ ] milk\n- [x] **eggs**",
+		Visibility: store.Private,
+	}
+	e := event.NewMemoEvent(event.MemoUpdated, memo)
+	message := NewMessage(e, memo, &store.User{ID: 2, Username: "bob"})
+	require.Equal(t, "memo.updated", message.Event)
+	require.Equal(t, "updated", message.Action)
+	require.Equal(t, "bob", message.Creator)
+	require.Equal(t, []string{"shopping", "home/kitchen"}, message.Tags)
+	require.Equal(t, []*Task{{Text: "milk"}, {Text: "**eggs**", Completed: true}}, message.Tasks)
+}
@@ -0,0 +1,704 @@
+// Package notifier notifies the users of the mentions and the due reminders in their inboxes,
+// emails them the notifications and the digests they opted in to, once the workspace SMTP server is configured,
+// and pushes the inbox messages to the ntfy or Gotify server they configured and to their browsers with Web Push.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/yourselfhosted/gomark/ast"
+
+	"github.com/usememos/memos/internal/event"
+	"github.com/usememos/memos/internal/i18n"
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/internal/workerpool"
+	"github.com/usememos/memos/plugin/mail"
+	"github.com/usememos/memos/plugin/push"
+	"github.com/usememos/memos/plugin/webpush"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/route/api/v1"
+	apiv2 "github.com/usememos/memos/server/route/api/v2"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// reminderInterval is the interval of checking for due reminders.
+	reminderInterval = time.Minute
+	// reminderBatchSize is the max number of reminders delivered in a check.
+	reminderBatchSize = 20
+	// maxDigestMemos is the max number of memos listed in a digest.
+	maxDigestMemos = 50
+	// maxSnippetLength is the number of the characters of the memo content included in the emails.
+	maxSnippetLength = 200
+	// emailDestination is the destination of the emails on the worker pool, they're all sent to the SMTP server of the workspace.
+	emailDestination = "smtp"
+)
+
+// mentionRegexp matches the mentions of the usernames, e.g. `@alice`, but not the email addresses.
+var mentionRegexp = regexp.MustCompile(`(?:^|[^\w@/])@([a-zA-Z0-9][a-zA-Z0-9-]{1,30}[a-zA-Z0-9])`)
+
+// Notifier delivers the emails and the push messages on the worker pool, so a slow SMTP or push server
+// doesn't hold up the handling of the events.
+type Notifier struct {
+	Store       *store.Store
+	EventBroker *event.Broker
+	Pool        *workerpool.Pool
+}
+
+func NewNotifier(store *store.Store, eventBroker *event.Broker, pool *workerpool.Pool) *Notifier {
+	return &Notifier{
+		Store:       store,
+		EventBroker: eventBroker,
+		Pool:        pool,
+	}
+}
+
+func (n *Notifier) Start(ctx context.Context) {
+	subscription := n.EventBroker.Subscribe()
+	defer n.EventBroker.Unsubscribe(subscription)
+	reminderTicker := time.NewTicker(reminderInterval)
+	defer reminderTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if err := n.handleEvent(ctx, e); err != nil {
+				slog.Warn("Failed to notify event", slog.String("type", string(e.Type)), slog.Any("err", err))
+			}
+		case <-reminderTicker.C:
+			if err := n.DeliverReminders(ctx); err != nil {
+				slog.Warn("Failed to deliver memo reminders", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (n *Notifier) handleEvent(ctx context.Context, e *event.Event) error {
+	switch e.Type {
+	case event.MemoCreated:
+		return n.notifyMentions(ctx, e.MemoID)
+	case event.InboxCreated:
+		return n.notifyInbox(ctx, e.InboxID)
+	case event.ReactionCreated:
+		return n.emailReaction(ctx, e.ReactionID, e.MemoID)
+	default:
+		return nil
+	}
+}
+
+// notifyMentions sends the users mentioned in the memo an inbox message.
+// A mention of a group handle, e.g. `@design`, notifies the members of the group, the user with the username goes first.
+// The mentioned users who can't see the memo are skipped, so the mentions in the private memos are ignored.
+func (n *Notifier) notifyMentions(ctx context.Context, memoID int32) error {
+	memo, err := n.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.Visibility == store.Private {
+		return err
+	}
+	usernames, err := getMentionedUsernames(memo.Content)
+	if err != nil {
+		return err
+	}
+	receiverIDs := []int32{}
+	for _, username := range usernames {
+		user, err := n.Store.GetUser(ctx, &store.FindUser{Username: &username})
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			receiverIDs = append(receiverIDs, user.ID)
+			continue
+		}
+		group, err := n.Store.GetUserGroup(ctx, &store.FindUserGroup{Name: &username})
+		if err != nil {
+			return err
+		}
+		if group == nil {
+			continue
+		}
+		members, err := n.Store.ListUserGroupMembers(ctx, &store.FindUserGroupMember{GroupID: &group.ID})
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			receiverIDs = append(receiverIDs, member.UserID)
+		}
+	}
+
+	notified := map[int32]bool{}
+	for _, receiverID := range receiverIDs {
+		if notified[receiverID] || receiverID == memo.CreatorID {
+			continue
+		}
+		notified[receiverID] = true
+		user, err := n.Store.GetUser(ctx, &store.FindUser{ID: &receiverID})
+		if err != nil {
+			return err
+		}
+		if user == nil || user.RowStatus == store.Archived {
+			continue
+		}
+		visible, err := n.Store.IsMemoVisibleTo(ctx, memo, user.ID)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			continue
+		}
+		activity, err := n.Store.CreateActivity(ctx, &store.Activity{
+			CreatorID: memo.CreatorID,
+			Type:      store.ActivityTypeMemoMention,
+			Level:     store.ActivityLevelInfo,
+			Payload: &storepb.ActivityPayload{
+				MemoMention: &storepb.ActivityMemoMentionPayload{MemoId: memo.ID},
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create activity")
+		}
+		inbox, err := n.Store.CreateInbox(ctx, &store.Inbox{
+			SenderID:   memo.CreatorID,
+			ReceiverID: user.ID,
+			Status:     store.UNREAD,
+			Message: &storepb.InboxMessage{
+				Type:       storepb.InboxMessage_TYPE_MEMO_MENTION,
+				ActivityId: &activity.ID,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create inbox")
+		}
+		n.EventBroker.Publish(event.NewInboxEvent(inbox))
+	}
+	return nil
+}
+
+// getMentionedUsernames returns the usernames mentioned in the text of the content, the code isn't searched.
+func getMentionedUsernames(content string) ([]string, error) {
+	nodes, err := markdown.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	usernames := []string{}
+	seen := map[string]bool{}
+	apiv2.TraverseASTNodes(nodes, func(node ast.Node) {
+		textNode, ok := node.(*ast.Text)
+		if !ok {
+			return
+		}
+		for _, match := range mentionRegexp.FindAllStringSubmatch(textNode.Content, -1) {
+			if username := match[1]; !seen[username] {
+				seen[username] = true
+				usernames = append(usernames, username)
+			}
+		}
+	})
+	return usernames, nil
+}
+
+// DeliverReminders sends the users an inbox message for each of their due reminders.
+func (n *Notifier) DeliverReminders(ctx context.Context) error {
+	now, limit := time.Now().Unix(), reminderBatchSize
+	reminders, err := n.Store.ListMemoReminders(ctx, &store.FindMemoReminder{
+		Pending:        true,
+		RemindTsBefore: &now,
+		Limit:          &limit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memo reminders")
+	}
+	for _, reminder := range reminders {
+		memo, err := n.Store.GetMemo(ctx, &store.FindMemo{ID: &reminder.MemoID})
+		if err != nil {
+			return errors.Wrap(err, "failed to get memo")
+		}
+		// The reminder is dropped if the user can no longer see the memo.
+		visible := false
+		if memo != nil {
+			if visible, err = n.Store.IsMemoVisibleTo(ctx, memo, reminder.CreatorID); err != nil {
+				return errors.Wrap(err, "failed to check memo visibility")
+			}
+		}
+		if !visible {
+			if err := n.Store.DeleteMemoReminder(ctx, &store.DeleteMemoReminder{CreatorID: reminder.CreatorID, MemoID: reminder.MemoID}); err != nil {
+				return errors.Wrap(err, "failed to delete memo reminder")
+			}
+			continue
+		}
+		activity, err := n.Store.CreateActivity(ctx, &store.Activity{
+			CreatorID: reminder.CreatorID,
+			Type:      store.ActivityTypeMemoReminder,
+			Level:     store.ActivityLevelInfo,
+			Payload: &storepb.ActivityPayload{
+				MemoReminder: &storepb.ActivityMemoReminderPayload{MemoId: memo.ID, ReminderId: reminder.ID},
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create activity")
+		}
+		inbox, err := n.Store.CreateInbox(ctx, &store.Inbox{
+			SenderID:   reminder.CreatorID,
+			ReceiverID: reminder.CreatorID,
+			Status:     store.UNREAD,
+			Message: &storepb.InboxMessage{
+				Type:       storepb.InboxMessage_TYPE_MEMO_REMINDER,
+				ActivityId: &activity.ID,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create inbox")
+		}
+		if err := n.Store.UpdateMemoReminder(ctx, &store.UpdateMemoReminder{ID: reminder.ID, DeliveredTs: &now}); err != nil {
+			return errors.Wrap(err, "failed to update memo reminder")
+		}
+		n.EventBroker.Publish(event.NewInboxEvent(inbox))
+	}
+	return nil
+}
+
+// notifyInbox notifies the receiver of the inbox message by email if they opted in to the emails of its type,
+// and by the push service if they configured one.
+func (n *Notifier) notifyInbox(ctx context.Context, inboxID int32) error {
+	inboxes, err := n.Store.ListInboxes(ctx, &store.FindInbox{ID: &inboxID})
+	if err != nil || len(inboxes) == 0 || inboxes[0].Message.GetActivityId() == 0 {
+		return err
+	}
+	inbox := inboxes[0]
+	receiver, notification, err := n.getNotificationReceiver(ctx, inbox.ReceiverID)
+	if err != nil || receiver == nil {
+		return err
+	}
+	activityID := inbox.Message.GetActivityId()
+	activity, err := n.Store.GetActivity(ctx, &store.FindActivity{ID: &activityID})
+	if err != nil || activity == nil {
+		return err
+	}
+	sender, err := n.Store.GetUser(ctx, &store.FindUser{ID: &inbox.SenderID})
+	if err != nil || sender == nil {
+		return err
+	}
+	localizer, err := n.getLocalizer(ctx, receiver.ID)
+	if err != nil {
+		return err
+	}
+
+	var memoID int32
+	var subject string
+	var emailEnabled bool
+	switch inbox.Message.Type {
+	case storepb.InboxMessage_TYPE_MEMO_COMMENT:
+		emailEnabled = notification.EmailComment
+		memoID = activity.Payload.GetMemoComment().GetMemoId()
+		subject = localizer.T("notification.memo-comment", "name", sender.Nickname)
+	case storepb.InboxMessage_TYPE_MEMO_MENTION:
+		emailEnabled = notification.EmailMention
+		memoID = activity.Payload.GetMemoMention().GetMemoId()
+		subject = localizer.T("notification.memo-mention", "name", sender.Nickname)
+	case storepb.InboxMessage_TYPE_MEMO_REMINDER:
+		emailEnabled = notification.EmailReminder
+		memoID = activity.Payload.GetMemoReminder().GetMemoId()
+		subject = localizer.T("notification.memo-reminder")
+	default:
+		return nil
+	}
+	pushConfig := convertPushConfig(notification.GetPush())
+	webPushSubscriptions, err := n.Store.GetUserWebPushSubscriptions(ctx, receiver.ID)
+	if err != nil {
+		return err
+	}
+	if !emailEnabled && pushConfig == nil && len(webPushSubscriptions) == 0 {
+		return nil
+	}
+	memo, err := n.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil {
+		return err
+	}
+	memoURL, err := n.getMemoURL(ctx, memo)
+	if err != nil {
+		return err
+	}
+
+	// The deliveries are run on the worker pool without waiting for them, their errors are logged.
+	if emailEnabled {
+		text := getMemoSnippet(memo.Content)
+		if memoURL != "" {
+			text += "\n\n" + memoURL
+		}
+		n.dispatch(ctx, emailDestination, func(ctx context.Context) error {
+			return n.sendEmail(ctx, receiver, subject, text)
+		})
+	}
+	if pushConfig != nil {
+		n.dispatch(ctx, getDestination(pushConfig.ServerURL), func(ctx context.Context) error {
+			if err := push.Send(ctx, pushConfig, &push.Message{
+				Title: subject,
+				Text:  getMemoSnippet(memo.Content),
+				URL:   memoURL,
+			}); err != nil {
+				return errors.Wrap(err, "failed to push notification")
+			}
+			return nil
+		})
+	}
+	if len(webPushSubscriptions) > 0 {
+		return n.sendWebPush(ctx, receiver, webPushSubscriptions, &webPushMessage{
+			Title: subject,
+			Body:  getMemoSnippet(memo.Content),
+			URL:   "/m/" + memo.UID,
+			Tag:   fmt.Sprintf("inbox-%d", inbox.ID),
+		})
+	}
+	return nil
+}
+
+// webPushMessage is the payload of the Web Push messages, which is shown as a notification by the service worker.
+type webPushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// URL is the path of the page opened by clicking the notification.
+	URL string `json:"url"`
+	// Tag identifies the notification, so a notification pushed twice is shown once.
+	Tag string `json:"tag"`
+}
+
+// sendWebPush pushes the message to the browsers of the user on the worker pool, the expired subscriptions are removed.
+func (n *Notifier) sendWebPush(ctx context.Context, user *store.User, subscriptions []*storepb.WebPushSubscriptionsUserSetting_Subscription, message *webPushMessage) error {
+	key, err := apiv1.GetWebPushVAPIDKey(ctx, n.Store)
+	if err != nil {
+		return errors.Wrap(err, "failed to get VAPID key")
+	}
+	// The subject is the contact of the server for the push services.
+	workspaceGeneralSetting, err := n.Store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	subject := workspaceGeneralSetting.GetInstanceUrl()
+	if subject == "" {
+		subject = "https://usememos.com"
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	for _, subscription := range subscriptions {
+		webPushSubscription := &webpush.Subscription{Endpoint: subscription.Endpoint}
+		webPushSubscription.Keys.P256dh = subscription.P256Dh
+		webPushSubscription.Keys.Auth = subscription.Auth
+		n.dispatch(ctx, getDestination(subscription.Endpoint), func(ctx context.Context) error {
+			err := webpush.Send(ctx, key, subject, webPushSubscription, payload)
+			if errors.Is(err, webpush.ErrSubscriptionExpired) {
+				if err := n.Store.RemoveUserWebPushSubscription(ctx, user.ID, subscription.Endpoint); err != nil {
+					return errors.Wrap(err, "failed to remove expired subscription")
+				}
+				return nil
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to send Web Push message")
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// dispatch runs the delivery to the destination on the worker pool without waiting for it, its error is logged.
+func (n *Notifier) dispatch(ctx context.Context, destination string, deliver func(ctx context.Context) error) {
+	n.Pool.Submit(ctx, destination, func(ctx context.Context) error {
+		if err := deliver(ctx); err != nil {
+			slog.Warn("Failed to deliver notification", slog.String("destination", destination), slog.Any("err", err))
+			return err
+		}
+		return nil
+	})
+}
+
+// getDestination returns the host of the URL of the push server, which the deliveries to are limited by the worker pool.
+func getDestination(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
+}
+
+// emailReaction emails the creator of the memo of the reaction, if they opted in to the emails of reactions.
+func (n *Notifier) emailReaction(ctx context.Context, reactionID, memoID int32) error {
+	reactions, err := n.Store.ListReactions(ctx, &store.FindReaction{ID: &reactionID})
+	if err != nil || len(reactions) == 0 {
+		return err
+	}
+	reaction := reactions[0]
+	memo, err := n.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+	if err != nil || memo == nil || memo.CreatorID == reaction.CreatorId {
+		return err
+	}
+	receiver, notification, err := n.getNotificationReceiver(ctx, memo.CreatorID)
+	if err != nil || receiver == nil || !notification.EmailReaction {
+		return err
+	}
+	sender, err := n.Store.GetUser(ctx, &store.FindUser{ID: &reaction.CreatorId})
+	if err != nil || sender == nil {
+		return err
+	}
+	text, err := n.formatMemo(ctx, memo)
+	if err != nil {
+		return err
+	}
+	localizer, err := n.getLocalizer(ctx, receiver.ID)
+	if err != nil {
+		return err
+	}
+	subject := localizer.T("notification.memo-reaction", "name", sender.Nickname)
+	detail := localizer.T("notification.memo-reaction-detail", "name", sender.Nickname, "reaction", reaction.ReactionType.String())
+	n.dispatch(ctx, emailDestination, func(ctx context.Context) error {
+		return n.sendEmail(ctx, receiver, subject, detail+"\n\n"+text)
+	})
+	return nil
+}
+
+// SendDigests is run by the scheduler. It emails the users who opted in to the digests the memos of others created since their last digest,
+// once a day or a week has begun in the timezones of the users.
+func (n *Notifier) SendDigests(ctx context.Context) error {
+	smtpSetting, err := n.getSMTPSetting(ctx)
+	if err != nil || smtpSetting == nil {
+		return err
+	}
+	userSettings, err := n.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_NOTIFICATION,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list user settings")
+	}
+	now := time.Now()
+	for _, userSetting := range userSettings {
+		notification := userSetting.GetNotification()
+		if notification.GetDigest() == storepb.NotificationUserSetting_DIGEST_UNSPECIFIED {
+			continue
+		}
+		location, err := n.Store.GetUserLocation(ctx, userSetting.UserId)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user location")
+		}
+		if notification.LastDigestTs >= getDigestPeriodStart(now.In(location), notification.GetDigest()).Unix() {
+			continue
+		}
+		if err := n.sendDigest(ctx, userSetting.UserId, notification.LastDigestTs); err != nil {
+			slog.Warn("Failed to send digest", slog.Int("user", int(userSetting.UserId)), slog.Any("err", err))
+			continue
+		}
+		notification.LastDigestTs = now.Unix()
+		if _, err := n.Store.UpsertUserSetting(ctx, userSetting); err != nil {
+			return errors.Wrap(err, "failed to upsert user setting")
+		}
+	}
+	return nil
+}
+
+// getDigestPeriodStart returns the start of the day or the week of the time, in its location.
+// The weeks start on Mondays.
+func getDigestPeriodStart(t time.Time, digest storepb.NotificationUserSetting_Digest) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if digest == storepb.NotificationUserSetting_WEEKLY {
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	}
+	return start
+}
+
+func (n *Notifier) sendDigest(ctx context.Context, userID int32, createdTsAfter int64) error {
+	user, err := n.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil || user == nil || user.RowStatus == store.Archived {
+		return err
+	}
+	normalStatus, limit := store.Normal, maxDigestMemos+1
+	memos, err := n.Store.ListMemos(ctx, &store.FindMemo{
+		RowStatus:       &normalStatus,
+		CreatedTsAfter:  &createdTsAfter,
+		VisibilityList:  []store.Visibility{store.Public, store.Protected},
+		ExcludeComments: true,
+		ExcludeHidden:   true,
+		Limit:           &limit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memos")
+	}
+	more := len(memos) > maxDigestMemos
+	if more {
+		memos = memos[:maxDigestMemos]
+	}
+	parts := []string{}
+	for _, memo := range memos {
+		if memo.CreatorID == user.ID {
+			continue
+		}
+		text, err := n.formatMemo(ctx, memo)
+		if err != nil {
+			return err
+		}
+		creator, err := n.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+		if err != nil {
+			return err
+		}
+		if creator != nil {
+			text = fmt.Sprintf("%s (@%s):\n%s", creator.Nickname, creator.Username, text)
+		}
+		parts = append(parts, text)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	localizer, err := n.getLocalizer(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	subject := localizer.T("digest.subject", "count", len(parts))
+	if more {
+		parts = append(parts, localizer.T("digest.more"))
+		subject = localizer.T("digest.subject-more", "count", maxDigestMemos)
+	}
+	// The digest is sent on the worker pool too, but it's waited for, since the digest isn't marked as sent if it fails.
+	done := n.Pool.Submit(ctx, emailDestination, func(ctx context.Context) error {
+		return n.sendEmail(ctx, user, subject, strings.Join(parts, "\n\n---\n\n"))
+	})
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// getNotificationReceiver returns the user and their notification setting, nil if the user can't be notified.
+// The users without emails are returned too, since they may be notified by the push services.
+func (n *Notifier) getNotificationReceiver(ctx context.Context, userID int32) (*store.User, *storepb.NotificationUserSetting, error) {
+	user, err := n.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil || user == nil || user.RowStatus == store.Archived {
+		return nil, nil, err
+	}
+	userSetting, err := n.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_NOTIFICATION,
+	})
+	if err != nil || userSetting == nil {
+		return nil, nil, err
+	}
+	return user, userSetting.GetNotification(), nil
+}
+
+// getLocalizer returns the localizer of the locale of the user, the messages of the notifications are in it.
+func (n *Notifier) getLocalizer(ctx context.Context, userID int32) (*i18n.Localizer, error) {
+	locale, err := n.Store.GetUserLocale(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user locale")
+	}
+	return i18n.NewLocalizer(locale), nil
+}
+
+// formatMemo returns the snippet of the memo content with the link to the memo, if the instance url is set.
+func (n *Notifier) formatMemo(ctx context.Context, memo *store.Memo) (string, error) {
+	text := getMemoSnippet(memo.Content)
+	memoURL, err := n.getMemoURL(ctx, memo)
+	if err != nil {
+		return "", err
+	}
+	if memoURL != "" {
+		text += "\n\n" + memoURL
+	}
+	return text, nil
+}
+
+// getMemoURL returns the link to the memo, empty if the instance url isn't set.
+func (n *Notifier) getMemoURL(ctx context.Context, memo *store.Memo) (string, error) {
+	workspaceGeneralSetting, err := n.Store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return "", err
+	}
+	instanceURL := strings.TrimSuffix(workspaceGeneralSetting.GetInstanceUrl(), "/")
+	if instanceURL == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("%s/m/%s", instanceURL, memo.UID), nil
+}
+
+// convertPushConfig returns the config of the push service of the setting, nil if no service is configured.
+func convertPushConfig(setting *storepb.PushNotificationSetting) *push.Config {
+	config := &push.Config{
+		ServerURL: setting.GetServerUrl(),
+		Topic:     setting.GetTopic(),
+		Token:     setting.GetToken(),
+	}
+	switch setting.GetProvider() {
+	case storepb.PushNotificationSetting_NTFY:
+		config.Provider = push.ProviderNtfy
+	case storepb.PushNotificationSetting_GOTIFY:
+		config.Provider = push.ProviderGotify
+	default:
+		return nil
+	}
+	return config
+}
+
+func getMemoSnippet(content string) string {
+	content = strings.TrimSpace(content)
+	if utf8.RuneCountInString(content) <= maxSnippetLength {
+		return content
+	}
+	return string([]rune(content)[:maxSnippetLength]) + "..."
+}
+
+// getSMTPSetting returns the SMTP setting of the workspace, nil if the emails are not sent.
+func (n *Notifier) getSMTPSetting(ctx context.Context) (*storepb.SMTPSetting, error) {
+	integrationSetting, err := n.Store.GetWorkspaceIntegrationSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if integrationSetting.GetSmtp().GetHost() == "" {
+		return nil, nil
+	}
+	return integrationSetting.GetSmtp(), nil
+}
+
+func (n *Notifier) sendEmail(ctx context.Context, user *store.User, subject, text string) error {
+	smtpSetting, err := n.getSMTPSetting(ctx)
+	if err != nil || smtpSetting == nil || user.Email == "" {
+		return err
+	}
+	config := &mail.SenderConfig{
+		Host:      smtpSetting.Host,
+		Port:      int(smtpSetting.Port),
+		Username:  smtpSetting.Username,
+		Password:  smtpSetting.Password,
+		FromEmail: smtpSetting.FromEmail,
+		FromName:  smtpSetting.FromName,
+	}
+	if config.FromName == "" {
+		config.FromName = "Memos"
+	}
+	switch smtpSetting.Security {
+	case storepb.SMTPSetting_NONE:
+		config.Security = mail.SecurityNone
+	case storepb.SMTPSetting_STARTTLS:
+		config.Security = mail.SecuritySTARTTLS
+	case storepb.SMTPSetting_TLS:
+		config.Security = mail.SecurityTLS
+	default:
+		config.Security = mail.SecurityAuto
+	}
+	return mail.Send(ctx, config, &mail.OutgoingMessage{
+		To:      user.Email,
+		Subject: subject,
+		Text:    text,
+	})
+}
@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/push"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestGetMentionedUsernames(t *testing.T) {
+	tests := []struct {
+		content   string
+		usernames []string
+	}{
+		{
+			content:   "Hello @alice and @bob-2, thanks @alice!",
+			usernames: []string{"alice", "bob-2"},
+		},
+		{
+			content:   "Mail alice@example.com or see https://example.com/@bob",
+			usernames: []string{},
+		},
+		{
+			content:   "Run `@carol` in the code",
+			usernames: []string{},
+		},
+		{
+			content:   "@dave",
+			usernames: []string{"dave"},
+		},
+	}
+	for _, test := range tests {
+		usernames, err := getMentionedUsernames(test.content)
+		require.NoError(t, err)
+		require.Equal(t, test.usernames, usernames, test.content)
+	}
+}
+
+func TestConvertPushConfig(t *testing.T) {
+	require.Nil(t, convertPushConfig(nil))
+	require.Nil(t, convertPushConfig(&storepb.PushNotificationSetting{ServerUrl: "https://ntfy.sh", Topic: "memos"}))
+	require.Equal(t, &push.Config{
+		Provider:  push.ProviderNtfy,
+		ServerURL: "https://ntfy.sh",
+		Topic:     "memos",
+	}, convertPushConfig(&storepb.PushNotificationSetting{Provider: storepb.PushNotificationSetting_NTFY, ServerUrl: "https://ntfy.sh", Topic: "memos"}))
+}
+
+func TestGetDigestPeriodStart(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	// It's Wednesday in Tokyo, but still Tuesday in UTC.
+	now := time.Date(2024, 5, 1, 8, 30, 0, 0, location)
+	require.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, location), getDigestPeriodStart(now, storepb.NotificationUserSetting_DAILY))
+	require.Equal(t, time.Date(2024, 4, 29, 0, 0, 0, 0, location), getDigestPeriodStart(now, storepb.NotificationUserSetting_WEEKLY))
+	require.Equal(t, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), getDigestPeriodStart(now.UTC(), storepb.NotificationUserSetting_DAILY))
+	// The weeks starting on Mondays include the Sundays.
+	sunday := time.Date(2024, 5, 5, 23, 0, 0, 0, location)
+	require.Equal(t, time.Date(2024, 4, 29, 0, 0, 0, 0, location), getDigestPeriodStart(sunday, storepb.NotificationUserSetting_WEEKLY))
+}
@@ -0,0 +1,229 @@
+package replicator
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage/s3"
+)
+
+// Object is an object of the replica.
+type Object struct {
+	// Key is the slash separated path of the object under the replica.
+	Key string
+	// ModTime is the time the object was written, which the point-in-time restore is based on.
+	ModTime time.Time
+}
+
+// Replica is the storage the snapshots and the WAL segments are replicated to.
+type Replica interface {
+	// WriteObject writes the object of the key, it's atomic so a partial object is never listed.
+	WriteObject(ctx context.Context, key string, r io.Reader) error
+	OpenObject(ctx context.Context, key string) (io.ReadCloser, error)
+	// ListObjects lists the objects whose keys start with the prefix, sorted by key.
+	ListObjects(ctx context.Context, prefix string) ([]*Object, error)
+	DeleteObjects(ctx context.Context, keys []string) error
+}
+
+// NewReplica returns the replica of the URL, which is either `file:///path/to/replica` or
+// `s3://bucket/path?endpoint=https://s3.example.com&region=us-east-1`.
+// The credentials of S3 are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+func NewReplica(ctx context.Context, rawURL string) (Replica, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid replica url")
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.New("the file replica requires a path")
+		}
+		return &fileReplica{root: filepath.FromSlash(u.Path)}, nil
+	case "s3":
+		if u.Host == "" {
+			return nil, errors.New("the s3 replica requires a bucket")
+		}
+		region := u.Query().Get("region")
+		if region == "" {
+			region = "us-east-1"
+		}
+		endpoint := u.Query().Get("endpoint")
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+		client, err := s3.NewClient(ctx, &s3.Config{
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			Bucket:    u.Host,
+			EndPoint:  endpoint,
+			Region:    region,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create s3 client")
+		}
+		return &s3Replica{client: client, prefix: strings.Trim(u.Path, "/")}, nil
+	default:
+		return nil, errors.Errorf("unsupported replica scheme %q", u.Scheme)
+	}
+}
+
+type fileReplica struct {
+	root string
+}
+
+func (r *fileReplica) WriteObject(_ context.Context, key string, src io.Reader) error {
+	filePath := filepath.Join(r.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
+	// The object is written to a temporary file first, so a partial object isn't restored.
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to sync file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close file")
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (r *fileReplica) OpenObject(_ context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(r.root, filepath.FromSlash(key)))
+}
+
+func (r *fileReplica) ListObjects(_ context.Context, prefix string) ([]*Object, error) {
+	objects := []*Object{}
+	err := filepath.WalkDir(r.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+		relativePath, err := filepath.Rel(r.root, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relativePath)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &Object{Key: key, ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list files")
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+	return objects, nil
+}
+
+func (r *fileReplica) DeleteObjects(_ context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := os.Remove(filepath.Join(r.root, filepath.FromSlash(key))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return errors.Wrap(err, "failed to remove file")
+		}
+	}
+	return nil
+}
+
+type s3Replica struct {
+	client *s3.Client
+	// prefix is the path of the replica in the bucket.
+	prefix string
+}
+
+func (r *s3Replica) objectKey(key string) string {
+	return path.Join(r.prefix, key)
+}
+
+func (r *s3Replica) WriteObject(ctx context.Context, key string, src io.Reader) error {
+	// The uploader doesn't need the size of the object, which isn't known before it's compressed.
+	if _, err := manager.NewUploader(r.client.Client).Upload(ctx, &awss3.PutObjectInput{
+		Bucket: aws.String(r.client.Config.Bucket),
+		Key:    aws.String(r.objectKey(key)),
+		Body:   src,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to upload object %s", key)
+	}
+	return nil
+}
+
+func (r *s3Replica) OpenObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := r.client.GetObject(ctx, r.objectKey(key), "")
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+func (r *s3Replica) ListObjects(ctx context.Context, prefix string) ([]*Object, error) {
+	objects := []*Object{}
+	paginator := awss3.NewListObjectsV2Paginator(r.client.Client, &awss3.ListObjectsV2Input{
+		Bucket: aws.String(r.client.Config.Bucket),
+		Prefix: aws.String(r.objectKey(prefix)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list objects")
+		}
+		for _, object := range page.Contents {
+			key := strings.TrimPrefix(strings.TrimPrefix(aws.ToString(object.Key), r.prefix), "/")
+			objects = append(objects, &Object{Key: key, ModTime: aws.ToTime(object.LastModified)})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+	return objects, nil
+}
+
+func (r *s3Replica) DeleteObjects(ctx context.Context, keys []string) error {
+	// At most 1000 objects are deleted by a request.
+	for start := 0; start < len(keys); start += 1000 {
+		identifiers := []types.ObjectIdentifier{}
+		for _, key := range keys[start:min(start+1000, len(keys))] {
+			identifiers = append(identifiers, types.ObjectIdentifier{Key: aws.String(r.objectKey(key))})
+		}
+		if _, err := r.client.Client.DeleteObjects(ctx, &awss3.DeleteObjectsInput{
+			Bucket: aws.String(r.client.Config.Bucket),
+			Delete: &types.Delete{Objects: identifiers},
+		}); err != nil {
+			return errors.Wrap(err, "failed to delete objects")
+		}
+	}
+	return nil
+}
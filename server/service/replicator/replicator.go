@@ -0,0 +1,371 @@
+// Package replicator continuously replicates the SQLite database to a file or S3 replica by shipping its WAL,
+// in the layout of Litestream, so the database can be restored to a point in time without external tooling.
+package replicator
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/pierrec/lz4/v4"
+	"github.com/pkg/errors"
+
+	// Import the SQLite driver.
+	_ "modernc.org/sqlite"
+
+	"github.com/usememos/memos/server/profile"
+)
+
+// checkpointThreshold is the size of the shipped WAL after which it's checkpointed, so it's restarted
+// by the next transaction instead of growing.
+const checkpointThreshold = 4 << 20
+
+// errDiscontinuity is returned if the WAL was changed beyond the shipped frames, e.g. checkpointed and restarted
+// by another process, so the replica can't follow it and a new generation is started.
+var errDiscontinuity = errors.New("WAL discontinuity")
+
+// position is the position of the shipped WAL.
+type position struct {
+	index uint32
+	// offset is the size of the WAL shipped, 0 if no segment of the index is shipped.
+	offset uint32
+	// header is the header of the WAL of the index, nil if there's no segment of the index.
+	header *walHeader
+	// checksum1 and checksum2 are the checksums of the WAL at the offset.
+	checksum1 uint32
+	checksum2 uint32
+}
+
+// Replicator replicates the database of the profile to the replica. The automatic checkpoints of the database must
+// be disabled, see the `wal_autocheckpoint` pragma of the SQLite driver, since the WAL is checkpointed by the
+// replicator once its frames are shipped.
+type Replicator struct {
+	Profile *profile.Profile
+	Replica Replica
+
+	db      *sql.DB
+	started atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+
+	generation     string
+	generationTime time.Time
+	position       position
+	// restartAllowed is true if the WAL may have been restarted without the frames shipped by the replicator being
+	// lost, i.e. all of its frames were shipped while they were checkpointed, or were in the snapshot.
+	restartAllowed bool
+}
+
+func NewReplicator(ctx context.Context, profile *profile.Profile) (*Replicator, error) {
+	replica, err := NewReplica(ctx, profile.ReplicaURL)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", profile.DSN+"?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=wal_autocheckpoint(0)")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open database")
+	}
+	return &Replicator{
+		Profile: profile,
+		Replica: replica,
+		db:      db,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+func (r *Replicator) Start(ctx context.Context) {
+	r.started.Store(true)
+	defer close(r.done)
+	ticker := time.NewTicker(r.Profile.ReplicaSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Sync(ctx); err != nil {
+			slog.Warn("Failed to replicate database", slog.Any("err", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			// The frames written since the last sync are shipped before the database is closed.
+			if err := r.Sync(ctx); err != nil {
+				slog.Warn("Failed to replicate database", slog.Any("err", err))
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop ships the remaining frames of the WAL and closes the database of the replicator.
+func (r *Replicator) Stop() {
+	close(r.stop)
+	if r.started.Load() {
+		<-r.done
+	}
+	r.db.Close()
+}
+
+// Sync ships the frames of the WAL committed since the last sync. A new generation is started if there's none,
+// its snapshot is older than the snapshot interval or the WAL can't be followed.
+func (r *Replicator) Sync(ctx context.Context) error {
+	if r.generation == "" || time.Since(r.generationTime) >= r.Profile.ReplicaSnapshotInterval {
+		return r.newGeneration(ctx)
+	}
+	if err := r.shipWAL(ctx); err != nil {
+		if errors.Is(err, errDiscontinuity) {
+			slog.Info("Starting new replica generation after WAL discontinuity")
+			return r.newGeneration(ctx)
+		}
+		return err
+	}
+	// The WAL isn't checkpointed again until it's restarted.
+	if r.position.offset >= checkpointThreshold && !r.restartAllowed {
+		return r.checkpoint(ctx)
+	}
+	return nil
+}
+
+// newGeneration uploads the snapshot of the database and the WAL following it as a new generation,
+// and deletes the generations older than the retention.
+func (r *Replicator) newGeneration(ctx context.Context) error {
+	r.generation = ""
+	generation, err := newGenerationID()
+	if err != nil {
+		return err
+	}
+	snapshot, err := os.CreateTemp(filepath.Dir(r.Profile.DSN), ".memos-snapshot-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create snapshot file")
+	}
+	defer os.Remove(snapshot.Name())
+	defer snapshot.Close()
+
+	// The writes are blocked while the database file is copied and the WAL is read, so the snapshot and the WAL
+	// following it are consistent. The database file isn't changed meanwhile, since only the replicator checkpoints.
+	var segment []byte
+	var next position
+	if err := r.withWriteLock(ctx, func() error {
+		db, err := os.Open(r.Profile.DSN)
+		if err != nil {
+			return errors.Wrap(err, "failed to open database file")
+		}
+		defer db.Close()
+		if _, err := io.Copy(snapshot, db); err != nil {
+			return errors.Wrap(err, "failed to copy database file")
+		}
+		segment, next, err = r.readWAL(position{}, false)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if _, err := snapshot.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := r.writeObject(ctx, formatSnapshotKey(generation, 0), snapshot); err != nil {
+		return errors.Wrap(err, "failed to upload snapshot")
+	}
+	if len(segment) > 0 {
+		if err := r.writeObject(ctx, formatWALSegmentKey(generation, 0, 0), bytes.NewReader(segment)); err != nil {
+			return errors.Wrap(err, "failed to upload WAL segment")
+		}
+	}
+	r.generation, r.generationTime, r.position = generation, time.Now(), next
+	// All the frames of the WAL are either shipped or in the snapshot, so it may be restarted.
+	r.restartAllowed = true
+	slog.Info("Started new replica generation", slog.String("generation", generation))
+
+	if err := r.deleteExpiredGenerations(ctx); err != nil {
+		slog.Warn("Failed to delete expired replica generations", slog.Any("err", err))
+	}
+	return nil
+}
+
+// shipWAL uploads the frames of the WAL committed since the last sync.
+func (r *Replicator) shipWAL(ctx context.Context) error {
+	segment, next, err := r.readWAL(r.position, r.restartAllowed)
+	if err != nil || len(segment) == 0 {
+		return err
+	}
+	if err := r.writeObject(ctx, formatWALSegmentKey(r.generation, next.index, next.offset-uint32(len(segment))), bytes.NewReader(segment)); err != nil {
+		return errors.Wrap(err, "failed to upload WAL segment")
+	}
+	// The WAL isn't restarted once frames are committed after the checkpoint, until it's checkpointed again.
+	r.position, r.restartAllowed = next, false
+	return nil
+}
+
+// checkpoint ships the remaining frames of the WAL and checkpoints it with the writes blocked, so no frame is
+// written between them. Once all the frames are checkpointed, the WAL is restarted by the next transaction.
+func (r *Replicator) checkpoint(ctx context.Context) error {
+	var segment []byte
+	var next position
+	var complete bool
+	if err := r.withWriteLock(ctx, func() error {
+		var err error
+		segment, next, err = r.readWAL(r.position, r.restartAllowed)
+		if err != nil {
+			return err
+		}
+		var busy, log, checkpointed int
+		if err := r.db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(PASSIVE)").Scan(&busy, &log, &checkpointed); err != nil {
+			return errors.Wrap(err, "failed to checkpoint")
+		}
+		complete = busy == 0 && log == checkpointed
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(segment) > 0 {
+		if err := r.writeObject(ctx, formatWALSegmentKey(r.generation, next.index, next.offset-uint32(len(segment))), bytes.NewReader(segment)); err != nil {
+			// The frames may be overwritten once the WAL is restarted, so the generation can't be followed.
+			r.generation = ""
+			return errors.Wrap(err, "failed to upload WAL segment")
+		}
+		r.position, r.restartAllowed = next, false
+	}
+	// The frames are checkpointed after they're read, so the WAL may be restarted once all of them are checkpointed.
+	if complete {
+		r.restartAllowed = true
+	}
+	return nil
+}
+
+// readWAL returns the segment of the WAL committed after the position, and the position after the segment.
+// If the WAL was restarted and restartAllowed is true, the segment is the start of the WAL of the next index.
+func (r *Replicator) readWAL(current position, restartAllowed bool) ([]byte, position, error) {
+	file, err := os.Open(r.Profile.DSN + "-wal")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && current.header == nil {
+			return nil, current, nil
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, current, errDiscontinuity
+		}
+		return nil, current, errors.Wrap(err, "failed to open WAL")
+	}
+	defer file.Close()
+	buffer := make([]byte, walHeaderSize)
+	if _, err := io.ReadFull(file, buffer); err != nil {
+		if current.header == nil {
+			return nil, current, nil
+		}
+		return nil, current, errDiscontinuity
+	}
+	header, err := parseWALHeader(buffer)
+	if err != nil {
+		if current.header == nil {
+			return nil, current, nil
+		}
+		return nil, current, errDiscontinuity
+	}
+
+	next := current
+	if current.header != nil && (header.salt1 != current.header.salt1 || header.salt2 != current.header.salt2) {
+		if !restartAllowed {
+			return nil, current, errDiscontinuity
+		}
+		next = position{index: current.index + 1}
+	}
+	start, newHeader := int64(next.offset), next.header == nil
+	if newHeader {
+		next.header, next.checksum1, next.checksum2 = header, header.checksum1, header.checksum2
+		start = walHeaderSize
+	}
+	frames, err := io.ReadAll(io.NewSectionReader(file, start, 1<<62))
+	if err != nil {
+		return nil, current, errors.Wrap(err, "failed to read WAL")
+	}
+	end, checksum1, checksum2 := next.header.scanFrames(frames, next.checksum1, next.checksum2)
+	if end == 0 {
+		// The header isn't shipped before a transaction is committed in the WAL.
+		return nil, current, nil
+	}
+	segment := frames[:end]
+	if newHeader {
+		segment = append(buffer, segment...)
+	}
+	next.offset = uint32(start) + uint32(end)
+	next.checksum1, next.checksum2 = checksum1, checksum2
+	return segment, next, nil
+}
+
+// withWriteLock runs fn while the writes to the database are blocked.
+func (r *Replicator) withWriteLock(ctx context.Context, fn func() error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection")
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return errors.Wrap(err, "failed to lock database")
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+	return fn()
+}
+
+// writeObject writes the object compressed with LZ4.
+func (r *Replicator) writeObject(ctx context.Context, key string, src io.Reader) error {
+	reader, writer := io.Pipe()
+	go func() {
+		compressor := lz4.NewWriter(writer)
+		_, err := io.Copy(compressor, src)
+		if err == nil {
+			err = compressor.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	err := r.Replica.WriteObject(ctx, key, reader)
+	// The compressor is stopped if the object isn't written.
+	reader.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+// deleteExpiredGenerations deletes the generations other than the current one whose last objects are older than the retention.
+func (r *Replicator) deleteExpiredGenerations(ctx context.Context) error {
+	if r.Profile.ReplicaRetention <= 0 {
+		return nil
+	}
+	objects, err := r.Replica.ListObjects(ctx, generationsDir+"/")
+	if err != nil {
+		return err
+	}
+	keys, lastModified := map[string][]string{}, map[string]time.Time{}
+	for _, object := range objects {
+		parsed, ok := parseObjectKey(object.Key)
+		if !ok || parsed.generation == r.generation {
+			continue
+		}
+		keys[parsed.generation] = append(keys[parsed.generation], object.Key)
+		if object.ModTime.After(lastModified[parsed.generation]) {
+			lastModified[parsed.generation] = object.ModTime
+		}
+	}
+	expiredKeys := []string{}
+	for generation, modTime := range lastModified {
+		if time.Since(modTime) > r.Profile.ReplicaRetention {
+			expiredKeys = append(expiredKeys, keys[generation]...)
+		}
+	}
+	return r.Replica.DeleteObjects(ctx, expiredKeys)
+}
+
+// newGenerationID returns a random ID of a generation, the 16 hex characters of Litestream.
+func newGenerationID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,98 @@
+package replicator
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/server/profile"
+)
+
+func TestReplicateAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "memos.db")
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=wal_autocheckpoint(0)")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.ExecContext(ctx, "CREATE TABLE memo (id INTEGER PRIMARY KEY, content TEXT)")
+	require.NoError(t, err)
+	insert := func(count int) {
+		for range count {
+			_, err := db.ExecContext(ctx, "INSERT INTO memo (content) VALUES (?)", strings.Repeat("memo ", 100))
+			require.NoError(t, err)
+		}
+	}
+
+	replicator, err := NewReplicator(ctx, &profile.Profile{
+		DSN:                     dbPath,
+		ReplicaURL:              "file://" + filepath.ToSlash(filepath.Join(dir, "replica")),
+		ReplicaSyncInterval:     time.Second,
+		ReplicaSnapshotInterval: time.Hour,
+	})
+	require.NoError(t, err)
+	defer replicator.Stop()
+
+	insert(10)
+	require.NoError(t, replicator.Sync(ctx))
+	generation := replicator.generation
+	insert(10)
+	require.NoError(t, replicator.Sync(ctx))
+
+	time.Sleep(20 * time.Millisecond)
+	timestamp := time.Now()
+	time.Sleep(20 * time.Millisecond)
+
+	// The WAL is restarted by the first transaction after the checkpoint, which is followed by the next index.
+	insert(10)
+	require.NoError(t, replicator.Sync(ctx))
+	require.NoError(t, replicator.checkpoint(ctx))
+	insert(10)
+	require.NoError(t, replicator.Sync(ctx))
+	require.Equal(t, generation, replicator.generation)
+	require.Equal(t, uint32(1), replicator.position.index)
+
+	count := func(dbPath string) int {
+		restored, err := sql.Open("sqlite", dbPath)
+		require.NoError(t, err)
+		defer restored.Close()
+		var count int
+		require.NoError(t, restored.QueryRowContext(ctx, "SELECT COUNT(*) FROM memo").Scan(&count))
+		return count
+	}
+	result, err := Restore(ctx, replicator.Replica, filepath.Join(dir, "latest.db"), time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), result.Index)
+	require.Equal(t, 40, count(filepath.Join(dir, "latest.db")))
+	_, err = Restore(ctx, replicator.Replica, filepath.Join(dir, "timestamp.db"), timestamp)
+	require.NoError(t, err)
+	require.Equal(t, 20, count(filepath.Join(dir, "timestamp.db")))
+
+	// A checkpoint by another process can't be followed, the replica starts a new generation.
+	_, err = db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)")
+	require.NoError(t, err)
+	insert(10)
+	require.NoError(t, replicator.Sync(ctx))
+	require.NotEqual(t, generation, replicator.generation)
+	insert(10)
+	require.NoError(t, replicator.Sync(ctx))
+	_, err = Restore(ctx, replicator.Replica, filepath.Join(dir, "generation.db"), time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 60, count(filepath.Join(dir, "generation.db")))
+}
+
+func TestParseObjectKey(t *testing.T) {
+	parsed, ok := parseObjectKey(formatWALSegmentKey("0123456789abcdef", 2, 4152))
+	require.True(t, ok)
+	require.Equal(t, &objectKey{generation: "0123456789abcdef", index: 2, offset: 4152}, parsed)
+	parsed, ok = parseObjectKey(formatSnapshotKey("0123456789abcdef", 1))
+	require.True(t, ok)
+	require.Equal(t, &objectKey{generation: "0123456789abcdef", snapshot: true, index: 1}, parsed)
+	_, ok = parseObjectKey("generations/0123456789abcdef/wal/invalid.wal.lz4")
+	require.False(t, ok)
+}
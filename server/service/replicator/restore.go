@@ -0,0 +1,167 @@
+package replicator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pierrec/lz4/v4"
+	"github.com/pkg/errors"
+)
+
+// RestoreResult is the position of the replica the database is restored to.
+type RestoreResult struct {
+	Generation string
+	Index      uint32
+	Offset     uint32
+	// Time is the time the last restored object was written to the replica.
+	Time time.Time
+}
+
+// Restore writes the database of the replica as of the timestamp to the output path, the latest one if the timestamp is zero.
+// The snapshot of the generation active at the timestamp is restored, and the WAL segments written until then are applied to it.
+func Restore(ctx context.Context, replica Replica, outputPath string, timestamp time.Time) (*RestoreResult, error) {
+	objects, err := replica.ListObjects(ctx, generationsDir+"/")
+	if err != nil {
+		return nil, err
+	}
+	var snapshot *Object
+	var snapshotKey *objectKey
+	for _, object := range objects {
+		parsed, ok := parseObjectKey(object.Key)
+		if !ok || !parsed.snapshot || (!timestamp.IsZero() && object.ModTime.After(timestamp)) {
+			continue
+		}
+		if snapshot == nil || object.ModTime.After(snapshot.ModTime) {
+			snapshot, snapshotKey = object, parsed
+		}
+	}
+	if snapshot == nil {
+		return nil, errors.New("no snapshot of the replica before the timestamp")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(outputPath), ".memos-restore-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create database file")
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if err := readObject(ctx, replica, snapshot.Key, func(r io.Reader) error {
+		_, err := io.Copy(tmp, r)
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to restore snapshot")
+	}
+
+	result := &RestoreResult{Generation: snapshotKey.generation, Index: snapshotKey.index, Time: snapshot.ModTime}
+	var header *walHeader
+	for _, object := range objects {
+		parsed, ok := parseObjectKey(object.Key)
+		if !ok || parsed.snapshot || parsed.generation != result.Generation || parsed.index < snapshotKey.index {
+			continue
+		}
+		if !timestamp.IsZero() && object.ModTime.After(timestamp) {
+			break
+		}
+		// The segments are applied in order, a missing one ends the restore.
+		if parsed.index == result.Index+1 && parsed.offset == 0 {
+			result.Index, result.Offset = parsed.index, 0
+		}
+		if parsed.index != result.Index || parsed.offset != result.Offset {
+			break
+		}
+		var size uint32
+		if err := readObject(ctx, replica, object.Key, func(r io.Reader) error {
+			segment, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			if parsed.offset == 0 {
+				if header, err = parseWALHeader(segment); err != nil {
+					return err
+				}
+				if err := applyFrames(tmp, header, segment[walHeaderSize:]); err != nil {
+					return err
+				}
+			} else if err := applyFrames(tmp, header, segment); err != nil {
+				return err
+			}
+			size = uint32(len(segment))
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to apply WAL segment %s", object.Key)
+		}
+		result.Offset += size
+		result.Time = object.ModTime
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := checkDatabase(ctx, tmp.Name()); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), outputPath); err != nil {
+		return nil, errors.Wrap(err, "failed to move database file")
+	}
+	return result, nil
+}
+
+// applyFrames writes the pages of the WAL frames to the database file, as a checkpoint does.
+func applyFrames(db *os.File, header *walHeader, frames []byte) error {
+	if header == nil {
+		return errors.New("WAL segment without header")
+	}
+	frameSize := header.frameSize()
+	if len(frames)%frameSize != 0 {
+		return errors.New("partial WAL frame")
+	}
+	for position := 0; position < len(frames); position += frameSize {
+		frame := frames[position : position+frameSize]
+		pageNumber := binary.BigEndian.Uint32(frame[0:])
+		if _, err := db.WriteAt(frame[walFrameHeaderSize:], int64(pageNumber-1)*int64(header.pageSize)); err != nil {
+			return err
+		}
+		// The database is truncated to its size after the transaction, e.g. after a vacuum.
+		if commitSize := binary.BigEndian.Uint32(frame[4:]); commitSize != 0 {
+			if err := db.Truncate(int64(commitSize) * int64(header.pageSize)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readObject reads the object of the replica decompressed with LZ4.
+func readObject(ctx context.Context, replica Replica, key string, fn func(r io.Reader) error) error {
+	object, err := replica.OpenObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+	return fn(lz4.NewReader(object))
+}
+
+// checkDatabase checks the integrity of the restored database.
+func checkDatabase(ctx context.Context, dbPath string) error {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+		return errors.Wrap(err, "failed to check restored database")
+	}
+	if result != "ok" {
+		return errors.Errorf("restored database is corrupted: %s", result)
+	}
+	return nil
+}
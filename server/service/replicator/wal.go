@@ -0,0 +1,162 @@
+package replicator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The layout of the replica is the one of Litestream, so either of them can restore the other's replica:
+//
+//	generations/<generation>/snapshots/<index>.snapshot.lz4
+//	generations/<generation>/wal/<index>_<offset>.wal.lz4
+//
+// A generation is a snapshot of the database and the WAL following it. The index of the WAL is incremented once
+// the WAL is restarted after a checkpoint, and the offset of a segment is its position in the WAL file, so the
+// segment at offset 0 starts with the WAL header.
+const (
+	generationsDir = "generations"
+
+	walHeaderSize      = 32
+	walFrameHeaderSize = 24
+)
+
+func formatGenerationDir(generation string) string {
+	return fmt.Sprintf("%s/%s/", generationsDir, generation)
+}
+
+func formatSnapshotKey(generation string, index uint32) string {
+	return fmt.Sprintf("%s/%s/snapshots/%08x.snapshot.lz4", generationsDir, generation, index)
+}
+
+func formatWALSegmentKey(generation string, index, offset uint32) string {
+	return fmt.Sprintf("%s/%s/wal/%08x_%08x.wal.lz4", generationsDir, generation, index, offset)
+}
+
+// objectKey is a parsed key of an object of the replica.
+type objectKey struct {
+	generation string
+	snapshot   bool
+	index      uint32
+	offset     uint32
+}
+
+// parseObjectKey parses the key of the snapshot or the WAL segment, false if it's neither of them.
+func parseObjectKey(key string) (*objectKey, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[0] != generationsDir {
+		return nil, false
+	}
+	parsed := &objectKey{generation: parts[1]}
+	switch {
+	case parts[2] == "snapshots" && strings.HasSuffix(parts[3], ".snapshot.lz4"):
+		index, err := strconv.ParseUint(strings.TrimSuffix(parts[3], ".snapshot.lz4"), 16, 32)
+		if err != nil {
+			return nil, false
+		}
+		parsed.snapshot, parsed.index = true, uint32(index)
+	case parts[2] == "wal" && strings.HasSuffix(parts[3], ".wal.lz4"):
+		rawIndex, rawOffset, ok := strings.Cut(strings.TrimSuffix(parts[3], ".wal.lz4"), "_")
+		if !ok {
+			return nil, false
+		}
+		index, err := strconv.ParseUint(rawIndex, 16, 32)
+		if err != nil {
+			return nil, false
+		}
+		offset, err := strconv.ParseUint(rawOffset, 16, 32)
+		if err != nil {
+			return nil, false
+		}
+		parsed.index, parsed.offset = uint32(index), uint32(offset)
+	default:
+		return nil, false
+	}
+	return parsed, true
+}
+
+// walHeader is the header of the WAL file, see https://www.sqlite.org/fileformat.html#the_write_ahead_log.
+type walHeader struct {
+	// bigEndian is true if the checksums are computed on the big-endian words.
+	bigEndian bool
+	pageSize  uint32
+	salt1     uint32
+	salt2     uint32
+	checksum1 uint32
+	checksum2 uint32
+}
+
+func parseWALHeader(b []byte) (*walHeader, error) {
+	if len(b) < walHeaderSize {
+		return nil, errors.New("short WAL header")
+	}
+	header := &walHeader{
+		pageSize:  binary.BigEndian.Uint32(b[8:]),
+		salt1:     binary.BigEndian.Uint32(b[16:]),
+		salt2:     binary.BigEndian.Uint32(b[20:]),
+		checksum1: binary.BigEndian.Uint32(b[24:]),
+		checksum2: binary.BigEndian.Uint32(b[28:]),
+	}
+	switch binary.BigEndian.Uint32(b[0:]) {
+	case 0x377f0682:
+	case 0x377f0683:
+		header.bigEndian = true
+	default:
+		return nil, errors.New("invalid WAL magic")
+	}
+	// The page size of 65536 is stored as 1.
+	if header.pageSize == 1 {
+		header.pageSize = 65536
+	}
+	if header.pageSize < 512 || header.pageSize&(header.pageSize-1) != 0 {
+		return nil, errors.Errorf("invalid WAL page size %d", header.pageSize)
+	}
+	if s1, s2 := walChecksum(header.bigEndian, 0, 0, b[:24]); s1 != header.checksum1 || s2 != header.checksum2 {
+		return nil, errors.New("invalid WAL header checksum")
+	}
+	return header, nil
+}
+
+func (h *walHeader) frameSize() int {
+	return walFrameHeaderSize + int(h.pageSize)
+}
+
+// scanFrames returns the end of the last commit frame in the frames of the WAL starting at offset, which follow
+// the checksums s1 and s2. The frames after an invalid one, e.g. the ones left from before the WAL was restarted,
+// and the frames of the uncommitted transactions are ignored. The returned checksums are the ones of the commit frame.
+func (h *walHeader) scanFrames(frames []byte, s1, s2 uint32) (end int, commitS1, commitS2 uint32) {
+	commitS1, commitS2 = s1, s2
+	frameSize := h.frameSize()
+	for position := 0; position+frameSize <= len(frames); position += frameSize {
+		frame := frames[position : position+frameSize]
+		if binary.BigEndian.Uint32(frame[8:]) != h.salt1 || binary.BigEndian.Uint32(frame[12:]) != h.salt2 {
+			break
+		}
+		s1, s2 = walChecksum(h.bigEndian, s1, s2, frame[:8])
+		s1, s2 = walChecksum(h.bigEndian, s1, s2, frame[walFrameHeaderSize:])
+		if s1 != binary.BigEndian.Uint32(frame[16:]) || s2 != binary.BigEndian.Uint32(frame[20:]) {
+			break
+		}
+		// The commit frames store the size of the database in pages after the transaction.
+		if binary.BigEndian.Uint32(frame[4:]) != 0 {
+			end, commitS1, commitS2 = position+frameSize, s1, s2
+		}
+	}
+	return end, commitS1, commitS2
+}
+
+// walChecksum computes the checksum of the WAL over b following s1 and s2.
+func walChecksum(bigEndian bool, s1, s2 uint32, b []byte) (uint32, uint32) {
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if bigEndian {
+		byteOrder = binary.BigEndian
+	}
+	for i := 0; i+8 <= len(b); i += 8 {
+		s1 += byteOrder.Uint32(b[i:]) + s2
+		s2 += byteOrder.Uint32(b[i+4:]) + s1
+	}
+	return s1, s2
+}
@@ -0,0 +1,281 @@
+// Package scheduler runs the periodic tasks of the workspace on the cron schedules of the scheduler setting,
+// and keeps the last run of each task in the store for the host to inspect.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/cron"
+	"github.com/usememos/memos/internal/telemetry"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// tickInterval is the interval of checking for due tasks, the finest resolution of the cron expressions.
+const tickInterval = time.Minute
+
+var (
+	// ErrTaskNotFound is returned if no task of the name is registered.
+	ErrTaskNotFound = errors.New("scheduled task not found")
+	// ErrTaskRunning is returned if the task is already running, a task doesn't overlap itself.
+	ErrTaskRunning = errors.New("scheduled task is running")
+)
+
+// Task is a periodic task of the workspace.
+type Task struct {
+	// Name is the name of the task in the scheduler setting, e.g. `resource_gc`.
+	Name string
+	// DefaultCron is the schedule of the task unless the scheduler setting overrides it.
+	DefaultCron string
+	// RunOnStart is the flag to also run the task when the server starts, e.g. for the tasks whose results expire.
+	RunOnStart bool
+	// DisabledByDefault is the flag to not run the task on a schedule until the host enables it in the scheduler setting,
+	// e.g. for the destructive tasks.
+	DisabledByDefault bool
+	Run               func(ctx context.Context) error
+}
+
+// TaskStatus is the schedule and the last run of a task.
+type TaskStatus struct {
+	Task     *Task
+	Cron     string
+	Disabled bool
+	// NextRunTs is the time the task runs next, 0 if it isn't scheduled.
+	NextRunTs int64
+	// Running is the flag of the task being run by this server.
+	Running bool
+	// LastRun is the last run of the task by any of the replicas, nil if it has never run.
+	LastRun *store.TaskRun
+}
+
+// Scheduler runs the registered tasks when they are due.
+type Scheduler struct {
+	Store *store.Store
+
+	mutex   sync.Mutex
+	ctx     context.Context
+	tasks   []*Task
+	running map[string]bool
+}
+
+func NewScheduler(store *store.Store) *Scheduler {
+	return &Scheduler{
+		Store:   store,
+		ctx:     context.Background(),
+		running: map[string]bool{},
+	}
+}
+
+// Register registers the task. The tasks must be registered before the scheduler is started.
+func (s *Scheduler) Register(task *Task) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tasks = append(s.tasks, task)
+}
+
+func (s *Scheduler) getTask(name string) *Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, task := range s.tasks {
+		if task.Name == name {
+			return task
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mutex.Lock()
+	s.ctx = ctx
+	tasks := s.tasks
+	s.mutex.Unlock()
+
+	for _, task := range tasks {
+		if task.RunOnStart {
+			go s.claimAndRun(ctx, task, time.Now())
+		}
+	}
+	for {
+		// The ticks are aligned to the minutes, so the replicas sharing the store tick at once.
+		now := time.Now()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(now.Truncate(tickInterval).Add(tickInterval).Sub(now)):
+		}
+		if err := s.runDue(ctx, time.Now().Truncate(tickInterval)); err != nil {
+			slog.Warn("Failed to run scheduled tasks", slog.Any("err", err))
+		}
+	}
+}
+
+// runDue runs the tasks which are due at the minute, in the timezone of the scheduler setting.
+func (s *Scheduler) runDue(ctx context.Context, minute time.Time) error {
+	setting, err := s.Store.GetWorkspaceSchedulerSetting(ctx)
+	if err != nil {
+		return err
+	}
+	moment := cron.NewMoment(minute.In(getLocation(setting)))
+	for _, task := range s.listTasks() {
+		schedule, disabled := getSchedule(setting, task)
+		if disabled || !schedule.IsDue(moment) {
+			continue
+		}
+		go s.claimAndRun(ctx, task, minute)
+	}
+	return nil
+}
+
+// claimAndRun runs the task unless another replica sharing the store runs it at the minute.
+func (s *Scheduler) claimAndRun(ctx context.Context, task *Task, minute time.Time) {
+	if !s.Store.ClaimJobRun(ctx, "scheduler."+task.Name, tickInterval) {
+		return
+	}
+	if err := s.run(ctx, task); err != nil && !errors.Is(err, ErrTaskRunning) {
+		slog.Warn("Failed to run scheduled task", slog.String("task", task.Name), slog.Time("minute", minute), slog.Any("err", err))
+	}
+}
+
+// RunTask runs the task in the background now, regardless of its schedule.
+func (s *Scheduler) RunTask(name string) error {
+	task := s.getTask(name)
+	if task == nil {
+		return ErrTaskNotFound
+	}
+	s.mutex.Lock()
+	ctx, running := s.ctx, s.running[name]
+	s.mutex.Unlock()
+	if running {
+		return ErrTaskRunning
+	}
+	go func() {
+		if err := s.run(ctx, task); err != nil && !errors.Is(err, ErrTaskRunning) {
+			slog.Warn("Failed to run scheduled task", slog.String("task", task.Name), slog.Any("err", err))
+		}
+	}()
+	return nil
+}
+
+// run runs the task and keeps the run in the store.
+func (s *Scheduler) run(ctx context.Context, task *Task) error {
+	s.mutex.Lock()
+	if s.running[task.Name] {
+		s.mutex.Unlock()
+		return ErrTaskRunning
+	}
+	s.running[task.Name] = true
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.running, task.Name)
+		s.mutex.Unlock()
+	}()
+
+	taskRun := &store.TaskRun{
+		Name:      task.Name,
+		Status:    store.TaskRunning,
+		StartedTs: time.Now().Unix(),
+	}
+	if _, err := s.Store.UpsertTaskRun(ctx, taskRun); err != nil {
+		return errors.Wrap(err, "failed to upsert task run")
+	}
+	runErr := telemetry.TraceJob(ctx, task.Name, task.Run)
+	taskRun.Status, taskRun.FinishedTs = store.TaskSucceeded, time.Now().Unix()
+	if runErr != nil {
+		taskRun.Status, taskRun.Error = store.TaskFailed, runErr.Error()
+	}
+	// The run is kept even if the server is stopping.
+	if _, err := s.Store.UpsertTaskRun(context.WithoutCancel(ctx), taskRun); err != nil {
+		return errors.Wrap(err, "failed to upsert task run")
+	}
+	return runErr
+}
+
+// ListTasks returns the statuses of the registered tasks, in the order they're registered.
+func (s *Scheduler) ListTasks(ctx context.Context) ([]*TaskStatus, error) {
+	setting, err := s.Store.GetWorkspaceSchedulerSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	taskRuns, err := s.Store.ListTaskRuns(ctx, &store.FindTaskRun{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list task runs")
+	}
+	lastRuns := map[string]*store.TaskRun{}
+	for _, taskRun := range taskRuns {
+		lastRuns[taskRun.Name] = taskRun
+	}
+
+	now := time.Now().In(getLocation(setting))
+	statuses := []*TaskStatus{}
+	for _, task := range s.listTasks() {
+		schedule, disabled := getSchedule(setting, task)
+		status := &TaskStatus{
+			Task:     task,
+			Cron:     getCron(setting, task),
+			Disabled: disabled,
+			LastRun:  lastRuns[task.Name],
+		}
+		if next := schedule.Next(now); !disabled && !next.IsZero() {
+			status.NextRunTs = next.Unix()
+		}
+		s.mutex.Lock()
+		status.Running = s.running[task.Name]
+		s.mutex.Unlock()
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *Scheduler) listTasks() []*Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.tasks
+}
+
+// getLocation returns the timezone of the scheduler setting, UTC if it's unset or unknown.
+func getLocation(setting *storepb.WorkspaceSchedulerSetting) *time.Location {
+	location, err := time.LoadLocation(setting.GetTimezone())
+	if err != nil {
+		slog.Warn("Unknown scheduler timezone", slog.String("timezone", setting.GetTimezone()), slog.Any("err", err))
+		return time.UTC
+	}
+	return location
+}
+
+// getCron returns the cron expression of the task in the scheduler setting, or its default one.
+func getCron(setting *storepb.WorkspaceSchedulerSetting, task *Task) string {
+	for _, scheduledTask := range setting.GetTasks() {
+		if scheduledTask.Name == task.Name && scheduledTask.Cron != "" {
+			return scheduledTask.Cron
+		}
+	}
+	return task.DefaultCron
+}
+
+// getSchedule returns the schedule of the task, and whether it's disabled in the scheduler setting.
+// The default schedule is used if the cron expression of the setting is invalid.
+func getSchedule(setting *storepb.WorkspaceSchedulerSetting, task *Task) (*cron.Schedule, bool) {
+	disabled := task.DisabledByDefault
+	for _, scheduledTask := range setting.GetTasks() {
+		if scheduledTask.Name == task.Name {
+			disabled = scheduledTask.Disabled
+		}
+	}
+	cronExpr := getCron(setting, task)
+	schedule, err := cron.NewSchedule(cronExpr)
+	if err != nil && cronExpr != task.DefaultCron {
+		slog.Warn("Invalid cron expression of scheduled task", slog.String("task", task.Name), slog.String("cron", cronExpr), slog.Any("err", err))
+		schedule, err = cron.NewSchedule(task.DefaultCron)
+	}
+	if err != nil {
+		slog.Warn("Invalid default cron expression of scheduled task", slog.String("task", task.Name), slog.Any("err", err))
+		return &cron.Schedule{}, true
+	}
+	return schedule, disabled
+}
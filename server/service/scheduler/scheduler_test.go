@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	scheduler := NewScheduler(ts)
+	var hourlyRuns, dailyRuns atomic.Int32
+	scheduler.Register(&Task{
+		Name:        "hourly",
+		DefaultCron: "0 * * * *",
+		Run: func(context.Context) error {
+			hourlyRuns.Add(1)
+			return nil
+		},
+	})
+	scheduler.Register(&Task{
+		Name:        "daily",
+		DefaultCron: "0 3 * * *",
+		Run: func(context.Context) error {
+			dailyRuns.Add(1)
+			return errors.New("unavailable")
+		},
+	})
+	_, err := ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_SCHEDULER,
+		Value: &storepb.WorkspaceSetting_Scheduler{Scheduler: &storepb.WorkspaceSchedulerSetting{
+			Timezone: "Asia/Tokyo",
+			Tasks: []*storepb.ScheduledTask{
+				{Name: "hourly", Disabled: true},
+				{Name: "daily", Cron: "30 12 * * *"},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	hourlyName, dailyName := "hourly", "daily"
+	// 12:30 in Tokyo, the disabled task doesn't run on its schedule.
+	require.NoError(t, scheduler.runDue(ctx, time.Date(2024, 5, 9, 3, 30, 0, 0, time.UTC)))
+	require.Eventually(t, func() bool {
+		taskRun, err := ts.GetTaskRun(ctx, &store.FindTaskRun{Name: &dailyName})
+		return err == nil && taskRun != nil && taskRun.Status != store.TaskRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(0), hourlyRuns.Load())
+	require.Equal(t, int32(1), dailyRuns.Load())
+
+	statuses, err := scheduler.ListTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.True(t, statuses[0].Disabled)
+	require.Zero(t, statuses[0].NextRunTs)
+	require.Nil(t, statuses[0].LastRun)
+	require.Equal(t, "30 12 * * *", statuses[1].Cron)
+	require.Equal(t, store.TaskFailed, statuses[1].LastRun.Status)
+	require.Equal(t, "unavailable", statuses[1].LastRun.Error)
+	next := time.Unix(statuses[1].NextRunTs, 0).UTC()
+	require.Equal(t, 3, next.Hour())
+	require.Equal(t, 30, next.Minute())
+
+	// The disabled task can still be run by the host.
+	require.NoError(t, scheduler.RunTask("hourly"))
+	require.Eventually(t, func() bool {
+		taskRun, err := ts.GetTaskRun(ctx, &store.FindTaskRun{Name: &hourlyName})
+		return err == nil && taskRun != nil && taskRun.Status == store.TaskSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(1), hourlyRuns.Load())
+	require.ErrorIs(t, scheduler.RunTask("unknown"), ErrTaskNotFound)
+}
+
+func TestSchedulerDoesNotOverlap(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	scheduler := NewScheduler(ts)
+	release := make(chan struct{})
+	scheduler.Register(&Task{
+		Name:        "slow",
+		DefaultCron: "* * * * *",
+		Run: func(context.Context) error {
+			<-release
+			return nil
+		},
+	})
+
+	require.NoError(t, scheduler.RunTask("slow"))
+	require.Eventually(t, func() bool {
+		statuses, err := scheduler.ListTasks(ctx)
+		return err == nil && statuses[0].Running
+	}, 5*time.Second, 10*time.Millisecond)
+	require.ErrorIs(t, scheduler.RunTask("slow"), ErrTaskRunning)
+	close(release)
+	require.Eventually(t, func() bool {
+		statuses, err := scheduler.ListTasks(ctx)
+		return err == nil && !statuses[0].Running
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestGetScheduleDisabledByDefault(t *testing.T) {
+	task := &Task{Name: "resource_gc", DefaultCron: "0 3 * * *", DisabledByDefault: true}
+	tests := []struct {
+		setting  *storepb.WorkspaceSchedulerSetting
+		disabled bool
+	}{
+		{setting: &storepb.WorkspaceSchedulerSetting{}, disabled: true},
+		{setting: &storepb.WorkspaceSchedulerSetting{Tasks: []*storepb.ScheduledTask{{Name: "other"}}}, disabled: true},
+		{setting: &storepb.WorkspaceSchedulerSetting{Tasks: []*storepb.ScheduledTask{{Name: "resource_gc"}}}, disabled: false},
+		{setting: &storepb.WorkspaceSchedulerSetting{Tasks: []*storepb.ScheduledTask{{Name: "resource_gc", Disabled: true}}}, disabled: true},
+	}
+	for _, test := range tests {
+		_, disabled := getSchedule(test.setting, task)
+		require.Equal(t, test.disabled, disabled)
+	}
+}
@@ -0,0 +1,322 @@
+// Package seed generates the demo data of the workspace in configurable volumes, e.g. to measure the performance
+// of the server against a large workspace before a release.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// batchSize is the number of the memos created in a transaction.
+const batchSize = 200
+
+// Options are the volumes of the generated data.
+type Options struct {
+	// Users is the number of the users, the existing users of the usernames are reused.
+	Users int
+	// Memos is the number of the memos of each user.
+	Memos int
+	// ResourceRatio is the ratio of the memos with a resource, from 0 to 1.
+	ResourceRatio float64
+	// Tags is the number of the tags used by each user.
+	Tags int
+	// Days is the number of the days before now over which the memos are created.
+	Days int
+	// Seed is the seed of the random data, the same seed generates the same data.
+	Seed int64
+	// UsernamePrefix is the prefix of the usernames of the users, e.g. `seed` for `seed1`, `seed2`, ...
+	UsernamePrefix string
+	// Password is the password of the generated users.
+	Password string
+}
+
+// Result is the number of the generated models.
+type Result struct {
+	Users     int
+	Memos     int
+	Resources int
+	Tags      int
+	Relations int
+	Reactions int
+}
+
+type seeder struct {
+	store   *store.Store
+	options *Options
+	random  *rand.Rand
+	now     time.Time
+	result  *Result
+}
+
+// Seed generates the users of the options and their memos, with the tags, the resources, the relations and the reactions.
+// The progress is called with the number of the memos generated after each batch.
+func Seed(ctx context.Context, s *store.Store, options *Options, progress func(memos int)) (*Result, error) {
+	if options.Users < 1 || options.Memos < 0 || options.Tags < 0 || options.Days < 1 {
+		return nil, errors.New("there must be at least a user and a day, and the numbers can't be negative")
+	}
+	if options.ResourceRatio < 0 || options.ResourceRatio > 1 {
+		return nil, errors.New("resource ratio must be from 0 to 1")
+	}
+	seeder := &seeder{
+		store:   s,
+		options: options,
+		random:  rand.New(rand.NewPCG(uint64(options.Seed), uint64(options.Seed))),
+		now:     time.Now(),
+		result:  &Result{},
+	}
+	users, err := seeder.seedUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		tags, err := seeder.seedTags(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		for created := 0; created < options.Memos; created += batchSize {
+			count := min(batchSize, options.Memos-created)
+			if err := s.RunInTx(ctx, func(txStore *store.Store) error {
+				return seeder.seedMemos(ctx, txStore, user, users, tags, count)
+			}); err != nil {
+				return nil, errors.Wrap(err, "failed to create memos")
+			}
+			if progress != nil {
+				progress(seeder.result.Memos)
+			}
+		}
+	}
+	return seeder.result, nil
+}
+
+func (s *seeder) seedUsers(ctx context.Context) ([]*store.User, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(s.options.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate password hash")
+	}
+	users := []*store.User{}
+	for i := 1; i <= s.options.Users; i++ {
+		username := fmt.Sprintf("%s%d", s.options.UsernamePrefix, i)
+		user, err := s.store.GetUser(ctx, &store.FindUser{Username: &username})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find user")
+		}
+		if user == nil {
+			firstName, lastName := pick(s.random, firstNames), pick(s.random, lastNames)
+			user, err = s.store.CreateUser(ctx, &store.User{
+				Username:     username,
+				Role:         store.RoleUser,
+				Email:        fmt.Sprintf("%s@example.com", username),
+				Nickname:     firstName + " " + lastName,
+				PasswordHash: string(passwordHash),
+				Description:  s.sentence(),
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create user %s", username)
+			}
+			s.result.Users++
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// seedTags returns the tags of the user, which are picked from the topics, and numbered once the topics run out.
+func (s *seeder) seedTags(ctx context.Context, user *store.User) ([]string, error) {
+	tags := []string{}
+	for _, index := range s.random.Perm(max(s.options.Tags, len(topics)))[:s.options.Tags] {
+		tag := topics[index%len(topics)]
+		if index >= len(topics) {
+			tag = fmt.Sprintf("%s/%d", tag, index/len(topics))
+		}
+		if _, err := s.store.UpsertTag(ctx, &store.Tag{Name: tag, CreatorID: user.ID}); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert tag")
+		}
+		tags = append(tags, tag)
+	}
+	s.result.Tags += len(tags)
+	return tags, nil
+}
+
+func (s *seeder) seedMemos(ctx context.Context, txStore *store.Store, user *store.User, users []*store.User, tags []string, count int) error {
+	memos := []*store.Memo{}
+	for i := 0; i < count; i++ {
+		memo, err := txStore.CreateMemo(ctx, &store.Memo{
+			UID:        shortuuid.New(),
+			CreatorID:  user.ID,
+			Content:    s.content(tags),
+			Visibility: s.visibility(),
+		})
+		if err != nil {
+			return err
+		}
+		// The memos are spread over the days, and some of them are edited afterwards.
+		createdTs := s.now.Unix() - s.random.Int64N(int64(s.options.Days)*86400)
+		updatedTs := createdTs
+		if s.random.IntN(4) == 0 {
+			updatedTs = min(createdTs+s.random.Int64N(7*86400), s.now.Unix())
+		}
+		if err := txStore.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs, UpdatedTs: &updatedTs}); err != nil {
+			return err
+		}
+		memo.CreatedTs, memo.UpdatedTs = createdTs, updatedTs
+		s.result.Memos++
+
+		if s.random.IntN(50) == 0 {
+			if _, err := txStore.UpsertMemoOrganizer(ctx, &store.MemoOrganizer{MemoID: memo.ID, UserID: user.ID, Pinned: true}); err != nil {
+				return err
+			}
+		}
+		if s.random.Float64() < s.options.ResourceRatio {
+			if _, err := txStore.CreateResource(ctx, s.resource(user, memo)); err != nil {
+				return err
+			}
+			s.result.Resources++
+		}
+		if len(memos) > 0 && s.random.IntN(20) == 0 {
+			if _, err := txStore.UpsertMemoRelation(ctx, &store.MemoRelation{
+				MemoID:        memo.ID,
+				RelatedMemoID: memos[s.random.IntN(len(memos))].ID,
+				Type:          store.MemoRelationReference,
+			}); err != nil {
+				return err
+			}
+			s.result.Relations++
+		}
+		if memo.Visibility != store.Private && s.random.IntN(10) == 0 {
+			if _, err := txStore.UpsertReaction(ctx, &storepb.Reaction{
+				CreatorId:    users[s.random.IntN(len(users))].ID,
+				ContentId:    fmt.Sprintf("memos/%d", memo.ID),
+				ReactionType: storepb.Reaction_Type(1 + s.random.IntN(len(storepb.Reaction_Type_name)-1)),
+			}); err != nil {
+				return err
+			}
+			s.result.Reactions++
+		}
+		memos = append(memos, memo)
+	}
+	return nil
+}
+
+// visibility returns the visibility of a memo, most of the memos are private.
+func (s *seeder) visibility() store.Visibility {
+	switch n := s.random.IntN(20); {
+	case n < 12:
+		return store.Private
+	case n < 17:
+		return store.Protected
+	default:
+		return store.Public
+	}
+}
+
+// content returns the Markdown content of a memo, of the paragraphs, the lists, the tasks, the code and the links
+// the memos usually have.
+func (s *seeder) content(tags []string) string {
+	blocks := []string{}
+	for range 1 + s.random.IntN(3) {
+		paragraph := []string{}
+		for range 1 + s.random.IntN(4) {
+			paragraph = append(paragraph, s.sentence())
+		}
+		blocks = append(blocks, strings.Join(paragraph, " "))
+	}
+	switch s.random.IntN(10) {
+	case 0:
+		items := []string{}
+		for range 1 + s.random.IntN(4) {
+			items = append(items, "- "+s.phrase())
+		}
+		blocks = append(blocks, strings.Join(items, "\n"))
+	case 1:
+		tasks := []string{}
+		for range 1 + s.random.IntN(4) {
+			done := " "
+			if s.random.IntN(2) == 0 {
+				done = "x"
+			}
+			tasks = append(tasks, fmt.Sprintf("- [%s] %s", done, s.phrase()))
+		}
+		blocks = append(blocks, strings.Join(tasks, "\n"))
+	case 2:
+		blocks = append(blocks, fmt.Sprintf("```go\nfunc %s() error {\n\treturn nil\n}\n```", pick(s.random, words)))
+	case 3:
+		blocks = append(blocks, fmt.Sprintf("[%s](https://example.com/%s)", s.phrase(), pick(s.random, words)))
+	}
+	if len(tags) > 0 {
+		memoTags := []string{}
+		for range s.random.IntN(4) {
+			memoTags = append(memoTags, "#"+pick(s.random, tags))
+		}
+		if len(memoTags) > 0 {
+			blocks = append(blocks, strings.Join(memoTags, " "))
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func (s *seeder) resource(user *store.User, memo *store.Memo) *store.Resource {
+	name := pick(s.random, words)
+	var filename, resourceType string
+	var blob []byte
+	if s.random.IntN(2) == 0 {
+		filename, resourceType = name+".txt", "text/plain"
+		blob = []byte(s.content(nil))
+	} else {
+		filename, resourceType = name+".svg", "image/svg+xml"
+		blob = []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="64" height="64"><rect width="64" height="64" fill="#%06x"/></svg>`, s.random.IntN(1<<24)))
+	}
+	return &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+		Filename:  filename,
+		Blob:      blob,
+		Type:      resourceType,
+		Size:      int64(len(blob)),
+		MemoID:    &memo.ID,
+	}
+}
+
+func (s *seeder) sentence() string {
+	sentence := s.phrase()
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+func (s *seeder) phrase() string {
+	phrase := []string{}
+	for range 3 + s.random.IntN(10) {
+		phrase = append(phrase, pick(s.random, words))
+	}
+	return strings.Join(phrase, " ")
+}
+
+func pick(random *rand.Rand, values []string) string {
+	return values[random.IntN(len(values))]
+}
+
+var (
+	firstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Frances", "Donald", "Radia", "Edsger", "Hedy", "Niklaus", "Joan", "Guido"}
+	lastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Allen", "Knuth", "Perlman", "Dijkstra", "Lamarr", "Wirth", "Clarke", "Rossum"}
+	topics     = []string{
+		"work", "ideas", "reading", "books", "journal", "todo", "travel", "recipes", "health", "fitness",
+		"music", "movies", "projects", "golang", "design", "meeting", "family", "finance", "learning", "quotes",
+		"garden", "photography", "writing", "research", "shopping", "home", "productivity", "podcasts", "games", "weekly",
+	}
+	words = []string{
+		"the", "a", "note", "idea", "about", "with", "meeting", "project", "review", "draft", "plan", "today", "tomorrow",
+		"read", "write", "call", "team", "design", "server", "release", "memo", "book", "chapter", "coffee", "walk",
+		"morning", "evening", "weekend", "list", "check", "update", "fix", "bug", "feature", "question", "answer",
+		"remember", "later", "important", "quick", "thought", "from", "into", "after", "before", "while", "small",
+		"large", "new", "old", "good", "better", "simple", "complex", "data", "search", "cache", "garden", "recipe",
+		"trip", "city", "music", "song", "paper", "article", "summary", "goal", "habit", "sleep", "run", "learn",
+	}
+)
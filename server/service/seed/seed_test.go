@@ -0,0 +1,61 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	options := &Options{
+		Users:          2,
+		Memos:          250,
+		ResourceRatio:  0.5,
+		Tags:           40,
+		Days:           30,
+		Seed:           1,
+		UsernamePrefix: "seed",
+		Password:       "secret",
+	}
+	progress := []int{}
+	result, err := Seed(ctx, ts, options, func(memos int) {
+		progress = append(progress, memos)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Users)
+	require.Equal(t, 500, result.Memos)
+	require.Equal(t, 80, result.Tags)
+	require.NotZero(t, result.Resources)
+	require.Equal(t, []int{200, 250, 450, 500}, progress)
+
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 500)
+	for _, memo := range memos {
+		require.NotEmpty(t, memo.Content)
+		require.GreaterOrEqual(t, memo.UpdatedTs, memo.CreatedTs)
+	}
+	resources, err := ts.ListResources(ctx, &store.FindResource{})
+	require.NoError(t, err)
+	require.Len(t, resources, result.Resources)
+	tags, err := ts.ListTags(ctx, &store.FindTag{CreatorID: memos[0].CreatorID})
+	require.NoError(t, err)
+	require.Len(t, tags, 40)
+
+	// The users are reused.
+	options.Users, options.Memos = 3, 1
+	result, err = Seed(ctx, ts, options, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Users)
+	require.Equal(t, 3, result.Memos)
+
+	_, err = Seed(ctx, ts, &Options{Users: 1, Days: 1, ResourceRatio: 2}, nil)
+	require.Error(t, err)
+}
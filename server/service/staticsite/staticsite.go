@@ -0,0 +1,237 @@
+// Package staticsite renders the public memos as the content tree of a static site generator, Hugo or Jekyll.
+package staticsite
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// FormatHugo is the content tree of Hugo, the posts are in content/memos and the assets in static/resources.
+	FormatHugo = "hugo"
+	// FormatJekyll is the content tree of Jekyll, the posts are in _posts and the assets in assets/resources.
+	FormatJekyll = "jekyll"
+)
+
+// Formats are the supported formats of the site.
+var Formats = []string{FormatHugo, FormatJekyll}
+
+// maxTitleLength is the number of the characters of the first line used as the title of a post.
+const maxTitleLength = 80
+
+var (
+	memoReferenceRegexp = regexp.MustCompile(`\[\[memos/([a-zA-Z0-9-]+)(?:\?([^\]]*))?\]\]`)
+	resourceEmbedRegexp = regexp.MustCompile(`!\[\[resources/([a-zA-Z0-9-]+)(?:\?[^\]]*)?\]\]`)
+	filenameReplacer    = strings.NewReplacer("/", "_", "\\", "_")
+)
+
+// Result is the number of the written files.
+type Result struct {
+	Posts  int `json:"posts"`
+	Assets int `json:"assets"`
+}
+
+// Site is the content tree of the exported memos and resources.
+// The references between the exported memos are linked to their permalinks, the other references are kept as their texts.
+type Site struct {
+	format    string
+	memoUIDs  map[string]bool
+	resources map[string]*store.Resource
+}
+
+type frontMatter struct {
+	Title          string   `yaml:"title"`
+	Date           string   `yaml:"date"`
+	Lastmod        string   `yaml:"lastmod,omitempty"`
+	LastModifiedAt string   `yaml:"last_modified_at,omitempty"`
+	Layout         string   `yaml:"layout,omitempty"`
+	Tags           []string `yaml:"tags,omitempty"`
+	URL            string   `yaml:"url,omitempty"`
+	Permalink      string   `yaml:"permalink,omitempty"`
+	Pinned         bool     `yaml:"pinned,omitempty"`
+}
+
+// New returns the site of the memos and the resources in the format.
+func New(format string, memos []*store.Memo, resources []*store.Resource) (*Site, error) {
+	if format != FormatHugo && format != FormatJekyll {
+		return nil, errors.Errorf("unsupported site format %q", format)
+	}
+	site := &Site{
+		format:    format,
+		memoUIDs:  map[string]bool{},
+		resources: map[string]*store.Resource{},
+	}
+	for _, memo := range memos {
+		site.memoUIDs[memo.UID] = true
+	}
+	for _, resource := range resources {
+		site.resources[resource.UID] = resource
+	}
+	return site, nil
+}
+
+// Permalink returns the permalink of the memo, which is derived from its uid so it doesn't change when the memo is edited.
+func (s *Site) Permalink(memo *store.Memo) string {
+	return "/memos/" + memo.UID + "/"
+}
+
+// PostPath returns the path of the file of the post of the memo.
+func (s *Site) PostPath(memo *store.Memo) string {
+	if s.format == FormatJekyll {
+		// The posts of Jekyll are named after their dates.
+		return "_posts/" + time.Unix(memo.CreatedTs, 0).UTC().Format("2006-01-02") + "-" + memo.UID + ".md"
+	}
+	return "content/memos/" + memo.UID + ".md"
+}
+
+// AssetPath returns the path of the file of the resource, or empty if the resource is a link to an external file.
+func (s *Site) AssetPath(resource *store.Resource) string {
+	if resource.ExternalLink != "" {
+		return ""
+	}
+	if s.format == FormatJekyll {
+		return "assets/resources/" + resource.UID + "/" + getAssetFilename(resource)
+	}
+	return "static/resources/" + resource.UID + "/" + getAssetFilename(resource)
+}
+
+// AssetURL returns the URL of the resource on the site.
+func (s *Site) AssetURL(resource *store.Resource) string {
+	if resource.ExternalLink != "" {
+		return resource.ExternalLink
+	}
+	filename := url.PathEscape(getAssetFilename(resource))
+	if s.format == FormatJekyll {
+		return "/assets/resources/" + resource.UID + "/" + filename
+	}
+	return "/resources/" + resource.UID + "/" + filename
+}
+
+// RenderPost returns the file of the post of the memo, which is the front matter followed by the content.
+// The resources of the memo which aren't embedded in the content are appended to it.
+func (s *Site) RenderPost(memo *store.Memo, tags []string, resources []*store.Resource) ([]byte, error) {
+	title, content := getPostTitle(memo)
+	embeddedUIDs := map[string]bool{}
+	content = replaceOutsideCodeBlocks(content, func(line string) string {
+		line = resourceEmbedRegexp.ReplaceAllStringFunc(line, func(embed string) string {
+			resource := s.resources[resourceEmbedRegexp.FindStringSubmatch(embed)[1]]
+			if resource == nil {
+				return ""
+			}
+			embeddedUIDs[resource.UID] = true
+			return s.formatAsset(resource)
+		})
+		return memoReferenceRegexp.ReplaceAllStringFunc(line, func(reference string) string {
+			matches := memoReferenceRegexp.FindStringSubmatch(reference)
+			text := matches[1]
+			if query, err := url.ParseQuery(matches[2]); err == nil && query.Get("text") != "" {
+				text = query.Get("text")
+			}
+			if !s.memoUIDs[matches[1]] {
+				return text
+			}
+			return "[" + text + "](" + s.Permalink(&store.Memo{UID: matches[1]}) + ")"
+		})
+	})
+	attachments := []string{}
+	for _, resource := range resources {
+		if !embeddedUIDs[resource.UID] {
+			attachments = append(attachments, s.formatAsset(resource))
+		}
+	}
+	content = strings.TrimSpace(content)
+	if len(attachments) > 0 {
+		content = strings.TrimSpace(content + "\n\n" + strings.Join(attachments, "\n"))
+	}
+
+	matter := &frontMatter{
+		Title:  title,
+		Date:   time.Unix(memo.CreatedTs, 0).UTC().Format(time.RFC3339),
+		Tags:   tags,
+		Pinned: memo.Pinned,
+	}
+	updated := ""
+	if memo.UpdatedTs > memo.CreatedTs {
+		updated = time.Unix(memo.UpdatedTs, 0).UTC().Format(time.RFC3339)
+	}
+	if s.format == FormatJekyll {
+		matter.LastModifiedAt = updated
+		matter.Layout = "post"
+		matter.Permalink = s.Permalink(memo)
+	} else {
+		matter.Lastmod = updated
+		matter.URL = s.Permalink(memo)
+	}
+	data, err := yaml.Marshal(matter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal front matter")
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString("---\n")
+	buf.Write(data)
+	buf.WriteString("---\n\n")
+	buf.WriteString(content)
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// formatAsset returns the Markdown of the resource, an image for the images and a link for the other files.
+func (s *Site) formatAsset(resource *store.Resource) string {
+	link := "[" + resource.Filename + "](" + s.AssetURL(resource) + ")"
+	if strings.HasPrefix(resource.Type, "image/") {
+		return "!" + link
+	}
+	return link
+}
+
+// getPostTitle returns the title of the memo and the content of the post.
+// A heading of the first level on the first line is the title, and it's removed from the content since the layouts show the titles.
+func getPostTitle(memo *store.Memo) (string, string) {
+	content := strings.TrimSpace(strings.ReplaceAll(memo.Content, "\r\n", "\n"))
+	firstLine, rest, _ := strings.Cut(content, "\n")
+	if title, ok := strings.CutPrefix(firstLine, "# "); ok && strings.TrimSpace(title) != "" {
+		return strings.TrimSpace(title), strings.TrimSpace(rest)
+	}
+	title := strings.TrimSpace(strings.TrimLeft(firstLine, "#>-*"))
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		title = string([]rune(title)[:maxTitleLength]) + "…"
+	}
+	if title == "" {
+		title = memo.UID
+	}
+	return title, content
+}
+
+func getAssetFilename(resource *store.Resource) string {
+	filename := filenameReplacer.Replace(path.Base(resource.Filename))
+	if filename == "" || filename == "." || filename == ".." {
+		return resource.UID
+	}
+	return filename
+}
+
+// replaceOutsideCodeBlocks replaces the lines of the content which aren't in the fenced code blocks.
+func replaceOutsideCodeBlocks(content string, replace func(line string) string) string {
+	lines := strings.Split(content, "\n")
+	inCodeBlock := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if !inCodeBlock {
+			lines[i] = replace(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package staticsite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestRenderPost(t *testing.T) {
+	memo := &store.Memo{
+		UID:       "plan",
+		CreatedTs: 1704103200,
+		UpdatedTs: 1704189600,
+		Content:   "# Plan\n\nSee [[memos/ideas?text=the+ideas]] and [[memos/secret]].\n\n![[resources/chart]]\n\n```\n[[memos/ideas]]\n```",
+		Pinned:    true,
+	}
+	chart := &store.Resource{UID: "chart", Filename: "chart 1.png", Type: "image/png"}
+	notes := &store.Resource{UID: "notes", Filename: "notes.pdf", Type: "application/pdf"}
+	site, err := New(FormatHugo, []*store.Memo{memo, {UID: "ideas"}}, []*store.Resource{chart, notes})
+	require.NoError(t, err)
+
+	require.Equal(t, "content/memos/plan.md", site.PostPath(memo))
+	require.Equal(t, "static/resources/chart/chart 1.png", site.AssetPath(chart))
+	data, err := site.RenderPost(memo, []string{"work"}, []*store.Resource{chart, notes})
+	require.NoError(t, err)
+	// The references to the memos which aren't exported are kept as texts, the resources which aren't embedded are appended.
+	require.Equal(t, `---
+title: Plan
+date: "2024-01-01T10:00:00Z"
+lastmod: "2024-01-02T10:00:00Z"
+tags:
+    - work
+url: /memos/plan/
+pinned: true
+---
+
+See [the ideas](/memos/ideas/) and secret.
+
+![chart 1.png](/resources/chart/chart%201.png)
+
+`+"```\n[[memos/ideas]]\n```"+`
+
+[notes.pdf](/resources/notes/notes.pdf)
+`, string(data))
+
+	site, err = New(FormatJekyll, []*store.Memo{memo}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "_posts/2024-01-01-plan.md", site.PostPath(memo))
+	data, err = site.RenderPost(&store.Memo{UID: "note", CreatedTs: 1704103200, Content: "A short note #work"}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "---\ntitle: 'A short note #work'\ndate: \"2024-01-01T10:00:00Z\"\nlayout: post\npermalink: /memos/note/\n---\n\nA short note #work\n", string(data))
+
+	_, err = New("gatsby", nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,199 @@
+package webhookdispatcher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/i18n"
+	"github.com/usememos/memos/internal/telemetry"
+	"github.com/usememos/memos/internal/workerpool"
+	"github.com/usememos/memos/plugin/webhook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// dispatchInterval is the interval of checking for due deliveries.
+	dispatchInterval = 5 * time.Second
+	// dispatchBatchSize is the max number of deliveries attempted in a dispatch.
+	dispatchBatchSize = 20
+	// MaxDeliveryAttempts is the number of attempts after which a delivery is failed.
+	MaxDeliveryAttempts = 10
+	// initialRetryDelay is the delay before the first retry, which is doubled for every following retry.
+	initialRetryDelay = 30 * time.Second
+)
+
+// Enqueue queues the payload rendered with the payload template of the webhook for delivery.
+// The delivery is attempted by the dispatcher, so it doesn't block the caller.
+// The titles of the presets are in the locale of the creator of the webhook.
+func Enqueue(ctx context.Context, s *store.Store, hook *storepb.Webhook, payload *webhook.WebhookPayload) error {
+	payload.URL = hook.Url
+	locale, err := s.GetUserLocale(ctx, hook.CreatorId)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user locale")
+	}
+	payload.Title = i18n.NewLocalizer(locale).T("webhook." + payload.ActivityType)
+	body, err := webhook.Render(hook.PayloadTemplate, payload)
+	if err != nil {
+		return err
+	}
+	if _, err := s.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID:     hook.Id,
+		ActivityType:  payload.ActivityType,
+		Payload:       string(body),
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: time.Now().Unix(),
+	}); err != nil {
+		return errors.Wrap(err, "failed to create webhook delivery")
+	}
+	return nil
+}
+
+// Dispatcher delivers the queued webhook payloads, and retries the failed deliveries with exponential backoff.
+// The deliveries are run on the worker pool, so a slow webhook doesn't hold up the deliveries to the other hosts.
+type Dispatcher struct {
+	Store *store.Store
+	Pool  *workerpool.Pool
+}
+
+func NewDispatcher(store *store.Store, pool *workerpool.Pool) *Dispatcher {
+	return &Dispatcher{
+		Store: store,
+		Pool:  pool,
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// The replicas sharing the store take turns, so a delivery isn't attempted by two of them at once.
+		if !d.Store.ClaimJobRun(ctx, "webhook_dispatch", dispatchInterval) {
+			continue
+		}
+		if err := telemetry.TraceJob(ctx, "webhook_dispatch", d.Dispatch); err != nil {
+			slog.Warn("Failed to dispatch webhook deliveries", slog.Any("err", err))
+		}
+	}
+}
+
+// Dispatch attempts the deliveries which are due, and waits for them, so a delivery isn't attempted again
+// by the next dispatch before its result is recorded.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	pendingStatus, now, limit := store.WebhookDeliveryPending, time.Now().Unix(), dispatchBatchSize
+	deliveries, err := d.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &now,
+		Limit:               &limit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list webhook deliveries")
+	}
+
+	attempts := make([]*attempt, len(deliveries))
+	for i, delivery := range deliveries {
+		hook, err := d.Store.GetWebhooks(ctx, &store.FindWebhook{ID: &delivery.WebhookID})
+		if err != nil {
+			return errors.Wrap(err, "failed to find webhook")
+		}
+		attempts[i] = d.start(ctx, delivery, hook)
+	}
+	var updateErr error
+	for i, attempt := range attempts {
+		attempt.err = <-attempt.done
+		// The delivery isn't attempted once the server is stopping, it stays pending.
+		if errors.Is(attempt.err, workerpool.ErrStopped) {
+			continue
+		}
+		if err := d.finish(ctx, deliveries[i], attempt); err != nil && updateErr == nil {
+			updateErr = err
+		}
+	}
+	return updateErr
+}
+
+// attempt is an attempt of a delivery, its status code is set before its error is received from done.
+type attempt struct {
+	statusCode int
+	// final is true if the delivery isn't retried once the attempt fails.
+	final bool
+	done  <-chan error
+	err   error
+}
+
+// start submits the delivery of the payload to the webhook to the worker pool.
+func (d *Dispatcher) start(ctx context.Context, delivery *store.WebhookDelivery, hook *storepb.Webhook) *attempt {
+	attempt := &attempt{}
+	if hook == nil {
+		done := make(chan error, 1)
+		done <- errors.New("webhook not found")
+		attempt.done, attempt.final = done, true
+		return attempt
+	}
+	attempt.done = d.Pool.Submit(ctx, getDestination(hook.Url), func(ctx context.Context) error {
+		var err error
+		attempt.statusCode, err = webhook.Deliver(ctx, hook.Url, []byte(delivery.Payload), hook.Secret, fmt.Sprint(delivery.ID))
+		return err
+	})
+	return attempt
+}
+
+// finish records the result of the attempt of the delivery, and schedules its retry if it failed.
+func (d *Dispatcher) finish(ctx context.Context, delivery *store.WebhookDelivery, attempt *attempt) error {
+	now := time.Now().Unix()
+	attempts := delivery.Attempts + 1
+	if attempt.final {
+		attempts = MaxDeliveryAttempts
+	}
+	update := &store.UpdateWebhookDelivery{
+		ID:        delivery.ID,
+		UpdatedTs: &now,
+		Attempts:  &attempts,
+	}
+
+	lastStatusCode, lastError := int32(attempt.statusCode), ""
+	update.LastStatusCode = &lastStatusCode
+	update.LastError = &lastError
+	status := store.WebhookDeliverySucceeded
+	if err := attempt.err; err != nil {
+		lastError = err.Error()
+		status = store.WebhookDeliveryPending
+		if attempts >= MaxDeliveryAttempts {
+			status = store.WebhookDeliveryFailed
+		} else {
+			nextAttemptTs := time.Now().Add(getRetryDelay(attempts)).Unix()
+			update.NextAttemptTs = &nextAttemptTs
+		}
+	}
+	update.Status = &status
+	if err := d.Store.UpdateWebhookDelivery(ctx, update); err != nil {
+		return errors.Wrap(err, "failed to update webhook delivery")
+	}
+	return nil
+}
+
+// getDestination returns the host of the URL, which the deliveries to are limited by the worker pool.
+func getDestination(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
+}
+
+// getRetryDelay returns the delay before the next attempt after the given number of attempts.
+func getRetryDelay(attempts int32) time.Duration {
+	return initialRetryDelay << (attempts - 1)
+}
@@ -0,0 +1,19 @@
+package webhookdispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRetryDelay(t *testing.T) {
+	require.Equal(t, 30*time.Second, getRetryDelay(1))
+	require.Equal(t, time.Minute, getRetryDelay(2))
+	require.Equal(t, 128*time.Minute, getRetryDelay(MaxDeliveryAttempts-1))
+}
+
+func TestGetDestination(t *testing.T) {
+	require.Equal(t, "example.com:8080", getDestination("https://example.com:8080/hooks/memos?token=1"))
+	require.Equal(t, "example.com", getDestination("http://example.com"))
+}
@@ -0,0 +1,467 @@
+package workspacearchive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// ErrNotEmpty is returned if the workspace to import into already has content.
+var ErrNotEmpty = errors.New("workspace is not empty")
+
+// The workspace settings which belong to the instance rather than the workspace, they're not imported.
+var skippedWorkspaceSettings = []string{"secret-session", "server-id"}
+
+// Result is the number of the imported items.
+type Result struct {
+	Users     int `json:"users"`
+	Groups    int `json:"groups"`
+	Memos     int `json:"memos"`
+	Resources int `json:"resources"`
+	Relations int `json:"relations"`
+	Reactions int `json:"reactions"`
+}
+
+// Import imports the archive into a fresh workspace, which has no memos and no users except the host.
+// The host of the archive replaces the current host, so the session of the host stays valid.
+// The items get new ids, the references between them are remapped.
+// The import runs in a transaction, so nothing is imported if it fails.
+func Import(ctx context.Context, s *store.Store, archive *Archive, hostUserID int32) (*Result, error) {
+	if archive.Version != Version {
+		return nil, errors.Errorf("unsupported archive version %d", archive.Version)
+	}
+	var result *Result
+	if err := s.RunInTx(ctx, func(txStore *store.Store) error {
+		var err error
+		result, err = importArchive(ctx, txStore, archive, hostUserID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func importArchive(ctx context.Context, s *store.Store, archive *Archive, hostUserID int32) (*Result, error) {
+	users, err := s.ListUsers(ctx, &store.FindUser{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+	memos, err := s.ListMemos(ctx, &store.FindMemo{Limit: &[]int{1}[0]})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memos")
+	}
+	if len(memos) > 0 || len(users) != 1 || users[0].ID != hostUserID {
+		return nil, ErrNotEmpty
+	}
+
+	result := &Result{}
+	userIDs, err := importUsers(ctx, s, archive, hostUserID)
+	if err != nil {
+		return nil, err
+	}
+	result.Users = len(userIDs)
+	// mapUserID returns the new id of the user, or 0 if the user isn't in the archive.
+	mapUserID := func(id int32) int32 {
+		return userIDs[id]
+	}
+
+	storageIDs := map[int32]int32{}
+	for _, storage := range archive.Storages {
+		created, err := s.CreateStorage(ctx, &store.Storage{
+			Name:   storage.Name,
+			Type:   storage.Type,
+			Config: storage.Config,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create storage %q", storage.Name)
+		}
+		storageIDs[storage.ID] = created.ID
+	}
+
+	if err := importWorkspaceSettings(ctx, s, archive, storageIDs, mapUserID); err != nil {
+		return nil, err
+	}
+
+	for _, identityProvider := range archive.IdentityProviders {
+		if _, err := s.CreateIdentityProvider(ctx, &store.IdentityProvider{
+			Name:             identityProvider.Name,
+			Type:             store.IdentityProviderType(identityProvider.Type),
+			IdentifierFilter: identityProvider.IdentifierFilter,
+			Config: &store.IdentityProviderConfig{
+				OAuth2Config: identityProvider.OAuth2Config,
+			},
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to create identity provider %q", identityProvider.Name)
+		}
+	}
+
+	for _, value := range archive.UserSettings {
+		userSetting := &storepb.UserSetting{}
+		if err := protojson.Unmarshal(value, userSetting); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal user setting")
+		}
+		if userSetting.Key == storepb.UserSettingKey_USER_SETTING_ACCESS_TOKENS {
+			continue
+		}
+		userSetting.UserId = mapUserID(userSetting.UserId)
+		if userSetting.UserId == 0 {
+			continue
+		}
+		if _, err := s.UpsertUserSetting(ctx, userSetting); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert user setting")
+		}
+	}
+
+	groupIDs, err := importUserGroups(ctx, s, archive, mapUserID)
+	if err != nil {
+		return nil, err
+	}
+	result.Groups = len(groupIDs)
+
+	// Memos are created in the order of the ids, so the new ids keep the order.
+	archiveMemos := slices.Clone(archive.Memos)
+	slices.SortFunc(archiveMemos, func(a, b *Memo) int {
+		return int(a.ID - b.ID)
+	})
+	memoIDs := map[int32]int32{}
+	for _, memo := range archiveMemos {
+		creatorID := mapUserID(memo.CreatorID)
+		if creatorID == 0 {
+			return nil, errors.Errorf("creator %d of memo %d not found", memo.CreatorID, memo.ID)
+		}
+		visibility := store.Visibility(memo.Visibility)
+		var groupID *int32
+		if memo.GroupID != nil {
+			if id, ok := groupIDs[*memo.GroupID]; ok {
+				groupID = &id
+			}
+		}
+		// The memos of a group which isn't in the archive are kept private, like the ones of a deleted group.
+		if visibility == store.Group && groupID == nil {
+			visibility = store.Private
+		}
+		created, err := s.CreateMemo(ctx, &store.Memo{
+			UID:        memo.UID,
+			CreatorID:  creatorID,
+			Content:    memo.Content,
+			Visibility: visibility,
+			GroupID:    groupID,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create memo %d", memo.ID)
+		}
+		rowStatus := store.RowStatus(memo.RowStatus)
+		if err := s.UpdateMemo(ctx, &store.UpdateMemo{
+			ID:        created.ID,
+			CreatedTs: &memo.CreatedTs,
+			UpdatedTs: &memo.UpdatedTs,
+			RowStatus: &rowStatus,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to update memo %d", memo.ID)
+		}
+		memoIDs[memo.ID] = created.ID
+	}
+	result.Memos = len(memoIDs)
+
+	for _, memoOrganizer := range archive.MemoOrganizers {
+		memoID, userID := memoIDs[memoOrganizer.MemoID], mapUserID(memoOrganizer.UserID)
+		if memoID == 0 || userID == 0 {
+			continue
+		}
+		if _, err := s.UpsertMemoOrganizer(ctx, &store.MemoOrganizer{
+			MemoID: memoID,
+			UserID: userID,
+			Pinned: memoOrganizer.Pinned,
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert memo organizer")
+		}
+	}
+
+	for _, memoRelation := range archive.MemoRelations {
+		memoID, relatedMemoID := memoIDs[memoRelation.MemoID], memoIDs[memoRelation.RelatedMemoID]
+		if memoID == 0 || relatedMemoID == 0 {
+			continue
+		}
+		if _, err := s.UpsertMemoRelation(ctx, &store.MemoRelation{
+			MemoID:        memoID,
+			RelatedMemoID: relatedMemoID,
+			Type:          store.MemoRelationType(memoRelation.Type),
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert memo relation")
+		}
+		result.Relations++
+	}
+
+	for _, resource := range archive.Resources {
+		creatorID := mapUserID(resource.CreatorID)
+		if creatorID == 0 {
+			return nil, errors.Errorf("creator %d of resource %d not found", resource.CreatorID, resource.ID)
+		}
+		var memoID *int32
+		if resource.MemoID != nil {
+			if id, ok := memoIDs[*resource.MemoID]; ok {
+				memoID = &id
+			}
+		}
+		created, err := s.CreateResource(ctx, &store.Resource{
+			UID:          resource.UID,
+			CreatorID:    creatorID,
+			Filename:     resource.Filename,
+			Blob:         resource.Blob,
+			InternalPath: resource.InternalPath,
+			ExternalLink: resource.ExternalLink,
+			Type:         resource.Type,
+			Size:         resource.Size,
+			MemoID:       memoID,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create resource %d", resource.ID)
+		}
+		if _, err := s.UpdateResource(ctx, &store.UpdateResource{
+			ID:        created.ID,
+			UpdatedTs: &resource.UpdatedTs,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to update resource %d", resource.ID)
+		}
+		result.Resources++
+	}
+
+	for _, tag := range archive.Tags {
+		creatorID := mapUserID(tag.CreatorID)
+		if creatorID == 0 {
+			continue
+		}
+		if _, err := s.UpsertTag(ctx, &store.Tag{
+			Name:      tag.Name,
+			CreatorID: creatorID,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to upsert tag %q", tag.Name)
+		}
+	}
+
+	for _, reaction := range archive.Reactions {
+		creatorID := mapUserID(reaction.CreatorID)
+		contentID, ok := mapContentID(reaction.ContentID, memoIDs)
+		if creatorID == 0 || !ok {
+			continue
+		}
+		if _, err := s.UpsertReaction(ctx, &storepb.Reaction{
+			CreatorId:    creatorID,
+			ContentId:    contentID,
+			ReactionType: storepb.Reaction_Type(storepb.Reaction_Type_value[reaction.ReactionType]),
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert reaction")
+		}
+		result.Reactions++
+	}
+
+	for _, webhook := range archive.Webhooks {
+		creatorID := mapUserID(webhook.CreatorID)
+		if creatorID == 0 {
+			continue
+		}
+		created, err := s.CreateWebhook(ctx, &storepb.Webhook{
+			CreatorId:       creatorID,
+			Name:            webhook.Name,
+			Url:             webhook.URL,
+			Secret:          webhook.Secret,
+			PayloadTemplate: webhook.PayloadTemplate,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create webhook %q", webhook.Name)
+		}
+		if rowStatus := storepb.RowStatus(storepb.RowStatus_value[webhook.RowStatus]); rowStatus != created.RowStatus {
+			if _, err := s.UpdateWebhook(ctx, &store.UpdateWebhook{
+				ID:        created.Id,
+				RowStatus: &rowStatus,
+			}); err != nil {
+				return nil, errors.Wrapf(err, "failed to update webhook %q", webhook.Name)
+			}
+		}
+	}
+
+	for _, incomingWebhook := range archive.IncomingWebhooks {
+		creatorID := mapUserID(incomingWebhook.CreatorID)
+		if creatorID == 0 {
+			continue
+		}
+		if _, err := s.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+			CreatorID:  creatorID,
+			Name:       incomingWebhook.Name,
+			Token:      incomingWebhook.Token,
+			Visibility: store.Visibility(incomingWebhook.Visibility),
+			Tags:       incomingWebhook.Tags,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to create incoming webhook %q", incomingWebhook.Name)
+		}
+	}
+
+	return result, nil
+}
+
+// importUsers imports the users and returns the new ids by the ids of the archive.
+func importUsers(ctx context.Context, s *store.Store, archive *Archive, hostUserID int32) (map[int32]int32, error) {
+	archiveUsers := slices.Clone(archive.Users)
+	slices.SortFunc(archiveUsers, func(a, b *User) int {
+		return int(a.ID - b.ID)
+	})
+	userIDs := map[int32]int32{}
+	hostImported := false
+	for _, user := range archiveUsers {
+		update := &store.UpdateUser{
+			Username:     &user.Username,
+			Email:        &user.Email,
+			Nickname:     &user.Nickname,
+			PasswordHash: &user.PasswordHash,
+			AvatarURL:    &user.AvatarURL,
+			Description:  &user.Description,
+		}
+		if store.Role(user.Role) == store.RoleHost && !hostImported {
+			update.ID = hostUserID
+			hostImported = true
+		} else {
+			created, err := s.CreateUser(ctx, &store.User{
+				Username:     user.Username,
+				Role:         store.Role(user.Role),
+				Email:        user.Email,
+				Nickname:     user.Nickname,
+				PasswordHash: user.PasswordHash,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create user %q", user.Username)
+			}
+			update.ID = created.ID
+			update.Username = nil
+			update.Email = nil
+			update.Nickname = nil
+			update.PasswordHash = nil
+		}
+		rowStatus := store.RowStatus(user.RowStatus)
+		update.RowStatus = &rowStatus
+		update.UpdatedTs = &user.UpdatedTs
+		if _, err := s.UpdateUser(ctx, update); err != nil {
+			return nil, errors.Wrapf(err, "failed to update user %q", user.Username)
+		}
+		userIDs[user.ID] = update.ID
+	}
+	return userIDs, nil
+}
+
+// importUserGroups imports the groups and their members, and returns the new ids of the groups by the ids of the archive.
+func importUserGroups(ctx context.Context, s *store.Store, archive *Archive, mapUserID func(int32) int32) (map[int32]int32, error) {
+	groupIDs := map[int32]int32{}
+	for _, userGroup := range archive.UserGroups {
+		creatorID := mapUserID(userGroup.CreatorID)
+		if creatorID == 0 {
+			continue
+		}
+		created, err := s.CreateUserGroup(ctx, &store.UserGroup{
+			CreatorID:   creatorID,
+			Name:        userGroup.Name,
+			Description: userGroup.Description,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create user group %q", userGroup.Name)
+		}
+		if _, err := s.UpdateUserGroup(ctx, &store.UpdateUserGroup{
+			ID:        created.ID,
+			UpdatedTs: &userGroup.UpdatedTs,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to update user group %q", userGroup.Name)
+		}
+		groupIDs[userGroup.ID] = created.ID
+	}
+
+	for _, userGroupMember := range archive.UserGroupMembers {
+		groupID, userID := groupIDs[userGroupMember.GroupID], mapUserID(userGroupMember.UserID)
+		if groupID == 0 || userID == 0 {
+			continue
+		}
+		if _, err := s.UpsertUserGroupMember(ctx, &store.UserGroupMember{
+			GroupID: groupID,
+			UserID:  userID,
+			Role:    store.UserGroupRole(userGroupMember.Role),
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to upsert user group member")
+		}
+	}
+	return groupIDs, nil
+}
+
+func importWorkspaceSettings(ctx context.Context, s *store.Store, archive *Archive, storageIDs map[int32]int32, mapUserID func(int32) int32) error {
+	for _, setting := range archive.WorkspaceSettings {
+		if slices.Contains(skippedWorkspaceSettings, setting.Name) {
+			continue
+		}
+		value := setting.Value
+		// The storage service id is -1 for the local storage, 0 for the database.
+		if setting.Name == "storage-service-id" {
+			storageID, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.Wrap(err, "invalid storage service id")
+			}
+			if newID, ok := storageIDs[int32(storageID)]; ok {
+				value = strconv.Itoa(int(newID))
+			}
+		}
+		if _, err := s.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+			Name:        setting.Name,
+			Value:       value,
+			Description: setting.Description,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to upsert workspace setting %q", setting.Name)
+		}
+	}
+
+	// Reload the settings to refresh the cache, and remap the users of the upload restrictions.
+	workspaceSettings, err := s.ListWorkspaceSettingsV1(ctx, &store.FindWorkspaceSettingV1{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list workspace settings")
+	}
+	for _, workspaceSetting := range workspaceSettings {
+		storageSetting := workspaceSetting.GetStorage()
+		if storageSetting == nil || len(storageSetting.UploadRestrictions) == 0 {
+			continue
+		}
+		uploadRestrictions := []*storepb.UploadRestriction{}
+		for _, uploadRestriction := range storageSetting.UploadRestrictions {
+			if uploadRestriction.UserId != 0 {
+				uploadRestriction.UserId = mapUserID(uploadRestriction.UserId)
+				if uploadRestriction.UserId == 0 {
+					continue
+				}
+			}
+			uploadRestrictions = append(uploadRestrictions, uploadRestriction)
+		}
+		storageSetting.UploadRestrictions = uploadRestrictions
+		if _, err := s.UpsertWorkspaceSettingV1(ctx, workspaceSetting); err != nil {
+			return errors.Wrap(err, "failed to upsert workspace storage setting")
+		}
+	}
+	return nil
+}
+
+// mapContentID returns the content id with the new id of the memo, e.g. memos/101.
+func mapContentID(contentID string, memoIDs map[int32]int32) (string, bool) {
+	name, idString, ok := strings.Cut(contentID, "/")
+	if !ok || name != "memos" {
+		return "", false
+	}
+	id, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return "", false
+	}
+	newID, ok := memoIDs[int32(id)]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("memos/%d", newID), true
+}
@@ -0,0 +1,394 @@
+// Package workspacearchive exports the whole workspace as a portable JSON archive, and imports it into a fresh instance.
+// The archive doesn't depend on the database driver, so it can be used to move an instance, e.g. from SQLite to PostgreSQL.
+package workspacearchive
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// Version is the version of the archive format, it's increased on incompatible changes.
+const Version = 1
+
+// Archive is the content of the workspace.
+// The ids are the ones of the exported instance, the import creates new ones and remaps the references.
+type Archive struct {
+	Version int `json:"version"`
+	// MemosVersion is the version of the exported instance.
+	MemosVersion string `json:"memosVersion"`
+	CreatedTs    int64  `json:"createdTs"`
+
+	WorkspaceSettings []*WorkspaceSetting `json:"workspaceSettings"`
+	Storages          []*Storage          `json:"storages"`
+	IdentityProviders []*IdentityProvider `json:"identityProviders"`
+	Users             []*User             `json:"users"`
+	// UserSettings are the store user settings encoded with protojson.
+	UserSettings     []json.RawMessage  `json:"userSettings"`
+	UserGroups       []*UserGroup       `json:"userGroups"`
+	UserGroupMembers []*UserGroupMember `json:"userGroupMembers"`
+	Memos            []*Memo            `json:"memos"`
+	MemoOrganizers   []*MemoOrganizer   `json:"memoOrganizers"`
+	MemoRelations    []*MemoRelation    `json:"memoRelations"`
+	Resources        []*Resource        `json:"resources"`
+	Tags             []*Tag             `json:"tags"`
+	Reactions        []*Reaction        `json:"reactions"`
+	Webhooks         []*Webhook         `json:"webhooks"`
+	IncomingWebhooks []*IncomingWebhook `json:"incomingWebhooks"`
+}
+
+type WorkspaceSetting struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+type Storage struct {
+	ID     int32  `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Config string `json:"config"`
+}
+
+type IdentityProvider struct {
+	Name             string                              `json:"name"`
+	Type             string                              `json:"type"`
+	IdentifierFilter string                              `json:"identifierFilter"`
+	OAuth2Config     *store.IdentityProviderOAuth2Config `json:"oauth2Config"`
+}
+
+type User struct {
+	ID           int32  `json:"id"`
+	RowStatus    string `json:"rowStatus"`
+	CreatedTs    int64  `json:"createdTs"`
+	UpdatedTs    int64  `json:"updatedTs"`
+	Username     string `json:"username"`
+	Role         string `json:"role"`
+	Email        string `json:"email"`
+	Nickname     string `json:"nickname"`
+	PasswordHash string `json:"passwordHash"`
+	AvatarURL    string `json:"avatarUrl"`
+	Description  string `json:"description"`
+}
+
+type UserGroup struct {
+	ID          int32  `json:"id"`
+	CreatorID   int32  `json:"creatorId"`
+	CreatedTs   int64  `json:"createdTs"`
+	UpdatedTs   int64  `json:"updatedTs"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UserGroupMember struct {
+	GroupID int32  `json:"groupId"`
+	UserID  int32  `json:"userId"`
+	Role    string `json:"role"`
+}
+
+type Memo struct {
+	ID         int32  `json:"id"`
+	UID        string `json:"uid"`
+	RowStatus  string `json:"rowStatus"`
+	CreatorID  int32  `json:"creatorId"`
+	CreatedTs  int64  `json:"createdTs"`
+	UpdatedTs  int64  `json:"updatedTs"`
+	Content    string `json:"content"`
+	Visibility string `json:"visibility"`
+	// GroupID is the group of the GROUP visibility.
+	GroupID *int32 `json:"groupId"`
+}
+
+type MemoOrganizer struct {
+	MemoID int32 `json:"memoId"`
+	UserID int32 `json:"userId"`
+	Pinned bool  `json:"pinned"`
+}
+
+type MemoRelation struct {
+	MemoID        int32  `json:"memoId"`
+	RelatedMemoID int32  `json:"relatedMemoId"`
+	Type          string `json:"type"`
+}
+
+// Resource is the metadata of a resource. The blob is only included if it's stored in the database,
+// the local files of the internal path must be copied along with the archive.
+type Resource struct {
+	ID           int32  `json:"id"`
+	UID          string `json:"uid"`
+	CreatorID    int32  `json:"creatorId"`
+	CreatedTs    int64  `json:"createdTs"`
+	UpdatedTs    int64  `json:"updatedTs"`
+	Filename     string `json:"filename"`
+	Blob         []byte `json:"blob,omitempty"`
+	InternalPath string `json:"internalPath"`
+	ExternalLink string `json:"externalLink"`
+	Type         string `json:"type"`
+	Size         int64  `json:"size"`
+	MemoID       *int32 `json:"memoId"`
+}
+
+type Tag struct {
+	Name      string `json:"name"`
+	CreatorID int32  `json:"creatorId"`
+}
+
+type Reaction struct {
+	CreatorID int32 `json:"creatorId"`
+	// ContentID is the name of the content, e.g. memos/101.
+	ContentID    string `json:"contentId"`
+	ReactionType string `json:"reactionType"`
+}
+
+type Webhook struct {
+	CreatorID       int32  `json:"creatorId"`
+	RowStatus       string `json:"rowStatus"`
+	Name            string `json:"name"`
+	URL             string `json:"url"`
+	Secret          string `json:"secret"`
+	PayloadTemplate string `json:"payloadTemplate"`
+}
+
+type IncomingWebhook struct {
+	CreatorID  int32    `json:"creatorId"`
+	Name       string   `json:"name"`
+	Token      string   `json:"token"`
+	Visibility string   `json:"visibility"`
+	Tags       []string `json:"tags"`
+}
+
+// Export returns the archive of the workspace.
+func Export(ctx context.Context, s *store.Store, memosVersion string) (*Archive, error) {
+	archive := &Archive{
+		Version:      Version,
+		MemosVersion: memosVersion,
+		CreatedTs:    time.Now().Unix(),
+	}
+
+	workspaceSettings, err := s.ListWorkspaceSettings(ctx, &store.FindWorkspaceSetting{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list workspace settings")
+	}
+	for _, setting := range workspaceSettings {
+		archive.WorkspaceSettings = append(archive.WorkspaceSettings, &WorkspaceSetting{
+			Name:        setting.Name,
+			Value:       setting.Value,
+			Description: setting.Description,
+		})
+	}
+
+	storages, err := s.ListStorages(ctx, &store.FindStorage{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list storages")
+	}
+	for _, storage := range storages {
+		archive.Storages = append(archive.Storages, &Storage{
+			ID:     storage.ID,
+			Name:   storage.Name,
+			Type:   storage.Type,
+			Config: storage.Config,
+		})
+	}
+
+	identityProviders, err := s.ListIdentityProviders(ctx, &store.FindIdentityProvider{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list identity providers")
+	}
+	for _, identityProvider := range identityProviders {
+		item := &IdentityProvider{
+			Name:             identityProvider.Name,
+			Type:             identityProvider.Type.String(),
+			IdentifierFilter: identityProvider.IdentifierFilter,
+		}
+		if identityProvider.Config != nil {
+			item.OAuth2Config = identityProvider.Config.OAuth2Config
+		}
+		archive.IdentityProviders = append(archive.IdentityProviders, item)
+	}
+
+	users, err := s.ListUsers(ctx, &store.FindUser{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+	for _, user := range users {
+		archive.Users = append(archive.Users, &User{
+			ID:           user.ID,
+			RowStatus:    string(user.RowStatus),
+			CreatedTs:    user.CreatedTs,
+			UpdatedTs:    user.UpdatedTs,
+			Username:     user.Username,
+			Role:         string(user.Role),
+			Email:        user.Email,
+			Nickname:     user.Nickname,
+			PasswordHash: user.PasswordHash,
+			AvatarURL:    user.AvatarURL,
+			Description:  user.Description,
+		})
+	}
+
+	userSettings, err := s.ListUserSettings(ctx, &store.FindUserSetting{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user settings")
+	}
+	for _, userSetting := range userSettings {
+		// The access tokens are signed with the secret of the instance, so they can't be used by another one.
+		if userSetting.Key == storepb.UserSettingKey_USER_SETTING_ACCESS_TOKENS {
+			continue
+		}
+		value, err := protojson.Marshal(userSetting)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal user setting")
+		}
+		archive.UserSettings = append(archive.UserSettings, value)
+	}
+
+	userGroups, err := s.ListUserGroups(ctx, &store.FindUserGroup{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user groups")
+	}
+	for _, userGroup := range userGroups {
+		archive.UserGroups = append(archive.UserGroups, &UserGroup{
+			ID:          userGroup.ID,
+			CreatorID:   userGroup.CreatorID,
+			CreatedTs:   userGroup.CreatedTs,
+			UpdatedTs:   userGroup.UpdatedTs,
+			Name:        userGroup.Name,
+			Description: userGroup.Description,
+		})
+	}
+
+	userGroupMembers, err := s.ListUserGroupMembers(ctx, &store.FindUserGroupMember{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user group members")
+	}
+	for _, userGroupMember := range userGroupMembers {
+		archive.UserGroupMembers = append(archive.UserGroupMembers, &UserGroupMember{
+			GroupID: userGroupMember.GroupID,
+			UserID:  userGroupMember.UserID,
+			Role:    userGroupMember.Role.String(),
+		})
+	}
+
+	memos, err := s.ListMemos(ctx, &store.FindMemo{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memos")
+	}
+	for _, memo := range memos {
+		archive.Memos = append(archive.Memos, &Memo{
+			ID:         memo.ID,
+			UID:        memo.UID,
+			RowStatus:  string(memo.RowStatus),
+			CreatorID:  memo.CreatorID,
+			CreatedTs:  memo.CreatedTs,
+			UpdatedTs:  memo.UpdatedTs,
+			Content:    memo.Content,
+			Visibility: string(memo.Visibility),
+			GroupID:    memo.GroupID,
+		})
+	}
+
+	memoOrganizers, err := s.ListMemoOrganizer(ctx, &store.FindMemoOrganizer{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo organizers")
+	}
+	for _, memoOrganizer := range memoOrganizers {
+		archive.MemoOrganizers = append(archive.MemoOrganizers, &MemoOrganizer{
+			MemoID: memoOrganizer.MemoID,
+			UserID: memoOrganizer.UserID,
+			Pinned: memoOrganizer.Pinned,
+		})
+	}
+
+	memoRelations, err := s.ListMemoRelations(ctx, &store.FindMemoRelation{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo relations")
+	}
+	for _, memoRelation := range memoRelations {
+		archive.MemoRelations = append(archive.MemoRelations, &MemoRelation{
+			MemoID:        memoRelation.MemoID,
+			RelatedMemoID: memoRelation.RelatedMemoID,
+			Type:          string(memoRelation.Type),
+		})
+	}
+
+	resources, err := s.ListResources(ctx, &store.FindResource{GetBlob: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list resources")
+	}
+	for _, resource := range resources {
+		archive.Resources = append(archive.Resources, &Resource{
+			ID:           resource.ID,
+			UID:          resource.UID,
+			CreatorID:    resource.CreatorID,
+			CreatedTs:    resource.CreatedTs,
+			UpdatedTs:    resource.UpdatedTs,
+			Filename:     resource.Filename,
+			Blob:         resource.Blob,
+			InternalPath: resource.InternalPath,
+			ExternalLink: resource.ExternalLink,
+			Type:         resource.Type,
+			Size:         resource.Size,
+			MemoID:       resource.MemoID,
+		})
+	}
+
+	tags, err := s.ListTags(ctx, &store.FindTag{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tags")
+	}
+	for _, tag := range tags {
+		archive.Tags = append(archive.Tags, &Tag{
+			Name:      tag.Name,
+			CreatorID: tag.CreatorID,
+		})
+	}
+
+	reactions, err := s.ListReactions(ctx, &store.FindReaction{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list reactions")
+	}
+	for _, reaction := range reactions {
+		archive.Reactions = append(archive.Reactions, &Reaction{
+			CreatorID:    reaction.CreatorId,
+			ContentID:    reaction.ContentId,
+			ReactionType: reaction.ReactionType.String(),
+		})
+	}
+
+	webhooks, err := s.ListWebhooks(ctx, &store.FindWebhook{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhooks")
+	}
+	for _, webhook := range webhooks {
+		archive.Webhooks = append(archive.Webhooks, &Webhook{
+			CreatorID:       webhook.CreatorId,
+			RowStatus:       webhook.RowStatus.String(),
+			Name:            webhook.Name,
+			URL:             webhook.Url,
+			Secret:          webhook.Secret,
+			PayloadTemplate: webhook.PayloadTemplate,
+		})
+	}
+
+	incomingWebhooks, err := s.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list incoming webhooks")
+	}
+	for _, incomingWebhook := range incomingWebhooks {
+		archive.IncomingWebhooks = append(archive.IncomingWebhooks, &IncomingWebhook{
+			CreatorID:  incomingWebhook.CreatorID,
+			Name:       incomingWebhook.Name,
+			Token:      incomingWebhook.Token,
+			Visibility: string(incomingWebhook.Visibility),
+			Tags:       incomingWebhook.Tags,
+		})
+	}
+
+	return archive, nil
+}
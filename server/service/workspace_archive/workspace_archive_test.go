@@ -0,0 +1,172 @@
+package workspacearchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/test/store"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	host, err := ts.CreateUser(ctx, &store.User{
+		Username: "host",
+		Role:     store.RoleHost,
+		Email:    "host@test.com",
+		Nickname: "Host",
+	})
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "user",
+		Role:     store.RoleUser,
+		Nickname: "User",
+	})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "memo",
+		CreatorID:  user.ID,
+		Content:    "test content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	comment, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "comment",
+		CreatorID:  host.ID,
+		Content:    "test comment",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	group, err := ts.CreateUserGroup(ctx, &store.UserGroup{CreatorID: host.ID, Name: "team"})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserGroupMember(ctx, &store.UserGroupMember{GroupID: group.ID, UserID: host.ID, Role: store.UserGroupRoleOwner})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserGroupMember(ctx, &store.UserGroupMember{GroupID: group.ID, UserID: user.ID, Role: store.UserGroupRoleMember})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "group-memo",
+		CreatorID:  user.ID,
+		Content:    "test group content",
+		Visibility: store.Group,
+		GroupID:    &group.ID,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        comment.ID,
+		RelatedMemoID: memo.ID,
+		Type:          store.MemoRelationComment,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       "resource",
+		CreatorID: user.ID,
+		Filename:  "test.txt",
+		Blob:      []byte("test blob"),
+		Type:      "text/plain",
+		Size:      9,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertReaction(ctx, &storepb.Reaction{
+		CreatorId:    host.ID,
+		ContentId:    fmt.Sprintf("memos/%d", memo.ID),
+		ReactionType: storepb.Reaction_HEART,
+	})
+	require.NoError(t, err)
+	archive, err := Export(ctx, ts, "0.22.0")
+	require.NoError(t, err)
+	ts.Close()
+
+	// The archive is imported through JSON, as it's done by the API.
+	data, err := json.Marshal(archive)
+	require.NoError(t, err)
+	archive = &Archive{}
+	require.NoError(t, json.Unmarshal(data, archive))
+
+	ts = teststore.NewTestingStore(ctx, t)
+	newHost, err := ts.CreateUser(ctx, &store.User{
+		Username: "new_host",
+		Role:     store.RoleHost,
+	})
+	require.NoError(t, err)
+	result, err := Import(ctx, ts, archive, newHost.ID)
+	require.NoError(t, err)
+	require.Equal(t, &Result{Users: 2, Groups: 1, Memos: 3, Resources: 1, Relations: 1, Reactions: 1}, result)
+
+	importedHost, err := ts.GetUser(ctx, &store.FindUser{ID: &newHost.ID})
+	require.NoError(t, err)
+	require.Equal(t, "host", importedHost.Username)
+	require.Equal(t, "host@test.com", importedHost.Email)
+	username := "user"
+	importedUser, err := ts.GetUser(ctx, &store.FindUser{Username: &username})
+	require.NoError(t, err)
+	require.NotNil(t, importedUser)
+	uid := "memo"
+	importedMemo, err := ts.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	require.NoError(t, err)
+	require.Equal(t, importedUser.ID, importedMemo.CreatorID)
+	require.Equal(t, memo.CreatedTs, importedMemo.CreatedTs)
+	resources, err := ts.ListResources(ctx, &store.FindResource{MemoID: &importedMemo.ID, GetBlob: true})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Equal(t, []byte("test blob"), resources[0].Blob)
+	memoRelations, err := ts.ListMemoRelations(ctx, &store.FindMemoRelation{RelatedMemoID: &importedMemo.ID})
+	require.NoError(t, err)
+	require.Len(t, memoRelations, 1)
+
+	name := "team"
+	importedGroup, err := ts.GetUserGroup(ctx, &store.FindUserGroup{Name: &name})
+	require.NoError(t, err)
+	require.NotNil(t, importedGroup)
+	require.Equal(t, newHost.ID, importedGroup.CreatorID)
+	member, err := ts.GetUserGroupMember(ctx, importedGroup.ID, importedUser.ID)
+	require.NoError(t, err)
+	require.NotNil(t, member)
+	require.Equal(t, store.UserGroupRoleMember, member.Role)
+	uid = "group-memo"
+	importedGroupMemo, err := ts.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	require.NoError(t, err)
+	require.Equal(t, store.Group, importedGroupMemo.Visibility)
+	require.Equal(t, importedGroup.ID, *importedGroupMemo.GroupID)
+
+	// The workspace isn't fresh anymore.
+	_, err = Import(ctx, ts, archive, newHost.ID)
+	require.ErrorIs(t, err, ErrNotEmpty)
+	ts.Close()
+}
+
+// TestImportRollback tests nothing is imported if the import fails.
+func TestImportRollback(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	host, err := ts.CreateUser(ctx, &store.User{
+		Username: "host",
+		Role:     store.RoleHost,
+	})
+	require.NoError(t, err)
+	archive := &Archive{
+		Version: Version,
+		Users: []*User{
+			{ID: 1, Username: "archived_host", Role: store.RoleHost.String(), RowStatus: store.Normal.String()},
+			{ID: 2, Username: "user", Role: store.RoleUser.String(), RowStatus: store.Normal.String()},
+		},
+		// The creator of the memo isn't in the archive.
+		Memos: []*Memo{{ID: 1, UID: "memo", CreatorID: 3, Visibility: store.Public.String()}},
+	}
+	_, err = Import(ctx, ts, archive, host.ID)
+	require.Error(t, err)
+
+	users, err := ts.ListUsers(ctx, &store.FindUser{})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	importedHost, err := ts.GetUser(ctx, &store.FindUser{ID: &host.ID})
+	require.NoError(t, err)
+	require.Equal(t, "host", importedHost.Username)
+}
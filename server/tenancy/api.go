@@ -0,0 +1,201 @@
+package tenancy
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// adminPrefix is the prefix of the paths of the super-admin API.
+const adminPrefix = "/api/tenancy"
+
+// instanceHealthPaths are the paths of the probes of the instance, which are served by the tenants on their hostnames.
+var instanceHealthPaths = []string{"/healthz", "/livez", "/readyz"}
+
+type UpsertTenantRequest struct {
+	Slug      string   `json:"slug"`
+	Name      string   `json:"name"`
+	Hostnames []string `json:"hostnames"`
+}
+
+// isAdminPath returns true if the path is of the super-admin API or of the probes of the instance.
+func isAdminPath(path string) bool {
+	return path == adminPrefix || strings.HasPrefix(path, adminPrefix+"/") || slices.Contains(instanceHealthPaths, path)
+}
+
+func (m *Manager) registerRoutes(e *echo.Echo) {
+	e.GET("/healthz", func(c echo.Context) error {
+		return c.String(http.StatusOK, "Service ready.")
+	})
+	e.GET("/livez", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	// The instance is ready once the tenants are started, their own dependencies are checked by their probes.
+	e.GET("/readyz", func(c echo.Context) error {
+		if m.shuttingDown.Load() {
+			return c.String(http.StatusServiceUnavailable, "[-]shutdown failed: reason withheld\nreadyz check failed\n")
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+
+	g := e.Group(adminPrefix, m.authenticate)
+	g.GET("/tenants", m.listTenants)
+	g.POST("/tenants", m.createTenant)
+	g.PATCH("/tenants/:slug", m.updateTenant)
+	g.DELETE("/tenants/:slug", m.deleteTenant)
+}
+
+// authenticate checks the bearer token of the super-admin API, which is disabled without the token of the profile.
+func (m *Manager) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if m.profile.TenancyAdminToken == "" {
+			return echo.NewHTTPError(http.StatusForbidden, "the super-admin API is disabled, set --tenancy-admin-token to enable it")
+		}
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(m.profile.TenancyAdminToken)) != 1 {
+			return echo.NewHTTPError(http.StatusUnauthorized, "invalid super-admin token")
+		}
+		return next(c)
+	}
+}
+
+func (m *Manager) listTenants(c echo.Context) error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return c.JSON(http.StatusOK, m.records())
+}
+
+func (m *Manager) createTenant(c echo.Context) error {
+	request := &UpsertTenantRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted create tenant request").SetInternal(err)
+	}
+	record := &Tenant{
+		Slug:      request.Slug,
+		Name:      request.Name,
+		Hostnames: request.Hostnames,
+		CreatedTs: time.Now().Unix(),
+	}
+	if err := record.validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// The requests wait for the new tenant to be started, which is as long as the migration of its database.
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.indexOf(record.Slug) >= 0 {
+		return echo.NewHTTPError(http.StatusConflict, "the slug is taken by another tenant")
+	}
+	if err := m.checkConflicts(record); err != nil {
+		return err
+	}
+	// The directory of a deleted tenant isn't reused, so its data isn't served to the new one.
+	if _, err := os.Stat(m.tenantProfile(record.Slug).Data); err == nil {
+		return echo.NewHTTPError(http.StatusConflict, "the data directory of the slug exists, it's of a deleted tenant")
+	}
+	t, err := m.startTenant(m.ctx, record)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start tenant").SetInternal(err)
+	}
+	m.tenants = append(m.tenants, t)
+	if err := saveRegistry(filepath.Join(m.profile.Data, registryFile), m.records()); err != nil {
+		m.tenants = m.tenants[:len(m.tenants)-1]
+		t.shutdown(context.Background())
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save tenant registry").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, record)
+}
+
+func (m *Manager) updateTenant(c echo.Context) error {
+	request := &UpsertTenantRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Malformatted update tenant request").SetInternal(err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	index := m.indexOf(c.Param("slug"))
+	if index < 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "tenant not found")
+	}
+	current := m.tenants[index].Tenant
+	record := &Tenant{
+		Slug:      current.Slug,
+		Name:      request.Name,
+		Hostnames: request.Hostnames,
+		CreatedTs: current.CreatedTs,
+	}
+	if err := record.validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := m.checkConflicts(record); err != nil {
+		return err
+	}
+	m.tenants[index].Tenant = record
+	if err := saveRegistry(filepath.Join(m.profile.Data, registryFile), m.records()); err != nil {
+		m.tenants[index].Tenant = current
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save tenant registry").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, record)
+}
+
+// deleteTenant stops the server of the tenant and removes it from the registry. Its data directory is kept unless `purge` is set.
+func (m *Manager) deleteTenant(c echo.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	index := m.indexOf(c.Param("slug"))
+	if index < 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "tenant not found")
+	}
+	t := m.tenants[index]
+	m.tenants = slices.Delete(m.tenants, index, index+1)
+	if err := saveRegistry(filepath.Join(m.profile.Data, registryFile), m.records()); err != nil {
+		m.tenants = slices.Insert(m.tenants, index, t)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save tenant registry").SetInternal(err)
+	}
+	t.shutdown(c.Request().Context())
+	if c.QueryParam("purge") == "true" {
+		if err := os.RemoveAll(m.tenantProfile(t.Slug).Data); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to purge tenant data").SetInternal(err)
+		}
+	}
+	return c.JSON(http.StatusOK, true)
+}
+
+// checkConflicts checks the hostnames of the tenant aren't of another one.
+func (m *Manager) checkConflicts(record *Tenant) error {
+	for _, t := range m.tenants {
+		if t.Slug == record.Slug {
+			continue
+		}
+		for _, hostname := range record.Hostnames {
+			if slices.Contains(t.Hostnames, hostname) {
+				return echo.NewHTTPError(http.StatusConflict, "the hostname "+hostname+" is of another tenant")
+			}
+		}
+	}
+	return nil
+}
+
+// indexOf returns the index of the tenant of the slug, -1 if there isn't one.
+func (m *Manager) indexOf(slug string) int {
+	return slices.IndexFunc(m.tenants, func(t *tenant) bool {
+		return t.Slug == slug
+	})
+}
+
+// records returns the tenants of the registry, the caller holds the mutex.
+func (m *Manager) records() []*Tenant {
+	records := []*Tenant{}
+	for _, t := range m.tenants {
+		records = append(records, t.Tenant)
+	}
+	return records
+}
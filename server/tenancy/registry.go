@@ -0,0 +1,80 @@
+package tenancy
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	slugPattern     = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,30}[a-z0-9])?$`)
+	hostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]{0,251}[a-z0-9])?$`)
+)
+
+// Tenant is a workspace of the tenancy, with its own users, memos, settings and data directory.
+type Tenant struct {
+	// Slug identifies the tenant, it's the name of its data directory and selects it by the `/w/<slug>` path.
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// Hostnames select the tenant by the Host header of the requests, e.g. `family.memos.example.com`.
+	Hostnames []string `json:"hostnames"`
+	CreatedTs int64    `json:"createdTs"`
+}
+
+// validate normalizes the hostnames of the tenant and checks its slug and hostnames.
+func (t *Tenant) validate() error {
+	if !slugPattern.MatchString(t.Slug) {
+		return errors.Errorf("invalid slug %q, it must be 1 to 32 lowercase letters, digits or hyphens", t.Slug)
+	}
+	hostnames := []string{}
+	for _, hostname := range t.Hostnames {
+		hostname = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+		if !hostnamePattern.MatchString(hostname) {
+			return errors.Errorf("invalid hostname %q", hostname)
+		}
+		hostnames = append(hostnames, hostname)
+	}
+	t.Hostnames = hostnames
+	return nil
+}
+
+// loadRegistry reads the tenants of the registry file, none if it doesn't exist yet.
+func loadRegistry(path string) ([]*Tenant, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []*Tenant{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read tenant registry")
+	}
+	tenants := []*Tenant{}
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant registry")
+	}
+	return tenants, nil
+}
+
+// saveRegistry writes the tenants to the registry file, which is replaced at once so a crash doesn't leave it partial.
+func saveRegistry(path string, tenants []*Tenant) error {
+	data, err := json.MarshalIndent(tenants, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tenants-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to write tenant registry")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write tenant registry")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write tenant registry")
+	}
+	return os.Rename(tmp.Name(), path)
+}
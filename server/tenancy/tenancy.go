@@ -0,0 +1,257 @@
+// Package tenancy serves the isolated workspaces of the tenants on a single instance. Every tenant has its own server,
+// with its own SQLite database and data directory under `tenants/<slug>` of the data directory, and it's selected by
+// the hostnames of the tenant, or by the `/w/<slug>` path, which is remembered by a cookie for the paths of the app.
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/handoff"
+	"github.com/usememos/memos/server"
+	"github.com/usememos/memos/server/profile"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+)
+
+const (
+	// pathPrefix is the prefix of the paths selecting a tenant by its slug.
+	pathPrefix = "/w/"
+	// cookieName is the cookie remembering the tenant selected by the path, so the paths of the app without the prefix are served by it.
+	cookieName = "memos_tenant"
+	// registryFile is the file of the tenants in the data directory.
+	registryFile = "tenants.json"
+	// tenantsDir is the directory of the data directories of the tenants in the data directory.
+	tenantsDir = "tenants"
+)
+
+// tenant is a tenant of the registry, and the server of its workspace once it's started.
+type tenant struct {
+	*Tenant
+	server *server.Server
+	// handler serves the requests of the tenant, the echo of its server.
+	handler http.Handler
+	cancel  context.CancelFunc
+}
+
+// Manager routes the requests to the servers of the tenants, and manages the tenants with the super-admin API.
+type Manager struct {
+	profile *profile.Profile
+	// admin serves the super-admin API and the probes of the instance.
+	admin      *echo.Echo
+	httpServer *http.Server
+
+	// ctx is the context of the servers of the tenants, including the ones created by the API.
+	ctx context.Context
+	// mutex guards the tenants, and serializes the changes of the registry.
+	mutex   sync.RWMutex
+	tenants []*tenant
+	// shuttingDown is set once the instance is shutting down, so it isn't ready for the new requests.
+	shuttingDown atomic.Bool
+}
+
+func NewManager(profile *profile.Profile) *Manager {
+	m := &Manager{
+		profile: profile,
+		admin:   echo.New(),
+	}
+	m.admin.HideBanner = true
+	m.admin.HidePort = true
+	m.registerRoutes(m.admin)
+	return m
+}
+
+// Start starts the servers of the tenants of the registry, and serves them until the instance is shut down.
+func (m *Manager) Start(ctx context.Context) error {
+	m.ctx = ctx
+	records, err := loadRegistry(filepath.Join(m.profile.Data, registryFile))
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		t, err := m.startTenant(ctx, record)
+		if err != nil {
+			return errors.Wrapf(err, "failed to start tenant %s", record.Slug)
+		}
+		m.tenants = append(m.tenants, t)
+	}
+
+	listener, err := handoff.Listen("http", fmt.Sprintf("%s:%d", m.profile.Addr, m.profile.Port), m.profile.ReusePort)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen")
+	}
+	m.httpServer = &http.Server{
+		Handler:           m,
+		ReadHeaderTimeout: 30 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	if err := handoff.Ready(); err != nil {
+		slog.Warn("failed to finish handoff", slog.Any("err", err))
+	}
+	return m.httpServer.Serve(listener)
+}
+
+// Shutdown drains the in-flight requests, and shuts the servers of the tenants down.
+func (m *Manager) Shutdown(ctx context.Context) {
+	m.shuttingDown.Store(true)
+	if m.httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(ctx, m.profile.ShutdownTimeout)
+		if err := m.httpServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("failed to shutdown server", slog.Any("err", err))
+		}
+		cancel()
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, t := range m.tenants {
+		t.shutdown(ctx)
+	}
+	m.tenants = nil
+}
+
+// startTenant opens the database of the tenant, migrates it and starts its server.
+func (m *Manager) startTenant(ctx context.Context, record *Tenant) (*tenant, error) {
+	tenantProfile := m.tenantProfile(record.Slug)
+	if err := os.MkdirAll(tenantProfile.Data, 0770); err != nil {
+		return nil, errors.Wrap(err, "failed to create data directory")
+	}
+	dbDriver, err := db.NewDBDriver(tenantProfile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db driver")
+	}
+	if err := dbDriver.Migrate(ctx); err != nil {
+		dbDriver.Close()
+		return nil, errors.Wrap(err, "failed to migrate database")
+	}
+	storeInstance := store.New(dbDriver, tenantProfile)
+	if err := storeInstance.MigrateManually(ctx); err != nil {
+		storeInstance.Close()
+		return nil, errors.Wrap(err, "failed to migrate manually")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s, err := server.NewServer(ctx, tenantProfile, storeInstance)
+	if err != nil {
+		cancel()
+		storeInstance.Close()
+		return nil, errors.Wrap(err, "failed to create server")
+	}
+	if err := s.StartRunners(ctx); err != nil {
+		cancel()
+		s.Shutdown(context.Background())
+		return nil, err
+	}
+	return &tenant{Tenant: record, server: s, handler: s.GetEcho(), cancel: cancel}, nil
+}
+
+// tenantProfile returns the profile of the server of the tenant. The listeners of the instance aren't started by the
+// servers of the tenants, and the events are published under the subjects and the topics of the tenant.
+func (m *Manager) tenantProfile(slug string) *profile.Profile {
+	tenantProfile := *m.profile
+	tenantProfile.Data = filepath.Join(m.profile.Data, tenantsDir, slug)
+	tenantProfile.DSN = filepath.Join(tenantProfile.Data, fmt.Sprintf("memos_%s.db", m.profile.Mode))
+	tenantProfile.Port = 0
+	tenantProfile.SMTPAddr = ""
+	tenantProfile.PprofAddr = ""
+	// The profiles are of the instance, they aren't served to the hosts of the tenants.
+	tenantProfile.Pprof = false
+	tenantProfile.EventBusSubject = m.profile.EventBusSubject + "." + slug
+	tenantProfile.MQTTTopic = m.profile.MQTTTopic + "/" + slug
+	return &tenantProfile
+}
+
+// shutdown shuts the server of the tenant down, and stops its runners.
+func (t *tenant) shutdown(ctx context.Context) {
+	if t.server != nil {
+		t.server.Shutdown(ctx)
+	}
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
+// ServeHTTP serves the super-admin API and the probes of the instance, and the other requests by the servers of their tenants.
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t := m.findTenantByHost(r.Host)
+	if t == nil {
+		if isAdminPath(r.URL.Path) {
+			m.admin.ServeHTTP(w, r)
+			return
+		}
+		t = m.findTenantByPath(w, r)
+	}
+	if t == nil {
+		http.Error(w, "workspace not found", http.StatusNotFound)
+		return
+	}
+	t.handler.ServeHTTP(w, r)
+}
+
+// findTenantByHost returns the tenant of the hostname of the host, nil if there isn't one.
+func (m *Manager) findTenantByHost(host string) *tenant {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for _, t := range m.tenants {
+		if slices.Contains(t.Hostnames, host) {
+			return t
+		}
+	}
+	return nil
+}
+
+// findTenantByPath returns the tenant of the `/w/<slug>` path, whose prefix is stripped and remembered by the cookie,
+// or the tenant of the cookie for the other paths, nil if there isn't one.
+func (m *Manager) findTenantByPath(w http.ResponseWriter, r *http.Request) *tenant {
+	if strings.HasPrefix(r.URL.Path, pathPrefix) {
+		slug, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, pathPrefix), "/")
+		t := m.findTenant(slug)
+		if t == nil {
+			return nil
+		}
+		r.URL.Path, r.URL.RawPath = "/"+path, ""
+		http.SetCookie(w, &http.Cookie{
+			Name:     cookieName,
+			Value:    slug,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		return t
+	}
+	cookie, err := r.Cookie(cookieName)
+	if err != nil {
+		return nil
+	}
+	return m.findTenant(cookie.Value)
+}
+
+// findTenant returns the tenant of the slug, nil if there isn't one.
+func (m *Manager) findTenant(slug string) *tenant {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for _, t := range m.tenants {
+		if t.Slug == slug {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package tenancy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/server/profile"
+)
+
+func TestServeHTTP(t *testing.T) {
+	m := NewManager(&profile.Profile{TenancyAdminToken: "secret"})
+	for _, slug := range []string{"family", "work"} {
+		m.tenants = append(m.tenants, &tenant{
+			Tenant: &Tenant{Slug: slug, Hostnames: []string{slug + ".memos.example.com"}},
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(slug + " " + r.URL.Path))
+			}),
+		})
+	}
+	serve := func(request *http.Request) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		m.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/memo", nil)
+	request.Host = "Work.memos.example.com:8081"
+	require.Equal(t, "work /api/v1/memo", serve(request).Body.String())
+
+	recorder := serve(httptest.NewRequest(http.MethodGet, "/w/family/explore", nil))
+	require.Equal(t, "family /explore", recorder.Body.String())
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, "family", cookies[0].Value)
+
+	// The paths of the app without the prefix are served by the tenant of the cookie.
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/memo", nil)
+	request.AddCookie(cookies[0])
+	require.Equal(t, "family /api/v1/memo", serve(request).Body.String())
+
+	require.Equal(t, http.StatusNotFound, serve(httptest.NewRequest(http.MethodGet, "/api/v1/memo", nil)).Code)
+	require.Equal(t, http.StatusNotFound, serve(httptest.NewRequest(http.MethodGet, "/w/unknown/", nil)).Code)
+	require.Equal(t, http.StatusUnauthorized, serve(httptest.NewRequest(http.MethodGet, "/api/tenancy/tenants", nil)).Code)
+	request = httptest.NewRequest(http.MethodGet, "/api/tenancy/tenants", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	recorder = serve(request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"slug":"work"`)
+}
+
+func TestRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	tenants, err := loadRegistry(path)
+	require.NoError(t, err)
+	require.Empty(t, tenants)
+
+	record := &Tenant{Slug: "family", Hostnames: []string{" Family.Memos.Example.COM. "}}
+	require.NoError(t, record.validate())
+	require.Equal(t, []string{"family.memos.example.com"}, record.Hostnames)
+	require.NoError(t, saveRegistry(path, []*Tenant{record}))
+	tenants, err = loadRegistry(path)
+	require.NoError(t, err)
+	require.Equal(t, []*Tenant{record}, tenants)
+
+	require.Error(t, (&Tenant{Slug: "Family"}).validate())
+	require.Error(t, (&Tenant{Slug: "-family"}).validate())
+	require.Error(t, (&Tenant{Slug: "family", Hostnames: []string{"memos.example.com:8081"}}).validate())
+}
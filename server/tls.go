@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/usememos/memos/internal/handoff"
+	"github.com/usememos/memos/store"
+)
+
+// certificateCheckInterval is the min interval of checking the files of the TLS certificate for changes.
+const certificateCheckInterval = time.Minute
+
+// certificateLoader loads the TLS certificate from its files, and reloads it once they change, e.g. renewed by certbot.
+type certificateLoader struct {
+	certFile string
+	keyFile  string
+
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	modTime     time.Time
+	checkedAt   time.Time
+}
+
+func newCertificateLoader(certFile, keyFile string) (*certificateLoader, error) {
+	loader := &certificateLoader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := loader.load(); err != nil {
+		return nil, err
+	}
+	return loader, nil
+}
+
+func (l *certificateLoader) load() error {
+	modTime, err := l.getModTime()
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load TLS certificate")
+	}
+	l.certificate, l.modTime = &certificate, modTime
+	return nil
+}
+
+// getModTime returns the latest modification time of the files.
+func (l *certificateLoader) getModTime() (time.Time, error) {
+	modTime := time.Time{}
+	for _, name := range []string{l.certFile, l.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, errors.Wrap(err, "failed to stat TLS certificate")
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+// GetCertificate returns the certificate of the handshakes, the previous one is kept if the changed files fail to load.
+func (l *certificateLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if time.Since(l.checkedAt) < certificateCheckInterval {
+		return l.certificate, nil
+	}
+	l.checkedAt = time.Now()
+	if modTime, err := l.getModTime(); err == nil && modTime.Equal(l.modTime) {
+		return l.certificate, nil
+	}
+	if err := l.load(); err != nil {
+		slog.Warn("Failed to reload TLS certificate", slog.Any("err", err))
+	}
+	return l.certificate, nil
+}
+
+// newTLSConfig returns the TLS config of the certificate of the profile, for HTTP/2 and HTTP/1.1.
+func (s *Server) newTLSConfig() (*tls.Config, error) {
+	if s.Profile.ACME {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(filepath.Join(s.Profile.Data, "acme")),
+			HostPolicy: s.acmeHostPolicy,
+			Email:      s.Profile.ACMEEmail,
+		}
+		// The config of the manager also answers the TLS-ALPN-01 challenges.
+		tlsConfig := manager.TLSConfig()
+		tlsConfig.MinVersion = tls.VersionTLS12
+		return tlsConfig, nil
+	}
+	loader, err := newCertificateLoader(s.Profile.TLSCert, s.Profile.TLSKey)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: loader.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}, nil
+}
+
+// acmeHostPolicy allows the certificates of the host of the instance URL and of the verified custom domains of the users,
+// so the handshakes of the other hostnames can't exhaust the rate limits of Let's Encrypt.
+func (s *Server) acmeHostPolicy(ctx context.Context, host string) error {
+	workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if instanceURL, err := url.Parse(workspaceGeneralSetting.GetInstanceUrl()); err == nil && strings.EqualFold(instanceURL.Hostname(), host) {
+		return nil
+	}
+	hostname, verified := strings.ToLower(host), true
+	domain, err := s.Store.GetUserDomain(ctx, &store.FindUserDomain{Hostname: &hostname, Verified: &verified})
+	if err != nil {
+		return err
+	}
+	if domain == nil {
+		return errors.Errorf("host %q isn't allowed", host)
+	}
+	return nil
+}
+
+// startHTTP3Server serves HTTP/3 on the UDP port of the same number as the one of the server.
+func (s *Server) startHTTP3Server(tlsConfig *tls.Config) error {
+	conn, err := handoff.ListenPacket("http3", fmt.Sprintf("%s:%d", s.Profile.Addr, s.Profile.Port), s.Profile.ReusePort)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen on UDP port")
+	}
+	s.http3Server.TLSConfig = http3.ConfigureTLSConfig(tlsConfig)
+	go func() {
+		if err := s.http3Server.Serve(conn); err != nil && err != http.ErrServerClosed {
+			slog.Error("HTTP/3 server stopped", slog.Any("err", err))
+		}
+	}()
+	return nil
+}
+
+// AltSvcMiddleware advertises HTTP/3 in the Alt-Svc header of the responses over TLS, so the clients switch to it.
+func AltSvcMiddleware(http3Server *http3.Server) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.IsTLS() {
+				_ = http3Server.SetQUICHeaders(c.Response().Header())
+			}
+			return next(c)
+		}
+	}
+}
@@ -9,8 +9,18 @@ import (
 type ActivityType string
 
 const (
-	ActivityTypeMemoComment   ActivityType = "MEMO_COMMENT"
-	ActivityTypeVersionUpdate ActivityType = "VERSION_UPDATE"
+	ActivityTypeMemoCreate   ActivityType = "MEMO_CREATE"
+	ActivityTypeMemoComment  ActivityType = "MEMO_COMMENT"
+	ActivityTypeMemoReaction ActivityType = "MEMO_REACTION"
+	ActivityTypeMemoShare    ActivityType = "MEMO_SHARE"
+	ActivityTypeUserCreate   ActivityType = "USER_CREATE"
+	// ActivityTypeMemoReportResolve is the activity of an admin resolving a memo report, which notifies the reporter.
+	ActivityTypeMemoReportResolve ActivityType = "MEMO_REPORT_RESOLVE"
+	ActivityTypeVersionUpdate     ActivityType = "VERSION_UPDATE"
+	// ActivityTypeMemoMention is the activity of a user mentioning another user in a memo, which notifies the mentioned user.
+	ActivityTypeMemoMention ActivityType = "MEMO_MENTION"
+	// ActivityTypeMemoReminder is the activity of a reminder of a memo being due, which notifies the user who set it.
+	ActivityTypeMemoReminder ActivityType = "MEMO_REMINDER"
 )
 
 func (t ActivityType) String() string {
@@ -41,8 +51,17 @@ type Activity struct {
 }
 
 type FindActivity struct {
-	ID   *int32
-	Type *ActivityType
+	ID        *int32
+	Type      *ActivityType
+	TypeList  []ActivityType
+	CreatorID *int32
+	// CreatedTsAfter and CreatedTsBefore are exclusive.
+	CreatedTsAfter  *int64
+	CreatedTsBefore *int64
+
+	// Pagination
+	Limit  *int
+	Offset *int
 }
 
 func (s *Store) CreateActivity(ctx context.Context, create *Activity) (*Activity, error) {
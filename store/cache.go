@@ -1,9 +1,169 @@
 package store
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 )
 
+// sharedCacheTTL is the TTL of the models cached in the shared state, which bounds the staleness of the models changed
+// without the store, e.g. in the database directly.
+const sharedCacheTTL = time.Hour
+
 func getUserSettingV1CacheKey(userID int32, key string) string {
 	return fmt.Sprintf("%d-%s-v1", userID, key)
 }
+
+// txCacheKey is the key of a model cached in a transaction, with the name of its cache.
+type txCacheKey struct {
+	name string
+	key  any
+}
+
+// CacheStats is the number of the hits and the misses of a cache of the store.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+type cacheCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// GetCacheStats returns the stats of the caches of the store by their names, e.g. `user`.
+func (s *Store) GetCacheStats() map[string]*CacheStats {
+	stats := map[string]*CacheStats{}
+	s.cacheCounters.Range(func(name, value any) bool {
+		counter := value.(*cacheCounter)
+		stats[name.(string)] = &CacheStats{
+			Hits:   counter.hits.Load(),
+			Misses: counter.misses.Load(),
+		}
+		return true
+	})
+	return stats
+}
+
+// loadCache returns the cached model of the key, from the shared state if the store uses one, otherwise from the local cache.
+func loadCache[V any](ctx context.Context, s *Store, cache *sync.Map, name string, key any) (V, bool) {
+	value, ok := loadCacheValue[V](ctx, s, cache, name, key)
+	counter, _ := s.cacheCounters.LoadOrStore(name, &cacheCounter{})
+	if ok {
+		counter.(*cacheCounter).hits.Add(1)
+	} else {
+		counter.(*cacheCounter).misses.Add(1)
+	}
+	return value, ok
+}
+
+func loadCacheValue[V any](ctx context.Context, s *Store, cache *sync.Map, name string, key any) (V, bool) {
+	var value V
+	if s.shared == nil {
+		cached, ok := cache.Load(key)
+		if !ok {
+			return value, false
+		}
+		return cached.(V), true
+	}
+	data, ok, err := s.shared.Get(ctx, getSharedCacheKey(name, key))
+	if err != nil {
+		slog.Warn("Failed to get shared cache", slog.String("cache", name), slog.Any("err", err))
+		return value, false
+	}
+	if !ok {
+		return value, false
+	}
+	value, err = decodeCacheValue[V](data)
+	if err != nil {
+		slog.Warn("Failed to decode shared cache", slog.String("cache", name), slog.Any("err", err))
+		return value, false
+	}
+	return value, true
+}
+
+// storeCache caches the model of the key. A changed model replaces the cached one, while a model loaded from the database
+// is only cached in the shared state if it isn't yet, so it doesn't replace the model changed by another replica meanwhile.
+func storeCache(ctx context.Context, s *Store, cache *sync.Map, name string, key, value any, changed bool) {
+	if s.txCacheKeys != nil {
+		s.txCacheKeys.Store(txCacheKey{name: name, key: key}, true)
+	}
+	if s.shared == nil {
+		cache.Store(key, value)
+		return
+	}
+	data, err := encodeCacheValue(value)
+	if err != nil {
+		slog.Warn("Failed to encode shared cache", slog.String("cache", name), slog.Any("err", err))
+		return
+	}
+	if changed {
+		err = s.shared.Set(ctx, getSharedCacheKey(name, key), data, sharedCacheTTL)
+	} else {
+		err = s.shared.Add(ctx, getSharedCacheKey(name, key), data, sharedCacheTTL)
+	}
+	if err != nil {
+		slog.Warn("Failed to set shared cache", slog.String("cache", name), slog.Any("err", err))
+	}
+}
+
+func deleteCache(ctx context.Context, s *Store, cache *sync.Map, name string, key any) {
+	if s.txCacheKeys != nil {
+		s.txCacheKeys.Store(txCacheKey{name: name, key: key}, true)
+	}
+	if s.shared == nil {
+		cache.Delete(key)
+		return
+	}
+	if err := s.shared.Delete(ctx, getSharedCacheKey(name, key)); err != nil {
+		slog.Error("Failed to delete shared cache", slog.String("cache", name), slog.Any("err", err))
+	}
+}
+
+// getCache returns the cache of the name, nil if there isn't one.
+func (s *Store) getCache(name string) *sync.Map {
+	switch name {
+	case "idp":
+		return &s.idpCache
+	case "user":
+		return &s.userCache
+	case "user_setting":
+		return &s.userSettingCache
+	case "workspace_setting":
+		return &s.workspaceSettingCache
+	case "workspace_setting_v1":
+		return &s.workspaceSettingV1Cache
+	default:
+		return nil
+	}
+}
+
+func getSharedCacheKey(name string, key any) string {
+	return fmt.Sprintf("cache:%s:%v", name, key)
+}
+
+// encodeCacheValue encodes the protobuf messages in their wire format, and the other models in JSON.
+func encodeCacheValue(value any) ([]byte, error) {
+	if message, ok := value.(proto.Message); ok {
+		return proto.Marshal(message)
+	}
+	return json.Marshal(value)
+}
+
+func decodeCacheValue[V any](data []byte) (V, error) {
+	var value V
+	if _, ok := any(value).(proto.Message); ok {
+		value = reflect.New(reflect.TypeOf(value).Elem()).Interface().(V)
+		err := proto.Unmarshal(data, any(value).(proto.Message))
+		return value, err
+	}
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+)
+
+// ContentInterceptor is run on the memos and the resources before they're saved, e.g. to run the workspace hooks on them.
+// It's run by the store, so no API, integration or import saving them skips it.
+type ContentInterceptor interface {
+	// InterceptMemoCreate may change the content of the memo to create, an error rejects it.
+	InterceptMemoCreate(ctx context.Context, s *Store, create *Memo) error
+	// InterceptMemoUpdate may change the content of the update of the memo, it's only run if the content is updated.
+	// An error rejects the update.
+	InterceptMemoUpdate(ctx context.Context, s *Store, update *UpdateMemo) error
+	// InterceptResourceCreate is run on the resource to create, an error rejects it.
+	InterceptResourceCreate(ctx context.Context, s *Store, create *Resource) error
+}
+
+// UseContentInterceptor makes the store run the interceptor on the memos and the resources it saves.
+// It must be called before the store saves any memos or resources.
+func (s *Store) UseContentInterceptor(interceptor ContentInterceptor) {
+	s.contentInterceptor = interceptor
+}
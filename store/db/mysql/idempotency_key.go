@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateIdempotencyKey(ctx context.Context, create *store.IdempotencyKey) (*store.IdempotencyKey, error) {
+	fields := []string{"`creator_id`", "`entity_type`", "`key`", "`entity_id`"}
+	placeholder := []string{"?", "?", "?", "?"}
+	args := []any{create.CreatorID, create.EntityType, create.Key, create.EntityID}
+
+	stmt := "INSERT INTO `idempotency_key` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	id32 := int32(id)
+	list, err := d.ListIdempotencyKeys(ctx, &store.FindIdempotencyKey{ID: &id32})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find created idempotency key %d", id32)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListIdempotencyKeys(ctx context.Context, find *store.FindIdempotencyKey) ([]*store.IdempotencyKey, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+	if find.EntityType != nil {
+		where, args = append(where, "`entity_type` = ?"), append(args, *find.EntityType)
+	}
+	if find.Key != nil {
+		where, args = append(where, "`key` = ?"), append(args, *find.Key)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), `creator_id`, `entity_type`, `key`, `entity_id` FROM `idempotency_key` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.IdempotencyKey{}
+	for rows.Next() {
+		idempotencyKey := &store.IdempotencyKey{}
+		if err := rows.Scan(
+			&idempotencyKey.ID,
+			&idempotencyKey.CreatedTs,
+			&idempotencyKey.CreatorID,
+			&idempotencyKey.EntityType,
+			&idempotencyKey.Key,
+			&idempotencyKey.EntityID,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, idempotencyKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateIdempotencyKey(ctx context.Context, update *store.UpdateIdempotencyKey) error {
+	_, err := d.conn().ExecContext(ctx, "UPDATE `idempotency_key` SET `entity_id` = ? WHERE `id` = ?", update.EntityID, update.ID)
+	return err
+}
+
+func (d *DB) DeleteIdempotencyKey(ctx context.Context, delete *store.DeleteIdempotencyKey) error {
+	where, args := []string{"1 = 1"}, []any{}
+	if delete.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *delete.ID)
+	}
+	if delete.CreatedTsBefore != nil {
+		where, args = append(where, "UNIX_TIMESTAMP(`created_ts`) < ?"), append(args, *delete.CreatedTsBefore)
+	}
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `idempotency_key` WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
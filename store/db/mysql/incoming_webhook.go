@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateIncomingWebhook(ctx context.Context, create *store.IncomingWebhook) (*store.IncomingWebhook, error) {
+	fields := []string{"`creator_id`", "`name`", "`token`", "`visibility`", "`tags`"}
+	placeholder := []string{"?", "?", "?", "?", "?"}
+	args := []any{create.CreatorID, create.Name, create.Token, create.Visibility, strings.Join(create.Tags, ",")}
+
+	stmt := "INSERT INTO `incoming_webhook` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	id32 := int32(id)
+	list, err := d.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{ID: &id32})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find created incoming webhook %d", id32)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListIncomingWebhooks(ctx context.Context, find *store.FindIncomingWebhook) ([]*store.IncomingWebhook, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+	if find.Token != nil {
+		where, args = append(where, "`token` = ?"), append(args, *find.Token)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), `creator_id`, `name`, `token`, `visibility`, `tags` FROM `incoming_webhook` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.IncomingWebhook{}
+	for rows.Next() {
+		incomingWebhook := &store.IncomingWebhook{}
+		var tags string
+		if err := rows.Scan(
+			&incomingWebhook.ID,
+			&incomingWebhook.CreatedTs,
+			&incomingWebhook.CreatorID,
+			&incomingWebhook.Name,
+			&incomingWebhook.Token,
+			&incomingWebhook.Visibility,
+			&tags,
+		); err != nil {
+			return nil, err
+		}
+		if tags != "" {
+			incomingWebhook.Tags = strings.Split(tags, ",")
+		}
+		list = append(list, incomingWebhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteIncomingWebhook(ctx context.Context, delete *store.DeleteIncomingWebhook) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `incoming_webhook` WHERE `id` = ?", delete.ID)
+	return err
+}
+
+func vacuumIncomingWebhook(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `incoming_webhook` WHERE `creator_id` NOT IN (SELECT `id` FROM `user`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateInvitation(ctx context.Context, create *store.Invitation) (*store.Invitation, error) {
+	fields := []string{"`creator_id`", "`code`", "`role`", "`max_uses`", "`expires_ts`"}
+	placeholder := []string{"?", "?", "?", "?", "?"}
+	args := []any{create.CreatorID, create.Code, create.Role, create.MaxUses, create.ExpiresTs}
+
+	stmt := "INSERT INTO `invitation` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	id32 := int32(id)
+	list, err := d.ListInvitations(ctx, &store.FindInvitation{ID: &id32})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find created invitation %d", id32)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListInvitations(ctx context.Context, find *store.FindInvitation) ([]*store.Invitation, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, "`id` = ?"), append(args, *v)
+	}
+	if v := find.CreatorID; v != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *v)
+	}
+	if v := find.Code; v != nil {
+		where, args = append(where, "`code` = ?"), append(args, *v)
+	}
+
+	query := "SELECT `id`, `creator_id`, UNIX_TIMESTAMP(`created_ts`), `code`, `role`, `max_uses`, `use_count`, `expires_ts` FROM `invitation` WHERE " + strings.Join(where, " AND ") + " ORDER BY `created_ts` DESC, `id` DESC"
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.Invitation{}
+	for rows.Next() {
+		invitation := &store.Invitation{}
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.CreatorID,
+			&invitation.CreatedTs,
+			&invitation.Code,
+			&invitation.Role,
+			&invitation.MaxUses,
+			&invitation.UseCount,
+			&invitation.ExpiresTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) RedeemInvitation(ctx context.Context, code string, now int64) (bool, error) {
+	stmt := "UPDATE `invitation` SET `use_count` = `use_count` + 1 WHERE `code` = ? AND `use_count` < `max_uses` AND (`expires_ts` = 0 OR `expires_ts` > ?)"
+	result, err := d.conn().ExecContext(ctx, stmt, code, now)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (d *DB) DeleteInvitation(ctx context.Context, delete *store.DeleteInvitation) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `invitation` WHERE `id` = ?", delete.ID)
+	return err
+}
+
+func vacuumInvitation(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `invitation` WHERE `creator_id` NOT IN (SELECT `id` FROM `user`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateJob(ctx context.Context, create *store.Job) (*store.Job, error) {
+	fields := []string{"`kind`", "`payload`", "`status`", "`max_attempts`", "`next_attempt_ts`", "`last_error`"}
+	placeholder := []string{"?", "?", "?", "?", "?", "?"}
+	args := []any{create.Kind, create.Payload, create.Status, create.MaxAttempts, create.NextAttemptTs, create.LastError}
+
+	stmt := "INSERT INTO `job` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	id32 := int32(id)
+	list, err := d.ListJobs(ctx, &store.FindJob{ID: &id32})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find created job %d", id32)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListJobs(ctx context.Context, find *store.FindJob) ([]*store.Job, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.Kind != nil {
+		where, args = append(where, "`kind` = ?"), append(args, *find.Kind)
+	}
+	if find.Status != nil {
+		where, args = append(where, "`status` = ?"), append(args, *find.Status)
+	}
+	if find.NextAttemptTsBefore != nil {
+		where, args = append(where, "`next_attempt_ts` <= ?"), append(args, *find.NextAttemptTsBefore)
+	}
+	if find.UpdatedTsBefore != nil {
+		where, args = append(where, "UNIX_TIMESTAMP(`updated_ts`) < ?"), append(args, *find.UpdatedTsBefore)
+	}
+
+	query := "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `kind`, `payload`, `status`, `attempts`, `max_attempts`, `next_attempt_ts`, `last_error` FROM `job` WHERE " + strings.Join(where, " AND ") + " ORDER BY `id` DESC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.Job{}
+	for rows.Next() {
+		job := &store.Job{}
+		if err := rows.Scan(
+			&job.ID,
+			&job.CreatedTs,
+			&job.UpdatedTs,
+			&job.Kind,
+			&job.Payload,
+			&job.Status,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.NextAttemptTs,
+			&job.LastError,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateJob(ctx context.Context, update *store.UpdateJob) error {
+	set, args := []string{}, []any{}
+	if update.UpdatedTs != nil {
+		set, args = append(set, "`updated_ts` = FROM_UNIXTIME(?)"), append(args, *update.UpdatedTs)
+	}
+	if update.Status != nil {
+		set, args = append(set, "`status` = ?"), append(args, *update.Status)
+	}
+	if update.Attempts != nil {
+		set, args = append(set, "`attempts` = ?"), append(args, *update.Attempts)
+	}
+	if update.NextAttemptTs != nil {
+		set, args = append(set, "`next_attempt_ts` = ?"), append(args, *update.NextAttemptTs)
+	}
+	if update.LastError != nil {
+		set, args = append(set, "`last_error` = ?"), append(args, *update.LastError)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	args = append(args, update.ID)
+
+	stmt := "UPDATE `job` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
+	_, err := d.conn().ExecContext(ctx, stmt, args...)
+	return err
+}
+
+func (d *DB) DeleteJobs(ctx context.Context, delete *store.DeleteJob) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `job` WHERE `status` = ? AND UNIX_TIMESTAMP(`updated_ts`) < ?", delete.Status, delete.UpdatedTsBefore)
+	return err
+}
@@ -12,12 +12,12 @@ import (
 )
 
 func (d *DB) CreateMemo(ctx context.Context, create *store.Memo) (*store.Memo, error) {
-	fields := []string{"`uid`", "`creator_id`", "`content`", "`visibility`"}
-	placeholder := []string{"?", "?", "?", "?"}
-	args := []any{create.UID, create.CreatorID, create.Content, create.Visibility}
+	fields := []string{"`uid`", "`creator_id`", "`content`", "`visibility`", "`group_id`"}
+	placeholder := []string{"?", "?", "?", "?", "?"}
+	args := []any{create.UID, create.CreatorID, create.Content, create.Visibility, create.GroupID}
 
 	stmt := "INSERT INTO `memo` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +66,10 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 	}
 	if v := find.ContentSearch; len(v) != 0 {
 		for _, s := range v {
+			if find.SearchResourceText {
+				where, args = append(where, "(`memo`.`content` LIKE ? OR EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`extracted_text` LIKE ?))"), append(args, "%"+s+"%", "%"+s+"%")
+				continue
+			}
 			where, args = append(where, "`memo`.`content` LIKE ?"), append(args, "%"+s+"%")
 		}
 	}
@@ -77,20 +81,97 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		}
 		where = append(where, fmt.Sprintf("`memo`.`visibility` in (%s)", strings.Join(placeholder, ",")))
 	}
+	if v := find.GroupID; v != nil {
+		where, args = append(where, "`memo`.`visibility` = 'GROUP' AND `memo`.`group_id` = ?"), append(args, *v)
+	}
+	if v := find.GroupMemberID; v != nil {
+		where, args = append(where, "(`memo`.`visibility` != 'GROUP' OR `memo`.`creator_id` = ? OR `memo`.`group_id` IN (SELECT `group_id` FROM `user_group_member` WHERE `user_id` = ?))"), append(args, *v, *v)
+	}
+	if v := find.ExcludeCreatorIDs; len(v) != 0 {
+		placeholder := []string{}
+		for _, creatorID := range v {
+			placeholder = append(placeholder, "?")
+			args = append(args, creatorID)
+		}
+		where = append(where, fmt.Sprintf("`memo`.`creator_id` NOT IN (%s)", strings.Join(placeholder, ",")))
+	}
+	for _, tag := range find.ExcludeTags {
+		conditions := []string{}
+		for _, pattern := range store.GetMemoTagPatterns(tag) {
+			conditions, args = append(conditions, "`memo`.`content` LIKE ?"), append(args, pattern)
+		}
+		where = append(where, "NOT ("+strings.Join(conditions, " OR ")+")")
+	}
+	if v := find.ExploreApproved; v != nil {
+		where, args = append(where, existsClause(*v, "SELECT 1 FROM `memo_property` WHERE `memo_property`.`memo_id` = `memo`.`id` AND `memo_property`.`name` = ?")), append(args, store.MemoExploreApprovedProperty)
+	}
 	if find.ExcludeComments {
 		having = append(having, "`parent_id` IS NULL")
 	}
+	if find.ExcludeHidden {
+		hidden := "`memo`.`id` NOT IN (SELECT `memo_id` FROM `memo_report` WHERE `status` = 'HIDDEN')"
+		if v := find.HiddenVisibleToID; v != nil {
+			hidden, args = "("+hidden+" OR `memo`.`creator_id` = ?)", append(args, *v)
+		}
+		where = append(where, hidden)
+	}
+	if v := find.ContentRegex; v != nil {
+		where, args = append(where, "`memo`.`content` REGEXP ?"), append(args, *v)
+	}
+	if v := find.Pinned; v != nil {
+		where, args = append(where, "IFNULL(`memo_organizer`.`pinned`, 0) = ?"), append(args, *v)
+	}
+	if v := find.HasResource; v != nil {
+		where = append(where, existsClause(*v, "SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id`"))
+	}
+	if v := find.ResourceType; v != nil {
+		where, args = append(where, "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`type` LIKE ?)"), append(args, *v+"%")
+	}
+	if v := find.HasRelation; v != nil {
+		where = append(where, existsClause(*v, "SELECT 1 FROM `memo_relation` AS `reference` WHERE `reference`.`type` = 'REFERENCE' AND (`reference`.`memo_id` = `memo`.`id` OR `reference`.`related_memo_id` = `memo`.`id`)"))
+	}
+	for _, property := range []struct {
+		value    *bool
+		patterns []string
+	}{
+		{find.HasLink, store.MemoLinkPatterns},
+		{find.HasCode, store.MemoCodePatterns},
+		{find.HasTaskList, store.MemoTaskListPatterns},
+		{find.HasIncompleteTasks, store.MemoIncompleteTaskPatterns},
+	} {
+		if property.value == nil {
+			continue
+		}
+		conditions := []string{}
+		for _, pattern := range property.patterns {
+			conditions, args = append(conditions, "`memo`.`content` LIKE ?"), append(args, pattern)
+		}
+		condition := "(" + strings.Join(conditions, " OR ") + ")"
+		if !*property.value {
+			condition = "NOT " + condition
+		}
+		where = append(where, condition)
+	}
 
 	orders := []string{}
 	if find.OrderByPinned {
 		orders = append(orders, "`pinned` DESC")
 	}
-	if find.OrderByUpdatedTs {
-		orders = append(orders, "`updated_ts` DESC")
-	} else {
-		orders = append(orders, "`created_ts` DESC")
-	}
-	orders = append(orders, "`id` DESC")
+	direction := "DESC"
+	if find.OrderAsc {
+		direction = "ASC"
+	}
+	switch find.GetOrderBy() {
+	case store.MemoOrderByUpdatedTs:
+		orders = append(orders, "`updated_ts` "+direction)
+	case store.MemoOrderByContentLength:
+		orders = append(orders, "CHAR_LENGTH(`memo`.`content`) "+direction)
+	case store.MemoOrderByReactionCount:
+		orders = append(orders, "(SELECT COUNT(*) FROM `reaction` WHERE `reaction`.`content_id` = CONCAT('memos/', `memo`.`id`)) "+direction)
+	default:
+		orders = append(orders, "`created_ts` "+direction)
+	}
+	orders = append(orders, "`id` "+direction)
 	if find.Random {
 		orders = append(orders, "RAND()")
 	}
@@ -103,6 +184,7 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		"UNIX_TIMESTAMP(`memo`.`updated_ts`) AS `updated_ts`",
 		"`memo`.`row_status` AS `row_status`",
 		"`memo`.`visibility` AS `visibility`",
+		"`memo`.`group_id` AS `group_id`",
 		"IFNULL(`memo_organizer`.`pinned`, 0) AS `pinned`",
 		"`memo_relation`.`related_memo_id` AS `parent_id`",
 	}
@@ -118,7 +200,7 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		}
 	}
 
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +217,7 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 			&memo.UpdatedTs,
 			&memo.RowStatus,
 			&memo.Visibility,
+			&memo.GroupID,
 			&memo.Pinned,
 			&memo.ParentID,
 		}
@@ -172,6 +255,9 @@ func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
 	if v := update.UID; v != nil {
 		set, args = append(set, "`uid` = ?"), append(args, *v)
 	}
+	if v := update.CreatorID; v != nil {
+		set, args = append(set, "`creator_id` = ?"), append(args, *v)
+	}
 	if v := update.CreatedTs; v != nil {
 		set, args = append(set, "`created_ts` = FROM_UNIXTIME(?)"), append(args, *v)
 	}
@@ -187,10 +273,13 @@ func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
 	if v := update.Visibility; v != nil {
 		set, args = append(set, "`visibility` = ?"), append(args, *v)
 	}
+	if v := update.GroupID; v != nil {
+		set, args = append(set, "`group_id` = NULLIF(?, 0)"), append(args, *v)
+	}
 	args = append(args, update.ID)
 
 	stmt := "UPDATE `memo` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
 		return err
 	}
 	return nil
@@ -199,7 +288,7 @@ func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
 func (d *DB) DeleteMemo(ctx context.Context, delete *store.DeleteMemo) error {
 	where, args := []string{"`id` = ?"}, []any{delete.ID}
 	stmt := "DELETE FROM `memo` WHERE " + strings.Join(where, " AND ")
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -223,3 +312,11 @@ func vacuumMemo(ctx context.Context, tx *sql.Tx) error {
 
 	return nil
 }
+
+// existsClause returns the EXISTS clause of the subquery, or the NOT EXISTS clause if exists is false.
+func existsClause(exists bool, subquery string) string {
+	if exists {
+		return "EXISTS (" + subquery + ")"
+	}
+	return "NOT EXISTS (" + subquery + ")"
+}
@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertMemoEmbedding(ctx context.Context, upsert *store.MemoEmbedding) (*store.MemoEmbedding, error) {
+	stmt := "INSERT INTO `memo_embedding` (`memo_id`, `model`, `embedding`, `updated_ts`) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE `model` = ?, `embedding` = ?, `updated_ts` = ?"
+	embedding := store.EncodeEmbedding(upsert.Embedding)
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.MemoID, upsert.Model, embedding, upsert.UpdatedTs, upsert.Model, embedding, upsert.UpdatedTs); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListMemoEmbeddings(ctx context.Context, find *store.FindMemoEmbedding) ([]*store.MemoEmbedding, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)"), append(args, *find.CreatorID)
+	}
+	if find.Model != nil {
+		where, args = append(where, "`model` = ?"), append(args, *find.Model)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT `memo_id`, `model`, `embedding`, `updated_ts` FROM `memo_embedding` WHERE "+strings.Join(where, " AND ")+" ORDER BY `memo_id` ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoEmbedding{}
+	for rows.Next() {
+		memoEmbedding := &store.MemoEmbedding{}
+		var embedding []byte
+		if err := rows.Scan(
+			&memoEmbedding.MemoID,
+			&memoEmbedding.Model,
+			&embedding,
+			&memoEmbedding.UpdatedTs,
+		); err != nil {
+			return nil, err
+		}
+		if memoEmbedding.Embedding, err = store.DecodeEmbedding(embedding); err != nil {
+			return nil, err
+		}
+		list = append(list, memoEmbedding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func vacuumMemoEmbedding(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `memo_embedding` WHERE `memo_id` NOT IN (SELECT `id` FROM `memo`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	return err
+}
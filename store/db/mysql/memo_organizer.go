@@ -10,7 +10,7 @@ import (
 
 func (d *DB) UpsertMemoOrganizer(ctx context.Context, upsert *store.MemoOrganizer) (*store.MemoOrganizer, error) {
 	stmt := "INSERT INTO `memo_organizer` (`memo_id`, `user_id`, `pinned`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `pinned` = ?"
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.MemoID, upsert.UserID, upsert.Pinned, upsert.Pinned); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.MemoID, upsert.UserID, upsert.Pinned, upsert.Pinned); err != nil {
 		return nil, err
 	}
 	return upsert, nil
@@ -28,7 +28,7 @@ func (d *DB) ListMemoOrganizer(ctx context.Context, find *store.FindMemoOrganize
 	}
 
 	query := "SELECT `memo_id`, `user_id`, `pinned` FROM `memo_organizer` WHERE " + strings.Join(where, " AND ")
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +64,7 @@ func (d *DB) DeleteMemoOrganizer(ctx context.Context, delete *store.DeleteMemoOr
 		where, args = append(where, "`user_id` = ?"), append(args, *v)
 	}
 	stmt := "DELETE FROM `memo_organizer` WHERE " + strings.Join(where, " AND ")
-	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
 		return err
 	}
 	return nil
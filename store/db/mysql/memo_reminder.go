@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertMemoReminder(ctx context.Context, upsert *store.MemoReminder) (*store.MemoReminder, error) {
+	stmt := "INSERT INTO `memo_reminder` (`creator_id`, `memo_id`, `remind_ts`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `remind_ts` = VALUES(`remind_ts`), `delivered_ts` = 0"
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.CreatorID, upsert.MemoID, upsert.RemindTs); err != nil {
+		return nil, err
+	}
+
+	list, err := d.ListMemoReminders(ctx, &store.FindMemoReminder{CreatorID: &upsert.CreatorID, MemoID: &upsert.MemoID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find upserted memo reminder of memo %d", upsert.MemoID)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListMemoReminders(ctx context.Context, find *store.FindMemoReminder) ([]*store.MemoReminder, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if find.RemindTsBefore != nil {
+		where, args = append(where, "`remind_ts` < ?"), append(args, *find.RemindTsBefore)
+	}
+	if find.Pending {
+		where = append(where, "`delivered_ts` = 0")
+	}
+
+	query := "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), `creator_id`, `memo_id`, `remind_ts`, `delivered_ts` FROM `memo_reminder` WHERE " + strings.Join(where, " AND ") + " ORDER BY `remind_ts` ASC, `id` ASC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoReminder{}
+	for rows.Next() {
+		reminder := &store.MemoReminder{}
+		if err := rows.Scan(
+			&reminder.ID,
+			&reminder.CreatedTs,
+			&reminder.CreatorID,
+			&reminder.MemoID,
+			&reminder.RemindTs,
+			&reminder.DeliveredTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateMemoReminder(ctx context.Context, update *store.UpdateMemoReminder) error {
+	set, args := []string{}, []any{}
+	if update.DeliveredTs != nil {
+		set, args = append(set, "`delivered_ts` = ?"), append(args, *update.DeliveredTs)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	args = append(args, update.ID)
+	_, err := d.conn().ExecContext(ctx, "UPDATE `memo_reminder` SET "+strings.Join(set, ", ")+" WHERE `id` = ?", args...)
+	return err
+}
+
+func (d *DB) DeleteMemoReminder(ctx context.Context, delete *store.DeleteMemoReminder) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `memo_reminder` WHERE `creator_id` = ? AND `memo_id` = ?", delete.CreatorID, delete.MemoID)
+	return err
+}
+
+func vacuumMemoReminder(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `memo_reminder` WHERE `memo_id` NOT IN (SELECT `id` FROM `memo`) OR `creator_id` NOT IN (SELECT `id` FROM `user`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	return err
+}
@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateMemoReport(ctx context.Context, create *store.MemoReport) (*store.MemoReport, error) {
+	fields := []string{"`reporter_id`", "`memo_id`", "`reason`", "`resolution`"}
+	placeholder := []string{"?", "?", "?", "?"}
+	args := []any{create.ReporterID, create.MemoID, create.Reason, create.Resolution}
+
+	stmt := "INSERT INTO `memo_report` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	id32 := int32(id)
+	list, err := d.ListMemoReports(ctx, &store.FindMemoReport{ID: &id32})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find created memo report %d", id32)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListMemoReports(ctx context.Context, find *store.FindMemoReport) ([]*store.MemoReport, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`memo_report`.`id` = ?"), append(args, *find.ID)
+	}
+	if find.ReporterID != nil {
+		where, args = append(where, "`memo_report`.`reporter_id` = ?"), append(args, *find.ReporterID)
+	}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_report`.`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if find.Status != nil {
+		where, args = append(where, "`memo_report`.`status` = ?"), append(args, find.Status.String())
+	}
+	if find.MemoCreatorID != nil {
+		where, args = append(where, "`memo_report`.`memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)"), append(args, *find.MemoCreatorID)
+	}
+
+	query := "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `reporter_id`, `memo_id`, `reason`, `status`, `resolver_id`, `resolution` FROM `memo_report` WHERE " + strings.Join(where, " AND ") + " ORDER BY `id` DESC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoReport{}
+	for rows.Next() {
+		report := &store.MemoReport{}
+		if err := rows.Scan(
+			&report.ID,
+			&report.CreatedTs,
+			&report.UpdatedTs,
+			&report.ReporterID,
+			&report.MemoID,
+			&report.Reason,
+			&report.Status,
+			&report.ResolverID,
+			&report.Resolution,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateMemoReport(ctx context.Context, update *store.UpdateMemoReport) (*store.MemoReport, error) {
+	set, args := []string{}, []any{}
+	if update.UpdatedTs != nil {
+		set, args = append(set, "`updated_ts` = FROM_UNIXTIME(?)"), append(args, *update.UpdatedTs)
+	}
+	if update.Status != nil {
+		set, args = append(set, "`status` = ?"), append(args, update.Status.String())
+	}
+	if update.ResolverID != nil {
+		set, args = append(set, "`resolver_id` = ?"), append(args, *update.ResolverID)
+	}
+	if update.Resolution != nil {
+		set, args = append(set, "`resolution` = ?"), append(args, *update.Resolution)
+	}
+	if len(set) > 0 {
+		args = append(args, update.ID)
+		stmt := "UPDATE `memo_report` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
+		if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := d.ListMemoReports(ctx, &store.FindMemoReport{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("memo report %d not found", update.ID)
+	}
+	return list[0], nil
+}
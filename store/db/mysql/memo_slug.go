@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertMemoSlug(ctx context.Context, upsert *store.MemoSlug) (*store.MemoSlug, error) {
+	stmt := "INSERT INTO `memo_slug` (`memo_id`, `creator_id`, `slug`, `updated_ts`) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE `creator_id` = ?, `slug` = ?, `updated_ts` = ?"
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.MemoID, upsert.CreatorID, upsert.Slug, upsert.UpdatedTs, upsert.CreatorID, upsert.Slug, upsert.UpdatedTs); err != nil {
+		return nil, err
+	}
+	if err := d.conn().QueryRowContext(ctx, "SELECT `click_count` FROM `memo_slug` WHERE `memo_id` = ?", upsert.MemoID).Scan(&upsert.ClickCount); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListMemoSlugs(ctx context.Context, find *store.FindMemoSlug) ([]*store.MemoSlug, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_slug`.`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if find.Slug != nil {
+		where, args = append(where, "`memo_slug`.`slug` = ?"), append(args, *find.Slug)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`memo_slug`.`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+
+	query := "SELECT `memo_slug`.`memo_id`, `memo_slug`.`creator_id`, `memo_slug`.`slug`, `memo_slug`.`click_count`, `memo_slug`.`updated_ts` FROM `memo_slug` WHERE " + strings.Join(where, " AND ") + " ORDER BY `memo_slug`.`memo_id` ASC"
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoSlug{}
+	for rows.Next() {
+		memoSlug := &store.MemoSlug{}
+		if err := rows.Scan(
+			&memoSlug.MemoID,
+			&memoSlug.CreatorID,
+			&memoSlug.Slug,
+			&memoSlug.ClickCount,
+			&memoSlug.UpdatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, memoSlug)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) IncreaseMemoSlugClickCount(ctx context.Context, memoID int32) error {
+	_, err := d.conn().ExecContext(ctx, "UPDATE `memo_slug` SET `click_count` = `click_count` + 1 WHERE `memo_id` = ?", memoID)
+	return err
+}
+
+func (d *DB) DeleteMemoSlug(ctx context.Context, delete *store.DeleteMemoSlug) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `memo_slug` WHERE `memo_id` = ?", delete.MemoID)
+	return err
+}
+
+func vacuumMemoSlug(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `memo_slug` WHERE `memo_id` NOT IN (SELECT `id` FROM `memo`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	return err
+}
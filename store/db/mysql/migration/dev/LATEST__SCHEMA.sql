@@ -43,7 +43,10 @@ CREATE TABLE `memo` (
   `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `row_status` VARCHAR(256) NOT NULL DEFAULT 'NORMAL',
   `content` TEXT NOT NULL,
-  `visibility` VARCHAR(256) NOT NULL DEFAULT 'PRIVATE'
+  `visibility` VARCHAR(256) NOT NULL DEFAULT 'PRIVATE',
+  `group_id` INT,
+  INDEX `idx_memo_created_ts` (`created_ts`),
+  INDEX `idx_memo_updated_ts` (`updated_ts`)
 );
 
 -- memo_organizer
@@ -75,7 +78,8 @@ CREATE TABLE `resource` (
   `type` VARCHAR(256) NOT NULL DEFAULT '',
   `size` INT NOT NULL DEFAULT '0',
   `internal_path` VARCHAR(256) NOT NULL DEFAULT '',
-  `memo_id` INT DEFAULT NULL
+  `memo_id` INT DEFAULT NULL,
+  `extracted_text` LONGTEXT
 );
 
 -- tag
@@ -130,7 +134,49 @@ CREATE TABLE `webhook` (
   `row_status` VARCHAR(256) NOT NULL DEFAULT 'NORMAL',
   `creator_id` INT NOT NULL,
   `name` TEXT NOT NULL,
-  `url` TEXT NOT NULL
+  `url` TEXT NOT NULL,
+  `secret` VARCHAR(256) NOT NULL DEFAULT '',
+  `payload_template` TEXT NOT NULL
+);
+
+-- webhook_delivery
+CREATE TABLE `webhook_delivery` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `webhook_id` INT NOT NULL,
+  `activity_type` VARCHAR(256) NOT NULL,
+  `payload` LONGTEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'PENDING',
+  `attempts` INT NOT NULL DEFAULT 0,
+  `next_attempt_ts` BIGINT NOT NULL DEFAULT 0,
+  `last_status_code` INT NOT NULL DEFAULT 0,
+  `last_error` TEXT NOT NULL,
+  INDEX `idx_webhook_delivery_webhook_id` (`webhook_id`),
+  INDEX `idx_webhook_delivery_status` (`status`, `next_attempt_ts`)
+);
+
+-- incoming_webhook
+CREATE TABLE `incoming_webhook` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `name` VARCHAR(256) NOT NULL,
+  `token` VARCHAR(256) NOT NULL UNIQUE,
+  `visibility` VARCHAR(256) NOT NULL DEFAULT '',
+  `tags` VARCHAR(1024) NOT NULL DEFAULT '',
+  INDEX `idx_incoming_webhook_creator_id` (`creator_id`)
+);
+
+-- idempotency_key
+CREATE TABLE `idempotency_key` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `entity_type` VARCHAR(256) NOT NULL,
+  `key` VARCHAR(256) NOT NULL,
+  `entity_id` INT NOT NULL DEFAULT 0,
+  UNIQUE(`creator_id`,`entity_type`,`key`)
 );
 
 -- reaction
@@ -140,5 +186,167 @@ CREATE TABLE `reaction` (
   `creator_id` INT NOT NULL,
   `content_id` VARCHAR(256) NOT NULL,
   `reaction_type` VARCHAR(256) NOT NULL,
-  UNIQUE(`creator_id`,`content_id`,`reaction_type`)  
+  UNIQUE(`creator_id`,`content_id`,`reaction_type`),
+  INDEX `idx_reaction_content_id` (`content_id`)
+);
+
+-- memo_report
+CREATE TABLE `memo_report` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `reporter_id` INT NOT NULL,
+  `memo_id` INT NOT NULL,
+  `reason` TEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'OPEN',
+  `resolver_id` INT NOT NULL DEFAULT 0,
+  `resolution` TEXT NOT NULL,
+  UNIQUE(`reporter_id`,`memo_id`),
+  INDEX `idx_memo_report_memo_id` (`memo_id`)
+);
+
+-- telegram_message
+CREATE TABLE `telegram_message` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `memo_id` INT NOT NULL,
+  `chat_id` BIGINT NOT NULL,
+  `message_id` BIGINT NOT NULL,
+  `source_message_id` BIGINT NOT NULL DEFAULT 0,
+  INDEX `idx_telegram_message_memo_id` (`memo_id`)
+);
+
+-- memo_reminder
+CREATE TABLE `memo_reminder` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `memo_id` INT NOT NULL,
+  `remind_ts` BIGINT NOT NULL,
+  `delivered_ts` BIGINT NOT NULL DEFAULT 0,
+  UNIQUE(`creator_id`,`memo_id`),
+  INDEX `idx_memo_reminder_remind_ts` (`remind_ts`)
+);
+
+-- vault_file
+CREATE TABLE `vault_file` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `creator_id` INT NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  `path` VARCHAR(512) NOT NULL,
+  `memo_id` INT NOT NULL DEFAULT 0,
+  `resource_id` INT NOT NULL DEFAULT 0,
+  `deleted` BOOLEAN NOT NULL DEFAULT FALSE,
+  UNIQUE(`creator_id`,`path`)
+);
+
+-- memo_property
+CREATE TABLE `memo_property` (
+  `memo_id` INT NOT NULL,
+  `name` VARCHAR(256) NOT NULL,
+  `value` TEXT NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  UNIQUE(`memo_id`,`name`)
+);
+
+-- memo_embedding
+CREATE TABLE `memo_embedding` (
+  `memo_id` INT NOT NULL,
+  `model` VARCHAR(256) NOT NULL,
+  `embedding` LONGBLOB NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  UNIQUE(`memo_id`)
+);
+
+-- job
+CREATE TABLE `job` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `kind` VARCHAR(256) NOT NULL,
+  `payload` LONGTEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'PENDING',
+  `attempts` INT NOT NULL DEFAULT 0,
+  `max_attempts` INT NOT NULL DEFAULT 0,
+  `next_attempt_ts` BIGINT NOT NULL DEFAULT 0,
+  `last_error` TEXT NOT NULL,
+  INDEX `idx_job_status` (`status`, `next_attempt_ts`)
+);
+
+-- task_run
+CREATE TABLE `task_run` (
+  `name` VARCHAR(256) NOT NULL PRIMARY KEY,
+  `status` VARCHAR(256) NOT NULL,
+  `started_ts` BIGINT NOT NULL,
+  `finished_ts` BIGINT NOT NULL DEFAULT 0,
+  `error` TEXT NOT NULL
+);
+
+-- user_group
+CREATE TABLE `user_group` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `creator_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `name` VARCHAR(256) NOT NULL UNIQUE,
+  `description` TEXT NOT NULL
+);
+
+-- user_group_member
+CREATE TABLE `user_group_member` (
+  `group_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `role` VARCHAR(256) NOT NULL DEFAULT 'MEMBER',
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(`group_id`,`user_id`),
+  INDEX `idx_user_group_member_user_id` (`user_id`)
+);
+
+-- user_domain
+CREATE TABLE `user_domain` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `hostname` VARCHAR(256) NOT NULL UNIQUE,
+  `verification_token` VARCHAR(256) NOT NULL,
+  `verified_ts` BIGINT NOT NULL DEFAULT 0,
+  INDEX `idx_user_domain_user_id` (`user_id`)
+);
+
+-- invitation
+CREATE TABLE `invitation` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `creator_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `code` VARCHAR(256) NOT NULL UNIQUE,
+  `role` VARCHAR(256) NOT NULL DEFAULT 'USER',
+  `max_uses` INT NOT NULL DEFAULT 1,
+  `use_count` INT NOT NULL DEFAULT 0,
+  `expires_ts` BIGINT NOT NULL DEFAULT 0,
+  INDEX `idx_invitation_creator_id` (`creator_id`)
+);
+
+-- guest_comment
+CREATE TABLE `guest_comment` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `memo_id` INT NOT NULL,
+  `name` VARCHAR(256) NOT NULL,
+  `email` VARCHAR(256) NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'PENDING',
+  `ip_hash` VARCHAR(256) NOT NULL DEFAULT '',
+  INDEX `idx_guest_comment_memo_id` (`memo_id`),
+  INDEX `idx_guest_comment_ip_hash` (`ip_hash`)
+);
+
+-- memo_slug
+CREATE TABLE `memo_slug` (
+  `memo_id` INT NOT NULL UNIQUE,
+  `slug` VARCHAR(256) NOT NULL,
+  `click_count` INT NOT NULL DEFAULT 0,
+  `updated_ts` BIGINT NOT NULL,
+  `creator_id` INT NOT NULL DEFAULT 0,
+  UNIQUE INDEX `idx_memo_slug_creator_id_slug` (`creator_id`, `slug`)
 );
@@ -0,0 +1,17 @@
+ALTER TABLE `webhook` ADD COLUMN `secret` VARCHAR(256) NOT NULL DEFAULT '';
+
+CREATE TABLE `webhook_delivery` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `webhook_id` INT NOT NULL,
+  `activity_type` VARCHAR(256) NOT NULL,
+  `payload` LONGTEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'PENDING',
+  `attempts` INT NOT NULL DEFAULT 0,
+  `next_attempt_ts` BIGINT NOT NULL DEFAULT 0,
+  `last_status_code` INT NOT NULL DEFAULT 0,
+  `last_error` TEXT NOT NULL,
+  INDEX `idx_webhook_delivery_webhook_id` (`webhook_id`),
+  INDEX `idx_webhook_delivery_status` (`status`, `next_attempt_ts`)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE `incoming_webhook` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `name` VARCHAR(256) NOT NULL,
+  `token` VARCHAR(256) NOT NULL UNIQUE,
+  `visibility` VARCHAR(256) NOT NULL DEFAULT '',
+  `tags` VARCHAR(1024) NOT NULL DEFAULT '',
+  INDEX `idx_incoming_webhook_creator_id` (`creator_id`)
+);
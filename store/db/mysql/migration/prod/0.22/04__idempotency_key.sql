@@ -0,0 +1,9 @@
+CREATE TABLE `idempotency_key` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `entity_type` VARCHAR(256) NOT NULL,
+  `key` VARCHAR(256) NOT NULL,
+  `entity_id` INT NOT NULL DEFAULT 0,
+  UNIQUE(`creator_id`,`entity_type`,`key`)
+);
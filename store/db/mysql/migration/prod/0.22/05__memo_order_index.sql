@@ -0,0 +1,5 @@
+CREATE INDEX `idx_memo_created_ts` ON `memo` (`created_ts`);
+
+CREATE INDEX `idx_memo_updated_ts` ON `memo` (`updated_ts`);
+
+CREATE INDEX `idx_reaction_content_id` ON `reaction` (`content_id`);
@@ -0,0 +1,13 @@
+CREATE TABLE `memo_report` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `reporter_id` INT NOT NULL,
+  `memo_id` INT NOT NULL,
+  `reason` TEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'OPEN',
+  `resolver_id` INT NOT NULL DEFAULT 0,
+  `resolution` TEXT NOT NULL,
+  UNIQUE(`reporter_id`,`memo_id`),
+  INDEX `idx_memo_report_memo_id` (`memo_id`)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE `telegram_message` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `memo_id` INT NOT NULL,
+  `chat_id` BIGINT NOT NULL,
+  `message_id` BIGINT NOT NULL,
+  `source_message_id` BIGINT NOT NULL DEFAULT 0,
+  INDEX `idx_telegram_message_memo_id` (`memo_id`)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE `memo_reminder` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `memo_id` INT NOT NULL,
+  `remind_ts` BIGINT NOT NULL,
+  `delivered_ts` BIGINT NOT NULL DEFAULT 0,
+  UNIQUE(`creator_id`,`memo_id`),
+  INDEX `idx_memo_reminder_remind_ts` (`remind_ts`)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE `vault_file` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `creator_id` INT NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  `path` VARCHAR(512) NOT NULL,
+  `memo_id` INT NOT NULL DEFAULT 0,
+  `resource_id` INT NOT NULL DEFAULT 0,
+  `deleted` BOOLEAN NOT NULL DEFAULT FALSE,
+  UNIQUE(`creator_id`,`path`)
+);
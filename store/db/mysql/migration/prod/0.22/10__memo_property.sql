@@ -0,0 +1,7 @@
+CREATE TABLE `memo_property` (
+  `memo_id` INT NOT NULL,
+  `name` VARCHAR(256) NOT NULL,
+  `value` TEXT NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  UNIQUE(`memo_id`,`name`)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE `memo_embedding` (
+  `memo_id` INT NOT NULL,
+  `model` VARCHAR(256) NOT NULL,
+  `embedding` LONGBLOB NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  UNIQUE(`memo_id`)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE `task_run` (
+  `name` VARCHAR(256) NOT NULL PRIMARY KEY,
+  `status` VARCHAR(256) NOT NULL,
+  `started_ts` BIGINT NOT NULL,
+  `finished_ts` BIGINT NOT NULL DEFAULT 0,
+  `error` TEXT NOT NULL
+);
@@ -0,0 +1,9 @@
+CREATE TABLE `user_domain` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `hostname` VARCHAR(256) NOT NULL UNIQUE,
+  `verification_token` VARCHAR(256) NOT NULL,
+  `verified_ts` BIGINT NOT NULL DEFAULT 0,
+  INDEX `idx_user_domain_user_id` (`user_id`)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE `invitation` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `creator_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `code` VARCHAR(256) NOT NULL UNIQUE,
+  `role` VARCHAR(256) NOT NULL DEFAULT 'USER',
+  `max_uses` INT NOT NULL DEFAULT 1,
+  `use_count` INT NOT NULL DEFAULT 0,
+  `expires_ts` BIGINT NOT NULL DEFAULT 0,
+  INDEX `idx_invitation_creator_id` (`creator_id`)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE `guest_comment` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `memo_id` INT NOT NULL,
+  `name` VARCHAR(256) NOT NULL,
+  `email` VARCHAR(256) NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'PENDING',
+  `ip_hash` VARCHAR(256) NOT NULL DEFAULT '',
+  INDEX `idx_guest_comment_memo_id` (`memo_id`),
+  INDEX `idx_guest_comment_ip_hash` (`ip_hash`)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE `memo_slug` (
+  `memo_id` INT NOT NULL UNIQUE,
+  `slug` VARCHAR(256) NOT NULL,
+  `click_count` INT NOT NULL DEFAULT 0,
+  `updated_ts` BIGINT NOT NULL,
+  INDEX `idx_memo_slug_slug` (`slug`)
+);
@@ -0,0 +1,10 @@
+ALTER TABLE `memo_slug` ADD COLUMN `creator_id` INT NOT NULL DEFAULT 0;
+
+DELETE FROM `memo_slug` WHERE `memo_id` NOT IN (SELECT `id` FROM `memo`);
+
+UPDATE `memo_slug` JOIN `memo` ON `memo_slug`.`memo_id` = `memo`.`id` SET `memo_slug`.`creator_id` = `memo`.`creator_id`;
+
+-- The slugs taken by several memos of a creator are kept by the first memo.
+DELETE `memo_slug` FROM `memo_slug` JOIN `memo_slug` AS `other` ON `other`.`creator_id` = `memo_slug`.`creator_id` AND `other`.`slug` = `memo_slug`.`slug` AND `other`.`memo_id` < `memo_slug`.`memo_id`;
+
+ALTER TABLE `memo_slug` DROP INDEX `idx_memo_slug_slug`, ADD UNIQUE INDEX `idx_memo_slug_creator_id_slug` (`creator_id`, `slug`);
@@ -31,7 +31,7 @@ func (d *DB) Migrate(ctx context.Context) error {
 }
 
 func (d *DB) nonProdMigrate(ctx context.Context) error {
-	rows, err := d.db.QueryContext(ctx, "SHOW TABLES")
+	rows, err := d.conn().QueryContext(ctx, "SHOW TABLES")
 	if err != nil {
 		return errors.Errorf("failed to query database tables: %s", err)
 	}
@@ -60,7 +60,7 @@ func (d *DB) nonProdMigrate(ctx context.Context) error {
 	}
 
 	stmt := string(buf)
-	if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt); err != nil {
 		return errors.Errorf("failed to exec SQL %s: %s", stmt, err)
 	}
 	return nil
@@ -75,7 +75,7 @@ func (d *DB) prodMigrate(ctx context.Context) error {
 		if err != nil {
 			return errors.Errorf("failed to read latest schema file: %s", err)
 		}
-		if _, err := d.db.ExecContext(ctx, string(buf)); err != nil {
+		if _, err := d.conn().ExecContext(ctx, string(buf)); err != nil {
 			return errors.Errorf("failed to exec latest schema: %s", err)
 		}
 		if _, err := d.UpsertMigrationHistory(ctx, &store.UpsertMigrationHistory{
@@ -127,7 +127,7 @@ func (d *DB) applyMigrationForMinorVersion(ctx context.Context, minorVersion str
 			if strings.TrimSpace(stmt) == "" {
 				continue
 			}
-			if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+			if _, err := d.conn().ExecContext(ctx, stmt); err != nil {
 				return errors.Wrapf(err, "migrate error: %s", stmt)
 			}
 		}
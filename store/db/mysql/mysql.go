@@ -8,16 +8,26 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/telemetry"
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
 )
 
 type DB struct {
-	db      *sql.DB
+	db *sql.DB
+	// tx is the transaction the driver is bound to, see WithTx.
+	tx      *sql.Tx
 	profile *profile.Profile
 	config  *mysql.Config
 }
 
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 func NewDB(profile *profile.Profile) (store.Driver, error) {
 	// Open MySQL connection with parameter.
 	// multiStatements=true is required for migration.
@@ -33,7 +43,7 @@ func NewDB(profile *profile.Profile) (store.Driver, error) {
 		return nil, errors.New("Parse DSN eroor")
 	}
 
-	driver.db, err = sql.Open("mysql", dsn)
+	driver.db, err = telemetry.OpenDB("mysql", dsn, "mysql")
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open db: %s", profile.DSN)
 	}
@@ -45,13 +55,42 @@ func (d *DB) GetDB() *sql.DB {
 	return d.db
 }
 
-func (d *DB) Vacuum(ctx context.Context) error {
+// conn returns the transaction if the driver is bound to one, otherwise the database.
+func (d *DB) conn() queryer {
+	if d.tx != nil {
+		return d.tx
+	}
+	return d.db
+}
+
+// WithTx runs fn with the driver bound to a transaction, which is committed if fn returns no error.
+// If the driver is already bound to a transaction, fn joins it.
+func (d *DB) WithTx(ctx context.Context, fn func(driver store.Driver) error) error {
+	if d.tx != nil {
+		return fn(d)
+	}
 	tx, err := d.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	txDriver := *d
+	txDriver.tx = tx
+	if err := fn(&txDriver); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Vacuum deletes the rows of the deleted models, in the transaction of the driver if it's bound to one.
+func (d *DB) Vacuum(ctx context.Context) error {
+	return d.WithTx(ctx, func(driver store.Driver) error {
+		return vacuumImpl(ctx, driver.(*DB).tx)
+	})
+}
+
+func vacuumImpl(ctx context.Context, tx *sql.Tx) error {
 	if err := vacuumMemo(ctx, tx); err != nil {
 		return err
 	}
@@ -70,12 +109,51 @@ func (d *DB) Vacuum(ctx context.Context) error {
 	if err := vacuumInbox(ctx, tx); err != nil {
 		return err
 	}
+	if err := vacuumMemoReminder(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumVaultFile(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoProperty(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoSlug(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoEmbedding(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumUserGroupMember(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumUserDomain(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumInvitation(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumActivity(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumReaction(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumWebhook(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumIncomingWebhook(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumGuestComment(ctx, tx); err != nil {
+		return err
+	}
 	if err := vacuumTag(ctx, tx); err != nil {
 		// Prevent revive warning.
 		return err
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (d *DB) GetCurrentDBSize(ctx context.Context) (int64, error) {
@@ -83,7 +161,7 @@ func (d *DB) GetCurrentDBSize(ctx context.Context) (int64, error) {
 		" FROM information_schema.TABLES" +
 		" WHERE `table_schema` = ?" +
 		" GROUP BY `table_schema`"
-	rows, err := d.db.QueryContext(ctx, query, d.config.DBName)
+	rows, err := d.conn().QueryContext(ctx, query, d.config.DBName)
 	if err != nil {
 		slog.Error("Query db size error, make sure you have enough privilege", err)
 		return 0, err
@@ -104,6 +182,18 @@ func (d *DB) GetCurrentDBSize(ctx context.Context) (int64, error) {
 	return size, nil
 }
 
+// maintainedTables are the tables with the most writes, which are defragmented by the maintenance.
+var maintainedTables = []string{"memo", "memo_organizer", "memo_relation", "resource", "activity", "inbox", "reaction", "webhook_delivery"}
+
+// MaintenanceStatements defragments the tables with the most writes and updates their index statistics.
+func (*DB) MaintenanceStatements() []string {
+	statements := []string{}
+	for _, table := range maintainedTables {
+		statements = append(statements, "OPTIMIZE TABLE `"+table+"`")
+	}
+	return statements
+}
+
 func (d *DB) Close() error {
 	return d.db.Close()
 }
@@ -2,6 +2,8 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,7 +17,7 @@ func (d *DB) UpsertReaction(ctx context.Context, upsert *storepb.Reaction) (*sto
 	placeholder := []string{"?", "?", "?"}
 	args := []interface{}{upsert.CreatorId, upsert.ContentId, upsert.ReactionType.String()}
 	stmt := "INSERT INTO `reaction` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +49,7 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 		where, args = append(where, "`content_id` = ?"), append(args, *find.ContentID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, `
+	query := `
 		SELECT
 			id,
 			UNIX_TIMESTAMP(created_ts) AS created_ts,
@@ -55,10 +57,15 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 			content_id,
 			reaction_type
 		FROM reaction
-		WHERE `+strings.Join(where, " AND ")+`
-		ORDER BY id ASC`,
-		args...,
-	)
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY id ASC`
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +109,16 @@ func (d *DB) GetReaction(ctx context.Context, find *store.FindReaction) (*storep
 }
 
 func (d *DB) DeleteReaction(ctx context.Context, delete *store.DeleteReaction) error {
-	_, err := d.db.ExecContext(ctx, "DELETE FROM `reaction` WHERE `id` = ?", delete.ID)
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `reaction` WHERE `id` = ?", delete.ID)
 	return err
 }
+
+func vacuumReaction(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `reaction` WHERE `creator_id` NOT IN (SELECT `id` FROM `user`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
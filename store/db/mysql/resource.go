@@ -15,7 +15,7 @@ func (d *DB) CreateResource(ctx context.Context, create *store.Resource) (*store
 	args := []any{create.UID, create.Filename, create.Blob, create.ExternalLink, create.Type, create.Size, create.CreatorID, create.InternalPath, create.MemoID}
 
 	stmt := "INSERT INTO `resource` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,12 +51,12 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 		where = append(where, "`memo_id` IS NOT NULL")
 	}
 
-	fields := []string{"`id`", "`uid`", "`filename`", "`external_link`", "`type`", "`size`", "`creator_id`", "UNIX_TIMESTAMP(`created_ts`)", "UNIX_TIMESTAMP(`updated_ts`)", "`internal_path`", "`memo_id`"}
+	fields := []string{"`id`", "`uid`", "`filename`", "`external_link`", "`type`", "`size`", "`creator_id`", "UNIX_TIMESTAMP(`created_ts`)", "UNIX_TIMESTAMP(`updated_ts`)", "`internal_path`", "`memo_id`", "`extracted_text`"}
 	if find.GetBlob {
 		fields = append(fields, "`blob`")
 	}
 
-	query := fmt.Sprintf("SELECT %s FROM `resource` WHERE %s ORDER BY `updated_ts` DESC, `created_ts` DESC", strings.Join(fields, ", "), strings.Join(where, " AND "))
+	query := fmt.Sprintf("SELECT %s FROM `resource` WHERE %s ORDER BY `updated_ts` DESC, `created_ts` DESC, `id` DESC", strings.Join(fields, ", "), strings.Join(where, " AND "))
 	if find.Limit != nil {
 		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
 		if find.Offset != nil {
@@ -64,7 +64,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 		}
 	}
 
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +74,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 	for rows.Next() {
 		resource := store.Resource{}
 		var memoID sql.NullInt32
+		var extractedText sql.NullString
 		dests := []any{
 			&resource.ID,
 			&resource.UID,
@@ -86,6 +87,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 			&resource.UpdatedTs,
 			&resource.InternalPath,
 			&memoID,
+			&extractedText,
 		}
 		if find.GetBlob {
 			dests = append(dests, &resource.Blob)
@@ -96,6 +98,9 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 		if memoID.Valid {
 			resource.MemoID = &memoID.Int32
 		}
+		if extractedText.Valid {
+			resource.ExtractedText = &extractedText.String
+		}
 		list = append(list, &resource)
 	}
 
@@ -124,6 +129,9 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 	if v := update.UID; v != nil {
 		set, args = append(set, "`uid` = ?"), append(args, *v)
 	}
+	if v := update.CreatorID; v != nil {
+		set, args = append(set, "`creator_id` = ?"), append(args, *v)
+	}
 	if v := update.UpdatedTs; v != nil {
 		set, args = append(set, "`updated_ts` = FROM_UNIXTIME(?)"), append(args, *v)
 	}
@@ -142,10 +150,13 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 	if v := update.Blob; v != nil {
 		set, args = append(set, "`blob` = ?"), append(args, v)
 	}
+	if v := update.ExtractedText; v != nil {
+		set, args = append(set, "`extracted_text` = ?"), append(args, *v)
+	}
 
 	args = append(args, update.ID)
 	stmt := "UPDATE `resource` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
 		return nil, err
 	}
 
@@ -154,7 +165,7 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 
 func (d *DB) DeleteResource(ctx context.Context, delete *store.DeleteResource) error {
 	stmt := "DELETE FROM `resource` WHERE `id` = ?"
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
+	result, err := d.conn().ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
 		return err
 	}
@@ -13,7 +13,7 @@ func (d *DB) CreateStorage(ctx context.Context, create *store.Storage) (*store.S
 	args := []any{create.Name, create.Type, create.Config}
 
 	stmt := "INSERT INTO `storage` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func (d *DB) ListStorages(ctx context.Context, find *store.FindStorage) ([]*stor
 		where, args = append(where, "`id` = ?"), append(args, *find.ID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, "SELECT `id`, `name`, `type`, `config` FROM `storage` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
+	rows, err := d.conn().QueryContext(ctx, "SELECT `id`, `name`, `type`, `config` FROM `storage` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
 		args...,
 	)
 	if err != nil {
@@ -75,14 +75,14 @@ func (d *DB) UpdateStorage(ctx context.Context, update *store.UpdateStorage) (*s
 	args = append(args, update.ID)
 
 	stmt := "UPDATE `storage` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	_, err := d.db.ExecContext(ctx, stmt, args...)
+	_, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	storage := &store.Storage{}
 	stmt = "SELECT `id`, `name`, `type`, `config` FROM `storage` WHERE `id` = ?"
-	if err := d.db.QueryRowContext(ctx, stmt, update.ID).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, update.ID).Scan(
 		&storage.ID,
 		&storage.Name,
 		&storage.Type,
@@ -96,7 +96,7 @@ func (d *DB) UpdateStorage(ctx context.Context, update *store.UpdateStorage) (*s
 
 func (d *DB) DeleteStorage(ctx context.Context, delete *store.DeleteStorage) error {
 	stmt := "DELETE FROM `storage` WHERE `id` = ?"
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
+	result, err := d.conn().ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
 		return err
 	}
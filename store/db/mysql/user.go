@@ -17,7 +17,7 @@ func (d *DB) CreateUser(ctx context.Context, create *store.User) (*store.User, e
 	args := []any{create.Username, create.Role, create.Email, create.Nickname, create.PasswordHash, create.AvatarURL}
 
 	stmt := "INSERT INTO user (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +68,7 @@ func (d *DB) UpdateUser(ctx context.Context, update *store.UpdateUser) (*store.U
 	args = append(args, update.ID)
 
 	query := "UPDATE `user` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	if _, err := d.db.ExecContext(ctx, query, args...); err != nil {
+	if _, err := d.conn().ExecContext(ctx, query, args...); err != nil {
 		return nil, err
 	}
 
@@ -91,6 +91,9 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 	if v := find.Role; v != nil {
 		where, args = append(where, "`role` = ?"), append(args, *v)
 	}
+	if v := find.RowStatus; v != nil {
+		where, args = append(where, "`row_status` = ?"), append(args, *v)
+	}
 	if v := find.Email; v != nil {
 		where, args = append(where, "`email` = ?"), append(args, *v)
 	}
@@ -98,7 +101,7 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 		where, args = append(where, "`nickname` = ?"), append(args, *v)
 	}
 
-	orderBy := []string{"`created_ts` DESC", "`row_status` DESC"}
+	orderBy := []string{"`created_ts` DESC", "`row_status` DESC", "`id` DESC"}
 	if find.Random {
 		orderBy = slices.Concat([]string{"RAND()"}, orderBy)
 	}
@@ -106,8 +109,11 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 	query := "SELECT `id`, `username`, `role`, `email`, `nickname`, `password_hash`, `avatar_url`, `description`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `row_status` FROM `user` WHERE " + strings.Join(where, " AND ") + " ORDER BY " + strings.Join(orderBy, ", ")
 	if v := find.Limit; v != nil {
 		query += fmt.Sprintf(" LIMIT %d", *v)
+		if v := find.Offset; v != nil {
+			query += fmt.Sprintf(" OFFSET %d", *v)
+		}
 	}
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +159,7 @@ func (d *DB) GetUser(ctx context.Context, find *store.FindUser) (*store.User, er
 }
 
 func (d *DB) DeleteUser(ctx context.Context, delete *store.DeleteUser) error {
-	result, err := d.db.ExecContext(ctx, "DELETE FROM `user` WHERE `id` = ?", delete.ID)
+	result, err := d.conn().ExecContext(ctx, "DELETE FROM `user` WHERE `id` = ?", delete.ID)
 	if err != nil {
 		return err
 	}
@@ -168,3 +174,12 @@ func (d *DB) DeleteUser(ctx context.Context, delete *store.DeleteUser) error {
 
 	return nil
 }
+
+func (d *DB) GetUserUsage(ctx context.Context, userID int32) (int32, int64, error) {
+	var memoCount int32
+	var resourceSize int64
+	if err := d.conn().QueryRowContext(ctx, "SELECT (SELECT COUNT(*) FROM `memo` WHERE `creator_id` = ?), (SELECT COALESCE(SUM(`size`), 0) FROM `resource` WHERE `creator_id` = ?)", userID, userID).Scan(&memoCount, &resourceSize); err != nil {
+		return 0, 0, err
+	}
+	return memoCount, resourceSize, nil
+}
@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateUserDomain(ctx context.Context, create *store.UserDomain) (*store.UserDomain, error) {
+	fields := []string{"`user_id`", "`hostname`", "`verification_token`", "`verified_ts`"}
+	placeholder := []string{"?", "?", "?", "?"}
+	args := []any{create.UserID, create.Hostname, create.VerificationToken, create.VerifiedTs}
+
+	stmt := "INSERT INTO `user_domain` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	id32 := int32(id)
+	list, err := d.ListUserDomains(ctx, &store.FindUserDomain{ID: &id32})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find created user domain %d", id32)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListUserDomains(ctx context.Context, find *store.FindUserDomain) ([]*store.UserDomain, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, "`id` = ?"), append(args, *v)
+	}
+	if v := find.UserID; v != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *v)
+	}
+	if v := find.Hostname; v != nil {
+		where, args = append(where, "`hostname` = ?"), append(args, *v)
+	}
+	if v := find.Verified; v != nil {
+		if *v {
+			where = append(where, "`verified_ts` > 0")
+		} else {
+			where = append(where, "`verified_ts` = 0")
+		}
+	}
+
+	query := "SELECT `id`, `user_id`, UNIX_TIMESTAMP(`created_ts`), `hostname`, `verification_token`, `verified_ts` FROM `user_domain` WHERE " + strings.Join(where, " AND ") + " ORDER BY `hostname` ASC"
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.UserDomain{}
+	for rows.Next() {
+		domain := &store.UserDomain{}
+		if err := rows.Scan(
+			&domain.ID,
+			&domain.UserID,
+			&domain.CreatedTs,
+			&domain.Hostname,
+			&domain.VerificationToken,
+			&domain.VerifiedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, domain)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateUserDomain(ctx context.Context, update *store.UpdateUserDomain) (*store.UserDomain, error) {
+	set, args := []string{}, []any{}
+	if v := update.VerifiedTs; v != nil {
+		set, args = append(set, "`verified_ts` = ?"), append(args, *v)
+	}
+	if len(set) > 0 {
+		args = append(args, update.ID)
+		stmt := "UPDATE `user_domain` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
+		if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := d.ListUserDomains(ctx, &store.FindUserDomain{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("user domain %d not found", update.ID)
+	}
+	return list[0], nil
+}
+
+func (d *DB) DeleteUserDomain(ctx context.Context, delete *store.DeleteUserDomain) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `user_domain` WHERE `id` = ?", delete.ID)
+	return err
+}
+
+func vacuumUserDomain(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `user_domain` WHERE `user_id` NOT IN (SELECT `id` FROM `user`)"
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -9,12 +10,12 @@ import (
 )
 
 func (d *DB) CreateWebhook(ctx context.Context, create *storepb.Webhook) (*storepb.Webhook, error) {
-	fields := []string{"`name`", "`url`", "`creator_id`"}
-	placeholder := []string{"?", "?", "?"}
-	args := []any{create.Name, create.Url, create.CreatorId}
+	fields := []string{"`name`", "`url`", "`creator_id`", "`secret`", "`payload_template`"}
+	placeholder := []string{"?", "?", "?", "?", "?"}
+	args := []any{create.Name, create.Url, create.CreatorId, create.Secret, create.PayloadTemplate}
 
 	stmt := "INSERT INTO `webhook` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +38,7 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `row_status`, `creator_id`, `name`, `url` FROM `webhook` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
+	rows, err := d.conn().QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `row_status`, `creator_id`, `name`, `url`, `secret`, `payload_template` FROM `webhook` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
 		args...,
 	)
 	if err != nil {
@@ -57,6 +58,8 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			&webhook.CreatorId,
 			&webhook.Name,
 			&webhook.Url,
+			&webhook.Secret,
+			&webhook.PayloadTemplate,
 		); err != nil {
 			return nil, err
 		}
@@ -93,10 +96,16 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 	if update.URL != nil {
 		set, args = append(set, "`url` = ?"), append(args, *update.URL)
 	}
+	if update.Secret != nil {
+		set, args = append(set, "`secret` = ?"), append(args, *update.Secret)
+	}
+	if update.PayloadTemplate != nil {
+		set, args = append(set, "`payload_template` = ?"), append(args, *update.PayloadTemplate)
+	}
 	args = append(args, update.ID)
 
 	stmt := "UPDATE `webhook` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	_, err := d.db.ExecContext(ctx, stmt, args...)
+	_, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +119,20 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 }
 
 func (d *DB) DeleteWebhook(ctx context.Context, delete *store.DeleteWebhook) error {
-	_, err := d.db.ExecContext(ctx, "DELETE FROM `webhook` WHERE `id` = ?", delete.ID)
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM `webhook` WHERE `id` = ?", delete.ID)
 	return err
 }
+
+func vacuumWebhook(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM `webhook` WHERE `creator_id` NOT IN (SELECT `id` FROM `user`)"
+	if _, err := tx.ExecContext(ctx, stmt); err != nil {
+		return err
+	}
+	// The deliveries of the deleted webhooks have the payloads of the memos.
+	stmt = "DELETE FROM `webhook_delivery` WHERE `webhook_id` NOT IN (SELECT `id` FROM `webhook`)"
+	if _, err := tx.ExecContext(ctx, stmt); err != nil {
+		return err
+	}
+
+	return nil
+}
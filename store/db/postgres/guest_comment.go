@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateGuestComment(ctx context.Context, create *store.GuestComment) (*store.GuestComment, error) {
+	fields := []string{"memo_id", "name", "email", "content", "status", "ip_hash"}
+	args := []any{create.MemoID, create.Name, create.Email, create.Content, create.Status.String(), create.IPHash}
+
+	stmt := "INSERT INTO guest_comment (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListGuestComments(ctx context.Context, find *store.FindGuestComment) ([]*store.GuestComment, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.MemoID; v != nil {
+		where, args = append(where, "memo_id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, "status = "+placeholder(len(args)+1)), append(args, v.String())
+	}
+	if v := find.IPHash; v != nil {
+		where, args = append(where, "ip_hash = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.MemoCreatorID; v != nil {
+		where, args = append(where, "memo_id IN (SELECT id FROM memo WHERE creator_id = "+placeholder(len(args)+1)+")"), append(args, *v)
+	}
+	if v := find.CreatedTsAfter; v != nil {
+		where, args = append(where, "created_ts > "+placeholder(len(args)+1)), append(args, *v)
+	}
+
+	query := "SELECT id, created_ts, updated_ts, memo_id, name, email, content, status, ip_hash FROM guest_comment WHERE " + strings.Join(where, " AND ") + " ORDER BY created_ts ASC, id ASC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.GuestComment{}
+	for rows.Next() {
+		guestComment := &store.GuestComment{}
+		if err := rows.Scan(
+			&guestComment.ID,
+			&guestComment.CreatedTs,
+			&guestComment.UpdatedTs,
+			&guestComment.MemoID,
+			&guestComment.Name,
+			&guestComment.Email,
+			&guestComment.Content,
+			&guestComment.Status,
+			&guestComment.IPHash,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, guestComment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateGuestComment(ctx context.Context, update *store.UpdateGuestComment) (*store.GuestComment, error) {
+	set, args := []string{}, []any{}
+	if update.UpdatedTs != nil {
+		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *update.UpdatedTs)
+	}
+	if update.Status != nil {
+		set, args = append(set, "status = "+placeholder(len(args)+1)), append(args, update.Status.String())
+	}
+	if len(set) > 0 {
+		args = append(args, update.ID)
+		stmt := "UPDATE guest_comment SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args))
+		if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := d.ListGuestComments(ctx, &store.FindGuestComment{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("guest comment %d not found", update.ID)
+	}
+	return list[0], nil
+}
+
+func (d *DB) DeleteGuestComment(ctx context.Context, delete *store.DeleteGuestComment) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM guest_comment WHERE id = $1", delete.ID)
+	return err
+}
+
+func vacuumGuestComment(ctx context.Context, tx *sql.Tx) error {
+	stmt := "DELETE FROM guest_comment WHERE memo_id NOT IN (SELECT id FROM memo)"
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateIdempotencyKey(ctx context.Context, create *store.IdempotencyKey) (*store.IdempotencyKey, error) {
+	fields := []string{"creator_id", "entity_type", "key", "entity_id"}
+	args := []any{create.CreatorID, create.EntityType, create.Key, create.EntityID}
+	stmt := "INSERT INTO idempotency_key (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts"
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListIdempotencyKeys(ctx context.Context, find *store.FindIdempotencyKey) ([]*store.IdempotencyKey, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *find.ID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "creator_id = "+placeholder(len(args)+1)), append(args, *find.CreatorID)
+	}
+	if find.EntityType != nil {
+		where, args = append(where, "entity_type = "+placeholder(len(args)+1)), append(args, *find.EntityType)
+	}
+	if find.Key != nil {
+		where, args = append(where, "key = "+placeholder(len(args)+1)), append(args, *find.Key)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT id, created_ts, creator_id, entity_type, key, entity_id FROM idempotency_key WHERE "+strings.Join(where, " AND ")+" ORDER BY id DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.IdempotencyKey{}
+	for rows.Next() {
+		idempotencyKey := &store.IdempotencyKey{}
+		if err := rows.Scan(
+			&idempotencyKey.ID,
+			&idempotencyKey.CreatedTs,
+			&idempotencyKey.CreatorID,
+			&idempotencyKey.EntityType,
+			&idempotencyKey.Key,
+			&idempotencyKey.EntityID,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, idempotencyKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateIdempotencyKey(ctx context.Context, update *store.UpdateIdempotencyKey) error {
+	_, err := d.conn().ExecContext(ctx, "UPDATE idempotency_key SET entity_id = "+placeholder(1)+" WHERE id = "+placeholder(2), update.EntityID, update.ID)
+	return err
+}
+
+func (d *DB) DeleteIdempotencyKey(ctx context.Context, delete *store.DeleteIdempotencyKey) error {
+	where, args := []string{"1 = 1"}, []any{}
+	if delete.ID != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *delete.ID)
+	}
+	if delete.CreatedTsBefore != nil {
+		where, args = append(where, "created_ts < "+placeholder(len(args)+1)), append(args, *delete.CreatedTsBefore)
+	}
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM idempotency_key WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
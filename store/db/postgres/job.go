@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateJob(ctx context.Context, create *store.Job) (*store.Job, error) {
+	fields := []string{"kind", "payload", "status", "max_attempts", "next_attempt_ts"}
+	args := []any{create.Kind, create.Payload, create.Status, create.MaxAttempts, create.NextAttemptTs}
+	stmt := "INSERT INTO job (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListJobs(ctx context.Context, find *store.FindJob) ([]*store.Job, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *find.ID)
+	}
+	if find.Kind != nil {
+		where, args = append(where, "kind = "+placeholder(len(args)+1)), append(args, *find.Kind)
+	}
+	if find.Status != nil {
+		where, args = append(where, "status = "+placeholder(len(args)+1)), append(args, *find.Status)
+	}
+	if find.NextAttemptTsBefore != nil {
+		where, args = append(where, "next_attempt_ts <= "+placeholder(len(args)+1)), append(args, *find.NextAttemptTsBefore)
+	}
+	if find.UpdatedTsBefore != nil {
+		where, args = append(where, "updated_ts < "+placeholder(len(args)+1)), append(args, *find.UpdatedTsBefore)
+	}
+
+	query := "SELECT id, created_ts, updated_ts, kind, payload, status, attempts, max_attempts, next_attempt_ts, last_error FROM job WHERE " + strings.Join(where, " AND ") + " ORDER BY id DESC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.Job{}
+	for rows.Next() {
+		job := &store.Job{}
+		if err := rows.Scan(
+			&job.ID,
+			&job.CreatedTs,
+			&job.UpdatedTs,
+			&job.Kind,
+			&job.Payload,
+			&job.Status,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.NextAttemptTs,
+			&job.LastError,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateJob(ctx context.Context, update *store.UpdateJob) error {
+	set, args := []string{}, []any{}
+	if update.UpdatedTs != nil {
+		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *update.UpdatedTs)
+	}
+	if update.Status != nil {
+		set, args = append(set, "status = "+placeholder(len(args)+1)), append(args, *update.Status)
+	}
+	if update.Attempts != nil {
+		set, args = append(set, "attempts = "+placeholder(len(args)+1)), append(args, *update.Attempts)
+	}
+	if update.NextAttemptTs != nil {
+		set, args = append(set, "next_attempt_ts = "+placeholder(len(args)+1)), append(args, *update.NextAttemptTs)
+	}
+	if update.LastError != nil {
+		set, args = append(set, "last_error = "+placeholder(len(args)+1)), append(args, *update.LastError)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	stmt := "UPDATE job SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1)
+	args = append(args, update.ID)
+	_, err := d.conn().ExecContext(ctx, stmt, args...)
+	return err
+}
+
+func (d *DB) DeleteJobs(ctx context.Context, delete *store.DeleteJob) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM job WHERE status = "+placeholder(1)+" AND updated_ts < "+placeholder(2), delete.Status, delete.UpdatedTsBefore)
+	return err
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertMemoProperty(ctx context.Context, upsert *store.MemoProperty) (*store.MemoProperty, error) {
+	stmt := "INSERT INTO memo_property (memo_id, name, value, updated_ts) VALUES (" + placeholders(4) + ") ON CONFLICT(memo_id, name) DO UPDATE SET value = EXCLUDED.value, updated_ts = EXCLUDED.updated_ts"
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.MemoID, upsert.Name, upsert.Value, upsert.UpdatedTs); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListMemoProperties(ctx context.Context, find *store.FindMemoProperty) ([]*store.MemoProperty, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.MemoID != nil {
+		where, args = append(where, "memo_id = "+placeholder(len(args)+1)), append(args, *find.MemoID)
+	}
+	if find.Name != nil {
+		where, args = append(where, "name = "+placeholder(len(args)+1)), append(args, *find.Name)
+	}
+	if find.Value != nil {
+		where, args = append(where, "value = "+placeholder(len(args)+1)), append(args, *find.Value)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT memo_id, name, value, updated_ts FROM memo_property WHERE "+strings.Join(where, " AND ")+" ORDER BY memo_id ASC, name ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoProperty{}
+	for rows.Next() {
+		memoProperty := &store.MemoProperty{}
+		if err := rows.Scan(
+			&memoProperty.MemoID,
+			&memoProperty.Name,
+			&memoProperty.Value,
+			&memoProperty.UpdatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, memoProperty)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteMemoProperty(ctx context.Context, delete *store.DeleteMemoProperty) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM memo_property WHERE memo_id = "+placeholder(1)+" AND name = "+placeholder(2), delete.MemoID, delete.Name)
+	return err
+}
+
+func vacuumMemoProperty(ctx context.Context, tx *sql.Tx) error {
+	stmt := `DELETE FROM memo_property WHERE memo_id NOT IN (SELECT id FROM memo)`
+	_, err := tx.ExecContext(ctx, stmt)
+	return err
+}
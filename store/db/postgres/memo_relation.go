@@ -19,7 +19,7 @@ func (d *DB) UpsertMemoRelation(ctx context.Context, create *store.MemoRelation)
 		RETURNING memo_id, related_memo_id, type
 	`
 	memoRelation := &store.MemoRelation{}
-	if err := d.db.QueryRowContext(
+	if err := d.conn().QueryRowContext(
 		ctx,
 		stmt,
 		create.MemoID,
@@ -48,7 +48,7 @@ func (d *DB) ListMemoRelations(ctx context.Context, find *store.FindMemoRelation
 		where, args = append(where, "type = "+placeholder(len(args)+1)), append(args, find.Type)
 	}
 
-	rows, err := d.db.QueryContext(ctx, `
+	rows, err := d.conn().QueryContext(ctx, `
 		SELECT
 			memo_id,
 			related_memo_id,
@@ -92,7 +92,7 @@ func (d *DB) DeleteMemoRelation(ctx context.Context, delete *store.DeleteMemoRel
 		where, args = append(where, "type = "+placeholder(len(args)+1)), append(args, delete.Type)
 	}
 	stmt := `DELETE FROM memo_relation WHERE ` + strings.Join(where, " AND ")
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
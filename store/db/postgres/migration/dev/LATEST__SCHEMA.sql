@@ -43,9 +43,14 @@ CREATE TABLE memo (
   updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
   row_status TEXT NOT NULL DEFAULT 'NORMAL',
   content TEXT NOT NULL,
-  visibility TEXT NOT NULL DEFAULT 'PRIVATE'
+  visibility TEXT NOT NULL DEFAULT 'PRIVATE',
+  group_id INTEGER
 );
 
+CREATE INDEX idx_memo_created_ts ON memo (created_ts);
+
+CREATE INDEX idx_memo_updated_ts ON memo (updated_ts);
+
 -- memo_organizer
 CREATE TABLE memo_organizer (
   memo_id INTEGER NOT NULL,
@@ -75,7 +80,8 @@ CREATE TABLE resource (
   type TEXT NOT NULL DEFAULT '',
   size INTEGER NOT NULL DEFAULT 0,
   internal_path TEXT NOT NULL DEFAULT '',
-  memo_id INTEGER DEFAULT NULL
+  memo_id INTEGER DEFAULT NULL,
+  extracted_text TEXT
 );
 
 -- tag
@@ -130,7 +136,52 @@ CREATE TABLE webhook (
   row_status TEXT NOT NULL DEFAULT 'NORMAL',
   creator_id INTEGER NOT NULL,
   name TEXT NOT NULL,
-  url TEXT NOT NULL
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL DEFAULT '',
+  payload_template TEXT NOT NULL DEFAULT ''
+);
+
+-- webhook_delivery
+CREATE TABLE webhook_delivery (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  webhook_id INTEGER NOT NULL,
+  activity_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'PENDING',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+  last_status_code INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_webhook_delivery_webhook_id ON webhook_delivery (webhook_id);
+
+CREATE INDEX idx_webhook_delivery_status ON webhook_delivery (status, next_attempt_ts);
+
+-- incoming_webhook
+CREATE TABLE incoming_webhook (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  token TEXT NOT NULL UNIQUE,
+  visibility TEXT NOT NULL DEFAULT '',
+  tags TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_incoming_webhook_creator_id ON incoming_webhook (creator_id);
+
+-- idempotency_key
+CREATE TABLE idempotency_key (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  entity_type TEXT NOT NULL,
+  key TEXT NOT NULL,
+  entity_id INTEGER NOT NULL DEFAULT 0,
+  UNIQUE(creator_id, entity_type, key)
 );
 
 -- reaction
@@ -142,3 +193,176 @@ CREATE TABLE reaction (
   reaction_type TEXT NOT NULL,
   UNIQUE(creator_id, content_id, reaction_type)
 );
+
+CREATE INDEX idx_reaction_content_id ON reaction (content_id);
+
+-- memo_report
+CREATE TABLE memo_report (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  reporter_id INTEGER NOT NULL,
+  memo_id INTEGER NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'OPEN',
+  resolver_id INTEGER NOT NULL DEFAULT 0,
+  resolution TEXT NOT NULL DEFAULT '',
+  UNIQUE(reporter_id, memo_id)
+);
+
+CREATE INDEX idx_memo_report_memo_id ON memo_report (memo_id);
+
+-- telegram_message
+CREATE TABLE telegram_message (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  memo_id INTEGER NOT NULL,
+  chat_id BIGINT NOT NULL,
+  message_id BIGINT NOT NULL,
+  source_message_id BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_telegram_message_memo_id ON telegram_message (memo_id);
+
+-- memo_reminder
+CREATE TABLE memo_reminder (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  memo_id INTEGER NOT NULL,
+  remind_ts BIGINT NOT NULL,
+  delivered_ts BIGINT NOT NULL DEFAULT 0,
+  UNIQUE(creator_id, memo_id)
+);
+
+CREATE INDEX idx_memo_reminder_remind_ts ON memo_reminder (remind_ts);
+
+-- vault_file
+CREATE TABLE vault_file (
+  id SERIAL PRIMARY KEY,
+  creator_id INTEGER NOT NULL,
+  updated_ts BIGINT NOT NULL,
+  path TEXT NOT NULL,
+  memo_id INTEGER NOT NULL DEFAULT 0,
+  resource_id INTEGER NOT NULL DEFAULT 0,
+  deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  UNIQUE(creator_id, path)
+);
+
+-- memo_property
+CREATE TABLE memo_property (
+  memo_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  value TEXT NOT NULL DEFAULT '',
+  updated_ts BIGINT NOT NULL,
+  UNIQUE(memo_id, name)
+);
+
+-- memo_embedding
+CREATE TABLE memo_embedding (
+  memo_id INTEGER NOT NULL,
+  model TEXT NOT NULL,
+  embedding BYTEA NOT NULL,
+  updated_ts BIGINT NOT NULL,
+  UNIQUE(memo_id)
+);
+
+-- job
+CREATE TABLE job (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  kind TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'PENDING',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  max_attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_job_status ON job (status, next_attempt_ts);
+
+-- task_run
+CREATE TABLE task_run (
+  name TEXT NOT NULL PRIMARY KEY,
+  status TEXT NOT NULL,
+  started_ts BIGINT NOT NULL,
+  finished_ts BIGINT NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT ''
+);
+
+-- user_group
+CREATE TABLE user_group (
+  id SERIAL PRIMARY KEY,
+  creator_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT ''
+);
+
+-- user_group_member
+CREATE TABLE user_group_member (
+  group_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  role TEXT NOT NULL DEFAULT 'MEMBER',
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  UNIQUE(group_id, user_id)
+);
+
+CREATE INDEX idx_user_group_member_user_id ON user_group_member (user_id);
+
+-- user_domain
+CREATE TABLE user_domain (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  hostname TEXT NOT NULL UNIQUE,
+  verification_token TEXT NOT NULL,
+  verified_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_user_domain_user_id ON user_domain (user_id);
+
+-- invitation
+CREATE TABLE invitation (
+  id SERIAL PRIMARY KEY,
+  creator_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  code TEXT NOT NULL UNIQUE,
+  role TEXT NOT NULL DEFAULT 'USER',
+  max_uses INTEGER NOT NULL DEFAULT 1,
+  use_count INTEGER NOT NULL DEFAULT 0,
+  expires_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_invitation_creator_id ON invitation (creator_id);
+
+-- guest_comment
+CREATE TABLE guest_comment (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  memo_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  email TEXT NOT NULL DEFAULT '',
+  content TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'PENDING',
+  ip_hash TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_guest_comment_memo_id ON guest_comment (memo_id);
+
+CREATE INDEX idx_guest_comment_ip_hash ON guest_comment (ip_hash);
+
+-- memo_slug
+CREATE TABLE memo_slug (
+  memo_id INTEGER NOT NULL UNIQUE,
+  slug TEXT NOT NULL,
+  click_count INTEGER NOT NULL DEFAULT 0,
+  updated_ts BIGINT NOT NULL,
+  creator_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_memo_slug_creator_id_slug ON memo_slug (creator_id, slug);
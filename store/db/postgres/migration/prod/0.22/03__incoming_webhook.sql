@@ -0,0 +1,11 @@
+CREATE TABLE incoming_webhook (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  token TEXT NOT NULL UNIQUE,
+  visibility TEXT NOT NULL DEFAULT '',
+  tags TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_incoming_webhook_creator_id ON incoming_webhook (creator_id);
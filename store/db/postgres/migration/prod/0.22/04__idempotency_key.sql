@@ -0,0 +1,9 @@
+CREATE TABLE idempotency_key (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  entity_type TEXT NOT NULL,
+  key TEXT NOT NULL,
+  entity_id INTEGER NOT NULL DEFAULT 0,
+  UNIQUE(creator_id, entity_type, key)
+);
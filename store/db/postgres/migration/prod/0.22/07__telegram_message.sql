@@ -0,0 +1,10 @@
+CREATE TABLE telegram_message (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  memo_id INTEGER NOT NULL,
+  chat_id BIGINT NOT NULL,
+  message_id BIGINT NOT NULL,
+  source_message_id BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_telegram_message_memo_id ON telegram_message (memo_id);
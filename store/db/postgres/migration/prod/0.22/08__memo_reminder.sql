@@ -0,0 +1,11 @@
+CREATE TABLE memo_reminder (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  memo_id INTEGER NOT NULL,
+  remind_ts BIGINT NOT NULL,
+  delivered_ts BIGINT NOT NULL DEFAULT 0,
+  UNIQUE(creator_id, memo_id)
+);
+
+CREATE INDEX idx_memo_reminder_remind_ts ON memo_reminder (remind_ts);
@@ -0,0 +1,10 @@
+CREATE TABLE vault_file (
+  id SERIAL PRIMARY KEY,
+  creator_id INTEGER NOT NULL,
+  updated_ts BIGINT NOT NULL,
+  path TEXT NOT NULL,
+  memo_id INTEGER NOT NULL DEFAULT 0,
+  resource_id INTEGER NOT NULL DEFAULT 0,
+  deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  UNIQUE(creator_id, path)
+);
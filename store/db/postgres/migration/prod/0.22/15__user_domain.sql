@@ -0,0 +1,10 @@
+CREATE TABLE user_domain (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  hostname TEXT NOT NULL UNIQUE,
+  verification_token TEXT NOT NULL,
+  verified_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_user_domain_user_id ON user_domain (user_id);
@@ -0,0 +1,15 @@
+CREATE TABLE guest_comment (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  memo_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  email TEXT NOT NULL DEFAULT '',
+  content TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'PENDING',
+  ip_hash TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_guest_comment_memo_id ON guest_comment (memo_id);
+
+CREATE INDEX idx_guest_comment_ip_hash ON guest_comment (ip_hash);
@@ -0,0 +1,12 @@
+ALTER TABLE memo_slug ADD COLUMN creator_id INTEGER NOT NULL DEFAULT 0;
+
+DELETE FROM memo_slug WHERE memo_id NOT IN (SELECT id FROM memo);
+
+UPDATE memo_slug SET creator_id = (SELECT creator_id FROM memo WHERE memo.id = memo_slug.memo_id);
+
+-- The slugs taken by several memos of a creator are kept by the first memo.
+DELETE FROM memo_slug WHERE EXISTS (SELECT 1 FROM memo_slug AS other WHERE other.creator_id = memo_slug.creator_id AND other.slug = memo_slug.slug AND other.memo_id < memo_slug.memo_id);
+
+DROP INDEX idx_memo_slug_slug;
+
+CREATE UNIQUE INDEX idx_memo_slug_creator_id_slug ON memo_slug (creator_id, slug);
@@ -31,7 +31,7 @@ func (d *DB) Migrate(ctx context.Context) error {
 }
 
 func (d *DB) nonProdMigrate(ctx context.Context) error {
-	rows, err := d.db.QueryContext(ctx, "SELECT tablename FROM pg_catalog.pg_tables WHERE schemaname != 'pg_catalog' AND schemaname != 'information_schema';")
+	rows, err := d.conn().QueryContext(ctx, "SELECT tablename FROM pg_catalog.pg_tables WHERE schemaname != 'pg_catalog' AND schemaname != 'information_schema';")
 	if err != nil {
 		return errors.Errorf("failed to query database tables: %s", err)
 	}
@@ -60,7 +60,7 @@ func (d *DB) nonProdMigrate(ctx context.Context) error {
 	}
 
 	stmt := string(buf)
-	if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt); err != nil {
 		return errors.Errorf("failed to exec SQL %s: %s", stmt, err)
 	}
 
@@ -78,7 +78,7 @@ func (d *DB) prodMigrate(ctx context.Context) error {
 		}
 
 		stmt := string(buf)
-		if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+		if _, err := d.conn().ExecContext(ctx, stmt); err != nil {
 			return errors.Errorf("failed to exec SQL %s: %s", stmt, err)
 		}
 		if _, err := d.UpsertMigrationHistory(ctx, &store.UpsertMigrationHistory{
@@ -130,7 +130,7 @@ func (d *DB) applyMigrationForMinorVersion(ctx context.Context, minorVersion str
 			if strings.TrimSpace(stmt) == "" {
 				continue
 			}
-			if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+			if _, err := d.conn().ExecContext(ctx, stmt); err != nil {
 				return errors.Wrapf(err, "migrate error: %s", stmt)
 			}
 		}
@@ -9,23 +9,33 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/telemetry"
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
 )
 
 type DB struct {
-	db      *sql.DB
+	db *sql.DB
+	// tx is the transaction the driver is bound to, see WithTx.
+	tx      *sql.Tx
 	profile *profile.Profile
 	// Add any other fields as needed
 }
 
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 func NewDB(profile *profile.Profile) (store.Driver, error) {
 	if profile == nil {
 		return nil, errors.New("profile is nil")
 	}
 
 	// Open the PostgreSQL connection
-	db, err := sql.Open("postgres", profile.DSN)
+	db, err := telemetry.OpenDB("postgres", profile.DSN, "postgresql")
 	if err != nil {
 		log.Printf("Failed to open database: %s", err)
 		return nil, errors.Wrapf(err, "failed to open database: %s", profile.DSN)
@@ -44,13 +54,42 @@ func (d *DB) GetDB() *sql.DB {
 	return d.db
 }
 
-func (d *DB) Vacuum(ctx context.Context) error {
+// conn returns the transaction if the driver is bound to one, otherwise the database.
+func (d *DB) conn() queryer {
+	if d.tx != nil {
+		return d.tx
+	}
+	return d.db
+}
+
+// WithTx runs fn with the driver bound to a transaction, which is committed if fn returns no error.
+// If the driver is already bound to a transaction, fn joins it.
+func (d *DB) WithTx(ctx context.Context, fn func(driver store.Driver) error) error {
+	if d.tx != nil {
+		return fn(d)
+	}
 	tx, err := d.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	txDriver := *d
+	txDriver.tx = tx
+	if err := fn(&txDriver); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Vacuum deletes the rows of the deleted models, in the transaction of the driver if it's bound to one.
+func (d *DB) Vacuum(ctx context.Context) error {
+	return d.WithTx(ctx, func(driver store.Driver) error {
+		return vacuumImpl(ctx, driver.(*DB).tx)
+	})
+}
+
+func vacuumImpl(ctx context.Context, tx *sql.Tx) error {
 	if err := vacuumMemo(ctx, tx); err != nil {
 		return err
 	}
@@ -69,16 +108,64 @@ func (d *DB) Vacuum(ctx context.Context) error {
 	if err := vacuumInbox(ctx, tx); err != nil {
 		return err
 	}
+	if err := vacuumMemoReminder(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumVaultFile(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoProperty(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoSlug(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoEmbedding(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumUserGroupMember(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumUserDomain(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumInvitation(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumActivity(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumReaction(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumWebhook(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumIncomingWebhook(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumGuestComment(ctx, tx); err != nil {
+		return err
+	}
 	if err := vacuumTag(ctx, tx); err != nil {
 		// Prevent revive warning.
 		return err
 	}
 
-	return tx.Commit()
+	return nil
+}
+
+func (d *DB) GetCurrentDBSize(ctx context.Context) (int64, error) {
+	var size int64
+	if err := d.conn().QueryRowContext(ctx, "SELECT pg_database_size(current_database())").Scan(&size); err != nil {
+		return 0, err
+	}
+	return size, nil
 }
 
-func (*DB) GetCurrentDBSize(context.Context) (int64, error) {
-	return 0, errors.New("unimplemented")
+// MaintenanceStatements updates the statistics of the query planner, the dead rows are vacuumed by the autovacuum.
+func (*DB) MaintenanceStatements() []string {
+	return []string{"ANALYZE"}
 }
 
 func (d *DB) Close() error {
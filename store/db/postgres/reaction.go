@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"strings"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -12,7 +14,7 @@ func (d *DB) UpsertReaction(ctx context.Context, upsert *storepb.Reaction) (*sto
 	fields := []string{"creator_id", "content_id", "reaction_type"}
 	args := []interface{}{upsert.CreatorId, upsert.ContentId, upsert.ReactionType.String()}
 	stmt := "INSERT INTO reaction (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&upsert.Id,
 		&upsert.CreatedTs,
 	); err != nil {
@@ -35,7 +37,7 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 		where, args = append(where, "content_id = "+placeholder(len(args)+1)), append(args, *find.ContentID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, `
+	query := `
 		SELECT
 			id,
 			created_ts,
@@ -43,10 +45,15 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 			content_id,
 			reaction_type
 		FROM reaction
-		WHERE `+strings.Join(where, " AND ")+`
-		ORDER BY id ASC`,
-		args...,
-	)
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY id ASC`
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +84,16 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 }
 
 func (d *DB) DeleteReaction(ctx context.Context, delete *store.DeleteReaction) error {
-	_, err := d.db.ExecContext(ctx, "DELETE FROM reaction WHERE id = $1", delete.ID)
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM reaction WHERE id = $1", delete.ID)
 	return err
 }
+
+func vacuumReaction(ctx context.Context, tx *sql.Tx) error {
+	stmt := `DELETE FROM reaction WHERE creator_id NOT IN (SELECT id FROM "user")`
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
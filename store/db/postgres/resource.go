@@ -14,7 +14,7 @@ func (d *DB) CreateResource(ctx context.Context, create *store.Resource) (*store
 	args := []any{create.UID, create.Filename, create.Blob, create.ExternalLink, create.Type, create.Size, create.CreatorID, create.InternalPath, create.MemoID}
 
 	stmt := "INSERT INTO resource (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
 		return nil, err
 	}
 	return create, nil
@@ -42,7 +42,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 		where = append(where, "memo_id IS NOT NULL")
 	}
 
-	fields := []string{"id", "uid", "filename", "external_link", "type", "size", "creator_id", "created_ts", "updated_ts", "internal_path", "memo_id"}
+	fields := []string{"id", "uid", "filename", "external_link", "type", "size", "creator_id", "created_ts", "updated_ts", "internal_path", "memo_id", "extracted_text"}
 	if find.GetBlob {
 		fields = append(fields, "blob")
 	}
@@ -52,7 +52,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 			%s
 		FROM resource
 		WHERE %s
-		ORDER BY updated_ts DESC, created_ts DESC
+		ORDER BY updated_ts DESC, created_ts DESC, id DESC
 	`, strings.Join(fields, ", "), strings.Join(where, " AND "))
 	if find.Limit != nil {
 		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
@@ -61,7 +61,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 		}
 	}
 
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +71,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 	for rows.Next() {
 		resource := store.Resource{}
 		var memoID sql.NullInt32
+		var extractedText sql.NullString
 		dests := []any{
 			&resource.ID,
 			&resource.UID,
@@ -83,6 +84,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 			&resource.UpdatedTs,
 			&resource.InternalPath,
 			&memoID,
+			&extractedText,
 		}
 		if find.GetBlob {
 			dests = append(dests, &resource.Blob)
@@ -93,6 +95,9 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 		if memoID.Valid {
 			resource.MemoID = &memoID.Int32
 		}
+		if extractedText.Valid {
+			resource.ExtractedText = &extractedText.String
+		}
 		list = append(list, &resource)
 	}
 
@@ -109,6 +114,9 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 	if v := update.UID; v != nil {
 		set, args = append(set, "uid = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := update.CreatorID; v != nil {
+		set, args = append(set, "creator_id = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := update.UpdatedTs; v != nil {
 		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -127,6 +135,9 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 	if v := update.Blob; v != nil {
 		set, args = append(set, "blob = "+placeholder(len(args)+1)), append(args, v)
 	}
+	if v := update.ExtractedText; v != nil {
+		set, args = append(set, "extracted_text = "+placeholder(len(args)+1)), append(args, *v)
+	}
 
 	fields := []string{"id", "uid", "filename", "external_link", "type", "size", "creator_id", "created_ts", "updated_ts", "internal_path"}
 	stmt := `UPDATE resource SET ` + strings.Join(set, ", ") + ` WHERE id = ` + placeholder(len(args)+1) + ` RETURNING ` + strings.Join(fields, ", ")
@@ -144,7 +155,7 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 		&resource.UpdatedTs,
 		&resource.InternalPath,
 	}
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(dests...); err != nil {
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(dests...); err != nil {
 		return nil, err
 	}
 
@@ -153,7 +164,7 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) (
 
 func (d *DB) DeleteResource(ctx context.Context, delete *store.DeleteResource) error {
 	stmt := `DELETE FROM resource WHERE id = $1`
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
+	result, err := d.conn().ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
 		return err
 	}
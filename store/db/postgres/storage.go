@@ -12,7 +12,7 @@ func (d *DB) CreateStorage(ctx context.Context, create *store.Storage) (*store.S
 	args := []any{create.Name, create.Type, create.Config}
 
 	stmt := "INSERT INTO storage (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&create.ID,
 	); err != nil {
 		return nil, err
@@ -28,7 +28,7 @@ func (d *DB) ListStorages(ctx context.Context, find *store.FindStorage) ([]*stor
 		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *find.ID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, `
+	rows, err := d.conn().QueryContext(ctx, `
 		SELECT
 			id,
 			name,
@@ -77,7 +77,7 @@ func (d *DB) UpdateStorage(ctx context.Context, update *store.UpdateStorage) (*s
 	stmt := `UPDATE storage SET ` + strings.Join(set, ", ") + ` WHERE id = ` + placeholder(len(args)+1) + ` RETURNING id, name, type, config`
 	args = append(args, update.ID)
 	storage := &store.Storage{}
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&storage.ID,
 		&storage.Name,
 		&storage.Type,
@@ -91,7 +91,7 @@ func (d *DB) UpdateStorage(ctx context.Context, update *store.UpdateStorage) (*s
 
 func (d *DB) DeleteStorage(ctx context.Context, delete *store.DeleteStorage) error {
 	stmt := `DELETE FROM storage WHERE id = $1`
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
+	result, err := d.conn().ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
 		return err
 	}
@@ -10,7 +10,7 @@ import (
 
 func (d *DB) UpsertTag(ctx context.Context, upsert *store.Tag) (*store.Tag, error) {
 	stmt := "INSERT INTO tag (name, creator_id) VALUES ($1, $2) ON CONFLICT (name, creator_id) DO UPDATE SET name = $3"
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.Name, upsert.CreatorID, upsert.Name); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.Name, upsert.CreatorID, upsert.Name); err != nil {
 		return nil, err
 	}
 	return upsert, nil
@@ -31,7 +31,7 @@ func (d *DB) ListTags(ctx context.Context, find *store.FindTag) ([]*store.Tag, e
 		WHERE ` + strings.Join(where, " AND ") + `
 		ORDER BY name ASC
 	`
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,7 @@ func (d *DB) ListTags(ctx context.Context, find *store.FindTag) ([]*store.Tag, e
 func (d *DB) DeleteTag(ctx context.Context, delete *store.DeleteTag) error {
 	where, args := []string{"name = $1", "creator_id = $2"}, []any{delete.Name, delete.CreatorID}
 	stmt := `DELETE FROM tag WHERE ` + strings.Join(where, " AND ")
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateTelegramMessage(ctx context.Context, create *store.TelegramMessage) (*store.TelegramMessage, error) {
+	fields := []string{"memo_id", "chat_id", "message_id", "source_message_id"}
+	args := []any{create.MemoID, create.ChatID, create.MessageID, create.SourceMessageID}
+	stmt := "INSERT INTO telegram_message (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts"
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListTelegramMessages(ctx context.Context, find *store.FindTelegramMessage) ([]*store.TelegramMessage, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.MemoID != nil {
+		where, args = append(where, "memo_id = "+placeholder(len(args)+1)), append(args, *find.MemoID)
+	}
+	if find.ChatID != nil {
+		where, args = append(where, "chat_id = "+placeholder(len(args)+1)), append(args, *find.ChatID)
+	}
+	if find.MessageID != nil {
+		where, args = append(where, "(message_id = "+placeholder(len(args)+1)+" OR source_message_id = "+placeholder(len(args)+1)+")"), append(args, *find.MessageID)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT id, created_ts, memo_id, chat_id, message_id, source_message_id FROM telegram_message WHERE "+strings.Join(where, " AND ")+" ORDER BY id ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.TelegramMessage{}
+	for rows.Next() {
+		telegramMessage := &store.TelegramMessage{}
+		if err := rows.Scan(
+			&telegramMessage.ID,
+			&telegramMessage.CreatedTs,
+			&telegramMessage.MemoID,
+			&telegramMessage.ChatID,
+			&telegramMessage.MessageID,
+			&telegramMessage.SourceMessageID,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, telegramMessage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteTelegramMessage(ctx context.Context, delete *store.DeleteTelegramMessage) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM telegram_message WHERE memo_id = "+placeholder(1), delete.MemoID)
+	return err
+}
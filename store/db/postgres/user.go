@@ -13,7 +13,7 @@ func (d *DB) CreateUser(ctx context.Context, create *store.User) (*store.User, e
 	fields := []string{"username", "role", "email", "nickname", "password_hash", "avatar_url"}
 	args := []any{create.Username, create.Role, create.Email, create.Nickname, create.PasswordHash, create.AvatarURL}
 	stmt := "INSERT INTO \"user\" (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, avatar_url, description, created_ts, updated_ts, row_status"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&create.ID,
 		&create.AvatarURL,
 		&create.Description,
@@ -62,7 +62,7 @@ func (d *DB) UpdateUser(ctx context.Context, update *store.UpdateUser) (*store.U
 	`
 	args = append(args, update.ID)
 	user := &store.User{}
-	if err := d.db.QueryRowContext(ctx, query, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, query, args...).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Role,
@@ -93,6 +93,9 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 	if v := find.Role; v != nil {
 		where, args = append(where, "role = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := find.RowStatus; v != nil {
+		where, args = append(where, "row_status = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := find.Email; v != nil {
 		where, args = append(where, "email = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -100,7 +103,7 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 		where, args = append(where, "nickname = "+placeholder(len(args)+1)), append(args, *v)
 	}
 
-	orderBy := []string{"created_ts DESC", "row_status DESC"}
+	orderBy := []string{"created_ts DESC", "row_status DESC", "id DESC"}
 	if find.Random {
 		orderBy = slices.Concat([]string{"RANDOM()"}, orderBy)
 	}
@@ -122,8 +125,11 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 		WHERE ` + strings.Join(where, " AND ") + ` ORDER BY ` + strings.Join(orderBy, ", ")
 	if v := find.Limit; v != nil {
 		query += fmt.Sprintf(" LIMIT %d", *v)
+		if v := find.Offset; v != nil {
+			query += fmt.Sprintf(" OFFSET %d", *v)
+		}
 	}
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +164,7 @@ func (d *DB) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User
 }
 
 func (d *DB) DeleteUser(ctx context.Context, delete *store.DeleteUser) error {
-	result, err := d.db.ExecContext(ctx, `DELETE FROM "user" WHERE id = $1`, delete.ID)
+	result, err := d.conn().ExecContext(ctx, `DELETE FROM "user" WHERE id = $1`, delete.ID)
 	if err != nil {
 		return err
 	}
@@ -173,3 +179,12 @@ func (d *DB) DeleteUser(ctx context.Context, delete *store.DeleteUser) error {
 
 	return nil
 }
+
+func (d *DB) GetUserUsage(ctx context.Context, userID int32) (int32, int64, error) {
+	var memoCount int32
+	var resourceSize int64
+	if err := d.conn().QueryRowContext(ctx, "SELECT (SELECT COUNT(*) FROM memo WHERE creator_id = $1), (SELECT COALESCE(SUM(size), 0) FROM resource WHERE creator_id = $1)", userID).Scan(&memoCount, &resourceSize); err != nil {
+		return 0, 0, err
+	}
+	return memoCount, resourceSize, nil
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateUserGroup(ctx context.Context, create *store.UserGroup) (*store.UserGroup, error) {
+	fields := []string{"creator_id", "name", "description"}
+	args := []any{create.CreatorID, create.Name, create.Description}
+
+	stmt := "INSERT INTO user_group (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListUserGroups(ctx context.Context, find *store.FindUserGroup) ([]*store.UserGroup, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, "user_group.id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.Name; v != nil {
+		where, args = append(where, "user_group.name = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.MemberID; v != nil {
+		where, args = append(where, "user_group.id IN (SELECT group_id FROM user_group_member WHERE user_id = "+placeholder(len(args)+1)+")"), append(args, *v)
+	}
+
+	query := "SELECT id, creator_id, created_ts, updated_ts, name, description FROM user_group WHERE " + strings.Join(where, " AND ") + " ORDER BY name ASC"
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.UserGroup{}
+	for rows.Next() {
+		group := &store.UserGroup{}
+		if err := rows.Scan(
+			&group.ID,
+			&group.CreatorID,
+			&group.CreatedTs,
+			&group.UpdatedTs,
+			&group.Name,
+			&group.Description,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateUserGroup(ctx context.Context, update *store.UpdateUserGroup) (*store.UserGroup, error) {
+	set, args := []string{}, []any{}
+	if v := update.UpdatedTs; v != nil {
+		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := update.Name; v != nil {
+		set, args = append(set, "name = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := update.Description; v != nil {
+		set, args = append(set, "description = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if len(set) > 0 {
+		stmt := "UPDATE user_group SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1)
+		args = append(args, update.ID)
+		if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := d.ListUserGroups(ctx, &store.FindUserGroup{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("user group %d not found", update.ID)
+	}
+	return list[0], nil
+}
+
+func (d *DB) DeleteUserGroup(ctx context.Context, delete *store.DeleteUserGroup) error {
+	return d.WithTx(ctx, func(driver store.Driver) error {
+		conn := driver.(*DB).conn()
+		// The memos of the group aren't shared any more, they're kept private to their creators.
+		if _, err := conn.ExecContext(ctx, "UPDATE memo SET visibility = 'PRIVATE', group_id = NULL WHERE group_id = $1", delete.ID); err != nil {
+			return err
+		}
+		if _, err := conn.ExecContext(ctx, "DELETE FROM user_group_member WHERE group_id = $1", delete.ID); err != nil {
+			return err
+		}
+		_, err := conn.ExecContext(ctx, "DELETE FROM user_group WHERE id = $1", delete.ID)
+		return err
+	})
+}
+
+func (d *DB) UpsertUserGroupMember(ctx context.Context, upsert *store.UserGroupMember) (*store.UserGroupMember, error) {
+	stmt := "INSERT INTO user_group_member (group_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT(group_id, user_id) DO UPDATE SET role = EXCLUDED.role RETURNING created_ts"
+	if err := d.conn().QueryRowContext(ctx, stmt, upsert.GroupID, upsert.UserID, upsert.Role.String()).Scan(&upsert.CreatedTs); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListUserGroupMembers(ctx context.Context, find *store.FindUserGroupMember) ([]*store.UserGroupMember, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.GroupID; v != nil {
+		where, args = append(where, "group_id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.UserID; v != nil {
+		where, args = append(where, "user_id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+
+	query := "SELECT group_id, user_id, role, created_ts FROM user_group_member WHERE " + strings.Join(where, " AND ") + " ORDER BY created_ts ASC, user_id ASC"
+	rows, err := d.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.UserGroupMember{}
+	for rows.Next() {
+		member := &store.UserGroupMember{}
+		if err := rows.Scan(
+			&member.GroupID,
+			&member.UserID,
+			&member.Role,
+			&member.CreatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteUserGroupMember(ctx context.Context, delete *store.DeleteUserGroupMember) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM user_group_member WHERE group_id = $1 AND user_id = $2", delete.GroupID, delete.UserID)
+	return err
+}
+
+func vacuumUserGroupMember(ctx context.Context, tx *sql.Tx) error {
+	stmt := `DELETE FROM user_group_member WHERE user_id NOT IN (SELECT id FROM "user")`
+	_, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateVaultFile(ctx context.Context, create *store.VaultFile) (*store.VaultFile, error) {
+	fields := []string{"creator_id", "updated_ts", "path", "memo_id", "resource_id", "deleted"}
+	args := []any{create.CreatorID, create.UpdatedTs, create.Path, create.MemoID, create.ResourceID, create.Deleted}
+	stmt := "INSERT INTO vault_file (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id"
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(&create.ID); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListVaultFiles(ctx context.Context, find *store.FindVaultFile) ([]*store.VaultFile, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *find.ID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "creator_id = "+placeholder(len(args)+1)), append(args, *find.CreatorID)
+	}
+	if find.Path != nil {
+		where, args = append(where, "path = "+placeholder(len(args)+1)), append(args, *find.Path)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT id, creator_id, updated_ts, path, memo_id, resource_id, deleted FROM vault_file WHERE "+strings.Join(where, " AND ")+" ORDER BY path ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.VaultFile{}
+	for rows.Next() {
+		vaultFile := &store.VaultFile{}
+		if err := rows.Scan(
+			&vaultFile.ID,
+			&vaultFile.CreatorID,
+			&vaultFile.UpdatedTs,
+			&vaultFile.Path,
+			&vaultFile.MemoID,
+			&vaultFile.ResourceID,
+			&vaultFile.Deleted,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, vaultFile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateVaultFile(ctx context.Context, update *store.UpdateVaultFile) error {
+	set, args := []string{}, []any{}
+	if update.UpdatedTs != nil {
+		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *update.UpdatedTs)
+	}
+	if update.Path != nil {
+		set, args = append(set, "path = "+placeholder(len(args)+1)), append(args, *update.Path)
+	}
+	if update.MemoID != nil {
+		set, args = append(set, "memo_id = "+placeholder(len(args)+1)), append(args, *update.MemoID)
+	}
+	if update.ResourceID != nil {
+		set, args = append(set, "resource_id = "+placeholder(len(args)+1)), append(args, *update.ResourceID)
+	}
+	if update.Deleted != nil {
+		set, args = append(set, "deleted = "+placeholder(len(args)+1)), append(args, *update.Deleted)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	args = append(args, update.ID)
+	_, err := d.conn().ExecContext(ctx, "UPDATE vault_file SET "+strings.Join(set, ", ")+" WHERE id = "+placeholder(len(args)), args...)
+	return err
+}
+
+func (d *DB) DeleteVaultFile(ctx context.Context, delete *store.DeleteVaultFile) error {
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM vault_file WHERE id = "+placeholder(1), delete.ID)
+	return err
+}
+
+// vacuumVaultFile deletes the files of the deleted users. The files of the deleted memos are kept as deleted files.
+func vacuumVaultFile(ctx context.Context, tx *sql.Tx) error {
+	stmt := `DELETE FROM vault_file WHERE creator_id NOT IN (SELECT id FROM "user")`
+	_, err := tx.ExecContext(ctx, stmt)
+	return err
+}
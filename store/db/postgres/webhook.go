@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -9,11 +10,11 @@ import (
 )
 
 func (d *DB) CreateWebhook(ctx context.Context, create *storepb.Webhook) (*storepb.Webhook, error) {
-	fields := []string{"name", "url", "creator_id"}
-	args := []any{create.Name, create.Url, create.CreatorId}
+	fields := []string{"name", "url", "creator_id", "secret", "payload_template"}
+	args := []any{create.Name, create.Url, create.CreatorId, create.Secret, create.PayloadTemplate}
 	stmt := "INSERT INTO webhook (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts, row_status"
 	var rowStatus string
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&create.Id,
 		&create.CreatedTs,
 		&create.UpdatedTs,
@@ -36,7 +37,7 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 		where, args = append(where, "creator_id = "+placeholder(len(args)+1)), append(args, *find.CreatorID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, `
+	rows, err := d.conn().QueryContext(ctx, `
 		SELECT
 			id,
 			created_ts,
@@ -44,7 +45,9 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			row_status,
 			creator_id,
 			name,
-			url
+			url,
+			secret,
+			payload_template
 		FROM webhook
 		WHERE `+strings.Join(where, " AND ")+`
 		ORDER BY id DESC`,
@@ -67,6 +70,8 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			&webhook.CreatorId,
 			&webhook.Name,
 			&webhook.Url,
+			&webhook.Secret,
+			&webhook.PayloadTemplate,
 		); err != nil {
 			return nil, err
 		}
@@ -92,12 +97,18 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 	if update.URL != nil {
 		set, args = append(set, "url = "+placeholder(len(args)+1)), append(args, *update.URL)
 	}
+	if update.Secret != nil {
+		set, args = append(set, "secret = "+placeholder(len(args)+1)), append(args, *update.Secret)
+	}
+	if update.PayloadTemplate != nil {
+		set, args = append(set, "payload_template = "+placeholder(len(args)+1)), append(args, *update.PayloadTemplate)
+	}
 
-	stmt := "UPDATE webhook SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1) + " RETURNING id, created_ts, updated_ts, row_status, creator_id, name, url"
+	stmt := "UPDATE webhook SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1) + " RETURNING id, created_ts, updated_ts, row_status, creator_id, name, url, secret, payload_template"
 	args = append(args, update.ID)
 	webhook := &storepb.Webhook{}
 	var rowStatus string
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&webhook.Id,
 		&webhook.CreatedTs,
 		&webhook.UpdatedTs,
@@ -105,6 +116,8 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 		&webhook.CreatorId,
 		&webhook.Name,
 		&webhook.Url,
+		&webhook.Secret,
+		&webhook.PayloadTemplate,
 	); err != nil {
 		return nil, err
 	}
@@ -113,6 +126,20 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 }
 
 func (d *DB) DeleteWebhook(ctx context.Context, delete *store.DeleteWebhook) error {
-	_, err := d.db.ExecContext(ctx, "DELETE FROM webhook WHERE id = $1", delete.ID)
+	_, err := d.conn().ExecContext(ctx, "DELETE FROM webhook WHERE id = $1", delete.ID)
 	return err
 }
+
+func vacuumWebhook(ctx context.Context, tx *sql.Tx) error {
+	stmt := `DELETE FROM webhook WHERE creator_id NOT IN (SELECT id FROM "user")`
+	if _, err := tx.ExecContext(ctx, stmt); err != nil {
+		return err
+	}
+	// The deliveries of the deleted webhooks have the payloads of the memos.
+	stmt = `DELETE FROM webhook_delivery WHERE webhook_id NOT IN (SELECT id FROM webhook)`
+	if _, err := tx.ExecContext(ctx, stmt); err != nil {
+		return err
+	}
+
+	return nil
+}
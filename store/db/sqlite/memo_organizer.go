@@ -21,7 +21,7 @@ func (d *DB) UpsertMemoOrganizer(ctx context.Context, upsert *store.MemoOrganize
 		SET
 			pinned = EXCLUDED.pinned
 	`
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.MemoID, upsert.UserID, upsert.Pinned); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.MemoID, upsert.UserID, upsert.Pinned); err != nil {
 		return nil, err
 	}
 
@@ -47,7 +47,7 @@ func (d *DB) ListMemoOrganizer(ctx context.Context, find *store.FindMemoOrganize
 		FROM memo_organizer
 		WHERE %s
 	`, strings.Join(where, " AND "))
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (d *DB) DeleteMemoOrganizer(ctx context.Context, delete *store.DeleteMemoOr
 		where, args = append(where, "user_id = ?"), append(args, *v)
 	}
 	stmt := `DELETE FROM memo_organizer WHERE ` + strings.Join(where, " AND ")
-	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, args...); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1 @@
+ALTER TABLE resource ADD COLUMN extracted_text TEXT;
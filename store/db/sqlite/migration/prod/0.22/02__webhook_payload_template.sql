@@ -0,0 +1 @@
+ALTER TABLE webhook ADD COLUMN payload_template TEXT NOT NULL DEFAULT '';
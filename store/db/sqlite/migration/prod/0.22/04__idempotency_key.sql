@@ -0,0 +1,9 @@
+CREATE TABLE idempotency_key (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  creator_id INTEGER NOT NULL,
+  entity_type TEXT NOT NULL,
+  key TEXT NOT NULL,
+  entity_id INTEGER NOT NULL DEFAULT 0,
+  UNIQUE(creator_id, entity_type, key)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE memo_report (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  reporter_id INTEGER NOT NULL,
+  memo_id INTEGER NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL CHECK (status IN ('OPEN', 'DISMISSED', 'HIDDEN')) DEFAULT 'OPEN',
+  resolver_id INTEGER NOT NULL DEFAULT 0,
+  resolution TEXT NOT NULL DEFAULT '',
+  UNIQUE(reporter_id, memo_id)
+);
+
+CREATE INDEX idx_memo_report_memo_id ON memo_report (memo_id);
@@ -0,0 +1,10 @@
+CREATE TABLE telegram_message (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  memo_id INTEGER NOT NULL,
+  chat_id BIGINT NOT NULL,
+  message_id BIGINT NOT NULL,
+  source_message_id BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_telegram_message_memo_id ON telegram_message (memo_id);
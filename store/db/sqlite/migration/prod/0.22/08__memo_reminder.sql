@@ -0,0 +1,11 @@
+CREATE TABLE memo_reminder (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  creator_id INTEGER NOT NULL,
+  memo_id INTEGER NOT NULL,
+  remind_ts BIGINT NOT NULL,
+  delivered_ts BIGINT NOT NULL DEFAULT 0,
+  UNIQUE(creator_id, memo_id)
+);
+
+CREATE INDEX idx_memo_reminder_remind_ts ON memo_reminder (remind_ts);
@@ -0,0 +1,10 @@
+CREATE TABLE vault_file (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  creator_id INTEGER NOT NULL,
+  updated_ts BIGINT NOT NULL,
+  path TEXT NOT NULL,
+  memo_id INTEGER NOT NULL DEFAULT 0,
+  resource_id INTEGER NOT NULL DEFAULT 0,
+  deleted INTEGER NOT NULL CHECK (deleted IN (0, 1)) DEFAULT 0,
+  UNIQUE(creator_id, path)
+);
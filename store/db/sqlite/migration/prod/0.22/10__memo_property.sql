@@ -0,0 +1,7 @@
+CREATE TABLE memo_property (
+  memo_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  value TEXT NOT NULL DEFAULT '',
+  updated_ts BIGINT NOT NULL,
+  UNIQUE(memo_id, name)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE task_run (
+  name TEXT NOT NULL PRIMARY KEY,
+  status TEXT NOT NULL CHECK (status IN ('RUNNING', 'SUCCEEDED', 'FAILED')),
+  started_ts BIGINT NOT NULL,
+  finished_ts BIGINT NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT ''
+);
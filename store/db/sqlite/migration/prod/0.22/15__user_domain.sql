@@ -0,0 +1,10 @@
+CREATE TABLE user_domain (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  hostname TEXT NOT NULL UNIQUE,
+  verification_token TEXT NOT NULL,
+  verified_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_user_domain_user_id ON user_domain (user_id);
@@ -0,0 +1,15 @@
+CREATE TABLE guest_comment (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  memo_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  email TEXT NOT NULL DEFAULT '',
+  content TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')) DEFAULT 'PENDING',
+  ip_hash TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_guest_comment_memo_id ON guest_comment (memo_id);
+
+CREATE INDEX idx_guest_comment_ip_hash ON guest_comment (ip_hash);
@@ -0,0 +1,8 @@
+CREATE TABLE memo_slug (
+  memo_id INTEGER NOT NULL UNIQUE,
+  slug TEXT NOT NULL,
+  click_count INTEGER NOT NULL DEFAULT 0,
+  updated_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_memo_slug_slug ON memo_slug (slug);
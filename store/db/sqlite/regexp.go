@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+)
+
+// maxRegexpCacheSize is the max number of compiled patterns cached for the REGEXP function.
+const maxRegexpCacheSize = 64
+
+// regexpCache caches the compiled patterns of the REGEXP function, as it's called for every row.
+var regexpCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: map[string]*regexp.Regexp{}}
+
+func init() {
+	// SQLite has no built-in implementation of the REGEXP operator, `X REGEXP Y` calls `regexp(Y, X)`.
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pattern, ok := args[0].(string)
+		if !ok {
+			return nil, errors.New("regexp pattern must be a string")
+		}
+		var value string
+		switch v := args[1].(type) {
+		case string:
+			value = v
+		case []byte:
+			value = string(v)
+		case nil:
+			return false, nil
+		default:
+			return nil, errors.New("regexp value must be a string")
+		}
+
+		re, err := compileRegexp(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(value), nil
+	})
+}
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	regexpCache.Lock()
+	defer regexpCache.Unlock()
+	if re, ok := regexpCache.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid regexp pattern")
+	}
+	if len(regexpCache.patterns) >= maxRegexpCacheSize {
+		regexpCache.patterns = map[string]*regexp.Regexp{}
+	}
+	regexpCache.patterns[pattern] = re
+	return re, nil
+}
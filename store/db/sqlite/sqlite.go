@@ -3,7 +3,6 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"os"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -12,15 +11,25 @@ import (
 	// Import the SQLite driver.
 	_ "modernc.org/sqlite"
 
+	"github.com/usememos/memos/internal/telemetry"
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
 )
 
 type DB struct {
-	db      *sql.DB
+	db *sql.DB
+	// tx is the transaction the driver is bound to, see WithTx.
+	tx      *sql.Tx
 	profile *profile.Profile
 }
 
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // NewDB opens a database specified by its database driver name and a
 // driver-specific data source name, usually consisting of at least a
 // database name and connection information.
@@ -44,7 +53,12 @@ func NewDB(profile *profile.Profile) (store.Driver, error) {
 	// - https://pkg.go.dev/modernc.org/sqlite#Driver.Open
 	// - https://www.sqlite.org/sharedcache.html
 	// - https://www.sqlite.org/pragma.html
-	sqliteDB, err := sql.Open("sqlite", profile.DSN+"?_pragma=foreign_keys(0)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)")
+	dsn := profile.DSN + "?_pragma=foreign_keys(0)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"
+	// The WAL is checkpointed by the replicator once its frames are shipped to the replica.
+	if profile.ReplicaURL != "" {
+		dsn += "&_pragma=wal_autocheckpoint(0)"
+	}
+	sqliteDB, err := telemetry.OpenDB("sqlite", dsn, "sqlite")
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open db with dsn: %s", profile.DSN)
 	}
@@ -58,20 +72,45 @@ func (d *DB) GetDB() *sql.DB {
 	return d.db
 }
 
-func (d *DB) Vacuum(ctx context.Context) error {
+// conn returns the transaction if the driver is bound to one, otherwise the database.
+func (d *DB) conn() queryer {
+	if d.tx != nil {
+		return d.tx
+	}
+	return d.db
+}
+
+// WithTx runs fn with the driver bound to a transaction, which is committed if fn returns no error.
+// If the driver is already bound to a transaction, fn joins it.
+func (d *DB) WithTx(ctx context.Context, fn func(driver store.Driver) error) error {
+	if d.tx != nil {
+		return fn(d)
+	}
 	tx, err := d.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	if err := vacuumImpl(ctx, tx); err != nil {
+	txDriver := *d
+	txDriver.tx = tx
+	if err := fn(&txDriver); err != nil {
 		return err
 	}
+	return tx.Commit()
+}
 
-	if err := tx.Commit(); err != nil {
+// Vacuum deletes the rows of the deleted models, in the transaction of the driver if it's bound to one.
+func (d *DB) Vacuum(ctx context.Context) error {
+	if err := d.WithTx(ctx, func(driver store.Driver) error {
+		return vacuumImpl(ctx, driver.(*DB).tx)
+	}); err != nil {
 		return err
 	}
+	// The database file can't be vacuumed in a transaction, it's left to the next vacuum outside of one.
+	if d.tx != nil {
+		return nil
+	}
 
 	// Vacuum sqlite database file size after deleting resource.
 	if _, err := d.db.Exec("VACUUM"); err != nil {
@@ -100,6 +139,45 @@ func vacuumImpl(ctx context.Context, tx *sql.Tx) error {
 	if err := vacuumInbox(ctx, tx); err != nil {
 		return err
 	}
+	if err := vacuumMemoReminder(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumVaultFile(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoProperty(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoSlug(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumMemoEmbedding(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumUserGroupMember(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumUserDomain(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumInvitation(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumActivity(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumReaction(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumWebhook(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumIncomingWebhook(ctx, tx); err != nil {
+		return err
+	}
+	if err := vacuumGuestComment(ctx, tx); err != nil {
+		return err
+	}
 	if err := vacuumTag(ctx, tx); err != nil {
 		// Prevent revive warning.
 		return err
@@ -108,13 +186,24 @@ func vacuumImpl(ctx context.Context, tx *sql.Tx) error {
 	return nil
 }
 
-func (d *DB) GetCurrentDBSize(context.Context) (int64, error) {
-	fi, err := os.Stat(d.profile.DSN)
-	if err != nil {
-		return 0, status.Errorf(codes.Internal, "failed to get file info: %v", err)
+// GetCurrentDBSize returns the size of the pages of the database, the ones in the WAL which aren't checkpointed
+// to the database file yet included.
+func (d *DB) GetCurrentDBSize(ctx context.Context) (int64, error) {
+	var size int64
+	if err := d.conn().QueryRowContext(ctx, "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size); err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get database size: %v", err)
 	}
+	return size, nil
+}
 
-	return fi.Size(), nil
+// MaintenanceStatements rebuilds the database file without the free pages, and updates the statistics of the query planner.
+// The WAL is truncated afterwards, otherwise the rebuilt pages stay in it until the next checkpoint,
+// unless the database is replicated, whose WAL is only checkpointed by the replicator.
+func (d *DB) MaintenanceStatements() []string {
+	if d.profile.ReplicaURL != "" {
+		return []string{"VACUUM", "ANALYZE"}
+	}
+	return []string{"VACUUM", "ANALYZE", "PRAGMA wal_checkpoint(TRUNCATE)"}
 }
 
 func (d *DB) Close() error {
@@ -13,7 +13,7 @@ func (d *DB) CreateStorage(ctx context.Context, create *store.Storage) (*store.S
 	args := []any{create.Name, create.Type, create.Config}
 
 	stmt := "INSERT INTO `storage` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ") RETURNING `id`"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&create.ID,
 	); err != nil {
 		return nil, err
@@ -29,7 +29,7 @@ func (d *DB) ListStorages(ctx context.Context, find *store.FindStorage) ([]*stor
 		where, args = append(where, "id = ?"), append(args, *find.ID)
 	}
 
-	rows, err := d.db.QueryContext(ctx, `
+	rows, err := d.conn().QueryContext(ctx, `
 		SELECT
 			id,
 			name,
@@ -89,7 +89,7 @@ func (d *DB) UpdateStorage(ctx context.Context, update *store.UpdateStorage) (*s
 			config
 	`
 	storage := &store.Storage{}
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+	if err := d.conn().QueryRowContext(ctx, stmt, args...).Scan(
 		&storage.ID,
 		&storage.Name,
 		&storage.Type,
@@ -106,7 +106,7 @@ func (d *DB) DeleteStorage(ctx context.Context, delete *store.DeleteStorage) err
 		DELETE FROM storage
 		WHERE id = ?
 	`
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
+	result, err := d.conn().ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
 		return err
 	}
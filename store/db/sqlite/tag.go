@@ -18,7 +18,7 @@ func (d *DB) UpsertTag(ctx context.Context, upsert *store.Tag) (*store.Tag, erro
 		SET
 			name = EXCLUDED.name
 	`
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.Name, upsert.CreatorID); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.Name, upsert.CreatorID); err != nil {
 		return nil, err
 	}
 
@@ -41,7 +41,7 @@ func (d *DB) ListTags(ctx context.Context, find *store.FindTag) ([]*store.Tag, e
 		WHERE ` + strings.Join(where, " AND ") + `
 		ORDER BY name ASC
 	`
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +70,7 @@ func (d *DB) ListTags(ctx context.Context, find *store.FindTag) ([]*store.Tag, e
 func (d *DB) DeleteTag(ctx context.Context, delete *store.DeleteTag) error {
 	where, args := []string{"name = ?", "creator_id = ?"}, []any{delete.Name, delete.CreatorID}
 	stmt := `DELETE FROM tag WHERE ` + strings.Join(where, " AND ")
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := d.conn().ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertTaskRun(ctx context.Context, upsert *store.TaskRun) (*store.TaskRun, error) {
+	stmt := "INSERT INTO `task_run` (`name`, `status`, `started_ts`, `finished_ts`, `error`) VALUES (?, ?, ?, ?, ?) ON CONFLICT(`name`) DO UPDATE SET `status` = EXCLUDED.`status`, `started_ts` = EXCLUDED.`started_ts`, `finished_ts` = EXCLUDED.`finished_ts`, `error` = EXCLUDED.`error`"
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.Name, upsert.Status, upsert.StartedTs, upsert.FinishedTs, upsert.Error); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListTaskRuns(ctx context.Context, find *store.FindTaskRun) ([]*store.TaskRun, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.Name != nil {
+		where, args = append(where, "`name` = ?"), append(args, *find.Name)
+	}
+
+	rows, err := d.conn().QueryContext(ctx, "SELECT `name`, `status`, `started_ts`, `finished_ts`, `error` FROM `task_run` WHERE "+strings.Join(where, " AND ")+" ORDER BY `name` ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.TaskRun{}
+	for rows.Next() {
+		taskRun := &store.TaskRun{}
+		if err := rows.Scan(
+			&taskRun.Name,
+			&taskRun.Status,
+			&taskRun.StartedTs,
+			&taskRun.FinishedTs,
+			&taskRun.Error,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, taskRun)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
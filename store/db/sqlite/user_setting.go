@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -36,11 +37,75 @@ func (d *DB) UpsertUserSetting(ctx context.Context, upsert *storepb.UserSetting)
 		valueString = upsert.GetMemoVisibility()
 	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_TELEGRAM_USER_ID {
 		valueString = upsert.GetTelegramUserId()
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_SLACK_USER_ID {
+		valueString = upsert.GetSlackUserId()
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID {
+		valueString = upsert.GetDiscordUserId()
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_EMAIL_INGESTION_TOKEN {
+		valueString = upsert.GetEmailIngestionToken()
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_FEED_TOKEN {
+		valueString = upsert.GetFeedToken()
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_TIMEZONE {
+		valueString = upsert.GetTimezone()
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_WEB_PUSH_SUBSCRIPTIONS {
+		valueBytes, err := protojson.Marshal(upsert.GetWebPushSubscriptions())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_PROFILE {
+		valueBytes, err := protojson.Marshal(upsert.GetProfile())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC {
+		valueBytes, err := protojson.Marshal(upsert.GetHighlightSync())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_GITHUB_GIST {
+		valueBytes, err := protojson.Marshal(upsert.GetGithubGist())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_BLUESKY {
+		valueBytes, err := protojson.Marshal(upsert.GetBluesky())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_MASTODON {
+		valueBytes, err := protojson.Marshal(upsert.GetMastodon())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_LAST_ACTIVE_TS {
+		valueString = strconv.FormatInt(upsert.GetLastActiveTs(), 10)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_DELETION_TS {
+		valueString = strconv.FormatInt(upsert.GetDeletionTs(), 10)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT {
+		valueString = strconv.FormatBool(upsert.GetExploreOptOut())
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_QUOTA {
+		valueBytes, err := protojson.Marshal(upsert.GetQuota())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.UserSettingKey_USER_SETTING_NOTIFICATION {
+		valueBytes, err := protojson.Marshal(upsert.GetNotification())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
 	} else {
 		return nil, errors.Errorf("unknown user setting key: %s", upsert.Key.String())
 	}
 
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.UserId, upsert.Key.String(), valueString); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.UserId, upsert.Key.String(), valueString); err != nil {
 		return nil, err
 	}
 
@@ -64,7 +129,7 @@ func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting)
 			value
 		FROM user_setting
 		WHERE ` + strings.Join(where, " AND ")
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +171,114 @@ func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting)
 			userSetting.Value = &storepb.UserSetting_TelegramUserId{
 				TelegramUserId: valueString,
 			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_SLACK_USER_ID {
+			userSetting.Value = &storepb.UserSetting_SlackUserId{
+				SlackUserId: valueString,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_DISCORD_USER_ID {
+			userSetting.Value = &storepb.UserSetting_DiscordUserId{
+				DiscordUserId: valueString,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_EMAIL_INGESTION_TOKEN {
+			userSetting.Value = &storepb.UserSetting_EmailIngestionToken{
+				EmailIngestionToken: valueString,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_FEED_TOKEN {
+			userSetting.Value = &storepb.UserSetting_FeedToken{
+				FeedToken: valueString,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_TIMEZONE {
+			userSetting.Value = &storepb.UserSetting_Timezone{
+				Timezone: valueString,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_WEB_PUSH_SUBSCRIPTIONS {
+			webPushSubscriptionsUserSetting := &storepb.WebPushSubscriptionsUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), webPushSubscriptionsUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_WebPushSubscriptions{
+				WebPushSubscriptions: webPushSubscriptionsUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_PROFILE {
+			profileUserSetting := &storepb.ProfileUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), profileUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_Profile{
+				Profile: profileUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_HIGHLIGHT_SYNC {
+			highlightSyncUserSetting := &storepb.HighlightSyncUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), highlightSyncUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_HighlightSync{
+				HighlightSync: highlightSyncUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_GITHUB_GIST {
+			githubGistUserSetting := &storepb.GithubGistUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), githubGistUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_GithubGist{
+				GithubGist: githubGistUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_BLUESKY {
+			blueskyUserSetting := &storepb.BlueskyUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), blueskyUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_Bluesky{
+				Bluesky: blueskyUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_MASTODON {
+			mastodonUserSetting := &storepb.MastodonUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), mastodonUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_Mastodon{
+				Mastodon: mastodonUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_LAST_ACTIVE_TS {
+			lastActiveTs, err := strconv.ParseInt(valueString, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_LastActiveTs{
+				LastActiveTs: lastActiveTs,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_DELETION_TS {
+			deletionTs, err := strconv.ParseInt(valueString, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_DeletionTs{
+				DeletionTs: deletionTs,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT {
+			exploreOptOut, err := strconv.ParseBool(valueString)
+			if err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_ExploreOptOut{
+				ExploreOptOut: exploreOptOut,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_QUOTA {
+			quotaUserSetting := &storepb.QuotaUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), quotaUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_Quota{
+				Quota: quotaUserSetting,
+			}
+		} else if userSetting.Key == storepb.UserSettingKey_USER_SETTING_NOTIFICATION {
+			notificationUserSetting := &storepb.NotificationUserSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), notificationUserSetting); err != nil {
+				return nil, err
+			}
+			userSetting.Value = &storepb.UserSetting_Notification{
+				Notification: notificationUserSetting,
+			}
 		} else {
 			// Skip unknown user setting key.
 			continue
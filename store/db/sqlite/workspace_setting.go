@@ -21,7 +21,7 @@ func (d *DB) UpsertWorkspaceSetting(ctx context.Context, upsert *store.Workspace
 			value = EXCLUDED.value,
 			description = EXCLUDED.description
 	`
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.Name, upsert.Value, upsert.Description); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.Name, upsert.Value, upsert.Description); err != nil {
 		return nil, err
 	}
 
@@ -42,7 +42,7 @@ func (d *DB) ListWorkspaceSettings(ctx context.Context, find *store.FindWorkspac
 		FROM system_setting
 		WHERE ` + strings.Join(where, " AND ")
 
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +70,7 @@ func (d *DB) ListWorkspaceSettings(ctx context.Context, find *store.FindWorkspac
 
 func (d *DB) DeleteWorkspaceSetting(ctx context.Context, delete *store.DeleteWorkspaceSetting) error {
 	stmt := "DELETE FROM system_setting WHERE name = ?"
-	_, err := d.db.ExecContext(ctx, stmt, delete.Name)
+	_, err := d.conn().ExecContext(ctx, stmt, delete.Name)
 	return err
 }
 
@@ -89,8 +89,50 @@ func (d *DB) UpsertWorkspaceSettingV1(ctx context.Context, upsert *storepb.Works
 			return nil, err
 		}
 		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_STORAGE {
+		valueBytes, err := protojson.Marshal(upsert.GetStorage())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_INTEGRATION {
+		valueBytes, err := protojson.Marshal(upsert.GetIntegration())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_SCHEDULER {
+		valueBytes, err := protojson.Marshal(upsert.GetScheduler())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_ANNOUNCEMENT {
+		valueBytes, err := protojson.Marshal(upsert.GetAnnouncement())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_EXPLORE {
+		valueBytes, err := protojson.Marshal(upsert.GetExplore())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_BRANDING {
+		valueBytes, err := protojson.Marshal(upsert.GetBranding())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
+	} else if upsert.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_HOOK {
+		valueBytes, err := protojson.Marshal(upsert.GetHook())
+		if err != nil {
+			return nil, err
+		}
+		valueString = string(valueBytes)
 	}
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.Key.String(), valueString); err != nil {
+	if _, err := d.conn().ExecContext(ctx, stmt, upsert.Key.String(), valueString); err != nil {
 		return nil, err
 	}
 	return upsert, nil
@@ -103,7 +145,7 @@ func (d *DB) ListWorkspaceSettingsV1(ctx context.Context, find *store.FindWorksp
 	}
 
 	query := `SELECT name, value FROM system_setting WHERE ` + strings.Join(where, " AND ")
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := d.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +168,48 @@ func (d *DB) ListWorkspaceSettingsV1(ctx context.Context, find *store.FindWorksp
 				return nil, err
 			}
 			workspaceSetting.Value = &storepb.WorkspaceSetting_General{General: generalSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_STORAGE {
+			storageSetting := &storepb.WorkspaceStorageSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), storageSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Storage{Storage: storageSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_INTEGRATION {
+			integrationSetting := &storepb.WorkspaceIntegrationSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), integrationSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Integration{Integration: integrationSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_SCHEDULER {
+			schedulerSetting := &storepb.WorkspaceSchedulerSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), schedulerSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Scheduler{Scheduler: schedulerSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_ANNOUNCEMENT {
+			announcementSetting := &storepb.WorkspaceAnnouncementSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), announcementSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Announcement{Announcement: announcementSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_EXPLORE {
+			exploreSetting := &storepb.WorkspaceExploreSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), exploreSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Explore{Explore: exploreSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_BRANDING {
+			brandingSetting := &storepb.WorkspaceBrandingSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), brandingSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Branding{Branding: brandingSetting}
+		} else if workspaceSetting.Key == storepb.WorkspaceSettingKey_WORKSPACE_SETTING_HOOK {
+			hookSetting := &storepb.WorkspaceHookSetting{}
+			if err := protojson.Unmarshal([]byte(valueString), hookSetting); err != nil {
+				return nil, err
+			}
+			workspaceSetting.Value = &storepb.WorkspaceSetting_Hook{Hook: hookSetting}
 		} else {
 			// Skip unknown workspace setting key.
 			continue
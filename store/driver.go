@@ -15,6 +15,11 @@ type Driver interface {
 
 	Migrate(ctx context.Context) error
 	Vacuum(ctx context.Context) error
+	// MaintenanceStatements returns the housekeeping statements of the database run by the scheduled maintenance,
+	// e.g. `ANALYZE`. They're run out of transactions.
+	MaintenanceStatements() []string
+	// WithTx runs fn with the driver bound to a transaction, which is committed if fn returns no error.
+	WithTx(ctx context.Context, fn func(driver Driver) error) error
 
 	// current file is driver
 	GetCurrentDBSize(ctx context.Context) (int64, error)
@@ -61,6 +66,8 @@ type Driver interface {
 	UpdateUser(ctx context.Context, update *UpdateUser) (*User, error)
 	ListUsers(ctx context.Context, find *FindUser) ([]*User, error)
 	DeleteUser(ctx context.Context, delete *DeleteUser) error
+	// GetUserUsage returns the number of the memos and the total size of the resources of the user.
+	GetUserUsage(ctx context.Context, userID int32) (int32, int64, error)
 
 	// UserSetting model related methods.
 	UpsertUserSetting(ctx context.Context, upsert *storepb.UserSetting) (*storepb.UserSetting, error)
@@ -95,8 +102,99 @@ type Driver interface {
 	UpdateWebhook(ctx context.Context, update *UpdateWebhook) (*storepb.Webhook, error)
 	DeleteWebhook(ctx context.Context, delete *DeleteWebhook) error
 
+	// WebhookDelivery model related methods.
+	CreateWebhookDelivery(ctx context.Context, create *WebhookDelivery) (*WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, find *FindWebhookDelivery) ([]*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDelivery) error
+
+	// IncomingWebhook model related methods.
+	CreateIncomingWebhook(ctx context.Context, create *IncomingWebhook) (*IncomingWebhook, error)
+	ListIncomingWebhooks(ctx context.Context, find *FindIncomingWebhook) ([]*IncomingWebhook, error)
+	DeleteIncomingWebhook(ctx context.Context, delete *DeleteIncomingWebhook) error
+
+	// MemoReport model related methods.
+	CreateMemoReport(ctx context.Context, create *MemoReport) (*MemoReport, error)
+	ListMemoReports(ctx context.Context, find *FindMemoReport) ([]*MemoReport, error)
+	UpdateMemoReport(ctx context.Context, update *UpdateMemoReport) (*MemoReport, error)
+
+	// UserGroup model related methods.
+	CreateUserGroup(ctx context.Context, create *UserGroup) (*UserGroup, error)
+	ListUserGroups(ctx context.Context, find *FindUserGroup) ([]*UserGroup, error)
+	UpdateUserGroup(ctx context.Context, update *UpdateUserGroup) (*UserGroup, error)
+	DeleteUserGroup(ctx context.Context, delete *DeleteUserGroup) error
+	UpsertUserGroupMember(ctx context.Context, upsert *UserGroupMember) (*UserGroupMember, error)
+	ListUserGroupMembers(ctx context.Context, find *FindUserGroupMember) ([]*UserGroupMember, error)
+	DeleteUserGroupMember(ctx context.Context, delete *DeleteUserGroupMember) error
+
+	// UserDomain model related methods.
+	CreateUserDomain(ctx context.Context, create *UserDomain) (*UserDomain, error)
+	ListUserDomains(ctx context.Context, find *FindUserDomain) ([]*UserDomain, error)
+	UpdateUserDomain(ctx context.Context, update *UpdateUserDomain) (*UserDomain, error)
+	DeleteUserDomain(ctx context.Context, delete *DeleteUserDomain) error
+
+	// Invitation model related methods.
+	CreateInvitation(ctx context.Context, create *Invitation) (*Invitation, error)
+	ListInvitations(ctx context.Context, find *FindInvitation) ([]*Invitation, error)
+	// RedeemInvitation increases the use count of the invitation of the code, if it's neither used up nor expired at now.
+	RedeemInvitation(ctx context.Context, code string, now int64) (bool, error)
+	DeleteInvitation(ctx context.Context, delete *DeleteInvitation) error
+
+	// GuestComment model related methods.
+	CreateGuestComment(ctx context.Context, create *GuestComment) (*GuestComment, error)
+	ListGuestComments(ctx context.Context, find *FindGuestComment) ([]*GuestComment, error)
+	UpdateGuestComment(ctx context.Context, update *UpdateGuestComment) (*GuestComment, error)
+	DeleteGuestComment(ctx context.Context, delete *DeleteGuestComment) error
+
+	// IdempotencyKey model related methods.
+	CreateIdempotencyKey(ctx context.Context, create *IdempotencyKey) (*IdempotencyKey, error)
+	ListIdempotencyKeys(ctx context.Context, find *FindIdempotencyKey) ([]*IdempotencyKey, error)
+	UpdateIdempotencyKey(ctx context.Context, update *UpdateIdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, delete *DeleteIdempotencyKey) error
+
 	// Reaction model related methods.
 	UpsertReaction(ctx context.Context, create *storepb.Reaction) (*storepb.Reaction, error)
 	ListReactions(ctx context.Context, find *FindReaction) ([]*storepb.Reaction, error)
 	DeleteReaction(ctx context.Context, delete *DeleteReaction) error
+
+	// TelegramMessage model related methods.
+	CreateTelegramMessage(ctx context.Context, create *TelegramMessage) (*TelegramMessage, error)
+	ListTelegramMessages(ctx context.Context, find *FindTelegramMessage) ([]*TelegramMessage, error)
+	DeleteTelegramMessage(ctx context.Context, delete *DeleteTelegramMessage) error
+
+	// MemoReminder model related methods.
+	UpsertMemoReminder(ctx context.Context, upsert *MemoReminder) (*MemoReminder, error)
+	ListMemoReminders(ctx context.Context, find *FindMemoReminder) ([]*MemoReminder, error)
+	UpdateMemoReminder(ctx context.Context, update *UpdateMemoReminder) error
+	DeleteMemoReminder(ctx context.Context, delete *DeleteMemoReminder) error
+
+	// VaultFile model related methods.
+	CreateVaultFile(ctx context.Context, create *VaultFile) (*VaultFile, error)
+	ListVaultFiles(ctx context.Context, find *FindVaultFile) ([]*VaultFile, error)
+	UpdateVaultFile(ctx context.Context, update *UpdateVaultFile) error
+	DeleteVaultFile(ctx context.Context, delete *DeleteVaultFile) error
+
+	// MemoProperty model related methods.
+	UpsertMemoProperty(ctx context.Context, upsert *MemoProperty) (*MemoProperty, error)
+	ListMemoProperties(ctx context.Context, find *FindMemoProperty) ([]*MemoProperty, error)
+	DeleteMemoProperty(ctx context.Context, delete *DeleteMemoProperty) error
+
+	// MemoSlug model related methods.
+	UpsertMemoSlug(ctx context.Context, upsert *MemoSlug) (*MemoSlug, error)
+	ListMemoSlugs(ctx context.Context, find *FindMemoSlug) ([]*MemoSlug, error)
+	IncreaseMemoSlugClickCount(ctx context.Context, memoID int32) error
+	DeleteMemoSlug(ctx context.Context, delete *DeleteMemoSlug) error
+
+	// MemoEmbedding model related methods.
+	UpsertMemoEmbedding(ctx context.Context, upsert *MemoEmbedding) (*MemoEmbedding, error)
+	ListMemoEmbeddings(ctx context.Context, find *FindMemoEmbedding) ([]*MemoEmbedding, error)
+
+	// Job model related methods.
+	CreateJob(ctx context.Context, create *Job) (*Job, error)
+	ListJobs(ctx context.Context, find *FindJob) ([]*Job, error)
+	UpdateJob(ctx context.Context, update *UpdateJob) error
+	DeleteJobs(ctx context.Context, delete *DeleteJob) error
+
+	// TaskRun model related methods.
+	UpsertTaskRun(ctx context.Context, upsert *TaskRun) (*TaskRun, error)
+	ListTaskRuns(ctx context.Context, find *FindTaskRun) ([]*TaskRun, error)
 }
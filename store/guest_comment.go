@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+)
+
+// GuestCommentStatus is the status of a guest comment.
+type GuestCommentStatus string
+
+const (
+	// GuestCommentPending is the status of the comments waiting for the memo creator to moderate them.
+	GuestCommentPending GuestCommentStatus = "PENDING"
+	// GuestCommentApproved is the status of the comments shown with the memo.
+	GuestCommentApproved GuestCommentStatus = "APPROVED"
+	// GuestCommentRejected is the status of the comments which aren't shown.
+	GuestCommentRejected GuestCommentStatus = "REJECTED"
+)
+
+func (s GuestCommentStatus) String() string {
+	return string(s)
+}
+
+// GuestComment is a comment on a public memo by a visitor without an account.
+type GuestComment struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+
+	MemoID int32
+	// Name is the name the guest signs the comment with.
+	Name string
+	// Email is the optional email of the guest, it's only shown to the moderators.
+	Email   string
+	Content string
+	Status  GuestCommentStatus
+	// IPHash is the hash of the IP address of the guest, the comments from an address are rate-limited.
+	IPHash string
+}
+
+type FindGuestComment struct {
+	ID     *int32
+	MemoID *int32
+	Status *GuestCommentStatus
+	IPHash *string
+	// MemoCreatorID is used to find the comments on the memos of a user.
+	MemoCreatorID *int32
+	// CreatedTsAfter is exclusive.
+	CreatedTsAfter *int64
+
+	// Pagination
+	Limit  *int
+	Offset *int
+}
+
+type UpdateGuestComment struct {
+	ID        int32
+	UpdatedTs *int64
+	Status    *GuestCommentStatus
+}
+
+type DeleteGuestComment struct {
+	ID int32
+}
+
+func (s *Store) CreateGuestComment(ctx context.Context, create *GuestComment) (*GuestComment, error) {
+	return s.driver.CreateGuestComment(ctx, create)
+}
+
+func (s *Store) ListGuestComments(ctx context.Context, find *FindGuestComment) ([]*GuestComment, error) {
+	return s.driver.ListGuestComments(ctx, find)
+}
+
+func (s *Store) GetGuestComment(ctx context.Context, find *FindGuestComment) (*GuestComment, error) {
+	list, err := s.ListGuestComments(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateGuestComment(ctx context.Context, update *UpdateGuestComment) (*GuestComment, error) {
+	return s.driver.UpdateGuestComment(ctx, update)
+}
+
+func (s *Store) DeleteGuestComment(ctx context.Context, delete *DeleteGuestComment) error {
+	return s.driver.DeleteGuestComment(ctx, delete)
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+)
+
+// IdempotencyKey records the entity created for a client supplied key,
+// so a retried create request returns the original entity instead of creating a duplicate.
+type IdempotencyKey struct {
+	ID        int32
+	CreatedTs int64
+
+	CreatorID int32
+	// EntityType is the type of the created entity, e.g. "memo" or "resource".
+	EntityType string
+	Key        string
+	// EntityID is the id of the created entity, it's 0 while the request is in progress.
+	EntityID int32
+}
+
+type FindIdempotencyKey struct {
+	ID         *int32
+	CreatorID  *int32
+	EntityType *string
+	Key        *string
+}
+
+type UpdateIdempotencyKey struct {
+	ID       int32
+	EntityID int32
+}
+
+type DeleteIdempotencyKey struct {
+	ID *int32
+	// CreatedTsBefore is used to delete the expired keys.
+	CreatedTsBefore *int64
+}
+
+// CreateIdempotencyKey creates the key, it fails if the key of the same creator and entity type exists.
+func (s *Store) CreateIdempotencyKey(ctx context.Context, create *IdempotencyKey) (*IdempotencyKey, error) {
+	return s.driver.CreateIdempotencyKey(ctx, create)
+}
+
+func (s *Store) ListIdempotencyKeys(ctx context.Context, find *FindIdempotencyKey) ([]*IdempotencyKey, error) {
+	return s.driver.ListIdempotencyKeys(ctx, find)
+}
+
+func (s *Store) GetIdempotencyKey(ctx context.Context, find *FindIdempotencyKey) (*IdempotencyKey, error) {
+	list, err := s.ListIdempotencyKeys(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateIdempotencyKey(ctx context.Context, update *UpdateIdempotencyKey) error {
+	return s.driver.UpdateIdempotencyKey(ctx, update)
+}
+
+func (s *Store) DeleteIdempotencyKey(ctx context.Context, delete *DeleteIdempotencyKey) error {
+	return s.driver.DeleteIdempotencyKey(ctx, delete)
+}
@@ -64,7 +64,7 @@ func (s *Store) CreateIdentityProvider(ctx context.Context, create *IdentityProv
 		return nil, err
 	}
 
-	s.idpCache.Store(identityProvider.ID, identityProvider)
+	storeCache(ctx, s, &s.idpCache, "idp", identityProvider.ID, identityProvider, true)
 	return identityProvider, nil
 }
 
@@ -75,15 +75,15 @@ func (s *Store) ListIdentityProviders(ctx context.Context, find *FindIdentityPro
 	}
 
 	for _, item := range identityProviders {
-		s.idpCache.Store(item.ID, item)
+		storeCache(ctx, s, &s.idpCache, "idp", item.ID, item, false)
 	}
 	return identityProviders, nil
 }
 
 func (s *Store) GetIdentityProvider(ctx context.Context, find *FindIdentityProvider) (*IdentityProvider, error) {
 	if find.ID != nil {
-		if cache, ok := s.idpCache.Load(*find.ID); ok {
-			return cache.(*IdentityProvider), nil
+		if cache, ok := loadCache[*IdentityProvider](ctx, s, &s.idpCache, "idp", *find.ID); ok {
+			return cache, nil
 		}
 	}
 
@@ -105,7 +105,7 @@ func (s *Store) UpdateIdentityProvider(ctx context.Context, update *UpdateIdenti
 		return nil, err
 	}
 
-	s.idpCache.Store(identityProvider.ID, identityProvider)
+	storeCache(ctx, s, &s.idpCache, "idp", identityProvider.ID, identityProvider, true)
 	return identityProvider, nil
 }
 
@@ -115,6 +115,6 @@ func (s *Store) DeleteIdentityProvider(ctx context.Context, delete *DeleteIdenti
 		return err
 	}
 
-	s.idpCache.Delete(delete.ID)
+	deleteCache(ctx, s, &s.idpCache, "idp", delete.ID)
 	return nil
 }
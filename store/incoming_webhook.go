@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+)
+
+// IncomingWebhook is an endpoint which creates memos for its creator from the posted content.
+type IncomingWebhook struct {
+	ID        int32
+	CreatedTs int64
+
+	CreatorID int32
+	Name      string
+	// Token is the secret part of the endpoint url.
+	Token string
+	// Visibility is the default visibility of the created memos, the user's default visibility is used if it's empty.
+	Visibility Visibility
+	// Tags are the default tags appended to the content of the created memos.
+	Tags []string
+}
+
+type FindIncomingWebhook struct {
+	ID        *int32
+	CreatorID *int32
+	Token     *string
+}
+
+type DeleteIncomingWebhook struct {
+	ID int32
+}
+
+func (s *Store) CreateIncomingWebhook(ctx context.Context, create *IncomingWebhook) (*IncomingWebhook, error) {
+	return s.driver.CreateIncomingWebhook(ctx, create)
+}
+
+func (s *Store) ListIncomingWebhooks(ctx context.Context, find *FindIncomingWebhook) ([]*IncomingWebhook, error) {
+	return s.driver.ListIncomingWebhooks(ctx, find)
+}
+
+func (s *Store) GetIncomingWebhook(ctx context.Context, find *FindIncomingWebhook) (*IncomingWebhook, error) {
+	list, err := s.ListIncomingWebhooks(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteIncomingWebhook(ctx context.Context, delete *DeleteIncomingWebhook) error {
+	return s.driver.DeleteIncomingWebhook(ctx, delete)
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// Invitation is a code the users sign up with, when the signup is invite-only.
+type Invitation struct {
+	ID        int32
+	CreatorID int32
+	CreatedTs int64
+
+	// Code is unique, it's the secret shared with the invited users.
+	Code string
+	// Role is the role of the users signing up with the invitation.
+	Role Role
+	// MaxUses is the max number of the users signing up with the invitation.
+	MaxUses  int32
+	UseCount int32
+	// ExpiresTs is the time the invitation can't be used after, 0 means it never expires.
+	ExpiresTs int64
+}
+
+type FindInvitation struct {
+	ID        *int32
+	CreatorID *int32
+	Code      *string
+}
+
+type DeleteInvitation struct {
+	ID int32
+}
+
+func (s *Store) CreateInvitation(ctx context.Context, create *Invitation) (*Invitation, error) {
+	return s.driver.CreateInvitation(ctx, create)
+}
+
+func (s *Store) ListInvitations(ctx context.Context, find *FindInvitation) ([]*Invitation, error) {
+	return s.driver.ListInvitations(ctx, find)
+}
+
+func (s *Store) GetInvitation(ctx context.Context, find *FindInvitation) (*Invitation, error) {
+	list, err := s.ListInvitations(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// RedeemInvitation uses the invitation of the code once, and returns it. It returns nil if there is no invitation
+// of the code, or it's used up or expired.
+func (s *Store) RedeemInvitation(ctx context.Context, code string) (*Invitation, error) {
+	redeemed, err := s.driver.RedeemInvitation(ctx, code, time.Now().Unix())
+	if err != nil || !redeemed {
+		return nil, err
+	}
+	return s.GetInvitation(ctx, &FindInvitation{Code: &code})
+}
+
+func (s *Store) DeleteInvitation(ctx context.Context, delete *DeleteInvitation) error {
+	return s.driver.DeleteInvitation(ctx, delete)
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+)
+
+// JobStatus is the status of a queued job.
+type JobStatus string
+
+const (
+	// JobPending is the status of the jobs waiting for the next attempt.
+	JobPending JobStatus = "PENDING"
+	// JobRunning is the status of the jobs being attempted.
+	JobRunning JobStatus = "RUNNING"
+	// JobSucceeded is the status of the jobs which are done.
+	JobSucceeded JobStatus = "SUCCEEDED"
+	// JobDead is the status of the jobs which ran out of attempts or failed permanently, until they are requeued.
+	JobDead JobStatus = "DEAD"
+)
+
+func (s JobStatus) String() string {
+	return string(s)
+}
+
+// Job is a background job queued in the store, so it survives the restarts of the server.
+type Job struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Kind is the kind of the job, which selects its handler.
+	Kind string
+	// Payload is the JSON encoded arguments of the job.
+	Payload       string
+	Status        JobStatus
+	Attempts      int32
+	MaxAttempts   int32
+	NextAttemptTs int64
+	LastError     string
+}
+
+type FindJob struct {
+	ID     *int32
+	Kind   *string
+	Status *JobStatus
+	// NextAttemptTsBefore is used to find the jobs which are due.
+	NextAttemptTsBefore *int64
+	// UpdatedTsBefore is used to find the jobs which have been running for too long.
+	UpdatedTsBefore *int64
+
+	// Pagination
+	Limit  *int
+	Offset *int
+}
+
+type UpdateJob struct {
+	ID            int32
+	UpdatedTs     *int64
+	Status        *JobStatus
+	Attempts      *int32
+	NextAttemptTs *int64
+	LastError     *string
+}
+
+type DeleteJob struct {
+	Status          JobStatus
+	UpdatedTsBefore int64
+}
+
+func (s *Store) CreateJob(ctx context.Context, create *Job) (*Job, error) {
+	return s.driver.CreateJob(ctx, create)
+}
+
+func (s *Store) ListJobs(ctx context.Context, find *FindJob) ([]*Job, error) {
+	return s.driver.ListJobs(ctx, find)
+}
+
+func (s *Store) GetJob(ctx context.Context, find *FindJob) (*Job, error) {
+	list, err := s.ListJobs(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateJob(ctx context.Context, update *UpdateJob) error {
+	return s.driver.UpdateJob(ctx, update)
+}
+
+// DeleteJobs deletes the jobs of the status which are last updated before the time.
+func (s *Store) DeleteJobs(ctx context.Context, delete *DeleteJob) error {
+	return s.driver.DeleteJobs(ctx, delete)
+}
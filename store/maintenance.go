@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenanceWindowEnded is returned if the maintenance window ends before all the statements are started.
+var ErrMaintenanceWindowEnded = errors.New("database maintenance window ended")
+
+// MaintenanceStatement is a housekeeping statement run by the maintenance.
+type MaintenanceStatement struct {
+	Statement string
+	Duration  time.Duration
+}
+
+// MaintenanceResult is the result of the database maintenance.
+type MaintenanceResult struct {
+	// Statements are the statements run, in order.
+	Statements []*MaintenanceStatement
+	// SizeBefore and SizeAfter are the sizes of the database in bytes before and after the maintenance,
+	// 0 if they're unknown.
+	SizeBefore int64
+	SizeAfter  int64
+}
+
+// MaintainDatabase runs the housekeeping statements of the driver, e.g. `VACUUM` of SQLite.
+// The statements aren't started after the end of the window, as they may lock the tables.
+func (s *Store) MaintainDatabase(ctx context.Context, windowEnd time.Time) (*MaintenanceResult, error) {
+	result := &MaintenanceResult{}
+	// The size isn't available to the users without the privilege, e.g. of MySQL, which doesn't fail the maintenance.
+	result.SizeBefore, _ = s.driver.GetCurrentDBSize(ctx)
+	for _, statement := range s.driver.MaintenanceStatements() {
+		if time.Now().After(windowEnd) {
+			return result, ErrMaintenanceWindowEnded
+		}
+		start := time.Now()
+		if _, err := s.driver.GetDB().ExecContext(ctx, statement); err != nil {
+			return result, errors.Wrapf(err, "failed to run %q", statement)
+		}
+		result.Statements = append(result.Statements, &MaintenanceStatement{
+			Statement: statement,
+			Duration:  time.Since(start),
+		})
+	}
+	result.SizeAfter, _ = s.driver.GetCurrentDBSize(ctx)
+	return result, nil
+}
@@ -17,6 +17,8 @@ const (
 	Protected Visibility = "PROTECTED"
 	// Private is the PRIVATE visibility.
 	Private Visibility = "PRIVATE"
+	// Group is the GROUP visibility, the memo is visible to the members of its group.
+	Group Visibility = "GROUP"
 )
 
 func (v Visibility) String() string {
@@ -27,6 +29,8 @@ func (v Visibility) String() string {
 		return "PROTECTED"
 	case Private:
 		return "PRIVATE"
+	case Group:
+		return "GROUP"
 	}
 	return "PRIVATE"
 }
@@ -46,6 +50,8 @@ type Memo struct {
 	// Domain specific fields
 	Content    string
 	Visibility Visibility
+	// GroupID is the id of the group of the GROUP visibility, nil for the other visibilities.
+	GroupID *int32
 
 	// Composed fields
 	Pinned   bool
@@ -65,27 +71,96 @@ type FindMemo struct {
 	UpdatedTsBefore *int64
 
 	// Domain specific fields
-	ContentSearch   []string
-	VisibilityList  []Visibility
-	ExcludeContent  bool
-	ExcludeComments bool
-	Random          bool
+	ContentSearch []string
+	// SearchResourceText is the flag to match the content search in the text extracted from related resources as well.
+	SearchResourceText bool
+	VisibilityList     []Visibility
+	ExcludeContent     bool
+	ExcludeComments    bool
+	// ExcludeHidden is the flag to exclude the memos hidden by the admins after they were reported.
+	// The hidden memos of HiddenVisibleToID are kept, so the creators still find their own memos.
+	ExcludeHidden     bool
+	HiddenVisibleToID *int32
+	Random            bool
+	// ContentRegex is the regular expression matched against the content, in the syntax of the database.
+	ContentRegex *string
+	Pinned       *bool
+	HasResource  *bool
+	// ResourceType is the prefix of the type of a related resource, e.g. "image/".
+	ResourceType *string
+	// HasRelation is the flag to match memos referencing or referenced by other memos.
+	HasRelation        *bool
+	HasLink            *bool
+	HasCode            *bool
+	HasTaskList        *bool
+	HasIncompleteTasks *bool
+	// GroupID is used to find the memos shared with a group.
+	GroupID *int32
+	// GroupMemberID limits the GROUP memos to the ones of the groups of the user, and the user's own.
+	GroupMemberID *int32
+	// ExcludeCreatorIDs is used to exclude the memos of the users, e.g. the ones kept out of explore.
+	ExcludeCreatorIDs []int32
+	// ExcludeTags is used to exclude the memos with the tags or their child tags.
+	ExcludeTags []string
+	// ExploreApproved is used to find the memos approved, or not approved, by the admins to be listed in explore.
+	ExploreApproved *bool
 
 	// Pagination
 	Limit            *int
 	Offset           *int
 	OrderByUpdatedTs bool
 	OrderByPinned    bool
+	// OrderBy is the field to order memos by, it takes precedence over OrderByUpdatedTs.
+	OrderBy MemoOrderBy
+	// OrderAsc orders memos ascending, the default is descending.
+	OrderAsc bool
+}
+
+// MemoOrderBy is a field to order memos by.
+type MemoOrderBy string
+
+const (
+	MemoOrderByCreatedTs     MemoOrderBy = "created_ts"
+	MemoOrderByUpdatedTs     MemoOrderBy = "updated_ts"
+	MemoOrderByContentLength MemoOrderBy = "content_length"
+	MemoOrderByReactionCount MemoOrderBy = "reaction_count"
+)
+
+// GetOrderBy returns the field to order memos by, which defaults to the created or updated time.
+func (find *FindMemo) GetOrderBy() MemoOrderBy {
+	if find.OrderBy != "" {
+		return find.OrderBy
+	}
+	if find.OrderByUpdatedTs {
+		return MemoOrderByUpdatedTs
+	}
+	return MemoOrderByCreatedTs
+}
+
+// The LIKE patterns of the memo content properties, which are matched against the content by the drivers.
+var (
+	MemoLinkPatterns           = []string{"%http://%", "%https://%"}
+	MemoCodePatterns           = []string{"%`%"}
+	MemoTaskListPatterns       = []string{"%- [ ] %", "%- [x] %", "%* [ ] %", "%* [x] %"}
+	MemoIncompleteTaskPatterns = []string{"%- [ ] %", "%* [ ] %"}
+)
+
+// GetMemoTagPatterns returns the LIKE patterns of the tag and its child tags, which are matched against the content by the drivers.
+func GetMemoTagPatterns(tag string) []string {
+	return []string{"%#" + tag, "%#" + tag + " %", "%#" + tag + "\n%", "%#" + tag + "/%"}
 }
 
 type UpdateMemo struct {
 	ID         int32
 	UID        *string
+	CreatorID  *int32
 	CreatedTs  *int64
 	UpdatedTs  *int64
 	RowStatus  *RowStatus
 	Content    *string
 	Visibility *Visibility
+	// GroupID is the group of the GROUP visibility, 0 clears it.
+	GroupID *int32
 }
 
 type DeleteMemo struct {
@@ -96,6 +171,14 @@ func (s *Store) CreateMemo(ctx context.Context, create *Memo) (*Memo, error) {
 	if !util.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
 	}
+	if err := s.checkMemoQuota(ctx, create.CreatorID); err != nil {
+		return nil, err
+	}
+	if s.contentInterceptor != nil {
+		if err := s.contentInterceptor.InterceptMemoCreate(ctx, s, create); err != nil {
+			return nil, err
+		}
+	}
 	return s.driver.CreateMemo(ctx, create)
 }
 
@@ -120,7 +203,18 @@ func (s *Store) UpdateMemo(ctx context.Context, update *UpdateMemo) error {
 	if update.UID != nil && !util.UIDMatcher.MatchString(*update.UID) {
 		return errors.New("invalid uid")
 	}
-	return s.driver.UpdateMemo(ctx, update)
+	if s.contentInterceptor != nil && update.Content != nil {
+		if err := s.contentInterceptor.InterceptMemoUpdate(ctx, s, update); err != nil {
+			return err
+		}
+	}
+	if err := s.driver.UpdateMemo(ctx, update); err != nil {
+		return err
+	}
+	if update.CreatorID != nil {
+		return s.moveMemoSlug(ctx, update.ID, *update.CreatorID)
+	}
+	return nil
 }
 
 func (s *Store) DeleteMemo(ctx context.Context, delete *DeleteMemo) error {
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// MemoEmbedding is the embedding vector of the content of a memo, which the semantic search compares to the embedding of the query.
+// The vectors are stored as bytes by all the drivers and compared by the server, so the search doesn't need extensions of the databases.
+type MemoEmbedding struct {
+	MemoID int32
+	// Model is the model which created the embedding, the embeddings of different models aren't comparable.
+	Model     string
+	Embedding []float32
+	UpdatedTs int64
+}
+
+type FindMemoEmbedding struct {
+	MemoID    *int32
+	CreatorID *int32
+	Model     *string
+}
+
+func (s *Store) UpsertMemoEmbedding(ctx context.Context, upsert *MemoEmbedding) (*MemoEmbedding, error) {
+	return s.driver.UpsertMemoEmbedding(ctx, upsert)
+}
+
+func (s *Store) ListMemoEmbeddings(ctx context.Context, find *FindMemoEmbedding) ([]*MemoEmbedding, error) {
+	return s.driver.ListMemoEmbeddings(ctx, find)
+}
+
+// EncodeEmbedding returns the bytes of the vector stored by the drivers, the little endian float32 of the dimensions.
+func EncodeEmbedding(embedding []float32) []byte {
+	data := make([]byte, 4*len(embedding))
+	for i, value := range embedding {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(value))
+	}
+	return data
+}
+
+// DecodeEmbedding returns the vector of the bytes stored by the drivers.
+func DecodeEmbedding(data []byte) ([]float32, error) {
+	if len(data)%4 != 0 {
+		return nil, errors.Errorf("invalid embedding of %d bytes", len(data))
+	}
+	embedding := make([]float32, len(data)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return embedding, nil
+}
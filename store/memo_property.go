@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+)
+
+// MemoExploreApprovedProperty is the name of the property of the memos approved by the admins to be listed in explore,
+// its value is the id of the admin.
+const MemoExploreApprovedProperty = "explore_approved"
+
+// MemoProperty is a property of a memo set by the integrations, e.g. the URL of the gist the memo is mirrored to.
+// A memo has a property of a name once.
+type MemoProperty struct {
+	MemoID    int32
+	Name      string
+	Value     string
+	UpdatedTs int64
+}
+
+type FindMemoProperty struct {
+	MemoID *int32
+	Name   *string
+	Value  *string
+}
+
+type DeleteMemoProperty struct {
+	MemoID int32
+	Name   string
+}
+
+func (s *Store) UpsertMemoProperty(ctx context.Context, upsert *MemoProperty) (*MemoProperty, error) {
+	return s.driver.UpsertMemoProperty(ctx, upsert)
+}
+
+func (s *Store) ListMemoProperties(ctx context.Context, find *FindMemoProperty) ([]*MemoProperty, error) {
+	return s.driver.ListMemoProperties(ctx, find)
+}
+
+func (s *Store) GetMemoProperty(ctx context.Context, find *FindMemoProperty) (*MemoProperty, error) {
+	list, err := s.ListMemoProperties(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteMemoProperty(ctx context.Context, delete *DeleteMemoProperty) error {
+	return s.driver.DeleteMemoProperty(ctx, delete)
+}
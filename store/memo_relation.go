@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"errors"
+	"regexp"
 )
 
 type MemoRelationType string
@@ -13,6 +15,19 @@ const (
 	MemoRelationComment MemoRelationType = "COMMENT"
 )
 
+// customMemoRelationTypeMatcher matches the names of the user-defined relation types, e.g. blocks or follows-up.
+var customMemoRelationTypeMatcher = regexp.MustCompile(`^[a-z][a-z0-9-]{0,63}$`)
+
+// IsCustom returns true if the type is a valid user-defined relation type.
+func (t MemoRelationType) IsCustom() bool {
+	return customMemoRelationTypeMatcher.MatchString(string(t))
+}
+
+// IsValid returns true if the type is a builtin or a valid user-defined relation type.
+func (t MemoRelationType) IsValid() bool {
+	return t == MemoRelationReference || t == MemoRelationComment || t.IsCustom()
+}
+
 type MemoRelation struct {
 	MemoID        int32
 	RelatedMemoID int32
@@ -32,6 +47,9 @@ type DeleteMemoRelation struct {
 }
 
 func (s *Store) UpsertMemoRelation(ctx context.Context, create *MemoRelation) (*MemoRelation, error) {
+	if !create.Type.IsValid() {
+		return nil, errors.New("invalid memo relation type")
+	}
 	return s.driver.UpsertMemoRelation(ctx, create)
 }
 
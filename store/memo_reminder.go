@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+)
+
+// MemoReminder reminds the user of the memo at the time, by an inbox message and the notification channels of the user.
+// A user has at most one reminder for a memo.
+type MemoReminder struct {
+	ID        int32
+	CreatedTs int64
+
+	CreatorID int32
+	MemoID    int32
+	RemindTs  int64
+	// DeliveredTs is the time the reminder was delivered, 0 if it's pending.
+	DeliveredTs int64
+}
+
+type FindMemoReminder struct {
+	ID        *int32
+	CreatorID *int32
+	MemoID    *int32
+	// RemindTsBefore matches the reminders due before the time.
+	RemindTsBefore *int64
+	// Pending matches the reminders which are not delivered yet.
+	Pending bool
+	// Limit is the max number of the reminders, ordered by remind time.
+	Limit *int
+}
+
+type UpdateMemoReminder struct {
+	ID          int32
+	DeliveredTs *int64
+}
+
+type DeleteMemoReminder struct {
+	CreatorID int32
+	MemoID    int32
+}
+
+// UpsertMemoReminder creates the reminder of the user for the memo, or reschedules it if it exists.
+// A rescheduled reminder is pending again.
+func (s *Store) UpsertMemoReminder(ctx context.Context, upsert *MemoReminder) (*MemoReminder, error) {
+	return s.driver.UpsertMemoReminder(ctx, upsert)
+}
+
+func (s *Store) ListMemoReminders(ctx context.Context, find *FindMemoReminder) ([]*MemoReminder, error) {
+	return s.driver.ListMemoReminders(ctx, find)
+}
+
+func (s *Store) GetMemoReminder(ctx context.Context, find *FindMemoReminder) (*MemoReminder, error) {
+	list, err := s.ListMemoReminders(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateMemoReminder(ctx context.Context, update *UpdateMemoReminder) error {
+	return s.driver.UpdateMemoReminder(ctx, update)
+}
+
+func (s *Store) DeleteMemoReminder(ctx context.Context, delete *DeleteMemoReminder) error {
+	return s.driver.DeleteMemoReminder(ctx, delete)
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+)
+
+// MemoReportStatus is the status of a memo report.
+type MemoReportStatus string
+
+const (
+	// MemoReportOpen is the status of the reports waiting for review.
+	MemoReportOpen MemoReportStatus = "OPEN"
+	// MemoReportDismissed is the status of the reports reviewed without action.
+	MemoReportDismissed MemoReportStatus = "DISMISSED"
+	// MemoReportHidden is the status of the reports whose memo is hidden from other users.
+	MemoReportHidden MemoReportStatus = "HIDDEN"
+)
+
+func (s MemoReportStatus) String() string {
+	return string(s)
+}
+
+// MemoReport is a report of a memo or comment by a user, which is reviewed by the admins.
+type MemoReport struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+
+	ReporterID int32
+	MemoID     int32
+	Reason     string
+	Status     MemoReportStatus
+	// ResolverID is the id of the admin who reviewed the report, it's 0 while the report is open.
+	ResolverID int32
+	Resolution string
+}
+
+type FindMemoReport struct {
+	ID         *int32
+	ReporterID *int32
+	MemoID     *int32
+	Status     *MemoReportStatus
+	// MemoCreatorID is used to find the reports of the memos of a user.
+	MemoCreatorID *int32
+
+	// Pagination
+	Limit  *int
+	Offset *int
+}
+
+type UpdateMemoReport struct {
+	ID         int32
+	UpdatedTs  *int64
+	Status     *MemoReportStatus
+	ResolverID *int32
+	Resolution *string
+}
+
+// CreateMemoReport creates the report, it fails if the user has reported the memo.
+func (s *Store) CreateMemoReport(ctx context.Context, create *MemoReport) (*MemoReport, error) {
+	return s.driver.CreateMemoReport(ctx, create)
+}
+
+func (s *Store) ListMemoReports(ctx context.Context, find *FindMemoReport) ([]*MemoReport, error) {
+	return s.driver.ListMemoReports(ctx, find)
+}
+
+func (s *Store) GetMemoReport(ctx context.Context, find *FindMemoReport) (*MemoReport, error) {
+	list, err := s.ListMemoReports(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateMemoReport(ctx context.Context, update *UpdateMemoReport) (*MemoReport, error) {
+	return s.driver.UpdateMemoReport(ctx, update)
+}
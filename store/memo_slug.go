@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMemoSlugExists is returned if the slug is taken by another memo of the creator.
+var ErrMemoSlugExists = errors.New("memo slug already exists")
+
+// MemoSlug is the human-readable slug of a memo, which is unique among the memos of its creator.
+// The memos are linked by the slugs as `/m/{username}/{slug}`, and the clicks of the links are counted.
+type MemoSlug struct {
+	MemoID int32
+	// CreatorID is the id of the creator of the memo.
+	CreatorID  int32
+	Slug       string
+	ClickCount int32
+	UpdatedTs  int64
+}
+
+type FindMemoSlug struct {
+	MemoID *int32
+	Slug   *string
+	// CreatorID is the id of the creator of the memo.
+	CreatorID *int32
+}
+
+type DeleteMemoSlug struct {
+	MemoID int32
+}
+
+// UpsertMemoSlug sets the slug of the memo, the click count is kept.
+// ErrMemoSlugExists is returned if another memo of the creator has the slug.
+func (s *Store) UpsertMemoSlug(ctx context.Context, upsert *MemoSlug) (*MemoSlug, error) {
+	memoSlug, err := s.driver.UpsertMemoSlug(ctx, upsert)
+	if err != nil {
+		// The unique violation of the slug differs among the drivers, so it's told by the memo having the slug.
+		if existing, findErr := s.GetMemoSlug(ctx, &FindMemoSlug{Slug: &upsert.Slug, CreatorID: &upsert.CreatorID}); findErr == nil && existing != nil && existing.MemoID != upsert.MemoID {
+			return nil, ErrMemoSlugExists
+		}
+		return nil, err
+	}
+	return memoSlug, nil
+}
+
+// moveMemoSlug moves the slug of the memo to its new creator, the slug is dropped if the creator has it already.
+func (s *Store) moveMemoSlug(ctx context.Context, memoID, creatorID int32) error {
+	memoSlug, err := s.GetMemoSlug(ctx, &FindMemoSlug{MemoID: &memoID})
+	if err != nil || memoSlug == nil {
+		return err
+	}
+	existing, err := s.GetMemoSlug(ctx, &FindMemoSlug{Slug: &memoSlug.Slug, CreatorID: &creatorID})
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.MemoID != memoID {
+		return s.DeleteMemoSlug(ctx, &DeleteMemoSlug{MemoID: memoID})
+	}
+	memoSlug.CreatorID = creatorID
+	_, err = s.driver.UpsertMemoSlug(ctx, memoSlug)
+	return err
+}
+
+func (s *Store) ListMemoSlugs(ctx context.Context, find *FindMemoSlug) ([]*MemoSlug, error) {
+	return s.driver.ListMemoSlugs(ctx, find)
+}
+
+func (s *Store) GetMemoSlug(ctx context.Context, find *FindMemoSlug) (*MemoSlug, error) {
+	list, err := s.ListMemoSlugs(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// IncreaseMemoSlugClickCount counts a click of the link of the slug of the memo.
+func (s *Store) IncreaseMemoSlugClickCount(ctx context.Context, memoID int32) error {
+	return s.driver.IncreaseMemoSlugClickCount(ctx, memoID)
+}
+
+func (s *Store) DeleteMemoSlug(ctx context.Context, delete *DeleteMemoSlug) error {
+	return s.driver.DeleteMemoSlug(ctx, delete)
+}
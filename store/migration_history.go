@@ -1,5 +1,12 @@
 package store
 
+import (
+	"context"
+	"sort"
+
+	"github.com/usememos/memos/server/version"
+)
+
 type MigrationHistory struct {
 	Version   string
 	CreatedTs int64
@@ -11,3 +18,25 @@ type UpsertMigrationHistory struct {
 
 type FindMigrationHistory struct {
 }
+
+// HasPendingMigration returns true if the schema of the database is older than the one of the current version.
+// The latest schema of the modes other than prod is applied on start, so they never have a pending migration.
+func (s *Store) HasPendingMigration(ctx context.Context) (bool, error) {
+	if s.Profile.Mode != "prod" {
+		return false, nil
+	}
+	migrationHistoryList, err := s.driver.FindMigrationHistoryList(ctx, &FindMigrationHistory{})
+	if err != nil {
+		return false, err
+	}
+	if len(migrationHistoryList) == 0 {
+		return true, nil
+	}
+	versionList := []string{}
+	for _, migrationHistory := range migrationHistoryList {
+		versionList = append(versionList, migrationHistory.Version)
+	}
+	sort.Sort(version.SortVersion(versionList))
+	schemaVersion := version.GetSchemaVersion(version.GetCurrentVersion(s.Profile.Mode))
+	return version.IsVersionGreaterThan(schemaVersion, versionList[len(versionList)-1]), nil
+}
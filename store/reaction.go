@@ -10,6 +10,10 @@ type FindReaction struct {
 	ID        *int32
 	CreatorID *int32
 	ContentID *string
+
+	// Pagination
+	Limit  *int
+	Offset *int
 }
 
 type DeleteReaction struct {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -35,6 +36,9 @@ type Resource struct {
 	Type         string
 	Size         int64
 	MemoID       *int32
+	// ExtractedText is the text extracted from the resource content, e.g. by OCR.
+	// It's nil if the text is not extracted yet.
+	ExtractedText *string
 }
 
 type FindResource struct {
@@ -50,25 +54,40 @@ type FindResource struct {
 }
 
 type UpdateResource struct {
-	ID           int32
-	UID          *string
-	UpdatedTs    *int64
-	Filename     *string
-	InternalPath *string
-	ExternalLink *string
-	MemoID       *int32
-	Blob         []byte
+	ID            int32
+	UID           *string
+	CreatorID     *int32
+	UpdatedTs     *int64
+	Filename      *string
+	InternalPath  *string
+	ExternalLink  *string
+	MemoID        *int32
+	Blob          []byte
+	ExtractedText *string
 }
 
 type DeleteResource struct {
 	ID     int32
 	MemoID *int32
+	// KeepFiles keeps the local file and thumbnail of the resource,
+	// e.g. when the deletion is in a transaction which may be rolled back.
+	KeepFiles bool
 }
 
 func (s *Store) CreateResource(ctx context.Context, create *Resource) (*Resource, error) {
 	if !util.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
 	}
+	if err := s.checkResourceQuota(ctx, create.CreatorID, create.Size); err != nil {
+		return nil, err
+	}
+	if s.contentInterceptor != nil {
+		if err := s.contentInterceptor.InterceptResourceCreate(ctx, s, create); err != nil {
+			// The blob saved for the rejected resource isn't kept.
+			s.DeleteResourceFiles(create)
+			return nil, err
+		}
+	}
 	return s.driver.CreateResource(ctx, create)
 }
 
@@ -105,8 +124,16 @@ func (s *Store) DeleteResource(ctx context.Context, delete *DeleteResource) erro
 		return errors.Wrap(nil, "resource not found")
 	}
 
-	// Delete the local file.
-	if resource.InternalPath != "" {
+	if !delete.KeepFiles {
+		s.DeleteResourceFiles(resource)
+	}
+	return s.driver.DeleteResource(ctx, delete)
+}
+
+// DeleteResourceFiles deletes the local file and thumbnail of the resource.
+func (s *Store) DeleteResourceFiles(resource *Resource) {
+	// Delete the local file, the references to the remote storages, e.g. `sftp://` and `s3://`, aren't local files.
+	if resource.InternalPath != "" && !strings.Contains(resource.InternalPath, "://") {
 		resourcePath := filepath.FromSlash(resource.InternalPath)
 		if !filepath.IsAbs(resourcePath) {
 			resourcePath = filepath.Join(s.Profile.Data, resourcePath)
@@ -120,5 +147,4 @@ func (s *Store) DeleteResource(ctx context.Context, delete *DeleteResource) erro
 		thumbnailPath := filepath.Join(s.Profile.Data, thumbnailImagePath, fmt.Sprintf("%d%s", resource.ID, ext))
 		_ = os.Remove(thumbnailPath)
 	}
-	return s.driver.DeleteResource(ctx, delete)
 }
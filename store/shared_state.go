@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SharedState is the state shared by the replicas of a deployment, e.g. on Redis.
+// Without it, the caches, the counters and the locks are local to the instance, which is enough for a single replica.
+type SharedState interface {
+	// Get returns the value of the key, ok is false if the key doesn't exist.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set sets the value of the key, which expires after the TTL.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Add sets the value of the key like Set, unless the key exists.
+	Add(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// Increment increments the counter of the key, which expires after the window from the first increment.
+	// It returns the count and the time left until the counter expires.
+	Increment(ctx context.Context, key string, window time.Duration) (count int64, ttl time.Duration, err error)
+	// TryLock takes the lock of the key for the TTL, unless another replica holds it.
+	// The returned function releases the lock before it expires.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(context.Context) error, ok bool, err error)
+}
+
+// UseSharedState makes the store cache the models in the shared state, so the replicas don't serve the stale models
+// changed by each other, e.g. the revoked access tokens. It must be called before the store is used.
+func (s *Store) UseSharedState(shared SharedState) {
+	s.shared = shared
+}
+
+// GetSharedState returns the shared state of the replicas, nil if the store isn't shared.
+func (s *Store) GetSharedState() SharedState {
+	return s.shared
+}
+
+// ClaimJobRun returns true if the replica should run the background job now, which is false if another replica has run it
+// within the interval. It's always true without the shared state. If the shared state fails, the job is run anyway.
+func (s *Store) ClaimJobRun(ctx context.Context, name string, interval time.Duration) bool {
+	if s.shared == nil {
+		return true
+	}
+	// The claim expires a bit before the interval, so the next run of the replica isn't skipped by the drift of its timer.
+	_, ok, err := s.shared.TryLock(ctx, "job:"+name, interval*9/10)
+	if err != nil {
+		slog.Warn("Failed to claim job run", slog.String("job", name), slog.Any("err", err))
+		return true
+	}
+	return ok
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 
 	"github.com/usememos/memos/server/profile"
@@ -16,6 +17,13 @@ type Store struct {
 	userCache               sync.Map // map[int]*User
 	userSettingCache        sync.Map // map[string]*UserSetting
 	idpCache                sync.Map // map[int]*IdentityProvider
+	cacheCounters           sync.Map // map[string]*cacheCounter
+	// shared is the state shared by the replicas, nil if the store isn't shared.
+	shared SharedState
+	// contentInterceptor is run on the memos and the resources before they're saved, nil if there isn't one.
+	contentInterceptor ContentInterceptor
+	// txCacheKeys are the keys of the models cached or uncached in the transaction, nil if the store isn't bound to one.
+	txCacheKeys *sync.Map // map[txCacheKey]bool
 }
 
 // New creates a new instance of Store.
@@ -33,6 +41,27 @@ func (s *Store) MigrateManually(ctx context.Context) error {
 	return nil
 }
 
+// RunInTx runs fn in a database transaction, which is rolled back if fn returns an error.
+// The store passed to fn is bound to the transaction and has its own caches,
+// the models it caches are removed from the caches of the store once the transaction ends.
+func (s *Store) RunInTx(ctx context.Context, fn func(txStore *Store) error) error {
+	txCacheKeys := &sync.Map{}
+	err := s.driver.WithTx(ctx, func(driver Driver) error {
+		txStore := New(driver, s.Profile)
+		txStore.contentInterceptor = s.contentInterceptor
+		txStore.txCacheKeys = txCacheKeys
+		return fn(txStore)
+	})
+	txCacheKeys.Range(func(key, _ any) bool {
+		txCacheKey := key.(txCacheKey)
+		if cache := s.getCache(txCacheKey.name); cache != nil {
+			deleteCache(ctx, s, cache, txCacheKey.name, txCacheKey.key)
+		}
+		return true
+	})
+	return err
+}
+
 func (s *Store) Vacuum(ctx context.Context) error {
 	return s.driver.Vacuum(ctx)
 }
@@ -41,6 +70,16 @@ func (s *Store) Close() error {
 	return s.driver.Close()
 }
 
+// Ping checks the database is reachable.
+func (s *Store) Ping(ctx context.Context) error {
+	return s.driver.GetDB().PingContext(ctx)
+}
+
+// GetDBStats returns the stats of the connection pool of the database.
+func (s *Store) GetDBStats() sql.DBStats {
+	return s.driver.GetDB().Stats()
+}
+
 func (s *Store) GetCurrentDBSize(ctx context.Context) (int64, error) {
 	return s.driver.GetCurrentDBSize(ctx)
 }
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+)
+
+// TaskRunStatus is the status of the last run of a scheduled task.
+type TaskRunStatus string
+
+const (
+	// TaskRunning is the status of the tasks being run.
+	TaskRunning TaskRunStatus = "RUNNING"
+	// TaskSucceeded is the status of the tasks whose last run succeeded.
+	TaskSucceeded TaskRunStatus = "SUCCEEDED"
+	// TaskFailed is the status of the tasks whose last run failed.
+	TaskFailed TaskRunStatus = "FAILED"
+)
+
+func (s TaskRunStatus) String() string {
+	return string(s)
+}
+
+// TaskRun is the last run of a scheduled task, a task has a run once.
+type TaskRun struct {
+	Name   string
+	Status TaskRunStatus
+	// StartedTs is the time the run started.
+	StartedTs int64
+	// FinishedTs is the time the run finished, 0 while it's running.
+	FinishedTs int64
+	// Error is the error of the failed run.
+	Error string
+}
+
+type FindTaskRun struct {
+	Name *string
+}
+
+func (s *Store) UpsertTaskRun(ctx context.Context, upsert *TaskRun) (*TaskRun, error) {
+	return s.driver.UpsertTaskRun(ctx, upsert)
+}
+
+func (s *Store) ListTaskRuns(ctx context.Context, find *FindTaskRun) ([]*TaskRun, error) {
+	return s.driver.ListTaskRuns(ctx, find)
+}
+
+func (s *Store) GetTaskRun(ctx context.Context, find *FindTaskRun) (*TaskRun, error) {
+	list, err := s.ListTaskRuns(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
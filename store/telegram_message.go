@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+)
+
+// TelegramMessage links a memo to the message of the Telegram bot confirming it's saved,
+// so the changes of the memo are synced back to the chat.
+type TelegramMessage struct {
+	ID        int32
+	CreatedTs int64
+
+	MemoID int32
+	ChatID int64
+	// MessageID is the id of the message sent by the bot.
+	MessageID int64
+	// SourceMessageID is the id of the message the memo was saved from.
+	SourceMessageID int64
+}
+
+type FindTelegramMessage struct {
+	MemoID *int32
+	ChatID *int64
+	// MessageID matches either the message sent by the bot or the message the memo was saved from.
+	MessageID *int64
+}
+
+type DeleteTelegramMessage struct {
+	MemoID int32
+}
+
+func (s *Store) CreateTelegramMessage(ctx context.Context, create *TelegramMessage) (*TelegramMessage, error) {
+	return s.driver.CreateTelegramMessage(ctx, create)
+}
+
+func (s *Store) ListTelegramMessages(ctx context.Context, find *FindTelegramMessage) ([]*TelegramMessage, error) {
+	return s.driver.ListTelegramMessages(ctx, find)
+}
+
+func (s *Store) GetTelegramMessage(ctx context.Context, find *FindTelegramMessage) (*TelegramMessage, error) {
+	list, err := s.ListTelegramMessages(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteTelegramMessage(ctx context.Context, delete *DeleteTelegramMessage) error {
+	return s.driver.DeleteTelegramMessage(ctx, delete)
+}
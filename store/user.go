@@ -88,6 +88,8 @@ type FindUser struct {
 	Random bool
 	// The maximum number of users to return.
 	Limit *int
+	// The number of users to skip, only used with limit.
+	Offset *int
 }
 
 type DeleteUser struct {
@@ -100,7 +102,7 @@ func (s *Store) CreateUser(ctx context.Context, create *User) (*User, error) {
 		return nil, err
 	}
 
-	s.userCache.Store(user.ID, user)
+	storeCache(ctx, s, &s.userCache, "user", user.ID, user, true)
 	return user, nil
 }
 
@@ -110,7 +112,7 @@ func (s *Store) UpdateUser(ctx context.Context, update *UpdateUser) (*User, erro
 		return nil, err
 	}
 
-	s.userCache.Store(user.ID, user)
+	storeCache(ctx, s, &s.userCache, "user", user.ID, user, true)
 	return user, nil
 }
 
@@ -121,7 +123,7 @@ func (s *Store) ListUsers(ctx context.Context, find *FindUser) ([]*User, error)
 	}
 
 	for _, user := range list {
-		s.userCache.Store(user.ID, user)
+		storeCache(ctx, s, &s.userCache, "user", user.ID, user, false)
 	}
 	return list, nil
 }
@@ -132,8 +134,8 @@ func (s *Store) GetUser(ctx context.Context, find *FindUser) (*User, error) {
 			return SystemBot, nil
 		}
 
-		if cache, ok := s.userCache.Load(*find.ID); ok {
-			return cache.(*User), nil
+		if cache, ok := loadCache[*User](ctx, s, &s.userCache, "user", *find.ID); ok {
+			return cache, nil
 		}
 	}
 
@@ -146,7 +148,6 @@ func (s *Store) GetUser(ctx context.Context, find *FindUser) (*User, error) {
 	}
 
 	user := list[0]
-	s.userCache.Store(user.ID, user)
 	return user, nil
 }
 
@@ -156,6 +157,6 @@ func (s *Store) DeleteUser(ctx context.Context, delete *DeleteUser) error {
 		return err
 	}
 
-	s.userCache.Delete(delete.ID)
+	deleteCache(ctx, s, &s.userCache, "user", delete.ID)
 	return nil
 }
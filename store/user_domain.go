@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+)
+
+// UserDomain is an external domain serving the public memos of a user, e.g. `notes.example.com`.
+type UserDomain struct {
+	ID        int32
+	UserID    int32
+	CreatedTs int64
+
+	// Hostname is unique and lower case, without the port.
+	Hostname string
+	// VerificationToken is published in the TXT record of the domain to prove the ownership of it.
+	VerificationToken string
+	// VerifiedTs is the time the ownership of the domain is verified, 0 if it isn't verified yet.
+	// Only the verified domains are served.
+	VerifiedTs int64
+}
+
+type FindUserDomain struct {
+	ID       *int32
+	UserID   *int32
+	Hostname *string
+	Verified *bool
+}
+
+type UpdateUserDomain struct {
+	ID         int32
+	VerifiedTs *int64
+}
+
+type DeleteUserDomain struct {
+	ID int32
+}
+
+func (s *Store) CreateUserDomain(ctx context.Context, create *UserDomain) (*UserDomain, error) {
+	return s.driver.CreateUserDomain(ctx, create)
+}
+
+func (s *Store) ListUserDomains(ctx context.Context, find *FindUserDomain) ([]*UserDomain, error) {
+	return s.driver.ListUserDomains(ctx, find)
+}
+
+func (s *Store) GetUserDomain(ctx context.Context, find *FindUserDomain) (*UserDomain, error) {
+	list, err := s.ListUserDomains(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateUserDomain(ctx context.Context, update *UpdateUserDomain) (*UserDomain, error) {
+	return s.driver.UpdateUserDomain(ctx, update)
+}
+
+func (s *Store) DeleteUserDomain(ctx context.Context, delete *DeleteUserDomain) error {
+	return s.driver.DeleteUserDomain(ctx, delete)
+}
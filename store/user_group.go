@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+)
+
+// UserGroupRole is the role of a member of a user group.
+type UserGroupRole string
+
+const (
+	// UserGroupRoleOwner is the role of the members managing the group and its members.
+	UserGroupRoleOwner UserGroupRole = "OWNER"
+	// UserGroupRoleMember is the role of the members sharing the memos of the group.
+	UserGroupRoleMember UserGroupRole = "MEMBER"
+)
+
+func (r UserGroupRole) String() string {
+	return string(r)
+}
+
+// UserGroup is a group of users sharing the memos of the GROUP visibility, e.g. a team.
+type UserGroup struct {
+	ID        int32
+	CreatorID int32
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Name is unique, the members are mentioned together by it, e.g. `@design`.
+	Name        string
+	Description string
+}
+
+type FindUserGroup struct {
+	ID   *int32
+	Name *string
+	// MemberID is used to find the groups of a user.
+	MemberID *int32
+}
+
+type UpdateUserGroup struct {
+	ID          int32
+	UpdatedTs   *int64
+	Name        *string
+	Description *string
+}
+
+type DeleteUserGroup struct {
+	ID int32
+}
+
+type UserGroupMember struct {
+	GroupID   int32
+	UserID    int32
+	Role      UserGroupRole
+	CreatedTs int64
+}
+
+type FindUserGroupMember struct {
+	GroupID *int32
+	UserID  *int32
+}
+
+type DeleteUserGroupMember struct {
+	GroupID int32
+	UserID  int32
+}
+
+func (s *Store) CreateUserGroup(ctx context.Context, create *UserGroup) (*UserGroup, error) {
+	return s.driver.CreateUserGroup(ctx, create)
+}
+
+func (s *Store) ListUserGroups(ctx context.Context, find *FindUserGroup) ([]*UserGroup, error) {
+	return s.driver.ListUserGroups(ctx, find)
+}
+
+func (s *Store) GetUserGroup(ctx context.Context, find *FindUserGroup) (*UserGroup, error) {
+	list, err := s.ListUserGroups(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateUserGroup(ctx context.Context, update *UpdateUserGroup) (*UserGroup, error) {
+	return s.driver.UpdateUserGroup(ctx, update)
+}
+
+// DeleteUserGroup deletes the group and its memberships, the memos of the group become private to their creators.
+func (s *Store) DeleteUserGroup(ctx context.Context, delete *DeleteUserGroup) error {
+	return s.driver.DeleteUserGroup(ctx, delete)
+}
+
+// UpsertUserGroupMember adds the user to the group, or updates the role of the member.
+func (s *Store) UpsertUserGroupMember(ctx context.Context, upsert *UserGroupMember) (*UserGroupMember, error) {
+	return s.driver.UpsertUserGroupMember(ctx, upsert)
+}
+
+func (s *Store) ListUserGroupMembers(ctx context.Context, find *FindUserGroupMember) ([]*UserGroupMember, error) {
+	return s.driver.ListUserGroupMembers(ctx, find)
+}
+
+// GetUserGroupMember returns the membership of the user in the group, nil if the user isn't a member.
+func (s *Store) GetUserGroupMember(ctx context.Context, groupID, userID int32) (*UserGroupMember, error) {
+	list, err := s.ListUserGroupMembers(ctx, &FindUserGroupMember{GroupID: &groupID, UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteUserGroupMember(ctx context.Context, delete *DeleteUserGroupMember) error {
+	return s.driver.DeleteUserGroupMember(ctx, delete)
+}
+
+// IsMemoVisibleTo returns true if the memo is visible to the user, 0 for the anonymous users. The public memos are visible
+// to everyone, the protected memos to the signed in users, the private memos to their creators, and the group memos
+// to the members of their groups.
+func (s *Store) IsMemoVisibleTo(ctx context.Context, memo *Memo, userID int32) (bool, error) {
+	if memo.Visibility == Public || (userID != 0 && memo.CreatorID == userID) {
+		return true, nil
+	}
+	if userID == 0 {
+		return false, nil
+	}
+	switch memo.Visibility {
+	case Protected:
+		return true, nil
+	case Group:
+		if memo.GroupID == nil {
+			return false, nil
+		}
+		member, err := s.GetUserGroupMember(ctx, *memo.GroupID, userID)
+		if err != nil {
+			return false, err
+		}
+		return member != nil, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrQuotaExceeded is returned when creating a memo or resource exceeds the quota of the creator.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// GetUserUsage returns the number of the memos and the total size of the resources of the user.
+func (s *Store) GetUserUsage(ctx context.Context, userID int32) (int32, int64, error) {
+	return s.driver.GetUserUsage(ctx, userID)
+}
+
+func (s *Store) checkMemoQuota(ctx context.Context, creatorID int32) error {
+	quota, err := s.GetUserQuota(ctx, creatorID)
+	if err != nil {
+		return err
+	}
+	if quota.MaxMemoCount <= 0 {
+		return nil
+	}
+	memoCount, _, err := s.GetUserUsage(ctx, creatorID)
+	if err != nil {
+		return err
+	}
+	if memoCount >= quota.MaxMemoCount {
+		return fmt.Errorf("%w: the maximum number of memos is %d", ErrQuotaExceeded, quota.MaxMemoCount)
+	}
+	return nil
+}
+
+func (s *Store) checkResourceQuota(ctx context.Context, creatorID int32, size int64) error {
+	quota, err := s.GetUserQuota(ctx, creatorID)
+	if err != nil {
+		return err
+	}
+	if quota.MaxResourceSize <= 0 {
+		return nil
+	}
+	_, resourceSize, err := s.GetUserUsage(ctx, creatorID)
+	if err != nil {
+		return err
+	}
+	if resourceSize+size > quota.MaxResourceSize {
+		return fmt.Errorf("%w: the maximum size of resources is %d bytes", ErrQuotaExceeded, quota.MaxResourceSize)
+	}
+	return nil
+}
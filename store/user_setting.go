@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
@@ -17,7 +19,7 @@ func (s *Store) UpsertUserSetting(ctx context.Context, upsert *storepb.UserSetti
 		return nil, err
 	}
 
-	s.userSettingCache.Store(getUserSettingV1CacheKey(userSettingMessage.UserId, userSettingMessage.Key.String()), userSettingMessage)
+	storeCache(ctx, s, &s.userSettingCache, "user_setting", getUserSettingV1CacheKey(userSettingMessage.UserId, userSettingMessage.Key.String()), userSettingMessage, true)
 	return userSettingMessage, nil
 }
 
@@ -28,15 +30,15 @@ func (s *Store) ListUserSettings(ctx context.Context, find *FindUserSetting) ([]
 	}
 
 	for _, userSetting := range userSettingList {
-		s.userSettingCache.Store(getUserSettingV1CacheKey(userSetting.UserId, userSetting.Key.String()), userSetting)
+		storeCache(ctx, s, &s.userSettingCache, "user_setting", getUserSettingV1CacheKey(userSetting.UserId, userSetting.Key.String()), userSetting, false)
 	}
 	return userSettingList, nil
 }
 
 func (s *Store) GetUserSetting(ctx context.Context, find *FindUserSetting) (*storepb.UserSetting, error) {
 	if find.UserID != nil {
-		if cache, ok := s.userSettingCache.Load(getUserSettingV1CacheKey(*find.UserID, find.Key.String())); ok {
-			return cache.(*storepb.UserSetting), nil
+		if cache, ok := loadCache[*storepb.UserSetting](ctx, s, &s.userSettingCache, "user_setting", getUserSettingV1CacheKey(*find.UserID, find.Key.String())); ok {
+			return cache, nil
 		}
 	}
 
@@ -50,7 +52,6 @@ func (s *Store) GetUserSetting(ctx context.Context, find *FindUserSetting) (*sto
 	}
 
 	userSetting := list[0]
-	s.userSettingCache.Store(getUserSettingV1CacheKey(userSetting.UserId, userSetting.Key.String()), userSetting)
 	return userSetting, nil
 }
 
@@ -97,3 +98,203 @@ func (s *Store) RemoveUserAccessToken(ctx context.Context, userID int32, token s
 
 	return err
 }
+
+// GetUserQuota returns the quota of the user, which is empty if it's not set.
+func (s *Store) GetUserQuota(ctx context.Context, userID int32) (*storepb.QuotaUserSetting, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_QUOTA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return &storepb.QuotaUserSetting{}, nil
+	}
+	return userSetting.GetQuota(), nil
+}
+
+// GetUserLocation returns the timezone of the user, UTC if it's unset or unknown.
+func (s *Store) GetUserLocation(ctx context.Context, userID int32) (*time.Location, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_TIMEZONE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(userSetting.GetTimezone())
+	if err != nil {
+		return time.UTC, nil
+	}
+	return location, nil
+}
+
+// GetUserLocale returns the preferred locale of the user, the default locale of the workspace if it's unset.
+func (s *Store) GetUserLocale(ctx context.Context, userID int32) (string, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_LOCALE,
+	})
+	if err != nil {
+		return "", err
+	}
+	if userSetting != nil && userSetting.GetLocale() != "" {
+		return userSetting.GetLocale(), nil
+	}
+	return s.GetWorkspaceDefaultLocale(ctx)
+}
+
+// GetWorkspaceDefaultLocale returns the default locale of the customized profile of the workspace, empty if it's unset.
+func (s *Store) GetWorkspaceDefaultLocale(ctx context.Context) (string, error) {
+	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
+		Name: "customized-profile",
+	})
+	if err != nil || workspaceSetting == nil {
+		return "", err
+	}
+	customizedProfile := struct {
+		Locale string `json:"locale"`
+	}{}
+	if err := json.Unmarshal([]byte(workspaceSetting.Value), &customizedProfile); err != nil {
+		// The locale of an invalid profile is unset.
+		return "", nil
+	}
+	return customizedProfile.Locale, nil
+}
+
+// GetUserProfile returns the public profile of the user, which is empty if it's not set.
+func (s *Store) GetUserProfile(ctx context.Context, userID int32) (*storepb.ProfileUserSetting, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_PROFILE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return &storepb.ProfileUserSetting{}, nil
+	}
+	return userSetting.GetProfile(), nil
+}
+
+// ListProfilePinnedMemos returns the memos pinned on the profile of the user in order, the ones which aren't public
+// any more are left out.
+func (s *Store) ListProfilePinnedMemos(ctx context.Context, userID int32, memoIDs []int32) ([]*Memo, error) {
+	normalStatus := Normal
+	list := []*Memo{}
+	for _, memoID := range memoIDs {
+		memo, err := s.GetMemo(ctx, &FindMemo{
+			ID:             &memoID,
+			RowStatus:      &normalStatus,
+			CreatorID:      &userID,
+			VisibilityList: []Visibility{Public},
+			ExcludeHidden:  true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if memo != nil {
+			list = append(list, memo)
+		}
+	}
+	return list, nil
+}
+
+// lastActiveUpdateInterval is the minimum interval of updating the last active time of a user.
+const lastActiveUpdateInterval = 5 * time.Minute
+
+// UpdateUserLastActiveTs records the user is active now.
+// The time is written at most once in the interval to avoid writing on every request.
+func (s *Store) UpdateUserLastActiveTs(ctx context.Context, userID int32) error {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_LAST_ACTIVE_TS,
+	})
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	if userSetting != nil && now-userSetting.GetLastActiveTs() < int64(lastActiveUpdateInterval.Seconds()) {
+		return nil
+	}
+	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_LAST_ACTIVE_TS,
+		Value: &storepb.UserSetting_LastActiveTs{
+			LastActiveTs: now,
+		},
+	})
+	return err
+}
+
+// maxWebPushSubscriptions is the max number of the Web Push subscriptions of a user, the oldest are removed.
+const maxWebPushSubscriptions = 20
+
+// GetUserWebPushSubscriptions returns the Web Push subscriptions of the user.
+func (s *Store) GetUserWebPushSubscriptions(ctx context.Context, userID int32) ([]*storepb.WebPushSubscriptionsUserSetting_Subscription, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_WEB_PUSH_SUBSCRIPTIONS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return []*storepb.WebPushSubscriptionsUserSetting_Subscription{}, nil
+	}
+	return userSetting.GetWebPushSubscriptions().Subscriptions, nil
+}
+
+// UpsertUserWebPushSubscription adds the subscription of the user, or replaces the subscription of the same endpoint.
+func (s *Store) UpsertUserWebPushSubscription(ctx context.Context, userID int32, subscription *storepb.WebPushSubscriptionsUserSetting_Subscription) error {
+	oldSubscriptions, err := s.GetUserWebPushSubscriptions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	newSubscriptions := make([]*storepb.WebPushSubscriptionsUserSetting_Subscription, 0, len(oldSubscriptions)+1)
+	for _, oldSubscription := range oldSubscriptions {
+		if oldSubscription.Endpoint != subscription.Endpoint {
+			newSubscriptions = append(newSubscriptions, oldSubscription)
+		}
+	}
+	newSubscriptions = append(newSubscriptions, subscription)
+	if len(newSubscriptions) > maxWebPushSubscriptions {
+		newSubscriptions = newSubscriptions[len(newSubscriptions)-maxWebPushSubscriptions:]
+	}
+	return s.upsertUserWebPushSubscriptions(ctx, userID, newSubscriptions)
+}
+
+// RemoveUserWebPushSubscription removes the subscription of the endpoint of the user.
+func (s *Store) RemoveUserWebPushSubscription(ctx context.Context, userID int32, endpoint string) error {
+	oldSubscriptions, err := s.GetUserWebPushSubscriptions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	newSubscriptions := make([]*storepb.WebPushSubscriptionsUserSetting_Subscription, 0, len(oldSubscriptions))
+	for _, oldSubscription := range oldSubscriptions {
+		if oldSubscription.Endpoint != endpoint {
+			newSubscriptions = append(newSubscriptions, oldSubscription)
+		}
+	}
+	if len(newSubscriptions) == len(oldSubscriptions) {
+		return nil
+	}
+	return s.upsertUserWebPushSubscriptions(ctx, userID, newSubscriptions)
+}
+
+func (s *Store) upsertUserWebPushSubscriptions(ctx context.Context, userID int32, subscriptions []*storepb.WebPushSubscriptionsUserSetting_Subscription) error {
+	_, err := s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_USER_SETTING_WEB_PUSH_SUBSCRIPTIONS,
+		Value: &storepb.UserSetting_WebPushSubscriptions{
+			WebPushSubscriptions: &storepb.WebPushSubscriptionsUserSetting{
+				Subscriptions: subscriptions,
+			},
+		},
+	})
+	return err
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+)
+
+// VaultFile links a file of the Obsidian vault of the user to a memo or a resource, so the file keeps its path
+// and the changes are synced to the same memo. The file of a deleted memo or resource is kept as deleted,
+// so the deletion is synced to the other devices.
+type VaultFile struct {
+	ID        int32
+	CreatorID int32
+	// UpdatedTs is the time the path or the status of the file changed.
+	UpdatedTs int64
+
+	// Path is the path of the file, relative to the synced folder of the vault.
+	Path string
+	// Either MemoID or ResourceID is set, the notes are memos and the attachments are resources.
+	MemoID     int32
+	ResourceID int32
+	Deleted    bool
+}
+
+type FindVaultFile struct {
+	ID        *int32
+	CreatorID *int32
+	Path      *string
+}
+
+type UpdateVaultFile struct {
+	ID         int32
+	UpdatedTs  *int64
+	Path       *string
+	MemoID     *int32
+	ResourceID *int32
+	Deleted    *bool
+}
+
+type DeleteVaultFile struct {
+	ID int32
+}
+
+func (s *Store) CreateVaultFile(ctx context.Context, create *VaultFile) (*VaultFile, error) {
+	return s.driver.CreateVaultFile(ctx, create)
+}
+
+func (s *Store) ListVaultFiles(ctx context.Context, find *FindVaultFile) ([]*VaultFile, error) {
+	return s.driver.ListVaultFiles(ctx, find)
+}
+
+func (s *Store) GetVaultFile(ctx context.Context, find *FindVaultFile) (*VaultFile, error) {
+	list, err := s.ListVaultFiles(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateVaultFile(ctx context.Context, update *UpdateVaultFile) error {
+	return s.driver.UpdateVaultFile(ctx, update)
+}
+
+func (s *Store) DeleteVaultFile(ctx context.Context, delete *DeleteVaultFile) error {
+	return s.driver.DeleteVaultFile(ctx, delete)
+}
@@ -12,10 +12,12 @@ type FindWebhook struct {
 }
 
 type UpdateWebhook struct {
-	ID        int32
-	RowStatus *storepb.RowStatus
-	Name      *string
-	URL       *string
+	ID              int32
+	RowStatus       *storepb.RowStatus
+	Name            *string
+	URL             *string
+	Secret          *string
+	PayloadTemplate *string
 }
 
 type DeleteWebhook struct {
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+)
+
+// WebhookDeliveryStatus is the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the status of deliveries waiting for the next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliverySucceeded is the status of deliveries accepted by the receiver.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryFailed is the status of deliveries which ran out of attempts.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "FAILED"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookDelivery is a payload queued for delivery to a webhook, and the log of its delivery attempts.
+type WebhookDelivery struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+
+	WebhookID    int32
+	ActivityType string
+	// Payload is the JSON encoded request body.
+	Payload       string
+	Status        WebhookDeliveryStatus
+	Attempts      int32
+	NextAttemptTs int64
+	// LastStatusCode is the response status code of the last attempt, zero if no response is received.
+	LastStatusCode int32
+	LastError      string
+}
+
+type FindWebhookDelivery struct {
+	ID        *int32
+	WebhookID *int32
+	Status    *WebhookDeliveryStatus
+	// NextAttemptTsBefore is used to find the deliveries which are due.
+	NextAttemptTsBefore *int64
+	CreatedTsAfter      *int64
+
+	// Pagination
+	Limit  *int
+	Offset *int
+}
+
+type UpdateWebhookDelivery struct {
+	ID             int32
+	UpdatedTs      *int64
+	Status         *WebhookDeliveryStatus
+	Attempts       *int32
+	NextAttemptTs  *int64
+	LastStatusCode *int32
+	LastError      *string
+}
+
+func (s *Store) CreateWebhookDelivery(ctx context.Context, create *WebhookDelivery) (*WebhookDelivery, error) {
+	return s.driver.CreateWebhookDelivery(ctx, create)
+}
+
+func (s *Store) ListWebhookDeliveries(ctx context.Context, find *FindWebhookDelivery) ([]*WebhookDelivery, error) {
+	return s.driver.ListWebhookDeliveries(ctx, find)
+}
+
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDelivery) error {
+	return s.driver.UpdateWebhookDelivery(ctx, update)
+}
@@ -33,15 +33,15 @@ func (s *Store) ListWorkspaceSettings(ctx context.Context, find *FindWorkspaceSe
 	}
 
 	for _, systemSettingMessage := range list {
-		s.workspaceSettingCache.Store(systemSettingMessage.Name, systemSettingMessage)
+		storeCache(ctx, s, &s.workspaceSettingCache, "workspace_setting", systemSettingMessage.Name, systemSettingMessage, false)
 	}
 	return list, nil
 }
 
 func (s *Store) GetWorkspaceSetting(ctx context.Context, find *FindWorkspaceSetting) (*WorkspaceSetting, error) {
 	if find.Name != "" {
-		if cache, ok := s.workspaceSettingCache.Load(find.Name); ok {
-			return cache.(*WorkspaceSetting), nil
+		if cache, ok := loadCache[*WorkspaceSetting](ctx, s, &s.workspaceSettingCache, "workspace_setting", find.Name); ok {
+			return cache, nil
 		}
 	}
 
@@ -55,7 +55,6 @@ func (s *Store) GetWorkspaceSetting(ctx context.Context, find *FindWorkspaceSett
 	}
 
 	systemSettingMessage := list[0]
-	s.workspaceSettingCache.Store(systemSettingMessage.Name, systemSettingMessage)
 	return systemSettingMessage, nil
 }
 
@@ -64,7 +63,7 @@ func (s *Store) DeleteWorkspaceSetting(ctx context.Context, delete *DeleteWorksp
 	if err != nil {
 		return errors.Wrap(err, "Failed to delete workspace setting")
 	}
-	s.workspaceSettingCache.Delete(delete.Name)
+	deleteCache(ctx, s, &s.workspaceSettingCache, "workspace_setting", delete.Name)
 	return nil
 }
 
@@ -77,7 +76,7 @@ func (s *Store) UpsertWorkspaceSettingV1(ctx context.Context, upsert *storepb.Wo
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to upsert workspace setting")
 	}
-	s.workspaceSettingV1Cache.Store(workspaceSetting.Key.String(), workspaceSetting)
+	storeCache(ctx, s, &s.workspaceSettingV1Cache, "workspace_setting_v1", workspaceSetting.Key.String(), workspaceSetting, true)
 	return workspaceSetting, nil
 }
 
@@ -88,15 +87,15 @@ func (s *Store) ListWorkspaceSettingsV1(ctx context.Context, find *FindWorkspace
 	}
 
 	for _, workspaceSetting := range list {
-		s.workspaceSettingV1Cache.Store(workspaceSetting.Key.String(), workspaceSetting)
+		storeCache(ctx, s, &s.workspaceSettingV1Cache, "workspace_setting_v1", workspaceSetting.Key.String(), workspaceSetting, false)
 	}
 	return list, nil
 }
 
 func (s *Store) GetWorkspaceSettingV1(ctx context.Context, find *FindWorkspaceSettingV1) (*storepb.WorkspaceSetting, error) {
 	if find.Key != storepb.WorkspaceSettingKey_WORKSPACE_SETTING_KEY_UNSPECIFIED {
-		if cache, ok := s.workspaceSettingV1Cache.Load(find.Key.String()); ok {
-			return cache.(*storepb.WorkspaceSetting), nil
+		if cache, ok := loadCache[*storepb.WorkspaceSetting](ctx, s, &s.workspaceSettingV1Cache, "workspace_setting_v1", find.Key.String()); ok {
+			return cache, nil
 		}
 	}
 
@@ -109,7 +108,6 @@ func (s *Store) GetWorkspaceSettingV1(ctx context.Context, find *FindWorkspaceSe
 	}
 
 	workspaceSetting := list[0]
-	s.workspaceSettingV1Cache.Store(workspaceSetting.Key.String(), workspaceSetting)
 	return workspaceSetting, nil
 }
 
@@ -127,3 +125,136 @@ func (s *Store) GetWorkspaceGeneralSetting(ctx context.Context) (*storepb.Worksp
 	}
 	return workspaceGeneralSetting, nil
 }
+
+func (s *Store) GetWorkspaceStorageSetting(ctx context.Context) (*storepb.WorkspaceStorageSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_STORAGE,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceStorageSetting := &storepb.WorkspaceStorageSetting{}
+	if workspaceSetting != nil {
+		workspaceStorageSetting = workspaceSetting.GetStorage()
+	}
+	return workspaceStorageSetting, nil
+}
+
+func (s *Store) GetWorkspaceIntegrationSetting(ctx context.Context) (*storepb.WorkspaceIntegrationSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_INTEGRATION,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceIntegrationSetting := &storepb.WorkspaceIntegrationSetting{}
+	if workspaceSetting != nil {
+		workspaceIntegrationSetting = workspaceSetting.GetIntegration()
+	}
+	return workspaceIntegrationSetting, nil
+}
+
+func (s *Store) GetWorkspaceSchedulerSetting(ctx context.Context) (*storepb.WorkspaceSchedulerSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_SCHEDULER,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceSchedulerSetting := &storepb.WorkspaceSchedulerSetting{}
+	if workspaceSetting != nil {
+		workspaceSchedulerSetting = workspaceSetting.GetScheduler()
+	}
+	return workspaceSchedulerSetting, nil
+}
+
+func (s *Store) GetWorkspaceAnnouncementSetting(ctx context.Context) (*storepb.WorkspaceAnnouncementSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_ANNOUNCEMENT,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceAnnouncementSetting := &storepb.WorkspaceAnnouncementSetting{}
+	if workspaceSetting != nil {
+		workspaceAnnouncementSetting = workspaceSetting.GetAnnouncement()
+	}
+	return workspaceAnnouncementSetting, nil
+}
+
+func (s *Store) GetWorkspaceExploreSetting(ctx context.Context) (*storepb.WorkspaceExploreSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_EXPLORE,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceExploreSetting := &storepb.WorkspaceExploreSetting{}
+	if workspaceSetting != nil {
+		workspaceExploreSetting = workspaceSetting.GetExplore()
+	}
+	return workspaceExploreSetting, nil
+}
+
+func (s *Store) GetWorkspaceBrandingSetting(ctx context.Context) (*storepb.WorkspaceBrandingSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_BRANDING,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceBrandingSetting := &storepb.WorkspaceBrandingSetting{}
+	if workspaceSetting != nil {
+		workspaceBrandingSetting = workspaceSetting.GetBranding()
+	}
+	return workspaceBrandingSetting, nil
+}
+
+func (s *Store) GetWorkspaceHookSetting(ctx context.Context) (*storepb.WorkspaceHookSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSettingV1(ctx, &FindWorkspaceSettingV1{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_HOOK,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace setting")
+	}
+
+	workspaceHookSetting := &storepb.WorkspaceHookSetting{}
+	if workspaceSetting != nil {
+		workspaceHookSetting = workspaceSetting.GetHook()
+	}
+	return workspaceHookSetting, nil
+}
+
+// ApplyExploreSetting narrows the memos found down to the ones listed in explore,
+// with the explore setting of the workspace and the users opted out of explore.
+func (s *Store) ApplyExploreSetting(ctx context.Context, find *FindMemo) error {
+	workspaceExploreSetting, err := s.GetWorkspaceExploreSetting(ctx)
+	if err != nil {
+		return err
+	}
+	find.ExcludeCreatorIDs = append(find.ExcludeCreatorIDs, workspaceExploreSetting.ExcludedUserIds...)
+	find.ExcludeTags = append(find.ExcludeTags, workspaceExploreSetting.ExcludedTags...)
+	if workspaceExploreSetting.RequireApproval {
+		approved := true
+		find.ExploreApproved = &approved
+	}
+
+	userSettings, err := s.ListUserSettings(ctx, &FindUserSetting{
+		Key: storepb.UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list user settings")
+	}
+	for _, userSetting := range userSettings {
+		if userSetting.GetExploreOptOut() {
+			find.ExcludeCreatorIDs = append(find.ExcludeCreatorIDs, userSetting.UserId)
+		}
+	}
+	return nil
+}
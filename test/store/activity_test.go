@@ -32,3 +32,72 @@ func TestActivityStore(t *testing.T) {
 	require.Equal(t, activity, activities[0])
 	ts.Close()
 }
+
+func TestActivityStorePagination(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	activityIDList := []int32{}
+	for i := 0; i < 3; i++ {
+		activity, err := ts.CreateActivity(ctx, &store.Activity{
+			CreatorID: user.ID,
+			Type:      store.ActivityTypeMemoComment,
+			Level:     store.ActivityLevelInfo,
+			Payload:   &storepb.ActivityPayload{},
+		})
+		require.NoError(t, err)
+		activityIDList = append(activityIDList, activity.ID)
+	}
+	limit, offset := 2, 1
+	activities, err := ts.ListActivities(ctx, &store.FindActivity{
+		CreatorID: &user.ID,
+		Limit:     &limit,
+		Offset:    &offset,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(activities))
+	// Activities are ordered by create time, then id descending.
+	require.Equal(t, activityIDList[1], activities[0].ID)
+	require.Equal(t, activityIDList[0], activities[1].ID)
+	ts.Close()
+}
+
+func TestActivityStoreFilter(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	for _, activityType := range []store.ActivityType{store.ActivityTypeMemoCreate, store.ActivityTypeMemoReaction, store.ActivityTypeUserCreate} {
+		_, err := ts.CreateActivity(ctx, &store.Activity{
+			CreatorID: user.ID,
+			Type:      activityType,
+			Level:     store.ActivityLevelInfo,
+			Payload: &storepb.ActivityPayload{
+				MemoCreate: &storepb.ActivityMemoCreatePayload{MemoId: 1},
+			},
+		})
+		require.NoError(t, err)
+	}
+	activities, err := ts.ListActivities(ctx, &store.FindActivity{
+		TypeList: []store.ActivityType{store.ActivityTypeMemoCreate, store.ActivityTypeUserCreate},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(activities))
+	require.Equal(t, store.ActivityTypeUserCreate, activities[0].Type)
+	require.Equal(t, int32(1), activities[1].Payload.GetMemoCreate().GetMemoId())
+
+	createdTs := activities[0].CreatedTs
+	activities, err = ts.ListActivities(ctx, &store.FindActivity{
+		CreatedTsAfter: &createdTs,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(activities))
+	createdTs++
+	activities, err = ts.ListActivities(ctx, &store.FindActivity{
+		CreatedTsBefore: &createdTs,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(activities))
+	ts.Close()
+}
@@ -0,0 +1,73 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestGuestCommentStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "guest-comment",
+		CreatorID:  user.ID,
+		Content:    "public memo",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob"} {
+		guestComment, err := ts.CreateGuestComment(ctx, &store.GuestComment{
+			MemoID:  memo.ID,
+			Name:    name,
+			Content: "comment of " + name,
+			Status:  store.GuestCommentPending,
+			IPHash:  "hash-" + name,
+		})
+		require.NoError(t, err)
+		require.Equal(t, name, guestComment.Name)
+		require.Equal(t, store.GuestCommentPending, guestComment.Status)
+	}
+	guestComments, err := ts.ListGuestComments(ctx, &store.FindGuestComment{MemoCreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, guestComments, 2)
+	require.Equal(t, "alice", guestComments[0].Name)
+	ipHash := "hash-bob"
+	createdTsAfter := time.Now().Add(-time.Hour).Unix()
+	guestComments, err = ts.ListGuestComments(ctx, &store.FindGuestComment{IPHash: &ipHash, CreatedTsAfter: &createdTsAfter})
+	require.NoError(t, err)
+	require.Len(t, guestComments, 1)
+	createdTsAfter = time.Now().Add(time.Hour).Unix()
+	guestComments, err = ts.ListGuestComments(ctx, &store.FindGuestComment{IPHash: &ipHash, CreatedTsAfter: &createdTsAfter})
+	require.NoError(t, err)
+	require.Len(t, guestComments, 0)
+
+	approved := store.GuestCommentApproved
+	guestComments, err = ts.ListGuestComments(ctx, &store.FindGuestComment{IPHash: &ipHash})
+	require.NoError(t, err)
+	guestComment, err := ts.UpdateGuestComment(ctx, &store.UpdateGuestComment{ID: guestComments[0].ID, Status: &approved})
+	require.NoError(t, err)
+	require.Equal(t, store.GuestCommentApproved, guestComment.Status)
+	guestComments, err = ts.ListGuestComments(ctx, &store.FindGuestComment{MemoID: &memo.ID, Status: &approved})
+	require.NoError(t, err)
+	require.Len(t, guestComments, 1)
+	require.Equal(t, "bob", guestComments[0].Name)
+
+	require.NoError(t, ts.DeleteGuestComment(ctx, &store.DeleteGuestComment{ID: guestComment.ID}))
+	guestComments, err = ts.ListGuestComments(ctx, &store.FindGuestComment{})
+	require.NoError(t, err)
+	require.Len(t, guestComments, 1)
+	// The comments are deleted with the memo.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}))
+	guestComments, err = ts.ListGuestComments(ctx, &store.FindGuestComment{})
+	require.NoError(t, err)
+	require.Len(t, guestComments, 0)
+	ts.Close()
+}
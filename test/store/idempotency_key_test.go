@@ -0,0 +1,58 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestIdempotencyKeyStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	idempotencyKey, err := ts.CreateIdempotencyKey(ctx, &store.IdempotencyKey{
+		CreatorID:  user.ID,
+		EntityType: "memo",
+		Key:        "test_key",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), idempotencyKey.EntityID)
+	// The same key can't be created twice for the same entity type.
+	_, err = ts.CreateIdempotencyKey(ctx, &store.IdempotencyKey{
+		CreatorID:  user.ID,
+		EntityType: "memo",
+		Key:        "test_key",
+	})
+	require.Error(t, err)
+
+	err = ts.UpdateIdempotencyKey(ctx, &store.UpdateIdempotencyKey{
+		ID:       idempotencyKey.ID,
+		EntityID: 1,
+	})
+	require.NoError(t, err)
+	entityType, key := "memo", "test_key"
+	found, err := ts.GetIdempotencyKey(ctx, &store.FindIdempotencyKey{
+		CreatorID:  &user.ID,
+		EntityType: &entityType,
+		Key:        &key,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Equal(t, int32(1), found.EntityID)
+
+	createdTsBefore := found.CreatedTs + 1
+	err = ts.DeleteIdempotencyKey(ctx, &store.DeleteIdempotencyKey{
+		CreatedTsBefore: &createdTsBefore,
+	})
+	require.NoError(t, err)
+	found, err = ts.GetIdempotencyKey(ctx, &store.FindIdempotencyKey{
+		CreatorID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Nil(t, found)
+	ts.Close()
+}
@@ -0,0 +1,47 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestIncomingWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	incomingWebhook, err := ts.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+		CreatorID:  user.ID,
+		Name:       "test_incoming_webhook",
+		Token:      "test_token",
+		Visibility: store.Protected,
+		Tags:       []string{"alert", "monitoring"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "test_incoming_webhook", incomingWebhook.Name)
+
+	token := "test_token"
+	found, err := ts.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		Token: &token,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Equal(t, user.ID, found.CreatorID)
+	require.Equal(t, store.Protected, found.Visibility)
+	require.Equal(t, []string{"alert", "monitoring"}, found.Tags)
+
+	err = ts.DeleteIncomingWebhook(ctx, &store.DeleteIncomingWebhook{
+		ID: incomingWebhook.ID,
+	})
+	require.NoError(t, err)
+	incomingWebhooks, err := ts.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{
+		CreatorID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(incomingWebhooks))
+	ts.Close()
+}
@@ -0,0 +1,64 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestInvitationStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	invitation, err := ts.CreateInvitation(ctx, &store.Invitation{
+		CreatorID: user.ID,
+		Code:      "code",
+		Role:      store.RoleAdmin,
+		MaxUses:   2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), invitation.UseCount)
+	// The codes are unique.
+	_, err = ts.CreateInvitation(ctx, &store.Invitation{CreatorID: user.ID, Code: "code", Role: store.RoleUser, MaxUses: 1})
+	require.Error(t, err)
+
+	for i := 0; i < 2; i++ {
+		redeemed, err := ts.RedeemInvitation(ctx, "code")
+		require.NoError(t, err)
+		require.Equal(t, int32(i+1), redeemed.UseCount)
+		require.Equal(t, store.RoleAdmin, redeemed.Role)
+	}
+	// The invitation is used up.
+	redeemed, err := ts.RedeemInvitation(ctx, "code")
+	require.NoError(t, err)
+	require.Nil(t, redeemed)
+	redeemed, err = ts.RedeemInvitation(ctx, "unknown")
+	require.NoError(t, err)
+	require.Nil(t, redeemed)
+
+	_, err = ts.CreateInvitation(ctx, &store.Invitation{
+		CreatorID: user.ID,
+		Code:      "expired",
+		Role:      store.RoleUser,
+		MaxUses:   1,
+		ExpiresTs: time.Now().Add(-time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	redeemed, err = ts.RedeemInvitation(ctx, "expired")
+	require.NoError(t, err)
+	require.Nil(t, redeemed)
+
+	invitations, err := ts.ListInvitations(ctx, &store.FindInvitation{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, invitations, 2)
+	require.NoError(t, ts.DeleteInvitation(ctx, &store.DeleteInvitation{ID: invitation.ID}))
+	invitations, err = ts.ListInvitations(ctx, &store.FindInvitation{})
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	ts.Close()
+}
@@ -0,0 +1,47 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestJobStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	job, err := ts.CreateJob(ctx, &store.Job{
+		Kind:          "crosspost.mastodon",
+		Payload:       `{"memoId":1}`,
+		Status:        store.JobPending,
+		MaxAttempts:   8,
+		NextAttemptTs: 1700000000,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, job.ID)
+
+	pendingStatus, before := store.JobPending, int64(1700000000)
+	jobs, err := ts.ListJobs(ctx, &store.FindJob{Status: &pendingStatus, NextAttemptTsBefore: &before})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, `{"memoId":1}`, jobs[0].Payload)
+
+	succeededStatus, updatedTs := store.JobSucceeded, int64(1700000000)
+	require.NoError(t, ts.UpdateJob(ctx, &store.UpdateJob{ID: job.ID, Status: &succeededStatus, UpdatedTs: &updatedTs}))
+	jobs, err = ts.ListJobs(ctx, &store.FindJob{Status: &pendingStatus})
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+
+	// Only the jobs of the status updated before the time are deleted.
+	require.NoError(t, ts.DeleteJobs(ctx, &store.DeleteJob{Status: store.JobSucceeded, UpdatedTsBefore: updatedTs}))
+	job, err = ts.GetJob(ctx, &store.FindJob{ID: &job.ID})
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	require.NoError(t, ts.DeleteJobs(ctx, &store.DeleteJob{Status: store.JobSucceeded, UpdatedTsBefore: updatedTs + 1}))
+	job, err = ts.GetJob(ctx, &store.FindJob{ID: &job.ID})
+	require.NoError(t, err)
+	require.Nil(t, job)
+	ts.Close()
+}
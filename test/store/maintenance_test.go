@@ -0,0 +1,38 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestMaintainDatabase(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-memo",
+		CreatorID:  user.ID,
+		Content:    "test_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	result, err := ts.MaintainDatabase(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Statements)
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+
+	// No statement is started after the window.
+	result, err = ts.MaintainDatabase(ctx, time.Now().Add(-time.Minute))
+	require.ErrorIs(t, err, store.ErrMaintenanceWindowEnded)
+	require.Empty(t, result.Statements)
+	ts.Close()
+}
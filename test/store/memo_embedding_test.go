@@ -0,0 +1,61 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoEmbeddingStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "embedded-memo",
+		CreatorID:  user.ID,
+		Content:    "Notes about gardening",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	_, err = ts.UpsertMemoEmbedding(ctx, &store.MemoEmbedding{
+		MemoID:    memo.ID,
+		Model:     "old-model",
+		Embedding: []float32{1, 2},
+		UpdatedTs: 1700000000,
+	})
+	require.NoError(t, err)
+	// The embedding of a memo is replaced.
+	_, err = ts.UpsertMemoEmbedding(ctx, &store.MemoEmbedding{
+		MemoID:    memo.ID,
+		Model:     "new-model",
+		Embedding: []float32{0.5, -0.25, 3},
+		UpdatedTs: 1700000001,
+	})
+	require.NoError(t, err)
+
+	embeddings, err := ts.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoEmbedding{
+		{MemoID: memo.ID, Model: "new-model", Embedding: []float32{0.5, -0.25, 3}, UpdatedTs: 1700000001},
+	}, embeddings)
+	model := "old-model"
+	embeddings, err = ts.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{Model: &model})
+	require.NoError(t, err)
+	require.Len(t, embeddings, 0)
+	otherUserID := user.ID + 1
+	embeddings, err = ts.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{CreatorID: &otherUserID})
+	require.NoError(t, err)
+	require.Len(t, embeddings, 0)
+
+	// The embeddings of the deleted memos are deleted.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}))
+	embeddings, err = ts.ListMemoEmbeddings(ctx, &store.FindMemoEmbedding{})
+	require.NoError(t, err)
+	require.Len(t, embeddings, 0)
+	ts.Close()
+}
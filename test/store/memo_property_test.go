@@ -0,0 +1,73 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoPropertyStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "gist-memo",
+		CreatorID:  user.ID,
+		Content:    "```go\npackage main\n```",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	_, err = ts.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      "gist_url",
+		Value:     "https://gist.github.com/bob/1",
+		UpdatedTs: 1700000000,
+	})
+	require.NoError(t, err)
+	// The property of a name is updated.
+	_, err = ts.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      "gist_url",
+		Value:     "https://gist.github.com/bob/2",
+		UpdatedTs: 1700000001,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID:    memo.ID,
+		Name:      "other",
+		Value:     "value",
+		UpdatedTs: 1700000000,
+	})
+	require.NoError(t, err)
+
+	properties, err := ts.ListMemoProperties(ctx, &store.FindMemoProperty{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoProperty{
+		{MemoID: memo.ID, Name: "gist_url", Value: "https://gist.github.com/bob/2", UpdatedTs: 1700000001},
+		{MemoID: memo.ID, Name: "other", Value: "value", UpdatedTs: 1700000000},
+	}, properties)
+
+	value := "https://gist.github.com/bob/2"
+	properties, err = ts.ListMemoProperties(ctx, &store.FindMemoProperty{Value: &value})
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	require.Equal(t, "gist_url", properties[0].Name)
+
+	require.NoError(t, ts.DeleteMemoProperty(ctx, &store.DeleteMemoProperty{MemoID: memo.ID, Name: "other"}))
+	name := "other"
+	property, err := ts.GetMemoProperty(ctx, &store.FindMemoProperty{MemoID: &memo.ID, Name: &name})
+	require.NoError(t, err)
+	require.Nil(t, property)
+
+	// The properties of the deleted memos are deleted.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}))
+	properties, err = ts.ListMemoProperties(ctx, &store.FindMemoProperty{})
+	require.NoError(t, err)
+	require.Len(t, properties, 0)
+	ts.Close()
+}
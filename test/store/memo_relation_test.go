@@ -58,5 +58,26 @@ func TestMemoRelationStore(t *testing.T) {
 	}
 	_, err = ts.UpsertMemoRelation(ctx, commentRelation)
 	require.NoError(t, err)
+	// Custom relation.
+	customRelation := &store.MemoRelation{
+		MemoID:        memo.ID,
+		RelatedMemoID: relatedMemo.ID,
+		Type:          store.MemoRelationType("blocks"),
+	}
+	_, err = ts.UpsertMemoRelation(ctx, customRelation)
+	require.NoError(t, err)
+	memoRelations, err := ts.ListMemoRelations(ctx, &store.FindMemoRelation{
+		MemoID: &memo.ID,
+		Type:   &customRelation.Type,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memoRelations))
+	require.Equal(t, relatedMemo.ID, memoRelations[0].RelatedMemoID)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        memo.ID,
+		RelatedMemoID: relatedMemo.ID,
+		Type:          store.MemoRelationType("Bad Type"),
+	})
+	require.Error(t, err)
 	ts.Close()
 }
@@ -0,0 +1,78 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoReportStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	reporter, err := ts.CreateUser(ctx, &store.User{
+		Username: "reporter",
+		Role:     store.RoleUser,
+	})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "reported-memo",
+		CreatorID:  user.ID,
+		Content:    "reported memo content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "other-memo",
+		CreatorID:  user.ID,
+		Content:    "other memo content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	report, err := ts.CreateMemoReport(ctx, &store.MemoReport{
+		ReporterID: reporter.ID,
+		MemoID:     memo.ID,
+		Reason:     "spam",
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.MemoReportOpen, report.Status)
+	// A user reports a memo once.
+	_, err = ts.CreateMemoReport(ctx, &store.MemoReport{
+		ReporterID: reporter.ID,
+		MemoID:     memo.ID,
+	})
+	require.Error(t, err)
+
+	hiddenStatus := store.MemoReportHidden
+	resolution := "removed"
+	report, err = ts.UpdateMemoReport(ctx, &store.UpdateMemoReport{
+		ID:         report.ID,
+		Status:     &hiddenStatus,
+		ResolverID: &user.ID,
+		Resolution: &resolution,
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.MemoReportHidden, report.Status)
+	require.Equal(t, user.ID, report.ResolverID)
+	reports, err := ts.ListMemoReports(ctx, &store.FindMemoReport{
+		MemoCreatorID: &user.ID,
+		Status:        &hiddenStatus,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reports))
+
+	// The hidden memo is only listed to its creator.
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{ExcludeHidden: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memos))
+	require.Equal(t, "other-memo", memos[0].UID)
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{ExcludeHidden: true, HiddenVisibleToID: &user.ID})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(memos))
+	ts.Close()
+}
@@ -0,0 +1,120 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoSlugStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	otherUser, err := ts.CreateUser(ctx, &store.User{
+		Username: "other",
+		Role:     store.RoleUser,
+		Email:    "other@test.com",
+		Nickname: "other_nickname",
+	})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "slug-memo",
+		CreatorID:  user.ID,
+		Content:    "Hello",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	otherMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "other-slug-memo",
+		CreatorID:  otherUser.ID,
+		Content:    "Hello",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	memoSlug, err := ts.UpsertMemoSlug(ctx, &store.MemoSlug{
+		MemoID:    memo.ID,
+		CreatorID: user.ID,
+		Slug:      "hello",
+		UpdatedTs: 1700000000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), memoSlug.ClickCount)
+	_, err = ts.UpsertMemoSlug(ctx, &store.MemoSlug{
+		MemoID:    otherMemo.ID,
+		CreatorID: otherUser.ID,
+		Slug:      "hello",
+		UpdatedTs: 1700000000,
+	})
+	require.NoError(t, err)
+
+	// The slugs are found among the memos of their creators.
+	slug := "hello"
+	memoSlug, err = ts.GetMemoSlug(ctx, &store.FindMemoSlug{Slug: &slug, CreatorID: &otherUser.ID})
+	require.NoError(t, err)
+	require.Equal(t, otherMemo.ID, memoSlug.MemoID)
+
+	require.NoError(t, ts.IncreaseMemoSlugClickCount(ctx, memo.ID))
+	require.NoError(t, ts.IncreaseMemoSlugClickCount(ctx, memo.ID))
+	// The click count is kept when the slug is changed.
+	memoSlug, err = ts.UpsertMemoSlug(ctx, &store.MemoSlug{
+		MemoID:    memo.ID,
+		CreatorID: user.ID,
+		Slug:      "hello-world",
+		UpdatedTs: 1700000001,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), memoSlug.ClickCount)
+	memoSlug, err = ts.GetMemoSlug(ctx, &store.FindMemoSlug{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, &store.MemoSlug{MemoID: memo.ID, CreatorID: user.ID, Slug: "hello-world", ClickCount: 2, UpdatedTs: 1700000001}, memoSlug)
+
+	// The slugs are unique among the memos of their creators.
+	secondMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "second-slug-memo",
+		CreatorID:  user.ID,
+		Content:    "Hello",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoSlug(ctx, &store.MemoSlug{
+		MemoID:    secondMemo.ID,
+		CreatorID: user.ID,
+		Slug:      "hello-world",
+		UpdatedTs: 1700000002,
+	})
+	require.ErrorIs(t, err, store.ErrMemoSlugExists)
+
+	// The slug moves along with the memo to another creator, unless the creator has it already.
+	_, err = ts.UpsertMemoSlug(ctx, &store.MemoSlug{
+		MemoID:    secondMemo.ID,
+		CreatorID: user.ID,
+		Slug:      "hello",
+		UpdatedTs: 1700000002,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatorID: &otherUser.ID}))
+	memoSlug, err = ts.GetMemoSlug(ctx, &store.FindMemoSlug{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, otherUser.ID, memoSlug.CreatorID)
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: secondMemo.ID, CreatorID: &otherUser.ID}))
+	memoSlug, err = ts.GetMemoSlug(ctx, &store.FindMemoSlug{MemoID: &secondMemo.ID})
+	require.NoError(t, err)
+	require.Nil(t, memoSlug)
+
+	require.NoError(t, ts.DeleteMemoSlug(ctx, &store.DeleteMemoSlug{MemoID: otherMemo.ID}))
+	memoSlug, err = ts.GetMemoSlug(ctx, &store.FindMemoSlug{MemoID: &otherMemo.ID})
+	require.NoError(t, err)
+	require.Nil(t, memoSlug)
+
+	// The slugs of the deleted memos are deleted.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}))
+	memoSlugs, err := ts.ListMemoSlugs(ctx, &store.FindMemoSlug{})
+	require.NoError(t, err)
+	require.Len(t, memoSlugs, 0)
+	ts.Close()
+}
@@ -2,10 +2,13 @@ package teststore
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -82,3 +85,230 @@ func TestDeleteMemoStore(t *testing.T) {
 	require.NoError(t, err)
 	ts.Close()
 }
+
+func TestMemoStoreRunInTx(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	err = ts.RunInTx(ctx, func(txStore *store.Store) error {
+		if _, err := txStore.CreateMemo(ctx, &store.Memo{
+			UID:        "test-rolled-back-memo",
+			CreatorID:  user.ID,
+			Content:    "test_content",
+			Visibility: store.Public,
+		}); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	memoList, err := ts.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(memoList))
+
+	err = ts.RunInTx(ctx, func(txStore *store.Store) error {
+		_, err := txStore.CreateMemo(ctx, &store.Memo{
+			UID:        "test-committed-memo",
+			CreatorID:  user.ID,
+			Content:    "test_content",
+			Visibility: store.Public,
+		})
+		return err
+	})
+	require.NoError(t, err)
+	memoList, err = ts.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memoList))
+
+	// The memos are deleted and vacuumed in the transaction.
+	err = ts.RunInTx(ctx, func(txStore *store.Store) error {
+		return txStore.DeleteMemo(ctx, &store.DeleteMemo{
+			ID: memoList[0].ID,
+		})
+	})
+	require.NoError(t, err)
+	memoList, err = ts.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(memoList))
+	ts.Close()
+}
+
+func TestMemoListFilter(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	taskMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-task-memo",
+		CreatorID:  user.ID,
+		Content:    "- [ ] buy milk\n- [x] read https://usememos.com",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	imageMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-image-memo",
+		CreatorID:  user.ID,
+		Content:    "photo 2024",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       "test-image-resource",
+		CreatorID: user.ID,
+		Filename:  "photo.png",
+		Type:      "image/png",
+		MemoID:    &imageMemo.ID,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoOrganizer(ctx, &store.MemoOrganizer{
+		MemoID: imageMemo.ID,
+		UserID: user.ID,
+		Pinned: true,
+	})
+	require.NoError(t, err)
+
+	truthy, falsy := true, false
+	imageType, contentRegex := "image/", "[0-9]{4}$"
+	for _, test := range []struct {
+		find *store.FindMemo
+		want []int32
+	}{
+		{&store.FindMemo{HasIncompleteTasks: &truthy}, []int32{taskMemo.ID}},
+		{&store.FindMemo{HasTaskList: &falsy}, []int32{imageMemo.ID}},
+		{&store.FindMemo{HasLink: &truthy}, []int32{taskMemo.ID}},
+		{&store.FindMemo{HasResource: &truthy}, []int32{imageMemo.ID}},
+		{&store.FindMemo{ResourceType: &imageType}, []int32{imageMemo.ID}},
+		{&store.FindMemo{Pinned: &falsy}, []int32{taskMemo.ID}},
+		{&store.FindMemo{ContentRegex: &contentRegex}, []int32{imageMemo.ID}},
+		{&store.FindMemo{HasRelation: &truthy}, []int32{}},
+	} {
+		memoList, err := ts.ListMemos(ctx, test.find)
+		require.NoError(t, err)
+		memoIDList := []int32{}
+		for _, memo := range memoList {
+			memoIDList = append(memoIDList, memo.ID)
+		}
+		require.Equal(t, test.want, memoIDList)
+	}
+	ts.Close()
+}
+
+func TestMemoListExplore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser})
+	require.NoError(t, err)
+	memoIDs := map[string]int32{}
+	for _, memo := range []*store.Memo{
+		{UID: "plain", CreatorID: user.ID, Content: "hello #note"},
+		{UID: "draft", CreatorID: user.ID, Content: "idea #draft"},
+		{UID: "child-draft", CreatorID: user.ID, Content: "#draft/idea later"},
+		{UID: "drafts", CreatorID: user.ID, Content: "#drafts are fine"},
+		{UID: "other", CreatorID: other.ID, Content: "memo of other"},
+	} {
+		memo.Visibility = store.Public
+		memo, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+		memoIDs[memo.UID] = memo.ID
+	}
+	listExploreMemoUIDs := func() []string {
+		find := &store.FindMemo{OrderBy: store.MemoOrderByCreatedTs, OrderAsc: true}
+		require.NoError(t, ts.ApplyExploreSetting(ctx, find))
+		memoList, err := ts.ListMemos(ctx, find)
+		require.NoError(t, err)
+		uids := []string{}
+		for _, memo := range memoList {
+			uids = append(uids, memo.UID)
+		}
+		return uids
+	}
+	require.Equal(t, []string{"plain", "draft", "child-draft", "drafts", "other"}, listExploreMemoUIDs())
+
+	_, err = ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_EXPLORE,
+		Value: &storepb.WorkspaceSetting_Explore{
+			Explore: &storepb.WorkspaceExploreSetting{ExcludedTags: []string{"draft"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"plain", "drafts", "other"}, listExploreMemoUIDs())
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: other.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_EXPLORE_OPT_OUT,
+		Value:  &storepb.UserSetting_ExploreOptOut{ExploreOptOut: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"plain", "drafts"}, listExploreMemoUIDs())
+
+	_, err = ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_EXPLORE,
+		Value: &storepb.WorkspaceSetting_Explore{
+			Explore: &storepb.WorkspaceExploreSetting{ExcludedUserIds: []int32{other.ID}, RequireApproval: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{}, listExploreMemoUIDs())
+	_, err = ts.UpsertMemoProperty(ctx, &store.MemoProperty{
+		MemoID: memoIDs["draft"],
+		Name:   store.MemoExploreApprovedProperty,
+		Value:  fmt.Sprint(user.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"draft"}, listExploreMemoUIDs())
+	ts.Close()
+}
+
+func TestMemoListOrderBy(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	longMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "long-memo",
+		CreatorID:  user.ID,
+		Content:    "a long memo content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	shortMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "short-memo",
+		CreatorID:  user.ID,
+		Content:    "short",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertReaction(ctx, &storepb.Reaction{
+		CreatorId:    user.ID,
+		ContentId:    fmt.Sprintf("memos/%d", longMemo.ID),
+		ReactionType: storepb.Reaction_HEART,
+	})
+	require.NoError(t, err)
+
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{
+		OrderBy:  store.MemoOrderByContentLength,
+		OrderAsc: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int32{shortMemo.ID, longMemo.ID}, []int32{memos[0].ID, memos[1].ID})
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{
+		OrderBy: store.MemoOrderByReactionCount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int32{longMemo.ID, shortMemo.ID}, []int32{memos[0].ID, memos[1].ID})
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{
+		OrderAsc: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int32{longMemo.ID, shortMemo.ID}, []int32{memos[0].ID, memos[1].ID})
+	ts.Close()
+}
@@ -63,3 +63,51 @@ func TestResourceStore(t *testing.T) {
 	require.NoError(t, err)
 	ts.Close()
 }
+
+func TestResourceExtractedTextSearch(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-extracted-text",
+		CreatorID:  user.ID,
+		Content:    "whiteboard",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	resource, err := ts.CreateResource(ctx, &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+		Filename:  "whiteboard.png",
+		Blob:      []byte("test"),
+		Type:      "image/png",
+		Size:      4,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resource.ExtractedText)
+
+	extractedText := "quarterly roadmap"
+	_, err = ts.UpdateResource(ctx, &store.UpdateResource{
+		ID:            resource.ID,
+		ExtractedText: &extractedText,
+	})
+	require.NoError(t, err)
+	resource, err = ts.GetResource(ctx, &store.FindResource{ID: &resource.ID})
+	require.NoError(t, err)
+	require.Equal(t, extractedText, *resource.ExtractedText)
+
+	memoList, err := ts.ListMemos(ctx, &store.FindMemo{
+		ContentSearch:      []string{"roadmap"},
+		SearchResourceText: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memoList))
+	memoList, err = ts.ListMemos(ctx, &store.FindMemo{
+		ContentSearch: []string{"roadmap"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(memoList))
+	ts.Close()
+}
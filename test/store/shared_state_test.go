@@ -0,0 +1,117 @@
+package teststore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+	"github.com/usememos/memos/test"
+)
+
+// memorySharedState is the shared state of the replicas in memory, the TTLs are ignored.
+type memorySharedState struct {
+	mutex  sync.Mutex
+	values map[string][]byte
+}
+
+func (s *memorySharedState) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+func (s *memorySharedState) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *memorySharedState) Add(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.values[key]; !ok {
+		s.values[key] = value
+	}
+	return nil
+}
+
+func (s *memorySharedState) Delete(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+func (*memorySharedState) Increment(context.Context, string, time.Duration) (int64, time.Duration, error) {
+	return 0, 0, nil
+}
+
+func (s *memorySharedState) TryLock(_ context.Context, key string, _ time.Duration) (func(context.Context) error, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.values[key]; ok {
+		return nil, false, nil
+	}
+	s.values[key] = []byte{}
+	return func(ctx context.Context) error { return s.Delete(ctx, key) }, true, nil
+}
+
+func TestSharedStateCache(t *testing.T) {
+	ctx := context.Background()
+	profile := test.GetTestingProfile(t)
+	dbDriver, err := db.NewDBDriver(profile)
+	require.NoError(t, err)
+	resetTestingDB(ctx, profile, dbDriver)
+	require.NoError(t, dbDriver.Migrate(ctx))
+	// The replicas share the database and the shared state, but not their local caches.
+	shared := &memorySharedState{values: map[string][]byte{}}
+	replica, otherReplica := store.New(dbDriver, profile), store.New(dbDriver, profile)
+	replica.UseSharedState(shared)
+	otherReplica.UseSharedState(shared)
+	defer replica.Close()
+
+	user, err := createTestingHostUser(ctx, replica)
+	require.NoError(t, err)
+	_, err = replica.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_ACCESS_TOKENS,
+		Value: &storepb.UserSetting_AccessTokens{
+			AccessTokens: &storepb.AccessTokensUserSetting{
+				AccessTokens: []*storepb.AccessTokensUserSetting_AccessToken{{AccessToken: "token"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	accessTokens, err := otherReplica.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, accessTokens, 1)
+
+	// The access token revoked on a replica is revoked on the others.
+	_, err = replica.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_ACCESS_TOKENS,
+		Value:  &storepb.UserSetting_AccessTokens{AccessTokens: &storepb.AccessTokensUserSetting{}},
+	})
+	require.NoError(t, err)
+	accessTokens, err = otherReplica.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, accessTokens)
+
+	nickname := "renamed"
+	_, err = otherReplica.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, Nickname: &nickname})
+	require.NoError(t, err)
+	user, err = replica.GetUser(ctx, &store.FindUser{ID: &user.ID})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", user.Nickname)
+
+	require.True(t, replica.ClaimJobRun(ctx, "job", time.Minute))
+	require.False(t, otherReplica.ClaimJobRun(ctx, "job", time.Minute))
+}
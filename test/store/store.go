@@ -46,6 +46,9 @@ func resetTestingDB(ctx context.Context, profile *profile.Profile, dbDriver stor
 		DROP TABLE IF EXISTS idp;
 		DROP TABLE IF EXISTS inbox;
 		DROP TABLE IF EXISTS webhook;
+		DROP TABLE IF EXISTS webhook_delivery;
+		DROP TABLE IF EXISTS incoming_webhook;
+		DROP TABLE IF EXISTS idempotency_key;
 		DROP TABLE IF EXISTS reaction;`)
 		if err != nil {
 			fmt.Printf("failed to reset testing db, error: %+v\n", err)
@@ -67,6 +70,9 @@ func resetTestingDB(ctx context.Context, profile *profile.Profile, dbDriver stor
 		DROP TABLE IF EXISTS idp CASCADE;
 		DROP TABLE IF EXISTS inbox CASCADE;
 		DROP TABLE IF EXISTS webhook CASCADE;
+		DROP TABLE IF EXISTS webhook_delivery CASCADE;
+		DROP TABLE IF EXISTS incoming_webhook CASCADE;
+		DROP TABLE IF EXISTS idempotency_key CASCADE;
 		DROP TABLE IF EXISTS reaction CASCADE;`)
 		if err != nil {
 			fmt.Printf("failed to reset testing db, error: %+v\n", err)
@@ -0,0 +1,48 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestTaskRunStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	_, err := ts.UpsertTaskRun(ctx, &store.TaskRun{
+		Name:      "resource_gc",
+		Status:    store.TaskRunning,
+		StartedTs: 1700000000,
+	})
+	require.NoError(t, err)
+	// A task has its last run only.
+	_, err = ts.UpsertTaskRun(ctx, &store.TaskRun{
+		Name:       "resource_gc",
+		Status:     store.TaskFailed,
+		StartedTs:  1700000000,
+		FinishedTs: 1700000060,
+		Error:      "storage unavailable",
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertTaskRun(ctx, &store.TaskRun{
+		Name:       "digests",
+		Status:     store.TaskSucceeded,
+		StartedTs:  1700000000,
+		FinishedTs: 1700000001,
+	})
+	require.NoError(t, err)
+
+	taskRuns, err := ts.ListTaskRuns(ctx, &store.FindTaskRun{})
+	require.NoError(t, err)
+	require.Len(t, taskRuns, 2)
+	name := "resource_gc"
+	taskRun, err := ts.GetTaskRun(ctx, &store.FindTaskRun{Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, store.TaskFailed, taskRun.Status)
+	require.Equal(t, int64(1700000060), taskRun.FinishedTs)
+	require.Equal(t, "storage unavailable", taskRun.Error)
+	ts.Close()
+}
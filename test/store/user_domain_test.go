@@ -0,0 +1,51 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestUserDomainStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	domain, err := ts.CreateUserDomain(ctx, &store.UserDomain{
+		UserID:            user.ID,
+		Hostname:          "notes.example.com",
+		VerificationToken: "token",
+	})
+	require.NoError(t, err)
+	require.Zero(t, domain.VerifiedTs)
+	// The hostnames are unique.
+	_, err = ts.CreateUserDomain(ctx, &store.UserDomain{
+		UserID:            user.ID,
+		Hostname:          "notes.example.com",
+		VerificationToken: "another token",
+	})
+	require.Error(t, err)
+
+	verified := true
+	domains, err := ts.ListUserDomains(ctx, &store.FindUserDomain{Verified: &verified})
+	require.NoError(t, err)
+	require.Empty(t, domains)
+	verifiedTs := int64(1700000000)
+	domain, err = ts.UpdateUserDomain(ctx, &store.UpdateUserDomain{ID: domain.ID, VerifiedTs: &verifiedTs})
+	require.NoError(t, err)
+	require.Equal(t, verifiedTs, domain.VerifiedTs)
+	hostname := "notes.example.com"
+	domain, err = ts.GetUserDomain(ctx, &store.FindUserDomain{Hostname: &hostname, Verified: &verified})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, domain.UserID)
+
+	require.NoError(t, ts.DeleteUserDomain(ctx, &store.DeleteUserDomain{ID: domain.ID}))
+	domains, err = ts.ListUserDomains(ctx, &store.FindUserDomain{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, domains)
+	ts.Close()
+}
@@ -0,0 +1,110 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestUserGroupStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	member, err := ts.CreateUser(ctx, &store.User{
+		Username: "member",
+		Role:     store.RoleUser,
+	})
+	require.NoError(t, err)
+	outsider, err := ts.CreateUser(ctx, &store.User{
+		Username: "outsider",
+		Role:     store.RoleUser,
+	})
+	require.NoError(t, err)
+
+	group, err := ts.CreateUserGroup(ctx, &store.UserGroup{
+		CreatorID:   user.ID,
+		Name:        "design",
+		Description: "the design team",
+	})
+	require.NoError(t, err)
+	// The group names are unique.
+	_, err = ts.CreateUserGroup(ctx, &store.UserGroup{
+		CreatorID: member.ID,
+		Name:      "design",
+	})
+	require.Error(t, err)
+	_, err = ts.UpsertUserGroupMember(ctx, &store.UserGroupMember{GroupID: group.ID, UserID: user.ID, Role: store.UserGroupRoleOwner})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserGroupMember(ctx, &store.UserGroupMember{GroupID: group.ID, UserID: member.ID, Role: store.UserGroupRoleOwner})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserGroupMember(ctx, &store.UserGroupMember{GroupID: group.ID, UserID: member.ID, Role: store.UserGroupRoleMember})
+	require.NoError(t, err)
+	members, err := ts.ListUserGroupMembers(ctx, &store.FindUserGroupMember{GroupID: &group.ID})
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	membership, err := ts.GetUserGroupMember(ctx, group.ID, member.ID)
+	require.NoError(t, err)
+	require.Equal(t, store.UserGroupRoleMember, membership.Role)
+	groups, err := ts.ListUserGroups(ctx, &store.FindUserGroup{MemberID: &outsider.ID})
+	require.NoError(t, err)
+	require.Empty(t, groups)
+
+	name := "product"
+	group, err = ts.UpdateUserGroup(ctx, &store.UpdateUserGroup{ID: group.ID, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "product", group.Name)
+	require.Equal(t, "the design team", group.Description)
+
+	groupMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "group-memo",
+		CreatorID:  user.ID,
+		Content:    "group memo content",
+		Visibility: store.Group,
+		GroupID:    &group.ID,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "protected-memo",
+		CreatorID:  user.ID,
+		Content:    "protected memo content",
+		Visibility: store.Protected,
+	})
+	require.NoError(t, err)
+	for userID, expected := range map[int32]bool{user.ID: true, member.ID: true, outsider.ID: false, 0: false} {
+		visible, err := ts.IsMemoVisibleTo(ctx, groupMemo, userID)
+		require.NoError(t, err)
+		require.Equal(t, expected, visible, userID)
+	}
+
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{GroupID: &group.ID})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	require.Equal(t, group.ID, *memos[0].GroupID)
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{GroupMemberID: &member.ID})
+	require.NoError(t, err)
+	require.Len(t, memos, 2)
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{GroupMemberID: &outsider.ID})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	require.Equal(t, store.Protected, memos[0].Visibility)
+
+	require.NoError(t, ts.DeleteUserGroupMember(ctx, &store.DeleteUserGroupMember{GroupID: group.ID, UserID: member.ID}))
+	visible, err := ts.IsMemoVisibleTo(ctx, groupMemo, member.ID)
+	require.NoError(t, err)
+	require.False(t, visible)
+
+	// The memos of a deleted group become private to their creators.
+	require.NoError(t, ts.DeleteUserGroup(ctx, &store.DeleteUserGroup{ID: group.ID}))
+	groupMemo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &groupMemo.ID})
+	require.NoError(t, err)
+	require.Equal(t, store.Private, groupMemo.Visibility)
+	require.Nil(t, groupMemo.GroupID)
+	members, err = ts.ListUserGroupMembers(ctx, &store.FindUserGroupMember{GroupID: &group.ID})
+	require.NoError(t, err)
+	require.Empty(t, members)
+	ts.Close()
+}
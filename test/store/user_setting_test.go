@@ -2,7 +2,9 @@ package teststore
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -26,3 +28,185 @@ func TestUserSettingStore(t *testing.T) {
 	require.Equal(t, 1, len(list))
 	ts.Close()
 }
+
+func TestUserQuota(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_QUOTA,
+		Value: &storepb.UserSetting_Quota{
+			Quota: &storepb.QuotaUserSetting{
+				MaxMemoCount:    1,
+				MaxResourceSize: 100,
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "first-memo",
+		CreatorID:  user.ID,
+		Content:    "first memo",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "second-memo",
+		CreatorID:  user.ID,
+		Content:    "second memo",
+		Visibility: store.Private,
+	})
+	require.ErrorIs(t, err, store.ErrQuotaExceeded)
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       "large-resource",
+		CreatorID: user.ID,
+		Filename:  "large.txt",
+		Blob:      make([]byte, 101),
+		Type:      "text/plain",
+		Size:      101,
+	})
+	require.ErrorIs(t, err, store.ErrQuotaExceeded)
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       "small-resource",
+		CreatorID: user.ID,
+		Filename:  "small.txt",
+		Blob:      make([]byte, 60),
+		Type:      "text/plain",
+		Size:      60,
+	})
+	require.NoError(t, err)
+	memoCount, resourceSize, err := ts.GetUserUsage(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), memoCount)
+	require.Equal(t, int64(60), resourceSize)
+	// The size of the existing resources counts towards the quota.
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       "another-resource",
+		CreatorID: user.ID,
+		Filename:  "another.txt",
+		Blob:      make([]byte, 50),
+		Type:      "text/plain",
+		Size:      50,
+	})
+	require.ErrorIs(t, err, store.ErrQuotaExceeded)
+	ts.Close()
+}
+
+func TestUserWebPushSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	for _, endpoint := range []string{"https://push.example.com/1", "https://push.example.com/2", "https://push.example.com/1"} {
+		err := ts.UpsertUserWebPushSubscription(ctx, user.ID, &storepb.WebPushSubscriptionsUserSetting_Subscription{
+			Endpoint: endpoint,
+			P256Dh:   "key",
+			Auth:     "auth",
+		})
+		require.NoError(t, err)
+	}
+	subscriptions, err := ts.GetUserWebPushSubscriptions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(subscriptions))
+	require.Equal(t, "https://push.example.com/2", subscriptions[0].Endpoint)
+	require.Equal(t, "https://push.example.com/1", subscriptions[1].Endpoint)
+
+	require.NoError(t, ts.RemoveUserWebPushSubscription(ctx, user.ID, "https://push.example.com/2"))
+	subscriptions, err = ts.GetUserWebPushSubscriptions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(subscriptions))
+	ts.Close()
+}
+
+func TestUserProfile(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	profile, err := ts.GetUserProfile(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, profile.Bio)
+
+	memoIDs := []int32{}
+	for i, visibility := range []store.Visibility{store.Public, store.Private, store.Public} {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    "memo",
+			Visibility: visibility,
+		})
+		require.NoError(t, err)
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_PROFILE,
+		Value: &storepb.UserSetting_Profile{
+			Profile: &storepb.ProfileUserSetting{
+				Bio:           "bio",
+				PinnedMemoIds: []int32{memoIDs[2], memoIDs[1], memoIDs[0]},
+			},
+		},
+	})
+	require.NoError(t, err)
+	profile, err = ts.GetUserProfile(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "bio", profile.Bio)
+	// The private memo is skipped, and the order of the pinned memos is kept.
+	memos, err := ts.ListProfilePinnedMemos(ctx, user.ID, profile.PinnedMemoIds)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(memos))
+	require.Equal(t, memoIDs[2], memos[0].ID)
+	require.Equal(t, memoIDs[0], memos[1].ID)
+	ts.Close()
+}
+
+func TestUserLocation(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	location, err := ts.GetUserLocation(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, location)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_TIMEZONE,
+		Value:  &storepb.UserSetting_Timezone{Timezone: "Europe/Berlin"},
+	})
+	require.NoError(t, err)
+	location, err = ts.GetUserLocation(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Berlin", location.String())
+	ts.Close()
+}
+
+func TestUserLocale(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	locale, err := ts.GetUserLocale(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "", locale)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+		Name:  "customized-profile",
+		Value: `{"name":"memos","locale":"de"}`,
+	})
+	require.NoError(t, err)
+	locale, err = ts.GetUserLocale(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "de", locale)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_LOCALE,
+		Value:  &storepb.UserSetting_Locale{Locale: "fr"},
+	})
+	require.NoError(t, err)
+	locale, err = ts.GetUserLocale(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "fr", locale)
+	ts.Close()
+}
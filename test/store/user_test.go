@@ -2,11 +2,13 @@ package teststore
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -54,3 +56,133 @@ func createTestingHostUser(ctx context.Context, ts *store.Store) (*store.User, e
 	user, err := ts.CreateUser(ctx, userCreate)
 	return user, err
 }
+
+func TestUserCacheStats(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.GetUser(ctx, &store.FindUser{ID: &user.ID})
+	require.NoError(t, err)
+	missingID := user.ID + 1
+	_, err = ts.GetUser(ctx, &store.FindUser{ID: &missingID})
+	require.NoError(t, err)
+	stats := ts.GetCacheStats()["user"]
+	require.NotNil(t, stats)
+	require.Equal(t, int64(1), stats.Hits)
+	require.Equal(t, int64(1), stats.Misses)
+	ts.Close()
+}
+
+// TestUserCacheRunInTx tests the users changed in a transaction aren't served from the stale cache afterwards.
+func TestUserCacheRunInTx(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.GetUser(ctx, &store.FindUser{ID: &user.ID})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		nickname string
+		err      error
+		want     string
+	}{
+		{nickname: "rolled_back", err: fmt.Errorf("rollback"), want: user.Nickname},
+		{nickname: "committed", want: "committed"},
+	} {
+		err = ts.RunInTx(ctx, func(txStore *store.Store) error {
+			if _, err := txStore.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, Nickname: &test.nickname}); err != nil {
+				return err
+			}
+			return test.err
+		})
+		require.Equal(t, test.err, err)
+		cached, err := ts.GetUser(ctx, &store.FindUser{ID: &user.ID})
+		require.NoError(t, err)
+		require.Equal(t, test.want, cached.Nickname)
+	}
+	ts.Close()
+}
+
+func TestDeleteUserVacuum(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	host, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "leaving",
+		Role:     store.RoleUser,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_USER_SETTING_DELETION_TS,
+		Value:  &storepb.UserSetting_DeletionTs{DeletionTs: 1700000000},
+	})
+	require.NoError(t, err)
+	userSettings, err := ts.ListUserSettings(ctx, &store.FindUserSetting{Key: storepb.UserSettingKey_USER_SETTING_DELETION_TS})
+	require.NoError(t, err)
+	require.Len(t, userSettings, 1)
+	require.Equal(t, int64(1700000000), userSettings[0].GetDeletionTs())
+
+	for _, creatorID := range []int32{host.ID, user.ID} {
+		_, err = ts.UpsertReaction(ctx, &storepb.Reaction{
+			CreatorId:    creatorID,
+			ContentId:    "memos/1",
+			ReactionType: storepb.Reaction_HEART,
+		})
+		require.NoError(t, err)
+		_, err = ts.CreateActivity(ctx, &store.Activity{
+			CreatorID: creatorID,
+			Type:      store.ActivityTypeMemoComment,
+			Level:     store.ActivityLevelInfo,
+			Payload:   &storepb.ActivityPayload{},
+		})
+		require.NoError(t, err)
+		_, err = ts.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+			CreatorID:  creatorID,
+			Name:       "incoming",
+			Token:      fmt.Sprintf("token-%d", creatorID),
+			Visibility: store.Private,
+		})
+		require.NoError(t, err)
+	}
+	webhook, err := ts.CreateWebhook(ctx, &storepb.Webhook{
+		CreatorId: user.ID,
+		Name:      "test_webhook",
+		Url:       "https://example.com",
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID:    webhook.Id,
+		ActivityType: "memos.memo.created",
+		Payload:      "{}",
+		Status:       store.WebhookDeliveryPending,
+	})
+	require.NoError(t, err)
+
+	// The data of the deleted user is vacuumed with them, the data of the others is kept.
+	require.NoError(t, ts.DeleteUser(ctx, &store.DeleteUser{ID: user.ID}))
+	userSettings, err = ts.ListUserSettings(ctx, &store.FindUserSetting{Key: storepb.UserSettingKey_USER_SETTING_DELETION_TS})
+	require.NoError(t, err)
+	require.Empty(t, userSettings)
+	for creatorID, expected := range map[int32]int{host.ID: 1, user.ID: 0} {
+		reactions, err := ts.ListReactions(ctx, &store.FindReaction{CreatorID: &creatorID})
+		require.NoError(t, err)
+		require.Len(t, reactions, expected)
+		activities, err := ts.ListActivities(ctx, &store.FindActivity{CreatorID: &creatorID})
+		require.NoError(t, err)
+		require.Len(t, activities, expected)
+		incomingWebhooks, err := ts.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{CreatorID: &creatorID})
+		require.NoError(t, err)
+		require.Len(t, incomingWebhooks, expected)
+	}
+	webhooks, err := ts.ListWebhooks(ctx, &store.FindWebhook{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+	deliveries, err := ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{WebhookID: &webhook.Id})
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+	ts.Close()
+}
@@ -0,0 +1,74 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestVaultFileStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "vault-note",
+		CreatorID:  user.ID,
+		Content:    "vault note content",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	vaultFile, err := ts.CreateVaultFile(ctx, &store.VaultFile{
+		CreatorID: user.ID,
+		UpdatedTs: 1700000000,
+		Path:      "notes/Vault note.md",
+		MemoID:    memo.ID,
+	})
+	require.NoError(t, err)
+	// A path is linked to a file once.
+	_, err = ts.CreateVaultFile(ctx, &store.VaultFile{
+		CreatorID:  user.ID,
+		UpdatedTs:  1700000000,
+		Path:       "notes/Vault note.md",
+		ResourceID: 1,
+	})
+	require.Error(t, err)
+	_, err = ts.CreateVaultFile(ctx, &store.VaultFile{
+		CreatorID:  user.ID,
+		UpdatedTs:  1700000000,
+		Path:       "attachments/image.png",
+		ResourceID: 1,
+	})
+	require.NoError(t, err)
+
+	vaultFiles, err := ts.ListVaultFiles(ctx, &store.FindVaultFile{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, vaultFiles, 2)
+	require.Equal(t, "attachments/image.png", vaultFiles[0].Path)
+
+	path, deleted, updatedTs := "Vault note.md", true, int64(1700000100)
+	err = ts.UpdateVaultFile(ctx, &store.UpdateVaultFile{
+		ID:        vaultFile.ID,
+		Path:      &path,
+		Deleted:   &deleted,
+		UpdatedTs: &updatedTs,
+	})
+	require.NoError(t, err)
+	vaultFile, err = ts.GetVaultFile(ctx, &store.FindVaultFile{CreatorID: &user.ID, Path: &path})
+	require.NoError(t, err)
+	require.NotNil(t, vaultFile)
+	require.Equal(t, memo.ID, vaultFile.MemoID)
+	require.True(t, vaultFile.Deleted)
+	require.Equal(t, updatedTs, vaultFile.UpdatedTs)
+
+	err = ts.DeleteVaultFile(ctx, &store.DeleteVaultFile{ID: vaultFile.ID})
+	require.NoError(t, err)
+	vaultFiles, err = ts.ListVaultFiles(ctx, &store.FindVaultFile{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, vaultFiles, 1)
+	ts.Close()
+}
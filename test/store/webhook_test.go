@@ -49,3 +49,68 @@ func TestWebhookStore(t *testing.T) {
 	require.Equal(t, 0, len(webhooks))
 	ts.Close()
 }
+
+func TestWebhookDeliveryStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	webhook, err := ts.CreateWebhook(ctx, &storepb.Webhook{
+		CreatorId: user.ID,
+		Name:      "test_webhook",
+		Url:       "https://example.com",
+		Secret:    "test_secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "test_secret", webhook.Secret)
+	delivery, err := ts.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID:     webhook.Id,
+		ActivityType:  "memos.memo.created",
+		Payload:       "{}",
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: 100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.WebhookDeliveryPending, delivery.Status)
+
+	pendingStatus, before := store.WebhookDeliveryPending, int64(100)
+	deliveries, err := ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &before,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deliveries))
+	before = 99
+	deliveries, err = ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &before,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(deliveries))
+
+	failedStatus, attempts, statusCode, lastError := store.WebhookDeliveryFailed, int32(1), int32(500), "failed"
+	err = ts.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{
+		ID:             delivery.ID,
+		Status:         &failedStatus,
+		Attempts:       &attempts,
+		LastStatusCode: &statusCode,
+		LastError:      &lastError,
+	})
+	require.NoError(t, err)
+	deliveries, err = ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		WebhookID: &webhook.Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deliveries))
+	require.Equal(t, failedStatus, deliveries[0].Status)
+	require.Equal(t, attempts, deliveries[0].Attempts)
+	require.Equal(t, statusCode, deliveries[0].LastStatusCode)
+	require.Equal(t, lastError, deliveries[0].LastError)
+	createdTsAfter := delivery.CreatedTs + 1
+	deliveries, err = ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		CreatedTsAfter: &createdTsAfter,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(deliveries))
+	ts.Close()
+}
@@ -28,3 +28,84 @@ func TestWorkspaceSettingV1Store(t *testing.T) {
 	require.Equal(t, workspaceSetting, list[0])
 	ts.Close()
 }
+
+func TestWorkspaceStorageSettingStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	_, err := ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_STORAGE,
+		Value: &storepb.WorkspaceSetting_Storage{
+			Storage: &storepb.WorkspaceStorageSetting{
+				ImageCompressionThresholdMib: 2,
+				ImageQuality:                 80,
+				ImageMaxDimension:            2048,
+				UploadRestrictions: []*storepb.UploadRestriction{
+					{Role: "USER", AllowedMimeTypes: []string{"image/*"}, MaxUploadSizeMib: 8},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	workspaceStorageSetting, err := ts.GetWorkspaceStorageSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), workspaceStorageSetting.ImageCompressionThresholdMib)
+	require.Equal(t, int32(80), workspaceStorageSetting.ImageQuality)
+	require.Equal(t, int32(2048), workspaceStorageSetting.ImageMaxDimension)
+	require.Len(t, workspaceStorageSetting.UploadRestrictions, 1)
+	require.Equal(t, []string{"image/*"}, workspaceStorageSetting.UploadRestrictions[0].AllowedMimeTypes)
+	ts.Close()
+}
+
+func TestWorkspaceAnnouncementSettingStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	workspaceAnnouncementSetting, err := ts.GetWorkspaceAnnouncementSetting(ctx)
+	require.NoError(t, err)
+	require.Empty(t, workspaceAnnouncementSetting.Content)
+	_, err = ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_ANNOUNCEMENT,
+		Value: &storepb.WorkspaceSetting_Announcement{
+			Announcement: &storepb.WorkspaceAnnouncementSetting{
+				Content:     "Maintenance on **Sunday**",
+				Severity:    storepb.WorkspaceAnnouncementSetting_WARNING,
+				Dismissible: true,
+				ExpireTs:    1900000000,
+			},
+		},
+	})
+	require.NoError(t, err)
+	workspaceAnnouncementSetting, err = ts.GetWorkspaceAnnouncementSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Maintenance on **Sunday**", workspaceAnnouncementSetting.Content)
+	require.Equal(t, storepb.WorkspaceAnnouncementSetting_WARNING, workspaceAnnouncementSetting.Severity)
+	require.True(t, workspaceAnnouncementSetting.Dismissible)
+	require.Equal(t, int64(1900000000), workspaceAnnouncementSetting.ExpireTs)
+	ts.Close()
+}
+
+func TestWorkspaceBrandingSettingStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	workspaceBrandingSetting, err := ts.GetWorkspaceBrandingSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "", workspaceBrandingSetting.Title)
+	_, err = ts.UpsertWorkspaceSettingV1(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_WORKSPACE_SETTING_BRANDING,
+		Value: &storepb.WorkspaceSetting_Branding{
+			Branding: &storepb.WorkspaceBrandingSetting{
+				Title:          "Team notes",
+				LogoResourceId: 1,
+				AccentColor:    "#0f766e",
+				CustomCss:      "body { font-family: serif; }",
+			},
+		},
+	})
+	require.NoError(t, err)
+	workspaceBrandingSetting, err = ts.GetWorkspaceBrandingSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Team notes", workspaceBrandingSetting.Title)
+	require.Equal(t, int32(1), workspaceBrandingSetting.LogoResourceId)
+	require.Equal(t, "#0f766e", workspaceBrandingSetting.AccentColor)
+	require.Equal(t, "body { font-family: serif; }", workspaceBrandingSetting.CustomCss)
+	ts.Close()
+}
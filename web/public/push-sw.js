@@ -0,0 +1,23 @@
+// The service worker showing the Web Push notifications of memos.
+self.addEventListener("push", (event) => {
+  const message = event.data ? event.data.json() : {};
+  event.waitUntil(
+    self.registration.showNotification(message.title || "Memos", {
+      body: message.body,
+      tag: message.tag,
+      icon: "/android-chrome-192x192.png",
+      data: { url: message.url || "/" },
+    }),
+  );
+});
+
+self.addEventListener("notificationclick", (event) => {
+  event.notification.close();
+  const url = new URL(event.notification.data.url, self.location.origin).href;
+  event.waitUntil(
+    self.clients.matchAll({ type: "window", includeUncontrolled: true }).then((clients) => {
+      const client = clients.find((client) => client.url === url);
+      return client ? client.focus() : self.clients.openWindow(url);
+    }),
+  );
+});
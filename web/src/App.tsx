@@ -66,12 +66,27 @@ const App = () => {
     }
   }, [workspaceGeneralSetting.additionalScript]);
 
-  // Dynamic update metadata with customized profile.
   useEffect(() => {
-    document.title = systemStatus.customizedProfile.name;
+    if (workspaceProfile.customCss) {
+      const styleEl = document.createElement("style");
+      styleEl.innerHTML = workspaceProfile.customCss;
+      styleEl.setAttribute("type", "text/css");
+      document.body.insertAdjacentElement("beforeend", styleEl);
+    }
+  }, [workspaceProfile.customCss]);
+
+  useEffect(() => {
+    if (workspaceProfile.accentColor) {
+      document.documentElement.style.setProperty("--accent-color", workspaceProfile.accentColor);
+    }
+  }, [workspaceProfile.accentColor]);
+
+  // Dynamic update metadata with customized profile, the branding of the workspace goes first.
+  useEffect(() => {
+    document.title = workspaceProfile.title || systemStatus.customizedProfile.name;
     const link = document.querySelector("link[rel~='icon']") as HTMLLinkElement;
-    link.href = systemStatus.customizedProfile.logoUrl || "/logo.webp";
-  }, [systemStatus.customizedProfile]);
+    link.href = workspaceProfile.logoUrl || systemStatus.customizedProfile.logoUrl || "/logo.webp";
+  }, [systemStatus.customizedProfile, workspaceProfile.title, workspaceProfile.logoUrl]);
 
   useEffect(() => {
     if (!userSetting) {
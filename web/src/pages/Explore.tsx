@@ -33,7 +33,7 @@ const Explore = () => {
   }, [tagQuery, textQuery]);
 
   const fetchMemos = async () => {
-    const filters = [`row_status == "NORMAL"`, `visibilities == [${user ? "'PUBLIC', 'PROTECTED'" : "'PUBLIC'"}]`];
+    const filters = [`row_status == "NORMAL"`, `visibilities == [${user ? "'PUBLIC', 'PROTECTED'" : "'PUBLIC'"}]`, `explore == true`];
     const contentSearch: string[] = [];
     if (tagQuery) {
       contentSearch.push(JSON.stringify(`#${tagQuery}`));